@@ -0,0 +1,135 @@
+package addon
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+const (
+	// GrafanaDashboardLabel the label of the ConfigMaps which the Grafana sidecar loads dashboards from
+	GrafanaDashboardLabel = "grafana_dashboard"
+
+	// GrafanaDashboardPipelineActivity the dashboard of the pipeline builds of the team
+	GrafanaDashboardPipelineActivity = "jx-pipeline-activity"
+	// GrafanaDashboardBuildPods the dashboard of the resource usage of the build pods
+	GrafanaDashboardBuildPods = "jx-build-pods"
+	// GrafanaDashboardEnvironments the dashboard of the deployments in the environments
+	GrafanaDashboardEnvironments = "jx-environments"
+)
+
+// GrafanaPanel a graph of a dashboard along with the prometheus query it displays
+type GrafanaPanel struct {
+	Title        string
+	Expr         string
+	LegendFormat string
+}
+
+// GrafanaDashboards returns the Jenkins X dashboards indexed by the name of their ConfigMap for the
+// given dev namespace. The environments of the team are in the namespaces prefixed with the dev namespace
+func GrafanaDashboards(devNs string) (map[string]string, error) {
+	buildPods := fmt.Sprintf(`namespace="%s", pod=~".*-[0-9]+-.*"`, devNs)
+	environments := fmt.Sprintf(`namespace=~"%s-.+"`, regexp.QuoteMeta(devNs))
+	dashboards := map[string][]GrafanaPanel{
+		GrafanaDashboardPipelineActivity: {
+			{
+				Title:        "Running builds",
+				Expr:         fmt.Sprintf(`sum(kube_pod_status_phase{%s, phase="Running"})`, buildPods),
+				LegendFormat: "running",
+			},
+			{
+				Title:        "Build results",
+				Expr:         fmt.Sprintf(`sum by (phase) (kube_pod_status_phase{%s, phase=~"Succeeded|Failed"})`, buildPods),
+				LegendFormat: "{{phase}}",
+			},
+			{
+				Title:        "Builds started per hour",
+				Expr:         fmt.Sprintf(`sum(changes(kube_pod_created{%s}[1h]))`, buildPods),
+				LegendFormat: "builds",
+			},
+		},
+		GrafanaDashboardBuildPods: {
+			{
+				Title:        "Build pod CPU usage",
+				Expr:         fmt.Sprintf(`sum by (pod) (rate(container_cpu_usage_seconds_total{%s, container!=""}[5m]))`, buildPods),
+				LegendFormat: "{{pod}}",
+			},
+			{
+				Title:        "Build pod memory usage",
+				Expr:         fmt.Sprintf(`sum by (pod) (container_memory_working_set_bytes{%s, container!=""})`, buildPods),
+				LegendFormat: "{{pod}}",
+			},
+		},
+		GrafanaDashboardEnvironments: {
+			{
+				Title:        "Available replicas",
+				Expr:         fmt.Sprintf(`sum by (namespace, deployment) (kube_deployment_status_replicas_available{%s})`, environments),
+				LegendFormat: "{{namespace}}/{{deployment}}",
+			},
+			{
+				Title:        "Deployments",
+				Expr:         fmt.Sprintf(`sum by (namespace) (changes(kube_deployment_status_observed_generation{%s}[1h]))`, environments),
+				LegendFormat: "{{namespace}}",
+			},
+		},
+	}
+	titles := map[string]string{
+		GrafanaDashboardPipelineActivity: "Jenkins X / Pipeline Activity",
+		GrafanaDashboardBuildPods:        "Jenkins X / Build Pods",
+		GrafanaDashboardEnvironments:     "Jenkins X / Environment Deployments",
+	}
+
+	answer := map[string]string{}
+	for name, panels := range dashboards {
+		data, err := grafanaDashboardJSON(name, titles[name], panels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal the Grafana dashboard %s: %s", name, err)
+		}
+		answer[name] = data
+	}
+	return answer, nil
+}
+
+// grafanaDashboardJSON renders the dashboard model which Grafana imports from the JSON files of the ConfigMaps
+func grafanaDashboardJSON(uid string, title string, panels []GrafanaPanel) (string, error) {
+	panelModels := []map[string]interface{}{}
+	for i, panel := range panels {
+		panelModels = append(panelModels, map[string]interface{}{
+			"id":         i + 1,
+			"type":       "graph",
+			"title":      panel.Title,
+			"datasource": "Prometheus",
+			"gridPos": map[string]int{
+				"x": (i % 2) * 12,
+				"y": (i / 2) * 8,
+				"w": 12,
+				"h": 8,
+			},
+			"targets": []map[string]string{
+				{
+					"expr":         panel.Expr,
+					"legendFormat": panel.LegendFormat,
+					"refId":        "A",
+				},
+			},
+		})
+	}
+	model := map[string]interface{}{
+		"uid":           uid,
+		"title":         title,
+		"tags":          []string{"jenkins-x"},
+		"editable":      true,
+		"schemaVersion": 16,
+		"refresh":       "30s",
+		"time": map[string]string{
+			"from": "now-6h",
+			"to":   "now",
+		},
+		"panels": panelModels,
+	}
+	data, err := json.MarshalIndent(model, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,33 @@
+package addon_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/addon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrafanaDashboards(t *testing.T) {
+	t.Parallel()
+
+	dashboards, err := addon.GrafanaDashboards("jx")
+	require.NoError(t, err)
+
+	for _, name := range []string{addon.GrafanaDashboardPipelineActivity, addon.GrafanaDashboardBuildPods, addon.GrafanaDashboardEnvironments} {
+		data := dashboards[name]
+		require.NotEmpty(t, data, "missing dashboard %s", name)
+
+		model := map[string]interface{}{}
+		err = json.Unmarshal([]byte(data), &model)
+		require.NoError(t, err, "invalid JSON for dashboard %s", name)
+		assert.Equal(t, name, model["uid"])
+		assert.True(t, strings.HasPrefix(model["title"].(string), "Jenkins X / "), "title of dashboard %s", name)
+		assert.NotEmpty(t, model["panels"], "panels of dashboard %s", name)
+	}
+	assert.Contains(t, dashboards[addon.GrafanaDashboardBuildPods], `namespace=\"jx\"`)
+	assert.Contains(t, dashboards[addon.GrafanaDashboardBuildPods], `container!=\"\"`)
+	assert.Contains(t, dashboards[addon.GrafanaDashboardEnvironments], `namespace=~\"jx-.+\"`)
+}
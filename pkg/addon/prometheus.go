@@ -0,0 +1,130 @@
+package addon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// PrometheusCondition a prometheus query whose value must satisfy a comparison such as an error rate below a threshold
+type PrometheusCondition struct {
+	Query     string
+	Operator  string
+	Threshold float64
+}
+
+var prometheusConditionRegex = regexp.MustCompile(`^(.+?)\s*(<=|>=|==|!=|<|>)\s*([-+]?[0-9]*\.?[0-9]+([eE][-+]?[0-9]+)?)\s*$`)
+
+// ParsePrometheusCondition parses a condition of the form 'query operator threshold'
+// such as 'sum(rate(http_errors_total[5m])) < 0.05'
+func ParsePrometheusCondition(text string) (*PrometheusCondition, error) {
+	matches := prometheusConditionRegex.FindStringSubmatch(strings.TrimSpace(text))
+	if matches == nil {
+		return nil, fmt.Errorf("the condition %s should be of the form 'query operator threshold' with one of the operators <, <=, >, >=, ==, !=", text)
+	}
+	threshold, err := strconv.ParseFloat(matches[3], 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid threshold in condition %s", text)
+	}
+	return &PrometheusCondition{
+		Query:     matches[1],
+		Operator:  matches[2],
+		Threshold: threshold,
+	}, nil
+}
+
+// Satisfied returns true if the given value of the query satisfies the condition
+func (c *PrometheusCondition) Satisfied(value float64) bool {
+	switch c.Operator {
+	case "<":
+		return value < c.Threshold
+	case "<=":
+		return value <= c.Threshold
+	case ">":
+		return value > c.Threshold
+	case ">=":
+		return value >= c.Threshold
+	case "==":
+		return value == c.Threshold
+	case "!=":
+		return value != c.Threshold
+	default:
+		return false
+	}
+}
+
+// String returns the text of the condition
+func (c *PrometheusCondition) String() string {
+	return fmt.Sprintf("%s %s %s", c.Query, c.Operator, strconv.FormatFloat(c.Threshold, 'f', -1, 64))
+}
+
+type prometheusQueryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+type prometheusSample struct {
+	Value []interface{} `json:"value"`
+}
+
+// QueryPrometheus evaluates the given instant query via the HTTP API of the Prometheus server at the given URL.
+// The query must return a scalar or a vector with a single sample
+func QueryPrometheus(client *http.Client, prometheusURL string, query string) (float64, error) {
+	u := strings.TrimSuffix(prometheusURL, "/") + "/api/v1/query?query=" + url.QueryEscape(query)
+	resp, err := client.Get(u)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to query Prometheus at %s", prometheusURL)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read the response of Prometheus")
+	}
+	response := &prometheusQueryResponse{}
+	err = json.Unmarshal(body, response)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to parse the response of Prometheus with status %d", resp.StatusCode)
+	}
+	if response.Status != "success" {
+		return 0, fmt.Errorf("query %s failed: %s", query, response.Error)
+	}
+	var value []interface{}
+	switch response.Data.ResultType {
+	case "scalar":
+		err = json.Unmarshal(response.Data.Result, &value)
+	case "vector":
+		samples := []prometheusSample{}
+		err = json.Unmarshal(response.Data.Result, &samples)
+		if err == nil {
+			if len(samples) != 1 {
+				return 0, fmt.Errorf("query %s returned %d samples rather than a single one", query, len(samples))
+			}
+			value = samples[0].Value
+		}
+	default:
+		return 0, fmt.Errorf("query %s returned an unsupported result type %s", query, response.Data.ResultType)
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to parse the result of query %s", query)
+	}
+	// a sample is a pair of the timestamp and the value as a string
+	if len(value) != 2 {
+		return 0, fmt.Errorf("query %s returned an invalid sample", query)
+	}
+	text, ok := value[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("query %s returned an invalid sample value", query)
+	}
+	return strconv.ParseFloat(text, 64)
+}
@@ -0,0 +1,63 @@
+package addon_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/addon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePrometheusCondition(t *testing.T) {
+	t.Parallel()
+	c, err := addon.ParsePrometheusCondition(`sum(rate(http_requests_total{code=~"5.."}[5m])) / sum(rate(http_requests_total[5m])) <= 0.05`)
+	require.NoError(t, err)
+	assert.Equal(t, `sum(rate(http_requests_total{code=~"5.."}[5m])) / sum(rate(http_requests_total[5m]))`, c.Query)
+	assert.Equal(t, "<=", c.Operator)
+	assert.Equal(t, 0.05, c.Threshold)
+	assert.True(t, c.Satisfied(0.01))
+	assert.True(t, c.Satisfied(0.05))
+	assert.False(t, c.Satisfied(0.1))
+
+	c, err = addon.ParsePrometheusCondition("up > 0")
+	require.NoError(t, err)
+	assert.Equal(t, "up > 0", c.String())
+	assert.True(t, c.Satisfied(1))
+
+	_, err = addon.ParsePrometheusCondition("up")
+	assert.Error(t, err)
+}
+
+func TestQueryPrometheus(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("query") {
+		case "vector":
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1556000000.1,"0.02"]}]}}`)
+		case "scalar":
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"scalar","result":[1556000000.1,"3"]}}`)
+		case "empty":
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"status":"error","error":"parse error"}`)
+		}
+	}))
+	defer server.Close()
+
+	value, err := addon.QueryPrometheus(server.Client(), server.URL, "vector")
+	require.NoError(t, err)
+	assert.Equal(t, 0.02, value)
+
+	value, err = addon.QueryPrometheus(server.Client(), server.URL+"/", "scalar")
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, value)
+
+	_, err = addon.QueryPrometheus(server.Client(), server.URL, "empty")
+	assert.Error(t, err)
+	_, err = addon.QueryPrometheus(server.Client(), server.URL, "invalid(")
+	assert.Error(t, err)
+}
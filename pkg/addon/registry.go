@@ -0,0 +1,170 @@
+package addon
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+// Addon describes an addon which is installed via a helm chart
+type Addon struct {
+	Name        string `yaml:"name"`
+	Chart       string `yaml:"chart"`
+	Description string `yaml:"description,omitempty"`
+	// Service the name of the service of the addon which is exposed once installed
+	Service string `yaml:"service,omitempty"`
+	// Values the chart values the addon supports which are validated on install
+	Values []AddonValue `yaml:"values,omitempty"`
+	// PostInstall the shell commands run after the chart has been installed
+	PostInstall []string `yaml:"postInstall,omitempty"`
+}
+
+// AddonValue describes a chart value of an addon
+type AddonValue struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Required    bool   `yaml:"required,omitempty"`
+	Default     string `yaml:"default,omitempty"`
+}
+
+// Registry the addons which can be installed indexed by name
+type Registry struct {
+	Addons map[string]*Addon
+}
+
+// registryFile the file format of custom addons
+type registryFile struct {
+	Addons []*Addon `yaml:"addons"`
+}
+
+// NewRegistry creates a registry of the addons for the given charts and services indexed by addon name
+func NewRegistry(charts map[string]string, services map[string]string) *Registry {
+	r := &Registry{
+		Addons: map[string]*Addon{},
+	}
+	for name, chart := range charts {
+		r.Addons[name] = &Addon{
+			Name:    name,
+			Chart:   chart,
+			Service: services[name],
+		}
+	}
+	return r
+}
+
+// LoadRegistry creates a registry of the given built in addons along with any custom addons declared in
+// the `~/.jx/addon-registry.yml` file
+func LoadRegistry(charts map[string]string, services map[string]string) (*Registry, error) {
+	r := NewRegistry(charts, services)
+	fileName, err := registryFileName()
+	if err != nil {
+		return r, err
+	}
+	err = r.LoadFile(fileName)
+	return r, err
+}
+
+// LoadFile adds the addons declared in the given file if it exists
+func (r *Registry) LoadFile(fileName string) error {
+	exists, err := util.FileExists(fileName)
+	if err != nil || !exists {
+		return err
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return fmt.Errorf("Failed to load file %s due to %s", fileName, err)
+	}
+	file := &registryFile{}
+	err = yaml.Unmarshal(data, file)
+	if err != nil {
+		return fmt.Errorf("Failed to unmarshal YAML file %s due to %s", fileName, err)
+	}
+	for _, a := range file.Addons {
+		if a.Name == "" || a.Chart == "" {
+			return fmt.Errorf("addons in file %s must have a name and a chart", fileName)
+		}
+		r.Addons[a.Name] = a
+	}
+	return nil
+}
+
+// Get returns the addon of the given name or nil if there is no such addon
+func (r *Registry) Get(name string) *Addon {
+	return r.Addons[name]
+}
+
+// Names returns the sorted names of the addons
+func (r *Registry) Names() []string {
+	answer := []string{}
+	for name := range r.Addons {
+		answer = append(answer, name)
+	}
+	sort.Strings(answer)
+	return answer
+}
+
+// ResolveValues validates the set values of the form name=value against the values of the addon
+// returning them with the defaults of any values which were not set
+func (a *Addon) ResolveValues(setValues []string) ([]string, error) {
+	answer := []string{}
+	names := map[string]bool{}
+	for _, v := range setValues {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid value %s for addon %s, expected name=value", v, a.Name)
+		}
+		names[parts[0]] = true
+		answer = append(answer, v)
+	}
+	missing := []string{}
+	for _, value := range a.Values {
+		if names[value.Name] {
+			continue
+		}
+		if value.Default != "" {
+			answer = append(answer, value.Name+"="+value.Default)
+		} else if value.Required {
+			missing = append(missing, value.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing the required values of addon %s: %s", a.Name, strings.Join(missing, ", "))
+	}
+	return answer, nil
+}
+
+// RunPostInstall runs the post install commands of the addon in the given namespace
+func (a *Addon) RunPostInstall(ns string) error {
+	for _, command := range a.PostInstall {
+		cmd := util.Command{
+			Name: "sh",
+			Args: []string{"-c", command},
+			Env: map[string]string{
+				"ADDON_NAME":      a.Name,
+				"ADDON_NAMESPACE": ns,
+			},
+		}
+		_, err := cmd.RunWithoutRetry()
+		if err != nil {
+			return fmt.Errorf("post install command '%s' of addon %s failed: %s", command, a.Name, err)
+		}
+	}
+	return nil
+}
+
+func registryFileName() (string, error) {
+	dir, err := util.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "addon-registry.yml"), nil
+}
@@ -0,0 +1,60 @@
+package addon_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/addon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryLoadFile(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "test-addon-registry")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "addon-registry.yml")
+	data := `addons:
+- name: mydb
+  chart: myrepo/mydb
+  description: a database
+  values:
+  - name: storage.size
+    default: 10Gi
+  - name: password
+    required: true
+`
+	err = ioutil.WriteFile(fileName, []byte(data), 0644)
+	require.NoError(t, err)
+
+	registry := addon.NewRegistry(map[string]string{"gitea": "jenkins-x/gitea"}, map[string]string{"gitea": "gitea-gitea"})
+	err = registry.LoadFile(fileName)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"gitea", "mydb"}, registry.Names())
+	assert.Equal(t, "gitea-gitea", registry.Get("gitea").Service)
+	assert.Nil(t, registry.Get("cheese"))
+
+	mydb := registry.Get("mydb")
+	require.NotNil(t, mydb)
+	assert.Equal(t, "myrepo/mydb", mydb.Chart)
+
+	_, err = mydb.ResolveValues([]string{""})
+	assert.Error(t, err, "should fail without the required password")
+
+	values, err := mydb.ResolveValues([]string{"password=secret"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"password=secret", "storage.size=10Gi"}, values)
+
+	values, err = mydb.ResolveValues([]string{"password=secret", "storage.size=1Gi"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"password=secret", "storage.size=1Gi"}, values)
+
+	_, err = mydb.ResolveValues([]string{"password"})
+	assert.Error(t, err)
+}
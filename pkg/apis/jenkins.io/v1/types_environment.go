@@ -89,6 +89,48 @@ const (
 	PromotionEngineProw    PromotionEngineType = "Prow"
 )
 
+// ImageBuilderType is the kind of tool the pipelines of a team use to build container images
+type ImageBuilderType string
+
+const (
+	// ImageBuilderDocker builds the Dockerfile of a project using skaffold and the Docker daemon
+	ImageBuilderDocker ImageBuilderType = "docker"
+	// ImageBuilderKaniko builds the Dockerfile of a project using kaniko which does not need a Docker daemon
+	ImageBuilderKaniko ImageBuilderType = "kaniko"
+	// ImageBuilderBuildpacks builds projects without a Dockerfile using a Cloud Native Buildpacks builder
+	ImageBuilderBuildpacks ImageBuilderType = "buildpacks"
+	// ImageBuilderBuildKit builds the Dockerfile of a project using rootless BuildKit which does not need a Docker daemon
+	ImageBuilderBuildKit ImageBuilderType = "buildkit"
+)
+
+// ImageBuilderTypes the supported kinds of image builder
+var ImageBuilderTypes = []string{string(ImageBuilderDocker), string(ImageBuilderKaniko), string(ImageBuilderBuildpacks), string(ImageBuilderBuildKit)}
+
+// ArtifactRepositoryType is the kind of repository the pipelines of a team resolve and deploy artifacts with
+type ArtifactRepositoryType string
+
+const (
+	// ArtifactRepositoryNexus uses the Nexus repository installed with Jenkins X
+	ArtifactRepositoryNexus ArtifactRepositoryType = "nexus"
+	// ArtifactRepositoryArtifactory uses an Artifactory repository
+	ArtifactRepositoryArtifactory ArtifactRepositoryType = "artifactory"
+	// ArtifactRepositoryNone resolves artifacts from the public repositories and does not deploy artifacts
+	ArtifactRepositoryNone ArtifactRepositoryType = "none"
+)
+
+// ArtifactRepositoryTypes the supported kinds of artifact repository
+var ArtifactRepositoryTypes = []string{string(ArtifactRepositoryNexus), string(ArtifactRepositoryArtifactory), string(ArtifactRepositoryNone)}
+
+// PipelineEngineType is the kind of engine which runs the serverless pipelines of a team using Prow
+type PipelineEngineType string
+
+const (
+	// PipelineEngineKnativeBuild runs each pipeline as a Knative Build
+	PipelineEngineKnativeBuild PipelineEngineType = "knative-build"
+	// PipelineEngineTekton runs each pipeline as a Tekton PipelineRun of Tasks sharing a workspace volume
+	PipelineEngineTekton PipelineEngineType = "tekton"
+)
+
 // WebHookEngineType is the type of webhook processing implementation the team uses
 type WebHookEngineType string
 
@@ -132,27 +174,51 @@ type EnvironmentRepository struct {
 
 // TeamSettings the default settings for a team
 type TeamSettings struct {
-	UseGitOps           bool                 `json:"useGitOps,omitempty" protobuf:"bytes,1,opt,name=useGitOps"`
-	AskOnCreate         bool                 `json:"askOnCreate,omitempty" protobuf:"bytes,2,opt,name=askOnCreate"`
-	BranchPatterns      string               `json:"branchPatterns,omitempty" protobuf:"bytes,3,opt,name=branchPatterns"`
-	ForkBranchPatterns  string               `json:"forkBranchPatterns,omitempty" protobuf:"bytes,4,opt,name=forkBranchPatterns"`
-	QuickstartLocations []QuickStartLocation `json:"quickstartLocations,omitempty" protobuf:"bytes,5,opt,name=quickstartLocations"`
-	BuildPackURL        string               `json:"buildPackUrl,omitempty" protobuf:"bytes,6,opt,name=buildPackUrl"`
-	BuildPackRef        string               `json:"buildPackRef,omitempty" protobuf:"bytes,7,opt,name=buildPackRef"`
-	HelmBinary          string               `json:"helmBinary,omitempty" protobuf:"bytes,8,opt,name=helmBinary"`
-	PostPreviewJobs     []batchv1.Job        `json:"postPreviewJobs,omitempty" protobuf:"bytes,9,opt,name=postPreviewJobs"`
-	PromotionEngine     PromotionEngineType  `json:"promotionEngine,omitempty" protobuf:"bytes,10,opt,name=promotionEngine"`
-	NoTiller            bool                 `json:"noTiller,omitempty" protobuf:"bytes,11,opt,name=noTiller"`
-	HelmTemplate        bool                 `json:"helmTemplate,omitempty" protobuf:"bytes,12,opt,name=helmTemplate"`
-	GitServer           string               `json:"gitServer,omitempty" protobuf:"bytes,13,opt,name=gitServer" command:"gitserver" commandUsage:"Default git server for new repositories"`
-	Organisation        string               `json:"organisation,omitempty" protobuf:"bytes,14,opt,name=organisation" command:"organisation" commandUsage:"Default git organisation for new repositories"`
-	PipelineUsername    string               `json:"pipelineUsername,omitempty" protobuf:"bytes,15,opt,name=pipelineUsername" command:"pipelineusername" commandUsage:"User used by pipeline. Is given write permission on new repositories."`
-	DockerRegistryOrg   string               `json:"dockerRegistryOrg,omitempty" protobuf:"bytes,16,opt,name=dockerRegistryOrg" command:"dockerregistryorg" commandUsage:"Docker registry organisation used for new projects in Jenkins X."`
-	GitPrivate          bool                 `json:"gitPrivate,omitempty" protobuf:"bytes,17,opt,name=gitPrivate" command:"gitprivate" commandUsage:"Are new repositories private by default"`
-	KubeProvider        string               `json:"kubeProvider,omitempty" protobuf:"bytes,18,opt,name=kubeProvider"`
-	AppsRepository      string               `json:"appsRepository,omitempty" protobuf:"bytes,19,opt,name=appsRepository"`
-	BuildPackName       string               `json:"buildPackName,omitempty" protobuf:"bytes,20,opt,name=buildPackName"`
-	StorageLocations    []StorageLocation    `json:"storageLocations,omitempty" protobuf:"bytes,21,opt,name=storageLocations"`
+	UseGitOps             bool                   `json:"useGitOps,omitempty" protobuf:"bytes,1,opt,name=useGitOps"`
+	AskOnCreate           bool                   `json:"askOnCreate,omitempty" protobuf:"bytes,2,opt,name=askOnCreate"`
+	BranchPatterns        string                 `json:"branchPatterns,omitempty" protobuf:"bytes,3,opt,name=branchPatterns"`
+	ForkBranchPatterns    string                 `json:"forkBranchPatterns,omitempty" protobuf:"bytes,4,opt,name=forkBranchPatterns"`
+	QuickstartLocations   []QuickStartLocation   `json:"quickstartLocations,omitempty" protobuf:"bytes,5,opt,name=quickstartLocations"`
+	BuildPackURL          string                 `json:"buildPackUrl,omitempty" protobuf:"bytes,6,opt,name=buildPackUrl" command:"buildpackurl" commandUsage:"Git URL of the build packs used for new projects"`
+	BuildPackRef          string                 `json:"buildPackRef,omitempty" protobuf:"bytes,7,opt,name=buildPackRef" command:"buildpackref" commandUsage:"Git branch or tag of the build packs used for new projects"`
+	HelmBinary            string                 `json:"helmBinary,omitempty" protobuf:"bytes,8,opt,name=helmBinary"`
+	PostPreviewJobs       []batchv1.Job          `json:"postPreviewJobs,omitempty" protobuf:"bytes,9,opt,name=postPreviewJobs"`
+	PromotionEngine       PromotionEngineType    `json:"promotionEngine,omitempty" protobuf:"bytes,10,opt,name=promotionEngine" command:"promotionengine" commandUsage:"Engine which promotes applications to environments" commandValues:"Jenkins,Prow"`
+	NoTiller              bool                   `json:"noTiller,omitempty" protobuf:"bytes,11,opt,name=noTiller"`
+	HelmTemplate          bool                   `json:"helmTemplate,omitempty" protobuf:"bytes,12,opt,name=helmTemplate"`
+	GitServer             string                 `json:"gitServer,omitempty" protobuf:"bytes,13,opt,name=gitServer" command:"gitserver" commandUsage:"Default git server for new repositories"`
+	Organisation          string                 `json:"organisation,omitempty" protobuf:"bytes,14,opt,name=organisation" command:"organisation" commandUsage:"Default git organisation for new repositories"`
+	PipelineUsername      string                 `json:"pipelineUsername,omitempty" protobuf:"bytes,15,opt,name=pipelineUsername" command:"pipelineusername" commandUsage:"User used by pipeline. Is given write permission on new repositories."`
+	DockerRegistryOrg     string                 `json:"dockerRegistryOrg,omitempty" protobuf:"bytes,16,opt,name=dockerRegistryOrg" command:"dockerregistryorg" commandUsage:"Docker registry organisation used for new projects in Jenkins X."`
+	GitPrivate            bool                   `json:"gitPrivate,omitempty" protobuf:"bytes,17,opt,name=gitPrivate" command:"gitprivate" commandUsage:"Are new repositories private by default"`
+	KubeProvider          string                 `json:"kubeProvider,omitempty" protobuf:"bytes,18,opt,name=kubeProvider"`
+	AppsRepository        string                 `json:"appsRepository,omitempty" protobuf:"bytes,19,opt,name=appsRepository" command:"appsrepository" commandUsage:"Helm chart repository of the apps installed by the team"`
+	BuildPackName         string                 `json:"buildPackName,omitempty" protobuf:"bytes,20,opt,name=buildPackName"`
+	StorageLocations      []StorageLocation      `json:"storageLocations,omitempty" protobuf:"bytes,21,opt,name=storageLocations"`
+	ImageBuilder          ImageBuilderType       `json:"imageBuilder,omitempty" protobuf:"bytes,22,opt,name=imageBuilder" command:"imagebuilder" commandUsage:"Tool which builds the container images of the pipelines" commandValues:"docker,kaniko,buildpacks,buildkit"`
+	BuildpacksBuilder     string                 `json:"buildpacksBuilder,omitempty" protobuf:"bytes,23,opt,name=buildpacksBuilder"`
+	PipelineEngine        PipelineEngineType     `json:"pipelineEngine,omitempty" protobuf:"bytes,24,opt,name=pipelineEngine" command:"pipelineengine" commandUsage:"Engine which runs the serverless pipelines" commandValues:"knative-build,tekton"`
+	Addons                []AddonSettings        `json:"addons,omitempty" protobuf:"bytes,25,opt,name=addons"`
+	ImageCache            bool                   `json:"imageCache,omitempty" protobuf:"bytes,26,opt,name=imageCache"`
+	ImageCacheRepo        string                 `json:"imageCacheRepo,omitempty" protobuf:"bytes,27,opt,name=imageCacheRepo"`
+	SecurityPolicy        SecurityPolicy         `json:"securityPolicy,omitempty" protobuf:"bytes,28,opt,name=securityPolicy"`
+	ArtifactRepository    ArtifactRepositoryType `json:"artifactRepository,omitempty" protobuf:"bytes,29,opt,name=artifactRepository"`
+	ArtifactRepositoryURL string                 `json:"artifactRepositoryUrl,omitempty" protobuf:"bytes,30,opt,name=artifactRepositoryUrl"`
+	BuildsOnSpot          bool                   `json:"buildsOnSpot,omitempty" protobuf:"bytes,31,opt,name=buildsOnSpot"`
+	JenkinsLibraries      []JenkinsLibrary       `json:"jenkinsLibraries,omitempty" protobuf:"bytes,32,opt,name=jenkinsLibraries"`
+	NetworkPolicy         NetworkPolicySettings  `json:"networkPolicy,omitempty" protobuf:"bytes,33,opt,name=networkPolicy"`
+	Notifications         []NotificationChannel  `json:"notifications,omitempty" protobuf:"bytes,34,opt,name=notifications"`
+	VaultURL              string                 `json:"vaultUrl,omitempty" protobuf:"bytes,35,opt,name=vaultUrl"`
+	PromotionStrategy     PromotionStrategyType  `json:"promotionStrategy,omitempty" protobuf:"bytes,36,opt,name=promotionStrategy" command:"promotionstrategy" commandUsage:"Default promotion strategy of new environments" commandValues:"Auto,Manual,Never"`
+}
+
+// AddonSettings records an addon installed by the team so that it can be reinstalled or upgraded with the same settings
+type AddonSettings struct {
+	Name      string   `json:"name" protobuf:"bytes,1,opt,name=name"`
+	Chart     string   `json:"chart,omitempty" protobuf:"bytes,2,opt,name=chart"`
+	Version   string   `json:"version,omitempty" protobuf:"bytes,3,opt,name=version"`
+	Namespace string   `json:"namespace,omitempty" protobuf:"bytes,4,opt,name=namespace"`
+	SetValues []string `json:"setValues,omitempty" protobuf:"bytes,5,rep,name=setValues"`
 }
 
 // StorageLocation
@@ -160,6 +226,66 @@ type StorageLocation struct {
 	Classifier string `json:"classifier,omitempty" protobuf:"bytes,1,opt,name=classifier"`
 	GitURL     string `json:"gitUrl,omitempty" protobuf:"bytes,2,opt,name=gitUrl"`
 	HttpURL    string `json:"httpUrl,omitempty" protobuf:"bytes,3,opt,name=httpUrl"`
+	BucketURL  string `json:"bucketUrl,omitempty" protobuf:"bytes,4,opt,name=bucketUrl"`
+}
+
+// JenkinsLibrary a global pipeline library of the static Jenkins server which is configured via configuration as code
+type JenkinsLibrary struct {
+	Name           string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	GitURL         string `json:"gitUrl" protobuf:"bytes,2,opt,name=gitUrl"`
+	DefaultVersion string `json:"defaultVersion,omitempty" protobuf:"bytes,3,opt,name=defaultVersion"`
+	Implicit       bool   `json:"implicit,omitempty" protobuf:"bytes,4,opt,name=implicit"`
+}
+
+// NetworkPolicySettings the network isolation of the environment and preview namespaces of the team
+type NetworkPolicySettings struct {
+	// Enabled denies the ingress traffic into the namespaces other than from the allowed namespaces
+	Enabled bool `json:"enabled,omitempty" protobuf:"bytes,1,opt,name=enabled"`
+	// IngressNamespace the namespace of the ingress controller
+	IngressNamespace string `json:"ingressNamespace,omitempty" protobuf:"bytes,2,opt,name=ingressNamespace"`
+	// AllowedNamespaces the other namespaces allowed to access the namespaces such as the one of a monitoring service
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty" protobuf:"bytes,3,rep,name=allowedNamespaces"`
+}
+
+// NotificationKindType the kind of a notification channel
+type NotificationKindType string
+
+const (
+	// NotificationKindTypeSlack posts the notifications to a Slack channel using a bot token
+	NotificationKindTypeSlack NotificationKindType = "slack"
+	// NotificationKindTypeTeams posts the notifications to a Microsoft Teams channel using an incoming webhook
+	NotificationKindTypeTeams NotificationKindType = "teams"
+	// NotificationKindTypeEmail emails the notifications to the recipients using an SMTP server
+	NotificationKindTypeEmail NotificationKindType = "email"
+	// NotificationKindTypeWebhook posts the events as JSON to a URL such as Opsgenie or an internal system
+	NotificationKindTypeWebhook NotificationKindType = "webhook"
+	// NotificationKindTypePagerDuty triggers PagerDuty incidents using the Events API v2
+	NotificationKindTypePagerDuty NotificationKindType = "pagerduty"
+)
+
+// NotificationChannel a channel notified of the pipeline, promotion and preview events of the team
+type NotificationChannel struct {
+	// Name the unique name of the channel
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Kind the kind of the channel
+	Kind NotificationKindType `json:"kind" protobuf:"bytes,2,opt,name=kind"`
+	// Channel the chat channel to post to such as #builds
+	Channel string `json:"channel,omitempty" protobuf:"bytes,3,opt,name=channel"`
+	// SecretName the name of the Secret containing the token, the URL, the HTTP headers or the SMTP credentials used to
+	// notify the channel
+	SecretName string `json:"secretName,omitempty" protobuf:"bytes,4,opt,name=secretName"`
+	// Events the events which are notified. All events are notified if empty
+	Events []string `json:"events,omitempty" protobuf:"bytes,5,rep,name=events"`
+	// Repositories the repositories whose events are notified in the form owner/name or name. All repositories if empty
+	Repositories []string `json:"repositories,omitempty" protobuf:"bytes,6,rep,name=repositories"`
+	// Templates the Go templates of the messages indexed by event overriding the default messages
+	Templates map[string]string `json:"templates,omitempty" protobuf:"bytes,7,rep,name=templates"`
+	// Recipients the email addresses notified by an email channel
+	Recipients []string `json:"recipients,omitempty" protobuf:"bytes,8,rep,name=recipients"`
+	// From the sender address of the emails
+	From string `json:"from,omitempty" protobuf:"bytes,9,opt,name=from"`
+	// SMTPServer the host:port of the SMTP server used to send the emails
+	SMTPServer string `json:"smtpServer,omitempty" protobuf:"bytes,10,opt,name=smtpServer"`
 }
 
 // QuickStartLocation
@@ -270,9 +396,56 @@ func (t *TeamSettings) StorageLocation(classifier string) *StorageLocation {
 	return &t.StorageLocations[len(t.StorageLocations) -1]
 }
 
+// Addon returns the settings of the installed addon with the given name or nil if it is not installed
+func (t *TeamSettings) Addon(name string) *AddonSettings {
+	for idx, a := range t.Addons {
+		if a.Name == name {
+			return &t.Addons[idx]
+		}
+	}
+	return nil
+}
+
+// SetAddon records the settings of an installed addon replacing any previous settings of the addon
+func (t *TeamSettings) SetAddon(addon AddonSettings) {
+	existing := t.Addon(addon.Name)
+	if existing != nil {
+		*existing = addon
+		return
+	}
+	t.Addons = append(t.Addons, addon)
+}
+
+// RemoveAddon removes the settings of the addon with the given name returning true if it was installed
+func (t *TeamSettings) RemoveAddon(name string) bool {
+	for idx, a := range t.Addons {
+		if a.Name == name {
+			t.Addons = append(t.Addons[:idx], t.Addons[idx+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// GetArtifactRepository returns the artifact repository of the team defaulting to nexus
+func (t *TeamSettings) GetArtifactRepository() ArtifactRepositoryType {
+	if t.ArtifactRepository == "" {
+		return ArtifactRepositoryNexus
+	}
+	return t.ArtifactRepository
+}
+
+// GetImageBuilder returns the image builder of the team defaulting to docker
+func (t *TeamSettings) GetImageBuilder() ImageBuilderType {
+	if t.ImageBuilder == "" {
+		return ImageBuilderDocker
+	}
+	return t.ImageBuilder
+}
+
 // IsEmpty returns true if the storage location is empty
 func (s *StorageLocation) IsEmpty() bool {
-	return s.GitURL == "" && s.HttpURL == ""
+	return s.GitURL == "" && s.HttpURL == "" && s.BucketURL == ""
 }
 
 // Description returns the textual description of the storage location
@@ -283,5 +456,8 @@ func (s *StorageLocation) Description() string {
 	if s.HttpURL != "" {
 		return s.HttpURL
 	}
+	if s.BucketURL != "" {
+		return "bucket: " + s.BucketURL
+	}
 	return "current git repo"
 }
\ No newline at end of file
@@ -55,6 +55,7 @@ type ExtensionSpec struct {
 	Namespace   string               `json:"namespace,omitempty"  protobuf:"bytes,9,opt,name=namespace"`
 	UUID        string               `json:"uuid,omitempty"  protobuf:"bytes,10,opt,name=uuid"`
 	Children    []string             `json:"children,omitempty"  protobuf:"bytes,11,opt,name=children"`
+	Image       string               `json:"image,omitempty"  protobuf:"bytes,12,opt,name=image"`
 }
 
 // ExtensionWhen specifies when in the lifecycle an extension should execute. By default Post.
@@ -71,8 +72,24 @@ const (
 	ExtensionWhenUninstall ExtensionWhen = "onUninstall"
 	// Executed when an extension upgrades
 	ExtensionWhenUpgrade ExtensionWhen = "onUpgrade"
+	// Executed after Jenkins X is installed into a team
+	ExtensionWhenPostInstall ExtensionWhen = "postInstall"
+	// Executed after an environment is created
+	ExtensionWhenPostEnvironmentCreate ExtensionWhen = "postEnvironmentCreate"
+	// Executed after an application is imported
+	ExtensionWhenPostImport ExtensionWhen = "postImport"
+	// Executed before an application is promoted to an environment
+	ExtensionWhenPrePromote ExtensionWhen = "prePromote"
 )
 
+// ExtensionLifecycleEvents the lifecycle events of a team to which extensions can be attached
+var ExtensionLifecycleEvents = []ExtensionWhen{
+	ExtensionWhenPostInstall,
+	ExtensionWhenPostEnvironmentCreate,
+	ExtensionWhenPostImport,
+	ExtensionWhenPrePromote,
+}
+
 // ExtensionGiven specifies the condition (if the extension is executing in a pipeline on which the extension should execute. By default Always.
 type ExtensionGiven string
 
@@ -100,6 +117,7 @@ type ExtensionExecution struct {
 	Given                ExtensionGiven        `json:"given,omitempty"  protobuf:"bytes,5,opt,name=given"`
 	Namespace            string                `json:"namespace,omitempty"  protobuf:"bytes,7,opt,name=namespace"`
 	UUID                 string                `json:"uuid,omitempty"  protobuf:"bytes,8,opt,name=uuid"`
+	Image                string                `json:"image,omitempty"  protobuf:"bytes,9,opt,name=image"`
 }
 
 // ExtensionRepositoryLockList contains a list of ExtensionRepositoryLock items
@@ -136,6 +154,7 @@ type ExtensionDefinition struct {
 	Children    []ExtensionDefinitionChildReference `json:"children,omitempty"`
 	ScriptFile  string                              `json:"scriptFile,omitempty"`
 	Parameters  []ExtensionParameter                `json:"parameters,omitempty"`
+	Image       string                              `json:"image,omitempty"`
 }
 
 // ExtensionDefinitionChildReference provides a reference to a child
@@ -194,6 +213,7 @@ const (
 	VersionGlobalParameterName        string = "extVersion"
 	TeamNamespaceGlobalParameterName  string = "extTeamNamespace"
 	OwnerReferenceGlobalParameterName string = "extOwnerReference"
+	LifecycleEventGlobalParameterName string = "extLifecycleEvent"
 )
 
 func (e *ExtensionExecution) Execute(verbose bool) (err error) {
@@ -352,6 +372,16 @@ func (e *ExtensionSpec) IsOnUninstall() bool {
 	return e.Contains(ExtensionWhenUninstall)
 }
 
+// IsLifecycleHook returns true if the extension is attached to any of the ExtensionLifecycleEvents
+func (e *ExtensionSpec) IsLifecycleHook() bool {
+	for _, when := range ExtensionLifecycleEvents {
+		if e.Contains(when) {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *ExtensionSpec) Contains(when ExtensionWhen) bool {
 	for _, w := range e.When {
 		if when == w {
@@ -485,4 +515,6 @@ type Binary struct {
 	Goarch string `json:"goarch,omitempty"  protobuf:"bytes,1,opt,name=goarch"`
 	Goos   string `json:"goos,omitempty"  protobuf:"bytes,2,opt,name=goos"`
 	URL    string `json:"url,omitempty"  protobuf:"bytes,3,opt,name=url"`
+	// Checksum the SHA256 checksum of the file downloaded from the URL
+	Checksum string `json:"checksum,omitempty"  protobuf:"bytes,4,opt,name=checksum"`
 }
@@ -98,10 +98,10 @@ const (
 	ReleaseStatusTypeFailed ReleaseStatusType = "Failed"
 )
 
-// IsClosed returns true if this issue is closed or fixed
+// IsClosed returns true if this issue is closed, fixed, resolved or done
 func (i *IssueSummary) IsClosed() bool {
 	lower := strings.ToLower(i.State)
-	return strings.HasPrefix(lower, "clos") || strings.HasPrefix(lower, "fix")
+	return strings.HasPrefix(lower, "clos") || strings.HasPrefix(lower, "fix") || strings.HasPrefix(lower, "resolv") || lower == "done"
 }
 
 // +genclient
@@ -0,0 +1,76 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+
+// ImageScan represents the vulnerabilities found by scanning a container image built by a pipeline
+type ImageScan struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Spec ImageScanSpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ImageScanList is a structure used by k8s to store lists of image scans
+type ImageScanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ImageScan `json:"items"`
+}
+
+// ImageScanSpec provides details of the scan of an image
+type ImageScanSpec struct {
+	Image           string               `json:"image"  protobuf:"bytes,1,opt,name=image"`
+	Scanner         string               `json:"scanner,omitempty"  protobuf:"bytes,2,opt,name=scanner"`
+	Application     string               `json:"application,omitempty"  protobuf:"bytes,3,opt,name=application"`
+	Version         string               `json:"version,omitempty"  protobuf:"bytes,4,opt,name=version"`
+	GitURL          string               `json:"gitUrl,omitempty"  protobuf:"bytes,5,opt,name=gitUrl"`
+	PullRequest     string               `json:"pullRequest,omitempty"  protobuf:"bytes,6,opt,name=pullRequest"`
+	Vulnerabilities []ImageVulnerability `json:"vulnerabilities,omitempty"  protobuf:"bytes,7,rep,name=vulnerabilities"`
+}
+
+// ImageVulnerability a vulnerability found in a package of an image
+type ImageVulnerability struct {
+	ID               string `json:"id"  protobuf:"bytes,1,opt,name=id"`
+	Package          string `json:"package,omitempty"  protobuf:"bytes,2,opt,name=package"`
+	InstalledVersion string `json:"installedVersion,omitempty"  protobuf:"bytes,3,opt,name=installedVersion"`
+	FixedVersion     string `json:"fixedVersion,omitempty"  protobuf:"bytes,4,opt,name=fixedVersion"`
+	Severity         string `json:"severity,omitempty"  protobuf:"bytes,5,opt,name=severity"`
+	URL              string `json:"url,omitempty"  protobuf:"bytes,6,opt,name=url"`
+}
+
+// SecurityPolicy the policy which prevents promoting images with vulnerabilities to environments
+type SecurityPolicy struct {
+	// MaxSeverity the highest severity of vulnerability allowed in a promoted image. Empty allows all vulnerabilities
+	MaxSeverity string `json:"maxSeverity,omitempty"  protobuf:"bytes,1,opt,name=maxSeverity"`
+	// Environments the environments the policy applies to. Empty applies the policy to the production environment
+	Environments []string `json:"environments,omitempty"  protobuf:"bytes,2,rep,name=environments"`
+}
+
+// AppliesTo returns true if the policy applies to the environment of the given name
+func (p *SecurityPolicy) AppliesTo(env string) bool {
+	if p.MaxSeverity == "" {
+		return false
+	}
+	if len(p.Environments) == 0 {
+		return env == "production"
+	}
+	for _, e := range p.Environments {
+		if e == env {
+			return true
+		}
+	}
+	return false
+}
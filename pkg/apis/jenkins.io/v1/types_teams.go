@@ -26,6 +26,15 @@ type TeamSpec struct {
 	Label   string       `json:"label,omitempty" protobuf:"bytes,1,opt,name=label"`
 	Kind    TeamKindType `json:"kind,omitempty" protobuf:"bytes,2,opt,name=kind"`
 	Members []string     `json:"members,omitempty" protobuf:"bytes,3,opt,name=members"`
+
+	// GitServer the default git server for new repositories of the team. Defaults to the one of the admin team
+	GitServer string `json:"gitServer,omitempty" protobuf:"bytes,4,opt,name=gitServer"`
+	// Organisation the default git organisation for new repositories of the team
+	Organisation string `json:"organisation,omitempty" protobuf:"bytes,5,opt,name=organisation"`
+	// NoEnvironments disables the creation of the default Staging and Production environments of the team
+	NoEnvironments bool `json:"noEnvironments,omitempty" protobuf:"bytes,6,opt,name=noEnvironments"`
+	// Addons the names of the addons to install into the team when it is provisioned
+	Addons []string `json:"addons,omitempty" protobuf:"bytes,7,opt,name=addons"`
 }
 
 // TeamStatus is the status for an Team resource
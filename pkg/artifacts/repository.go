@@ -0,0 +1,275 @@
+package artifacts
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/beevik/etree"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultNexusURL the URL of the Nexus repository installed with Jenkins X
+	DefaultNexusURL = "http://nexus"
+
+	// MavenSettingsFile the name of the Maven settings file
+	MavenSettingsFile = "settings.xml"
+	// GradleInitScriptFile the name of the Gradle init script
+	GradleInitScriptFile = "init.gradle"
+	// NpmConfigFile the name of the npm configuration file
+	NpmConfigFile = ".npmrc"
+)
+
+// Repository the artifact repository the pipelines resolve and deploy artifacts with
+type Repository struct {
+	Kind     v1.ArtifactRepositoryType
+	URL      string
+	Username string
+	Password string
+}
+
+// Enabled returns true if the pipelines use the repository rather than the public repositories
+func (r *Repository) Enabled() bool {
+	return r.Kind != v1.ArtifactRepositoryNone && r.URL != ""
+}
+
+// MavenGroupURL returns the URL the pipelines resolve Maven artifacts from
+func (r *Repository) MavenGroupURL() string {
+	if r.Kind == v1.ArtifactRepositoryArtifactory {
+		return r.url("libs-release")
+	}
+	return r.url("repository/maven-group/")
+}
+
+// MavenReleasesURL returns the URL the pipelines deploy released Maven artifacts to
+func (r *Repository) MavenReleasesURL() string {
+	if r.Kind == v1.ArtifactRepositoryArtifactory {
+		return r.url("libs-release-local")
+	}
+	return r.url("repository/maven-releases/")
+}
+
+// MavenSnapshotsURL returns the URL the pipelines deploy snapshot Maven artifacts to
+func (r *Repository) MavenSnapshotsURL() string {
+	if r.Kind == v1.ArtifactRepositoryArtifactory {
+		return r.url("libs-snapshot-local")
+	}
+	return r.url("repository/maven-snapshots/")
+}
+
+// NpmURL returns the URL of the npm registry
+func (r *Repository) NpmURL() string {
+	if r.Kind == v1.ArtifactRepositoryArtifactory {
+		return r.url("api/npm/npm/")
+	}
+	return r.url("repository/npm-group/")
+}
+
+func (r *Repository) url(path string) string {
+	return strings.TrimSuffix(r.URL, "/") + "/" + path
+}
+
+const mavenSettingsTemplate = `<settings>
+      <!-- sets the local maven repository outside of the ~/.m2 folder for easier mounting of secrets and repo -->
+      <localRepository>${user.home}/.mvnrepository</localRepository>
+      <!-- lets disable the download progress indicator that fills up logs -->
+      <interactiveMode>false</interactiveMode>
+{{- if .Enabled }}
+      <mirrors>
+          <mirror>
+          <id>{{ .Kind }}</id>
+          <mirrorOf>external:*</mirrorOf>
+          <url>{{ .MavenGroupURL }}</url>
+          </mirror>
+      </mirrors>
+      <servers>
+          <server>
+          <id>{{ .Kind }}</id>
+          <username>{{ .Username }}</username>
+          <password>{{ .Password }}</password>
+          </server>
+          <server>
+          <id>local-{{ .Kind }}</id>
+          <username>{{ .Username }}</username>
+          <password>{{ .Password }}</password>
+          </server>
+      </servers>
+{{- end }}
+      <profiles>
+{{- if .Enabled }}
+          <profile>
+              <id>{{ .Kind }}</id>
+              <properties>
+                  <altDeploymentRepository>local-{{ .Kind }}::default::{{ .MavenSnapshotsURL }}</altDeploymentRepository>
+                  <altReleaseDeploymentRepository>local-{{ .Kind }}::default::{{ .MavenReleasesURL }}</altReleaseDeploymentRepository>
+                  <altSnapshotDeploymentRepository>local-{{ .Kind }}::default::{{ .MavenSnapshotsURL }}</altSnapshotDeploymentRepository>
+              </properties>
+          </profile>
+{{- end }}
+          <profile>
+              <id>release</id>
+              <properties>
+                  <gpg.executable>gpg</gpg.executable>
+                  <gpg.passphrase>mysecretpassphrase</gpg.passphrase>
+              </properties>
+          </profile>
+      </profiles>
+{{- if .Enabled }}
+      <activeProfiles>
+          <!--make the profile active all the time -->
+          <activeProfile>{{ .Kind }}</activeProfile>
+      </activeProfiles>
+{{- end }}
+  </settings>
+`
+
+const gradleInitScriptTemplate = `{{- if .Enabled -}}
+// resolves the dependencies and plugins of the builds from the {{ .Kind }} repository
+def repoURL = '{{ .MavenGroupURL }}'
+def configureRepositories = { handler ->
+    handler.all { repo ->
+        if (repo instanceof MavenArtifactRepository && repo.url.toString() != repoURL) {
+            handler.remove repo
+        }
+    }
+    handler.maven {
+        url repoURL
+        credentials {
+            username '{{ .Username }}'
+            password '{{ .Password }}'
+        }
+    }
+}
+settingsEvaluated { settings ->
+    configureRepositories(settings.pluginManagement.repositories)
+}
+allprojects {
+    buildscript {
+        configureRepositories(repositories)
+    }
+    configureRepositories(repositories)
+}
+{{- end }}
+`
+
+// MavenSettings returns the Maven settings.xml which resolves and deploys artifacts with the repository
+func MavenSettings(r *Repository) (string, error) {
+	return render("settings.xml", mavenSettingsTemplate, r)
+}
+
+// MergeMavenSettings merges the mirror, servers and profile which resolve and deploy artifacts with the repository into
+// the existing Maven settings.xml so that the rest of the settings are kept. The entries of any previous artifact
+// repository are replaced. The settings of the repository are returned if there are no existing settings
+func MergeMavenSettings(existing string, r *Repository) (string, error) {
+	if strings.TrimSpace(existing) == "" {
+		return MavenSettings(r)
+	}
+	doc := etree.NewDocument()
+	err := doc.ReadFromString(existing)
+	if err != nil {
+		return "", errors.Wrap(err, "parsing the existing Maven settings")
+	}
+	root := doc.Root()
+	if root == nil || root.Tag != "settings" {
+		return "", fmt.Errorf("the existing Maven settings have no settings element")
+	}
+
+	ids := []string{}
+	for _, kind := range []v1.ArtifactRepositoryType{v1.ArtifactRepositoryNexus, v1.ArtifactRepositoryArtifactory} {
+		ids = append(ids, string(kind), "local-"+string(kind))
+	}
+	removeElementsWithID(root, "mirrors", "mirror", ids)
+	removeElementsWithID(root, "servers", "server", ids)
+	removeElementsWithID(root, "profiles", "profile", ids)
+	if activeProfiles := root.SelectElement("activeProfiles"); activeProfiles != nil {
+		for _, activeProfile := range activeProfiles.SelectElements("activeProfile") {
+			if util.StringArrayIndex(ids, strings.TrimSpace(activeProfile.Text())) >= 0 {
+				activeProfiles.RemoveChild(activeProfile)
+			}
+		}
+	}
+
+	if r.Enabled() {
+		kind := string(r.Kind)
+		mirror := ensureElement(root, "mirrors").CreateElement("mirror")
+		mirror.CreateElement("id").SetText(kind)
+		mirror.CreateElement("mirrorOf").SetText("external:*")
+		mirror.CreateElement("url").SetText(r.MavenGroupURL())
+
+		servers := ensureElement(root, "servers")
+		for _, id := range []string{kind, "local-" + kind} {
+			server := servers.CreateElement("server")
+			server.CreateElement("id").SetText(id)
+			server.CreateElement("username").SetText(r.Username)
+			server.CreateElement("password").SetText(r.Password)
+		}
+
+		profile := ensureElement(root, "profiles").CreateElement("profile")
+		profile.CreateElement("id").SetText(kind)
+		properties := profile.CreateElement("properties")
+		properties.CreateElement("altDeploymentRepository").SetText("local-" + kind + "::default::" + r.MavenSnapshotsURL())
+		properties.CreateElement("altReleaseDeploymentRepository").SetText("local-" + kind + "::default::" + r.MavenReleasesURL())
+		properties.CreateElement("altSnapshotDeploymentRepository").SetText("local-" + kind + "::default::" + r.MavenSnapshotsURL())
+
+		ensureElement(root, "activeProfiles").CreateElement("activeProfile").SetText(kind)
+	}
+	doc.Indent(2)
+	return doc.WriteToString()
+}
+
+// removeElementsWithID removes the elements of the list element whose id is one of the given ids
+func removeElementsWithID(root *etree.Element, listTag string, tag string, ids []string) {
+	list := root.SelectElement(listTag)
+	if list == nil {
+		return
+	}
+	for _, e := range list.SelectElements(tag) {
+		id := e.SelectElement("id")
+		if id != nil && util.StringArrayIndex(ids, strings.TrimSpace(id.Text())) >= 0 {
+			list.RemoveChild(e)
+		}
+	}
+}
+
+// ensureElement returns the child element with the given tag creating it if it does not exist
+func ensureElement(parent *etree.Element, tag string) *etree.Element {
+	answer := parent.SelectElement(tag)
+	if answer == nil {
+		answer = parent.CreateElement(tag)
+	}
+	return answer
+}
+
+// GradleInitScript returns the Gradle init script which resolves dependencies and plugins from the repository
+func GradleInitScript(r *Repository) (string, error) {
+	return render("init.gradle", gradleInitScriptTemplate, r)
+}
+
+// NpmConfig returns the .npmrc which resolves packages from the repository
+func NpmConfig(r *Repository) string {
+	if !r.Enabled() {
+		return ""
+	}
+	npmURL := r.NpmURL()
+	host := npmURL[strings.Index(npmURL, "//"):]
+	auth := base64.StdEncoding.EncodeToString([]byte(r.Username + ":" + r.Password))
+	return fmt.Sprintf("registry=%s\n%s:_auth=%s\nalways-auth=true\n", npmURL, host, auth)
+}
+
+func render(name string, text string, r *Repository) (string, error) {
+	t, err := template.New(name).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buffer bytes.Buffer
+	err = t.Execute(&buffer, r)
+	if err != nil {
+		return "", fmt.Errorf("failed to render the %s of the artifact repository: %s", name, err)
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,112 @@
+package artifacts_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/artifacts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoryURLs(t *testing.T) {
+	t.Parallel()
+
+	nexus := &artifacts.Repository{Kind: v1.ArtifactRepositoryNexus, URL: "http://nexus"}
+	assert.Equal(t, "http://nexus/repository/maven-group/", nexus.MavenGroupURL())
+	assert.Equal(t, "http://nexus/repository/maven-releases/", nexus.MavenReleasesURL())
+	assert.Equal(t, "http://nexus/repository/npm-group/", nexus.NpmURL())
+
+	artifactory := &artifacts.Repository{Kind: v1.ArtifactRepositoryArtifactory, URL: "https://acme.com/artifactory/"}
+	assert.Equal(t, "https://acme.com/artifactory/libs-release", artifactory.MavenGroupURL())
+	assert.Equal(t, "https://acme.com/artifactory/libs-snapshot-local", artifactory.MavenSnapshotsURL())
+	assert.Equal(t, "https://acme.com/artifactory/api/npm/npm/", artifactory.NpmURL())
+}
+
+func TestMavenSettings(t *testing.T) {
+	t.Parallel()
+
+	repo := &artifacts.Repository{Kind: v1.ArtifactRepositoryArtifactory, URL: "https://acme.com/artifactory", Username: "admin", Password: "secret"}
+	settings, err := artifacts.MavenSettings(repo)
+	assert.NoError(t, err)
+	assert.Contains(t, settings, "<url>https://acme.com/artifactory/libs-release</url>")
+	assert.Contains(t, settings, "local-artifactory::default::https://acme.com/artifactory/libs-release-local")
+	assert.Contains(t, settings, "<password>secret</password>")
+	assert.Contains(t, settings, "<activeProfile>artifactory</activeProfile>")
+
+	settings, err = artifacts.MavenSettings(&artifacts.Repository{Kind: v1.ArtifactRepositoryNone})
+	assert.NoError(t, err)
+	assert.False(t, strings.Contains(settings, "<mirrors>"), "settings without a repository should not have mirrors")
+	assert.Contains(t, settings, "<id>release</id>")
+}
+
+func TestGradleAndNpmSettings(t *testing.T) {
+	t.Parallel()
+
+	repo := &artifacts.Repository{Kind: v1.ArtifactRepositoryNexus, URL: "http://nexus", Username: "admin", Password: "admin123"}
+	script, err := artifacts.GradleInitScript(repo)
+	assert.NoError(t, err)
+	assert.Contains(t, script, "def repoURL = 'http://nexus/repository/maven-group/'")
+
+	npmrc := artifacts.NpmConfig(repo)
+	assert.Equal(t, "registry=http://nexus/repository/npm-group/\n//nexus/repository/npm-group/:_auth=YWRtaW46YWRtaW4xMjM=\nalways-auth=true\n", npmrc)
+
+	none := &artifacts.Repository{Kind: v1.ArtifactRepositoryNone}
+	script, err = artifacts.GradleInitScript(none)
+	assert.NoError(t, err)
+	assert.Equal(t, "", strings.TrimSpace(script))
+	assert.Equal(t, "", artifacts.NpmConfig(none))
+}
+
+const existingMavenSettings = `<?xml version="1.0" encoding="UTF-8"?>
+<settings>
+  <localRepository>/home/jenkins/.mvnrepository</localRepository>
+  <mirrors>
+    <mirror>
+      <id>nexus</id>
+      <mirrorOf>external:*</mirrorOf>
+      <url>http://nexus/repository/maven-group/</url>
+    </mirror>
+  </mirrors>
+  <servers>
+    <server>
+      <id>local-nexus</id>
+      <username>admin</username>
+      <password>admin123</password>
+    </server>
+    <server>
+      <id>corporate</id>
+      <username>bob</username>
+      <password>builder</password>
+    </server>
+  </servers>
+  <activeProfiles>
+    <activeProfile>nexus</activeProfile>
+    <activeProfile>corporate</activeProfile>
+  </activeProfiles>
+</settings>
+`
+
+func TestMergeMavenSettings(t *testing.T) {
+	t.Parallel()
+
+	repo := &artifacts.Repository{Kind: v1.ArtifactRepositoryArtifactory, URL: "https://acme.com/artifactory", Username: "admin", Password: "secret"}
+	settings, err := artifacts.MergeMavenSettings(existingMavenSettings, repo)
+	assert.NoError(t, err)
+	assert.Contains(t, settings, "<localRepository>/home/jenkins/.mvnrepository</localRepository>")
+	assert.Contains(t, settings, "<id>corporate</id>")
+	assert.Contains(t, settings, "<activeProfile>corporate</activeProfile>")
+	assert.Contains(t, settings, "<url>https://acme.com/artifactory/libs-release</url>")
+	assert.Contains(t, settings, "<id>local-artifactory</id>")
+	assert.Contains(t, settings, "<activeProfile>artifactory</activeProfile>")
+	assert.NotContains(t, settings, "nexus", "the entries of the previous repository should be replaced")
+
+	settings, err = artifacts.MergeMavenSettings(settings, &artifacts.Repository{Kind: v1.ArtifactRepositoryNone})
+	assert.NoError(t, err)
+	assert.NotContains(t, settings, "artifactory")
+	assert.Contains(t, settings, "<id>corporate</id>")
+
+	settings, err = artifacts.MergeMavenSettings("", repo)
+	assert.NoError(t, err)
+	assert.Contains(t, settings, "<activeProfile>artifactory</activeProfile>")
+}
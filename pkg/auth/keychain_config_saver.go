@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"github.com/jenkins-x/jx/pkg/keychain"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// KeychainService the service name the configs are stored under in the OS keychain
+const KeychainService = "jx"
+
+// LoadConfig loads the config from the OS keychain
+func (k *KeychainAuthConfigSaver) LoadConfig() (*AuthConfig, error) {
+	config := &AuthConfig{}
+	data, err := k.keychain.Get(KeychainService, k.account)
+	if err == keychain.ErrNotFound {
+		return config, nil
+	}
+	if err != nil {
+		return config, errors.Wrapf(err, "reading %s from the keychain", k.account)
+	}
+	err = yaml.Unmarshal([]byte(data), config)
+	if err != nil {
+		return config, errors.Wrapf(err, "unmarshalling %s from the keychain", k.account)
+	}
+	return config, nil
+}
+
+// SaveConfig saves the config to the OS keychain
+func (k *KeychainAuthConfigSaver) SaveConfig(config *AuthConfig) error {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return k.keychain.Set(KeychainService, k.account, string(data))
+}
+
+// NewKeychainAuthConfigService creates a new ConfigService that saves its config under the account in the OS keychain
+func NewKeychainAuthConfigService(account string, keychain keychain.Keychain) ConfigService {
+	saver := &KeychainAuthConfigSaver{
+		keychain: keychain,
+		account:  account,
+	}
+	return NewAuthConfigService(saver)
+}
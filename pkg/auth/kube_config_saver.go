@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// KubeAuthConfigKey the key of the config in the data of the Secret
+	KubeAuthConfigKey = "config.yaml"
+
+	kubeAuthSecretPrefix = "jx-auth-"
+)
+
+var invalidSecretNameChars = regexp.MustCompile("[^a-z0-9-]+")
+
+// LoadConfig loads the config from the Secret in the cluster
+func (k *KubeAuthConfigSaver) LoadConfig() (*AuthConfig, error) {
+	config := &AuthConfig{}
+	secret, err := k.kubeClient.CoreV1().Secrets(k.namespace).Get(k.secretName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return config, nil
+	}
+	if err != nil {
+		return config, errors.Wrapf(err, "getting secret %s in namespace %s", k.secretName, k.namespace)
+	}
+	err = yaml.Unmarshal(secret.Data[KubeAuthConfigKey], config)
+	if err != nil {
+		return config, errors.Wrapf(err, "unmarshalling secret %s", k.secretName)
+	}
+	return config, nil
+}
+
+// SaveConfig saves the config to the Secret in the cluster, creating it if it does not exist
+func (k *KubeAuthConfigSaver) SaveConfig(config *AuthConfig) error {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	secrets := k.kubeClient.CoreV1().Secrets(k.namespace)
+	secret, err := secrets.Get(k.secretName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		secret = &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: k.secretName,
+			},
+			Data: map[string][]byte{
+				KubeAuthConfigKey: data,
+			},
+		}
+		_, err = secrets.Create(secret)
+		return errors.Wrapf(err, "creating secret %s in namespace %s", k.secretName, k.namespace)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "getting secret %s in namespace %s", k.secretName, k.namespace)
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[KubeAuthConfigKey] = data
+	_, err = secrets.Update(secret)
+	return errors.Wrapf(err, "updating secret %s in namespace %s", k.secretName, k.namespace)
+}
+
+// NewKubeAuthConfigService creates a new ConfigService that saves its config to a Secret in the given namespace.
+// The name of the Secret is derived from the config name such as gitAuth.yaml
+func NewKubeAuthConfigService(configName string, kubeClient kubernetes.Interface, namespace string) ConfigService {
+	saver := &KubeAuthConfigSaver{
+		kubeClient: kubeClient,
+		namespace:  namespace,
+		secretName: KubeAuthSecretName(configName),
+	}
+	return NewAuthConfigService(saver)
+}
+
+// KubeAuthSecretName returns the name of the Secret used to store the config of the given name
+func KubeAuthSecretName(configName string) string {
+	name := strings.TrimSuffix(configName, ".yaml")
+	name = invalidSecretNameChars.ReplaceAllString(strings.ToLower(name), "-")
+	return kubeAuthSecretPrefix + strings.Trim(name, "-")
+}
@@ -0,0 +1,52 @@
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestKubeAuthConfigService(t *testing.T) {
+	t.Parallel()
+
+	kubeClient := fake.NewSimpleClientset()
+	service := auth.NewKubeAuthConfigService("gitAuth.yaml", kubeClient, "jx")
+
+	config, err := service.LoadConfig()
+	require.NoError(t, err)
+	assert.Empty(t, config.Servers)
+
+	config.Servers = []*auth.AuthServer{
+		{
+			URL:  "https://github.com",
+			Name: "GitHub",
+			Kind: "github",
+			Users: []*auth.UserAuth{
+				{Username: "jstrachan", ApiToken: "abc"},
+			},
+		},
+	}
+	err = service.SaveConfig()
+	require.NoError(t, err)
+
+	_, err = kubeClient.CoreV1().Secrets("jx").Get("jx-auth-gitauth", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	loaded, err := auth.NewKubeAuthConfigService("gitAuth.yaml", kubeClient, "jx").LoadConfig()
+	require.NoError(t, err)
+	require.Len(t, loaded.Servers, 1)
+	assert.Equal(t, "https://github.com", loaded.Servers[0].URL)
+	assert.Equal(t, "abc", loaded.Servers[0].Users[0].ApiToken)
+}
+
+func TestKubeAuthSecretName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "jx-auth-gitauth", auth.KubeAuthSecretName("gitAuth.yaml"))
+	assert.Equal(t, "jx-auth-chartmuseumauth", auth.KubeAuthSecretName("chartmuseumAuth.yaml"))
+	assert.Equal(t, "jx-auth-my-config", auth.KubeAuthSecretName("my_config"))
+}
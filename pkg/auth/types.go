@@ -1,7 +1,9 @@
 package auth
 
 import (
+	"github.com/jenkins-x/jx/pkg/keychain"
 	"github.com/jenkins-x/jx/pkg/vault"
+	"k8s.io/client-go/kubernetes"
 )
 
 const (
@@ -49,3 +51,16 @@ type VaultAuthConfigSaver struct {
 	vaultClient vault.Client
 	secretName  string
 }
+
+// KeychainAuthConfigSaver is a ConfigSaver that saves configs to the OS keychain
+type KeychainAuthConfigSaver struct {
+	keychain keychain.Keychain
+	account  string
+}
+
+// KubeAuthConfigSaver is a ConfigSaver that saves configs to a Secret in the cluster
+type KubeAuthConfigSaver struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+	secretName string
+}
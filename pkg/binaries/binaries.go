@@ -13,6 +13,7 @@ import (
 const EksctlVersion = "0.1.12"
 const IBMCloudVersion = "0.10.1"
 const HeptioAuthenticatorAwsVersion = "1.10.3"
+const OpaVersion = "0.10.7"
 
 func BinaryWithExtension(binary string) string {
 	if runtime.GOOS == "windows" {
@@ -0,0 +1,160 @@
+package binaries
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// ChecksumOptions describes where the published checksum and optional signature of a downloaded file can be found
+type ChecksumOptions struct {
+	// ChecksumURL the URL of a file containing the SHA256 checksum of the file or a list of "<sha256>  <file name>" lines
+	ChecksumURL string
+	// SignatureURL the URL of the detached GPG signature of the checksum file, if one is published
+	SignatureURL string
+	// FileName the name of the file in the checksum list, defaults to the last path element of the download URL
+	FileName string
+	// Checksum the expected SHA256 checksum of the file when it is pinned, such as by the version stream, rather than
+	// published next to the file
+	Checksum string
+}
+
+// DownloadAndVerifyFile downloads the file then verifies its SHA256 checksum and the signature of the checksum file.
+// The downloaded file is removed if the verification fails
+func DownloadAndVerifyFile(clientURL string, fullPath string, options ChecksumOptions) error {
+	err := DownloadFile(clientURL, fullPath)
+	if err != nil {
+		return err
+	}
+	if options.FileName == "" {
+		options.FileName = clientURL[strings.LastIndex(clientURL, "/")+1:]
+	}
+	err = VerifyDownload(fullPath, options)
+	if err != nil {
+		os.Remove(fullPath)
+		return errors.Wrapf(err, "verifying %s, use --insecure-skip-verify to skip the verification", clientURL)
+	}
+	return nil
+}
+
+// VerifyDownload verifies the SHA256 checksum of the file against the pinned or published checksum
+func VerifyDownload(fullPath string, options ChecksumOptions) error {
+	expected := options.Checksum
+	if expected == "" {
+		var err error
+		expected, err = publishedChecksum(options)
+		if err != nil {
+			return err
+		}
+	}
+	actual, err := FileChecksum(fullPath)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(expected, actual) {
+		return fmt.Errorf("the SHA256 checksum of %s is %s but the published checksum is %s", options.FileName, actual, expected)
+	}
+	log.Infof("Verified the checksum of %s\n", util.ColorInfo(options.FileName))
+	return nil
+}
+
+// publishedChecksum downloads the checksum file and verifies its signature if one is published, returning the
+// checksum of the file
+func publishedChecksum(options ChecksumOptions) (string, error) {
+	if options.ChecksumURL == "" {
+		return "", fmt.Errorf("no checksum is published for %s", options.FileName)
+	}
+	dir, err := ioutil.TempDir("", "jx-checksum-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	checksumFile := filepath.Join(dir, "checksums")
+	err = util.DownloadFile(checksumFile, options.ChecksumURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "downloading the checksum %s", options.ChecksumURL)
+	}
+	if options.SignatureURL != "" {
+		err = verifySignature(checksumFile, options.SignatureURL, dir)
+		if err != nil {
+			return "", err
+		}
+	}
+	data, err := ioutil.ReadFile(checksumFile)
+	if err != nil {
+		return "", err
+	}
+	return FindChecksum(string(data), options.FileName)
+}
+
+// FindChecksum returns the checksum of the file name from the contents of a checksum file. The contents are either
+// a single checksum or lines of "<sha256>  <file name>"
+func FindChecksum(checksums string, fileName string) (string, error) {
+	lines := strings.Split(strings.TrimSpace(checksums), "\n")
+	if len(lines) == 1 {
+		fields := strings.Fields(lines[0])
+		if len(fields) == 1 {
+			return fields[0], nil
+		}
+	}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == fileName {
+			return fields[0], nil
+		}
+	}
+	return "", fmt.Errorf("no checksum found for %s", fileName)
+}
+
+// FileChecksum returns the hex encoded SHA256 checksum of the file
+func FileChecksum(fileName string) (string, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// verifySignature verifies the detached signature of the checksum file. gpg and the public key of the signer are
+// required so that a published signature is always verified
+func verifySignature(checksumFile string, signatureURL string, dir string) error {
+	gpg, err := exec.LookPath("gpg")
+	if err != nil {
+		return fmt.Errorf("gpg is required to verify the signature %s but it is not on the PATH", signatureURL)
+	}
+	signatureFile := filepath.Join(dir, "checksums.sig")
+	err = util.DownloadFile(signatureFile, signatureURL)
+	if err != nil {
+		return errors.Wrapf(err, "downloading the signature %s", signatureURL)
+	}
+	var out bytes.Buffer
+	cmd := exec.Command(gpg, "--verify", signatureFile, checksumFile)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err = cmd.Run()
+	if err != nil {
+		if strings.Contains(out.String(), "No public key") {
+			return fmt.Errorf("the key which signed %s is not in your gpg keyring, please import the public key of the publisher", signatureURL)
+		}
+		return fmt.Errorf("invalid signature %s: %s", signatureURL, strings.TrimSpace(out.String()))
+	}
+	return nil
+}
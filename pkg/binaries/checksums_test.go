@@ -0,0 +1,70 @@
+package binaries
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindChecksum(t *testing.T) {
+	t.Parallel()
+
+	sum, err := FindChecksum("abc123\n", "kubectl")
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", sum)
+
+	sums := "abc123  terraform_0.11.10_darwin_amd64.zip\ndef456  terraform_0.11.10_linux_amd64.zip\n"
+	sum, err = FindChecksum(sums, "terraform_0.11.10_linux_amd64.zip")
+	require.NoError(t, err)
+	assert.Equal(t, "def456", sum)
+
+	_, err = FindChecksum(sums, "terraform_0.11.10_windows_amd64.zip")
+	assert.Error(t, err)
+}
+
+func TestFileChecksum(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "test-checksum-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "hello")
+	err = ioutil.WriteFile(fileName, []byte("hello\n"), 0644)
+	require.NoError(t, err)
+
+	sum, err := FileChecksum(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, "5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03", sum)
+}
+
+func TestVerifyDownloadWithPinnedChecksum(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "test-checksum-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "hello")
+	err = ioutil.WriteFile(fileName, []byte("hello\n"), 0644)
+	require.NoError(t, err)
+
+	err = VerifyDownload(fileName, ChecksumOptions{
+		FileName: "hello",
+		Checksum: "5891B5B522D5DF086D0FF0B110FBD9D21BB4FC7163AF34D08286A2E846F6BE03",
+	})
+	assert.NoError(t, err)
+
+	err = VerifyDownload(fileName, ChecksumOptions{
+		FileName: "hello",
+		Checksum: "abc123",
+	})
+	assert.Error(t, err)
+
+	err = VerifyDownload(fileName, ChecksumOptions{FileName: "hello"})
+	assert.Error(t, err)
+}
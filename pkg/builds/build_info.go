@@ -5,6 +5,7 @@ import (
 	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/tekton"
 	corev1 "k8s.io/api/core/v1"
 	"regexp"
 	"sort"
@@ -41,6 +42,92 @@ type BuildPodInfoFilter struct {
 	Pending    bool
 }
 
+const (
+	// BuildStepContainerPrefix the prefix of the names of the init containers which run the build steps
+	BuildStepContainerPrefix = "build-step-"
+)
+
+// StepName returns the name of the build step run by the container
+func StepName(container *corev1.Container) string {
+	return strings.TrimPrefix(container.Name, BuildStepContainerPrefix)
+}
+
+// StepContainers returns the containers of the build pod in the order the steps run. If a step is given
+// only the containers whose step name contains it are returned
+func (b *BuildPodInfo) StepContainers(step string) []corev1.Container {
+	answer := []corev1.Container{}
+	if b.Pod == nil {
+		return answer
+	}
+	for _, c := range stepContainers(b.Pod) {
+		if step == "" || strings.Contains(StepName(&c), step) {
+			answer = append(answer, c)
+		}
+	}
+	return answer
+}
+
+// stepContainers returns the containers running the build steps of the pod in the same way as StepStatuses
+func stepContainers(pod *corev1.Pod) []corev1.Container {
+	if pod.Labels[tekton.LabelPipelineRunName] == "" {
+		return pod.Spec.InitContainers
+	}
+	answer := []corev1.Container{}
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for _, c := range containers {
+			if strings.HasPrefix(c.Name, BuildStepContainerPrefix) {
+				answer = append(answer, c)
+			}
+		}
+	}
+	return answer
+}
+
+// StepStatus returns the status of the container of the step or nil if the pod has no status for it yet
+func StepStatus(pod *corev1.Pod, containerName string) *corev1.ContainerStatus {
+	for _, status := range StepStatuses(pod) {
+		if status.Name == containerName {
+			return &status
+		}
+	}
+	return nil
+}
+
+// IsStepStarted returns true if the container of the step is running or has terminated so that its log is
+// available
+func IsStepStarted(pod *corev1.Pod, containerName string) bool {
+	status := StepStatus(pod, containerName)
+	return status != nil && (status.State.Running != nil || status.State.Terminated != nil)
+}
+
+// FailedStep returns the name of the container of the first build step which terminated with an error or an empty
+// string if no step has failed. The later steps of the pod never start once a step has failed
+func FailedStep(pod *corev1.Pod) string {
+	for _, status := range StepStatuses(pod) {
+		if t := status.State.Terminated; t != nil && t.ExitCode != 0 {
+			return status.Name
+		}
+	}
+	return ""
+}
+
+// StepStatuses returns the statuses of the containers running the build steps of the pod. The steps of Knative builds
+// run in init containers whereas the steps of Tekton tasks run in either depending on the version of Tekton
+func StepStatuses(pod *corev1.Pod) []corev1.ContainerStatus {
+	if pod.Labels[tekton.LabelPipelineRunName] == "" {
+		return pod.Status.InitContainerStatuses
+	}
+	answer := []corev1.ContainerStatus{}
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if strings.HasPrefix(status.Name, BuildStepContainerPrefix) {
+				answer = append(answer, status)
+			}
+		}
+	}
+	return answer
+}
+
 // CreateBuildPodInfo creates a BuildPodInfo from a Pod
 func CreateBuildPodInfo(pod *corev1.Pod) *BuildPodInfo {
 	branch := ""
@@ -55,7 +142,9 @@ func CreateBuildPodInfo(pod *corev1.Pod) *BuildPodInfo {
 		log.Warnf("Failed to compile regexp because %s", err)
 	}
 	gitURL := ""
-	for _, initContainer := range pod.Spec.InitContainers {
+	// the steps of Tekton tasks may run in containers rather than init containers
+	containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, initContainer := range containers {
 		if initContainer.Name == "build-step-git-source" {
 			args := initContainer.Args
 			for i := 0; i <= len(args)-2; i += 2 {
@@ -190,12 +279,36 @@ func (o *BuildPodInfoFilter) BuildMatches(info *BuildPodInfo) bool {
 	return true
 }
 
+// ActivityMatches returns true if the pipeline activity of a build matches the owner, repository, branch and build filters
+func (o *BuildPodInfoFilter) ActivityMatches(activity *v1.PipelineActivity) bool {
+	d := kube.CreatePipelineDetails(activity)
+	if d == nil {
+		return false
+	}
+	if o.Owner != "" && o.Owner != d.GitOwner {
+		return false
+	}
+	if o.Repository != "" && o.Repository != d.GitRepository {
+		return false
+	}
+	if o.Branch != "" && strings.ToLower(o.Branch) != strings.ToLower(d.BranchName) {
+		return false
+	}
+	if o.Build != "" && o.Build != d.Build {
+		return false
+	}
+	if o.Filter != "" && !strings.Contains(activity.Name, o.Filter) {
+		return false
+	}
+	return true
+}
+
 // BuildNumber returns the integer build number filter if specified
 func (o *BuildPodInfoFilter) BuildNumber() int {
 	text := o.Build
 	if text != "" {
 		answer, err := strconv.Atoi(text)
-		if err != nil {
+		if err == nil {
 			return answer
 		}
 	}
@@ -2,11 +2,14 @@ package builds_test
 
 import (
 	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/builds"
+	"github.com/jenkins-x/jx/pkg/tekton"
 	"github.com/jenkins-x/jx/pkg/tests"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"path/filepath"
 	"testing"
 )
@@ -47,3 +50,116 @@ func AssertLoadPod(t *testing.T, fileName string) *corev1.Pod {
 	}
 	return nil
 }
+
+func TestBuildStepContainers(t *testing.T) {
+	t.Parallel()
+
+	pod := AssertLoadPod(t, filepath.Join("test_data", "pod1.yml"))
+	if pod != nil {
+		b := builds.CreateBuildPodInfo(pod)
+
+		steps := []string{}
+		for _, c := range b.StepContainers("") {
+			steps = append(steps, builds.StepName(&c))
+		}
+		assert.Equal(t, []string{"credential-initializer", "git-source", "jenkins"}, steps)
+
+		containers := b.StepContainers("git")
+		if assert.Len(t, containers, 1) {
+			assert.Equal(t, "build-step-git-source", containers[0].Name)
+			assert.True(t, builds.IsStepStarted(pod, containers[0].Name))
+		}
+		assert.Empty(t, b.StepContainers("does-not-exist"))
+		assert.False(t, builds.IsStepStarted(pod, "does-not-exist"))
+		assert.Equal(t, "", builds.FailedStep(pod))
+	}
+}
+
+func TestTektonBuildPod(t *testing.T) {
+	t.Parallel()
+
+	env := []corev1.EnvVar{
+		{Name: "REPO_OWNER", Value: "myorg"},
+		{Name: "REPO_NAME", Value: "myapp"},
+		{Name: "BRANCH_NAME", Value: "master"},
+		{Name: "JX_BUILD_NUMBER", Value: "3"},
+		{Name: "SOURCE_URL", Value: "https://github.com/myorg/myapp.git"},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "myorg-myapp-master-3-build-pod-abc",
+			Labels: map[string]string{
+				tekton.LabelPipelineRunName: "myorg-myapp-master-3",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "build-step-git-clone", Env: env},
+				{Name: "build-step-step1", Env: env},
+				{Name: "nop"},
+			},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "build-step-git-clone",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{ExitCode: 128},
+					},
+				},
+				{
+					Name: "build-step-step1",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"},
+					},
+				},
+				{Name: "nop"},
+			},
+		},
+	}
+	assert.Equal(t, "myorg-myapp-master-3", builds.BuildName(pod))
+	assert.True(t, builds.IsStepStarted(pod, "build-step-git-clone"))
+	assert.False(t, builds.IsStepStarted(pod, "build-step-step1"))
+	assert.False(t, builds.IsStepStarted(pod, "nop"))
+	assert.Equal(t, "build-step-git-clone", builds.FailedStep(pod))
+
+	statuses := builds.StepStatuses(pod)
+	if assert.Len(t, statuses, 2) {
+		assert.Equal(t, "build-step-git-clone", statuses[0].Name)
+		assert.Equal(t, "build-step-step1", statuses[1].Name)
+	}
+
+	b := builds.CreateBuildPodInfo(pod)
+	containers := b.StepContainers("")
+	if assert.Len(t, containers, 2) {
+		assert.Equal(t, "git-clone", builds.StepName(&containers[0]))
+		assert.Equal(t, "step1", builds.StepName(&containers[1]))
+	}
+	assert.Equal(t, "myorg-myapp-master-3", b.Name)
+	assert.Equal(t, "myorg/myapp/master", b.Pipeline)
+	assert.Equal(t, "3", b.Build)
+	assert.NotNil(t, b.GitInfo)
+}
+
+func TestBuildPodInfoFilterActivityMatches(t *testing.T) {
+	t.Parallel()
+
+	activity := &v1.PipelineActivity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "jstrachan-demo78-master-42",
+		},
+		Spec: v1.PipelineActivitySpec{
+			Pipeline:      "jstrachan/demo78/master",
+			GitOwner:      "jstrachan",
+			GitRepository: "demo78",
+			Build:         "42",
+		},
+	}
+
+	assert.True(t, (&builds.BuildPodInfoFilter{}).ActivityMatches(activity))
+	assert.True(t, (&builds.BuildPodInfoFilter{Owner: "jstrachan", Repository: "demo78", Branch: "Master", Build: "42"}).ActivityMatches(activity))
+	assert.False(t, (&builds.BuildPodInfoFilter{Build: "41"}).ActivityMatches(activity))
+	assert.False(t, (&builds.BuildPodInfoFilter{Branch: "PR-1"}).ActivityMatches(activity))
+	assert.False(t, (&builds.BuildPodInfoFilter{Repository: "cheese"}).ActivityMatches(activity))
+	assert.Equal(t, 42, (&builds.BuildPodInfoFilter{Build: "42"}).BuildNumber())
+}
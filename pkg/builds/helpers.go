@@ -1,12 +1,18 @@
 package builds
 
 import (
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/tekton"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 )
 
-// GetBuildPods returns all the build pods in the given namespace
+var buildResource = schema.GroupVersionResource{Group: "build.knative.dev", Version: "v1alpha1", Resource: "builds"}
+
+// GetBuildPods returns all the build pods in the given namespace including the pods of the tasks of Tekton pipeline runs
 func GetBuildPods(kubeClient kubernetes.Interface, ns string) ([]*corev1.Pod, error) {
 	answer := []*corev1.Pod{}
 	podList, err := kubeClient.CoreV1().Pods(ns).List(metav1.ListOptions{
@@ -24,9 +30,46 @@ func GetBuildPods(kubeClient kubernetes.Interface, ns string) ([]*corev1.Pod, er
 		}
 	}
 
+	tektonPodList, err := kubeClient.CoreV1().Pods(ns).List(metav1.ListOptions{
+		LabelSelector: tekton.LabelPipelineRunName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	podList.Items = append(podList.Items, tektonPodList.Items...)
+
 	for _, pod := range podList.Items {
 		copy := pod
 		answer = append(answer, &copy)
 	}
 	return answer, nil
 }
+
+// BuildName returns the name of the Knative build or Tekton pipeline run of a build pod or an empty string if the pod
+// is not a build pod
+func BuildName(pod *corev1.Pod) string {
+	labels := pod.Labels
+	for _, label := range []string{LabelBuildName, LabelOldBuildName, tekton.LabelPipelineRunName} {
+		if name := labels[label]; name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// RetryEvictedBuildPod runs the Knative Build or Tekton PipelineRun of an evicted build pod again, returning the name
+// of the new build or an empty string if the pod is not a build pod or its build was already retried maxRetries times
+func RetryEvictedBuildPod(dynamicClient dynamic.Interface, pod *corev1.Pod, maxRetries int) (string, error) {
+	labels := pod.Labels
+	if name := labels[tekton.LabelPipelineRunName]; name != "" {
+		return tekton.RetryPipelineRun(dynamicClient, pod.Namespace, name, maxRetries)
+	}
+	name := labels[LabelBuildName]
+	if name == "" {
+		name = labels[LabelOldBuildName]
+	}
+	if name == "" {
+		return "", nil
+	}
+	return kube.RetryEvictedResource(dynamicClient.Resource(buildResource).Namespace(pod.Namespace), name, maxRetries)
+}
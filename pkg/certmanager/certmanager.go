@@ -9,7 +9,8 @@ import (
 
 // CopyCertmanagerResources copies certmanager resources to the targetNamespace
 func CopyCertmanagerResources(targetNamespace string, ic kube.IngressConfig, kubeClient kubernetes.Interface) error {
-	if ic.TLS {
+	// ClusterIssuers are cluster wide so there is nothing to copy
+	if ic.TLS && !ic.ClusterIssuer {
 		err := kube.CleanCertmanagerResources(kubeClient, targetNamespace, ic)
 		if err != nil {
 			return fmt.Errorf("failed to create certmanager resources in target namespace %s: %v", targetNamespace, err)
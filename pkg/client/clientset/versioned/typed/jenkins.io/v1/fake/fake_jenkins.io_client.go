@@ -40,6 +40,10 @@ func (c *FakeJenkinsV1) GitServices(namespace string) v1.GitServiceInterface {
 	return &FakeGitServices{c, namespace}
 }
 
+func (c *FakeJenkinsV1) ImageScans(namespace string) v1.ImageScanInterface {
+	return &FakeImageScans{c, namespace}
+}
+
 func (c *FakeJenkinsV1) PipelineActivities(namespace string) v1.PipelineActivityInterface {
 	return &FakePipelineActivities{c, namespace}
 }
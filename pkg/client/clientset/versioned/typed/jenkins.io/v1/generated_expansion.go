@@ -16,6 +16,8 @@ type ExtensionExpansion interface{}
 
 type GitServiceExpansion interface{}
 
+type ImageScanExpansion interface{}
+
 type PipelineActivityExpansion interface{}
 
 type PluginExpansion interface{}
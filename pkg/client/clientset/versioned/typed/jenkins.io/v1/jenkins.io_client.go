@@ -18,6 +18,7 @@ type JenkinsV1Interface interface {
 	EnvironmentRoleBindingsGetter
 	ExtensionsGetter
 	GitServicesGetter
+	ImageScansGetter
 	PipelineActivitiesGetter
 	PluginsGetter
 	ReleasesGetter
@@ -59,6 +60,10 @@ func (c *JenkinsV1Client) GitServices(namespace string) GitServiceInterface {
 	return newGitServices(c, namespace)
 }
 
+func (c *JenkinsV1Client) ImageScans(namespace string) ImageScanInterface {
+	return newImageScans(c, namespace)
+}
+
 func (c *JenkinsV1Client) PipelineActivities(namespace string) PipelineActivityInterface {
 	return newPipelineActivities(c, namespace)
 }
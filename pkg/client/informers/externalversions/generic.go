@@ -51,6 +51,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Jenkins().V1().Extensions().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("gitservices"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Jenkins().V1().GitServices().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("imagescans"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Jenkins().V1().ImageScans().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("pipelineactivities"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Jenkins().V1().PipelineActivities().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("plugins"):
@@ -22,6 +22,8 @@ type Interface interface {
 	Extensions() ExtensionInformer
 	// GitServices returns a GitServiceInformer.
 	GitServices() GitServiceInformer
+	// ImageScans returns a ImageScanInformer.
+	ImageScans() ImageScanInformer
 	// PipelineActivities returns a PipelineActivityInformer.
 	PipelineActivities() PipelineActivityInformer
 	// Plugins returns a PluginInformer.
@@ -82,6 +84,11 @@ func (v *version) GitServices() GitServiceInformer {
 	return &gitServiceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ImageScans returns a ImageScanInformer.
+func (v *version) ImageScans() ImageScanInformer {
+	return &imageScanInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // PipelineActivities returns a PipelineActivityInformer.
 func (v *version) PipelineActivities() PipelineActivityInformer {
 	return &pipelineActivityInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
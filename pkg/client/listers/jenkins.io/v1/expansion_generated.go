@@ -58,6 +58,14 @@ type GitServiceListerExpansion interface{}
 // GitServiceNamespaceLister.
 type GitServiceNamespaceListerExpansion interface{}
 
+// ImageScanListerExpansion allows custom methods to be added to
+// ImageScanLister.
+type ImageScanListerExpansion interface{}
+
+// ImageScanNamespaceListerExpansion allows custom methods to be added to
+// ImageScanNamespaceLister.
+type ImageScanNamespaceListerExpansion interface{}
+
 // PipelineActivityListerExpansion allows custom methods to be added to
 // PipelineActivityLister.
 type PipelineActivityListerExpansion interface{}
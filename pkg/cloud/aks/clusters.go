@@ -0,0 +1,49 @@
+package aks
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// Cluster an AKS cluster as returned by 'az aks list'
+type Cluster struct {
+	Name              string      `json:"name"`
+	ResourceGroup     string      `json:"resourceGroup"`
+	Location          string      `json:"location"`
+	KubernetesVersion string      `json:"kubernetesVersion"`
+	ProvisioningState string      `json:"provisioningState"`
+	AgentPoolProfiles []AgentPool `json:"agentPoolProfiles"`
+}
+
+// AgentPool a pool of nodes of an AKS cluster
+type AgentPool struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// NodeCount returns the number of nodes in all the agent pools of the cluster
+func (c *Cluster) NodeCount() int {
+	answer := 0
+	for _, pool := range c.AgentPoolProfiles {
+		answer += pool.Count
+	}
+	return answer
+}
+
+// ListClusters returns the AKS clusters of the current subscription
+func (az *AzureRunner) ListClusters() ([]Cluster, error) {
+	output, err := az.azureCLI("aks", "list", "--output", "json")
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing the AKS clusters: %s", output)
+	}
+	clusters := []Cluster{}
+	if output == "" {
+		return clusters, nil
+	}
+	err = json.Unmarshal([]byte(output), &clusters)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing the AKS clusters")
+	}
+	return clusters, nil
+}
@@ -0,0 +1,35 @@
+package aks_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListClusters(t *testing.T) {
+	azureCLI := aksWithRunner(t, nil, `[
+		{
+			"name": "cheese",
+			"resourceGroup": "dairy",
+			"location": "westeurope",
+			"kubernetesVersion": "1.12.5",
+			"provisioningState": "Succeeded",
+			"agentPoolProfiles": [
+				{"name": "nodepool1", "count": 3},
+				{"name": "nodepool2", "count": 2}
+			]
+		}
+	]`)
+	clusters, err := azureCLI.ListClusters()
+	require.NoError(t, err)
+	require.Len(t, clusters, 1)
+
+	cluster := clusters[0]
+	assert.Equal(t, "cheese", cluster.Name)
+	assert.Equal(t, "dairy", cluster.ResourceGroup)
+	assert.Equal(t, "westeurope", cluster.Location)
+	assert.Equal(t, "1.12.5", cluster.KubernetesVersion)
+	assert.Equal(t, "Succeeded", cluster.ProvisioningState)
+	assert.Equal(t, 5, cluster.NodeCount())
+}
@@ -0,0 +1,89 @@
+package amazon
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/pkg/errors"
+)
+
+// EksCluster an EKS cluster and the number of its running nodes
+type EksCluster struct {
+	Name    string
+	Region  string
+	Version string
+	Status  string
+	Nodes   int
+}
+
+// ListEksClusters returns the EKS clusters of the account in the region
+func ListEksClusters(profile string, region string) ([]EksCluster, error) {
+	region, err := ResolveRegion(profile, region)
+	if err != nil {
+		return nil, err
+	}
+	session, err := NewAwsSession(profile, region)
+	if err != nil {
+		return nil, err
+	}
+	eksService := eks.New(session)
+	ec2Service := ec2.New(session)
+	names := []*string{}
+	input := &eks.ListClustersInput{}
+	for {
+		list, err := eksService.ListClusters(input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing the EKS clusters in region %s", region)
+		}
+		names = append(names, list.Clusters...)
+		if aws.StringValue(list.NextToken) == "" {
+			break
+		}
+		input.NextToken = list.NextToken
+	}
+	answer := []EksCluster{}
+	for _, name := range names {
+		cluster := EksCluster{
+			Name:   aws.StringValue(name),
+			Region: region,
+		}
+		description, err := eksService.DescribeCluster(&eks.DescribeClusterInput{Name: name})
+		if err != nil {
+			return nil, errors.Wrapf(err, "describing the EKS cluster %s", cluster.Name)
+		}
+		if description.Cluster != nil {
+			cluster.Version = aws.StringValue(description.Cluster.Version)
+			cluster.Status = aws.StringValue(description.Cluster.Status)
+		}
+		cluster.Nodes, err = countEksNodes(ec2Service, cluster.Name)
+		if err != nil {
+			return nil, err
+		}
+		answer = append(answer, cluster)
+	}
+	return answer, nil
+}
+
+// countEksNodes counts the running EC2 instances which are tagged as belonging to the cluster
+func countEksNodes(ec2Service *ec2.EC2, clusterName string) (int, error) {
+	instances, err := ec2Service.DescribeInstances(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("tag-key"),
+				Values: []*string{aws.String("kubernetes.io/cluster/" + clusterName)},
+			},
+			{
+				Name:   aws.String("instance-state-name"),
+				Values: []*string{aws.String("running")},
+			},
+		},
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "listing the nodes of the EKS cluster %s", clusterName)
+	}
+	answer := 0
+	for _, reservation := range instances.Reservations {
+		answer += len(reservation.Instances)
+	}
+	return answer, nil
+}
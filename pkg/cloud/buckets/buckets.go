@@ -0,0 +1,208 @@
+package buckets
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/jenkins-x/jx/pkg/cloud/amazon"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SchemeGCS the URL scheme of Google Cloud Storage buckets
+	SchemeGCS = "gs"
+	// SchemeS3 the URL scheme of AWS S3 buckets or S3 compatible buckets such as MinIO
+	SchemeS3 = "s3"
+
+	// EndpointParameter the query parameter of an S3 bucket URL for the endpoint of an S3 compatible server such as MinIO
+	EndpointParameter = "endpoint"
+	// RegionParameter the query parameter of an S3 bucket URL for the region of the bucket
+	RegionParameter = "region"
+)
+
+// BucketLocation is a parsed bucket URL of the form scheme://bucket/prefix such as
+// gs://mybucket/logs, s3://mybucket or s3://mybucket?endpoint=http://minio.jx.svc:9000 for MinIO
+type BucketLocation struct {
+	Scheme   string
+	Bucket   string
+	Prefix   string
+	Endpoint string
+	Region   string
+}
+
+// IsBucketURL returns true if the URL refers to a supported storage bucket
+func IsBucketURL(text string) bool {
+	return strings.HasPrefix(text, SchemeGCS+"://") || strings.HasPrefix(text, SchemeS3+"://")
+}
+
+// ParseBucketURL parses the bucket URL
+func ParseBucketURL(bucketURL string) (*BucketLocation, error) {
+	u, err := url.Parse(bucketURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing the bucket URL %s", bucketURL)
+	}
+	if u.Scheme != SchemeGCS && u.Scheme != SchemeS3 {
+		return nil, fmt.Errorf("unsupported scheme %s of bucket URL %s, supported schemes are %s:// and %s://", u.Scheme, bucketURL, SchemeGCS, SchemeS3)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("no bucket name in bucket URL %s", bucketURL)
+	}
+	query := u.Query()
+	return &BucketLocation{
+		Scheme:   u.Scheme,
+		Bucket:   u.Host,
+		Prefix:   strings.Trim(u.Path, "/"),
+		Endpoint: query.Get(EndpointParameter),
+		Region:   query.Get(RegionParameter),
+	}, nil
+}
+
+// Key returns the key of the object for the given path within the bucket prefix
+func (l *BucketLocation) Key(path string) string {
+	path = strings.TrimPrefix(path, "/")
+	if l.Prefix == "" {
+		return path
+	}
+	return l.Prefix + "/" + path
+}
+
+// ObjectURL returns the URL of the object for the given path which can be read via ReadURL
+func (l *BucketLocation) ObjectURL(path string) string {
+	answer := l.Scheme + "://" + l.Bucket + "/" + l.Key(path)
+	query := url.Values{}
+	if l.Endpoint != "" {
+		query.Set(EndpointParameter, l.Endpoint)
+	}
+	if l.Region != "" {
+		query.Set(RegionParameter, l.Region)
+	}
+	if len(query) > 0 {
+		answer += "?" + query.Encode()
+	}
+	return answer
+}
+
+// WriteFile writes the data to the path in the bucket returning the URL of the object
+func WriteFile(bucketURL string, path string, data []byte) (string, error) {
+	location, err := ParseBucketURL(bucketURL)
+	if err != nil {
+		return "", err
+	}
+	key := location.Key(path)
+	switch location.Scheme {
+	case SchemeGCS:
+		err = writeGCSFile(location.Bucket, key, data)
+	default:
+		err = writeS3File(location, key, data)
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "writing %s to bucket %s", key, bucketURL)
+	}
+	return location.ObjectURL(path), nil
+}
+
+// ReadURL reads the object at the given URL which was returned from WriteFile
+func ReadURL(objectURL string) ([]byte, error) {
+	location, err := ParseBucketURL(objectURL)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	switch location.Scheme {
+	case SchemeGCS:
+		data, err = readGCSFile(location.Bucket, location.Prefix)
+	default:
+		data, err = readS3File(location, location.Prefix)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s", objectURL)
+	}
+	return data, nil
+}
+
+func writeGCSFile(bucket string, key string, data []byte) error {
+	tmpFile, err := ioutil.TempFile("", "jx-bucket-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	_, err = tmpFile.Write(data)
+	tmpFile.Close()
+	if err != nil {
+		return err
+	}
+	cmd := util.Command{
+		Name: "gsutil",
+		Args: []string{"cp", tmpFile.Name(), fmt.Sprintf("gs://%s/%s", bucket, key)},
+	}
+	_, err = cmd.RunWithoutRetry()
+	return err
+}
+
+func readGCSFile(bucket string, key string) ([]byte, error) {
+	tmpFile, err := ioutil.TempFile("", "jx-bucket-")
+	if err != nil {
+		return nil, err
+	}
+	tmpFile.Close()
+	defer os.Remove(tmpFile.Name())
+	cmd := util.Command{
+		Name: "gsutil",
+		Args: []string{"cp", fmt.Sprintf("gs://%s/%s", bucket, key), tmpFile.Name()},
+	}
+	_, err = cmd.RunWithoutRetry()
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadFile(tmpFile.Name())
+}
+
+func createS3Client(location *BucketLocation) (*s3.S3, error) {
+	sess, err := amazon.NewAwsSession("", location.Region)
+	if err != nil {
+		return nil, err
+	}
+	config := &aws.Config{}
+	if location.Endpoint != "" {
+		// S3 compatible servers like MinIO do not support virtual hosted buckets
+		config.Endpoint = aws.String(location.Endpoint)
+		config.S3ForcePathStyle = aws.Bool(true)
+	}
+	return s3.New(sess, config), nil
+}
+
+func writeS3File(location *BucketLocation, key string, data []byte) error {
+	svc, err := createS3Client(location)
+	if err != nil {
+		return err
+	}
+	_, err = svc.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(location.Bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(data),
+	})
+	return err
+}
+
+func readS3File(location *BucketLocation, key string) ([]byte, error) {
+	svc, err := createS3Client(location)
+	if err != nil {
+		return nil, err
+	}
+	output, err := svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(location.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer output.Body.Close()
+	return ioutil.ReadAll(output.Body)
+}
@@ -0,0 +1,41 @@
+package buckets_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/cloud/buckets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBucketURL(t *testing.T) {
+	t.Parallel()
+
+	location, err := buckets.ParseBucketURL("gs://my-logs/jx/")
+	require.NoError(t, err)
+	assert.Equal(t, "gs", location.Scheme)
+	assert.Equal(t, "my-logs", location.Bucket)
+	assert.Equal(t, "jx", location.Prefix)
+	assert.Equal(t, "gs://my-logs/jx/jenkins-x/logs/myorg/myrepo/master/42.log", location.ObjectURL("jenkins-x/logs/myorg/myrepo/master/42.log"))
+
+	location, err = buckets.ParseBucketURL("s3://my-logs?endpoint=http://minio.jx.svc:9000&region=us-east-1")
+	require.NoError(t, err)
+	assert.Equal(t, "s3", location.Scheme)
+	assert.Equal(t, "my-logs", location.Bucket)
+	assert.Equal(t, "", location.Prefix)
+	assert.Equal(t, "http://minio.jx.svc:9000", location.Endpoint)
+	assert.Equal(t, "us-east-1", location.Region)
+	assert.Equal(t, "jenkins-x/logs/42.log", location.Key("jenkins-x/logs/42.log"))
+
+	objectURL := location.ObjectURL("jenkins-x/logs/42.log")
+	assert.True(t, buckets.IsBucketURL(objectURL))
+	object, err := buckets.ParseBucketURL(objectURL)
+	require.NoError(t, err)
+	assert.Equal(t, "jenkins-x/logs/42.log", object.Prefix)
+	assert.Equal(t, location.Endpoint, object.Endpoint)
+
+	_, err = buckets.ParseBucketURL("https://github.com/myorg/mylogs.git")
+	assert.Error(t, err)
+	_, err = buckets.ParseBucketURL("s3:///logs")
+	assert.Error(t, err)
+}
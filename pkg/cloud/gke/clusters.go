@@ -0,0 +1,56 @@
+package gke
+
+import (
+	"encoding/json"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// Cluster a GKE cluster as returned by 'gcloud container clusters list'
+type Cluster struct {
+	Name                 string `json:"name"`
+	Location             string `json:"location"`
+	Zone                 string `json:"zone"`
+	CurrentMasterVersion string `json:"currentMasterVersion"`
+	CurrentNodeCount     int    `json:"currentNodeCount"`
+	Status               string `json:"status"`
+}
+
+// ClusterLocation returns the region or zone of the cluster
+func (c *Cluster) ClusterLocation() string {
+	if c.Location != "" {
+		return c.Location
+	}
+	return c.Zone
+}
+
+// ListClusters returns all the GKE clusters of the given project or of the current project if none is given
+func ListClusters(projectID string) ([]Cluster, error) {
+	args := []string{"container", "clusters", "list", "--format", "json"}
+	if projectID != "" {
+		args = append(args, "--project", projectID)
+	}
+	cmd := &util.CommandSpec{
+		Name: "gcloud",
+		Args: args,
+	}
+	output, err := runCommand(cmd)
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing the GKE clusters: %s", output)
+	}
+	return ParseClusters(output)
+}
+
+// ParseClusters parses the JSON output of 'gcloud container clusters list'
+func ParseClusters(output string) ([]Cluster, error) {
+	clusters := []Cluster{}
+	if output == "" {
+		return clusters, nil
+	}
+	err := json.Unmarshal([]byte(output), &clusters)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing the GKE clusters")
+	}
+	return clusters, nil
+}
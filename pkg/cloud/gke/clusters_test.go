@@ -0,0 +1,42 @@
+package gke
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClusters(t *testing.T) {
+	t.Parallel()
+	clusters, err := ParseClusters(`[
+  {
+    "name": "zonal",
+    "zone": "europe-west1-b",
+    "currentMasterVersion": "1.11.7-gke.4",
+    "currentNodeCount": 3,
+    "status": "RUNNING"
+  },
+  {
+    "name": "regional",
+    "location": "europe-west1",
+    "zone": "europe-west1",
+    "currentMasterVersion": "1.12.5-gke.5",
+    "currentNodeCount": 9,
+    "status": "PROVISIONING"
+  }
+]`)
+	require.NoError(t, err)
+	require.Len(t, clusters, 2)
+
+	assert.Equal(t, "zonal", clusters[0].Name)
+	assert.Equal(t, "europe-west1-b", clusters[0].ClusterLocation())
+	assert.Equal(t, "1.11.7-gke.4", clusters[0].CurrentMasterVersion)
+	assert.Equal(t, 3, clusters[0].CurrentNodeCount)
+	assert.Equal(t, "RUNNING", clusters[0].Status)
+	assert.Equal(t, "europe-west1", clusters[1].ClusterLocation())
+
+	clusters, err = ParseClusters("")
+	require.NoError(t, err)
+	assert.Empty(t, clusters)
+}
@@ -0,0 +1,118 @@
+package gke
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// ManagedZoneName returns the name of the Cloud DNS managed zone used for the given domain
+func ManagedZoneName(domain string) string {
+	return strings.Replace(strings.TrimSuffix(domain, "."), ".", "-", -1)
+}
+
+// GetCurrentProject returns the current project of the gcloud configuration
+func GetCurrentProject() (string, error) {
+	cmd := &util.CommandSpec{
+		Name: "gcloud",
+		Args: []string{"config", "get-value", "project"},
+	}
+	out, err := runCommand(cmd)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// CreateManagedZone creates the Cloud DNS managed zone for the given domain if it does not exist yet
+func CreateManagedZone(projectID string, domain string) error {
+	zone := ManagedZoneName(domain)
+	cmd := &util.CommandSpec{
+		Name: "gcloud",
+		Args: []string{"dns", "managed-zones", "describe", zone, "--project", projectID},
+	}
+	_, err := runCommand(cmd)
+	if err == nil {
+		return nil
+	}
+
+	log.Infof("Creating Cloud DNS managed zone %s for domain %s\n", util.ColorInfo(zone), util.ColorInfo(domain))
+	cmd = &util.CommandSpec{
+		Name: "gcloud",
+		Args: []string{"dns", "managed-zones", "create", zone,
+			"--dns-name", domain + ".",
+			"--description", "Jenkins X managed zone for " + domain,
+			"--project", projectID},
+	}
+	_, err = runCommand(cmd)
+	if err != nil {
+		return errors.Wrapf(err, "creating managed zone %s", zone)
+	}
+	return nil
+}
+
+// GetManagedZoneNameServers returns the name servers of the managed zone of the given domain
+func GetManagedZoneNameServers(projectID string, domain string) ([]string, error) {
+	cmd := &util.CommandSpec{
+		Name: "gcloud",
+		Args: []string{"dns", "managed-zones", "describe", ManagedZoneName(domain),
+			"--project", projectID, "--format", "value(nameServers)"},
+	}
+	out, err := runCommand(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return strings.FieldsFunc(strings.TrimSpace(out), func(r rune) bool {
+		return r == ';' || r == ','
+	}), nil
+}
+
+// UpsertWildcardRecord creates or replaces the wildcard A record of the domain so that it points at the given address
+func UpsertWildcardRecord(projectID string, domain string, address string) error {
+	zone := ManagedZoneName(domain)
+	wildcard := "*." + domain + "."
+	zoneArgs := []string{"--zone", zone, "--project", projectID}
+
+	existing := &util.CommandSpec{
+		Name: "gcloud",
+		Args: append([]string{"dns", "record-sets", "list", "--name", wildcard, "--type", "A", "--format", "value(rrdatas[0])"}, zoneArgs...),
+	}
+	out, err := runCommand(existing)
+	old := strings.TrimSpace(out)
+	if err == nil && old == address {
+		return nil
+	}
+
+	log.Infof("Pointing DNS record %s at %s\n", util.ColorInfo(wildcard), util.ColorInfo(address))
+	steps := [][]string{{"dns", "record-sets", "transaction", "start"}}
+	if err == nil && old != "" {
+		steps = append(steps, []string{"dns", "record-sets", "transaction", "remove", old, "--name", wildcard, "--ttl", "300", "--type", "A"})
+	}
+	steps = append(steps,
+		[]string{"dns", "record-sets", "transaction", "add", address, "--name", wildcard, "--ttl", "300", "--type", "A"},
+		[]string{"dns", "record-sets", "transaction", "execute"})
+
+	// the transaction is stored in the working directory so lets use a clean one
+	dir, err := ioutil.TempDir("", "jx-dns-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	for _, step := range steps {
+		cmd := &util.CommandSpec{
+			Dir:  dir,
+			Name: "gcloud",
+			Args: append(step, zoneArgs...),
+		}
+		_, err := runCommand(cmd)
+		if err != nil {
+			return errors.Wrapf(err, "updating record %s in zone %s", wildcard, zone)
+		}
+	}
+	return nil
+}
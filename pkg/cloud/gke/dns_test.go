@@ -0,0 +1,35 @@
+package gke
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagedZoneName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "jx-acme-com", ManagedZoneName("jx.acme.com"))
+	assert.Equal(t, "acme-com", ManagedZoneName("acme.com."))
+}
+
+func TestUpsertWildcardRecordReplacesTheOldAddress(t *testing.T) {
+	runner := util.NewFakeCommandRunner()
+	runner.Outputs["gcloud dns record-sets list"] = "1.2.3.4"
+	SetCommandRunner(runner)
+	defer SetCommandRunner(util.NewCommandRunner())
+
+	err := UpsertWildcardRecord("cheese", "jx.acme.com", "5.6.7.8")
+	require.NoError(t, err)
+
+	zoneArgs := " --zone jx-acme-com --project cheese"
+	expected := []string{
+		"gcloud dns record-sets list --name *.jx.acme.com. --type A --format value(rrdatas[0])" + zoneArgs,
+		"gcloud dns record-sets transaction start" + zoneArgs,
+		"gcloud dns record-sets transaction remove 1.2.3.4 --name *.jx.acme.com. --ttl 300 --type A" + zoneArgs,
+		"gcloud dns record-sets transaction add 5.6.7.8 --name *.jx.acme.com. --ttl 300 --type A" + zoneArgs,
+		"gcloud dns record-sets transaction execute" + zoneArgs,
+	}
+	assert.Equal(t, expected, runner.CommandLines())
+}
@@ -27,8 +27,21 @@ var (
 		"roles/container.developer",
 		"roles/storage.objectAdmin",
 		"roles/editor"}
+
+	// commandRunner runs the gcloud and gsutil commands
+	commandRunner = util.NewCommandRunner()
 )
 
+// SetCommandRunner configures the runner of the gcloud and gsutil commands such as a util.FakeCommandRunner in tests
+func SetCommandRunner(runner util.CommandRunner) {
+	commandRunner = runner
+}
+
+// runCommand runs the command via the CommandRunner so that it is cancelled when jx is interrupted
+func runCommand(cmd *util.CommandSpec) (string, error) {
+	return commandRunner.Run(util.InterruptContext(), cmd)
+}
+
 // ClusterName gets the cluster name from the current context
 // Note that this just reads the ClusterName from the local kube config, which can be renamed (but is unlikely to happen)
 func ClusterName(kuber kube.Kuber) (string, error) {
@@ -72,11 +85,11 @@ func BucketExists(projectID string, bucketName string) (bool, error) {
 		args = append(args, projectID)
 	}
 
-	cmd := util.Command{
+	cmd := &util.CommandSpec{
 		Name: "gsutil",
 		Args: args,
 	}
-	output, err := cmd.RunWithoutRetry()
+	output, err := runCommand(cmd)
 	if err != nil {
 		log.Infof("Error checking bucket exists: %s, %s\n", output, err)
 		return false, err
@@ -96,11 +109,11 @@ func CreateBucket(projectID string, bucketName string, location string) error {
 
 	args = append(args, fullBucketName)
 
-	cmd := util.Command{
+	cmd := &util.CommandSpec{
 		Name: "gsutil",
 		Args: args,
 	}
-	output, err := cmd.RunWithoutRetry()
+	output, err := runCommand(cmd)
 	if err != nil {
 		log.Infof("Error creating bucket: %s, %s\n", output, err)
 		return err
@@ -113,11 +126,11 @@ func FindBucket(bucketName string) bool {
 	fullBucketName := fmt.Sprintf("gs://%s", bucketName)
 	args := []string{"list", "-b", fullBucketName}
 
-	cmd := util.Command{
+	cmd := &util.CommandSpec{
 		Name: "gsutil",
 		Args: args,
 	}
-	_, err := cmd.RunWithoutRetry()
+	_, err := runCommand(cmd)
 	if err != nil {
 		return false
 	}
@@ -133,11 +146,11 @@ func DeleteAllObjectsInBucket(bucketName string) error {
 	fullBucketName := fmt.Sprintf("gs://%s", bucketName)
 	args := []string{"-m", "rm", "-r", fullBucketName}
 
-	cmd := util.Command{
+	cmd := &util.CommandSpec{
 		Name: "gsutil",
 		Args: args,
 	}
-	_, err := cmd.RunWithoutRetry()
+	_, err := runCommand(cmd)
 	if err != nil {
 		return err
 	}
@@ -153,11 +166,11 @@ func DeleteBucket(bucketName string) error {
 	fullBucketName := fmt.Sprintf("gs://%s", bucketName)
 	args := []string{"rb", fullBucketName}
 
-	cmd := util.Command{
+	cmd := &util.CommandSpec{
 		Name: "gsutil",
 		Args: args,
 	}
-	_, err := cmd.RunWithoutRetry()
+	_, err := runCommand(cmd)
 	if err != nil {
 		return err
 	}
@@ -179,11 +192,16 @@ func FindServiceAccount(serviceAccount string, projectID string) bool {
 		"--project",
 		projectID}
 
-	cmd := util.Command{
+	cmd := &util.CommandSpec{
 		Name: "gcloud",
 		Args: args,
 	}
-	output, err := cmd.RunWithoutRetry()
+	var output string
+	err := util.RetryWithOptions(util.CloudRetryOptions("listing service accounts"), func() error {
+		var err error
+		output, err = runCommand(cmd)
+		return err
+	})
 	if err != nil {
 		return false
 	}
@@ -224,11 +242,11 @@ func GetOrCreateServiceAccount(serviceAccount string, projectID string, clusterC
 			"--project",
 			projectID}
 
-		cmd := util.Command{
+		cmd := &util.CommandSpec{
 			Name: "gcloud",
 			Args: args,
 		}
-		_, err = cmd.RunWithoutRetry()
+		_, err = runCommand(cmd)
 		if err != nil {
 			return "", err
 		}
@@ -246,11 +264,21 @@ func GetOrCreateServiceAccount(serviceAccount string, projectID string, clusterC
 				"--project",
 				projectID}
 
-			cmd := util.Command{
+			cmd := &util.CommandSpec{
 				Name: "gcloud",
 				Args: args,
 			}
-			_, err := cmd.RunWithoutRetry()
+			// the service account which has just been created may not be visible to IAM yet so lets retry it not
+			// existing as well as the transient errors
+			retryOptions := util.CloudRetryOptions("assigning role " + role)
+			retryOptions.Retryable = func(err error) bool {
+				message := err.Error()
+				return util.IsTransientCloudError(err) || (strings.Contains(message, serviceAccount+"@") && strings.Contains(message, "does not exist"))
+			}
+			err := util.RetryWithOptions(retryOptions, func() error {
+				_, err := runCommand(cmd)
+				return err
+			})
 			if err != nil {
 				return "", err
 			}
@@ -297,11 +325,11 @@ func CreateServiceAccountKey(serviceAccount string, projectID string, keyPath st
 		"--project",
 		projectID}
 
-	cmd := util.Command{
+	cmd := &util.CommandSpec{
 		Name: "gcloud",
 		Args: args,
 	}
-	_, err := cmd.RunWithoutRetry()
+	_, err := runCommand(cmd)
 	if err != nil {
 		return errors.Wrap(err, "creating a new service account key")
 	}
@@ -320,11 +348,11 @@ func GetServiceAccountKeys(serviceAccount string, projectID string) ([]string, e
 		account,
 		"--project",
 		projectID}
-	cmd := util.Command{
+	cmd := &util.CommandSpec{
 		Name: "gcloud",
 		Args: args,
 	}
-	output, err := cmd.RunWithoutRetry()
+	output, err := runCommand(cmd)
 	if err != nil {
 		return keys, errors.Wrapf(err, "listing the keys of the service account '%s'", account)
 	}
@@ -354,11 +382,11 @@ func DeleteServiceAccountKey(serviceAccount string, projectID string, key string
 		"--project",
 		projectID,
 		"--quiet"}
-	cmd := util.Command{
+	cmd := &util.CommandSpec{
 		Name: "gcloud",
 		Args: args,
 	}
-	_, err := cmd.RunWithoutRetry()
+	_, err := runCommand(cmd)
 	if err != nil {
 		return errors.Wrapf(err, "deleting the key '%s'from service account '%s'", key, account)
 	}
@@ -406,11 +434,11 @@ func DeleteServiceAccount(serviceAccount string, projectID string, roles []strin
 			"--project",
 			projectID}
 
-		cmd := util.Command{
+		cmd := &util.CommandSpec{
 			Name: "gcloud",
 			Args: args,
 		}
-		_, err := cmd.RunWithoutRetry()
+		_, err := runCommand(cmd)
 		if err != nil {
 			return err
 		}
@@ -422,11 +450,11 @@ func DeleteServiceAccount(serviceAccount string, projectID string, roles []strin
 		"--project",
 		projectID}
 
-	cmd := util.Command{
+	cmd := &util.CommandSpec{
 		Name: "gcloud",
 		Args: args,
 	}
-	_, err := cmd.RunWithoutRetry()
+	_, err := runCommand(cmd)
 	if err != nil {
 		return err
 	}
@@ -444,12 +472,12 @@ func GetEnabledApis(projectID string) ([]string, error) {
 
 	apis := []string{}
 
-	cmd := util.Command{
+	cmd := &util.CommandSpec{
 		Name: "gcloud",
 		Args: args,
 	}
 
-	out, err := cmd.RunWithoutRetry()
+	out, err := runCommand(cmd)
 	if err != nil {
 		return nil, err
 	}
@@ -494,13 +522,13 @@ func EnableAPIs(projectID string, apis ...string) error {
 		args = append(args, projectID)
 	}
 
-	log.Infof("Lets ensure we have container and compute enabled on your project via: %s\n", util.ColorInfo("gcloud "+strings.Join(args, " ")))
+	log.Infof("Lets ensure we have container and compute enabled on your project via: %s\n", util.ColorInfo("gcloud "+strings.Join(util.RedactArgs(args), " ")))
 
-	cmd := util.Command{
+	cmd := &util.CommandSpec{
 		Name: "gcloud",
 		Args: args,
 	}
-	_, err = cmd.RunWithoutRetry()
+	_, err = runCommand(cmd)
 	if err != nil {
 		return err
 	}
@@ -517,17 +545,21 @@ func Login(serviceAccountKeyPath string, skipLogin bool) error {
 			return errors.New("Unable to locate service account " + serviceAccountKeyPath)
 		}
 
-		cmd := util.Command{
+		cmd := &util.CommandSpec{
 			Name: "gcloud",
 			Args: []string{"auth", "activate-service-account", "--key-file", serviceAccountKeyPath},
 		}
-		_, err := cmd.RunWithoutRetry()
+		_, err := runCommand(cmd)
 		if err != nil {
 			return err
 		}
 
 		// GCP IAM changes can take up to 80 seconds to propagate
-		retry(10, 10*time.Second, func() error {
+		util.RetryWithOptions(util.RetryOptions{
+			Attempts:        10,
+			InitialInterval: 10 * time.Second,
+			MaxInterval:     30 * time.Second,
+		}, func() error {
 			log.Infof("Checking for readiness...\n")
 
 			projects, err := GetGoogleProjects()
@@ -543,11 +575,11 @@ func Login(serviceAccountKeyPath string, skipLogin bool) error {
 		})
 
 	} else if !skipLogin {
-		cmd := util.Command{
+		cmd := &util.CommandSpec{
 			Name: "gcloud",
 			Args: []string{"auth", "login", "--brief"},
 		}
-		_, err := cmd.RunWithoutRetry()
+		_, err := runCommand(cmd)
 		if err != nil {
 			return err
 		}
@@ -555,26 +587,6 @@ func Login(serviceAccountKeyPath string, skipLogin bool) error {
 	return nil
 }
 
-func retry(attempts int, sleep time.Duration, fn func() error) error {
-	if err := fn(); err != nil {
-		if s, ok := err.(stop); ok {
-			// Return the original error for later checking
-			return s.error
-		}
-
-		if attempts--; attempts > 0 {
-			time.Sleep(sleep)
-			return retry(attempts, 2*sleep, fn)
-		}
-		return err
-	}
-	return nil
-}
-
-type stop struct {
-	error
-}
-
 // CheckPermission checks permission on the given project
 func CheckPermission(perm string, projectID string) (bool, error) {
 	if projectID == "" {
@@ -587,11 +599,11 @@ func CheckPermission(perm string, projectID string) (bool, error) {
 		"--filter",
 		perm}
 
-	cmd := util.Command{
+	cmd := &util.CommandSpec{
 		Name: "gcloud",
 		Args: args,
 	}
-	output, err := cmd.RunWithoutRetry()
+	output, err := runCommand(cmd)
 	if err != nil {
 		return false, err
 	}
@@ -619,11 +631,11 @@ func CreateKmsKeyring(keyringName string, projectID string) error {
 		projectID,
 	}
 
-	cmd := util.Command{
+	cmd := &util.CommandSpec{
 		Name: "gcloud",
 		Args: args,
 	}
-	_, err := cmd.RunWithoutRetry()
+	_, err := runCommand(cmd)
 	if err != nil {
 		return errors.Wrap(err, "creating kms keyring")
 	}
@@ -642,11 +654,11 @@ func IsKmsKeyringAvailable(keyringName string, projectID string) bool {
 		projectID,
 	}
 
-	cmd := util.Command{
+	cmd := &util.CommandSpec{
 		Name: "gcloud",
 		Args: args,
 	}
-	_, err := cmd.RunWithoutRetry()
+	_, err := runCommand(cmd)
 	if err != nil {
 		return false
 	}
@@ -671,11 +683,11 @@ func CreateKmsKey(keyName string, keyringName string, projectID string) error {
 		"--project",
 		projectID,
 	}
-	cmd := util.Command{
+	cmd := &util.CommandSpec{
 		Name: "gcloud",
 		Args: args,
 	}
-	_, err := cmd.RunWithoutRetry()
+	_, err := runCommand(cmd)
 	if err != nil {
 		return errors.Wrapf(err, "creating kms key '%s' into keyring '%s'", keyName, keyringName)
 	}
@@ -696,11 +708,11 @@ func IsKmsKeyAvailable(keyName string, keyringName string, projectID string) boo
 		projectID,
 	}
 
-	cmd := util.Command{
+	cmd := &util.CommandSpec{
 		Name: "gcloud",
 		Args: args,
 	}
-	_, err := cmd.RunWithoutRetry()
+	_, err := runCommand(cmd)
 	if err != nil {
 		return false
 	}
@@ -0,0 +1,117 @@
+package gke
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+const (
+	// GPUTaintKey the key of the taint of the GPU nodes so that only pods tolerating it such as ML training steps
+	// are scheduled on them
+	GPUTaintKey = "nvidia.com/gpu"
+	// GPUTaintValue the value of the taint of the GPU nodes
+	GPUTaintValue = "present"
+	// GPUNodePoolName the name of the node pool with the GPUs
+	GPUNodePoolName = "gpu-pool"
+	// GPUNodePoolTerraformFile the file of the terraform of the GPU node pool in the terraform directory of the cluster
+	GPUNodePoolTerraformFile = "gpu-pool.tf"
+	// DefaultGPUMaxNodes the maximum number of nodes the GPU node pool autoscales up to
+	DefaultGPUMaxNodes = 2
+	// ClusterTerraformResource the cluster resource of the Jenkins X GKE terraform templates which the GPU node pool
+	// is added to
+	ClusterTerraformResource = "google_container_cluster.jx-cluster"
+)
+
+// GPUNodePool the node pool with GPUs added to a cluster
+type GPUNodePool struct {
+	GPUType  string
+	GPUCount int
+	MaxNodes int
+}
+
+// the node pool reuses the variables of the cluster and lives in the same terraform directory so that it shares the
+// state of the cluster
+const gpuNodePoolTerraform = `variable "gpu_type" {}
+variable "gpu_count" {}
+variable "gpu_max_nodes" {}
+
+provider "google-beta" {
+  credentials = "${file(var.credentials)}"
+  project     = "${var.gcp_project}"
+  zone        = "${var.gcp_zone}"
+}
+
+resource "google_container_node_pool" "{{ .Name }}" {
+  provider = "google-beta"
+  name     = "{{ .Name }}"
+  zone     = "${var.gcp_zone}"
+  cluster  = "{{ .Cluster }}"
+
+  initial_node_count = 0
+
+  autoscaling {
+    min_node_count = 0
+    max_node_count = "${var.gpu_max_nodes}"
+  }
+
+  node_config {
+    machine_type = "${var.node_machine_type}"
+
+    guest_accelerator {
+      type  = "${var.gpu_type}"
+      count = "${var.gpu_count}"
+    }
+
+    taint {
+      key    = "{{ .TaintKey }}"
+      value  = "{{ .TaintValue }}"
+      effect = "NO_SCHEDULE"
+    }
+
+    oauth_scopes = [
+      "https://www.googleapis.com/auth/compute",
+      "https://www.googleapis.com/auth/devstorage.read_only",
+      "https://www.googleapis.com/auth/logging.write",
+      "https://www.googleapis.com/auth/monitoring",
+    ]
+  }
+}
+`
+
+// Terraform returns the terraform template which creates the node pool
+func (p *GPUNodePool) Terraform() (string, error) {
+	t, err := template.New("gpu-node-pool").Parse(gpuNodePoolTerraform)
+	if err != nil {
+		return "", err
+	}
+	data := map[string]string{
+		"Name":       GPUNodePoolName,
+		"Cluster":    "${" + ClusterTerraformResource + ".name}",
+		"TaintKey":   GPUTaintKey,
+		"TaintValue": GPUTaintValue,
+	}
+	var buffer bytes.Buffer
+	err = t.Execute(&buffer, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to render the terraform of the GPU node pool: %s", err)
+	}
+	return buffer.String(), nil
+}
+
+// TerraformVars returns the terraform variables of the node pool which are added to the variables of the cluster
+func (p *GPUNodePool) TerraformVars() map[string]string {
+	maxNodes := p.MaxNodes
+	if maxNodes < 1 {
+		maxNodes = DefaultGPUMaxNodes
+	}
+	gpuCount := p.GPUCount
+	if gpuCount < 1 {
+		gpuCount = 1
+	}
+	return map[string]string{
+		"gpu_type":      p.GPUType,
+		"gpu_count":     fmt.Sprintf("%d", gpuCount),
+		"gpu_max_nodes": fmt.Sprintf("%d", maxNodes),
+	}
+}
@@ -0,0 +1,142 @@
+package gke
+
+import (
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// NvidiaDriverInstallerName the name of the daemonset which installs the NVIDIA drivers on the GPU nodes
+	NvidiaDriverInstallerName = "nvidia-driver-installer"
+	// NvidiaDriverInstallerImage the driver installer image which is preloaded on the Container-Optimized OS nodes so
+	// that the driver matches the version of the node
+	NvidiaDriverInstallerImage = "cos-nvidia-installer:fixed"
+	// NvidiaDriverPauseImage the image which keeps the pods of the daemonset running once the driver is installed
+	NvidiaDriverPauseImage = "gcr.io/google-containers/pause:2.0"
+	// GKEAcceleratorLabel the label of the GKE nodes which have GPUs attached
+	GKEAcceleratorLabel = "cloud.google.com/gke-accelerator"
+
+	nvidiaInstallDirHost = "/home/kubernetes/bin/nvidia"
+	vulkanICDDirHost     = "/home/kubernetes/bin/nvidia/vulkan/icd.d"
+	cosToolsDirHost      = "/var/lib/cos-tools"
+)
+
+// NvidiaDriverDaemonSet returns the daemonset which installs the NVIDIA drivers on the Container-Optimized OS GPU
+// nodes. It is generated rather than applied from the GKE repository so that it only changes with jx
+func NvidiaDriverDaemonSet() *appsv1.DaemonSet {
+	labels := map[string]string{
+		"k8s-app": NvidiaDriverInstallerName,
+	}
+	privileged := true
+	hostPath := func(name string, path string) corev1.Volume {
+		return corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{Path: path},
+			},
+		}
+	}
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NvidiaDriverInstallerName,
+			Namespace: metav1.NamespaceSystem,
+			Labels:    labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.RollingUpdateDaemonSetStrategyType,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Affinity: &corev1.Affinity{
+						NodeAffinity: &corev1.NodeAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+								NodeSelectorTerms: []corev1.NodeSelectorTerm{
+									{
+										MatchExpressions: []corev1.NodeSelectorRequirement{
+											{
+												Key:      GKEAcceleratorLabel,
+												Operator: corev1.NodeSelectorOpExists,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					// the GPU nodes are tainted so that only the pods which need them are scheduled on them
+					Tolerations: []corev1.Toleration{
+						{
+							Operator: corev1.TolerationOpExists,
+						},
+					},
+					HostNetwork: true,
+					HostPID:     true,
+					Volumes: []corev1.Volume{
+						hostPath("dev", "/dev"),
+						hostPath("vulkan-icd-mount", vulkanICDDirHost),
+						hostPath("nvidia-install-dir-host", nvidiaInstallDirHost),
+						hostPath("root-mount", "/"),
+						hostPath("cos-tools", cosToolsDirHost),
+					},
+					InitContainers: []corev1.Container{
+						{
+							Name:            NvidiaDriverInstallerName,
+							Image:           NvidiaDriverInstallerImage,
+							ImagePullPolicy: corev1.PullNever,
+							SecurityContext: &corev1.SecurityContext{
+								Privileged: &privileged,
+							},
+							Env: []corev1.EnvVar{
+								{Name: "NVIDIA_INSTALL_DIR_HOST", Value: nvidiaInstallDirHost},
+								{Name: "NVIDIA_INSTALL_DIR_CONTAINER", Value: "/usr/local/nvidia"},
+								{Name: "VULKAN_ICD_DIR_HOST", Value: vulkanICDDirHost},
+								{Name: "VULKAN_ICD_DIR_CONTAINER", Value: "/etc/vulkan/icd.d"},
+								{Name: "ROOT_MOUNT_DIR", Value: "/root"},
+								{Name: "COS_TOOLS_DIR_HOST", Value: cosToolsDirHost},
+								{Name: "COS_TOOLS_DIR_CONTAINER", Value: "/build/cos-tools"},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "nvidia-install-dir-host", MountPath: "/usr/local/nvidia"},
+								{Name: "vulkan-icd-mount", MountPath: "/etc/vulkan/icd.d"},
+								{Name: "dev", MountPath: "/dev"},
+								{Name: "root-mount", MountPath: "/root"},
+								{Name: "cos-tools", MountPath: "/build/cos-tools"},
+							},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:  "pause",
+							Image: NvidiaDriverPauseImage,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InstallNvidiaDriver creates or updates the daemonset which installs the NVIDIA drivers on the GPU nodes
+func InstallNvidiaDriver(client kubernetes.Interface) error {
+	daemonSet := NvidiaDriverDaemonSet()
+	daemonSets := client.AppsV1().DaemonSets(daemonSet.Namespace)
+	existing, err := daemonSets.Get(daemonSet.Name, metav1.GetOptions{})
+	if err == nil {
+		existing.Spec = daemonSet.Spec
+		_, err = daemonSets.Update(existing)
+	} else {
+		_, err = daemonSets.Create(daemonSet)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "saving the daemonset %s which installs the NVIDIA drivers", daemonSet.Name)
+	}
+	return nil
+}
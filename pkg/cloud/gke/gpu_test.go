@@ -0,0 +1,59 @@
+package gke_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/cloud/gke"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestGPUNodePoolTerraform(t *testing.T) {
+	t.Parallel()
+	pool := &gke.GPUNodePool{}
+
+	text, err := pool.Terraform()
+	require.NoError(t, err)
+	assert.Contains(t, text, `resource "google_container_node_pool" "gpu-pool"`)
+	assert.Contains(t, text, `cluster  = "${google_container_cluster.jx-cluster.name}"`)
+	assert.Contains(t, text, `key    = "nvidia.com/gpu"`)
+	assert.Contains(t, text, `effect = "NO_SCHEDULE"`)
+	assert.Contains(t, text, `type  = "${var.gpu_type}"`)
+	assert.Contains(t, text, `max_node_count = "${var.gpu_max_nodes}"`)
+	assert.NotContains(t, text, `variable "credentials"`, "the node pool should reuse the variables of the cluster")
+}
+
+func TestGPUNodePoolTerraformVars(t *testing.T) {
+	t.Parallel()
+	pool := &gke.GPUNodePool{
+		GPUType: "nvidia-tesla-k80",
+	}
+
+	expected := map[string]string{
+		"gpu_type":      "nvidia-tesla-k80",
+		"gpu_count":     "1",
+		"gpu_max_nodes": "2",
+	}
+	assert.Equal(t, expected, pool.TerraformVars())
+
+	pool.GPUCount = 4
+	pool.MaxNodes = 3
+	assert.Equal(t, "4", pool.TerraformVars()["gpu_count"])
+	assert.Equal(t, "3", pool.TerraformVars()["gpu_max_nodes"])
+}
+
+func TestNvidiaDriverDaemonSet(t *testing.T) {
+	t.Parallel()
+	daemonSet := gke.NvidiaDriverDaemonSet()
+
+	podSpec := daemonSet.Spec.Template.Spec
+	require.Len(t, podSpec.InitContainers, 1)
+	assert.Equal(t, gke.NvidiaDriverInstallerImage, podSpec.InitContainers[0].Image)
+	assert.Equal(t, corev1.PullNever, podSpec.InitContainers[0].ImagePullPolicy)
+	for _, container := range append(podSpec.InitContainers, podSpec.Containers...) {
+		assert.NotContains(t, container.Image, ":latest", "the images of the driver installer should be pinned")
+	}
+	assert.Equal(t, gke.GKEAcceleratorLabel, podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Key)
+	assert.Equal(t, corev1.TolerationOpExists, podSpec.Tolerations[0].Operator)
+}
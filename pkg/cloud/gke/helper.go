@@ -18,12 +18,12 @@ func GetGoogleZones(project string) ([]string, error) {
 		args = append(args, project)
 	}
 
-	cmd := util.Command{
+	cmd := &util.CommandSpec{
 		Name: "gcloud",
 		Args: args,
 	}
 
-	out, err := cmd.RunWithoutRetry()
+	out, err := runCommand(cmd)
 	if err != nil {
 		return nil, err
 	}
@@ -39,11 +39,11 @@ func GetGoogleZones(project string) ([]string, error) {
 }
 
 func GetGoogleProjects() ([]string, error) {
-	cmd := util.Command{
+	cmd := &util.CommandSpec{
 		Name: "gcloud",
 		Args: []string{"projects", "list"},
 	}
-	out, err := cmd.RunWithoutRetry()
+	out, err := runCommand(cmd)
 	if err != nil {
 		return nil, err
 	}
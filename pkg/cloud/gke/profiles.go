@@ -0,0 +1,182 @@
+package gke
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// ProfileMinimal a team trying out Jenkins X with the occasional build
+	ProfileMinimal = "minimal"
+	// ProfileBalanced a team running a few builds at the same time
+	ProfileBalanced = "balanced"
+	// ProfileCIHeavy a team running lots of JVM builds at the same time
+	ProfileCIHeavy = "ci-heavy"
+
+	// platformCPU the vCPUs requested by the pods of the Jenkins X platform
+	platformCPU = 2.0
+	// platformMemoryGB the memory requested by the pods of the Jenkins X platform
+	platformMemoryGB = 8.0
+	// buildCPU the vCPUs used by a build pod
+	buildCPU = 1.0
+	// defaultBuildMemoryGB the memory used by a build pod of a language with no specific requirement
+	defaultBuildMemoryGB = 2.0
+	// minRecommendedNodes the minimum number of nodes recommended so that the platform survives losing a node
+	minRecommendedNodes = 3
+)
+
+// WorkloadProfiles the names of the predefined workload profiles
+var WorkloadProfiles = []string{ProfileMinimal, ProfileBalanced, ProfileCIHeavy}
+
+// BuildLanguages the languages which the memory of the builds is known for
+var BuildLanguages = []string{"Java", "Scala", "Go", "Rust", "JavaScript", "Python", "Ruby"}
+
+// buildMemoryGB the memory a build pod of a language typically uses
+var buildMemoryGB = map[string]float64{
+	"java":       4,
+	"scala":      4,
+	"rust":       3,
+	"go":         2,
+	"javascript": 2,
+	"python":     1,
+	"ruby":       1,
+}
+
+// MachineType the vCPUs and memory of a Google Cloud machine type
+type MachineType struct {
+	Name     string
+	CPU      float64
+	MemoryGB float64
+}
+
+// recommendedMachineTypes the machine types to recommend from, smallest first
+var recommendedMachineTypes = []MachineType{
+	{Name: "n1-standard-2", CPU: 2, MemoryGB: 7.5},
+	{Name: "n1-standard-4", CPU: 4, MemoryGB: 15},
+	{Name: "n1-standard-8", CPU: 8, MemoryGB: 30},
+	{Name: "n1-highmem-2", CPU: 2, MemoryGB: 13},
+	{Name: "n1-highmem-4", CPU: 4, MemoryGB: 26},
+	{Name: "n1-highmem-8", CPU: 8, MemoryGB: 52},
+}
+
+// Workload the builds a team runs on the cluster
+type Workload struct {
+	ConcurrentBuilds int
+	Languages        []string
+}
+
+// Recommendation the machine type and node counts recommended for a workload along with the reasons why
+type Recommendation struct {
+	MachineType string
+	MinNodes    int
+	MaxNodes    int
+	Reasons     []string
+}
+
+// ProfileWorkload returns the workload of the predefined profile with the given name
+func ProfileWorkload(profile string) (Workload, error) {
+	switch profile {
+	case ProfileMinimal:
+		return Workload{ConcurrentBuilds: 1, Languages: []string{"Go"}}, nil
+	case ProfileBalanced:
+		return Workload{ConcurrentBuilds: 3, Languages: []string{"Java", "JavaScript"}}, nil
+	case ProfileCIHeavy:
+		return Workload{ConcurrentBuilds: 10, Languages: []string{"Java"}}, nil
+	default:
+		return Workload{}, util.InvalidOption("profile", profile, WorkloadProfiles)
+	}
+}
+
+// RecommendMachineType recommends the machine type and the number of nodes which fit the Jenkins X platform and
+// the concurrent builds of the workload
+func RecommendMachineType(workload Workload) Recommendation {
+	builds := workload.ConcurrentBuilds
+	if builds < 1 {
+		builds = 1
+	}
+	memoryPerBuild := 0.0
+	heaviest := ""
+	for _, language := range workload.Languages {
+		memory, ok := buildMemoryGB[strings.ToLower(language)]
+		if ok && memory > memoryPerBuild {
+			memoryPerBuild = memory
+			heaviest = language
+		}
+	}
+	reasons := []string{}
+	if memoryPerBuild == 0 {
+		memoryPerBuild = defaultBuildMemoryGB
+		reasons = append(reasons, fmt.Sprintf("each build is assumed to use %s of memory", formatGB(memoryPerBuild)))
+	} else {
+		reasons = append(reasons, fmt.Sprintf("%s builds use around %s of memory each", heaviest, formatGB(memoryPerBuild)))
+	}
+
+	cpu := platformCPU + float64(builds)*buildCPU
+	memory := platformMemoryGB + float64(builds)*memoryPerBuild
+	reasons = append(reasons, fmt.Sprintf("the Jenkins X platform needs %s vCPUs and %s plus %d concurrent builds need %s vCPUs and %s in total",
+		formatFloat(platformCPU), formatGB(platformMemoryGB), builds, formatFloat(cpu), formatGB(memory)))
+
+	// pick bigger machines for more builds so that the nodes are not mostly taken up by the platform
+	size := 2.0
+	if builds > 6 {
+		size = 8
+	} else if builds > 2 {
+		size = 4
+	}
+	machine := machineTypeFor(size, memoryPerBuild/buildCPU)
+	reasons = append(reasons, fmt.Sprintf("%s has %s vCPUs and %s of memory which fits %d builds per node",
+		machine.Name, formatFloat(machine.CPU), formatGB(machine.MemoryGB), buildsPerNode(machine, memoryPerBuild)))
+
+	maxNodes := nodesFor(machine, cpu, memory)
+	minNodes := nodesFor(machine, platformCPU+buildCPU, platformMemoryGB+memoryPerBuild)
+	if minNodes < minRecommendedNodes {
+		minNodes = minRecommendedNodes
+		reasons = append(reasons, fmt.Sprintf("a minimum of %d nodes keeps Jenkins X running if a node is lost", minRecommendedNodes))
+	}
+	if maxNodes < minNodes+2 {
+		maxNodes = minNodes + 2
+	}
+	reasons = append(reasons, fmt.Sprintf("the cluster autoscales up to %d nodes when all the builds run at the same time", maxNodes))
+	return Recommendation{
+		MachineType: machine.Name,
+		MinNodes:    minNodes,
+		MaxNodes:    maxNodes,
+		Reasons:     reasons,
+	}
+}
+
+// machineTypeFor returns the machine type with the given number of vCPUs which has enough memory per vCPU
+func machineTypeFor(cpu float64, memoryPerCPU float64) MachineType {
+	for _, machine := range recommendedMachineTypes {
+		if machine.CPU == cpu && machine.MemoryGB/machine.CPU >= memoryPerCPU {
+			return machine
+		}
+	}
+	// fall back to the high memory machine with the given number of vCPUs
+	answer := recommendedMachineTypes[0]
+	for _, machine := range recommendedMachineTypes {
+		if machine.CPU == cpu {
+			answer = machine
+		}
+	}
+	return answer
+}
+
+func nodesFor(machine MachineType, cpu float64, memory float64) int {
+	return int(math.Ceil(math.Max(cpu/machine.CPU, memory/machine.MemoryGB)))
+}
+
+func buildsPerNode(machine MachineType, memoryPerBuild float64) int {
+	return int(math.Min(machine.CPU/buildCPU, math.Floor(machine.MemoryGB/memoryPerBuild)))
+}
+
+func formatGB(value float64) string {
+	return formatFloat(value) + "GB"
+}
+
+func formatFloat(value float64) string {
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", value), ".0")
+}
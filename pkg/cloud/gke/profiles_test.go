@@ -0,0 +1,47 @@
+package gke
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecommendMachineTypeForProfiles(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		profile     string
+		machineType string
+		minNodes    int
+		maxNodes    int
+	}{
+		{ProfileMinimal, "n1-standard-2", 3, 5},
+		{ProfileBalanced, "n1-highmem-4", 3, 5},
+		{ProfileCIHeavy, "n1-highmem-8", 3, 5},
+	}
+	for _, tt := range tests {
+		workload, err := ProfileWorkload(tt.profile)
+		require.NoError(t, err)
+		r := RecommendMachineType(workload)
+		assert.Equal(t, tt.machineType, r.MachineType, "machine type of profile %s", tt.profile)
+		assert.Equal(t, tt.minNodes, r.MinNodes, "min nodes of profile %s", tt.profile)
+		assert.Equal(t, tt.maxNodes, r.MaxNodes, "max nodes of profile %s", tt.profile)
+		assert.NotEmpty(t, r.Reasons)
+	}
+
+	_, err := ProfileWorkload("cheese")
+	assert.Error(t, err)
+}
+
+func TestRecommendMachineTypeScalesWithBuilds(t *testing.T) {
+	t.Parallel()
+	r := RecommendMachineType(Workload{ConcurrentBuilds: 40, Languages: []string{"python", "JavaScript"}})
+	assert.Equal(t, "n1-standard-8", r.MachineType)
+	assert.Equal(t, 3, r.MinNodes)
+	assert.Equal(t, 6, r.MaxNodes)
+	assert.Contains(t, r.Reasons[0], "JavaScript builds use around 2GB")
+
+	r = RecommendMachineType(Workload{})
+	assert.Equal(t, "n1-standard-2", r.MachineType)
+	assert.Contains(t, r.Reasons[0], "assumed to use 2GB")
+}
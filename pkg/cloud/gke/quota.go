@@ -0,0 +1,117 @@
+package gke
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// QuotaCPUs the regional quota metric for CPUs
+	QuotaCPUs = "CPUS"
+	// QuotaInUseAddresses the regional quota metric for in use IP addresses
+	QuotaInUseAddresses = "IN_USE_ADDRESSES"
+)
+
+// Quota represents a Compute Engine quota of a region
+type Quota struct {
+	Metric string  `json:"metric"`
+	Limit  float64 `json:"limit"`
+	Usage  float64 `json:"usage"`
+}
+
+// QuotaShortfall represents a quota which does not have enough capacity left
+type QuotaShortfall struct {
+	Metric    string
+	Required  float64
+	Available float64
+}
+
+// String returns a description of the shortfall
+func (s QuotaShortfall) String() string {
+	return fmt.Sprintf("%s requires %g but only %g available", s.Metric, s.Required, s.Available)
+}
+
+// Available returns how much of the quota has not been used yet
+func (q *Quota) Available() float64 {
+	return q.Limit - q.Usage
+}
+
+// GetRegionQuotas returns the Compute Engine quotas of the given region
+func GetRegionQuotas(projectID string, region string) ([]Quota, error) {
+	args := []string{"compute", "regions", "describe", region, "--format", "json"}
+	if projectID != "" {
+		args = append(args, "--project", projectID)
+	}
+	cmd := &util.CommandSpec{
+		Name: "gcloud",
+		Args: args,
+	}
+	out, err := runCommand(cmd)
+	if err != nil {
+		return nil, errors.Wrapf(err, "describing region %s", region)
+	}
+	return ParseRegionQuotas([]byte(out))
+}
+
+// ParseRegionQuotas parses the quotas from the JSON output of 'gcloud compute regions describe'
+func ParseRegionQuotas(data []byte) ([]Quota, error) {
+	region := struct {
+		Quotas []Quota `json:"quotas"`
+	}{}
+	err := json.Unmarshal(data, &region)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing region quotas")
+	}
+	return region.Quotas, nil
+}
+
+// MachineTypeCPUs returns the number of vCPUs of the given machine type such as n1-standard-4.
+// Shared core machine types count as a single CPU
+func MachineTypeCPUs(machineType string) int {
+	idx := strings.LastIndex(machineType, "-")
+	if idx >= 0 {
+		cpus, err := strconv.Atoi(machineType[idx+1:])
+		if err == nil && cpus > 0 {
+			return cpus
+		}
+	}
+	return 1
+}
+
+// NodePoolQuotaRequirements returns the regional quota consumed by a node pool of the given machine type and size
+func NodePoolQuotaRequirements(machineType string, nodes int) map[string]float64 {
+	return map[string]float64{
+		QuotaCPUs:           float64(MachineTypeCPUs(machineType) * nodes),
+		QuotaInUseAddresses: float64(nodes),
+	}
+}
+
+// FindQuotaShortfalls returns the quotas which do not have enough capacity available for the given requirements
+func FindQuotaShortfalls(quotas []Quota, requirements map[string]float64) []QuotaShortfall {
+	answer := []QuotaShortfall{}
+	for _, q := range quotas {
+		required, ok := requirements[q.Metric]
+		if ok && q.Available() < required {
+			answer = append(answer, QuotaShortfall{
+				Metric:    q.Metric,
+				Required:  required,
+				Available: q.Available(),
+			})
+		}
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].Metric < answer[j].Metric
+	})
+	return answer
+}
+
+// QuotaIncreaseURL returns the URL of the console page to request quota increases for the given project
+func QuotaIncreaseURL(projectID string) string {
+	return fmt.Sprintf("https://console.cloud.google.com/iam-admin/quotas?project=%s", projectID)
+}
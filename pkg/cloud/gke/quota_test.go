@@ -0,0 +1,42 @@
+package gke
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRegionJSON = `{
+  "name": "europe-west1",
+  "quotas": [
+    {"limit": 24.0, "metric": "CPUS", "usage": 20.0},
+    {"limit": 8.0, "metric": "IN_USE_ADDRESSES", "usage": 2.0},
+    {"limit": 4096.0, "metric": "DISKS_TOTAL_GB", "usage": 300.0}
+  ],
+  "status": "UP"
+}`
+
+func TestMachineTypeCPUs(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, 2, MachineTypeCPUs("n1-standard-2"))
+	assert.Equal(t, 16, MachineTypeCPUs("n1-highmem-16"))
+	assert.Equal(t, 1, MachineTypeCPUs("g1-small"))
+	assert.Equal(t, 1, MachineTypeCPUs("f1-micro"))
+}
+
+func TestFindQuotaShortfalls(t *testing.T) {
+	t.Parallel()
+	quotas, err := ParseRegionQuotas([]byte(testRegionJSON))
+	require.NoError(t, err)
+	require.Len(t, quotas, 3)
+
+	shortfalls := FindQuotaShortfalls(quotas, NodePoolQuotaRequirements("n1-standard-2", 3))
+	require.Len(t, shortfalls, 1)
+	assert.Equal(t, QuotaCPUs, shortfalls[0].Metric)
+	assert.Equal(t, 6.0, shortfalls[0].Required)
+	assert.Equal(t, 4.0, shortfalls[0].Available)
+
+	shortfalls = FindQuotaShortfalls(quotas, NodePoolQuotaRequirements("n1-standard-1", 3))
+	assert.Empty(t, shortfalls)
+}
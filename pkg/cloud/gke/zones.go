@@ -0,0 +1,158 @@
+package gke
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// GCPingEndpointsURL the URL of the endpoints in each Google Cloud region which the latency is measured to
+const GCPingEndpointsURL = "https://global.gcping.com/api/endpoints"
+
+// gcpingEndpoint an endpoint of a region returned by gcping
+type gcpingEndpoint struct {
+	URL    string `json:"URL"`
+	Region string `json:"Region"`
+}
+
+// MachineTypeZones returns the zones which offer the given machine type
+func MachineTypeZones(projectID string, machineType string) ([]string, error) {
+	return zonesOffering(projectID, "machine-types", machineType)
+}
+
+// AcceleratorZones returns the zones which offer the given type of GPU such as nvidia-tesla-k80
+func AcceleratorZones(projectID string, acceleratorType string) ([]string, error) {
+	return zonesOffering(projectID, "accelerator-types", acceleratorType)
+}
+
+func zonesOffering(projectID string, resource string, name string) ([]string, error) {
+	args := []string{"compute", resource, "list", "--filter", "name=" + name, "--format", "value(zone)"}
+	if projectID != "" {
+		args = append(args, "--project", projectID)
+	}
+	cmd := &util.CommandSpec{
+		Name: "gcloud",
+		Args: args,
+	}
+	output, err := runCommand(cmd)
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing the zones offering the %s %s: %s", resource, name, output)
+	}
+	zones := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		zone := strings.TrimSpace(line)
+		if zone != "" {
+			zones = append(zones, zone)
+		}
+	}
+	sort.Strings(zones)
+	return zones, nil
+}
+
+// FilterZones returns the zones which are in each of the lists of allowed zones
+func FilterZones(zones []string, allowed ...[]string) []string {
+	answer := []string{}
+	for _, zone := range zones {
+		found := true
+		for _, list := range allowed {
+			if util.StringArrayIndex(list, zone) < 0 {
+				found = false
+				break
+			}
+		}
+		if found {
+			answer = append(answer, zone)
+		}
+	}
+	return answer
+}
+
+// MeasureRegionLatencies returns the latency from here to each of the Google Cloud regions which have a gcping
+// endpoint. The regions are measured in parallel and the fastest of a couple of requests to each is used as the first
+// request also includes the TLS handshake
+func MeasureRegionLatencies(client *http.Client) (map[string]time.Duration, error) {
+	resp, err := client.Get(GCPingEndpointsURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting the endpoints from %s", GCPingEndpointsURL)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	endpoints := map[string]gcpingEndpoint{}
+	err = json.Unmarshal(data, &endpoints)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing the endpoints from %s", GCPingEndpointsURL)
+	}
+	answer := map[string]time.Duration{}
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for region, endpoint := range endpoints {
+		if endpoint.URL == "" || region == "global" {
+			continue
+		}
+		wg.Add(1)
+		go func(region string, url string) {
+			defer wg.Done()
+			latency := measureLatency(client, url)
+			if latency > 0 {
+				lock.Lock()
+				answer[region] = latency
+				lock.Unlock()
+			}
+		}(region, endpoint.URL)
+	}
+	wg.Wait()
+	return answer, nil
+}
+
+// measureLatency returns the fastest of a couple of pings of the endpoint or zero if it could not be reached
+func measureLatency(client *http.Client, url string) time.Duration {
+	var fastest time.Duration
+	for i := 0; i < 2; i++ {
+		start := time.Now()
+		resp, err := client.Get(url + "/api/ping")
+		if err != nil {
+			break
+		}
+		resp.Body.Close()
+		latency := time.Since(start)
+		if fastest == 0 || latency < fastest {
+			fastest = latency
+		}
+	}
+	return fastest
+}
+
+// SortZonesByLatency sorts the zones by the latency of their region with the zones of regions with no known
+// latency last
+func SortZonesByLatency(zones []string, latencies map[string]time.Duration) []string {
+	answer := append([]string{}, zones...)
+	sort.SliceStable(answer, func(i, j int) bool {
+		li, iok := latencies[GetRegionFromZone(answer[i])]
+		lj, jok := latencies[GetRegionFromZone(answer[j])]
+		if iok != jok {
+			return iok
+		}
+		return li < lj
+	})
+	return answer
+}
+
+// ZoneDescription returns the zone along with the latency of its region if known
+func ZoneDescription(zone string, latencies map[string]time.Duration) string {
+	latency, ok := latencies[GetRegionFromZone(zone)]
+	if !ok {
+		return zone
+	}
+	return fmt.Sprintf("%s (%dms)", zone, latency/time.Millisecond)
+}
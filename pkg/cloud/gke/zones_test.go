@@ -0,0 +1,45 @@
+package gke_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/cloud/gke"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterZones(t *testing.T) {
+	t.Parallel()
+	zones := []string{"europe-west1-b", "europe-west1-c", "us-east1-b", "us-west1-a"}
+
+	assert.Equal(t, zones, gke.FilterZones(zones))
+	assert.Equal(t, []string{"europe-west1-c", "us-east1-b"},
+		gke.FilterZones(zones, []string{"europe-west1-c", "us-east1-b", "asia-east1-a"}))
+	assert.Equal(t, []string{"us-east1-b"},
+		gke.FilterZones(zones, []string{"europe-west1-c", "us-east1-b"}, []string{"us-east1-b", "us-west1-a"}))
+	assert.Equal(t, []string{}, gke.FilterZones(zones, []string{}))
+}
+
+func TestSortZonesByLatency(t *testing.T) {
+	t.Parallel()
+	zones := []string{"asia-east1-a", "europe-west1-b", "europe-west1-c", "us-east1-b", "us-west1-a"}
+	latencies := map[string]time.Duration{
+		"europe-west1": 20 * time.Millisecond,
+		"us-east1":     90 * time.Millisecond,
+		"us-west1":     150 * time.Millisecond,
+	}
+
+	assert.Equal(t, []string{"europe-west1-b", "europe-west1-c", "us-east1-b", "us-west1-a", "asia-east1-a"},
+		gke.SortZonesByLatency(zones, latencies))
+	assert.Equal(t, "asia-east1-a", zones[0], "the zones passed in should not be modified")
+}
+
+func TestZoneDescription(t *testing.T) {
+	t.Parallel()
+	latencies := map[string]time.Duration{
+		"europe-west1": 23 * time.Millisecond,
+	}
+
+	assert.Equal(t, "europe-west1-b (23ms)", gke.ZoneDescription("europe-west1-b", latencies))
+	assert.Equal(t, "us-east1-b", gke.ZoneDescription("us-east1-b", latencies))
+}
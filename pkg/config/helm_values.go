@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
@@ -60,14 +61,39 @@ type ControllerBuildConfig struct {
 	Enabled *bool `json:"enabled,omitempty"`
 }
 
+// ComponentConfig to enable or disable an optional component of the platform chart
+type ComponentConfig struct {
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
 type HelmValuesConfig struct {
 	ExposeController *ExposeController                  `json:"expose,omitempty"`
 	Jenkins          JenkinsValuesConfig                `json:"jenkins,omitempty"`
 	Prow             ProwValuesConfig                   `json:"prow,omitempty"`
 	PipelineSecrets  JenkinsPipelineSecretsValuesConfig `json:"PipelineSecrets,omitempty"`
 	ControllerBuild  ControllerBuildConfig              `json:"controllerbuild,omitempty"`
+	Nexus            *ComponentConfig                   `json:"nexus,omitempty"`
+	Chartmuseum      *ComponentConfig                   `json:"chartmuseum,omitempty"`
+	Monocular        *ComponentConfig                   `json:"monocular,omitempty"`
+	DockerRegistry   *ComponentConfig                   `json:"docker-registry,omitempty"`
 }
 
+const (
+	// ComponentJenkins the Jenkins component of the platform
+	ComponentJenkins = "jenkins"
+	// ComponentNexus the Nexus component of the platform
+	ComponentNexus = "nexus"
+	// ComponentChartmuseum the Chartmuseum component of the platform
+	ComponentChartmuseum = "chartmuseum"
+	// ComponentMonocular the Monocular component of the platform
+	ComponentMonocular = "monocular"
+	// ComponentDockerRegistry the Docker registry component of the platform
+	ComponentDockerRegistry = "docker-registry"
+)
+
+// PlatformComponents the optional components of the platform which can be excluded from the install
+var PlatformComponents = []string{ComponentJenkins, ComponentNexus, ComponentChartmuseum, ComponentMonocular, ComponentDockerRegistry}
+
 type HelmValuesConfigService struct {
 	FileName string
 	Config   HelmValuesConfig
@@ -105,6 +131,52 @@ func (c *HelmValuesConfig) AddExposeControllerValues(cmd *cobra.Command, ignoreD
 	c.ExposeController.Annotations = annotations
 }
 
+// DisableComponent disables the given platform component so that it is not installed
+func (c *HelmValuesConfig) DisableComponent(name string) error {
+	disabled := false
+	switch name {
+	case ComponentJenkins:
+		c.Jenkins.Enabled = &disabled
+	case ComponentNexus:
+		c.Nexus = &ComponentConfig{Enabled: &disabled}
+	case ComponentChartmuseum:
+		c.Chartmuseum = &ComponentConfig{Enabled: &disabled}
+	case ComponentMonocular:
+		c.Monocular = &ComponentConfig{Enabled: &disabled}
+	case ComponentDockerRegistry:
+		c.DockerRegistry = &ComponentConfig{Enabled: &disabled}
+	default:
+		return fmt.Errorf("unknown component %s. Supported components: %s", name, strings.Join(PlatformComponents, ", "))
+	}
+	return nil
+}
+
+// IsComponentEnabled returns true if the given platform component has not been disabled
+func (c *HelmValuesConfig) IsComponentEnabled(name string) bool {
+	var enabled *bool
+	switch name {
+	case ComponentJenkins:
+		enabled = c.Jenkins.Enabled
+	case ComponentNexus:
+		if c.Nexus != nil {
+			enabled = c.Nexus.Enabled
+		}
+	case ComponentChartmuseum:
+		if c.Chartmuseum != nil {
+			enabled = c.Chartmuseum.Enabled
+		}
+	case ComponentMonocular:
+		if c.Monocular != nil {
+			enabled = c.Monocular.Enabled
+		}
+	case ComponentDockerRegistry:
+		if c.DockerRegistry != nil {
+			enabled = c.DockerRegistry.Enabled
+		}
+	}
+	return enabled == nil || *enabled
+}
+
 func (c HelmValuesConfig) String() (string, error) {
 	b, err := yaml.Marshal(c)
 	if err != nil {
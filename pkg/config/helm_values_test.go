@@ -34,3 +34,22 @@ func TestEnvironmentExposecontrollerHelmValues(t *testing.T) {
 	values.ExposeController.Config.TLSAcme = "false"
 	assert.Equal(t, helmValuesFromFile, values, "expected exposecontroller helm values do not match")
 }
+
+func TestDisableComponent(t *testing.T) {
+	t.Parallel()
+
+	values := config.HelmValuesConfig{}
+	assert.True(t, values.IsComponentEnabled(config.ComponentNexus))
+
+	assert.NoError(t, values.DisableComponent(config.ComponentNexus))
+	assert.NoError(t, values.DisableComponent(config.ComponentChartmuseum))
+	assert.Error(t, values.DisableComponent("cheese"))
+
+	assert.False(t, values.IsComponentEnabled(config.ComponentNexus))
+	assert.False(t, values.IsComponentEnabled(config.ComponentChartmuseum))
+	assert.True(t, values.IsComponentEnabled(config.ComponentMonocular))
+
+	text, err := values.String()
+	assert.NoError(t, err)
+	assert.Contains(t, text, "nexus:\n  enabled: false")
+}
@@ -41,6 +41,21 @@ type IssueTrackerConfig struct {
 	Kind    string `yaml:"kind,omitempty"`
 	URL     string `yaml:"url,omitempty"`
 	Project string `yaml:"project,omitempty"`
+	// Transition the status the issues of a release are moved to when it is promoted to a transition environment
+	Transition string `yaml:"transition,omitempty"`
+	// TransitionEnvironments the environments whose promotions transition the issues. Defaults to production
+	TransitionEnvironments []string `yaml:"transitionEnvironments,omitempty"`
+}
+
+// TransitionsOn returns true if the issues of releases promoted to the environment should be transitioned
+func (c *IssueTrackerConfig) TransitionsOn(env string) bool {
+	if c.Transition == "" {
+		return false
+	}
+	if len(c.TransitionEnvironments) == 0 {
+		return env == "production"
+	}
+	return util.StringArrayIndex(c.TransitionEnvironments, env) >= 0
 }
 
 type WikiConfig struct {
@@ -47,3 +47,18 @@ func TestProjectConfigMarshal(t *testing.T) {
 	assert.True(t, projectConfig.Builds[0].ExcludePodTemplateEnv)
 	assert.True(t, projectConfig.Builds[0].ExcludePodTemplateVolumes)
 }
+
+func TestIssueTrackerTransitionsOn(t *testing.T) {
+	t.Parallel()
+
+	tracker := &config.IssueTrackerConfig{Kind: "jira"}
+	assert.False(t, tracker.TransitionsOn("production"))
+
+	tracker.Transition = "Done"
+	assert.True(t, tracker.TransitionsOn("production"))
+	assert.False(t, tracker.TransitionsOn("staging"))
+
+	tracker.TransitionEnvironments = []string{"staging"}
+	assert.True(t, tracker.TransitionsOn("staging"))
+	assert.False(t, tracker.TransitionsOn("production"))
+}
@@ -0,0 +1,47 @@
+package draft
+
+import (
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// buildPackMarker maps the files which identify the language or framework of a project to its build pack
+type buildPackMarker struct {
+	BuildPack string
+	Patterns  []string
+}
+
+// buildPackMarkers the markers in order of precedence. For example a Maven project with a package.json for its
+// frontend is still built with Maven
+var buildPackMarkers = []buildPackMarker{
+	{BuildPack: "maven", Patterns: []string{"pom.xml"}},
+	{BuildPack: "gradle", Patterns: []string{"build.gradle", "build.gradle.kts"}},
+	{BuildPack: "go", Patterns: []string{"go.mod", "Gopkg.toml", "glide.yaml"}},
+	{BuildPack: "csharp", Patterns: []string{"*.csproj", "*.fsproj", "*.sln"}},
+	{BuildPack: "python", Patterns: []string{"requirements.txt", "setup.py", "Pipfile", "pyproject.toml"}},
+	{BuildPack: "javascript", Patterns: []string{"package.json"}},
+}
+
+// DetectBuildPack returns the name of the build pack matching the build files in the root of the directory or an
+// empty string if none match, in which case the languages of the source code need to be detected
+func DetectBuildPack(dir string) (string, error) {
+	for _, marker := range buildPackMarkers {
+		for _, pattern := range marker.Patterns {
+			matches, err := filepath.Glob(filepath.Join(dir, pattern))
+			if err != nil {
+				return "", err
+			}
+			for _, match := range matches {
+				exists, err := util.FileExists(match)
+				if err != nil {
+					return "", err
+				}
+				if exists {
+					return marker.BuildPack, nil
+				}
+			}
+		}
+	}
+	return "", nil
+}
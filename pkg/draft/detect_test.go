@@ -0,0 +1,45 @@
+package draft_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/draft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectBuildPack(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		files []string
+		want  string
+	}{
+		"go modules":        {files: []string{"go.mod", "main.go"}, want: "go"},
+		"dep":               {files: []string{"Gopkg.toml"}, want: "go"},
+		"node":              {files: []string{"package.json"}, want: "javascript"},
+		"maven with node":   {files: []string{"pom.xml", "package.json"}, want: "maven"},
+		"gradle kotlin dsl": {files: []string{"build.gradle.kts"}, want: "gradle"},
+		"python":            {files: []string{"requirements.txt", "app.py"}, want: "python"},
+		"dotnet":            {files: []string{"MyApp.csproj", "Program.cs"}, want: "csharp"},
+		"unknown":           {files: []string{"README.md"}, want: ""},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "test-detect-build-pack-")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			for _, file := range tc.files {
+				err = ioutil.WriteFile(filepath.Join(dir, file), []byte(""), 0644)
+				require.NoError(t, err)
+			}
+			pack, err := draft.DetectBuildPack(dir)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, pack)
+		})
+	}
+}
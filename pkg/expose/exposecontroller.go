@@ -7,11 +7,13 @@ import (
 	"time"
 
 	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 
 	"github.com/jenkins-x/jx/pkg/certmanager"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/Pallinder/go-randomdata"
@@ -61,7 +63,17 @@ func Expose(devNamespace, targetNamespace, password string, kubeClient kubernete
 		return fmt.Errorf("cannot get existing team exposecontroller config from namespace %s: %v", devNamespace, err)
 	}
 
-	err = services.AnnotateNamespaceServicesWithCertManager(kubeClient, targetNamespace, ic.Issuer)
+	if ic.TLSSecretName != "" {
+		// the ingresses use the wildcard certificate so cert-manager must not request a certificate for each of them
+		err = services.CleanServiceAnnotations(kubeClient, targetNamespace)
+		if err == nil && targetNamespace != devNamespace {
+			err = copyTLSSecret(kubeClient, devNamespace, targetNamespace, ic.TLSSecretName)
+		}
+	} else if ic.ClusterIssuer {
+		err = services.AnnotateNamespaceServicesWithCertManagerClusterIssuer(kubeClient, targetNamespace, ic.Issuer)
+	} else {
+		err = services.AnnotateNamespaceServicesWithCertManager(kubeClient, targetNamespace, ic.Issuer)
+	}
 	if err != nil {
 		return err
 	}
@@ -93,6 +105,14 @@ func RunExposecontroller(devNamespace, targetNamespace string, ic kube.IngressCo
 		exValues = append(exValues, "config.http=true")
 	}
 
+	if ic.PathMode == kube.PathModePath {
+		exValues = append(exValues, "config.pathMode="+kube.PathModePath)
+	}
+
+	if ic.TLSSecretName != "" {
+		exValues = append(exValues, "config.tlsSecretName="+ic.TLSSecretName)
+	}
+
 	if len(services) > 0 {
 		serviceCfg := "config.extravalues.services={"
 		for i, service := range services {
@@ -125,6 +145,40 @@ func RunExposecontroller(devNamespace, targetNamespace string, ic kube.IngressCo
 
 }
 
+// copyTLSSecret copies the Secret of the wildcard certificate from the dev namespace as ingresses can only use the
+// Secrets of their own namespace
+func copyTLSSecret(kubeClient kubernetes.Interface, devNamespace string, targetNamespace string, name string) error {
+	secret, err := kubeClient.CoreV1().Secrets(devNamespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Warnf("The wildcard certificate %s has not been issued yet so the ingresses in namespace %s use the default certificate until the next time they are exposed\n", name, targetNamespace)
+			return nil
+		}
+		return fmt.Errorf("cannot get secret %s in namespace %s: %v", name, devNamespace, err)
+	}
+	copy := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secret.Name,
+			Labels:      secret.Labels,
+			Annotations: secret.Annotations,
+		},
+		Type: secret.Type,
+		Data: secret.Data,
+	}
+	secrets := kubeClient.CoreV1().Secrets(targetNamespace)
+	existing, err := secrets.Get(name, metav1.GetOptions{})
+	if err != nil {
+		_, err = secrets.Create(copy)
+	} else {
+		copy.ResourceVersion = existing.ResourceVersion
+		_, err = secrets.Update(copy)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot copy secret %s to namespace %s: %v", name, targetNamespace, err)
+	}
+	return nil
+}
+
 // CleanExposecontrollerReources cleans expose controller resources
 func CleanExposecontrollerReources(kubeClient kubernetes.Interface, ns string) {
 	// let's not error if nothing to cleanup
@@ -70,6 +70,7 @@ func ToExecutable(e *jenkinsv1.ExtensionSpec, paramValues []jenkinsv1.ExtensionP
 		Description:          e.Description,
 		Script:               e.Script,
 		Given:                e.Given,
+		Image:                e.Image,
 		EnvironmentVariables: envVars,
 	}
 	envVarsFormatted := new(bytes.Buffer)
@@ -0,0 +1,169 @@
+package extensions
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	jenkinsv1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultLifecycleJobTimeout the maximum time an extension running in a container may take
+const DefaultLifecycleJobTimeout = 20 * time.Minute
+
+// LifecycleExtensions returns the extensions which are attached to the given lifecycle event sorted by name
+func LifecycleExtensions(exts []jenkinsv1.Extension, when jenkinsv1.ExtensionWhen) []jenkinsv1.Extension {
+	answer := []jenkinsv1.Extension{}
+	for _, ext := range exts {
+		if ext.Spec.Contains(when) {
+			answer = append(answer, ext)
+		}
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].Spec.FullyQualifiedName() < answer[j].Spec.FullyQualifiedName()
+	})
+	return answer
+}
+
+// LifecycleEnvironmentVariables returns the environment variables which describe the lifecycle event to an extension.
+// The names of the values are converted in the same way as the parameters of the extension so that an "app" value is
+// available as EXT_APP
+func LifecycleEnvironmentVariables(when jenkinsv1.ExtensionWhen, values map[string]string) []jenkinsv1.EnvironmentVariable {
+	answer := []jenkinsv1.EnvironmentVariable{
+		{
+			Name:  namespaceName(jenkinsv1.LifecycleEventGlobalParameterName),
+			Value: string(when),
+		},
+	}
+	names := []string{}
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		answer = append(answer, jenkinsv1.EnvironmentVariable{
+			Name:  namespaceName("ext", name),
+			Value: values[name],
+		})
+	}
+	return answer
+}
+
+// RunLifecycleExtensions runs the extensions of the team in the given namespace which are attached to the lifecycle
+// event. Extensions with an image run as a job in the team namespace using the given service account, all others run
+// their script locally. The first failing extension stops the execution. No extensions are run if the user is not
+// allowed to list them
+func RunLifecycleExtensions(jxClient versioned.Interface, kubeClient kubernetes.Interface, ns string,
+	serviceAccount string, when jenkinsv1.ExtensionWhen, values map[string]string, verbose bool) error {
+	exts := jxClient.JenkinsV1().Extensions(ns)
+	list, err := exts.List(metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// the Extension CRD is not registered so there are no extensions
+			return nil
+		}
+		if apierrors.IsForbidden(err) {
+			log.Warnf("Not running the %s extensions as you are not allowed to list the extensions in namespace %s\n",
+				when, ns)
+			return nil
+		}
+		return errors.Wrapf(err, "listing the extensions in namespace %s", ns)
+	}
+	hooks := LifecycleExtensions(list.Items, when)
+	if len(hooks) == 0 {
+		return nil
+	}
+	config, err := (&jenkinsv1.ExtensionConfigList{}).LoadFromConfigMap(ExtensionsConfigDefaultConfigMap, kubeClient, ns)
+	if err != nil {
+		return errors.Wrapf(err, "loading the extensions configuration of namespace %s", ns)
+	}
+	parameters := map[string][]jenkinsv1.ExtensionParameterValue{}
+	for _, c := range config.Extensions {
+		parameters[c.FullyQualifiedName()] = c.Parameters
+	}
+	for _, ext := range hooks {
+		name := ext.Spec.FullyQualifiedName()
+		e, _, err := ToExecutable(&ext.Spec, parameters[name], ns, exts)
+		if err != nil {
+			return err
+		}
+		e.EnvironmentVariables = append(e.EnvironmentVariables, LifecycleEnvironmentVariables(when, values)...)
+		log.Infof("Running %s extension %s\n", util.ColorInfo(when), util.ColorInfo(name))
+		if e.Image != "" {
+			err = ExecuteJob(kubeClient, ns, serviceAccount, &e, DefaultLifecycleJobTimeout)
+		} else {
+			err = e.Execute(verbose)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "running the %s extension %s", when, name)
+		}
+	}
+	return nil
+}
+
+// ExecuteJob runs the extension as a job in the given namespace using the image of the extension and the given service
+// account. The script of the extension, if any, is run with sh. The job is removed if it succeeds and kept for its
+// logs if it fails
+func ExecuteJob(kubeClient kubernetes.Interface, ns string, serviceAccount string, e *jenkinsv1.ExtensionExecution,
+	timeout time.Duration) error {
+	env := []corev1.EnvVar{}
+	for _, v := range e.EnvironmentVariables {
+		env = append(env, corev1.EnvVar{
+			Name:  v.Name,
+			Value: v.Value,
+		})
+	}
+	container := corev1.Container{
+		Name:  "extension",
+		Image: e.Image,
+		Env:   env,
+	}
+	if e.Script != "" {
+		container.Command = []string{"sh", "-c", e.Script}
+	}
+	backoffLimit := int32(0)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: kube.ToValidName("ext-"+e.Name) + "-",
+			Labels: map[string]string{
+				"jenkins.io/extension": kube.ToValidName(e.FullyQualifiedKebabName()),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: serviceAccount,
+					Containers:         []corev1.Container{container},
+				},
+			},
+		},
+	}
+	job, err := kubeClient.BatchV1().Jobs(ns).Create(job)
+	if err != nil {
+		return errors.Wrapf(err, "creating the job of extension %s", e.FullyQualifiedName())
+	}
+	err = kube.WaitForJobToTerminate(kubeClient, ns, job.Name, timeout)
+	if err != nil {
+		return err
+	}
+	job, err = kubeClient.BatchV1().Jobs(ns).Get(job.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if job.Status.Succeeded == 0 {
+		return fmt.Errorf("job %s failed, see its logs with: kubectl logs -n %s job/%s", job.Name, ns, job.Name)
+	}
+	return kube.DeleteJob(kubeClient, ns, job.Name)
+}
@@ -0,0 +1,94 @@
+package extensions_test
+
+import (
+	"errors"
+	"testing"
+
+	jenkinsv1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned/fake"
+	"github.com/jenkins-x/jx/pkg/extensions"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func lifecycleExtension(name string, whens ...jenkinsv1.ExtensionWhen) jenkinsv1.Extension {
+	return jenkinsv1.Extension{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "team." + name,
+		},
+		Spec: jenkinsv1.ExtensionSpec{
+			Name:      name,
+			Namespace: "team",
+			When:      whens,
+		},
+	}
+}
+
+func TestLifecycleExtensions(t *testing.T) {
+	t.Parallel()
+	exts := []jenkinsv1.Extension{
+		lifecycleExtension("notify", jenkinsv1.ExtensionWhenPostImport, jenkinsv1.ExtensionWhenPostEnvironmentCreate),
+		lifecycleExtension("change-freeze", jenkinsv1.ExtensionWhenPrePromote),
+		lifecycleExtension("catalog", jenkinsv1.ExtensionWhenPostImport),
+		lifecycleExtension("spotbugs", jenkinsv1.ExtensionWhenPost),
+	}
+
+	names := []string{}
+	for _, ext := range extensions.LifecycleExtensions(exts, jenkinsv1.ExtensionWhenPostImport) {
+		names = append(names, ext.Spec.Name)
+	}
+	assert.Equal(t, []string{"catalog", "notify"}, names)
+	assert.Empty(t, extensions.LifecycleExtensions(exts, jenkinsv1.ExtensionWhenPostInstall))
+
+	assert.True(t, exts[1].Spec.IsLifecycleHook())
+	assert.False(t, exts[3].Spec.IsLifecycleHook())
+}
+
+func TestLifecycleEnvironmentVariables(t *testing.T) {
+	t.Parallel()
+	envVars := extensions.LifecycleEnvironmentVariables(jenkinsv1.ExtensionWhenPrePromote, map[string]string{
+		"version":     "1.2.3",
+		"app":         "cheese",
+		"environment": "staging",
+	})
+	assert.Equal(t, []jenkinsv1.EnvironmentVariable{
+		{Name: "EXT_LIFECYCLE_EVENT", Value: "prePromote"},
+		{Name: "EXT_APP", Value: "cheese"},
+		{Name: "EXT_ENVIRONMENT", Value: "staging"},
+		{Name: "EXT_VERSION", Value: "1.2.3"},
+	}, envVars)
+}
+
+func TestRunLifecycleExtensionsWithoutMatchingExtensions(t *testing.T) {
+	t.Parallel()
+	ext := lifecycleExtension("change-freeze", jenkinsv1.ExtensionWhenPrePromote)
+	ext.Namespace = "jx"
+	jxClient := fake.NewSimpleClientset(&ext)
+	kubeClient := kubefake.NewSimpleClientset()
+
+	err := extensions.RunLifecycleExtensions(jxClient, kubeClient, "jx", "jenkins", jenkinsv1.ExtensionWhenPostImport, nil,
+		false)
+	assert.NoError(t, err)
+
+	// the extensions configuration is only loaded if an extension runs
+	configMaps, err := kubeClient.CoreV1().ConfigMaps("jx").List(metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, configMaps.Items)
+}
+
+func TestRunLifecycleExtensionsWhenForbidden(t *testing.T) {
+	t.Parallel()
+	jxClient := fake.NewSimpleClientset()
+	jxClient.PrependReactor("list", "extensions", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(jenkinsv1.Resource("extensions"), "", errors.New("not allowed"))
+	})
+	kubeClient := kubefake.NewSimpleClientset()
+
+	err := extensions.RunLifecycleExtensions(jxClient, kubeClient, "jx", "jenkins", jenkinsv1.ExtensionWhenPrePromote,
+		nil, false)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,145 @@
+package extensions
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	jenkinsv1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultPluginIndexURL the default index of the plugins which can be installed via jx plugin install
+	DefaultPluginIndexURL = "https://raw.githubusercontent.com/jenkins-x/jx-plugins/master/index.yaml"
+
+	// PluginIndexURLEnvVar the environment variable which overrides the default plugin index
+	PluginIndexURLEnvVar = "JX_PLUGIN_INDEX"
+
+	// pluginIndexDownloadNamespace the plugin directory the versions of the plugins of an index are downloaded to
+	pluginIndexDownloadNamespace = "index"
+)
+
+// PluginIndex lists the plugins which can be installed from an index
+type PluginIndex struct {
+	Plugins []jenkinsv1.PluginSpec `json:"plugins,omitempty"`
+}
+
+// LoadPluginIndex loads the plugin index from the given URL or local file
+func LoadPluginIndex(indexURL string) (*PluginIndex, error) {
+	var data []byte
+	var err error
+	if strings.HasPrefix(indexURL, "http://") || strings.HasPrefix(indexURL, "https://") {
+		data, err = downloadPluginIndex(indexURL)
+	} else {
+		data, err = ioutil.ReadFile(indexURL)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading the plugin index %s", indexURL)
+	}
+	index := &PluginIndex{}
+	err = yaml.Unmarshal(data, index)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing the plugin index %s", indexURL)
+	}
+	return index, nil
+}
+
+func downloadPluginIndex(indexURL string) ([]byte, error) {
+	resp, err := util.GetClient().Get(indexURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s getting %s", resp.Status, indexURL)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// Find returns the plugin with the given name or sub command or nil if the index does not contain it
+func (i *PluginIndex) Find(name string) *jenkinsv1.PluginSpec {
+	for idx := range i.Plugins {
+		plugin := &i.Plugins[idx]
+		if plugin.Name == name || plugin.SubCommand == name || PluginBinaryName(plugin.SubCommand) == name {
+			return plugin
+		}
+	}
+	return nil
+}
+
+// PluginBinaryName returns the name of the executable which implements the given sub command
+func PluginBinaryName(subCommand string) string {
+	name := "jx-" + strings.Join(strings.Fields(subCommand), "-")
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// LocalPluginBinDir returns the directory of the plugins installed from a plugin index
+func LocalPluginBinDir() (string, error) {
+	return util.PluginBinDir("")
+}
+
+// InstalledPluginPath returns the path of the plugin installed from a plugin index or an empty string if it is not
+// installed
+func InstalledPluginPath(plugin jenkinsv1.PluginSpec) (string, error) {
+	dir, err := LocalPluginBinDir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, PluginBinaryName(plugin.SubCommand))
+	exists, err := util.FileExists(path)
+	if err != nil || !exists {
+		return "", err
+	}
+	return path, nil
+}
+
+// InstallIndexPlugin downloads the binary of the plugin for the current platform into the LocalPluginBinDir so that
+// it is found when its sub command is invoked. The download is verified against the checksum of the index entry
+func InstallIndexPlugin(plugin jenkinsv1.PluginSpec) (string, error) {
+	if plugin.SubCommand == "" || plugin.Name == "" || plugin.Version == "" {
+		return "", fmt.Errorf("the plugin index entry %s must have a name, subCommand and version", plugin.Name)
+	}
+	binary, err := FindPluginBinary(plugin)
+	if err != nil {
+		return "", err
+	}
+	if binary.Checksum == "" {
+		return "", fmt.Errorf("the plugin index entry %s has no checksum for the binary %s", plugin.Name, binary.URL)
+	}
+	dir, err := LocalPluginBinDir()
+	if err != nil {
+		return "", err
+	}
+	downloaded, err := EnsurePluginInstalled(jenkinsv1.Plugin{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      plugin.Name,
+			Namespace: pluginIndexDownloadNamespace,
+		},
+		Spec: plugin,
+	})
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, PluginBinaryName(plugin.SubCommand))
+	tmpPath := path + ".tmp"
+	err = util.CopyFile(downloaded, tmpPath)
+	if err != nil {
+		return "", err
+	}
+	err = util.ReplaceExecutable(tmpPath, path)
+	if err != nil {
+		os.Remove(tmpPath)
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,86 @@
+package extensions_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	jenkinsv1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/extensions"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pluginIndexYaml = `plugins:
+- name: jx-cheese
+  subCommand: cheese
+  version: 1.0.0
+  description: Cheese related commands
+  binaries:
+  - goos: linux
+    goarch: amd64
+    url: https://example.com/jx-cheese-linux-amd64.tar.gz
+    checksum: 2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae
+- name: jx-wine
+  subCommand: wine list
+  version: 2.1.0
+`
+
+func TestLoadPluginIndex(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "jx-plugin-index-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "index.yaml")
+	err = ioutil.WriteFile(fileName, []byte(pluginIndexYaml), util.DefaultWritePermissions)
+	require.NoError(t, err)
+
+	index, err := extensions.LoadPluginIndex(fileName)
+	require.NoError(t, err)
+	assert.Len(t, index.Plugins, 2)
+
+	plugin := index.Find("cheese")
+	require.NotNil(t, plugin)
+	assert.Equal(t, "jx-cheese", plugin.Name)
+	assert.Equal(t, "1.0.0", plugin.Version)
+	require.Len(t, plugin.Binaries, 1)
+	assert.Equal(t, "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae", plugin.Binaries[0].Checksum)
+
+	plugin = index.Find("jx-wine")
+	require.NotNil(t, plugin)
+	assert.Equal(t, "wine list", plugin.SubCommand)
+
+	assert.Nil(t, index.Find("beer"))
+}
+
+func TestPluginBinaryName(t *testing.T) {
+	t.Parallel()
+	expected := "jx-wine-list"
+	if runtime.GOOS == "windows" {
+		expected += ".exe"
+	}
+	assert.Equal(t, expected, extensions.PluginBinaryName("wine list"))
+}
+
+func TestInstallIndexPluginRequiresChecksum(t *testing.T) {
+	t.Parallel()
+	plugin := jenkinsv1.PluginSpec{
+		Name:       "jx-cheese",
+		SubCommand: "cheese",
+		Version:    "1.0.0",
+		Binaries: []jenkinsv1.Binary{
+			{
+				Goos:   runtime.GOOS,
+				Goarch: runtime.GOARCH,
+				URL:    "https://example.com/jx-cheese.tar.gz",
+			},
+		},
+	}
+	_, err := extensions.InstallIndexPlugin(plugin)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no checksum")
+}
@@ -12,7 +12,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jenkins-x/jx/pkg/binaries"
 	jenkinsv1client "github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/jenkins-x/jx/pkg/log"
@@ -102,18 +104,44 @@ func isExecutable(fullPath string) (bool, error) {
 
 // FindPluginUrl finds the download URL for the current platform for a plugin
 func FindPluginUrl(plugin jenkinsv1.PluginSpec) (string, error) {
-	u := ""
-	for _, binary := range plugin.Binaries {
+	binary, err := FindPluginBinary(plugin)
+	if err != nil {
+		return "", err
+	}
+	return binary.URL, nil
+}
+
+// FindPluginBinary finds the binary for the current platform for a plugin
+func FindPluginBinary(plugin jenkinsv1.PluginSpec) (*jenkinsv1.Binary, error) {
+	var answer *jenkinsv1.Binary
+	for i := range plugin.Binaries {
+		binary := &plugin.Binaries[i]
 		if strings.ToLower(runtime.GOOS) == strings.ToLower(binary.Goos) && strings.ToLower(runtime.
 			GOARCH) == strings.ToLower(binary.Goarch) {
-			u = binary.URL
+			answer = binary
 		}
 	}
-	if u == "" {
-		return "", fmt.Errorf("unable to locate binary for %s %s for %s", runtime.GOARCH, runtime.GOOS,
+	if answer == nil || answer.URL == "" {
+		return nil, fmt.Errorf("unable to locate binary for %s %s for %s", runtime.GOARCH, runtime.GOOS,
 			plugin.SubCommand)
 	}
-	return u, nil
+	return answer, nil
+}
+
+// verifyPluginDownload verifies the downloaded file against the checksum of the binary if it has one
+func verifyPluginDownload(binary *jenkinsv1.Binary, downloadFile string) error {
+	if binary.Checksum == "" {
+		return nil
+	}
+	actual, err := binaries.FileChecksum(downloadFile)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(binary.Checksum, actual) {
+		return fmt.Errorf("the SHA256 checksum of %s is %s but the expected checksum is %s", binary.URL, actual,
+			binary.Checksum)
+	}
+	return nil
 }
 
 // EnsurePluginInstalled ensures that the correct version of a plugin is installed locally.
@@ -125,10 +153,11 @@ func EnsurePluginInstalled(plugin jenkinsv1.Plugin) (string, error) {
 	}
 	path := filepath.Join(pluginBinDir, fmt.Sprintf("%s-%s", plugin.Spec.Name, plugin.Spec.Version))
 	if _, err = os.Stat(path); os.IsNotExist(err) {
-		u, err := FindPluginUrl(plugin.Spec)
+		binary, err := FindPluginBinary(plugin.Spec)
 		if err != nil {
 			return "", err
 		}
+		u := binary.URL
 		log.Infof("Installing plugin %s version %s for command %s from %s\n", util.ColorInfo(plugin.Spec.Name),
 			util.ColorInfo(plugin.Spec.Version), util.ColorInfo(fmt.Sprintf("jx %s", plugin.Spec.SubCommand)), util.ColorInfo(u))
 
@@ -192,6 +221,10 @@ func EnsurePluginInstalled(plugin jenkinsv1.Plugin) (string, error) {
 		if err != nil {
 			return path, err
 		}
+		err = verifyPluginDownload(binary, downloadFile)
+		if err != nil {
+			return "", errors.Wrapf(err, "unable to install plugin %s", plugin.Name)
+		}
 
 		oldPath := downloadFile
 		if strings.HasSuffix(filename, ".tar.gz") {
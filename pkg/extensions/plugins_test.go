@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/jenkins-x/jx/pkg/log"
@@ -18,6 +20,7 @@ import (
 
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -82,6 +85,44 @@ func TestEnsurePluginInstalled(t *testing.T) {
 	assert.EqualValues(t, testString, res)
 }
 
+func TestEnsurePluginInstalledVerifiesChecksum(t *testing.T) {
+	ns := binDirNs + "-checksum"
+	testPluginBinDir, err := util.PluginBinDir(ns)
+	require.NoError(t, err)
+	defer os.RemoveAll(testPluginBinDir)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "#!/bin/sh\necho %s\n", testString)
+	}))
+	defer srv.Close()
+
+	testPlugin := jenkinsv1.Plugin{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: ns,
+		},
+		Spec: jenkinsv1.PluginSpec{
+			Binaries: []jenkinsv1.Binary{
+				{
+					URL:      srv.URL + "/jx-test",
+					Goarch:   runtime.GOARCH,
+					Goos:     runtime.GOOS,
+					Checksum: "0000000000000000000000000000000000000000000000000000000000000000",
+				},
+			},
+			Version:    version,
+			Name:       name,
+			SubCommand: "test-plugin",
+		},
+	}
+	path, err := extensions.EnsurePluginInstalled(testPlugin)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SHA256 checksum")
+	assert.Equal(t, "", path)
+	exists, err := util.FileExists(filepath.Join(testPluginBinDir, fmt.Sprintf("%s-%s", name, version)))
+	require.NoError(t, err)
+	assert.False(t, exists, "the plugin should not be installed")
+}
+
 func serveTestScript(t *testing.T) (*http.Server, int) {
 
 	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%s", "0.0.0.0", port))
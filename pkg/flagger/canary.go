@@ -0,0 +1,172 @@
+package flagger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// MetricRequestSuccessRate the builtin metric of the percentage of requests which succeed
+	MetricRequestSuccessRate = "request-success-rate"
+	// MetricRequestDuration the builtin metric of the 99th percentile of the request duration in milliseconds
+	MetricRequestDuration = "request-duration"
+)
+
+var (
+	canaryResource = schema.GroupVersionResource{Group: "flagger.app", Version: "v1beta1", Resource: "canaries"}
+)
+
+// CreateCanaryArguments the arguments to generate the canary of a deployment
+type CreateCanaryArguments struct {
+	Name        string
+	Deployment  string
+	Port        int32
+	Interval    string
+	Threshold   int
+	StepWeights []int
+	// MinSuccessRate the minimum percentage of requests which must succeed at each step
+	MinSuccessRate float64
+	// MaxRequestDuration the maximum 99th percentile request duration in milliseconds at each step
+	MaxRequestDuration float64
+}
+
+// CreateCanary generates the canary which progressively shifts traffic to new revisions of the deployment
+// rolling back if the metrics regress
+func CreateCanary(args *CreateCanaryArguments) *Canary {
+	minSuccessRate := args.MinSuccessRate
+	maxRequestDuration := args.MaxRequestDuration
+	return &Canary{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: APIVersion,
+			Kind:       "Canary",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: args.Name,
+		},
+		Spec: CanarySpec{
+			TargetRef: TargetRef{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       args.Deployment,
+			},
+			Service: CanaryService{
+				Port: args.Port,
+			},
+			Analysis: CanaryAnalysis{
+				Interval:    args.Interval,
+				Threshold:   args.Threshold,
+				StepWeights: args.StepWeights,
+				Metrics: []CanaryMetric{
+					{
+						Name:           MetricRequestSuccessRate,
+						Interval:       args.Interval,
+						ThresholdRange: &ThresholdRange{Min: &minSuccessRate},
+					},
+					{
+						Name:           MetricRequestDuration,
+						Interval:       args.Interval,
+						ThresholdRange: &ThresholdRange{Max: &maxRequestDuration},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ParseStepWeights parses the comma separated traffic percentages of the steps of a canary such as 10,25,50
+func ParseStepWeights(text string) ([]int, error) {
+	answer := []int{}
+	last := 0
+	for _, s := range strings.Split(text, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		weight, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid step weight %s: %s", s, err)
+		}
+		if weight <= last || weight > 100 {
+			return nil, fmt.Errorf("step weights must be increasing percentages between 1 and 100 but got %s", text)
+		}
+		last = weight
+		answer = append(answer, weight)
+	}
+	if len(answer) == 0 {
+		return nil, fmt.Errorf("no step weights specified")
+	}
+	return answer, nil
+}
+
+// toUnstructured converts the canary to an unstructured resource without its status
+func toUnstructured(canary *Canary) (*unstructured.Unstructured, error) {
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(canary)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert to unstructured")
+	}
+	u := &unstructured.Unstructured{Object: data}
+	unstructured.RemoveNestedField(u.Object, "status")
+	unstructured.RemoveNestedField(u.Object, "metadata", "creationTimestamp")
+	return u, nil
+}
+
+// CanaryYAML returns the canary as YAML such as to add it to the chart of a GitOps environment
+func CanaryYAML(canary *Canary) ([]byte, error) {
+	u, err := toUnstructured(canary)
+	if err != nil {
+		return nil, err
+	}
+	data, err := yaml.Marshal(u.Object)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal the Canary %s", canary.Name)
+	}
+	return data, nil
+}
+
+// ApplyCanary creates or updates the canary in the given namespace
+func ApplyCanary(dynamicClient dynamic.Interface, ns string, canary *Canary) error {
+	u, err := toUnstructured(canary)
+	if err != nil {
+		return err
+	}
+
+	client := dynamicClient.Resource(canaryResource).Namespace(ns)
+	existing, err := client.Get(canary.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get the Canary %s in namespace %s", canary.Name, ns)
+		}
+		_, err = client.Create(u)
+	} else {
+		u.SetResourceVersion(existing.GetResourceVersion())
+		_, err = client.Update(u)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to apply the Canary %s in namespace %s", canary.Name, ns)
+	}
+	return nil
+}
+
+// GetCanaryStatus returns the status of the canary of the given name
+func GetCanaryStatus(dynamicClient dynamic.Interface, ns string, name string) (*CanaryStatus, error) {
+	u, err := dynamicClient.Resource(canaryResource).Namespace(ns).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the Canary %s in namespace %s", name, ns)
+	}
+	canary := &Canary{}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, canary)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert the Canary %s", name)
+	}
+	return &canary.Status, nil
+}
@@ -0,0 +1,51 @@
+package flagger_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/flagger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStepWeights(t *testing.T) {
+	t.Parallel()
+
+	weights, err := flagger.ParseStepWeights("10, 25,50")
+	require.NoError(t, err)
+	assert.Equal(t, []int{10, 25, 50}, weights)
+
+	for _, text := range []string{"", "10,abc", "50,25", "10,110", "0,10"} {
+		_, err = flagger.ParseStepWeights(text)
+		assert.Error(t, err, "step weights %s", text)
+	}
+}
+
+func TestCreateCanary(t *testing.T) {
+	t.Parallel()
+
+	canary := flagger.CreateCanary(&flagger.CreateCanaryArguments{
+		Name:               "jx-production-myapp",
+		Deployment:         "jx-production-myapp",
+		Port:               80,
+		Interval:           "1m",
+		Threshold:          5,
+		StepWeights:        []int{10, 25, 50},
+		MinSuccessRate:     99,
+		MaxRequestDuration: 500,
+	})
+	assert.Equal(t, flagger.APIVersion, canary.APIVersion)
+	assert.Equal(t, "jx-production-myapp", canary.Spec.TargetRef.Name)
+	assert.Equal(t, []int{10, 25, 50}, canary.Spec.Analysis.StepWeights)
+	require.Len(t, canary.Spec.Analysis.Metrics, 2)
+	assert.Equal(t, 99.0, *canary.Spec.Analysis.Metrics[0].ThresholdRange.Min)
+	assert.Equal(t, 500.0, *canary.Spec.Analysis.Metrics[1].ThresholdRange.Max)
+
+	data, err := flagger.CanaryYAML(canary)
+	require.NoError(t, err)
+	text := string(data)
+	assert.Contains(t, text, "apiVersion: "+flagger.APIVersion)
+	assert.Contains(t, text, "kind: Canary")
+	assert.Contains(t, text, "name: jx-production-myapp")
+	assert.NotContains(t, text, "status:")
+}
@@ -0,0 +1,87 @@
+package flagger
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// the subset of the Flagger v1beta1 API which jx generates. The resources are applied via the dynamic
+// client so that we do not depend on the Flagger client libraries
+
+const (
+	// APIVersion the API version of the Flagger resources
+	APIVersion = "flagger.app/v1beta1"
+
+	// PhaseInitializing the canary is waiting for the primary deployment to be created
+	PhaseInitializing = "Initializing"
+	// PhaseInitialized the primary deployment is ready and the canary is waiting for a new revision
+	PhaseInitialized = "Initialized"
+	// PhaseProgressing traffic is being shifted to the new revision
+	PhaseProgressing = "Progressing"
+	// PhasePromoting the new revision is being copied to the primary deployment
+	PhasePromoting = "Promoting"
+	// PhaseFinalising traffic is being routed back to the primary deployment
+	PhaseFinalising = "Finalising"
+	// PhaseSucceeded the new revision passed the analysis and has been promoted
+	PhaseSucceeded = "Succeeded"
+	// PhaseFailed the new revision failed the analysis and traffic has been rolled back
+	PhaseFailed = "Failed"
+)
+
+// Canary the progressive delivery of a deployment
+type Canary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CanarySpec   `json:"spec"`
+	Status CanaryStatus `json:"status,omitempty"`
+}
+
+// CanarySpec the deployment to shift traffic to and the analysis to run at each step
+type CanarySpec struct {
+	TargetRef TargetRef      `json:"targetRef"`
+	Service   CanaryService  `json:"service"`
+	Analysis  CanaryAnalysis `json:"analysis"`
+}
+
+// TargetRef a reference to the deployment of the canary
+type TargetRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+}
+
+// CanaryService the port of the service Flagger routes the traffic of
+type CanaryService struct {
+	Port int32 `json:"port"`
+}
+
+// CanaryAnalysis the traffic weights to step through and the metrics to check at each step
+type CanaryAnalysis struct {
+	// Interval the time to wait between each step such as 1m
+	Interval string `json:"interval"`
+	// Threshold the number of failed metric checks before the canary is rolled back
+	Threshold int `json:"threshold"`
+	// StepWeights the percentages of traffic routed to the new revision
+	StepWeights []int          `json:"stepWeights"`
+	Metrics     []CanaryMetric `json:"metrics,omitempty"`
+}
+
+// CanaryMetric a metric checked at each step of the analysis
+type CanaryMetric struct {
+	Name           string          `json:"name"`
+	Interval       string          `json:"interval,omitempty"`
+	ThresholdRange *ThresholdRange `json:"thresholdRange,omitempty"`
+}
+
+// ThresholdRange the range of values of a metric which pass the check
+type ThresholdRange struct {
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+}
+
+// CanaryStatus the progress of the canary
+type CanaryStatus struct {
+	Phase        string `json:"phase,omitempty"`
+	CanaryWeight int    `json:"canaryWeight,omitempty"`
+	FailedChecks int    `json:"failedChecks,omitempty"`
+}
@@ -1,10 +1,16 @@
 package gits
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -23,6 +29,8 @@ type BitbucketServerProvider struct {
 	Client   *bitbucket.APIClient
 	Username string
 	Context  context.Context
+	// RestURL the base URL of the REST APIs which are not covered by the client such as build statuses
+	RestURL string
 
 	Server auth.AuthServer
 	User   auth.UserAuth
@@ -80,12 +88,55 @@ func NewBitbucketServerProvider(server *auth.AuthServer, user *auth.UserAuth, gi
 		Git:      git,
 	}
 
-	cfg := bitbucket.NewConfiguration(server.URL + "/rest")
+	provider.RestURL = server.URL + "/rest"
+	cfg := bitbucket.NewConfiguration(provider.RestURL)
 	provider.Client = bitbucket.NewAPIClient(apiKeyAuthContext, cfg)
 
 	return &provider, nil
 }
 
+// restCall invokes the REST API at the given path relative to the RestURL using the API token of the user.
+// The JSON response is decoded into the result if it is not nil. The raw response body is returned
+func (b *BitbucketServerProvider) restCall(method string, path string, body interface{}, result interface{}) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	u := util.UrlJoin(b.RestURL, path)
+	req, err := http.NewRequest(method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+b.User.ApiToken)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := util.GetClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return data, fmt.Errorf("%s %s failed with status %s: %s", method, u, resp.Status, string(data))
+	}
+	if result != nil && len(data) > 0 {
+		err = json.Unmarshal(data, result)
+		if err != nil {
+			return data, fmt.Errorf("failed to parse the response of %s %s: %s", method, u, err)
+		}
+	}
+	return data, nil
+}
+
 func BitbucketServerRepositoryToGitRepository(bRepo bitbucket.Repository) *GitRepository {
 	var sshURL string
 	var httpCloneURL string
@@ -626,7 +677,41 @@ func (b *BitbucketServerProvider) ListCommitStatus(org, repo, sha string) ([]*Gi
 }
 
 func (b *BitbucketServerProvider) UpdateCommitStatus(org string, repo string, sha string, status *GitRepoStatus) (*GitRepoStatus, error) {
-	return &GitRepoStatus{}, errors.New("TODO")
+	key := status.Context
+	if key == "" {
+		key = "jenkins-x"
+	}
+	state := toBitbucketServerState(status.State)
+	buildStatus := map[string]string{
+		"state":       state,
+		"key":         key,
+		"name":        key,
+		"url":         status.TargetURL,
+		"description": status.Description,
+	}
+	_, err := b.restCall(http.MethodPost, "/build-status/1.0/commits/"+sha, buildStatus, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &GitRepoStatus{
+		ID:          key,
+		Context:     status.Context,
+		URL:         status.TargetURL,
+		State:       stateMap[state],
+		TargetURL:   status.TargetURL,
+		Description: status.Description,
+	}, nil
+}
+
+// toBitbucketServerState converts the GitHub style status states to the build states of Bitbucket Server
+func toBitbucketServerState(state string) string {
+	switch state {
+	case "success":
+		return "SUCCESSFUL"
+	case "error", "failure":
+		return "FAILED"
+	}
+	return "INPROGRESS"
 }
 
 func convertBitBucketBuildStatusToGitStatus(buildStatus *bitbucket.BuildStatus) *GitRepoStatus {
@@ -673,6 +758,17 @@ func (b *BitbucketServerProvider) MergePullRequest(pr *GitPullRequest, message s
 func (b *BitbucketServerProvider) CreateWebHook(data *GitWebHookArguments) error {
 	projectKey, repo := parseBitBucketServerURL(data.Repo.URL)
 
+	requestBody, err := json.Marshal(webHookOptions(data))
+	if err != nil {
+		return err
+	}
+
+	_, err = b.Client.DefaultApi.CreateWebhook(projectKey, repo, requestBody, []string{"application/json"})
+
+	return err
+}
+
+func webHookOptions(data *GitWebHookArguments) map[string]interface{} {
 	var options = map[string]interface{}{
 		"url":    data.URL,
 		"name":   "Jenkins X Web Hook",
@@ -685,24 +781,52 @@ func (b *BitbucketServerProvider) CreateWebHook(data *GitWebHookArguments) error
 			"secret": data.Secret,
 		}
 	}
+	return options
+}
 
-	requestBody, err := json.Marshal(options)
-	if err != nil {
-		return err
-	}
-
-	_, err = b.Client.DefaultApi.CreateWebhook(projectKey, repo, requestBody, []string{"application/json"})
+type webHook struct {
+	ID            int64             `json:"id"`
+	URL           string            `json:"url"`
+	Configuration map[string]string `json:"configuration"`
+}
 
-	return err
+type webHooksPage struct {
+	IsLastPage    bool      `json:"isLastPage"`
+	NextPageStart int       `json:"nextPageStart"`
+	Values        []webHook `json:"values"`
 }
 
-func (p *BitbucketServerProvider) ListWebHooks(owner string, repo string) ([]*GitWebHookArguments, error) {
+func (b *BitbucketServerProvider) ListWebHooks(owner string, repo string) ([]*GitWebHookArguments, error) {
 	webHooks := []*GitWebHookArguments{}
-	return webHooks, fmt.Errorf("not implemented!")
+	start := 0
+	for {
+		var page webHooksPage
+		path := fmt.Sprintf("/api/1.0/projects/%s/repos/%s/webhooks?start=%d", owner, repo, start)
+		_, err := b.restCall(http.MethodGet, path, nil, &page)
+		if err != nil {
+			return webHooks, err
+		}
+		for _, hook := range page.Values {
+			webHooks = append(webHooks, &GitWebHookArguments{
+				ID:     hook.ID,
+				Owner:  owner,
+				Repo:   &GitRepository{Name: repo},
+				URL:    hook.URL,
+				Secret: hook.Configuration["secret"],
+			})
+		}
+		if page.IsLastPage || page.NextPageStart <= start {
+			break
+		}
+		start = page.NextPageStart
+	}
+	return webHooks, nil
 }
 
-func (p *BitbucketServerProvider) UpdateWebHook(data *GitWebHookArguments) error {
-	return fmt.Errorf("not implemented!")
+func (b *BitbucketServerProvider) UpdateWebHook(data *GitWebHookArguments) error {
+	path := fmt.Sprintf("/api/1.0/projects/%s/repos/%s/webhooks/%d", data.Owner, data.Repo.Name, data.ID)
+	_, err := b.restCall(http.MethodPut, path, webHookOptions(data), nil)
+	return err
 }
 
 func (b *BitbucketServerProvider) SearchIssues(org string, name string, query string) ([]*GitIssue, error) {
@@ -754,10 +878,18 @@ func (b *BitbucketServerProvider) AddPRComment(pr *GitPullRequest, comment strin
 	}
 	n := *pr.Number
 
-	prComment := `{
-		"text": "` + comment + `"
-	}`
-	_, err := b.Client.DefaultApi.CreateComment_1(pr.Owner, pr.Repo, n, prComment, []string{"application/json"})
+	prComment, err := json.Marshal(map[string]string{"text": comment})
+	if err != nil {
+		return err
+	}
+	_, err = b.Client.DefaultApi.CreateComment_1(pr.Owner, pr.Repo, n, string(prComment), []string{"application/json"})
+	return err
+}
+
+// AddCommitComment adds a comment to the given commit
+func (b *BitbucketServerProvider) AddCommitComment(projectKey string, repo string, sha string, comment string) error {
+	path := fmt.Sprintf("/api/1.0/projects/%s/repos/%s/commits/%s/comments", projectKey, repo, sha)
+	_, err := b.restCall(http.MethodPost, path, map[string]string{"text": comment}, nil)
 	return err
 }
 
@@ -863,7 +995,22 @@ func (b *BitbucketServerProvider) AcceptInvitation(ID int64) (*github.Response,
 }
 
 func (b *BitbucketServerProvider) GetContent(org string, name string, path string, ref string) (*GitFileContent, error) {
-	return nil, fmt.Errorf("Getting content not supported on bitbucket")
+	rawPath := fmt.Sprintf("/api/1.0/projects/%s/repos/%s/raw/%s", org, name, path)
+	if ref != "" {
+		rawPath += "?at=" + url.QueryEscape(ref)
+	}
+	data, err := b.restCall(http.MethodGet, rawPath, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &GitFileContent{
+		Type:     "file",
+		Encoding: "base64",
+		Size:     len(data),
+		Name:     filepath.Base(path),
+		Path:     path,
+		Content:  base64.StdEncoding.EncodeToString(data),
+	}, nil
 }
 
 func BitBucketServerAccessTokenURL(url string) string {
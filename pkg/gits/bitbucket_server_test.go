@@ -58,14 +58,22 @@ var bitbucketServerRouter = util.Router{
 	"/rest/api/1.0/projects/TEST-ORG/repos/test-repo/pull-requests/1/comments": util.MethodMap{
 		"POST": "pr-comment.json",
 	},
+	"/rest/api/1.0/projects/TEST-ORG/repos/test-repo/commits/d6f24ee03d76a2caf0a4e1975fb43e8f61759b9c/comments": util.MethodMap{
+		"POST": "commit-comment.json",
+	},
 	"/rest/api/1.0/projects/TEST-ORG/repos/test-repo/webhooks": util.MethodMap{
+		"GET":  "webhooks.json",
 		"POST": "webhook.json",
 	},
+	"/rest/api/1.0/projects/TEST-ORG/repos/test-repo/webhooks/14": util.MethodMap{
+		"PUT": "webhook.json",
+	},
 	"/rest/api/1.0/users/test-user": util.MethodMap{
 		"GET": "user.json",
 	},
 	"/rest/build-status/1.0/commits/d6f24ee03d76a2caf0a4e1975fb43e8f61759b9c": util.MethodMap{
-		"GET": "build-statuses.json",
+		"GET":  "build-statuses.json",
+		"POST": "build-status-created.json",
 	},
 }
 
@@ -106,6 +114,7 @@ func (suite *BitbucketServerProviderTestSuite) SetupSuite() {
 
 	apiKeyAuthContext := context.WithValue(ctx, bitbucket.ContextAccessToken, ua.ApiToken)
 	suite.provider.Client = bitbucket.NewAPIClient(apiKeyAuthContext, cfg)
+	suite.provider.RestURL = suite.server.URL + "/rest"
 }
 
 func (suite *BitbucketServerProviderTestSuite) TestGetRepository() {
@@ -269,6 +278,19 @@ func (suite *BitbucketServerProviderTestSuite) TestListCommitStatuses() {
 	}
 }
 
+func (suite *BitbucketServerProviderTestSuite) TestUpdateCommitStatus() {
+	status := &gits.GitRepoStatus{
+		Context:   "jenkins-x",
+		State:     "success",
+		TargetURL: "https://my-jenkins.example.com/job/test-repo/1",
+	}
+	result, err := suite.provider.UpdateCommitStatus("TEST-ORG", "test-repo", "d6f24ee03d76a2caf0a4e1975fb43e8f61759b9c", status)
+	suite.Require().Nil(err)
+	suite.Require().Equal("success", result.State)
+	suite.Require().Equal("jenkins-x", result.ID)
+	suite.Require().Equal(status.TargetURL, result.TargetURL)
+}
+
 func (suite *BitbucketServerProviderTestSuite) TestMergePullRequest() {
 
 	id := 1
@@ -294,6 +316,19 @@ func (suite *BitbucketServerProviderTestSuite) TestCreateWebHook() {
 	suite.Require().Nil(err)
 }
 
+func (suite *BitbucketServerProviderTestSuite) TestListWebHooks() {
+	hooks, err := suite.provider.ListWebHooks("TEST-ORG", "test-repo")
+	suite.Require().Nil(err)
+	suite.Require().Len(hooks, 1)
+	suite.Require().Equal(int64(14), hooks[0].ID)
+	suite.Require().Equal("https://my-jenkins.example.com/bitbucket-webhook/", hooks[0].URL)
+	suite.Require().Equal("someSecret", hooks[0].Secret)
+
+	hooks[0].URL = "https://new-jenkins.example.com/bitbucket-webhook/"
+	err = suite.provider.UpdateWebHook(hooks[0])
+	suite.Require().Nil(err)
+}
+
 func (suite *BitbucketServerProviderTestSuite) TestUserInfo() {
 
 	userInfo := suite.provider.UserInfo("test-user")
@@ -337,6 +372,14 @@ func (suite *BitbucketServerProviderTestSuite) TestAddPRComment() {
 	suite.Require().Nil(err)
 }
 
+func (suite *BitbucketServerProviderTestSuite) TestAddCommitComment() {
+	err := suite.provider.AddCommitComment("TEST-ORG", "test-repo", "d6f24ee03d76a2caf0a4e1975fb43e8f61759b9c", "Deployed to staging.")
+	suite.Require().Nil(err)
+
+	err = suite.provider.AddCommitComment("TEST-ORG", "test-repo", "0000000000000000000000000000000000000000", "Deployed to staging.")
+	suite.Require().NotNil(err)
+}
+
 func TestBitbucketServerProviderTestSuite(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping TestBitbucketServerProviderTestSuite in short mode")
@@ -2,17 +2,20 @@ package gits
 
 import (
 	"bytes"
-	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
-	"github.com/jenkins-x/jx/pkg/util"
 	"strconv"
 	"strings"
+
+	"github.com/blang/semver"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/util"
 )
 
 type CommitInfo struct {
-	Kind    string
-	Feature string
-	Message string
-	group   *CommitGroup
+	Kind     string
+	Feature  string
+	Message  string
+	Breaking bool
+	group    *CommitGroup
 }
 
 type CommitGroup struct {
@@ -35,6 +38,8 @@ var (
 		"revert":   createCommitGroup("Reverts"),
 		"style":    createCommitGroup("Styles"),
 		"chore":    createCommitGroup("Chores"),
+		"build":    createCommitGroup("Build System"),
+		"ci":       createCommitGroup("Continuous Integration"),
 		"":         createCommitGroup(""),
 	}
 
@@ -59,16 +64,24 @@ func ConventionalCommitTypeToTitle(kind string) *CommitGroup {
 	return answer
 }
 
-// ParseCommit parses a conventional commit
+// ParseCommit parses a conventional commit such as 'feat: message' or 'fix!: message'. The 'feat:(scope) message'
+// format is also supported. Commits which do not start with a known type are kept as they are so that they are
+// listed as other changes.
 // see: https://conventionalcommits.org/
 func ParseCommit(message string) *CommitInfo {
 	answer := &CommitInfo{
-		Message: message,
+		Message:  message,
+		Breaking: strings.Contains(message, "BREAKING CHANGE"),
 	}
 
 	idx := strings.Index(message, ":")
 	if idx > 0 {
-		answer.Kind = message[0:idx]
+		kind := message[0:idx]
+		if ConventionalCommitTitles[commitType(kind)] == nil {
+			return answer
+		}
+		answer.Kind = strings.TrimSuffix(kind, "!")
+		answer.Breaking = answer.Breaking || strings.HasSuffix(kind, "!")
 
 		rest := strings.TrimSpace(message[idx+1:])
 		if strings.HasPrefix(rest, "(") {
@@ -83,9 +96,84 @@ func ParseCommit(message string) *CommitInfo {
 	return answer
 }
 
+// commitType returns the lower case type of the kind of a conventional commit without its scope or breaking
+// change marker, such as feat for 'feat(api)!'
+func commitType(kind string) string {
+	kind = strings.TrimSuffix(kind, "!")
+	idx := strings.Index(kind, "(")
+	if idx > 0 && strings.HasSuffix(kind, ")") {
+		kind = kind[0:idx]
+	}
+	return strings.ToLower(kind)
+}
+
+// LatestVersionTag returns the tag with the highest semantic version along with its version or nil if
+// there are no semantic version tags
+func LatestVersionTag(tags []string) (string, *semver.Version) {
+	return LatestPrefixedVersionTag(tags, "")
+}
+
+// LatestPrefixedVersionTag returns the tag with the given prefix and the highest semantic version after the prefix
+// along with its version or nil if there are no such tags. The applications of a monorepo use the prefix to
+// version their releases separately
+func LatestPrefixedVersionTag(tags []string, prefix string) (string, *semver.Version) {
+	answer := ""
+	var latest *semver.Version
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if !strings.HasPrefix(tag, prefix) {
+			continue
+		}
+		v, err := semver.ParseTolerant(strings.TrimPrefix(tag, prefix))
+		if err != nil {
+			continue
+		}
+		if latest == nil || v.GT(*latest) {
+			version := v
+			latest = &version
+			answer = tag
+		}
+	}
+	return answer, latest
+}
+
+// NextVersion returns the next semantic version based on the conventional commit messages since the current
+// version. Breaking changes bump the major version, features the minor version and anything else the patch version.
+// Breaking changes only bump the minor version before 1.0.0
+func NextVersion(current semver.Version, messages []string) semver.Version {
+	breaking := false
+	feature := false
+	for _, message := range messages {
+		ci := ParseCommit(message)
+		if ci.Breaking {
+			breaking = true
+		}
+		if commitType(ci.Kind) == "feat" {
+			feature = true
+		}
+	}
+	next := semver.Version{
+		Major: current.Major,
+		Minor: current.Minor,
+		Patch: current.Patch,
+	}
+	switch {
+	case breaking && current.Major > 0:
+		next.Major++
+		next.Minor = 0
+		next.Patch = 0
+	case breaking || feature:
+		next.Minor++
+		next.Patch = 0
+	default:
+		next.Patch++
+	}
+	return next
+}
+
 func (c *CommitInfo) Group() *CommitGroup {
 	if c.group == nil {
-		c.group = ConventionalCommitTitles[strings.ToLower(c.Kind)]
+		c.group = ConventionalCommitTitles[commitType(c.Kind)]
 	}
 	return c.group
 }
@@ -174,18 +262,17 @@ func GenerateMarkdown(releaseSpec *v1.ReleaseSpec, gitInfo *GitRepository) (stri
 		}
 	}
 
-	if len(issues) > 0 {
-		buffer.WriteString("\n### Issues\n\n")
-
-		previous := ""
-		for _, issue := range issues {
-			msg := describeIssue(gitInfo, &issue)
-			if msg != previous {
-				buffer.WriteString("* " + msg + "\n")
-				previous = msg
-			}
+	resolvedIssues := []v1.IssueSummary{}
+	otherIssues := []v1.IssueSummary{}
+	for _, issue := range issues {
+		if issue.IsClosed() {
+			resolvedIssues = append(resolvedIssues, issue)
+		} else {
+			otherIssues = append(otherIssues, issue)
 		}
 	}
+	writeIssues(&buffer, gitInfo, "Resolved Issues", resolvedIssues)
+	writeIssues(&buffer, gitInfo, "Issues", otherIssues)
 	if len(prs) > 0 {
 		buffer.WriteString("\n### Pull Requests\n\n")
 
@@ -201,6 +288,23 @@ func GenerateMarkdown(releaseSpec *v1.ReleaseSpec, gitInfo *GitRepository) (stri
 	return buffer.String(), nil
 }
 
+// writeIssues writes a section of the changelog listing the issues
+func writeIssues(buffer *bytes.Buffer, info *GitRepository, title string, issues []v1.IssueSummary) {
+	if len(issues) == 0 {
+		return
+	}
+	buffer.WriteString("\n### " + title + "\n\n")
+
+	previous := ""
+	for _, issue := range issues {
+		msg := describeIssue(info, &issue)
+		if msg != previous {
+			buffer.WriteString("* " + msg + "\n")
+			previous = msg
+		}
+	}
+}
+
 func describeIssue(info *GitRepository, issue *v1.IssueSummary) string {
 	return describeIssueShort(info, issue) + issue.Title + describeUser(info, issue.User)
 }
@@ -265,5 +369,8 @@ func describeCommit(info *GitRepository, cs *v1.CommitSummary, ci *CommitInfo, i
 			issueText += " " + describeIssueShort(info, issue)
 		}
 	}
+	if ci.Breaking {
+		prefix = "**BREAKING** " + prefix
+	}
 	return prefix + lines[0] + describeUser(info, user) + issueText
 }
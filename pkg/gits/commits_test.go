@@ -3,6 +3,8 @@ package gits_test
 import (
 	"testing"
 
+	"github.com/blang/semver"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/stretchr/testify/assert"
 )
@@ -26,6 +28,24 @@ func TestParseCommits(t *testing.T) {
 		Feature: "",
 		Message: "wine is good too",
 	})
+	assertParseCommit(t, "fix!: drop the old API", &gits.CommitInfo{
+		Kind:     "fix",
+		Message:  "drop the old API",
+		Breaking: true,
+	})
+	assertParseCommit(t, "feat(api)!: new endpoints", &gits.CommitInfo{
+		Kind:     "feat(api)",
+		Message:  "new endpoints",
+		Breaking: true,
+	})
+	assertParseCommit(t, "refactor: remove the old API\n\nBREAKING CHANGE: the old API is gone", &gits.CommitInfo{
+		Kind:     "refactor",
+		Message:  "remove the old API\n\nBREAKING CHANGE: the old API is gone",
+		Breaking: true,
+	})
+	assertParseCommit(t, "Update README: fix typo", &gits.CommitInfo{
+		Message: "Update README: fix typo",
+	})
 }
 
 func assertParseCommit(t *testing.T, input string, expected *gits.CommitInfo) {
@@ -36,3 +56,78 @@ func assertParseCommit(t *testing.T, input string, expected *gits.CommitInfo) {
 	assert.Equal(t, expected.Message, info.Message, "Message for Commit %s", info)
 	assert.Equal(t, expected, info, "CommitInfo for Commit %s", info)
 }
+
+func TestGenerateMarkdownResolvedIssues(t *testing.T) {
+	t.Parallel()
+	gitInfo, err := gits.ParseGitURL("https://github.com/myorg/myapp.git")
+	assert.NoError(t, err)
+
+	spec := &v1.ReleaseSpec{
+		Commits: []v1.CommitSummary{
+			{Message: "fix: handle empty carts ABC-1", IssueIDs: []string{"ABC-1"}},
+			{Message: "feat: faster checkout #2", IssueIDs: []string{"2"}},
+		},
+		Issues: []v1.IssueSummary{
+			{ID: "ABC-1", URL: "https://jira.example.com/browse/ABC-1", Title: "Empty carts fail", State: "Done"},
+			{ID: "2", URL: "https://github.com/myorg/myapp/issues/2", Title: "Slow checkout", State: "open"},
+		},
+	}
+	markdown, err := gits.GenerateMarkdown(spec, gitInfo)
+	assert.NoError(t, err)
+	assert.Contains(t, markdown, "\n### Resolved Issues\n\n* [ABC-1](https://jira.example.com/browse/ABC-1) Empty carts fail\n")
+	assert.Contains(t, markdown, "\n### Issues\n\n* [#2](https://github.com/myorg/myapp/issues/2) Slow checkout\n")
+	assert.Contains(t, markdown, "handle empty carts ABC-1 [ABC-1](https://jira.example.com/browse/ABC-1)")
+}
+
+func TestLatestVersionTag(t *testing.T) {
+	t.Parallel()
+
+	tag, version := gits.LatestVersionTag([]string{"v1.0.0", "latest", "v1.10.0", "v1.9.3", ""})
+	assert.Equal(t, "v1.10.0", tag)
+	if assert.NotNil(t, version) {
+		assert.Equal(t, "1.10.0", version.String())
+	}
+
+	tag, version = gits.LatestVersionTag([]string{"latest"})
+	assert.Equal(t, "", tag)
+	assert.Nil(t, version)
+}
+
+func TestLatestPrefixedVersionTag(t *testing.T) {
+	t.Parallel()
+
+	tags := []string{"v2.0.0", "orders-v1.0.0", "orders-v1.2.0", "payments-v3.0.0"}
+	tag, version := gits.LatestPrefixedVersionTag(tags, "orders-")
+	assert.Equal(t, "orders-v1.2.0", tag)
+	if assert.NotNil(t, version) {
+		assert.Equal(t, "1.2.0", version.String())
+	}
+
+	tag, _ = gits.LatestVersionTag(tags)
+	assert.Equal(t, "v2.0.0", tag)
+
+	tag, version = gits.LatestPrefixedVersionTag(tags, "cli-")
+	assert.Equal(t, "", tag)
+	assert.Nil(t, version)
+}
+
+func TestNextVersion(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		current  string
+		messages []string
+		expected string
+	}{
+		{"1.2.3", []string{"fix: a bug", "something regular"}, "1.2.4"},
+		{"1.2.3", []string{"fix: a bug", "feat(api): new endpoint"}, "1.3.0"},
+		{"1.2.3", []string{"feat!: new api"}, "2.0.0"},
+		{"1.2.3", []string{"refactor: tidy\n\nBREAKING CHANGE: removed the old api"}, "2.0.0"},
+		{"0.2.3", []string{"feat!: new api"}, "0.3.0"},
+	}
+	for _, tc := range testCases {
+		current := semver.MustParse(tc.current)
+		next := gits.NextVersion(current, tc.messages)
+		assert.Equal(t, tc.expected, next.String(), "next version of %s for %v", tc.current, tc.messages)
+	}
+}
@@ -379,6 +379,11 @@ func (g *GitCLI) FetchBranch(dir string, repo string, refspec string) error {
 	return g.gitCmd(dir, "fetch", repo, refspec)
 }
 
+// ResetHard resets the working tree and the current branch of the repository in the given directory to the given ref
+func (g *GitCLI) ResetHard(dir string, ref string) error {
+	return g.gitCmd(dir, "reset", "--hard", ref)
+}
+
 // GetAuthorEmailForCommit returns the author email from commit message with the given SHA
 func (g *GitCLI) GetAuthorEmailForCommit(dir string, sha string) (string, error) {
 	text, err := g.gitCmdWithOutput(dir, "show", "-s", "--format=%aE", sha)
@@ -529,7 +534,7 @@ func (g *GitCLI) GetLatestCommitMessage(dir string) (string, error) {
 
 // FetchTags fetches all the tags
 func (g *GitCLI) FetchTags(dir string) error {
-	return g.gitCmd("", "fetch", "--tags", "-v")
+	return g.gitCmd(dir, "fetch", "--tags", "-v")
 }
 
 // Tags returns all tags from the repository at the given directory
@@ -545,7 +550,7 @@ func (g *GitCLI) Tags(dir string) ([]string, error) {
 
 // CreateTag creates a tag with the given name and message in the repository at the given directory
 func (g *GitCLI) CreateTag(dir string, tag string, msg string) error {
-	return g.gitCmd("", "tag", "-fa", tag, "-m", msg)
+	return g.gitCmd(dir, "tag", "-fa", tag, "-m", msg)
 }
 
 // PrintCreateRepositoryGenerateAccessToken prints the access token URL of a Git repository
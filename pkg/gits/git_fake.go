@@ -311,6 +311,11 @@ func (g *GitFake) Checkout(dir string, branch string) error {
 	return nil
 }
 
+// ResetHard resets the current branch to the ref
+func (g *GitFake) ResetHard(dir string, ref string) error {
+	return nil
+}
+
 // CheckoutOrphan checkout the orphan
 func (g *GitFake) CheckoutOrphan(dir string, branch string) error {
 	g.CurrentBranch = branch
@@ -1,8 +1,9 @@
 package gits
 
 import (
-	"errors"
+	"encoding/base64"
 	"fmt"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -242,11 +243,48 @@ func (p *GiteaProvider) CreateWebHook(data *GitWebHookArguments) error {
 
 func (p *GiteaProvider) ListWebHooks(owner string, repo string) ([]*GitWebHookArguments, error) {
 	webHooks := []*GitWebHookArguments{}
-	return webHooks, fmt.Errorf("not implemented!")
+	if owner == "" {
+		owner = p.Username
+	}
+	hooks, err := p.Client.ListRepoHooks(owner, repo)
+	if err != nil {
+		return webHooks, err
+	}
+	for _, hook := range hooks {
+		webHooks = append(webHooks, &GitWebHookArguments{
+			ID:     hook.ID,
+			Owner:  owner,
+			Repo:   &GitRepository{Name: repo},
+			URL:    hook.Config["url"],
+			Secret: hook.Config["secret"],
+		})
+	}
+	return webHooks, nil
 }
 
 func (p *GiteaProvider) UpdateWebHook(data *GitWebHookArguments) error {
-	return fmt.Errorf("not implemented!")
+	owner := data.Owner
+	if owner == "" {
+		owner = p.Username
+	}
+	config := map[string]string{
+		"url":          data.URL,
+		"content_type": "json",
+	}
+	if data.Secret != "" {
+		config["secret"] = data.Secret
+	}
+	active := true
+	hook := gitea.EditHookOption{
+		Config: config,
+		Events: []string{"create", "push", "pull_request"},
+		Active: &active,
+	}
+	err := p.Client.EditRepoHook(owner, data.Repo.Name, data.ID, hook)
+	if err != nil {
+		return fmt.Errorf("Failed to update webhook %d for %s/%s due to: %s", data.ID, owner, data.Repo.Name, err)
+	}
+	return nil
 }
 
 func (p *GiteaProvider) CreatePullRequest(data *GitPullRequestArguments) (*GitPullRequest, error) {
@@ -536,8 +574,25 @@ func (p *GiteaProvider) ListCommitStatus(org string, repo string, sha string) ([
 	return answer, nil
 }
 
-func (b *GiteaProvider) UpdateCommitStatus(org string, repo string, sha string, status *GitRepoStatus) (*GitRepoStatus, error) {
-	return &GitRepoStatus{}, errors.New("TODO")
+func (p *GiteaProvider) UpdateCommitStatus(org string, repo string, sha string, status *GitRepoStatus) (*GitRepoStatus, error) {
+	opt := gitea.CreateStatusOption{
+		State:       gitea.StatusState(status.State),
+		TargetURL:   status.TargetURL,
+		Description: status.Description,
+		Context:     status.Context,
+	}
+	result, err := p.Client.CreateStatus(org, repo, sha, opt)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to update the status of %s/%s with ref %s due to: %s", org, repo, sha, err)
+	}
+	return &GitRepoStatus{
+		ID:          strconv.FormatInt(result.ID, 10),
+		Context:     result.Context,
+		URL:         result.URL,
+		TargetURL:   result.TargetURL,
+		State:       string(result.State),
+		Description: result.Description,
+	}, nil
 }
 
 func (p *GiteaProvider) RenameRepository(org string, name string, newName string) (*GitRepository, error) {
@@ -696,5 +751,19 @@ func (p *GiteaProvider) AcceptInvitation(ID int64) (*github.Response, error) {
 }
 
 func (p *GiteaProvider) GetContent(org string, name string, path string, ref string) (*GitFileContent, error) {
-	return nil, fmt.Errorf("Getting content not supported on gitea")
+	if ref == "" {
+		ref = "master"
+	}
+	data, err := p.Client.GetFile(org, name, ref, path)
+	if err != nil {
+		return nil, err
+	}
+	return &GitFileContent{
+		Type:     "file",
+		Encoding: "base64",
+		Size:     len(data),
+		Name:     filepath.Base(path),
+		Path:     path,
+		Content:  base64.StdEncoding.EncodeToString(data),
+	}, nil
 }
@@ -0,0 +1,211 @@
+package gits_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/gits"
+	mocks "github.com/jenkins-x/jx/pkg/gits/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// giteaRoute a fake response of the Gitea API
+type giteaRoute struct {
+	method string
+	status int
+	body   string
+}
+
+// createGiteaProvider creates a Gitea provider for the user test against a fake Gitea API serving the given routes.
+// The bodies of the requests are stored by path
+func createGiteaProvider(t *testing.T, routes map[string]giteaRoute) (gits.GitProvider, map[string]map[string]interface{}, func()) {
+	requests := map[string]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, ok := routes[r.URL.Path]
+		if !ok || route.method != r.Method {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Body != nil && r.ContentLength != 0 {
+			body := map[string]interface{}{}
+			err := json.NewDecoder(r.Body).Decode(&body)
+			assert.NoError(t, err, "decoding the body of %s %s", r.Method, r.URL.Path)
+			requests[r.URL.Path] = body
+		}
+		status := route.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprint(w, route.body)
+	}))
+
+	user := &auth.UserAuth{
+		Username: "test",
+		ApiToken: "test",
+	}
+	authServer := createAuthServer(server.URL, "Gitea", gits.KindGitea, user)
+	provider := createGitProvider(t, gits.KindGitea, authServer, user, mocks.NewMockGitter())
+	require.NotNil(t, provider)
+	return provider, requests, server.Close
+}
+
+func TestGiteaListWebHooks(t *testing.T) {
+	t.Parallel()
+
+	hooks := `[
+  {"id": 1, "type": "gitea", "config": {"url": "http://hook.jx.1.2.3.4.nip.io/hook", "content_type": "json", "secret": "s3cret"}, "events": ["push"], "active": true},
+  {"id": 2, "type": "gitea", "config": {"url": "https://ci.example.com/webhook", "content_type": "json"}, "events": ["push"], "active": true}
+]`
+	provider, _, cleanup := createGiteaProvider(t, map[string]giteaRoute{
+		"/api/v1/repos/myorg/myrepo/hooks": {method: http.MethodGet, body: hooks},
+		"/api/v1/repos/test/myrepo/hooks":  {method: http.MethodGet, body: "[]"},
+	})
+	defer cleanup()
+
+	webHooks, err := provider.ListWebHooks("myorg", "myrepo")
+	require.NoError(t, err)
+	assert.Equal(t, []*gits.GitWebHookArguments{
+		{
+			ID:     1,
+			Owner:  "myorg",
+			Repo:   &gits.GitRepository{Name: "myrepo"},
+			URL:    "http://hook.jx.1.2.3.4.nip.io/hook",
+			Secret: "s3cret",
+		},
+		{
+			ID:    2,
+			Owner: "myorg",
+			Repo:  &gits.GitRepository{Name: "myrepo"},
+			URL:   "https://ci.example.com/webhook",
+		},
+	}, webHooks)
+
+	webHooks, err = provider.ListWebHooks("", "myrepo")
+	require.NoError(t, err, "the repositories of the user should be used when no owner is given")
+	assert.Empty(t, webHooks)
+
+	_, err = provider.ListWebHooks("myorg", "missing")
+	assert.Error(t, err)
+}
+
+func TestGiteaUpdateWebHook(t *testing.T) {
+	t.Parallel()
+
+	provider, requests, cleanup := createGiteaProvider(t, map[string]giteaRoute{
+		"/api/v1/repos/myorg/myrepo/hooks/7": {method: http.MethodPatch, body: "{}"},
+	})
+	defer cleanup()
+
+	err := provider.UpdateWebHook(&gits.GitWebHookArguments{
+		ID:     7,
+		Owner:  "myorg",
+		Repo:   &gits.GitRepository{Name: "myrepo"},
+		URL:    "http://hook.jx.1.2.3.4.nip.io/hook",
+		Secret: "s3cret",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"config": map[string]interface{}{
+			"url":          "http://hook.jx.1.2.3.4.nip.io/hook",
+			"content_type": "json",
+			"secret":       "s3cret",
+		},
+		"events": []interface{}{"create", "push", "pull_request"},
+		"active": true,
+	}, requests["/api/v1/repos/myorg/myrepo/hooks/7"])
+
+	err = provider.UpdateWebHook(&gits.GitWebHookArguments{
+		ID:    8,
+		Owner: "myorg",
+		Repo:  &gits.GitRepository{Name: "myrepo"},
+		URL:   "http://hook.jx.1.2.3.4.nip.io/hook",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to update webhook 8 for myorg/myrepo")
+}
+
+func TestGiteaUpdateCommitStatus(t *testing.T) {
+	t.Parallel()
+
+	sha := "d6f24eea26a2bbbc9e8a7a6e8b1b4b2e6b5c2f36"
+	status := `{
+  "id": 42,
+  "state": "success",
+  "target_url": "http://jenkins.jx.1.2.3.4.nip.io/job/myorg/job/myrepo/job/master/1/",
+  "description": "the build passed",
+  "url": "https://gitea.example.com/api/v1/repos/myorg/myrepo/statuses/` + sha + `",
+  "context": "continuous-integration/jenkins"
+}`
+	provider, requests, cleanup := createGiteaProvider(t, map[string]giteaRoute{
+		"/api/v1/repos/myorg/myrepo/statuses/" + sha: {method: http.MethodPost, status: http.StatusCreated, body: status},
+	})
+	defer cleanup()
+
+	result, err := provider.UpdateCommitStatus("myorg", "myrepo", sha, &gits.GitRepoStatus{
+		State:       "success",
+		TargetURL:   "http://jenkins.jx.1.2.3.4.nip.io/job/myorg/job/myrepo/job/master/1/",
+		Description: "the build passed",
+		Context:     "continuous-integration/jenkins",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"state":       "success",
+		"target_url":  "http://jenkins.jx.1.2.3.4.nip.io/job/myorg/job/myrepo/job/master/1/",
+		"description": "the build passed",
+		"context":     "continuous-integration/jenkins",
+	}, requests["/api/v1/repos/myorg/myrepo/statuses/"+sha])
+	assert.Equal(t, &gits.GitRepoStatus{
+		ID:          "42",
+		Context:     "continuous-integration/jenkins",
+		URL:         "https://gitea.example.com/api/v1/repos/myorg/myrepo/statuses/" + sha,
+		TargetURL:   "http://jenkins.jx.1.2.3.4.nip.io/job/myorg/job/myrepo/job/master/1/",
+		State:       "success",
+		Description: "the build passed",
+	}, result)
+
+	_, err = provider.UpdateCommitStatus("myorg", "missing", sha, &gits.GitRepoStatus{State: "failure"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to update the status of myorg/missing with ref "+sha)
+}
+
+func TestGiteaGetContent(t *testing.T) {
+	t.Parallel()
+
+	values := "replicaCount: 2\n"
+	provider, _, cleanup := createGiteaProvider(t, map[string]giteaRoute{
+		"/api/v1/repos/myorg/myrepo/raw/master/charts/myrepo/values.yaml": {method: http.MethodGet, body: values},
+		"/api/v1/repos/myorg/myrepo/raw/v1.0.0/charts/myrepo/values.yaml": {method: http.MethodGet, body: "replicaCount: 1\n"},
+	})
+	defer cleanup()
+
+	testCases := []struct {
+		ref      string
+		expected string
+	}{
+		{"", values},
+		{"master", values},
+		{"v1.0.0", "replicaCount: 1\n"},
+	}
+	for _, tc := range testCases {
+		content, err := provider.GetContent("myorg", "myrepo", "charts/myrepo/values.yaml", tc.ref)
+		require.NoError(t, err, "ref %s", tc.ref)
+		assert.Equal(t, "values.yaml", content.Name)
+		assert.Equal(t, "charts/myrepo/values.yaml", content.Path)
+		assert.Equal(t, "base64", content.Encoding)
+		assert.Equal(t, len(tc.expected), content.Size)
+		decoded, err := base64.StdEncoding.DecodeString(content.Content)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, string(decoded), "ref %s", tc.ref)
+	}
+
+	_, err := provider.GetContent("myorg", "myrepo", "missing.yaml", "master")
+	assert.Error(t, err)
+}
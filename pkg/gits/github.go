@@ -368,6 +368,11 @@ func (p *GitHubProvider) ListWebHooks(owner string, repo string) ([]*GitWebHookA
 				Repo:  nil,
 				URL:   s,
 			}
+			// GitHub masks the secret of a hook but lets us know if it has one
+			secret, ok := hook.Config["secret"].(string)
+			if ok {
+				webHook.Secret = secret
+			}
 			webHooks = append(webHooks, webHook)
 		}
 	}
@@ -973,6 +978,19 @@ func (p *GitHubProvider) CreateIssue(owner string, repo string, issue *GitIssue)
 	return p.fromGithubIssue(owner, repo, number, i)
 }
 
+// AddIssueLabels adds the labels to the issue keeping its existing labels
+func (p *GitHubProvider) AddIssueLabels(owner string, repo string, number int, labels []string) error {
+	_, _, err := p.Client.Issues.AddLabelsToIssue(p.Context, owner, repo, number, labels)
+	return err
+}
+
+// CloseIssue closes the issue
+func (p *GitHubProvider) CloseIssue(owner string, repo string, number int) error {
+	state := "closed"
+	_, _, err := p.Client.Issues.Edit(p.Context, owner, repo, number, &github.IssueRequest{State: &state})
+	return err
+}
+
 func (p *GitHubProvider) fromGithubIssue(org string, name string, number int, i *github.Issue) (*GitIssue, error) {
 	isPull := i.IsPullRequest()
 	url := p.IssueURL(org, name, number, isPull)
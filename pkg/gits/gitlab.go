@@ -2,7 +2,6 @@ package gits
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -68,7 +67,26 @@ func (g *GitlabProvider) ListRepositories(org string) ([]*GitRepository, error)
 
 func (g *GitlabProvider) ListReleases(org string, name string) ([]*GitRelease, error) {
 	answer := []*GitRelease{}
-	// TODO
+	pid, err := g.projectId(org, g.Username, name)
+	if err != nil {
+		return answer, err
+	}
+	tags, _, err := g.Client.Tags.ListTags(pid, nil)
+	if err != nil {
+		return answer, err
+	}
+	for _, tag := range tags {
+		// GitLab stores releases as release notes on tags
+		if tag.Release == nil {
+			continue
+		}
+		answer = append(answer, &GitRelease{
+			Name:    tag.Name,
+			TagName: tag.Release.TagName,
+			Body:    tag.Release.Description,
+			HTMLURL: util.UrlJoin(g.Server.URL, owner(org, g.Username), name, "tags", tag.Name),
+		})
+	}
 	return answer, nil
 }
 
@@ -368,8 +386,33 @@ func (g *GitlabProvider) ListCommitStatus(org string, repo string, sha string) (
 	return statuses, nil
 }
 
-func (b *GitlabProvider) UpdateCommitStatus(org string, repo string, sha string, status *GitRepoStatus) (*GitRepoStatus, error) {
-	return &GitRepoStatus{}, errors.New("TODO")
+func (g *GitlabProvider) UpdateCommitStatus(org string, repo string, sha string, status *GitRepoStatus) (*GitRepoStatus, error) {
+	pid, err := g.projectId(org, g.Username, repo)
+	if err != nil {
+		return nil, err
+	}
+	opt := &gitlab.SetCommitStatusOptions{
+		State:       gitlab.BuildStateValue(toGitlabState(status.State)),
+		Name:        gitlab.String(status.Context),
+		TargetURL:   gitlab.String(status.TargetURL),
+		Description: gitlab.String(status.Description),
+	}
+	result, _, err := g.Client.Commits.SetCommitStatus(pid, sha, opt)
+	if err != nil {
+		return nil, err
+	}
+	return fromCommitStatus(result), nil
+}
+
+// toGitlabState converts the GitHub style status states to the states of GitLab
+func toGitlabState(state string) string {
+	switch state {
+	case "error", "failure":
+		return string(gitlab.Failed)
+	case "pending":
+		return string(gitlab.Pending)
+	}
+	return state
 }
 
 func fromCommitStatus(status *gitlab.CommitStatus) *GitRepoStatus {
@@ -396,27 +439,60 @@ func (g *GitlabProvider) MergePullRequest(pr *GitPullRequest, message string) er
 func (g *GitlabProvider) CreateWebHook(data *GitWebHookArguments) error {
 	pid, err := g.projectId(data.Owner, g.Username, data.Repo.Name)
 	if err != nil {
-		return nil
+		return err
 	}
 
-	owner := owner(g.Username, data.Owner)
+	owner := owner(data.Owner, g.Username)
 	webhookURL := util.UrlJoin(data.URL, owner, data.Repo.Name)
 	opt := &gitlab.AddProjectHookOptions{
-		URL:   &webhookURL,
-		Token: &data.Secret,
+		URL:                 &webhookURL,
+		Token:               &data.Secret,
+		PushEvents:          gitlab.Bool(true),
+		TagPushEvents:       gitlab.Bool(true),
+		MergeRequestsEvents: gitlab.Bool(true),
+		NoteEvents:          gitlab.Bool(true),
 	}
 
 	_, _, err = g.Client.Projects.AddProjectHook(pid, opt)
 	return err
 }
 
-func (p *GitlabProvider) ListWebHooks(owner string, repo string) ([]*GitWebHookArguments, error) {
+func (g *GitlabProvider) ListWebHooks(owner string, repo string) ([]*GitWebHookArguments, error) {
 	webHooks := []*GitWebHookArguments{}
-	return webHooks, fmt.Errorf("not implemented!")
+	pid, err := g.projectId(owner, g.Username, repo)
+	if err != nil {
+		return webHooks, err
+	}
+	hooks, _, err := g.Client.Projects.ListProjectHooks(pid, nil)
+	if err != nil {
+		return webHooks, err
+	}
+	for _, hook := range hooks {
+		webHooks = append(webHooks, &GitWebHookArguments{
+			ID:    int64(hook.ID),
+			Owner: owner,
+			Repo:  &GitRepository{Name: repo},
+			URL:   hook.URL,
+		})
+	}
+	return webHooks, nil
 }
 
-func (p *GitlabProvider) UpdateWebHook(data *GitWebHookArguments) error {
-	return fmt.Errorf("not implemented!")
+func (g *GitlabProvider) UpdateWebHook(data *GitWebHookArguments) error {
+	pid, err := g.projectId(data.Owner, g.Username, data.Repo.Name)
+	if err != nil {
+		return err
+	}
+	opt := &gitlab.EditProjectHookOptions{
+		URL:                 &data.URL,
+		Token:               &data.Secret,
+		PushEvents:          gitlab.Bool(true),
+		TagPushEvents:       gitlab.Bool(true),
+		MergeRequestsEvents: gitlab.Bool(true),
+		NoteEvents:          gitlab.Bool(true),
+	}
+	_, _, err = g.Client.Projects.EditProjectHook(pid, int(data.ID), opt)
+	return err
 }
 
 func (g *GitlabProvider) SearchIssues(org, repo, query string) ([]*GitIssue, error) {
@@ -487,6 +563,38 @@ func (g *GitlabProvider) CreateIssue(owner string, repo string, issue *GitIssue)
 	return fromGitlabIssue(gitlabIssue, owner, repo), nil
 }
 
+// AddIssueLabels adds the labels to the issue keeping its existing labels
+func (g *GitlabProvider) AddIssueLabels(owner string, repo string, number int, labels []string) error {
+	pid, err := g.projectId(owner, g.Username, repo)
+	if err != nil {
+		return err
+	}
+	issue, _, err := g.Client.Issues.GetIssue(pid, number)
+	if err != nil {
+		return err
+	}
+	// lets keep the existing labels as GitLab replaces the labels of the issue
+	answer := append([]string{}, issue.Labels...)
+	for _, label := range labels {
+		if util.StringArrayIndex(answer, label) < 0 {
+			answer = append(answer, label)
+		}
+	}
+	_, _, err = g.Client.Issues.UpdateIssue(pid, number, &gitlab.UpdateIssueOptions{Labels: answer})
+	return err
+}
+
+// CloseIssue closes the issue
+func (g *GitlabProvider) CloseIssue(owner string, repo string, number int) error {
+	pid, err := g.projectId(owner, g.Username, repo)
+	if err != nil {
+		return err
+	}
+	stateEvent := "close"
+	_, _, err = g.Client.Issues.UpdateIssue(pid, number, &gitlab.UpdateIssueOptions{StateEvent: &stateEvent})
+	return err
+}
+
 func fromGitlabIssues(issues []*gitlab.Issue, owner, repo string) []*GitIssue {
 	var result []*GitIssue
 
@@ -612,11 +720,28 @@ func (p *GitlabProvider) UserInfo(username string) *GitUser {
 }
 
 func (g *GitlabProvider) UpdateRelease(owner string, repo string, tag string, releaseInfo *GitRelease) error {
-	return nil
+	pid, err := g.projectId(owner, g.Username, repo)
+	if err != nil {
+		return err
+	}
+	t, _, err := g.Client.Tags.GetTag(pid, tag)
+	if err != nil {
+		return err
+	}
+	if t.Release == nil {
+		_, _, err = g.Client.Tags.CreateReleaseNote(pid, tag, &gitlab.CreateReleaseNoteOptions{Description: &releaseInfo.Body})
+	} else {
+		_, _, err = g.Client.Tags.UpdateReleaseNote(pid, tag, &gitlab.UpdateReleaseNoteOptions{Description: &releaseInfo.Body})
+	}
+	return err
 }
 
 func (p *GitlabProvider) IssueURL(org string, name string, number int, isPull bool) string {
-	return ""
+	kind := "issues"
+	if isPull {
+		kind = "merge_requests"
+	}
+	return util.UrlJoin(p.Server.URL, owner(org, p.Username), name, kind, strconv.Itoa(number))
 }
 
 func (p *GitlabProvider) AddCollaborator(user string, organisation string, repo string) error {
@@ -634,8 +759,24 @@ func (p *GitlabProvider) AcceptInvitation(ID int64) (*github.Response, error) {
 	return &github.Response{}, nil
 }
 
-func (p *GitlabProvider) GetContent(org string, name string, path string, ref string) (*GitFileContent, error) {
-	return nil, fmt.Errorf("Getting content not supported on gitlab")
+func (g *GitlabProvider) GetContent(org string, name string, path string, ref string) (*GitFileContent, error) {
+	pid, err := g.projectId(org, g.Username, name)
+	if err != nil {
+		return nil, err
+	}
+	f, _, err := g.Client.RepositoryFiles.GetFile(pid, path, &gitlab.GetFileOptions{Ref: gitlab.String(ref)})
+	if err != nil {
+		return nil, err
+	}
+	return &GitFileContent{
+		Type:     "file",
+		Encoding: f.Encoding,
+		Size:     f.Size,
+		Name:     f.FileName,
+		Path:     f.FilePath,
+		Content:  f.Content,
+		Sha:      f.BlobID,
+	}, nil
 }
 
 // GitlabAccessTokenURL returns the URL to click on to generate a personal access token for the Git provider
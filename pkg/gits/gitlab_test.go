@@ -97,6 +97,9 @@ func configureGitlabMock(suite *GitlabProviderSuite, mux *http.ServeMux) {
 		fmt.Sprintf("/api/v4/projects/%s", gitlabProjectID): util.MethodMap{
 			"GET": "project.json",
 		},
+		"/api/v4/projects/5860291/hooks": util.MethodMap{
+			"GET": "hooks.json",
+		},
 	}
 	for path, methodMap := range gitlabRouter {
 		mux.HandleFunc(path, util.GetMockAPIResponseFromFile("test_data/gitlab", methodMap))
@@ -172,6 +175,20 @@ func (suite *GitlabProviderSuite) TestAcceptInvitations() {
 	suite.Require().Nil(err)
 }
 
+func (suite *GitlabProviderSuite) TestListWebHooks() {
+	hooks, err := suite.provider.ListWebHooks("", "userproject")
+
+	suite.Require().Nil(err)
+	suite.Require().Len(hooks, 1)
+	suite.Require().Equal(int64(1), hooks[0].ID)
+	suite.Require().Equal("http://hook.jenkins-x.io/testperson/userproject", hooks[0].URL)
+}
+
+func (suite *GitlabProviderSuite) TestIssueURL() {
+	suite.Require().Equal(suite.server.URL+"/testorg/orgproject/merge_requests/3", suite.provider.IssueURL(gitlabOrgName, "orgproject", 3, true))
+	suite.Require().Equal(suite.server.URL+"/testperson/userproject/issues/2", suite.provider.IssueURL("", "userproject", 2, false))
+}
+
 // In order for 'go test' to run this suite, we need to create
 // a normal test function and pass our suite to suite.Run
 func TestGitlabProviderSuite(t *testing.T) {
@@ -21,6 +21,15 @@ type OrganisationChecker interface {
 	IsUserInOrganisation(user string, organisation string) (bool, error)
 }
 
+// IssueUpdater is implemented by the git providers which can label and close issues
+type IssueUpdater interface {
+	// AddIssueLabels adds the labels to the issue keeping its existing labels
+	AddIssueLabels(owner string, repo string, number int, labels []string) error
+
+	// CloseIssue closes the issue
+	CloseIssue(owner string, repo string, number int) error
+}
+
 // GitProvider is the interface for abstracting use of different git provider APIs
 //go:generate pegomock generate github.com/jenkins-x/jx/pkg/gits GitProvider -o mocks/git_provider.go --generate-matchers
 type GitProvider interface {
@@ -191,6 +200,7 @@ type Gitter interface {
 	CheckoutOrphan(dir string, branch string) error
 	ConvertToValidBranchName(name string) string
 	FetchBranch(dir string, repo string, refspec string) error
+	ResetHard(dir string, ref string) error
 
 	Stash(dir string) error
 
@@ -188,6 +188,24 @@ func (pr *GitPullRequest) IsClosed() bool {
 	return pr.ClosedAt != nil
 }
 
+// IsFinished returns true if the PullRequest has been merged, closed, declined or superseded so that any
+// resources created for it, such as preview environments, can be removed
+func (pr *GitPullRequest) IsFinished() bool {
+	if pr.IsClosed() || (pr.Merged != nil && *pr.Merged) {
+		return true
+	}
+	if pr.State == nil {
+		return false
+	}
+	state := strings.ToLower(*pr.State)
+	for _, prefix := range []string{"clos", "merged", "superseded", "declined"} {
+		if strings.HasPrefix(state, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // NumberString returns the string representation of the Pull Request number or blank if its missing
 func (pr *GitPullRequest) NumberString() string {
 	n := pr.Number
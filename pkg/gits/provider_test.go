@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/jenkins-x/jx/pkg/auth"
 	"github.com/jenkins-x/jx/pkg/gits"
@@ -831,3 +832,32 @@ func assertProvider(t *testing.T, want gits.GitProvider, result gits.GitProvider
 	assert.Equal(t, want.ServerURL(), result.ServerURL())
 	assert.Equal(t, want.UserAuth(), result.UserAuth())
 }
+
+func TestPullRequestIsFinished(t *testing.T) {
+	t.Parallel()
+
+	merged := true
+	now := time.Now()
+	testCases := []struct {
+		state    string
+		pr       gits.GitPullRequest
+		expected bool
+	}{
+		{"open", gits.GitPullRequest{State: stringPtr("open")}, false},
+		{"OPEN", gits.GitPullRequest{State: stringPtr("OPEN")}, false},
+		{"closed", gits.GitPullRequest{State: stringPtr("closed")}, true},
+		{"MERGED", gits.GitPullRequest{State: stringPtr("MERGED")}, true},
+		{"DECLINED", gits.GitPullRequest{State: stringPtr("DECLINED")}, true},
+		{"SUPERSEDED", gits.GitPullRequest{State: stringPtr("SUPERSEDED")}, true},
+		{"merged flag", gits.GitPullRequest{Merged: &merged}, true},
+		{"closed at", gits.GitPullRequest{ClosedAt: &now}, true},
+		{"no state", gits.GitPullRequest{}, false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, tc.pr.IsFinished(), "pull request %s", tc.state)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -58,6 +58,34 @@ func NewHelmCLI(binary string, version Version, cwd string, debug bool, args ...
 	return cli
 }
 
+// DetectVersion returns the major version of the given helm binary. The binary is asked for its version unless it is
+// the helm3 binary installed by jx. If the version cannot be found helm 2 is assumed
+func DetectVersion(binary string) Version {
+	if filepath.Base(binary) == "helm3" {
+		return V3
+	}
+	cmd := &util.Command{
+		Name: binary,
+		Args: []string{"version", "--client", "--short"},
+	}
+	output, err := cmd.RunWithoutRetry()
+	if err != nil {
+		return V2
+	}
+	return ParseVersion(output)
+}
+
+// ParseVersion returns the major version of helm from the output of the helm version command
+func ParseVersion(output string) Version {
+	text := strings.TrimSpace(output)
+	text = strings.TrimSpace(strings.TrimPrefix(text, "Client:"))
+	text = strings.TrimPrefix(text, "v")
+	if strings.HasPrefix(text, "3.") {
+		return V3
+	}
+	return V2
+}
+
 // SetHost is used to point at a locally running tiller
 func (h *HelmCLI) SetHost(tillerAddress string) {
 	if h.Debug {
@@ -98,6 +126,13 @@ func (h *HelmCLI) runHelmWithOutput(args ...string) (string, error) {
 
 // Init executes the helm init command according with the given flags
 func (h *HelmCLI) Init(clientOnly bool, serviceAccount string, tillerNamespace string, upgrade bool) error {
+	if h.BinVersion == V3 {
+		// helm 3 has no tiller and no init command so there is nothing to initialise
+		if h.Debug {
+			log.Infof("Skipping the initialisation of Helm 3\n")
+		}
+		return nil
+	}
 	args := []string{}
 	args = append(args, "init")
 	if clientOnly {
@@ -114,7 +149,7 @@ func (h *HelmCLI) Init(clientOnly bool, serviceAccount string, tillerNamespace s
 	}
 
 	if h.Debug {
-		log.Infof("Initialising Helm '%s'\n", util.ColorInfo(strings.Join(args, " ")))
+		log.Infof("Initialising Helm '%s'\n", util.ColorInfo(strings.Join(util.RedactArgs(args), " ")))
 	}
 
 	return h.runHelm(args...)
@@ -236,14 +271,18 @@ func (h *HelmCLI) BuildDependency() error {
 func (h *HelmCLI) InstallChart(chart string, releaseName string, ns string, version *string, timeout *int,
 	values []string, valueFiles []string, repo string, username string, password string) error {
 	args := []string{}
-	args = append(args, "install", "--wait", "--name", releaseName, "--namespace", ns, chart)
+	if h.BinVersion == V3 {
+		args = append(args, "install", "--wait", "--namespace", ns, releaseName, chart)
+	} else {
+		args = append(args, "install", "--wait", "--name", releaseName, "--namespace", ns, chart)
+	}
 	repo, err := addUsernamePasswordToURL(repo, username, password)
 	if err != nil {
 		return err
 	}
 
 	if timeout != nil {
-		args = append(args, "--timeout", strconv.Itoa(*timeout))
+		args = append(args, "--timeout", h.timeoutArg(*timeout))
 	}
 	if version != nil {
 		args = append(args, "--version", *version)
@@ -264,7 +303,7 @@ func (h *HelmCLI) InstallChart(chart string, releaseName string, ns string, vers
 		args = append(args, "--password", password)
 	}
 	if h.Debug {
-		log.Infof("Installing Chart '%s'\n", util.ColorInfo(strings.Join(args, " ")))
+		log.Infof("Installing Chart '%s'\n", util.ColorInfo(strings.Join(util.RedactArgs(args), " ")))
 	}
 
 	return h.runHelm(args...)
@@ -303,7 +342,7 @@ func (h *HelmCLI) FetchChart(chart string, version *string, untar bool, untardir
 	}
 
 	if h.Debug {
-		log.Infof("Fetching Chart '%s'\n", util.ColorInfo(strings.Join(args, " ")))
+		log.Infof("Fetching Chart '%s'\n", util.ColorInfo(strings.Join(util.RedactArgs(args), " ")))
 	}
 
 	return h.runHelm(args...)
@@ -313,6 +352,9 @@ func (h *HelmCLI) FetchChart(chart string, version *string, untar bool, untardir
 func (h *HelmCLI) Template(chart string, releaseName string, ns string, outDir string, upgrade bool,
 	values []string, valueFiles []string) error {
 	args := []string{"template", "--name", releaseName, "--namespace", ns, chart, "--output-dir", outDir, "--debug"}
+	if h.BinVersion == V3 {
+		args = []string{"template", releaseName, chart, "--namespace", ns, "--output-dir", outDir, "--debug"}
+	}
 	if upgrade {
 		args = append(args, "--is-upgrade")
 	}
@@ -324,11 +366,11 @@ func (h *HelmCLI) Template(chart string, releaseName string, ns string, outDir s
 	}
 
 	if h.Debug {
-		log.Infof("Generating Chart Template '%s'\n", util.ColorInfo(strings.Join(args, " ")))
+		log.Infof("Generating Chart Template '%s'\n", util.ColorInfo(strings.Join(util.RedactArgs(args), " ")))
 	}
 	err := h.runHelm(args...)
 	if err != nil {
-		return errors.Wrapf(err, "Failed to run helm %s", strings.Join(args, " "))
+		return errors.Wrapf(err, "Failed to run helm %s", strings.Join(util.RedactArgs(args), " "))
 	}
 	return err
 }
@@ -355,7 +397,7 @@ func (h *HelmCLI) UpgradeChart(chart string, releaseName string, ns string, vers
 		args = append(args, "--force")
 	}
 	if timeout != nil {
-		args = append(args, "--timeout", strconv.Itoa(*timeout))
+		args = append(args, "--timeout", h.timeoutArg(*timeout))
 	}
 	if version != nil {
 		args = append(args, "--version", *version)
@@ -378,25 +420,78 @@ func (h *HelmCLI) UpgradeChart(chart string, releaseName string, ns string, vers
 	args = append(args, releaseName, chart)
 
 	if h.Debug {
-		log.Infof("Upgrading Chart '%s'\n", util.ColorInfo(strings.Join(args, " ")))
+		log.Infof("Upgrading Chart '%s'\n", util.ColorInfo(strings.Join(util.RedactArgs(args), " ")))
 	}
 
 	return h.runHelm(args...)
 }
 
-// DeleteRelease removes the given release
+// timeoutArg returns the value of the --timeout flag for the given number of seconds. Helm 3 expects a duration
+func (h *HelmCLI) timeoutArg(seconds int) string {
+	if h.BinVersion == V3 {
+		return strconv.Itoa(seconds) + "s"
+	}
+	return strconv.Itoa(seconds)
+}
+
+// namespaceArgs returns the --namespace flag for the helm 3 commands which act on a release. Helm 2 finds releases
+// via tiller so its commands do not take a namespace
+func (h *HelmCLI) namespaceArgs(ns string) []string {
+	if h.BinVersion == V3 && ns != "" {
+		return []string{"--namespace", ns}
+	}
+	return nil
+}
+
+// DiffChart returns the changes an upgrade of the release to the given chart would make using the helm diff plugin.
+// The values of Secrets are not shown as the diff usually ends up in the pipeline logs
+func (h *HelmCLI) DiffChart(chart string, releaseName string, ns string, values []string, valueFiles []string) (string, error) {
+	args := []string{"diff", "upgrade", "--allow-unreleased", "--suppress-secrets"}
+	if ns != "" {
+		args = append(args, "--namespace", ns)
+	}
+	args = append(args, releaseName, chart)
+	for _, value := range values {
+		args = append(args, "--set", value)
+	}
+	for _, valueFile := range valueFiles {
+		args = append(args, "--values", valueFile)
+	}
+	if h.Debug {
+		log.Infof("Diffing Chart '%s'\n", util.ColorInfo(strings.Join(util.RedactArgs(args), " ")))
+	}
+	output, err := h.runHelmWithOutput(args...)
+	if err != nil {
+		return output, errors.Wrapf(err, "failed to run helm %s. Is the helm diff plugin installed?", strings.Join(util.RedactArgs(args), " "))
+	}
+	return output, nil
+}
+
+// DeleteRelease removes the given release. Releases are always purged by helm 3
 func (h *HelmCLI) DeleteRelease(ns string, releaseName string, purge bool) error {
 	args := []string{}
 	args = append(args, "delete")
-	if purge {
+	if purge && h.BinVersion != V3 {
 		args = append(args, "--purge")
 	}
+	args = append(args, h.namespaceArgs(ns)...)
 	args = append(args, releaseName)
 	return h.runHelm(args...)
 }
 
-// ListCharts execute the helm list command and returns its output
+// RollbackRelease rolls back the release to the given revision. A revision of 0 rolls back to the previous revision
+func (h *HelmCLI) RollbackRelease(ns string, releaseName string, revision int) error {
+	args := []string{"rollback"}
+	args = append(args, h.namespaceArgs(ns)...)
+	args = append(args, releaseName, strconv.Itoa(revision))
+	return h.runHelm(args...)
+}
+
+// ListCharts execute the helm list command and returns its output. The releases of all the namespaces are listed
 func (h *HelmCLI) ListCharts() (string, error) {
+	if h.BinVersion == V3 {
+		return h.runHelmWithOutput("list", "--all-namespaces")
+	}
 	return h.runHelmWithOutput("list")
 }
 
@@ -456,36 +551,76 @@ func (h *HelmCLI) FindChart() (string, error) {
 
 // StatusRelease returns the output of the helm status command for a given release
 func (h *HelmCLI) StatusRelease(ns string, releaseName string) error {
-	return h.runHelm("status", releaseName)
+	args := []string{"status"}
+	args = append(args, h.namespaceArgs(ns)...)
+	args = append(args, releaseName)
+	return h.runHelm(args...)
 }
 
 // StatusReleases returns the status of all installed releases
 func (h *HelmCLI) StatusReleases(ns string) (map[string]Release, error) {
-	output, err := h.ListCharts()
+	var output string
+	var err error
+	if h.BinVersion == V3 {
+		output, err = h.runHelmWithOutput("list", "--namespace", ns)
+	} else {
+		output, err = h.ListCharts()
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list the installed chart releases")
 	}
-	lines := strings.Split(output, "\n")
+	return ParseReleases(output), nil
+}
+
+// ParseReleases parses the output of the helm list command. The columns are found from the header as helm 2 and
+// helm 3 order them differently
+func ParseReleases(output string) map[string]Release {
 	statusMap := map[string]Release{}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	columns := map[string]int{}
+	for i, column := range strings.Split(lines[0], "\t") {
+		columns[strings.TrimSpace(column)] = i
+	}
+	nameIdx, statusIdx, chartIdx := columns["NAME"], columns["STATUS"], columns["CHART"]
+	namespaceIdx, hasNamespace := columns["NAMESPACE"]
+	if statusIdx == 0 || chartIdx == 0 {
+		return statusMap
+	}
 	for _, line := range lines[1:] {
 		fields := strings.Split(line, "\t")
-		if len(fields) > 3 {
-			release := strings.TrimSpace(fields[0])
+		if len(fields) <= statusIdx || len(fields) <= chartIdx {
+			continue
+		}
+		release := strings.TrimSpace(fields[nameIdx])
 
-			versionRaw := strings.TrimSpace(fields[4])
-			versionRawSplit := strings.Split(versionRaw, "-")
-			version := versionRawSplit[len(versionRawSplit)-1]
+		versionRaw := strings.TrimSpace(fields[chartIdx])
+		versionRawSplit := strings.Split(versionRaw, "-")
+		version := versionRawSplit[len(versionRawSplit)-1]
 
-			helmRelease := Release{
-				Release: strings.TrimSpace(fields[0]),
-				Status:  strings.TrimSpace(fields[3]),
-				Version: version,
-			}
+		namespace := ""
+		if hasNamespace && len(fields) > namespaceIdx {
+			namespace = strings.TrimSpace(fields[namespaceIdx])
+		}
+		statusMap[release] = Release{
+			Release:   release,
+			Status:    strings.TrimSpace(fields[statusIdx]),
+			Version:   version,
+			Namespace: namespace,
+		}
+	}
+	return statusMap
+}
 
-			statusMap[release] = helmRelease
+// ReleasesInNamespace returns the releases which are installed in the given namespace. Helm 2 lists the releases of
+// all the namespaces whichever namespace is asked for
+func ReleasesInNamespace(releases map[string]Release, ns string) map[string]Release {
+	answer := map[string]Release{}
+	for name, release := range releases {
+		if release.Namespace == ns {
+			answer[name] = release
 		}
 	}
-	return statusMap, nil
+	return answer
 }
 
 // Lint lints the helm chart from the current working directory and returns the warnings in the output
@@ -506,7 +641,7 @@ func (h *HelmCLI) Version(tls bool) (string, error) {
 // Version executes the helm version command and returns its output
 func (h *HelmCLI) VersionWithArgs(tls bool, extraArgs ...string) (string, error) {
 	args := []string{"version", "--short"}
-	if tls {
+	if tls && h.BinVersion != V3 {
 		args = append(args, "--tls")
 	}
 	args = append(args, extraArgs...)
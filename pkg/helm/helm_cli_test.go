@@ -303,3 +303,149 @@ func TestPackage(t *testing.T) {
 	assert.NoError(t, err, "should package chart without any error")
 	verifyArgs(t, helm, runner, expectedArgs...)
 }
+
+func createHelm3(t *testing.T, expectedError error, expectedOutput string) (*helm.HelmCLI, *mocks.MockCommander) {
+	RegisterMockTestingT(t)
+	runner := mocks.NewMockCommander()
+	When(runner.RunWithoutRetry()).ThenReturn(expectedOutput, expectedError)
+	cli := helm.NewHelmCLIWithRunner(runner, "helm3", helm.V3, cwd, true)
+	return cli, runner
+}
+
+func TestInitHelm3(t *testing.T) {
+	helm, runner := createHelm3(t, nil, "")
+
+	err := helm.Init(true, serviceAccount, namespace, true)
+
+	assert.NoError(t, err, "should init helm 3 without any error")
+	runner.VerifyWasCalled(Never()).RunWithoutRetry()
+}
+
+func TestInstallChartHelm3(t *testing.T) {
+	value := []string{"test"}
+	expectedArgs := []string{"install", "--wait", "--namespace", namespace, releaseName, chart, "--set", value[0]}
+	helm, runner := createHelm3(t, nil, "")
+
+	err := helm.InstallChart(chart, releaseName, namespace, nil, nil, value, nil, "", "", "")
+	assert.NoError(t, err, "should install the chart with helm 3 without any error")
+	verifyArgs(t, helm, runner, expectedArgs...)
+}
+
+func TestTemplateHelm3(t *testing.T) {
+	expectedArgs := []string{"template", releaseName, chart, "--namespace", namespace, "--output-dir", "out", "--debug"}
+	helm, runner := createHelm3(t, nil, "")
+
+	err := helm.Template(chart, releaseName, namespace, "out", false, nil, nil)
+	assert.NoError(t, err, "should render the chart with helm 3 without any error")
+	verifyArgs(t, helm, runner, expectedArgs...)
+}
+
+func TestDeleteReleaseHelm3(t *testing.T) {
+	expectedArgs := []string{"delete", "--namespace", namespace, releaseName}
+	helm, runner := createHelm3(t, nil, "")
+
+	err := helm.DeleteRelease(namespace, releaseName, true)
+	assert.NoError(t, err, "should delete the release with helm 3 without any error")
+	verifyArgs(t, helm, runner, expectedArgs...)
+}
+
+func TestUpgradeChartHelm3(t *testing.T) {
+	timeout := 600
+	expectedArgs := []string{"upgrade", "--namespace", namespace, "--install", "--wait", "--timeout", "600s",
+		releaseName, chart}
+	helm, runner := createHelm3(t, nil, "")
+
+	err := helm.UpgradeChart(chart, releaseName, namespace, nil, true, &timeout, false, true, nil, nil, "", "", "")
+	assert.NoError(t, err, "should upgrade the chart with helm 3 without any error")
+	verifyArgs(t, helm, runner, expectedArgs...)
+}
+
+func TestInstallChartTimeoutHelm3(t *testing.T) {
+	timeout := 600
+	expectedArgs := []string{"install", "--wait", "--namespace", namespace, releaseName, chart, "--timeout", "600s"}
+	helm, runner := createHelm3(t, nil, "")
+
+	err := helm.InstallChart(chart, releaseName, namespace, nil, &timeout, nil, nil, "", "", "")
+	assert.NoError(t, err, "should install the chart with helm 3 without any error")
+	verifyArgs(t, helm, runner, expectedArgs...)
+}
+
+func TestStatusReleaseHelm3(t *testing.T) {
+	expectedArgs := []string{"status", "--namespace", namespace, releaseName}
+	helm, runner := createHelm3(t, nil, "")
+
+	err := helm.StatusRelease(namespace, releaseName)
+	assert.NoError(t, err, "should get the status of the release with helm 3 without any error")
+	verifyArgs(t, helm, runner, expectedArgs...)
+}
+
+func TestRollbackReleaseHelm3(t *testing.T) {
+	expectedArgs := []string{"rollback", "--namespace", namespace, releaseName, "2"}
+	helm, runner := createHelm3(t, nil, "")
+
+	err := helm.RollbackRelease(namespace, releaseName, 2)
+	assert.NoError(t, err, "should roll back the release with helm 3 without any error")
+	verifyArgs(t, helm, runner, expectedArgs...)
+}
+
+func TestListChartsHelm3(t *testing.T) {
+	expectedArgs := []string{"list", "--all-namespaces"}
+	helm, runner := createHelm3(t, nil, "")
+
+	_, err := helm.ListCharts()
+	assert.NoError(t, err, "should list the releases with helm 3 without any error")
+	verifyArgs(t, helm, runner, expectedArgs...)
+}
+
+func TestStatusReleasesHelm3(t *testing.T) {
+	output := "NAME     \tNAMESPACE     \tREVISION\tUPDATED                              \tSTATUS  \tCHART       \n" +
+		"jxing    \ttest-namespace\t1       \t2019-03-20 10:55:09.113 +0000 UTC\tdeployed\tnginx-ingress-0.20.1\n"
+	expectedArgs := []string{"list", "--namespace", namespace}
+	cli, runner := createHelm3(t, nil, output)
+
+	statusMap, err := cli.StatusReleases(namespace)
+	assert.NoError(t, err, "should list the release statuses with helm 3 without any error")
+	verifyArgs(t, cli, runner, expectedArgs...)
+	assert.Equal(t, map[string]helm.Release{
+		"jxing": {Release: "jxing", Status: "deployed", Version: "0.20.1", Namespace: "test-namespace"},
+	}, statusMap)
+}
+
+func TestReleasesInNamespace(t *testing.T) {
+	t.Parallel()
+	output := "NAME         \tREVISION\tUPDATED                 \tSTATUS  \tCHART             \tNAMESPACE    \n" +
+		"jenkins-x    \t1       \tMon Jul  2 16:16:20 2018\tDEPLOYED\tjenkins-x-platform-0.0.1655\tjx           \n" +
+		"jx-staging   \t1       \tMon Jul  2 16:21:06 2018\tDEPLOYED\tenv-0.0.1         \tjx-staging   \n" +
+		"jx-production\t1       \tMon Jul  2 16:22:47 2018\tDEPLOYED\tenv-0.0.1         \tjx-production\n"
+	releases := helm.ParseReleases(output)
+	assert.Len(t, releases, 3)
+
+	assert.Equal(t, map[string]helm.Release{
+		"jx-staging": {Release: "jx-staging", Status: "DEPLOYED", Version: "0.0.1", Namespace: "jx-staging"},
+	}, helm.ReleasesInNamespace(releases, "jx-staging"))
+	assert.Empty(t, helm.ReleasesInNamespace(releases, "cheese"))
+}
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, helm.V2, helm.ParseVersion("Client: v2.11.0+g2e55dbe\n"))
+	assert.Equal(t, helm.V2, helm.ParseVersion("Client: v2.11.0+g2e55dbe\nServer: v2.11.0+g2e55dbe\n"))
+	assert.Equal(t, helm.V3, helm.ParseVersion("v3.0.0-alpha.1+g2e55dbe\n"))
+	assert.Equal(t, helm.V2, helm.ParseVersion(""))
+}
+
+func TestDetectVersionOfHelm3Binary(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, helm.V3, helm.DetectVersion("/home/jx/bin/helm3"))
+}
+
+func TestDiffChart(t *testing.T) {
+	valueFile := []string{"./myvalues.yaml"}
+	expectedArgs := []string{"diff", "upgrade", "--allow-unreleased", "--suppress-secrets", "--namespace", namespace, releaseName, chart, "--values", valueFile[0]}
+	helm, runner := createHelm(t, nil, "some changes")
+
+	output, err := helm.DiffChart(chart, releaseName, namespace, nil, valueFile)
+	assert.NoError(t, err, "should diff the chart without any error")
+	assert.Equal(t, "some changes", output)
+	verifyArgs(t, helm, runner, expectedArgs...)
+}
@@ -121,6 +121,48 @@ func (r *Requirements) RemoveApplication(app string) bool {
 	return false
 }
 
+// DependencyChange describes how the version of a dependency changes between two requirements
+type DependencyChange struct {
+	Name        string
+	FromVersion string
+	ToVersion   string
+}
+
+// DiffRequirements returns the dependencies which are added, removed or change version between the two requirements
+// sorted by name. An empty version indicates the dependency is missing from that side
+func DiffRequirements(from *Requirements, to *Requirements) []DependencyChange {
+	versions := func(r *Requirements) map[string]string {
+		answer := map[string]string{}
+		if r != nil {
+			for _, dep := range r.Dependencies {
+				if dep != nil {
+					answer[dep.Name] = dep.Version
+				}
+			}
+		}
+		return answer
+	}
+	fromVersions := versions(from)
+	toVersions := versions(to)
+
+	changes := []DependencyChange{}
+	for name, fromVersion := range fromVersions {
+		toVersion := toVersions[name]
+		if fromVersion != toVersion {
+			changes = append(changes, DependencyChange{Name: name, FromVersion: fromVersion, ToVersion: toVersion})
+		}
+	}
+	for name, toVersion := range toVersions {
+		if _, ok := fromVersions[name]; !ok {
+			changes = append(changes, DependencyChange{Name: name, ToVersion: toVersion})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
 // FindRequirementsFileName returns the default requirements.yaml file name
 func FindRequirementsFileName(dir string) (string, error) {
 	names := []string{
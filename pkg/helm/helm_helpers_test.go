@@ -2,6 +2,7 @@ package helm_test
 
 import (
 	"fmt"
+	"github.com/jenkins-x/jx/pkg/helm"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/magiconair/properties/assert"
 	"testing"
@@ -69,3 +70,28 @@ func assertCombineMapTrees(t *testing.T, expected map[string]interface{}, destin
 func mapToString(m map[string]interface{}) string {
 	return fmt.Sprintf("%#v", m)
 }
+
+func TestDiffRequirements(t *testing.T) {
+	t.Parallel()
+
+	from := &helm.Requirements{
+		Dependencies: []*helm.Dependency{
+			{Name: "jenkins", Version: "0.16.1"},
+			{Name: "nexus", Version: "0.1.10"},
+			{Name: "monocular", Version: "0.6.2"},
+		},
+	}
+	to := &helm.Requirements{
+		Dependencies: []*helm.Dependency{
+			{Name: "jenkins", Version: "0.16.3"},
+			{Name: "nexus", Version: "0.1.10"},
+			{Name: "chartmuseum", Version: "1.7.0"},
+		},
+	}
+	changes := helm.DiffRequirements(from, to)
+	assert.Equal(t, changes, []helm.DependencyChange{
+		{Name: "chartmuseum", ToVersion: "1.7.0"},
+		{Name: "jenkins", FromVersion: "0.16.1", ToVersion: "0.16.3"},
+		{Name: "monocular", FromVersion: "0.6.2"},
+	})
+}
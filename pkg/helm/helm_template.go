@@ -154,6 +154,17 @@ func (h *HelmTemplate) BuildDependency() error {
 	return h.Client.BuildDependency()
 }
 
+// Template generates the YAML from the chart template to the given directory
+func (h *HelmTemplate) Template(chart string, releaseName string, ns string, outDir string, upgrade bool,
+	values []string, valueFiles []string) error {
+	return h.Client.Template(chart, releaseName, ns, outDir, upgrade, values, valueFiles)
+}
+
+// DiffChart is not supported as there are no helm releases to compare against when using helm template
+func (h *HelmTemplate) DiffChart(chart string, releaseName string, ns string, values []string, valueFiles []string) (string, error) {
+	return "", fmt.Errorf("cannot diff release %s as helm diff is not supported when using helm template", releaseName)
+}
+
 // ListCharts execute the helm list command and returns its output
 func (h *HelmTemplate) ListCharts() (string, error) {
 	ns := h.Namespace
@@ -450,6 +461,11 @@ func (h *HelmTemplate) DeleteRelease(ns string, releaseName string, purge bool)
 	return h.deleteResourcesBySelector(ns, selector, true)
 }
 
+// RollbackRelease is not supported when using helm template as there is no release history
+func (h *HelmTemplate) RollbackRelease(ns string, releaseName string, revision int) error {
+	return fmt.Errorf("rolling back release %s is not supported when using helm template mode", releaseName)
+}
+
 // StatusRelease returns the output of the helm status command for a given release
 func (h *HelmTemplate) StatusRelease(ns string, releaseName string) error {
 	// TODO
@@ -474,6 +490,7 @@ func (h *HelmTemplate) StatusReleases(ns string) (map[string]Release, error) {
 				Release: releaseName,
 				Status: "DEPLOYED",
 				Version: "",
+				Namespace: ns,
 			}
 
 			if releaseName != "" {
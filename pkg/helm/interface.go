@@ -18,10 +18,14 @@ type Helmer interface {
 		values []string, valueFiles []string, repo string, username string, password string) error
 	FetchChart(chart string, version *string, untar bool, untardir string, repo string, username string,
 		password string) error
+	Template(chart string, releaseName string, ns string, outDir string, upgrade bool,
+		values []string, valueFiles []string) error
 	UpgradeChart(chart string, releaseName string, ns string, version *string, install bool,
 		timeout *int, force bool, wait bool, values []string, valueFiles []string, repo string, username string,
 		password string) error
+	DiffChart(chart string, releaseName string, ns string, values []string, valueFiles []string) (string, error)
 	DeleteRelease(ns string, releaseName string, purge bool) error
+	RollbackRelease(ns string, releaseName string, revision int) error
 	ListCharts() (string, error)
 	SearchChartVersions(chart string) ([]string, error)
 	FindChart() (string, error)
@@ -38,7 +42,8 @@ type Helmer interface {
 
 // Release defines a struct to store details about a helm release
 type Release struct {
-	Release string
-	Status  string
-	Version string
+	Release   string
+	Status    string
+	Version   string
+	Namespace string
 }
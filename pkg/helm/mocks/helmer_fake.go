@@ -35,6 +35,11 @@ func (FakeHelmer) DeleteRelease(ns string, releaseName string, purge bool) error
 	return nil
 }
 
+// DiffChart fake
+func (FakeHelmer) DiffChart(chart string, releaseName string, ns string, values []string, valueFiles []string) (string, error) {
+	return "", nil
+}
+
 // Env return env
 func (FakeHelmer) Env() map[string]string {
 	return map[string]string{}
@@ -102,6 +107,11 @@ func (FakeHelmer) RemoveRequirementsLock() error {
 	return nil
 }
 
+// RollbackRelease fake
+func (FakeHelmer) RollbackRelease(ns string, releaseName string, revision int) error {
+	return nil
+}
+
 // SearchChartVersions fake
 func (FakeHelmer) SearchChartVersions(chart string) ([]string, error) {
 	return nil, nil
@@ -134,6 +144,12 @@ func (FakeHelmer) StatusReleases(ns string) (map[string]helm.Release, error) {
 	return map[string]helm.Release{}, nil
 }
 
+// Template fake
+func (FakeHelmer) Template(chart string, releaseName string, ns string, outDir string, upgrade bool,
+	values []string, valueFiles []string) error {
+	return nil
+}
+
 // UpdateRepo fake
 func (FakeHelmer) UpdateRepo() error {
 	return nil
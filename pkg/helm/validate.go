@@ -0,0 +1,75 @@
+package helm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/blang/semver"
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/helm/pkg/chartutil"
+)
+
+// ValidateChart checks the metadata, requirements and values of the chart in the given directory. If a version is
+// given the chart must have that version. All the problems found are returned together
+func ValidateChart(dir string, version string) error {
+	chartFile := filepath.Join(dir, ChartFileName)
+	exists, err := util.FileExists(chartFile)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("no %s found in %s", ChartFileName, dir)
+	}
+	chart, err := chartutil.LoadChartfile(chartFile)
+	if err != nil {
+		return errors.Wrapf(err, "loading %s", chartFile)
+	}
+
+	problems := []error{}
+	if chart.Name == "" {
+		problems = append(problems, fmt.Errorf("%s has no name", chartFile))
+	} else if absDir, err := filepath.Abs(dir); err == nil && chart.Name != filepath.Base(absDir) {
+		problems = append(problems, fmt.Errorf("the name %s in %s does not match its directory %s", chart.Name, chartFile, filepath.Base(absDir)))
+	}
+	if _, err := semver.Parse(chart.Version); err != nil {
+		problems = append(problems, fmt.Errorf("the version %q in %s is not a semantic version: %s", chart.Version, chartFile, err))
+	}
+	if version != "" && chart.Version != version {
+		problems = append(problems, fmt.Errorf("the version %s in %s should be %s", chart.Version, chartFile, version))
+	}
+
+	requirements, err := LoadRequirementsFile(filepath.Join(dir, RequirementsFileName))
+	if err != nil {
+		problems = append(problems, errors.Wrapf(err, "loading %s", RequirementsFileName))
+	} else {
+		for _, dep := range requirements.Dependencies {
+			if dep.Version == "" {
+				problems = append(problems, fmt.Errorf("the dependency %s in %s has no version", dep.Name, RequirementsFileName))
+			}
+			if dep.Repository == "" {
+				problems = append(problems, fmt.Errorf("the dependency %s in %s has no repository", dep.Name, RequirementsFileName))
+			}
+		}
+	}
+
+	valuesFile := filepath.Join(dir, ValuesFileName)
+	exists, err = util.FileExists(valuesFile)
+	if err != nil {
+		return err
+	}
+	if exists {
+		data, err := ioutil.ReadFile(valuesFile)
+		if err != nil {
+			return err
+		}
+		values := map[string]interface{}{}
+		if err := yaml.Unmarshal(data, &values); err != nil {
+			problems = append(problems, fmt.Errorf("%s is not valid YAML: %s", ValuesFileName, err))
+		}
+	}
+	return utilerrors.NewAggregate(problems)
+}
@@ -0,0 +1,70 @@
+package helm_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeChart(t *testing.T, files map[string]string) string {
+	root, err := ioutil.TempDir("", "test-validate-chart-")
+	require.NoError(t, err)
+	dir := filepath.Join(root, "myapp")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	for name, content := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestValidateChart(t *testing.T) {
+	t.Parallel()
+	dir := writeChart(t, map[string]string{
+		helm.ChartFileName: "name: myapp\nversion: 1.2.3\n",
+		helm.RequirementsFileName: `dependencies:
+- name: postgresql
+  version: 0.9.1
+  repository: https://kubernetes-charts.storage.googleapis.com
+`,
+		helm.ValuesFileName: "replicaCount: 1\n",
+	})
+	defer os.RemoveAll(filepath.Dir(dir))
+
+	assert.NoError(t, helm.ValidateChart(dir, ""))
+	assert.NoError(t, helm.ValidateChart(dir, "1.2.3"))
+	assert.EqualError(t, helm.ValidateChart(dir, "1.2.4"), "the version 1.2.3 in "+filepath.Join(dir, helm.ChartFileName)+" should be 1.2.4")
+}
+
+func TestValidateChartReportsAllProblems(t *testing.T) {
+	t.Parallel()
+	dir := writeChart(t, map[string]string{
+		helm.ChartFileName: "name: cheese\nversion: latest\n",
+		helm.RequirementsFileName: `dependencies:
+- name: postgresql
+`,
+		helm.ValuesFileName: "replicaCount: [1\n",
+	})
+	defer os.RemoveAll(filepath.Dir(dir))
+
+	err := helm.ValidateChart(dir, "")
+	require.Error(t, err)
+	message := err.Error()
+	assert.Contains(t, message, "does not match its directory myapp")
+	assert.Contains(t, message, `the version "latest"`)
+	assert.Contains(t, message, "the dependency postgresql in requirements.yaml has no version")
+	assert.Contains(t, message, "the dependency postgresql in requirements.yaml has no repository")
+	assert.Contains(t, message, "values.yaml is not valid YAML")
+}
+
+func TestValidateChartWithoutChartFile(t *testing.T) {
+	t.Parallel()
+	dir := writeChart(t, map[string]string{})
+	defer os.RemoveAll(filepath.Dir(dir))
+
+	assert.EqualError(t, helm.ValidateChart(dir, ""), "no Chart.yaml found in "+dir)
+}
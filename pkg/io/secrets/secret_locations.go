@@ -7,10 +7,39 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
-const vaultSecretsMarker = "useVaultForSecrets"
+const (
+	vaultSecretsMarker = "useVaultForSecrets"
+	secretsLocationKey = "secretsLocation"
+)
+
+// SecretsLocationKind is the kind of store where the secrets such as the auth tokens are kept
+type SecretsLocationKind string
+
+const (
+	// FileSystemLocationKind stores the secrets in files in the jx config directory
+	FileSystemLocationKind SecretsLocationKind = "filesystem"
+	// KeychainLocationKind stores the secrets in the OS keychain of the user
+	KeychainLocationKind SecretsLocationKind = "keychain"
+	// KubeLocationKind stores the secrets in Kubernetes Secrets in the dev namespace
+	KubeLocationKind SecretsLocationKind = "kube"
+	// VaultLocationKind stores the secrets in the system Vault
+	VaultLocationKind SecretsLocationKind = "vault"
+)
+
+// SecretsLocationKinds the supported secrets locations
+var SecretsLocationKinds = []string{
+	string(FileSystemLocationKind),
+	string(KeychainLocationKind),
+	string(KubeLocationKind),
+	string(VaultLocationKind),
+}
 
 // SecretLocation interfaces to identify where is the secrets location
 type SecretLocation interface {
+	// Location returns the kind of store where the secrets are kept
+	Location() SecretsLocationKind
+	// SetLocation sets the kind of store where the secrets are kept
+	SetLocation(location SecretsLocationKind) error
 	// InVault returns whether secrets are stored in Vault
 	InVault() bool
 	// SetInVault sets whether secrets are stored in Vault or not
@@ -20,7 +49,7 @@ type SecretLocation interface {
 type secretLocation struct {
 	kubeClient kubernetes.Interface
 	namespace  string
-	usingVault *bool // use a tri-state boolean. nil means uninitialised (so need to lookup from cluster)
+	location   *SecretsLocationKind // nil means uninitialised (so need to lookup from cluster)
 }
 
 // NewSecretLocation creates a SecretLocation
@@ -31,36 +60,62 @@ func NewSecretLocation(kubeClient kubernetes.Interface, namespace string) Secret
 	}
 }
 
-// UsingVaultForSecrets returns true if the cluster has been configured to store secrets in vault
-func (s *secretLocation) InVault() bool {
-	if s.usingVault == nil {
+// Location returns the secrets location configured in the cluster's installation config map, defaulting to the file system
+func (s *secretLocation) Location() SecretsLocationKind {
+	if s.location == nil {
+		location := FileSystemLocationKind
 		configMap, err := getInstallConfigMap(s.kubeClient, s.namespace)
-		b := false
 		if err == nil {
-			b = configMap[vaultSecretsMarker] != ""
+			if configMap[secretsLocationKey] != "" {
+				location = SecretsLocationKind(configMap[secretsLocationKey])
+			} else if configMap[vaultSecretsMarker] != "" {
+				location = VaultLocationKind
+			}
 		}
-		s.usingVault = &b
+		s.location = &location
 	}
-	return *s.usingVault
+	return *s.location
 }
 
-// UseVaultForSecrets configures the cluster's installation config map to denote that secrets should be stored in vault
-func (s *secretLocation) SetInVault(useVault bool) error {
+// SetLocation configures the cluster's installation config map with the location of the secrets
+func (s *secretLocation) SetLocation(location SecretsLocationKind) error {
 	_, err := kube.DefaultModifyConfigMap(s.kubeClient, s.namespace, kube.ConfigMapNameJXInstallConfig, func(configMap *v1.ConfigMap) error {
-		if useVault {
+		if location == VaultLocationKind {
 			configMap.Data[vaultSecretsMarker] = "true"
 		} else {
 			delete(configMap.Data, vaultSecretsMarker)
 		}
-		s.usingVault = &useVault
+		if location == "" || location == FileSystemLocationKind || location == VaultLocationKind {
+			delete(configMap.Data, secretsLocationKey)
+		} else {
+			configMap.Data[secretsLocationKey] = string(location)
+		}
+		s.location = &location
 		return nil
 	}, nil)
 	if err != nil {
-		return errors.Wrapf(err, "saving vault flag in configmap %s", kube.ConfigMapNameJXInstallConfig)
+		return errors.Wrapf(err, "saving secrets location in configmap %s", kube.ConfigMapNameJXInstallConfig)
 	}
 	return nil
 }
 
+// InVault returns true if the cluster has been configured to store secrets in vault
+func (s *secretLocation) InVault() bool {
+	return s.Location() == VaultLocationKind
+}
+
+// SetInVault configures the cluster's installation config map to denote that secrets should be stored in vault.
+// Disabling vault falls back to the file system but keeps any other location such as the keychain
+func (s *secretLocation) SetInVault(useVault bool) error {
+	if useVault {
+		return s.SetLocation(VaultLocationKind)
+	}
+	if s.Location() != VaultLocationKind {
+		return nil
+	}
+	return s.SetLocation(FileSystemLocationKind)
+}
+
 func getInstallConfigMap(kubeClient kubernetes.Interface, namespace string) (map[string]string, error) {
 	configMap, err := kube.GetConfigMapData(kubeClient, kube.ConfigMapNameJXInstallConfig, namespace)
 	if err != nil {
@@ -63,6 +63,35 @@ func TestUseVaultForSecrets_NoJxInstallConfigMap(t *testing.T) {
 	assert.False(t, secretLocation.InVault())
 }
 
+func TestSecretsLocation(t *testing.T) {
+	t.Parallel()
+
+	kubeClient := createMockCluster()
+	secretLocation := NewSecretLocation(kubeClient, ns)
+	assert.Equal(t, FileSystemLocationKind, secretLocation.Location())
+
+	err := secretLocation.SetLocation(KeychainLocationKind)
+	assert.NoError(t, err)
+	assert.Equal(t, KeychainLocationKind, secretLocation.Location())
+	assert.False(t, secretLocation.InVault())
+
+	configMap, err := kubeClient.Core().ConfigMaps(ns).Get("jx-install-config", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "keychain", configMap.Data["secretsLocation"])
+	assert.Equal(t, "two", configMap.Data["one"])
+
+	// a new SecretLocation reads the location from the cluster
+	assert.Equal(t, KeychainLocationKind, NewSecretLocation(kubeClient, ns).Location())
+
+	err = secretLocation.SetLocation(VaultLocationKind)
+	assert.NoError(t, err)
+	configMap, err = kubeClient.Core().ConfigMaps(ns).Get("jx-install-config", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "", configMap.Data["secretsLocation"])
+	assert.Equal(t, "true", configMap.Data["useVaultForSecrets"])
+	assert.Equal(t, VaultLocationKind, NewSecretLocation(kubeClient, ns).Location())
+}
+
 func createMockCluster() *fake.Clientset {
 	namespace := &v1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
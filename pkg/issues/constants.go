@@ -5,8 +5,13 @@ const (
 	Jira     = "jira"
 	Trello   = "trello"
 	Git      = "git"
+	GitHub   = "github"
+	GitLab   = "gitlab"
+
+	// StatusClosed the status which closes GitHub and GitLab issues when they are transitioned
+	StatusClosed = "closed"
 )
 
 var (
-	IssueTrackerKinds = []string{Bugzilla, Jira, Trello}
+	IssueTrackerKinds = []string{Bugzilla, GitHub, GitLab, Jira, Trello}
 )
@@ -3,6 +3,7 @@ package issues
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jenkins-x/jx/pkg/gits"
@@ -73,6 +74,31 @@ func (i *GitIssueProvider) CreateIssueComment(key string, comment string) error
 	return i.GitProvider.CreateIssueComment(i.Owner, i.Repository, n, comment)
 }
 
+// TransitionIssue closes the issue if the status is closed otherwise it adds the status as a label of the issue as
+// GitHub and GitLab issues have no workflow of statuses
+func (i *GitIssueProvider) TransitionIssue(key string, status string) error {
+	n, err := issueKeyToNumber(key)
+	if err != nil {
+		return err
+	}
+	updater, ok := i.GitProvider.(gits.IssueUpdater)
+	if !ok {
+		return fmt.Errorf("Cannot transition issue %s as the %s git provider cannot update issues", key, i.GitProvider.Kind())
+	}
+	if strings.EqualFold(status, StatusClosed) {
+		err = updater.CloseIssue(i.Owner, i.Repository, n)
+		if err != nil {
+			return fmt.Errorf("Failed to close issue %s: %s", key, err)
+		}
+		return nil
+	}
+	err = updater.AddIssueLabels(i.Owner, i.Repository, n, []string{status})
+	if err != nil {
+		return fmt.Errorf("Failed to add the label %s to issue %s: %s", status, key, err)
+	}
+	return nil
+}
+
 func (i *GitIssueProvider) HomeURL() string {
 	return util.UrlJoin(i.GitProvider.ServerURL(), i.Owner, i.Repository)
 }
@@ -0,0 +1,48 @@
+package issues_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/issues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIssueUpdater records the labels added to and the issues closed by a git provider
+type fakeIssueUpdater struct {
+	gits.GitProvider
+	labels map[int][]string
+	closed []int
+}
+
+func (f *fakeIssueUpdater) AddIssueLabels(owner string, repo string, number int, labels []string) error {
+	f.labels[number] = append(f.labels[number], labels...)
+	return nil
+}
+
+func (f *fakeIssueUpdater) CloseIssue(owner string, repo string, number int) error {
+	f.closed = append(f.closed, number)
+	return nil
+}
+
+func TestGitIssueProviderTransitionIssue(t *testing.T) {
+	t.Parallel()
+	updater := &fakeIssueUpdater{labels: map[int][]string{}}
+	tracker, err := issues.CreateGitIssueProvider(updater, "myorg", "myapp")
+	require.NoError(t, err)
+
+	transitioner, ok := tracker.(issues.IssueTransitioner)
+	require.True(t, ok, "git issue trackers should support transitions")
+
+	err = transitioner.TransitionIssue("12", "released")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"released"}, updater.labels[12])
+	assert.Empty(t, updater.closed)
+
+	err = transitioner.TransitionIssue("3", "Closed")
+	require.NoError(t, err)
+	assert.Equal(t, []int{3}, updater.closed)
+
+	assert.Error(t, transitioner.TransitionIssue("ABC-1", "released"))
+}
@@ -108,7 +108,38 @@ func (i *JiraService) CreateIssue(issue *gits.GitIssue) (*gits.GitIssue, error)
 }
 
 func (i *JiraService) CreateIssueComment(key string, comment string) error {
-	return fmt.Errorf("TODO")
+	_, _, err := i.JiraClient.Issue.AddComment(key, &jira.Comment{Body: comment})
+	if err != nil {
+		return fmt.Errorf("Failed to comment on issue %s: %s", key, err)
+	}
+	return nil
+}
+
+// TransitionIssue moves the issue to the given status using the transition of the same name
+func (i *JiraService) TransitionIssue(key string, status string) error {
+	issue, _, err := i.JiraClient.Issue.Get(key, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to find issue %s: %s", key, err)
+	}
+	if issue.Fields != nil && issue.Fields.Status != nil && strings.EqualFold(issue.Fields.Status.Name, status) {
+		return nil
+	}
+	transitions, _, err := i.JiraClient.Issue.GetTransitions(key)
+	if err != nil {
+		return fmt.Errorf("Failed to find the transitions of issue %s: %s", key, err)
+	}
+	names := []string{}
+	for _, t := range transitions {
+		if strings.EqualFold(t.Name, status) {
+			_, err = i.JiraClient.Issue.DoTransition(key, t.ID)
+			if err != nil {
+				return fmt.Errorf("Failed to transition issue %s to %s: %s", key, status, err)
+			}
+			return nil
+		}
+		names = append(names, t.Name)
+	}
+	return fmt.Errorf("Cannot transition issue %s to %s as the available transitions are: %s", key, status, strings.Join(names, ", "))
 }
 
 func (i *JiraService) IssueURL(key string) string {
@@ -126,6 +157,10 @@ func (i *JiraService) jiraToGitIssue(issue *jira.Issue) *gits.GitIssue {
 		answer.Body = fields.Description
 		answer.Labels = gits.ToGitLabels(fields.Labels)
 		answer.ClosedAt = jiraTimeToTimeP(fields.Resolutiondate)
+		if fields.Status != nil {
+			state := fields.Status.Name
+			answer.State = &state
+		}
 		answer.User = jiraUserToGitUser(fields.Reporter)
 		assignee := jiraUserToGitUser(fields.Assignee)
 		if assignee != nil {
@@ -0,0 +1,36 @@
+package issues
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	jiraIssueKeyRegex = regexp.MustCompile(`\b([A-Z][A-Z0-9_]+-\d+)\b`)
+	gitIssueKeyRegex  = regexp.MustCompile(`#(\d+)\b`)
+)
+
+// ParseIssueKeys returns the unique keys of the issues referenced in the text, such as a commit message, in the
+// order they are referenced. Jira keys look like ABC-123 and are filtered by the project if one is given. Git
+// issues look like #123 and their keys are the issue numbers
+func ParseIssueKeys(kind string, project string, text string) []string {
+	regex := gitIssueKeyRegex
+	prefix := ""
+	if kind == Jira {
+		regex = jiraIssueKeyRegex
+		if project != "" {
+			prefix = strings.ToUpper(project) + "-"
+		}
+	}
+	answer := []string{}
+	found := map[string]bool{}
+	for _, match := range regex.FindAllStringSubmatch(text, -1) {
+		key := match[1]
+		if found[key] || !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		found[key] = true
+		answer = append(answer, key)
+	}
+	return answer
+}
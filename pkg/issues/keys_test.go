@@ -0,0 +1,22 @@
+package issues_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/issues"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIssueKeys(t *testing.T) {
+	t.Parallel()
+
+	message := `fix(ui): handle empty carts fixes #12
+
+Also relates to #3 and #12 as well as ABC-45, XYZ-1 and PROJ2-7`
+
+	assert.Equal(t, []string{"12", "3"}, issues.ParseIssueKeys(issues.Git, "", message))
+	assert.Equal(t, []string{"12", "3"}, issues.ParseIssueKeys(issues.GitHub, "myorg/myapp", message))
+	assert.Equal(t, []string{"ABC-45", "XYZ-1", "PROJ2-7"}, issues.ParseIssueKeys(issues.Jira, "", message))
+	assert.Equal(t, []string{"ABC-45"}, issues.ParseIssueKeys(issues.Jira, "abc", message))
+	assert.Empty(t, issues.ParseIssueKeys(issues.Jira, "", "chore: release 1.0.2"))
+}
@@ -31,6 +31,12 @@ type IssueProvider interface {
 	HomeURL() string
 }
 
+// IssueTransitioner is implemented by issue trackers whose issues move through a workflow of statuses
+type IssueTransitioner interface {
+	// TransitionIssue moves the issue of the given key to the given status
+	TransitionIssue(key string, status string) error
+}
+
 func CreateIssueProvider(kind string, server *auth.AuthServer, userAuth *auth.UserAuth, project string, batchMode bool, git gits.Gitter) (IssueProvider, error) {
 	switch kind {
 	case Jira:
@@ -0,0 +1,584 @@
+package jenkins
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CascConfigMapName the name of the ConfigMap which contains the configuration as code of Jenkins
+	CascConfigMapName = "jenkins-x-casc"
+	// CascFileName the name of the configuration as code file in the ConfigMap
+	CascFileName = "jenkins.yaml"
+	// CascSecretsDir the directory of the Jenkins container which the credential secrets are mounted in. The
+	// configuration as code plugin resolves the variables of the credentials from the files of this directory
+	CascSecretsDir = "/run/secrets"
+	// CascReloadPath the path of the Jenkins endpoint which reloads the configuration as code
+	CascReloadPath = "/configuration-as-code/reload"
+	// CascConfigDir the directory of the Jenkins container which the ConfigMap of the configuration as code is
+	// mounted in
+	CascConfigDir = "/var/jenkins_casc"
+	// CascConfigEnvVar the environment variable which tells the configuration as code plugin where to load it from
+	CascConfigEnvVar = "CASC_JENKINS_CONFIG"
+	// JenkinsContainerName the name of the Jenkins container in the pod of the Jenkins chart
+	JenkinsContainerName = "jenkins"
+
+	cascConfigVolume  = "jenkins-x-casc"
+	cascSecretsVolume = "jenkins-x-casc-secrets"
+
+	defaultLibraryVersion = "master"
+	agentWorkingDir       = "/home/jenkins"
+	reloadTimeout         = 30 * time.Second
+)
+
+// CascConfig the settings of the team which the Jenkins configuration as code is rendered from
+type CascConfig struct {
+	Namespace    string
+	JenkinsURL   string
+	PodTemplates map[string]*corev1.Pod
+	Credentials  []corev1.Secret
+	Libraries    []v1.JenkinsLibrary
+	// PipelineSecrets the pipeline secrets which are injected into all the pod templates
+	PipelineSecrets []corev1.Secret
+}
+
+type cascDocument struct {
+	Jenkins      cascJenkins      `json:"jenkins"`
+	Credentials  cascCredentials  `json:"credentials"`
+	Unclassified cascUnclassified `json:"unclassified"`
+}
+
+type cascJenkins struct {
+	NumExecutors int         `json:"numExecutors"`
+	Clouds       []cascCloud `json:"clouds"`
+}
+
+type cascCloud struct {
+	Kubernetes cascKubernetesCloud `json:"kubernetes"`
+}
+
+type cascKubernetesCloud struct {
+	Name       string            `json:"name"`
+	Namespace  string            `json:"namespace"`
+	JenkinsURL string            `json:"jenkinsUrl,omitempty"`
+	Templates  []cascPodTemplate `json:"templates"`
+}
+
+type cascPodTemplate struct {
+	Name             string           `json:"name"`
+	Label            string           `json:"label"`
+	NodeUsageMode    string           `json:"nodeUsageMode"`
+	ServiceAccount   string           `json:"serviceAccount,omitempty"`
+	Containers       []cascContainer  `json:"containers"`
+	Volumes          []cascVolume     `json:"volumes,omitempty"`
+	ImagePullSecrets []cascPullSecret `json:"imagePullSecrets,omitempty"`
+	NodeSelector     string           `json:"nodeSelector,omitempty"`
+}
+
+type cascContainer struct {
+	Name                  string       `json:"name"`
+	Image                 string       `json:"image"`
+	Command               string       `json:"command,omitempty"`
+	Args                  string       `json:"args,omitempty"`
+	TtyEnabled            bool         `json:"ttyEnabled"`
+	Privileged            bool         `json:"privileged,omitempty"`
+	WorkingDir            string       `json:"workingDir"`
+	ResourceRequestCpu    string       `json:"resourceRequestCpu,omitempty"`
+	ResourceRequestMemory string       `json:"resourceRequestMemory,omitempty"`
+	ResourceLimitCpu      string       `json:"resourceLimitCpu,omitempty"`
+	ResourceLimitMemory   string       `json:"resourceLimitMemory,omitempty"`
+	EnvVars               []cascEnvVar `json:"envVars,omitempty"`
+}
+
+type cascEnvVar struct {
+	EnvVar       *cascKeyValue       `json:"envVar,omitempty"`
+	SecretEnvVar *cascSecretKeyValue `json:"secretEnvVar,omitempty"`
+}
+
+type cascKeyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type cascSecretKeyValue struct {
+	Key        string `json:"key"`
+	SecretName string `json:"secretName"`
+	SecretKey  string `json:"secretKey"`
+}
+
+type cascPullSecret struct {
+	Name string `json:"name"`
+}
+
+type cascVolume struct {
+	SecretVolume   *cascSecretVolume   `json:"secretVolume,omitempty"`
+	HostPathVolume *cascHostPathVolume `json:"hostPathVolume,omitempty"`
+}
+
+type cascSecretVolume struct {
+	MountPath  string `json:"mountPath"`
+	SecretName string `json:"secretName"`
+}
+
+type cascHostPathVolume struct {
+	MountPath string `json:"mountPath"`
+	HostPath  string `json:"hostPath"`
+}
+
+type cascCredentials struct {
+	System cascSystemCredentials `json:"system"`
+}
+
+type cascSystemCredentials struct {
+	DomainCredentials []cascDomainCredentials `json:"domainCredentials"`
+}
+
+type cascDomainCredentials struct {
+	Credentials []map[string]cascCredential `json:"credentials"`
+}
+
+type cascCredential struct {
+	Scope       string `json:"scope"`
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	Username    string `json:"username,omitempty"`
+	Password    string `json:"password,omitempty"`
+	Secret      string `json:"secret,omitempty"`
+}
+
+type cascUnclassified struct {
+	GlobalLibraries cascGlobalLibraries `json:"globalLibraries"`
+}
+
+type cascGlobalLibraries struct {
+	Libraries []cascLibrary `json:"libraries"`
+}
+
+type cascLibrary struct {
+	Name           string        `json:"name"`
+	DefaultVersion string        `json:"defaultVersion"`
+	Implicit       bool          `json:"implicit"`
+	Retriever      cascRetriever `json:"retriever"`
+}
+
+type cascRetriever struct {
+	ModernSCM cascModernSCM `json:"modernSCM"`
+}
+
+type cascModernSCM struct {
+	SCM cascSCM `json:"scm"`
+}
+
+type cascSCM struct {
+	Git cascGitSCM `json:"git"`
+}
+
+type cascGitSCM struct {
+	Remote string `json:"remote"`
+}
+
+// RenderConfigurationAsCode renders the configuration as code YAML of Jenkins from the settings of the team
+func RenderConfigurationAsCode(config *CascConfig) ([]byte, error) {
+	doc := cascDocument{}
+	cloud := cascKubernetesCloud{
+		Name:       "kubernetes",
+		Namespace:  config.Namespace,
+		JenkinsURL: config.JenkinsURL,
+		Templates:  []cascPodTemplate{},
+	}
+	names := []string{}
+	for name := range config.PodTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pod := config.PodTemplates[name].DeepCopy()
+		pod.Spec.Volumes = kube.AddPipelineSecrets(pod.Spec.Containers, pod.Spec.Volumes, config.PipelineSecrets)
+		cloud.Templates = append(cloud.Templates, cascPodTemplateFor(name, pod))
+	}
+	doc.Jenkins.Clouds = []cascCloud{{Kubernetes: cloud}}
+
+	credentials := []map[string]cascCredential{}
+	for _, secret := range config.Credentials {
+		credential, err := cascCredentialFor(&secret)
+		if err != nil {
+			return nil, err
+		}
+		credentials = append(credentials, credential)
+	}
+	doc.Credentials.System.DomainCredentials = []cascDomainCredentials{{Credentials: credentials}}
+
+	libraries := []cascLibrary{}
+	for _, library := range config.Libraries {
+		version := library.DefaultVersion
+		if version == "" {
+			version = defaultLibraryVersion
+		}
+		l := cascLibrary{
+			Name:           library.Name,
+			DefaultVersion: version,
+			Implicit:       library.Implicit,
+		}
+		l.Retriever.ModernSCM.SCM.Git.Remote = library.GitURL
+		libraries = append(libraries, l)
+	}
+	doc.Unclassified.GlobalLibraries.Libraries = libraries
+
+	data, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the Jenkins configuration as code")
+	}
+	return data, nil
+}
+
+// cascPodTemplateFor converts a pod template of the team into the pod template of the Kubernetes plugin
+func cascPodTemplateFor(name string, pod *corev1.Pod) cascPodTemplate {
+	label := pod.Name
+	if label == "" {
+		label = "jenkins-" + name
+	}
+	template := cascPodTemplate{
+		Name:           name,
+		Label:          label,
+		NodeUsageMode:  "EXCLUSIVE",
+		ServiceAccount: pod.Spec.ServiceAccountName,
+		Containers:     []cascContainer{},
+	}
+	for _, secret := range pod.Spec.ImagePullSecrets {
+		template.ImagePullSecrets = append(template.ImagePullSecrets, cascPullSecret{Name: secret.Name})
+	}
+	if len(pod.Spec.NodeSelector) > 0 {
+		selectors := []string{}
+		for k, v := range pod.Spec.NodeSelector {
+			selectors = append(selectors, k+"="+v)
+		}
+		sort.Strings(selectors)
+		template.NodeSelector = strings.Join(selectors, ",")
+	}
+	mountPaths := map[string]string{}
+	for _, c := range pod.Spec.Containers {
+		for _, m := range c.VolumeMounts {
+			mountPaths[m.Name] = m.MountPath
+		}
+	}
+	for _, volume := range pod.Spec.Volumes {
+		mountPath := mountPaths[volume.Name]
+		if mountPath == "" {
+			continue
+		}
+		if volume.Secret != nil {
+			template.Volumes = append(template.Volumes, cascVolume{
+				SecretVolume: &cascSecretVolume{MountPath: mountPath, SecretName: volume.Secret.SecretName},
+			})
+		} else if volume.HostPath != nil {
+			template.Volumes = append(template.Volumes, cascVolume{
+				HostPathVolume: &cascHostPathVolume{MountPath: mountPath, HostPath: volume.HostPath.Path},
+			})
+		}
+	}
+	for _, c := range pod.Spec.Containers {
+		container := cascContainer{
+			Name:       c.Name,
+			Image:      c.Image,
+			Command:    strings.Join(c.Command, " "),
+			Args:       strings.Join(c.Args, " "),
+			TtyEnabled: c.TTY,
+			WorkingDir: c.WorkingDir,
+		}
+		if container.WorkingDir == "" {
+			container.WorkingDir = agentWorkingDir
+		}
+		if c.SecurityContext != nil && c.SecurityContext.Privileged != nil {
+			container.Privileged = *c.SecurityContext.Privileged
+		}
+		if q, ok := c.Resources.Requests[corev1.ResourceCPU]; ok {
+			container.ResourceRequestCpu = q.String()
+		}
+		if q, ok := c.Resources.Requests[corev1.ResourceMemory]; ok {
+			container.ResourceRequestMemory = q.String()
+		}
+		if q, ok := c.Resources.Limits[corev1.ResourceCPU]; ok {
+			container.ResourceLimitCpu = q.String()
+		}
+		if q, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
+			container.ResourceLimitMemory = q.String()
+		}
+		for _, env := range c.Env {
+			if env.ValueFrom == nil {
+				container.EnvVars = append(container.EnvVars, cascEnvVar{EnvVar: &cascKeyValue{Key: env.Name, Value: env.Value}})
+			} else if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+				container.EnvVars = append(container.EnvVars, cascEnvVar{
+					SecretEnvVar: &cascSecretKeyValue{Key: env.Name, SecretName: ref.Name, SecretKey: ref.Key},
+				})
+			}
+		}
+		template.Containers = append(template.Containers, container)
+	}
+	return template
+}
+
+// cascCredentialFor converts a credentials secret of the team into a credential whose values are resolved by the
+// configuration as code plugin from the files of the secret mounted in the secrets directory
+func cascCredentialFor(secret *corev1.Secret) (map[string]cascCredential, error) {
+	credential := cascCredential{
+		Scope:       "GLOBAL",
+		ID:          secret.Name,
+		Description: secret.Annotations[kube.AnnotationCredentialsDescription],
+	}
+	kind := secret.Labels[kube.LabelCredentialsType]
+	switch kind {
+	case kube.ValueCredentialTypeUsernamePassword:
+		credential.Username = CascSecretVariable(secret.Name, kube.SecretDataUsername)
+		credential.Password = CascSecretVariable(secret.Name, kube.SecretDataPassword)
+		return map[string]cascCredential{"usernamePassword": credential}, nil
+	case kube.ValueCredentialTypeSecretText:
+		credential.Secret = CascSecretVariable(secret.Name, kube.SecretDataText)
+		return map[string]cascCredential{"string": credential}, nil
+	default:
+		return nil, fmt.Errorf("unsupported credentials type %s of secret %s", kind, secret.Name)
+	}
+}
+
+// CascSecretVariable returns the variable which the configuration as code plugin resolves from the file of the key
+// of the secret in the secrets directory
+func CascSecretVariable(secretName string, key string) string {
+	return "${" + CascSecretFileName(secretName, key) + "}"
+}
+
+// CascSecretFileName returns the name of the file the key of the secret is mounted as in the secrets directory
+func CascSecretFileName(secretName string, key string) string {
+	return secretName + "-" + key
+}
+
+// cascSecretKeys returns the keys of the credentials secret which the configuration as code refers to
+func cascSecretKeys(secret *corev1.Secret) []string {
+	switch secret.Labels[kube.LabelCredentialsType] {
+	case kube.ValueCredentialTypeUsernamePassword:
+		return []string{kube.SecretDataUsername, kube.SecretDataPassword}
+	case kube.ValueCredentialTypeSecretText:
+		return []string{kube.SecretDataText}
+	default:
+		return nil
+	}
+}
+
+// cascVolumes returns the volumes, volume mounts and environment variables of the Jenkins container which load the
+// configuration as code from its ConfigMap and mount the keys of the credential secrets in the secrets directory
+func cascVolumes(secrets []corev1.Secret) ([]corev1.Volume, []corev1.VolumeMount, []corev1.EnvVar) {
+	sources := []corev1.VolumeProjection{}
+	for i := range secrets {
+		secret := &secrets[i]
+		items := []corev1.KeyToPath{}
+		for _, key := range cascSecretKeys(secret) {
+			items = append(items, corev1.KeyToPath{Key: key, Path: CascSecretFileName(secret.Name, key)})
+		}
+		if len(items) == 0 {
+			continue
+		}
+		sources = append(sources, corev1.VolumeProjection{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+				Items:                items,
+			},
+		})
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].Secret.Name < sources[j].Secret.Name
+	})
+
+	volumes := []corev1.Volume{
+		{
+			Name: cascConfigVolume,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: CascConfigMapName},
+				},
+			},
+		},
+	}
+	mounts := []corev1.VolumeMount{
+		{Name: cascConfigVolume, MountPath: CascConfigDir, ReadOnly: true},
+	}
+	if len(sources) > 0 {
+		volumes = append(volumes, corev1.Volume{
+			Name: cascSecretsVolume,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{Sources: sources},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{Name: cascSecretsVolume, MountPath: CascSecretsDir, ReadOnly: true})
+	}
+	envVars := []corev1.EnvVar{
+		{Name: CascConfigEnvVar, Value: CascConfigDir + "/" + CascFileName},
+	}
+	return volumes, mounts, envVars
+}
+
+// CascHelmValues returns the values of the Jenkins chart which mount the configuration as code and the credential
+// secrets into the Jenkins container and point the configuration as code plugin at them
+func CascHelmValues(secrets []corev1.Secret) map[string]interface{} {
+	volumes, mounts, envVars := cascVolumes(secrets)
+	return map[string]interface{}{
+		"Master": map[string]interface{}{
+			"ContainerEnv": envVars,
+		},
+		"Persistence": map[string]interface{}{
+			"volumes": volumes,
+			"mounts":  mounts,
+		},
+	}
+}
+
+// EnsureCascPodSpec adds the volumes, volume mounts and environment variables of the configuration as code to the
+// Jenkins container of the pod spec, returning true if the pod spec was modified
+func EnsureCascPodSpec(podSpec *corev1.PodSpec, secrets []corev1.Secret) (bool, error) {
+	var container *corev1.Container
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name == JenkinsContainerName {
+			container = &podSpec.Containers[i]
+		}
+	}
+	if container == nil {
+		return false, fmt.Errorf("no %s container found in the Jenkins pod", JenkinsContainerName)
+	}
+	volumes, mounts, envVars := cascVolumes(secrets)
+	modified := false
+	if !hasVolume(volumes, cascSecretsVolume) {
+		// the credential secrets may all have been removed
+		podSpec.Volumes, modified = removeVolume(podSpec.Volumes, cascSecretsVolume)
+		container.VolumeMounts = removeVolumeMount(container.VolumeMounts, cascSecretsVolume)
+	}
+	for _, volume := range volumes {
+		podSpec.Volumes = updateVolume(podSpec.Volumes, volume, &modified)
+	}
+	for _, mount := range mounts {
+		container.VolumeMounts = updateVolumeMount(container.VolumeMounts, mount, &modified)
+	}
+	for _, envVar := range envVars {
+		container.Env = updateEnvVar(container.Env, envVar, &modified)
+	}
+	return modified, nil
+}
+
+func hasVolume(volumes []corev1.Volume, name string) bool {
+	for _, v := range volumes {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func removeVolume(volumes []corev1.Volume, name string) ([]corev1.Volume, bool) {
+	answer := []corev1.Volume{}
+	for _, v := range volumes {
+		if v.Name != name {
+			answer = append(answer, v)
+		}
+	}
+	return answer, len(answer) != len(volumes)
+}
+
+func removeVolumeMount(mounts []corev1.VolumeMount, name string) []corev1.VolumeMount {
+	answer := []corev1.VolumeMount{}
+	for _, m := range mounts {
+		if m.Name != name {
+			answer = append(answer, m)
+		}
+	}
+	return answer
+}
+
+func updateVolume(volumes []corev1.Volume, volume corev1.Volume, modified *bool) []corev1.Volume {
+	for i, v := range volumes {
+		if v.Name == volume.Name {
+			if !reflect.DeepEqual(v, volume) {
+				volumes[i] = volume
+				*modified = true
+			}
+			return volumes
+		}
+	}
+	*modified = true
+	return append(volumes, volume)
+}
+
+func updateVolumeMount(mounts []corev1.VolumeMount, mount corev1.VolumeMount, modified *bool) []corev1.VolumeMount {
+	for i, m := range mounts {
+		if m.Name == mount.Name {
+			if !reflect.DeepEqual(m, mount) {
+				mounts[i] = mount
+				*modified = true
+			}
+			return mounts
+		}
+	}
+	*modified = true
+	return append(mounts, mount)
+}
+
+func updateEnvVar(envVars []corev1.EnvVar, envVar corev1.EnvVar, modified *bool) []corev1.EnvVar {
+	for i, e := range envVars {
+		if e.Name == envVar.Name {
+			if !reflect.DeepEqual(e, envVar) {
+				envVars[i] = envVar
+				*modified = true
+			}
+			return envVars
+		}
+	}
+	*modified = true
+	return append(envVars, envVar)
+}
+
+// CascConfigMap returns the ConfigMap which contains the configuration as code of Jenkins
+func CascConfigMap(ns string, data []byte) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CascConfigMapName,
+			Namespace: ns,
+			Labels: map[string]string{
+				kube.LabelCreatedBy: kube.ValueCreatedByJX,
+			},
+		},
+		Data: map[string]string{
+			CascFileName: string(data),
+		},
+	}
+}
+
+// ReloadConfigurationAsCode asks Jenkins to apply its configuration as code again
+func ReloadConfigurationAsCode(jenkinsURL string, username string, apiToken string) error {
+	reloadURL := util.UrlJoin(jenkinsURL, CascReloadPath)
+	req, err := http.NewRequest(http.MethodPost, reloadURL, nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(username, apiToken)
+	resp, err := util.GetClientWithTimeout(reloadTimeout).Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to reload the configuration as code of Jenkins at %s", jenkinsURL)
+	}
+	defer resp.Body.Close()
+	// Jenkins redirects to the configuration page once the configuration is reloaded
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("failed to reload the configuration as code of Jenkins at %s: %s", jenkinsURL, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package jenkins_test
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRenderConfigurationAsCode(t *testing.T) {
+	t.Parallel()
+	config := &jenkins.CascConfig{
+		Namespace:  "jx",
+		JenkinsURL: "http://jenkins:8080",
+		PodTemplates: map[string]*corev1.Pod{
+			"maven": {
+				ObjectMeta: metav1.ObjectMeta{Name: "jenkins-maven"},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "jenkins",
+					Containers: []corev1.Container{
+						{
+							Name:    "maven",
+							Image:   "jenkinsxio/builder-maven:0.1.1",
+							Command: []string{"/bin/sh", "-c"},
+							Args:    []string{"cat"},
+							TTY:     true,
+							Env:     []corev1.EnvVar{{Name: "MAVEN_OPTS", Value: "-Xmx1g"}},
+						},
+					},
+				},
+			},
+		},
+		Credentials: []corev1.Secret{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "jx-pipeline-git-github",
+					Labels: map[string]string{kube.LabelCredentialsType: kube.ValueCredentialTypeUsernamePassword},
+				},
+			},
+		},
+		Libraries: []v1.JenkinsLibrary{
+			{Name: "shared", GitURL: "https://github.com/myorg/shared-library.git", Implicit: true},
+		},
+		PipelineSecrets: []corev1.Secret{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "npm",
+					Labels: map[string]string{kube.LabelPipelineSecret: kube.ValuePipelineSecretEnv},
+				},
+				Data: map[string][]byte{"npm-token": []byte("abc123")},
+			},
+		},
+	}
+
+	data, err := jenkins.RenderConfigurationAsCode(config)
+	require.NoError(t, err)
+
+	doc := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal(data, &doc))
+
+	clouds := doc["jenkins"].(map[string]interface{})["clouds"].([]interface{})
+	cloud := clouds[0].(map[string]interface{})["kubernetes"].(map[string]interface{})
+	assert.Equal(t, "jx", cloud["namespace"])
+	template := cloud["templates"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "jenkins-maven", template["label"])
+	container := template["containers"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "/bin/sh -c", container["command"])
+	assert.Equal(t, "/home/jenkins", container["workingDir"])
+	assert.Contains(t, container["envVars"], map[string]interface{}{
+		"secretEnvVar": map[string]interface{}{"key": "NPM_TOKEN", "secretName": "npm", "secretKey": "npm-token"},
+	})
+	assert.Empty(t, config.PodTemplates["maven"].Spec.Containers[0].Env[1:], "the pod templates of the team should not be modified")
+
+	assert.Contains(t, string(data), "password: ${jx-pipeline-git-github-password}")
+	assert.Contains(t, string(data), "remote: https://github.com/myorg/shared-library.git")
+	assert.Contains(t, string(data), "defaultVersion: master")
+}
+
+func TestRenderConfigurationAsCodeUnsupportedCredentials(t *testing.T) {
+	t.Parallel()
+	config := &jenkins.CascConfig{
+		Credentials: []corev1.Secret{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "my-file",
+					Labels: map[string]string{kube.LabelCredentialsType: "secretFile"},
+				},
+			},
+		},
+	}
+
+	_, err := jenkins.RenderConfigurationAsCode(config)
+	assert.Error(t, err)
+}
+
+func TestEnsureCascPodSpec(t *testing.T) {
+	t.Parallel()
+	secrets := []corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "jx-pipeline-git-github",
+				Labels: map[string]string{kube.LabelCredentialsType: kube.ValueCredentialTypeUsernamePassword},
+			},
+		},
+	}
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{
+			{Name: jenkins.JenkinsContainerName, Image: "jenkinsxio/jenkinsx:0.0.60"},
+		},
+	}
+
+	modified, err := jenkins.EnsureCascPodSpec(podSpec, secrets)
+	require.NoError(t, err)
+	assert.True(t, modified)
+
+	container := podSpec.Containers[0]
+	assert.Equal(t, []corev1.EnvVar{{Name: jenkins.CascConfigEnvVar, Value: "/var/jenkins_casc/jenkins.yaml"}}, container.Env)
+	require.Len(t, container.VolumeMounts, 2)
+	assert.Equal(t, jenkins.CascConfigDir, container.VolumeMounts[0].MountPath)
+	assert.Equal(t, jenkins.CascSecretsDir, container.VolumeMounts[1].MountPath)
+
+	require.Len(t, podSpec.Volumes, 2)
+	assert.Equal(t, jenkins.CascConfigMapName, podSpec.Volumes[0].ConfigMap.Name)
+	sources := podSpec.Volumes[1].Projected.Sources
+	require.Len(t, sources, 1)
+	assert.Equal(t, []corev1.KeyToPath{
+		{Key: kube.SecretDataUsername, Path: "jx-pipeline-git-github-username"},
+		{Key: kube.SecretDataPassword, Path: "jx-pipeline-git-github-password"},
+	}, sources[0].Secret.Items)
+
+	modified, err = jenkins.EnsureCascPodSpec(podSpec, secrets)
+	require.NoError(t, err)
+	assert.False(t, modified, "mounting again should not modify the pod spec")
+
+	modified, err = jenkins.EnsureCascPodSpec(podSpec, nil)
+	require.NoError(t, err)
+	assert.True(t, modified)
+	assert.Len(t, podSpec.Volumes, 1)
+	assert.Len(t, podSpec.Containers[0].VolumeMounts, 1)
+
+	_, err = jenkins.EnsureCascPodSpec(&corev1.PodSpec{}, secrets)
+	assert.Error(t, err)
+}
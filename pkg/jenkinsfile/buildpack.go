@@ -10,28 +10,106 @@ import (
 	"strings"
 )
 
-// InitBuildPack initialises the build pack URL and git ref returning the packs dir or an error
+const (
+	// BuildPackDirEnvVar the environment variable used to point at a local checkout of a build pack repository
+	// instead of the team build pack, which is handy when developing build packs
+	BuildPackDirEnvVar = "JX_BUILD_PACK_DIR"
+)
+
+// InitBuildPack initialises the build pack URL and git ref returning the packs dir or an error.
+// If the URL is a local directory then its packs are used as they are so that build packs can be developed locally.
+// Refs other than master (branches, tags or shas) are cloned into their own directory so that versions of the
+// build pack can be used side by side
 func InitBuildPack(gitter gits.Gitter, packURL string, packRef string) (string, error) {
+	if exists, err := util.FileExists(packURL); err == nil && exists {
+		return LocalBuildPack(packURL)
+	}
 	u, err := url.Parse(strings.TrimSuffix(packURL, ".git"))
 	if err != nil {
 		return "", fmt.Errorf("Failed to parse build pack URL: %s: %s", packURL, err)
 	}
+	if u.Scheme == "file" {
+		return LocalBuildPack(u.Path)
+	}
 
 	draftDir, err := util.DraftDir()
 	if err != nil {
 		return "", err
 	}
 	dir := filepath.Join(draftDir, "packs", u.Host, u.Path)
+	if packRef != "master" && packRef != "" {
+		dir += "@" + packRef
+	}
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return "", fmt.Errorf("Could not create %s: %s", dir, err)
 	}
 
-	err = gitter.CloneOrPull(packURL, dir)
+	if packRef == "master" || packRef == "" {
+		err = gitter.CloneOrPull(packURL, dir)
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, "packs"), nil
+	}
+	err = checkoutBuildPackRef(gitter, packURL, dir, packRef)
 	if err != nil {
 		return "", err
 	}
-	if packRef != "master" && packRef != "" {
+	return filepath.Join(dir, "packs"), nil
+}
+
+// LocalBuildPack returns the packs dir of the build pack repository checked out in the given directory
+func LocalBuildPack(dir string) (string, error) {
+	packsDir := filepath.Join(dir, "packs")
+	exists, err := util.FileExists(packsDir)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", fmt.Errorf("The local build pack %s does not contain a packs directory", dir)
+	}
+	return packsDir, nil
+}
+
+// checkoutBuildPackRef clones the build pack into the directory if required then checks out the ref.
+// Branches are pulled to pick up any changes whereas tags and shas are recloned if they cannot be found
+func checkoutBuildPackRef(gitter gits.Gitter, packURL string, dir string, packRef string) error {
+	empty, err := util.IsEmpty(dir)
+	if err != nil {
+		return err
+	}
+	if empty {
+		err = gitter.Clone(packURL, dir)
+		if err != nil {
+			return err
+		}
+	}
+	remoteBranches, err := gitter.RemoteBranches(dir)
+	if err != nil {
+		return err
+	}
+	if util.StringArrayIndex(remoteBranches, "origin/"+packRef) >= 0 {
 		err = gitter.CheckoutRemoteBranch(dir, packRef)
+		if err != nil {
+			return err
+		}
+		if empty {
+			return nil
+		}
+		return gitter.Pull(dir)
+	}
+	err = gitter.Checkout(dir, packRef)
+	if err == nil || empty {
+		return err
+	}
+	// the tag or sha may be newer than our clone so lets clone it again
+	err = os.RemoveAll(dir)
+	if err != nil {
+		return err
+	}
+	err = gitter.Clone(packURL, dir)
+	if err != nil {
+		return err
 	}
-	return filepath.Join(dir, "packs"), err
+	return gitter.Checkout(dir, packRef)
 }
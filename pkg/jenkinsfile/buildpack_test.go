@@ -0,0 +1,34 @@
+package jenkinsfile_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/jenkinsfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitBuildPackLocalDir(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "test-build-pack-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = jenkinsfile.InitBuildPack(nil, dir, "")
+	assert.Error(t, err, "a local build pack without a packs directory should fail")
+
+	packsDir := filepath.Join(dir, "packs")
+	require.NoError(t, os.MkdirAll(filepath.Join(packsDir, "maven"), 0755))
+
+	answer, err := jenkinsfile.InitBuildPack(nil, dir, "")
+	require.NoError(t, err)
+	assert.Equal(t, packsDir, answer)
+
+	answer, err = jenkinsfile.InitBuildPack(nil, "file://"+dir, "v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, packsDir, answer)
+}
@@ -0,0 +1,144 @@
+package jenkinsfile
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	stagesBlockRegex = regexp.MustCompile(`(?m)^\s*stages\s*\{`)
+	stepsBlockRegex  = regexp.MustCompile(`(?m)\bsteps\s*\{`)
+
+	tagCommandRegex     = regexp.MustCompile(`\bjx step (tag|next-version)\b`)
+	releaseVersionRegex = regexp.MustCompile(`echo \\?\$\(jx-release-version\) > VERSION`)
+)
+
+// ScopeToPath rewrites a declarative Jenkinsfile of an application in the given sub directory of a monorepo so that
+// the steps run inside the sub directory and the stages only run when the commits change files in it. The stages
+// also run when there are no change sets such as on the first build of a branch
+func ScopeToPath(jenkinsfile string, path string) (string, error) {
+	path = strings.Trim(path, "/")
+	quotedPath := strings.Replace(path, "'", "\\'", -1)
+
+	answer, err := wrapBlocks(jenkinsfile, stepsBlockRegex, fmt.Sprintf("dir('%s') {", quotedPath))
+	if err != nil {
+		return "", err
+	}
+
+	loc := stagesBlockRegex.FindStringIndex(answer)
+	if loc == nil {
+		return "", fmt.Errorf("no stages block found in the Jenkinsfile")
+	}
+	end, err := matchingBrace(answer, loc[1]-1)
+	if err != nil {
+		return "", err
+	}
+	header := fmt.Sprintf(`stage('%s') {
+  when {
+    anyOf {
+      changeset "%s/**"
+      expression { return currentBuild.changeSets.isEmpty() }
+    }
+  }
+  stages {`, quotedPath, path)
+	stages := nestBlock(answer[loc[1]:end], lineIndent(answer, loc[1]-1), header, "  }\n}")
+	return answer[:loc[1]] + stages + answer[end:], nil
+}
+
+// PrefixReleaseTags rewrites the release steps of a Jenkinsfile of an application of a monorepo so that the release
+// tags of the application start with the given prefix and its versions are worked out from its own tags only
+func PrefixReleaseTags(jenkinsfile string, prefix string) string {
+	answer := tagCommandRegex.ReplaceAllString(jenkinsfile, "jx step $1 --tag-prefix "+prefix)
+	return releaseVersionRegex.ReplaceAllString(answer, "jx step next-version --use-git-tag-only --tag-prefix "+prefix)
+}
+
+// wrapBlocks wraps the contents of all the blocks matching the regular expression in a nested block opened by the header
+func wrapBlocks(text string, blockRegex *regexp.Regexp, header string) (string, error) {
+	var buffer strings.Builder
+	last := 0
+	for _, loc := range blockRegex.FindAllStringIndex(text, -1) {
+		if loc[0] < last {
+			continue
+		}
+		end, err := matchingBrace(text, loc[1]-1)
+		if err != nil {
+			return "", err
+		}
+		content := text[loc[1]:end]
+		buffer.WriteString(text[last:loc[1]])
+		if strings.Contains(content, "\n") {
+			buffer.WriteString(nestBlock(content, lineIndent(text, loc[1]-1), header, "}"))
+		} else {
+			// keep the blocks written on a single line on a single line
+			buffer.WriteString(" " + header + content + "} ")
+		}
+		last = end
+	}
+	buffer.WriteString(text[last:])
+	return buffer.String(), nil
+}
+
+// nestBlock nests the contents of a block in the block opened by the header lines and closed by the footer lines. The
+// indent is the indentation of the line opening the outer block and the contents are indented by the number of blocks
+// the header opens
+func nestBlock(content string, indent string, header string, footer string) string {
+	inner := indent + "  "
+	depth := strings.Count(header, "{") - strings.Count(header, "}")
+	var buffer strings.Builder
+	for _, line := range strings.Split(header, "\n") {
+		buffer.WriteString("\n" + inner + line)
+	}
+	buffer.WriteString(indentLines(strings.TrimRight(content, " \t\n"), strings.Repeat("  ", depth)))
+	for _, line := range strings.Split(footer, "\n") {
+		buffer.WriteString("\n" + inner + line)
+	}
+	buffer.WriteString("\n" + indent)
+	return buffer.String()
+}
+
+// indentLines prefixes all the non blank lines but the first one with the given indentation
+func indentLines(text string, indent string) string {
+	lines := strings.Split(text, "\n")
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) != "" {
+			lines[i] = indent + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// lineIndent returns the indentation of the line containing the given index
+func lineIndent(text string, index int) string {
+	line := text[strings.LastIndex(text[:index], "\n")+1:]
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
+
+// matchingBrace returns the index of the brace closing the one at the given index, ignoring braces in strings
+func matchingBrace(text string, open int) (int, error) {
+	depth := 0
+	var quote byte
+	for i := open; i < len(text); i++ {
+		c := text[i]
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '\'', '"':
+			quote = c
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("no closing brace found for the block at offset %d", open)
+}
@@ -0,0 +1,134 @@
+package jenkinsfile_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/jenkinsfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScopeToPath(t *testing.T) {
+	t.Parallel()
+
+	source := `pipeline {
+  agent any
+  stages {
+    stage('Build') {
+      steps {
+        sh "echo '{ not a block'"
+        sh "make build"
+      }
+    }
+  }
+}
+`
+	expected := `pipeline {
+  agent any
+  stages {
+    stage('services/app') {
+      when {
+        anyOf {
+          changeset "services/app/**"
+          expression { return currentBuild.changeSets.isEmpty() }
+        }
+      }
+      stages {
+        stage('Build') {
+          steps {
+            dir('services/app') {
+              sh "echo '{ not a block'"
+              sh "make build"
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+	actual, err := jenkinsfile.ScopeToPath(source, "services/app/")
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestScopeToPathWithNestedStages(t *testing.T) {
+	t.Parallel()
+
+	source := `pipeline {
+  agent any
+  stages {
+    stage('Test') {
+      parallel {
+        stage('Unit') {
+          steps { sh "make test" }
+        }
+        stage('Lint') {
+          steps {
+            sh "make lint"
+          }
+        }
+      }
+    }
+  }
+}
+`
+	expected := `pipeline {
+  agent any
+  stages {
+    stage('app') {
+      when {
+        anyOf {
+          changeset "app/**"
+          expression { return currentBuild.changeSets.isEmpty() }
+        }
+      }
+      stages {
+        stage('Test') {
+          parallel {
+            stage('Unit') {
+              steps { dir('app') { sh "make test" } }
+            }
+            stage('Lint') {
+              steps {
+                dir('app') {
+                  sh "make lint"
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+	actual, err := jenkinsfile.ScopeToPath(source, "app")
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestPrefixReleaseTags(t *testing.T) {
+	t.Parallel()
+
+	source := `steps {
+  sh "echo \$(jx-release-version) > VERSION"
+  sh "jx step tag --version \$(cat VERSION)"
+  sh "jx step next-version --filename pom.xml --tag"
+}
+`
+	expected := `steps {
+  sh "jx step next-version --use-git-tag-only --tag-prefix orders-"
+  sh "jx step tag --tag-prefix orders- --version \$(cat VERSION)"
+  sh "jx step next-version --tag-prefix orders- --filename pom.xml --tag"
+}
+`
+	assert.Equal(t, expected, jenkinsfile.PrefixReleaseTags(source, "orders-"))
+}
+
+func TestScopeToPathWithoutStages(t *testing.T) {
+	t.Parallel()
+
+	_, err := jenkinsfile.ScopeToPath("node {\n}\n", "app")
+	assert.Error(t, err)
+}
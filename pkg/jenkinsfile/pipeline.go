@@ -21,6 +21,9 @@ const (
 
 	// PipelineTemplateFileName defines the jenkisnfile template used to generate the pipeline
 	PipelineTemplateFileName = "Jenkinsfile.tmpl"
+
+	// SkaffoldBuildCommand the command used by the build packs to build the container image with the Docker daemon
+	SkaffoldBuildCommand = "skaffold build -f skaffold.yaml"
 )
 
 // PipelineAgent contains the agent definition metadata
@@ -101,6 +104,10 @@ type CreateJenkinsfileArguments struct {
 	TemplateFile      string
 	OutputFile        string
 	JenkinsfileRunner bool
+	// ImageBuildCommand if specified replaces the skaffold command used to build the container image
+	ImageBuildCommand string
+	// StaticAnalysisCommand if specified is run after the build of pull requests and releases
+	StaticAnalysisCommand string
 }
 
 // Validate validates all the arguments are set correctly
@@ -203,6 +210,35 @@ func removeWhenSteps(prow bool, steps []*PipelineStep) []*PipelineStep {
 	return answer
 }
 
+// ReplaceCommands replaces the text of any commands in the pipelines which contain the old text with the new text
+func (p *Pipelines) ReplaceCommands(old string, new string) {
+	for _, l := range p.All() {
+		if l != nil {
+			for _, lifecycle := range l.All() {
+				if lifecycle != nil {
+					lifecycle.ReplaceCommands(old, new)
+				}
+			}
+		}
+	}
+	if p.Post != nil {
+		p.Post.ReplaceCommands(old, new)
+	}
+}
+
+// ReplaceCommands replaces the text of any commands in the lifecycle which contain the old text with the new text
+func (l *PipelineLifecycle) ReplaceCommands(old string, new string) {
+	replaceCommands(old, new, l.PreSteps)
+	replaceCommands(old, new, l.Steps)
+}
+
+func replaceCommands(old string, new string, steps []*PipelineStep) {
+	for _, step := range steps {
+		step.Command = strings.Replace(step.Command, old, new, -1)
+		replaceCommands(old, new, step.Steps)
+	}
+}
+
 // Extend extends these pipelines with the base pipeline
 func (p *Pipelines) Extend(base *Pipelines) error {
 	p.PullRequest = ExtendPipelines(p.PullRequest, base.PullRequest)
@@ -505,6 +541,22 @@ func (c *PipelineConfig) ExtendPipeline(base *PipelineConfig, jenkinsfileRunner
 	return nil
 }
 
+// AddPostBuildCommand appends the command to the post build steps of the pull request and release pipelines
+// running it in the container and directory of the agent
+func (c *PipelineConfig) AddPostBuildCommand(command string) {
+	for _, l := range []*PipelineLifecycles{c.Pipelines.PullRequest, c.Pipelines.Release} {
+		if l == nil {
+			continue
+		}
+		if l.PostBuild == nil {
+			l.PostBuild = &PipelineLifecycle{}
+		}
+		steps := defaultDirAroundSteps(c.Agent.Dir, []*PipelineStep{{Command: command}})
+		steps = defaultContainerAroundSteps(c.Agent.Container, steps)
+		l.PostBuild.Steps = append(l.PostBuild.Steps, steps...)
+	}
+}
+
 func (c *PipelineConfig) defaultContainerAndDir() {
 	c.Pipelines.defaultContainerAndDir(c.Agent.Container, c.Agent.Dir)
 }
@@ -557,6 +609,12 @@ func (a *CreateJenkinsfileArguments) GenerateJenkinsfile(resolver ImportFileReso
 	if err != nil {
 		return err
 	}
+	if a.ImageBuildCommand != "" {
+		config.Pipelines.ReplaceCommands(SkaffoldBuildCommand, a.ImageBuildCommand)
+	}
+	if a.StaticAnalysisCommand != "" {
+		config.AddPostBuildCommand(a.StaticAnalysisCommand)
+	}
 
 	templateFile := a.TemplateFile
 
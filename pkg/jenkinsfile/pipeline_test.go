@@ -0,0 +1,66 @@
+package jenkinsfile_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/jenkinsfile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceCommands(t *testing.T) {
+	t.Parallel()
+
+	build := &jenkinsfile.PipelineStep{
+		Command: "export VERSION=$PREVIEW_VERSION && " + jenkinsfile.SkaffoldBuildCommand,
+	}
+	container := &jenkinsfile.PipelineStep{
+		Container: "maven",
+		Steps: []*jenkinsfile.PipelineStep{
+			{Command: "mvn install"},
+			build,
+		},
+	}
+	pipelines := &jenkinsfile.Pipelines{
+		PullRequest: &jenkinsfile.PipelineLifecycles{
+			Build: &jenkinsfile.PipelineLifecycle{
+				Steps: []*jenkinsfile.PipelineStep{container},
+			},
+		},
+	}
+
+	pipelines.ReplaceCommands(jenkinsfile.SkaffoldBuildCommand, "jx step image build --builder kaniko")
+
+	assert.Equal(t, "export VERSION=$PREVIEW_VERSION && jx step image build --builder kaniko", build.Command)
+	assert.Equal(t, "mvn install", container.Steps[0].Command)
+}
+
+func TestAddPostBuildCommand(t *testing.T) {
+	t.Parallel()
+
+	config := &jenkinsfile.PipelineConfig{
+		Agent: jenkinsfile.PipelineAgent{
+			Container: "maven",
+		},
+		Pipelines: jenkinsfile.Pipelines{
+			PullRequest: &jenkinsfile.PipelineLifecycles{},
+			Release: &jenkinsfile.PipelineLifecycles{
+				PostBuild: &jenkinsfile.PipelineLifecycle{
+					Steps: []*jenkinsfile.PipelineStep{{Command: "jx step post build"}},
+				},
+			},
+		},
+	}
+
+	config.AddPostBuildCommand("jx step scan sonarqube")
+
+	steps := config.Pipelines.PullRequest.PostBuild.Steps
+	assert.Len(t, steps, 1)
+	assert.Equal(t, "maven", steps[0].Container)
+	assert.Equal(t, "jx step scan sonarqube", steps[0].Steps[0].Command)
+
+	steps = config.Pipelines.Release.PostBuild.Steps
+	assert.Len(t, steps, 2)
+	assert.Equal(t, "jx step post build", steps[0].Command)
+	assert.Equal(t, "jx step scan sonarqube", steps[1].Steps[0].Command)
+	assert.Nil(t, config.Pipelines.Feature)
+}
@@ -0,0 +1,266 @@
+package syntax
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jenkinsfile"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// PipelineKindRelease the pipeline run when changes are merged to the master branch
+	PipelineKindRelease = "release"
+	// PipelineKindPullRequest the pipeline run for Pull Requests
+	PipelineKindPullRequest = "pullrequest"
+	// PipelineKindFeature the pipeline run for feature branches
+	PipelineKindFeature = "feature"
+)
+
+// PipelineKinds the kinds of pipeline which can be converted
+var PipelineKinds = []string{PipelineKindRelease, PipelineKindPullRequest, PipelineKindFeature}
+
+var (
+	envVarNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	envVarLineRegex = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_]*)\s*=\s*(.*)$`)
+)
+
+// ParsedPipeline is the YAML syntax of a pipeline made up of stages of steps
+type ParsedPipeline struct {
+	Agent       Agent    `yaml:"agent,omitempty"`
+	Environment []EnvVar `yaml:"environment,omitempty"`
+	Stages      []Stage  `yaml:"stages,omitempty"`
+	Post        []Step   `yaml:"post,omitempty"`
+}
+
+// Agent defines where the steps run: a Jenkins agent label and/or the container of the pod template
+type Agent struct {
+	Label     string `yaml:"label,omitempty"`
+	Container string `yaml:"container,omitempty"`
+}
+
+// EnvVar an environment variable available to the steps
+type EnvVar struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+// Stage a named group of steps or of nested stages
+type Stage struct {
+	Name        string   `yaml:"name"`
+	Agent       *Agent   `yaml:"agent,omitempty"`
+	Environment []EnvVar `yaml:"environment,omitempty"`
+	Steps       []Step   `yaml:"steps,omitempty"`
+	Stages      []Stage  `yaml:"stages,omitempty"`
+}
+
+// Step a shell command run in a container and directory
+type Step struct {
+	Comment   string `yaml:"comment,omitempty"`
+	Command   string `yaml:"sh,omitempty"`
+	Container string `yaml:"container,omitempty"`
+	Dir       string `yaml:"dir,omitempty"`
+}
+
+// LoadParsedPipeline loads the pipeline YAML syntax from the given file
+func LoadParsedPipeline(fileName string) (*ParsedPipeline, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load file %s", fileName)
+	}
+	pipeline := &ParsedPipeline{}
+	err = yaml.UnmarshalStrict(data, pipeline)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal file %s", fileName)
+	}
+	return pipeline, nil
+}
+
+// ToYaml returns the YAML of the pipeline
+func (p *ParsedPipeline) ToYaml() (string, error) {
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Validate returns an error describing every problem with the pipeline or nil if it is valid
+func (p *ParsedPipeline) Validate() error {
+	errs := validateEnvironment("pipeline", p.Environment)
+	if len(p.Stages) == 0 {
+		errs = append(errs, fmt.Errorf("the pipeline has no stages"))
+	}
+	names := map[string]bool{}
+	for i := range p.Stages {
+		errs = append(errs, p.Stages[i].validate(&p.Agent, names)...)
+	}
+	for i, step := range p.Post {
+		errs = append(errs, step.validate(fmt.Sprintf("post step %d", i+1), &p.Agent)...)
+	}
+	return util.CombineErrors(errs...)
+}
+
+func (s *Stage) validate(parentAgent *Agent, names map[string]bool) []error {
+	errs := []error{}
+	name := s.Name
+	if name == "" {
+		errs = append(errs, fmt.Errorf("a stage has no name"))
+		name = "<unnamed>"
+	} else if names[name] {
+		errs = append(errs, fmt.Errorf("the stage name %s is used more than once", name))
+	}
+	names[name] = true
+	label := "stage " + name
+	errs = append(errs, validateEnvironment(label, s.Environment)...)
+
+	if len(s.Steps) == 0 && len(s.Stages) == 0 {
+		errs = append(errs, fmt.Errorf("%s has no steps or stages", label))
+	} else if len(s.Steps) > 0 && len(s.Stages) > 0 {
+		errs = append(errs, fmt.Errorf("%s has both steps and stages", label))
+	}
+	agent := parentAgent
+	if s.Agent != nil {
+		agent = s.Agent
+	}
+	for i, step := range s.Steps {
+		errs = append(errs, step.validate(fmt.Sprintf("step %d of %s", i+1, label), agent)...)
+	}
+	for i := range s.Stages {
+		errs = append(errs, s.Stages[i].validate(agent, names)...)
+	}
+	return errs
+}
+
+func (s *Step) validate(label string, agent *Agent) []error {
+	errs := []error{}
+	if strings.TrimSpace(s.Command) == "" {
+		errs = append(errs, fmt.Errorf("%s has no sh command", label))
+	}
+	if s.Container == "" && agent.Container == "" && agent.Label == "" {
+		errs = append(errs, fmt.Errorf("%s has no container or agent to run in", label))
+	}
+	return errs
+}
+
+func validateEnvironment(label string, env []EnvVar) []error {
+	errs := []error{}
+	for _, e := range env {
+		if !envVarNameRegex.MatchString(e.Name) {
+			errs = append(errs, fmt.Errorf("%s has an invalid environment variable name '%s'", label, e.Name))
+		}
+	}
+	return errs
+}
+
+// FromPipelineConfig converts the given kind of pipeline of the build pack configuration to the YAML syntax
+// returning any warnings about steps which could not be converted
+func FromPipelineConfig(config *jenkinsfile.PipelineConfig, kind string) (*ParsedPipeline, []string, error) {
+	var lifecycles *jenkinsfile.PipelineLifecycles
+	switch kind {
+	case PipelineKindRelease:
+		lifecycles = config.Pipelines.Release
+	case PipelineKindPullRequest:
+		lifecycles = config.Pipelines.PullRequest
+	case PipelineKindFeature:
+		lifecycles = config.Pipelines.Feature
+	default:
+		return nil, nil, util.InvalidOption("kind", kind, PipelineKinds)
+	}
+	if lifecycles == nil {
+		return nil, nil, fmt.Errorf("the build pack has no %s pipeline", kind)
+	}
+
+	c := &converter{}
+	pipeline := &ParsedPipeline{
+		Agent: Agent{
+			Label:     config.Agent.Label,
+			Container: config.Agent.Container,
+		},
+		Environment: parseEnvironment(config.Environment),
+	}
+	stageNames := []string{"setup", "setVersion", "preBuild", "build", "postBuild", "promote"}
+	for i, lifecycle := range lifecycles.All() {
+		if lifecycle == nil {
+			continue
+		}
+		steps := c.convertSteps(lifecycle.Steps, "", "", "")
+		if len(steps) > 0 {
+			pipeline.Stages = append(pipeline.Stages, Stage{
+				Name:  stageNames[i],
+				Steps: steps,
+			})
+		}
+	}
+	if config.Pipelines.Post != nil {
+		pipeline.Post = c.convertSteps(config.Pipelines.Post.Steps, "", "", "")
+	}
+	return pipeline, c.warnings, nil
+}
+
+type converter struct {
+	warnings []string
+}
+
+// convertSteps flattens the nested container and dir steps into steps which run a command in a container and dir
+func (c *converter) convertSteps(steps []*jenkinsfile.PipelineStep, container string, dir string, comment string) []Step {
+	answer := []Step{}
+	for _, s := range steps {
+		stepContainer := container
+		if s.Container != "" {
+			stepContainer = s.Container
+		}
+		stepDir := dir
+		if s.Dir != "" {
+			stepDir = s.Dir
+		}
+		stepComment := comment
+		if s.Comment != "" {
+			stepComment = s.Comment
+		}
+		switch {
+		case s.Container != "" || s.Dir != "":
+			// like the Jenkinsfile the container and dir steps only wrap their child steps
+		case s.Command != "":
+			answer = append(answer, Step{
+				Comment:   stepComment,
+				Command:   s.Command,
+				Container: stepContainer,
+				Dir:       stepDir,
+			})
+			stepComment = ""
+		case s.Groovy != "":
+			c.warnings = append(c.warnings, fmt.Sprintf("cannot convert groovy step: %s", strings.TrimSpace(s.Groovy)))
+		}
+		answer = append(answer, c.convertSteps(s.Steps, stepContainer, stepDir, stepComment)...)
+	}
+	return answer
+}
+
+// parseEnvironment parses the lines of the Jenkinsfile environment block of the form NAME = value
+func parseEnvironment(text string) []EnvVar {
+	answer := []EnvVar{}
+	for _, line := range strings.Split(text, "\n") {
+		matches := envVarLineRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if len(matches) == 3 {
+			answer = append(answer, EnvVar{
+				Name:  matches[1],
+				Value: trimQuotes(strings.TrimSpace(matches[2])),
+			})
+		}
+	}
+	return answer
+}
+
+func trimQuotes(text string) string {
+	for _, q := range []string{`"`, `'`} {
+		if len(text) >= 2 && strings.HasPrefix(text, q) && strings.HasSuffix(text, q) {
+			return text[1 : len(text)-1]
+		}
+	}
+	return text
+}
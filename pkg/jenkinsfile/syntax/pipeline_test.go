@@ -0,0 +1,113 @@
+package syntax_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/jenkinsfile"
+	"github.com/jenkins-x/jx/pkg/jenkinsfile/syntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromPipelineConfig(t *testing.T) {
+	t.Parallel()
+
+	config := &jenkinsfile.PipelineConfig{
+		Agent: jenkinsfile.PipelineAgent{
+			Label:     "jenkins-maven",
+			Container: "maven",
+		},
+		Environment: `
+    DEPLOY_NAMESPACE = "jx-staging"
+    CHARTMUSEUM_CREDS = credentials('jenkins-x-chartmuseum')`,
+		Pipelines: jenkinsfile.Pipelines{
+			Release: &jenkinsfile.PipelineLifecycles{
+				SetVersion: &jenkinsfile.PipelineLifecycle{
+					Steps: []*jenkinsfile.PipelineStep{
+						{
+							Container: "maven",
+							Steps: []*jenkinsfile.PipelineStep{
+								{Command: "echo $(jx-release-version) > VERSION", Comment: "so we can retrieve the version in later steps"},
+							},
+						},
+					},
+				},
+				Build: &jenkinsfile.PipelineLifecycle{
+					Steps: []*jenkinsfile.PipelineStep{
+						{
+							Container: "maven",
+							Steps: []*jenkinsfile.PipelineStep{
+								{Command: "mvn clean deploy"},
+								{
+									Dir: "charts/myapp",
+									Steps: []*jenkinsfile.PipelineStep{
+										{Command: "jx step helm release"},
+									},
+								},
+								{Groovy: "retry(3)"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	pipeline, warnings, err := syntax.FromPipelineConfig(config, syntax.PipelineKindRelease)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cannot convert groovy step: retry(3)"}, warnings)
+	assert.Equal(t, syntax.Agent{Label: "jenkins-maven", Container: "maven"}, pipeline.Agent)
+	assert.Equal(t, []syntax.EnvVar{
+		{Name: "DEPLOY_NAMESPACE", Value: "jx-staging"},
+		{Name: "CHARTMUSEUM_CREDS", Value: "credentials('jenkins-x-chartmuseum')"},
+	}, pipeline.Environment)
+
+	require.Len(t, pipeline.Stages, 2)
+	assert.Equal(t, "setVersion", pipeline.Stages[0].Name)
+	assert.Equal(t, []syntax.Step{
+		{Comment: "so we can retrieve the version in later steps", Command: "echo $(jx-release-version) > VERSION", Container: "maven"},
+	}, pipeline.Stages[0].Steps)
+	assert.Equal(t, "build", pipeline.Stages[1].Name)
+	assert.Equal(t, []syntax.Step{
+		{Command: "mvn clean deploy", Container: "maven"},
+		{Command: "jx step helm release", Container: "maven", Dir: "charts/myapp"},
+	}, pipeline.Stages[1].Steps)
+	assert.NoError(t, pipeline.Validate())
+
+	_, _, err = syntax.FromPipelineConfig(config, syntax.PipelineKindPullRequest)
+	assert.Error(t, err)
+	_, _, err = syntax.FromPipelineConfig(config, "cheese")
+	assert.Error(t, err)
+}
+
+func TestValidateParsedPipeline(t *testing.T) {
+	t.Parallel()
+
+	pipeline := &syntax.ParsedPipeline{
+		Environment: []syntax.EnvVar{{Name: "NOT-VALID", Value: "x"}},
+		Stages: []syntax.Stage{
+			{
+				Name:  "build",
+				Steps: []syntax.Step{{Command: "make build"}},
+			},
+			{
+				Name:  "build",
+				Agent: &syntax.Agent{Container: "go"},
+				Steps: []syntax.Step{{Command: ""}},
+			},
+			{
+				Name: "empty",
+			},
+		},
+	}
+	err := pipeline.Validate()
+	require.Error(t, err)
+	message := err.Error()
+	assert.Contains(t, message, "invalid environment variable name 'NOT-VALID'")
+	assert.Contains(t, message, "step 1 of stage build has no container or agent to run in")
+	assert.Contains(t, message, "the stage name build is used more than once")
+	assert.Contains(t, message, "has no sh command")
+	assert.Contains(t, message, "stage empty has no steps or stages")
+
+	assert.EqualError(t, (&syntax.ParsedPipeline{}).Validate(), "the pipeline has no stages")
+}
@@ -53,5 +53,86 @@ spec:
       name: letsencrypt-staging
     # Enable the HTTP-01 challenge provider
     http01: {}
+`
+	Cert_manager_cluster_issuer_prod = `
+apiVersion: certmanager.k8s.io/v1alpha1
+kind: ClusterIssuer
+metadata:
+  name: letsencrypt-prod
+  labels:
+    jenkins.io/created-by: jx
+spec:
+  acme:
+    # The ACME server URL
+    server: https://acme-v02.api.letsencrypt.org/directory
+    # Email address used for ACME registration
+    email: %s
+    # Name of a secret used to store the ACME account private key
+    privateKeySecretRef:
+      name: letsencrypt-prod
+    # Enable the HTTP-01 challenge provider
+    http01: {}
+`
+	Cert_manager_cluster_issuer_prod_dns01 = `
+apiVersion: certmanager.k8s.io/v1alpha1
+kind: ClusterIssuer
+metadata:
+  name: letsencrypt-prod
+  labels:
+    jenkins.io/created-by: jx
+spec:
+  acme:
+    # The ACME server URL
+    server: https://acme-v02.api.letsencrypt.org/directory
+    # Email address used for ACME registration
+    email: %s
+    # Name of a secret used to store the ACME account private key
+    privateKeySecretRef:
+      name: letsencrypt-prod
+    # Enable the HTTP-01 challenge provider
+    http01: {}
+    # Enable the DNS-01 challenge provider used for wildcard certificates
+    dns01:
+      providers:
+%s
+`
+	Cert_manager_dns01_clouddns = `      - name: clouddns
+        clouddns:
+          project: %s
+          serviceAccountSecretRef:
+            name: %s
+            key: credentials.json
+`
+	Cert_manager_dns01_route53 = `      - name: route53
+        route53:
+          region: %s
+`
+	Cert_manager_wildcard_certificate = `
+apiVersion: certmanager.k8s.io/v1alpha1
+kind: Certificate
+metadata:
+  name: %s
+spec:
+  secretName: %s
+  issuerRef:
+    name: %s
+    kind: ClusterIssuer
+  commonName: "*.%s"
+  acme:
+    config:
+    - dns01:
+        provider: %s
+      domains:
+      - "*.%s"
+`
+	Cert_manager_cluster_issuer_self_signed = `
+apiVersion: certmanager.k8s.io/v1alpha1
+kind: ClusterIssuer
+metadata:
+  name: selfsigned
+  labels:
+    jenkins.io/created-by: jx
+spec:
+  selfSigned: {}
 `
 )
@@ -21,17 +21,20 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"syscall"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/jenkins-x/jx/pkg/cloud/gke"
 	"github.com/jenkins-x/jx/pkg/extensions"
 
 	"github.com/jenkins-x/jx/pkg/log"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/jenkins-x/jx/pkg/version"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
@@ -54,10 +57,15 @@ func NewJXCommand(f Factory, in terminal.FileReader, out terminal.FileWriter, er
 		Use:   "jx",
 		Short: "jx is a command line tool for working with Jenkins X",
 		//Long: ``,
-		Run: runHelp,
-		/*
-			BashCompletionFunction: bash_completion_func,
-		*/
+		Run:                    runHelp,
+		BashCompletionFunction: bashCompletionFunction(),
+	}
+	addLoggingFlags(cmds)
+	addTelemetry(cmds)
+	if f != nil {
+		if runner := f.CreateCommandRunner(); runner != nil {
+			gke.SetCommandRunner(runner)
+		}
 	}
 
 	addCommands := NewCmdAdd(f, in, out, err)
@@ -71,6 +79,8 @@ func NewJXCommand(f Factory, in terminal.FileReader, out terminal.FileWriter, er
 		NewCmdInstall(f, in, out, err),
 		NewCmdUninstall(f, in, out, err),
 		NewCmdUpgrade(f, in, out, err),
+		NewCmdRestore(f, in, out, err),
+		NewCmdResume(f, in, out, err),
 	}
 	installCommands = append(installCommands, findCommands("cluster", createCommands, deleteCommands)...)
 	installCommands = append(installCommands, findCommands("cluster", updateCommands)...)
@@ -96,8 +106,10 @@ func NewJXCommand(f Factory, in terminal.FileReader, out terminal.FileWriter, er
 	addonCommands = append(addonCommands, findCommands("app", createCommands, deleteCommands, addCommands)...)
 
 	environmentsCommands := []*cobra.Command{
+		NewCmdDiff(f, in, out, err),
 		NewCmdPreview(f, in, out, err),
 		NewCmdPromote(f, in, out, err),
+		NewCmdRollback(f, in, out, err),
 	}
 	environmentsCommands = append(environmentsCommands, findCommands("environment", createCommands, deleteCommands, editCommands, getCommands)...)
 
@@ -137,10 +149,12 @@ func NewJXCommand(f Factory, in terminal.FileReader, out terminal.FileWriter, er
 			Message: "Working with Applications:",
 			Commands: []*cobra.Command{
 				NewCmdConsole(f, in, out, err),
+				NewCmdForward(f, in, out, err),
 				NewCmdLogs(f, in, out, err),
 				NewCmdOpen(f, in, out, err),
 				NewCmdRsh(f, in, out, err),
 				NewCmdSync(f, in, out, err),
+				NewCmdUI(f, in, out, err),
 			},
 		},
 		{
@@ -207,6 +221,7 @@ func NewJXCommand(f Factory, in terminal.FileReader, out terminal.FileWriter, er
 	cmds.SetVersionTemplate("{{printf .Version}}\n")
 	cmds.AddCommand(NewCmdOptions(out))
 	cmds.AddCommand(NewCmdDiagnose(f, in, out, err))
+	cmds.AddCommand(NewCmdPlugin(f, in, out, err))
 
 	managedPlugins := &managedPluginHandler{
 		CommonOptions: commonOptions,
@@ -221,12 +236,17 @@ func NewJXCommand(f Factory, in terminal.FileReader, out terminal.FileWriter, er
 		// the specified command does not already exist
 		if _, _, err := cmds.Find(cmdPathPieces); err != nil {
 			if managedPluginsEnabled {
-				if err := handleEndpointExtensions(managedPlugins, cmdPathPieces); err != nil {
+				if err := handleEndpointExtensions(managedPlugins, cmdPathPieces, func() []string {
+					// the managed plugins were just listed so the team can be looked up in the cluster
+					return commonOptions.pluginEnvironment(true)
+				}); err != nil {
 					log.Errorf("%v\n", err)
 					os.Exit(1)
 				}
 			} else {
-				if err := handleEndpointExtensions(localPlugins, cmdPathPieces); err != nil {
+				if err := handleEndpointExtensions(localPlugins, cmdPathPieces, func() []string {
+					return commonOptions.pluginEnvironment(false)
+				}); err != nil {
 					log.Errorf("%v\n", err)
 					os.Exit(1)
 				}
@@ -333,7 +353,19 @@ func (h *localPluginHandler) Lookup(filename string) (string, error) {
 		filename = filename + ".exe"
 	}
 
-	return exec.LookPath(filename)
+	path, err := exec.LookPath(filename)
+	if err == nil {
+		return path, nil
+	}
+	// fall back to the plugins installed from a plugin index
+	dir, dirErr := extensions.LocalPluginBinDir()
+	if dirErr == nil {
+		installed := filepath.Join(dir, filename)
+		if exists, _ := util.FileExists(installed); exists {
+			return installed, nil
+		}
+	}
+	return path, err
 }
 
 // Execute implements PluginHandler
@@ -341,7 +373,9 @@ func (h *localPluginHandler) Execute(executablePath string, cmdArgs, environment
 	return syscall.Exec(executablePath, cmdArgs, environment)
 }
 
-func handleEndpointExtensions(pluginHandler PluginHandler, cmdArgs []string) error {
+// handleEndpointExtensions executes the plugin binary matching the longest prefix of the arguments. The plugin is
+// passed the current environment along with the variables describing the current team and namespace
+func handleEndpointExtensions(pluginHandler PluginHandler, cmdArgs []string, environment func() []string) error {
 	remainingArgs := []string{} // all "non-flag" arguments
 
 	for idx := range cmdArgs {
@@ -375,7 +409,7 @@ func handleEndpointExtensions(pluginHandler PluginHandler, cmdArgs []string) err
 	// invoke cmd binary relaying the current environment and args given
 	// remainingArgs will always have at least one element.
 	// execve will make remainingArgs[0] the "binary name".
-	if err := pluginHandler.Execute(foundBinaryPath, append([]string{foundBinaryPath}, cmdArgs[len(remainingArgs):]...), os.Environ()); err != nil {
+	if err := pluginHandler.Execute(foundBinaryPath, append([]string{foundBinaryPath}, cmdArgs[len(remainingArgs):]...), environment()); err != nil {
 		return err
 	}
 
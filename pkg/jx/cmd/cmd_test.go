@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJXCommandMergesTheFlagsOfAllCommands(t *testing.T) {
+	t.Parallel()
+
+	root := NewJXCommand(NewFactory(), os.Stdin, os.Stdout, os.Stderr)
+	assert.Nil(t, root.PersistentFlags().Lookup(optionVerbose), "--verbose is registered by each command")
+	assert.NotNil(t, root.PersistentFlags().Lookup(optionLogFormat))
+
+	var visit func(c *cobra.Command)
+	visit = func(c *cobra.Command) {
+		assert.NotPanics(t, func() {
+			c.InheritedFlags()
+			c.Flags()
+		}, c.CommandPath())
+		for _, child := range c.Commands() {
+			visit(child)
+		}
+	}
+	visit(root)
+}
+
+func TestConfigureLogging(t *testing.T) {
+	defer log.SetLevel("info")
+	defer log.SetJSONFormat(false)
+
+	root := &cobra.Command{Use: "jx"}
+	addLoggingFlags(root)
+	o := &CommonOptions{}
+	child := &cobra.Command{Use: "child"}
+	o.addCommonFlags(child)
+	root.AddCommand(child)
+
+	require.NoError(t, child.ParseFlags([]string{"--verbose", "--log-format", "json"}))
+	require.NoError(t, configureLogging(child))
+	assert.True(t, o.Verbose)
+	assert.True(t, log.IsDebug())
+
+	child = &cobra.Command{Use: "quiet"}
+	root.AddCommand(child)
+	require.NoError(t, child.ParseFlags([]string{"--quiet"}))
+	require.NoError(t, configureLogging(child))
+	assert.Equal(t, "warning", log.GetLevel())
+
+	child = &cobra.Command{Use: "invalid"}
+	root.AddCommand(child)
+	require.NoError(t, child.ParseFlags([]string{"--log-format", "xml"}))
+	assert.Error(t, configureLogging(child))
+}
@@ -32,12 +32,14 @@ import (
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/table"
 	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/versionstream"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 	gitcfg "gopkg.in/src-d/go-git.v4/config"
 	"gopkg.in/yaml.v2"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -47,6 +49,8 @@ const (
 	optionBatchMode        = "batch-mode"
 	optionVerbose          = "verbose"
 	optionLogLevel         = "log-level"
+	optionLogFormat        = "log-format"
+	optionQuiet            = "quiet"
 	optionHeadless         = "headless"
 	optionNoBrew           = "no-brew"
 	optionInstallDeps      = "install-dependencies"
@@ -79,6 +83,9 @@ type CommonOptions struct {
 	Username               string
 	ExternalJenkinsBaseURL string
 	PullSecrets            string
+	VersionStreamRef       string
+	InsecureSkipVerify     bool
+	Helm3                  bool
 
 	// common cached clients
 	KubeClientCached       kubernetes.Interface
@@ -94,6 +101,8 @@ type CommonOptions struct {
 	vaultOperatorClient    vaultoperatorclient.Interface
 	modifyDevEnvironmentFn ModifyDevEnvironmentFn
 	modifyEnvironmentFn    ModifyEnvironmentFn
+	versions               *versionstream.Versions
+	commandRunner          util.CommandRunner
 
 	Prow
 }
@@ -134,14 +143,62 @@ func (o *CommonOptions) SetDevNamespace(ns string) {
 
 // Debugf outputs the given text to the console if verbose mode is enabled
 func (o *CommonOptions) Debugf(format string, a ...interface{}) {
-	if o.Verbose {
+	if o.Verbose || log.IsDebug() {
 		log.Infof(format, a...)
 	}
 }
 
+// addLoggingFlags adds the logging flags which apply to all commands. The --verbose flag is registered by each command
+// via addCommonFlags
+func addLoggingFlags(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+	flags.Bool(optionQuiet, false, "Only log warnings and errors such as when running in CI")
+	flags.String(optionLogFormat, "text", "The format of the logs. One of: text, json")
+	cmd.PersistentPreRun = func(c *cobra.Command, args []string) {
+		err := configureLogging(c)
+		CheckErr(err)
+	}
+}
+
+// configureLogging configures the level and format of the logs from the logging flags of the command
+func configureLogging(cmd *cobra.Command) error {
+	flags := cmd.Flags()
+	flagValue := func(name string) string {
+		flag := flags.Lookup(name)
+		if flag == nil {
+			return ""
+		}
+		return flag.Value.String()
+	}
+	level := ""
+	if flag := flags.Lookup(optionLogLevel); flag != nil && flag.Changed {
+		level = flag.Value.String()
+	}
+	if flagValue(optionVerbose) == "true" {
+		level = logrus.DebugLevel.String()
+	} else if flagValue(optionQuiet) == "true" {
+		level = logrus.WarnLevel.String()
+	}
+	if level != "" {
+		err := log.SetLevel(level)
+		if err != nil {
+			return util.InvalidOptionError(optionLogLevel, level, err)
+		}
+	}
+	switch format := flagValue(optionLogFormat); format {
+	case "", "text":
+		log.SetJSONFormat(false)
+	case "json":
+		log.SetJSONFormat(true)
+	default:
+		return util.InvalidOption(optionLogFormat, format, []string{"text", "json"})
+	}
+	return nil
+}
+
 func (options *CommonOptions) addCommonFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(&options.BatchMode, optionBatchMode, "b", false, "In batch mode the command never prompts for user input")
-	cmd.Flags().BoolVarP(&options.Verbose, optionVerbose, "", false, "Enable verbose logging")
+	cmd.Flags().BoolVarP(&options.Verbose, optionVerbose, "", false, "Enable verbose logging including the external commands which are run along with their durations")
 	cmd.Flags().StringVarP(&options.LogLevel, optionLogLevel, "", logrus.InfoLevel.String(), "Logging level. Possible values - panic, fatal, error, warning, info, debug.")
 	cmd.Flags().BoolVarP(&options.Headless, optionHeadless, "", false, "Enable headless operation if using browser automation")
 	cmd.Flags().BoolVarP(&options.NoBrew, optionNoBrew, "", false, "Disables the use of brew on macOS to install or upgrade command line dependencies")
@@ -176,6 +233,19 @@ func (o *CommonOptions) KubeClient() (kubernetes.Interface, string, error) {
 	return o.KubeClientCached, o.currentNamespace, nil
 }
 
+// dynamicClient creates a dynamic client for the resources we do not have a generated client for
+func (o *CommonOptions) dynamicClient() (dynamic.Interface, error) {
+	config, err := o.Factory.CreateKubeConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the dynamic client")
+	}
+	return client, nil
+}
+
 // KubeClientAndDevNamespace returns a kube client and the development namespace
 func (o *CommonOptions) KubeClientAndDevNamespace() (kubernetes.Interface, string, error) {
 	kubeClient, curNs, err := o.KubeClient()
@@ -302,6 +372,10 @@ func (o *CommonOptions) Git() gits.Gitter {
 func (o *CommonOptions) Helm() helm.Helmer {
 	if o.helm == nil {
 		helmBinary, noTiller, helmTemplate, _ := o.TeamHelmBin()
+		if o.Helm3 {
+			o.helm = helm.NewHelmCLI(helmBinary, helm.V3, "", o.Verbose)
+			return o.helm
+		}
 		o.helm = o.Factory.GetHelm(o.Verbose, helmBinary, noTiller, helmTemplate)
 	}
 	return o.helm
@@ -2,10 +2,19 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
+	"github.com/jenkins-x/jx/pkg/addon"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/auth"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // getAddonAuth returns the server and user auth for the given addon service URL
@@ -54,3 +63,125 @@ func (o *CommonOptions) CreateAddonAuthConfigService() (auth.ConfigService, erro
 	}
 	return o.Factory.CreateAddonAuthConfigService(secrets)
 }
+
+// addonRegistry returns the registry of the built in addons and any custom addons of the user
+func (o *CommonOptions) addonRegistry() (*addon.Registry, error) {
+	registry, err := addon.LoadRegistry(kube.AddonCharts, kube.AddonServices)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the addon registry")
+	}
+	return registry, nil
+}
+
+// recordAddon records the settings of the installed addon in the team settings so that upgrades preserve them. The
+// values which are secrets, such as passwords, are stored in a Secret rather than in the team settings
+func (o *CommonOptions) recordAddon(settings v1.AddonSettings) error {
+	values, secretValues := splitAddonSecretValues(settings.SetValues)
+	err := o.saveAddonSecretValues(settings.Name, secretValues)
+	if err != nil {
+		return err
+	}
+	settings.SetValues = values
+	callback := func(env *v1.Environment) error {
+		env.Spec.TeamSettings.SetAddon(settings)
+		return nil
+	}
+	return o.ModifyDevEnvironment(callback)
+}
+
+// forgetAddon removes the settings of a deleted addon from the team settings
+func (o *CommonOptions) forgetAddon(name string) error {
+	err := o.saveAddonSecretValues(name, nil)
+	if err != nil {
+		return err
+	}
+	callback := func(env *v1.Environment) error {
+		env.Spec.TeamSettings.RemoveAddon(name)
+		return nil
+	}
+	return o.ModifyDevEnvironment(callback)
+}
+
+// addonSetValues returns the values the addon was installed with including the values stored in its Secret
+func (o *CommonOptions) addonSetValues(settings *v1.AddonSettings) ([]string, error) {
+	answer := append([]string{}, settings.SetValues...)
+	kubeClient, ns, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return nil, err
+	}
+	name := addonValuesSecretName(settings.Name)
+	secret, err := kubeClient.CoreV1().Secrets(ns).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return answer, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get the secret %s in namespace %s", name, ns)
+	}
+	keys := []string{}
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		answer = append(answer, key+"="+string(secret.Data[key]))
+	}
+	return answer, nil
+}
+
+// saveAddonSecretValues stores the secret values of the addon in its Secret or deletes the Secret if there are none
+func (o *CommonOptions) saveAddonSecretValues(addonName string, values map[string]string) error {
+	kubeClient, ns, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	name := addonValuesSecretName(addonName)
+	secrets := kubeClient.CoreV1().Secrets(ns)
+	if len(values) == 0 {
+		err = secrets.Delete(name, &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete the secret %s in namespace %s", name, ns)
+		}
+		return nil
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				kube.LabelCreatedBy: kube.ValueCreatedByJX,
+			},
+		},
+		Data: map[string][]byte{},
+	}
+	for key, value := range values {
+		secret.Data[key] = []byte(value)
+	}
+	_, err = secrets.Create(secret)
+	if apierrors.IsAlreadyExists(err) {
+		_, err = secrets.Update(secret)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to save the secret %s in namespace %s", name, ns)
+	}
+	return nil
+}
+
+// addonValuesSecretName returns the name of the Secret the secret values of the addon are stored in
+func addonValuesSecretName(addonName string) string {
+	return kube.ToValidName("jx-addon-" + addonName + "-values")
+}
+
+// splitAddonSecretValues splits the name=value pairs of an addon into the values which can be stored in the team
+// settings and the values which are secrets indexed by name
+func splitAddonSecretValues(setValues []string) ([]string, map[string]string) {
+	values := []string{}
+	secretValues := map[string]string{}
+	for _, v := range setValues {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) == 2 && util.IsSecretName(parts[0]) {
+			secretValues[parts[0]] = parts[1]
+		} else if strings.TrimSpace(v) != "" {
+			values = append(values, v)
+		}
+	}
+	return values, secretValues
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitAddonSecretValues(t *testing.T) {
+	t.Parallel()
+
+	values, secretValues := splitAddonSecretValues([]string{
+		"globalConfig.users.admin.password=s3cret",
+		"globalConfig.configDir=/anchore_service_dir",
+		"",
+		"sonar.token=abc=123",
+	})
+	assert.Equal(t, []string{"globalConfig.configDir=/anchore_service_dir"}, values)
+	assert.Equal(t, map[string]string{
+		"globalConfig.users.admin.password": "s3cret",
+		"sonar.token":                       "abc=123",
+	}, secretValues)
+}
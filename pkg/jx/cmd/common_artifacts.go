@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/artifacts"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// npmConfigMountPath the path the npm configuration of the artifact repository is mounted at in the build pods
+	npmConfigMountPath = "/root/.npmrc"
+	// gradleInitScriptMountPath the path the Gradle init script of the artifact repository is mounted at in the build pods
+	gradleInitScriptMountPath = "/root/.gradle/init.d/jx-artifact-repository.gradle"
+)
+
+// teamArtifactRepository returns the artifact repository of the team with the credentials stored in its secret
+func (o *CommonOptions) teamArtifactRepository() (*artifacts.Repository, error) {
+	settings, err := o.TeamSettings()
+	if err != nil {
+		return nil, err
+	}
+	repo := &artifacts.Repository{
+		Kind: settings.GetArtifactRepository(),
+		URL:  settings.ArtifactRepositoryURL,
+	}
+	if repo.Kind == v1.ArtifactRepositoryNexus && repo.URL == "" {
+		repo.URL = artifacts.DefaultNexusURL
+	}
+	client, ns, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := client.CoreV1().Secrets(ns).Get(kube.SecretArtifactRepository, metav1.GetOptions{})
+	if err == nil {
+		repo.Username = string(secret.Data[kube.SecretDataUsername])
+		repo.Password = string(secret.Data[kube.SecretDataPassword])
+	}
+	return repo, nil
+}
+
+// applyArtifactRepository rewrites the Maven, Gradle and npm settings of the build pods so that the pipelines resolve
+// and deploy artifacts with the repository and stores the repository in the team settings. The repository is merged
+// into the existing Maven settings so that any other customisations are kept
+func (o *CommonOptions) applyArtifactRepository(repo *artifacts.Repository) error {
+	client, ns, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	existingMavenSettings := ""
+	secret, err := client.CoreV1().Secrets(ns).Get(kube.SecretJenkinsMavenSettings, metav1.GetOptions{})
+	if err == nil {
+		existingMavenSettings = string(secret.Data[artifacts.MavenSettingsFile])
+	} else if !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "getting the secret %s", kube.SecretJenkinsMavenSettings)
+	}
+	mavenSettings, err := artifacts.MergeMavenSettings(existingMavenSettings, repo)
+	if err != nil {
+		return errors.Wrapf(err, "merging the artifact repository into the Maven settings of the secret %s",
+			kube.SecretJenkinsMavenSettings)
+	}
+	gradleInitScript, err := artifacts.GradleInitScript(repo)
+	if err != nil {
+		return err
+	}
+
+	err = updateSecretData(client, ns, kube.SecretJenkinsMavenSettings, map[string][]byte{
+		artifacts.MavenSettingsFile: []byte(mavenSettings),
+	})
+	if err != nil {
+		return err
+	}
+	err = updateSecretData(client, ns, kube.SecretArtifactRepository, map[string][]byte{
+		"url":                          []byte(repo.URL),
+		kube.SecretDataUsername:        []byte(repo.Username),
+		kube.SecretDataPassword:        []byte(repo.Password),
+		artifacts.NpmConfigFile:        []byte(artifacts.NpmConfig(repo)),
+		artifacts.GradleInitScriptFile: []byte(gradleInitScript),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = kube.UpdatePodTemplates(client, ns, func(name string, pod *corev1.Pod) bool {
+		modified := false
+		if kube.EnsureSecretFile(pod, kube.SecretArtifactRepository, artifacts.NpmConfigFile, npmConfigMountPath) {
+			modified = true
+		}
+		if kube.EnsureSecretFile(pod, kube.SecretArtifactRepository, artifacts.GradleInitScriptFile, gradleInitScriptMountPath) {
+			modified = true
+		}
+		return modified
+	})
+	if err != nil {
+		return err
+	}
+
+	callback := func(env *v1.Environment) error {
+		teamSettings := &env.Spec.TeamSettings
+		teamSettings.ArtifactRepository = repo.Kind
+		teamSettings.ArtifactRepositoryURL = repo.URL
+		return nil
+	}
+	err = o.ModifyDevEnvironment(callback)
+	if err != nil {
+		return err
+	}
+	if repo.Enabled() {
+		log.Infof("The pipelines of the team now resolve and deploy artifacts with %s at %s\n", util.ColorInfo(repo.Kind), util.ColorInfo(repo.URL))
+	} else {
+		log.Infof("The pipelines of the team now resolve artifacts from the public repositories\n")
+	}
+	return nil
+}
+
+// updateSecretData sets the data of the secret creating it if it does not exist
+func updateSecretData(client kubernetes.Interface, ns string, name string, data map[string][]byte) error {
+	secrets := client.CoreV1().Secrets(ns)
+	secret, err := secrets.Get(name, metav1.GetOptions{})
+	if err != nil {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Data: data,
+		}
+		_, err = secrets.Create(secret)
+		if !apierrors.IsAlreadyExists(err) {
+			if err != nil {
+				return fmt.Errorf("cannot create secret %s in namespace %s: %v", name, ns, err)
+			}
+			return nil
+		}
+		secret, err = secrets.Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	for k, v := range data {
+		secret.Data[k] = v
+	}
+	_, err = secrets.Update(secret)
+	if err != nil {
+		return fmt.Errorf("cannot update secret %s in namespace %s: %v", name, ns, err)
+	}
+	return nil
+}
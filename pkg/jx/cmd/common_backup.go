@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/cloud/amazon"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// BackupProviderGCP backs up to a Google Cloud Storage bucket
+	BackupProviderGCP = "gcp"
+	// BackupProviderAWS backs up to an AWS S3 bucket
+	BackupProviderAWS = "aws"
+
+	defaultBackupScheduleName = "jx"
+	defaultBackupTTL          = "720h0m0s"
+	veleroCredentialsSecret   = "velero-credentials"
+	veleroCredentialsKey      = "cloud"
+)
+
+// BackupProviders the supported providers of the bucket Velero backs up to
+var BackupProviders = []string{BackupProviderGCP, BackupProviderAWS}
+
+// BackupFlags the flags of the Velero backups
+type BackupFlags struct {
+	Provider        string
+	Bucket          string
+	CredentialsFile string
+	Schedule        string
+	TTL             string
+}
+
+// installVelero installs Velero into the given namespace backing up to the bucket of the given flags
+func (o *CommonOptions) installVelero(ns string, releaseName string, version string, flags BackupFlags, setValues []string) error {
+	if flags.Bucket == "" {
+		return util.MissingOption("backup-bucket")
+	}
+	if util.StringArrayIndex(BackupProviders, flags.Provider) < 0 {
+		return util.InvalidOption("backup-provider", flags.Provider, BackupProviders)
+	}
+	values := []string{
+		"configuration.provider=" + flags.Provider,
+		"configuration.backupStorageLocation.name=" + flags.Provider,
+		"configuration.backupStorageLocation.bucket=" + flags.Bucket,
+		"configuration.volumeSnapshotLocation.name=" + flags.Provider,
+	}
+	if flags.Provider == BackupProviderAWS {
+		region, err := amazon.ResolveRegionWithoutOptions()
+		if err != nil {
+			return errors.Wrap(err, "finding the AWS region")
+		}
+		values = append(values, "configuration.backupStorageLocation.config.region="+region,
+			"configuration.volumeSnapshotLocation.config.region="+region)
+	}
+	if flags.CredentialsFile != "" {
+		err := o.createVeleroCredentialsSecret(ns, flags.CredentialsFile)
+		if err != nil {
+			return err
+		}
+		values = append(values, "credentials.existingSecret="+veleroCredentialsSecret)
+	} else {
+		values = append(values, "credentials.useSecret=false")
+	}
+	values = append(values, setValues...)
+
+	log.Infof("Installing %s to back up to the %s bucket %s\n", util.ColorInfo("velero"), flags.Provider, util.ColorInfo(flags.Bucket))
+	err := o.installChart(releaseName, kube.ChartVelero, version, ns, true, values, nil, "")
+	if err != nil {
+		return errors.Wrap(err, "velero deployment failed")
+	}
+	if flags.Schedule != "" {
+		return o.createBackupSchedule(ns, flags.Schedule, flags.TTL)
+	}
+	return nil
+}
+
+// createVeleroCredentialsSecret creates, or updates, the Secret with the cloud credentials Velero uses to access
+// the bucket
+func (o *CommonOptions) createVeleroCredentialsSecret(ns string, credentialsFile string) error {
+	data, err := ioutil.ReadFile(credentialsFile)
+	if err != nil {
+		return errors.Wrapf(err, "reading the credentials file %s", credentialsFile)
+	}
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	err = kube.EnsureNamespaceCreated(client, ns, nil, nil)
+	if err != nil {
+		return err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: veleroCredentialsSecret,
+		},
+		Data: map[string][]byte{
+			veleroCredentialsKey: data,
+		},
+	}
+	_, err = client.CoreV1().Secrets(ns).Create(secret)
+	if apierrors.IsAlreadyExists(err) {
+		_, err = client.CoreV1().Secrets(ns).Update(secret)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "saving the Secret %s in namespace %s", veleroCredentialsSecret, ns)
+	}
+	return nil
+}
+
+// backupNamespaces returns the development namespace and the namespaces of the permanent environments which hold
+// the state of Jenkins X. The namespaces are selected by the team label so that the namespaces of environments
+// which are not registered in the development environment yet, such as while restoring, are included too
+func (o *CommonOptions) backupNamespaces() ([]string, error) {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return nil, err
+	}
+	envs, _, err := kube.GetEnvironments(jxClient, devNs)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing the environments")
+	}
+	previewNamespaces := []string{}
+	namespaces := []string{devNs}
+	for _, env := range envs {
+		ns := env.Spec.Namespace
+		if ns == "" {
+			continue
+		}
+		if env.Spec.Kind == v1.EnvironmentKindTypePreview {
+			previewNamespaces = append(previewNamespaces, ns)
+			continue
+		}
+		if util.StringArrayIndex(namespaces, ns) < 0 {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	teamNamespaces, err := client.CoreV1().Namespaces().List(metav1.ListOptions{
+		LabelSelector: kube.LabelTeam + "=" + devNs,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing the namespaces of team %s", devNs)
+	}
+	for _, namespace := range teamNamespaces.Items {
+		ns := namespace.Name
+		if util.StringArrayIndex(previewNamespaces, ns) >= 0 || util.StringArrayIndex(namespaces, ns) >= 0 {
+			continue
+		}
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// createBackupSchedule creates, or recreates, the Velero schedule which backs up the state of Jenkins X
+func (o *CommonOptions) createBackupSchedule(ns string, schedule string, ttl string) error {
+	namespaces, err := o.backupNamespaces()
+	if err != nil {
+		return err
+	}
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	if ttl == "" {
+		ttl = defaultBackupTTL
+	}
+	err = kube.CreateVeleroSchedule(client, ns, defaultBackupScheduleName, schedule, kube.NewVeleroBackupSpec(namespaces, ttl))
+	if err != nil {
+		return err
+	}
+	log.Infof("Scheduled backups of the namespaces %s at %s\n", util.ColorInfo(namespaces), util.ColorInfo(schedule))
+	return nil
+}
+
+// updateBackupSchedule recreates the Velero schedule of the team, if there is one, so that it backs up the namespaces
+// of the environments which were created or deleted since it was scheduled
+func (o *CommonOptions) updateBackupSchedule() error {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	existing, err := kube.GetVeleroSchedule(client, kube.DefaultVeleroNamespace, defaultBackupScheduleName)
+	if err != nil || existing == nil {
+		return err
+	}
+	return o.createBackupSchedule(kube.DefaultVeleroNamespace, existing.Spec.Schedule, existing.Spec.Template.TTL)
+}
+
+// defaultBackupProvider returns the provider of the bucket Velero backs up to for the given Kubernetes provider
+func defaultBackupProvider(kubeProvider string) (string, error) {
+	switch kubeProvider {
+	case GKE:
+		return BackupProviderGCP, nil
+	case EKS, AWS:
+		return BackupProviderAWS, nil
+	default:
+		return "", fmt.Errorf("no default backup provider for the %s cluster, use --backup-provider to choose one of: %s",
+			kubeProvider, strings.Join(BackupProviders, ", "))
+	}
+}
+
+// waitForVeleroPhase waits for the Velero backup or restore with the given name to complete
+func (o *CommonOptions) waitForVeleroPhase(ns string, resource string, name string, timeout time.Duration) error {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	phase := ""
+	err = o.retryUntilTrueOrTimeout(timeout, 5*time.Second, func() (bool, error) {
+		phase, err = kube.GetVeleroPhase(client, ns, resource, name)
+		if err != nil {
+			return false, err
+		}
+		return phase == kube.VeleroPhaseCompleted || phase == kube.VeleroPhaseFailed || phase == kube.VeleroPhasePartiallyFailed, nil
+	})
+	if err != nil {
+		return err
+	}
+	switch phase {
+	case kube.VeleroPhaseFailed:
+		return fmt.Errorf("the Velero %s %s failed. Use 'kubectl describe %s %s -n %s' to find out why", resource, name, resource, name, ns)
+	case kube.VeleroPhasePartiallyFailed:
+		log.Warnf("The Velero %s %s completed with errors\n", resource, name)
+	}
+	return nil
+}
+
+// restoreBackup restores the given Velero backup, or the latest completed one if no name is given. On a new cluster
+// Velero may take a little while to find the backups in the bucket so we wait for the backup to appear
+func (o *CommonOptions) restoreBackup(ns string, backupName string, wait bool, timeout time.Duration) error {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	err = o.retryQuietlyUntilTimeout(timeout, 10*time.Second, func() error {
+		backups, err := kube.GetVeleroBackups(client, ns)
+		if err != nil {
+			return err
+		}
+		if backupName == "" {
+			latest := kube.LatestCompletedVeleroBackup(backups)
+			if latest == nil {
+				return fmt.Errorf("no completed backups found in namespace %s", ns)
+			}
+			backupName = latest.Name
+			return nil
+		}
+		for _, backup := range backups {
+			if backup.Name == backupName {
+				return nil
+			}
+		}
+		return fmt.Errorf("no backup %s found in namespace %s", backupName, ns)
+	})
+	if err != nil {
+		return err
+	}
+
+	restoreName := fmt.Sprintf("%s-%s", backupName, time.Now().Format("20060102150405"))
+	log.Infof("Restoring backup %s\n", util.ColorInfo(backupName))
+	err = kube.CreateVeleroRestore(client, ns, restoreName, backupName)
+	if err != nil {
+		return err
+	}
+	if !wait {
+		log.Infof("Use %s to follow the restore\n", util.ColorInfo(fmt.Sprintf("kubectl describe restores %s -n %s", restoreName, ns)))
+		return nil
+	}
+	err = o.waitForVeleroPhase(ns, "restores", restoreName, timeout)
+	if err != nil {
+		return err
+	}
+	log.Successf("Restored backup %s", backupName)
+	return nil
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultBackupProvider(t *testing.T) {
+	t.Parallel()
+	provider, err := defaultBackupProvider(GKE)
+	require.NoError(t, err)
+	assert.Equal(t, BackupProviderGCP, provider)
+
+	for _, kubeProvider := range []string{EKS, AWS} {
+		provider, err = defaultBackupProvider(kubeProvider)
+		require.NoError(t, err)
+		assert.Equal(t, BackupProviderAWS, provider)
+	}
+
+	_, err = defaultBackupProvider(AKS)
+	assert.Error(t, err)
+}
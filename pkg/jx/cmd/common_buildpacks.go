@@ -3,14 +3,20 @@ package cmd
 import (
 	"fmt"
 	"github.com/jenkins-x/draft-repo/pkg/draft/pack"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/config"
 	jxdraft "github.com/jenkins-x/jx/pkg/draft"
 	"github.com/jenkins-x/jx/pkg/jenkins"
 	"github.com/jenkins-x/jx/pkg/jenkinsfile"
+	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/sonarqube"
 	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 )
 
 // InvokeDraftPack used to pass arguments into the draft pack invocation
@@ -22,10 +28,20 @@ type InvokeDraftPack struct {
 	WithRename              bool
 	InitialisedGit          bool
 	DisableJenkinsfileCheck bool
+	// PacksDir a local build pack repository to use instead of the team build pack
+	PacksDir string
+	// Overwrite replaces any files in the project which came from the build pack with the current version of the pack,
+	// backing up the old ones first
+	Overwrite bool
 }
 
-// initBuildPacks initalise the build packs
+// initBuildPacks initalise the build packs returning the packs dir. If the $JX_BUILD_PACK_DIR environment variable
+// is set then the build pack repository in that directory is used instead of the team build pack
 func (o *CommonOptions) initBuildPacks() (string, error) {
+	if dir := os.Getenv(jenkinsfile.BuildPackDirEnvVar); dir != "" {
+		log.Infof("Using the local build pack %s from $%s\n", util.ColorInfo(dir), jenkinsfile.BuildPackDirEnvVar)
+		return jenkinsfile.LocalBuildPack(dir)
+	}
 	settings, err := o.TeamSettings()
 	if err != nil {
 		return "", err
@@ -35,7 +51,13 @@ func (o *CommonOptions) initBuildPacks() (string, error) {
 
 // invokeDraftPack invokes a draft pack copying in a Jenkinsfile if required
 func (o *CommonOptions) invokeDraftPack(i *InvokeDraftPack) (string, error) {
-	packsDir, err := o.initBuildPacks()
+	var packsDir string
+	var err error
+	if i.PacksDir != "" {
+		packsDir, err = jenkinsfile.LocalBuildPack(i.PacksDir)
+	} else {
+		packsDir, err = o.initBuildPacks()
+	}
 	if err != nil {
 		return "", err
 	}
@@ -110,12 +132,26 @@ func (o *CommonOptions) invokeDraftPack(i *InvokeDraftPack) (string, error) {
 		} else if exists, err := util.FileExists(packagerConfigName); err == nil && exists {
 			lpack = filepath.Join(packsDir, "cwp")
 		} else {
-			// pack detection time
-			lpack, err = jxdraft.DoPackDetectionForBuildPack(o.Out, dir, packsDir)
-
+			// lets detect the build pack from the build files before falling back to the languages of the source
+			detected, err := jxdraft.DetectBuildPack(dir)
 			if err != nil {
 				return "", err
 			}
+			if detected != "" {
+				lpack = filepath.Join(packsDir, detected)
+				exists, _ := util.FileExists(lpack)
+				if !exists {
+					log.Warnf("No build pack %s found in %s so detecting the language\n", detected, packsDir)
+					lpack = ""
+				}
+			}
+			if lpack == "" {
+				// pack detection time
+				lpack, err = jxdraft.DoPackDetectionForBuildPack(o.Out, dir, packsDir)
+				if err != nil {
+					return "", err
+				}
+			}
 		}
 	}
 	log.Success("selected pack: " + lpack + "\n")
@@ -124,7 +160,7 @@ func (o *CommonOptions) invokeDraftPack(i *InvokeDraftPack) (string, error) {
 	chartsDir := filepath.Join(dir, "charts")
 	jenkinsfileExists, err := util.FileExists(jenkinsfilePath)
 	exists, err := util.FileExists(chartsDir)
-	if exists && err == nil {
+	if exists && err == nil && !i.Overwrite {
 		exists, err = util.FileExists(filepath.Join(dir, "Dockerfile"))
 		if exists && err == nil {
 			if jenkinsfileExists || disableJenkinsfileCheck {
@@ -155,10 +191,29 @@ func (o *CommonOptions) invokeDraftPack(i *InvokeDraftPack) (string, error) {
 		}
 	}
 
+	if i.Overwrite {
+		err = backupBuildPackFiles(dir, lpack)
+		if err != nil {
+			return draftPack, err
+		}
+	}
+	dockerfile := filepath.Join(dir, "Dockerfile")
+	dockerfileExists, err := util.FileExists(dockerfile)
+	if err != nil {
+		return draftPack, err
+	}
 	err = CopyBuildPack(dir, lpack)
 	if err != nil {
 		log.Warnf("Failed to apply the build pack in %s due to %s", dir, err)
 	}
+	imageBuilder := o.teamImageBuilder()
+	if imageBuilder == v1.ImageBuilderBuildpacks && !dockerfileExists {
+		// Cloud Native Buildpacks detect how to build the image from the source so lets not add a Dockerfile
+		err = util.DeleteFile(dockerfile)
+		if err != nil {
+			return draftPack, err
+		}
+	}
 
 	if !jenkinsfileExists || jenkinsfileBackup != "" {
 		// lets check if we have a pipeline.yaml in the build pack so we can generate one dynamically
@@ -195,10 +250,12 @@ func (o *CommonOptions) invokeDraftPack(i *InvokeDraftPack) (string, error) {
 
 			if templateFile != "" {
 				arguments := &jenkinsfile.CreateJenkinsfileArguments{
-					ConfigFile:   pipelineFile,
-					TemplateFile: templateFile,
-					OutputFile:   generateJenkinsPath,
-					JenkinsfileRunner: prow,
+					ConfigFile:            pipelineFile,
+					TemplateFile:          templateFile,
+					OutputFile:            generateJenkinsPath,
+					JenkinsfileRunner:     prow,
+					ImageBuildCommand:     imageBuildCommand(imageBuilder),
+					StaticAnalysisCommand: o.teamStaticAnalysisCommand(draftPack),
 				}
 				err = arguments.GenerateJenkinsfile(moduleResolver.AsImportResolver())
 				if err != nil {
@@ -250,6 +307,88 @@ func (o *CommonOptions) invokeDraftPack(i *InvokeDraftPack) (string, error) {
 	return draftPack, nil
 }
 
+// teamImageBuilder returns the image builder of the team defaulting to docker if the team settings cannot be loaded
+func (o *CommonOptions) teamImageBuilder() v1.ImageBuilderType {
+	settings, err := o.TeamSettings()
+	if err != nil {
+		log.Warnf("Failed to load the team settings so building images with docker: %s\n", err)
+		return v1.ImageBuilderDocker
+	}
+	return settings.GetImageBuilder()
+}
+
+// teamStaticAnalysisCommand returns the command which analyses the source code of projects of the build pack if the
+// team has installed the SonarQube addon and the build pack is supported
+func (o *CommonOptions) teamStaticAnalysisCommand(pack string) string {
+	settings, err := o.TeamSettings()
+	if err != nil {
+		log.Warnf("Failed to load the team settings so not analysing the source code: %s\n", err)
+		return ""
+	}
+	if settings.Addon(kube.DefaultSonarQubeReleaseName) == nil || !sonarqube.IsSupportedBuildPack(pack) {
+		return ""
+	}
+	return sonarqube.AnalysisCommand
+}
+
+// backupBuildPackFiles moves the files and directories of the project which are provided by the build pack to
+// backups so that the pack can be copied into the project again without losing any changes made to them. The
+// Jenkinsfile is left alone as it is regenerated separately
+func backupBuildPackFiles(dir string, packDir string) error {
+	files, err := ioutil.ReadDir(packDir)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		name := f.Name()
+		switch name {
+		case jenkinsfile.PipelineConfigFileName, jenkinsfile.PipelineTemplateFileName, jenkins.DefaultJenkinsfile:
+			continue
+		}
+		path := filepath.Join(dir, name)
+		exists, err := util.FileExists(path)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		backup, err := uniqueBackupPath(path)
+		if err != nil {
+			return err
+		}
+		if f.IsDir() {
+			err = util.RenameDir(path, backup, false)
+		} else {
+			err = util.RenameFile(path, backup)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "backing up %s from %s", name, dir)
+		}
+		log.Infof("Moved %s to %s so that it can be replaced by the build pack\n", util.ColorInfo(name), util.ColorInfo(filepath.Base(backup)))
+	}
+	return nil
+}
+
+// uniqueBackupPath returns the path with the JenkinsfileBackupSuffix appended, followed by a number if a backup
+// already exists, so that older backups are never overwritten
+func uniqueBackupPath(path string) (string, error) {
+	for i := 0; i < 100; i++ {
+		backup := path + JenkinsfileBackupSuffix
+		if i > 0 {
+			backup += strconv.Itoa(i)
+		}
+		exists, err := util.FileExists(backup)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return backup, nil
+		}
+	}
+	return "", fmt.Errorf("could not find a unique backup name for %s", path)
+}
+
 // CopyBuildPack copies the build pack from the source dir to the destination dir
 func CopyBuildPack(dest, src string) error {
 	// first do some validation that we are copying from a valid pack directory
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupBuildPackFiles(t *testing.T) {
+	t.Parallel()
+
+	packDir, err := ioutil.TempDir("", "test-pack")
+	require.NoError(t, err)
+	defer os.RemoveAll(packDir)
+	dir, err := ioutil.TempDir("", "test-project")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeTestFile(t, filepath.Join(packDir, "Dockerfile"), "FROM scratch")
+	writeTestFile(t, filepath.Join(packDir, "Jenkinsfile"), "pipeline {}")
+	writeTestFile(t, filepath.Join(packDir, "charts", "values.yaml"), "replicaCount: 1")
+
+	writeTestFile(t, filepath.Join(dir, "Dockerfile"), "FROM myimage")
+	writeTestFile(t, filepath.Join(dir, "Dockerfile.backup"), "FROM oldimage")
+	writeTestFile(t, filepath.Join(dir, "Jenkinsfile"), "my pipeline")
+	writeTestFile(t, filepath.Join(dir, "charts", "myapp", "values.yaml"), "replicaCount: 3")
+	writeTestFile(t, filepath.Join(dir, "main.go"), "package main")
+
+	err = backupBuildPackFiles(dir, packDir)
+	require.NoError(t, err)
+
+	assertTestFile(t, filepath.Join(dir, "Dockerfile.backup"), "FROM oldimage")
+	assertTestFile(t, filepath.Join(dir, "Dockerfile.backup1"), "FROM myimage")
+	assertTestFile(t, filepath.Join(dir, "charts.backup", "myapp", "values.yaml"), "replicaCount: 3")
+	assertTestFile(t, filepath.Join(dir, "Jenkinsfile"), "my pipeline")
+	assertTestFile(t, filepath.Join(dir, "main.go"), "package main")
+
+	for _, name := range []string{"Dockerfile", "charts"} {
+		exists, err := util.FileExists(filepath.Join(dir, name))
+		require.NoError(t, err)
+		assert.False(t, exists, "%s should have been moved to a backup", name)
+	}
+}
+
+func writeTestFile(t *testing.T, path string, text string) {
+	err := os.MkdirAll(filepath.Dir(path), util.DefaultWritePermissions)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(path, []byte(text), util.DefaultWritePermissions)
+	require.NoError(t, err)
+}
+
+func assertTestFile(t *testing.T, path string, expected string) {
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(data))
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func (o *CommonOptions) ensureCertmanager() error {
@@ -16,27 +17,95 @@ func (o *CommonOptions) ensureCertmanager() error {
 	if err != nil {
 		ok := util.Confirm("CertManager deployment not found, shall we install it now?", true, "CertManager automatically configures Ingress rules with TLS using signed certificates from LetsEncrypt", o.In, o.Out, o.Err)
 		if ok {
-
-			values := []string{"rbac.create=true", "ingressShim.extraArgs='{--default-issuer-name=letsencrypt-staging,--default-issuer-kind=Issuer}'"}
-			err = o.installChartOptions(helm.InstallChartOptions{
-				ReleaseName: "cert-manager",
-				Chart:       "stable/cert-manager",
-				Version:     "",
-				Ns:          CertManagerNamespace,
-				HelmUpdate:  true,
-				SetValues:   values,
-			})
-			if err != nil {
-				return fmt.Errorf("CertManager deployment failed: %v", err)
-			}
-
-			log.Info("waiting for CertManager deployment to be ready, this can take a few minutes\n")
-
-			err = kube.WaitForDeploymentToBeReady(o.KubeClientCached, CertManagerDeployment, CertManagerNamespace, 10*time.Minute)
-			if err != nil {
-				return err
-			}
+			return o.installCertmanager(kube.CertmanagerIssuerStaging, "Issuer")
 		}
 	}
 	return err
 }
+
+// installCertmanager installs cert-manager using the given issuer name and kind as the default issuer
+// then waits for the deployment to be ready
+func (o *CommonOptions) installCertmanager(defaultIssuerName string, defaultIssuerKind string) error {
+	values := []string{"rbac.create=true",
+		fmt.Sprintf("ingressShim.extraArgs='{--default-issuer-name=%s,--default-issuer-kind=%s}'", defaultIssuerName, defaultIssuerKind)}
+	err := o.installChartOptions(helm.InstallChartOptions{
+		ReleaseName: "cert-manager",
+		Chart:       "stable/cert-manager",
+		Version:     "",
+		Ns:          CertManagerNamespace,
+		HelmUpdate:  true,
+		SetValues:   values,
+	})
+	if err != nil {
+		return fmt.Errorf("CertManager deployment failed: %v", err)
+	}
+
+	log.Info("waiting for CertManager deployment to be ready, this can take a few minutes\n")
+
+	return kube.WaitForDeploymentToBeReady(o.KubeClientCached, CertManagerDeployment, CertManagerNamespace, 10*time.Minute)
+}
+
+// configureTLS installs cert-manager with a ClusterIssuer for the given ingress config then re-exposes the
+// services of the namespace so that their ingress rules use HTTPS
+func (o *CommonOptions) configureTLS(ns string, ic kube.IngressConfig) error {
+	if !ic.TLS || !ic.ClusterIssuer {
+		return nil
+	}
+	_, err := kube.GetDeploymentPods(o.KubeClientCached, CertManagerDeployment, CertManagerNamespace)
+	if err != nil {
+		err = o.installCertmanager(ic.Issuer, "ClusterIssuer")
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Creating the cert-manager ClusterIssuer %s\n", util.ColorInfo(ic.Issuer))
+	// the cert-manager webhooks may take a little while to accept resources after the deployment is ready
+	err = o.retryQuietlyUntilTimeout(2*time.Minute, 5*time.Second, func() error {
+		return kube.CreateCertmanagerClusterIssuer(o.KubeClientCached, ic)
+	})
+	if err != nil {
+		return err
+	}
+	if ic.Issuer == kube.CertmanagerIssuerSelfSigned {
+		log.Warnf("Using self signed certificates as %s is not a public domain, browsers will not trust them\n", ic.Domain)
+	}
+	return o.expose(ns, ns, "")
+}
+
+// configureWildcardCertificate requests a certificate for all the subdomains of the domain from LetsEncrypt using the
+// DNS-01 challenge of the DNS provider. Returns false if no wildcard certificate is used for the ingress config
+func (o *CommonOptions) configureWildcardCertificate(ns string, ic kube.IngressConfig, dnsProvider string, serviceAccountKeyFile string) (bool, error) {
+	if !ic.TLS || !ic.ClusterIssuer || ic.Issuer != kube.CertmanagerIssuerProd || dnsProvider == "" || dnsProvider == DNSProviderNipIO {
+		return false, nil
+	}
+	provider, err := o.dns01Provider(dnsProvider, serviceAccountKeyFile)
+	if err != nil {
+		return false, err
+	}
+	log.Infof("Requesting a wildcard certificate for %s using %s\n", util.ColorInfo("*."+ic.Domain), util.ColorInfo(dnsProvider))
+	err = kube.CreateCertmanagerClusterIssuerWithDNS01(o.KubeClientCached, ic, *provider)
+	if err != nil {
+		return false, err
+	}
+	err = kube.CreateCertmanagerWildcardCertificate(o.KubeClientCached, ns, ic, *provider)
+	if err != nil {
+		return false, err
+	}
+	_, err = kube.DefaultModifyConfigMap(o.KubeClientCached, ns, kube.IngressConfigConfigmap, func(cm *corev1.ConfigMap) error {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[kube.TLSSecretName] = kube.CertmanagerWildcardSecret
+		return nil
+	}, nil)
+	if err != nil {
+		return false, err
+	}
+	// expose the services again so that their ingresses use the wildcard certificate
+	err = o.expose(ns, ns, "")
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// Checkpoint records the step a long running command was performing when it was interrupted so that the command can
+// be resumed
+type Checkpoint struct {
+	// Name the unique name of the checkpoint such as the command and the cluster it creates
+	Name string `json:"name"`
+	// Step a description of what the command was doing when interrupted
+	Step string `json:"step"`
+	// Time when the command was interrupted
+	Time time.Time `json:"time,omitempty"`
+	// Values any state needed to resume the command such as the directories of generated files
+	Values map[string]string `json:"values,omitempty"`
+	// Hint explains what happens when the command is resumed
+	Hint string `json:"hint,omitempty"`
+	// Args the arguments of the jx command which resumes the command
+	Args []string `json:"args,omitempty"`
+	// Dir the directory the command was run in
+	Dir string `json:"dir,omitempty"`
+}
+
+// checkpointFilePermissions the permissions of the checkpoint files which are only readable by the user as the
+// arguments of the commands may include secrets
+const checkpointFilePermissions = 0600
+
+// checkpointsDir returns the directory in which the checkpoints of interrupted commands are stored
+func checkpointsDir() (string, error) {
+	configDir, err := util.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(configDir, "checkpoints")
+	err = os.MkdirAll(dir, util.DefaultWritePermissions)
+	if err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// checkpointFile returns the file name of the checkpoint with the given name
+func checkpointFile(name string) (string, error) {
+	dir, err := checkpointsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".yml"), nil
+}
+
+// listCheckpoints returns the names of the checkpoints of the interrupted commands
+func listCheckpoints() ([]string, error) {
+	dir, err := checkpointsDir()
+	if err != nil {
+		return nil, err
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, f := range files {
+		if !f.IsDir() && filepath.Ext(f.Name()) == ".yml" {
+			names = append(names, strings.TrimSuffix(f.Name(), ".yml"))
+		}
+	}
+	return names, nil
+}
+
+// loadCheckpoint loads the checkpoint with the given name or returns nil if the command was not interrupted
+func loadCheckpoint(name string) (*Checkpoint, error) {
+	fileName, err := checkpointFile(name)
+	if err != nil {
+		return nil, err
+	}
+	exists, err := util.FileExists(fileName)
+	if err != nil || !exists {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading checkpoint %s", fileName)
+	}
+	checkpoint := &Checkpoint{}
+	err = yaml.Unmarshal(data, checkpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unmarshalling checkpoint %s", fileName)
+	}
+	return checkpoint, nil
+}
+
+// saveCheckpoint stores the checkpoint returning the file it was stored in
+func saveCheckpoint(checkpoint *Checkpoint) (string, error) {
+	fileName, err := checkpointFile(checkpoint.Name)
+	if err != nil {
+		return "", err
+	}
+	data, err := yaml.Marshal(checkpoint)
+	if err != nil {
+		return "", errors.Wrapf(err, "marshalling checkpoint %s", checkpoint.Name)
+	}
+	err = ioutil.WriteFile(fileName, data, checkpointFilePermissions)
+	if err != nil {
+		return "", errors.Wrapf(err, "writing checkpoint %s", fileName)
+	}
+	return fileName, nil
+}
+
+// deleteCheckpoint removes the checkpoint with the given name if it exists
+func deleteCheckpoint(name string) error {
+	fileName, err := checkpointFile(name)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(fileName)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// runCheckpointStep runs a long running step of a command. The step is given the values of the checkpoint which
+// are the ones recorded by an earlier interrupted run when resuming. If the step is interrupted the checkpoint is
+// stored and guidance on how to resume the command is printed. If the step succeeds the checkpoint of any earlier
+// interrupted run is removed
+func (o *CommonOptions) runCheckpointStep(checkpoint *Checkpoint, step func(values map[string]string) error) error {
+	previous, err := loadCheckpoint(checkpoint.Name)
+	if err != nil {
+		log.Warnf("Failed to load the checkpoint %s: %s\n", checkpoint.Name, err)
+	}
+	if checkpoint.Values == nil {
+		checkpoint.Values = map[string]string{}
+	}
+	if previous != nil {
+		log.Infof("Resuming from %s which was interrupted at %s\n", util.ColorInfo(previous.Step), previous.Time.Format(time.RFC822))
+		for k, v := range previous.Values {
+			checkpoint.Values[k] = v
+		}
+	}
+
+	err = step(checkpoint.Values)
+	if util.IsInterrupted(err) {
+		checkpoint.Time = time.Now()
+		if len(checkpoint.Args) == 0 {
+			checkpoint.Args = o.resumeArgs(nil)
+		}
+		if checkpoint.Dir == "" {
+			checkpoint.Dir, _ = os.Getwd()
+		}
+		fileName, saveErr := saveCheckpoint(checkpoint)
+		log.Warnf("\nInterrupted while %s\n", checkpoint.Step)
+		if saveErr != nil {
+			log.Warnf("Failed to record the checkpoint: %s\n", saveErr)
+		} else {
+			log.Infof("The progress has been recorded in %s\n", util.ColorInfo(fileName))
+		}
+		if checkpoint.Hint != "" {
+			log.Infof("%s\n", checkpoint.Hint)
+		}
+		log.Infof("To resume run:\n\n    %s\n\n", util.ColorInfo("jx resume "+checkpoint.Name))
+		return errors.Wrapf(err, "interrupted while %s", checkpoint.Step)
+	}
+	if err == nil {
+		err = deleteCheckpoint(checkpoint.Name)
+		if err != nil {
+			log.Warnf("Failed to remove the checkpoint %s: %s\n", checkpoint.Name, err)
+		}
+		return nil
+	}
+	return err
+}
+
+// resumeArgs returns the arguments of the current command. The given flag values, such as generated names, are
+// appended if the flags were not specified on the command line
+func (o *CommonOptions) resumeArgs(flags map[string]string) []string {
+	args := append([]string{}, os.Args[1:]...)
+	names := []string{}
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if o.Cmd != nil && o.Cmd.Flags().Changed(name) {
+			continue
+		}
+		args = append(args, "--"+name, flags[name])
+	}
+	return args
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/cloud/aks"
+	"github.com/jenkins-x/jx/pkg/cloud/gke"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// JenkinsXUnknown Jenkins X could not be detected on the cluster as there are no credentials for it
+	JenkinsXUnknown = "Unknown"
+	// JenkinsXNotInstalled Jenkins X is not installed on the cluster
+	JenkinsXNotInstalled = "No"
+
+	detectJenkinsXTimeout = 10 * time.Second
+)
+
+// CloudCluster a Kubernetes cluster of a cloud provider
+type CloudCluster struct {
+	Name     string `json:"name"`
+	Location string `json:"location,omitempty"`
+	Version  string `json:"version,omitempty"`
+	Nodes    int    `json:"nodes"`
+	Status   string `json:"status,omitempty"`
+	JenkinsX string `json:"jenkinsX,omitempty"`
+}
+
+// detectJenkinsX sets whether Jenkins X is installed on each of the clusters. A cluster can only be checked if there
+// is a context for it in the kube config such as after using 'gcloud container clusters get-credentials'
+func (o *CommonOptions) detectJenkinsX(clusters []CloudCluster) {
+	config, _, err := o.Kube().LoadConfig()
+	if err != nil {
+		log.Warnf("Failed to load the kube config so cannot detect Jenkins X: %s\n", err)
+	}
+	for i := range clusters {
+		cluster := &clusters[i]
+		cluster.JenkinsX = JenkinsXUnknown
+		if config == nil {
+			continue
+		}
+		ctxName := kube.ContextForCluster(config, cluster.Name)
+		if ctxName == "" {
+			continue
+		}
+		namespaces, err := devNamespacesOfContext(config, ctxName)
+		if err != nil {
+			if o.Verbose {
+				log.Warnf("Failed to detect Jenkins X on the cluster %s: %s\n", cluster.Name, err)
+			}
+			continue
+		}
+		if len(namespaces) == 0 {
+			cluster.JenkinsX = JenkinsXNotInstalled
+		} else {
+			cluster.JenkinsX = strings.Join(namespaces, ", ")
+		}
+	}
+}
+
+// devNamespacesOfContext returns the development namespaces of Jenkins X on the cluster of the given context
+func devNamespacesOfContext(config *api.Config, ctxName string) ([]string, error) {
+	restConfig, err := clientcmd.NewNonInteractiveClientConfig(*config, ctxName, &clientcmd.ConfigOverrides{}, nil).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	restConfig.Timeout = detectJenkinsXTimeout
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	list, err := client.CoreV1().Namespaces().List(metav1.ListOptions{
+		LabelSelector: kube.LabelEnvironment + "=" + kube.LabelValueDevEnvironment,
+	})
+	if err != nil {
+		return nil, err
+	}
+	answer := []string{}
+	for _, ns := range list.Items {
+		answer = append(answer, ns.Name)
+	}
+	return answer, nil
+}
+
+// renderCloudClusters displays the clusters as a table or in the given output format
+func (o *GetOptions) renderCloudClusters(clusters []CloudCluster) error {
+	o.detectJenkinsX(clusters)
+	if o.outputObjects() {
+		return o.renderResult(clusters, o.Output)
+	}
+	if len(clusters) == 0 {
+		return outputEmptyListWarning(o.Out)
+	}
+	table := o.CreateTable()
+	table.AddRow("NAME", "LOCATION", "VERSION", "NODES", "STATUS", "JENKINS X")
+	for _, c := range clusters {
+		table.AddRow(c.Name, c.Location, c.Version, fmt.Sprintf("%d", c.Nodes), c.Status, c.JenkinsX)
+	}
+	table.Render()
+	return nil
+}
+
+// useCloudCluster makes the context of the cloud cluster with the given name the current context. If there is no
+// context for the cluster yet its credentials are fetched with the CLI of the provider. The project is only used for
+// GKE clusters and defaults to the current project
+func (o *CommonOptions) useCloudCluster(provider string, name string, projectID string) error {
+	config, po, err := o.Kube().LoadConfig()
+	if err != nil {
+		return errors.Wrap(err, "loading the kube config")
+	}
+	ctxName := kube.ContextForCluster(config, name)
+	if ctxName == "" {
+		err = o.fetchCloudClusterCredentials(provider, name, projectID)
+		if err != nil {
+			return err
+		}
+		config, po, err = o.Kube().LoadConfig()
+		if err != nil {
+			return errors.Wrap(err, "loading the kube config")
+		}
+		ctxName = kube.ContextForCluster(config, name)
+		if ctxName == "" {
+			return fmt.Errorf("no context found in the kube config for the cluster %s", name)
+		}
+	}
+	if config.CurrentContext != ctxName {
+		config.CurrentContext = ctxName
+		err = clientcmd.ModifyConfig(po, *config, false)
+		if err != nil {
+			return errors.Wrapf(err, "switching to the context %s", ctxName)
+		}
+	}
+	log.Infof("Using the cluster %s via the context %s\n", util.ColorInfo(name), util.ColorInfo(ctxName))
+	return nil
+}
+
+// fetchCloudClusterCredentials adds a context for the cloud cluster with the given name to the kube config
+func (o *CommonOptions) fetchCloudClusterCredentials(provider string, name string, projectID string) error {
+	switch provider {
+	case GKE:
+		if projectID == "" {
+			currentProject, err := gke.GetCurrentProject()
+			if err != nil {
+				return errors.Wrap(err, "getting the current Google Cloud project")
+			}
+			projectID = currentProject
+		}
+		clusters, err := gke.ListClusters(projectID)
+		if err != nil {
+			return err
+		}
+		for _, c := range clusters {
+			if c.Name != name {
+				continue
+			}
+			location := c.ClusterLocation()
+			locationFlag := "--region"
+			if strings.Count(location, "-") > 1 {
+				locationFlag = "--zone"
+			}
+			return o.runCommandVerbose("gcloud", "container", "clusters", "get-credentials", name, locationFlag, location,
+				"--project", projectID)
+		}
+	case AKS:
+		clusters, err := aks.NewAzureRunner().ListClusters()
+		if err != nil {
+			return err
+		}
+		for _, c := range clusters {
+			if c.Name == name {
+				return o.runCommandVerbose("az", "aks", "get-credentials", "--resource-group", c.ResourceGroup, "--name", name)
+			}
+		}
+	case EKS:
+		return o.runCommandVerbose("eksctl", "utils", "write-kubeconfig", "--name", name)
+	default:
+		return fmt.Errorf("no context found in the kube config for the cluster %s. Use --provider with one of %s to fetch its credentials",
+			name, strings.Join([]string{GKE, AKS, EKS}, ", "))
+	}
+	return fmt.Errorf("no %s cluster called %s found", provider, name)
+}
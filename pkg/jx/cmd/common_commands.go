@@ -1,128 +1,115 @@
 package cmd
 
 import (
-	"fmt"
+	"context"
 	"io/ioutil"
 	"os"
-	"os/exec"
 	"strings"
 
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 )
 
-// TODO Refactor to use util.Run or util.RunWithoutRetry?
-
-func (o *CommonOptions) runCommandFromDir(dir, name string, args ...string) error {
-	e := exec.Command(name, args...)
-	if dir != "" {
-		e.Dir = dir
+// CommandRunner returns the runner of external commands lazily creating it via the Factory
+func (o *CommonOptions) CommandRunner() util.CommandRunner {
+	if o.commandRunner == nil {
+		if o.Factory != nil {
+			o.commandRunner = o.Factory.CreateCommandRunner()
+		}
+		if o.commandRunner == nil {
+			o.commandRunner = util.NewCommandRunner()
+		}
 	}
-	e.Stdout = o.Out
-	e.Stderr = o.Err
-	os.Setenv("PATH", util.PathWithBinary())
-	err := e.Run()
+	return o.commandRunner
+}
+
+// SetCommandRunner configures the runner of external commands such as a util.FakeCommandRunner in tests
+func (o *CommonOptions) SetCommandRunner(runner util.CommandRunner) {
+	o.commandRunner = runner
+}
+
+// Context returns the context of the commands run by jx which is cancelled when jx is interrupted
+func (o *CommonOptions) Context() context.Context {
+	return util.InterruptContext()
+}
+
+// runCommandSpec runs the command via the CommandRunner logging an error if it fails
+func (o *CommonOptions) runCommandSpec(cmd *util.CommandSpec) error {
+	_, err := o.CommandRunner().Run(o.Context(), cmd)
 	if err != nil {
-		log.Errorf("Error: Command failed  %s %s\n", name, strings.Join(args, " "))
+		log.Errorf("Error: Command failed  %s %s\n", cmd.Name, strings.Join(util.RedactArgs(cmd.Args), " "))
 	}
 	return err
 }
 
+func (o *CommonOptions) runCommandFromDir(dir, name string, args ...string) error {
+	return o.runCommandSpec(&util.CommandSpec{
+		Name: name,
+		Args: args,
+		Dir:  dir,
+		Out:  o.Out,
+		Err:  o.Err,
+	})
+}
+
 // RunCommand runs a command
 func (o *CommonOptions) RunCommand(name string, args ...string) error {
-	e := exec.Command(name, args...)
-	if o.Verbose {
-		e.Stdout = o.Out
-		e.Stderr = o.Err
+	cmd := &util.CommandSpec{
+		Name: name,
+		Args: args,
 	}
-	os.Setenv("PATH", util.PathWithBinary())
-	err := e.Run()
-	if err != nil {
-		log.Errorf("Error: Command failed  %s %s\n", name, strings.Join(args, " "))
+	if o.Verbose || log.IsDebug() {
+		cmd.Out = o.Out
+		cmd.Err = o.Err
 	}
-	return err
+	return o.runCommandSpec(cmd)
 }
 
 func (o *CommonOptions) runCommandVerbose(name string, args ...string) error {
-	e := exec.Command(name, args...)
-	e.Stdout = o.Out
-	e.Stderr = o.Err
-	os.Setenv("PATH", util.PathWithBinary())
-	err := e.Run()
-	if err != nil {
-		log.Errorf("Error: Command failed  %s %s\n", name, strings.Join(args, " "))
-	}
-	return err
+	return o.runCommandVerboseAt("", name, args...)
 }
 
 func (o *CommonOptions) runCommandVerboseAt(dir string, name string, args ...string) error {
-	e := exec.Command(name, args...)
-	if dir != "" {
-		e.Dir = dir
-	}
-	e.Stdout = o.Out
-	e.Stderr = o.Err
-	os.Setenv("PATH", util.PathWithBinary())
-	err := e.Run()
-	if err != nil {
-		log.Errorf("Error: Command failed  %s %s\n", name, strings.Join(args, " "))
-	}
-	return err
+	return o.runCommandFromDir(dir, name, args...)
 }
 
 func (o *CommonOptions) runCommandQuietly(name string, args ...string) error {
-	e := exec.Command(name, args...)
-	e.Stdout = ioutil.Discard
-	e.Stderr = ioutil.Discard
-	os.Setenv("PATH", util.PathWithBinary())
-	return e.Run()
+	_, err := o.CommandRunner().Run(o.Context(), &util.CommandSpec{
+		Name: name,
+		Args: args,
+		Out:  ioutil.Discard,
+		Err:  ioutil.Discard,
+	})
+	return err
 }
 
 func (o *CommonOptions) runCommandInteractive(interactive bool, name string, args ...string) error {
-	e := exec.Command(name, args...)
-	e.Stdout = o.Out
-	e.Stderr = o.Err
-	if interactive {
-		e.Stdin = os.Stdin
-	}
-	os.Setenv("PATH", util.PathWithBinary())
-	err := e.Run()
-	if err != nil {
-		log.Errorf("Error: Command failed  %s %s\n", name, strings.Join(args, " "))
-	}
-	return err
+	return o.runCommandInteractiveInDir(interactive, "", name, args...)
 }
 
 func (o *CommonOptions) runCommandInteractiveInDir(interactive bool, dir string, name string, args ...string) error {
-	e := exec.Command(name, args...)
-	e.Stdout = o.Out
-	e.Stderr = o.Err
-	if interactive {
-		e.Stdin = os.Stdin
-	}
-	if dir != "" {
-		e.Dir = dir
+	cmd := &util.CommandSpec{
+		Name: name,
+		Args: args,
+		Dir:  dir,
+		Out:  o.Out,
+		Err:  o.Err,
 	}
-	os.Setenv("PATH", util.PathWithBinary())
-	err := e.Run()
-	if err != nil {
-		log.Errorf("Error: Command failed  %s %s\n", name, strings.Join(args, " "))
+	if interactive {
+		cmd.In = os.Stdin
 	}
-	return err
+	return o.runCommandSpec(cmd)
 }
 
 // getCommandOutput evaluates the given command and returns the trimmed output
 func (o *CommonOptions) getCommandOutput(dir string, name string, args ...string) (string, error) {
-	os.Setenv("PATH", util.PathWithBinary())
-	e := exec.Command(name, args...)
-	if dir != "" {
-		e.Dir = dir
-	}
-	data, err := e.CombinedOutput()
-	text := string(data)
-	text = strings.TrimSpace(text)
+	text, err := o.CommandRunner().Run(o.Context(), &util.CommandSpec{
+		Name: name,
+		Args: args,
+		Dir:  dir,
+	})
 	if err != nil {
-		return "", fmt.Errorf("Command failed '%s %s': %s %s\n", name, strings.Join(args, " "), text, err)
+		return "", err
 	}
-	return text, err
+	return text, nil
 }
@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/cloud/amazon"
+	"github.com/jenkins-x/jx/pkg/cloud/gke"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DNSProviderNipIO uses the magic nip.io DNS so no DNS records need to be created
+	DNSProviderNipIO = "nip.io"
+	// DNSProviderCloudDNS uses Google Cloud DNS
+	DNSProviderCloudDNS = "clouddns"
+	// DNSProviderRoute53 uses AWS Route 53
+	DNSProviderRoute53 = "route53"
+
+	externalDNSReleaseName = "external-dns"
+	externalDNSChart       = "stable/external-dns"
+	externalDNSNamespace   = "kube-system"
+
+	// dnsCredentialsSecret the Secret with the Google Cloud credentials external-dns and cert-manager use to
+	// manage the records of the domain
+	dnsCredentialsSecret = "jx-dns-credentials"
+	dnsCredentialsKey    = "credentials.json"
+)
+
+// DNSProviders the supported DNS providers
+var DNSProviders = []string{DNSProviderNipIO, DNSProviderCloudDNS, DNSProviderRoute53}
+
+// magicDNSDomains the wildcard DNS services which resolve the IP address in the host name so need no DNS records
+var magicDNSDomains = []string{"nip.io", "xip.io"}
+
+// isMagicDNSDomain returns true if the domain is resolved by a wildcard DNS service such as nip.io
+func isMagicDNSDomain(domain string) bool {
+	domain = strings.TrimSuffix(domain, ".")
+	for _, magic := range magicDNSDomains {
+		if domain == magic || strings.HasSuffix(domain, "."+magic) {
+			return true
+		}
+	}
+	return false
+}
+
+// getIngressAddress returns the external IP or host name of the ingress controller's LoadBalancer Service
+func (o *CommonOptions) getIngressAddress(client kubernetes.Interface, ingressNamespace string, ingressService string) (string, error) {
+	svc, err := client.CoreV1().Services(ingressNamespace).Get(ingressService, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	address := ""
+	for _, v := range svc.Status.LoadBalancer.Ingress {
+		if v.IP != "" {
+			address = v.IP
+		} else if v.Hostname != "" {
+			address = v.Hostname
+		}
+	}
+	if address == "" {
+		return "", fmt.Errorf("no external address found for service %s in namespace %s", ingressService, ingressNamespace)
+	}
+	return address, nil
+}
+
+// defaultDNSProvider returns the DNS provider of the cloud a custom domain is managed with if none is specified
+func defaultDNSProvider(provider string, domain string) string {
+	if domain == "" || isMagicDNSDomain(domain) {
+		return ""
+	}
+	switch provider {
+	case GKE:
+		return DNSProviderCloudDNS
+	case AWS, EKS:
+		return DNSProviderRoute53
+	}
+	return ""
+}
+
+// configureDNS creates the DNS zone and wildcard record of the domain with the given DNS provider so that it points
+// at the ingress address. external-dns is installed to keep the records in sync, then we wait for the domain to resolve.
+// The optional service account key file is used by external-dns to manage the Google Cloud DNS zone
+func (o *CommonOptions) configureDNS(dnsProvider string, domain string, address string, serviceAccountKeyFile string) error {
+	if dnsProvider == "" || dnsProvider == DNSProviderNipIO {
+		return nil
+	}
+	if domain == "" {
+		return util.InvalidOptionf("domain", domain, "a domain is required when using the DNS provider %s", dnsProvider)
+	}
+	if isMagicDNSDomain(domain) {
+		log.Infof("No DNS records are needed for the domain %s\n", util.ColorInfo(domain))
+		return nil
+	}
+
+	// the label lets uninstall tell the external-dns installed by jx apart from one installed by the user
+	externalDNSValues := []string{"rbac.create=true", "domainFilters[0]=" + domain, "txtOwnerId=jenkins-x",
+		"podLabels.jenkins\\.io/created-by=" + kube.ValueCreatedByJX}
+	switch dnsProvider {
+	case DNSProviderCloudDNS:
+		projectID, err := gke.GetCurrentProject()
+		if err != nil {
+			return errors.Wrap(err, "finding the current Google Cloud project")
+		}
+		err = gke.CreateManagedZone(projectID, domain)
+		if err != nil {
+			return err
+		}
+		err = gke.UpsertWildcardRecord(projectID, domain, address)
+		if err != nil {
+			return err
+		}
+		nameServers, err := gke.GetManagedZoneNameServers(projectID, domain)
+		if err == nil && len(nameServers) > 0 {
+			o.checkZoneDelegation(domain, nameServers)
+		}
+		externalDNSValues = append(externalDNSValues, "provider=google", "google.project="+projectID)
+		if serviceAccountKeyFile != "" {
+			err = o.createDNSCredentialsSecret(externalDNSNamespace, serviceAccountKeyFile)
+			if err != nil {
+				return err
+			}
+			externalDNSValues = append(externalDNSValues, "google.serviceAccountSecret="+dnsCredentialsSecret)
+		}
+	case DNSProviderRoute53:
+		err := amazon.RegisterAwsCustomDomain(domain, address)
+		if err != nil {
+			return err
+		}
+		externalDNSValues = append(externalDNSValues, "provider=aws")
+	default:
+		return util.InvalidOption("dns-provider", dnsProvider, DNSProviders)
+	}
+
+	log.Infof("Installing %s to manage the DNS records of %s\n", util.ColorInfo(externalDNSReleaseName), util.ColorInfo(domain))
+	err := o.installChartOptions(helm.InstallChartOptions{
+		ReleaseName: externalDNSReleaseName,
+		Chart:       externalDNSChart,
+		Ns:          externalDNSNamespace,
+		HelmUpdate:  true,
+		SetValues:   externalDNSValues,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "installing %s", externalDNSChart)
+	}
+	return o.waitForDNSPropagation(domain)
+}
+
+// waitForDNSPropagation waits for a host of the wildcard domain to resolve
+func (o *CommonOptions) waitForDNSPropagation(domain string) error {
+	host := "jx." + domain
+	log.Infof("Waiting for %s to resolve, this can take a few minutes...\n", util.ColorInfo(host))
+	err := o.retryQuietlyUntilTimeout(10*time.Minute, 10*time.Second, func() error {
+		_, err := net.LookupHost(host)
+		return err
+	})
+	if err != nil {
+		log.Warnf("The domain %s has not propagated yet. Ingress rules will start to work once it resolves\n", domain)
+		return nil
+	}
+	log.Successf("DNS for %s configured", domain)
+	return nil
+}
+
+// checkZoneDelegation warns if the domain is not delegated to the name servers of its DNS zone yet as the records
+// created in the zone cannot be resolved until the registrar of the domain is updated
+func (o *CommonOptions) checkZoneDelegation(domain string, nameServers []string) {
+	records, err := net.LookupNS(domain)
+	actual := []string{}
+	if err == nil {
+		for _, record := range records {
+			actual = append(actual, record.Host)
+		}
+	}
+	if nameServersMatch(actual, nameServers) {
+		log.Infof("The domain %s is delegated to its DNS zone\n", util.ColorInfo(domain))
+		return
+	}
+	log.Warnf("The domain %s is not delegated to its DNS zone yet. Make sure the registrar of %s delegates to the name servers: %s\n",
+		domain, domain, strings.Join(nameServers, ", "))
+}
+
+// nameServersMatch returns true if all the expected name servers are in the actual name servers ignoring case and
+// any trailing dots
+func nameServersMatch(actual []string, expected []string) bool {
+	if len(actual) == 0 || len(expected) == 0 {
+		return false
+	}
+	normalize := func(host string) string {
+		return strings.ToLower(strings.TrimSuffix(host, "."))
+	}
+	found := map[string]bool{}
+	for _, host := range actual {
+		found[normalize(host)] = true
+	}
+	for _, host := range expected {
+		if !found[normalize(host)] {
+			return false
+		}
+	}
+	return true
+}
+
+// createDNSCredentialsSecret creates, or updates, the Secret with the Google Cloud service account key file used to
+// manage the records of the domain in the given namespace
+func (o *CommonOptions) createDNSCredentialsSecret(ns string, serviceAccountKeyFile string) error {
+	data, err := ioutil.ReadFile(serviceAccountKeyFile)
+	if err != nil {
+		return errors.Wrapf(err, "reading the service account key file %s", serviceAccountKeyFile)
+	}
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	err = kube.EnsureNamespaceCreated(client, ns, nil, nil)
+	if err != nil {
+		return err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: dnsCredentialsSecret,
+		},
+		Data: map[string][]byte{
+			dnsCredentialsKey: data,
+		},
+	}
+	_, err = client.CoreV1().Secrets(ns).Create(secret)
+	if apierrors.IsAlreadyExists(err) {
+		_, err = client.CoreV1().Secrets(ns).Update(secret)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "saving the Secret %s in namespace %s", dnsCredentialsSecret, ns)
+	}
+	return nil
+}
+
+// dns01Provider returns the DNS-01 challenge provider cert-manager uses to request wildcard certificates from the
+// given DNS provider
+func (o *CommonOptions) dns01Provider(dnsProvider string, serviceAccountKeyFile string) (*kube.DNS01Provider, error) {
+	switch dnsProvider {
+	case DNSProviderCloudDNS:
+		if serviceAccountKeyFile == "" {
+			return nil, fmt.Errorf("a service account key file is required to request wildcard certificates using %s", dnsProvider)
+		}
+		projectID, err := gke.GetCurrentProject()
+		if err != nil {
+			return nil, errors.Wrap(err, "finding the current Google Cloud project")
+		}
+		err = o.createDNSCredentialsSecret(CertManagerNamespace, serviceAccountKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		return &kube.DNS01Provider{
+			Name:                 "clouddns",
+			Project:              projectID,
+			ServiceAccountSecret: dnsCredentialsSecret,
+		}, nil
+	case DNSProviderRoute53:
+		region, err := amazon.ResolveRegionWithoutOptions()
+		if err != nil {
+			return nil, errors.Wrap(err, "finding the AWS region")
+		}
+		return &kube.DNS01Provider{
+			Name:   "route53",
+			Region: region,
+		}, nil
+	}
+	return nil, util.InvalidOption("dns-provider", dnsProvider, DNSProviders)
+}
+
+// reportDomainReadiness logs whether the domain resolves and whether its wildcard certificate has been issued yet
+func (o *CommonOptions) reportDomainReadiness(ns string, ic kube.IngressConfig, wildcardCertificate bool) {
+	if ic.Domain == "" || strings.HasSuffix(ic.Domain, DNSProviderNipIO) {
+		return
+	}
+	host := "jx." + ic.Domain
+	if _, err := net.LookupHost(host); err == nil {
+		log.Infof("DNS:         %s resolves\n", util.ColorInfo(host))
+	} else {
+		log.Warnf("DNS:         %s does not resolve yet\n", host)
+	}
+	if wildcardCertificate {
+		ready, err := kube.IsCertmanagerCertificateReady(o.KubeClientCached, ns, kube.CertmanagerCertificateWildcard)
+		if err == nil && ready {
+			log.Infof("Certificate: %s issued for *.%s\n", util.ColorInfo(kube.CertmanagerCertificateWildcard), util.ColorInfo(ic.Domain))
+		} else {
+			log.Warnf("Certificate: %s for *.%s is not issued yet. Use %s to check on it\n", kube.CertmanagerCertificateWildcard,
+				ic.Domain, fmt.Sprintf("kubectl describe certificate %s -n %s", kube.CertmanagerCertificateWildcard, ns))
+		}
+	}
+}
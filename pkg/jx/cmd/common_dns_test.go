@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultDNSProvider(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, DNSProviderCloudDNS, defaultDNSProvider(GKE, "mycompany.dev"))
+	assert.Equal(t, DNSProviderRoute53, defaultDNSProvider(EKS, "mycompany.dev"))
+	assert.Equal(t, DNSProviderRoute53, defaultDNSProvider(AWS, "mycompany.dev"))
+	assert.Equal(t, "", defaultDNSProvider(GKE, ""))
+	assert.Equal(t, "", defaultDNSProvider(GKE, "1.2.3.4.nip.io"))
+	assert.Equal(t, "", defaultDNSProvider(GKE, "1.2.3.4.xip.io"))
+	assert.Equal(t, "", defaultDNSProvider(MINIKUBE, "mycompany.dev"))
+}
+
+func TestIsMagicDNSDomain(t *testing.T) {
+	t.Parallel()
+	assert.True(t, isMagicDNSDomain("1.2.3.4.nip.io"))
+	assert.True(t, isMagicDNSDomain("1.2.3.4.xip.io."))
+	assert.False(t, isMagicDNSDomain("mycompany.dev"))
+	assert.False(t, isMagicDNSDomain("snip.io"))
+}
+
+func TestNameServersMatch(t *testing.T) {
+	t.Parallel()
+	expected := []string{"ns-cloud-a1.googledomains.com.", "ns-cloud-a2.googledomains.com."}
+	assert.True(t, nameServersMatch([]string{"NS-cloud-a2.googledomains.com", "ns-cloud-a1.googledomains.com."}, expected))
+	assert.False(t, nameServersMatch([]string{"ns-cloud-a1.googledomains.com."}, expected))
+	assert.False(t, nameServersMatch([]string{"ns1.registrar.com."}, expected))
+	assert.False(t, nameServersMatch(nil, expected))
+}
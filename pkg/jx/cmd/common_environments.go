@@ -22,88 +22,50 @@ type ModifyRequirementsFn func(requirements *helm.Requirements) error
 // ConfigureGitFolderFn callback to optionally configure git before its used for creating commits and PRs
 type ConfigureGitFolderFn func(dir string, gitInfo *gits.GitRepository, gitAdapter gits.Gitter) error
 
+// ModifyEnvironmentDirFn callback for modifying the files of the source of an environment
+type ModifyEnvironmentDirFn func(dir string) error
+
 type CreateEnvPullRequestFn func(env *v1.Environment, modifyRequirementsFn ModifyRequirementsFn, branchNameText string, title string, message string, pullRequestInfo *gits.PullRequestInfo) (*gits.PullRequestInfo, error)
 
 func (o *CommonOptions) createEnvironmentPullRequest(env *v1.Environment, modifyRequirementsFn ModifyRequirementsFn,
 	branchNameText *string, title *string, message *string, pullRequestInfo *gits.PullRequestInfo,
 	configGitFn ConfigureGitFolderFn) (*gits.PullRequestInfo, error) {
-	var answer *gits.PullRequestInfo
-	source := &env.Spec.Source
-	gitURL := source.URL
-	if gitURL == "" {
-		return answer, fmt.Errorf("No source git URL")
+	modifyDirFn := func(dir string) error {
+		return modifyRequirementsInDir(dir, modifyRequirementsFn)
 	}
-	gitInfo, err := gits.ParseGitURL(gitURL)
+	return o.createEnvironmentPullRequestForDir(env, modifyDirFn, branchNameText, title, message, pullRequestInfo, configGitFn)
+}
+
+// modifyRequirementsInDir modifies the requirements of the chart of the environment source in the given directory
+func modifyRequirementsInDir(dir string, modifyRequirementsFn ModifyRequirementsFn) error {
+	requirementsFile, err := helm.FindRequirementsFileName(dir)
 	if err != nil {
-		return answer, err
+		return err
+	}
+	requirements, err := helm.LoadRequirementsFile(requirementsFile)
+	if err != nil {
+		return err
 	}
 
-	environmentsDir, err := util.EnvironmentsDir()
+	err = modifyRequirementsFn(requirements)
 	if err != nil {
-		return answer, err
+		return err
 	}
-	dir := filepath.Join(environmentsDir, gitInfo.Organisation, gitInfo.Name)
+	return helm.SaveRequirementsFile(requirementsFile, requirements)
+}
 
-	// now lets clone the fork and push it...
-	exists, err := util.FileExists(dir)
+// createEnvironmentPullRequestForDir creates a Pull Request on the source of the environment with the changes which
+// the callback makes to the files of its source
+func (o *CommonOptions) createEnvironmentPullRequestForDir(env *v1.Environment, modifyDirFn ModifyEnvironmentDirFn,
+	branchNameText *string, title *string, message *string, pullRequestInfo *gits.PullRequestInfo,
+	configGitFn ConfigureGitFolderFn) (*gits.PullRequestInfo, error) {
+	var answer *gits.PullRequestInfo
+	dir, base, gitInfo, err := o.cloneOrPullEnvironmentRepo(env, configGitFn)
 	if err != nil {
 		return answer, err
 	}
 
 	branchName := o.Git().ConvertToValidBranchName(asText(branchNameText))
-	base := source.Ref
-	if base == "" {
-		base = "master"
-	}
-
-	if exists {
-		if configGitFn != nil {
-			err = configGitFn(dir, gitInfo, o.Git())
-			if err != nil {
-				return answer, err
-			}
-		}
-		// lets check the git remote URL is setup correctly
-		err = o.Git().SetRemoteURL(dir, "origin", gitURL)
-		if err != nil {
-			return answer, err
-		}
-		err = o.Git().Stash(dir)
-		if err != nil {
-			return answer, err
-		}
-		err = o.Git().Checkout(dir, base)
-		if err != nil {
-			return answer, err
-		}
-		err = o.Git().Pull(dir)
-		if err != nil {
-			return answer, err
-		}
-	} else {
-		err := os.MkdirAll(dir, DefaultWritePermissions)
-		if err != nil {
-			return answer, fmt.Errorf("Failed to create directory %s due to %s", dir, err)
-		}
-		err = o.Git().Clone(gitURL, dir)
-		if err != nil {
-			return answer, err
-		}
-		if configGitFn != nil {
-			err = configGitFn(dir, gitInfo, o.Git())
-			if err != nil {
-				return answer, err
-			}
-		}
-		if base != "master" {
-			err = o.Git().Checkout(dir, base)
-			if err != nil {
-				return answer, err
-			}
-		}
-
-		// TODO lets fork if required???
-	}
 	branchNames, err := o.Git().RemoteBranchNames(dir, "remotes/origin/")
 	if err != nil {
 		return answer, fmt.Errorf("Failed to load remote branch names: %s", err)
@@ -122,21 +84,7 @@ func (o *CommonOptions) createEnvironmentPullRequest(env *v1.Environment, modify
 		return answer, err
 	}
 
-	requirementsFile, err := helm.FindRequirementsFileName(dir)
-	if err != nil {
-		return answer, err
-	}
-	requirements, err := helm.LoadRequirementsFile(requirementsFile)
-	if err != nil {
-		return answer, err
-	}
-
-	err = modifyRequirementsFn(requirements)
-	if err != nil {
-		return answer, err
-	}
-
-	err = helm.SaveRequirementsFile(requirementsFile, requirements)
+	err = modifyDirFn(dir)
 	if err != nil {
 		return answer, err
 	}
@@ -204,6 +152,135 @@ func (o *CommonOptions) createEnvironmentPullRequest(env *v1.Environment, modify
 	}, nil
 }
 
+// commitEnvironmentChanges modifies the requirements of the environment and commits and pushes the change directly
+// to the base branch of the environment's Git repository rather than via a Pull Request
+func (o *CommonOptions) commitEnvironmentChanges(env *v1.Environment, modifyRequirementsFn ModifyRequirementsFn,
+	message string, configGitFn ConfigureGitFolderFn) (bool, error) {
+	dir, base, _, err := o.cloneOrPullEnvironmentRepo(env, configGitFn)
+	if err != nil {
+		return false, err
+	}
+	requirementsFile, err := helm.FindRequirementsFileName(dir)
+	if err != nil {
+		return false, err
+	}
+	requirements, err := helm.LoadRequirementsFile(requirementsFile)
+	if err != nil {
+		return false, err
+	}
+	err = modifyRequirementsFn(requirements)
+	if err != nil {
+		return false, err
+	}
+	err = helm.SaveRequirementsFile(requirementsFile, requirements)
+	if err != nil {
+		return false, err
+	}
+	err = o.Git().Add(dir, "*", "*/*")
+	if err != nil {
+		return false, err
+	}
+	changed, err := o.Git().HasChanges(dir)
+	if err != nil {
+		return false, err
+	}
+	if !changed {
+		log.Warnf("%s\n", "No changes made to the GitOps Environment source code. Code must be up to date!")
+		return false, nil
+	}
+	err = o.Git().CommitDir(dir, message)
+	if err != nil {
+		return false, err
+	}
+	err = o.Git().Push(dir)
+	if err != nil {
+		return false, err
+	}
+	log.Infof("Pushed the change to the %s branch of %s\n", util.ColorInfo(base), util.ColorInfo(env.Spec.Source.URL))
+	return true, nil
+}
+
+// cloneOrPullEnvironmentRepo clones the Git repository of the environment or pulls the latest changes of the base
+// branch if it was cloned already. Returns the directory and the base branch
+func (o *CommonOptions) cloneOrPullEnvironmentRepo(env *v1.Environment, configGitFn ConfigureGitFolderFn) (string, string, *gits.GitRepository, error) {
+	source := &env.Spec.Source
+	gitURL := source.URL
+	if gitURL == "" {
+		return "", "", nil, fmt.Errorf("No source git URL")
+	}
+	gitInfo, err := gits.ParseGitURL(gitURL)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	environmentsDir, err := util.EnvironmentsDir()
+	if err != nil {
+		return "", "", nil, err
+	}
+	dir := filepath.Join(environmentsDir, gitInfo.Organisation, gitInfo.Name)
+
+	// now lets clone the fork and push it...
+	exists, err := util.FileExists(dir)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	base := source.Ref
+	if base == "" {
+		base = "master"
+	}
+
+	if exists {
+		if configGitFn != nil {
+			err = configGitFn(dir, gitInfo, o.Git())
+			if err != nil {
+				return "", "", nil, err
+			}
+		}
+		// lets check the git remote URL is setup correctly
+		err = o.Git().SetRemoteURL(dir, "origin", gitURL)
+		if err != nil {
+			return "", "", nil, err
+		}
+		err = o.Git().Stash(dir)
+		if err != nil {
+			return "", "", nil, err
+		}
+		err = o.Git().Checkout(dir, base)
+		if err != nil {
+			return "", "", nil, err
+		}
+		err = o.Git().Pull(dir)
+		if err != nil {
+			return "", "", nil, err
+		}
+	} else {
+		err := os.MkdirAll(dir, DefaultWritePermissions)
+		if err != nil {
+			return "", "", nil, fmt.Errorf("Failed to create directory %s due to %s", dir, err)
+		}
+		err = o.Git().Clone(gitURL, dir)
+		if err != nil {
+			return "", "", nil, err
+		}
+		if configGitFn != nil {
+			err = configGitFn(dir, gitInfo, o.Git())
+			if err != nil {
+				return "", "", nil, err
+			}
+		}
+		if base != "master" {
+			err = o.Git().Checkout(dir, base)
+			if err != nil {
+				return "", "", nil, err
+			}
+		}
+
+		// TODO lets fork if required???
+	}
+	return dir, base, gitInfo, nil
+}
+
 func (o *CommonOptions) registerEnvironmentCRD() error {
 	apisClient, err := o.CreateApiExtensionsClient()
 	if err != nil {
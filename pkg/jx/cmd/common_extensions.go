@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	jenkinsv1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/extensions"
+	"github.com/jenkins-x/jx/pkg/log"
+)
+
+// runLifecycleExtensions runs the extensions of the current team which are attached to the given lifecycle event. The
+// values describe the event to the extensions, such as the application or environment
+func (o *CommonOptions) runLifecycleExtensions(when jenkinsv1.ExtensionWhen, values map[string]string) error {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	// the extensions running as jobs have the same permissions as the pipelines of the team
+	serviceAccount, err := o.pipelineServiceAccount()
+	if err != nil {
+		return err
+	}
+	return extensions.RunLifecycleExtensions(jxClient, kubeClient, ns, serviceAccount, when, values, o.Verbose)
+}
+
+// runPostLifecycleExtensions runs the extensions attached to a lifecycle event which has already happened so that a
+// failing extension is reported without failing the command
+func (o *CommonOptions) runPostLifecycleExtensions(when jenkinsv1.ExtensionWhen, values map[string]string) {
+	err := o.runLifecycleExtensions(when, values)
+	if err != nil {
+		log.Warnf("Failed to run the %s extensions: %s\n", when, err)
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jenkins-x/jx/pkg/cloud/gke"
 	"github.com/jenkins-x/jx/pkg/log"
@@ -11,6 +12,9 @@ import (
 	"gopkg.in/AlecAivazis/survey.v1"
 )
 
+// zoneLatencyTimeout the timeout of each request when measuring the latency to the Google Cloud regions
+const zoneLatencyTimeout = 5 * time.Second
+
 // asks to chose from existing projects or optionally creates one if none exist
 func (o *CommonOptions) getGoogleProjectId() (string, error) {
 	surveyOpts := survey.WithStdio(o.In, o.Out, o.Err)
@@ -71,21 +75,113 @@ func (o *CommonOptions) getGoogleProjectId() (string, error) {
 }
 
 func (o *CommonOptions) getGoogleZone(projectId string) (string, error) {
+	return o.getGoogleZoneFor(projectId, "", "", false)
+}
+
+// getGoogleZoneFor asks to chose a zone which offers the given machine type and GPU, if not blank. When sortByLatency
+// is enabled the zones closest to the user are listed first along with the measured latency
+func (o *CommonOptions) getGoogleZoneFor(projectId string, machineType string, gpu string, sortByLatency bool) (string, error) {
 	availableZones, err := gke.GetGoogleZones(projectId)
 	if err != nil {
 		return "", err
 	}
+	allowed := [][]string{}
+	if machineType != "" {
+		zones, err := gke.MachineTypeZones(projectId, machineType)
+		if err != nil {
+			log.Warnf("Unable to find the zones offering the machine type %s: %s\n", machineType, err)
+		} else {
+			allowed = append(allowed, zones)
+		}
+	}
+	if gpu != "" {
+		zones, err := gke.AcceleratorZones(projectId, gpu)
+		if err != nil {
+			return "", err
+		}
+		allowed = append(allowed, zones)
+	}
+	availableZones = gke.FilterZones(availableZones, allowed...)
+	if len(availableZones) == 0 {
+		if gpu != "" {
+			return "", fmt.Errorf("no zones offer both the machine type %s and the GPU %s", machineType, gpu)
+		}
+		return "", fmt.Errorf("no zones offer the machine type %s", machineType)
+	}
+
+	latencies := map[string]time.Duration{}
+	if sortByLatency {
+		log.Infof("Measuring the latency to the Google Cloud regions\n")
+		latencies, err = gke.MeasureRegionLatencies(util.GetClientWithTimeout(zoneLatencyTimeout))
+		if err != nil {
+			log.Warnf("Unable to measure the latency to the Google Cloud regions: %s\n", err)
+		} else {
+			availableZones = gke.SortZonesByLatency(availableZones, latencies)
+		}
+	}
+	options := []string{}
+	for _, zone := range availableZones {
+		options = append(options, gke.ZoneDescription(zone, latencies))
+	}
 	prompts := &survey.Select{
 		Message:  "Google Cloud Zone:",
-		Options:  availableZones,
+		Options:  options,
 		PageSize: 10,
 		Help:     "The compute zone (e.g. us-central1-a) for the cluster",
 	}
-	zone := ""
+	answer := ""
 	surveyOpts := survey.WithStdio(o.In, o.Out, o.Err)
-	err = survey.AskOne(prompts, &zone, nil, surveyOpts)
+	err = survey.AskOne(prompts, &answer, nil, surveyOpts)
 	if err != nil {
 		return "", err
 	}
-	return zone, nil
+	fields := strings.Fields(answer)
+	if len(fields) == 0 {
+		return "", errors.New("no Google Cloud Zone selected")
+	}
+	return fields[0], nil
+}
+
+// checkGKEQuota verifies that the region of the given zone has enough quota left for a node pool of the given
+// machine type and size. If not the user is warned and, unless in batch mode, offered to pick a different zone.
+// The zone to use is returned
+func (o *CommonOptions) checkGKEQuota(projectId string, zone string, machineType string, nodes int) (string, error) {
+	requirements := gke.NodePoolQuotaRequirements(machineType, nodes)
+	for {
+		region := gke.GetRegionFromZone(zone)
+		quotas, err := gke.GetRegionQuotas(projectId, region)
+		if err != nil {
+			log.Warnf("Unable to check the quotas of region %s: %s\n", region, err)
+			return zone, nil
+		}
+		shortfalls := gke.FindQuotaShortfalls(quotas, requirements)
+		if len(shortfalls) == 0 {
+			return zone, nil
+		}
+		for _, s := range shortfalls {
+			log.Warnf("Insufficient quota in region %s: %s\n", util.ColorInfo(region), s.String())
+		}
+		log.Warnf("You can request a quota increase at %s\n", util.ColorInfo(gke.QuotaIncreaseURL(projectId)))
+
+		if o.BatchMode || !util.Confirm("Would you like to pick a different zone?", true,
+			"Creating the cluster in this zone is likely to fail part way through", o.In, o.Out, o.Err) {
+			return zone, nil
+		}
+		zone, err = o.getGoogleZoneFor(projectId, machineType, "", false)
+		if err != nil {
+			return zone, err
+		}
+	}
+}
+
+// getGKECredentials adds the credentials of the GKE cluster to the kube config returning the output of gcloud. The
+// command is retried if the GKE API is rate limited or briefly unavailable
+func (o *CommonOptions) getGKECredentials(clusterName string, zone string, projectID string) (string, error) {
+	var output string
+	err := util.RetryWithOptions(util.CloudRetryOptions("getting the credentials of cluster "+clusterName), func() error {
+		var err error
+		output, err = o.getCommandOutput("", "gcloud", "container", "clusters", "get-credentials", clusterName, "--zone", zone, "--project", projectID)
+		return err
+	})
+	return output, err
 }
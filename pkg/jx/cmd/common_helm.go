@@ -109,6 +109,9 @@ func (o *CommonOptions) installChartAt(dir string, releaseName string, chart str
 }
 
 func (o *CommonOptions) installChartOptions(options helm.InstallChartOptions) error {
+	if options.Version == "" && options.Dir == "" {
+		options.Version = o.resolveChartVersion(options.Chart)
+	}
 	return helm.InstallFromChartOptions(options, o.Helm(), o.KubeClientCached, defaultInstallTimeout)
 }
 
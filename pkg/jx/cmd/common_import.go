@@ -16,6 +16,18 @@ import (
 
 // ImportProject imports a MultiBranchProject into Jenkins for the given git URL
 func (o *CommonOptions) ImportProject(gitURL string, dir string, jenkinsfile string, branchPattern, credentials string, failIfExists bool, gitProvider gits.GitProvider, authConfigSvc auth.ConfigService, isEnvironment bool, batchMode bool) error {
+	err := o.ImportProjectJob(gitURL, dir, jenkinsfile, "", branchPattern, credentials, failIfExists, gitProvider, authConfigSvc, isEnvironment, batchMode)
+	if err != nil {
+		return err
+	}
+	return o.CreateJenkinsWebHook(gitURL, gitProvider)
+}
+
+// ImportProjectJob imports a MultiBranchProject into Jenkins for the given git URL using the given job name which
+// defaults to the name of the repository. This lets the applications of a monorepo each have their own job.
+// The webhook of the repository is registered separately via CreateJenkinsWebHook so that the jobs of a monorepo
+// share a single webhook
+func (o *CommonOptions) ImportProjectJob(gitURL string, dir string, jenkinsfile string, jobName string, branchPattern, credentials string, failIfExists bool, gitProvider gits.GitProvider, authConfigSvc auth.ConfigService, isEnvironment bool, batchMode bool) error {
 	jenk, err := o.JenkinsClient()
 	if err != nil {
 		return err
@@ -159,7 +171,9 @@ func (o *CommonOptions) ImportProject(gitURL string, dir string, jenkinsfile str
 
 	err = o.retry(10, time.Second*10, func() error {
 		projectXml := jenkins.CreateMultiBranchProjectXml(gitInfo, gitProvider, credentials, branchPattern, jenkinsfile)
-		jobName := gitInfo.Name
+		if jobName == "" {
+			jobName = gitInfo.Name
+		}
 		job, err := jenk.GetJobByPath(org, jobName)
 		if err == nil {
 			if failIfExists {
@@ -187,11 +201,20 @@ func (o *CommonOptions) ImportProject(gitURL string, dir string, jenkinsfile str
 		}
 		return nil
 	})
+	return err
+}
+
+// CreateJenkinsWebHook registers the webhook of Jenkins on the git repository
+func (o *CommonOptions) CreateJenkinsWebHook(gitURL string, gitProvider gits.GitProvider) error {
+	jenk, err := o.JenkinsClient()
 	if err != nil {
 		return err
 	}
+	gitInfo, err := gits.ParseGitURL(gitURL)
+	if err != nil {
+		return fmt.Errorf("Failed to parse Git URL %s due to: %s", gitURL, err)
+	}
 
-	// register the webhook
 	suffix := gitProvider.JenkinsWebHookPath(gitURL, "")
 	jenkBaseURL := o.ExternalJenkinsBaseURL
 	if jenkBaseURL == "" {
@@ -28,11 +28,14 @@ import (
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/maven"
+	"github.com/jenkins-x/jx/pkg/policy"
 	"github.com/jenkins-x/jx/pkg/prow"
 	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/versionstream"
 	"github.com/pborman/uuid"
 	"github.com/pkg/errors"
 	logger "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -165,13 +168,49 @@ func (o *CommonOptions) installBrew() error {
 	return o.RunCommand("sh", "-c", "/usr/bin/ruby -e \"$(curl -fsSL https://raw.githubusercontent.com/Homebrew/install/master/install)\"")
 }
 
+// addSkipVerifyFlags adds the flag to skip the verification of downloaded dependencies
+func (o *CommonOptions) addSkipVerifyFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&o.InsecureSkipVerify, "insecure-skip-verify", "", false, "Skips the checksum and signature verification of downloaded dependencies. Not recommended")
+}
+
+// downloadAndVerifyFile downloads the file and verifies its published checksum unless --insecure-skip-verify is set
+func (o *CommonOptions) downloadAndVerifyFile(clientURL string, fullPath string, options binaries.ChecksumOptions) error {
+	if o.InsecureSkipVerify {
+		log.Warnf("Skipping the verification of %s\n", clientURL)
+		return binaries.DownloadFile(clientURL, fullPath)
+	}
+	return binaries.DownloadAndVerifyFile(clientURL, fullPath, options)
+}
+
+// chocolateyAvailable returns true if this is windows and the chocolatey package manager is on the PATH. Chocolatey
+// is not used when the binaries are installed into the directory given by $JX_BIN
+func chocolateyAvailable() bool {
+	if runtime.GOOS != "windows" || util.JXBinOverridden() {
+		return false
+	}
+	_, err := exec.LookPath("choco")
+	return err == nil
+}
+
+// installWithChocolatey installs the package with chocolatey if it is available. Returns false if the
+// binary should be downloaded instead
+func (o *CommonOptions) installWithChocolatey(pkg string) (bool, error) {
+	if !chocolateyAvailable() {
+		return false, nil
+	}
+	log.Infof("Installing %s with %s\n", util.ColorInfo(pkg), util.ColorInfo("chocolatey"))
+	return true, o.RunCommand("choco", "install", pkg, "-y")
+}
+
 func shouldInstallBinary(name string) (fileName string, download bool, err error) {
 	fileName = binaries.BinaryWithExtension(name)
 	download = false
-	pgmPath, err := exec.LookPath(fileName)
-	if err == nil {
-		logger.Debugf("%s is already available on your PATH at %s", util.ColorInfo(fileName), util.ColorInfo(pgmPath))
-		return
+	if !util.JXBinOverridden() {
+		pgmPath, err := exec.LookPath(fileName)
+		if err == nil {
+			logger.Debugf("%s is already available on your PATH at %s", util.ColorInfo(fileName), util.ColorInfo(pgmPath))
+			return fileName, download, nil
+		}
 	}
 
 	binDir, err := util.JXBinLocation()
@@ -185,7 +224,7 @@ func shouldInstallBinary(name string) (fileName string, download bool, err error
 		return
 	}
 	if exists {
-		logger.Debugf("Please add %s to your PATH", util.ColorInfo(binDir))
+		logger.Debugf("Please add %s to your PATH: %s", util.ColorInfo(binDir), util.ColorInfo(util.PathInstructions(binDir)))
 		return
 	}
 	download = true
@@ -367,6 +406,9 @@ func (o *CommonOptions) installKubectl() error {
 	if runtime.GOOS == "darwin" && !o.NoBrew {
 		return o.RunCommand("brew", "install", "kubectl")
 	}
+	if installed, err := o.installWithChocolatey("kubernetes-cli"); installed {
+		return err
+	}
 	binDir, err := util.JXBinLocation()
 	if err != nil {
 		return err
@@ -376,7 +418,7 @@ func (o *CommonOptions) installKubectl() error {
 		return err
 	}
 	kubernetes := "kubernetes"
-	latestVersion, err := o.getLatestVersionFromKubernetesReleaseUrl()
+	latestVersion, err := o.resolveToolVersion("kubectl", o.getLatestVersionFromKubernetesReleaseUrl)
 	if err != nil {
 		return fmt.Errorf("Unable to get latest version for github.com/%s/%s %v", kubernetes, kubernetes, err)
 	}
@@ -384,7 +426,7 @@ func (o *CommonOptions) installKubectl() error {
 	clientURL := fmt.Sprintf("https://storage.googleapis.com/kubernetes-release/release/v%s/bin/%s/%s/%s", latestVersion, runtime.GOOS, runtime.GOARCH, fileName)
 	fullPath := filepath.Join(binDir, fileName)
 	tmpFile := fullPath + ".tmp"
-	err = binaries.DownloadFile(clientURL, tmpFile)
+	err = o.downloadAndVerifyFile(clientURL, tmpFile, binaries.ChecksumOptions{ChecksumURL: clientURL + ".sha256"})
 	if err != nil {
 		return err
 	}
@@ -399,6 +441,9 @@ func (o *CommonOptions) installKustomize() error {
 	if runtime.GOOS == "darwin" && !o.NoBrew {
 		return o.RunCommand("brew", "install", "kustomize")
 	}
+	if installed, err := o.installWithChocolatey("kustomize"); installed {
+		return err
+	}
 	binDir, err := util.JXBinLocation()
 	if err != nil {
 		return err
@@ -408,15 +453,23 @@ func (o *CommonOptions) installKustomize() error {
 		return err
 	}
 
-	latestVersion, err := util.GetLatestVersionFromGitHub("kubernetes-sigs", "kustomize")
+	latestVersion, err := o.resolveToolVersion("kustomize", func() (semver.Version, error) {
+		return util.GetLatestVersionFromGitHub("kubernetes-sigs", "kustomize")
+	})
 	if err != nil {
 		return fmt.Errorf("unable to get latest version for github.com/%s/%s %v", "kubernetes-sigs", "kustomize", err)
 	}
 
 	clientURL := fmt.Sprintf("https://github.com/kubernetes-sigs/kustomize/releases/download/v%v/kustomize_%s_%s_%s", latestVersion, latestVersion, runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		clientURL += ".exe"
+	}
+	checksums := binaries.ChecksumOptions{
+		ChecksumURL: fmt.Sprintf("https://github.com/kubernetes-sigs/kustomize/releases/download/v%v/checksums.txt", latestVersion),
+	}
 	fullPath := filepath.Join(binDir, fileName)
 	tmpFile := fullPath + ".tmp"
-	err = binaries.DownloadFile(clientURL, tmpFile)
+	err = o.downloadAndVerifyFile(clientURL, tmpFile, checksums)
 	if err != nil {
 		return err
 	}
@@ -427,6 +480,38 @@ func (o *CommonOptions) installKustomize() error {
 	return os.Chmod(fullPath, 0755)
 }
 
+// downloadZipBinary downloads the zip archive and moves the file at the given path inside the archive to fullPath
+func (o *CommonOptions) downloadZipBinary(clientURL string, binDir string, fileNameInArchive string, fullPath string, checksums binaries.ChecksumOptions) error {
+	zipFile := fullPath + ".zip"
+	err := o.downloadAndVerifyFile(clientURL, zipFile, checksums)
+	if err != nil {
+		return err
+	}
+	zipDir := filepath.Join(binDir, filepath.Base(fullPath)+"-tmp-"+uuid.NewUUID().String())
+	err = os.MkdirAll(zipDir, DefaultWritePermissions)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(zipDir)
+	err = util.Unzip(zipFile, zipDir)
+	if err != nil {
+		return err
+	}
+	f := filepath.Join(zipDir, fileNameInArchive)
+	exists, err := util.FileExists(f)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("could not find file %s inside the downloaded file %s", fileNameInArchive, clientURL)
+	}
+	err = util.RenameFile(f, fullPath)
+	if err != nil {
+		return err
+	}
+	return os.Remove(zipFile)
+}
+
 func (o *CommonOptions) installOc() error {
 	// need to fix the version we download as not able to work out the oc sha in the URL yet
 	sha := "191fece"
@@ -696,6 +781,12 @@ func (o *CommonOptions) installHelm() error {
 		}
 		return o.installHelmSecretsPlugin(binary, true)
 	}
+	if installed, err := o.installWithChocolatey("kubernetes-helm"); installed {
+		if err != nil {
+			return err
+		}
+		return o.installHelmSecretsPlugin(binary, true)
+	}
 
 	binDir, err := util.JXBinLocation()
 	if err != nil {
@@ -706,14 +797,26 @@ func (o *CommonOptions) installHelm() error {
 	if err != nil || !flag {
 		return err
 	}
-	latestVersion, err := util.GetLatestVersionFromGitHub("kubernetes", "helm")
+	latestVersion, err := o.resolveToolVersion(binary, func() (semver.Version, error) {
+		return util.GetLatestVersionFromGitHub("kubernetes", "helm")
+	})
 	if err != nil {
 		return err
 	}
-	clientURL := fmt.Sprintf("https://storage.googleapis.com/kubernetes-helm/helm-v%s-%s-%s.tar.gz", latestVersion, runtime.GOOS, runtime.GOARCH)
 	fullPath := filepath.Join(binDir, fileName)
+	if runtime.GOOS == "windows" {
+		// the windows release is a zip containing windows-amd64/helm.exe
+		clientURL := fmt.Sprintf("https://storage.googleapis.com/kubernetes-helm/helm-v%s-%s-%s.zip", latestVersion, runtime.GOOS, runtime.GOARCH)
+		err = o.downloadZipBinary(clientURL, binDir, filepath.Join(runtime.GOOS+"-"+runtime.GOARCH, fileName), fullPath,
+			binaries.ChecksumOptions{ChecksumURL: clientURL + ".sha256"})
+		if err != nil {
+			return err
+		}
+		return o.installHelmSecretsPlugin(fullPath, true)
+	}
+	clientURL := fmt.Sprintf("https://storage.googleapis.com/kubernetes-helm/helm-v%s-%s-%s.tar.gz", latestVersion, runtime.GOOS, runtime.GOARCH)
 	tarFile := fullPath + ".tgz"
-	err = binaries.DownloadFile(clientURL, tarFile)
+	err = o.downloadAndVerifyFile(clientURL, tarFile, binaries.ChecksumOptions{ChecksumURL: clientURL + ".sha256"})
 	if err != nil {
 		return err
 	}
@@ -955,6 +1058,9 @@ func (o *CommonOptions) installTerraform() error {
 	if runtime.GOOS == "darwin" && !o.NoBrew {
 		return o.RunCommand("brew", "install", "terraform")
 	}
+	if installed, err := o.installWithChocolatey("terraform"); installed {
+		return err
+	}
 
 	binDir, err := util.JXBinLocation()
 	if err != nil {
@@ -965,15 +1071,22 @@ func (o *CommonOptions) installTerraform() error {
 	if err != nil || !flag {
 		return err
 	}
-	latestVersion, err := util.GetLatestVersionFromGitHub("hashicorp", "terraform")
+	latestVersion, err := o.resolveToolVersion(binary, func() (semver.Version, error) {
+		return util.GetLatestVersionFromGitHub("hashicorp", "terraform")
+	})
 	if err != nil {
 		return err
 	}
 
 	clientURL := fmt.Sprintf("https://releases.hashicorp.com/terraform/%s/terraform_%s_%s_%s.zip", latestVersion, latestVersion, runtime.GOOS, runtime.GOARCH)
+	checksumURL := fmt.Sprintf("https://releases.hashicorp.com/terraform/%s/terraform_%s_SHA256SUMS", latestVersion, latestVersion)
+	checksums := binaries.ChecksumOptions{
+		ChecksumURL:  checksumURL,
+		SignatureURL: checksumURL + ".sig",
+	}
 	fullPath := filepath.Join(binDir, fileName)
 	zipFile := fullPath + ".zip"
-	err = binaries.DownloadFile(clientURL, zipFile)
+	err = o.downloadAndVerifyFile(clientURL, zipFile, checksums)
 	if err != nil {
 		return err
 	}
@@ -1113,68 +1226,49 @@ func (o *CommonOptions) installJx(upgrade bool, version string) error {
 		fullPath += ".exe"
 	}
 	tmpArchiveFile := fullPath + ".tmp"
-	err = binaries.DownloadFile(clientURL, tmpArchiveFile)
+	// the release publishes the checksum of each archive next to it via gh-release checksums
+	err = o.downloadAndVerifyFile(clientURL, tmpArchiveFile, binaries.ChecksumOptions{ChecksumURL: clientURL + ".sha256"})
 	if err != nil {
 		return err
 	}
-	// Untar the new binary into a temp directory
-	jxHome, err := util.ConfigDir()
+	// Extract the new binary next to the current one so that it can be atomically renamed over it
+	extractDir, err := ioutil.TempDir(binDir, ".jx-upgrade-")
 	if err != nil {
 		return err
 	}
+	defer os.RemoveAll(extractDir)
 
+	newBinary := filepath.Join(extractDir, binary)
 	if runtime.GOOS != "windows" {
-		err = util.UnTargz(tmpArchiveFile, jxHome, []string{binary, fileName})
-		if err != nil {
-			return err
-		}
-		err = os.Remove(tmpArchiveFile)
-		if err != nil {
-			return err
-		}
-		err = os.Remove(filepath.Join(binDir, "jx"))
-		if err != nil && o.Verbose {
-			log.Infof("Skipping removal of old jx binary: %s\n", err)
-		}
-		// Copy over the new binary
-		err = os.Rename(filepath.Join(jxHome, "jx"), filepath.Join(binDir, "jx"))
-		if err != nil {
-			return err
-		}
-	} else { // windows
+		err = util.UnTargz(tmpArchiveFile, extractDir, []string{binary, fileName})
+	} else {
 		windowsBinaryFromArchive := "jx-windows-amd64.exe"
-		err = util.UnzipSpecificFiles(tmpArchiveFile, jxHome, windowsBinaryFromArchive)
-		if err != nil {
-			return err
-		}
-		err = os.Remove(tmpArchiveFile)
-		if err != nil {
-			return err
-		}
-		// A standard remove and rename (or overwrite) will not work as the file will be locked as windows is running it
-		// the trick is to rename to a tempfile :-o
-		// this will leave old files around but well at least it updates.
-		// we could schedule the file for cleanup at next boot but....
-		// HKLM\System\CurrentControlSet\Control\Session Manager\PendingFileRenameOperations
-		err = os.Rename(filepath.Join(binDir, "jx.exe"), filepath.Join(binDir, "jx.exe.deleteme"))
-		// if we can not rename it this i pretty fatal as we won;t be able to overwrite either
-		if err != nil {
-			return err
-		}
-		// Copy over the new binary
-		err = os.Rename(filepath.Join(jxHome, windowsBinaryFromArchive), filepath.Join(binDir, "jx.exe"))
-		if err != nil {
-			return err
-		}
+		newBinary = filepath.Join(extractDir, windowsBinaryFromArchive)
+		err = util.UnzipSpecificFiles(tmpArchiveFile, extractDir, windowsBinaryFromArchive)
+	}
+	if err != nil {
+		return err
+	}
+	err = os.Remove(tmpArchiveFile)
+	if err != nil {
+		return err
+	}
+	// windows locks the running binary so it is renamed out of the way rather than overwritten
+	err = util.ReplaceExecutable(newBinary, fullPath)
+	if err != nil {
+		return errors.Wrapf(err, "replacing %s", fullPath)
 	}
 	log.Infof("Jenkins X client has been installed into %s\n", util.ColorInfo(fullPath))
-	return os.Chmod(fullPath, 0755)
+	return nil
 }
 
 func (o *CommonOptions) installMinikube() error {
 	if runtime.GOOS == "darwin" && !o.NoBrew {
 		return o.RunCommand("brew", "cask", "install", "minikube")
 	}
+	if installed, err := o.installWithChocolatey("minikube"); installed {
+		return err
+	}
 
 	binDir, err := util.JXBinLocation()
 	if err != nil {
@@ -1234,9 +1328,20 @@ func (o *CommonOptions) installMinishift() error {
 	return os.Chmod(fullPath, 0755)
 }
 
+// gcloudArchs maps the architectures to the ones used in the names of the Google Cloud SDK archives
+var gcloudArchs = map[string]string{
+	"amd64": "x86_64",
+	"386":   "x86",
+}
+
+// installGcloud installs the Google Cloud SDK. Chocolatey and Homebrew verify the checksums of the packages they
+// install, otherwise the archive of the version pinned by the version stream is downloaded
 func (o *CommonOptions) installGcloud() error {
+	if installed, err := o.installWithChocolatey("gcloudsdk"); installed {
+		return err
+	}
 	if runtime.GOOS != "darwin" || o.NoBrew {
-		return errors.New("please install missing gcloud sdk - see https://cloud.google.com/sdk/downloads#interactive")
+		return o.downloadGcloud()
 	}
 	err := o.RunCommand("brew", "tap", "caskroom/cask")
 	if err != nil {
@@ -1246,7 +1351,68 @@ func (o *CommonOptions) installGcloud() error {
 	return o.RunCommand("brew", "cask", "install", "google-cloud-sdk")
 }
 
+// downloadGcloud downloads the archive of the Google Cloud SDK into the jx config directory and links its binaries into
+// the jx bin directory. Google does not publish checksum files next to the archives so the archive is verified
+// against the checksum pinned by the version stream
+func (o *CommonOptions) downloadGcloud() error {
+	arch := gcloudArchs[runtime.GOARCH]
+	if (runtime.GOOS != "linux" && runtime.GOOS != "darwin") || arch == "" {
+		return errors.New("please install missing gcloud sdk - see https://cloud.google.com/sdk/downloads#interactive")
+	}
+	versions := o.versionStream()
+	version := versions.ToolVersion("gcloud")
+	if version == "" {
+		return fmt.Errorf("no gcloud version is pinned in the version stream %s, please install missing gcloud sdk - see https://cloud.google.com/sdk/downloads#interactive", versionstream.DefaultVersionsURL)
+	}
+	fileName := fmt.Sprintf("google-cloud-sdk-%s-%s-%s.tar.gz", version, runtime.GOOS, arch)
+	checksum := versions.Checksum(fileName)
+	if checksum == "" && !o.InsecureSkipVerify {
+		return fmt.Errorf("no checksum of %s is pinned in the version stream %s so it cannot be verified, use --insecure-skip-verify to skip the verification", fileName, versionstream.DefaultVersionsURL)
+	}
+	configDir, err := util.ConfigDir()
+	if err != nil {
+		return err
+	}
+	binDir, err := util.JXBinLocation()
+	if err != nil {
+		return err
+	}
+
+	clientURL := "https://dl.google.com/dl/cloudsdk/channels/rapid/downloads/" + fileName
+	tarFile := filepath.Join(configDir, fileName)
+	err = o.downloadAndVerifyFile(clientURL, tarFile, binaries.ChecksumOptions{FileName: fileName, Checksum: checksum})
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tarFile)
+	sdkDir := filepath.Join(configDir, "google-cloud-sdk")
+	err = os.RemoveAll(sdkDir)
+	if err != nil {
+		return err
+	}
+	err = o.RunCommand("tar", "-xzf", tarFile, "-C", configDir)
+	if err != nil {
+		return errors.Wrapf(err, "extracting %s", fileName)
+	}
+	for _, binary := range []string{"gcloud", "gsutil"} {
+		link := filepath.Join(binDir, binary)
+		err = os.Remove(link)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		err = os.Symlink(filepath.Join(sdkDir, "bin", binary), link)
+		if err != nil {
+			return errors.Wrapf(err, "linking %s into %s", binary, binDir)
+		}
+	}
+	log.Infof("Installed the Google Cloud SDK %s into %s\n", util.ColorInfo(version), util.ColorInfo(sdkDir))
+	return nil
+}
+
 func (o *CommonOptions) installAzureCli() error {
+	if installed, err := o.installWithChocolatey("azure-cli"); installed {
+		return err
+	}
 	return o.RunCommand("brew", "install", "azure-cli")
 }
 
@@ -1305,6 +1471,18 @@ func (o *CommonOptions) installHeptioAuthenticatorAwsWithVersion(version string,
 	})
 }
 
+// installOpa installs the Open Policy Agent which evaluates the Rego of the Gatekeeper constraint templates
+func (o *CommonOptions) installOpa() error {
+	return o.installOrUpdateBinary(InstallOrUpdateBinaryOptions{
+		Binary:              policy.OpaBinary,
+		GitHubOrganization:  "open-policy-agent",
+		DownloadUrlTemplate: "https://github.com/open-policy-agent/opa/releases/download/v{{.version}}/opa_{{.os}}_{{.arch}}",
+		Version:             binaries.OpaVersion,
+		SkipPathScan:        false,
+		VersionExtractor:    nil,
+	})
+}
+
 func (o *CommonOptions) GetCloudProvider(p string) (string, error) {
 	surveyOpts := survey.WithStdio(o.In, o.Out, o.Err)
 	if p == "" {
@@ -1398,29 +1576,41 @@ func (o *CommonOptions) installMissingDependencies(providerSpecificDeps []string
 // installRequirements installs any requirements for the given provider kind
 func (o *CommonOptions) installRequirements(cloudProvider string, extraDependencies ...string) error {
 	var deps []string
+	for _, dep := range providerDependencies(cloudProvider) {
+		deps = o.addRequiredBinary(dep, deps)
+	}
+
+	for _, dep := range extraDependencies {
+		deps = o.addRequiredBinary(dep, deps)
+	}
+
+	return o.installMissingDependencies(deps)
+}
+
+// providerDependencies returns the binaries required by the given cloud provider
+func providerDependencies(cloudProvider string) []string {
 	switch cloudProvider {
 	case IKS:
-		deps = o.addRequiredBinary("ibmcloud", deps)
+		return []string{"ibmcloud"}
 	case AWS:
-		deps = o.addRequiredBinary("kops", deps)
+		return []string{"kops"}
 	case EKS:
-		deps = o.addRequiredBinary("eksctl", deps)
-		deps = o.addRequiredBinary("heptio-authenticator-aws", deps)
+		return []string{"eksctl", "heptio-authenticator-aws"}
 	case AKS:
-		deps = o.addRequiredBinary("az", deps)
+		return []string{"az"}
 	case GKE:
-		deps = o.addRequiredBinary("gcloud", deps)
+		return []string{"gcloud"}
 	case OKE:
-		deps = o.addRequiredBinary("oci", deps)
+		return []string{"oci"}
 	case MINIKUBE:
-		deps = o.addRequiredBinary("minikube", deps)
-	}
-
-	for _, dep := range extraDependencies {
-		deps = o.addRequiredBinary(dep, deps)
+		return []string{"minikube"}
 	}
+	return []string{}
+}
 
-	return o.installMissingDependencies(deps)
+// clusterDependencies returns the binaries required for all cloud providers
+func clusterDependencies() []string {
+	return []string{"kubectl", "helm"}
 }
 
 func (o *CommonOptions) addRequiredBinary(binName string, deps []string) []string {
@@ -1659,6 +1849,49 @@ func (o *CommonOptions) installProw() error {
 	return nil
 }
 
+// installTekton installs the Tekton pipeline controller which runs the serverless pipelines instead of Knative Build.
+// The tiller namespace is empty when helm does not use tiller
+func (o *CommonOptions) installTekton(ns string, tillerNamespace string) error {
+	log.Infof("Installing Tekton into namespace %s\n", util.ColorInfo(ns))
+
+	values := []string{"auth.git.username=" + o.Username, "auth.git.password=" + o.OAUTHToken}
+	if o.SetValues != "" {
+		values = append(values, strings.Split(o.SetValues, ",")...)
+	}
+	err := o.retry(2, time.Second, func() error {
+		return o.installChart(kube.DefaultTektonReleaseName, kube.ChartTekton, "", ns, true, values, nil, "")
+	})
+	if err != nil {
+		return fmt.Errorf("failed to install Tekton: %v", err)
+	}
+
+	// the pipelines only get the permissions they need in the team namespace, to deploy via tiller and to create
+	// the namespaces of the previews
+	err = o.ensureServiceAccount(ns, defaultTektonServiceAccount)
+	if err != nil {
+		return errors.Wrapf(err, "creating the ServiceAccount %s", defaultTektonServiceAccount)
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	err = kube.EnsureRoleBinding(kubeClient, ns, defaultTektonServiceAccount, kube.PipelineRules, ns, defaultTektonServiceAccount)
+	if err != nil {
+		return errors.Wrapf(err, "binding the ServiceAccount %s", defaultTektonServiceAccount)
+	}
+	if tillerNamespace != "" && tillerNamespace != ns {
+		err = kube.EnsureRoleBinding(kubeClient, tillerNamespace, defaultTektonServiceAccount+"-"+ns, kube.TillerClientRules, ns, defaultTektonServiceAccount)
+		if err != nil {
+			return errors.Wrapf(err, "binding the ServiceAccount %s in the tiller namespace", defaultTektonServiceAccount)
+		}
+	}
+	err = kube.EnsureClusterRoleBinding(kubeClient, defaultTektonServiceAccount+"-"+ns, kube.PreviewNamespaceRules, ns, defaultTektonServiceAccount)
+	if err != nil {
+		return errors.Wrapf(err, "binding the ServiceAccount %s", defaultTektonServiceAccount)
+	}
+	return nil
+}
+
 func (o *CommonOptions) createWebhookProw(gitURL string, gitProvider gits.GitProvider) error {
 	ns, _, err := kube.GetDevNamespace(o.KubeClientCached, o.currentNamespace)
 	if err != nil {
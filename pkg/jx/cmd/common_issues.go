@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/jenkins-x/jx/pkg/auth"
 	"github.com/jenkins-x/jx/pkg/config"
@@ -9,6 +10,7 @@ import (
 	"github.com/jenkins-x/jx/pkg/issues"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
 )
 
 func (o *CommonOptions) CreateIssueTrackerAuthConfigService() (auth.ConfigService, error) {
@@ -28,36 +30,31 @@ func (o *CommonOptions) errorCreateIssueTrackerAuthConfigService(parentError err
 }
 
 func (o *CommonOptions) createIssueProvider(dir string) (issues.IssueProvider, error) {
-	gitDir, gitConfDir, err := o.Git().FindGitConfigDir(dir)
+	_, gitConfDir, err := o.Git().FindGitConfigDir(dir)
 	if err != nil {
 		return nil, fmt.Errorf("No issue tracker configured for this project and cannot find the .git directory: %s", err)
 	}
-	pc, _, err := config.LoadProjectConfig(dir)
+	it, err := o.issueTrackerConfig(dir)
 	if err != nil {
 		return nil, err
 	}
-	if pc != nil && pc.IssueTracker == nil {
-		pc, _, err = config.LoadProjectConfig(gitDir)
-		if err != nil {
-			return nil, err
+	if it != nil && it.Kind != "" {
+		switch it.Kind {
+		case issues.GitHub, issues.GitLab:
+			return o.createGitIssueTracker(it)
 		}
-	}
-	if pc != nil {
-		it := pc.IssueTracker
-		if it != nil {
-			if it.URL != "" && it.Kind != "" {
-				authConfigSvc, err := o.CreateIssueTrackerAuthConfigService()
-				if err != nil {
-					return nil, err
-				}
-				config := authConfigSvc.Config()
-				server := config.GetOrCreateServer(it.URL)
-				userAuth, err := config.PickServerUserAuth(server, "user to access the issue tracker", o.BatchMode, "", o.In, o.Out, o.Err)
-				if err != nil {
-					return nil, err
-				}
-				return issues.CreateIssueProvider(it.Kind, server, userAuth, it.Project, o.BatchMode, o.Git())
+		if it.URL != "" {
+			authConfigSvc, err := o.CreateIssueTrackerAuthConfigService()
+			if err != nil {
+				return nil, err
+			}
+			config := authConfigSvc.Config()
+			server := config.GetOrCreateServer(it.URL)
+			userAuth, err := config.PickServerUserAuth(server, "user to access the issue tracker", o.BatchMode, "", o.In, o.Out, o.Err)
+			if err != nil {
+				return nil, err
 			}
+			return issues.CreateIssueProvider(it.Kind, server, userAuth, it.Project, o.BatchMode, o.Git())
 		}
 	}
 
@@ -78,3 +75,52 @@ func (o *CommonOptions) createIssueProvider(dir string) (issues.IssueProvider, e
 	}
 	return issues.CreateGitIssueProvider(gitProvider, gitInfo.Organisation, gitInfo.Name)
 }
+
+// issueTrackerConfig returns the issue tracker configuration of the project in the given directory or its git
+// root directory or nil if the project has no issue tracker configured
+func (o *CommonOptions) issueTrackerConfig(dir string) (*config.IssueTrackerConfig, error) {
+	gitDir, _, err := o.Git().FindGitConfigDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find the .git directory: %s", err)
+	}
+	pc, _, err := config.LoadProjectConfig(dir)
+	if err != nil {
+		return nil, err
+	}
+	if pc != nil && pc.IssueTracker == nil && gitDir != "" {
+		pc, _, err = config.LoadProjectConfig(gitDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if pc == nil {
+		return nil, nil
+	}
+	return pc.IssueTracker, nil
+}
+
+// createGitIssueTracker creates the issue tracker of a GitHub or GitLab repository configured as the issue tracker
+// of the project with the project in the form owner/repository
+func (o *CommonOptions) createGitIssueTracker(it *config.IssueTrackerConfig) (issues.IssueProvider, error) {
+	serverURL := it.URL
+	if serverURL == "" {
+		if it.Kind == issues.GitLab {
+			serverURL = "https://gitlab.com"
+		} else {
+			serverURL = gits.GitHubURL
+		}
+	}
+	if len(strings.Split(it.Project, "/")) != 2 {
+		return nil, fmt.Errorf("the project of the %s issue tracker should be of the form owner/repository but was '%s'", it.Kind, it.Project)
+	}
+	gitURL := util.UrlJoin(serverURL, it.Project) + ".git"
+	gitInfo, err := gits.ParseGitURL(gitURL)
+	if err != nil {
+		return nil, err
+	}
+	gitProvider, err := o.gitProviderForURL(gitURL, "user name to use for authenticating with git issues")
+	if err != nil {
+		return nil, err
+	}
+	return issues.CreateGitIssueProvider(gitProvider, gitInfo.Organisation, gitInfo.Name)
+}
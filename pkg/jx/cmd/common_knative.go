@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/jenkins-x/jx/pkg/knative"
+	"github.com/jenkins-x/jx/pkg/log"
+)
+
+// findKnativeServiceURL returns the URL of the first Knative Service in the namespace with one of the given names or
+// an empty string if there is none
+func (o *CommonOptions) findKnativeServiceURL(ns string, names []string) string {
+	dynamicClient, err := o.dynamicClient()
+	if err != nil {
+		log.Warnf("Failed to look for Knative Services in namespace %s: %s\n", ns, err)
+		return ""
+	}
+	url, err := knative.FindServiceURL(dynamicClient, ns, names)
+	if err != nil {
+		log.Warnf("Failed to look for Knative Services in namespace %s: %s\n", ns, err)
+		return ""
+	}
+	return url
+}
+
+// findKnativeServiceStatus returns the status of the first Knative Service in the namespace with one of the given
+// names or nil if there is none
+func (o *CommonOptions) findKnativeServiceStatus(ns string, names []string) (*knative.ServiceStatus, error) {
+	dynamicClient, err := o.dynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		status, err := knative.GetServiceStatus(dynamicClient, ns, name)
+		if err != nil {
+			return nil, err
+		}
+		if status != nil {
+			return status, nil
+		}
+	}
+	return nil, nil
+}
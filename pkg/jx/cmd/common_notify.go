@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/notify"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	optionEvents = "events"
+
+	// notificationTimeout the maximum time to wait for a notification channel to accept a notification
+	notificationTimeout = 30 * time.Second
+)
+
+// sendNotification sends the event to the notification channels of the team. Failures are only logged as
+// notifications should never fail a pipeline or a promotion
+func (o *CommonOptions) sendNotification(event *notify.Event) {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		log.Warnf("Failed to create the jx client to send the %s notification: %s\n", event.Kind, err)
+		return
+	}
+	env, err := kube.GetDevEnvironment(jxClient, ns)
+	if err != nil || env == nil {
+		return
+	}
+	channels := env.Spec.TeamSettings.Notifications
+	if len(channels) == 0 {
+		return
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		log.Warnf("Failed to create the kube client to send the %s notification: %s\n", event.Kind, err)
+		return
+	}
+	notifyChannels(kubeClient, ns, channels, event)
+}
+
+// notifyChannels sends the event to each of the given notification channels which match it
+func notifyChannels(kubeClient kubernetes.Interface, ns string, channels []v1.NotificationChannel, event *notify.Event) {
+	for i := range channels {
+		channel := &channels[i]
+		if !notify.Matches(channel, event) {
+			continue
+		}
+		var data map[string][]byte
+		if channel.SecretName != "" {
+			secret, err := kubeClient.CoreV1().Secrets(ns).Get(channel.SecretName, metav1.GetOptions{})
+			if err != nil {
+				log.Warnf("Failed to load the Secret %s of notification channel %s: %s\n", channel.SecretName, channel.Name, err)
+				continue
+			}
+			data = secret.Data
+		}
+		sender, err := notify.NewSender(channel, data)
+		if err != nil {
+			log.Warnf("%s\n", err)
+			continue
+		}
+		message, err := notify.RenderMessage(channel, event)
+		if err != nil {
+			log.Warnf("%s\n", err)
+			continue
+		}
+		err = notify.SendWithTimeout(sender, event, message, notificationTimeout)
+		if err != nil {
+			log.Warnf("Failed to send the %s notification to channel %s: %s\n", event.Kind, channel.Name, err)
+		}
+	}
+}
+
+// saveNotificationChannel stores the credentials of the notification channel in its Secret and adds or
+// replaces the channel in the team settings
+func (o *CommonOptions) saveNotificationChannel(channel v1.NotificationChannel, secretData map[string]string) error {
+	kubeClient, ns, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	if channel.SecretName != "" && len(secretData) > 0 {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: channel.SecretName,
+				Labels: map[string]string{
+					kube.LabelKind: "notification",
+				},
+			},
+			StringData: secretData,
+		}
+		_, err = kubeClient.CoreV1().Secrets(ns).Create(secret)
+		if apierrors.IsAlreadyExists(err) {
+			_, err = kubeClient.CoreV1().Secrets(ns).Update(secret)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "saving the Secret %s in namespace %s", channel.SecretName, ns)
+		}
+	}
+	return o.ModifyDevEnvironment(func(env *v1.Environment) error {
+		settings := &env.Spec.TeamSettings
+		for i, c := range settings.Notifications {
+			if c.Name == channel.Name {
+				settings.Notifications[i] = channel
+				return nil
+			}
+		}
+		settings.Notifications = append(settings.Notifications, channel)
+		return nil
+	})
+}
+
+// validateNotificationEvents returns an error if any of the events cannot be notified
+func validateNotificationEvents(events []string) error {
+	for _, event := range events {
+		if util.StringArrayIndex(notify.Events, event) < 0 {
+			return util.InvalidOption(optionEvents, event, notify.Events)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// OAuth2ProxyProviderGitHub authenticates the users with GitHub
+	OAuth2ProxyProviderGitHub = "github"
+	// OAuth2ProxyProviderGoogle authenticates the users with Google
+	OAuth2ProxyProviderGoogle = "google"
+	// OAuth2ProxyProviderOIDC authenticates the users with an OpenID Connect provider
+	OAuth2ProxyProviderOIDC = "oidc"
+
+	oauth2ProxyTLSSecret = "tls-oauth2-proxy"
+
+	// oauth2ProxySecretSuffix the suffix of the name of the Secret with the client and cookie secrets which the
+	// chart reads rather than them being passed to helm on the command line
+	oauth2ProxySecretSuffix = "-credentials"
+)
+
+// OAuth2ProxyProviders the identity providers the oauth2-proxy supports
+var OAuth2ProxyProviders = []string{OAuth2ProxyProviderGitHub, OAuth2ProxyProviderGoogle, OAuth2ProxyProviderOIDC}
+
+// DefaultOAuth2ProxyServices the services of the web UIs of the platform which the oauth2-proxy protects by default.
+// Jenkins is not protected as its webhooks and the API token calls jx makes would be redirected to the sign in
+var DefaultOAuth2ProxyServices = []string{"nexus", "monocular", "grafana"}
+
+// OAuth2ProxyFlags the flags of the oauth2-proxy which authenticates the users of the web UIs
+type OAuth2ProxyFlags struct {
+	Provider      string
+	ClientID      string
+	ClientSecret  string
+	OIDCIssuerURL string
+	EmailDomain   string
+	GitHubOrg     string
+	GitHubTeam    string
+	Services      []string
+}
+
+// addFlags adds the flags of the oauth2-proxy with the given prefix so that they do not clash with other flags
+func (f *OAuth2ProxyFlags) addFlags(cmd *cobra.Command, prefix string) {
+	cmd.Flags().StringVarP(&f.Provider, prefix+"provider", "", "", "The identity provider the users of the web UIs sign in with. One of: "+strings.Join(OAuth2ProxyProviders, ", "))
+	cmd.Flags().StringVarP(&f.ClientID, prefix+"client-id", "", "", "The client ID of the OAuth application registered with the identity provider")
+	cmd.Flags().StringVarP(&f.ClientSecret, prefix+"client-secret", "", "", "The client secret of the OAuth application registered with the identity provider")
+	cmd.Flags().StringVarP(&f.OIDCIssuerURL, prefix+"oidc-issuer-url", "", "", "The issuer URL of the OpenID Connect provider")
+	cmd.Flags().StringVarP(&f.EmailDomain, prefix+"email-domain", "", "", "The email domain of the users who are allowed to sign in. Required unless a GitHub organisation is given")
+	cmd.Flags().StringVarP(&f.GitHubOrg, prefix+"github-org", "", "", "The GitHub organisation whose members are allowed to sign in")
+	cmd.Flags().StringVarP(&f.GitHubTeam, prefix+"github-team", "", "", "The teams of the GitHub organisation whose members are allowed to sign in, separated by commas")
+	cmd.Flags().StringSliceVarP(&f.Services, prefix+"services", "", DefaultOAuth2ProxyServices, "The services of the web UIs which users have to sign in to")
+}
+
+// installOAuth2Proxy installs the oauth2-proxy into the given namespace and puts it in front of the ingresses of the
+// web UIs so that they share a single sign in rather than basic authentication
+func (o *CommonOptions) installOAuth2Proxy(ns string, releaseName string, version string, flags OAuth2ProxyFlags, setValues []string) error {
+	if util.StringArrayIndex(OAuth2ProxyProviders, flags.Provider) < 0 {
+		return util.InvalidOption("provider", flags.Provider, OAuth2ProxyProviders)
+	}
+	if flags.Provider == OAuth2ProxyProviderOIDC && flags.OIDCIssuerURL == "" {
+		return util.MissingOption("oidc-issuer-url")
+	}
+	err := validateOAuth2ProxyRestriction(flags)
+	if err != nil {
+		return err
+	}
+	if flags.ClientID == "" {
+		if o.BatchMode {
+			return util.MissingOption("client-id")
+		}
+		flags.ClientID, err = util.PickValue("OAuth client ID:", "", true, "", o.In, o.Out, o.Err)
+		if err != nil {
+			return err
+		}
+	}
+	if flags.ClientSecret == "" {
+		if o.BatchMode {
+			return util.MissingOption("client-secret")
+		}
+		flags.ClientSecret, err = util.PickPassword("OAuth client secret:", "", o.In, o.Out, o.Err)
+		if err != nil {
+			return err
+		}
+	}
+
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ingressConfig, err := kube.GetIngressConfig(client, ns)
+	if err != nil {
+		return errors.Wrap(err, "retrieving existing ingress configuration")
+	}
+	if ingressConfig.Domain == "" {
+		return fmt.Errorf("no domain found in the ingress configuration of namespace %s", ns)
+	}
+	if !ingressConfig.TLS {
+		log.Warnf("TLS is not enabled so the sign in cookies are sent unencrypted. Enable it via: jx upgrade ingress\n")
+	}
+	secretName := releaseName + oauth2ProxySecretSuffix
+	err = o.saveOAuth2ProxySecret(ns, secretName, flags)
+	if err != nil {
+		return err
+	}
+	host := fmt.Sprintf("%s.%s.%s", releaseName, ns, ingressConfig.Domain)
+	proxyURL := "http://" + host
+	if ingressConfig.TLS {
+		proxyURL = "https://" + host
+	}
+
+	emailDomain := flags.EmailDomain
+	if emailDomain == "" {
+		// the GitHub organisation restricts who can sign in
+		emailDomain = "*"
+	}
+	values := []string{
+		"config.existingSecret=" + secretName,
+		"extraArgs.provider=" + flags.Provider,
+		"extraArgs.email-domain=" + emailDomain,
+		"extraArgs.cookie-domain=." + ingressConfig.Domain,
+		"extraArgs.whitelist-domain=." + ingressConfig.Domain,
+		"extraArgs.redirect-url=" + proxyURL + "/oauth2/callback",
+		fmt.Sprintf("extraArgs.cookie-secure=%t", ingressConfig.TLS),
+		"ingress.enabled=true",
+		"ingress.hosts={" + host + "}",
+	}
+	if flags.OIDCIssuerURL != "" {
+		values = append(values, "extraArgs.oidc-issuer-url="+flags.OIDCIssuerURL)
+	}
+	if flags.GitHubOrg != "" {
+		values = append(values, "extraArgs.github-org="+flags.GitHubOrg)
+	}
+	if flags.GitHubTeam != "" {
+		values = append(values, "extraArgs.github-team="+strings.Replace(flags.GitHubTeam, ",", "\\,", -1))
+	}
+	if ingressConfig.TLS {
+		values = append(values, "ingress.tls[0].secretName="+oauth2ProxyTLSSecret, "ingress.tls[0].hosts={"+host+"}")
+		issuerAnnotation := "certmanager\\.k8s\\.io/issuer"
+		if ingressConfig.ClusterIssuer {
+			issuerAnnotation = "certmanager\\.k8s\\.io/cluster-issuer"
+		}
+		values = append(values, "ingress.annotations."+issuerAnnotation+"="+ingressConfig.Issuer)
+	}
+	values = append(values, setValues...)
+
+	log.Infof("Installing %s to authenticate the users of the web UIs with %s\n", util.ColorInfo("oauth2-proxy"), util.ColorInfo(flags.Provider))
+	err = o.installChart(releaseName, kube.ChartOAuth2Proxy, version, ns, true, values, nil, "")
+	if err != nil {
+		return errors.Wrap(err, "oauth2-proxy deployment failed")
+	}
+	return o.protectWithOAuth2Proxy(ns, flags.Services, proxyURL)
+}
+
+// validateOAuth2ProxyRestriction returns an error unless the users who can sign in are restricted to the members of a
+// GitHub organisation or to an email domain as otherwise anyone with an account of the identity provider could
+func validateOAuth2ProxyRestriction(flags OAuth2ProxyFlags) error {
+	if flags.GitHubTeam != "" && flags.GitHubOrg == "" {
+		return util.MissingOption("github-org")
+	}
+	if flags.EmailDomain == "*" {
+		return util.InvalidOptionf("email-domain", flags.EmailDomain, "it would let anyone sign in. Please specify the email domain of your users")
+	}
+	if flags.EmailDomain != "" {
+		return nil
+	}
+	if flags.Provider == OAuth2ProxyProviderGitHub {
+		if flags.GitHubOrg == "" {
+			return fmt.Errorf("please specify the GitHub organisation or the email domain of the users who are allowed to sign in")
+		}
+		return nil
+	}
+	return util.MissingOption("email-domain")
+}
+
+// saveOAuth2ProxySecret stores the client credentials and the cookie secret of the oauth2-proxy in a Secret. The
+// cookie secret of an existing Secret is kept so that users stay signed in
+func (o *CommonOptions) saveOAuth2ProxySecret(ns string, name string, flags OAuth2ProxyFlags) error {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	secrets := client.CoreV1().Secrets(ns)
+	secret, err := secrets.Get(name, metav1.GetOptions{})
+	create := apierrors.IsNotFound(err)
+	if err != nil && !create {
+		return errors.Wrapf(err, "getting the Secret %s in namespace %s", name, ns)
+	}
+	if create {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					kube.LabelCreatedBy: kube.ValueCreatedByJX,
+				},
+			},
+		}
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	if len(secret.Data["cookie-secret"]) == 0 {
+		cookieSecret, err := oauth2ProxyCookieSecret()
+		if err != nil {
+			return err
+		}
+		secret.Data["cookie-secret"] = []byte(cookieSecret)
+	}
+	secret.Data["client-id"] = []byte(flags.ClientID)
+	secret.Data["client-secret"] = []byte(flags.ClientSecret)
+	if create {
+		_, err = secrets.Create(secret)
+	} else {
+		_, err = secrets.Update(secret)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "saving the Secret %s in namespace %s", name, ns)
+	}
+	return nil
+}
+
+// protectWithOAuth2Proxy makes the ingresses of the given services authenticate their users with the oauth2-proxy.
+// Both the services and their current ingresses are annotated so that the ingresses exposecontroller generates
+// later on are protected too
+func (o *CommonOptions) protectWithOAuth2Proxy(ns string, services []string, proxyURL string) error {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	for _, name := range services {
+		if name == kube.ServiceJenkins {
+			log.Warnf("Protecting %s with the oauth2-proxy stops its webhooks and the API calls of jx from working\n", name)
+		}
+		svc, err := client.CoreV1().Services(ns).Get(name, metav1.GetOptions{})
+		if err != nil {
+			continue
+		}
+		if kube.ProtectServiceWithOAuth2Proxy(svc, proxyURL) {
+			_, err = client.CoreV1().Services(ns).Update(svc)
+			if err != nil {
+				return errors.Wrapf(err, "failed to update service %s in namespace %s", name, ns)
+			}
+		}
+	}
+
+	ingresses, err := client.ExtensionsV1beta1().Ingresses(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the ingresses in namespace %s", ns)
+	}
+	for i := range ingresses.Items {
+		ingress := &ingresses.Items[i]
+		name := oauth2ProxyServiceOfIngress(ingress.Name, ingressHosts(ingress.Spec.Rules), services)
+		if name == "" || !kube.ProtectIngressWithOAuth2Proxy(ingress, proxyURL) {
+			continue
+		}
+		_, err = client.ExtensionsV1beta1().Ingresses(ns).Update(ingress)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update ingress %s in namespace %s", ingress.Name, ns)
+		}
+		log.Infof("Users now sign in to %s with the oauth2-proxy\n", util.ColorInfo(name))
+	}
+	return nil
+}
+
+// oauth2ProxyServiceOfIngress returns the service of the given services which the ingress exposes or an empty string
+func oauth2ProxyServiceOfIngress(ingressName string, hosts []string, services []string) string {
+	for _, name := range services {
+		if ingressName == name || strings.HasSuffix(ingressName, "-"+name) {
+			return name
+		}
+		for _, host := range hosts {
+			if strings.HasPrefix(host, name+".") {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+func ingressHosts(rules []extv1beta1.IngressRule) []string {
+	hosts := []string{}
+	for _, rule := range rules {
+		hosts = append(hosts, rule.Host)
+	}
+	return hosts
+}
+
+// oauth2ProxyCookieSecret generates the secret the oauth2-proxy encrypts its cookies with
+func oauth2ProxyCookieSecret() (string, error) {
+	data := make([]byte, 16)
+	_, err := rand.Read(data)
+	if err != nil {
+		return "", errors.Wrap(err, "generating the cookie secret")
+	}
+	return base64.URLEncoding.EncodeToString(data), nil
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateOAuth2ProxyRestriction(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, validateOAuth2ProxyRestriction(OAuth2ProxyFlags{Provider: OAuth2ProxyProviderGitHub, GitHubOrg: "myorg"}))
+	assert.NoError(t, validateOAuth2ProxyRestriction(OAuth2ProxyFlags{Provider: OAuth2ProxyProviderGitHub, GitHubOrg: "myorg", GitHubTeam: "devs"}))
+	assert.NoError(t, validateOAuth2ProxyRestriction(OAuth2ProxyFlags{Provider: OAuth2ProxyProviderGitHub, EmailDomain: "example.com"}))
+	assert.NoError(t, validateOAuth2ProxyRestriction(OAuth2ProxyFlags{Provider: OAuth2ProxyProviderGoogle, EmailDomain: "example.com"}))
+
+	assert.Error(t, validateOAuth2ProxyRestriction(OAuth2ProxyFlags{Provider: OAuth2ProxyProviderGitHub}))
+	assert.Error(t, validateOAuth2ProxyRestriction(OAuth2ProxyFlags{Provider: OAuth2ProxyProviderGitHub, GitHubTeam: "devs"}))
+	assert.Error(t, validateOAuth2ProxyRestriction(OAuth2ProxyFlags{Provider: OAuth2ProxyProviderGoogle}))
+	assert.Error(t, validateOAuth2ProxyRestriction(OAuth2ProxyFlags{Provider: OAuth2ProxyProviderOIDC, EmailDomain: "*"}))
+	assert.Error(t, validateOAuth2ProxyRestriction(OAuth2ProxyFlags{Provider: OAuth2ProxyProviderGitHub, GitHubOrg: "myorg", EmailDomain: "*"}))
+}
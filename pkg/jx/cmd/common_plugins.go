@@ -6,10 +6,13 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io"
+	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
 
 	"github.com/jenkins-x/jx/pkg/extensions"
 
@@ -20,6 +23,67 @@ import (
 	jenkinsv1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 )
 
+const (
+	// pluginEnvBinary the environment variable containing the path of the jx binary which invoked the plugin
+	pluginEnvBinary = "JX_BIN"
+	// pluginEnvHome the environment variable containing the jx configuration directory
+	pluginEnvHome = "JX_HOME"
+	// pluginEnvNamespace the environment variable containing the current namespace
+	pluginEnvNamespace = "JX_NAMESPACE"
+	// pluginEnvTeam the environment variable containing the development namespace of the current team
+	pluginEnvTeam = "JX_TEAM"
+	// pluginEnvEnvironment the environment variable containing the name of the current environment
+	pluginEnvEnvironment = "JX_ENVIRONMENT"
+	// pluginEnvKubeContext the environment variable containing the name of the current kubernetes context
+	pluginEnvKubeContext = "JX_KUBE_CONTEXT"
+)
+
+// pluginEnvironment returns the environment passed to plugins. It is the current environment along with variables
+// describing the jx binary, the current team and namespace so that plugins behave like built in commands. The context
+// and namespace are read from the kube config so that plugins still start without a cluster. The team and environment
+// are only looked up when the cluster is known to be reachable, such as when it manages the plugins. Values which
+// cannot be found are left out
+func (o *CommonOptions) pluginEnvironment(clusterAvailable bool) []string {
+	environ := os.Environ()
+	values := map[string]string{}
+	if binary, err := os.Executable(); err == nil {
+		values[pluginEnvBinary] = binary
+	}
+	if home, err := util.ConfigDir(); err == nil {
+		values[pluginEnvHome] = home
+	}
+	if config, _, err := o.Kube().LoadConfig(); err == nil && config != nil {
+		values[pluginEnvKubeContext] = kube.CurrentContextName(config)
+		values[pluginEnvNamespace] = kube.CurrentNamespace(config)
+	}
+	if clusterAvailable {
+		if team, env, err := o.TeamAndEnvironmentNames(); err == nil {
+			values[pluginEnvTeam] = team
+			values[pluginEnvEnvironment] = env
+		} else {
+			o.Debugf("Not passing the current team to the plugin: %s\n", err)
+		}
+	}
+	return appendPluginEnvironment(environ, values)
+}
+
+// appendPluginEnvironment appends the non empty values to the environment unless they are already set
+func appendPluginEnvironment(environ []string, values map[string]string) []string {
+	names := []string{}
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := values[name]
+		if value == "" || os.Getenv(name) != "" {
+			continue
+		}
+		environ = append(environ, name+"="+value)
+	}
+	return environ
+}
+
 func (o *CommonOptions) isManagedPluginsEnabled() bool {
 	apisClient, err := o.CreateApiExtensionsClient()
 	if err != nil {
@@ -91,6 +155,9 @@ func (o *CommonOptions) getPluginCommandGroups(verifier extensions.PathVerifier)
 	}
 
 	paths := sets.NewString(filepath.SplitList(os.Getenv(path))...)
+	if dir, err := extensions.LocalPluginBinDir(); err == nil {
+		paths.Insert(dir)
+	}
 	for _, dir := range paths.List() {
 		files, err := ioutil.ReadDir(dir)
 		if err != nil {
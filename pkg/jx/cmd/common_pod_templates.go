@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// PodTemplateFlags the flags for customising a Jenkins pod template
+type PodTemplateFlags struct {
+	Image            string
+	Requests         []string
+	Limits           []string
+	Env              []string
+	SecretVolumes    []string
+	ConfigMapVolumes []string
+}
+
+func (f *PodTemplateFlags) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.Image, "image", "i", "", "The container image of the build container")
+	cmd.Flags().StringArrayVarP(&f.Requests, "request", "", []string{}, "The resource requests of the build container of the form name=quantity such as cpu=500m. Can be specified multiple times")
+	cmd.Flags().StringArrayVarP(&f.Limits, "limit", "", []string{}, "The resource limits of the build container of the form name=quantity such as memory=2Gi. Can be specified multiple times")
+	cmd.Flags().StringArrayVarP(&f.Env, "env", "e", []string{}, "The environment variables of the build container of the form NAME=value. Can be specified multiple times")
+	cmd.Flags().StringArrayVarP(&f.SecretVolumes, "secret-volume", "", []string{}, "Mounts a Secret in the build container of the form secretName=mountPath. Can be specified multiple times")
+	cmd.Flags().StringArrayVarP(&f.ConfigMapVolumes, "configmap-volume", "", []string{}, "Mounts a ConfigMap in the build container of the form configMapName=mountPath. Can be specified multiple times")
+}
+
+// customisation returns the customisation of the pod template from the flags
+func (f *PodTemplateFlags) customisation() (*kube.PodTemplateCustomisation, error) {
+	answer := &kube.PodTemplateCustomisation{
+		Image: f.Image,
+	}
+	var err error
+	answer.Resources.Requests, err = parseResourceList("request", f.Requests)
+	if err != nil {
+		return nil, err
+	}
+	answer.Resources.Limits, err = parseResourceList("limit", f.Limits)
+	if err != nil {
+		return nil, err
+	}
+	for _, text := range f.Env {
+		name, value, err := splitPodTemplateFlag("env", text, "NAME=value")
+		if err != nil {
+			return nil, err
+		}
+		answer.Env = append(answer.Env, corev1.EnvVar{Name: name, Value: value})
+	}
+	for _, text := range f.SecretVolumes {
+		name, path, err := splitPodTemplateFlag("secret-volume", text, "secretName=mountPath")
+		if err != nil {
+			return nil, err
+		}
+		answer.Volumes = append(answer.Volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: name},
+			},
+		})
+		answer.VolumeMounts = append(answer.VolumeMounts, corev1.VolumeMount{Name: name, MountPath: path})
+	}
+	for _, text := range f.ConfigMapVolumes {
+		name, path, err := splitPodTemplateFlag("configmap-volume", text, "configMapName=mountPath")
+		if err != nil {
+			return nil, err
+		}
+		answer.Volumes = append(answer.Volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: name},
+				},
+			},
+		})
+		answer.VolumeMounts = append(answer.VolumeMounts, corev1.VolumeMount{Name: name, MountPath: path})
+	}
+	return answer, nil
+}
+
+func parseResourceList(option string, values []string) (corev1.ResourceList, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	answer := corev1.ResourceList{}
+	for _, text := range values {
+		name, value, err := splitPodTemplateFlag(option, text, "name=quantity")
+		if err != nil {
+			return nil, err
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, util.InvalidOptionf(option, text, "invalid quantity: %s", err)
+		}
+		answer[corev1.ResourceName(name)] = quantity
+	}
+	return answer, nil
+}
+
+func splitPodTemplateFlag(option string, text string, format string) (string, string, error) {
+	values := strings.SplitN(text, "=", 2)
+	if len(values) != 2 || values[0] == "" || values[1] == "" {
+		return "", "", util.InvalidOptionf(option, text, "should be of the form %s", format)
+	}
+	return values[0], values[1], nil
+}
+
+// podTemplateNames returns the sorted names of the pod templates
+func podTemplateNames(podTemplates map[string]*corev1.Pod) []string {
+	names := []string{}
+	for k := range podTemplates {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resourceListText returns the resources of the list as text sorted by name
+func resourceListText(list corev1.ResourceList) string {
+	values := []string{}
+	for k, v := range list {
+		values = append(values, string(k)+"="+v.String())
+	}
+	sort.Strings(values)
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPodTemplateFlagsCustomisation(t *testing.T) {
+	t.Parallel()
+	flags := &PodTemplateFlags{
+		Image:         "myorg/builder-maven:1.0.0",
+		Requests:      []string{"cpu=500m", "memory=1Gi"},
+		Env:           []string{"MAVEN_OPTS=-Xmx1g -Dfoo=bar"},
+		SecretVolumes: []string{"e2e-creds=/home/jenkins/e2e"},
+	}
+	customisation, err := flags.customisation()
+	require.NoError(t, err)
+
+	assert.Equal(t, "myorg/builder-maven:1.0.0", customisation.Image)
+	assert.Equal(t, "cpu=500m,memory=1Gi", resourceListText(customisation.Resources.Requests))
+	assert.Nil(t, customisation.Resources.Limits)
+	assert.Equal(t, []corev1.EnvVar{{Name: "MAVEN_OPTS", Value: "-Xmx1g -Dfoo=bar"}}, customisation.Env)
+	require.Len(t, customisation.Volumes, 1)
+	assert.Equal(t, "e2e-creds", customisation.Volumes[0].Secret.SecretName)
+	assert.Equal(t, []corev1.VolumeMount{{Name: "e2e-creds", MountPath: "/home/jenkins/e2e"}}, customisation.VolumeMounts)
+
+	flags = &PodTemplateFlags{Limits: []string{"memory=lots"}}
+	_, err = flags.customisation()
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/quickstarts"
+)
+
+// loadQuickstartModel loads the quickstarts from the quickstart locations of the team, which can include private
+// organisations on any Git server, and from the extra GitHub organisations
+func (o *CommonOptions) loadQuickstartModel(config *auth.AuthConfig, gitHubOrganisations []string, ignoreTeam bool) (*quickstarts.QuickstartModel, error) {
+	var locations []v1.QuickStartLocation
+	if !ignoreTeam {
+		jxClient, ns, err := o.JXClientAndDevNamespace()
+		if err != nil {
+			return nil, err
+		}
+
+		locations, err = kube.GetQuickstartLocations(jxClient, ns)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// lets add any extra github organisations if they are not already configured
+	for _, org := range gitHubOrganisations {
+		found := false
+		for _, loc := range locations {
+			if loc.GitURL == gits.GitHubURL && loc.Owner == org {
+				found = true
+				break
+			}
+		}
+		if !found {
+			locations = append(locations, v1.QuickStartLocation{
+				GitURL:   gits.GitHubURL,
+				GitKind:  gits.KindGitHub,
+				Owner:    org,
+				Includes: []string{"*"},
+				Excludes: []string{"WIP-*"},
+			})
+		}
+	}
+
+	gitMap := map[string]map[string]v1.QuickStartLocation{}
+	for _, loc := range locations {
+		m := gitMap[loc.GitURL]
+		if m == nil {
+			m = map[string]v1.QuickStartLocation{}
+			gitMap[loc.GitURL] = m
+		}
+		m[loc.Owner] = loc
+	}
+	return o.LoadQuickstartsFromMap(config, gitMap)
+}
+
+// LoadQuickstartsFromMap Load all quickstarts
+func (o *CommonOptions) LoadQuickstartsFromMap(config *auth.AuthConfig, gitMap map[string]map[string]v1.QuickStartLocation) (*quickstarts.QuickstartModel, error) {
+	model := quickstarts.NewQuickstartModel()
+
+	for gitURL, m := range gitMap {
+		for _, location := range m {
+			kind := location.GitKind
+			if kind == "" {
+				kind = gits.KindGitHub
+			}
+			gitProvider, err := o.gitProviderForGitServerURL(gitURL, kind)
+			if err != nil {
+				return model, err
+			}
+			o.Debugf("Searching for repositories in Git server %s owner %s includes %s excludes %s as user %s \n", gitProvider.ServerURL(), location.Owner, strings.Join(location.Includes, ", "), strings.Join(location.Excludes, ", "), gitProvider.CurrentUsername())
+			err = model.LoadGithubQuickstarts(gitProvider, location.Owner, location.Includes, location.Excludes)
+			if err != nil {
+				o.Debugf("Quickstart load error: %s\n", err.Error())
+			}
+		}
+	}
+	return model, nil
+}
@@ -0,0 +1,345 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver"
+	chgit "github.com/jenkins-x/chyle/chyle/git"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/issues"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ReleaseContentDeployed the status of commits and issues in the releases deployed to the environment
+	ReleaseContentDeployed = "Deployed"
+	// ReleaseContentPending the status of commits and issues in the releases waiting to be promoted to the environment
+	ReleaseContentPending = "Pending"
+
+	optionSourceEnv = "source"
+)
+
+// ReleaseContentOptions the flags shared by the commands which show the content of the releases of an application
+// in an environment
+type ReleaseContentOptions struct {
+	Application       string
+	Environment       string
+	SourceEnvironment string
+	All               bool
+}
+
+// ReleaseContent the commits and issues of the releases of an application which are deployed to an environment and
+// which are pending promotion to it
+type ReleaseContent struct {
+	Application     string          `json:"application"`
+	Environment     string          `json:"environment"`
+	DeployedVersion string          `json:"deployedVersion,omitempty"`
+	Source          string          `json:"source,omitempty"`
+	SourceVersion   string          `json:"sourceVersion,omitempty"`
+	Commits         []ReleaseCommit `json:"commits"`
+	Issues          []ReleaseIssue  `json:"issues"`
+}
+
+// ReleaseCommit a commit of a release
+type ReleaseCommit struct {
+	Version string           `json:"version"`
+	Status  string           `json:"status"`
+	Commit  v1.CommitSummary `json:"commit"`
+}
+
+// ReleaseIssue an issue of a release
+type ReleaseIssue struct {
+	Version string          `json:"version"`
+	Status  string          `json:"status"`
+	Issue   v1.IssueSummary `json:"issue"`
+}
+
+// addReleaseContentFlags adds the flags to select the application and environments
+func (o *ReleaseContentOptions) addReleaseContentFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.Application, "app", "a", "", "The application to show. Defaults to the name of the git repository in the current directory")
+	cmd.Flags().StringVarP(&o.Environment, "env", "e", "", "The environment the releases are deployed to or pending promotion to")
+	cmd.Flags().StringVarP(&o.SourceEnvironment, optionSourceEnv, "s", "", "The environment to compare with, such as staging. Defaults to the latest release")
+	cmd.Flags().BoolVarP(&o.All, "all", "", false, "Shows the content of all the deployed releases rather than only the current one")
+}
+
+// releaseContent returns the commits and issues of the application which are deployed to the environment and which
+// are pending promotion to it from the source environment or from the latest release if there is no source
+func (o *CommonOptions) releaseContent(flags *ReleaseContentOptions, dir string) (*ReleaseContent, error) {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return nil, err
+	}
+	app := flags.Application
+	if app == "" {
+		gitInfo, err := o.FindGitInfo(dir)
+		if err != nil || gitInfo == nil {
+			return nil, util.MissingOption("app")
+		}
+		app = gitInfo.Name
+	}
+	if flags.Environment == "" {
+		return nil, util.MissingOption("env")
+	}
+	env, err := jxClient.JenkinsV1().Environments(ns).Get(flags.Environment, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting environment %s", flags.Environment)
+	}
+	deployedVersion, err := o.applicationVersion(env, app)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := []string{ns, env.Spec.Namespace}
+
+	sourceVersion := ""
+	if flags.SourceEnvironment != "" {
+		sourceEnv, err := jxClient.JenkinsV1().Environments(ns).Get(flags.SourceEnvironment, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting environment %s", flags.SourceEnvironment)
+		}
+		sourceVersion, err = o.applicationVersion(sourceEnv, app)
+		if err != nil {
+			return nil, err
+		}
+		if sourceVersion == "" {
+			return nil, fmt.Errorf("application %s is not deployed to environment %s", app, flags.SourceEnvironment)
+		}
+		namespaces = append(namespaces, sourceEnv.Spec.Namespace)
+	}
+
+	releases := []v1.Release{}
+	for _, releaseNs := range namespaces {
+		if releaseNs == "" {
+			continue
+		}
+		list, err := kube.GetOrderedReleases(jxClient, releaseNs, "")
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing the releases in namespace %s", releaseNs)
+		}
+		releases = append(releases, list...)
+	}
+	content := CollectReleaseContent(releases, app, deployedVersion, sourceVersion, flags.All)
+	content.Environment = env.Name
+	content.Source = flags.SourceEnvironment
+	if len(content.Commits) == 0 && deployedVersion != "" && deployedVersion != sourceVersion {
+		err = o.addGitHistory(content, dir)
+		if err != nil {
+			log.Warnf("Failed to find the commits of %s in the git history: %s\n", app, err)
+		}
+	}
+	return content, nil
+}
+
+// applicationVersion returns the version of the application deployed in the environment's namespace or in its
+// git repository if it is not deployed yet. An empty version is returned if the application is not in the environment
+func (o *CommonOptions) applicationVersion(env *v1.Environment, app string) (string, error) {
+	if env.Spec.Namespace != "" {
+		kubeClient, _, err := o.KubeClient()
+		if err != nil {
+			return "", err
+		}
+		deployments, err := kube.GetDeployments(kubeClient, env.Spec.Namespace)
+		if err != nil {
+			return "", errors.Wrapf(err, "getting deployments in namespace %s", env.Spec.Namespace)
+		}
+		for name, d := range deployments {
+			if kube.GetAppName(name, env.Spec.Namespace) == app {
+				version := kube.GetVersion(&d.ObjectMeta)
+				if version != "" {
+					return version, nil
+				}
+			}
+		}
+	}
+	if env.Spec.Source.URL == "" {
+		return "", nil
+	}
+	versions, err := o.getEnvironmentRequirementVersions(env)
+	if err != nil {
+		return "", errors.Wrapf(err, "loading the requirements of environment %s from %s", env.Name, env.Spec.Source.URL)
+	}
+	return versions[app], nil
+}
+
+// addGitHistory adds the commits between the git tags of the deployed and source versions when there are no
+// Release resources for them, such as when the changelog is not generated in the pipeline
+func (o *CommonOptions) addGitHistory(content *ReleaseContent, dir string) error {
+	gitDir, _, err := o.Git().FindGitConfigDir(dir)
+	if err != nil {
+		return err
+	}
+	if gitDir == "" {
+		return nil
+	}
+	tags, err := o.Git().Tags(gitDir)
+	if err != nil {
+		return err
+	}
+	previousTag := versionTag(tags, content.DeployedVersion)
+	if previousTag == "" {
+		return fmt.Errorf("no git tag found for version %s", content.DeployedVersion)
+	}
+	version := content.SourceVersion
+	if version == "" {
+		version = latestTagVersion(tags)
+	}
+	currentTag := versionTag(tags, version)
+	if currentTag == "" {
+		return fmt.Errorf("no git tag found for version %s", version)
+	}
+	if currentTag == previousTag {
+		return nil
+	}
+	commits, err := chgit.FetchCommits(gitDir, previousTag, currentTag)
+	if err != nil {
+		return err
+	}
+	if commits == nil {
+		return nil
+	}
+
+	kind, project := issues.Git, ""
+	it, err := o.issueTrackerConfig(gitDir)
+	if err == nil && it != nil && it.Kind == issues.Jira {
+		kind, project = it.Kind, it.Project
+	}
+	found := map[string]bool{}
+	for _, commit := range *commits {
+		if len(commit.ParentHashes) > 1 {
+			continue
+		}
+		summary := v1.CommitSummary{
+			Message: commit.Message,
+			SHA:     commit.Hash.String(),
+			Author: &v1.UserDetails{
+				Name:  commit.Author.Name,
+				Email: commit.Author.Email,
+			},
+		}
+		for _, key := range issues.ParseIssueKeys(kind, project, commit.Message) {
+			summary.IssueIDs = append(summary.IssueIDs, key)
+			if !found[key] {
+				found[key] = true
+				content.Issues = append(content.Issues, ReleaseIssue{
+					Version: version,
+					Status:  ReleaseContentPending,
+					Issue:   v1.IssueSummary{ID: key},
+				})
+			}
+		}
+		content.Commits = append(content.Commits, ReleaseCommit{
+			Version: version,
+			Status:  ReleaseContentPending,
+			Commit:  summary,
+		})
+	}
+	return nil
+}
+
+// CollectReleaseContent returns the commits and issues of the releases of the application. Releases up to the
+// deployed version are deployed and the newer ones up to the source version, or all the newer ones if there is no
+// source version, are pending. Only the release of the deployed version itself is included unless all is true
+func CollectReleaseContent(releases []v1.Release, app string, deployedVersion string, sourceVersion string, all bool) *ReleaseContent {
+	content := &ReleaseContent{
+		Application:     app,
+		DeployedVersion: deployedVersion,
+		SourceVersion:   sourceVersion,
+		Commits:         []ReleaseCommit{},
+		Issues:          []ReleaseIssue{},
+	}
+	appReleases := []v1.Release{}
+	versions := map[string]bool{}
+	for _, release := range releases {
+		version := release.Spec.Version
+		if release.Spec.Name != app || version == "" || versions[version] {
+			continue
+		}
+		versions[version] = true
+		appReleases = append(appReleases, release)
+	}
+	kube.SortReleases(appReleases)
+
+	issueIndexes := map[string]int{}
+	for _, release := range appReleases {
+		version := release.Spec.Version
+		if sourceVersion != "" && releaseVersionLess(sourceVersion, version) {
+			continue
+		}
+		status := ReleaseContentPending
+		if deployedVersion != "" && !releaseVersionLess(deployedVersion, version) {
+			if !all && version != deployedVersion {
+				continue
+			}
+			status = ReleaseContentDeployed
+		}
+		for _, commit := range release.Spec.Commits {
+			content.Commits = append(content.Commits, ReleaseCommit{
+				Version: version,
+				Status:  status,
+				Commit:  commit,
+			})
+		}
+		for _, issue := range release.Spec.Issues {
+			key := issue.ID
+			if key == "" {
+				key = issue.URL
+			}
+			idx, ok := issueIndexes[key]
+			if !ok {
+				issueIndexes[key] = len(content.Issues)
+				content.Issues = append(content.Issues, ReleaseIssue{
+					Version: version,
+					Status:  status,
+					Issue:   issue,
+				})
+				continue
+			}
+			// an issue referenced by several releases is deployed as soon as one of them is
+			if status == ReleaseContentDeployed && content.Issues[idx].Status != ReleaseContentDeployed {
+				content.Issues[idx].Version = version
+				content.Issues[idx].Status = status
+			}
+		}
+	}
+	return content
+}
+
+// versionTag returns the git tag of the version or an empty string if there is none
+func versionTag(tags []string, version string) string {
+	for _, tag := range []string{"v" + version, version} {
+		if util.StringArrayIndex(tags, tag) >= 0 {
+			return tag
+		}
+	}
+	return ""
+}
+
+// latestTagVersion returns the newest version of the git tags
+func latestTagVersion(tags []string) string {
+	answer := ""
+	for _, tag := range tags {
+		version := strings.TrimPrefix(tag, "v")
+		if _, err := semver.ParseTolerant(version); err != nil {
+			continue
+		}
+		if answer == "" || releaseVersionLess(answer, version) {
+			answer = version
+		}
+	}
+	return answer
+}
+
+// releaseVersionLess returns true if the version a is older than the version b
+func releaseVersionLess(a string, b string) bool {
+	va, errA := semver.ParseTolerant(a)
+	vb, errB := semver.ParseTolerant(b)
+	if errA == nil && errB == nil {
+		return va.LT(vb)
+	}
+	return a < b
+}
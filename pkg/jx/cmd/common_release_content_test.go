@@ -0,0 +1,80 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectReleaseContent(t *testing.T) {
+	t.Parallel()
+
+	releases := []v1.Release{
+		testRelease("myapp", "1.0.1", "a1", "#1"),
+		testRelease("myapp", "1.0.3", "a3", "#3"),
+		testRelease("myapp", "1.0.10", "a10", "#1"),
+		testRelease("myapp", "1.0.2", "a2", "#2"),
+		testRelease("myapp", "1.0.2", "a2", "#2"),
+		testRelease("other", "1.0.4", "b4", "#4"),
+	}
+
+	content := cmd.CollectReleaseContent(releases, "myapp", "1.0.2", "", false)
+	assert.Equal(t, []string{"1.0.10 Pending a10", "1.0.3 Pending a3", "1.0.2 Deployed a2"}, commitRows(content))
+	assert.Equal(t, []string{"#1 1.0.10 Pending", "#3 1.0.3 Pending", "#2 1.0.2 Deployed"}, issueRows(content))
+
+	content = cmd.CollectReleaseContent(releases, "myapp", "1.0.2", "1.0.3", true)
+	assert.Equal(t, []string{"1.0.3 Pending a3", "1.0.2 Deployed a2", "1.0.1 Deployed a1"}, commitRows(content))
+	assert.Equal(t, []string{"#3 1.0.3 Pending", "#2 1.0.2 Deployed", "#1 1.0.1 Deployed"}, issueRows(content))
+
+	content = cmd.CollectReleaseContent(releases, "myapp", "1.0.2", "", true)
+	assert.Equal(t, []string{"#1 1.0.1 Deployed", "#3 1.0.3 Pending", "#2 1.0.2 Deployed"}, issueRows(content))
+
+	content = cmd.CollectReleaseContent(releases, "myapp", "", "1.0.2", false)
+	assert.Equal(t, []string{"1.0.2 Pending a2", "1.0.1 Pending a1"}, commitRows(content))
+
+	content = cmd.CollectReleaseContent(releases, "myapp", "1.0.10", "1.0.10", false)
+	assert.Equal(t, []string{"1.0.10 Deployed a10"}, commitRows(content))
+
+	content = cmd.CollectReleaseContent(releases, "unknown", "1.0.0", "", true)
+	assert.Empty(t, content.Commits)
+	assert.Empty(t, content.Issues)
+}
+
+func testRelease(app string, version string, sha string, issue string) v1.Release {
+	return v1.Release{
+		Spec: v1.ReleaseSpec{
+			Name:    app,
+			Version: version,
+			Commits: []v1.CommitSummary{
+				{
+					SHA:      sha,
+					Message:  "fix " + issue,
+					IssueIDs: []string{issue},
+				},
+			},
+			Issues: []v1.IssueSummary{
+				{
+					ID: issue,
+				},
+			},
+		},
+	}
+}
+
+func commitRows(content *cmd.ReleaseContent) []string {
+	answer := []string{}
+	for _, c := range content.Commits {
+		answer = append(answer, c.Version+" "+c.Status+" "+c.Commit.SHA)
+	}
+	return answer
+}
+
+func issueRows(content *cmd.ReleaseContent) []string {
+	answer := []string{}
+	for _, i := range content.Issues {
+		answer = append(answer, i.Issue.ID+" "+i.Version+" "+i.Status)
+	}
+	return answer
+}
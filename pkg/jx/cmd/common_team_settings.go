@@ -6,6 +6,7 @@ import (
 	"os/user"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/jenkins-x/jx/pkg/builds"
 
@@ -233,6 +234,18 @@ func (o *CommonOptions) registerWorkflowCRD() error {
 	return nil
 }
 
+func (o *CommonOptions) registerImageScanCRD() error {
+	apisClient, err := o.Factory.CreateApiExtensionsClient()
+	if err != nil {
+		return err
+	}
+	err = kube.RegisterImageScanCRD(apisClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to register the ImageScan CRD")
+	}
+	return nil
+}
+
 // ModifyTeam lazily creates the Team CRD if it does not exist or updates it if it requires a change.
 // The Team CRD will be modified in the specified admin namespace.
 func (o *CommonOptions) ModifyTeam(adminNs string, teamName string, callback func(env *v1.Team) error) error {
@@ -356,12 +369,20 @@ func (o *CommonOptions) getUsername(userName string) (string, error) {
 	return userName, nil
 }
 
-func addTeamSettingsCommandsFromTags(baseCmd *cobra.Command, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer, options *EditOptions) error {
-	teamSettings := &v1.TeamSettings{}
-	value := reflect.ValueOf(teamSettings).Elem()
-	t := value.Type()
-	for i := 0; i < value.NumField(); i++ {
-		field := value.Field(i)
+// teamSettingsField a team setting which can be edited by its command name
+type teamSettingsField struct {
+	Command   string
+	Usage     string
+	Values    []string
+	FieldName string
+	Kind      reflect.Kind
+}
+
+// teamSettingsFields returns the team settings which can be edited based on the command tags of the TeamSettings
+func teamSettingsFields() []teamSettingsField {
+	answer := []teamSettingsField{}
+	t := reflect.TypeOf(v1.TeamSettings{})
+	for i := 0; i < t.NumField(); i++ {
 		structField := t.Field(i)
 		tag := structField.Tag
 		command, ok := tag.Lookup("command")
@@ -372,42 +393,131 @@ func addTeamSettingsCommandsFromTags(baseCmd *cobra.Command, in terminal.FileRea
 		if !ok {
 			continue
 		}
+		field := teamSettingsField{
+			Command:   command,
+			Usage:     commandUsage,
+			FieldName: structField.Name,
+			Kind:      structField.Type.Kind(),
+		}
+		if values, ok := tag.Lookup("commandValues"); ok {
+			field.Values = strings.Split(values, ",")
+		}
+		answer = append(answer, field)
+	}
+	return answer
+}
+
+// teamSettingsCommands returns the command names of the team settings which can be edited
+func teamSettingsCommands() []string {
+	answer := []string{}
+	for _, field := range teamSettingsFields() {
+		if field.Kind == reflect.String || field.Kind == reflect.Bool {
+			answer = append(answer, field.Command)
+		}
+	}
+	return answer
+}
+
+// findTeamSettingsField returns the team setting with the given command name or nil if there is none
+func findTeamSettingsField(command string) *teamSettingsField {
+	for _, field := range teamSettingsFields() {
+		if field.Command == command {
+			return &field
+		}
+	}
+	return nil
+}
+
+// parseValue validates the text value of the setting returning it as a string or bool
+func (f *teamSettingsField) parseValue(text string) (interface{}, error) {
+	switch f.Kind {
+	case reflect.Bool:
+		value, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, util.InvalidOptionError(f.Command, text, err)
+		}
+		return value, nil
+	case reflect.String:
+		if len(f.Values) > 0 && util.StringArrayIndex(f.Values, text) < 0 {
+			return nil, util.InvalidOption(f.Command, text, f.Values)
+		}
+		return text, nil
+	default:
+		return nil, fmt.Errorf("setting %s of kind %s cannot be edited", f.Command, f.Kind)
+	}
+}
 
+// getValue returns the current value of the setting
+func (f *teamSettingsField) getValue(teamSettings *v1.TeamSettings) reflect.Value {
+	return reflect.ValueOf(teamSettings).Elem().FieldByName(f.FieldName)
+}
+
+// setValue sets the setting to the string or bool value
+func (f *teamSettingsField) setValue(teamSettings *v1.TeamSettings, value interface{}) {
+	valueField := f.getValue(teamSettings)
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		valueField.SetString(v.String())
+	case reflect.Bool:
+		valueField.SetBool(v.Bool())
+	}
+}
+
+// pickValue asks for the value of the setting defaulting to its current value
+func (f *teamSettingsField) pickValue(teamSettings *v1.TeamSettings, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) (interface{}, error) {
+	current := f.getValue(teamSettings)
+	switch {
+	case f.Kind == reflect.Bool:
+		return util.Confirm(f.Usage+":", current.Bool(), "", in, out, errOut), nil
+	case len(f.Values) > 0:
+		return util.PickNameWithDefault(f.Values, f.Usage+":", current.String(), "", in, out, errOut)
+	default:
+		return util.PickValue(f.Usage+":", current.String(), false, "", in, out, errOut)
+	}
+}
+
+// hasSubCommand returns true if the command already has a sub command with the given name
+func hasSubCommand(cmd *cobra.Command, name string) bool {
+	for _, c := range cmd.Commands() {
+		if c.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func addTeamSettingsCommandsFromTags(baseCmd *cobra.Command, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer, options *EditOptions) error {
+	for _, f := range teamSettingsFields() {
+		field := f
+		if field.Kind != reflect.String && field.Kind != reflect.Bool {
+			continue
+		}
+		if hasSubCommand(baseCmd, field.Command) {
+			// a dedicated command such as 'jx edit imagebuilder' does more than change the setting
+			continue
+		}
 		cmd := &cobra.Command{
-			Use:   command,
-			Short: commandUsage,
+			Use:   field.Command,
+			Short: field.Usage,
 			Run: func(cmd *cobra.Command, args []string) {
 				var value interface{}
 				var err error
 				if len(args) > 0 {
-					if structField.Type.String() == "string" {
-						value = args[0]
-					} else if structField.Type.String() == "bool" {
-						value, err = strconv.ParseBool(args[0])
-						CheckErr(err)
-					}
+					value, err = field.parseValue(args[0])
+					CheckErr(err)
 				} else if !options.BatchMode {
-					var err error
-					if structField.Type.String() == "string" {
-						value, err = util.PickValue(commandUsage+":", field.String(), true, "", in, out, errOut)
-					} else if structField.Type.String() == "bool" {
-						value = util.Confirm(commandUsage+":", field.Bool(), "", in, out, errOut)
-					}
+					teamSettings, err := options.TeamSettings()
+					CheckErr(err)
+					value, err = field.pickValue(teamSettings, in, out, errOut)
 					CheckErr(err)
 				} else {
-					fatal(fmt.Sprintf("No value to set %s", command), 1)
+					fatal(fmt.Sprintf("No value to set %s", field.Command), 1)
 				}
 
 				callback := func(env *v1.Environment) error {
-					teamSettings := &env.Spec.TeamSettings
-					valueField := reflect.ValueOf(teamSettings).Elem().FieldByName(structField.Name)
-					switch value.(type) {
-					case string:
-						valueField.SetString(value.(string))
-					case bool:
-						valueField.SetBool(value.(bool))
-					}
-					log.Infof("Setting the team %s to: %s\n", util.ColorInfo(command), util.ColorInfo(value))
+					field.setValue(&env.Spec.TeamSettings, value)
+					log.Infof("Setting the team %s to: %s\n", util.ColorInfo(field.Command), util.ColorInfo(value))
 					return nil
 				}
 				CheckErr(options.ModifyDevEnvironment(callback))
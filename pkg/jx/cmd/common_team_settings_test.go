@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamSettingsFields(t *testing.T) {
+	t.Parallel()
+
+	commands := teamSettingsCommands()
+	assert.Contains(t, commands, "gitserver")
+	assert.Contains(t, commands, "promotionengine")
+	assert.Contains(t, commands, "promotionstrategy")
+	assert.Contains(t, commands, "imagebuilder")
+	assert.Contains(t, commands, "pipelineengine")
+
+	settings := &v1.TeamSettings{}
+
+	field := findTeamSettingsField("organisation")
+	require.NotNil(t, field)
+	value, err := field.parseValue("myorg")
+	require.NoError(t, err)
+	field.setValue(settings, value)
+	assert.Equal(t, "myorg", settings.Organisation)
+
+	field = findTeamSettingsField("gitprivate")
+	require.NotNil(t, field)
+	_, err = field.parseValue("maybe")
+	assert.Error(t, err, "gitprivate should only accept booleans")
+	value, err = field.parseValue("true")
+	require.NoError(t, err)
+	field.setValue(settings, value)
+	assert.True(t, settings.GitPrivate)
+
+	field = findTeamSettingsField("promotionengine")
+	require.NotNil(t, field)
+	_, err = field.parseValue("Spinnaker")
+	assert.Error(t, err, "promotionengine should only accept its values")
+	value, err = field.parseValue("Prow")
+	require.NoError(t, err)
+	field.setValue(settings, value)
+	assert.Equal(t, v1.PromotionEngineProw, settings.PromotionEngine)
+
+	field = findTeamSettingsField("pipelineengine")
+	require.NotNil(t, field)
+	value, err = field.parseValue("tekton")
+	require.NoError(t, err)
+	field.setValue(settings, value)
+	assert.Equal(t, v1.PipelineEngineTekton, settings.PipelineEngine)
+
+	assert.Nil(t, findTeamSettingsField("cheese"))
+}
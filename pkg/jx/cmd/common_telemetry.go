@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/jenkins-x/jx/pkg/telemetry"
+	"github.com/spf13/cobra"
+)
+
+// addTelemetry records the anonymous usage metrics of the commands if the user opted in via jx edit config
+func addTelemetry(cmd *cobra.Command) {
+	preRun := cmd.PersistentPreRun
+	cmd.PersistentPreRun = func(c *cobra.Command, args []string) {
+		telemetry.Start(c.CommandPath(), commandProvider(c))
+		if preRun != nil {
+			preRun(c, args)
+		}
+	}
+	cmd.PersistentPostRun = func(c *cobra.Command, args []string) {
+		telemetry.Finish(true)
+	}
+}
+
+// commandProvider returns the kubernetes provider of the command from its provider flag or, for commands such as
+// jx create cluster gke, from its name
+func commandProvider(cmd *cobra.Command) string {
+	if flag := cmd.Flags().Lookup("provider"); flag != nil {
+		return flag.Value.String()
+	}
+	if cmd.HasParent() && cmd.Parent().Name() == "cluster" {
+		return cmd.Name()
+	}
+	return ""
+}
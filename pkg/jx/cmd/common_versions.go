@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/blang/semver"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/versionstream"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// addVersionStreamFlags adds the flags used to pin the version stream
+func (o *CommonOptions) addVersionStreamFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.VersionStreamRef, "version-stream-ref", "", "", "The git ref (branch, tag or sha) of the version stream used to resolve the versions of the tools and charts to install. Defaults to master")
+}
+
+// versionStreamDir returns the directory the version stream repository is cloned into
+func versionStreamDir() (string, error) {
+	configDir, err := util.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "jenkins-x-versions"), nil
+}
+
+// versionStream returns the tested versions of the tools and charts. The local clone of the version stream
+// repository is used, cloning it the first time, falling back to the latest releases if that fails. The local
+// clone is only ever reset to the version stream so 'jx upgrade versions' submits its changes as a Pull Request
+func (o *CommonOptions) versionStream() *versionstream.Versions {
+	if o.versions != nil {
+		return o.versions
+	}
+	o.versions = versionstream.DefaultVersions()
+	dir, err := o.cloneVersionStream(o.VersionStreamRef != "")
+	if err != nil {
+		log.Warnf("Failed to clone the version stream %s so using the latest releases: %s\n", versionstream.DefaultVersionsURL, err)
+		return o.versions
+	}
+	versions, err := versionstream.LoadVersions(dir)
+	if err != nil {
+		log.Warnf("Failed to load the version stream so using the latest releases: %s\n", err)
+		return o.versions
+	}
+	o.versions = versions
+	return o.versions
+}
+
+// cloneVersionStream clones the version stream repository at the VersionStreamRef if there is no local clone yet.
+// An existing clone is only updated to the latest commit of the ref if update is true
+func (o *CommonOptions) cloneVersionStream(update bool) (string, error) {
+	dir, err := versionStreamDir()
+	if err != nil {
+		return "", err
+	}
+	err = os.MkdirAll(dir, DefaultWritePermissions)
+	if err != nil {
+		return dir, errors.Wrapf(err, "creating directory %s", dir)
+	}
+	empty, err := util.IsEmpty(dir)
+	if err != nil {
+		return dir, err
+	}
+	ref := o.VersionStreamRef
+	if ref == "" {
+		ref = "master"
+	}
+	if empty {
+		err = o.Git().Clone(versionstream.DefaultVersionsURL, dir)
+		if err != nil {
+			return dir, err
+		}
+		if ref == "master" {
+			return dir, nil
+		}
+	} else if !update {
+		return dir, nil
+	}
+	// lets reset the current branch rather than checking out the ref so that tags and shas don't leave a
+	// detached HEAD behind
+	err = o.Git().FetchTags(dir)
+	if err != nil {
+		return dir, err
+	}
+	err = o.Git().FetchBranch(dir, "origin", ref)
+	if err != nil {
+		return dir, err
+	}
+	return dir, o.Git().ResetHard(dir, "FETCH_HEAD")
+}
+
+// resolveToolVersion returns the version of the tool from the version stream or the latest release if it is not pinned
+func (o *CommonOptions) resolveToolVersion(name string, latestFn func() (semver.Version, error)) (string, error) {
+	version := o.versionStream().ToolVersion(name)
+	if version != "" {
+		o.Debugf("Using %s version %s from the version stream\n", name, version)
+		return version, nil
+	}
+	latest, err := latestFn()
+	if err != nil {
+		return "", err
+	}
+	return latest.String(), nil
+}
+
+// resolveChartVersion returns the version of the chart from the version stream or an empty string to use the latest
+func (o *CommonOptions) resolveChartVersion(chart string) string {
+	version := o.versionStream().ChartVersion(chart)
+	if version != "" {
+		o.Debugf("Using chart %s version %s from the version stream\n", chart, version)
+	}
+	return version
+}
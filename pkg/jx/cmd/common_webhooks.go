@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// WebHookStatusOK the repository has a webhook for the current endpoint
+	WebHookStatusOK = "OK"
+	// WebHookStatusStale the webhook points at an old endpoint such as after the domain of the cluster changed
+	WebHookStatusStale = "Stale"
+	// WebHookStatusMissing the repository has no webhook for the current endpoint
+	WebHookStatusMissing = "Missing"
+	// WebHookStatusNoSecret the webhook points at the current endpoint but has no secret which Prow requires
+	WebHookStatusNoSecret = "No Secret"
+)
+
+// WebHookStatus the status of a webhook of a repository
+type WebHookStatus struct {
+	ID         int64  `json:"id,omitempty"`
+	Owner      string `json:"owner"`
+	Repository string `json:"repository"`
+	URL        string `json:"url,omitempty"`
+	Status     string `json:"status"`
+}
+
+// WebHookMatcher finds the webhooks of the team on a repository and checks them against the current endpoint
+type WebHookMatcher struct {
+	// Endpoint the URL the webhooks should be sent to
+	Endpoint string
+	// PreviousURL the URL of the old endpoint the webhooks used to be sent to
+	PreviousURL string
+	// ExactMatch only hooks with the endpoint or previous URL are considered to belong to the team.
+	// Otherwise any hook sent to a Prow or Jenkins endpoint of Jenkins X is also assumed to be a stale hook
+	ExactMatch bool
+	// RequireSecret if the webhooks must have a secret such as when using Prow
+	RequireSecret bool
+	// SecretsListed if the git provider returns whether a hook has a secret when listing the hooks
+	SecretsListed bool
+}
+
+// CheckWebHooks returns the status of each of the webhooks of the team on the repository or a single
+// missing status if the repository has none
+func (m *WebHookMatcher) CheckWebHooks(owner string, repo string, hooks []*gits.GitWebHookArguments) []WebHookStatus {
+	answer := []WebHookStatus{}
+	found := false
+	for _, hook := range hooks {
+		status := ""
+		if hook.URL == m.Endpoint {
+			found = true
+			status = WebHookStatusOK
+			if m.RequireSecret && m.SecretsListed && hook.Secret == "" {
+				status = WebHookStatusNoSecret
+			}
+		} else if m.isStale(hook.URL) {
+			found = true
+			status = WebHookStatusStale
+		}
+		if status != "" {
+			answer = append(answer, WebHookStatus{
+				ID:         hook.ID,
+				Owner:      owner,
+				Repository: repo,
+				URL:        hook.URL,
+				Status:     status,
+			})
+		}
+	}
+	if !found {
+		answer = append(answer, WebHookStatus{
+			Owner:      owner,
+			Repository: repo,
+			Status:     WebHookStatusMissing,
+		})
+	}
+	return answer
+}
+
+// isStale returns true if the hook URL is for an old endpoint of the team. Unless a previous URL is given only hooks
+// sent to a known Jenkins X endpoint are considered so that the webhooks of other tools are left alone. With an exact
+// match the hook must also be for the same service and path as the endpoint but on a different domain
+func (m *WebHookMatcher) isStale(hookURL string) bool {
+	if m.PreviousURL != "" {
+		return hookURL == m.PreviousURL
+	}
+	service1, path1, ok := webHookService(hookURL)
+	if !ok || jxWebHookPaths[service1] != path1 {
+		return false
+	}
+	if !m.ExactMatch {
+		return true
+	}
+	service2, path2, ok := webHookService(m.Endpoint)
+	return ok && service1 == service2 && path1 == path2
+}
+
+// jxWebHookPaths the paths of the webhook endpoints of Jenkins X indexed by the name of the exposed service
+var jxWebHookPaths = map[string]string{
+	"hook":    "/hook",
+	"jenkins": "/github-webhook",
+}
+
+// webHookService returns the name of the exposed service, which is the first label of the host name, and the path
+// of a webhook URL
+func webHookService(hookURL string) (string, string, bool) {
+	u, err := url.Parse(hookURL)
+	if err != nil || u.Hostname() == "" {
+		return "", "", false
+	}
+	return strings.SplitN(u.Hostname(), ".", 2)[0], strings.TrimSuffix(u.Path, "/"), true
+}
+
+// webHookSecret returns the HMAC token Prow uses to validate the webhooks or an empty string if not using Prow
+func (o *CommonOptions) webHookSecret() (string, error) {
+	isProw, err := o.isProw()
+	if err != nil {
+		return "", err
+	}
+	if !isProw {
+		return "", nil
+	}
+	ns, _, err := kube.GetDevNamespace(o.KubeClientCached, o.currentNamespace)
+	if err != nil {
+		return "", err
+	}
+	secret, err := o.KubeClientCached.CoreV1().Secrets(ns).Get("hmac-token", metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find the Prow hmac-token Secret in namespace %s", ns)
+	}
+	return string(secret.Data["hmac"]), nil
+}
+
+// webHookRepositories returns the names of the repositories of the organisation or the given repository
+func webHookRepositories(git gits.GitProvider, org string, repo string) ([]string, error) {
+	if repo != "" {
+		return []string{repo}, nil
+	}
+	repositories, err := git.ListRepositories(org)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to list the repositories of %s", org)
+	}
+	answer := []string{}
+	for _, r := range repositories {
+		answer = append(answer, r.Name)
+	}
+	return answer, nil
+}
+
+// teamRepositories returns the names of the repositories of the organisation which have been imported into the team,
+// which are the ones with pipelines in the team
+func teamRepositories(jxClient versioned.Interface, ns string, org string) (map[string]bool, error) {
+	activities, err := jxClient.JenkinsV1().PipelineActivities(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the PipelineActivities in namespace %s", ns)
+	}
+	answer := map[string]bool{}
+	for _, a := range activities.Items {
+		if strings.EqualFold(a.Spec.GitOwner, org) && a.Spec.GitRepository != "" {
+			answer[strings.ToLower(a.Spec.GitRepository)] = true
+		}
+	}
+	return answer, nil
+}
@@ -0,0 +1,66 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckWebHooks(t *testing.T) {
+	t.Parallel()
+
+	matcher := &cmd.WebHookMatcher{
+		Endpoint:      "http://hook.jx.1.2.3.4.nip.io/hook",
+		ExactMatch:    true,
+		RequireSecret: true,
+		SecretsListed: true,
+	}
+
+	statuses := matcher.CheckWebHooks("myorg", "myrepo", []*gits.GitWebHookArguments{
+		{ID: 1, URL: "http://hook.jx.1.2.3.4.nip.io/hook", Secret: "********"},
+		{ID: 2, URL: "https://ci.example.com/webhook"},
+	})
+	assert.Equal(t, []cmd.WebHookStatus{
+		{ID: 1, Owner: "myorg", Repository: "myrepo", URL: "http://hook.jx.1.2.3.4.nip.io/hook", Status: cmd.WebHookStatusOK},
+	}, statuses)
+
+	statuses = matcher.CheckWebHooks("myorg", "myrepo", []*gits.GitWebHookArguments{
+		{ID: 3, URL: "http://hook.jx.5.6.7.8.nip.io/hook"},
+	})
+	assert.Equal(t, []cmd.WebHookStatus{
+		{ID: 3, Owner: "myorg", Repository: "myrepo", URL: "http://hook.jx.5.6.7.8.nip.io/hook", Status: cmd.WebHookStatusStale},
+	}, statuses)
+
+	statuses = matcher.CheckWebHooks("myorg", "myrepo", []*gits.GitWebHookArguments{
+		{ID: 4, URL: "http://hook.jx.1.2.3.4.nip.io/hook"},
+	})
+	assert.Equal(t, cmd.WebHookStatusNoSecret, statuses[0].Status)
+
+	statuses = matcher.CheckWebHooks("myorg", "myrepo", []*gits.GitWebHookArguments{
+		{ID: 5, URL: "https://ci.example.com/webhook"},
+	})
+	assert.Equal(t, []cmd.WebHookStatus{
+		{Owner: "myorg", Repository: "myrepo", Status: cmd.WebHookStatusMissing},
+	}, statuses)
+
+	matcher.ExactMatch = false
+	statuses = matcher.CheckWebHooks("myorg", "myrepo", []*gits.GitWebHookArguments{
+		{ID: 8, URL: "http://jenkins.jx.5.6.7.8.nip.io/github-webhook/"},
+		{ID: 9, URL: "https://hook.jx.example.com/events"},
+		{ID: 10, URL: "https://ci.example.com/hook"},
+	})
+	assert.Equal(t, []cmd.WebHookStatus{
+		{ID: 8, Owner: "myorg", Repository: "myrepo", URL: "http://jenkins.jx.5.6.7.8.nip.io/github-webhook/", Status: cmd.WebHookStatusStale},
+	}, statuses, "only the hooks sent to a Jenkins X endpoint should be stale")
+
+	matcher.PreviousURL = "https://old.example.com/hook"
+	statuses = matcher.CheckWebHooks("myorg", "myrepo", []*gits.GitWebHookArguments{
+		{ID: 6, URL: "https://old.example.com/hook"},
+		{ID: 7, URL: "http://hook.jx.5.6.7.8.nip.io/hook"},
+	})
+	assert.Equal(t, []cmd.WebHookStatus{
+		{ID: 6, Owner: "myorg", Repository: "myrepo", URL: "https://old.example.com/hook", Status: cmd.WebHookStatusStale},
+	}, statuses)
+}
@@ -14,7 +14,7 @@ const boilerPlate = ""
 
 var (
 	completion_long = templates.LongDesc(`
-		Output shell completion code for the given shell (bash or zsh).
+		Output shell completion code for the given shell (bash, zsh or fish).
 
 		This command prints shell code which must be evaluation to provide interactive
 		completion of jx commands.
@@ -38,6 +38,13 @@ var (
 
 		    $ source <(jx completion zsh)
 
+		If you use fish, the following will load jx fish completion:
+
+		    $ jx completion fish | source
+
+		The completion also completes the names of the environments, apps, clusters and contexts
+		of the current team and Kubernetes configuration for the arguments and flags which use them.
+
 		[1] zsh completions are only supported in versions of zsh >= 5.2`)
 )
 
@@ -45,6 +52,7 @@ var (
 	completion_shells = map[string]func(out io.Writer, cmd *cobra.Command) error{
 		"bash": runCompletionBash,
 		"zsh":  runCompletionZsh,
+		"fish": runCompletionFish,
 	}
 )
 
@@ -63,7 +71,7 @@ func NewCmdCompletion(f Factory, in terminal.FileReader, out terminal.FileWriter
 
 	cmd := &cobra.Command{
 		Use:   "completion SHELL",
-		Short: "Output shell completion code for the given shell (bash or zsh)",
+		Short: "Output shell completion code for the given shell (bash, zsh or fish)",
 		Long:  completion_long,
 		Run: func(cmd *cobra.Command, args []string) {
 			options.Cmd = cmd
@@ -74,6 +82,8 @@ func NewCmdCompletion(f Factory, in terminal.FileReader, out terminal.FileWriter
 		ValidArgs: shells,
 	}
 
+	cmd.AddCommand(NewCmdCompletionValues(f, in, out, errOut))
+
 	return cmd
 }
 
@@ -112,7 +122,9 @@ func (o *CommonOptions) Run() error {
 		return UsageError(cmd, "Unsupported shell type %q.", args[0])
 	}
 
-	return run(o.Out, cmd.Parent())
+	root := cmd.Parent()
+	addCompletionFlagAnnotations(root)
+	return run(o.Out, root)
 }
 
 func runCompletionBash(out io.Writer, cmd *cobra.Command) error {
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const fishCompletionHead = `# fish completion for jx
+
+function __fish_jx_using_command
+    set -l words
+    for word in (commandline -opc)
+        switch $word
+            case '-*'
+            case '*'
+                set words $words $word
+        end
+    end
+    test "$words" = "$argv"
+end
+
+function __fish_jx_values
+    jx completion values $argv 2>/dev/null
+end
+
+`
+
+func runCompletionFish(out io.Writer, cmd *cobra.Command) error {
+	buf := new(bytes.Buffer)
+	buf.WriteString(fishCompletionHead)
+	writeFishCompletions(buf, cmd, []string{})
+	_, err := out.Write(buf.Bytes())
+	return err
+}
+
+// writeFishCompletions writes the completions of the sub commands, arguments and flags of the command and all of
+// its sub commands
+func writeFishCompletions(buf *bytes.Buffer, cmd *cobra.Command, path []string) {
+	path = append(path, cmd.Name())
+	condition := "__fish_jx_using_command " + strings.Join(path, " ")
+
+	for _, c := range cmd.Commands() {
+		if !c.IsAvailableCommand() {
+			continue
+		}
+		fmt.Fprintf(buf, "complete -c jx -f -n '%s' -a %s -d %s\n", condition, c.Name(), fishQuote(c.Short))
+	}
+	kind := completionArgKinds[strings.Join(path, "_")]
+	if kind != "" {
+		fmt.Fprintf(buf, "complete -c jx -f -n '%s' -a '(__fish_jx_values %s)'\n", condition, kind)
+	} else if len(cmd.ValidArgs) > 0 {
+		fmt.Fprintf(buf, "complete -c jx -f -n '%s' -a %s\n", condition, fishQuote(strings.Join(cmd.ValidArgs, " ")))
+	}
+
+	seen := map[string]bool{}
+	writeFlag := func(flag *pflag.Flag) {
+		if flag.Hidden || seen[flag.Name] {
+			return
+		}
+		seen[flag.Name] = true
+		fmt.Fprintf(buf, "complete -c jx -n '%s' -l %s", condition, flag.Name)
+		if flag.Shorthand != "" {
+			fmt.Fprintf(buf, " -s %s", flag.Shorthand)
+		}
+		if flag.Value.Type() != "bool" {
+			buf.WriteString(" -r")
+		}
+		if kind := completionFlagKinds[flag.Name]; kind != "" {
+			fmt.Fprintf(buf, " -f -a '(__fish_jx_values %s)'", kind)
+		}
+		fmt.Fprintf(buf, " -d %s\n", fishQuote(flag.Usage))
+	}
+	cmd.NonInheritedFlags().VisitAll(writeFlag)
+	cmd.InheritedFlags().VisitAll(writeFlag)
+
+	for _, c := range cmd.Commands() {
+		if c.IsAvailableCommand() {
+			writeFishCompletions(buf, c, path)
+		}
+	}
+}
+
+// fishQuote returns the text as a single quoted fish string
+func fishQuote(text string) string {
+	text = strings.Replace(text, `\`, `\\`, -1)
+	text = strings.Replace(text, `'`, `\'`, -1)
+	return "'" + text + "'"
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBashCompletionFunction(t *testing.T) {
+	t.Parallel()
+	text := bashCompletionFunction()
+	assert.Contains(t, text, "__jx_get_environments()")
+	assert.Contains(t, text, "jx completion values \"$1\"")
+	assert.Contains(t, text, "jx_delete_environment | jx_environment)\n            __jx_get_environments\n")
+}
+
+func TestFishCompletion(t *testing.T) {
+	t.Parallel()
+	var env string
+	var verbose bool
+	root := &cobra.Command{Use: "jx"}
+	root.PersistentFlags().BoolVarP(&verbose, "verbose", "", false, "Enables verbose output")
+	environment := &cobra.Command{Use: "environment", Short: "View or change the current environment", Run: func(*cobra.Command, []string) {}}
+	environment.Flags().StringVarP(&env, "env", "e", "", "The environment's name")
+	root.AddCommand(environment)
+
+	buf := new(bytes.Buffer)
+	err := runCompletionFish(buf, root)
+	assert.NoError(t, err)
+	text := buf.String()
+	assert.Contains(t, text, "complete -c jx -f -n '__fish_jx_using_command jx' -a environment -d 'View or change the current environment'\n")
+	assert.Contains(t, text, "complete -c jx -f -n '__fish_jx_using_command jx environment' -a '(__fish_jx_values environments)'\n")
+	assert.Contains(t, text, "complete -c jx -n '__fish_jx_using_command jx environment' -l env -s e -r -f -a '(__fish_jx_values environments)' -d 'The environment\\'s name'\n")
+	assert.Contains(t, text, "complete -c jx -n '__fish_jx_using_command jx environment' -l verbose -d 'Enables verbose output'\n")
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+const (
+	completionApps         = "apps"
+	completionClusters     = "clusters"
+	completionContexts     = "contexts"
+	completionEnvironments = "environments"
+)
+
+var (
+	completionValueKinds = []string{completionApps, completionClusters, completionContexts, completionEnvironments}
+
+	// completionArgKinds the kind of values completed for the arguments of the commands
+	completionArgKinds = map[string]string{
+		"jx_context":            completionContexts,
+		"jx_delete_application": completionApps,
+		"jx_delete_contexts":    completionContexts,
+		"jx_delete_environment": completionEnvironments,
+		"jx_environment":        completionEnvironments,
+		"jx_forward":            completionApps,
+		"jx_logs":               completionApps,
+		"jx_promote":            completionApps,
+		"jx_rsh":                completionApps,
+	}
+
+	// completionFlagKinds the kind of values completed for the flags of any command
+	completionFlagKinds = map[string]string{
+		"app":          completionApps,
+		"application":  completionApps,
+		"cluster":      completionClusters,
+		"cluster-name": completionClusters,
+		"context":      completionContexts,
+		"env":          completionEnvironments,
+		"environment":  completionEnvironments,
+	}
+)
+
+// CompletionValuesOptions the options for the hidden command used by the shell completion scripts
+type CompletionValuesOptions struct {
+	CommonOptions
+}
+
+// NewCmdCompletionValues creates the hidden command which prints the dynamic values, such as the names of the
+// environments or apps, completed by the shell completion scripts
+func NewCmdCompletionValues(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &CompletionValuesOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			In:      in,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:       "values KIND",
+		Short:     "Prints the values of the given kind used by the shell completion scripts",
+		Hidden:    true,
+		ValidArgs: completionValueKinds,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	return cmd
+}
+
+// Run prints the values one per line
+func (o *CompletionValuesOptions) Run() error {
+	if len(o.Args) != 1 {
+		return util.MissingArgument("kind")
+	}
+	kind := o.Args[0]
+	var values []string
+	var err error
+	switch kind {
+	case completionApps:
+		values, err = o.appNames()
+	case completionClusters, completionContexts:
+		values, err = o.kubeConfigNames(kind)
+	case completionEnvironments:
+		values, err = o.environmentNames()
+	default:
+		return util.InvalidArg(kind, completionValueKinds)
+	}
+	if err != nil {
+		return err
+	}
+	for _, value := range values {
+		fmt.Fprintln(o.Out, value)
+	}
+	return nil
+}
+
+// kubeConfigNames returns the names of the clusters or contexts of the kube config
+func (o *CompletionValuesOptions) kubeConfigNames(kind string) ([]string, error) {
+	config, _, err := o.Kube().LoadConfig()
+	if err != nil || config == nil {
+		return nil, err
+	}
+	names := []string{}
+	if kind == completionClusters {
+		for name := range config.Clusters {
+			names = append(names, name)
+		}
+	} else {
+		for name := range config.Contexts {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// environmentNames returns the names of the environments of the team
+func (o *CompletionValuesOptions) environmentNames() ([]string, error) {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return nil, err
+	}
+	return kube.GetEnvironmentNames(jxClient, ns)
+}
+
+// appNames returns the names of the apps deployed to the environments of the team
+func (o *CompletionValuesOptions) appNames() ([]string, error) {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return nil, err
+	}
+	envMap, envNames, err := kube.GetOrderedEnvironments(jxClient, ns)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, envName := range envNames {
+		env := envMap[envName]
+		if env.Spec.Kind == v1.EnvironmentKindTypeDevelopment || env.Spec.Namespace == "" {
+			continue
+		}
+		deployments, err := kube.GetDeployments(kubeClient, env.Spec.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range deployments {
+			name := kube.GetName(&d.ObjectMeta)
+			if util.StringArrayIndex(names, name) < 0 {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// completionValuesFunc returns the name of the bash function which completes the values of the given kind
+func completionValuesFunc(kind string) string {
+	return "__jx_get_" + kind
+}
+
+// bashCompletionFunction returns the bash functions which complete the dynamic values of the arguments and flags
+// of the commands by invoking 'jx completion values'
+func bashCompletionFunction() string {
+	var buf bytes.Buffer
+	buf.WriteString(`__jx_get_values()
+{
+    local jx_out
+    if jx_out=$(jx completion values "$1" 2>/dev/null); then
+        COMPREPLY=( $( compgen -W "${jx_out[*]}" -- "$cur" ) )
+    fi
+}
+`)
+	commands := map[string][]string{}
+	for command, kind := range completionArgKinds {
+		commands[kind] = append(commands[kind], command)
+	}
+	for _, kind := range completionValueKinds {
+		fmt.Fprintf(&buf, "\n%s()\n{\n    __jx_get_values %s\n}\n", completionValuesFunc(kind), kind)
+	}
+	buf.WriteString("\n__custom_func() {\n    case ${last_command} in\n")
+	for _, kind := range completionValueKinds {
+		names := commands[kind]
+		if len(names) == 0 {
+			continue
+		}
+		sort.Strings(names)
+		fmt.Fprintf(&buf, "        %s)\n            %s\n            return\n            ;;\n", strings.Join(names, " | "), completionValuesFunc(kind))
+	}
+	buf.WriteString("        *)\n            ;;\n    esac\n}\n")
+	return buf.String()
+}
+
+// addCompletionFlagAnnotations annotates the flags of the command and its sub commands which complete dynamic values
+// so that the generated bash completion invokes the matching function
+func addCompletionFlagAnnotations(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.VisitAll(func(flag *pflag.Flag) {
+		kind := completionFlagKinds[flag.Name]
+		if kind != "" {
+			flags.SetAnnotation(flag.Name, cobra.BashCompCustom, []string{completionValuesFunc(kind)})
+		}
+	})
+	for _, c := range cmd.Commands() {
+		addCompletionFlagAnnotations(c)
+	}
+}
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// cisNamespace the namespace in which the CIS Kubernetes benchmark runs
+	cisNamespace = "jx-compliance-cis"
+	// cisJobName the name of the job which runs the CIS Kubernetes benchmark
+	cisJobName = "jx-kube-bench"
+	// cisContainerName the name of the kube-bench container
+	cisContainerName = "kube-bench"
+	// kubeBenchImage is the docker image which checks a node against the CIS Kubernetes benchmark. It is pinned as the
+	// JSON format of the results is parsed
+	kubeBenchImage = "aquasec/kube-bench:0.0.27"
+	// cisStateFail the state of a CIS benchmark check which failed
+	cisStateFail = "FAIL"
+)
+
+// cisControls the JSON report of kube-bench for one target of the CIS Kubernetes benchmark
+type cisControls struct {
+	ID        string     `json:"id"`
+	Version   string     `json:"version"`
+	Text      string     `json:"text"`
+	NodeType  string     `json:"node_type"`
+	Groups    []cisGroup `json:"tests"`
+	TotalPass int        `json:"total_pass"`
+	TotalFail int        `json:"total_fail"`
+	TotalWarn int        `json:"total_warn"`
+}
+
+// cisGroup a section of the CIS Kubernetes benchmark
+type cisGroup struct {
+	Section string     `json:"section"`
+	Desc    string     `json:"desc"`
+	Pass    int        `json:"pass"`
+	Fail    int        `json:"fail"`
+	Warn    int        `json:"warn"`
+	Checks  []cisCheck `json:"results"`
+}
+
+// cisCheck the result of a single check of the CIS Kubernetes benchmark
+type cisCheck struct {
+	ID          string `json:"test_number"`
+	Text        string `json:"test_desc"`
+	Remediation string `json:"remediation"`
+	State       string `json:"status"`
+	Scored      bool   `json:"scored"`
+}
+
+// parseCISResults parses the stream of JSON reports written by kube-bench
+func parseCISResults(data []byte) ([]cisControls, error) {
+	answer := []cisControls{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		controls := cisControls{}
+		err := decoder.Decode(&controls)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return answer, errors.Wrap(err, "failed to parse the results of the CIS benchmark")
+		}
+		answer = append(answer, controls)
+	}
+	return answer, nil
+}
+
+// failedChecks returns the scored checks which did not pass
+func (c *cisControls) failedChecks() []cisCheck {
+	answer := []cisCheck{}
+	for _, group := range c.Groups {
+		for _, check := range group.Checks {
+			if check.Scored && check.State == cisStateFail {
+				answer = append(answer, check)
+			}
+		}
+	}
+	return answer
+}
+
+// startCISBenchmark starts a job which checks a node of the cluster against the CIS Kubernetes benchmark
+func (o *CommonOptions) startCISBenchmark() error {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return errors.Wrap(err, "creating kube client")
+	}
+	err = kube.EnsureNamespaceCreated(kubeClient, cisNamespace, nil, nil)
+	if err != nil {
+		return errors.Wrapf(err, "creating namespace '%s'", cisNamespace)
+	}
+	job, err := o.getCISBenchmarkJob()
+	if err != nil {
+		return err
+	}
+	if job != nil {
+		return fmt.Errorf("the CIS benchmark has already been run. Use 'jx compliance delete' to remove its results first")
+	}
+	_, err = kubeClient.BatchV1().Jobs(cisNamespace).Create(cisBenchmarkJob())
+	if err != nil {
+		return errors.Wrap(err, "failed to start the CIS benchmark")
+	}
+	return nil
+}
+
+// getCISBenchmarkJob returns the job of the CIS benchmark or nil if it has not been started
+func (o *CommonOptions) getCISBenchmarkJob() (*batchv1.Job, error) {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating kube client")
+	}
+	job, err := kubeClient.BatchV1().Jobs(cisNamespace).Get(cisJobName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get the CIS benchmark job")
+	}
+	return job, nil
+}
+
+// retrieveCISResults returns the raw JSON results of the CIS benchmark from the logs of its pod
+func (o *CommonOptions) retrieveCISResults() ([]byte, error) {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating kube client")
+	}
+	pods, err := kubeClient.CoreV1().Pods(cisNamespace).List(metav1.ListOptions{LabelSelector: "job-name=" + cisJobName})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing the CIS benchmark pods")
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != v1.PodSucceeded {
+			continue
+		}
+		req := kubeClient.CoreV1().Pods(cisNamespace).GetLogs(pod.Name, &v1.PodLogOptions{Container: cisContainerName})
+		readCloser, err := req.Stream()
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading the logs of pod '%s'", pod.Name)
+		}
+		defer readCloser.Close()
+		return ioutil.ReadAll(readCloser)
+	}
+	return nil, fmt.Errorf("no completed pod found for the CIS benchmark job '%s'", cisJobName)
+}
+
+// deleteCISBenchmark removes the CIS benchmark job and its namespace
+func (o *CommonOptions) deleteCISBenchmark() error {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return errors.Wrap(err, "creating kube client")
+	}
+	err = kubeClient.CoreV1().Namespaces().Delete(cisNamespace, &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "deleting namespace '%s'", cisNamespace)
+	}
+	return nil
+}
+
+// complianceTestsStarted returns true if the namespace of the conformance tests exists
+func (o *CommonOptions) complianceTestsStarted() (bool, error) {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return false, errors.Wrap(err, "creating kube client")
+	}
+	_, err = kubeClient.CoreV1().Namespaces().Get(complianceNamespace, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "getting namespace '%s'", complianceNamespace)
+	}
+	return true, nil
+}
+
+// cisBenchmarkJob returns the job which runs kube-bench against the node on which it is scheduled. The job needs
+// access to the host processes and configuration of the node
+func cisBenchmarkJob() *batchv1.Job {
+	backoffLimit := int32(0)
+	hostPathVolume := func(name string, path string) v1.Volume {
+		return v1.Volume{
+			Name: name,
+			VolumeSource: v1.VolumeSource{
+				HostPath: &v1.HostPathVolumeSource{
+					Path: path,
+				},
+			},
+		}
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cisJobName,
+			Namespace: cisNamespace,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					HostPID:       true,
+					RestartPolicy: v1.RestartPolicyNever,
+					Containers: []v1.Container{
+						{
+							Name:            cisContainerName,
+							Image:           kubeBenchImage,
+							ImagePullPolicy: v1.PullAlways,
+							Command:         []string{"kube-bench", "node", "--json"},
+							VolumeMounts: []v1.VolumeMount{
+								{
+									Name:      "var-lib-kubelet",
+									MountPath: "/var/lib/kubelet",
+									ReadOnly:  true,
+								},
+								{
+									Name:      "etc-systemd",
+									MountPath: "/etc/systemd",
+									ReadOnly:  true,
+								},
+								{
+									Name:      "etc-kubernetes",
+									MountPath: "/etc/kubernetes",
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+					Volumes: []v1.Volume{
+						hostPathVolume("var-lib-kubelet", "/var/lib/kubelet"),
+						hostPathVolume("etc-systemd", "/etc/systemd"),
+						hostPathVolume("etc-kubernetes", "/etc/kubernetes"),
+					},
+				},
+			},
+		},
+	}
+}
+
+// cisStatus returns a human readable status of the CIS benchmark job
+func cisStatus(job *batchv1.Job) string {
+	switch {
+	case kube.IsJobSucceeded(job):
+		return "CIS benchmark completed. Use `jx compliance results` to display the results."
+	case kube.IsJobFinished(job):
+		return "CIS benchmark has failed. You can check what happened with `kubectl logs -n " + cisNamespace + " job/" + cisJobName + "`."
+	default:
+		return "CIS benchmark is still running."
+	}
+}
+
+// cisSectionName returns the section number and description of a group of checks
+func cisSectionName(group cisGroup) string {
+	return strings.TrimSpace(group.Section + " " + group.Desc)
+}
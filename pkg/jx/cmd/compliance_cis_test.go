@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKubeBenchOutput = `{"id":"2","version":"1.11","text":"Worker Node Security Configuration","node_type":"node","tests":[{"section":"2.1","pass":1,"fail":1,"warn":0,"desc":"Kubelet","results":[{"test_number":"2.1.1","test_desc":"Ensure that the --allow-privileged argument is set to false (Scored)","remediation":"Set --allow-privileged=false","status":"FAIL","scored":true},{"test_number":"2.1.2","test_desc":"Ensure that the --anonymous-auth argument is set to false (Scored)","status":"PASS","scored":true}]}],"total_pass":1,"total_fail":1,"total_warn":0}
+{"id":"3","version":"1.11","text":"Federated Deployments","node_type":"federated","tests":[{"section":"3.1","pass":0,"fail":0,"warn":1,"desc":"Federation API Server","results":[{"test_number":"3.1.1","test_desc":"Ensure that the --anonymous-auth argument is set to false (Not Scored)","status":"FAIL","scored":false}]}],"total_pass":0,"total_fail":0,"total_warn":1}
+`
+
+func TestParseCISResults(t *testing.T) {
+	t.Parallel()
+	controls, err := parseCISResults([]byte(testKubeBenchOutput))
+	require.NoError(t, err)
+	require.Len(t, controls, 2)
+
+	assert.Equal(t, "node", controls[0].NodeType)
+	require.Len(t, controls[0].Groups, 1)
+	assert.Equal(t, "2.1 Kubelet", cisSectionName(controls[0].Groups[0]))
+
+	failed := controls[0].failedChecks()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "2.1.1", failed[0].ID)
+	assert.Equal(t, "Set --allow-privileged=false", failed[0].Remediation)
+
+	assert.Empty(t, controls[1].failedChecks(), "checks which are not scored should not fail the benchmark")
+
+	summary := &complianceSummary{}
+	summary.addCISControls(controls)
+	assert.Equal(t, 1, summary.CISPassed)
+	assert.Equal(t, 1, summary.CISFailed)
+	assert.Equal(t, 1, summary.CISWarnings)
+	assert.True(t, summary.failed())
+}
+
+func TestParseCISResultsInvalid(t *testing.T) {
+	t.Parallel()
+	_, err := parseCISResults([]byte("not json"))
+	assert.Error(t, err)
+}
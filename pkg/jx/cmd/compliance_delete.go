@@ -5,6 +5,7 @@ import (
 
 	"github.com/heptio/sonobuoy/pkg/client"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
@@ -66,5 +67,10 @@ func (o *ComplianceDeleteOptions) Run() error {
 		EnableRBAC: false,
 		DeleteAll:  true,
 	}
-	return cc.Delete(deleteOpts)
+	// the CIS benchmark may have been run without the conformance tests so lets always delete it
+	err = cc.Delete(deleteOpts)
+	if err != nil {
+		err = errors.Wrap(err, "deleting the conformance tests")
+	}
+	return util.CombineErrors(err, o.deleteCISBenchmark())
 }
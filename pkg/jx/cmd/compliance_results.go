@@ -4,14 +4,18 @@ import (
 	"archive/tar"
 	"compress/gzip"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/heptio/sonobuoy/pkg/client"
 	"github.com/heptio/sonobuoy/pkg/client/results"
 	"github.com/heptio/sonobuoy/pkg/plugin/aggregation"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/onsi/ginkgo/reporters"
@@ -21,20 +25,37 @@ import (
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 )
 
+const (
+	// complianceResultsFileName the name of the file in which the conformance test results are stored
+	complianceResultsFileName = "sonobuoy-results.tar.gz"
+	// cisResultsFileName the name of the file in which the CIS benchmark results are stored
+	cisResultsFileName = "cis-benchmark.json"
+)
+
 var (
 	complianceResultsLong = templates.LongDesc(`
 		Shows the results of the compliance tests
+
+		A summary of the passed and failed conformance tests and CIS benchmark checks is displayed. The raw results
+		can be stored in a directory so that they can be archived along with the cluster configuration.
+
+		The command fails if any conformance test or CIS benchmark check failed so that it can gate a pipeline.
 	`)
 
 	complianceResultsExample = templates.Examples(`
 		# Show the compliance results
 		jx compliance results
+
+		# Show the compliance results and store them in a directory
+		jx compliance results --output-dir ./compliance
 	`)
 )
 
 // ComplianceResultsOptions options for "compliance results" command
 type ComplianceResultsOptions struct {
 	CommonOptions
+
+	OutputDir string
 }
 
 // NewCmdComplianceResults creates a command object for the "compliance results" action, which
@@ -63,24 +84,71 @@ func NewCmdComplianceResults(f Factory, in terminal.FileReader, out terminal.Fil
 		},
 	}
 
+	cmd.Flags().StringVarP(&options.OutputDir, "output-dir", "d", "", "The directory in which the raw results of the compliance tests are stored")
+
 	return cmd
 }
 
 // Run implements the "compliance results" command
 func (o *ComplianceResultsOptions) Run() error {
+	if o.OutputDir != "" {
+		err := os.MkdirAll(o.OutputDir, util.DefaultWritePermissions)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create the output directory %s", o.OutputDir)
+		}
+	}
+	summary := &complianceSummary{}
+	conformance, err := o.conformanceResults(summary)
+	if err != nil {
+		return err
+	}
+	cis, err := o.cisResults(summary)
+	if err != nil {
+		return err
+	}
+	if !conformance && !cis {
+		log.Infoln("Compliance results not ready. Run `jx compliance status` for status.")
+		return nil
+	}
+	log.Blank()
+	if conformance {
+		log.Infof("Conformance tests: %d passed, %d failed, %d skipped\n", summary.Passed, summary.Failed, summary.Skipped)
+	}
+	if cis {
+		log.Infof("CIS benchmark: %d passed, %d failed, %d warnings\n", summary.CISPassed, summary.CISFailed, summary.CISWarnings)
+	}
+	if o.OutputDir != "" {
+		log.Infof("Stored the compliance results in %s\n", util.ColorInfo(o.OutputDir))
+	}
+	if summary.failed() {
+		log.Infof("Compliance: %s\n", util.ColorError("FAILED"))
+		return errors.New("the cluster failed the compliance tests")
+	}
+	log.Infof("Compliance: %s\n", util.ColorInfo("PASSED"))
+	return nil
+}
+
+// conformanceResults displays the results of the conformance tests if they have completed
+func (o *ComplianceResultsOptions) conformanceResults(summary *complianceSummary) (bool, error) {
 	cc, err := o.Factory.CreateComplianceClient()
 	if err != nil {
-		return errors.Wrap(err, "could not create the compliance client")
+		return false, errors.Wrap(err, "could not create the compliance client")
 	}
 
 	status, err := cc.GetStatus(complianceNamespace)
 	if err != nil {
-		return errors.Wrap(err, "failed to retrieve the compliance status")
+		started, startedErr := o.complianceTestsStarted()
+		if startedErr != nil {
+			return false, startedErr
+		}
+		if !started {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "failed to get the status of the conformance tests")
 	}
 
 	if status.Status != aggregation.CompleteStatus && status.Status != aggregation.FailedStatus {
-		log.Infoln("Compliance results not ready. Run `jx compliance status` for status.")
-		return nil
+		return false, nil
 	}
 
 	cfg := &client.RetrieveConfig{
@@ -92,6 +160,15 @@ func (o *ComplianceResultsOptions) Run() error {
 	eg.Go(func() error { return <-errch })
 	eg.Go(func() error {
 		resultsReader, ec := untarResults(reader)
+		if o.OutputDir != "" {
+			fileName := filepath.Join(o.OutputDir, complianceResultsFileName)
+			file, err := os.Create(fileName)
+			if err != nil {
+				return errors.Wrapf(err, "could not create the file %s", fileName)
+			}
+			defer file.Close()
+			resultsReader = io.TeeReader(resultsReader, file)
+		}
 		gzr, err := gzip.NewReader(resultsReader)
 		if err != nil {
 			return errors.Wrap(err, "could not create a gzip reader for compliance results ")
@@ -101,6 +178,7 @@ func (o *ComplianceResultsOptions) Run() error {
 		if err != nil {
 			return errors.Wrap(err, "could not get the results of the compliance tests from the archive")
 		}
+		summary.addTests(testResults)
 		testResults = filterTests(
 			func(tc reporters.JUnitTestCase) bool {
 				return !results.Skipped(tc)
@@ -108,6 +186,11 @@ func (o *ComplianceResultsOptions) Run() error {
 		sort.Sort(StatusSortedTestCases(testResults))
 		o.printResults(testResults)
 
+		// drain the rest of the archive so that it is completely extracted
+		_, err = io.Copy(ioutil.Discard, resultsReader)
+		if err != nil {
+			return errors.Wrap(err, "could not read the compliance results archive")
+		}
 		err = <-ec
 		if err != nil {
 			return errors.Wrap(err, "could not extract the compliance results from archive")
@@ -117,9 +200,92 @@ func (o *ComplianceResultsOptions) Run() error {
 
 	err = eg.Wait()
 	if err != nil {
-		return errors.Wrap(err, "failed to retrieve the results")
+		return false, errors.Wrap(err, "failed to retrieve the results")
 	}
-	return nil
+	return true, nil
+}
+
+// cisResults displays the results of the CIS benchmark if it has completed
+func (o *ComplianceResultsOptions) cisResults(summary *complianceSummary) (bool, error) {
+	job, err := o.getCISBenchmarkJob()
+	if err != nil {
+		return false, err
+	}
+	if job == nil || !kube.IsJobSucceeded(job) {
+		return false, nil
+	}
+	data, err := o.retrieveCISResults()
+	if err != nil {
+		return false, err
+	}
+	if o.OutputDir != "" {
+		fileName := filepath.Join(o.OutputDir, cisResultsFileName)
+		err = ioutil.WriteFile(fileName, data, util.DefaultWritePermissions)
+		if err != nil {
+			return false, errors.Wrapf(err, "could not write the file %s", fileName)
+		}
+	}
+	controls, err := parseCISResults(data)
+	if err != nil {
+		return false, err
+	}
+	summary.addCISControls(controls)
+
+	log.Blank()
+	table := o.CreateTable()
+	table.SetColumnAlign(1, util.ALIGN_LEFT)
+	table.AddRow("CIS SECTION", "PASS", "FAIL", "WARN")
+	for _, c := range controls {
+		for _, group := range c.Groups {
+			table.AddRow(cisSectionName(group), strconv.Itoa(group.Pass), strconv.Itoa(group.Fail), strconv.Itoa(group.Warn))
+		}
+	}
+	table.Render()
+	for _, c := range controls {
+		for _, check := range c.failedChecks() {
+			log.Blank()
+			log.Infof("%s %s %s\n", util.ColorError(cisStateFail), check.ID, check.Text)
+			if check.Remediation != "" {
+				log.Infof("%s\n", check.Remediation)
+			}
+		}
+	}
+	return true, nil
+}
+
+// complianceSummary the number of tests and checks which passed or failed
+type complianceSummary struct {
+	Passed      int
+	Failed      int
+	Skipped     int
+	CISPassed   int
+	CISFailed   int
+	CISWarnings int
+}
+
+func (s *complianceSummary) addTests(testCases []reporters.JUnitTestCase) {
+	for _, tc := range testCases {
+		switch status(tc) {
+		case "PASSED":
+			s.Passed++
+		case "FAILED":
+			s.Failed++
+		case "SKIPPED":
+			s.Skipped++
+		}
+	}
+}
+
+func (s *complianceSummary) addCISControls(controls []cisControls) {
+	for _, c := range controls {
+		s.CISPassed += c.TotalPass
+		s.CISFailed += c.TotalFail
+		s.CISWarnings += c.TotalWarn
+	}
+}
+
+func (s *complianceSummary) failed() bool {
+	return s.Failed > 0 || s.CISFailed > 0
 }
 
 // Exit the main goroutine with status
@@ -1,31 +1,54 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
+	"strings"
 
 	"github.com/heptio/sonobuoy/pkg/client"
 	"github.com/heptio/sonobuoy/pkg/config"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 	"k8s.io/api/core/v1"
 )
 
+// complianceModes the modes in which the conformance tests can be run
+var complianceModes = []string{string(client.Quick), string(client.Conformance), string(client.Extended)}
+
 var (
 	complianceRuntLong = templates.LongDesc(`
 		Runs the compliance tests
+
+		By default the Kubernetes conformance tests are run via Sonobuoy. The CIS Kubernetes benchmark can also be
+		checked via kube-bench so that a cluster can be verified before it is used in production.
+
+		Use 'jx compliance status' to follow the progress of the tests and 'jx compliance results' to display a
+		summary of the results once the tests have completed.
 	`)
 
 	complianceRunExample = templates.Examples(`
 		# Run the compliance tests
 		jx compliance run
+
+		# Run the quick subset of the conformance tests along with the CIS benchmark
+		jx compliance run --mode quick --cis
+
+		# Only run the CIS benchmark
+		jx compliance run --cis --skip-conformance
 	`)
 )
 
 // ComplianceRuntOptions options for "compliance run" command
 type ComplianceRunOptions struct {
 	CommonOptions
+
+	Mode            string
+	CIS             bool
+	SkipConformance bool
 }
 
 // NewCmdComplianceRun creates a command object for the "compliance run" action, which
@@ -54,24 +77,44 @@ func NewCmdComplianceRun(f Factory, in terminal.FileReader, out terminal.FileWri
 		},
 	}
 
+	cmd.Flags().StringVarP(&options.Mode, "mode", "m", string(client.Conformance), fmt.Sprintf("The mode of the conformance tests. One of: %s", strings.Join(complianceModes, ", ")))
+	cmd.Flags().BoolVarP(&options.CIS, "cis", "", false, "Also checks the nodes of the cluster against the CIS Kubernetes benchmark")
+	cmd.Flags().BoolVarP(&options.SkipConformance, "skip-conformance", "", false, "Does not run the conformance tests")
+
 	return cmd
 }
 
 // Run implements the "compliance run" command
 func (o *ComplianceRunOptions) Run() error {
-	cc, err := o.Factory.CreateComplianceClient()
-	if err != nil {
-		return errors.Wrap(err, "could not create the compliance client")
+	if o.SkipConformance && !o.CIS {
+		return fmt.Errorf("there are no compliance tests to run. Use --cis to run the CIS benchmark")
+	}
+	if !o.SkipConformance {
+		var modeName client.Mode
+		if err := modeName.Set(o.Mode); err != nil {
+			return util.InvalidOption("mode", o.Mode, complianceModes)
+		}
+		cc, err := o.Factory.CreateComplianceClient()
+		if err != nil {
+			return errors.Wrap(err, "could not create the compliance client")
+		}
+		cfg := o.config(modeName)
+		if err := cc.Run(cfg); err != nil {
+			return errors.Wrap(err, "failed to start the compliance tests")
+		}
+		log.Infof("Started the %s conformance tests\n", util.ColorInfo(o.Mode))
 	}
-	cfg := o.config()
-	if err := cc.Run(cfg); err != nil {
-		return errors.Wrap(err, "failed to start the compliance tests")
+	if o.CIS {
+		if err := o.startCISBenchmark(); err != nil {
+			return err
+		}
+		log.Infof("Started the %s\n", util.ColorInfo("CIS benchmark"))
 	}
+	log.Infof("Use %s to check the progress of the compliance tests\n", util.ColorInfo("jx compliance status"))
 	return nil
 }
 
-func (o *ComplianceRunOptions) config() *client.RunConfig {
-	modeName := client.Conformance
+func (o *ComplianceRunOptions) config(modeName client.Mode) *client.RunConfig {
 	mode := modeName.Get()
 	genCfg := &client.GenConfig{
 		E2EConfig:            &mode.E2EConfig,
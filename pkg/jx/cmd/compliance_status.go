@@ -63,13 +63,22 @@ func (o *ComplianceStatusOptions) Run() error {
 	if err != nil {
 		return errors.Wrap(err, "could not create the compliance client")
 	}
+	job, err := o.getCISBenchmarkJob()
+	if err != nil {
+		return err
+	}
 	status, err := cc.GetStatus(complianceNamespace)
 	if err != nil {
-		log.Infof("No compliance status found. Use %s command to start the compliance tests.\n", util.ColorInfo("jx compliance run"))
-		log.Infof("You can watch the logs with %s command.\n", util.ColorInfo("jx compliance logs -f"))
-		return nil
+		if job == nil {
+			log.Infof("No compliance status found. Use %s command to start the compliance tests.\n", util.ColorInfo("jx compliance run"))
+			log.Infof("You can watch the logs with %s command.\n", util.ColorInfo("jx compliance logs -f"))
+		}
+	} else {
+		log.Infoln(hummanReadableStatus(status.Status))
+	}
+	if job != nil {
+		log.Infoln(cisStatus(job))
 	}
-	log.Infoln(hummanReadableStatus(status.Status))
 	return nil
 }
 
@@ -20,6 +20,7 @@ type ContextOptions struct {
 	CommonOptions
 
 	Filter string
+	JXOnly bool
 }
 
 var (
@@ -36,7 +37,10 @@ var (
 		jx ctx -b
 
 		# Change the current namespace to 'minikube'
-		jx ctx minikube`)
+		jx ctx minikube
+
+		# only pick from the contexts created by 'jx create cluster'
+		jx ctx --jx`)
 )
 
 func NewCmdContext(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
@@ -63,6 +67,7 @@ func NewCmdContext(f Factory, in terminal.FileReader, out terminal.FileWriter, e
 		},
 	}
 	cmd.Flags().StringVarP(&options.Filter, "filter", "f", "", "Filter the list of contexts to switch between using the given text")
+	cmd.Flags().BoolVarP(&options.JXOnly, "jx", "", false, "Only show the contexts created and managed by jx")
 	options.addCommonFlags(cmd)
 	return cmd
 }
@@ -80,6 +85,9 @@ func (o *ContextOptions) Run() error {
 	contextNames := []string{}
 	for k, v := range config.Contexts {
 		if k != "" && v != nil {
+			if o.JXOnly && !kube.IsJXContext(k) {
+				continue
+			}
 			if o.Filter == "" || strings.Index(k, o.Filter) >= 0 {
 				contextNames = append(contextNames, k)
 			}
@@ -16,7 +16,10 @@ import (
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/builds"
 	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx/pkg/cloud/buckets"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/notify"
+	"github.com/jenkins-x/jx/pkg/tekton"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
@@ -37,8 +40,19 @@ type ControllerBuildOptions struct {
 	InitGitCredentials bool
 
 	EnvironmentCache *kube.EnvironmentNamespaceCache
+
+	notifications chan *pipelineNotification
+}
+
+// pipelineNotification a notification of the status of a pipeline waiting to be sent to the channels of the team
+type pipelineNotification struct {
+	channels []v1.NotificationChannel
+	event    *notify.Event
 }
 
+// notificationQueueSize the number of pipeline notifications which can wait to be sent before new ones are dropped
+const notificationQueueSize = 100
+
 // NewCmdControllerBuild creates a command object for the generic "get" action, which
 // retrieves one or more resources from a server.
 func NewCmdControllerBuild(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
@@ -116,6 +130,11 @@ func (o *ControllerBuildOptions) Run() error {
 		}
 	}
 
+	// the notifications are sent by a single worker so that a slow notification channel never holds up the
+	// processing of the build pods and the notifications of a pipeline are sent in order
+	o.notifications = make(chan *pipelineNotification, notificationQueueSize)
+	go o.sendNotifications(kubeClient, ns)
+
 	pod := &corev1.Pod{}
 	log.Infof("Watching for Knative build pods in namespace %s\n", util.ColorInfo(ns))
 	listWatch := cache.NewListWatchFromClient(kubeClient.CoreV1().RESTClient(), "pods", ns, fields.Everything())
@@ -150,12 +169,12 @@ func (o *ControllerBuildOptions) onPod(obj interface{}, kubeClient kubernetes.In
 		return
 	}
 	if pod != nil {
+		if kube.IsPodEvicted(pod) {
+			o.retryEvictedBuild(pod)
+		}
 		labels := pod.Labels
 		if labels != nil {
-			buildName := labels[builds.LabelBuildName]
-			if buildName == "" {
-				buildName = labels[builds.LabelOldBuildName]
-			}
+			buildName := builds.BuildName(pod)
 			if buildName != "" {
 				if o.Verbose {
 					log.Infof("Found build pod %s\n", pod.Name)
@@ -174,12 +193,16 @@ func (o *ControllerBuildOptions) onPod(obj interface{}, kubeClient kubernetes.In
 							}
 							log.Warnf("Failed to %s PipelineActivities for build %s: %s\n", operation, buildName, err)
 						}
+						previousStatus := a.Spec.Status
 						if o.updatePipelineActivity(kubeClient, ns, a, buildName, pod) {
 							_, err := activities.Update(a)
 							if err != nil {
 								name = a.Name
 								return err
 							}
+							if a.Spec.Status != previousStatus {
+								o.notifyPipelineStatus(a)
+							}
 						}
 						return nil
 					})
@@ -192,6 +215,32 @@ func (o *ControllerBuildOptions) onPod(obj interface{}, kubeClient kubernetes.In
 	}
 }
 
+// retryEvictedBuild runs the build of the pod again if the builds of the team run on preemptible or spot nodes. Builds
+// which fail for any other reason are not retried as their steps may have side effects such as tagging a release
+func (o *ControllerBuildOptions) retryEvictedBuild(pod *corev1.Pod) {
+	settings, err := o.TeamSettings()
+	if err != nil {
+		log.Warnf("Failed to load the team settings: %s\n", err)
+		return
+	}
+	if !settings.BuildsOnSpot {
+		return
+	}
+	dynamicClient, err := o.dynamicClient()
+	if err != nil {
+		log.Warnf("Failed to create the dynamic client: %s\n", err)
+		return
+	}
+	name, err := builds.RetryEvictedBuildPod(dynamicClient, pod, kube.DefaultSpotRetries)
+	if err != nil {
+		log.Warnf("Failed to retry the build of the evicted pod %s: %s\n", pod.Name, err)
+		return
+	}
+	if name != "" {
+		log.Infof("Retrying the build of the evicted pod %s as %s\n", util.ColorInfo(pod.Name), util.ColorInfo(name))
+	}
+}
+
 // createPromoteStepActivityKey deduces the pipeline metadata from the Knative build pod
 func (o *ControllerBuildOptions) createPromoteStepActivityKey(buildName string, pod *corev1.Pod) *kube.PromoteStepActivityKey {
 
@@ -214,9 +263,15 @@ func (o *ControllerBuildOptions) createPromoteStepActivityKey(buildName string,
 
 func (o *ControllerBuildOptions) updatePipelineActivity(kubeClient kubernetes.Interface, ns string, activity *v1.PipelineActivity, buildName string, pod *corev1.Pod) bool {
 	copy := *activity
-	initContainersTerminated := len(pod.Status.InitContainerStatuses) > 0
-	for _, c := range pod.Status.InitContainerStatuses {
+	stepStatuses := builds.StepStatuses(pod)
+	initContainersTerminated := len(stepStatuses) > 0
+	// the tasks of a Tekton pipeline run in separate pods so lets prefix their steps with the task
+	taskName := pod.Labels[tekton.LabelPipelineTaskName]
+	for _, c := range stepStatuses {
 		name := strings.Replace(strings.TrimPrefix(c.Name, "build-step-"), "-", " ", -1)
+		if taskName != "" {
+			name = taskName + " " + name
+		}
 		title := strings.Title(name)
 		_, stage, _ := kube.GetOrCreateStage(activity, title)
 
@@ -295,6 +350,10 @@ func (o *ControllerBuildOptions) updatePipelineActivity(kubeClient kubernetes.In
 	if !allCompleted && initContainersTerminated {
 		allCompleted = true
 	}
+	// a Tekton pipeline which has not failed runs until the pod of its last task completes
+	if allCompleted && !failed && !tekton.IsLastTaskPod(pod) {
+		allCompleted = false
+	}
 	if allCompleted {
 		if failed {
 			spec.Status = v1.ActivityStatusTypeFailed
@@ -340,6 +399,65 @@ func (o *ControllerBuildOptions) updatePipelineActivity(kubeClient kubernetes.In
 	return !reflect.DeepEqual(&copy, activity)
 }
 
+// notifyPipelineStatus notifies the team when a pipeline starts, succeeds or fails
+func (o *ControllerBuildOptions) notifyPipelineStatus(activity *v1.PipelineActivity) {
+	kind := ""
+	switch activity.Spec.Status {
+	case v1.ActivityStatusTypeRunning:
+		kind = notify.EventPipelineStarted
+	case v1.ActivityStatusTypeSucceeded:
+		kind = notify.EventPipelineSucceeded
+	case v1.ActivityStatusTypeFailed:
+		kind = notify.EventPipelineFailed
+	default:
+		return
+	}
+	devEnv := o.EnvironmentCache.Item(kube.LabelValueDevEnvironment)
+	if devEnv == nil || len(devEnv.Spec.TeamSettings.Notifications) == 0 {
+		return
+	}
+	spec := &activity.Spec
+	event := &notify.Event{
+		Kind:       kind,
+		Owner:      spec.GitOwner,
+		Repository: spec.GitRepository,
+		Pipeline:   spec.Pipeline,
+		Build:      spec.Build,
+		Version:    spec.Version,
+		BuildURL:   spec.BuildLogsURL,
+	}
+	paths := strings.Split(spec.Pipeline, "/")
+	if len(paths) > 2 {
+		event.Branch = paths[len(paths)-1]
+	}
+	if event.BuildURL == "" {
+		event.BuildURL = spec.BuildURL
+	}
+	o.queueNotification(&pipelineNotification{
+		channels: devEnv.Spec.TeamSettings.Notifications,
+		event:    event,
+	})
+}
+
+// queueNotification queues the notification to be sent without blocking. The notification is dropped if the queue is
+// full as the notification channels are not keeping up
+func (o *ControllerBuildOptions) queueNotification(notification *pipelineNotification) bool {
+	select {
+	case o.notifications <- notification:
+		return true
+	default:
+		log.Warnf("Dropping the %s notification of pipeline %s as the notification queue is full\n", notification.event.Kind, notification.event.Pipeline)
+		return false
+	}
+}
+
+// sendNotifications sends the queued notifications to the notification channels
+func (o *ControllerBuildOptions) sendNotifications(kubeClient kubernetes.Interface, ns string) {
+	for notification := range o.notifications {
+		notifyChannels(kubeClient, ns, notification.channels, notification.event)
+	}
+}
+
 // generates the build log URL and returns the URL
 func (o *CommonOptions) generateBuildLogURL(podInterface typedcorev1.PodInterface, ns string, activity *v1.PipelineActivity, buildName string, pod *corev1.Pod, location *v1.StorageLocation, initGitCredentials bool) string {
 	data, err := builds.GetBuildLogsForPod(podInterface, pod)
@@ -355,6 +473,35 @@ func (o *CommonOptions) generateBuildLogURL(podInterface typedcorev1.PodInterfac
 		log.Infof("got build log for pod: %s PipelineActivity: %s with bytes: %d\n", pod.Name, activity.Name, len(data))
 	}
 
+	// mask the values of the pipeline secrets before the log is archived
+	kubeClient, _, err := o.KubeClient()
+	if err == nil {
+		err = registerPipelineSecrets(kubeClient, ns)
+	}
+	if err != nil {
+		log.Warnf("Failed to load the pipeline secrets in namespace %s: %s\n", ns, err)
+	}
+	data = []byte(util.RedactSecrets(string(data)))
+
+	owner := activity.Spec.GitOwner
+	repository := activity.RepositoryName()
+	branch := activity.BranchName()
+	buildNumber := activity.Spec.Build
+	if buildNumber == "" {
+		buildNumber = "1"
+	}
+	pathDir := filepath.Join("jenkins-x", "logs", owner, repository, branch)
+	fileName := filepath.Join(pathDir, buildNumber+".log")
+
+	if location.BucketURL != "" {
+		logURL, err := buckets.WriteFile(location.BucketURL, fileName, data)
+		if err != nil {
+			log.Warnf("Failed to archive the log of PipelineActivity %s: %s\n", activity.Name, err)
+			return ""
+		}
+		return logURL
+	}
+
 	sourceURL := location.GitURL
 	if sourceURL == "" {
 		// TODO handle http URLs too
@@ -380,20 +527,11 @@ func (o *CommonOptions) generateBuildLogURL(podInterface typedcorev1.PodInterfac
 
 	gitClient := gits.NewGitCLI()
 	ghPagesDir, err := cloneGitHubPagesBranchToTempDir(sourceURL, gitClient)
-	if err != err {
+	if err != nil {
 		log.Warnf("Failed to git clone gh-pages branch for %s: %s\n", sourceURL, err)
 		return ""
 	}
 
-	owner := activity.Spec.GitOwner
-	repository := activity.RepositoryName()
-	branch := activity.BranchName()
-	buildNumber := activity.Spec.Build
-	if buildNumber == "" {
-		buildNumber = "1"
-	}
-
-	pathDir := filepath.Join("jenkins-x", "logs", owner, repository, branch)
 	outDir := filepath.Join(ghPagesDir, pathDir)
 	err = os.MkdirAll(outDir, util.DefaultWritePermissions)
 	if err != nil {
@@ -401,7 +539,6 @@ func (o *CommonOptions) generateBuildLogURL(podInterface typedcorev1.PodInterfac
 		return ""
 	}
 
-	fileName := filepath.Join(pathDir, buildNumber+".log")
 	outFile := filepath.Join(ghPagesDir, fileName)
 	err = ioutil.WriteFile(outFile, data, util.DefaultWritePermissions)
 	if err != nil {
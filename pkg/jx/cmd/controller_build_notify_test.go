@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestControllerBuildQueueNotification(t *testing.T) {
+	t.Parallel()
+
+	o := &ControllerBuildOptions{
+		notifications: make(chan *pipelineNotification, 2),
+	}
+	notification := func(kind string) *pipelineNotification {
+		return &pipelineNotification{
+			event: &notify.Event{
+				Kind:     kind,
+				Pipeline: "myorg/myapp/master",
+			},
+		}
+	}
+
+	assert.True(t, o.queueNotification(notification(notify.EventPipelineStarted)))
+	assert.True(t, o.queueNotification(notification(notify.EventPipelineSucceeded)))
+	assert.False(t, o.queueNotification(notification(notify.EventPipelineFailed)), "a full queue should not block")
+
+	require.Len(t, o.notifications, 2)
+	assert.Equal(t, notify.EventPipelineStarted, (<-o.notifications).event.Kind)
+	assert.Equal(t, notify.EventPipelineSucceeded, (<-o.notifications).event.Kind)
+}
@@ -247,6 +247,7 @@ func (o *ControllerTeamOptions) onTeamChange(obj interface{}, kubeClient kuberne
 		//o.InstallOptions.Flags.NoDefaultEnvironments = true
 		io.Flags.Namespace = teamNs
 		io.Flags.DefaultEnvironmentPrefix = teamNs
+		io.Flags.NoDefaultEnvironments = team.Spec.NoEnvironments
 		io.CommonOptions.InstallDependencies = true
 
 		if io.Flags.Prow {
@@ -294,16 +295,18 @@ func (o *ControllerTeamOptions) onTeamChange(obj interface{}, kubeClient kuberne
 			return
 		}
 
-		if adminTeamSettings != nil {
-			callback := func(env *v1.Environment) error {
-				env.Spec.TeamSettings.BuildPackRef = adminTeamSettings.BuildPackRef
-				env.Spec.TeamSettings.BuildPackURL = adminTeamSettings.BuildPackURL
-				return nil
-			}
-			err = oc.modifyDevEnvironment(jxClient, teamNs, callback)
-			if err != nil {
-				log.Errorf("Failed to update team settings in namespace %s: %s\n", teamNs, err)
-			}
+		callback := func(env *v1.Environment) error {
+			applyTeamSettings(env, team, adminTeamSettings)
+			return nil
+		}
+		err = oc.modifyDevEnvironment(jxClient, teamNs, callback)
+		if err != nil {
+			log.Errorf("Failed to update team settings in namespace %s: %s\n", teamNs, err)
+		}
+
+		err = oc.installTeamAddons(teamNs, team.Spec.Addons)
+		if err != nil {
+			log.Errorf("Failed to install the addons into team %s: %s\n", teamNs, err)
 		}
 
 		err = oc.ModifyTeam(adminNs, team.Name, func(team *v1.Team) error {
@@ -318,6 +321,40 @@ func (o *ControllerTeamOptions) onTeamChange(obj interface{}, kubeClient kuberne
 	}
 }
 
+// applyTeamSettings defaults the settings of the dev environment of a new team from the team and the admin team
+func applyTeamSettings(env *v1.Environment, team *v1.Team, adminTeamSettings *v1.TeamSettings) {
+	if adminTeamSettings != nil {
+		env.Spec.TeamSettings.BuildPackRef = adminTeamSettings.BuildPackRef
+		env.Spec.TeamSettings.BuildPackURL = adminTeamSettings.BuildPackURL
+	}
+	if team.Spec.GitServer != "" {
+		env.Spec.TeamSettings.GitServer = team.Spec.GitServer
+	}
+	if team.Spec.Organisation != "" {
+		env.Spec.TeamSettings.Organisation = team.Spec.Organisation
+	}
+}
+
+// installTeamAddons installs the addons into the team namespace carrying on with the other addons if one fails
+func (o *CommonOptions) installTeamAddons(teamNs string, addons []string) error {
+	errs := []error{}
+	for _, addon := range addons {
+		ao := &CreateAddonOptions{
+			CreateOptions: CreateOptions{
+				CommonOptions: *o,
+			},
+			Namespace:  teamNs,
+			HelmUpdate: true,
+		}
+		ao.SetDevNamespace(teamNs)
+		err := ao.CreateAddon(addon)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "installing addon %s", addon))
+		}
+	}
+	return util.CombineErrors(errs...)
+}
+
 // LoadProwOAuthConfig returns the OAuth Token for Prow
 func (o *CommonOptions) LoadProwOAuthConfig(ns string) (string, error) {
 	options := *o
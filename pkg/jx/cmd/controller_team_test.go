@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/helm/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestApplyTeamSettings(t *testing.T) {
+	t.Parallel()
+
+	adminTeamSettings := &v1.TeamSettings{
+		BuildPackURL: "https://github.com/jenkins-x/jenkins-x-kubernetes.git",
+		BuildPackRef: "master",
+		GitServer:    "https://github.com",
+		Organisation: "admin-org",
+	}
+	team := &v1.Team{
+		Spec: v1.TeamSpec{
+			GitServer:    "https://gitlab.example.com",
+			Organisation: "myorg",
+		},
+	}
+
+	env := &v1.Environment{}
+	applyTeamSettings(env, team, adminTeamSettings)
+	assert.Equal(t, "https://github.com/jenkins-x/jenkins-x-kubernetes.git", env.Spec.TeamSettings.BuildPackURL)
+	assert.Equal(t, "master", env.Spec.TeamSettings.BuildPackRef)
+	assert.Equal(t, "https://gitlab.example.com", env.Spec.TeamSettings.GitServer)
+	assert.Equal(t, "myorg", env.Spec.TeamSettings.Organisation)
+
+	env = &v1.Environment{
+		Spec: v1.EnvironmentSpec{
+			TeamSettings: v1.TeamSettings{
+				GitServer:    "https://github.com",
+				Organisation: "install-org",
+			},
+		},
+	}
+	applyTeamSettings(env, &v1.Team{}, nil)
+	assert.Equal(t, "https://github.com", env.Spec.TeamSettings.GitServer, "the git server of the install should be kept")
+	assert.Equal(t, "install-org", env.Spec.TeamSettings.Organisation, "the organisation of the install should be kept")
+	assert.Equal(t, "", env.Spec.TeamSettings.BuildPackURL)
+}
+
+func TestInstallTeamAddons(t *testing.T) {
+	t.Parallel()
+
+	o := &CommonOptions{}
+	ConfigureTestOptionsWithResources(o, []runtime.Object{}, []runtime.Object{}, gits.NewGitCLI(), helm_test.NewFakeHelmer())
+
+	assert.NoError(t, o.installTeamAddons("jx-myteam", nil))
+
+	err := o.installTeamAddons("jx-myteam", []string{"cheese", "wine"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "installing addon cheese")
+	assert.Contains(t, err.Error(), "installing addon wine", "a failing addon should not stop the other addons being installed")
+}
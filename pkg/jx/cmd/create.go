@@ -67,6 +67,7 @@ func NewCmdCreate(f Factory, in terminal.FileReader, out terminal.FileWriter, er
 
 	cmd.AddCommand(NewCmdCreateAddon(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateArchetype(f, in, out, errOut))
+	cmd.AddCommand(NewCmdCreateBackup(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateBranchPattern(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateCamel(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateChat(f, in, out, errOut))
@@ -77,18 +78,24 @@ func NewCmdCreate(f Factory, in terminal.FileReader, out terminal.FileWriter, er
 	cmd.AddCommand(NewCmdCreateDocs(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateEnv(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateEtcHosts(f, in, out, errOut))
+	cmd.AddCommand(NewCmdCreateExtension(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateGkeServiceAccount(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateGit(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateIssue(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateJenkins(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateJHipster(f, in, out, errOut))
+	cmd.AddCommand(NewCmdCreateKubeConfig(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateLile(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateMicro(f, in, out, errOut))
+	cmd.AddCommand(NewCmdCreatePodTemplate(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreatePostPreviewJob(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateProject(f, in, out, errOut))
+	cmd.AddCommand(NewCmdCreateProw(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreatePullRequest(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateQuickstart(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateQuickstartLocation(f, in, out, errOut))
+	cmd.AddCommand(NewCmdCreateRelease(f, in, out, errOut))
+	cmd.AddCommand(NewCmdCreateSecret(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateSpring(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateTeam(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateTerraform(f, in, out, errOut))
@@ -5,7 +5,9 @@ import (
 	"io"
 	"strings"
 
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -52,17 +54,27 @@ func NewCmdCreateAddon(f Factory, in terminal.FileReader, out terminal.FileWrite
 
 	cmd.AddCommand(NewCmdCreateAddonAmbassador(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateAddonAnchore(f, in, out, errOut))
+	cmd.AddCommand(NewCmdCreateAddonArtifactory(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateAddonCloudBees(f, in, out, errOut))
+	cmd.AddCommand(NewCmdCreateAddonFlagger(f, in, out, errOut))
+	cmd.AddCommand(NewCmdCreateAddonGatekeeper(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateAddonGitea(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateAddonIstio(f, in, out, errOut))
+	cmd.AddCommand(NewCmdCreateAddonKaniko(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateAddonKnativeBuild(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateAddonKubeless(f, in, out, errOut))
+	cmd.AddCommand(NewCmdCreateAddonOAuth2Proxy(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateAddonOwasp(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateAddonPipelineEvents(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateAddonPrometheus(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateAddonProw(f, in, out, errOut))
+	cmd.AddCommand(NewCmdCreateAddonSlack(f, in, out, errOut))
+	cmd.AddCommand(NewCmdCreateAddonSonarQube(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateAddonSSO(f, in, out, errOut))
+	cmd.AddCommand(NewCmdCreateAddonTeams(f, in, out, errOut))
+	cmd.AddCommand(NewCmdCreateAddonTrivy(f, in, out, errOut))
 	cmd.AddCommand(NewCmdCreateAddonVault(f, in, out, errOut))
+	cmd.AddCommand(NewCmdCreateAddonVelero(f, in, out, errOut))
 
 	options.addFlags(cmd, kube.DefaultNamespace, "", "")
 	return cmd
@@ -93,28 +105,53 @@ func (o *CreateAddonOptions) Run() error {
 	return nil
 }
 
-func (o *CreateAddonOptions) CreateAddon(addon string) error {
+// CreateAddon installs or upgrades the addon with the given name and records it in the team settings
+func (o *CreateAddonOptions) CreateAddon(name string) error {
 	err := o.ensureHelm()
 	if err != nil {
 		return errors.Wrap(err, "failed to ensure that helm is present")
 	}
-	charts := kube.AddonCharts
-	chart := charts[addon]
-	if chart == "" {
-		return util.InvalidArg(addon, util.SortedMapKeys(charts))
+	registry, err := o.addonRegistry()
+	if err != nil {
+		return err
+	}
+	addon := registry.Get(name)
+	if addon == nil {
+		return util.InvalidArg(name, registry.Names())
+	}
+	setValues, err := addon.ResolveValues(strings.Split(o.SetValues, ","))
+	if err != nil {
+		return err
 	}
-	setValues := strings.Split(o.SetValues, ",")
 
-	err = o.installChart(addon, chart, o.Version, o.Namespace, o.HelmUpdate, setValues, o.ValueFiles, "")
+	// installing the chart upgrades any existing release so installing an addon again is safe
+	err = o.installChart(name, addon.Chart, o.Version, o.Namespace, o.HelmUpdate, setValues, o.ValueFiles, "")
+	if err != nil {
+		return fmt.Errorf("Failed to install chart %s: %s", addon.Chart, err)
+	}
+	err = addon.RunPostInstall(o.Namespace)
+	if err != nil {
+		return err
+	}
+	err = o.recordAddon(v1.AddonSettings{
+		Name:      name,
+		Chart:     addon.Chart,
+		Version:   o.Version,
+		Namespace: o.Namespace,
+		SetValues: setValues,
+	})
 	if err != nil {
-		return fmt.Errorf("Failed to install chart %s: %s", chart, err)
+		log.Warnf("Failed to record the addon %s in the team settings: %s\n", name, err)
 	}
-	return o.ExposeAddon(addon)
+	return o.exposeAddonService(name, addon.Service)
 }
 
 func (o *CreateAddonOptions) ExposeAddon(addon string) error {
-	service, ok := kube.AddonServices[addon]
-	if !ok {
+	return o.exposeAddonService(addon, kube.AddonServices[addon])
+}
+
+func (o *CreateAddonOptions) exposeAddonService(addon string, service string) error {
+	if service == "" {
 		return nil
 	}
 	svc, err := o.KubeClientCached.CoreV1().Services(o.Namespace).Get(service, meta_v1.GetOptions{})
@@ -15,6 +15,7 @@ import (
 
 	"time"
 
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
@@ -175,5 +176,17 @@ func (o *CreateAddonAnchoreOptions) Run() error {
 		}
 
 	}
+
+	// the pipelines scan the images they build with the scanner recorded in the team settings
+	err = o.recordAddon(v1.AddonSettings{
+		Name:      defaultAnchoreName,
+		Chart:     o.Chart,
+		Version:   o.Version,
+		Namespace: o.Namespace,
+		SetValues: setValues,
+	})
+	if err != nil {
+		log.Warnf("Failed to record the addon %s in the team settings: %s\n", defaultAnchoreName, err)
+	}
 	return nil
 }
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/artifacts"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	artifactoryRepoName         = "jfrog"
+	artifactoryRepoUrl          = "https://charts.jfrog.io"
+	defaultArtifactoryVersion   = ""
+	defaultArtifactoryUser      = "admin"
+	defaultArtifactoryPassword  = "password"
+	artifactoryPort             = 8081
+	artifactoryDeploymentSuffix = "-artifactory"
+)
+
+var (
+	createAddonArtifactoryLong = templates.LongDesc(`
+		Creates the Artifactory addon as the artifact repository of the team instead of Nexus.
+
+		Either installs Artifactory in the cluster or, with --url, connects to an existing Artifactory server.
+		The Maven, Gradle and npm settings of the build pods are then rewritten so that the pipelines resolve
+		and deploy artifacts with Artifactory.
+
+		To switch back to Nexus use 'jx edit artifactrepository nexus'
+`)
+
+	createAddonArtifactoryExample = templates.Examples(`
+		# Install Artifactory in the cluster
+		jx create addon artifactory
+
+		# Use an existing Artifactory server
+		jx create addon artifactory --url https://artifactory.acme.com/artifactory --username jenkins-x --password mypassword
+	`)
+)
+
+// CreateAddonArtifactoryOptions the options for the create addon artifactory command
+type CreateAddonArtifactoryOptions struct {
+	CreateAddonOptions
+
+	Chart    string
+	URL      string
+	Username string
+	Password string
+}
+
+// NewCmdCreateAddonArtifactory creates a command object for the "create addon artifactory" command
+func NewCmdCreateAddonArtifactory(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &CreateAddonArtifactoryOptions{
+		CreateAddonOptions: CreateAddonOptions{
+			CreateOptions: CreateOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					In:      in,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "artifactory",
+		Short:   "Create the Artifactory addon as the artifact repository of the team",
+		Long:    createAddonArtifactoryLong,
+		Example: createAddonArtifactoryExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	options.addFlags(cmd, kube.DefaultNamespace, kube.DefaultArtifactoryReleaseName, defaultArtifactoryVersion)
+
+	cmd.Flags().StringVarP(&options.Chart, optionChart, "c", kube.ChartArtifactory, "The name of the chart to use")
+	cmd.Flags().StringVarP(&options.URL, "url", "u", "", "The URL of an existing Artifactory server to use instead of installing Artifactory")
+	cmd.Flags().StringVarP(&options.Username, "username", "", defaultArtifactoryUser, "The name of the Artifactory user the pipelines resolve and deploy artifacts with")
+	cmd.Flags().StringVarP(&options.Password, "password", "p", defaultArtifactoryPassword, "The password of the Artifactory user the pipelines resolve and deploy artifacts with")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateAddonArtifactoryOptions) Run() error {
+	if o.Username == "" {
+		return util.MissingOption("username")
+	}
+	url := o.URL
+	if url == "" {
+		var err error
+		url, err = o.installArtifactory()
+		if err != nil {
+			return err
+		}
+	}
+
+	repo := &artifacts.Repository{
+		Kind:     v1.ArtifactRepositoryArtifactory,
+		URL:      url,
+		Username: o.Username,
+		Password: o.Password,
+	}
+	return o.applyArtifactRepository(repo)
+}
+
+// installArtifactory installs the Artifactory chart returning the URL the pipelines access it with
+func (o *CreateAddonArtifactoryOptions) installArtifactory() (string, error) {
+	if o.ReleaseName == "" {
+		return "", util.MissingOption(optionRelease)
+	}
+	if o.Chart == "" {
+		return "", util.MissingOption(optionChart)
+	}
+	err := o.ensureHelm()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to ensure that helm is present")
+	}
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return "", err
+	}
+	err = o.addHelmRepoIfMissing(artifactoryRepoUrl, artifactoryRepoName)
+	if err != nil {
+		return "", err
+	}
+
+	values := strings.Split(o.SetValues, ",")
+	err = o.installChart(o.ReleaseName, o.Chart, o.Version, o.Namespace, o.HelmUpdate, values, o.ValueFiles, "")
+	if err != nil {
+		return "", errors.Wrap(err, "artifactory deployment failed")
+	}
+
+	serviceName := o.ReleaseName + artifactoryDeploymentSuffix
+	log.Info("waiting for the Artifactory deployment to be ready, this can take a few minutes\n")
+	err = kube.WaitForDeploymentToBeReady(client, serviceName, o.Namespace, 10*time.Minute)
+	if err != nil {
+		return "", err
+	}
+	err = o.recordAddon(v1.AddonSettings{
+		Name:      kube.DefaultArtifactoryReleaseName,
+		Chart:     o.Chart,
+		Version:   o.Version,
+		Namespace: o.Namespace,
+		SetValues: values,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to record the addon in the team settings")
+	}
+	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d/artifactory", serviceName, o.Namespace, artifactoryPort), nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	flaggerRepoName          = "flagger"
+	flaggerRepoUrl           = "https://flagger.app"
+	defaultFlaggerVersion    = ""
+	defaultFlaggerMetricsURL = "http://prometheus.istio-system:9090"
+)
+
+var (
+	createAddonFlaggerLong = templates.LongDesc(`
+		Creates the Flagger addon for progressive delivery on top of the Istio service mesh.
+
+		Flagger shifts the traffic of an application to a new version in steps, checking the success rate and
+		latency of the requests at each step and rolling back if they regress. Use 'jx promote --canary' to
+		promote a version via a canary.
+`)
+
+	createAddonFlaggerExample = templates.Examples(`
+		# Create the Istio and Flagger addons
+		jx create addon istio
+		jx create addon flagger
+
+		# Promote to production via a canary
+		jx promote myapp --version 1.2.3 --env production --canary --steps 10,25,50
+	`)
+)
+
+// CreateAddonFlaggerOptions the options for the create addon flagger command
+type CreateAddonFlaggerOptions struct {
+	CreateAddonOptions
+
+	Chart      string
+	MetricsURL string
+}
+
+// NewCmdCreateAddonFlagger creates a command object for the "create addon flagger" command
+func NewCmdCreateAddonFlagger(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &CreateAddonFlaggerOptions{
+		CreateAddonOptions: CreateAddonOptions{
+			CreateOptions: CreateOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					In:      in,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "flagger",
+		Short:   "Create the Flagger addon for canary promotions",
+		Aliases: []string{"canary"},
+		Long:    createAddonFlaggerLong,
+		Example: createAddonFlaggerExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	options.addFlags(cmd, defaultIstioNamespace, kube.DefaultFlaggerReleaseName, defaultFlaggerVersion)
+
+	cmd.Flags().StringVarP(&options.Chart, optionChart, "c", kube.ChartFlagger, "The name of the chart to use")
+	cmd.Flags().StringVarP(&options.MetricsURL, "metrics-url", "", defaultFlaggerMetricsURL, "The URL of the Prometheus server Flagger queries the metrics of the canaries from")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateAddonFlaggerOptions) Run() error {
+	if o.ReleaseName == "" {
+		return util.MissingOption(optionRelease)
+	}
+	if o.Chart == "" {
+		return util.MissingOption(optionChart)
+	}
+	err := o.ensureHelm()
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure that helm is present")
+	}
+	err = o.addHelmRepoIfMissing(flaggerRepoUrl, flaggerRepoName)
+	if err != nil {
+		return err
+	}
+
+	values := []string{"meshProvider=istio", "metricsServer=" + o.MetricsURL}
+	values = append(values, strings.Split(o.SetValues, ",")...)
+	err = o.installChart(o.ReleaseName, o.Chart, o.Version, o.Namespace, true, values, nil, "")
+	if err != nil {
+		return errors.Wrap(err, "flagger deployment failed")
+	}
+	log.Infof("Installed Flagger. Use %s to promote via a canary\n", util.ColorInfo("jx promote --canary"))
+	return nil
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/policy"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	gatekeeperRepoName       = "gatekeeper"
+	gatekeeperRepoUrl        = "https://open-policy-agent.github.io/gatekeeper/charts"
+	defaultGatekeeperVersion = ""
+)
+
+var (
+	createAddonGatekeeperLong = templates.LongDesc(`
+		Creates the OPA Gatekeeper policy addon with a starter bundle of policies.
+
+		Gatekeeper denies the pods of the environment and preview namespaces which violate the policies at admission
+		time. The pipelines can evaluate the same policies on the rendered charts before promotion via
+		'jx step validate policies' so that the violations fail in CI first.
+
+		Starter policies: ` + strings.Join(policy.PolicyNames(policy.StarterPolicies), ", ") + `
+`)
+
+	createAddonGatekeeperExample = templates.Examples(`
+		# Create the Gatekeeper addon with all the starter policies
+		jx create addon gatekeeper
+
+		# Create the Gatekeeper addon with some of the starter policies
+		jx create addon gatekeeper --policy no-privileged-containers --policy no-host-namespaces
+
+		# Evaluate the policies on the chart of the current project in a pipeline
+		jx step validate policies --dir charts/myapp
+	`)
+)
+
+// CreateAddonGatekeeperOptions the options for the create addon gatekeeper command
+type CreateAddonGatekeeperOptions struct {
+	CreateAddonOptions
+
+	Chart    string
+	Policies []string
+}
+
+// NewCmdCreateAddonGatekeeper creates a command object for the "create addon gatekeeper" command
+func NewCmdCreateAddonGatekeeper(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &CreateAddonGatekeeperOptions{
+		CreateAddonOptions: CreateAddonOptions{
+			CreateOptions: CreateOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					In:      in,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "gatekeeper",
+		Short:   "Create the OPA Gatekeeper policy addon with a starter bundle of policies",
+		Long:    createAddonGatekeeperLong,
+		Example: createAddonGatekeeperExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	options.addFlags(cmd, kube.DefaultGatekeeperNamespace, kube.DefaultGatekeeperReleaseName, defaultGatekeeperVersion)
+
+	cmd.Flags().StringVarP(&options.Chart, optionChart, "c", kube.ChartGatekeeper, "The name of the chart to use")
+	cmd.Flags().StringArrayVarP(&options.Policies, "policy", "p", []string{}, "The starter policy to enforce. Can be specified multiple times. Defaults to all the starter policies")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateAddonGatekeeperOptions) Run() error {
+	if o.ReleaseName == "" {
+		return util.MissingOption(optionRelease)
+	}
+	if o.Chart == "" {
+		return util.MissingOption(optionChart)
+	}
+	policies, err := policy.FindPolicies(o.Policies)
+	if err != nil {
+		return util.InvalidOption("policy", strings.Join(o.Policies, ", "), policy.PolicyNames(policy.StarterPolicies))
+	}
+	err = o.ensureHelm()
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure that helm is present")
+	}
+	err = o.addHelmRepoIfMissing(gatekeeperRepoUrl, gatekeeperRepoName)
+	if err != nil {
+		return err
+	}
+
+	values := strings.Split(o.SetValues, ",")
+	err = o.installChart(o.ReleaseName, o.Chart, o.Version, o.Namespace, o.HelmUpdate, values, o.ValueFiles, "")
+	if err != nil {
+		return errors.Wrap(err, "gatekeeper deployment failed")
+	}
+	err = o.recordAddon(v1.AddonSettings{
+		Name:      kube.DefaultGatekeeperReleaseName,
+		Chart:     o.Chart,
+		Version:   o.Version,
+		Namespace: o.Namespace,
+		SetValues: values,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to record the addon in the team settings")
+	}
+
+	err = o.applyPolicies(policies)
+	if err != nil {
+		return err
+	}
+	log.Infof("Installed Gatekeeper enforcing the policies %s. Use %s in the pipelines to evaluate them before promotion\n",
+		util.ColorInfo(strings.Join(policy.PolicyNames(policies), ", ")), util.ColorInfo("jx step validate policies"))
+	return nil
+}
+
+// applyPolicies applies the constraint templates of the given policies then their constraints once Gatekeeper has
+// created the custom resource definitions of the templates
+func (o *CreateAddonGatekeeperOptions) applyPolicies(policies []*policy.Policy) error {
+	constraintTemplates := []map[string]interface{}{}
+	constraints := []map[string]interface{}{}
+	for _, p := range policies {
+		constraintTemplates = append(constraintTemplates, policy.ConstraintTemplate(p))
+		constraints = append(constraints, policy.Constraint(p, kube.LabelEnvironment, []string{kube.LabelValueDevEnvironment}))
+	}
+
+	dir, err := ioutil.TempDir("", "jx-gatekeeper-")
+	if err != nil {
+		return errors.Wrap(err, "failed to create a temporary directory")
+	}
+	defer os.RemoveAll(dir)
+
+	templatesFile := filepath.Join(dir, "templates.yaml")
+	err = writePolicyResources(templatesFile, constraintTemplates)
+	if err != nil {
+		return err
+	}
+	constraintsFile := filepath.Join(dir, "constraints.yaml")
+	err = writePolicyResources(constraintsFile, constraints)
+	if err != nil {
+		return err
+	}
+
+	err = util.Retry(2*time.Minute, func() error {
+		return o.RunCommand("kubectl", "apply", "-f", templatesFile)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to apply the Gatekeeper constraint templates")
+	}
+	err = util.Retry(2*time.Minute, func() error {
+		return o.RunCommand("kubectl", "apply", "-f", constraintsFile)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to apply the Gatekeeper constraints")
+	}
+	return nil
+}
+
+func writePolicyResources(fileName string, resources []map[string]interface{}) error {
+	data, err := policy.RenderYAML(resources)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(fileName, data, util.DefaultWritePermissions)
+	if err != nil {
+		return errors.Wrapf(err, "failed to write %s", fileName)
+	}
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
@@ -31,6 +33,9 @@ var (
 	create_addon_gitea_example = templates.Examples(`
 		# Create the Gitea addon 
 		jx create addon gitea
+
+		# Create the Gitea addon and use it as the Git server of the team for new environments and imports
+		jx create addon gitea --team-git-server
 	`)
 )
 
@@ -45,6 +50,8 @@ type CreateAddonGiteaOptions struct {
 	IsAdmin  bool
 	NoUser   bool
 	NoToken  bool
+
+	TeamGitServer bool
 }
 
 // NewCmdCreateAddonGitea creates a command object for the "create" command
@@ -86,6 +93,7 @@ func NewCmdCreateAddonGitea(f Factory, in terminal.FileReader, out terminal.File
 	cmd.Flags().BoolVarP(&options.IsAdmin, "admin", "", false, "Should the new user created be an admin of the Gitea server")
 	cmd.Flags().BoolVarP(&options.NoUser, "no-user", "", false, "If true disable trying to create a new user in the Gitea server")
 	cmd.Flags().BoolVarP(&options.NoToken, "no-token", "", false, "If true disable trying to create a new token in the Gitea server")
+	cmd.Flags().BoolVarP(&options.TeamGitServer, "team-git-server", "", false, "Use the Gitea server as the default Git server of the team so that no external Git provider is required")
 	return cmd
 }
 
@@ -155,8 +163,31 @@ func (o *CreateAddonGiteaOptions) Run() error {
 		}
 	}
 	if !o.NoUser && o.Username != "" && o.Password != "" {
-		return o.createGitToken()
+		err = o.createGitToken()
+		if err != nil {
+			return err
+		}
+	}
+	if o.TeamGitServer {
+		return o.configureTeamGitServer()
+	}
+	return nil
+}
+
+// configureTeamGitServer makes the Gitea server the default Git server of the team
+func (o *CreateAddonGiteaOptions) configureTeamGitServer() error {
+	gitURL, err := o.findService(gitKindToServiceName[gits.KindGitea])
+	if err != nil {
+		return errors.Wrap(err, "finding the Gitea service")
+	}
+	err = o.ModifyDevEnvironment(func(env *v1.Environment) error {
+		env.Spec.TeamSettings.GitServer = gitURL
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "updating the team settings")
 	}
+	log.Infof("New environments and imports of the team will use the Git server %s\n", util.ColorInfo(gitURL))
 	return nil
 }
 
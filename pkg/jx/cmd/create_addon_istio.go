@@ -30,6 +30,9 @@ const (
 var (
 	createAddonIstioLong = templates.LongDesc(`
 		Creates the istio addon for service mesh on Kubernetes
+
+		To promote applications via canaries on top of the service mesh also install the Flagger addon via
+		'jx create addon flagger'
 `)
 
 	createAddonIstioExample = templates.Examples(`
@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/binaries"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultKanikoExecutorImage the kaniko image the executor is installed from. The debug image contains a shell
+	// which is used to copy the executor into the build pods
+	DefaultKanikoExecutorImage = "gcr.io/kaniko-project/executor:debug-v0.9.0"
+
+	// DefaultBuildKitImage the rootless BuildKit image the buildctl and buildkitd binaries are installed from
+	DefaultBuildKitImage = "moby/buildkit:v0.4.0-rootless"
+
+	// DefaultPackInstallImage the image which downloads the pack CLI of Cloud Native Buildpacks into the build pods
+	DefaultPackInstallImage = "buildpack-deps:stretch-curl"
+	// DefaultPackURL the release of the pack CLI installed into the build pods
+	DefaultPackURL = "https://github.com/buildpack/pack/releases/download/v0.2.1/pack-v0.2.1-linux.tgz"
+
+	imageBuilderVolume = "image-builder"
+)
+
+var (
+	createAddonKanikoLong = templates.LongDesc(`
+		Creates the in-cluster image building addon so that the pipelines build images without a Docker daemon.
+
+		The kaniko executor or the rootless BuildKit binaries are installed by an init container into the build pod
+		templates which mount the Docker socket, or into the pod templates given via --pod-template. The Docker
+		socket is removed from those pod templates, the registry credentials of the team are mounted into them instead
+		and the pipelines of the team build images using kaniko or rootless BuildKit. Other pod templates are left
+		unchanged.
+
+		Projects created or imported afterwards build images with the new image builder. To switch an existing
+		project use 'jx step buildpack apply --overwrite'
+`)
+
+	createAddonKanikoExample = templates.Examples(`
+		# Build images using kaniko caching the image layers in the registry
+		jx create addon kaniko
+
+		# Build images using rootless BuildKit
+		jx create addon kaniko --builder buildkit
+
+		# Cache the image layers in a specific repository
+		jx create addon kaniko --cache-repo gcr.io/myproject/cache
+
+		# Only build images with kaniko in the maven and nodejs pod templates
+		jx create addon kaniko --pod-template maven --pod-template nodejs
+	`)
+)
+
+// CreateAddonKanikoOptions the options for the create addon kaniko command
+type CreateAddonKanikoOptions struct {
+	CreateOptions
+
+	Builder          string
+	Secret           string
+	Cache            bool
+	CacheRepo        string
+	KeepDockerSocket bool
+	Image            string
+	PodTemplates     []string
+
+	packChecksum string
+}
+
+// NewCmdCreateAddonKaniko creates a command object for the "create addon kaniko" command
+func NewCmdCreateAddonKaniko(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &CreateAddonKanikoOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "kaniko",
+		Short:   "Create the addon which builds images in the cluster without a Docker daemon",
+		Aliases: []string{"buildkit"},
+		Long:    createAddonKanikoLong,
+		Example: createAddonKanikoExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	cmd.Flags().StringVarP(&options.Builder, "builder", "", string(v1.ImageBuilderKaniko), "The image builder to use. Possible values: kaniko, buildkit")
+	cmd.Flags().StringVarP(&options.Secret, "secret", "", kube.SecretJenkinsDockerConfig, "The Secret containing the Docker config.json with the registry credentials")
+	cmd.Flags().BoolVarP(&options.Cache, "cache", "", true, "Caches the image layers in the registry")
+	cmd.Flags().StringVarP(&options.CacheRepo, "cache-repo", "", "", "The repository the image layers are cached in. Defaults to the image repository with a /cache suffix")
+	cmd.Flags().BoolVarP(&options.KeepDockerSocket, "keep-docker-socket", "", false, "Does not remove the Docker socket from the build pod templates")
+	cmd.Flags().StringVarP(&options.Image, "image", "i", "", "The image the image builder is installed from. Defaults to "+DefaultKanikoExecutorImage+" for kaniko and "+DefaultBuildKitImage+" for buildkit")
+	cmd.Flags().StringArrayVarP(&options.PodTemplates, "pod-template", "p", []string{}, "The pod templates to build images with the image builder. Defaults to the pod templates which mount the Docker socket")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateAddonKanikoOptions) Run() error {
+	builder := v1.ImageBuilderType(o.Builder)
+	builders := []string{string(v1.ImageBuilderKaniko), string(v1.ImageBuilderBuildKit)}
+	if util.StringArrayIndex(builders, o.Builder) < 0 {
+		return util.InvalidOption("builder", o.Builder, builders)
+	}
+	err := o.provisionImageBuilder(builder)
+	if err != nil {
+		return err
+	}
+
+	callback := func(env *v1.Environment) error {
+		teamSettings := &env.Spec.TeamSettings
+		teamSettings.ImageBuilder = builder
+		teamSettings.ImageCache = o.Cache
+		teamSettings.ImageCacheRepo = o.CacheRepo
+		return nil
+	}
+	err = o.ModifyDevEnvironment(callback)
+	if err != nil {
+		return err
+	}
+	log.Infof("The pipelines of the team now build images using %s\n", util.ColorInfo(builder))
+	return nil
+}
+
+// provisionImageBuilder installs the image builder into the pod templates which mount the Docker socket, or into the
+// chosen pod templates, along with the registry credentials
+func (o *CreateAddonKanikoOptions) provisionImageBuilder(builder v1.ImageBuilderType) error {
+	kubeClient, ns, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	_, err = kubeClient.CoreV1().Secrets(ns).Get(o.Secret, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to find the registry credentials Secret %s in namespace %s: %s. You can create it via 'jx create docker auth'", o.Secret, ns, err)
+	}
+	if builder == v1.ImageBuilderBuildpacks {
+		o.packChecksum, err = o.verifiedPackChecksum()
+		if err != nil {
+			return err
+		}
+	}
+
+	provisioned := []string{}
+	err = kube.UpdatePodTemplates(kubeClient, ns, func(name string, pod *corev1.Pod) bool {
+		if len(o.PodTemplates) > 0 {
+			if util.StringArrayIndex(o.PodTemplates, name) < 0 {
+				return false
+			}
+		} else if !kube.HasDockerSocket(pod) {
+			return false
+		}
+		provisioned = append(provisioned, name)
+		return o.provisionPodTemplate(name, pod, builder)
+	})
+	if err != nil {
+		return err
+	}
+	for _, name := range o.PodTemplates {
+		if util.StringArrayIndex(provisioned, name) < 0 {
+			return fmt.Errorf("failed to find the pod template %s in the ConfigMap %s in namespace %s", name, kube.ConfigMapJenkinsPodTemplates, ns)
+		}
+	}
+	if len(provisioned) == 0 {
+		log.Warnf("No pod templates mount the Docker socket so none were provisioned with %s. Use --pod-template to choose them\n", builder)
+	}
+	return nil
+}
+
+// provisionPodTemplate installs the image builder into the pod template, mounts the registry credentials and removes
+// the Docker socket unless it is kept or the image builder needs it returning true if the pod template was modified
+func (o *CreateAddonKanikoOptions) provisionPodTemplate(name string, pod *corev1.Pod, builder v1.ImageBuilderType) bool {
+	modified := false
+	image := o.Image
+	keepDockerSocket := o.KeepDockerSocket
+	switch builder {
+	case v1.ImageBuilderBuildpacks:
+		if image == "" {
+			image = DefaultPackInstallImage
+		}
+		command := []string{"sh", "-c", packInstallCommand(o.packChecksum)}
+		if kube.EnsureInitContainerInstall(pod, imageBuilderVolume, image, command, packBinDir) {
+			modified = true
+		}
+		// pack builds the image using the Docker daemon
+		keepDockerSocket = true
+	case v1.ImageBuilderBuildKit:
+		if image == "" {
+			image = DefaultBuildKitImage
+		}
+		command := []string{"sh", "-c", "cp /usr/bin/buildctl /usr/bin/buildctl-daemonless.sh /usr/bin/buildkitd /usr/bin/rootlesskit " + kube.ImageBuilderInstallPath}
+		if kube.EnsureInitContainerInstall(pod, imageBuilderVolume, image, command, buildKitBinDir) {
+			modified = true
+		}
+		if kube.EnableRootlessBuildKit(pod) {
+			modified = true
+		}
+	default:
+		if image == "" {
+			image = DefaultKanikoExecutorImage
+		}
+		command := []string{"/busybox/sh", "-c", "cp -a " + kanikoDir + "/. " + kube.ImageBuilderInstallPath}
+		if kube.EnsureInitContainerInstall(pod, imageBuilderVolume, image, command, kanikoDir) {
+			modified = true
+		}
+	}
+	if modified {
+		log.Infof("Installed %s into the pod template %s\n", util.ColorInfo(builder), util.ColorInfo(name))
+	}
+	if !keepDockerSocket && kube.RemoveDockerSocket(pod) {
+		log.Infof("Removed the Docker socket from the pod template %s\n", util.ColorInfo(name))
+		modified = true
+	}
+	if kube.EnsureDockerConfigSecret(pod, o.Secret, kube.DockerConfigMountPath) {
+		modified = true
+	}
+	return modified
+}
+
+// verifiedPackChecksum downloads the pack CLI and verifies it against the checksum pinned in the version stream or
+// published with the release, returning the checksum the build pods verify their download against
+func (o *CreateAddonKanikoOptions) verifiedPackChecksum() (string, error) {
+	dir, err := ioutil.TempDir("", "jx-pack-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Base(DefaultPackURL)
+	tarFile := filepath.Join(dir, fileName)
+	checksums := binaries.ChecksumOptions{
+		ChecksumURL: DefaultPackURL + ".sha256",
+		FileName:    fileName,
+		Checksum:    o.versionStream().Checksum(fileName),
+	}
+	err = o.downloadAndVerifyFile(DefaultPackURL, tarFile, checksums)
+	if err != nil {
+		return "", err
+	}
+	return binaries.FileChecksum(tarFile)
+}
+
+// packInstallCommand returns the shell command which downloads the pack CLI, verifies its checksum and extracts it
+// into the image builder volume
+func packInstallCommand(checksum string) string {
+	tarFile := "/tmp/" + filepath.Base(DefaultPackURL)
+	return "curl -sSL -o " + tarFile + " " + DefaultPackURL +
+		" && echo '" + checksum + "  " + tarFile + "' | sha256sum -c -" +
+		" && tar -xzf " + tarFile + " -C " + kube.ImageBuilderInstallPath
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCreateAddonKanikoProvisionPodTemplate(t *testing.T) {
+	t.Parallel()
+	o := &CreateAddonKanikoOptions{
+		Secret: kube.SecretJenkinsDockerConfig,
+	}
+	pod := dockerSocketPod()
+
+	assert.True(t, o.provisionPodTemplate("maven", pod, v1.ImageBuilderKaniko))
+
+	require.Len(t, pod.Spec.InitContainers, 1)
+	initContainer := pod.Spec.InitContainers[0]
+	assert.Equal(t, DefaultKanikoExecutorImage, initContainer.Image)
+	assert.Equal(t, []string{"/busybox/sh", "-c", "cp -a /kaniko/. /install"}, initContainer.Command)
+	assert.Equal(t, []corev1.VolumeMount{{Name: imageBuilderVolume, MountPath: kube.ImageBuilderInstallPath}}, initContainer.VolumeMounts)
+
+	assert.False(t, kube.HasDockerSocket(pod))
+	require.Len(t, pod.Spec.Volumes, 3)
+	assert.Equal(t, "workspace", pod.Spec.Volumes[0].Name)
+	assert.NotNil(t, pod.Spec.Volumes[1].EmptyDir)
+	assert.Equal(t, kube.SecretJenkinsDockerConfig, pod.Spec.Volumes[2].Secret.SecretName)
+
+	container := pod.Spec.Containers[0]
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "workspace", MountPath: "/home/jenkins"},
+		{Name: imageBuilderVolume, MountPath: "/kaniko"},
+		{Name: kube.SecretJenkinsDockerConfig, MountPath: kube.DockerConfigMountPath},
+	}, container.VolumeMounts)
+	assert.Equal(t, []corev1.EnvVar{{Name: "DOCKER_CONFIG", Value: kube.DockerConfigMountPath}}, container.Env)
+
+	assert.False(t, o.provisionPodTemplate("maven", pod, v1.ImageBuilderKaniko), "provisioning again should not modify the pod template")
+}
+
+func TestCreateAddonKanikoProvisionPodTemplateBuildKit(t *testing.T) {
+	t.Parallel()
+	o := &CreateAddonKanikoOptions{
+		Secret:           kube.SecretJenkinsDockerConfig,
+		KeepDockerSocket: true,
+	}
+	pod := dockerSocketPod()
+
+	assert.True(t, o.provisionPodTemplate("maven", pod, v1.ImageBuilderBuildKit))
+
+	require.Len(t, pod.Spec.InitContainers, 1)
+	assert.Equal(t, DefaultBuildKitImage, pod.Spec.InitContainers[0].Image)
+	assert.True(t, kube.HasDockerSocket(pod))
+	assert.Equal(t, "unconfined", pod.Annotations["container.apparmor.security.beta.kubernetes.io/maven"])
+
+	container := pod.Spec.Containers[0]
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: imageBuilderVolume, MountPath: "/buildkit/bin"})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "BUILDKITD_FLAGS", Value: "--oci-worker-no-process-sandbox"})
+}
+
+func TestCreateAddonKanikoProvisionPodTemplateBuildpacks(t *testing.T) {
+	t.Parallel()
+	o := &CreateAddonKanikoOptions{
+		Secret:       kube.SecretJenkinsDockerConfig,
+		packChecksum: "0123456789abcdef",
+	}
+	pod := dockerSocketPod()
+
+	assert.True(t, o.provisionPodTemplate("maven", pod, v1.ImageBuilderBuildpacks))
+
+	require.Len(t, pod.Spec.InitContainers, 1)
+	assert.Equal(t, DefaultPackInstallImage, pod.Spec.InitContainers[0].Image)
+	command := strings.Join(pod.Spec.InitContainers[0].Command, " ")
+	assert.Contains(t, command, "echo '0123456789abcdef  /tmp/pack-v0.2.1-linux.tgz' | sha256sum -c -")
+	assert.True(t, kube.HasDockerSocket(pod), "pack needs the Docker daemon")
+	assert.Contains(t, pod.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: imageBuilderVolume, MountPath: packBinDir})
+}
+
+func dockerSocketPod() *corev1.Pod {
+	return &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "maven",
+					Image: "jenkinsxio/builder-maven:0.1.1",
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "workspace", MountPath: "/home/jenkins"},
+						{Name: "docker-daemon", MountPath: kube.DockerSocketPath},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "workspace",
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+					},
+				},
+				{
+					Name: "docker-daemon",
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{Path: kube.DockerSocketPath},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const defaultOAuth2ProxyVersion = ""
+
+var (
+	createAddonOAuth2ProxyLong = templates.LongDesc(`
+		Creates the oauth2-proxy addon which makes the users of the web UIs of the platform such as Nexus, Monocular
+		and Grafana sign in with GitHub, Google or an OpenID Connect provider.
+
+		Only the members of a GitHub organisation, or of some of its teams, or the users of an email domain can sign in.
+		Jenkins is not protected by default as its webhooks and API must stay reachable.
+
+		The web UIs share a single sign in rather than each one using basic authentication. Register an OAuth
+		application with the identity provider whose callback URL is https://oauth2-proxy.<namespace>.<domain>/oauth2/callback
+		before creating the addon.
+`)
+
+	createAddonOAuth2ProxyExample = templates.Examples(`
+		# Make the members of a GitHub organisation sign in to the web UIs
+		jx create addon oauth2-proxy --provider github --github-org myorg --client-id abc --client-secret def
+
+		# Only let the members of some teams of a GitHub organisation sign in
+		jx create addon oauth2-proxy --provider github --github-org myorg --github-team developers,admins
+
+		# Make the users of a Google domain sign in to the web UIs
+		jx create addon oauth2-proxy --provider google --email-domain example.com
+
+		# Sign in with an OpenID Connect provider
+		jx create addon oauth2-proxy --provider oidc --oidc-issuer-url https://accounts.example.com --email-domain example.com
+	`)
+)
+
+// CreateAddonOAuth2ProxyOptions the options for the create addon oauth2-proxy command
+type CreateAddonOAuth2ProxyOptions struct {
+	CreateAddonOptions
+
+	OAuth2Proxy OAuth2ProxyFlags
+}
+
+// NewCmdCreateAddonOAuth2Proxy creates a command object for the "create addon oauth2-proxy" command
+func NewCmdCreateAddonOAuth2Proxy(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &CreateAddonOAuth2ProxyOptions{
+		CreateAddonOptions: CreateAddonOptions{
+			CreateOptions: CreateOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					In:      in,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "oauth2-proxy",
+		Short:   "Create the oauth2-proxy addon so that users sign in to the web UIs with an identity provider",
+		Aliases: []string{"oauth2proxy"},
+		Long:    createAddonOAuth2ProxyLong,
+		Example: createAddonOAuth2ProxyExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	options.addFlags(cmd, "", kube.DefaultOAuth2ProxyReleaseName, defaultOAuth2ProxyVersion)
+	options.OAuth2Proxy.addFlags(cmd, "")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateAddonOAuth2ProxyOptions) Run() error {
+	if o.ReleaseName == "" {
+		return util.MissingOption(optionRelease)
+	}
+	err := o.ensureHelm()
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure that helm is present")
+	}
+	if o.Namespace == "" {
+		_, o.Namespace, err = o.KubeClientAndDevNamespace()
+		if err != nil {
+			return err
+		}
+	}
+	values := []string{}
+	if o.SetValues != "" {
+		values = strings.Split(o.SetValues, ",")
+	}
+	err = o.installOAuth2Proxy(o.Namespace, o.ReleaseName, o.Version, o.OAuth2Proxy, values)
+	if err != nil {
+		return err
+	}
+	err = o.recordAddon(v1.AddonSettings{
+		Name:      kube.DefaultOAuth2ProxyReleaseName,
+		Chart:     kube.ChartOAuth2Proxy,
+		Version:   o.Version,
+		Namespace: o.Namespace,
+	})
+	if err != nil {
+		log.Warnf("Failed to record the addon in the team settings: %s\n", err)
+	}
+	log.Infof("Installed the oauth2-proxy. Users now sign in to the web UIs with %s\n", util.ColorInfo(o.OAuth2Proxy.Provider))
+	return nil
+}
@@ -7,8 +7,12 @@ import (
 	"path"
 	"strings"
 
+	"github.com/jenkins-x/jx/pkg/addon"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/pborman/uuid"
 	"github.com/pkg/errors"
@@ -16,10 +20,44 @@ import (
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 	"gopkg.in/yaml.v2"
 	core_v1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	prometheusRepoName      = "prometheus-community"
+	prometheusRepoUrl       = "https://prometheus-community.github.io/helm-charts"
+	prometheusIngressSecret = "prometheus-ingress"
+)
+
+var (
+	createAddonPrometheusLong = templates.LongDesc(`
+		Creates the Prometheus and Grafana monitoring addon.
+
+		Prometheus and Grafana are exposed via the Ingress entries http://prometheus.jx.your.domain.com and
+		http://grafana.jx.your.domain.com secured with basic authentication. The admin username is 'admin' and the
+		default password is 'admin' (see the --password flag).
+
+		Grafana is preconfigured with Jenkins X dashboards for the pipeline activity, the resource usage of
+		the build pods and the deployments in the environments. Use 'jx open grafana' to view them.
+
+		The addon installs the kube-prometheus-stack chart as the kube-prometheus-stack addon, which requires Helm 3.
+		Releases of the stable/prometheus chart installed as the prometheus addon are left untouched.
+`)
+
+	createAddonPrometheusExample = templates.Examples(`
+		# Create the Prometheus and Grafana monitoring addon
+		jx create addon prometheus
+
+		# Create the addon with a different admin password
+		jx create addon prometheus --password mysecret
+
+		# Open the Grafana dashboards
+		jx open grafana
+	`)
+)
+
+// CreateAddonPrometheusOptions the options for the create addon prometheus command
 type CreateAddonPrometheusOptions struct {
 	CreateOptions
 
@@ -31,6 +69,7 @@ type CreateAddonPrometheusOptions struct {
 	Password    string
 }
 
+// NewCmdCreateAddonPrometheus creates a command object for the "create addon prometheus" command
 func NewCmdCreateAddonPrometheus(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
 	options := &CreateAddonPrometheusOptions{
 		CreateOptions: CreateOptions{
@@ -45,13 +84,11 @@ func NewCmdCreateAddonPrometheus(f Factory, in terminal.FileReader, out terminal
 	}
 
 	cmd := &cobra.Command{
-		Use:   "prometheus",
-		Short: "Creates a prometheus addon",
-		Long: `Creates a prometheus addon.
-
-By default Prometheus Server is exposed via Ingress entry http://prometheus.jx.your.domain.com secured
-with basic authentication. Admin username is 'admin' and default password is 'admin' (see --password flag).
-`,
+		Use:     "prometheus",
+		Short:   "Creates the Prometheus and Grafana monitoring addon",
+		Long:    createAddonPrometheusLong,
+		Example: createAddonPrometheusExample,
+		Aliases: []string{"monitoring", kube.DefaultKubePrometheusStackReleaseName},
 		Run: func(cmd *cobra.Command, args []string) {
 			options.Cmd = cmd
 			options.Args = args
@@ -60,53 +97,84 @@ with basic authentication. Admin username is 'admin' and default password is 'ad
 		},
 	}
 
-	options.addFlags(cmd, kube.DefaultNamespace, "prometheus")
+	options.addFlags(cmd, kube.DefaultNamespace, kube.DefaultKubePrometheusStackReleaseName)
 	return cmd
 }
 
 func (options *CreateAddonPrometheusOptions) addFlags(cmd *cobra.Command, defaultNamespace string, defaultOptionRelease string) {
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", defaultNamespace, "The Namespace to install into")
 	cmd.Flags().StringVarP(&options.ReleaseName, optionRelease, "r", defaultOptionRelease, "The chart release name")
+	cmd.Flags().StringVarP(&options.Version, "version", "v", "", "The version of the kube-prometheus-stack chart to install")
 	cmd.Flags().BoolVarP(&options.HelmUpdate, "helm-update", "", true, "Should we run helm update first to ensure we use the latest version")
 	cmd.Flags().StringVarP(&options.SetValues, "set", "s", "", "The chart set values (can specify multiple or separate values with commas: key1=val1,key2=val2)")
-	cmd.Flags().StringVarP(&options.Password, "password", "", "admin", "Admin password to access Prometheus web UI.")
+	cmd.Flags().StringVarP(&options.Password, "password", "", "admin", "Admin password to access the Prometheus and Grafana web UIs")
 }
 
+// Run implements this command
 func (o *CreateAddonPrometheusOptions) Run() error {
 	err := o.ensureHelm()
 	if err != nil {
 		return errors.Wrap(err, "failed to ensure that helm is present")
 	}
+	if helm.DetectVersion(o.Helm().HelmBinary()) != helm.V3 {
+		return fmt.Errorf("the %s chart requires Helm 3 but %s is Helm 2. Install jx with Helm 3 to use the monitoring addon",
+			kube.ChartKubePrometheusStack, o.Helm().HelmBinary())
+	}
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+
+	err = o.addHelmRepoIfMissing(prometheusRepoUrl, prometheusRepoName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to add the %s helm repository", prometheusRepoName)
+	}
 
 	data := make(map[string][]byte)
 	hash := util.HashPassword(o.Password)
 	data[kube.AUTH] = []byte(fmt.Sprintf("admin:{SHA}%s", hash))
 	sec := &core_v1.Secret{
 		Data: data,
-		ObjectMeta: v1.ObjectMeta{
-			Name: "prometheus-ingress",
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: prometheusIngressSecret,
 		},
 	}
-	_, err = o.KubeClientCached.CoreV1().Secrets(o.Namespace).Create(sec)
+	secrets := client.CoreV1().Secrets(o.Namespace)
+	_, err = secrets.Create(sec)
+	if apierrors.IsAlreadyExists(err) {
+		_, err = secrets.Update(sec)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot create secret %s in target namespace %s: %v", prometheusIngressSecret, o.Namespace, err)
+	}
+
+	_, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	err = o.createGrafanaDashboards(o.Namespace, devNs)
 	if err != nil {
-		return fmt.Errorf("cannot create secret %s in target namespace %s: %v", "prometheus-ingress", o.Namespace, err)
+		return err
 	}
 
-	ingressConfig, err := o.KubeClientCached.CoreV1().ConfigMaps(o.Namespace).Get("ingress-config", meta_v1.GetOptions{})
+	ingressConfig, err := client.CoreV1().ConfigMaps(o.Namespace).Get("ingress-config", meta_v1.GetOptions{})
 	if err != nil {
 		return errors.Wrap(err, "Cannot get ingress config map.")
 	}
+	domain := ingressConfig.Data["domain"]
 
-	values := map[string]map[string]map[string]interface{}{
-		"server": {
-			"ingress": {
-				"enabled": true,
-				"hosts":   []string{"prometheus.jx." + ingressConfig.Data["domain"]},
-				"annotations": map[string]string{
-					"kubernetes.io/ingress.class":             "nginx",
-					"nginx.ingress.kubernetes.io/auth-type":   "basic",
-					"nginx.ingress.kubernetes.io/auth-secret": "prometheus-ingress",
-					"nginx.ingress.kubernetes.io/auth-realm":  "Authentication required to access Prometheus.",
+	values := map[string]interface{}{
+		"prometheus": map[string]interface{}{
+			"ingress": prometheusIngressValues("prometheus.jx."+domain, "Prometheus"),
+		},
+		"grafana": map[string]interface{}{
+			"adminPassword": o.Password,
+			"ingress":       prometheusIngressValues("grafana.jx."+domain, "Grafana"),
+			"sidecar": map[string]interface{}{
+				"dashboards": map[string]interface{}{
+					"enabled":         true,
+					"label":           addon.GrafanaDashboardLabel,
+					"searchNamespace": o.Namespace,
 				},
 			},
 		},
@@ -115,8 +183,8 @@ func (o *CreateAddonPrometheusOptions) Run() error {
 	if err != nil {
 		return err
 	}
-	prometheusIngressConfig := path.Join("/tmp", "prometheusIngressConfig_"+uuid.New())
-	err = ioutil.WriteFile(prometheusIngressConfig, valuesBytes, 0644)
+	prometheusValuesFile := path.Join("/tmp", "prometheusIngressConfig_"+uuid.New())
+	err = ioutil.WriteFile(prometheusValuesFile, valuesBytes, 0644)
 	if err != nil {
 		return err
 	}
@@ -124,15 +192,71 @@ func (o *CreateAddonPrometheusOptions) Run() error {
 	setValues := strings.Split(o.SetValues, ",")
 	err = o.installChartOptions(helm.InstallChartOptions{
 		ReleaseName: o.ReleaseName,
-		Chart:       "stable/prometheus",
+		Chart:       kube.ChartKubePrometheusStack,
 		Version:     o.Version,
 		Ns:          o.Namespace,
 		HelmUpdate:  o.HelmUpdate,
-		ValueFiles:  []string{prometheusIngressConfig},
+		ValueFiles:  []string{prometheusValuesFile},
 		SetValues:   setValues,
 	})
 	if err != nil {
-		return fmt.Errorf("Failed to install chart %s: %s", "prometheus", err)
+		return fmt.Errorf("Failed to install chart %s: %s", kube.ChartKubePrometheusStack, err)
+	}
+	err = o.recordAddon(v1.AddonSettings{
+		Name:      kube.DefaultKubePrometheusStackReleaseName,
+		Chart:     kube.ChartKubePrometheusStack,
+		Version:   o.Version,
+		Namespace: o.Namespace,
+		SetValues: setValues,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to record the addon in the team settings")
+	}
+	log.Infof("Installed the monitoring addon. Use %s to view the dashboards\n", util.ColorInfo("jx open grafana"))
+	return nil
+}
+
+// createGrafanaDashboards creates or updates the ConfigMaps of the Jenkins X dashboards which the Grafana sidecar loads
+// from the given namespace. The dashboards show the builds and environments of the team of the dev namespace
+func (o *CreateAddonPrometheusOptions) createGrafanaDashboards(ns string, devNs string) error {
+	dashboards, err := addon.GrafanaDashboards(devNs)
+	if err != nil {
+		return err
+	}
+	configMaps := o.KubeClientCached.CoreV1().ConfigMaps(ns)
+	for _, name := range util.SortedMapKeys(dashboards) {
+		cm := &core_v1.ConfigMap{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					addon.GrafanaDashboardLabel: "1",
+				},
+			},
+			Data: map[string]string{
+				name + ".json": dashboards[name],
+			},
+		}
+		_, err = configMaps.Create(cm)
+		if apierrors.IsAlreadyExists(err) {
+			_, err = configMaps.Update(cm)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to save the Grafana dashboard ConfigMap %s in namespace %s", name, ns)
+		}
 	}
 	return nil
 }
+
+// prometheusIngressValues returns the chart values of an ingress secured with the basic authentication of the addon
+func prometheusIngressValues(host string, realm string) map[string]interface{} {
+	return map[string]interface{}{
+		"enabled": true,
+		"hosts":   []string{host},
+		"annotations": map[string]string{
+			"kubernetes.io/ingress.class":             "nginx",
+			"nginx.ingress.kubernetes.io/auth-type":   "basic",
+			"nginx.ingress.kubernetes.io/auth-secret": prometheusIngressSecret,
+			"nginx.ingress.kubernetes.io/auth-realm":  fmt.Sprintf("Authentication required to access %s.", realm),
+		},
+	}
+}
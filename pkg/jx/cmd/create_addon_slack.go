@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/notify"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	defaultSlackNotificationName = "slack"
+	slackNotificationSecretName  = "jx-notify-slack"
+)
+
+var (
+	createAddonSlackLong = templates.LongDesc(`
+		Creates the Slack notification addon.
+
+		Posts pipeline starts, successes and failures, promotions and the URLs of preview environments to a Slack
+		channel using the token of a Slack bot. The bot must be invited into the channel.
+
+		Which events are posted can be filtered by event and by repository. The messages are Go templates which
+		can be customised in the notifications of the team settings.
+`)
+
+	createAddonSlackExample = templates.Examples(`
+		# Create the Slack addon posting all events to the #builds channel
+		jx create addon slack --channel "#builds"
+
+		# Only post failed pipelines and promotions of a repository
+		jx create addon slack --channel "#myapp" --events pipeline-failed --events promoted --repo myorg/myapp
+	`)
+)
+
+// CreateAddonSlackOptions the options for the create addon slack command
+type CreateAddonSlackOptions struct {
+	CreateAddonOptions
+
+	Name         string
+	Token        string
+	Channel      string
+	Events       []string
+	Repositories []string
+}
+
+// NewCmdCreateAddonSlack creates a command object for the "create addon slack" command
+func NewCmdCreateAddonSlack(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &CreateAddonSlackOptions{
+		CreateAddonOptions: CreateAddonOptions{
+			CreateOptions: CreateOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					In:      in,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "slack",
+		Short:   "Create the Slack notification addon",
+		Long:    createAddonSlackLong,
+		Example: createAddonSlackExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	cmd.Flags().StringVarP(&options.Name, "name", "n", defaultSlackNotificationName, "The name of the notification channel")
+	cmd.Flags().StringVarP(&options.Token, "token", "t", "", "The token of the Slack bot")
+	cmd.Flags().StringVarP(&options.Channel, "channel", "c", "", "The Slack channel to post to such as #builds")
+	cmd.Flags().StringArrayVarP(&options.Events, optionEvents, "e", []string{}, "The events to post. Defaults to all events")
+	cmd.Flags().StringArrayVarP(&options.Repositories, optionRepo, "r", []string{}, "The repositories, as owner/name or name, whose events are posted. Defaults to all repositories")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateAddonSlackOptions) Run() error {
+	if o.Name == "" {
+		return util.MissingOption("name")
+	}
+	err := validateNotificationEvents(o.Events)
+	if err != nil {
+		return err
+	}
+	if o.Channel == "" {
+		if o.BatchMode {
+			return util.MissingOption("channel")
+		}
+		o.Channel, err = util.PickValue("Slack channel:", "#builds", true, "The Slack channel to post to", o.In, o.Out, o.Err)
+		if err != nil {
+			return err
+		}
+	}
+	if o.Token == "" {
+		if o.BatchMode {
+			return util.MissingOption("token")
+		}
+		o.Token, err = util.PickPassword("Slack bot token:", "The Bot User OAuth Access Token of the Slack app", o.In, o.Out, o.Err)
+		if err != nil {
+			return err
+		}
+	}
+
+	channel := v1.NotificationChannel{
+		Name:         o.Name,
+		Kind:         v1.NotificationKindTypeSlack,
+		Channel:      o.Channel,
+		SecretName:   slackNotificationSecretName,
+		Events:       o.Events,
+		Repositories: o.Repositories,
+	}
+	if o.Name != defaultSlackNotificationName {
+		channel.SecretName = slackNotificationSecretName + "-" + o.Name
+	}
+	err = o.saveNotificationChannel(channel, map[string]string{
+		notify.SecretKeyToken: o.Token,
+	})
+	if err != nil {
+		return err
+	}
+	log.Infof("Events will be posted to the Slack channel %s\n", util.ColorInfo(o.Channel))
+	return nil
+}
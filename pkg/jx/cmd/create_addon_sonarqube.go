@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	core_v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/kube/services"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/sonarqube"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	defaultSonarQubeVersion  = ""
+	defaultSonarQubeUser     = "admin"
+	defaultSonarQubePassword = "admin"
+	sonarQubePort            = 9000
+	sonarQubeTokenName       = "jenkins-x"
+
+	sonarQubeSecretURL         = "url"
+	sonarQubeSecretExternalURL = "external-url"
+	sonarQubeSecretToken       = "token"
+)
+
+var (
+	createAddonSonarQubeLong = templates.LongDesc(`
+		Creates the SonarQube static analysis addon.
+
+		The pipelines of projects created or imported afterwards with a supported build pack analyse the source
+		code after the build, wait for the quality gate of the analysis and report its status on the Pull Request.
+		To add the analysis to an existing project use 'jx step buildpack apply --overwrite'
+
+		Supported build packs: ` + strings.Join(sonarqube.SupportedBuildPacks, ", ") + `
+`)
+
+	createAddonSonarQubeExample = templates.Examples(`
+		# Create the SonarQube addon
+		jx create addon sonarqube
+
+		# Open the SonarQube dashboard in a browser
+		jx open sonar
+	`)
+)
+
+// CreateAddonSonarQubeOptions the options for the create addon sonarqube command
+type CreateAddonSonarQubeOptions struct {
+	CreateAddonOptions
+
+	Chart    string
+	Username string
+	Password string
+}
+
+// NewCmdCreateAddonSonarQube creates a command object for the "create addon sonarqube" command
+func NewCmdCreateAddonSonarQube(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &CreateAddonSonarQubeOptions{
+		CreateAddonOptions: CreateAddonOptions{
+			CreateOptions: CreateOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					In:      in,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "sonarqube",
+		Short:   "Create the SonarQube static analysis addon",
+		Aliases: []string{"sonar"},
+		Long:    createAddonSonarQubeLong,
+		Example: createAddonSonarQubeExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	options.addFlags(cmd, kube.DefaultNamespace, kube.DefaultSonarQubeReleaseName, defaultSonarQubeVersion)
+
+	cmd.Flags().StringVarP(&options.Chart, optionChart, "c", kube.ChartSonarQube, "The name of the chart to use")
+	cmd.Flags().StringVarP(&options.Username, "username", "", defaultSonarQubeUser, "The name of the SonarQube administrator used to generate the token of the pipelines")
+	cmd.Flags().StringVarP(&options.Password, "password", "p", defaultSonarQubePassword, "The password of the SonarQube administrator used to generate the token of the pipelines")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateAddonSonarQubeOptions) Run() error {
+	if o.ReleaseName == "" {
+		return util.MissingOption(optionRelease)
+	}
+	if o.Chart == "" {
+		return util.MissingOption(optionChart)
+	}
+	err := o.ensureHelm()
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure that helm is present")
+	}
+	client, devNamespace, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+
+	values := strings.Split(o.SetValues, ",")
+	err = o.installChart(o.ReleaseName, o.Chart, o.Version, o.Namespace, o.HelmUpdate, values, o.ValueFiles, "")
+	if err != nil {
+		return errors.Wrap(err, "sonarqube deployment failed")
+	}
+
+	serviceName := o.ReleaseName + "-sonarqube"
+	log.Info("waiting for the SonarQube deployment to be ready, this can take a few minutes\n")
+	err = kube.WaitForDeploymentToBeReady(client, serviceName, o.Namespace, 10*time.Minute)
+	if err != nil {
+		return err
+	}
+	err = o.exposeAddonService(kube.DefaultSonarQubeReleaseName, serviceName)
+	if err != nil {
+		return err
+	}
+	externalURL, err := services.GetServiceURLFromName(client, serviceName, o.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get the external URL of service %s: %v", serviceName, err)
+	}
+
+	// the pipelines access SonarQube via the internal service URL
+	internalURL := fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", serviceName, o.Namespace, sonarQubePort)
+	sonarClient := sonarqube.NewClient(externalURL, "")
+	sonarClient.Username = o.Username
+	sonarClient.Password = o.Password
+	token := ""
+	err = o.retry(10, 10*time.Second, func() error {
+		token, err = sonarClient.GenerateToken(sonarQubeTokenName)
+		return err
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to generate the SonarQube token of the pipelines")
+	}
+
+	sec := &core_v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: kube.SecretSonarQube,
+		},
+		Data: map[string][]byte{
+			sonarQubeSecretURL:         []byte(internalURL),
+			sonarQubeSecretExternalURL: []byte(externalURL),
+			sonarQubeSecretToken:       []byte(token),
+		},
+	}
+	secrets := client.CoreV1().Secrets(devNamespace)
+	_, err = secrets.Create(sec)
+	if apierrors.IsAlreadyExists(err) {
+		_, err = secrets.Update(sec)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot create secret %s in namespace %s: %v", kube.SecretSonarQube, devNamespace, err)
+	}
+
+	err = o.recordAddon(v1.AddonSettings{
+		Name:      kube.DefaultSonarQubeReleaseName,
+		Chart:     o.Chart,
+		Version:   o.Version,
+		Namespace: o.Namespace,
+		SetValues: values,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to record the addon in the team settings")
+	}
+	log.Infof("SonarQube is available at %s. The pipelines of the supported build packs now analyse the source code\n", util.ColorInfo(externalURL))
+	return nil
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/notify"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	defaultTeamsNotificationName = "teams"
+	teamsNotificationSecretName  = "jx-notify-teams"
+)
+
+var (
+	createAddonTeamsLong = templates.LongDesc(`
+		Creates the Microsoft Teams notification addon.
+
+		Posts pipeline starts, successes and failures, promotions and the URLs of preview environments to a
+		Microsoft Teams channel using an Incoming Webhook connector of the channel.
+`)
+
+	createAddonTeamsExample = templates.Examples(`
+		# Create the Microsoft Teams addon
+		jx create addon teams --webhook-url https://outlook.office.com/webhook/...
+
+		# Only post promotions
+		jx create addon teams --events promoted
+	`)
+)
+
+// CreateAddonTeamsOptions the options for the create addon teams command
+type CreateAddonTeamsOptions struct {
+	CreateAddonOptions
+
+	Name         string
+	WebhookURL   string
+	Events       []string
+	Repositories []string
+}
+
+// NewCmdCreateAddonTeams creates a command object for the "create addon teams" command
+func NewCmdCreateAddonTeams(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &CreateAddonTeamsOptions{
+		CreateAddonOptions: CreateAddonOptions{
+			CreateOptions: CreateOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					In:      in,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "teams",
+		Short:   "Create the Microsoft Teams notification addon",
+		Long:    createAddonTeamsLong,
+		Example: createAddonTeamsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	cmd.Flags().StringVarP(&options.Name, "name", "n", defaultTeamsNotificationName, "The name of the notification channel")
+	cmd.Flags().StringVarP(&options.WebhookURL, "webhook-url", "w", "", "The URL of the Incoming Webhook connector of the Teams channel")
+	cmd.Flags().StringArrayVarP(&options.Events, optionEvents, "e", []string{}, "The events to post. Defaults to all events")
+	cmd.Flags().StringArrayVarP(&options.Repositories, optionRepo, "r", []string{}, "The repositories, as owner/name or name, whose events are posted. Defaults to all repositories")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateAddonTeamsOptions) Run() error {
+	if o.Name == "" {
+		return util.MissingOption("name")
+	}
+	err := validateNotificationEvents(o.Events)
+	if err != nil {
+		return err
+	}
+	if o.WebhookURL == "" {
+		if o.BatchMode {
+			return util.MissingOption("webhook-url")
+		}
+		o.WebhookURL, err = util.PickPassword("Incoming Webhook URL:", "The URL of the Incoming Webhook connector of the Teams channel", o.In, o.Out, o.Err)
+		if err != nil {
+			return err
+		}
+	}
+
+	channel := v1.NotificationChannel{
+		Name:         o.Name,
+		Kind:         v1.NotificationKindTypeTeams,
+		SecretName:   teamsNotificationSecretName,
+		Events:       o.Events,
+		Repositories: o.Repositories,
+	}
+	if o.Name != defaultTeamsNotificationName {
+		channel.SecretName = teamsNotificationSecretName + "-" + o.Name
+	}
+	err = o.saveNotificationChannel(channel, map[string]string{
+		notify.SecretKeyURL: o.WebhookURL,
+	})
+	if err != nil {
+		return err
+	}
+	log.Infof("Events will be posted to the Microsoft Teams channel %s\n", util.ColorInfo(o.Name))
+	return nil
+}
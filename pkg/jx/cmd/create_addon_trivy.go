@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	trivyRepoName       = "aquasecurity"
+	trivyRepoUrl        = "https://aquasecurity.github.io/helm-charts/"
+	defaultTrivyVersion = ""
+	trivyServerPort     = 4954
+)
+
+var (
+	createAddonTrivyLong = templates.LongDesc(`
+		Creates the Trivy image scanning addon.
+
+		The pipelines scan the images they build for vulnerabilities using the Trivy server, record the results
+		as ImageScan resources and comment the findings on Pull Requests.
+
+		To prevent promoting images with vulnerabilities use 'jx edit securitypolicy'
+`)
+
+	createAddonTrivyExample = templates.Examples(`
+		# Create the Trivy addon
+		jx create addon trivy
+
+		# Prevent promoting images with high or critical vulnerabilities to production
+		jx edit securitypolicy --max-severity medium
+	`)
+)
+
+// CreateAddonTrivyOptions the options for the create addon trivy command
+type CreateAddonTrivyOptions struct {
+	CreateAddonOptions
+
+	Chart string
+}
+
+// NewCmdCreateAddonTrivy creates a command object for the "create addon trivy" command
+func NewCmdCreateAddonTrivy(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &CreateAddonTrivyOptions{
+		CreateAddonOptions: CreateAddonOptions{
+			CreateOptions: CreateOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					In:      in,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "trivy",
+		Short:   "Create the Trivy image scanning addon",
+		Long:    createAddonTrivyLong,
+		Example: createAddonTrivyExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	options.addFlags(cmd, kube.DefaultNamespace, kube.DefaultTrivyReleaseName, defaultTrivyVersion)
+
+	cmd.Flags().StringVarP(&options.Chart, optionChart, "c", kube.ChartTrivy, "The name of the chart to use")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateAddonTrivyOptions) Run() error {
+	if o.ReleaseName == "" {
+		return util.MissingOption(optionRelease)
+	}
+	if o.Chart == "" {
+		return util.MissingOption(optionChart)
+	}
+	err := o.ensureHelm()
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure that helm is present")
+	}
+	err = o.addHelmRepoIfMissing(trivyRepoUrl, trivyRepoName)
+	if err != nil {
+		return err
+	}
+
+	values := strings.Split(o.SetValues, ",")
+	err = o.installChart(o.ReleaseName, o.Chart, o.Version, o.Namespace, o.HelmUpdate, values, o.ValueFiles, "")
+	if err != nil {
+		return errors.Wrap(err, "trivy deployment failed")
+	}
+	err = o.recordAddon(v1.AddonSettings{
+		Name:      kube.DefaultTrivyReleaseName,
+		Chart:     o.Chart,
+		Version:   o.Version,
+		Namespace: o.Namespace,
+		SetValues: values,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to record the addon in the team settings")
+	}
+	log.Infof("Installed Trivy. The pipelines now scan the images they build. Use %s to prevent promoting vulnerable images\n", util.ColorInfo("jx edit securitypolicy"))
+	return nil
+}
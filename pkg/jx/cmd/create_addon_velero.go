@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	defaultVeleroVersion = ""
+)
+
+var (
+	createAddonVeleroLong = templates.LongDesc(`
+		Creates the Velero addon which backs up the state of Jenkins X to a cloud storage bucket.
+
+		The backups contain the development namespace, the namespaces of the permanent environments and the cluster
+		wide resources they use such as the Custom Resource Definitions of Jenkins X. Use 'jx create backup' to back up
+		on demand and 'jx restore' to restore a backup, for example onto a new cluster.
+`)
+
+	createAddonVeleroExample = templates.Examples(`
+		# Create the Velero addon backing up to a Google Cloud Storage bucket every night
+		jx create addon velero --backup-bucket my-jx-backups --backup-credentials-file velero-sa.json --schedule "0 2 * * *"
+
+		# Create the Velero addon backing up to an S3 bucket
+		jx create addon velero --backup-provider aws --backup-bucket my-jx-backups --backup-credentials-file credentials-velero
+	`)
+)
+
+// CreateAddonVeleroOptions the options for the create addon velero command
+type CreateAddonVeleroOptions struct {
+	CreateAddonOptions
+
+	Backup BackupFlags
+}
+
+// NewCmdCreateAddonVelero creates a command object for the "create addon velero" command
+func NewCmdCreateAddonVelero(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &CreateAddonVeleroOptions{
+		CreateAddonOptions: CreateAddonOptions{
+			CreateOptions: CreateOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					In:      in,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "velero",
+		Short:   "Create the Velero addon for backing up Jenkins X",
+		Aliases: []string{"backups"},
+		Long:    createAddonVeleroLong,
+		Example: createAddonVeleroExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	options.addFlags(cmd, kube.DefaultVeleroNamespace, kube.DefaultVeleroReleaseName, defaultVeleroVersion)
+
+	cmd.Flags().StringVarP(&options.Backup.Provider, "backup-provider", "", "", "The cloud provider of the bucket the backups are stored in. Defaults to the provider of the cluster. One of: "+strings.Join(BackupProviders, ", "))
+	cmd.Flags().StringVarP(&options.Backup.Bucket, "backup-bucket", "", "", "The name of the bucket the backups are stored in")
+	cmd.Flags().StringVarP(&options.Backup.CredentialsFile, "backup-credentials-file", "", "", "The file with the cloud credentials Velero uses to access the bucket. Defaults to the credentials of the nodes")
+	cmd.Flags().StringVarP(&options.Backup.Schedule, "schedule", "", "", "The cron expression of the scheduled backups. No backups are scheduled if not specified")
+	cmd.Flags().StringVarP(&options.Backup.TTL, "ttl", "", defaultBackupTTL, "How long the scheduled backups are kept for")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateAddonVeleroOptions) Run() error {
+	if o.ReleaseName == "" {
+		return util.MissingOption(optionRelease)
+	}
+	err := o.ensureHelm()
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure that helm is present")
+	}
+	if o.Backup.Provider == "" {
+		settings, err := o.TeamSettings()
+		if err != nil {
+			return err
+		}
+		o.Backup.Provider, err = defaultBackupProvider(settings.KubeProvider)
+		if err != nil {
+			return err
+		}
+	}
+	values := []string{}
+	if o.SetValues != "" {
+		values = strings.Split(o.SetValues, ",")
+	}
+	err = o.installVelero(o.Namespace, o.ReleaseName, o.Version, o.Backup, values)
+	if err != nil {
+		return err
+	}
+	log.Infof("Installed Velero. Use %s to back up Jenkins X now\n", util.ColorInfo("jx create backup"))
+	return nil
+}
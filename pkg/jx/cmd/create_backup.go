@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+var (
+	createBackupLong = templates.LongDesc(`
+		Backs up the state of Jenkins X using Velero.
+
+		The backup contains the development namespace, the namespaces of the permanent environments and the cluster
+		wide resources they use such as the Custom Resource Definitions, Secrets and Environments of Jenkins X.
+		Install Velero first with 'jx create addon velero'.
+`)
+
+	createBackupExample = templates.Examples(`
+		# Back up Jenkins X now
+		jx create backup
+
+		# Back up Jenkins X before an upgrade and wait for the backup to complete
+		jx create backup --name before-upgrade --wait
+	`)
+)
+
+// CreateBackupOptions the options for the create backup command
+type CreateBackupOptions struct {
+	CreateOptions
+
+	Name            string
+	VeleroNamespace string
+	TTL             string
+	Wait            bool
+	Timeout         string
+}
+
+// NewCmdCreateBackup creates a command object for the "create backup" command
+func NewCmdCreateBackup(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &CreateBackupOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "backup",
+		Short:   "Backs up the state of Jenkins X",
+		Aliases: []string{"backups"},
+		Long:    createBackupLong,
+		Example: createBackupExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	cmd.Flags().StringVarP(&options.Name, optionName, "n", "", "The name of the backup. Defaults to a timestamped name")
+	cmd.Flags().StringVarP(&options.VeleroNamespace, "velero-namespace", "", kube.DefaultVeleroNamespace, "The namespace Velero is installed in")
+	cmd.Flags().StringVarP(&options.TTL, "ttl", "", defaultBackupTTL, "How long the backup is kept for")
+	cmd.Flags().BoolVarP(&options.Wait, "wait", "w", false, "Waits for the backup to complete")
+	cmd.Flags().StringVarP(&options.Timeout, "timeout", "t", "30m", "The maximum duration to wait for the backup to complete")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateBackupOptions) Run() error {
+	name := o.Name
+	if name == "" {
+		name = "jx-" + time.Now().Format("20060102150405")
+	}
+	timeout, err := time.ParseDuration(o.Timeout)
+	if err != nil {
+		return util.InvalidOptionError("timeout", o.Timeout, err)
+	}
+	namespaces, err := o.backupNamespaces()
+	if err != nil {
+		return err
+	}
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	err = kube.CreateVeleroBackup(client, o.VeleroNamespace, name, kube.NewVeleroBackupSpec(namespaces, o.TTL))
+	if err != nil {
+		return err
+	}
+	log.Infof("Backing up the namespaces %s to %s\n", util.ColorInfo(namespaces), util.ColorInfo(name))
+	if !o.Wait {
+		log.Infof("Use %s to follow the backup\n", util.ColorInfo(fmt.Sprintf("kubectl describe backups %s -n %s", name, o.VeleroNamespace)))
+		return nil
+	}
+	err = o.waitForVeleroPhase(o.VeleroNamespace, "backups", name, timeout)
+	if err != nil {
+		return err
+	}
+	log.Successf("Backed up Jenkins X to %s", name)
+	return nil
+}
@@ -6,8 +6,12 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/spf13/cobra"
@@ -22,6 +26,7 @@ type CreateClusterOptions struct {
 	Flags            InitFlags
 	Provider         string
 	SkipInstallation bool
+	KubeConfigOut    string
 }
 
 const (
@@ -143,6 +148,7 @@ func NewCmdCreateCluster(f Factory, in terminal.FileReader, out terminal.FileWri
 func (o *CreateClusterOptions) addCreateClusterFlags(cmd *cobra.Command) {
 	o.InstallOptions.addInstallFlags(cmd, true)
 	cmd.Flags().BoolVarP(&o.SkipInstallation, "skip-installation", "", false, "Provision cluster only, don't install Jenkins X into it")
+	cmd.Flags().StringVarP(&o.KubeConfigOut, "kubeconfig-out", "", "", "Writes the kube context of the new cluster to the given file rather than merging it into the current kube config")
 }
 
 func createCreateClusterOptions(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer, cloudProvider string) CreateClusterOptions {
@@ -163,22 +169,64 @@ func createCreateClusterOptions(f Factory, in terminal.FileReader, out terminal.
 	return options
 }
 
-func (o *CreateClusterOptions) initAndInstall(provider string) error {
+// initAndInstall installs Jenkins X into the new cluster unless the installation is skipped then creates the jx managed
+// kube context of the cluster, so that every provider creates the context at the same point
+func (o *CreateClusterOptions) initAndInstall(provider string, clusterName string) error {
 	if o.SkipInstallation {
 		log.Infof("%s cluster created. Skipping Jenkins X installation.\n", o.Provider)
-		return nil
-	}
+	} else {
+		o.InstallOptions.BatchMode = o.BatchMode
+		o.InstallOptions.Flags.Provider = provider
 
-	o.InstallOptions.BatchMode = o.BatchMode
-	o.InstallOptions.Flags.Provider = provider
+		installOpts := &o.InstallOptions
 
-	installOpts := &o.InstallOptions
+		// call jx install
+		err := installOpts.Run()
+		if err != nil {
+			return err
+		}
+	}
+	return o.setupKubeContext(clusterName)
+}
 
-	// call jx install
-	err := installOpts.Run()
+// setupKubeContext creates a jx managed context called 'jx-<cluster>' for the new cluster using the install namespace,
+// or the current namespace. The context is merged into the current kube config unless --kubeconfig-out is specified.
+// The cluster name defaults to the cluster of the current context, which the CLI of the provider points at the new
+// cluster
+func (o *CreateClusterOptions) setupKubeContext(clusterName string) error {
+	config, po, err := o.Kube().LoadConfig()
 	if err != nil {
 		return err
 	}
+	if clusterName == "" {
+		clusterName, _ = kube.CurrentCluster(config)
+		if clusterName == "" {
+			return errors.New("no cluster found for the current kube context")
+		}
+	}
+	ns := o.InstallOptions.Flags.Namespace
+	if ns == "" {
+		ns = kube.CurrentNamespace(config)
+	}
+	ctxName, err := kube.AddJXContext(config, clusterName, ns)
+	if err != nil {
+		return errors.Wrapf(err, "creating kube context for cluster %s", clusterName)
+	}
+	info := util.ColorInfo
+	if o.KubeConfigOut != "" {
+		err = kube.WriteContextToFile(config, ctxName, o.KubeConfigOut)
+		if err != nil {
+			return err
+		}
+		log.Infof("Wrote kube context %s to %s. Use it via: %s\n", info(ctxName), info(o.KubeConfigOut),
+			info(util.EnvVarInstructions("KUBECONFIG", o.KubeConfigOut)))
+		return nil
+	}
+	err = clientcmd.ModifyConfig(po, *config, false)
+	if err != nil {
+		return errors.Wrap(err, "updating the kube config")
+	}
+	log.Infof("Now using kube context %s with namespace %s\n", info(ctxName), info(ns))
 	return nil
 }
 
@@ -370,5 +370,5 @@ func (o *CreateClusterAKSOptions) createClusterAKS() error {
 	}
 
 	log.Info("Initialising cluster ...\n")
-	return o.initAndInstall(AKS)
+	return o.initAndInstall(AKS, clusterName)
 }
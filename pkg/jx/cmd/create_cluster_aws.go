@@ -210,7 +210,7 @@ func (o *CreateClusterAWSOptions) Run() error {
 			}
 			state = "s3://" + state
 
-			log.Infof("To work more easily with kops on the command line you may wish to run the following: %s\n", util.ColorInfo("export KOPS_STATE_STORE="+state))
+			log.Infof("To work more easily with kops on the command line you may wish to run the following: %s\n", util.ColorInfo(util.EnvVarInstructions("KOPS_STATE_STORE", state)))
 		}
 	}
 	o.Flags.State = state
@@ -295,7 +295,7 @@ func (o *CreateClusterAWSOptions) Run() error {
 	log.Blank()
 
 	log.Info("Initialising cluster ...\n")
-	return o.initAndInstall(AWS)
+	return o.initAndInstall(AWS, name)
 }
 
 func (o *CreateClusterAWSOptions) waitForClusterJson(clusterName string) (string, error) {
@@ -384,6 +384,6 @@ func (o *CreateClusterAWSOptions) runKops(args ...string) error {
 	if o.Flags.State != "" {
 		args = append(args, "--state", o.Flags.State)
 	}
-	log.Infof("running command: %s\n", util.ColorInfo("kops "+strings.Join(args, " ")))
+	log.Infof("running command: %s\n", util.ColorInfo("kops "+strings.Join(util.RedactArgs(args), " ")))
 	return o.runCommandVerbose("kops", args...)
 }
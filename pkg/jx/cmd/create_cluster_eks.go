@@ -185,7 +185,7 @@ cluster provisioning. Cleaning up stack %s and recreating it with eksctl.`,
 	logger.Info("Creating EKS cluster - this can take a while so please be patient...")
 	logger.Infof("You can watch progress in the CloudFormation console: %s", util.ColorInfo("https://console.aws.amazon.com/cloudformation/"))
 
-	logger.Debugf("Running command: %s", util.ColorInfo("eksctl "+strings.Join(args, " ")))
+	logger.Debugf("Running command: %s", util.ColorInfo("eksctl "+strings.Join(util.RedactArgs(args), " ")))
 	if logger.GetLevel() == logger.DebugLevel {
 		err = o.runCommandVerbose("eksctl", args...)
 		if err != nil {
@@ -200,5 +200,5 @@ cluster provisioning. Cleaning up stack %s and recreating it with eksctl.`,
 	}
 
 	logger.Info("Initialising cluster ...\n")
-	return o.initAndInstall(EKS)
+	return o.initAndInstall(EKS, flags.ClusterName)
 }
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/jenkins-x/jx/pkg/io/secrets"
@@ -20,6 +21,7 @@ import (
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	"k8s.io/client-go/kubernetes"
 )
 
 // CreateClusterOptions the flags for running create cluster
@@ -47,7 +49,11 @@ type CreateClusterGKEFlags struct {
 	Namespace       string
 	Labels          string
 	Scopes          []string
-	Preemptible 	bool
+	Preemptible     bool
+	SkipQuotaCheck  bool
+	Profile         string
+	GPU             string
+	ZoneLatency     bool
 }
 
 const clusterListHeader = "PROJECT_ID"
@@ -72,6 +78,12 @@ var (
 
 		jx create cluster gke
 
+		# use the machine type and number of nodes recommended for a team running lots of JVM builds
+		jx create cluster gke --profile ci-heavy
+
+		# list the zones closest to you first which offer GPUs and add a tainted node pool with them
+		jx create cluster gke --zone-latency --gpu nvidia-tesla-k80
+
 `)
 	disallowedLabelCharacters = regexp.MustCompile("[^a-z0-9-]")
 )
@@ -114,6 +126,10 @@ func NewCmdCreateClusterGKE(f Factory, in terminal.FileReader, out terminal.File
 	cmd.Flags().StringVarP(&options.Flags.Labels, "labels", "", "", "The labels to add to the cluster being created such as 'foo=bar,whatnot=123'. Label names must begin with a lowercase character ([a-z]), end with a lowercase alphanumeric ([a-z0-9]) with dashes (-), and lowercase alphanumeric ([a-z0-9]) between.")
 	cmd.Flags().StringArrayVarP(&options.Flags.Scopes, "scope", "", []string{}, "The OAuth scopes to be added to the cluster")
 	cmd.Flags().BoolVarP(&options.Flags.Preemptible, "preemptible", "", false, "Use preemptible VMs in the node-pool")
+	cmd.Flags().BoolVarP(&options.Flags.SkipQuotaCheck, "skip-quota-check", "", false, "Skip checking the region has enough CPU and address quota for the node pool before creating the cluster")
+	cmd.Flags().StringVarP(&options.Flags.Profile, "profile", "", "", "The workload profile to recommend the machine type and number of nodes for instead of asking about the builds. One of: "+strings.Join(gke.WorkloadProfiles, ", "))
+	cmd.Flags().StringVarP(&options.Flags.GPU, "gpu", "", "", "The type of GPU such as nvidia-tesla-k80 to add an autoscaling node pool with GPUs for ML workloads. The nodes are tainted with "+gke.GPUTaintKey+" so only pods tolerating it run on them. Only the zones offering it are listed")
+	cmd.Flags().BoolVarP(&options.Flags.ZoneLatency, "zone-latency", "", false, "Measure the latency to each Google Cloud region and list the closest zones first")
 
 	cmd.AddCommand(NewCmdCreateClusterGKETerraform(f, in, out, errOut))
 
@@ -172,12 +188,15 @@ func (o *CreateClusterGKEOptions) createClusterGKE() error {
 		log.Infof("No cluster name provided so using a generated one: %s\n", o.Flags.ClusterName)
 	}
 
-	zone := o.Flags.Zone
-	if zone == "" {
-		zone, err = o.getGoogleZone(projectId)
+	recommendation := gke.Recommendation{MachineType: "n1-standard-2", MinNodes: 3, MaxNodes: 5}
+	if o.Flags.MachineType == "" || o.Flags.MinNumOfNodes == "" || o.Flags.MaxNumOfNodes == "" {
+		r, err := o.recommendGKEMachineType(o.Flags.Profile, surveyOpts)
 		if err != nil {
 			return err
 		}
+		if r != nil {
+			recommendation = *r
+		}
 	}
 
 	machineType := o.Flags.MachineType
@@ -185,9 +204,9 @@ func (o *CreateClusterGKEOptions) createClusterGKE() error {
 		prompts := &survey.Select{
 			Message:  "Google Cloud Machine Type:",
 			Options:  gke.GetGoogleMachineTypes(),
-			Help:     "We recommend a minimum of n1-standard-2 for Jenkins X,  a table of machine descriptions can be found here https://cloud.google.com/kubernetes-engine/docs/concepts/cluster-architecture",
+			Help:     "We recommend " + recommendation.MachineType + " for your workload,  a table of machine descriptions can be found here https://cloud.google.com/kubernetes-engine/docs/concepts/cluster-architecture",
 			PageSize: 10,
-			Default:  "n1-standard-2",
+			Default:  recommendation.MachineType,
 		}
 
 		err := survey.AskOne(prompts, &machineType, nil, surveyOpts)
@@ -200,8 +219,8 @@ func (o *CreateClusterGKEOptions) createClusterGKE() error {
 	if minNumOfNodes == "" {
 		prompt := &survey.Input{
 			Message: "Minimum number of Nodes",
-			Default: "3",
-			Help:    "We recommend a minimum of 3 for Jenkins X,  the minimum number of nodes to be created in each of the cluster's zones",
+			Default: strconv.Itoa(recommendation.MinNodes),
+			Help:    "We recommend a minimum of " + strconv.Itoa(recommendation.MinNodes) + " for your workload,  the minimum number of nodes to be created in each of the cluster's zones",
 		}
 
 		survey.AskOne(prompt, &minNumOfNodes, nil, surveyOpts)
@@ -211,13 +230,32 @@ func (o *CreateClusterGKEOptions) createClusterGKE() error {
 	if maxNumOfNodes == "" {
 		prompt := &survey.Input{
 			Message: "Maximum number of Nodes",
-			Default: "5",
-			Help:    "We recommend at least 5 for Jenkins X,  the maximum number of nodes to be created in each of the cluster's zones",
+			Default: strconv.Itoa(recommendation.MaxNodes),
+			Help:    "We recommend at least " + strconv.Itoa(recommendation.MaxNodes) + " for your workload,  the maximum number of nodes to be created in each of the cluster's zones",
 		}
 
 		survey.AskOne(prompt, &maxNumOfNodes, nil, surveyOpts)
 	}
 
+	zone := o.Flags.Zone
+	if zone == "" {
+		zone, err = o.getGoogleZoneFor(projectId, machineType, o.Flags.GPU, o.Flags.ZoneLatency)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !o.Flags.SkipQuotaCheck {
+		nodes, err := strconv.Atoi(minNumOfNodes)
+		if err != nil {
+			return errors.Wrapf(err, "parsing the minimum number of nodes %s", minNumOfNodes)
+		}
+		zone, err = o.checkGKEQuota(projectId, zone, machineType, nodes)
+		if err != nil {
+			return err
+		}
+	}
+
 	// mandatory flags are machine type, num-nodes, zone,
 	args := []string{"container", "clusters", "create",
 		o.Flags.ClusterName, "--zone", zone,
@@ -285,21 +323,17 @@ func (o *CreateClusterGKEOptions) createClusterGKE() error {
 		return err
 	}
 
-	log.Info("Initialising cluster ...\n")
-	if o.InstallOptions.Flags.DefaultEnvironmentPrefix == "" {
-		o.InstallOptions.Flags.DefaultEnvironmentPrefix = o.Flags.ClusterName
-	}
-	err = o.initAndInstall(GKE)
+	output, err := o.getGKECredentials(o.Flags.ClusterName, zone, projectId)
 	if err != nil {
 		return err
 	}
+	log.Info(output)
 
-	err = o.RunCommand("gcloud", "container", "clusters", "get-credentials", o.Flags.ClusterName, "--zone", zone, "--project", projectId)
-	if err != nil {
-		return err
+	log.Info("Initialising cluster ...\n")
+	if o.InstallOptions.Flags.DefaultEnvironmentPrefix == "" {
+		o.InstallOptions.Flags.DefaultEnvironmentPrefix = o.Flags.ClusterName
 	}
-
-	context, err := o.getCommandOutput("", "kubectl", "config", "current-context")
+	err = o.initAndInstall(GKE, o.Flags.ClusterName)
 	if err != nil {
 		return err
 	}
@@ -312,9 +346,11 @@ func (o *CreateClusterGKEOptions) createClusterGKE() error {
 		ns = o.InstallOptions.Flags.Namespace
 	}
 
-	err = o.RunCommand("kubectl", "config", "set-context", context, "--namespace", ns)
-	if err != nil {
-		return err
+	if o.Flags.GPU != "" {
+		err = o.createGPUNodePool(kubeClient, zone, machineType)
+		if err != nil {
+			return err
+		}
 	}
 
 	err = o.RunCommand("kubectl", "get", "ingress")
@@ -341,3 +377,69 @@ func sanitizeLabel(username string) string {
 	sanitized := strings.ToLower(username)
 	return disallowedLabelCharacters.ReplaceAllString(sanitized, "-")
 }
+
+// recommendGKEMachineType recommends the machine type and number of nodes for the given workload profile or, when no
+// profile is given, for the concurrent builds and languages of the team. Returns nil in batch mode without a profile
+func (o *CommonOptions) recommendGKEMachineType(profile string, surveyOpts survey.AskOpt) (*gke.Recommendation, error) {
+	var workload gke.Workload
+	if profile != "" {
+		w, err := gke.ProfileWorkload(profile)
+		if err != nil {
+			return nil, err
+		}
+		workload = w
+	} else if o.BatchMode {
+		return nil, nil
+	} else {
+		builds := ""
+		prompt := &survey.Input{
+			Message: "How many builds does your team run at the same time?",
+			Default: "3",
+			Help:    "The number of concurrent builds is used to recommend the machine type and number of nodes",
+		}
+		err := survey.AskOne(prompt, &builds, nil, surveyOpts)
+		if err != nil {
+			return nil, err
+		}
+		workload.ConcurrentBuilds, err = strconv.Atoi(builds)
+		if err != nil {
+			return nil, util.InvalidArgError(builds, err)
+		}
+		languages := &survey.MultiSelect{
+			Message: "Which languages does your team build?",
+			Options: gke.BuildLanguages,
+			Help:    "The languages are used to estimate how much memory each build uses",
+		}
+		err = survey.AskOne(languages, &workload.Languages, nil, surveyOpts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	recommendation := gke.RecommendMachineType(workload)
+	log.Infof("We recommend %d to %d nodes of %s because:\n", recommendation.MinNodes, recommendation.MaxNodes, util.ColorInfo(recommendation.MachineType))
+	for _, reason := range recommendation.Reasons {
+		log.Infof("  * %s\n", reason)
+	}
+	return &recommendation, nil
+}
+
+// createGPUNodePool adds an autoscaling node pool with a GPU per node which only pods tolerating the GPU taint are
+// scheduled on and installs the NVIDIA drivers on its nodes
+func (o *CreateClusterGKEOptions) createGPUNodePool(kubeClient kubernetes.Interface, zone string, machineType string) error {
+	log.Infof("Adding the node pool %s with a %s GPU per node\n", util.ColorInfo(gke.GPUNodePoolName), util.ColorInfo(o.Flags.GPU))
+	err := o.RunCommand("gcloud", "container", "node-pools", "create", gke.GPUNodePoolName,
+		"--cluster", o.Flags.ClusterName,
+		"--zone", zone,
+		"--machine-type", machineType,
+		"--accelerator", "type="+o.Flags.GPU+",count=1",
+		"--num-nodes", "0",
+		"--enable-autoscaling",
+		"--min-nodes", "0",
+		"--max-nodes", strconv.Itoa(gke.DefaultGPUMaxNodes),
+		"--node-taints", gke.GPUTaintKey+"="+gke.GPUTaintValue+":NoSchedule")
+	if err != nil {
+		return errors.Wrapf(err, "creating the node pool %s", gke.GPUNodePoolName)
+	}
+	log.Infof("Installing the NVIDIA drivers on the GPU nodes\n")
+	return gke.InstallNvidiaDriver(kubeClient)
+}
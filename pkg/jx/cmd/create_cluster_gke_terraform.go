@@ -13,6 +13,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"time"
 
@@ -39,14 +40,19 @@ type CreateClusterGKETerraformFlags struct {
 	ClusterName string
 	//ClusterIpv4Cidr string
 	//ClusterVersion  string
-	DiskSize      string
-	MachineType   string
-	MinNumOfNodes string
-	MaxNumOfNodes string
-	ProjectId     string
-	SkipLogin     bool
-	Zone          string
-	Labels        string
+	DiskSize       string
+	MachineType    string
+	MinNumOfNodes  string
+	MaxNumOfNodes  string
+	ProjectId      string
+	SkipLogin      bool
+	Zone           string
+	Labels         string
+	SkipQuotaCheck bool
+	Profile        string
+	ZoneLatency    bool
+	GPUType        string
+	GPUCount       int
 }
 
 var (
@@ -69,6 +75,9 @@ var (
 
 		jx create cluster gke terraform
 
+		# add a node pool with a GPU per node which only pods tolerating the nvidia.com/gpu taint are scheduled on
+		jx create cluster gke terraform --gpu-type nvidia-tesla-k80 --gpu-count 1
+
 `)
 )
 
@@ -105,6 +114,11 @@ func NewCmdCreateClusterGKETerraform(f Factory, in terminal.FileReader, out term
 	cmd.Flags().StringVarP(&options.Flags.MaxNumOfNodes, "max-num-nodes", "", "", "The maximum number of nodes to be created in each of the cluster's zones")
 	cmd.Flags().StringVarP(&options.Flags.ProjectId, "project-id", "p", "", "Google Project ID to create cluster in")
 	cmd.Flags().StringVarP(&options.Flags.Zone, "zone", "z", "", "The compute zone (e.g. us-central1-a) for the cluster")
+	cmd.Flags().BoolVarP(&options.Flags.SkipQuotaCheck, "skip-quota-check", "", false, "Skip checking the region has enough CPU and address quota for the node pool before creating the cluster")
+	cmd.Flags().StringVarP(&options.Flags.Profile, "profile", "", "", "The workload profile to recommend the machine type and number of nodes for instead of asking about the builds. One of: "+strings.Join(gke.WorkloadProfiles, ", "))
+	cmd.Flags().BoolVarP(&options.Flags.ZoneLatency, "zone-latency", "", false, "Measure the latency to each Google Cloud region and list the closest zones first")
+	cmd.Flags().StringVarP(&options.Flags.GPUType, "gpu-type", "", "", "The type of GPU such as nvidia-tesla-k80 to add an autoscaling node pool with GPUs for ML workloads. The nodes are tainted with "+gke.GPUTaintKey+" so only pods tolerating it run on them")
+	cmd.Flags().IntVarP(&options.Flags.GPUCount, "gpu-count", "", 1, "The number of GPUs attached to each node of the GPU node pool")
 	cmd.Flags().StringVarP(&options.Flags.Labels, "labels", "", "", "The labels to add to the cluster being created such as 'foo=bar,whatnot=123'. Label names must begin with a lowercase character ([a-z]), end with a lowercase alphanumeric ([a-z0-9]) with dashes (-), and lowercase alphanumeric ([a-z0-9]) between.")
 	return cmd
 }
@@ -167,22 +181,14 @@ func (o *CreateClusterGKETerraformOptions) createClusterGKETerraform() error {
 		log.Infof("No cluster name provided so using a generated one: %s\n", o.Flags.ClusterName)
 	}
 
-	zone := o.Flags.Zone
-	if zone == "" {
-		availableZones, err := gke.GetGoogleZones(projectId)
+	recommendation := gke.Recommendation{MachineType: "n1-standard-2", MinNodes: 3, MaxNodes: 5}
+	if o.Flags.MachineType == "" || o.Flags.MinNumOfNodes == "" || o.Flags.MaxNumOfNodes == "" {
+		r, err := o.recommendGKEMachineType(o.Flags.Profile, surveyOpts)
 		if err != nil {
 			return err
 		}
-		prompts := &survey.Select{
-			Message:  "Google Cloud Zone:",
-			Options:  availableZones,
-			PageSize: 10,
-			Help:     "The compute zone (e.g. us-central1-a) for the cluster",
-		}
-
-		err = survey.AskOne(prompts, &zone, nil, surveyOpts)
-		if err != nil {
-			return err
+		if r != nil {
+			recommendation = *r
 		}
 	}
 
@@ -191,9 +197,9 @@ func (o *CreateClusterGKETerraformOptions) createClusterGKETerraform() error {
 		prompts := &survey.Select{
 			Message:  "Google Cloud Machine Type:",
 			Options:  gke.GetGoogleMachineTypes(),
-			Help:     "We recommend a minimum of n1-standard-2 for Jenkins X,  a table of machine descriptions can be found here https://cloud.google.com/kubernetes-engine/docs/concepts/cluster-architecture",
+			Help:     "We recommend " + recommendation.MachineType + " for your workload,  a table of machine descriptions can be found here https://cloud.google.com/kubernetes-engine/docs/concepts/cluster-architecture",
 			PageSize: 10,
-			Default:  "n1-standard-2",
+			Default:  recommendation.MachineType,
 		}
 
 		err := survey.AskOne(prompts, &machineType, nil, surveyOpts)
@@ -206,8 +212,8 @@ func (o *CreateClusterGKETerraformOptions) createClusterGKETerraform() error {
 	if minNumOfNodes == "" {
 		prompt := &survey.Input{
 			Message: "Minimum number of Nodes",
-			Default: "3",
-			Help:    "We recommend a minimum of 3 for Jenkins X,  the minimum number of nodes to be created in each of the cluster's zones",
+			Default: strconv.Itoa(recommendation.MinNodes),
+			Help:    "We recommend a minimum of " + strconv.Itoa(recommendation.MinNodes) + " for your workload,  the minimum number of nodes to be created in each of the cluster's zones",
 		}
 
 		survey.AskOne(prompt, &minNumOfNodes, nil, surveyOpts)
@@ -217,13 +223,32 @@ func (o *CreateClusterGKETerraformOptions) createClusterGKETerraform() error {
 	if maxNumOfNodes == "" {
 		prompt := &survey.Input{
 			Message: "Maximum number of Nodes",
-			Default: "5",
-			Help:    "We recommend at least 5 for Jenkins X,  the maximum number of nodes to be created in each of the cluster's zones",
+			Default: strconv.Itoa(recommendation.MaxNodes),
+			Help:    "We recommend at least " + strconv.Itoa(recommendation.MaxNodes) + " for your workload,  the maximum number of nodes to be created in each of the cluster's zones",
 		}
 
 		survey.AskOne(prompt, &maxNumOfNodes, nil, surveyOpts)
 	}
 
+	zone := o.Flags.Zone
+	if zone == "" {
+		zone, err = o.getGoogleZoneFor(projectId, machineType, o.Flags.GPUType, o.Flags.ZoneLatency)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !o.Flags.SkipQuotaCheck {
+		nodes, err := strconv.Atoi(minNumOfNodes)
+		if err != nil {
+			return fmt.Errorf("parsing the minimum number of nodes %s: %s", minNumOfNodes, err)
+		}
+		zone, err = o.checkGKEQuota(projectId, zone, machineType, nodes)
+		if err != nil {
+			return err
+		}
+	}
+
 	jxHome, err := util.ConfigDir()
 	if err != nil {
 		return err
@@ -291,40 +316,40 @@ func (o *CreateClusterGKETerraformOptions) createClusterGKETerraform() error {
 	o.writeKeyValueIfNotExists(terraformVars, "logging_service", "logging.googleapis.com")
 	o.writeKeyValueIfNotExists(terraformVars, "monitoring_service", "monitoring.googleapis.com")
 
-	args := []string{"init", terraformDir}
-	err = o.RunCommand("terraform", args...)
-	if err != nil {
-		return err
-	}
-
-	terraformState := filepath.Join(terraformDir, "terraform.tfstate")
-
-	args = []string{"plan",
-		fmt.Sprintf("-state=%s", terraformState),
-		fmt.Sprintf("-var-file=%s", terraformVars),
-		terraformDir}
-
-	output, err := o.getCommandOutput("", "terraform", args...)
-	if err != nil {
-		return err
+	var gpuPool *gke.GPUNodePool
+	if o.Flags.GPUType != "" {
+		gpuPool = &gke.GPUNodePool{
+			GPUType:  o.Flags.GPUType,
+			GPUCount: o.Flags.GPUCount,
+		}
+		err = o.addGPUNodePoolTerraform(terraformDir, terraformVars, gpuPool)
+		if err != nil {
+			return err
+		}
 	}
 
-	log.Info("Applying plan...\n")
-
-	args = []string{"apply",
-		"-auto-approve",
-		fmt.Sprintf("-state=%s", terraformState),
-		fmt.Sprintf("-var-file=%s", terraformVars),
-		terraformDir}
-
-	err = o.runCommandVerbose("terraform", args...)
+	checkpoint := &Checkpoint{
+		Name: "create-cluster-gke-terraform-" + o.Flags.ClusterName,
+		Step: "applying the terraform plan of cluster " + o.Flags.ClusterName,
+		Values: map[string]string{
+			"terraformDir":  terraformDir,
+			"terraformVars": terraformVars,
+		},
+		Hint: fmt.Sprintf("The terraform state is in %s. Resuming the command continues applying the plan", terraformDir),
+		Args: o.resumeArgs(map[string]string{
+			optionClusterName: o.Flags.ClusterName,
+		}),
+	}
+	err = o.runCheckpointStep(checkpoint, func(values map[string]string) error {
+		return o.applyTerraform(values["terraformDir"], values["terraformVars"])
+	})
 	if err != nil {
 		return err
 	}
 
 	// should we setup the labels at this point?
 	//gcloud container clusters update ninjacandy --update-labels ''
-	args = []string{"container",
+	args := []string{"container",
 		"clusters",
 		"update",
 		o.Flags.ClusterName}
@@ -353,44 +378,94 @@ func (o *CreateClusterGKETerraformOptions) createClusterGKETerraform() error {
 		return err
 	}
 
-	output, err = o.getCommandOutput("", "gcloud", "container", "clusters", "get-credentials", o.Flags.ClusterName, "--zone", zone, "--project", projectId)
+	output, err := o.getGKECredentials(o.Flags.ClusterName, zone, projectId)
 	if err != nil {
 		return err
 	}
 	log.Info(output)
 
+	if gpuPool != nil {
+		log.Infof("Installing the NVIDIA drivers on the GPU nodes\n")
+		client, _, err := o.KubeClient()
+		if err != nil {
+			return err
+		}
+		err = gke.InstallNvidiaDriver(client)
+		if err != nil {
+			return err
+		}
+	}
+
 	log.Info("Initialising cluster ...\n")
 	if o.InstallOptions.Flags.DefaultEnvironmentPrefix == "" {
 		o.InstallOptions.Flags.DefaultEnvironmentPrefix = o.Flags.ClusterName
 	}
-	err = o.initAndInstall(GKE)
+	err = o.initAndInstall(GKE, o.Flags.ClusterName)
 	if err != nil {
 		return err
 	}
 
-	context, err := o.getCommandOutput("", "kubectl", "config", "current-context")
+	err = o.RunCommand("kubectl", "get", "ingress")
 	if err != nil {
 		return err
 	}
-	log.Info(context)
+	return nil
+}
 
-	ns := o.InstallOptions.Flags.Namespace
-	if ns == "" {
-		_, ns, _ = o.KubeClient()
-		if err != nil {
-			return err
-		}
+// applyTerraform initialises the terraform templates in the directory then plans and applies them with the variables
+func (o *CreateClusterGKETerraformOptions) applyTerraform(terraformDir string, terraformVars string) error {
+	err := o.RunCommand("terraform", "init", terraformDir)
+	if err != nil {
+		return err
 	}
 
-	err = o.RunCommand("kubectl", "config", "set-context", context, "--namespace", ns)
+	terraformState := filepath.Join(terraformDir, "terraform.tfstate")
+
+	args := []string{"plan",
+		fmt.Sprintf("-state=%s", terraformState),
+		fmt.Sprintf("-var-file=%s", terraformVars),
+		terraformDir}
+
+	_, err = o.getCommandOutput("", "terraform", args...)
 	if err != nil {
 		return err
 	}
 
-	err = o.RunCommand("kubectl", "get", "ingress")
+	log.Info("Applying plan...\n")
+
+	args = []string{"apply",
+		"-auto-approve",
+		fmt.Sprintf("-state=%s", terraformState),
+		fmt.Sprintf("-var-file=%s", terraformVars),
+		terraformDir}
+
+	return o.runCommandVerbose("terraform", args...)
+}
+
+// addGPUNodePoolTerraform adds the terraform of the GPU node pool and its variables to the terraform of the cluster so
+// that the node pool is applied, tracked and destroyed with the cluster in the same state
+func (o *CreateClusterGKETerraformOptions) addGPUNodePoolTerraform(terraformDir string, terraformVars string, pool *gke.GPUNodePool) error {
+	text, err := pool.Terraform()
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(filepath.Join(terraformDir, gke.GPUNodePoolTerraformFile), []byte(text), util.DefaultWritePermissions)
 	if err != nil {
 		return err
 	}
+	vars := pool.TerraformVars()
+	keys := []string{}
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		err = o.writeKeyValueIfNotExists(terraformVars, key, vars[key])
+		if err != nil {
+			return err
+		}
+	}
+	log.Infof("Adding the node pool %s with %d %s GPUs per node\n", util.ColorInfo(gke.GPUNodePoolName), pool.GPUCount, util.ColorInfo(pool.GPUType))
 	return nil
 }
 
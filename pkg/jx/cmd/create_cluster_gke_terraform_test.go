@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyTerraform(t *testing.T) {
+	t.Parallel()
+	runner := util.NewFakeCommandRunner()
+	o := &CreateClusterGKETerraformOptions{}
+	o.SetCommandRunner(runner)
+
+	dir := filepath.Join("clusters", "cheese", "terraform")
+	vars := filepath.Join(dir, "terraform.tfvars")
+	state := filepath.Join(dir, "terraform.tfstate")
+	err := o.applyTerraform(dir, vars)
+	assert.NoError(t, err)
+
+	expected := []string{
+		"terraform init " + dir,
+		"terraform plan -state=" + state + " -var-file=" + vars + " " + dir,
+		"terraform apply -auto-approve -state=" + state + " -var-file=" + vars + " " + dir,
+	}
+	assert.Equal(t, expected, runner.CommandLines())
+}
+
+func TestApplyTerraformStopsWhenPlanFails(t *testing.T) {
+	t.Parallel()
+	runner := util.NewFakeCommandRunner()
+	runner.Errors["terraform plan"] = errors.New("invalid variable")
+	o := &CreateClusterGKETerraformOptions{}
+	o.SetCommandRunner(runner)
+
+	err := o.applyTerraform("terraform", "terraform.tfvars")
+	assert.Error(t, err)
+	assert.Len(t, runner.CommandLines(), 2, "terraform apply should not be run when the plan fails")
+}
@@ -531,9 +531,9 @@ L:
 	}
 
 	log.Info("Setting kube config file\n")
-	log.Infof("export KUBECONFIG=\"%s\"\n", kubeconfig)
+	log.Infof("%s\n", util.EnvVarInstructions("KUBECONFIG", kubeconfig))
 	os.Setenv("KUBECONFIG", kubeconfig)
 	log.Info("Initialising cluster ...\n")
 
-	return o.initAndInstall(IKS)
+	return o.initAndInstall(IKS, clusterName)
 }
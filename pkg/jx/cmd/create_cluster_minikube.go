@@ -260,25 +260,7 @@ func (o *CreateClusterMinikubeOptions) createClusterMinikube() error {
 	}
 
 	log.Info("Initialising cluster ...\n")
-	err = o.initAndInstall(MINIKUBE)
-	if err != nil {
-		return err
-	}
-
-	context, err := o.getCommandOutput("", "kubectl", "config", "current-context")
-	if err != nil {
-		return err
-	}
-
-	ns := o.Flags.Namespace
-	if ns == "" {
-		_, ns, _ = o.KubeClient()
-		if err != nil {
-			return err
-		}
-	}
-
-	err = o.RunCommand("kubectl", "config", "set-context", context, "--namespace", ns)
+	err = o.initAndInstall(MINIKUBE, MINIKUBE)
 	if err != nil {
 		return err
 	}
@@ -245,10 +245,5 @@ func (o *CreateClusterMinishiftOptions) createClusterMinishift() error {
 	}
 
 	log.Info("Initialising cluster ...\n")
-	err = o.initAndInstall(MINISHIFT)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return o.initAndInstall(MINISHIFT, MINISHIFT)
 }
@@ -448,7 +448,7 @@ func (o *CreateClusterOKEOptions) createClusterOKE() error {
 			}
 			log.Info("Initialising cluster ...\n")
 
-			return o.initAndInstall(OKE)
+			return o.initAndInstall(OKE, o.Flags.ClusterName)
 		}
 	}
 	return nil
@@ -31,9 +31,25 @@ import (
 )
 
 const (
-	optionLabel      = "label"
-	optionRequestCpu = "request-cpu"
-	devPodGoPath     = "/workspace"
+	optionLabel       = "label"
+	optionRequestCpu  = "request-cpu"
+	optionSyncMode    = "sync-mode"
+	optionIDE         = "ide"
+	optionIdleTimeout = "idle-timeout"
+	devPodGoPath      = "/workspace"
+
+	devPodSyncModeKsync = "ksync"
+	devPodSyncModeRsync = "rsync"
+
+	devPodIDETheia     = "theia"
+	devPodIDEVSCode    = "vscode"
+	devPodIDEJetBrains = "jetbrains"
+	devPodIDENone      = "none"
+)
+
+var (
+	devPodSyncModes = []string{devPodSyncModeKsync, devPodSyncModeRsync}
+	devPodIDEs      = []string{devPodIDETheia, devPodIDEVSCode, devPodIDEJetBrains, devPodIDENone}
 )
 
 var (
@@ -50,6 +66,12 @@ var (
 
 		# creates a new Maven DevPod 
 		jx create devpod -l maven
+
+		# creates a DevPod synchronising the current directory with rsync and attaches VS Code to it
+		jx create devpod --sync --sync-mode rsync --ide vscode
+
+		# creates a DevPod which is deleted after 2 hours without being used
+		jx create devpod --idle-timeout 2h
 	`)
 )
 
@@ -81,6 +103,9 @@ type CreateDevPodOptions struct {
 	DockerRegistry  string
 	TillerNamespace string
 	ServiceAccount  string
+	SyncMode        string
+	IDE             string
+	IdleTimeout     string
 
 	GitCredentials StepGitCredentialsOptions
 
@@ -140,6 +165,9 @@ func NewCmdCreateDevPod(f Factory, in terminal.FileReader, out terminal.FileWrit
 	cmd.Flags().StringVarP(&options.DockerRegistry, "docker-registry", "", "", "The Docker registry to use within the DevPod. If not specified, default to the built-in registry or $DOCKER_REGISTRY")
 	cmd.Flags().StringVarP(&options.TillerNamespace, "tiller-namespace", "", "", "The optional tiller namespace to use within the DevPod.")
 	cmd.Flags().StringVarP(&options.ServiceAccount, "service-account", "", "", "The ServiceAccount name used for the DevPod")
+	cmd.Flags().StringVarP(&options.SyncMode, optionSyncMode, "", devPodSyncModeKsync, "The tool used to synchronise the local file system with --sync. Possible values: "+strings.Join(devPodSyncModes, ", "))
+	cmd.Flags().StringVarP(&options.IDE, optionIDE, "", devPodIDETheia, "The IDE to attach to the DevPod. Possible values: "+strings.Join(devPodIDEs, ", "))
+	cmd.Flags().StringVarP(&options.IdleTimeout, optionIdleTimeout, "", "", "The duration without any use after which the DevPod is deleted by the CronJob running 'jx gc devpods', such as 2h. Defaults to never")
 
 	options.addCommonFlags(cmd)
 	return cmd
@@ -158,6 +186,21 @@ func (o *CreateDevPodOptions) Run() error {
 		return errors.New("Cannot specify --import-url && --sync")
 	}
 
+	if util.StringArrayIndex(devPodSyncModes, o.SyncMode) < 0 {
+		return util.InvalidOption(optionSyncMode, o.SyncMode, devPodSyncModes)
+	}
+	if util.StringArrayIndex(devPodIDEs, o.IDE) < 0 {
+		return util.InvalidOption(optionIDE, o.IDE, devPodIDEs)
+	}
+	if o.IdleTimeout != "" {
+		_, err := time.ParseDuration(o.IdleTimeout)
+		if err != nil {
+			return util.InvalidOptionError(optionIdleTimeout, o.IdleTimeout, err)
+		}
+	}
+	// Theia won't work in --sync mode as we can't share a volume
+	theia := !o.Sync && o.IDE == devPodIDETheia
+
 	client, curNs, err := o.KubeClient()
 	if err != nil {
 		return err
@@ -316,7 +359,9 @@ func (o *CreateDevPodOptions) Run() error {
 	if !o.Sync {
 		pod.Spec.Volumes = append(pod.Spec.Volumes, workspaceVolume)
 		container1.VolumeMounts = append(container1.VolumeMounts, workspaceVolumeMount)
+	}
 
+	if theia {
 		cpuLimit, _ := resource.ParseQuantity("400m")
 		cpuRequest, _ := resource.ParseQuantity("200m")
 		memoryLimit, _ := resource.ParseQuantity("1Gi")
@@ -399,7 +444,13 @@ func (o *CreateDevPodOptions) Run() error {
 	pod.Annotations[kube.AnnotationWorkingDir] = workingDir
 	if o.Sync {
 		pod.Annotations[kube.AnnotationLocalDir] = dir
+		pod.Annotations[kube.AnnotationDevPodSyncMode] = o.SyncMode
 	}
+	pod.Annotations[kube.AnnotationDevPodIDE] = o.IDE
+	if o.IdleTimeout != "" {
+		pod.Annotations[kube.AnnotationDevPodIdleTimeout] = o.IdleTimeout
+	}
+	pod.Annotations[kube.AnnotationDevPodLastActivity] = time.Now().UTC().Format(time.RFC3339)
 	container1.Env = append(container1.Env, corev1.EnvVar{
 		Name:  "WORK_DIR",
 		Value: workingDir,
@@ -428,6 +479,14 @@ func (o *CreateDevPodOptions) Run() error {
 			Value: editEnv.Spec.Namespace,
 		})
 	}
+	if o.IDE == devPodIDEJetBrains {
+		// the JetBrains Gateway connects to the IDE backend running in the DevPod
+		container1.Ports = append(container1.Ports, corev1.ContainerPort{
+			Name:          "jetbrains",
+			ContainerPort: jetBrainsBackendPort,
+		})
+	}
+
 	// Assign the container the ports provided as input
 	var exposeServicePorts []int
 
@@ -490,6 +549,10 @@ func (o *CreateDevPodOptions) Run() error {
 				pod = &p
 				name = pod.Name
 				log.Infof("Reusing pod %s - waiting for it to be ready...\n", util.ColorInfo(pod.Name))
+				err = kube.UpdateDevPodActivity(client, ns, name)
+				if err != nil {
+					log.Warnf("%s\n", err)
+				}
 				break
 			}
 		}
@@ -507,6 +570,13 @@ func (o *CreateDevPodOptions) Run() error {
 			}
 		}
 
+		if o.IdleTimeout != "" {
+			err = o.ensureDevPodsGCScheduled(client, ns)
+			if err != nil {
+				log.Warnf("Failed to schedule the deletion of idle DevPods: %s\n", err)
+			}
+		}
+
 		log.Infof("Created pod %s - waiting for it to be ready...\n", util.ColorInfo(name))
 
 		err = kube.WaitForPodNameToBeReady(client, ns, name, time.Hour)
@@ -588,7 +658,7 @@ func (o *CreateDevPodOptions) Run() error {
 			}
 			addedServices = true
 		}
-		if !o.Sync {
+		if theia {
 
 			// Create a service for theia
 			theiaService := corev1.Service{
@@ -634,7 +704,7 @@ func (o *CreateDevPodOptions) Run() error {
 	log.Infof("Pod %s is now ready!\n", util.ColorInfo(pod.Name))
 	log.Infof("You can open other shells into this DevPod via %s\n", util.ColorInfo("jx create devpod"))
 
-	if !o.Sync {
+	if theia {
 		theiaServiceURL, err := services.FindServiceURL(client, curNs, theiaServiceName)
 		if err != nil {
 			return err
@@ -682,13 +752,24 @@ func (o *CreateDevPodOptions) Run() error {
 			Pod:           pod.Name,
 			Daemon:        true,
 			Dir:           dir,
+			RemoteDir:     workingDir,
+			Mode:          o.SyncMode,
+		}
+		if o.SyncMode == devPodSyncModeRsync {
+			err = syncOptions.StartRsync()
+		} else {
+			err = syncOptions.CreateKsync(client, ns, pod.Name, dir, workingDir, userName)
 		}
-		err = syncOptions.CreateKsync(client, ns, pod.Name, dir, workingDir, userName)
 		if err != nil {
 			return err
 		}
 	}
 
+	err = o.attachIDE(ns, pod, workingDir)
+	if err != nil {
+		return err
+	}
+
 	var rshExec []string
 	if create {
 		//  Let install bash-completion to make life better
@@ -700,8 +781,8 @@ func (o *CreateDevPodOptions) Run() error {
 			"mkdir -p ~/.jx", "jx completion bash > ~/.jx/bash", "echo \"source ~/.jx/bash\" >> ~/.bashrc",
 		)
 
-		// Only add git secrets to the Theia container when sync flag is missing (otherwise Theia container won't exist)
-		if !o.Sync {
+		// Only add git secrets to the Theia container when it exists
+		if theia {
 			// Add Git Secrets to Theia container
 			secrets, err := o.LoadPipelineSecrets(kube.ValueKindGit, "")
 			if err != nil {
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// jetBrainsBackendPort the port the JetBrains IDE backend listens on in the DevPod
+	jetBrainsBackendPort = 5990
+)
+
+// attachIDE attaches the desktop IDE chosen via --ide to the DevPod
+func (o *CreateDevPodOptions) attachIDE(ns string, pod *corev1.Pod, workingDir string) error {
+	switch o.IDE {
+	case devPodIDEVSCode:
+		return o.attachVSCode(ns, pod, workingDir)
+	case devPodIDEJetBrains:
+		info := util.ColorInfo
+		log.Infof("\nTo use a JetBrains IDE start its backend in the DevPod with %s\n", info(fmt.Sprintf("remote-dev-server.sh run %s --listenOn 0.0.0.0 --port %d", workingDir, jetBrainsBackendPort)))
+		log.Infof("then forward its port with %s and connect JetBrains Gateway to %s\n\n",
+			info(fmt.Sprintf("kubectl port-forward -n %s %s %d", ns, pod.Name, jetBrainsBackendPort)), info(fmt.Sprintf("localhost:%d", jetBrainsBackendPort)))
+	}
+	return nil
+}
+
+// attachVSCode opens VS Code attached to the first container of the DevPod via the Kubernetes remote extension
+func (o *CreateDevPodOptions) attachVSCode(ns string, pod *corev1.Pod, workingDir string) error {
+	config, _, err := o.Kube().LoadConfig()
+	if err != nil {
+		return err
+	}
+	if len(pod.Spec.Containers) == 0 {
+		return fmt.Errorf("DevPod %s has no containers", pod.Name)
+	}
+	container := pod.Spec.Containers[0]
+	uri := vsCodeFolderURI(kube.CurrentContextName(config), ns, pod.Name, container.Name, container.Image, workingDir)
+
+	_, err = exec.LookPath("code")
+	if err != nil {
+		log.Infof("\nTo attach VS Code to the DevPod install the Kubernetes and Remote Containers extensions and run %s\n\n", util.ColorInfo("code --folder-uri "+uri))
+		return nil
+	}
+	log.Infof("Opening VS Code attached to DevPod %s\n", util.ColorInfo(pod.Name))
+	return o.runCommandVerbose("code", "--folder-uri", uri)
+}
+
+// vsCodeFolderURI returns the URI VS Code opens the folder of a container of a pod with
+func vsCodeFolderURI(context string, ns string, podName string, containerName string, image string, dir string) string {
+	return fmt.Sprintf("vscode-remote://k8s-container+context=%s+podname=%s+namespace=%s+name=%s+image=%s%s",
+		context, podName, ns, containerName, image, dir)
+}
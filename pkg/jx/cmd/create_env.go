@@ -7,6 +7,7 @@ import (
 	"io"
 
 	"fmt"
+	"strings"
 
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/config"
@@ -99,10 +100,10 @@ func NewCmdCreateEnv(f Factory, in terminal.FileReader, out terminal.FileWriter,
 	cmd.Flags().StringVarP(&options.Options.Spec.Source.URL, "git-url", "g", "", "The Git clone URL for the source code for GitOps based Environments")
 	cmd.Flags().StringVarP(&options.Options.Spec.Source.Ref, "git-ref", "r", "", "The Git repo reference for the source code for GitOps based Environments")
 	cmd.Flags().StringVarP(&options.GitRepositoryOptions.Owner, "git-owner", "", "", "Git organisation / owner")
-	cmd.Flags().Int32VarP(&options.Options.Spec.Order, "order", "o", 100, "The order weighting of the Environment so that they can be sorted by this order before name")
+	cmd.Flags().Int32VarP(&options.Options.Spec.Order, "order", "o", 0, "The order weighting of the Environment so that they can be sorted by this order before name. Defaults to after the existing Environments")
 	cmd.Flags().StringVarP(&options.Prefix, "prefix", "", "jx", "Environment repo prefix, your Git repo will be of the form 'environment-$prefix-$envName'")
 
-	cmd.Flags().StringVarP(&options.PromotionStrategy, "promotion", "p", "", "The promotion strategy")
+	cmd.Flags().StringVarP(&options.PromotionStrategy, "promotion", "p", "", "The promotion strategy. Defaults to the promotion strategy of the team. Possible values: "+strings.Join(v1.PromotionStrategyTypeValues, ", "))
 	cmd.Flags().StringVarP(&options.ForkEnvironmentGitRepo, "fork-git-repo", "f", kube.DefaultEnvironmentGitRepoURL, "The Git repository used as the fork when creating new Environment Git repos")
 	cmd.Flags().StringVarP(&options.EnvJobCredentials, "env-job-credentials", "", "", "The Jenkins credentials used by the GitOps Job for this environment")
 	cmd.Flags().StringVarP(&options.BranchPattern, "branches", "", "", "The branch pattern for branches to trigger CI/CD pipelines on the environment Git repository")
@@ -181,6 +182,9 @@ func (o *CreateEnvOptions) Run() error {
 	}
 
 	env := v1.Environment{}
+	if o.PromotionStrategy == "" && devEnv != nil {
+		o.PromotionStrategy = string(devEnv.Spec.TeamSettings.PromotionStrategy)
+	}
 	o.Options.Spec.PromotionStrategy = v1.PromotionStrategyType(o.PromotionStrategy)
 	gitProvider, err := kube.CreateEnvironmentSurvey(o.BatchMode, authConfigSvc, devEnv, &env, &o.Options, o.ForkEnvironmentGitRepo, ns,
 		jxClient, kubeClient, envDir, &o.GitRepositoryOptions, o.HelmValuesConfig, o.Prefix, o.Git(), o.In, o.Out, o.Err)
@@ -251,6 +255,19 @@ func (o *CreateEnvOptions) Run() error {
 		}
 	}
 
+	o.runPostLifecycleExtensions(v1.ExtensionWhenPostEnvironmentCreate, map[string]string{
+		"environment":          env.Name,
+		"environmentNamespace": env.Spec.Namespace,
+		"gitUrl":               gitURL,
+	})
+
+	if env.Spec.Kind.IsPermanent() {
+		err = o.updateBackupSchedule()
+		if err != nil {
+			log.Warnf("Failed to add the namespace %s to the scheduled backups: %s\n", env.Spec.Namespace, err)
+		}
+	}
+
 	if gitURL != "" {
 		if o.GitOpsMode {
 			return nil
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	jenkinsv1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pborman/uuid"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// defaultTeamExtensionNamespace the namespace of the extensions registered by a team rather than installed from an
+	// extensions repository
+	defaultTeamExtensionNamespace = "team"
+)
+
+var (
+	createExtensionLong = templates.LongDesc(`
+		Registers an extension which runs a custom step when a lifecycle event happens in your team.
+
+		The step is either a script which runs where the jx command causing the event runs, or a container image
+		which runs as a job in the development namespace of the team. The event is described to the step by
+		environment variables such as EXT_LIFECYCLE_EVENT, EXT_APP, EXT_VERSION, EXT_ENVIRONMENT and EXT_GIT_URL.
+
+		The lifecycle events are:
+
+		* postInstall - after Jenkins X is installed
+		* postEnvironmentCreate - after an environment is created
+		* postImport - after an application is imported
+		* prePromote - before an application is promoted, a failing step stops the promotion
+
+		Registering an extension with the same name again replaces it.
+`)
+
+	createExtensionExample = templates.Examples(`
+		# run a script after every import
+		jx create extension register-catalog --when postImport --script-file register.sh
+
+		# check an application in a container before it is promoted
+		jx create extension change-freeze --when prePromote --image myorg/change-freeze:1.0.0 --command 'check-freeze $EXT_ENVIRONMENT'
+	`)
+)
+
+// CreateExtensionOptions the options for the create extension command
+type CreateExtensionOptions struct {
+	CreateOptions
+
+	Name               string
+	ExtensionNamespace string
+	Description        string
+	When               []string
+	ScriptFile         string
+	Image              string
+	Command            string
+	Version            string
+}
+
+// NewCmdCreateExtension creates a command object for the "create extension" command
+func NewCmdCreateExtension(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &CreateExtensionOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "extension [name]",
+		Short:   "Registers an extension which runs when a lifecycle event happens in your team",
+		Aliases: []string{"ext", "extensions"},
+		Long:    createExtensionLong,
+		Example: createExtensionExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Name, "name", "n", "", "The name of the extension")
+	cmd.Flags().StringVarP(&options.ExtensionNamespace, "extension-namespace", "", defaultTeamExtensionNamespace, "The namespace which qualifies the name of the extension")
+	cmd.Flags().StringVarP(&options.Description, "description", "d", "", "The description of the extension")
+	cmd.Flags().StringArrayVarP(&options.When, "when", "w", []string{}, fmt.Sprintf("The lifecycle events to run the extension on. One or more of: %s", strings.Join(lifecycleEventNames(), ", ")))
+	cmd.Flags().StringVarP(&options.ScriptFile, "script-file", "s", "", "The script to run")
+	cmd.Flags().StringVarP(&options.Image, "image", "i", "", "The container image to run as a job in the development namespace")
+	cmd.Flags().StringVarP(&options.Command, "command", "c", "", "The command to run with sh in the container image instead of its entrypoint")
+	cmd.Flags().StringVarP(&options.Version, "version", "", "1.0.0", "The version of the extension")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateExtensionOptions) Run() error {
+	if o.Name == "" && len(o.Args) > 0 {
+		o.Name = o.Args[0]
+	}
+	if o.Name == "" {
+		return util.MissingOption("name")
+	}
+	spec, err := o.extensionSpec()
+	if err != nil {
+		return err
+	}
+
+	apisClient, err := o.CreateApiExtensionsClient()
+	if err != nil {
+		return err
+	}
+	err = kube.RegisterExtensionCRD(apisClient)
+	if err != nil {
+		return err
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	exts := jxClient.JenkinsV1().Extensions(ns)
+	name := spec.FullyQualifiedKebabName()
+	existing, err := exts.Get(name, metav1.GetOptions{})
+	if err == nil {
+		spec.UUID = existing.Spec.UUID
+		existing.Spec = *spec
+		_, err = exts.Update(existing)
+		if err != nil {
+			return err
+		}
+		log.Infof("Updated extension %s which runs on %s\n", util.ColorInfo(spec.FullyQualifiedName()), util.ColorInfo(strings.Join(o.When, ", ")))
+		return nil
+	}
+	spec.UUID = uuid.New()
+	_, err = exts.Create(&jenkinsv1.Extension{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: *spec,
+	})
+	if err != nil {
+		return err
+	}
+	log.Infof("Created extension %s which runs on %s\n", util.ColorInfo(spec.FullyQualifiedName()), util.ColorInfo(strings.Join(o.When, ", ")))
+	return nil
+}
+
+// extensionSpec validates the options and returns the extension they describe
+func (o *CreateExtensionOptions) extensionSpec() (*jenkinsv1.ExtensionSpec, error) {
+	if len(o.When) == 0 {
+		return nil, util.MissingOption("when")
+	}
+	events := lifecycleEventNames()
+	whens := []jenkinsv1.ExtensionWhen{}
+	for _, when := range o.When {
+		if util.StringArrayIndex(events, when) < 0 {
+			return nil, util.InvalidOption("when", when, events)
+		}
+		whens = append(whens, jenkinsv1.ExtensionWhen(when))
+	}
+	if o.ScriptFile == "" && o.Image == "" {
+		return nil, fmt.Errorf("either the --script-file or --image option is required")
+	}
+	if o.ScriptFile != "" && o.Image != "" {
+		return nil, fmt.Errorf("the --script-file and --image options cannot be combined, use --command to run a command in the image")
+	}
+	script := o.Command
+	if o.ScriptFile != "" {
+		data, err := ioutil.ReadFile(o.ScriptFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the script file %s: %s", o.ScriptFile, err)
+		}
+		script = strings.TrimSuffix(string(data), "\n")
+	}
+	return &jenkinsv1.ExtensionSpec{
+		Name:        o.Name,
+		Namespace:   o.ExtensionNamespace,
+		Description: o.Description,
+		Version:     o.Version,
+		When:        whens,
+		Script:      script,
+		Image:       o.Image,
+	}, nil
+}
+
+// lifecycleEventNames returns the names of the lifecycle events extensions can be attached to
+func lifecycleEventNames() []string {
+	answer := []string{}
+	for _, when := range jenkinsv1.ExtensionLifecycleEvents {
+		answer = append(answer, string(when))
+	}
+	return answer
+}
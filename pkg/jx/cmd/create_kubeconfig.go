@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+var (
+	createKubeConfigLong = templates.LongDesc(`
+		Creates a kube config file for a member of the team which authenticates them via an OIDC identity provider
+		such as Google, Azure Active Directory or Dex rather than sharing the admin credentials of the cluster.
+
+		The API server of the cluster must trust the identity provider and use the email claim as the user name.
+		The user is bound to the Roles of the team they are given so they get the same permissions as their jx roles.
+
+		The kube config uses the kubelogin plugin to log the user in via their browser, so they need to install it
+		first, for example via: kubectl krew install oidc-login
+`)
+
+	createKubeConfigExample = templates.Examples(`
+		# Create a kube config for a user authenticated by Google with the viewer role
+		jx create kubeconfig --user someone@corp.com --client-id myclient --role viewer
+
+		# Create a kube config for a user authenticated by Azure Active Directory
+		jx create kubeconfig --user someone@corp.com --provider azure --tenant mytenant --client-id myclient --role committer
+
+		# Create a kube config for a user authenticated by Dex
+		jx create kubeconfig --user someone@corp.com --provider dex --issuer-url https://dex.example.com --client-id myclient
+	`)
+)
+
+// CreateKubeConfigOptions the options for the create kubeconfig command
+type CreateKubeConfigOptions struct {
+	CreateOptions
+
+	User         string
+	Provider     string
+	IssuerURL    string
+	Tenant       string
+	ClientID     string
+	ClientSecret string
+	ExtraScopes  []string
+	Roles        []string
+	OutputFile   string
+}
+
+// NewCmdCreateKubeConfig creates a command object for the "create kubeconfig" command
+func NewCmdCreateKubeConfig(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &CreateKubeConfigOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "kubeconfig",
+		Short:   "Creates a kube config file for a member of the team which authenticates via OIDC",
+		Long:    createKubeConfigLong,
+		Example: createKubeConfigExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.User, "user", "u", "", "The email address of the user which is used as the user name by the API server")
+	cmd.Flags().StringVarP(&options.Provider, "provider", "p", kube.OIDCProviderGoogle, fmt.Sprintf("The OIDC identity provider. Supported providers: %s", strings.Join(kube.OIDCProviders, ", ")))
+	cmd.Flags().StringVarP(&options.IssuerURL, "issuer-url", "", "", "The issuer URL of the identity provider. Defaults to the one of the provider")
+	cmd.Flags().StringVarP(&options.Tenant, "tenant", "", "", "The tenant ID of the Azure Active Directory")
+	cmd.Flags().StringVarP(&options.ClientID, "client-id", "", "", "The OIDC client ID trusted by the API server")
+	cmd.Flags().StringVarP(&options.ClientSecret, "client-secret", "", "", "The OIDC client secret")
+	cmd.Flags().StringArrayVarP(&options.ExtraScopes, "scope", "", []string{"email"}, "The extra scopes requested from the identity provider. Can be specified multiple times")
+	cmd.Flags().StringArrayVarP(&options.Roles, "role", "r", []string{}, "The team roles of the user. Can be specified multiple times")
+	cmd.Flags().StringVarP(&options.OutputFile, "output", "o", "", "The kube config file to create. Defaults to kubeconfig-<user>")
+	return cmd
+}
+
+// Run implements this command
+func (o *CreateKubeConfigOptions) Run() error {
+	if o.User == "" {
+		return util.MissingOption("user")
+	}
+	if o.ClientID == "" {
+		return util.MissingOption("client-id")
+	}
+	issuerURL := o.IssuerURL
+	if issuerURL == "" {
+		var err error
+		issuerURL, err = kube.OIDCIssuerURL(o.Provider, o.Tenant)
+		if err != nil {
+			return errors.Wrap(err, "failed to find the issuer URL of the identity provider, try the --issuer-url flag")
+		}
+	}
+
+	err := o.registerEnvironmentRoleBindingCRD()
+	if err != nil {
+		return err
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+
+	roles, roleNames, err := kube.GetTeamRoles(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	userRoles := o.Roles
+	if len(userRoles) == 0 && len(roleNames) > 0 && !o.BatchMode {
+		userRoles, err = util.PickNames(roleNames, "Roles for user: "+o.User, "", o.In, o.Out, o.Err)
+		if err != nil {
+			return err
+		}
+	}
+	for _, role := range userRoles {
+		if roles[role] == nil {
+			return util.InvalidOption("role", role, roleNames)
+		}
+	}
+	if len(userRoles) > 0 {
+		// the API server uses the email claim of the OIDC token as the name of the user
+		err = kube.UpdateUserRoles(kubeClient, jxClient, ns, "User", o.User, userRoles, roles)
+		if err != nil {
+			return errors.Wrapf(err, "failed to bind the user %s to the roles %s", o.User, strings.Join(userRoles, ", "))
+		}
+		log.Infof("Bound the user %s to the roles %s\n", util.ColorInfo(o.User), util.ColorInfo(strings.Join(userRoles, ", ")))
+	} else {
+		log.Warnf("The user %s has no roles so they cannot access the team resources\n", o.User)
+	}
+
+	config, _, err := o.Kube().LoadConfig()
+	if err != nil {
+		return err
+	}
+	oidc := &kube.OIDCConfig{
+		IssuerURL:    issuerURL,
+		ClientID:     o.ClientID,
+		ClientSecret: o.ClientSecret,
+		ExtraScopes:  o.ExtraScopes,
+	}
+	userConfig, err := kube.CreateOIDCKubeConfig(config, o.User, oidc, ns)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create the kube config of user %s", o.User)
+	}
+	fileName := o.OutputFile
+	if fileName == "" {
+		fileName = "kubeconfig-" + kube.ToValidName(o.User)
+	}
+	err = clientcmd.WriteToFile(*userConfig, fileName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to write the kube config file %s", fileName)
+	}
+	log.Infof("Created the kube config file %s for user %s\n", util.ColorInfo(fileName), util.ColorInfo(o.User))
+	log.Infof("The user needs the kubelogin plugin, see %s\n", util.ColorInfo("https://github.com/int128/kubelogin"))
+	log.Infof("The user can use it via: %s\n", util.ColorInfo("export KUBECONFIG="+fileName))
+	return nil
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+var (
+	createPodTemplateLong = templates.LongDesc(`
+		Creates a Jenkins pod template for the build agents of your team from an existing pod template such as the
+		pod template of a build pack.
+
+		The new pod template is stored as a customisation of the existing pod template so that it picks up the
+		changes to the existing pod template when the platform is upgraded. The pod template is deleted via
+		'jx delete podtemplate'.
+`)
+
+	createPodTemplateExample = templates.Examples(`
+		# Create a pod template for large maven builds
+		jx create podtemplate maven-big --from maven --request memory=4Gi --limit memory=8Gi
+
+		# Create a pod template with a custom image and a Secret mounted
+		jx create podtemplate nodejs-e2e --from nodejs --image myorg/builder-e2e:1.0.0 --secret-volume e2e-creds=/home/jenkins/e2e
+	`)
+)
+
+// CreatePodTemplateOptions the options for the create podtemplate command
+type CreatePodTemplateOptions struct {
+	CreateOptions
+	PodTemplateFlags
+
+	Name string
+	From string
+}
+
+// NewCmdCreatePodTemplate creates a command object for the "create podtemplate" command
+func NewCmdCreatePodTemplate(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &CreatePodTemplateOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "podtemplate [name]",
+		Short:   "Creates a Jenkins pod template for the build agents of your team",
+		Aliases: []string{"podtemplates", "pod-template"},
+		Long:    createPodTemplateLong,
+		Example: createPodTemplateExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Name, "name", "n", "", "The name of the pod template")
+	cmd.Flags().StringVarP(&options.From, "from", "f", "", "The name of the pod template to create the pod template from")
+	options.PodTemplateFlags.addFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *CreatePodTemplateOptions) Run() error {
+	if o.Name == "" && len(o.Args) > 0 {
+		o.Name = o.Args[0]
+	}
+	if o.Name == "" {
+		return util.MissingOption("name")
+	}
+	if o.From == "" {
+		return util.MissingOption("from")
+	}
+	customisation, err := o.customisation()
+	if err != nil {
+		return err
+	}
+	customisation.From = o.From
+
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	_, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	podTemplates, err := kube.LoadPodTemplates(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	if podTemplates[o.Name] != nil {
+		return fmt.Errorf("the pod template %s already exists. You can change it via: jx edit podtemplate %s", o.Name, o.Name)
+	}
+	if podTemplates[o.From] == nil {
+		return util.InvalidOption("from", o.From, podTemplateNames(podTemplates))
+	}
+	err = kube.SavePodTemplateCustomisation(kubeClient, ns, o.Name, customisation)
+	if err != nil {
+		return err
+	}
+	log.Infof("Created the pod template %s from %s\n", util.ColorInfo(o.Name), util.ColorInfo(o.From))
+	log.Infof("To use it in the static Jenkins server run: %s\n", util.ColorInfo("jx edit jenkins"))
+	return nil
+}
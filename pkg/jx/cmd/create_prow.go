@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+// CreateProwOptions the options for the create prow command
+type CreateProwOptions struct {
+	CreateOptions
+}
+
+// NewCmdCreateProw creates a command object for the "create prow" command
+func NewCmdCreateProw(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &CreateProwOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "prow",
+		Short: "Creates a Prow resource such as a job",
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.AddCommand(NewCmdCreateProwJob(f, in, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *CreateProwOptions) Run() error {
+	return o.Cmd.Help()
+}
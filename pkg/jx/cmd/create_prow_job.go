@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/prow"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/test-infra/prow/config"
+)
+
+const defaultProwJobImage = "jenkinsxio/builder-base:latest"
+
+var (
+	createProwJobLong = templates.LongDesc(`
+		Creates a Prow job which runs a command in a container for the pull requests of a repository or when changes
+		are merged into its branches.
+
+		Presubmit jobs run on each pull request and report their status as a check of the pull request. They can be
+		triggered again by commenting '/test <name>' on the pull request.
+
+		If the development environment uses GitOps, the job is added to its prow/config.yaml via a Pull Request so that
+		the config-updater plugin updates the Prow configuration when it is merged. Otherwise the job is added to the
+		Prow ConfigMap directly.
+`)
+
+	createProwJobExample = templates.Examples(`
+		# Run the linter on the pull requests of the repository in the current directory
+		jx create prow job lint --command "make lint"
+
+		# Run the integration tests on the pull requests of a repository with a custom image
+		jx create prow job integration --repo myorg/myapp --image golang:1.11 --command "make test-integration"
+
+		# Publish the docs whenever changes are merged into master
+		jx create prow job docs --postsubmit --command "make publish-docs"
+	`)
+)
+
+// CreateProwJobOptions the options for the create prow job command
+type CreateProwJobOptions struct {
+	CreateOptions
+
+	Name       string
+	Repos      []string
+	Image      string
+	Command    string
+	Context    string
+	Branches   []string
+	PostSubmit bool
+	Manual     bool
+}
+
+// NewCmdCreateProwJob creates a command object for the "create prow job" command
+func NewCmdCreateProwJob(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &CreateProwJobOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "job [name]",
+		Short:   "Creates a Prow job which runs a command for the pull requests or merges of a repository",
+		Long:    createProwJobLong,
+		Example: createProwJobExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Name, "name", "n", "", "The name of the job")
+	cmd.Flags().StringArrayVarP(&options.Repos, "repo", "r", []string{}, "The repository of the form owner/name to add the job to. Can be specified multiple times. Defaults to the repository of the current directory")
+	cmd.Flags().StringVarP(&options.Image, "image", "i", defaultProwJobImage, "The container image the command runs in")
+	cmd.Flags().StringVarP(&options.Command, "command", "c", "", "The shell command the job runs")
+	cmd.Flags().StringVarP(&options.Context, "context", "", "", "The name of the status check reported on the pull requests. Defaults to the name of the job")
+	cmd.Flags().StringArrayVarP(&options.Branches, "branch", "b", []string{"master"}, "The branches which run a postsubmit job when changes are merged into them")
+	cmd.Flags().BoolVarP(&options.PostSubmit, "postsubmit", "", false, "Creates a postsubmit job which runs when changes are merged rather than a presubmit job which runs on the pull requests")
+	cmd.Flags().BoolVarP(&options.Manual, "manual", "", false, "The presubmit job only runs when triggered by a '/test <name>' comment on the pull request")
+	return cmd
+}
+
+// Run implements this command
+func (o *CreateProwJobOptions) Run() error {
+	if o.Name == "" && len(o.Args) > 0 {
+		o.Name = o.Args[0]
+	}
+	if len(o.Repos) == 0 {
+		gitInfo, err := o.FindGitInfo("")
+		if err != nil {
+			return errors.Wrap(err, "no --repo specified and failed to find the repository of the current directory")
+		}
+		o.Repos = []string{gitInfo.Organisation + "/" + gitInfo.Name}
+	}
+	preSubmit, postSubmit, err := o.createJobs()
+	if err != nil {
+		return err
+	}
+
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	_, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	prowOptions := prow.Options{
+		KubeClient: kubeClient,
+		NS:         ns,
+		Repos:      o.Repos,
+	}
+	// the config-updater plugin would overwrite the ConfigMap from the source of a GitOps development environment
+	gitOps, devEnv := o.GetDevEnv()
+	if gitOps {
+		return o.createJobPullRequest(devEnv, &prowOptions, preSubmit, postSubmit)
+	}
+	err = prowOptions.AddJobs(preSubmit, postSubmit)
+	if err != nil {
+		return errors.Wrapf(err, "failed to add the Prow job %s", o.Name)
+	}
+	kind := "presubmit"
+	if o.PostSubmit {
+		kind = "postsubmit"
+	}
+	log.Infof("Created the Prow %s job %s for %s\n", kind, util.ColorInfo(o.Name), util.ColorInfo(strings.Join(o.Repos, ", ")))
+	return nil
+}
+
+// createJobPullRequest adds the job to the Prow configuration of the development environment via a Pull Request
+func (o *CreateProwJobOptions) createJobPullRequest(devEnv *v1.Environment, prowOptions *prow.Options, preSubmit config.Presubmit, postSubmit config.Postsubmit) error {
+	modifyDirFn := func(dir string) error {
+		return prowOptions.AddJobsToConfigFile(dir, preSubmit, postSubmit)
+	}
+	branchName := "prow-job-" + o.Name
+	title := fmt.Sprintf("Add the Prow job %s", o.Name)
+	message := fmt.Sprintf("Add the Prow job %s for %s", o.Name, strings.Join(o.Repos, ", "))
+	info, err := o.createEnvironmentPullRequestForDir(devEnv, modifyDirFn, &branchName, &title, &message, nil, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create the Pull Request adding the Prow job %s to the development environment", o.Name)
+	}
+	if info != nil && info.PullRequest != nil {
+		log.Infof("Added the Prow job %s via Pull Request %s\n", util.ColorInfo(o.Name), info.PullRequest.URL)
+	}
+	return nil
+}
+
+// createJobs creates the presubmit or postsubmit job from the options. The other job has no name so it is not added
+func (o *CreateProwJobOptions) createJobs() (config.Presubmit, config.Postsubmit, error) {
+	preSubmit := config.Presubmit{}
+	postSubmit := config.Postsubmit{}
+	if o.Name == "" {
+		return preSubmit, postSubmit, util.MissingOption("name")
+	}
+	if o.Command == "" {
+		return preSubmit, postSubmit, util.MissingOption("command")
+	}
+	for _, repo := range o.Repos {
+		if len(strings.Split(repo, "/")) != 2 {
+			return preSubmit, postSubmit, util.InvalidOptionf("repo", repo, "repositories should be of the form owner/name")
+		}
+	}
+	spec := &corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Image:   o.Image,
+				Command: []string{"/bin/sh", "-c"},
+				Args:    []string{o.Command},
+			},
+		},
+	}
+
+	if o.PostSubmit {
+		if len(o.Branches) == 0 {
+			return preSubmit, postSubmit, util.MissingOption("branch")
+		}
+		postSubmit.Name = o.Name
+		postSubmit.Agent = prow.KubernetesAgent
+		postSubmit.Branches = o.Branches
+		postSubmit.Spec = spec
+		return preSubmit, postSubmit, nil
+	}
+
+	preSubmit.Name = o.Name
+	preSubmit.Agent = prow.KubernetesAgent
+	preSubmit.Context = o.Context
+	if preSubmit.Context == "" {
+		preSubmit.Context = o.Name
+	}
+	preSubmit.AlwaysRun = !o.Manual
+	preSubmit.RerunCommand = "/test " + o.Name
+	preSubmit.Trigger = fmt.Sprintf("(?m)^/test( all| %s),?(\\s+|$)", regexp.QuoteMeta(o.Name))
+	preSubmit.Spec = spec
+	return preSubmit, postSubmit, nil
+}
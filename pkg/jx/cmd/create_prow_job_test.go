@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/prow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateProwPresubmitJob(t *testing.T) {
+	t.Parallel()
+	o := &CreateProwJobOptions{
+		Name:    "lint",
+		Repos:   []string{"myorg/myapp"},
+		Image:   defaultProwJobImage,
+		Command: "make lint",
+	}
+
+	preSubmit, postSubmit, err := o.createJobs()
+	require.NoError(t, err)
+	assert.Equal(t, "", postSubmit.Name)
+	assert.Equal(t, "lint", preSubmit.Name)
+	assert.Equal(t, "lint", preSubmit.Context)
+	assert.Equal(t, prow.KubernetesAgent, preSubmit.Agent)
+	assert.True(t, preSubmit.AlwaysRun)
+	assert.Equal(t, "/test lint", preSubmit.RerunCommand)
+	assert.Equal(t, `(?m)^/test( all| lint),?(\s+|$)`, preSubmit.Trigger)
+	require.NotNil(t, preSubmit.Spec)
+	assert.Equal(t, []string{"make lint"}, preSubmit.Spec.Containers[0].Args)
+}
+
+func TestCreateProwPresubmitJobQuotesTheTrigger(t *testing.T) {
+	t.Parallel()
+	o := &CreateProwJobOptions{
+		Name:    "e2e.smoke+",
+		Repos:   []string{"myorg/myapp"},
+		Command: "make e2e",
+	}
+
+	preSubmit, _, err := o.createJobs()
+	require.NoError(t, err)
+	assert.Equal(t, `(?m)^/test( all| e2e\.smoke\+),?(\s+|$)`, preSubmit.Trigger)
+}
+
+func TestCreateProwPostsubmitJob(t *testing.T) {
+	t.Parallel()
+	o := &CreateProwJobOptions{
+		Name:       "docs",
+		Repos:      []string{"myorg/myapp"},
+		Image:      defaultProwJobImage,
+		Command:    "make publish-docs",
+		Branches:   []string{"master"},
+		PostSubmit: true,
+	}
+
+	preSubmit, postSubmit, err := o.createJobs()
+	require.NoError(t, err)
+	assert.Equal(t, "", preSubmit.Name)
+	assert.Equal(t, "docs", postSubmit.Name)
+	assert.Equal(t, []string{"master"}, postSubmit.Branches)
+}
+
+func TestCreateProwJobInvalidRepo(t *testing.T) {
+	t.Parallel()
+	o := &CreateProwJobOptions{
+		Name:    "lint",
+		Repos:   []string{"myapp"},
+		Command: "make lint",
+	}
+
+	_, _, err := o.createJobs()
+	assert.Error(t, err)
+}
@@ -9,15 +9,12 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/gits"
-	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/quickstarts"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 
-	"github.com/jenkins-x/jx/pkg/auth"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/util"
 )
@@ -110,50 +107,7 @@ func (o *CreateQuickstartOptions) Run() error {
 	}
 	config := authConfigSvc.Config()
 
-	var locations []v1.QuickStartLocation
-	if !o.IgnoreTeam {
-		jxClient, ns, err := o.JXClientAndDevNamespace()
-		if err != nil {
-			return err
-		}
-
-		locations, err = kube.GetQuickstartLocations(jxClient, ns)
-		if err != nil {
-			return err
-		}
-	}
-
-	// lets add any extra github organisations if they are not already configured
-	for _, org := range o.GitHubOrganisations {
-		found := false
-		for _, loc := range locations {
-			if loc.GitURL == gits.GitHubURL && loc.Owner == org {
-				found = true
-				break
-			}
-		}
-		if !found {
-			locations = append(locations, v1.QuickStartLocation{
-				GitURL:   gits.GitHubURL,
-				GitKind:  gits.KindGitHub,
-				Owner:    org,
-				Includes: []string{"*"},
-				Excludes: []string{"WIP-*"},
-			})
-		}
-	}
-
-	gitMap := map[string]map[string]v1.QuickStartLocation{}
-	for _, loc := range locations {
-		m := gitMap[loc.GitURL]
-		if m == nil {
-			m = map[string]v1.QuickStartLocation{}
-			gitMap[loc.GitURL] = m
-		}
-		m[loc.Owner] = loc
-	}
-
-	model, err := o.LoadQuickstartsFromMap(config, gitMap)
+	model, err := o.loadQuickstartModel(config, o.GitHubOrganisations, o.IgnoreTeam)
 	if err != nil {
 		return fmt.Errorf("failed to load quickstarts: %s", err)
 	}
@@ -288,27 +242,3 @@ func findFirstDirectory(dir string) (string, error) {
 	}
 	return "", fmt.Errorf("no child directory found in %s", dir)
 }
-
-// LoadQuickstartsFromMap Load all quickstarts
-func (o *CreateQuickstartOptions) LoadQuickstartsFromMap(config *auth.AuthConfig, gitMap map[string]map[string]v1.QuickStartLocation) (*quickstarts.QuickstartModel, error) {
-	model := quickstarts.NewQuickstartModel()
-
-	for gitURL, m := range gitMap {
-		for _, location := range m {
-			kind := location.GitKind
-			if kind == "" {
-				kind = gits.KindGitHub
-			}
-			gitProvider, err := o.gitProviderForGitServerURL(gitURL, kind)
-			if err != nil {
-				return model, err
-			}
-			o.Debugf("Searching for repositories in Git server %s owner %s includes %s excludes %s as user %s \n", gitProvider.ServerURL(), location.Owner, strings.Join(location.Includes, ", "), strings.Join(location.Excludes, ", "), gitProvider.CurrentUsername())
-			err = model.LoadGithubQuickstarts(gitProvider, location.Owner, location.Includes, location.Excludes)
-			if err != nil {
-				o.Debugf("Quickstart load error: %s\n", err.Error())
-			}
-		}
-	}
-	return model, nil
-}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/blang/semver"
+	chgit "github.com/jenkins-x/chyle/chyle/git"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+const (
+	defaultFirstReleaseVersion = "0.0.1"
+)
+
+var (
+	createReleaseLong = templates.LongDesc(`
+		Creates a release of the git repository in the current directory.
+
+		The version is tagged and pushed, then a changelog is generated from the commits and pull requests since the
+		previous release. The changelog is used to create the release on the git provider and a Release resource is
+		recorded in the development namespace so that the release is listed by 'jx get releases'.
+
+		If no version is specified the next version is calculated from the latest version tag using Conventional Commits:
+		breaking changes bump the major version, features the minor version and anything else the patch version.
+		See: https://conventionalcommits.org/
+`) + GitAccessDescription
+
+	createReleaseExample = templates.Examples(`
+		# Create a release using the next version based on the commits since the last release
+		jx create release
+
+		# Create a release of a specific version
+		jx create release --version 1.2.3
+
+		# Create a release with a header for the changelog
+		jx create release --header-file docs/changelog-header.md
+	`)
+)
+
+// CreateReleaseOptions the options for the create release command
+type CreateReleaseOptions struct {
+	CreateOptions
+
+	Dir            string
+	Version        string
+	TagPrefix      string
+	HeaderFile     string
+	FooterFile     string
+	NoPush         bool
+	NoReleaseInDev bool
+}
+
+// NewCmdCreateRelease creates a command object for the "create release" command
+func NewCmdCreateRelease(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &CreateReleaseOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "release",
+		Short:   "Creates a release with a changelog of the git repository in the current directory",
+		Aliases: []string{"rel"},
+		Long:    createReleaseLong,
+		Example: createReleaseExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "", "", "The directory of the git repository. Defaults to the current working directory")
+	cmd.Flags().StringVarP(&options.Version, "version", "v", "", "The version to release. Defaults to the next version based on the commits since the last release")
+	cmd.Flags().StringVarP(&options.TagPrefix, "tag-prefix", "", "v", "The prefix of the git tag of the version")
+	cmd.Flags().StringVarP(&options.HeaderFile, "header-file", "", "", "The file name of the changelog header in markdown. Can use go template expressions on the ReleaseSpec object: https://golang.org/pkg/text/template/")
+	cmd.Flags().StringVarP(&options.FooterFile, "footer-file", "", "", "The file name of the changelog footer in markdown. Can use go template expressions on the ReleaseSpec object: https://golang.org/pkg/text/template/")
+	cmd.Flags().BoolVarP(&options.NoPush, "no-push", "", false, "Only tags the version locally and outputs the changelog without creating the release on the git provider")
+	cmd.Flags().BoolVarP(&options.NoReleaseInDev, "no-dev-release", "", false, "Disables recording the Release resource in the development namespace")
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateReleaseOptions) Run() error {
+	dir := o.Dir
+	if dir == "" {
+		var err error
+		dir, err = os.Getwd()
+		if err != nil {
+			return err
+		}
+	}
+	gitDir, _, err := o.Git().FindGitConfigDir(dir)
+	if err != nil {
+		return err
+	}
+	if gitDir == "" {
+		return fmt.Errorf("no git repository could be found from %s", dir)
+	}
+
+	err = o.Git().FetchTags(dir)
+	if err != nil {
+		log.Warnf("Failed to fetch the git tags: %s\n", err)
+	}
+	tags, err := o.Git().Tags(dir)
+	if err != nil {
+		return errors.Wrapf(err, "listing the git tags in %s", dir)
+	}
+	previousTag, previousVersion := gits.LatestVersionTag(tags)
+
+	version := o.Version
+	if version == "" {
+		version, err = o.nextVersion(gitDir, previousTag, previousVersion)
+		if err != nil {
+			return err
+		}
+	}
+	if _, err := semver.ParseTolerant(version); err != nil {
+		return errors.Wrapf(err, "parsing the version %s", version)
+	}
+	tag := o.TagPrefix + version
+	if util.StringArrayIndex(tags, tag) >= 0 {
+		return fmt.Errorf("the tag %s already exists", tag)
+	}
+
+	if !o.BatchMode {
+		if !util.Confirm(fmt.Sprintf("Create release %s of %s?", version, dir), true, "Tags the version and creates the release with its changelog", o.In, o.Out, o.Err) {
+			return nil
+		}
+	}
+
+	err = o.Git().CreateTag(dir, tag, fmt.Sprintf("release %s", version))
+	if err != nil {
+		return errors.Wrapf(err, "creating the tag %s", tag)
+	}
+	if !o.NoPush {
+		err = o.Git().PushTag(dir, tag)
+		if err != nil {
+			return errors.Wrapf(err, "pushing the tag %s", tag)
+		}
+	}
+	log.Infof("Tagged version %s as %s\n", util.ColorInfo(version), util.ColorInfo(tag))
+
+	changelog := &StepChangelogOptions{
+		StepOptions: StepOptions{
+			CommonOptions: o.CommonOptions,
+		},
+		Dir:              dir,
+		PreviousRevision: previousTag,
+		CurrentRevision:  tag,
+		Version:          tag,
+		HeaderFile:       o.HeaderFile,
+		FooterFile:       o.FooterFile,
+		UpdateRelease:    !o.NoPush,
+		NoReleaseInDev:   o.NoReleaseInDev,
+		// the changelog of the first release contains all the commits of the repository
+		FirstRelease: previousTag == "",
+	}
+	err = changelog.Run()
+	if err != nil {
+		return errors.Wrapf(err, "generating the changelog of %s", tag)
+	}
+	log.Successf("Created release %s", tag)
+	return nil
+}
+
+// nextVersion returns the next version based on the conventional commits since the previous tag
+func (o *CreateReleaseOptions) nextVersion(gitDir string, previousTag string, previousVersion *semver.Version) (string, error) {
+	if previousVersion == nil {
+		return defaultFirstReleaseVersion, nil
+	}
+	commits, err := chgit.FetchCommits(gitDir, previousTag, "HEAD")
+	if err != nil {
+		return "", errors.Wrapf(err, "finding the commits since %s", previousTag)
+	}
+	messages := []string{}
+	if commits != nil {
+		for _, commit := range *commits {
+			messages = append(messages, commit.Message)
+		}
+	}
+	if len(messages) == 0 {
+		return "", fmt.Errorf("there are no commits since the release %s", previousTag)
+	}
+	next := gits.NextVersion(*previousVersion, messages)
+	return next.String(), nil
+}
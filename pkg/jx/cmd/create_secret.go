@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// pipelineSecretsVaultPath the path in Vault which the pipeline secrets are stored under
+const pipelineSecretsVaultPath = "pipelines"
+
+var (
+	createSecretLong = templates.LongDesc(`
+		Creates or updates a secret which the pipelines of the team can use.
+
+		The values of the secret are injected into the build pods as environment variables named after the upper
+		case keys, or mounted as files in ` + kube.PipelineSecretsMountPath + `/<name> when --file is used. The
+		secret can be restricted to the pipelines of some repositories. On a static Jenkins server the secrets which are
+		not restricted to some repositories are added to the pod templates of Jenkins.
+
+		The values of the pipeline secrets are masked in the build logs displayed by 'jx get build logs' and in the
+		build logs which are archived for the builds.
+
+		If the team stores its secrets in Vault the values are saved in Vault too.
+
+		Any key given without a value is prompted for so that the value does not end up in the shell history.
+`)
+
+	createSecretExample = templates.Examples(`
+		# Create a secret with an NPM token which all the pipelines can use as the NPM_TOKEN environment variable
+		jx create secret --pipeline npm npm-token
+
+		# Create a secret which only the pipelines of the repositories of an organisation can use
+		jx create secret --pipeline sonar token=abc123 --repo myorg/*
+
+		# Mount a signing key as a file in the pipelines of a repository
+		jx create secret --pipeline signing key.pem="$(cat key.pem)" --file --repo myorg/myapp
+	`)
+)
+
+// CreateSecretOptions the options for the create secret command
+type CreateSecretOptions struct {
+	CreateOptions
+
+	Pipeline string
+	Repos    []string
+	File     bool
+}
+
+// NewCmdCreateSecret creates a command object for the "create secret" command
+func NewCmdCreateSecret(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &CreateSecretOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "secret [key=value]...",
+		Short:   "Creates or updates a secret which the pipelines of the team can use",
+		Aliases: []string{"secrets"},
+		Long:    createSecretLong,
+		Example: createSecretExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Pipeline, "pipeline", "p", "", "The name of the pipeline secret")
+	cmd.Flags().StringArrayVarP(&options.Repos, "repo", "r", []string{}, "The repository of the form owner/name or owner/* whose pipelines can use the secret. Can be specified multiple times. Defaults to all the repositories")
+	cmd.Flags().BoolVarP(&options.File, "file", "", false, "Mounts the values of the secret as files rather than injecting them as environment variables")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateSecretOptions) Run() error {
+	if o.Pipeline == "" {
+		return util.MissingOption("pipeline")
+	}
+	name := kube.ToValidName(o.Pipeline)
+	for _, repo := range o.Repos {
+		if len(strings.Split(repo, "/")) != 2 {
+			return util.InvalidOptionf("repo", repo, "repositories should be of the form owner/name or owner/*")
+		}
+	}
+	if len(o.Args) == 0 {
+		return fmt.Errorf("missing the key=value pairs of the secret %s", name)
+	}
+	data, err := o.secretData()
+	if err != nil {
+		return err
+	}
+
+	kubeClient, ns, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	if o.Factory.UseVault() {
+		vaultClient, err := o.Factory.GetSystemVaultClient()
+		if err != nil {
+			return errors.Wrap(err, "retrieving the vault client")
+		}
+		path := pipelineSecretsVaultPath + "/" + name
+		values := map[string]interface{}{}
+		for k, v := range data {
+			values[k] = string(v)
+		}
+		_, err = vaultClient.Write(path, values)
+		if err != nil {
+			return errors.Wrapf(err, "writing the secret %s", path)
+		}
+	}
+
+	mount := kube.ValuePipelineSecretEnv
+	if o.File {
+		mount = kube.ValuePipelineSecretFile
+	}
+	secrets := kubeClient.CoreV1().Secrets(ns)
+	secret, err := secrets.Get(name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "getting the secret %s in namespace %s", name, ns)
+	}
+	create := err != nil
+	if create {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		}
+	} else if secret.Labels[kube.LabelPipelineSecret] == "" {
+		return fmt.Errorf("the secret %s in namespace %s already exists and is not a pipeline secret", name, ns)
+	}
+	if secret.Labels == nil {
+		secret.Labels = map[string]string{}
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Labels[kube.LabelPipelineSecret] = mount
+	secret.Labels[kube.LabelCreatedBy] = kube.ValueCreatedByJX
+	if len(o.Repos) > 0 {
+		secret.Annotations[kube.AnnotationPipelineSecretRepositories] = strings.Join(o.Repos, ",")
+	}
+	for k, v := range data {
+		secret.Data[k] = v
+	}
+	if create {
+		_, err = secrets.Create(secret)
+	} else {
+		_, err = secrets.Update(secret)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to save the secret %s in namespace %s", name, ns)
+	}
+
+	log.Successf("Saved the pipeline secret %s", util.ColorInfo(name))
+	prow, err := o.isProw()
+	if err != nil {
+		return err
+	}
+	if !prow {
+		if len(o.Repos) > 0 {
+			log.Warnf("The secret %s is restricted to some repositories so it is not added to the pod templates of Jenkins\n", name)
+		} else {
+			editOptions := &EditJenkinsOptions{
+				EditOptions: EditOptions{
+					CommonOptions: o.CommonOptions,
+				},
+			}
+			err = editOptions.applyConfigurationAsCode()
+			if err != nil {
+				return errors.Wrap(err, "failed to add the pipeline secret to the pod templates of Jenkins")
+			}
+		}
+	}
+	for k := range data {
+		if o.File {
+			log.Infof("The pipelines can read %s from the file %s\n", util.ColorInfo(k), util.ColorInfo(kube.PipelineSecretsMountPath+"/"+name+"/"+k))
+		} else {
+			log.Infof("The pipelines can read %s from the environment variable %s\n", util.ColorInfo(k), util.ColorInfo(kube.PipelineSecretEnvName(k)))
+		}
+	}
+	return nil
+}
+
+// secretData returns the values of the secret from the key=value arguments prompting for any missing values
+func (o *CreateSecretOptions) secretData() (map[string][]byte, error) {
+	data := map[string][]byte{}
+	surveyOpts := survey.WithStdio(o.In, o.Out, o.Err)
+	for _, arg := range o.Args {
+		key := arg
+		value := ""
+		hasValue := false
+		if i := strings.Index(arg, "="); i >= 0 {
+			key = arg[:i]
+			value = arg[i+1:]
+			hasValue = true
+		}
+		if key == "" {
+			return nil, util.InvalidArgf(arg, "the key of the secret value is empty")
+		}
+		if !hasValue {
+			if o.BatchMode {
+				return nil, util.InvalidArgf(arg, "no value given for key %s in batch mode", key)
+			}
+			prompt := &survey.Password{
+				Message: fmt.Sprintf("Value of %s:", key),
+			}
+			err := survey.AskOne(prompt, &value, survey.Required, surveyOpts)
+			if err != nil {
+				return nil, err
+			}
+		}
+		data[key] = []byte(value)
+	}
+	return data, nil
+}
@@ -36,6 +36,9 @@ var (
 
 		# To create a gradle project use:
 		jx create spring --type gradle-project
+
+		# To generate the project using a private Spring Initializr
+		jx create spring --server-url https://start.mycompany.com
 	`)
 )
 
@@ -87,6 +90,9 @@ func NewCmdCreateSpring(f Factory, in terminal.FileReader, out terminal.FileWrit
 	cmd.Flags().StringVarP(&options.SpringForm.JavaVersion, spring.OptionJavaVersion, "j", "", "Java version")
 	cmd.Flags().StringVarP(&options.SpringForm.Packaging, spring.OptionPackaging, "p", "", "Packaging")
 	cmd.Flags().StringVarP(&options.SpringForm.Type, spring.OptionType, "", "", "Project Type (such as maven-project or gradle-project)")
+	cmd.Flags().StringVarP(&options.SpringForm.PackageName, spring.OptionPackageName, "", "", "The base package of the generated code. Defaults to the group and artifact IDs")
+	cmd.Flags().StringVarP(&options.SpringForm.Description, spring.OptionDescription, "", "", "The description of the generated project")
+	cmd.Flags().StringVarP(&options.SpringForm.ServerURL, spring.OptionServerURL, "", spring.DefaultSpringURL, "The URL of the Spring Initializr used to generate the project")
 
 	return cmd
 }
@@ -97,9 +103,9 @@ func (o *CreateSpringOptions) Run() error {
 	if err != nil {
 		return err
 	}
-	model, err := spring.LoadSpringBoot(cacheDir)
+	model, err := spring.LoadSpringBootFromURL(o.SpringForm.ServerURL, cacheDir)
 	if err != nil {
-		return fmt.Errorf("Failed to load Spring Boot model %s", err)
+		return fmt.Errorf("Failed to load Spring Boot model from %s: %s", o.SpringForm.ServerURL, err)
 	}
 
 	data := &o.SpringForm
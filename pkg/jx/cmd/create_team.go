@@ -16,12 +16,17 @@ import (
 var (
 	createTeamLong = templates.LongDesc(`
 		Creates a Team
+
+		The Team is provisioned by the team controller into its own namespace with its own environments, git defaults
+		and addons without reinstalling the platform. Use 'jx team' to switch between the teams.
 `)
 
 	createTeamExample = templates.Examples(`
 		# Create a new pending Team which can then be provisioned
 		jx create team myname
-"
+
+		# Create a Team whose repositories default to the myorg organisation with the Prometheus addon
+		jx create team myname --git-org myorg --addon prometheus
 	`)
 )
 
@@ -29,8 +34,12 @@ var (
 type CreateTeamOptions struct {
 	CreateOptions
 
-	Name    string
-	Members []string
+	Name           string
+	Members        []string
+	GitServer      string
+	Organisation   string
+	NoEnvironments bool
+	Addons         []string
 }
 
 // NewCmdCreateTeam creates a command object for the "create" command
@@ -62,6 +71,10 @@ func NewCmdCreateTeam(f Factory, in terminal.FileReader, out terminal.FileWriter
 
 	cmd.Flags().StringVarP(&options.Name, optionName, "n", "", "The name of the new Team. Should be all lower case and no special characters other than '-'")
 	cmd.Flags().StringArrayVarP(&options.Members, "member", "m", []string{}, "The usernames of the members to add to the Team")
+	cmd.Flags().StringVarP(&options.GitServer, "git-server", "", "", "The default git server for new repositories of the Team. Defaults to the one of the admin team")
+	cmd.Flags().StringVarP(&options.Organisation, "git-org", "", "", "The default git organisation for new repositories of the Team")
+	cmd.Flags().BoolVarP(&options.NoEnvironments, "no-environments", "", false, "Disables the creation of the default Staging and Production environments of the Team")
+	cmd.Flags().StringArrayVarP(&options.Addons, "addon", "a", []string{}, "The names of the addons to install into the Team")
 
 	options.addCommonFlags(cmd)
 	return cmd
@@ -108,8 +121,11 @@ func (o *CreateTeamOptions) Run() error {
 		return fmt.Errorf("The Team %s already exists!", name)
 	}
 
-	// TODO configure other properties?
 	team := kube.CreateTeam(ns, name, o.Members)
+	team.Spec.GitServer = o.GitServer
+	team.Spec.Organisation = o.Organisation
+	team.Spec.NoEnvironments = o.NoEnvironments
+	team.Spec.Addons = o.Addons
 	_, err = jxClient.JenkinsV1().Teams(ns).Create(team)
 	if err != nil {
 		return fmt.Errorf("Failed to create Team %s: %s", name, err)
@@ -0,0 +1,86 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/gits/mocks"
+	"github.com/jenkins-x/jx/pkg/helm/mocks"
+	"github.com/jenkins-x/jx/pkg/jx/cmd"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/mocks"
+	"github.com/jenkins-x/jx/pkg/kube"
+	. "github.com/petergtz/pegomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextentions_mocks "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newCreateTeamOptions(t *testing.T) *cmd.CreateTeamOptions {
+	RegisterMockTestingT(t)
+
+	factory := cmd_test.NewMockFactory()
+	When(factory.CreateApiExtensionsClient()).ThenReturn(apiextentions_mocks.NewSimpleClientset(), nil)
+
+	o := &cmd.CreateTeamOptions{
+		CreateOptions: cmd.CreateOptions{
+			CommonOptions: cmd.CommonOptions{
+				Factory: factory,
+			},
+		},
+	}
+	cmd.ConfigureTestOptions(&o.CommonOptions, gits_test.NewMockGitter(), helm_test.NewMockHelmer())
+	return o
+}
+
+func TestCreateTeamWithGitDefaultsEnvironmentsAndAddons(t *testing.T) {
+	o := newCreateTeamOptions(t)
+	o.Args = []string{"myteam"}
+	o.Members = []string{"alice"}
+	o.GitServer = "https://gitlab.example.com"
+	o.Organisation = "myorg"
+	o.NoEnvironments = true
+	o.Addons = []string{"prometheus", "anchore"}
+
+	err := o.Run()
+	require.NoError(t, err)
+
+	jxClient, _, err := o.JXClient()
+	require.NoError(t, err)
+	team, err := jxClient.JenkinsV1().Teams("jx").Get("myteam", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice"}, team.Spec.Members)
+	assert.Equal(t, "https://gitlab.example.com", team.Spec.GitServer)
+	assert.Equal(t, "myorg", team.Spec.Organisation)
+	assert.True(t, team.Spec.NoEnvironments)
+	assert.Equal(t, []string{"prometheus", "anchore"}, team.Spec.Addons)
+}
+
+func TestCreateTeamDefaults(t *testing.T) {
+	o := newCreateTeamOptions(t)
+	o.Name = "myteam"
+
+	err := o.Run()
+	require.NoError(t, err)
+
+	jxClient, _, err := o.JXClient()
+	require.NoError(t, err)
+	team, err := jxClient.JenkinsV1().Teams("jx").Get("myteam", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "", team.Spec.GitServer, "the team controller defaults the git server to the one of the admin team")
+	assert.Equal(t, "", team.Spec.Organisation)
+	assert.False(t, team.Spec.NoEnvironments)
+	assert.Empty(t, team.Spec.Addons)
+}
+
+func TestCreateTeamAlreadyExists(t *testing.T) {
+	o := newCreateTeamOptions(t)
+	jxClient, _, err := o.JXClient()
+	require.NoError(t, err)
+	_, err = jxClient.JenkinsV1().Teams("jx").Create(kube.CreateTeam("jx", "myteam", nil))
+	require.NoError(t, err)
+
+	o.Name = "myteam"
+	err = o.Run()
+	require.Error(t, err)
+	assert.Equal(t, "The Team myteam already exists!", err.Error())
+}
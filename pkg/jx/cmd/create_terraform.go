@@ -939,6 +939,10 @@ func (options *CreateTerraformOptions) applyTerraformGKE(g *GKECluster, path str
 		serviceAccountPath = g.ServiceAccount
 		fmt.Fprintf(options.Out, "Using provided GCP service account: %s\n", util.ColorInfo(serviceAccountPath))
 	}
+	// external-dns and cert-manager manage the DNS records of a custom domain with the same service account
+	if options.InstallOptions.InitOptions.Flags.DNSServiceAccount == "" {
+		options.InstallOptions.InitOptions.Flags.DNSServiceAccount = serviceAccountPath
+	}
 
 	// create the bucket
 	bucketName := fmt.Sprintf("%s-%s-terraform-state", g.ProjectID, options.Flags.OrganisationName)
@@ -996,7 +1000,7 @@ func (options *CreateTerraformOptions) applyTerraformGKE(g *GKECluster, path str
 			return err
 		}
 
-		output, err := options.getCommandOutput("", "gcloud", "container", "clusters", "get-credentials", g.ClusterName(), "--zone", g.Zone, "--project", g.ProjectID)
+		output, err := options.getGKECredentials(g.ClusterName(), g.Zone, g.ProjectID)
 		if err != nil {
 			return err
 		}
@@ -21,11 +21,17 @@ const (
 var (
 	createUserLong = templates.LongDesc(`
 		Creates a user
+
+		The user can be mapped to their git provider login and given team roles such as viewer, developer or admin which
+		are bound to the environments of the team.
 `)
 
 	createUserExample = templates.Examples(`
 		# Create a user
 		jx create user -e "user@email.com" --login username --name username"
+
+		# Create a user for a GitHub login who is a developer in the team
+		jx create user --login username --git-user mygithubuser --role developer
 	`)
 )
 
@@ -33,6 +39,7 @@ var (
 type CreateUserOptions struct {
 	CreateOptions
 	UserSpec v1.UserDetails
+	Roles    []string
 }
 
 // NewCmdCreateUser creates a command object for the "create" command
@@ -65,6 +72,8 @@ func NewCmdCreateUser(f Factory, in terminal.FileReader, out terminal.FileWriter
 	cmd.Flags().StringVarP(&options.UserSpec.Login, optionLogin, "l", "", "The user login name")
 	cmd.Flags().StringVarP(&options.UserSpec.Name, "name", "n", "", "The textual full name of the user")
 	cmd.Flags().StringVarP(&options.UserSpec.Email, "email", "e", "", "The users email address")
+	cmd.Flags().StringVarP(&options.UserSpec.GitProviderUser, "git-user", "g", "", "The login of the user on the git provider")
+	cmd.Flags().StringArrayVarP(&options.Roles, "role", "r", []string{}, "The team roles of the user")
 
 	options.addCommonFlags(cmd)
 	return cmd
@@ -121,12 +130,31 @@ func (o *CreateUserOptions) Run() error {
 		name = strings.Title(login)
 	}
 	user := kube.CreateUser(ns, login, name, spec.Email)
-	_, err = jxClient.JenkinsV1().Users(ns).Create(user)
+	user.Spec.GitProviderUser = spec.GitProviderUser
+	user, err = jxClient.JenkinsV1().Users(ns).Create(user)
 	if err != nil {
 		return fmt.Errorf("Failed to create User %s: %s", login, err)
 	}
 	log.Infof("Created User: %s\n", util.ColorInfo(login))
-	log.Infof("You can configure the roles for the user via: %s\n", util.ColorInfo(fmt.Sprintf("jx edit userrole %s", login)))
+	if len(o.Roles) == 0 {
+		log.Infof("You can configure the roles for the user via: %s\n", util.ColorInfo(fmt.Sprintf("jx edit userrole %s", login)))
+		return nil
+	}
+
+	roles, roleNames, err := kube.GetTeamRoles(kubeClient, devNs)
+	if err != nil {
+		return err
+	}
+	for _, role := range o.Roles {
+		if roles[role] == nil {
+			return util.InvalidOption("role", role, roleNames)
+		}
+	}
+	err = kube.UpdateUserRoles(kubeClient, jxClient, devNs, user.SubjectKind(), user.Name, o.Roles, roles)
+	if err != nil {
+		return fmt.Errorf("Failed to update the roles of User %s: %s", login, err)
+	}
+	log.Infof("Updated roles for user: %s roles: %s\n", util.ColorInfo(login), util.ColorInfo(strings.Join(o.Roles, ", ")))
 	return nil
 
 }
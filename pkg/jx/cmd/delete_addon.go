@@ -1,16 +1,17 @@
 package cmd
 
 import (
-	"github.com/jenkins-x/jx/pkg/kube/services"
 	"io"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"fmt"
 
-	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 )
 
@@ -63,29 +64,48 @@ func (o *DeleteAddonOptions) Run() error {
 	if len(args) == 0 {
 		return o.Cmd.Help()
 	}
-	charts := kube.AddonCharts
+	registry, err := o.addonRegistry()
+	if err != nil {
+		return err
+	}
+	_, ns, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	releases, err := o.Helm().StatusReleases(ns)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the helm releases in namespace %s", ns)
+	}
 
 	for _, arg := range args {
-		chart := charts[arg]
-		if chart == "" {
-			return util.InvalidArg(arg, util.SortedMapKeys(charts))
+		addon := registry.Get(arg)
+		if addon == nil {
+			return util.InvalidArg(arg, registry.Names())
+		}
+		// deleting an addon which is not installed is not an error so that deletes can be repeated safely
+		if _, ok := releases[arg]; ok {
+			err := o.deleteChart(arg, o.Purge)
+			if err != nil {
+				return fmt.Errorf("Failed to delete chart %s: %s", addon.Chart, err)
+			}
+		} else {
+			log.Infof("Addon %s is not installed in namespace %s\n", util.ColorInfo(arg), util.ColorInfo(ns))
 		}
-		err := o.deleteChart(arg, o.Purge)
+		err = o.cleanupServiceLink(addon.Service)
 		if err != nil {
-			return fmt.Errorf("Failed to delete chart %s: %s", chart, err)
+			return fmt.Errorf("Failed to delete the service link for addon %s: %s", arg, err)
 		}
-		err = o.cleanupServiceLink(arg)
+		err = o.forgetAddon(arg)
 		if err != nil {
-			return fmt.Errorf("Failed to delete the service link for addon %s", arg)
+			return errors.Wrapf(err, "failed to remove the addon %s from the team settings", arg)
 		}
 	}
 
 	return nil
 }
 
-func (o *DeleteAddonOptions) cleanupServiceLink(addonName string) error {
-	serviceName, ok := kube.AddonServices[addonName]
-	if !ok {
+func (o *DeleteAddonOptions) cleanupServiceLink(serviceName string) error {
+	if serviceName == "" {
 		// No cleanup is required if no service link is associated with the Addon
 		return nil
 	}
@@ -94,10 +114,19 @@ func (o *DeleteAddonOptions) cleanupServiceLink(addonName string) error {
 		return err
 	}
 
-	svc, err := services.FindService(client, serviceName)
+	nsl, err := client.CoreV1().Namespaces().List(meta_v1.ListOptions{})
 	if err != nil {
 		return err
 	}
-
-	return client.CoreV1().Services(svc.GetNamespace()).Delete(svc.GetName(), &meta_v1.DeleteOptions{})
+	for _, ns := range nsl.Items {
+		err = client.CoreV1().Services(ns.GetName()).Delete(serviceName, &meta_v1.DeleteOptions{})
+		if err == nil {
+			return nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	// the service has already been removed
+	return nil
 }
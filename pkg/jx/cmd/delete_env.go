@@ -112,6 +112,10 @@ func (o *DeleteEnvOptions) Run() error {
 			return err
 		}
 	}
+	err = o.updateBackupSchedule()
+	if err != nil {
+		log.Warnf("Failed to remove the deleted environments from the scheduled backups: %s\n", err)
+	}
 	return nil
 }
 
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+var (
+	deletePodTemplateLong = templates.LongDesc(`
+		Removes the customisations of one or more Jenkins pod templates of the build agents of your team.
+
+		A pod template customised via 'jx edit podtemplate' is restored as it was before being customised and a pod
+		template created via 'jx create podtemplate' is deleted.
+`)
+
+	deletePodTemplateExample = templates.Examples(`
+		# Pick the customised pod templates to restore
+		jx delete podtemplate
+
+		# Restore the maven pod template and delete the maven-big pod template
+		jx delete podtemplate maven-big maven
+	`)
+)
+
+// DeletePodTemplateOptions the options for the delete podtemplate command
+type DeletePodTemplateOptions struct {
+	CommonOptions
+}
+
+// NewCmdDeletePodTemplate creates a command object for the "delete podtemplate" command
+func NewCmdDeletePodTemplate(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &DeletePodTemplateOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			In:      in,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "podtemplate [name]...",
+		Short:   "Removes the customisations of Jenkins pod templates of the build agents of your team",
+		Aliases: []string{"podtemplates", "pod-template"},
+		Long:    deletePodTemplateLong,
+		Example: deletePodTemplateExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *DeletePodTemplateOptions) Run() error {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	_, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	customisations, err := kube.LoadPodTemplateCustomisations(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	names := []string{}
+	for k := range customisations {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return fmt.Errorf("there are no customised pod templates")
+	}
+
+	args := o.Args
+	if len(args) == 0 {
+		if o.BatchMode {
+			return util.MissingArgument("name")
+		}
+		args, err = util.PickNames(names, "Pick the customised pod templates to restore: ", "", o.In, o.Out, o.Err)
+		if err != nil {
+			return err
+		}
+	}
+	for _, name := range args {
+		if customisations[name] == nil {
+			return util.InvalidArg(name, names)
+		}
+	}
+	// the pod templates created from another pod template must be deleted before it is restored
+	sort.SliceStable(args, func(i, j int) bool {
+		return customisations[args[i]].From != "" && customisations[args[j]].From == ""
+	})
+	for _, name := range args {
+		err = kube.RemovePodTemplateCustomisation(kubeClient, ns, name)
+		if err != nil {
+			return err
+		}
+		if customisations[name].From != "" {
+			log.Infof("Deleted the pod template %s\n", util.ColorInfo(name))
+		} else {
+			log.Infof("Restored the pod template %s\n", util.ColorInfo(name))
+		}
+	}
+	log.Infof("To update the static Jenkins server run: %s\n", util.ColorInfo("jx edit jenkins"))
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"strings"
 
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
@@ -12,6 +13,23 @@ import (
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 )
 
+var (
+	deletePreviewLong = templates.LongDesc(`
+		Deletes a preview environment along with its namespace.
+
+		Inside a pull request pipeline the preview environment of the current pull request is deleted, otherwise
+		you can pick the preview environments to delete.
+`)
+
+	deletePreviewExample = templates.Examples(`
+		# Delete the preview environment of the current pull request pipeline or pick the ones to delete
+		jx delete preview
+
+		# Delete the preview environment by name
+		jx delete preview --name myorg-myapp-pr-123
+	`)
+)
+
 // DeletePreviewOptions are the flags for delete commands
 type DeletePreviewOptions struct {
 	PreviewOptions
@@ -33,8 +51,10 @@ func NewCmdDeletePreview(f Factory, in terminal.FileReader, out terminal.FileWri
 	}
 
 	cmd := &cobra.Command{
-		Use:   "preview",
-		Short: "Deletes a preview environment",
+		Use:     "preview",
+		Short:   "Deletes a preview environment",
+		Long:    deletePreviewLong,
+		Example: deletePreviewExample,
 		Run: func(cmd *cobra.Command, args []string) {
 			options.Cmd = cmd
 			options.Args = args
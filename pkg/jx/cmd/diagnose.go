@@ -35,6 +35,7 @@ func NewCmdDiagnose(f Factory, in terminal.FileReader, out terminal.FileWriter,
 		},
 	}
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace to display the kube resources from. If left out, defaults to the current namespace")
+	cmd.AddCommand(NewCmdDiagnoseCluster(f, in, out, errOut))
 	options.addCommonFlags(cmd)
 	return cmd
 }
@@ -0,0 +1,404 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx/pkg/cloud/gke"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/table"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DiagnoseClusterOptions the options for the diagnose cluster command
+type DiagnoseClusterOptions struct {
+	CommonOptions
+
+	Provider    string
+	ProjectID   string
+	Zone        string
+	MachineType string
+	Nodes       int
+	Namespace   string
+	Preflight   bool
+	Postflight  bool
+
+	checks []*diagnoseCheck
+}
+
+// diagnoseCheck the result of a single diagnostic check
+type diagnoseCheck struct {
+	Name   string
+	Passed bool
+	// Warning whether the check passed but should still be looked at
+	Warning bool
+	Message string
+	Hint    string
+}
+
+var (
+	diagnoseClusterLong = templates.LongDesc(`
+		Validates the environment before installing Jenkins X (preflight) and the installation itself afterwards (postflight).
+
+		Preflight checks include the local binaries, billing, quotas, required APIs and IAM permissions of the cloud project.
+		Postflight checks include the reachability of the ingresses, DNS resolution, the webhook endpoint and whether the
+		webhooks of the environment repositories are registered.
+
+		A single pass/fail report is printed along with hints on how to fix any failing checks.
+`)
+
+	diagnoseClusterExample = templates.Examples(`
+		# run the preflight checks before creating a GKE cluster
+		jx diagnose cluster --preflight --provider gke --project-id myproject --zone europe-west1-b
+
+		# run the postflight checks against the current installation
+		jx diagnose cluster --postflight
+`)
+
+	diagnoseRequiredBinaries = [][]string{
+		{"git", "version"},
+		{"helm", "version", "--client", "--short"},
+		{"kubectl", "version", "--client", "--short"},
+	}
+
+	diagnoseRequiredGKEApis = []string{"compute.googleapis.com", "container.googleapis.com"}
+)
+
+// NewCmdDiagnoseCluster creates the command
+func NewCmdDiagnoseCluster(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &DiagnoseClusterOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			In:      in,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "cluster",
+		Short:   "Runs preflight and postflight checks of a Jenkins X cluster",
+		Long:    diagnoseClusterLong,
+		Example: diagnoseClusterExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Provider, "provider", "", "", "The Kubernetes provider of the cluster. Cloud specific preflight checks are only run for: "+GKE)
+	cmd.Flags().StringVarP(&options.ProjectID, "project-id", "p", "", "The cloud project ID to check")
+	cmd.Flags().StringVarP(&options.Zone, "zone", "z", "", "The compute zone the cluster is, or will be, created in")
+	cmd.Flags().StringVarP(&options.MachineType, "machine-type", "m", "n1-standard-2", "The machine type of the node pool used to check the quotas")
+	cmd.Flags().IntVarP(&options.Nodes, "nodes", "", 3, "The number of nodes of the node pool used to check the quotas")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace Jenkins X is installed in. If left out, defaults to the current namespace")
+	cmd.Flags().BoolVarP(&options.Preflight, "preflight", "", false, "Only run the checks which validate the environment before install")
+	cmd.Flags().BoolVarP(&options.Postflight, "postflight", "", false, "Only run the checks which validate an existing installation")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *DiagnoseClusterOptions) Run() error {
+	if !o.Preflight && !o.Postflight {
+		o.Preflight = true
+		o.Postflight = true
+	}
+	if o.Preflight {
+		o.runPreflightChecks()
+	}
+	if o.Postflight {
+		o.runPostflightChecks()
+	}
+	return o.report()
+}
+
+func (o *DiagnoseClusterOptions) addCheck(name string, err error, message string, hint string) {
+	check := &diagnoseCheck{
+		Name:    name,
+		Passed:  err == nil,
+		Message: message,
+		Hint:    hint,
+	}
+	if err != nil {
+		check.Message = err.Error()
+	}
+	o.checks = append(o.checks, check)
+}
+
+func (o *DiagnoseClusterOptions) runPreflightChecks() {
+	for _, binary := range diagnoseRequiredBinaries {
+		o.checkBinary(binary[0], binary[1:]...)
+	}
+	if o.Provider == GKE {
+		o.checkBinary("gcloud", "version")
+		o.runGKEPreflightChecks()
+	}
+}
+
+func (o *DiagnoseClusterOptions) checkBinary(name string, versionArgs ...string) {
+	checkName := fmt.Sprintf("binary %s", name)
+	hint := fmt.Sprintf("install %s via: jx install dependencies", name)
+	_, err := exec.LookPath(name)
+	if err != nil {
+		o.addCheck(checkName, fmt.Errorf("%s not found on the PATH", name), "", hint)
+		return
+	}
+	output, err := o.getCommandOutput("", name, versionArgs...)
+	o.addCheck(checkName, err, strings.Split(strings.TrimSpace(output), "\n")[0], hint)
+}
+
+func (o *DiagnoseClusterOptions) runGKEPreflightChecks() {
+	if o.ProjectID == "" {
+		o.addCheck("gke project", fmt.Errorf("no project specified"), "", "specify the project via --project-id")
+		return
+	}
+
+	output, err := o.getCommandOutput("", "gcloud", "beta", "billing", "projects", "describe", o.ProjectID, "--format=value(billingEnabled)")
+	if err == nil && strings.TrimSpace(strings.ToLower(output)) != "true" {
+		err = fmt.Errorf("billing is not enabled for project %s", o.ProjectID)
+	}
+	o.addCheck("gke billing", err, "billing enabled",
+		fmt.Sprintf("enable billing at https://console.cloud.google.com/billing/linkedaccount?project=%s", o.ProjectID))
+
+	enabled, err := gke.GetEnabledApis(o.ProjectID)
+	if err == nil {
+		missing := []string{}
+		for _, api := range diagnoseRequiredGKEApis {
+			if util.StringArrayIndex(enabled, api) < 0 {
+				missing = append(missing, api)
+			}
+		}
+		if len(missing) > 0 {
+			err = fmt.Errorf("APIs not enabled: %s", strings.Join(missing, ", "))
+		}
+	}
+	o.addCheck("gke apis", err, strings.Join(diagnoseRequiredGKEApis, ", "),
+		fmt.Sprintf("enable them via: gcloud services enable compute container --project %s", o.ProjectID))
+
+	hasPermission, err := gke.CheckPermission("container.clusters.create", o.ProjectID)
+	if err == nil && !hasPermission {
+		err = fmt.Errorf("missing permission container.clusters.create")
+	}
+	o.addCheck("gke iam", err, "container.clusters.create",
+		"grant your account the roles/container.admin role on the project")
+
+	if o.Zone != "" {
+		zones, err := gke.GetGoogleZones(o.ProjectID)
+		if err == nil && util.StringArrayIndex(zones, o.Zone) < 0 {
+			err = fmt.Errorf("zone %s is not available in project %s", o.Zone, o.ProjectID)
+		}
+		o.addCheck("gke zone", err, o.Zone, "list the available zones via: gcloud compute zones list")
+
+		region := gke.GetRegionFromZone(o.Zone)
+		quotas, err := gke.GetRegionQuotas(o.ProjectID, region)
+		if err == nil {
+			shortfalls := gke.FindQuotaShortfalls(quotas, gke.NodePoolQuotaRequirements(o.MachineType, o.Nodes))
+			if len(shortfalls) > 0 {
+				messages := []string{}
+				for _, s := range shortfalls {
+					messages = append(messages, s.String())
+				}
+				err = fmt.Errorf("insufficient quota in region %s: %s", region, strings.Join(messages, ", "))
+			}
+		}
+		o.addCheck("gke quota", err, fmt.Sprintf("%d x %s in %s", o.Nodes, o.MachineType, region),
+			fmt.Sprintf("request a quota increase at %s or pick a different zone", gke.QuotaIncreaseURL(o.ProjectID)))
+	}
+}
+
+func (o *DiagnoseClusterOptions) runPostflightChecks() {
+	client, currentNs, err := o.KubeClient()
+	if err != nil {
+		o.addCheck("kubernetes api", err, "", "check your kube context via: jx context")
+		return
+	}
+	ns := o.Namespace
+	if ns == "" {
+		ns = currentNs
+	}
+	version, err := client.Discovery().ServerVersion()
+	message := ""
+	if err == nil {
+		message = version.GitVersion
+	}
+	o.addCheck("kubernetes api", err, message, "check your kube context via: jx context")
+
+	httpClient := util.GetClientWithTimeout(10 * time.Second)
+	ings, err := client.ExtensionsV1beta1().Ingresses(ns).List(metav1.ListOptions{})
+	if err != nil {
+		o.addCheck("ingress", err, "", "check the ingress controller is installed")
+	} else {
+		for _, ing := range ings.Items {
+			for _, rule := range ing.Spec.Rules {
+				host := rule.Host
+				if host == "" {
+					continue
+				}
+				_, err := net.LookupHost(host)
+				o.addCheck(fmt.Sprintf("dns %s", host), err, "resolves",
+					"check the DNS records of your domain point at the ingress controller load balancer")
+				if err != nil {
+					continue
+				}
+				scheme := "http"
+				if len(ing.Spec.TLS) > 0 {
+					scheme = "https"
+				}
+				o.addCheck(fmt.Sprintf("ingress %s", ing.Name), checkURLReachable(httpClient, scheme+"://"+host), "reachable",
+					"check the ingress controller and any firewall rules allow traffic to the cluster")
+			}
+		}
+	}
+
+	webhookURL, err := o.GetWebHookEndpoint()
+	if err == nil {
+		err = checkURLReachable(httpClient, webhookURL)
+	}
+	o.addCheck("webhook endpoint", err, webhookURL,
+		"check the webhook service is exposed then register the webhooks via: jx update webhooks")
+	if err == nil {
+		o.runWebHookChecks()
+	}
+}
+
+// runWebHookChecks checks the webhooks of the environment repositories are registered for the webhook endpoint
+func (o *DiagnoseClusterOptions) runWebHookChecks() {
+	hint := "register the webhooks via: jx update webhooks --org <org> --create-missing"
+	matcher, git, err := o.createWebHookMatcher("", true)
+	if err != nil {
+		o.addCheck("webhooks", err, "", hint)
+		return
+	}
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		o.addCheck("webhooks", err, "", hint)
+		return
+	}
+	repos, err := environmentRepositories(jxClient, devNs, git.ServerURL())
+	if err != nil {
+		o.addCheck("webhooks", err, "", hint)
+		return
+	}
+	o.checkWebHooksRegistered(git, matcher, repos)
+}
+
+// checkWebHooksRegistered adds a check for each repository of whether its webhook is registered for the endpoint
+func (o *DiagnoseClusterOptions) checkWebHooksRegistered(git gits.GitProvider, matcher *WebHookMatcher, repos []*gits.GitRepository) {
+	for _, repo := range repos {
+		name := fmt.Sprintf("webhook %s/%s", repo.Organisation, repo.Name)
+		hint := fmt.Sprintf("register the webhook via: jx update webhooks --org %s --repo %s --create-missing", repo.Organisation, repo.Name)
+		hooks, err := git.ListWebHooks(repo.Organisation, repo.Name)
+		if err != nil {
+			o.addCheck(name, err, "", hint)
+			continue
+		}
+		statuses := matcher.CheckWebHooks(repo.Organisation, repo.Name, hooks)
+		for _, status := range statuses {
+			if status.Status == WebHookStatusOK {
+				statuses = []WebHookStatus{status}
+				break
+			}
+		}
+		status := statuses[0].Status
+		if status != WebHookStatusOK {
+			err = fmt.Errorf("webhook %s", strings.ToLower(status))
+		}
+		o.addCheck(name, err, matcher.Endpoint, hint)
+	}
+}
+
+// environmentRepositories returns the git repositories of the environments which are on the git server, as those
+// are the repositories which always have a webhook
+func environmentRepositories(jxClient versioned.Interface, ns string, gitServerURL string) ([]*gits.GitRepository, error) {
+	envs, err := jxClient.JenkinsV1().Environments(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the Environments in namespace %s", ns)
+	}
+	server, err := url.Parse(gitServerURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the git server URL %s", gitServerURL)
+	}
+	answer := []*gits.GitRepository{}
+	for _, env := range envs.Items {
+		gitURL := env.Spec.Source.URL
+		if gitURL == "" {
+			continue
+		}
+		gitInfo, err := gits.ParseGitURL(gitURL)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the git URL %s of the Environment %s", gitURL, env.Name)
+		}
+		if !strings.EqualFold(gitInfo.Host, server.Host) {
+			continue
+		}
+		answer = append(answer, gitInfo)
+	}
+	return answer, nil
+}
+
+func checkURLReachable(client *http.Client, url string) error {
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("%s returned status %s", url, resp.Status)
+	}
+	return nil
+}
+
+// status returns the colored status of the check
+func (c *diagnoseCheck) status() string {
+	if !c.Passed {
+		return util.ColorError("FAIL")
+	}
+	if c.Warning {
+		return util.ColorWarning("WARN")
+	}
+	return util.ColorInfo("PASS")
+}
+
+// renderDiagnoseChecks renders the results of the checks as a table
+func renderDiagnoseChecks(out io.Writer, checks []*diagnoseCheck) {
+	t := table.CreateTable(out)
+	t.AddRow("CHECK", "STATUS", "MESSAGE")
+	for _, check := range checks {
+		t.AddRow(check.Name, check.status(), check.Message)
+	}
+	t.Render()
+}
+
+func (o *DiagnoseClusterOptions) report() error {
+	renderDiagnoseChecks(o.Out, o.checks)
+	failed := []*diagnoseCheck{}
+	for _, check := range o.checks {
+		if !check.Passed {
+			failed = append(failed, check)
+		}
+	}
+
+	if len(failed) == 0 {
+		log.Infof("\nAll %d checks passed\n", len(o.checks))
+		return nil
+	}
+	log.Info("\nRemediation hints:\n")
+	for _, check := range failed {
+		log.Infof("  %s: %s\n", util.ColorWarning(check.Name), check.Hint)
+	}
+	return fmt.Errorf("%d of %d checks failed", len(failed), len(o.checks))
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	versiond_mocks "github.com/jenkins-x/jx/pkg/client/clientset/versioned/fake"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// webHookGitProvider a fake git provider which returns the webhooks of the repositories
+type webHookGitProvider struct {
+	*gits.FakeProvider
+	hooks map[string][]*gits.GitWebHookArguments
+}
+
+func (p *webHookGitProvider) ListWebHooks(owner string, repo string) ([]*gits.GitWebHookArguments, error) {
+	hooks, ok := p.hooks[owner+"/"+repo]
+	if !ok {
+		return nil, fmt.Errorf("repository %s/%s not found", owner, repo)
+	}
+	return hooks, nil
+}
+
+func TestDiagnoseClusterCheckWebHooksRegistered(t *testing.T) {
+	t.Parallel()
+
+	endpoint := "http://hook.jx.1.2.3.4.nip.io/hook"
+	git := &webHookGitProvider{
+		FakeProvider: gits.NewFakeProvider(),
+		hooks: map[string][]*gits.GitWebHookArguments{
+			"myorg/environment-staging": {
+				{ID: 1, URL: "https://ci.example.com/webhook"},
+				{ID: 2, URL: endpoint},
+			},
+			"myorg/environment-production": {
+				{ID: 3, URL: "http://hook.jx.5.6.7.8.nip.io/hook"},
+			},
+			"myorg/environment-preview": {},
+		},
+	}
+	matcher := &WebHookMatcher{
+		Endpoint:   endpoint,
+		ExactMatch: true,
+	}
+	repos := []*gits.GitRepository{
+		{Organisation: "myorg", Name: "environment-staging"},
+		{Organisation: "myorg", Name: "environment-production"},
+		{Organisation: "myorg", Name: "environment-preview"},
+		{Organisation: "myorg", Name: "environment-missing"},
+	}
+
+	o := &DiagnoseClusterOptions{}
+	o.checkWebHooksRegistered(git, matcher, repos)
+
+	require.Len(t, o.checks, 4)
+	assert.Equal(t, "webhook myorg/environment-staging", o.checks[0].Name)
+	assert.True(t, o.checks[0].Passed)
+	assert.Equal(t, endpoint, o.checks[0].Message)
+
+	assert.False(t, o.checks[1].Passed)
+	assert.Equal(t, "webhook stale", o.checks[1].Message)
+	assert.Contains(t, o.checks[1].Hint, "--org myorg --repo environment-production")
+
+	assert.False(t, o.checks[2].Passed)
+	assert.Equal(t, "webhook missing", o.checks[2].Message)
+
+	assert.False(t, o.checks[3].Passed)
+	assert.Equal(t, "repository myorg/environment-missing not found", o.checks[3].Message)
+}
+
+func TestDiagnoseClusterEnvironmentRepositories(t *testing.T) {
+	t.Parallel()
+
+	env := func(name string, gitURL string) *v1.Environment {
+		return &v1.Environment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "jx",
+			},
+			Spec: v1.EnvironmentSpec{
+				Source: v1.EnvironmentRepository{
+					URL: gitURL,
+				},
+			},
+		}
+	}
+	jxClient := versiond_mocks.NewSimpleClientset(
+		env("dev", ""),
+		env("staging", "https://github.com/myorg/environment-staging.git"),
+		env("production", "https://github.com/myorg/environment-production.git"),
+		env("other", "https://gitlab.com/myorg/environment-other.git"),
+	)
+
+	repos, err := environmentRepositories(jxClient, "jx", "https://github.com")
+	require.NoError(t, err)
+	names := []string{}
+	for _, repo := range repos {
+		assert.Equal(t, "myorg", repo.Organisation)
+		names = append(names, repo.Name)
+	}
+	assert.ElementsMatch(t, []string{"environment-staging", "environment-production"}, names)
+}
+
+func TestDiagnoseClusterReport(t *testing.T) {
+	t.Parallel()
+
+	out := &bytes.Buffer{}
+	o := &DiagnoseClusterOptions{
+		CommonOptions: CommonOptions{
+			Out: out,
+		},
+	}
+	o.addCheck("binary git", nil, "git version 2.17.1", "install git via: jx install dependencies")
+	require.NoError(t, o.report())
+	assert.Contains(t, out.String(), "git version 2.17.1")
+
+	o.addCheck("webhook myorg/environment-staging", fmt.Errorf("webhook missing"), "", "register the webhook")
+	err := o.report()
+	require.Error(t, err)
+	assert.Equal(t, "1 of 2 checks failed", err.Error())
+	assert.Contains(t, out.String(), "webhook missing")
+}
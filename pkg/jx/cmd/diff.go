@@ -0,0 +1,400 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	diffStatusSame          = "Same"
+	diffStatusVersion       = "Version differs"
+	diffStatusValues        = "Values differ"
+	diffStatusMissing       = "Missing in %s"
+	diffStatusNotDeployed   = "Not deployed"
+	diffStatusNotInGitOps   = "Not in GitOps"
+	diffStatusDrift         = "Drift"
+	diffLiveEnvironmentName = "live"
+)
+
+// DiffOptions the options for the diff command
+type DiffOptions struct {
+	GetOptions
+
+	Environments []string
+	ShowValues   bool
+	All          bool
+}
+
+// EnvironmentSnapshot the versions and chart values of the applications in an environment
+type EnvironmentSnapshot struct {
+	Name string `json:"name"`
+
+	// Versions the versions of the applications in the environment's git repository or the deployed versions if the
+	// environment does not use GitOps
+	Versions map[string]string `json:"versions"`
+
+	// LiveVersions the versions of the applications deployed in the environment's namespace
+	LiveVersions map[string]string `json:"liveVersions,omitempty"`
+
+	// Values the chart values of the applications flattened into dotted paths
+	Values map[string]map[string]string `json:"-"`
+}
+
+// ApplicationDiff the differences of an application between two environments
+type ApplicationDiff struct {
+	Name         string   `json:"name"`
+	LeftVersion  string   `json:"leftVersion,omitempty"`
+	RightVersion string   `json:"rightVersion,omitempty"`
+	Status       string   `json:"status"`
+	Values       []string `json:"values,omitempty"`
+	Drift        []string `json:"drift,omitempty"`
+}
+
+// Changed returns true if the application differs between the environments or has drifted from its GitOps version
+func (d *ApplicationDiff) Changed() bool {
+	return d.Status != diffStatusSame || len(d.Drift) > 0
+}
+
+var (
+	diff_long = templates.LongDesc(`
+		Compares the versions and chart values of the applications in two environments highlighting the differences.
+
+		This is useful to see what a promotion would change before promoting a large batch of applications.
+
+		The versions and values of GitOps environments are taken from the environment's git repository and any drift
+		between the git repository and the versions deployed in the cluster is reported too. When a single environment
+		is specified its git repository is compared with the live cluster.
+
+		The values of secrets such as passwords, tokens and the credentials of URLs are redacted when showing the
+		differences in the chart values.
+`)
+
+	diff_example = templates.Examples(`
+		# Compare the applications in staging with those in production
+		jx diff --env staging production
+
+		# Compare the applications including the differences in their chart values
+		jx diff staging production --values
+
+		# Compare the GitOps repository of production with the applications running in the cluster
+		jx diff --env production
+
+		# Output the differences as JSON
+		jx diff staging production -o json
+	`)
+)
+
+// NewCmdDiff creates the new command for: jx diff
+func NewCmdDiff(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &DiffOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "diff [environment] [environment]",
+		Short:   "Compares the versions and values of the applications in two Environments",
+		Long:    diff_long,
+		Example: diff_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringArrayVarP(&options.Environments, optionEnvironment, "e", []string{}, "The Environments to compare")
+	cmd.Flags().BoolVarP(&options.ShowValues, "values", "", false, "Shows the differences in the chart values of the applications. The values of secrets are redacted")
+	cmd.Flags().BoolVarP(&options.All, "all", "", false, "Shows all the applications including those which do not differ")
+	options.addGetFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *DiffOptions) Run() error {
+	envNames := append(append([]string{}, o.Environments...), o.Args...)
+	if len(envNames) == 0 || len(envNames) > 2 {
+		return fmt.Errorf("Please specify one environment to compare with the cluster or two environments to compare. e.g. 'jx diff --env staging production'")
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+
+	snapshots := []*EnvironmentSnapshot{}
+	for _, name := range envNames {
+		env, err := jxClient.JenkinsV1().Environments(ns).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "getting environment %s", name)
+		}
+		if len(envNames) == 1 && env.Spec.Source.URL == "" {
+			return fmt.Errorf("Environment %s has no git repository to compare with the cluster. Please specify another environment to compare it with", name)
+		}
+		snapshot, err := o.environmentSnapshot(env)
+		if err != nil {
+			return err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	var left, right *EnvironmentSnapshot
+	if len(snapshots) == 1 {
+		snapshot := snapshots[0]
+		left = &EnvironmentSnapshot{
+			Name:     snapshot.Name,
+			Versions: snapshot.Versions,
+		}
+		right = &EnvironmentSnapshot{
+			Name:     diffLiveEnvironmentName,
+			Versions: snapshot.LiveVersions,
+		}
+	} else {
+		left, right = snapshots[0], snapshots[1]
+	}
+	diffs := DiffEnvironments(left, right)
+	if !o.All {
+		changed := []ApplicationDiff{}
+		for _, d := range diffs {
+			if d.Changed() {
+				changed = append(changed, d)
+			}
+		}
+		diffs = changed
+	}
+	if o.outputObjects() {
+		return o.renderResult(diffs, o.Output)
+	}
+	if len(diffs) == 0 {
+		log.Infof("No differences found between %s and %s\n", util.ColorInfo(left.Name), util.ColorInfo(right.Name))
+		return nil
+	}
+
+	table := o.CreateTable()
+	table.AddRow("APPLICATION", strings.ToUpper(left.Name), strings.ToUpper(right.Name), "STATUS")
+	for _, d := range diffs {
+		status := d.Status
+		if len(d.Drift) > 0 {
+			status += ", " + diffStatusDrift + ": " + strings.Join(d.Drift, ", ")
+		}
+		if d.Changed() {
+			status = util.ColorWarning(status)
+		}
+		table.AddRow(d.Name, d.LeftVersion, d.RightVersion, status)
+	}
+	table.Render()
+
+	if o.ShowValues {
+		for _, d := range diffs {
+			if len(d.Values) == 0 {
+				continue
+			}
+			log.Infof("\nValues of %s:\n", util.ColorInfo(d.Name))
+			for _, line := range d.Values {
+				log.Infof("  %s\n", line)
+			}
+		}
+	}
+	return nil
+}
+
+// environmentSnapshot loads the versions and values of the applications in the environment from its git repository
+// and the versions deployed in its namespace
+func (o *DiffOptions) environmentSnapshot(env *v1.Environment) (*EnvironmentSnapshot, error) {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &EnvironmentSnapshot{
+		Name:         env.Name,
+		LiveVersions: map[string]string{},
+		Values:       map[string]map[string]string{},
+	}
+	envNs := env.Spec.Namespace
+	if envNs != "" {
+		deployments, err := kube.GetDeployments(kubeClient, envNs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting deployments in namespace %s", envNs)
+		}
+		for name, d := range deployments {
+			snapshot.LiveVersions[kube.GetAppName(name, envNs)] = kube.GetVersion(&d.ObjectMeta)
+		}
+	}
+	if env.Spec.Source.URL == "" {
+		snapshot.Versions = snapshot.LiveVersions
+		return snapshot, nil
+	}
+
+	snapshot.Versions, err = o.getEnvironmentRequirementVersions(env)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading the requirements of environment %s from %s", env.Name, env.Spec.Source.URL)
+	}
+	data, err := o.getEnvironmentSourceFile(env, "env/"+helm.ValuesFileName)
+	if err != nil {
+		log.Warnf("Failed to load the values of environment %s from %s: %s\n", env.Name, env.Spec.Source.URL, err)
+		return snapshot, nil
+	}
+	values := map[string]interface{}{}
+	err = yaml.Unmarshal(data, &values)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing the values of environment %s", env.Name)
+	}
+	for app := range snapshot.Versions {
+		appValues, ok := values[app].(map[string]interface{})
+		if ok {
+			flat := map[string]string{}
+			flattenValues("", appValues, flat)
+			snapshot.Values[app] = flat
+		}
+	}
+	return snapshot, nil
+}
+
+// DiffEnvironments compares the applications of the two environments returning the differences sorted by name
+func DiffEnvironments(left *EnvironmentSnapshot, right *EnvironmentSnapshot) []ApplicationDiff {
+	apps := []string{}
+	for _, versions := range []map[string]string{left.Versions, right.Versions} {
+		for app := range versions {
+			if util.StringArrayIndex(apps, app) < 0 {
+				apps = append(apps, app)
+			}
+		}
+	}
+	sort.Strings(apps)
+
+	answer := []ApplicationDiff{}
+	for _, app := range apps {
+		leftVersion, inLeft := left.Versions[app]
+		rightVersion, inRight := right.Versions[app]
+		d := ApplicationDiff{
+			Name:         app,
+			LeftVersion:  leftVersion,
+			RightVersion: rightVersion,
+			Values:       diffValues(left.Name, left.Values[app], right.Name, right.Values[app]),
+			Drift:        append(drift(left, app), drift(right, app)...),
+		}
+		switch {
+		case !inLeft:
+			d.Status = fmt.Sprintf(diffStatusMissing, left.Name)
+			if right.Name == diffLiveEnvironmentName {
+				d.Status = diffStatusNotInGitOps
+			}
+		case !inRight:
+			d.Status = fmt.Sprintf(diffStatusMissing, right.Name)
+			if right.Name == diffLiveEnvironmentName {
+				d.Status = diffStatusNotDeployed
+			}
+		case leftVersion != rightVersion:
+			d.Status = diffStatusVersion
+		case len(d.Values) > 0:
+			d.Status = diffStatusValues
+		default:
+			d.Status = diffStatusSame
+		}
+		answer = append(answer, d)
+	}
+	return answer
+}
+
+// drift returns a description of the deployed version of the application if it differs from its GitOps version
+func drift(snapshot *EnvironmentSnapshot, app string) []string {
+	if snapshot.LiveVersions == nil {
+		return nil
+	}
+	version, ok := snapshot.Versions[app]
+	if !ok {
+		return nil
+	}
+	live := snapshot.LiveVersions[app]
+	if live == version {
+		return nil
+	}
+	if live == "" {
+		live = "not deployed"
+	}
+	return []string{fmt.Sprintf("%s is running %s", snapshot.Name, live)}
+}
+
+// diffValues returns the chart values paths which differ between the two environments. The values of secrets such as
+// passwords and tokens are redacted so that only the fact that they differ is shown
+func diffValues(leftName string, left map[string]string, rightName string, right map[string]string) []string {
+	keys := []string{}
+	for _, values := range []map[string]string{left, right} {
+		for k := range values {
+			if util.StringArrayIndex(keys, k) < 0 {
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	answer := []string{}
+	for _, k := range keys {
+		l, inLeft := left[k]
+		r, inRight := right[k]
+		if inLeft && inRight && l == r {
+			continue
+		}
+		l = redactValue(k, l)
+		r = redactValue(k, r)
+		if !inLeft {
+			l = "<none>"
+		}
+		if !inRight {
+			r = "<none>"
+		}
+		answer = append(answer, fmt.Sprintf("%s: %s=%s %s=%s", k, leftName, l, rightName, r))
+	}
+	return answer
+}
+
+// redactValue returns the redacted value if the path of the value is the name of a secret or the value contains
+// credentials such as those of a URL
+func redactValue(path string, value string) string {
+	if util.IsSecretName(path) {
+		return util.RedactedValue
+	}
+	return util.RedactSecrets(value)
+}
+
+// flattenValues flattens the nested values into the map using dotted paths as the keys
+func flattenValues(prefix string, values map[string]interface{}, answer map[string]string) {
+	for k, v := range values {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		switch value := v.(type) {
+		case map[string]interface{}:
+			flattenValues(path, value, answer)
+		case []interface{}:
+			data, err := json.Marshal(value)
+			if err != nil {
+				answer[path] = fmt.Sprintf("%v", value)
+			} else {
+				answer[path] = string(data)
+			}
+		default:
+			answer[path] = fmt.Sprintf("%v", value)
+		}
+	}
+}
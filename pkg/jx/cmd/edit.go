@@ -53,13 +53,23 @@ func NewCmdEdit(f Factory, in terminal.FileReader, out terminal.FileWriter, errO
 
 	cmd.AddCommand(NewCmdCreateBranchPattern(f, in, out, errOut))
 	cmd.AddCommand(NewCmdEditAddon(f, in, out, errOut))
+	cmd.AddCommand(NewCmdEditArtifactRepository(f, in, out, errOut))
 	cmd.AddCommand(NewCmdEditBuildpack(f, in, out, errOut))
 	cmd.AddCommand(NewCmdEditConfig(f, in, out, errOut))
+	cmd.AddCommand(NewCmdEditDeploy(f, in, out, errOut))
 	cmd.AddCommand(NewCmdEditEnv(f, in, out, errOut))
 	cmd.AddCommand(NewCmdEditHelmBin(f, in, out, errOut))
+	cmd.AddCommand(NewCmdEditImageBuilder(f, in, out, errOut))
+	cmd.AddCommand(NewCmdEditIssueTracker(f, in, out, errOut))
+	cmd.AddCommand(NewCmdEditJenkins(f, in, out, errOut))
+	cmd.AddCommand(NewCmdEditNetworkPolicy(f, in, out, errOut))
+	cmd.AddCommand(NewCmdEditNotifications(f, in, out, errOut))
+	cmd.AddCommand(NewCmdEditPodTemplate(f, in, out, errOut))
+	cmd.AddCommand(NewCmdEditSecurityPolicy(f, in, out, errOut))
 	cmd.AddCommand(NewCmdEditStorage(f, in, out, errOut))
 	cmd.AddCommand(NewCmdEditUserRole(f, in, out, errOut))
 	cmd.AddCommand(NewCmdEditExtensionsRepository(f, in, out, errOut))
+	cmd.AddCommand(NewCmdEditSecret(f, in, out, errOut))
 	addTeamSettingsCommandsFromTags(cmd, in, out, errOut, options)
 	return cmd
 }
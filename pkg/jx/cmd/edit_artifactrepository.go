@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/artifacts"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	defaultNexusUser = "admin"
+)
+
+var (
+	editArtifactRepositoryLong = templates.LongDesc(`
+		Configures the artifact repository the pipelines of your team resolve and deploy artifacts with
+
+		The Maven, Gradle and npm settings of the build pods are rewritten for the chosen repository. Use 'none' to
+		resolve artifacts from the public repositories.
+
+		To install Artifactory in the cluster use 'jx create addon artifactory'
+`)
+
+	editArtifactRepositoryExample = templates.Examples(`
+		# Pick the artifact repository
+		jx edit artifactrepository
+
+		# Use the Nexus repository installed with Jenkins X
+		jx edit artifactrepository nexus
+
+		# Use an existing Artifactory server
+		jx edit artifactrepository artifactory --url https://artifactory.acme.com/artifactory --username jenkins-x --password mypassword
+	`)
+)
+
+// EditArtifactRepositoryOptions the options for the edit artifactrepository command
+type EditArtifactRepositoryOptions struct {
+	EditOptions
+
+	URL      string
+	Username string
+	Password string
+}
+
+// NewCmdEditArtifactRepository creates a command object for the "edit artifactrepository" command
+func NewCmdEditArtifactRepository(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &EditArtifactRepositoryOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "artifactrepository [nexus|artifactory|none]",
+		Short:   "Configures the artifact repository the pipelines resolve and deploy artifacts with",
+		Aliases: []string{"artifact-repository"},
+		Long:    editArtifactRepositoryLong,
+		Example: editArtifactRepositoryExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.URL, "url", "u", "", "The URL of the artifact repository")
+	cmd.Flags().StringVarP(&options.Username, "username", "", "", "The name of the user the pipelines resolve and deploy artifacts with")
+	cmd.Flags().StringVarP(&options.Password, "password", "p", "", "The password of the user the pipelines resolve and deploy artifacts with")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditArtifactRepositoryOptions) Run() error {
+	current, err := o.teamArtifactRepository()
+	if err != nil {
+		return err
+	}
+	kind := ""
+	if len(o.Args) > 0 {
+		kind = o.Args[0]
+	} else {
+		if o.BatchMode {
+			return util.MissingArgument("artifact repository")
+		}
+		kind, err = util.PickNameWithDefault(v1.ArtifactRepositoryTypes, "Pick the artifact repository: ", string(current.Kind),
+			"The repository the pipelines resolve and deploy artifacts with", o.In, o.Out, o.Err)
+		if err != nil {
+			return err
+		}
+	}
+	if util.StringArrayIndex(v1.ArtifactRepositoryTypes, kind) < 0 {
+		return util.InvalidArg(kind, v1.ArtifactRepositoryTypes)
+	}
+
+	repo := &artifacts.Repository{
+		Kind:     v1.ArtifactRepositoryType(kind),
+		URL:      o.URL,
+		Username: o.Username,
+		Password: o.Password,
+	}
+	if repo.Kind == current.Kind {
+		// keep the existing settings which are not overridden
+		if repo.URL == "" {
+			repo.URL = current.URL
+		}
+		if repo.Username == "" {
+			repo.Username = current.Username
+		}
+		if repo.Password == "" {
+			repo.Password = current.Password
+		}
+	}
+	switch repo.Kind {
+	case v1.ArtifactRepositoryNexus:
+		if repo.URL == "" {
+			repo.URL = artifacts.DefaultNexusURL
+		}
+		if repo.Username == "" {
+			repo.Username = defaultNexusUser
+		}
+		if repo.Password == "" {
+			log.Warnf("No password specified for the Nexus user %s. Use --password to deploy artifacts to Nexus\n", repo.Username)
+		}
+	case v1.ArtifactRepositoryArtifactory:
+		if repo.URL == "" {
+			return util.MissingOption("url")
+		}
+	case v1.ArtifactRepositoryNone:
+		repo.URL = ""
+	}
+	return o.applyArtifactRepository(repo)
+}
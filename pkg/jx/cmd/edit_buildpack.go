@@ -30,6 +30,9 @@ var (
 
         # to switch to kubernetes workloads for your team
 		jx edit buildpack -n kubernetes-workloads
+
+		# to use your own build pack repository pinned to a tag for your team
+		jx edit buildpack --url https://github.com/myorg/my-build-packs.git --ref v1.0.0
 		
 		For more documentation see: [https://jenkins-x.io/architecture/build-packs/](https://jenkins-x.io/architecture/build-packs/)
 	`)
@@ -110,6 +113,9 @@ func (o *EditBuildPackOptions) Run() error {
 		buildPackURL = buildPack.Spec.GitURL
 		BuildPackRef = buildPack.Spec.GitRef
 	}
+	if buildPackURL != "" && BuildPackRef == "" {
+		BuildPackRef = "master"
+	}
 	if o.BatchMode {
 		if buildPackURL == "" && BuildPackRef == "" {
 			return nil
@@ -3,47 +3,85 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/io/secrets"
+	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/telemetry"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/jenkins-x/jx/pkg/config"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
 )
 
 const (
 	chatKind  = "chat"
 	issueKind = "issues"
+	teamKind  = "team"
 	wikiKind  = "wiki"
+
+	optionBuildsOnSpot = "builds-on-spot"
 )
 
 var (
 	editConfigLong = templates.LongDesc(`
 		Edits the project configuration
+
+		Use the team kind to edit the settings of the team such as the default git server and organisation, the
+		docker registry organisation, the build packs, the apps repository and the promotion engine.
 `)
 
 	editConfigExample = templates.Examples(`
 		# Edit the project configuration for the current directory
 		jx edit config
+
+		# Store the credentials of the team in the OS keychain, migrating any existing credentials
+		jx edit config --secrets-location keychain
+
+		# Edit the settings of the team
+		jx edit config --kind team
+
+		# Set some settings of the team without prompting
+		jx edit config --set organisation=myorg --set gitprivate=true
+
+		# Run the builds of the team on the preemptible or spot nodes of the cluster retrying them if they get evicted
+		jx edit config --builds-on-spot
+
+		# Send anonymous usage metrics such as the commands run, their durations and whether they succeeded to an endpoint
+		jx edit config --telemetry on --telemetry-url https://telemetry.acme.com/events
 	`)
 
 	configKinds = []string{
 		chatKind,
 		issueKind,
+		teamKind,
 		wikiKind,
 	}
+
+	telemetryValues = []string{"on", "off"}
 )
 
 // EditConfigOptions the options for the create spring command
 type EditConfigOptions struct {
 	EditOptions
 
-	Dir  string
-	Kind string
+	Dir             string
+	Kind            string
+	SecretsLocation string
+	SetValues       []string
+	Telemetry       string
+	TelemetryURL    string
+	BuildsOnSpot    bool
 
 	IssuesAuthConfigSvc auth.ConfigService
 	ChatAuthConfigSvc   auth.ConfigService
@@ -77,12 +115,32 @@ func NewCmdEditConfig(f Factory, in terminal.FileReader, out terminal.FileWriter
 	}
 	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The root project directory")
 	cmd.Flags().StringVarP(&options.Kind, "kind", "k", "", "The kind of configuration to edit root project directory. Possible values "+strings.Join(configKinds, ", "))
+	cmd.Flags().StringVarP(&options.SecretsLocation, "secrets-location", "", "", "Changes where the credentials of the team are stored, migrating the existing credentials. Possible values "+strings.Join(secrets.SecretsLocationKinds, ", "))
+	cmd.Flags().StringArrayVarP(&options.SetValues, "set", "s", []string{}, "Sets a team setting without prompting using key=value. Possible keys "+strings.Join(teamSettingsCommands(), ", "))
+	cmd.Flags().BoolVarP(&options.BuildsOnSpot, optionBuildsOnSpot, "", false, "Makes the builds of the team prefer and tolerate the preemptible or spot nodes of the cluster and retries the pipeline tasks whose pods are evicted. Use --"+optionBuildsOnSpot+"=false to turn it off")
+	cmd.Flags().StringVarP(&options.Telemetry, "telemetry", "", "", "Turns sending anonymous usage metrics on or off. Possible values "+strings.Join(telemetryValues, ", "))
+	cmd.Flags().StringVarP(&options.TelemetryURL, "telemetry-url", "", "", "The endpoint the anonymous usage metrics are sent to. Required to turn telemetry on unless $"+telemetry.URLEnvVar+" or a previous endpoint is set")
 
 	return cmd
 }
 
 // Run implements the command
 func (o *EditConfigOptions) Run() error {
+	if o.Telemetry != "" {
+		return o.EditTelemetry()
+	}
+	if o.SecretsLocation != "" {
+		return o.EditSecretsLocation()
+	}
+	if o.Cmd != nil && o.Cmd.Flags().Changed(optionBuildsOnSpot) {
+		return o.EditBuildsOnSpot()
+	}
+	if len(o.SetValues) > 0 {
+		return o.SetTeamSettings(o.SetValues)
+	}
+	if o.Kind == teamKind {
+		return o.EditTeamSettings()
+	}
 	pc, fileName, err := config.LoadProjectConfig(o.Dir)
 	if err != nil {
 		return err
@@ -109,6 +167,9 @@ func (o *EditConfigOptions) Run() error {
 	if util.StringArrayIndex(configKinds, kind) < 0 {
 		return util.InvalidOption("kind", kind, configKinds)
 	}
+	if kind == teamKind {
+		return o.EditTeamSettings()
+	}
 	modified := false
 	switch kind {
 	case chatKind:
@@ -198,3 +259,238 @@ func (o *EditConfigOptions) EditChat(pc *config.ProjectConfig) (bool, error) {
 	}
 	return answer, nil
 }
+
+// EditSecretsLocation changes where the credentials of the team are stored and copies the existing
+// credentials into the new location
+func (o *EditConfigOptions) EditSecretsLocation() error {
+	if util.StringArrayIndex(secrets.SecretsLocationKinds, o.SecretsLocation) < 0 {
+		return util.InvalidOption("secrets-location", o.SecretsLocation, secrets.SecretsLocationKinds)
+	}
+	location := secrets.SecretsLocationKind(o.SecretsLocation)
+	current := o.Factory.SecretsLocation()
+	if current == location {
+		log.Infof("The credentials are already stored in the %s\n", util.ColorInfo(location))
+		return nil
+	}
+
+	configNames := []string{
+		GitAuthConfigFile,
+		JenkinsAuthConfigFile,
+		ChartmuseumAuthConfigFile,
+		IssuesAuthConfigFile,
+		ChatAuthConfigFile,
+		AddonAuthConfigFile,
+	}
+	configs := map[string]*auth.AuthConfig{}
+	for _, name := range configNames {
+		svc, err := o.Factory.CreateAuthConfigService(name)
+		if err != nil {
+			return errors.Wrapf(err, "creating the %s auth config service", name)
+		}
+		config, err := svc.LoadConfig()
+		if err != nil {
+			return errors.Wrapf(err, "loading %s from the %s", name, current)
+		}
+		if len(config.Servers) > 0 {
+			configs[name] = config
+		}
+	}
+
+	err := o.Factory.SetSecretsLocation(location)
+	if err != nil {
+		return err
+	}
+	for _, name := range configNames {
+		config := configs[name]
+		if config == nil {
+			continue
+		}
+		svc, err := o.Factory.CreateAuthConfigService(name)
+		if err != nil {
+			return errors.Wrapf(err, "creating the %s auth config service", name)
+		}
+		svc.SetConfig(config)
+		err = svc.SaveConfig()
+		if err != nil {
+			return errors.Wrapf(err, "saving %s to the %s", name, location)
+		}
+		log.Infof("Migrated %s from the %s to the %s\n", util.ColorInfo(name), current, location)
+	}
+	if current == secrets.FileSystemLocationKind {
+		// lets not leave the plain text credentials behind now they are in the new location
+		dir, err := util.ConfigDir()
+		if err != nil {
+			return err
+		}
+		for name := range configs {
+			fileName := filepath.Join(dir, name)
+			err = os.Remove(fileName)
+			if err != nil && !os.IsNotExist(err) {
+				return errors.Wrapf(err, "removing the migrated credentials file %s", fileName)
+			}
+		}
+	}
+	log.Successf("The credentials of the team are now stored in the %s", location)
+	return nil
+}
+
+// SetTeamSettings sets the team settings from the key=value expressions after validating all of them
+func (o *EditConfigOptions) SetTeamSettings(expressions []string) error {
+	type setting struct {
+		field *teamSettingsField
+		value interface{}
+	}
+	settings := []setting{}
+	for _, expression := range expressions {
+		paths := strings.SplitN(expression, "=", 2)
+		if len(paths) != 2 {
+			return util.InvalidOptionf("set", expression, "should be of the form key=value")
+		}
+		key := strings.TrimSpace(paths[0])
+		field := findTeamSettingsField(key)
+		if field == nil {
+			return util.InvalidOption("set", key, teamSettingsCommands())
+		}
+		value, err := field.parseValue(strings.TrimSpace(paths[1]))
+		if err != nil {
+			return err
+		}
+		settings = append(settings, setting{field, value})
+	}
+	for _, s := range settings {
+		if s.field.FieldName == "ImageBuilder" {
+			err := o.provisionTeamImageBuilder(v1.ImageBuilderType(s.value.(string)))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	callback := func(env *v1.Environment) error {
+		for _, s := range settings {
+			s.field.setValue(&env.Spec.TeamSettings, s.value)
+			log.Infof("Setting the team %s to: %s\n", util.ColorInfo(s.field.Command), util.ColorInfo(s.value))
+		}
+		return nil
+	}
+	return o.ModifyDevEnvironment(callback)
+}
+
+// EditTeamSettings prompts for each of the team settings defaulting to their current values
+func (o *EditConfigOptions) EditTeamSettings() error {
+	if o.BatchMode {
+		return util.MissingOption("set")
+	}
+	teamSettings, err := o.TeamSettings()
+	if err != nil {
+		return err
+	}
+	edited := *teamSettings
+	for _, f := range teamSettingsFields() {
+		field := f
+		if field.Kind != reflect.String && field.Kind != reflect.Bool {
+			continue
+		}
+		value, err := field.pickValue(&edited, o.In, o.Out, o.Err)
+		if err != nil {
+			return err
+		}
+		if text, ok := value.(string); ok {
+			value, err = field.parseValue(text)
+			if err != nil {
+				return err
+			}
+		}
+		field.setValue(&edited, value)
+	}
+	if reflect.DeepEqual(teamSettings, &edited) {
+		log.Infof("No team settings changed\n")
+		return nil
+	}
+	if edited.ImageBuilder != teamSettings.ImageBuilder {
+		err = o.provisionTeamImageBuilder(edited.ImageBuilder)
+		if err != nil {
+			return err
+		}
+	}
+	callback := func(env *v1.Environment) error {
+		for _, field := range teamSettingsFields() {
+			if field.Kind == reflect.String || field.Kind == reflect.Bool {
+				field.setValue(&env.Spec.TeamSettings, field.getValue(&edited).Interface())
+			}
+		}
+		return nil
+	}
+	err = o.ModifyDevEnvironment(callback)
+	if err != nil {
+		return err
+	}
+	log.Infof("Saved the team settings\n")
+	return nil
+}
+
+// EditTelemetry turns sending anonymous usage metrics on or off
+func (o *EditConfigOptions) EditTelemetry() error {
+	if util.StringArrayIndex(telemetryValues, o.Telemetry) < 0 {
+		return util.InvalidOption("telemetry", o.Telemetry, telemetryValues)
+	}
+	config, err := telemetry.LoadConfig()
+	if err != nil {
+		return err
+	}
+	config.Enabled = o.Telemetry == "on"
+	if o.TelemetryURL != "" {
+		config.URL = o.TelemetryURL
+	}
+	if config.Enabled && config.EndpointURL() == "" {
+		return util.MissingOption("telemetry-url")
+	}
+	err = config.Save()
+	if err != nil {
+		return errors.Wrap(err, "saving the telemetry configuration")
+	}
+	if config.Enabled {
+		log.Infof("Thank you! jx now sends the name, duration and result of each command along with the provider, jx version and OS to %s\n", util.ColorInfo(config.EndpointURL()))
+		log.Infof("No names, arguments, URLs or other identifiers are sent. To stop run: %s\n", util.ColorInfo("jx edit config --telemetry off"))
+	} else {
+		log.Infof("Telemetry is %s\n", util.ColorInfo("off"))
+	}
+	return nil
+}
+
+// EditBuildsOnSpot makes the build pods of the team prefer the preemptible or spot nodes of the cluster and
+// tolerate their taints or reverts them to run on any node
+func (o *EditConfigOptions) EditBuildsOnSpot() error {
+	client, ns, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	if o.BuildsOnSpot {
+		nodes, err := kube.FindSpotNodes(client)
+		if err != nil {
+			return err
+		}
+		if len(nodes) == 0 {
+			log.Warnf("No preemptible or spot nodes found so the builds run on the other nodes until a preemptible or spot node pool is added\n")
+		}
+	}
+	err = kube.UpdatePodTemplates(client, ns, func(name string, pod *corev1.Pod) bool {
+		return kube.ApplySpotScheduling(pod, o.BuildsOnSpot)
+	})
+	if err != nil {
+		return err
+	}
+	callback := func(env *v1.Environment) error {
+		env.Spec.TeamSettings.BuildsOnSpot = o.BuildsOnSpot
+		return nil
+	}
+	err = o.ModifyDevEnvironment(callback)
+	if err != nil {
+		return err
+	}
+	if o.BuildsOnSpot {
+		log.Infof("The builds of the team now prefer the preemptible or spot nodes and are run again up to %d times if their pods are evicted\n", kube.DefaultSpotRetries)
+	} else {
+		log.Infof("The builds of the team now run on any node\n")
+	}
+	return nil
+}
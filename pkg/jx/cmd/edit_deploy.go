@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/knative"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	optionDeployKind = "kind"
+)
+
+var (
+	editDeployLong = templates.LongDesc(`
+		Configures how the application in the current directory is deployed
+
+		The 'knative' kind deploys the application as a Knative Service which scales to zero when idle instead of
+		a Deployment, Service and Ingress. The chart of the application is modified so commit the changes to use the
+		new kind in the previews and the environments the application is promoted to.
+
+		Possible kinds: ` + strings.Join(knative.DeployKinds, ", ") + `
+`)
+
+	editDeployExample = templates.Examples(`
+		# Deploy the application as a Knative Service
+		jx edit deploy --kind knative
+
+		# Deploy the application as a Deployment, Service and Ingress
+		jx edit deploy --kind default
+	`)
+)
+
+// EditDeployOptions the options for the edit deploy command
+type EditDeployOptions struct {
+	EditOptions
+
+	Kind string
+	Dir  string
+}
+
+// NewCmdEditDeploy creates a command object for the "edit deploy" command
+func NewCmdEditDeploy(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &EditDeployOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "deploy",
+		Short:   "Configures how the application in the current directory is deployed",
+		Long:    editDeployLong,
+		Example: editDeployExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Kind, optionDeployKind, "k", "", "The kind of deployment of the application. Possible values: "+strings.Join(knative.DeployKinds, ", "))
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The directory of the application. Defaults to the current directory")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditDeployOptions) Run() error {
+	dir := o.Dir
+	if dir == "" {
+		var err error
+		dir, err = os.Getwd()
+		if err != nil {
+			return err
+		}
+	}
+	chartDir, err := findApplicationChartDir(dir)
+	if err != nil {
+		return err
+	}
+	if chartDir == "" {
+		return fmt.Errorf("no chart found in directory %s. Was the application created or imported with 'jx'?", dir)
+	}
+
+	if o.Kind == "" {
+		if o.BatchMode {
+			return util.MissingOption(optionDeployKind)
+		}
+		current, err := knative.GetDeployKind(chartDir)
+		if err != nil {
+			return err
+		}
+		o.Kind, err = util.PickNameWithDefault(knative.DeployKinds, "Pick the kind of deployment: ", current,
+			"How the application is deployed in the previews and environments", o.In, o.Out, o.Err)
+		if err != nil {
+			return err
+		}
+	}
+	if util.StringArrayIndex(knative.DeployKinds, o.Kind) < 0 {
+		return util.InvalidOption(optionDeployKind, o.Kind, knative.DeployKinds)
+	}
+
+	err = knative.SetDeployKind(chartDir, o.Kind)
+	if err != nil {
+		return err
+	}
+	log.Infof("Modified the chart %s to deploy the application as kind %s. Commit the changes to use it in the previews and environments\n", util.ColorInfo(chartDir), util.ColorInfo(o.Kind))
+	return nil
+}
+
+// findApplicationChartDir returns the directory of the chart of the application in the given directory ignoring the
+// preview chart or an empty string if there is none
+func findApplicationChartDir(dir string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "charts", "*", "Chart.yaml"))
+	if err != nil {
+		return "", fmt.Errorf("failed to find Chart.yaml file: %s", err)
+	}
+	for _, file := range files {
+		chartDir := filepath.Dir(file)
+		if filepath.Base(chartDir) != "preview" {
+			return chartDir, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+var (
+	editImageBuilderLong = templates.LongDesc(`
+		Configures the tool used by the pipelines of your team to build container images
+
+		* docker builds the Dockerfile of a project with skaffold and the Docker daemon
+		* kaniko builds the Dockerfile of a project without a Docker daemon
+		* buildkit builds the Dockerfile of a project without a Docker daemon using rootless BuildKit
+		* buildpacks builds projects without a Dockerfile using a Cloud Native Buildpacks builder
+
+		Projects created or imported afterwards use the image builder in their pipelines. To switch an existing
+		project use 'jx step buildpack apply --overwrite'
+
+		The image builder is installed into the build pod templates which mount the Docker socket along with the
+		registry credentials. To also remove the Docker socket from the build pods and configure the image cache
+		use 'jx create addon kaniko'
+`)
+
+	editImageBuilderExample = templates.Examples(`
+		# To build images without a Docker daemon use:
+		jx edit imagebuilder kaniko
+
+		# To build images without a Dockerfile using a specific builder use:
+		jx edit imagebuilder buildpacks --buildpacks-builder heroku/buildpacks:18
+
+		# To switch back to the Docker daemon use:
+		jx edit imagebuilder docker
+	`)
+)
+
+// EditImageBuilderOptions the options for the edit imagebuilder command
+type EditImageBuilderOptions struct {
+	EditOptions
+
+	BuildpacksBuilder string
+}
+
+// NewCmdEditImageBuilder creates a command object for the "edit imagebuilder" command
+func NewCmdEditImageBuilder(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &EditImageBuilderOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "imagebuilder [docker|kaniko|buildkit|buildpacks]",
+		Short:   "Configures the tool used by the pipelines of your team to build container images",
+		Aliases: []string{"image-builder"},
+		Long:    editImageBuilderLong,
+		Example: editImageBuilderExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.BuildpacksBuilder, "buildpacks-builder", "", "", "The Cloud Native Buildpacks builder image used by the buildpacks image builder. Defaults to "+DefaultBuildpacksBuilder)
+	options.addCommonFlags(cmd)
+	options.addSkipVerifyFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditImageBuilderOptions) Run() error {
+	builder := ""
+	if len(o.Args) > 0 {
+		builder = o.Args[0]
+	} else {
+		if o.BatchMode {
+			return util.MissingArgument("image builder")
+		}
+		settings, err := o.TeamSettings()
+		if err != nil {
+			return err
+		}
+		builder, err = util.PickNameWithDefault(v1.ImageBuilderTypes, "Pick the image builder: ", string(settings.GetImageBuilder()),
+			"The tool used by the pipelines to build container images", o.In, o.Out, o.Err)
+		if err != nil {
+			return err
+		}
+	}
+	if util.StringArrayIndex(v1.ImageBuilderTypes, builder) < 0 {
+		return util.InvalidArg(builder, v1.ImageBuilderTypes)
+	}
+
+	err := o.provisionTeamImageBuilder(v1.ImageBuilderType(builder))
+	if err != nil {
+		return err
+	}
+
+	callback := func(env *v1.Environment) error {
+		teamSettings := &env.Spec.TeamSettings
+		teamSettings.ImageBuilder = v1.ImageBuilderType(builder)
+		if o.BuildpacksBuilder != "" {
+			teamSettings.BuildpacksBuilder = o.BuildpacksBuilder
+		}
+		log.Infof("Setting the team image builder to: %s\n", util.ColorInfo(builder))
+		return nil
+	}
+	return o.ModifyDevEnvironment(callback)
+}
+
+// provisionTeamImageBuilder installs the image builder into the build pod templates. The Docker daemon is used by the
+// docker image builder so its socket is kept
+func (o *CommonOptions) provisionTeamImageBuilder(builder v1.ImageBuilderType) error {
+	if builder == "" || builder == v1.ImageBuilderDocker {
+		return nil
+	}
+	provisioner := &CreateAddonKanikoOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: *o,
+		},
+		Secret:           kube.SecretJenkinsDockerConfig,
+		KeepDockerSocket: true,
+	}
+	err := provisioner.provisionImageBuilder(builder)
+	if err != nil {
+		return errors.Wrapf(err, "installing %s into the build pod templates", builder)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/issues"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+const (
+	optionTransition    = "transition"
+	optionTransitionEnv = "transition-env"
+)
+
+var (
+	issueTrackerConfigKinds = []string{issues.GitHub, issues.GitLab, issues.Jira}
+
+	editIssueTrackerLong = templates.LongDesc(`
+		Configures the issue tracker of the project in the current directory
+
+		The keys of the issues referenced in the commit messages, such as #123 for GitHub and GitLab or ABC-123 for
+		Jira, are linked to the releases and listed in their changelogs. When a release is promoted the issues are
+		commented on and, if a transition is configured, moved to the status of the transition.
+
+		The configuration is stored in the jenkins-x.yml file of the project. Jira servers and their credentials
+		are added via 'jx create tracker server' and 'jx create tracker token'.
+
+		Possible kinds: ` + strings.Join(issueTrackerConfigKinds, ", ") + `
+`)
+
+	editIssueTrackerExample = templates.Examples(`
+		# Use a Jira project and move its issues to Done once they are promoted to production
+		jx edit issuetracker --kind jira --url https://myorg.atlassian.net --project ABC --transition Done
+
+		# Use the issues of another GitHub repository
+		jx edit issuetracker --kind github --project myorg/issues
+
+		# Move the issues to Ready for QA when promoted to staging
+		jx edit issuetracker --transition "Ready for QA" --transition-env staging
+	`)
+)
+
+// EditIssueTrackerOptions the options for the edit issuetracker command
+type EditIssueTrackerOptions struct {
+	EditOptions
+
+	Dir                    string
+	Kind                   string
+	URL                    string
+	Project                string
+	Transition             string
+	TransitionEnvironments []string
+}
+
+// NewCmdEditIssueTracker creates a command object for the "edit issuetracker" command
+func NewCmdEditIssueTracker(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &EditIssueTrackerOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "issuetracker",
+		Short:   "Configures the issue tracker of the project and how its issues are transitioned on promotion",
+		Aliases: []string{"issue-tracker", "tracker"},
+		Long:    editIssueTrackerLong,
+		Example: editIssueTrackerExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The root project directory")
+	cmd.Flags().StringVarP(&options.Kind, "kind", "k", "", "The kind of issue tracker. Possible values: "+strings.Join(issueTrackerConfigKinds, ", "))
+	cmd.Flags().StringVarP(&options.URL, "url", "u", "", "The URL of the issue tracker server. Defaults to the public server for GitHub and GitLab")
+	cmd.Flags().StringVarP(&options.Project, "project", "p", "", "The Jira project key or the owner/repository of the GitHub or GitLab issues")
+	cmd.Flags().StringVarP(&options.Transition, optionTransition, "t", "", "The status issues are moved to when their release is promoted. GitHub and GitLab issues are closed by the status "+issues.StatusClosed+" and labelled with any other status. Use --"+optionTransition+"='' to stop transitioning issues")
+	cmd.Flags().StringArrayVarP(&options.TransitionEnvironments, optionTransitionEnv, "e", []string{}, "The environments whose promotions transition the issues. Defaults to production")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditIssueTrackerOptions) Run() error {
+	pc, fileName, err := config.LoadProjectConfig(o.Dir)
+	if err != nil {
+		return err
+	}
+	if pc.IssueTracker == nil {
+		pc.IssueTracker = &config.IssueTrackerConfig{}
+	}
+	it := pc.IssueTracker
+
+	kind := o.Kind
+	if kind == "" && it.Kind == "" {
+		if o.BatchMode {
+			return util.MissingOption("kind")
+		}
+		kind, err = util.PickName(issueTrackerConfigKinds, "Pick the kind of issue tracker: ", "", o.In, o.Out, o.Err)
+		if err != nil {
+			return err
+		}
+	}
+	if kind != "" {
+		if util.StringArrayIndex(issueTrackerConfigKinds, kind) < 0 {
+			return util.InvalidOption("kind", kind, issueTrackerConfigKinds)
+		}
+		it.Kind = kind
+	}
+	if o.URL != "" {
+		it.URL = o.URL
+	}
+	if o.Project != "" {
+		it.Project = o.Project
+	}
+	if o.Transition != "" || (o.Cmd != nil && o.Cmd.Flags().Changed(optionTransition)) {
+		it.Transition = o.Transition
+	}
+	if len(o.TransitionEnvironments) > 0 {
+		it.TransitionEnvironments = o.TransitionEnvironments
+	}
+
+	if it.Kind == issues.Jira && it.URL == "" {
+		err = o.pickJiraServer(it)
+		if err != nil {
+			return err
+		}
+	}
+	if it.Project == "" {
+		if o.BatchMode {
+			return util.MissingOption("project")
+		}
+		it.Project, err = util.PickValue("Issue tracker project: ", "", true, "The Jira project key or the owner/repository of the issues", o.In, o.Out, o.Err)
+		if err != nil {
+			return err
+		}
+	}
+	if it.Kind != issues.Jira && len(strings.Split(it.Project, "/")) != 2 {
+		return util.InvalidOptionf("project", it.Project, "the project of a %s issue tracker should be of the form owner/repository", it.Kind)
+	}
+
+	err = pc.SaveConfig(fileName)
+	if err != nil {
+		return err
+	}
+	log.Infof("Saved the %s issue tracker of the project in %s\n", util.ColorInfo(it.Kind), util.ColorInfo(fileName))
+	if it.Transition != "" {
+		envs := it.TransitionEnvironments
+		if len(envs) == 0 {
+			envs = []string{"production"}
+		}
+		log.Infof("Issues will be moved to %s when promoted to %s\n", util.ColorInfo(it.Transition), util.ColorInfo(strings.Join(envs, ", ")))
+	}
+	return nil
+}
+
+// pickJiraServer picks the URL of the Jira server from the issue tracker servers which have been added
+func (o *EditIssueTrackerOptions) pickJiraServer(it *config.IssueTrackerConfig) error {
+	authConfigSvc, err := o.CreateIssueTrackerAuthConfigService()
+	if err != nil {
+		return err
+	}
+	authConfig := authConfigSvc.Config()
+	if len(authConfig.Servers) == 0 {
+		return fmt.Errorf("no issue tracker servers available. Please add one via: jx create tracker server")
+	}
+	server, err := authConfig.PickServer("Issue tracker service", o.BatchMode, o.In, o.Out, o.Err)
+	if err != nil {
+		return err
+	}
+	if server == nil || server.URL == "" {
+		return fmt.Errorf("no issue tracker server URL found")
+	}
+	it.URL = server.URL
+	return nil
+}
@@ -0,0 +1,58 @@
+package cmd_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/jx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditIssueTracker(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-edit-issuetracker")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	options := &cmd.EditIssueTrackerOptions{
+		Dir:        dir,
+		Kind:       "github",
+		Project:    "myorg/issues",
+		Transition: "Done",
+	}
+	options.BatchMode = true
+	err = options.Run()
+	require.NoError(t, err)
+
+	pc, _, err := config.LoadProjectConfig(dir)
+	require.NoError(t, err)
+	require.NotNil(t, pc.IssueTracker)
+	assert.Equal(t, "github", pc.IssueTracker.Kind)
+	assert.Equal(t, "myorg/issues", pc.IssueTracker.Project)
+	assert.Equal(t, "Done", pc.IssueTracker.Transition)
+	assert.True(t, pc.IssueTracker.TransitionsOn("production"))
+
+	options = &cmd.EditIssueTrackerOptions{
+		Dir:                    dir,
+		TransitionEnvironments: []string{"staging"},
+	}
+	options.BatchMode = true
+	err = options.Run()
+	require.NoError(t, err)
+
+	pc, _, err = config.LoadProjectConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "Done", pc.IssueTracker.Transition)
+	assert.True(t, pc.IssueTracker.TransitionsOn("staging"))
+
+	options = &cmd.EditIssueTrackerOptions{
+		Dir:     dir,
+		Kind:    "gitlab",
+		Project: "myproject",
+	}
+	options.BatchMode = true
+	assert.Error(t, options.Run())
+}
@@ -0,0 +1,404 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	editJenkinsLong = templates.LongDesc(`
+		Edits the configuration as code of the static Jenkins server of your team.
+
+		The configuration is rendered from the team settings: the pod templates of the build agents, the credential
+		secrets and the shared pipeline libraries. If the development environment is managed via GitOps the
+		configuration is changed via a Pull Request on its source, otherwise it is applied to the cluster and Jenkins
+		reloads it straight away.
+
+		The configuration is mounted into the Jenkins pod from the jenkins-x-casc ConfigMap and the credential secrets
+		are mounted in /run/secrets via the values of the Jenkins chart. The first time the command runs, or when the
+		credential secrets change, Jenkins restarts to pick up the mounts.
+
+		Running the command without any flags renders and applies the configuration again.
+`)
+
+	editJenkinsExample = templates.Examples(`
+		# Render and apply the configuration as code of Jenkins from the team settings
+		jx edit jenkins
+
+		# Add a shared pipeline library which is loaded implicitly by all the pipelines
+		jx edit jenkins --library shared=https://github.com/myorg/shared-library.git --implicit
+
+		# Add a shared pipeline library pinned to a tag
+		jx edit jenkins --library shared=https://github.com/myorg/shared-library.git@v1.0.0
+
+		# Remove a shared pipeline library
+		jx edit jenkins --remove-library shared
+	`)
+)
+
+// EditJenkinsOptions the options for the edit jenkins command
+type EditJenkinsOptions struct {
+	EditOptions
+
+	Libraries       []string
+	RemoveLibraries []string
+	Implicit        bool
+}
+
+// NewCmdEditJenkins creates a command object for the "edit jenkins" command
+func NewCmdEditJenkins(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &EditJenkinsOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "jenkins",
+		Short:   "Edits the configuration as code of the static Jenkins server of your team",
+		Long:    editJenkinsLong,
+		Example: editJenkinsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringArrayVarP(&options.Libraries, "library", "l", []string{}, "Adds or updates a shared pipeline library of the form name=gitURL[@version]. Can be specified multiple times")
+	cmd.Flags().StringArrayVarP(&options.RemoveLibraries, "remove-library", "", []string{}, "Removes the shared pipeline library with the given name. Can be specified multiple times")
+	cmd.Flags().BoolVarP(&options.Implicit, "implicit", "", false, "The added libraries are loaded implicitly by all the pipelines without an @Library annotation")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditJenkinsOptions) Run() error {
+	libraries := []v1.JenkinsLibrary{}
+	for _, text := range o.Libraries {
+		library, err := parseJenkinsLibrary(text)
+		if err != nil {
+			return err
+		}
+		library.Implicit = o.Implicit
+		libraries = append(libraries, library)
+	}
+
+	if len(libraries) > 0 || len(o.RemoveLibraries) > 0 {
+		callback := func(env *v1.Environment) error {
+			teamSettings := &env.Spec.TeamSettings
+			teamSettings.JenkinsLibraries = updateJenkinsLibraries(teamSettings.JenkinsLibraries, libraries, o.RemoveLibraries)
+			return nil
+		}
+		err := o.ModifyDevEnvironment(callback)
+		if err != nil {
+			return errors.Wrap(err, "failed to update the Jenkins libraries of the team")
+		}
+	}
+	return o.applyConfigurationAsCode()
+}
+
+// applyConfigurationAsCode renders the configuration as code of Jenkins from the team settings then applies it
+// via a Pull Request on the development environment or directly to the cluster
+func (o *EditJenkinsOptions) applyConfigurationAsCode() error {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	devEnv, err := kube.GetDevEnvironment(jxClient, ns)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find the development environment in namespace %s", ns)
+	}
+	if devEnv == nil {
+		return fmt.Errorf("No Development environment found for namespace %s", ns)
+	}
+
+	podTemplates, err := kube.LoadPodTemplates(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	secrets, err := kubeClient.CoreV1().Secrets(ns).List(metav1.ListOptions{
+		LabelSelector: kube.LabelCredentialsType,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the credential secrets in namespace %s", ns)
+	}
+	// the pod templates are shared by all the repositories so only the unrestricted pipeline secrets are injected
+	pipelineSecrets, err := kube.GetPipelineSecrets(kubeClient, ns, "", "")
+	if err != nil {
+		return err
+	}
+	jenkinsURL, err := o.GetJenkinsURL()
+	if err != nil {
+		return err
+	}
+
+	config := &jenkins.CascConfig{
+		Namespace:       ns,
+		JenkinsURL:      jenkinsURL,
+		PodTemplates:    podTemplates,
+		Credentials:     secrets.Items,
+		Libraries:       devEnv.Spec.TeamSettings.JenkinsLibraries,
+		PipelineSecrets: pipelineSecrets,
+	}
+	data, err := jenkins.RenderConfigurationAsCode(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to render the configuration as code of Jenkins")
+	}
+	cm := jenkins.CascConfigMap(ns, data)
+
+	if devEnv.Spec.TeamSettings.UseGitOps && devEnv.Spec.Source.URL != "" {
+		return o.createConfigurationAsCodePullRequest(devEnv, cm, secrets.Items)
+	}
+
+	configMaps := kubeClient.CoreV1().ConfigMaps(ns)
+	existing, err := configMaps.Get(cm.Name, metav1.GetOptions{})
+	if err == nil {
+		existing.Data = cm.Data
+		_, err = configMaps.Update(existing)
+	} else {
+		_, err = configMaps.Create(cm)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to save the ConfigMap %s in namespace %s", cm.Name, ns)
+	}
+	log.Infof("Saved the configuration as code of Jenkins in the ConfigMap %s\n", util.ColorInfo(cm.Name))
+
+	err = o.saveConfigurationAsCodeValues(ns, secrets.Items)
+	if err != nil {
+		return err
+	}
+	restarted, err := o.mountConfigurationAsCode(ns, secrets.Items)
+	if err != nil {
+		return err
+	}
+	if restarted {
+		log.Infof("Restarted Jenkins at %s which loaded the configuration as code on startup\n", util.ColorInfo(jenkinsURL))
+		return nil
+	}
+
+	authConfigSvc, err := o.Factory.CreateJenkinsAuthConfigService(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	userAuth := authConfigSvc.Config().FindUserAuth(jenkinsURL, "")
+	if userAuth == nil || userAuth.IsInvalid() {
+		log.Warnf("No API token found for Jenkins at %s so it will apply the configuration when it restarts\n", jenkinsURL)
+		return nil
+	}
+	err = jenkins.ReloadConfigurationAsCode(jenkinsURL, userAuth.Username, userAuth.ApiToken)
+	if err != nil {
+		return err
+	}
+	log.Infof("Reloaded the configuration as code of Jenkins at %s\n", util.ColorInfo(jenkinsURL))
+	return nil
+}
+
+// mountConfigurationAsCode mounts the ConfigMap of the configuration as code and the credential secrets into the
+// Jenkins deployment then waits for it to roll out. Returns true if Jenkins was restarted
+func (o *EditJenkinsOptions) mountConfigurationAsCode(ns string, secrets []corev1.Secret) (bool, error) {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return false, err
+	}
+	deployments := kubeClient.AppsV1().Deployments(ns)
+	deployment, err := deployments.Get(kube.ServiceJenkins, metav1.GetOptions{})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get the Jenkins deployment in namespace %s", ns)
+	}
+	modified, err := jenkins.EnsureCascPodSpec(&deployment.Spec.Template.Spec, secrets)
+	if err != nil {
+		return false, err
+	}
+	if !modified {
+		return false, nil
+	}
+	deployment, err = deployments.Update(deployment)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to mount the configuration as code into the Jenkins deployment in namespace %s", ns)
+	}
+	log.Infof("Mounted the configuration as code into the Jenkins deployment, waiting for Jenkins to restart\n")
+	generation := deployment.Generation
+	err = o.retryUntilTrueOrTimeout(10*time.Minute, 5*time.Second, func() (bool, error) {
+		d, err := deployments.Get(kube.ServiceJenkins, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return d.Status.ObservedGeneration >= generation && kube.IsDeploymentRolledOut(d), nil
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to wait for Jenkins to restart with the configuration as code")
+	}
+	return true, nil
+}
+
+// saveConfigurationAsCodeValues saves the chart values which mount the configuration as code into Jenkins in the
+// install configuration so that upgrading the platform keeps them
+func (o *EditJenkinsOptions) saveConfigurationAsCodeValues(ns string, secrets []corev1.Secret) error {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	secretResources := kubeClient.CoreV1().Secrets(ns)
+	installConfig, err := secretResources.Get(JXInstallConfig, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Warnf("No %s secret found in namespace %s so upgrading the platform will not mount the configuration as code of Jenkins\n", JXInstallConfig, ns)
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get the %s secret in namespace %s", JXInstallConfig, ns)
+	}
+	data, err := mergeConfigurationAsCodeValues(installConfig.Data[ExtraValuesFile], "", secrets)
+	if err != nil {
+		return errors.Wrapf(err, "failed to add the configuration as code values to %s", ExtraValuesFile)
+	}
+	if installConfig.Data == nil {
+		installConfig.Data = map[string][]byte{}
+	}
+	installConfig.Data[ExtraValuesFile] = data
+	_, err = secretResources.Update(installConfig)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update the %s secret in namespace %s", JXInstallConfig, ns)
+	}
+	return nil
+}
+
+// mergeConfigurationAsCodeValues merges the Jenkins chart values of the configuration as code into the values YAML,
+// nested in the given chart if it is not empty
+func mergeConfigurationAsCodeValues(data []byte, chartName string, secrets []corev1.Secret) ([]byte, error) {
+	values := map[string]interface{}{}
+	err := yaml.Unmarshal(data, &values)
+	if err != nil {
+		return nil, err
+	}
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	cascValues := map[string]interface{}{
+		"jenkins": jenkins.CascHelmValues(secrets),
+	}
+	if chartName != "" {
+		cascValues = map[string]interface{}{
+			chartName: cascValues,
+		}
+	}
+	util.CombineMapTrees(values, cascValues)
+	return yaml.Marshal(values)
+}
+
+// createConfigurationAsCodePullRequest creates a Pull Request on the development environment which adds the
+// ConfigMap of the configuration as code of Jenkins to its templates and mounts it into Jenkins via the values
+func (o *EditJenkinsOptions) createConfigurationAsCodePullRequest(devEnv *v1.Environment, cm *corev1.ConfigMap, secrets []corev1.Secret) error {
+	data, err := yaml.Marshal(cm)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the ConfigMap of the configuration as code of Jenkins")
+	}
+	modifyDirFn := func(dir string) error {
+		envDir := filepath.Join(dir, "env")
+		templatesDir := filepath.Join(envDir, "templates")
+		err := os.MkdirAll(templatesDir, util.DefaultWritePermissions)
+		if err != nil {
+			return err
+		}
+		err = ioutil.WriteFile(filepath.Join(templatesDir, jenkins.CascConfigMapName+"-configmap.yaml"), data, util.DefaultWritePermissions)
+		if err != nil {
+			return err
+		}
+		valuesFile := filepath.Join(envDir, helm.ValuesFileName)
+		exists, err := util.FileExists(valuesFile)
+		if err != nil {
+			return err
+		}
+		valuesData := []byte{}
+		if exists {
+			valuesData, err = ioutil.ReadFile(valuesFile)
+			if err != nil {
+				return errors.Wrapf(err, "failed to load %s", valuesFile)
+			}
+		}
+		valuesData, err = mergeConfigurationAsCodeValues(valuesData, JenkinsXPlatformChartName, secrets)
+		if err != nil {
+			return errors.Wrapf(err, "failed to add the configuration as code values to %s", valuesFile)
+		}
+		return ioutil.WriteFile(valuesFile, valuesData, util.DefaultWritePermissions)
+	}
+	branchName := "jenkins-casc"
+	title := "Update the configuration as code of Jenkins"
+	message := "Update the configuration as code of Jenkins from the team settings"
+	info, err := o.createEnvironmentPullRequestForDir(devEnv, modifyDirFn, &branchName, &title, &message, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the Pull Request on the development environment")
+	}
+	if info != nil && info.PullRequest != nil {
+		log.Infof("Updated the configuration as code of Jenkins via Pull Request %s\n", info.PullRequest.URL)
+	}
+	return nil
+}
+
+// parseJenkinsLibrary parses a shared pipeline library of the form name=gitURL[@version]
+func parseJenkinsLibrary(text string) (v1.JenkinsLibrary, error) {
+	library := v1.JenkinsLibrary{}
+	values := strings.SplitN(text, "=", 2)
+	if len(values) != 2 || values[0] == "" || values[1] == "" {
+		return library, util.InvalidOptionf("library", text, "libraries should be of the form name=gitURL[@version]")
+	}
+	library.Name = values[0]
+	library.GitURL = values[1]
+	// SSH URLs such as git@github.com:myorg/lib.git contain an @ before the path so only look for the version after it
+	idx := strings.LastIndex(library.GitURL, "@")
+	if idx > strings.LastIndex(library.GitURL, "/") {
+		library.DefaultVersion = library.GitURL[idx+1:]
+		library.GitURL = library.GitURL[:idx]
+	}
+	return library, nil
+}
+
+// updateJenkinsLibraries adds or replaces the libraries with the same name and removes the named libraries
+func updateJenkinsLibraries(existing []v1.JenkinsLibrary, libraries []v1.JenkinsLibrary, removeNames []string) []v1.JenkinsLibrary {
+	answer := []v1.JenkinsLibrary{}
+	for _, library := range existing {
+		if util.StringArrayIndex(removeNames, library.Name) >= 0 {
+			continue
+		}
+		replaced := false
+		for _, l := range libraries {
+			if l.Name == library.Name {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			answer = append(answer, library)
+		}
+	}
+	return append(answer, libraries...)
+}
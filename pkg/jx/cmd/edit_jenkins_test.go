@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJenkinsLibrary(t *testing.T) {
+	t.Parallel()
+	library, err := parseJenkinsLibrary("shared=https://github.com/myorg/shared-library.git@v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, v1.JenkinsLibrary{Name: "shared", GitURL: "https://github.com/myorg/shared-library.git", DefaultVersion: "v1.0.0"}, library)
+
+	library, err = parseJenkinsLibrary("shared=git@github.com:myorg/shared-library.git")
+	require.NoError(t, err)
+	assert.Equal(t, "git@github.com:myorg/shared-library.git", library.GitURL)
+	assert.Equal(t, "", library.DefaultVersion)
+
+	_, err = parseJenkinsLibrary("https://github.com/myorg/shared-library.git")
+	assert.Error(t, err)
+}
+
+func TestUpdateJenkinsLibraries(t *testing.T) {
+	t.Parallel()
+	existing := []v1.JenkinsLibrary{
+		{Name: "a", GitURL: "https://github.com/myorg/a.git"},
+		{Name: "b", GitURL: "https://github.com/myorg/b.git"},
+		{Name: "c", GitURL: "https://github.com/myorg/c.git"},
+	}
+	libraries := []v1.JenkinsLibrary{{Name: "b", GitURL: "https://github.com/myorg/b2.git"}}
+
+	answer := updateJenkinsLibraries(existing, libraries, []string{"c"})
+	assert.Equal(t, []v1.JenkinsLibrary{
+		{Name: "a", GitURL: "https://github.com/myorg/a.git"},
+		{Name: "b", GitURL: "https://github.com/myorg/b2.git"},
+	}, answer)
+}
+
+func TestMergeConfigurationAsCodeValues(t *testing.T) {
+	t.Parallel()
+	data := []byte("jenkins-x-platform:\n  jenkins:\n    Servers:\n      Global:\n        EnvVars:\n          DOCKER_REGISTRY: myregistry\n")
+
+	data, err := mergeConfigurationAsCodeValues(data, JenkinsXPlatformChartName, nil)
+	require.NoError(t, err)
+
+	values := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal(data, &values))
+	jenkinsValues := values[JenkinsXPlatformChartName].(map[string]interface{})["jenkins"].(map[string]interface{})
+	assert.NotNil(t, jenkinsValues["Servers"], "the existing values should be kept")
+	assert.Contains(t, string(data), "name: CASC_JENKINS_CONFIG")
+	assert.Contains(t, string(data), "mountPath: /var/jenkins_casc")
+}
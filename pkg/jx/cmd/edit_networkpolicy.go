@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+var (
+	editNetworkPolicyLong = templates.LongDesc(`
+		Configures the network isolation of the environment and preview namespaces of your team
+
+		When enabled each namespace gets NetworkPolicies which deny all the ingress traffic other than from the pods
+		of the same namespace, the ingress controller, the platform services in the development namespace of the
+		team and any other allowed namespaces. The NetworkPolicies are applied to the existing namespaces and to
+		the namespaces of new environments and previews.
+
+		The cluster must use a network plugin which enforces NetworkPolicies such as Calico.
+
+		NetworkPolicies select the allowed namespaces by their jenkins.io/namespace label. Labelling namespaces
+		outside of the team, such as kube-system, needs the cluster scoped permission to update namespaces. Without
+		it a cluster administrator has to label them, for example:
+
+		    kubectl label namespace kube-system jenkins.io/namespace=kube-system
+`)
+
+	editNetworkPolicyExample = templates.Examples(`
+		# Isolate the environment and preview namespaces of the team
+		jx edit networkpolicy --enable
+
+		# Isolate the namespaces with an ingress controller in the ingress-nginx namespace
+		jx edit networkpolicy --enable --ingress-namespace ingress-nginx
+
+		# Also allow the traffic from the monitoring namespace
+		jx edit networkpolicy --enable --allow monitoring
+
+		# Remove the network isolation
+		jx edit networkpolicy --disable
+	`)
+)
+
+// EditNetworkPolicyOptions the options for the edit networkpolicy command
+type EditNetworkPolicyOptions struct {
+	EditOptions
+
+	Enable            bool
+	Disable           bool
+	IngressNamespace  string
+	AllowedNamespaces []string
+}
+
+// NewCmdEditNetworkPolicy creates a command object for the "edit networkpolicy" command
+func NewCmdEditNetworkPolicy(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &EditNetworkPolicyOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "networkpolicy",
+		Short:   "Configures the network isolation of the environment and preview namespaces",
+		Aliases: []string{"networkpolicies", "network-policy"},
+		Long:    editNetworkPolicyLong,
+		Example: editNetworkPolicyExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().BoolVarP(&options.Enable, "enable", "", false, "Isolates the namespaces with NetworkPolicies")
+	cmd.Flags().BoolVarP(&options.Disable, "disable", "", false, "Removes the NetworkPolicies from the namespaces")
+	cmd.Flags().StringVarP(&options.IngressNamespace, "ingress-namespace", "", "", "The namespace of the ingress controller. Defaults to "+kube.DefaultIngressNamespace)
+	cmd.Flags().StringArrayVarP(&options.AllowedNamespaces, "allow", "a", []string{}, "Another namespace allowed to access the namespaces. Can be specified multiple times")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditNetworkPolicyOptions) Run() error {
+	if o.Enable && o.Disable {
+		return errors.New("the --enable and --disable flags cannot be used together")
+	}
+	settings := v1.NetworkPolicySettings{}
+	callback := func(env *v1.Environment) error {
+		teamSettings := &env.Spec.TeamSettings
+		if o.Enable {
+			teamSettings.NetworkPolicy.Enabled = true
+		}
+		if o.Disable {
+			teamSettings.NetworkPolicy.Enabled = false
+		}
+		if o.IngressNamespace != "" {
+			teamSettings.NetworkPolicy.IngressNamespace = o.IngressNamespace
+		}
+		if len(o.AllowedNamespaces) > 0 {
+			teamSettings.NetworkPolicy.AllowedNamespaces = o.AllowedNamespaces
+		}
+		settings = teamSettings.NetworkPolicy
+		return nil
+	}
+	err := o.ModifyDevEnvironment(callback)
+	if err != nil {
+		return errors.Wrap(err, "failed to update the network policy settings of the team")
+	}
+	return o.applyNetworkPolicies(&settings)
+}
+
+// applyNetworkPolicies creates or removes the NetworkPolicies of the namespaces of the environments of the team
+func (o *EditNetworkPolicyOptions) applyNetworkPolicies(settings *v1.NetworkPolicySettings) error {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	envMap, envNames, err := kube.GetEnvironments(jxClient, ns)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the environments in namespace %s", ns)
+	}
+	namespaces := []string{}
+	for _, name := range envNames {
+		spec := &envMap[name].Spec
+		if spec.Kind == v1.EnvironmentKindTypeDevelopment || spec.Cluster != "" || spec.Namespace == "" || spec.Namespace == ns {
+			continue
+		}
+		if settings.Enabled {
+			err = kube.EnsureNetworkPolicies(kubeClient, spec.Namespace, ns, settings)
+		} else {
+			err = kube.DeleteNetworkPolicies(kubeClient, spec.Namespace)
+		}
+		if err != nil {
+			return err
+		}
+		namespaces = append(namespaces, spec.Namespace)
+	}
+	if len(namespaces) == 0 {
+		log.Infof("No environment namespaces found in the cluster\n")
+		return nil
+	}
+	if settings.Enabled {
+		allowed := kube.NetworkPolicyAllowedNamespaces(ns, settings)
+		log.Infof("Isolated the namespaces %s allowing the traffic from %s\n", util.ColorInfo(strings.Join(namespaces, ", ")), util.ColorInfo(strings.Join(allowed, ", ")))
+	} else {
+		log.Infof("Removed the NetworkPolicies from the namespaces %s\n", util.ColorInfo(strings.Join(namespaces, ", ")))
+	}
+	return nil
+}
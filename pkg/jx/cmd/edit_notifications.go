@@ -0,0 +1,400 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/notify"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	optionNotificationKind = "kind"
+	optionSMTPServer       = "smtp-server"
+	optionRecipient        = "recipient"
+	optionTemplate         = "template"
+	optionHeader           = "header"
+
+	notificationSecretPrefix = "jx-notify-"
+)
+
+var (
+	notificationKinds = []string{
+		string(v1.NotificationKindTypeEmail),
+		string(v1.NotificationKindTypeSlack),
+		string(v1.NotificationKindTypeTeams),
+		string(v1.NotificationKindTypeWebhook),
+		string(v1.NotificationKindTypePagerDuty),
+	}
+
+	editNotificationsLong = templates.LongDesc(`
+		Adds, modifies or removes a notification channel of your team.
+
+		Notification channels are notified of pipeline starts, successes and failures, promotions and preview
+		environments. A channel can post to Slack or Microsoft Teams, send emails using an SMTP server, trigger
+		PagerDuty incidents using the Events API v2 or post the events as JSON to a webhook so that they can be wired
+		into Opsgenie or internal systems.
+
+		The token, URL, HTTP headers and SMTP credentials of a channel are stored in a Secret in the development namespace.
+
+		Possible kinds: ` + strings.Join(notificationKinds, ", ") + `
+		Possible events: ` + strings.Join(notify.Events, ", ") + `
+`)
+
+	editNotificationsExample = templates.Examples(`
+		# Email failed pipelines and promotions to the on call team
+		jx edit notifications --name oncall --kind email --smtp-server smtp.example.com:587 --smtp-user jx@example.com \
+			--recipient oncall@example.com --events pipeline-failed --events promoted
+
+		# Trigger a PagerDuty incident when a pipeline fails using the integration key of an Events API v2 integration
+		jx edit notifications --name pagerduty --kind pagerduty --token 0123456789abcdef --events pipeline-failed
+
+		# Post failed pipelines to a webhook authenticating with an API key header
+		jx edit notifications --name opsgenie --kind webhook --url https://events.example.com/jx \
+			--header "Authorization=GenieKey 0123456789abcdef" --events pipeline-failed
+
+		# Customise the message of promotions of an existing channel
+		jx edit notifications --name slack --template "promoted={{.Application}} {{.Version}} is live in {{.Environment}}"
+
+		# Remove a notification channel
+		jx edit notifications --name oncall --remove
+	`)
+)
+
+// EditNotificationsOptions the options for the edit notifications command
+type EditNotificationsOptions struct {
+	EditOptions
+
+	Name         string
+	Kind         string
+	Channel      string
+	Events       []string
+	Repositories []string
+	Templates    []string
+	Recipients   []string
+	From         string
+	SMTPServer   string
+	SMTPUser     string
+	SMTPPassword string
+	URL          string
+	Token        string
+	Headers      []string
+	Remove       bool
+}
+
+// NewCmdEditNotifications creates a command object for the "edit notifications" command
+func NewCmdEditNotifications(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &EditNotificationsOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "notifications",
+		Short:   "Adds, modifies or removes a notification channel of pipeline and promotion events",
+		Aliases: []string{"notification", "notify"},
+		Long:    editNotificationsLong,
+		Example: editNotificationsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Name, "name", "n", "", "The name of the notification channel")
+	cmd.Flags().StringVarP(&options.Kind, optionNotificationKind, "k", "", "The kind of the notification channel. Possible values: "+strings.Join(notificationKinds, ", "))
+	cmd.Flags().StringVarP(&options.Channel, "channel", "c", "", "The chat channel to post to such as #builds")
+	cmd.Flags().StringArrayVarP(&options.Events, optionEvents, "e", []string{}, "The events to notify. Defaults to all events")
+	cmd.Flags().StringArrayVarP(&options.Repositories, optionRepo, "r", []string{}, "The repositories, as owner/name or name, whose events are notified. Defaults to all repositories")
+	cmd.Flags().StringArrayVarP(&options.Templates, optionTemplate, "t", []string{}, "The Go template of the message of an event using event=template")
+	cmd.Flags().StringArrayVarP(&options.Recipients, optionRecipient, "", []string{}, "The email addresses to notify")
+	cmd.Flags().StringVarP(&options.From, "from", "", "", "The sender address of the emails. Defaults to the SMTP user")
+	cmd.Flags().StringVarP(&options.SMTPServer, optionSMTPServer, "", "", "The host:port of the SMTP server used to send emails")
+	cmd.Flags().StringVarP(&options.SMTPUser, "smtp-user", "", "", "The user name used to authenticate with the SMTP server")
+	cmd.Flags().StringVarP(&options.SMTPPassword, "smtp-password", "", "", "The password used to authenticate with the SMTP server")
+	cmd.Flags().StringVarP(&options.URL, "url", "u", "", "The URL of the webhook or Microsoft Teams incoming webhook. Overrides the URL of the PagerDuty Events API")
+	cmd.Flags().StringVarP(&options.Token, "token", "", "", "The Slack bot token, the PagerDuty integration key or the bearer token of the webhook")
+	cmd.Flags().StringArrayVarP(&options.Headers, optionHeader, "", []string{}, "The additional HTTP headers posted to the webhook using name=value. The values are stored in the Secret of the channel")
+	cmd.Flags().BoolVarP(&options.Remove, "remove", "", false, "Removes the notification channel")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditNotificationsOptions) Run() error {
+	if o.Name == "" {
+		return util.MissingOption("name")
+	}
+	if o.Remove {
+		return o.removeChannel()
+	}
+	teamSettings, err := o.TeamSettings()
+	if err != nil {
+		return err
+	}
+	channel := v1.NotificationChannel{
+		Name:       o.Name,
+		SecretName: notificationSecretPrefix + o.Name,
+	}
+	exists := false
+	for _, c := range teamSettings.Notifications {
+		if c.Name == o.Name {
+			channel = c
+			exists = true
+			break
+		}
+	}
+
+	err = o.applyFlags(&channel, exists)
+	if err != nil {
+		return err
+	}
+	secretData, err := o.secretData(&channel, exists)
+	if err != nil {
+		return err
+	}
+	if channel.SecretName == "" && len(secretData) > 0 {
+		channel.SecretName = notificationSecretPrefix + o.Name
+	}
+	err = o.saveNotificationChannel(channel, secretData)
+	if err != nil {
+		return err
+	}
+	if exists {
+		log.Infof("Updated the %s notification channel %s\n", channel.Kind, util.ColorInfo(channel.Name))
+	} else {
+		log.Infof("Added the %s notification channel %s\n", channel.Kind, util.ColorInfo(channel.Name))
+	}
+	return nil
+}
+
+// applyFlags modifies the notification channel with the flags which have been specified
+func (o *EditNotificationsOptions) applyFlags(channel *v1.NotificationChannel, exists bool) error {
+	if o.Kind == "" && !exists {
+		if o.BatchMode {
+			return util.MissingOption(optionNotificationKind)
+		}
+		var err error
+		o.Kind, err = util.PickName(notificationKinds, "Pick the kind of the notification channel: ", "", o.In, o.Out, o.Err)
+		if err != nil {
+			return err
+		}
+	}
+	if o.Kind != "" {
+		if util.StringArrayIndex(notificationKinds, o.Kind) < 0 {
+			return util.InvalidOption(optionNotificationKind, o.Kind, notificationKinds)
+		}
+		channel.Kind = v1.NotificationKindType(o.Kind)
+	}
+	err := validateNotificationEvents(o.Events)
+	if err != nil {
+		return err
+	}
+	if len(o.Events) > 0 {
+		channel.Events = o.Events
+	}
+	if len(o.Repositories) > 0 {
+		channel.Repositories = o.Repositories
+	}
+	if o.Channel != "" {
+		channel.Channel = o.Channel
+	}
+	if len(o.Recipients) > 0 {
+		channel.Recipients = o.Recipients
+	}
+	if o.From != "" {
+		channel.From = o.From
+	}
+	if o.SMTPServer != "" {
+		channel.SMTPServer = o.SMTPServer
+	}
+
+	values, err := parseKeyValues(optionTemplate, o.Templates, "event=template")
+	if err != nil {
+		return err
+	}
+	for event, text := range values {
+		err = validateNotificationEvents([]string{event})
+		if err != nil {
+			return err
+		}
+		if channel.Templates == nil {
+			channel.Templates = map[string]string{}
+		}
+		channel.Templates[event] = text
+	}
+	switch channel.Kind {
+	case v1.NotificationKindTypeSlack:
+		if channel.Channel == "" {
+			return util.MissingOption("channel")
+		}
+	case v1.NotificationKindTypeEmail:
+		if channel.SMTPServer == "" {
+			return util.MissingOption(optionSMTPServer)
+		}
+		if len(channel.Recipients) == 0 {
+			return util.MissingOption(optionRecipient)
+		}
+	}
+	return nil
+}
+
+// secretData returns the values to store in the Secret of the channel prompting for any which are required
+func (o *EditNotificationsOptions) secretData(channel *v1.NotificationChannel, exists bool) (map[string]string, error) {
+	data := map[string]string{}
+	var err error
+	switch channel.Kind {
+	case v1.NotificationKindTypeSlack:
+		if o.Token == "" && !exists {
+			if o.BatchMode {
+				return nil, util.MissingOption("token")
+			}
+			o.Token, err = util.PickPassword("Slack bot token:", "The Bot User OAuth Access Token of the Slack app", o.In, o.Out, o.Err)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if o.Token != "" {
+			data[notify.SecretKeyToken] = o.Token
+		}
+	case v1.NotificationKindTypeTeams, v1.NotificationKindTypeWebhook:
+		if o.URL == "" && !exists {
+			if o.BatchMode {
+				return nil, util.MissingOption("url")
+			}
+			o.URL, err = util.PickPassword("URL:", "The URL the events are posted to", o.In, o.Out, o.Err)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if o.URL != "" {
+			data[notify.SecretKeyURL] = o.URL
+		}
+		if o.Token != "" && channel.Kind == v1.NotificationKindTypeWebhook {
+			data[notify.SecretKeyToken] = o.Token
+		}
+		if channel.Kind == v1.NotificationKindTypeWebhook {
+			headers, err := parseKeyValues(optionHeader, o.Headers, "name=value")
+			if err != nil {
+				return nil, err
+			}
+			for name, value := range headers {
+				key := notify.SecretKeyHeaderPrefix + name
+				if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+					return nil, util.InvalidOptionf(optionHeader, name, "is not a valid header name: %s", strings.Join(errs, ", "))
+				}
+				data[key] = value
+			}
+		}
+	case v1.NotificationKindTypePagerDuty:
+		if o.Token == "" && !exists {
+			if o.BatchMode {
+				return nil, util.MissingOption("token")
+			}
+			o.Token, err = util.PickPassword("PagerDuty integration key:", "The integration key of the Events API v2 integration of the PagerDuty service", o.In, o.Out, o.Err)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if o.Token != "" {
+			data[notify.SecretKeyToken] = o.Token
+		}
+		if o.URL != "" {
+			data[notify.SecretKeyURL] = o.URL
+		}
+	case v1.NotificationKindTypeEmail:
+		if o.SMTPUser != "" {
+			data[notify.SecretKeyUsername] = o.SMTPUser
+			if o.SMTPPassword == "" && !o.BatchMode {
+				o.SMTPPassword, err = util.PickPassword("SMTP password:", "The password of the SMTP user", o.In, o.Out, o.Err)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		if o.SMTPPassword != "" {
+			data[notify.SecretKeyPassword] = o.SMTPPassword
+		}
+	}
+	if exists && len(data) > 0 && channel.SecretName != "" {
+		// lets keep any existing values which have not been changed
+		kubeClient, ns, err := o.KubeClientAndDevNamespace()
+		if err != nil {
+			return nil, err
+		}
+		secret, err := kubeClient.CoreV1().Secrets(ns).Get(channel.SecretName, metav1.GetOptions{})
+		if err == nil {
+			for k, v := range secret.Data {
+				if _, ok := data[k]; !ok {
+					data[k] = string(v)
+				}
+			}
+		}
+	}
+	return data, nil
+}
+
+// removeChannel removes the notification channel and its Secret
+func (o *EditNotificationsOptions) removeChannel() error {
+	secretName := ""
+	found := false
+	err := o.ModifyDevEnvironment(func(env *v1.Environment) error {
+		settings := &env.Spec.TeamSettings
+		for i, c := range settings.Notifications {
+			if c.Name == o.Name {
+				secretName = c.SecretName
+				settings.Notifications = append(settings.Notifications[:i], settings.Notifications[i+1:]...)
+				found = true
+				return nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return util.InvalidArgError(o.Name, errors.New("no notification channel found"))
+	}
+	if secretName != "" {
+		kubeClient, ns, err := o.KubeClientAndDevNamespace()
+		if err != nil {
+			return err
+		}
+		err = kubeClient.CoreV1().Secrets(ns).Delete(secretName, &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "deleting the Secret %s in namespace %s", secretName, ns)
+		}
+	}
+	log.Infof("Removed the notification channel %s\n", util.ColorInfo(o.Name))
+	return nil
+}
+
+// parseKeyValues parses the key=value flags
+func parseKeyValues(option string, flags []string, format string) (map[string]string, error) {
+	answer := map[string]string{}
+	for _, text := range flags {
+		values := strings.SplitN(text, "=", 2)
+		if len(values) != 2 || values[0] == "" {
+			return nil, util.InvalidOptionf(option, text, "should be of the form %s", format)
+		}
+		answer[values[0]] = values[1]
+	}
+	return answer, nil
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+var (
+	editPodTemplateLong = templates.LongDesc(`
+		Customises a Jenkins pod template of the build agents of your team such as the image, the resources, the
+		environment variables and the volumes of its build container.
+
+		The customisations are stored separately from the pod templates and are merged into the pod templates again
+		when the platform is upgraded so that they are not lost. The pod template is restored as it was before being
+		customised via 'jx delete podtemplate'.
+`)
+
+	editPodTemplateExample = templates.Examples(`
+		# Give the maven builds more memory
+		jx edit podtemplate maven --request memory=2Gi --limit memory=4Gi --env MAVEN_OPTS=-Xmx3g
+
+		# Use a custom image for the go builds
+		jx edit podtemplate go --image myorg/builder-go:1.0.0
+
+		# Pick the pod template to customise
+		jx edit podtemplate --configmap-volume settings=/home/jenkins/settings
+	`)
+)
+
+// EditPodTemplateOptions the options for the edit podtemplate command
+type EditPodTemplateOptions struct {
+	EditOptions
+	PodTemplateFlags
+
+	Name string
+}
+
+// NewCmdEditPodTemplate creates a command object for the "edit podtemplate" command
+func NewCmdEditPodTemplate(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &EditPodTemplateOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "podtemplate [name]",
+		Short:   "Customises a Jenkins pod template of the build agents of your team",
+		Aliases: []string{"podtemplates", "pod-template"},
+		Long:    editPodTemplateLong,
+		Example: editPodTemplateExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Name, "name", "n", "", "The name of the pod template")
+	options.PodTemplateFlags.addFlags(cmd)
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditPodTemplateOptions) Run() error {
+	customisation, err := o.customisation()
+	if err != nil {
+		return err
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	_, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	podTemplates, err := kube.LoadPodTemplates(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	names := podTemplateNames(podTemplates)
+
+	if o.Name == "" && len(o.Args) > 0 {
+		o.Name = o.Args[0]
+	}
+	if o.Name == "" {
+		if o.BatchMode {
+			return util.MissingOption("name")
+		}
+		o.Name, err = util.PickName(names, "Pick the pod template to customise: ", "", o.In, o.Out, o.Err)
+		if err != nil {
+			return err
+		}
+	}
+	if podTemplates[o.Name] == nil {
+		return util.InvalidArg(o.Name, names)
+	}
+	err = kube.SavePodTemplateCustomisation(kubeClient, ns, o.Name, customisation)
+	if err != nil {
+		return err
+	}
+	log.Infof("Customised the pod template %s\n", util.ColorInfo(o.Name))
+	log.Infof("To use it in the static Jenkins server run: %s\n", util.ColorInfo("jx edit jenkins"))
+	return nil
+}
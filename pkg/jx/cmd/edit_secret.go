@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/vault"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+var (
+	editSecretLong = templates.LongDesc(`
+		Creates or updates the values of a secret stored in Vault.
+
+		The values are merged with the existing values of the secret. Any key given without a value is prompted for
+		so that the value does not end up in the shell history.
+`)
+
+	editSecretExample = templates.Examples(`
+		# Update the username and be prompted for the password of the Nexus admin secret
+		jx edit secret admin/nexus Username=admin Password
+
+		# Create a secret in a Vault other than the system Vault
+		jx edit secret myapp/db password=s3cret --name my-vault --namespace my-namespace
+	`)
+)
+
+// EditSecretOptions the options for the edit secret command
+type EditSecretOptions struct {
+	EditOptions
+
+	Namespace  string
+	Name       string
+	VaultToken string
+}
+
+// NewCmdEditSecret creates a command object for the "edit secret" command
+func NewCmdEditSecret(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &EditSecretOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "secret <path> [key=value]...",
+		Short:   "Creates or updates the values of a secret stored in Vault",
+		Aliases: []string{"secrets"},
+		Long:    editSecretLong,
+		Example: editSecretExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "Namespace of the Vault")
+	cmd.Flags().StringVarP(&options.Name, "name", "m", "", "The name of the Vault to use")
+	cmd.Flags().StringVarP(&options.VaultToken, "vault-token", "", "", "The token used to access a Vault which is not managed by the vault operator")
+	return cmd
+}
+
+// Run implements the command
+func (o *EditSecretOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("missing the path of the secret")
+	}
+	path := o.Args[0]
+	if len(o.Args) == 1 {
+		return fmt.Errorf("missing the key=value pairs of the secret %s", path)
+	}
+
+	if o.VaultToken != "" {
+		o.Factory.SetVaultToken(o.VaultToken)
+	}
+	var vaultClient vault.Client
+	var err error
+	if o.Name != "" || o.Namespace != "" {
+		vaultClient, err = o.Factory.GetVaultClient(o.Name, o.Namespace)
+	} else {
+		vaultClient, err = o.Factory.GetSystemVaultClient()
+	}
+	if err != nil {
+		return errors.Wrap(err, "retrieving the vault client")
+	}
+
+	data, err := vaultClient.Read(path)
+	if err != nil {
+		return errors.Wrapf(err, "reading the secret %s", path)
+	}
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+
+	surveyOpts := survey.WithStdio(o.In, o.Out, o.Err)
+	for _, arg := range o.Args[1:] {
+		key := arg
+		value := ""
+		hasValue := false
+		if i := strings.Index(arg, "="); i >= 0 {
+			key = arg[:i]
+			value = arg[i+1:]
+			hasValue = true
+		}
+		if key == "" {
+			return util.InvalidArgf(arg, "the key of the secret value is empty")
+		}
+		if !hasValue {
+			if o.BatchMode {
+				return util.InvalidArgf(arg, "no value given for key %s in batch mode", key)
+			}
+			prompt := &survey.Password{
+				Message: fmt.Sprintf("Value of %s:", key),
+			}
+			err = survey.AskOne(prompt, &value, survey.Required, surveyOpts)
+			if err != nil {
+				return err
+			}
+		}
+		data[key] = value
+	}
+
+	_, err = vaultClient.Write(path, data)
+	if err != nil {
+		return errors.Wrapf(err, "writing the secret %s", path)
+	}
+	log.Successf("Saved the secret %s", util.ColorInfo(path))
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmd_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd"
+	cmd_mocks "github.com/jenkins-x/jx/pkg/jx/cmd/mocks"
+	. "github.com/petergtz/pegomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVaultClient stores the secrets in memory
+type fakeVaultClient struct {
+	secrets map[string]map[string]interface{}
+}
+
+func (f *fakeVaultClient) Write(secretName string, data map[string]interface{}) (map[string]interface{}, error) {
+	f.secrets[secretName] = data
+	return data, nil
+}
+
+func (f *fakeVaultClient) WriteObject(secretName string, secret interface{}) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+func (f *fakeVaultClient) WriteYaml(secretName string, yamlstring string) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+func (f *fakeVaultClient) List(path string) ([]string, error) {
+	return nil, nil
+}
+
+func (f *fakeVaultClient) Read(secretName string) (map[string]interface{}, error) {
+	return f.secrets[secretName], nil
+}
+
+func (f *fakeVaultClient) Config() (url.URL, string, error) {
+	return url.URL{}, "", nil
+}
+
+func newEditSecretOptions(t *testing.T, vaultClient *fakeVaultClient, args ...string) (*cmd.EditSecretOptions, *cmd_mocks.MockFactory) {
+	RegisterMockTestingT(t)
+	factory := cmd_mocks.NewMockFactory()
+	When(factory.GetSystemVaultClient()).ThenReturn(vaultClient, nil)
+
+	o := &cmd.EditSecretOptions{
+		EditOptions: cmd.EditOptions{
+			CommonOptions: cmd.CommonOptions{
+				Factory:   factory,
+				BatchMode: true,
+				Args:      args,
+			},
+		},
+	}
+	return o, factory
+}
+
+func TestEditSecretMergesValues(t *testing.T) {
+	vaultClient := &fakeVaultClient{secrets: map[string]map[string]interface{}{
+		"admin/nexus": {
+			"Username": "admin",
+			"Password": "old",
+		},
+	}}
+	o, factory := newEditSecretOptions(t, vaultClient, "admin/nexus", "Password=s3cret=", "Email=")
+	o.VaultToken = "mytoken"
+
+	err := o.Run()
+	require.NoError(t, err)
+
+	factory.VerifyWasCalledOnce().SetVaultToken("mytoken")
+	assert.Equal(t, map[string]interface{}{
+		"Username": "admin",
+		"Password": "s3cret=",
+		"Email":    "",
+	}, vaultClient.secrets["admin/nexus"])
+}
+
+func TestEditSecretCreatesSecret(t *testing.T) {
+	vaultClient := &fakeVaultClient{secrets: map[string]map[string]interface{}{}}
+	o, factory := newEditSecretOptions(t, vaultClient, "myapp/db", "password=s3cret")
+
+	err := o.Run()
+	require.NoError(t, err)
+
+	factory.VerifyWasCalled(Never()).SetVaultToken(AnyString())
+	assert.Equal(t, map[string]interface{}{"password": "s3cret"}, vaultClient.secrets["myapp/db"])
+}
+
+func TestEditSecretInvalidArgs(t *testing.T) {
+	testCases := []struct {
+		name  string
+		args  []string
+		error string
+	}{
+		{"no path", []string{}, "missing the path of the secret"},
+		{"no values", []string{"myapp/db"}, "missing the key=value pairs of the secret myapp/db"},
+		{"empty key", []string{"myapp/db", "=s3cret"}, "the key of the secret value is empty"},
+		{"no value in batch mode", []string{"myapp/db", "password"}, "no value given for key password in batch mode"},
+	}
+	for _, tc := range testCases {
+		vaultClient := &fakeVaultClient{secrets: map[string]map[string]interface{}{}}
+		o, _ := newEditSecretOptions(t, vaultClient, tc.args...)
+
+		err := o.Run()
+		require.Error(t, err, tc.name)
+		assert.Contains(t, err.Error(), tc.error, tc.name)
+		assert.Empty(t, vaultClient.secrets, tc.name)
+	}
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/security"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+const (
+	optionMaxSeverity = "max-severity"
+)
+
+var (
+	editSecurityPolicyLong = templates.LongDesc(`
+		Configures the security policy of your team which prevents promoting images with vulnerabilities
+
+		Promoting a version to an environment of the policy fails if the scan of its image found vulnerabilities more
+		severe than the maximum severity. The images are scanned by the pipelines if the team has installed an image
+		scanning addon such as 'jx create addon trivy'
+
+		Possible severities: ` + strings.Join(security.SeverityNames, ", ") + `
+`)
+
+	editSecurityPolicyExample = templates.Examples(`
+		# Prevent promoting images with critical vulnerabilities to production
+		jx edit securitypolicy --max-severity high
+
+		# Prevent promoting images with any vulnerabilities above low to staging and production
+		jx edit securitypolicy --max-severity low --env staging --env production
+
+		# Remove the security policy
+		jx edit securitypolicy --disable
+	`)
+)
+
+// EditSecurityPolicyOptions the options for the edit securitypolicy command
+type EditSecurityPolicyOptions struct {
+	EditOptions
+
+	MaxSeverity  string
+	Environments []string
+	Disable      bool
+}
+
+// NewCmdEditSecurityPolicy creates a command object for the "edit securitypolicy" command
+func NewCmdEditSecurityPolicy(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &EditSecurityPolicyOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "securitypolicy",
+		Short:   "Configures the security policy which prevents promoting images with vulnerabilities",
+		Aliases: []string{"security-policy"},
+		Long:    editSecurityPolicyLong,
+		Example: editSecurityPolicyExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.MaxSeverity, optionMaxSeverity, "m", "", "The highest severity of vulnerability allowed in promoted images")
+	cmd.Flags().StringArrayVarP(&options.Environments, "env", "e", []string{}, "The environments the policy applies to. Defaults to production")
+	cmd.Flags().BoolVarP(&options.Disable, "disable", "", false, "Removes the security policy")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditSecurityPolicyOptions) Run() error {
+	policy := v1.SecurityPolicy{}
+	if !o.Disable {
+		if o.MaxSeverity == "" {
+			if o.BatchMode {
+				return util.MissingOption(optionMaxSeverity)
+			}
+			var err error
+			o.MaxSeverity, err = util.PickNameWithDefault(security.SeverityNames, "Pick the highest severity allowed: ", security.SeverityHigh.String(),
+				"Promoting images with more severe vulnerabilities fails", o.In, o.Out, o.Err)
+			if err != nil {
+				return err
+			}
+		}
+		severity, err := security.ParseSeverity(o.MaxSeverity)
+		if err != nil {
+			return util.InvalidOption(optionMaxSeverity, o.MaxSeverity, security.SeverityNames)
+		}
+		policy.MaxSeverity = severity.String()
+		policy.Environments = o.Environments
+	}
+
+	callback := func(env *v1.Environment) error {
+		env.Spec.TeamSettings.SecurityPolicy = policy
+		if policy.MaxSeverity == "" {
+			log.Infof("Removed the security policy\n")
+		} else {
+			envs := policy.Environments
+			if len(envs) == 0 {
+				envs = []string{"production"}
+			}
+			log.Infof("Promoting images with vulnerabilities above %s to %s now fails\n", util.ColorInfo(policy.MaxSeverity), util.ColorInfo(strings.Join(envs, ", ")))
+		}
+		return nil
+	}
+	return o.ModifyDevEnvironment(callback)
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/cloud/buckets"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
@@ -17,6 +18,9 @@ var (
 
 		Per team you can specify a Git repository URL to store artifacts inside per classification or you can use a HTTP URL.
 
+		You can also use a Google Cloud Storage, AWS S3 or MinIO bucket URL so that the build logs, test reports and other
+		artifacts are archived long after the build pods have been garbage collected.
+
 		If you don't specify any specific storage for a classifier it will try the classifier 'default'.If there is still no configuration then it will default to the git repository for a project.'
 `)
 
@@ -33,6 +37,12 @@ var (
 		# Configure the git URL of where all storage goes to by default unless a specific classifier has a config
 		jx edit storage -c default --git-url https://github.com/myorg/mylogs.git'
 
+		# Configure a Google Cloud Storage bucket to archive the logs
+		jx edit storage -c logs --bucket-url gs://my-logs
+
+		# Configure a MinIO bucket to archive all storage by default
+		jx edit storage -c default --bucket-url "s3://my-bucket?endpoint=http://minio.jx.svc:9000"
+
 	`)
 )
 
@@ -43,6 +53,7 @@ type EditStorageOptions struct {
 	Classifier string
 	GitURL     string
 	HttpURL    string
+	BucketURL  string
 }
 
 // NewCmdEditStorage creates a command object for the "create" command
@@ -77,6 +88,7 @@ func NewCmdEditStorage(f Factory, in terminal.FileReader, out terminal.FileWrite
 	cmd.Flags().StringVarP(&options.Classifier, "classifier", "c", "", "A name which classifies this type of file. Example values: "+kube.ClassificationValues)
 	cmd.Flags().StringVarP(&options.HttpURL, "http-url", "", "", "Specify the HTTP endpoint to send each file to")
 	cmd.Flags().StringVarP(&options.GitURL, "git-url", "", "", "Specify the Git URL to populate in a gh-pages branch")
+	cmd.Flags().StringVarP(&options.BucketURL, "bucket-url", "", "", "Specify the bucket URL to store content in. Supports gs://bucket, s3://bucket and s3://bucket?endpoint=http://minio:9000 for MinIO")
 
 	return cmd
 }
@@ -94,7 +106,13 @@ func (o *EditStorageOptions) Run() error {
 		return util.MissingOption("classifier")
 	}
 
-	if !o.BatchMode && (o.HttpURL == "" && o.GitURL == "") {
+	if o.BucketURL != "" {
+		if _, err := buckets.ParseBucketURL(o.BucketURL); err != nil {
+			return err
+		}
+	}
+
+	if !o.BatchMode && (o.HttpURL == "" && o.GitURL == "" && o.BucketURL == "") {
 		o.GitURL, err = util.PickValue("Git repository URL to store content:", o.GitURL, false, "The Git URL will be used to clone and push the storage to", o.In, o.Out, o.Err)
 		if err != nil {
 		  return err
@@ -109,6 +127,7 @@ func (o *EditStorageOptions) Run() error {
 		location := env.Spec.TeamSettings.StorageLocation(o.Classifier)
 		location.GitURL = o.GitURL
 		location.HttpURL = o.HttpURL
+		location.BucketURL = o.BucketURL
 		return nil
 	}
 	return o.ModifyDevEnvironment(callback)
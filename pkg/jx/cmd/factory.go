@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 
 	"github.com/jenkins-x/jx/pkg/io/secrets"
+	"github.com/jenkins-x/jx/pkg/keychain"
 	"github.com/jenkins-x/jx/pkg/vault"
 
 	"github.com/jenkins-x/jx/pkg/helm"
@@ -64,6 +65,7 @@ type factory struct {
 	bearerToken     string
 	secretLocation  secrets.SecretLocation
 	useVault        bool
+	vaultToken      string
 }
 
 // NewFactory creates a factory with the default Kubernetes resources defined
@@ -290,35 +292,94 @@ func (f *factory) AuthMergePipelineSecrets(config *auth.AuthConfig, secrets *cor
 	return nil
 }
 
-// CreateAuthConfigService creates a new service saving auth config under the provided name. Depending on the factory,
-// It will either save the config to the local file-system, or a Vault
+// CreateAuthConfigService creates a new service saving auth config under the provided name. Depending on the
+// secrets location of the team it will save the config to the local file-system, the OS keychain, a Secret in
+// the cluster or a Vault
 func (f *factory) CreateAuthConfigService(configName string) (auth.ConfigService, error) {
-	if f.UseVault() {
+	switch f.SecretsLocation() {
+	case secrets.VaultLocationKind:
 		vaultClient, err := f.GetSystemVaultClient()
 		authService := auth.NewVaultAuthConfigService(configName, vaultClient)
 		return authService, err
-	} else {
+	case secrets.KeychainLocationKind:
+		return auth.NewKeychainAuthConfigService(configName, keychain.NewKeychain()), nil
+	case secrets.KubeLocationKind:
+		client, namespace, err := f.CreateKubeClient()
+		if err != nil {
+			return nil, errors.Wrap(err, "creating the kube client")
+		}
+		return auth.NewKubeAuthConfigService(configName, client, namespace), nil
+	default:
 		return auth.NewFileAuthConfigService(configName)
 	}
 }
 
 // UseVault idicates if the platform is using a Vault to manage the secrets
 func (f *factory) UseVault() bool {
+	return f.SecretsLocation() == secrets.VaultLocationKind
+}
+
+// SecretsLocation returns where the secrets of the team are stored, defaulting to the local file-system
+func (f *factory) SecretsLocation() secrets.SecretsLocationKind {
 	client, namespace, err := f.CreateKubeClient()
 	if err != nil {
-		return false
+		return secrets.FileSystemLocationKind
 	}
 	if f.secretLocation == nil {
 		f.secretLocation = secrets.NewSecretLocation(client, namespace)
 	}
-	return f.secretLocation.InVault()
+	return f.secretLocation.Location()
 }
 
-// GetSystemVaultClient gets the system vault client for managing the secrets
+// SetSecretsLocation saves where the secrets of the team are stored in the cluster
+func (f *factory) SetSecretsLocation(location secrets.SecretsLocationKind) error {
+	client, namespace, err := f.CreateKubeClient()
+	if err != nil {
+		return errors.Wrap(err, "creating the kube client")
+	}
+	if f.secretLocation == nil {
+		f.secretLocation = secrets.NewSecretLocation(client, namespace)
+	}
+	return f.secretLocation.SetLocation(location)
+}
+
+// GetSystemVaultClient gets the system vault client for managing the secrets. An existing vault is used
+// instead of the one managed by the vault operator when the team settings have its URL
 func (f *factory) GetSystemVaultClient() (vault.Client, error) {
+	vaultURL := f.externalVaultURL()
+	if vaultURL != "" {
+		return vault.NewExternalVaultClient(vaultURL, f.vaultToken)
+	}
 	return f.GetVaultClient("", "") // GetVaultClient will use defaults if empty strings specified
 }
 
+// SetVaultToken sets the token used to access an existing vault which is not managed by the vault operator
+func (f *factory) SetVaultToken(token string) {
+	f.vaultToken = token
+}
+
+// externalVaultURL returns the URL of the existing vault which the team stores its secrets in or an empty string if
+// the secrets are in the vault managed by the vault operator
+func (f *factory) externalVaultURL() string {
+	kubeClient, ns, err := f.CreateKubeClient()
+	if err != nil {
+		return ""
+	}
+	jxClient, _, err := f.CreateJXClient()
+	if err != nil {
+		return ""
+	}
+	devNs, _, err := kube.GetDevNamespace(kubeClient, ns)
+	if err != nil {
+		return ""
+	}
+	env, err := kube.GetDevEnvironment(jxClient, devNs)
+	if err != nil || env == nil {
+		return ""
+	}
+	return env.Spec.TeamSettings.VaultURL
+}
+
 // GetVaultClient returns the given vault client for managing secrets
 // Will use default values for name and namespace if nil values are applied
 func (f *factory) GetVaultClient(name string, namespace string) (vault.Client, error) {
@@ -544,6 +605,11 @@ func (f *factory) CreateComplianceClient() (*client.SonobuoyClient, error) {
 	return client.NewSonobuoyClient(config, skc)
 }
 
+// CreateCommandRunner creates the runner of external commands
+func (f *factory) CreateCommandRunner() util.CommandRunner {
+	return util.NewCommandRunner()
+}
+
 // CreateVaultOperatorClient creates a new vault operator client
 func (f *factory) CreateVaultOperatorClient() (vaultoperatorclient.Interface, error) {
 	config, err := f.CreateKubeConfig()
@@ -561,8 +627,16 @@ func (f *factory) GetHelm(verbose bool,
 	if helmBinary == "" {
 		helmBinary = "helm"
 	}
+	helmVersion := helm.DetectVersion(helmBinary)
+	if helmVersion == helm.V3 {
+		// there is no tiller with helm 3 so no need to run one locally or render the templates with kubectl
+		noTiller = false
+		helmTemplate = false
+	}
 	featureFlag := "none"
-	if helmTemplate {
+	if helmVersion == helm.V3 {
+		featureFlag = "helm3"
+	} else if helmTemplate {
 		featureFlag = "template-mode"
 	} else if noTiller {
 		featureFlag = "no-tiller-server"
@@ -570,7 +644,7 @@ func (f *factory) GetHelm(verbose bool,
 	if verbose {
 		log.Infof("Using helmBinary %s with feature flag: %s\n", util.ColorInfo(helmBinary), util.ColorInfo(featureFlag))
 	}
-	helmCLI := helm.NewHelmCLI(helmBinary, helm.V2, "", verbose)
+	helmCLI := helm.NewHelmCLI(helmBinary, helmVersion, "", verbose)
 	var h helm.Helmer = helmCLI
 	if helmTemplate {
 		kubeClient, ns, _ := f.CreateKubeClient()
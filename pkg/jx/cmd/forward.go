@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/kube/services"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// forwardReconnectDelay the time to wait before reconnecting a port forward which stopped
+	forwardReconnectDelay = 2 * time.Second
+)
+
+// ForwardOptions the options for the forward command
+type ForwardOptions struct {
+	CommonOptions
+
+	Namespace   string
+	Environment string
+	LocalPort   int
+}
+
+var (
+	forward_long = templates.LongDesc(`
+		Forwards a local port to the service of an application so that it can be used without an ingress such as
+		on a private cluster.
+
+		A free local port is chosen unless one is specified. The port forward is reconnected automatically whenever it
+		stops such as when the pods of the application are replaced by a new rollout.
+`)
+
+	forward_example = templates.Examples(`
+		# Forward a local port to the first port of the service of the application myapp
+		jx forward myapp
+
+		# Forward a local port to port 8080 of the service of myapp in the staging environment
+		jx forward myapp 8080 -e staging
+
+		# Forward local port 9000 to the Nexus service
+		jx forward nexus --local-port 9000
+`)
+)
+
+// NewCmdForward creates the command
+func NewCmdForward(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &ForwardOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			In:      in,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "forward <app> [port]",
+		Short:   "Forwards a local port to the service of an application",
+		Long:    forward_long,
+		Example: forward_example,
+		Aliases: []string{"port-forward"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace to look for the service. Defaults to the current namespace")
+	cmd.Flags().StringVarP(&options.Environment, "env", "e", "", "The Environment to look for the service. Defaults to the current environment")
+	cmd.Flags().IntVarP(&options.LocalPort, "local-port", "l", 0, "The local port to use. Defaults to the port of the service if it is free otherwise a free port")
+	return cmd
+}
+
+// Run implements this command
+func (o *ForwardOptions) Run() error {
+	args := o.Args
+	if len(args) == 0 {
+		return util.MissingArgument("app")
+	}
+	_, ns, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	if o.Namespace != "" {
+		ns = o.Namespace
+	} else if o.Environment != "" {
+		ns, err = o.findEnvironmentNamespace(o.Environment)
+		if err != nil {
+			return err
+		}
+	}
+	svc, err := o.findForwardService(args[0], ns)
+	if err != nil {
+		return err
+	}
+	if len(svc.Spec.Ports) == 0 {
+		return fmt.Errorf("Service %s in namespace %s has no ports", svc.Name, ns)
+	}
+	remotePort := int(svc.Spec.Ports[0].Port)
+	if len(args) > 1 {
+		remotePort, err = strconv.Atoi(args[1])
+		if err != nil {
+			return util.InvalidArgError(args[1], err)
+		}
+		ports := []string{}
+		found := false
+		for _, p := range svc.Spec.Ports {
+			ports = append(ports, strconv.Itoa(int(p.Port)))
+			if int(p.Port) == remotePort {
+				found = true
+			}
+		}
+		if !found {
+			return util.InvalidArg(args[1], ports)
+		}
+	}
+
+	preferred := o.LocalPort
+	if preferred == 0 {
+		preferred = remotePort
+	}
+	localPort, err := util.FreeLocalPort(preferred)
+	if err != nil {
+		return err
+	}
+	if o.LocalPort > 0 && localPort != o.LocalPort {
+		return fmt.Errorf("Local port %d is already in use", o.LocalPort)
+	}
+
+	log.Infof("Forwarding %s to port %d of service %s in namespace %s\n", util.ColorInfo(fmt.Sprintf("http://localhost:%d", localPort)), remotePort, util.ColorInfo(svc.Name), util.ColorInfo(ns))
+	log.Infof("Press Ctrl-C to stop\n")
+
+	os.Setenv("PATH", util.PathWithBinary())
+	kubectlArgs := []string{"port-forward", "-n", ns, "svc/" + svc.Name, fmt.Sprintf("%d:%d", localPort, remotePort)}
+	for {
+		e := exec.Command("kubectl", kubectlArgs...)
+		e.Stdout = o.Out
+		e.Stderr = o.Err
+		err = e.Run()
+		if err != nil {
+			log.Warnf("Port forward to service %s stopped: %s\n", svc.Name, err)
+		} else {
+			log.Warnf("Port forward to service %s stopped\n", svc.Name)
+		}
+		time.Sleep(forwardReconnectDelay)
+		log.Infof("Reconnecting the port forward to service %s\n", util.ColorInfo(svc.Name))
+	}
+}
+
+// findForwardService finds the service by its name or the name of its application falling back to picking one of
+// the services whose name contains the given name
+func (o *ForwardOptions) findForwardService(name string, ns string) (*corev1.Service, error) {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	svcs, err := services.GetServices(client, ns)
+	if err != nil {
+		return nil, err
+	}
+	if svc := svcs[name]; svc != nil {
+		return svc, nil
+	}
+	names := []string{}
+	for n, svc := range svcs {
+		if kube.GetName(&svc.ObjectMeta) == name {
+			return svc, nil
+		}
+		if strings.Contains(n, name) {
+			names = append(names, n)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("No service found for %s in namespace %s", name, ns)
+	}
+	sort.Strings(names)
+	n, err := util.PickName(names, "Pick Service:", "", o.In, o.Out, o.Err)
+	if err != nil {
+		return nil, err
+	}
+	return svcs[n], nil
+}
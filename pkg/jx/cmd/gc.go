@@ -71,6 +71,7 @@ func NewCmdGC(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut
 	}
 
 	cmd.AddCommand(NewCmdGCActivities(f, in, out, errOut))
+	cmd.AddCommand(NewCmdGCDevPods(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGCPreviews(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGCGKE(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGCHelm(f, in, out, errOut))
@@ -7,10 +7,13 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/tekton"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/jenkins-x/golang-jenkins"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
@@ -30,6 +33,8 @@ var (
 	GCActivitiesLong = templates.LongDesc(`
 		Garbage collect the Jenkins X Activity Custom Resource Definitions
 
+		If the team runs its pipelines with Tekton the Tekton resources and workspaces of the old builds of each
+		pipeline are deleted too.
 `)
 
 	GCActivitiesExample = templates.Examples(`
@@ -164,5 +169,33 @@ func (o *GCActivitiesOptions) Run() error {
 		}
 	}
 
+	settings, err := o.TeamSettings()
+	if err != nil {
+		return err
+	}
+	if settings.PipelineEngine == v1.PipelineEngineTekton {
+		return o.gcTektonPipelines(kubeClient, currentNs, activityBuilds)
+	}
+	return nil
+}
+
+// gcTektonPipelines deletes the Tekton resources and workspaces of the old builds of the pipelines
+func (o *GCActivitiesOptions) gcTektonPipelines(kubeClient kubernetes.Interface, ns string, activityBuilds map[string][]int) error {
+	dynamicClient, err := o.dynamicClient()
+	if err != nil {
+		return err
+	}
+	for pipeline := range activityBuilds {
+		pipelineID := kube.NewPipelineIDFromString(pipeline)
+		name := kube.ToValidName(pipelineID.Name)
+		err = tekton.GarbageCollectPipelines(dynamicClient, ns, name, o.RevisionHistoryLimit)
+		if err != nil {
+			return err
+		}
+		err = tekton.GarbageCollectWorkspaces(kubeClient, ns, name, o.RevisionHistoryLimit)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/version"
+)
+
+const (
+	// devPodsGCCronJobName the name of the CronJob which garbage collects the idle DevPods
+	devPodsGCCronJobName = "jx-gc-devpods"
+	// defaultDevPodsGCSchedule how often the idle DevPods are garbage collected by default
+	defaultDevPodsGCSchedule = "*/15 * * * *"
+)
+
+// GCDevPodsOptions the CLI options for the gc devpods command
+type GCDevPodsOptions struct {
+	CommonOptions
+
+	Schedule string
+}
+
+var (
+	GCDevPodsLong = templates.LongDesc(`
+		Garbage collect DevPods which have not been used for longer than their idle timeout.
+
+		The idle timeout of a DevPod is set via 'jx create devpod --idle-timeout'. Connecting to the DevPod via
+		'jx rsh --devpod' or synchronising files to it keeps it alive.
+
+		Use --schedule to garbage collect the idle DevPods periodically with a CronJob in the dev namespace. The
+		CronJob is created with the default schedule when a DevPod with an idle timeout is created.
+`)
+
+	GCDevPodsExample = templates.Examples(`
+		# delete the idle DevPods
+		jx gc devpods
+
+		# delete the idle DevPods every hour
+		jx gc devpods --schedule "0 * * * *"
+`)
+)
+
+// NewCmdGCDevPods creates the command object
+func NewCmdGCDevPods(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &GCDevPodsOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			In:      in,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "devpods",
+		Short:   "garbage collection for idle DevPods",
+		Aliases: []string{"devpod"},
+		Long:    GCDevPodsLong,
+		Example: GCDevPodsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Schedule, "schedule", "", "", "The cron schedule of a CronJob which deletes the idle DevPods periodically inside the cluster")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GCDevPodsOptions) Run() error {
+	client, curNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(client, curNs)
+	if err != nil {
+		return err
+	}
+	if o.Schedule != "" {
+		return o.scheduleDevPodsGC(client, ns, o.Schedule)
+	}
+	pods, err := kube.GetDevPods(client, ns)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	errs := []error{}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.DeletionTimestamp != nil || !kube.IsDevPodIdle(pod, now) {
+			continue
+		}
+		err = client.CoreV1().Pods(ns).Delete(pod.Name, &metav1.DeleteOptions{})
+		if err != nil {
+			log.Warnf("Failed to delete DevPod %s in namespace %s: %s\n", pod.Name, ns, err)
+			errs = append(errs, err)
+			continue
+		}
+		idle := now.Sub(kube.DevPodLastActivity(pod)).Round(time.Minute)
+		log.Infof("Deleted DevPod %s of user %s as it has been idle for %s\n", util.ColorInfo(pod.Name), util.ColorInfo(pod.Labels[kube.LabelDevPodUsername]), idle)
+	}
+	return util.CombineErrors(errs...)
+}
+
+// scheduleDevPodsGC creates or updates the CronJob which runs 'jx gc devpods' on the schedule in the dev namespace
+func (o *CommonOptions) scheduleDevPodsGC(client kubernetes.Interface, ns string, schedule string) error {
+	// the service accounts of the builds can delete the DevPods
+	serviceAccount, err := o.pipelineServiceAccount()
+	if err != nil {
+		return err
+	}
+	labels := map[string]string{
+		"app": devPodsGCCronJobName,
+	}
+	cronJob := &batchv1beta1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   devPodsGCCronJobName,
+			Labels: labels,
+		},
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule:          schedule,
+			ConcurrencyPolicy: batchv1beta1.ForbidConcurrent,
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: labels,
+						},
+						Spec: corev1.PodSpec{
+							RestartPolicy:      corev1.RestartPolicyNever,
+							ServiceAccountName: serviceAccount,
+							Containers: []corev1.Container{
+								{
+									Name:    "gc-devpods",
+									Image:   "jenkinsxio/jx:" + version.GetVersion(),
+									Command: []string{"jx"},
+									Args:    []string{"gc", "devpods", "--batch-mode"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	cronJobs := client.BatchV1beta1().CronJobs(ns)
+	existing, err := cronJobs.Get(devPodsGCCronJobName, metav1.GetOptions{})
+	if err == nil {
+		existing.Spec = cronJob.Spec
+		existing.Labels = cronJob.Labels
+		_, err = cronJobs.Update(existing)
+	} else {
+		_, err = cronJobs.Create(cronJob)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "saving CronJob %s in namespace %s", devPodsGCCronJobName, ns)
+	}
+	log.Infof("CronJob %s in namespace %s deletes the idle DevPods on schedule %s\n", util.ColorInfo(devPodsGCCronJobName), util.ColorInfo(ns), util.ColorInfo(schedule))
+	return nil
+}
+
+// ensureDevPodsGCScheduled creates the CronJob which garbage collects the idle DevPods with the default schedule if
+// it does not exist yet
+func (o *CommonOptions) ensureDevPodsGCScheduled(client kubernetes.Interface, ns string) error {
+	_, err := client.BatchV1beta1().CronJobs(ns).Get(devPodsGCCronJobName, metav1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+	return o.scheduleDevPodsGC(client, ns, defaultDevPodsGCSchedule)
+}
+
+// pipelineServiceAccount returns the service account which the pipelines of the team run as
+func (o *CommonOptions) pipelineServiceAccount() (string, error) {
+	prow, err := o.isProw()
+	if err != nil {
+		return "", err
+	}
+	if prow {
+		return "knative-build-bot", nil
+	}
+	return "jenkins", nil
+}
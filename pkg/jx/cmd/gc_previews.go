@@ -10,12 +10,11 @@ import (
 
 	"strconv"
 
-	"strings"
-
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
 )
 
 // GetOptions is the start of the data required to perform the operation.  As new fields are added, add them here instead of
@@ -113,17 +112,17 @@ func (o *GCPreviewsOptions) Run() error {
 			}
 			prNum, err := strconv.Atoi(e.Spec.PreviewGitSpec.Name)
 			if err != nil {
-				log.Warn("Unable to convert PR " + e.Spec.PreviewGitSpec.Name + " to a number" + "\n")
+				log.Warnf("Unable to convert PR %s of preview environment %s to a number, skipping\n", e.Spec.PreviewGitSpec.Name, e.Name)
+				continue
 			}
 			pullRequest, err := gitProvider.GetPullRequest(gitInfo.Organisation, gitInfo, prNum)
 			if err != nil {
-				log.Warnf("Can not get pull request %s, skipping: %s", e.Spec.PreviewGitSpec.Name, err)
+				log.Warnf("Can not get pull request %s, skipping: %s\n", e.Spec.PreviewGitSpec.Name, err)
 				continue
 			}
 
-			lowerState := strings.ToLower(*pullRequest.State)
-
-			if strings.HasPrefix(lowerState, "clos") || strings.HasPrefix(lowerState, "merged") || strings.HasPrefix(lowerState, "superseded") || strings.HasPrefix(lowerState, "declined") {
+			if pullRequest.IsFinished() {
+				log.Infof("Deleting preview environment %s as pull request %s is no longer open\n", util.ColorInfo(e.Name), util.ColorInfo(e.Spec.PreviewGitSpec.Name))
 				// lets delete the preview environment
 				deleteOpts := DeleteEnvOptions{
 					DeleteNamespace: true,
@@ -1,15 +1,20 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 
 	"github.com/ghodss/yaml"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/table"
 	"github.com/jenkins-x/jx/pkg/util"
 )
 
@@ -18,9 +23,19 @@ import (
 type GetOptions struct {
 	CommonOptions
 
-	Output string
+	Output    string
+	NoHeaders bool
+	SortBy    string
+	Watch     bool
+
+	// customWatch is enabled by the commands which watch for changes themselves rather than being run again
+	// every watch interval
+	customWatch bool
 }
 
+// getWatchInterval how often a get command is run again in watch mode
+const getWatchInterval = 2 * time.Second
+
 var (
 	get_long = templates.LongDesc(`
 		Display one or more resources.
@@ -35,6 +50,15 @@ var (
 
 		# List all URLs for services in the current namespace
 		jx get url
+
+		# List the environments sorted by namespace without the header row
+		jx get env --sort-by namespace --no-headers
+
+		# List only the name and namespace of the environments
+		jx get env -o custom-columns=NAME,NAMESPACE
+
+		# Watch the previews for changes
+		jx get previews -w
 	`)
 )
 
@@ -66,35 +90,44 @@ func NewCmdGet(f Factory, in terminal.FileReader, out terminal.FileWriter, errOu
 
 	cmd.AddCommand(NewCmdGetActivity(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetAddon(f, in, out, errOut))
+	cmd.AddCommand(NewCmdGetAks(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetApplications(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetAWSInfo(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetBranchPattern(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetBuild(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetBuildPack(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetChat(f, in, out, errOut))
+	cmd.AddCommand(NewCmdGetCommits(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetConfig(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetCVE(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetDevPod(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetEks(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetEnv(f, in, out, errOut))
+	cmd.AddCommand(NewCmdGetExtensions(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetGit(f, in, out, errOut))
+	cmd.AddCommand(NewCmdGetGke(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetHelmBin(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetIssue(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetIssues(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetLimits(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetPipeline(f, in, out, errOut))
+	cmd.AddCommand(NewCmdGetPodTemplates(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetPostPreviewJob(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetPreview(f, in, out, errOut))
+	cmd.AddCommand(NewCmdGetQuota(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetQuickstartLocation(f, in, out, errOut))
+	cmd.AddCommand(NewCmdGetQuickstarts(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetRelease(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetStorage(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetTeam(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetTeamRole(f, in, out, errOut))
+	cmd.AddCommand(NewCmdGetTeamSettings(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetToken(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetTracker(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetURL(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetUser(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetWorkflow(f, in, out, errOut))
+	cmd.AddCommand(NewCmdGetWebHooks(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetVault(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetSecret(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetVaultConfig(f, in, out, errOut))
@@ -113,9 +146,87 @@ func outputEmptyListWarning(out io.Writer) error {
 	return err
 }
 
+// addGetFlags adds the output flags shared by all the get commands and wraps the run function of the command so
+// that it is run again whenever the output changes in watch mode
 func (o *GetOptions) addGetFlags(cmd *cobra.Command) {
 	o.Cmd = cmd
-	cmd.Flags().StringVarP(&o.Output, "output", "o", "", "The output format such as 'yaml'")
+	cmd.Flags().StringVarP(&o.Output, "output", unusedShorthand(cmd, "o"), "", "The output format. One of: "+strings.Join(table.Formats, ", "))
+	cmd.Flags().BoolVarP(&o.NoHeaders, "no-headers", "", false, "Omits the header row of the table")
+	cmd.Flags().StringVarP(&o.SortBy, "sort-by", "", "", "Sorts the rows of the table by the column with the given header such as NAME")
+	cmd.Flags().BoolVarP(&o.Watch, "watch", unusedShorthand(cmd, "w"), false, "Watches for changes displaying the output again whenever it changes")
+
+	run := cmd.Run
+	if run == nil {
+		return
+	}
+	cmd.Run = func(c *cobra.Command, args []string) {
+		if !table.IsValidFormat(o.Output) {
+			CheckErr(util.InvalidOption("output", o.Output, table.Formats))
+		}
+		if !o.Watch || o.customWatch {
+			run(c, args)
+			return
+		}
+		o.watchOutput(func() {
+			run(c, args)
+		})
+	}
+}
+
+// unusedShorthand returns the shorthand if no other flag of the command uses it yet
+func unusedShorthand(cmd *cobra.Command, shorthand string) string {
+	if cmd.Flags().ShorthandLookup(shorthand) != nil {
+		return ""
+	}
+	return shorthand
+}
+
+// bufferedFileWriter collects the output of a command run in watch mode
+type bufferedFileWriter struct {
+	bytes.Buffer
+	fd uintptr
+}
+
+// Fd returns the file descriptor of the terminal the output is eventually written to
+func (w *bufferedFileWriter) Fd() uintptr {
+	return w.fd
+}
+
+// watchOutput runs the command every watch interval clearing the terminal and displaying the output again
+// whenever it changes
+func (o *GetOptions) watchOutput(run func()) {
+	out := o.Out
+	previous := ""
+	for {
+		buffer := &bufferedFileWriter{fd: out.Fd()}
+		o.Out = buffer
+		// most commands log their output rather than writing it to o.Out
+		restoreLog := log.SetOutput(buffer)
+		run()
+		restoreLog()
+		o.Out = out
+		text := buffer.String()
+		if text != previous {
+			fmt.Fprint(out, clearScreen)
+			fmt.Fprint(out, text)
+			previous = text
+		}
+		time.Sleep(getWatchInterval)
+	}
+}
+
+// CreateTable creates a table which is rendered in the output format of the get command
+func (o *GetOptions) CreateTable() table.Table {
+	answer := o.CommonOptions.CreateTable()
+	answer.Format = o.Output
+	answer.NoHeaders = o.NoHeaders
+	answer.SortBy = o.SortBy
+	return answer
+}
+
+// outputObjects returns true if the output format renders the resources themselves rather than a table
+func (o *GetOptions) outputObjects() bool {
+	return o.Output == table.FormatJSON || o.Output == table.FormatYAML
 }
 
 // renderResult renders the result in a given output format
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
 	"strings"
 	"time"
@@ -22,20 +23,25 @@ import (
 
 const (
 	indentation = "  "
+
+	// clearScreen moves the cursor to the top left of the terminal and clears it
+	clearScreen = "\033[H\033[2J"
 )
 
 // GetActivityOptions containers the CLI options
 type GetActivityOptions struct {
-	CommonOptions
+	GetOptions
 
 	Filter      string
 	BuildNumber string
-	Watch       bool
 }
 
 var (
 	get_activity_long = templates.LongDesc(`
 		Display the current activities for one or more projects.
+
+		Each build of a pipeline is displayed as a timeline of its stages and promotions with when they started,
+		how long they took and their status. Use --watch to keep the timeline updated as the builds progress.
 `)
 
 	get_activity_example = templates.Examples(`
@@ -53,11 +59,14 @@ var (
 // NewCmdGetActivity creates the new command for: jx get version
 func NewCmdGetActivity(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
 	options := &GetActivityOptions{
-		CommonOptions: CommonOptions{
-			Factory: f,
-			In:      in,
-			Out:     out,
-			Err:     errOut,
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+			customWatch: true,
 		},
 	}
 	cmd := &cobra.Command{
@@ -75,7 +84,7 @@ func NewCmdGetActivity(f Factory, in terminal.FileReader, out terminal.FileWrite
 	}
 	cmd.Flags().StringVarP(&options.Filter, "filter", "f", "", "Text to filter the pipeline names")
 	cmd.Flags().StringVarP(&options.BuildNumber, "build", "b", "", "The build number to filter on")
-	cmd.Flags().BoolVarP(&options.Watch, "watch", "w", false, "Whether to watch the activities for changes")
+	options.addGetFlags(cmd)
 	return cmd
 }
 
@@ -103,18 +112,19 @@ func (o *GetActivityOptions) Run() error {
 	table := o.CreateTable()
 	table.SetColumnAlign(1, util.ALIGN_RIGHT)
 	table.SetColumnAlign(2, util.ALIGN_RIGHT)
-	table.AddRow("STEP", "STARTED AGO", "DURATION", "STATUS")
-
 	if o.Watch {
 		return o.WatchActivities(&table, client, ns)
 	}
+	table.AddRow("STEP", "STARTED AGO", "DURATION", "STATUS")
 
 	list, err := client.JenkinsV1().PipelineActivities(ns).List(metav1.ListOptions{})
 	if err != nil {
 		return err
 	}
-	for _, activity := range list.Items {
-		o.addTableRow(&table, &activity)
+	activities := list.Items
+	SortPipelineActivities(activities)
+	for i := range activities {
+		o.addTableRow(&table, &activities[i])
 	}
 	table.Render()
 
@@ -124,6 +134,10 @@ func (o *GetActivityOptions) Run() error {
 func (o *GetActivityOptions) addTableRow(table *tbl.Table, activity *v1.PipelineActivity) bool {
 	if o.matches(activity) {
 		spec := &activity.Spec
+		duration := durationString(spec.StartedTimestamp, spec.CompletedTimestamp)
+		if duration == "" && spec.Status == v1.ActivityStatusTypeRunning {
+			duration = timeToString(spec.StartedTimestamp)
+		}
 		text := ""
 		version := activity.Spec.Version
 		if version != "" {
@@ -137,7 +151,7 @@ func (o *GetActivityOptions) addTableRow(table *tbl.Table, activity *v1.Pipeline
 		}
 		table.AddRow(spec.Pipeline+" #"+spec.Build,
 			timeToString(spec.StartedTimestamp),
-			durationString(spec.StartedTimestamp, spec.CompletedTimestamp),
+			duration,
 			statusText)
 		indent := indentation
 		for _, step := range spec.Steps {
@@ -148,8 +162,10 @@ func (o *GetActivityOptions) addTableRow(table *tbl.Table, activity *v1.Pipeline
 	return false
 }
 
+// WatchActivities renders the timeline of the matching activities every time one of them changes
 func (o *GetActivityOptions) WatchActivities(table *tbl.Table, jxClient versioned.Interface, ns string) error {
 	yamlSpecMap := map[string]string{}
+	activities := map[string]*v1.PipelineActivity{}
 	activity := &v1.PipelineActivity{}
 	listWatch := cache.NewListWatchFromClient(jxClient.JenkinsV1().RESTClient(), "pipelineactivities", ns, fields.Everything())
 	kube.SortListWatchByName(listWatch)
@@ -159,12 +175,18 @@ func (o *GetActivityOptions) WatchActivities(table *tbl.Table, jxClient versione
 		time.Minute*10,
 		cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
-				o.onActivity(table, obj, yamlSpecMap)
+				o.onActivity(table, obj, yamlSpecMap, activities)
 			},
 			UpdateFunc: func(oldObj, newObj interface{}) {
-				o.onActivity(table, newObj, yamlSpecMap)
+				o.onActivity(table, newObj, yamlSpecMap, activities)
 			},
 			DeleteFunc: func(obj interface{}) {
+				activity, ok := obj.(*v1.PipelineActivity)
+				if ok && activities[activity.Name] != nil {
+					delete(activities, activity.Name)
+					delete(yamlSpecMap, activity.Name)
+					o.renderTimeline(table, activities)
+				}
 			},
 		},
 	)
@@ -176,12 +198,15 @@ func (o *GetActivityOptions) WatchActivities(table *tbl.Table, jxClient versione
 	select {}
 }
 
-func (o *GetActivityOptions) onActivity(table *tbl.Table, obj interface{}, yamlSpecMap map[string]string) {
+func (o *GetActivityOptions) onActivity(table *tbl.Table, obj interface{}, yamlSpecMap map[string]string, activities map[string]*v1.PipelineActivity) {
 	activity, ok := obj.(*v1.PipelineActivity)
 	if !ok {
 		log.Infof("Object is not a PipelineActivity %#v\n", obj)
 		return
 	}
+	if !o.matches(activity) {
+		return
+	}
 	data, err := yaml.Marshal(&activity.Spec)
 	if err != nil {
 		log.Infof("Failed to marshal Activity.Spec to YAML: %s", err)
@@ -191,14 +216,29 @@ func (o *GetActivityOptions) onActivity(table *tbl.Table, obj interface{}, yamlS
 		old := yamlSpecMap[name]
 		if old == "" || old != text {
 			yamlSpecMap[name] = text
-			if o.addTableRow(table, activity) {
-				table.Render()
-				table.Clear()
-			}
+			activities[name] = activity
+			o.renderTimeline(table, activities)
 		}
 	}
 }
 
+// renderTimeline clears the terminal and renders the timeline of all the activities like a dashboard
+func (o *GetActivityOptions) renderTimeline(table *tbl.Table, activities map[string]*v1.PipelineActivity) {
+	list := []v1.PipelineActivity{}
+	for _, activity := range activities {
+		list = append(list, *activity)
+	}
+	SortPipelineActivities(list)
+
+	fmt.Fprint(o.Out, clearScreen)
+	table.AddRow("STEP", "STARTED AGO", "DURATION", "STATUS")
+	for i := range list {
+		o.addTableRow(table, &list[i])
+	}
+	table.Render()
+	table.Clear()
+}
+
 func (o *CommonOptions) addStepRow(table *tbl.Table, parent *v1.PipelineActivityStep, indent string) {
 	stage := parent.Stage
 	preview := parent.Preview
@@ -255,7 +295,7 @@ func addPromoteRow(table *tbl.Table, parent *v1.PromoteActivityStep, indent stri
 	}
 	appURL := parent.ApplicationURL
 	if appURL != "" {
-		addStepRowItem(table, &update.CoreActivityStep, indent, "Promoted", " Application is at: "+util.ColorInfo(appURL))
+		addStepRowItem(table, &parent.CoreActivityStep, indent, "Promoted", " Application is at: "+util.ColorInfo(appURL))
 	}
 }
 
@@ -276,9 +316,14 @@ func addStepRowItem(table *tbl.Table, step *v1.CoreActivityStep, indent string,
 			textName = name + ":" + textName
 		}
 	}
+	duration := durationString(step.StartedTimestamp, step.CompletedTimestamp)
+	if duration == "" && step.Status == v1.ActivityStatusTypeRunning {
+		// lets show how long a running step has been running for
+		duration = timeToString(step.StartedTimestamp)
+	}
 	table.AddRow(indent+textName,
 		timeToString(step.StartedTimestamp),
-		durationString(step.StartedTimestamp, step.CompletedTimestamp),
+		duration,
 		statusString(step.Status)+" "+text)
 }
 
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func timelineActivity(pipeline string, build string, status v1.ActivityStatusType, started time.Time, completed *time.Time) *v1.PipelineActivity {
+	activity := &v1.PipelineActivity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.Replace(pipeline, "/", "-", -1) + "-" + build,
+		},
+		Spec: v1.PipelineActivitySpec{
+			Pipeline:         pipeline,
+			Build:            build,
+			Status:           status,
+			StartedTimestamp: &metav1.Time{Time: started},
+		},
+	}
+	if completed != nil {
+		activity.Spec.CompletedTimestamp = &metav1.Time{Time: *completed}
+	}
+	return activity
+}
+
+func TestGetActivityAddTableRow(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	completed := now.Add(-time.Minute)
+	succeeded := timelineActivity("myorg/myapp/master", "1", v1.ActivityStatusTypeSucceeded, completed.Add(-90*time.Second), &completed)
+	succeeded.Spec.Version = "0.0.1"
+	succeeded.Spec.Steps = []v1.PipelineActivityStep{
+		{
+			Kind: v1.ActivityStepKindTypePromote,
+			Promote: &v1.PromoteActivityStep{
+				CoreActivityStep: v1.CoreActivityStep{
+					Status:             v1.ActivityStatusTypeSucceeded,
+					StartedTimestamp:   &metav1.Time{Time: completed.Add(-30 * time.Second)},
+					CompletedTimestamp: &metav1.Time{Time: completed},
+				},
+				Environment:    "staging",
+				ApplicationURL: "http://myapp.jx-staging.1.2.3.4.nip.io",
+			},
+		},
+	}
+	running := timelineActivity("myorg/myapp/master", "2", v1.ActivityStatusTypeRunning, now.Add(-2*time.Minute), nil)
+
+	o := &GetActivityOptions{}
+	tbl := table.CreateTable(&bytes.Buffer{})
+	require.True(t, o.addTableRow(&tbl, succeeded))
+	require.Len(t, tbl.Rows, 3)
+	assert.Equal(t, "myorg/myapp/master #1", tbl.Rows[0][0])
+	assert.Equal(t, "1m30s", tbl.Rows[0][2])
+	assert.Contains(t, tbl.Rows[0][3], "Version: ")
+	assert.Equal(t, indentation+"Promote: staging", tbl.Rows[1][0])
+	assert.Equal(t, "30s", tbl.Rows[1][2])
+	assert.Equal(t, indentation+indentation+"Promoted", tbl.Rows[2][0])
+	assert.Equal(t, "30s", tbl.Rows[2][2], "the promoted row should use the timestamps of the promotion")
+	assert.Contains(t, tbl.Rows[2][3], "http://myapp.jx-staging.1.2.3.4.nip.io")
+
+	tbl.Clear()
+	require.True(t, o.addTableRow(&tbl, running))
+	require.Len(t, tbl.Rows, 1)
+	assert.True(t, strings.HasPrefix(tbl.Rows[0][2], "2m"), "the duration of a running build should be how long it has been running for but was %s", tbl.Rows[0][2])
+
+	tbl.Clear()
+	o.BuildNumber = "3"
+	assert.False(t, o.addTableRow(&tbl, running))
+	assert.Empty(t, tbl.Rows)
+}
+
+func TestGetActivityOnActivityRendersSortedTimeline(t *testing.T) {
+	t.Parallel()
+
+	out := &bytes.Buffer{}
+	o := &GetActivityOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Out: out,
+			},
+		},
+		Filter: "myorg",
+	}
+	tbl := table.CreateTable(out)
+	yamlSpecMap := map[string]string{}
+	activities := map[string]*v1.PipelineActivity{}
+
+	now := time.Now()
+	o.onActivity(&tbl, timelineActivity("myorg/myapp/master", "1", v1.ActivityStatusTypeSucceeded, now, &now), yamlSpecMap, activities)
+	o.onActivity(&tbl, timelineActivity("myorg/myapp/master", "2", v1.ActivityStatusTypeRunning, now, nil), yamlSpecMap, activities)
+	o.onActivity(&tbl, timelineActivity("myorg/another/master", "1", v1.ActivityStatusTypeRunning, now, nil), yamlSpecMap, activities)
+	o.onActivity(&tbl, timelineActivity("other/myapp/master", "1", v1.ActivityStatusTypeRunning, now, nil), yamlSpecMap, activities)
+	o.onActivity(&tbl, "not an activity", yamlSpecMap, activities)
+
+	assert.Len(t, activities, 3, "activities not matching the filter should be ignored")
+	assert.Empty(t, tbl.Rows, "the rows should be cleared after rendering")
+
+	renders := strings.Split(out.String(), clearScreen)
+	require.Len(t, renders, 4, "the timeline should be rendered for each matching activity")
+	last := renders[3]
+	another := strings.Index(last, "myorg/another/master #1")
+	build2 := strings.Index(last, "myorg/myapp/master #2")
+	build1 := strings.Index(last, "myorg/myapp/master #1")
+	assert.True(t, another >= 0 && another < build2 && build2 < build1, "the timeline should be sorted by pipeline and latest build first:\n%s", last)
+
+	out.Reset()
+	o.onActivity(&tbl, timelineActivity("myorg/myapp/master", "2", v1.ActivityStatusTypeRunning, now, nil), yamlSpecMap, activities)
+	assert.Empty(t, out.String(), "an unchanged activity should not render the timeline")
+
+	o.onActivity(&tbl, timelineActivity("myorg/myapp/master", "2", v1.ActivityStatusTypeSucceeded, now, &now), yamlSpecMap, activities)
+	assert.Contains(t, out.String(), clearScreen)
+	assert.Equal(t, v1.ActivityStatusTypeSucceeded, activities["myorg-myapp-master-2"].Spec.Status)
+}
@@ -8,9 +8,8 @@ import (
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
-	"github.com/jenkins-x/jx/pkg/kube"
-	"github.com/jenkins-x/jx/pkg/util"
 )
 
 // GetAddonOptions the command line options
@@ -20,7 +19,9 @@ type GetAddonOptions struct {
 
 var (
 	get_addon_long = templates.LongDesc(`
-		Display the available addons
+		Display the available addons along with any custom addons declared in the ~/.jx/addon-registry.yml file.
+
+		Installed addons are recorded in the team settings so that they are preserved when upgrading.
 
 `)
 
@@ -57,6 +58,7 @@ func NewCmdGetAddon(f Factory, in terminal.FileReader, out terminal.FileWriter,
 		},
 	}
 
+	options.addGetFlags(cmd)
 	return cmd
 }
 
@@ -82,21 +84,32 @@ func (o *GetAddonOptions) Run() error {
 		log.Warnf("Failed to find Helm installs: %s\n", err)
 	}
 
-	charts := kube.AddonCharts
+	registry, err := o.addonRegistry()
+	if err != nil {
+		return err
+	}
+	teamSettings, err := o.TeamSettings()
+	if err != nil {
+		log.Warnf("Failed to load the team settings: %s\n", err)
+		teamSettings = &v1.TeamSettings{}
+	}
 
 	table := o.CreateTable()
-	table.AddRow("NAME", "CHART", "ENABLED", "STATUS", "VERSION")
+	table.AddRow("NAME", "CHART", "ENABLED", "STATUS", "VERSION", "INSTALLED", "DESCRIPTION")
 
-	keys := util.SortedMapKeys(charts)
-	for _, k := range keys {
-		chart := charts[k]
+	for _, k := range registry.Names() {
+		a := registry.Get(k)
 		status := statusMap[k].Status
 		version := statusMap[k].Version
 		enableText := ""
 		if addonEnabled[k] {
 			enableText = "yes"
 		}
-		table.AddRow(k, chart, enableText, status, version)
+		installedText := ""
+		if teamSettings.Addon(k) != nil {
+			installedText = "yes"
+		}
+		table.AddRow(k, a.Chart, enableText, status, version, installedText, a.Description)
 	}
 
 	table.Render()
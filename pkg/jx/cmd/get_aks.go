@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/cloud/aks"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+// GetAksOptions the command line options
+type GetAksOptions struct {
+	GetOptions
+}
+
+var (
+	getAksLong = templates.LongDesc(`
+		Display the AKS clusters of the current Azure subscription.
+
+		All the clusters of the subscription are listed, not just the ones created by jx, along with their version,
+		number of nodes and the development namespaces of Jenkins X if it is installed on them. Jenkins X can only
+		be detected on the clusters with a context in your kube config.
+`)
+
+	getAksExample = templates.Examples(`
+		# List the AKS clusters
+		jx get aks
+
+		# Install Jenkins X on one of them
+		jx install --provider aks --cluster mycluster
+	`)
+)
+
+// NewCmdGetAks creates the command
+func NewCmdGetAks(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &GetAksOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "aks",
+		Short:   "List the AKS clusters",
+		Long:    getAksLong,
+		Example: getAksExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addGetFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetAksOptions) Run() error {
+	aksClusters, err := aks.NewAzureRunner().ListClusters()
+	if err != nil {
+		return err
+	}
+	clusters := []CloudCluster{}
+	for _, c := range aksClusters {
+		clusters = append(clusters, CloudCluster{
+			Name:     c.Name,
+			Location: c.Location,
+			Version:  c.KubernetesVersion,
+			Nodes:    c.NodeCount(),
+			Status:   c.ProvisioningState,
+		})
+	}
+	return o.renderCloudClusters(clusters)
+}
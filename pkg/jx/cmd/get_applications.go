@@ -1,15 +1,19 @@
 package cmd
 
 import (
-	"github.com/jenkins-x/jx/pkg/kube/services"
-	"github.com/jenkins-x/jx/pkg/table"
-	"github.com/pkg/errors"
+	"encoding/base64"
+	"fmt"
 	"io"
-	"k8s.io/client-go/kubernetes"
 	"os/user"
 	"sort"
 	"strings"
 
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/kube/services"
+	"github.com/jenkins-x/jx/pkg/table"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
+
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 
@@ -24,13 +28,14 @@ import (
 
 // GetApplicationsOptions containers the CLI options
 type GetApplicationsOptions struct {
-	CommonOptions
+	GetOptions
 
 	Namespace   string
 	Environment string
 	HideUrl     bool
 	HidePod     bool
 	Previews    bool
+	GitOps      bool
 
 	Results GetApplicationsResults
 }
@@ -52,15 +57,36 @@ type EnvApps struct {
 
 // ApplicationEnvironmentInfo contains the results of an app for an environment
 type ApplicationEnvironmentInfo struct {
-	Deployment  *v1beta1.Deployment
-	Environment *v1.Environment
-	Version     string
-	URL         string
+	Deployment    *v1beta1.Deployment
+	Environment   *v1.Environment
+	Version       string
+	GitOpsVersion string
+	URL           string
+}
+
+// ApplicationSummary is the output format of an application across environments
+type ApplicationSummary struct {
+	Name         string                                    `json:"name"`
+	Environments map[string]*ApplicationEnvironmentSummary `json:"environments"`
+}
+
+// ApplicationEnvironmentSummary is the output format of an application in an environment
+type ApplicationEnvironmentSummary struct {
+	Namespace     string `json:"namespace"`
+	Version       string `json:"version,omitempty"`
+	GitOpsVersion string `json:"gitOpsVersion,omitempty"`
+	DesiredPods   int32  `json:"desiredPods"`
+	ReadyPods     int32  `json:"readyPods"`
+	Healthy       bool   `json:"healthy"`
+	URL           string `json:"url,omitempty"`
 }
 
 var (
 	get_version_long = templates.LongDesc(`
 		Display applications across environments.
+
+		For each environment the deployed version, the number of ready pods and the URL of the application are shown.
+		Use '--gitops' to also compare the deployed versions with the versions in the environment git repositories.
 `)
 
 	get_version_example = templates.Examples(`
@@ -81,17 +107,25 @@ var (
 
 		# List applications just showing the versions (hiding urls and pod counts)
 		jx get apps -u -p
+
+		# List applications along with the versions in the environment git repositories which are not deployed yet
+		jx get apps --gitops
+
+		# Output the applications as JSON for use in dashboards
+		jx get apps -o json
 	`)
 )
 
 // NewCmdGetApplications creates the new command for: jx get version
 func NewCmdGetApplications(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
 	options := &GetApplicationsOptions{
-		CommonOptions: CommonOptions{
-			Factory: f,
-			In:      in,
-			Out:     out,
-			Err:     errOut,
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
 		},
 	}
 	cmd := &cobra.Command{
@@ -112,6 +146,8 @@ func NewCmdGetApplications(f Factory, in terminal.FileReader, out terminal.FileW
 	cmd.Flags().BoolVarP(&options.Previews, "preview", "w", false, "Show preview environments only")
 	cmd.Flags().StringVarP(&options.Environment, "env", "e", "", "Filter applications in the given environment")
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "Filter applications in the given namespace")
+	cmd.Flags().BoolVarP(&options.GitOps, "gitops", "", false, "Compare the deployed versions with the versions in the environment git repositories")
+	options.addGetFlags(cmd)
 	return cmd
 }
 
@@ -129,6 +165,9 @@ func (o *GetApplicationsOptions) Run() error {
 
 	util.ReverseStrings(namespaces)
 	if len(apps) == 0 {
+		if o.outputObjects() {
+			return o.renderResult([]ApplicationSummary{}, o.Output)
+		}
 		log.Infof("No applications found in environments %s\n", strings.Join(envNames, ", "))
 		return nil
 	}
@@ -137,68 +176,186 @@ func (o *GetApplicationsOptions) Run() error {
 	o.Results.EnvApps = envApps
 	o.Results.EnvNames = envNames
 
-	table := o.generateTable(apps, envApps, kubeClient)
+	gitOpsVersions := map[string]map[string]string{}
+	if o.GitOps {
+		gitOpsVersions = o.getGitOpsVersions(envApps)
+	}
+
+	table := o.generateTable(apps, envApps, gitOpsVersions, kubeClient)
+	if o.outputObjects() {
+		return o.renderResult(o.Results.Summaries(apps), o.Output)
+	}
 
 	table.Render()
 	return nil
 }
 
-func (o *GetApplicationsOptions) generateTable(apps []string, envApps []EnvApps, kubeClient kubernetes.Interface) table.Table {
+// Summaries returns the summaries of the given applications for rendering as JSON or YAML
+func (r *GetApplicationsResults) Summaries(apps []string) []ApplicationSummary {
+	answer := []ApplicationSummary{}
+	for _, appName := range apps {
+		summary := ApplicationSummary{
+			Name:         appName,
+			Environments: map[string]*ApplicationEnvironmentSummary{},
+		}
+		for envName, info := range r.Applications[appName] {
+			envSummary := &ApplicationEnvironmentSummary{
+				Version:       info.Version,
+				GitOpsVersion: info.GitOpsVersion,
+				URL:           info.URL,
+			}
+			if info.Environment != nil {
+				envSummary.Namespace = info.Environment.Spec.Namespace
+			}
+			if info.Deployment != nil {
+				envSummary.DesiredPods, envSummary.ReadyPods = deploymentPods(info.Deployment)
+				envSummary.Healthy = envSummary.DesiredPods > 0 && envSummary.ReadyPods >= envSummary.DesiredPods
+			}
+			summary.Environments[envName] = envSummary
+		}
+		answer = append(answer, summary)
+	}
+	return answer
+}
+
+// deploymentPods returns the desired and ready replicas of the deployment
+func deploymentPods(d *v1beta1.Deployment) (int32, int32) {
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+	return desired, d.Status.ReadyReplicas
+}
+
+// getGitOpsVersions returns the versions of the applications in the requirements of the environment git
+// repositories indexed by the environment name then the application name
+func (o *GetApplicationsOptions) getGitOpsVersions(envApps []EnvApps) map[string]map[string]string {
+	answer := map[string]map[string]string{}
+	for _, ea := range envApps {
+		env := ea.Environment
+		if env.Spec.Kind != v1.EnvironmentKindTypePermanent || env.Spec.Source.URL == "" {
+			continue
+		}
+		versions, err := o.getEnvironmentRequirementVersions(&env)
+		if err != nil {
+			log.Warnf("Failed to load the requirements of environment %s from %s: %s\n", env.Name, env.Spec.Source.URL, err)
+			continue
+		}
+		answer[env.Name] = versions
+	}
+	return answer
+}
+
+// getEnvironmentRequirementVersions returns the versions of the applications in the requirements of the environment's
+// git repository indexed by the application name or alias
+func (o *CommonOptions) getEnvironmentRequirementVersions(env *v1.Environment) (map[string]string, error) {
+	data, err := o.getEnvironmentSourceFile(env, "env/"+helm.RequirementsFileName)
+	if err != nil {
+		return nil, err
+	}
+	requirements, err := helm.LoadRequirements(data)
+	if err != nil {
+		return nil, err
+	}
+	answer := map[string]string{}
+	for _, dep := range requirements.Dependencies {
+		name := dep.Name
+		if dep.Alias != "" {
+			name = dep.Alias
+		}
+		answer[name] = dep.Version
+	}
+	return answer, nil
+}
+
+// getEnvironmentSourceFile returns the content of the file at the given path in the environment's git repository
+func (o *CommonOptions) getEnvironmentSourceFile(env *v1.Environment, path string) ([]byte, error) {
+	gitProvider, gitInfo, err := o.createGitProviderForURLWithoutKind(env.Spec.Source.URL)
+	if err != nil {
+		return nil, err
+	}
+	ref := env.Spec.Source.Ref
+	if ref == "" {
+		ref = "master"
+	}
+	content, err := gitProvider.GetContent(gitInfo.Organisation, gitInfo.Name, path, ref)
+	if err != nil {
+		return nil, err
+	}
+	if content == nil {
+		return nil, fmt.Errorf("no content returned for %s", path)
+	}
+	return base64.StdEncoding.DecodeString(content.Content)
+}
+
+func (o *GetApplicationsOptions) generateTable(apps []string, envApps []EnvApps, gitOpsVersions map[string]map[string]string, kubeClient kubernetes.Interface) table.Table {
 	table := o.generateTableHeaders(envApps)
 
 	appEnvMap := map[string]map[string]*ApplicationEnvironmentInfo{}
 	for _, appName := range apps {
 		row := []string{appName}
 		for _, ea := range envApps {
-			version := ""
+			gitOpsVersion := gitOpsVersions[ea.Environment.Name][appName]
 			d, ok := ea.Apps[appName]
-			if ok {
-				appMap := appEnvMap[appName]
-				if appMap == nil {
-					appMap = map[string]*ApplicationEnvironmentInfo{}
-					appEnvMap[appName] = appMap
-				}
-				version = kube.GetVersion(&d.ObjectMeta)
-				appEnvInfo := &ApplicationEnvironmentInfo{
-					Deployment:  &d,
-					Environment: &ea.Environment,
-					Version:     version,
-				}
-				appMap[ea.Environment.Name] = appEnvInfo
+			if !ok {
+				// lets keep the columns aligned for apps which are not deployed in this environment
 				if ea.Environment.Spec.Kind != v1.EnvironmentKindTypePreview {
-					row = append(row, version)
+					row = append(row, versionCell("", gitOpsVersion))
 				}
 				if !o.HidePod {
-					pods := ""
-					replicas := ""
-					ready := d.Status.ReadyReplicas
-					if d.Spec.Replicas != nil && ready > 0 {
-						replicas = formatInt32(*d.Spec.Replicas)
-						pods = formatInt32(ready) + "/" + replicas
-					}
-					row = append(row, pods)
+					row = append(row, "")
 				}
 				if !o.HideUrl {
-					url, _ := services.FindServiceURL(kubeClient, d.Namespace, appName)
-					if url == "" {
-						url, _ = services.FindServiceURL(kubeClient, d.Namespace, d.Name)
-					}
-					if url == "" {
-						// handle helm3
-						chart, ok := d.Labels["chart"]
-						if ok {
-							idx := strings.LastIndex(chart, "-")
-							if idx > 0 {
-								svcName := chart[0:idx]
-								if svcName != appName && svcName != d.Name {
-									url, _ = services.FindServiceURL(kubeClient, d.Namespace, svcName)
-								}
+					row = append(row, "")
+				}
+				continue
+			}
+			appMap := appEnvMap[appName]
+			if appMap == nil {
+				appMap = map[string]*ApplicationEnvironmentInfo{}
+				appEnvMap[appName] = appMap
+			}
+			version := kube.GetVersion(&d.ObjectMeta)
+			appEnvInfo := &ApplicationEnvironmentInfo{
+				Deployment:    &d,
+				Environment:   &ea.Environment,
+				Version:       version,
+				GitOpsVersion: gitOpsVersion,
+			}
+			appMap[ea.Environment.Name] = appEnvInfo
+			if ea.Environment.Spec.Kind != v1.EnvironmentKindTypePreview {
+				row = append(row, versionCell(version, gitOpsVersion))
+			}
+			if !o.HidePod {
+				pods := ""
+				replicas := ""
+				ready := d.Status.ReadyReplicas
+				if d.Spec.Replicas != nil && ready > 0 {
+					replicas = formatInt32(*d.Spec.Replicas)
+					pods = formatInt32(ready) + "/" + replicas
+				}
+				row = append(row, pods)
+			}
+			if !o.HideUrl {
+				url, _ := services.FindServiceURL(kubeClient, d.Namespace, appName)
+				if url == "" {
+					url, _ = services.FindServiceURL(kubeClient, d.Namespace, d.Name)
+				}
+				if url == "" {
+					// handle helm3
+					chart, ok := d.Labels["chart"]
+					if ok {
+						idx := strings.LastIndex(chart, "-")
+						if idx > 0 {
+							svcName := chart[0:idx]
+							if svcName != appName && svcName != d.Name {
+								url, _ = services.FindServiceURL(kubeClient, d.Namespace, svcName)
 							}
 						}
 					}
-					row = append(row, url)
-					appEnvInfo.URL = url
 				}
+				row = append(row, url)
+				appEnvInfo.URL = url
 			}
 		}
 		table.AddRow(row...)
@@ -207,6 +364,15 @@ func (o *GetApplicationsOptions) generateTable(apps []string, envApps []EnvApps,
 	return table
 }
 
+// versionCell returns the deployed version highlighting any different version in the environment git repository
+// which has not been deployed yet
+func versionCell(version string, gitOpsVersion string) string {
+	if gitOpsVersion == "" || gitOpsVersion == version {
+		return version
+	}
+	return fmt.Sprintf("%s (%s pending)", version, util.ColorWarning(gitOpsVersion))
+}
+
 func (o *GetApplicationsOptions) getAppData(kubeClient kubernetes.Interface) (namespaces []string, envApps []EnvApps, envNames, apps []string, err error) {
 	f := o.Factory
 	client, currentNs, err := f.CreateJXClient()
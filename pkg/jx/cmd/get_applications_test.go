@@ -0,0 +1,77 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/api/apps/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetApplicationsSummaries(t *testing.T) {
+	t.Parallel()
+
+	replicas := int32(2)
+	staging := &v1.Environment{
+		ObjectMeta: metav1.ObjectMeta{Name: "staging"},
+		Spec:       v1.EnvironmentSpec{Namespace: "jx-staging"},
+	}
+	production := &v1.Environment{
+		ObjectMeta: metav1.ObjectMeta{Name: "production"},
+		Spec:       v1.EnvironmentSpec{Namespace: "jx-production"},
+	}
+	results := cmd.GetApplicationsResults{
+		Applications: map[string]map[string]*cmd.ApplicationEnvironmentInfo{
+			"myapp": {
+				"staging": {
+					Deployment: &v1beta1.Deployment{
+						Spec:   v1beta1.DeploymentSpec{Replicas: &replicas},
+						Status: v1beta1.DeploymentStatus{ReadyReplicas: 2},
+					},
+					Environment:   staging,
+					Version:       "1.0.2",
+					GitOpsVersion: "1.0.2",
+					URL:           "http://myapp.jx-staging.example.com",
+				},
+				"production": {
+					Deployment: &v1beta1.Deployment{
+						Spec:   v1beta1.DeploymentSpec{Replicas: &replicas},
+						Status: v1beta1.DeploymentStatus{ReadyReplicas: 1},
+					},
+					Environment:   production,
+					Version:       "1.0.1",
+					GitOpsVersion: "1.0.2",
+				},
+			},
+		},
+	}
+
+	summaries := results.Summaries([]string{"myapp", "other"})
+	assert.Len(t, summaries, 2)
+
+	app := summaries[0]
+	assert.Equal(t, "myapp", app.Name)
+	assert.Len(t, app.Environments, 2)
+
+	s := app.Environments["staging"]
+	if assert.NotNil(t, s) {
+		assert.Equal(t, "jx-staging", s.Namespace)
+		assert.Equal(t, "1.0.2", s.Version)
+		assert.Equal(t, int32(2), s.DesiredPods)
+		assert.Equal(t, int32(2), s.ReadyPods)
+		assert.True(t, s.Healthy)
+		assert.Equal(t, "http://myapp.jx-staging.example.com", s.URL)
+	}
+
+	p := app.Environments["production"]
+	if assert.NotNil(t, p) {
+		assert.Equal(t, "1.0.1", p.Version)
+		assert.Equal(t, "1.0.2", p.GitOpsVersion)
+		assert.False(t, p.Healthy)
+	}
+
+	assert.Equal(t, "other", summaries[1].Name)
+	assert.Empty(t, summaries[1].Environments)
+}
@@ -2,53 +2,94 @@ package cmd
 
 import (
 	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/builds"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/table"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
-
-	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/cache"
 )
 
 // GetBuildOptions the command line options
 type GetBuildOptions struct {
-	CommonOptions
+	GetOptions
 
-	Output string
+	BuildFilter builds.BuildPodInfoFilter
+	Status      string
+	Trigger     string
+}
+
+// BuildSummary summarises a build of a pipeline
+type BuildSummary struct {
+	Name          string       `json:"name"`
+	Pipeline      string       `json:"pipeline"`
+	Build         string       `json:"build"`
+	Status        string       `json:"status"`
+	Trigger       string       `json:"trigger"`
+	Started       *metav1.Time `json:"started,omitempty"`
+	Duration      string       `json:"duration,omitempty"`
+	CommitSHA     string       `json:"commitSHA,omitempty"`
+	CommitMessage string       `json:"commitMessage,omitempty"`
+	CommitURL     string       `json:"commitURL,omitempty"`
+	BuildLogsURL  string       `json:"buildLogsURL,omitempty"`
 }
 
 var (
 	get_build_long = templates.LongDesc(`
-		Display one or more resources.
+		Display the recent builds of the pipelines with their status, duration, trigger and commit.
 
-		` + valid_resources + `
+		The builds can be filtered by owner, repository, branch, status or trigger (push, pr or tag).
+		Use --watch to keep updating the list as builds start and complete.
 
 `)
 
 	get_build_example = templates.Examples(`
-		# List all pipelines
-		jx get pipeline
+		# List all the builds
+		jx get builds
+
+		# List the failed builds of the repo cheese
+		jx get builds --repo cheese --status failed
 
-		# List all URLs for services in the current namespace
-		jx get url
+		# List the Pull Request builds and watch for changes
+		jx get builds --trigger pr -w
+
+		# Output the builds of the master branch as JSON
+		jx get builds --branch master -o json
 	`)
 )
 
 // NewCmdGetBuild creates the command object
 func NewCmdGetBuild(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
 	options := &GetBuildOptions{
-		CommonOptions: CommonOptions{
-			Factory: f,
-			In:      in,
-			Out:     out,
-			Err:     errOut,
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+			customWatch: true,
 		},
 	}
 
 	cmd := &cobra.Command{
 		Use:     "build [flags]",
-		Short:   "Display one or more build resources",
+		Short:   "Display the recent builds of the pipelines",
 		Long:    get_build_long,
 		Example: get_build_example,
+		Aliases: []string{"builds"},
 		Run: func(cmd *cobra.Command, args []string) {
 			options.Cmd = cmd
 			options.Args = args
@@ -60,10 +101,180 @@ func NewCmdGetBuild(f Factory, in terminal.FileReader, out terminal.FileWriter,
 
 	cmd.AddCommand(NewCmdGetBuildLogs(f, in, out, errOut))
 	cmd.AddCommand(NewCmdGetBuildPods(f, in, out, errOut))
+
+	options.addGetFlags(cmd)
+	cmd.Flags().StringVarP(&options.BuildFilter.Filter, "filter", "f", "", "Filters the builds by those whose name contains the given text")
+	cmd.Flags().StringVarP(&options.BuildFilter.Owner, "owner", "", "", "Filters the owner (person/organisation) of the repository")
+	cmd.Flags().StringVarP(&options.BuildFilter.Repository, "repo", "r", "", "Filters the build repository")
+	cmd.Flags().StringVarP(&options.BuildFilter.Branch, "branch", "", "", "Filters the branch")
+	cmd.Flags().StringVarP(&options.BuildFilter.Build, "build", "b", "", "Filters the build number")
+	cmd.Flags().StringVarP(&options.Status, "status", "s", "", "Filters the build status such as Running, Succeeded or Failed")
+	cmd.Flags().StringVarP(&options.Trigger, "trigger", "t", "", "Filters the trigger of the build: push, pr or tag")
 	return cmd
 }
 
 // Run implements this command
 func (o *GetBuildOptions) Run() error {
-	return o.Cmd.Help()
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	if o.Watch {
+		return o.watchBuilds(jxClient, ns)
+	}
+
+	list, err := jxClient.JenkinsV1().PipelineActivities(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	activities := []v1.PipelineActivity{}
+	for _, activity := range list.Items {
+		if o.matches(&activity) {
+			activities = append(activities, activity)
+		}
+	}
+	SortPipelineActivities(activities)
+
+	if o.outputObjects() {
+		summaries := []BuildSummary{}
+		for i := range activities {
+			summaries = append(summaries, CreateBuildSummary(&activities[i]))
+		}
+		return o.renderResult(summaries, o.Output)
+	}
+	if len(activities) == 0 {
+		return outputEmptyListWarning(o.Out)
+	}
+
+	table := o.createBuildTable()
+	for i := range activities {
+		o.addBuildRow(&table, &activities[i])
+	}
+	table.Render()
+	return nil
+}
+
+func (o *GetBuildOptions) matches(activity *v1.PipelineActivity) bool {
+	if !o.BuildFilter.ActivityMatches(activity) {
+		return false
+	}
+	if o.Status != "" && !strings.EqualFold(o.Status, string(activityStatus(activity))) {
+		return false
+	}
+	if o.Trigger != "" && !strings.EqualFold(o.Trigger, kube.CreatePipelineDetails(activity).Trigger) {
+		return false
+	}
+	return true
+}
+
+func (o *GetBuildOptions) createBuildTable() table.Table {
+	table := o.CreateTable()
+	table.AddRow("PIPELINE", "BUILD", "STATUS", "TRIGGER", "STARTED AGO", "DURATION", "COMMIT")
+	return table
+}
+
+func (o *GetBuildOptions) addBuildRow(table *table.Table, activity *v1.PipelineActivity) {
+	summary := CreateBuildSummary(activity)
+	commit := summary.CommitSHA
+	if len(commit) > 7 {
+		commit = commit[0:7]
+	}
+	if summary.CommitMessage != "" {
+		commit += " " + summary.CommitMessage
+	}
+	table.AddRow(summary.Pipeline, "#"+summary.Build, statusString(activityStatus(activity)), summary.Trigger, timeToString(summary.Started), summary.Duration, commit)
+}
+
+func (o *GetBuildOptions) watchBuilds(jxClient versioned.Interface, ns string) error {
+	table := o.createBuildTable()
+	table.Render()
+	table.Clear()
+
+	yamlSpecMap := map[string]string{}
+	onBuild := func(obj interface{}) {
+		activity, ok := obj.(*v1.PipelineActivity)
+		if !ok || !o.matches(activity) {
+			return
+		}
+		data, err := yaml.Marshal(&activity.Spec)
+		if err != nil {
+			log.Warnf("Failed to marshal the spec of PipelineActivity %s to YAML: %s\n", activity.Name, err)
+			return
+		}
+		text := string(data)
+		if yamlSpecMap[activity.Name] == text {
+			return
+		}
+		yamlSpecMap[activity.Name] = text
+		o.addBuildRow(&table, activity)
+		table.Render()
+		table.Clear()
+	}
+
+	listWatch := cache.NewListWatchFromClient(jxClient.JenkinsV1().RESTClient(), "pipelineactivities", ns, fields.Everything())
+	kube.SortListWatchByName(listWatch)
+	_, controller := cache.NewInformer(
+		listWatch,
+		&v1.PipelineActivity{},
+		time.Minute*10,
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: onBuild,
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				onBuild(newObj)
+			},
+			DeleteFunc: func(obj interface{}) {
+			},
+		},
+	)
+
+	stop := make(chan struct{})
+	go controller.Run(stop)
+
+	// Wait forever
+	select {}
+}
+
+// CreateBuildSummary creates the summary of the build of the given pipeline activity
+func CreateBuildSummary(activity *v1.PipelineActivity) BuildSummary {
+	spec := &activity.Spec
+	details := kube.CreatePipelineDetails(activity)
+	duration := durationString(spec.StartedTimestamp, spec.CompletedTimestamp)
+	if duration == "" {
+		duration = timeToString(spec.StartedTimestamp)
+	}
+	return BuildSummary{
+		Name:          activity.Name,
+		Pipeline:      details.Pipeline,
+		Build:         spec.Build,
+		Status:        string(activityStatus(activity)),
+		Trigger:       details.Trigger,
+		Started:       spec.StartedTimestamp,
+		Duration:      duration,
+		CommitSHA:     spec.LastCommitSHA,
+		CommitMessage: strings.TrimSpace(strings.SplitN(spec.LastCommitMessage, "\n", 2)[0]),
+		CommitURL:     spec.LastCommitURL,
+		BuildLogsURL:  spec.BuildLogsURL,
+	}
+}
+
+// SortPipelineActivities sorts the activities by pipeline name then by build number with the latest build first
+func SortPipelineActivities(activities []v1.PipelineActivity) {
+	sort.Slice(activities, func(i, j int) bool {
+		p1 := activities[i].Spec.Pipeline
+		p2 := activities[j].Spec.Pipeline
+		if p1 != p2 {
+			return p1 < p2
+		}
+		b1, _ := strconv.Atoi(activities[i].Spec.Build)
+		b2, _ := strconv.Atoi(activities[j].Spec.Build)
+		return b1 > b2
+	})
+}
+
+// activityStatus returns the status of the activity defaulting to pending if it has not started yet
+func activityStatus(activity *v1.PipelineActivity) v1.ActivityStatusType {
+	if activity.Spec.Status == v1.ActivityStatusTypeNone {
+		return v1.ActivityStatusTypePending
+	}
+	return activity.Spec.Status
 }
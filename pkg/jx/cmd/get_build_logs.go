@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
 	"io"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,6 +14,7 @@ import (
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/builds"
 	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx/pkg/cloud/buckets"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
@@ -19,8 +23,10 @@ import (
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // GetBuildLogsOptions the command line options
@@ -29,13 +35,25 @@ type GetBuildLogsOptions struct {
 
 	Tail        bool
 	Wait        bool
+	Step        string
+	Previous    bool
+	NoColor     bool
 	BuildFilter builds.BuildPodInfoFilter
 }
 
+// ansiEscapeRegex matches the ANSI escape sequences build tools use to color their output
+var ansiEscapeRegex = regexp.MustCompile("\x1b\\[[0-9;]*[a-zA-Z]")
+
 var (
 	get_build_log_long = templates.LongDesc(`
 		Display a build log
 
+		If the pod of a knative build has been garbage collected the log is fetched from the storage location it was
+		archived to, such as a Google Cloud Storage, S3 or MinIO bucket configured via 'jx edit storage'. Use
+		--previous to view the archived log of the last completed build.
+
+		The log of each step is streamed as it runs, including the ANSI colors of the build tools unless --no-color
+		is used. Steps which restart and builds whose evicted pod is retried are followed until they complete.
 `)
 
 	get_build_log_example = templates.Examples(`
@@ -54,6 +72,15 @@ var (
 		# Pick a knative build for the 1234 Pull Request on the repo cheese
 		jx get build log --repo cheese --branch PR-1234
 
+		# Only display the log of the steps of a knative build which contain 'test'
+		jx get build log --repo cheese --step test
+
+		# Display the log of build 42 of the master branch of the repo cheese even if its pod has been removed
+		jx get build log --repo cheese --branch master --build 42
+
+		# Display the archived log of the last completed build of the master branch of the repo cheese
+		jx get build log --repo cheese --branch master --previous
+
 	`)
 )
 
@@ -92,6 +119,9 @@ func NewCmdGetBuildLogs(f Factory, in terminal.FileReader, out terminal.FileWrit
 	cmd.Flags().StringVarP(&options.BuildFilter.Repository, "repo", "r", "", "Filters the build repository")
 	cmd.Flags().StringVarP(&options.BuildFilter.Branch, "branch", "", "", "Filters the branch")
 	cmd.Flags().StringVarP(&options.BuildFilter.Build, "build", "b", "", "The build number to view")
+	cmd.Flags().StringVarP(&options.Step, "step", "s", "", "Only display the logs of the knative build steps whose name contains the given text")
+	cmd.Flags().BoolVarP(&options.Previous, "previous", "", false, "Display the archived log of the last completed build from the storage location of the team")
+	cmd.Flags().BoolVarP(&options.NoColor, "no-color", "", false, "Removes the ANSI colors of the build tools from the log")
 
 	return cmd
 }
@@ -205,6 +235,17 @@ func (o *GetBuildLogsOptions) getLastJenkinsBuild(name string, buildNumber int)
 }
 
 func (o *GetBuildLogsOptions) getProwBuildLog(kubeClient kubernetes.Interface, jxClient versioned.Interface, ns string) error {
+	err := registerPipelineSecrets(kubeClient, ns)
+	if err != nil {
+		log.Warnf("Failed to load the pipeline secrets so they will not be masked in the logs: %s\n", err)
+	}
+	if o.Previous {
+		found, err := o.getArchivedBuildLog(jxClient, ns)
+		if err == nil && !found {
+			err = fmt.Errorf("No archived build logs found which match the current filter!")
+		}
+		return err
+	}
 	pods, err := builds.GetBuildPods(kubeClient, ns)
 	if err != nil {
 		log.Warnf("Failed to query pods %s\n", err)
@@ -213,16 +254,20 @@ func (o *GetBuildLogsOptions) getProwBuildLog(kubeClient kubernetes.Interface, j
 
 	buildInfos := []*builds.BuildPodInfo{}
 	for _, pod := range pods {
-		initContainers := pod.Spec.InitContainers
-		if len(initContainers) > 0 {
-			buildInfo := builds.CreateBuildPodInfo(pod)
-			if o.BuildFilter.BuildMatches(buildInfo) {
-				buildInfos = append(buildInfos, buildInfo)
-			}
+		buildInfo := builds.CreateBuildPodInfo(pod)
+		if len(buildInfo.StepContainers("")) > 0 && o.BuildFilter.BuildMatches(buildInfo) {
+			buildInfos = append(buildInfos, buildInfo)
 		}
 	}
 	builds.SortBuildPodInfos(buildInfos)
 	if len(buildInfos) == 0 {
+		if o.BuildFilter.Build != "" {
+			// the pod may have been garbage collected so lets look for an archived log
+			found, err := o.getArchivedBuildLog(jxClient, ns)
+			if err != nil || found {
+				return err
+			}
+		}
 		return fmt.Errorf("No knative builds have been triggered which match the current filter!")
 	}
 
@@ -236,17 +281,25 @@ func (o *GetBuildLogsOptions) getProwBuildLog(kubeClient kubernetes.Interface, j
 		names = append(names, name)
 		buildMap[name] = build
 
-		if build.Branch == "master" {
+		if build.Branch == "master" && defaultName == "" {
 			defaultName = name
 		}
 	}
 
 	if len(args) == 0 {
-		name, err := util.PickNameWithDefault(names, "Which build do you want to view the logs of?: ", defaultName, "", o.In, o.Out, o.Err)
-		if err != nil {
-			return err
+		if o.BatchMode {
+			// lets default to the master build or else the latest build of the first pipeline matching the filter
+			if defaultName == "" {
+				defaultName = names[0]
+			}
+			args = []string{defaultName}
+		} else {
+			name, err := util.PickNameWithDefault(names, "Which build do you want to view the logs of?: ", defaultName, "", o.In, o.Out, o.Err)
+			if err != nil {
+				return err
+			}
+			args = []string{name}
 		}
-		args = []string{name}
 	}
 	if len(args) == 0 {
 		return fmt.Errorf("No pipeline chosen")
@@ -257,21 +310,215 @@ func (o *GetBuildLogsOptions) getProwBuildLog(kubeClient kubernetes.Interface, j
 		return fmt.Errorf("No Pipeline found for name %s", name)
 	}
 
-	pod := build.Pod
-	if pod == nil {
-		return fmt.Errorf("No Pod found for name %s", name)
+	log.Infof("Build logs for %s\n", util.ColorInfo(name))
+	for build != nil {
+		pod := build.Pod
+		if pod == nil {
+			return fmt.Errorf("No Pod found for name %s", name)
+		}
+		containers := build.StepContainers(o.Step)
+		if len(containers) == 0 {
+			if o.Step != "" {
+				return fmt.Errorf("No steps matching %s for Pod %s for build: %s", o.Step, pod.Name, name)
+			}
+			return fmt.Errorf("No build steps for Pod %s for build: %s", pod.Name, name)
+		}
+		for _, c := range containers {
+			err = o.getPodLog(kubeClient, ns, pod, c)
+			if err != nil {
+				return err
+			}
+		}
+		build, err = o.waitForRetriedBuild(kubeClient, ns, build)
+		if err != nil {
+			return err
+		}
 	}
-	initContainers := pod.Spec.InitContainers
-	if len(initContainers) <= 0 {
-		return fmt.Errorf("No InitContainers for Pod %s for build: %s", pod.Name, name)
+	return nil
+}
+
+// waitForRetriedBuild returns the build which retries the build if its pod was evicted or nil if it was not. The
+// controller retries the builds of evicted pods with the same build number
+func (o *GetBuildLogsOptions) waitForRetriedBuild(kubeClient kubernetes.Interface, ns string, build *builds.BuildPodInfo) (*builds.BuildPodInfo, error) {
+	pod, err := kubeClient.CoreV1().Pods(ns).Get(build.PodName, metav1.GetOptions{})
+	if err != nil || !kube.IsPodEvicted(pod) {
+		return nil, err
+	}
+	log.Infof("The pod %s of the build was evicted so waiting for the build to be retried\n", util.ColorInfo(pod.Name))
+	var answer *builds.BuildPodInfo
+	err = o.retryUntilTrueOrTimeout(time.Minute*5, time.Second*2, func() (bool, error) {
+		pods, err := builds.GetBuildPods(kubeClient, ns)
+		if err != nil {
+			return false, err
+		}
+		for _, p := range pods {
+			info := builds.CreateBuildPodInfo(p)
+			if info.Pipeline == build.Pipeline && info.Build == build.Build && info.PodName != build.PodName && p.CreationTimestamp.After(pod.CreationTimestamp.Time) {
+				answer = info
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "waiting for the build of the evicted pod %s to be retried", pod.Name)
 	}
+	log.Infof("Following the retried build pod %s\n", util.ColorInfo(answer.PodName))
+	return answer, nil
+}
 
-	lastInitC := initContainers[len(initContainers)-1]
-	log.Infof("Build logs for %s\n", util.ColorInfo(name))
-	return o.getPodLog(ns, pod, lastInitC)
+// registerPipelineSecrets registers the values of the pipeline secrets so that they are masked in the build logs
+func registerPipelineSecrets(kubeClient kubernetes.Interface, ns string) error {
+	list, err := kubeClient.CoreV1().Secrets(ns).List(metav1.ListOptions{
+		LabelSelector: kube.LabelPipelineSecret,
+	})
+	if err != nil {
+		return err
+	}
+	for _, secret := range list.Items {
+		for _, value := range secret.Data {
+			util.RegisterSecret(string(value))
+		}
+	}
+	return nil
 }
 
-func (o *GetBuildLogsOptions) getPodLog(ns string, pod *corev1.Pod, container corev1.Container) error {
-	log.Infof("Getting the pod log for pod %s and init container %s\n", pod.Name, container.Name)
-	return o.tailLogs(ns, pod.Name, container.Name)
+// getPodLog waits for the step to start then streams its log so that the steps are displayed in the order they run
+func (o *GetBuildLogsOptions) getPodLog(kubeClient kubernetes.Interface, ns string, pod *corev1.Pod, container corev1.Container) error {
+	stepName := builds.StepName(&container)
+	if !builds.IsStepStarted(pod, container.Name) {
+		log.Infof("Waiting for step %s to start\n", util.ColorInfo(stepName))
+		// the step never starts once the pod has completed or an earlier step failed
+		var p *corev1.Pod
+		err := o.retryUntilTrueOrTimeout(time.Hour, time.Second*2, func() (bool, error) {
+			var err error
+			p, err = kubeClient.CoreV1().Pods(ns).Get(pod.Name, metav1.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+			if builds.IsStepStarted(p, container.Name) || builds.FailedStep(p) != "" {
+				return true, nil
+			}
+			phase := p.Status.Phase
+			return phase == corev1.PodFailed || phase == corev1.PodSucceeded, nil
+		})
+		if err != nil {
+			return err
+		}
+		if !builds.IsStepStarted(p, container.Name) {
+			if failed := builds.FailedStep(p); failed != "" {
+				return fmt.Errorf("step %s of the build pod %s failed so step %s did not run", strings.TrimPrefix(failed, builds.BuildStepContainerPrefix), pod.Name, stepName)
+			}
+			if p.Status.Phase == corev1.PodFailed {
+				return fmt.Errorf("the build pod %s failed before step %s started", pod.Name, stepName)
+			}
+			log.Infof("Step %s did not run\n", util.ColorInfo(stepName))
+			return nil
+		}
+		pod = p
+	}
+	log.Infof("\n%s\n", util.ColorStatus("Step "+stepName))
+	return o.streamStepLog(kubeClient, ns, pod, container.Name)
+}
+
+// streamStepLog writes the log of the container of a step to the output as it is written, following the restarts of
+// the container until it completes
+func (o *GetBuildLogsOptions) streamStepLog(kubeClient kubernetes.Interface, ns string, pod *corev1.Pod, containerName string) error {
+	restarts := int32(0)
+	if status := builds.StepStatus(pod, containerName); status != nil {
+		restarts = status.RestartCount
+	}
+	for {
+		stream, err := kubeClient.CoreV1().Pods(ns).GetLogs(pod.Name, &corev1.PodLogOptions{
+			Container: containerName,
+			Follow:    true,
+		}).Stream()
+		if err != nil {
+			return errors.Wrapf(err, "streaming the log of container %s of pod %s", containerName, pod.Name)
+		}
+		err = o.writeStepLog(stream)
+		stream.Close()
+		if err != nil {
+			return err
+		}
+
+		p, err := kubeClient.CoreV1().Pods(ns).Get(pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		status := builds.StepStatus(p, containerName)
+		if status == nil || status.RestartCount <= restarts {
+			return nil
+		}
+		restarts = status.RestartCount
+		log.Infof("\n%s\n", util.ColorStatus("Step "+strings.TrimPrefix(containerName, builds.BuildStepContainerPrefix)+" restarted "+strconv.Itoa(int(restarts))+" times"))
+	}
+}
+
+// writeStepLog writes the lines of a step log to the output masking the pipeline secrets. The ANSI colors of the
+// build tools are passed through unless --no-color is used
+func (o *GetBuildLogsOptions) writeStepLog(reader io.Reader) error {
+	r := bufio.NewReader(reader)
+	for {
+		line, err := r.ReadString('\n')
+		if line != "" {
+			if o.NoColor {
+				line = ansiEscapeRegex.ReplaceAllString(line, "")
+			}
+			_, werr := io.WriteString(o.Out, util.RedactSecrets(line))
+			if werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// getArchivedBuildLog displays the log of the last completed build matching the filter from the storage location it
+// was archived to returning false if there is no archived log matching the filter
+func (o *GetBuildLogsOptions) getArchivedBuildLog(jxClient versioned.Interface, ns string) (bool, error) {
+	activities, err := jxClient.JenkinsV1().PipelineActivities(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+	activity := lastArchivedActivity(activities.Items, &o.BuildFilter)
+	if activity == nil {
+		return false, nil
+	}
+	logURL := activity.Spec.BuildLogsURL
+	if !buckets.IsBucketURL(logURL) {
+		log.Infof("%s %s\n", util.ColorStatus("view the log at:"), util.ColorInfo(logURL))
+		return true, nil
+	}
+	data, err := buckets.ReadURL(logURL)
+	if err != nil {
+		return false, errors.Wrapf(err, "fetching the archived log of %s", activity.Name)
+	}
+	log.Infof("Archived build log for %s\n", util.ColorInfo(activity.Name))
+	_, err = o.Out.Write(data)
+	return true, err
+}
+
+// lastArchivedActivity returns the activity with the highest build number which matches the filter and whose log
+// has been archived or nil if there is none
+func lastArchivedActivity(activities []v1.PipelineActivity, filter *builds.BuildPodInfoFilter) *v1.PipelineActivity {
+	var answer *v1.PipelineActivity
+	last := -1
+	for i := range activities {
+		activity := &activities[i]
+		if activity.Spec.BuildLogsURL == "" || !filter.ActivityMatches(activity) {
+			continue
+		}
+		build, _ := strconv.Atoi(activity.Spec.Build)
+		if build > last {
+			answer = activity
+			last = build
+		}
+	}
+	return answer
 }
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/builds"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWriteStepLog(t *testing.T) {
+	t.Parallel()
+
+	util.RegisterSecret("build-log-test-secret")
+	source := "\x1b[32mBUILD SUCCESS\x1b[0m\nusing build-log-test-secret\nno trailing newline"
+
+	out := &bytes.Buffer{}
+	o := &GetBuildLogsOptions{GetOptions: GetOptions{CommonOptions: CommonOptions{Out: out}}}
+	err := o.writeStepLog(strings.NewReader(source))
+	require.NoError(t, err)
+	assert.Equal(t, "\x1b[32mBUILD SUCCESS\x1b[0m\nusing "+util.RedactedValue+"\nno trailing newline", out.String())
+
+	out.Reset()
+	o.NoColor = true
+	err = o.writeStepLog(strings.NewReader(source))
+	require.NoError(t, err)
+	assert.Equal(t, "BUILD SUCCESS\nusing "+util.RedactedValue+"\nno trailing newline", out.String())
+}
+
+func TestGetPodLogStopsWhenAnEarlierStepFailed(t *testing.T) {
+	t.Parallel()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myorg-myapp-master-1",
+			Namespace: "jx",
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{Name: "build-step-git-source"},
+				{Name: "build-step-build"},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "build-step-git-source",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{ExitCode: 1},
+					},
+				},
+				{
+					Name: "build-step-build",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"},
+					},
+				},
+			},
+		},
+	}
+	kubeClient := fake.NewSimpleClientset(pod)
+
+	o := &GetBuildLogsOptions{}
+	err := o.getPodLog(kubeClient, "jx", pod, pod.Spec.InitContainers[1])
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "step git-source of the build pod myorg-myapp-master-1 failed")
+}
+
+func TestLastArchivedActivity(t *testing.T) {
+	t.Parallel()
+
+	activity := func(build string, logURL string) v1.PipelineActivity {
+		return v1.PipelineActivity{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "myorg-myapp-master-" + build,
+			},
+			Spec: v1.PipelineActivitySpec{
+				Pipeline:      "myorg/myapp/master",
+				GitOwner:      "myorg",
+				GitRepository: "myapp",
+				Build:         build,
+				BuildLogsURL:  logURL,
+			},
+		}
+	}
+	activities := []v1.PipelineActivity{
+		activity("2", "gs://logs/2.log"),
+		activity("10", "gs://logs/10.log"),
+		activity("11", ""),
+		activity("9", "gs://logs/9.log"),
+	}
+
+	filter := &builds.BuildPodInfoFilter{Repository: "myapp", Branch: "master"}
+	last := lastArchivedActivity(activities, filter)
+	if assert.NotNil(t, last) {
+		assert.Equal(t, "10", last.Spec.Build)
+	}
+
+	filter.Build = "9"
+	last = lastArchivedActivity(activities, filter)
+	if assert.NotNil(t, last) {
+		assert.Equal(t, "gs://logs/9.log", last.Spec.BuildLogsURL)
+	}
+
+	filter.Repository = "other"
+	assert.Nil(t, lastArchivedActivity(activities, filter))
+}
@@ -0,0 +1,65 @@
+package cmd_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCreateBuildSummary(t *testing.T) {
+	t.Parallel()
+
+	started := metav1.NewTime(time.Date(2019, 1, 2, 10, 0, 0, 0, time.UTC))
+	completed := metav1.NewTime(started.Add(90 * time.Second))
+	activity := &v1.PipelineActivity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "myorg-myapp-pr-12-3",
+		},
+		Spec: v1.PipelineActivitySpec{
+			Pipeline:           "myorg/myapp/PR-12",
+			Build:              "3",
+			StartedTimestamp:   &started,
+			CompletedTimestamp: &completed,
+			LastCommitSHA:      "f008e2e6d1d1ab5c",
+			LastCommitMessage:  "fix the tests\n\nsome more detail",
+		},
+	}
+
+	summary := cmd.CreateBuildSummary(activity)
+	assert.Equal(t, "myorg/myapp/PR-12", summary.Pipeline)
+	assert.Equal(t, "3", summary.Build)
+	assert.Equal(t, string(v1.ActivityStatusTypePending), summary.Status)
+	assert.Equal(t, kube.PipelineTriggerPullRequest, summary.Trigger)
+	assert.Equal(t, "1m30s", summary.Duration)
+	assert.Equal(t, "fix the tests", summary.CommitMessage)
+}
+
+func TestSortPipelineActivities(t *testing.T) {
+	t.Parallel()
+
+	newActivity := func(pipeline string, build string) v1.PipelineActivity {
+		return v1.PipelineActivity{
+			Spec: v1.PipelineActivitySpec{
+				Pipeline: pipeline,
+				Build:    build,
+			},
+		}
+	}
+	activities := []v1.PipelineActivity{
+		newActivity("myorg/myapp/master", "9"),
+		newActivity("myorg/another/master", "1"),
+		newActivity("myorg/myapp/master", "10"),
+	}
+	cmd.SortPipelineActivities(activities)
+
+	actual := []string{}
+	for _, a := range activities {
+		actual = append(actual, a.Spec.Pipeline+" #"+a.Spec.Build)
+	}
+	assert.Equal(t, []string{"myorg/another/master #1", "myorg/myapp/master #10", "myorg/myapp/master #9"}, actual)
+}
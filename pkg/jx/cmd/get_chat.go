@@ -60,6 +60,7 @@ func NewCmdGetChat(f Factory, in terminal.FileReader, out terminal.FileWriter, e
 		},
 	}
 	cmd.Flags().StringVarP(&options.Kind, "kind", "k", "", "Filters the chats by the kinds: "+strings.Join(chats.ChatKinds, ", "))
+	options.addGetFlags(cmd)
 	return cmd
 }
 
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// GetCommitsOptions contains the command line options
+type GetCommitsOptions struct {
+	GetOptions
+	ReleaseContentOptions
+
+	Dir string
+}
+
+var (
+	getCommitsLong = templates.LongDesc(`
+		Display the commits of an application which are deployed to an environment and which are pending promotion to it.
+
+		The commits are found in the Release resources created by the changelog of each release of the application or,
+		if there are none, in the git history between the tags of the versions.
+`)
+
+	getCommitsExample = templates.Examples(`
+		# List the commits of the current project which are released but not yet in production
+		jx get commits --env production
+
+		# List the commits of an application which are in staging but not yet in production
+		jx get commits --app myapp --env production --source staging
+
+		# Include the commits of all the releases deployed to production
+		jx get commits --app myapp --env production --all
+	`)
+)
+
+// NewCmdGetCommits creates the command
+func NewCmdGetCommits(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &GetCommitsOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+
+				Out: out,
+				Err: errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "commits [flags]",
+		Short:   "Display the commits of an application deployed to an environment and pending promotion to it",
+		Long:    getCommitsLong,
+		Example: getCommitsExample,
+		Aliases: []string{"commit"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The root project directory")
+	options.addReleaseContentFlags(cmd)
+
+	options.addGetFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetCommitsOptions) Run() error {
+	content, err := o.releaseContent(&o.ReleaseContentOptions, o.Dir)
+	if err != nil {
+		return err
+	}
+	if o.outputObjects() {
+		return o.renderResult(content, o.Output)
+	}
+	if len(content.Commits) == 0 {
+		log.Infof("No commits found for %s in %s\n", util.ColorInfo(content.Application), util.ColorInfo(content.Environment))
+		return nil
+	}
+
+	table := o.CreateTable()
+	table.AddRow("VERSION", "STATUS", "SHA", "AUTHOR", "MESSAGE")
+	for _, c := range content.Commits {
+		commit := c.Commit
+		author := ""
+		if commit.Author != nil {
+			author = commit.Author.Login
+			if author == "" {
+				author = commit.Author.Name
+			}
+		}
+		status := c.Status
+		if status == ReleaseContentPending {
+			status = util.ColorWarning(status)
+		}
+		table.AddRow(c.Version, status, shortSHA(commit.SHA), author, firstLine(commit.Message))
+	}
+	table.Render()
+	return nil
+}
+
+// shortSHA returns the abbreviated form of the git commit SHA
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[0:7]
+	}
+	return sha
+}
+
+// firstLine returns the first line of the text such as the subject of a commit message
+func firstLine(text string) string {
+	return strings.TrimSpace(strings.SplitN(text, "\n", 2)[0])
+}
@@ -59,6 +59,7 @@ func NewCmdGetConfig(f Factory, in terminal.FileReader, out terminal.FileWriter,
 		},
 	}
 	options.addGetConfigFlags(cmd)
+	options.addGetFlags(cmd)
 	return cmd
 }
 
@@ -73,6 +73,7 @@ func NewCmdGetCVE(f Factory, in terminal.FileReader, out terminal.FileWriter, er
 	options.addCommonFlags(cmd)
 	options.addGetCVEFlags(cmd)
 
+	options.addGetFlags(cmd)
 	return cmd
 }
 
@@ -58,6 +58,7 @@ func NewCmdGetDevPod(f Factory, in terminal.FileReader, out terminal.FileWriter,
 		},
 	}
 
+	options.addGetFlags(cmd)
 	return cmd
 }
 
@@ -81,20 +82,30 @@ func (o *GetDevPodOptions) Run() error {
 	names, m, err := kube.GetDevPodNames(client, ns, u.Username)
 
 	table := o.CreateTable()
-	table.AddRow("NAME", "POD TEMPLATE", "AGE", "STATUS")
+	table.AddRow("NAME", "POD TEMPLATE", "AGE", "STATUS", "IDE", "IDLE", "IDLE TIMEOUT")
 
+	now := time.Now()
 	for _, k := range names {
 		pod := m[k]
 		if pod != nil {
 			podTemplate := ""
+			ide := ""
 			status := kube.PodStatus(pod)
 			labels := pod.Labels
-			d := time.Now().Sub(pod.CreationTimestamp.Time).Round(time.Second)
+			d := now.Sub(pod.CreationTimestamp.Time).Round(time.Second)
 			age := d.String()
 			if labels != nil {
 				podTemplate = labels[kube.LabelPodTemplate]
 			}
-			table.AddRow(k, podTemplate, age, status)
+			if pod.Annotations != nil {
+				ide = pod.Annotations[kube.AnnotationDevPodIDE]
+			}
+			idle := now.Sub(kube.DevPodLastActivity(pod)).Round(time.Minute).String()
+			idleTimeout := ""
+			if timeout := kube.DevPodIdleTimeout(pod); timeout > 0 {
+				idleTimeout = timeout.String()
+			}
+			table.AddRow(k, podTemplate, age, status, ide, idle, idleTimeout)
 		}
 	}
 
@@ -1,18 +1,12 @@
 package cmd
 
 import (
-	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/jenkins-x/jx/pkg/cloud/amazon"
-	"github.com/pkg/errors"
-	"gopkg.in/yaml.v2"
 	"io"
-	"os"
-	"os/exec"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
-	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 )
@@ -25,7 +19,11 @@ type GetEksOptions struct {
 
 var (
 	getEksLong = templates.LongDesc(`
-		Display one or many EKS cluster resources 
+		Display one or many EKS cluster resources
+
+		All the EKS clusters of the account in the region are listed, not just the ones created by jx, along with
+		their version, number of nodes and the development namespaces of Jenkins X if it is installed on them.
+		Jenkins X can only be detected on the clusters with a context in your kube config.
 `)
 
 	getEksExample = templates.Examples(`
@@ -73,32 +71,21 @@ func NewCmdGetEks(f Factory, in terminal.FileReader, out terminal.FileWriter, er
 
 func (o *GetEksOptions) Run() error {
 	if len(o.Args) == 0 {
-		var deps []string
-		d := binaryShouldBeInstalled("eksctl")
-		if d != "" {
-			deps = append(deps, d)
-		}
-		d = binaryShouldBeInstalled("heptio-authenticator-aws")
-		if d != "" {
-			deps = append(deps, d)
-		}
-		err := o.installMissingDependencies(deps)
-		if err != nil {
-			log.Errorf("%v\nPlease fix the error or install manually then try again", err)
-			os.Exit(-1)
-		}
-
-		region, err := amazon.ResolveRegion(o.Profile, o.Region)
+		eksClusters, err := amazon.ListEksClusters(o.Profile, o.Region)
 		if err != nil {
 			return err
 		}
-		cmd := exec.Command("eksctl", "get", "cluster", "--region", region)
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			return nil
+		clusters := []CloudCluster{}
+		for _, c := range eksClusters {
+			clusters = append(clusters, CloudCluster{
+				Name:     c.Name,
+				Location: c.Region,
+				Version:  c.Version,
+				Nodes:    c.Nodes,
+				Status:   c.Status,
+			})
 		}
-		fmt.Print(string(output))
-		return nil
+		return o.renderCloudClusters(clusters)
 	} else {
 		cluster := o.Args[0]
 		session, err := amazon.NewAwsSession(o.Profile, o.Region)
@@ -120,19 +107,13 @@ func (o *GetEksOptions) Run() error {
 			return err
 		}
 
-		if o.Output == "" {
-			fmt.Println("NAME")
-			fmt.Println(cluster)
-		} else if o.Output == "yaml" {
-			reservations, err := yaml.Marshal(instances.Reservations)
-			if err != nil {
-				return err
-			}
-			fmt.Println(string(reservations))
-		} else {
-			return errors.New("Invalid output format.")
+		if o.outputObjects() {
+			return o.renderResult(instances.Reservations, o.Output)
 		}
-
+		table := o.CreateTable()
+		table.AddRow("NAME")
+		table.AddRow(cluster)
+		table.Render()
 		return nil
 	}
 }
@@ -97,8 +97,8 @@ func (o *GetEnvOptions) Run() error {
 		spec := &env.Spec
 
 		table := o.CreateTable()
-		table.AddRow("NAME", "LABEL", "KIND", "NAMESPACE", "SOURCE", "REF", "PR")
-		table.AddRow(e, spec.Label, spec.Namespace, kindString(spec), spec.Source.URL, spec.Source.Ref, spec.PullRequestURL)
+		table.AddRow("NAME", "LABEL", "KIND", "PROMOTE", "NAMESPACE", "ORDER", "SOURCE", "REF", "PR")
+		table.AddRow(e, spec.Label, kindString(spec), string(spec.PromotionStrategy), spec.Namespace, util.Int32ToA(spec.Order), spec.Source.URL, spec.Source.Ref, spec.PullRequestURL)
 		table.Render()
 		log.Blank()
 
@@ -133,13 +133,13 @@ func (o *GetEnvOptions) Run() error {
 		environments := o.filterEnvironments(envs.Items)
 		kube.SortEnvironments(environments)
 
-		if o.Output != "" {
+		if o.outputObjects() {
 			envs.Items = environments
 			return o.renderResult(envs, o.Output)
 		}
 		table := o.CreateTable()
 		if o.PreviewOnly {
-			table.AddRow("PULL REQUEST", "NAMESPACE", "APPLICATION")
+			table.AddRow("NAME", "PULL REQUEST", "NAMESPACE", "BUILD STATUS", "APPLICATION")
 		} else {
 			table.AddRow("NAME", "LABEL", "KIND", "PROMOTE", "NAMESPACE", "ORDER", "CLUSTER", "SOURCE", "REF", "PR")
 		}
@@ -147,7 +147,7 @@ func (o *GetEnvOptions) Run() error {
 		for _, env := range environments {
 			spec := &env.Spec
 			if o.PreviewOnly {
-				table.AddRow(spec.PullRequestURL, spec.Namespace, util.ColorInfo(spec.PreviewGitSpec.ApplicationURL))
+				table.AddRow(env.Name, spec.PullRequestURL, spec.Namespace, spec.PreviewGitSpec.BuildStatus, util.ColorInfo(spec.PreviewGitSpec.ApplicationURL))
 			} else {
 				table.AddRow(env.Name, spec.Label, kindString(spec), string(spec.PromotionStrategy), spec.Namespace, util.Int32ToA(spec.Order), spec.Cluster, spec.Source.URL, spec.Source.Ref, spec.PullRequestURL)
 			}
@@ -180,5 +180,5 @@ func (o *GetEnvOptions) matchesFilter(env *v1.Environment) bool {
 	if o.PromotionStrategy == "" {
 		return true
 	}
-	return env.Spec.PromotionStrategy == v1.PromotionStrategyType(o.PromotionStrategy)
+	return strings.EqualFold(string(env.Spec.PromotionStrategy), o.PromotionStrategy)
 }
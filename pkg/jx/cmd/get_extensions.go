@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	jenkinsv1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/extensions"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetExtensionsOptions the command line options
+type GetExtensionsOptions struct {
+	GetOptions
+
+	When string
+}
+
+var (
+	getExtensionsLong = templates.LongDesc(`
+		Display the extensions installed for the current team and when they run.
+`)
+
+	getExtensionsExample = templates.Examples(`
+		# List all the extensions of the team
+		jx get extensions
+
+		# List the extensions which run before an application is promoted
+		jx get extensions --when prePromote
+	`)
+)
+
+// NewCmdGetExtensions creates the command
+func NewCmdGetExtensions(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &GetExtensionsOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "extensions",
+		Short:   "Display the extensions of the team and when they run",
+		Aliases: []string{"extension", "ext"},
+		Long:    getExtensionsLong,
+		Example: getExtensionsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.When, "when", "w", "", "Only display the extensions which run on the given event such as: "+strings.Join(lifecycleEventNames(), ", "))
+	options.addGetFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetExtensionsOptions) Run() error {
+	apisClient, err := o.CreateApiExtensionsClient()
+	if err != nil {
+		return err
+	}
+	err = kube.RegisterExtensionCRD(apisClient)
+	if err != nil {
+		return err
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	list, err := jxClient.JenkinsV1().Extensions(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	items := list.Items
+	if o.When != "" {
+		items = extensions.LifecycleExtensions(items, jenkinsv1.ExtensionWhen(o.When))
+	} else {
+		sort.Slice(items, func(i, j int) bool {
+			return items[i].Spec.FullyQualifiedName() < items[j].Spec.FullyQualifiedName()
+		})
+	}
+	if o.outputObjects() {
+		return o.renderResult(items, o.Output)
+	}
+	if len(items) == 0 {
+		return outputEmptyListWarning(o.Out)
+	}
+
+	table := o.CreateTable()
+	table.AddRow("NAME", "VERSION", "WHEN", "RUNS", "DESCRIPTION")
+	for _, ext := range items {
+		spec := &ext.Spec
+		whens := []string{}
+		for _, when := range spec.When {
+			whens = append(whens, string(when))
+		}
+		if len(whens) == 0 && len(spec.Children) == 0 {
+			whens = append(whens, string(jenkinsv1.ExtensionWhenPost))
+		}
+		runs := "script"
+		if spec.Image != "" {
+			runs = util.ColorInfo(spec.Image)
+		} else if len(spec.Children) > 0 {
+			runs = "children"
+		}
+		table.AddRow(spec.FullyQualifiedName(), spec.Version, strings.Join(whens, ", "), runs, spec.Description)
+	}
+	table.Render()
+	return nil
+}
@@ -53,6 +53,7 @@ func NewCmdGetGit(f Factory, in terminal.FileReader, out terminal.FileWriter, er
 		},
 	}
 
+	options.addGetFlags(cmd)
 	return cmd
 }
 
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/cloud/gke"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+// GetGkeOptions the command line options
+type GetGkeOptions struct {
+	GetOptions
+
+	ProjectID string
+}
+
+var (
+	getGkeLong = templates.LongDesc(`
+		Display the GKE clusters of a Google Cloud project.
+
+		All the clusters of the project are listed, not just the ones created by jx, along with their version, number
+		of nodes and the development namespaces of Jenkins X if it is installed on them. Jenkins X can only be
+		detected on the clusters with a context in your kube config.
+`)
+
+	getGkeExample = templates.Examples(`
+		# List the GKE clusters of the current project
+		jx get gke
+
+		# List the GKE clusters of another project
+		jx get gke --project-id myproject
+
+		# Install Jenkins X on one of them
+		jx install --provider gke --cluster mycluster
+	`)
+)
+
+// NewCmdGetGke creates the command
+func NewCmdGetGke(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &GetGkeOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "gke",
+		Short:   "List the GKE clusters",
+		Long:    getGkeLong,
+		Example: getGkeExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.ProjectID, "project-id", "p", "", "The Google Cloud project. Defaults to the current project")
+	options.addGetFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetGkeOptions) Run() error {
+	gkeClusters, err := gke.ListClusters(o.ProjectID)
+	if err != nil {
+		return err
+	}
+	clusters := []CloudCluster{}
+	for _, c := range gkeClusters {
+		clusters = append(clusters, CloudCluster{
+			Name:     c.Name,
+			Location: c.ClusterLocation(),
+			Version:  c.CurrentMasterVersion,
+			Nodes:    c.CurrentNodeCount,
+			Status:   c.Status,
+		})
+	}
+	return o.renderCloudClusters(clusters)
+}
@@ -8,12 +8,16 @@ import (
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 
 	"github.com/jenkins-x/golang-jenkins"
+	"github.com/jenkins-x/jx/pkg/issues"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
 )
 
 // GetIssuesOptions contains the command line options
 type GetIssuesOptions struct {
 	GetOptions
+	ReleaseContentOptions
 	Dir    string
 	Filter string
 }
@@ -22,11 +26,19 @@ var (
 	GetIssuesLong = templates.LongDesc(`
 		Display one or more issues for a project.
 
+		When an environment is specified the issues of the releases of the application which are deployed to the
+		environment and which are pending promotion to it are displayed instead.
 `)
 
 	GetIssuesExample = templates.Examples(`
 		# List open issues on the current project
 		jx get issues
+
+		# List the issues of the current project which are released but not yet in production
+		jx get issues --env production
+
+		# List the issues of an application which are in staging but not yet in production
+		jx get issues --app myapp --env production --source staging
 	`)
 )
 
@@ -58,6 +70,7 @@ func NewCmdGetIssues(f Factory, in terminal.FileReader, out terminal.FileWriter,
 		},
 	}
 	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The root project directory")
+	options.addReleaseContentFlags(cmd)
 
 	options.addGetFlags(cmd)
 	return cmd
@@ -65,6 +78,9 @@ func NewCmdGetIssues(f Factory, in terminal.FileReader, out terminal.FileWriter,
 
 // Run implements this command
 func (o *GetIssuesOptions) Run() error {
+	if o.Application != "" || o.Environment != "" {
+		return o.getReleaseIssues()
+	}
 	tracker, err := o.createIssueProvider(o.Dir)
 	if err != nil {
 		return err
@@ -84,6 +100,67 @@ func (o *GetIssuesOptions) Run() error {
 	return nil
 }
 
+// getReleaseIssues displays the issues of the application deployed to the environment and pending promotion to it
+func (o *GetIssuesOptions) getReleaseIssues() error {
+	content, err := o.releaseContent(&o.ReleaseContentOptions, o.Dir)
+	if err != nil {
+		return err
+	}
+	o.loadIssueTitles(content.Issues)
+	if o.outputObjects() {
+		return o.renderResult(content, o.Output)
+	}
+	if len(content.Issues) == 0 {
+		log.Infof("No issues found for %s in %s\n", util.ColorInfo(content.Application), util.ColorInfo(content.Environment))
+		return nil
+	}
+
+	table := o.CreateTable()
+	table.AddRow("ISSUE", "VERSION", "STATUS", "STATE", "TITLE")
+	for _, i := range content.Issues {
+		issue := i.Issue
+		name := issue.URL
+		if name == "" {
+			name = issue.ID
+		}
+		status := i.Status
+		if status == ReleaseContentPending {
+			status = util.ColorWarning(status)
+		}
+		table.AddRow(name, i.Version, status, issue.State, issue.Title)
+	}
+	table.Render()
+	return nil
+}
+
+// loadIssueTitles looks up the issues found in the git history, which only have their keys, in the issue tracker
+func (o *GetIssuesOptions) loadIssueTitles(releaseIssues []ReleaseIssue) {
+	var tracker issues.IssueProvider
+	for i := range releaseIssues {
+		issue := &releaseIssues[i].Issue
+		if issue.Title != "" {
+			continue
+		}
+		if tracker == nil {
+			var err error
+			tracker, err = o.createIssueProvider(o.Dir)
+			if err != nil {
+				log.Warnf("Failed to create the issue tracker: %s\n", err)
+				return
+			}
+		}
+		gitIssue, err := tracker.GetIssue(issue.ID)
+		if err != nil || gitIssue == nil {
+			continue
+		}
+		issue.URL = gitIssue.URL
+		issue.Title = gitIssue.Title
+		if gitIssue.State != nil {
+			issue.State = *gitIssue.State
+		}
+	}
+}
+
 func (o *GetIssuesOptions) matchesFilter(job *gojenkins.Job) bool {
 	args := o.Args
 	if len(args) == 0 {
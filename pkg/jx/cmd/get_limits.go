@@ -76,6 +76,7 @@ func NewCmdGetLimits(f Factory, in terminal.FileReader, out terminal.FileWriter,
 		},
 	}
 
+	options.addGetFlags(cmd)
 	return cmd
 }
 
@@ -13,8 +13,10 @@ import (
 	"time"
 
 	"github.com/jenkins-x/golang-jenkins"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/table"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // GetPipelineOptions is the start of the data required to perform the operation.  As new fields are added, add them here instead of
@@ -23,17 +25,28 @@ type GetPipelineOptions struct {
 	GetOptions
 
 	ProwOptions prow.Options
+	Owner       string
+	Repository  string
+	Branch      string
+	Status      string
 }
 
 var (
 	get_pipeline_long = templates.LongDesc(`
-		Display one or more pipelines.
+		Display one or more pipelines with the status of their last build.
 
+		To view the recent builds of the pipelines use 'jx get builds'.
 `)
 
 	get_pipeline_example = templates.Examples(`
 		# List all pipelines
 		jx get pipeline
+
+		# List the pipelines of the repo cheese
+		jx get pipeline --repo cheese
+
+		# List the pipelines whose last build failed
+		jx get pipeline --status failed
 	`)
 )
 
@@ -65,6 +78,10 @@ func NewCmdGetPipeline(f Factory, in terminal.FileReader, out terminal.FileWrite
 	}
 
 	options.addGetFlags(cmd)
+	cmd.Flags().StringVarP(&options.Owner, "owner", "", "", "Filters the owner (person/organisation) of the repository")
+	cmd.Flags().StringVarP(&options.Repository, "repo", "r", "", "Filters the repository")
+	cmd.Flags().StringVarP(&options.Branch, "branch", "", "", "Filters the branch")
+	cmd.Flags().StringVarP(&options.Status, "status", "s", "", "Filters the status of the last build such as Running, Succeeded or Failed")
 	return cmd
 }
 
@@ -100,21 +117,33 @@ func (o *GetPipelineOptions) Run() error {
 		}
 		sort.Strings(names)
 
-		if len(names) == 0 {
+		latestBuilds, err := o.latestBuilds()
+		if err != nil {
+			return err
+		}
+		filtered := []string{}
+		for _, name := range names {
+			if o.matchesPipelineName(name) && o.matchesStatus(latestBuilds[name]) {
+				filtered = append(filtered, name)
+			}
+		}
+		if len(filtered) == 0 {
 			return outputEmptyListWarning(o.Out)
 		}
 
-		if o.Output != "" {
-			return o.renderResult(names, o.Output)
+		if o.outputObjects() {
+			return o.renderResult(filtered, o.Output)
 		}
 
 		table := createTable(o)
-
-		for _, j := range names {
-			if err != nil {
-				return err
+		for _, name := range filtered {
+			activity := latestBuilds[name]
+			if activity == nil {
+				table.AddRow(name, "N/A", "N/A", "Never Built", "N/A")
+				continue
 			}
-			table.AddRow(j, "N/A", "N/A", "N/A", "N/A")
+			summary := CreateBuildSummary(activity)
+			table.AddRow(name, activity.Spec.GitURL, "#"+summary.Build, statusString(activityStatus(activity)), summary.Duration)
 		}
 		table.Render()
 
@@ -131,7 +160,7 @@ func (o *GetPipelineOptions) Run() error {
 			return outputEmptyListWarning(o.Out)
 		}
 
-		if o.Output != "" {
+		if o.outputObjects() {
 			return o.renderResult(jobs, o.Output)
 		}
 
@@ -149,6 +178,64 @@ func (o *GetPipelineOptions) Run() error {
 	return nil
 }
 
+// latestBuilds returns the latest pipeline activity of each pipeline indexed by the pipeline name
+func (o *GetPipelineOptions) latestBuilds() (map[string]*v1.PipelineActivity, error) {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return nil, err
+	}
+	list, err := jxClient.JenkinsV1().PipelineActivities(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	activities := list.Items
+	SortPipelineActivities(activities)
+
+	answer := map[string]*v1.PipelineActivity{}
+	for i := range activities {
+		activity := &activities[i]
+		pipeline := activity.Spec.Pipeline
+		if answer[pipeline] == nil {
+			answer[pipeline] = activity
+		}
+	}
+	return answer, nil
+}
+
+// matchesPipelineName returns true if the pipeline name of the form owner/repository/branch matches the filters
+func (o *GetPipelineOptions) matchesPipelineName(name string) bool {
+	paths := strings.Split(name, "/")
+	if len(paths) == 3 {
+		if o.Owner != "" && o.Owner != paths[0] {
+			return false
+		}
+		if o.Repository != "" && o.Repository != paths[1] {
+			return false
+		}
+		if o.Branch != "" && !strings.EqualFold(o.Branch, paths[2]) {
+			return false
+		}
+	}
+	args := o.Args
+	if len(args) == 0 {
+		return true
+	}
+	for _, arg := range args {
+		if strings.Contains(name, arg) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesStatus returns true if the status of the latest build of the pipeline matches the status filter
+func (o *GetPipelineOptions) matchesStatus(latest *v1.PipelineActivity) bool {
+	if o.Status == "" {
+		return true
+	}
+	return latest != nil && strings.EqualFold(o.Status, string(activityStatus(latest)))
+}
+
 func createTable(o *GetPipelineOptions) table.Table {
 	table := o.CreateTable()
 	table.AddRow("Name", "URL", "LAST_BUILD", "STATUS", "DURATION")
@@ -169,13 +256,17 @@ func (o *GetPipelineOptions) dump(jenkins gojenkins.JenkinsClient, name string,
 		last, err := jenkins.GetLastBuild(job)
 		if err != nil {
 			if jenkins.IsErrNotFound(err) {
-				if o.matchesFilter(&job) {
+				if o.matchesFilter(&job) && o.Status == "" {
 					table.AddRow(job.FullName, job.Url, "", "Never Built", "")
 				}
 			}
 			return nil
 		}
-		if o.matchesFilter(&job) {
+		status := last.Result
+		if last.Building {
+			status = "Building"
+		}
+		if o.matchesFilter(&job) && (o.Status == "" || strings.EqualFold(o.Status, status)) {
 			if last.Building {
 				table.AddRow(job.FullName, job.Url, "#"+last.Id, "Building", time.Duration(last.EstimatedDuration).String()+"(est.)")
 			} else {
@@ -187,15 +278,5 @@ func (o *GetPipelineOptions) dump(jenkins gojenkins.JenkinsClient, name string,
 }
 
 func (o *GetPipelineOptions) matchesFilter(job *gojenkins.Job) bool {
-	args := o.Args
-	if len(args) == 0 {
-		return true
-	}
-	name := job.FullName
-	for _, arg := range args {
-		if strings.Contains(name, arg) {
-			return true
-		}
-	}
-	return false
+	return o.matchesPipelineName(job.FullName)
 }
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+// GetPodTemplatesOptions the command line options
+type GetPodTemplatesOptions struct {
+	GetOptions
+}
+
+var (
+	getPodTemplatesLong = templates.LongDesc(`
+		Display the Jenkins pod templates of the build agents of your team along with the image and resources of
+		their build container and the pod template they are customised from.
+`)
+
+	getPodTemplatesExample = templates.Examples(`
+		# List the pod templates of the team
+		jx get podtemplates
+	`)
+)
+
+// NewCmdGetPodTemplates creates the command
+func NewCmdGetPodTemplates(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &GetPodTemplatesOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "podtemplates",
+		Short:   "Display the Jenkins pod templates of the build agents of your team",
+		Long:    getPodTemplatesLong,
+		Example: getPodTemplatesExample,
+		Aliases: []string{"podtemplate", "pod-templates"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addGetFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetPodTemplatesOptions) Run() error {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	_, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	podTemplates, err := kube.LoadPodTemplates(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	customisations, err := kube.LoadPodTemplateCustomisations(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+
+	table := o.CreateTable()
+	table.AddRow("NAME", "IMAGE", "REQUESTS", "LIMITS", "CUSTOMISED")
+	for _, name := range podTemplateNames(podTemplates) {
+		image := ""
+		requests := ""
+		limits := ""
+		container := kube.PodTemplateBuildContainer(name, podTemplates[name])
+		if container != nil {
+			image = container.Image
+			requests = resourceListText(container.Resources.Requests)
+			limits = resourceListText(container.Resources.Limits)
+		}
+		customised := ""
+		customisation := customisations[name]
+		if customisation != nil {
+			customised = "yes"
+			if customisation.From != "" {
+				customised = "from " + customisation.From
+			}
+		}
+		table.AddRow(name, image, requests, limits, customised)
+	}
+	table.Render()
+	return nil
+}
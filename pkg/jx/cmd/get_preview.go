@@ -22,7 +22,10 @@ type GetPreviewOptions struct {
 
 var (
 	getPreviewLong = templates.LongDesc(`
-		Display one or more environments.
+		Display one or more preview environments along with their pull request, build status and application URL.
+
+		Preview environments are created for each pull request and are garbage collected via 'jx gc previews' once
+		the pull request is merged or closed.
 `)
 
 	getPreviewExample = templates.Examples(`
@@ -35,7 +38,7 @@ var (
 	`)
 )
 
-// NewCmdGetPreview creates the new command for: jx get env
+// NewCmdGetPreview creates the new command for: jx get previews
 func NewCmdGetPreview(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
 	options := &GetPreviewOptions{
 		GetEnvOptions: GetEnvOptions{
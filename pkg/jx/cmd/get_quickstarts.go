@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/quickstarts"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+// GetQuickstartsOptions the options for the get quickstarts command
+type GetQuickstartsOptions struct {
+	GetOptions
+
+	GitHubOrganisations []string
+	Filter              quickstarts.QuickstartFilter
+	IgnoreTeam          bool
+}
+
+var (
+	getQuickstartsLong = templates.LongDesc(`
+		Display the catalog of quickstarts which can be used to create new applications via 'jx create quickstart'.
+
+		The quickstarts are loaded from the quickstart locations of the team, see 'jx get quickstartlocations'.
+
+		For more documentation see: [https://jenkins-x.io/developing/create-quickstart/](https://jenkins-x.io/developing/create-quickstart/)
+
+`)
+
+	getQuickstartsExample = templates.Examples(`
+		# List all the quickstarts
+		jx get quickstarts
+
+		# List the Go quickstarts
+		jx get quickstarts --language go
+
+		# List the quickstarts using the spring framework tagged with rest
+		jx get qs --framework spring --tag rest
+	`)
+)
+
+// NewCmdGetQuickstarts creates the command for: jx get quickstarts
+func NewCmdGetQuickstarts(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &GetQuickstartsOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "quickstarts",
+		Short:   "Display the catalog of quickstarts",
+		Aliases: []string{"quickstart", "qs"},
+		Long:    getQuickstartsLong,
+		Example: getQuickstartsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addGetFlags(cmd)
+
+	cmd.Flags().StringArrayVarP(&options.GitHubOrganisations, "organisations", "g", []string{}, "The GitHub organisations to query for quickstarts")
+	cmd.Flags().StringArrayVarP(&options.Filter.Tags, "tag", "t", []string{}, "The tags on the quickstarts to filter")
+	cmd.Flags().StringVarP(&options.Filter.Owner, "owner", "", "", "The owner to filter on")
+	cmd.Flags().StringVarP(&options.Filter.Language, "language", "l", "", "The language to filter on")
+	cmd.Flags().StringVarP(&options.Filter.Framework, "framework", "", "", "The framework to filter on")
+	cmd.Flags().StringVarP(&options.Filter.Text, "filter", "f", "", "The text filter")
+	cmd.Flags().BoolVarP(&options.IgnoreTeam, "ignore-team", "", false, "Ignores the quickstart locations of the team and only uses the GitHub organisations")
+	return cmd
+}
+
+// Run implements this command
+func (o *GetQuickstartsOptions) Run() error {
+	authConfigSvc, err := o.CreateGitAuthConfigService()
+	if err != nil {
+		return err
+	}
+	model, err := o.loadQuickstartModel(authConfigSvc.Config(), o.GitHubOrganisations, o.IgnoreTeam)
+	if err != nil {
+		return err
+	}
+	err = model.ValidateFilter(&o.Filter)
+	if err != nil {
+		return err
+	}
+	results := model.Filter(&o.Filter)
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].ID < results[j].ID
+	})
+
+	table := o.CreateTable()
+	table.AddRow("NAME", "OWNER", "LANGUAGE", "FRAMEWORK", "TAGS")
+	for _, q := range results {
+		table.AddRow(q.Name, q.Owner, q.Language, q.Framework, strings.Join(q.Tags, ", "))
+	}
+	table.Render()
+	return nil
+}
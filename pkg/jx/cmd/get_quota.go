@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// quotaWarningPercent the percentage of the cluster capacity requested above which more nodes are recommended
+	quotaWarningPercent = 80
+)
+
+// GetQuotaOptions the command line options
+type GetQuotaOptions struct {
+	GetOptions
+
+	PreviewIdle time.Duration
+}
+
+var (
+	getQuotaLong = templates.LongDesc(`
+		Display the CPU and memory requested and limited by the pods of each environment compared with the capacity
+		of the nodes of the cluster.
+
+		Environments whose requests or limits exceed the resource quota of their namespace, or if they have no quota
+		whose cluster has pods requesting more than the capacity of its nodes, are flagged as over committed and Preview
+		environments which have not been deployed to for a while are flagged as idle so that they can be deleted via
+		'jx delete preview'. If the pods request most of the capacity of the cluster consider increasing the maximum
+		number of nodes of the cluster such as via the '--max-num-nodes' option of 'jx create cluster'.
+`)
+
+	getQuotaExample = templates.Examples(`
+		# Display the resources used by each environment
+		jx get quota
+
+		# Flag the Preview environments which have not been deployed to for a day as idle
+		jx get quota --preview-idle 24h
+	`)
+)
+
+// NewCmdGetQuota creates the command
+func NewCmdGetQuota(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &GetQuotaOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "quota",
+		Short:   "Display the resources used by each environment compared with the capacity of the cluster",
+		Long:    getQuotaLong,
+		Example: getQuotaExample,
+		Aliases: []string{"quotas", "usage"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().DurationVarP(&options.PreviewIdle, "preview-idle", "", 72*time.Hour, "The time since the last deployment after which a Preview environment is idle")
+	options.addGetFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetQuotaOptions) Run() error {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	envMap, envNames, err := kube.GetOrderedEnvironments(jxClient, devNs)
+	if err != nil {
+		return err
+	}
+	capacity, err := kube.GetClusterCapacity(client)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	totalCPU := capacity.CPU.DeepCopy()
+	totalCPU.Set(0)
+	totalMemory := capacity.Memory.DeepCopy()
+	totalMemory.Set(0)
+	idlePreviews := 0
+
+	table := o.CreateTable()
+	table.AddRow("ENV", "NAMESPACE", "PODS", "CPU REQUESTS", "CPU LIMITS", "MEMORY REQUESTS", "MEMORY LIMITS", "STATUS")
+	for _, name := range envNames {
+		env := envMap[name]
+		ns := env.Spec.Namespace
+		if ns == "" {
+			continue
+		}
+		usage, err := kube.GetNamespaceUsage(client, ns)
+		if err != nil {
+			log.Warnf("Failed to find the pods in namespace %s: %s\n", ns, err)
+			continue
+		}
+		totalCPU.Add(usage.CPURequests)
+		totalMemory.Add(usage.MemoryRequests)
+
+		status := ""
+		if usage.IsOverCommitted(capacity) {
+			status = util.ColorWarning("over committed")
+		} else if env.Spec.Kind == v1.EnvironmentKindTypePreview && usage.IsIdle(o.PreviewIdle, now) {
+			status = util.ColorWarning("idle")
+			idlePreviews++
+		}
+		table.AddRow(name, ns, fmt.Sprintf("%d", usage.Pods),
+			quantityOf(usage.CPURequests, capacity.CPU), usage.CPULimits.String(),
+			quantityOf(usage.MemoryRequests, capacity.Memory), usage.MemoryLimits.String(), status)
+	}
+	table.Render()
+
+	cpuPercent := kube.PercentOf(totalCPU, capacity.CPU)
+	memoryPercent := kube.PercentOf(totalMemory, capacity.Memory)
+	log.Infof("\nCluster: %d nodes, cpu %d%% of %s requested, memory %d%% of %s requested\n", capacity.Nodes,
+		cpuPercent, capacity.CPU.String(), memoryPercent, capacity.Memory.String())
+	if idlePreviews > 0 {
+		log.Warnf("There are %d idle Preview environments which could be deleted via: %s\n", idlePreviews, util.ColorInfo("jx delete preview"))
+	}
+	if capacity.IsOverCommitted() {
+		log.Warnf("The pods of the cluster request cpu %s and memory %s which is more than its nodes can allocate so some of them cannot be scheduled\n",
+			capacity.CPURequests.String(), capacity.MemoryRequests.String())
+	} else if cpuPercent >= quotaWarningPercent || memoryPercent >= quotaWarningPercent {
+		log.Warnf("The environments request most of the capacity of the cluster. Consider increasing the maximum number of nodes such as via %s\n", util.ColorInfo("--max-num-nodes"))
+	}
+	return nil
+}
+
+// quantityOf returns the quantity along with its percentage of the total
+func quantityOf(quantity resource.Quantity, total resource.Quantity) string {
+	return fmt.Sprintf("%s (%d%%)", quantity.String(), kube.PercentOf(quantity, total))
+}
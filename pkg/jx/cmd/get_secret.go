@@ -13,8 +13,10 @@ import (
 type GetSecretOptions struct {
 	GetOptions
 
-	Namespace string
-	Name      string
+	Namespace  string
+	Name       string
+	VaultToken string
+	Path       string
 }
 
 func (o *GetSecretOptions) VaultName() string {
@@ -33,6 +35,9 @@ var (
 	getSecretExample = templates.Examples(`
 		# List all secrets
 		jx get secrets
+
+		# List the admin secrets
+		jx get secrets --path admin
 	`)
 )
 
@@ -66,14 +71,19 @@ func NewCmdGetSecret(f Factory, in terminal.FileReader, out terminal.FileWriter,
 
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "Namespace from where to list the secrets")
 	cmd.Flags().StringVarP(&options.Name, "name", "m", "", "The name of the Vault to use")
+	cmd.Flags().StringVarP(&options.VaultToken, "vault-token", "", "", "The token used to access a Vault which is not managed by the vault operator")
+	cmd.Flags().StringVarP(&options.Path, "path", "p", "", "Only list the secrets under the given path")
 	return cmd
 }
 
 // Run implements the command
 func (o *GetSecretOptions) Run() error {
+	if o.VaultToken != "" {
+		o.Factory.SetVaultToken(o.VaultToken)
+	}
 	var vaultClient vault.Client
 	var err error
-	if o.Name != "" && o.Namespace != "" {
+	if o.Name != "" || o.Namespace != "" {
 		vaultClient, err = o.Factory.GetVaultClient(o.Name, o.Namespace)
 	} else {
 		vaultClient, err = o.Factory.GetSystemVaultClient()
@@ -81,7 +91,7 @@ func (o *GetSecretOptions) Run() error {
 	if err != nil {
 		return errors.Wrap(err, "retrieving the vault client")
 	}
-	secrets, err := vaultClient.List("")
+	secrets, err := vault.ListAll(vaultClient, o.Path)
 	if err != nil {
 		return errors.Wrap(err, "listing all secrets in vault")
 	}
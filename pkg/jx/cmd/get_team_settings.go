@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+)
+
+// GetTeamSettingsOptions the command line options
+type GetTeamSettingsOptions struct {
+	GetOptions
+}
+
+var (
+	getTeamSettingsLong = templates.LongDesc(`
+		Display the settings of the current team which can be changed with 'jx edit config' or 'jx edit <setting>'
+
+`)
+
+	getTeamSettingsExample = templates.Examples(`
+		# View the team settings
+		jx get teamsettings
+	`)
+)
+
+// NewCmdGetTeamSettings creates the command
+func NewCmdGetTeamSettings(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &GetTeamSettingsOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+
+				Out: out,
+				Err: errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "teamsettings",
+		Short:   "Display the settings of the current team",
+		Long:    getTeamSettingsLong,
+		Example: getTeamSettingsExample,
+		Aliases: []string{"team-settings"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addGetFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetTeamSettingsOptions) Run() error {
+	teamSettings, err := o.TeamSettings()
+	if err != nil {
+		return err
+	}
+	table := o.CreateTable()
+	table.AddRow("SETTING", "VALUE", "DESCRIPTION")
+	for _, field := range teamSettingsFields() {
+		if field.Kind != reflect.String && field.Kind != reflect.Bool {
+			continue
+		}
+		table.AddRow(field.Command, fmt.Sprintf("%v", field.getValue(teamSettings).Interface()), field.Usage)
+	}
+	table.Render()
+	return nil
+}
@@ -41,6 +41,7 @@ func NewCmdGetToken(f Factory, in terminal.FileReader, out terminal.FileWriter,
 		},
 	}
 	cmd.AddCommand(NewCmdGetTokenAddon(f, in, out, errOut))
+	options.addGetFlags(cmd)
 	return cmd
 }
 
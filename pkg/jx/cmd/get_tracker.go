@@ -60,6 +60,7 @@ func NewCmdGetTracker(f Factory, in terminal.FileReader, out terminal.FileWriter
 		},
 	}
 	cmd.Flags().StringVarP(&options.Kind, "kind", "k", "", "Filters the issue trackers by the kinds: "+strings.Join(issues.IssueTrackerKinds, ", "))
+	options.addGetFlags(cmd)
 	return cmd
 }
 
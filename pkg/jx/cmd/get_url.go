@@ -1,13 +1,15 @@
 package cmd
 
 import (
-	"github.com/jenkins-x/jx/pkg/kube/services"
 	"io"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/kube/services"
+	"github.com/jenkins-x/jx/pkg/util"
 )
 
 // GetURLOptions the command line options
@@ -16,6 +18,7 @@ type GetURLOptions struct {
 
 	Namespace   string
 	Environment string
+	All         bool
 }
 
 var (
@@ -27,6 +30,12 @@ var (
 	get_url_example = templates.Examples(`
 		# List all URLs in this namespace
 		jx get url
+
+		# List all URLs in the team namespace and the namespaces of all of its environments
+		jx get url --all
+
+		# List all URLs as JSON for use in scripts
+		jx get url --all -o json
 	`)
 )
 
@@ -58,6 +67,8 @@ func NewCmdGetURL(f Factory, in terminal.FileReader, out terminal.FileWriter, er
 		},
 	}
 	options.addGetUrlFlags(cmd)
+	options.addGetFlags(cmd)
+	cmd.Flags().BoolVarP(&options.All, "all", "a", false, "Display the URLs in the team namespace and the namespaces of all of its environments")
 	return cmd
 }
 
@@ -68,28 +79,71 @@ func (o *GetURLOptions) addGetUrlFlags(cmd *cobra.Command) {
 
 // Run implements this command
 func (o *GetURLOptions) Run() error {
-	client, ns, err := o.KubeClient()
+	urls, err := o.findURLs()
 	if err != nil {
 		return err
 	}
-	if o.Namespace != "" {
-		ns = o.Namespace
-	} else if o.Environment != "" {
-		ns, err = o.findEnvironmentNamespace(o.Environment)
-		if err != nil {
-			return err
-		}
-	}
-	urls, err := services.FindServiceURLs(client, ns)
-	if err != nil {
-		return err
+	if o.outputObjects() {
+		return o.renderResult(urls, o.Output)
 	}
 	table := o.CreateTable()
-	table.AddRow("Name", "URL")
+	if o.All {
+		table.AddRow("Name", "Namespace", "URL")
+	} else {
+		table.AddRow("Name", "URL")
+	}
 
 	for _, url := range urls {
-		table.AddRow(url.Name, url.URL)
+		if o.All {
+			table.AddRow(url.Name, url.Namespace, url.URL)
+		} else {
+			table.AddRow(url.Name, url.URL)
+		}
 	}
 	table.Render()
 	return nil
 }
+
+// findURLs returns the URLs of the services in the namespace or environment chosen or the current namespace.
+// If all is enabled the URLs of the services in the team namespace and all of its environments are returned
+func (o *GetURLOptions) findURLs() ([]services.ServiceURL, error) {
+	client, ns, err := o.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	namespaces := []string{ns}
+	if o.Namespace != "" {
+		namespaces = []string{o.Namespace}
+	} else if o.Environment != "" {
+		ns, err = o.findEnvironmentNamespace(o.Environment)
+		if err != nil {
+			return nil, err
+		}
+		namespaces = []string{ns}
+	} else if o.All {
+		jxClient, devNs, err := o.JXClientAndDevNamespace()
+		if err != nil {
+			return nil, err
+		}
+		envMap, envNames, err := kube.GetOrderedEnvironments(jxClient, devNs)
+		if err != nil {
+			return nil, err
+		}
+		namespaces = []string{devNs}
+		for _, name := range envNames {
+			envNs := envMap[name].Spec.Namespace
+			if envNs != "" && util.StringArrayIndex(namespaces, envNs) < 0 {
+				namespaces = append(namespaces, envNs)
+			}
+		}
+	}
+	answer := []services.ServiceURL{}
+	for _, ns := range namespaces {
+		urls, err := services.FindServiceURLs(client, ns)
+		if err != nil {
+			return answer, err
+		}
+		answer = append(answer, urls...)
+	}
+	return answer, nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/kube/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func exposedService(ns string, name string, url string) *corev1.Service {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+	}
+	if url != "" {
+		svc.Annotations = map[string]string{
+			services.ExposeURLAnnotation: url,
+		}
+	}
+	return svc
+}
+
+// configureURLTestOptions configures the options with a team which has a staging and a production environment
+func configureURLTestOptions(o *CommonOptions) {
+	k8sObjects := []runtime.Object{
+		exposedService("jx", "jenkins", "http://jenkins.jx.1.2.3.4.nip.io"),
+		exposedService("jx", "heapster", ""),
+		exposedService("jx-staging", "myapp", "http://myapp.jx-staging.1.2.3.4.nip.io"),
+		exposedService("jx-production", "myapp", "http://myapp.jx-production.1.2.3.4.nip.io"),
+		exposedService("other", "grafana", "http://grafana.other.1.2.3.4.nip.io"),
+	}
+	jxObjects := []runtime.Object{
+		kube.NewPermanentEnvironment("staging"),
+		kube.NewPermanentEnvironment("production"),
+	}
+	ConfigureTestOptionsWithResources(o, k8sObjects, jxObjects, gits.NewGitCLI(), nil)
+}
+
+func TestGetURLFindURLs(t *testing.T) {
+	t.Parallel()
+
+	o := &GetURLOptions{}
+	configureURLTestOptions(&o.CommonOptions)
+
+	urls, err := o.findURLs()
+	require.NoError(t, err)
+	assert.Equal(t, []services.ServiceURL{
+		{Name: "jenkins", Namespace: "jx", URL: "http://jenkins.jx.1.2.3.4.nip.io"},
+	}, urls)
+
+	o.Namespace = "other"
+	urls, err = o.findURLs()
+	require.NoError(t, err)
+	assert.Equal(t, []services.ServiceURL{
+		{Name: "grafana", Namespace: "other", URL: "http://grafana.other.1.2.3.4.nip.io"},
+	}, urls)
+}
+
+func TestGetURLFindURLsOfAllEnvironments(t *testing.T) {
+	t.Parallel()
+
+	o := &GetURLOptions{All: true}
+	configureURLTestOptions(&o.CommonOptions)
+
+	urls, err := o.findURLs()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []services.ServiceURL{
+		{Name: "jenkins", Namespace: "jx", URL: "http://jenkins.jx.1.2.3.4.nip.io"},
+		{Name: "myapp", Namespace: "jx-staging", URL: "http://myapp.jx-staging.1.2.3.4.nip.io"},
+		{Name: "myapp", Namespace: "jx-production", URL: "http://myapp.jx-production.1.2.3.4.nip.io"},
+	}, urls)
+}
+
+func TestGetURLOutputJSON(t *testing.T) {
+	t.Parallel()
+
+	out := &bytes.Buffer{}
+	o := &GetURLOptions{}
+	o.Out = out
+	o.Output = "json"
+	configureURLTestOptions(&o.CommonOptions)
+
+	err := o.Run()
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"name":"jenkins","namespace":"jx","url":"http://jenkins.jx.1.2.3.4.nip.io"}]`, out.String())
+}
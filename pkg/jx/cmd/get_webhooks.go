@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+// GetWebHooksOptions the command line options
+type GetWebHooksOptions struct {
+	GetOptions
+
+	Org             string
+	Repo            string
+	PreviousHookUrl string
+	ExactHookMatch  bool
+	Problems        bool
+}
+
+var (
+	getWebHooksLong = templates.LongDesc(`
+		Display the webhooks of the repositories of an organisation and whether they are sent to the current
+		webhook endpoint of the team.
+
+		A webhook is reported as Stale if it is sent to an old endpoint, such as after the domain of the cluster has
+		changed, and Missing if the repository has no webhook for the team. Use 'jx update webhooks' to repair them.
+`)
+
+	getWebHooksExample = templates.Examples(`
+		# List the webhooks of all the repositories in the organisation
+		jx get webhooks --org mycorp
+
+		# List only the repositories whose webhooks need repairing
+		jx get webhooks --org mycorp --problems
+	`)
+)
+
+// NewCmdGetWebHooks creates the command
+func NewCmdGetWebHooks(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &GetWebHooksOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "webhooks",
+		Short:   "Display the webhooks of the repositories of an organisation",
+		Long:    getWebHooksLong,
+		Example: getWebHooksExample,
+		Aliases: []string{"webhook", "hooks", "hook"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addGetFlags(cmd)
+	cmd.Flags().StringVarP(&options.Org, "org", "", "", "The name of the git organisation to query")
+	cmd.Flags().StringVarP(&options.Repo, "repo", "r", "", "The name of the repository to query")
+	cmd.Flags().StringVarP(&options.PreviousHookUrl, "previous-hook-url", "", "", "The URL of the old webhook endpoint to report as stale")
+	cmd.Flags().BoolVarP(&options.ExactHookMatch, "exact-hook-url-match", "", true, "Whether to exactly match the hook based on the URL")
+	cmd.Flags().BoolVarP(&options.Problems, "problems", "", false, "Only display the webhooks which are stale, missing or have no secret")
+	return cmd
+}
+
+// Run implements this command
+func (o *GetWebHooksOptions) Run() error {
+	if o.Org == "" {
+		return util.MissingOption("org")
+	}
+	matcher, git, err := o.createWebHookMatcher(o.PreviousHookUrl, o.ExactHookMatch)
+	if err != nil {
+		return err
+	}
+	repos, err := webHookRepositories(git, o.Org, o.Repo)
+	if err != nil {
+		return err
+	}
+
+	statuses := []WebHookStatus{}
+	for _, repo := range repos {
+		hooks, err := git.ListWebHooks(o.Org, repo)
+		if err != nil {
+			log.Warnf("Failed to list the webhooks of %s/%s: %s\n", o.Org, repo, err)
+			continue
+		}
+		for _, status := range matcher.CheckWebHooks(o.Org, repo, hooks) {
+			if !o.Problems || status.Status != WebHookStatusOK {
+				statuses = append(statuses, status)
+			}
+		}
+	}
+
+	if o.outputObjects() {
+		return o.renderResult(statuses, o.Output)
+	}
+	if len(statuses) == 0 {
+		return outputEmptyListWarning(o.Out)
+	}
+	table := o.CreateTable()
+	table.AddRow("OWNER", "REPOSITORY", "STATUS", "URL")
+	for _, s := range statuses {
+		table.AddRow(s.Owner, s.Repository, webHookStatusString(s.Status), s.URL)
+	}
+	table.Render()
+	return nil
+}
+
+// createWebHookMatcher creates the matcher for the current webhook endpoint and git provider of the team
+func (o *CommonOptions) createWebHookMatcher(previousURL string, exactMatch bool) (*WebHookMatcher, gits.GitProvider, error) {
+	authConfigService, err := o.CreateGitAuthConfigService()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create git auth service")
+	}
+	endpoint, err := o.GetWebHookEndpoint()
+	if err != nil {
+		return nil, nil, err
+	}
+	isProw, err := o.isProw()
+	if err != nil {
+		return nil, nil, err
+	}
+	gitServer := authConfigService.Config().CurrentServer
+	git, err := o.gitProviderForGitServerURL(gitServer, "github")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to determine git provider")
+	}
+	matcher := &WebHookMatcher{
+		Endpoint:      endpoint,
+		PreviousURL:   previousURL,
+		ExactMatch:    exactMatch,
+		RequireSecret: isProw,
+		SecretsListed: git.Kind() == gits.KindGitHub,
+	}
+	return matcher, git, nil
+}
+
+func webHookStatusString(status string) string {
+	switch status {
+	case WebHookStatusOK:
+		return util.ColorInfo(status)
+	case WebHookStatusMissing:
+		return util.ColorError(status)
+	default:
+		return util.ColorWarning(status)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/golang/glog"
+	"github.com/jenkins-x/jx/pkg/telemetry"
 	"github.com/spf13/cobra"
 )
 
@@ -50,6 +51,7 @@ func fatal(msg string, code int) {
 		}
 		fmt.Fprint(os.Stderr, msg)
 	}
+	telemetry.Finish(false)
 	os.Exit(code)
 }
 
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/cenkalti/backoff"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/cloud/amazon"
 	"github.com/pkg/errors"
 
@@ -91,6 +92,7 @@ type ImportOptions struct {
 	ListDraftPacks          bool
 	DraftPack               string
 	DockerRegistryOrg       string
+	Paths                   []string
 
 	DisableDotGitSearch   bool
 	InitialisedGit        bool
@@ -103,6 +105,8 @@ type ImportOptions struct {
 	DisableMaven          bool
 	PipelineUserName      string
 	PipelineServer        string
+
+	monorepoApps []*monorepoApp
 }
 
 var (
@@ -113,6 +117,9 @@ var (
 	    Or you can use '--dir' to specify a directory to import.
 
 	    You can specify the git URL as an argument.
+
+		The build pack is detected from the build files of the project such as go.mod, package.json, pom.xml,
+		build.gradle, requirements.txt or *.csproj falling back to the languages of the source code.
 	    
 		For more documentation see: [https://jenkins-x.io/developing/import/](https://jenkins-x.io/developing/import/)
 	    
@@ -125,6 +132,12 @@ var (
 		# Import a different folder
 		jx import /foo/bar
 
+		# Preview the import generating any missing Dockerfile, Jenkinsfile or chart for the detected build pack
+		jx import --dry-run
+
+		# Import each application in the services folder of a monorepo with its own chart and pipeline
+		jx import --path 'services/*'
+
 		# Import a Git repository from a URL
 		jx import --url https://github.com/jenkins-x/spring-boot-web-example.git
 
@@ -182,7 +195,7 @@ func (options *ImportOptions) addImportFlags(cmd *cobra.Command, createProject b
 	cmd.Flags().StringVarP(&options.Repository, "name", "", notCreateProject("n"), "Specify the Git repository name to import the project into (if it is not already in one)")
 	cmd.Flags().StringVarP(&options.Credentials, "credentials", notCreateProject("c"), "", "The Jenkins credentials name used by the job")
 	cmd.Flags().StringVarP(&options.Jenkinsfile, "jenkinsfile", notCreateProject("j"), "", "The name of the Jenkinsfile to use. If not specified then 'Jenkinsfile' will be used")
-	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Performs local changes to the repo but skips the import into Jenkins X")
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Performs local changes to the repo such as generating any missing Dockerfile, Jenkinsfile or chart then previews the import into Jenkins X without performing it")
 	cmd.Flags().BoolVarP(&options.DisableDraft, "no-draft", "", false, "Disable Draft from trying to default a Dockerfile and Helm Chart")
 	cmd.Flags().BoolVarP(&options.DisableJenkinsfileCheck, "no-jenkinsfile", "", false, "Disable defaulting a Jenkinsfile if its missing")
 	cmd.Flags().StringVarP(&options.ImportGitCommitMessage, "import-commit-message", "", "", "Should we override the Jenkinsfile in the project?")
@@ -190,6 +203,7 @@ func (options *ImportOptions) addImportFlags(cmd *cobra.Command, createProject b
 	cmd.Flags().BoolVarP(&options.ListDraftPacks, "list-packs", "", false, "list available draft packs")
 	cmd.Flags().StringVarP(&options.DraftPack, "pack", "", "", "The name of the pack to use")
 	cmd.Flags().StringVarP(&options.DockerRegistryOrg, "docker-registry-org", "", "", "The name of the docker registry organisation to use. If not specified then the Git provider organisation will be used")
+	cmd.Flags().StringArrayVarP(&options.Paths, "path", "", nil, "The paths or glob patterns of the application folders to import from a monorepo. Each application gets its own chart and pipeline which only runs when its folder changes")
 	cmd.Flags().StringVarP(&options.ExternalJenkinsBaseURL, "external-jenkins-url", "", "", "The jenkins url that an external git provider needs to use")
 
 	options.addCommonFlags(cmd)
@@ -230,6 +244,9 @@ func (options *ImportOptions) Run() error {
 			return err
 		}
 
+		if isProw && len(options.Paths) > 0 {
+			return fmt.Errorf("importing the applications of a monorepo with --path is only supported when using Jenkins")
+		}
 		if !isProw {
 			options.Jenkins, err = options.JenkinsClient()
 			if err != nil {
@@ -269,6 +286,9 @@ func (options *ImportOptions) Run() error {
 		if err != nil {
 			return err
 		}
+		if options.GitRepositoryOptions.ServerKind != "" {
+			server.Kind = options.GitRepositoryOptions.ServerKind
+		}
 		if server.Kind == "" {
 			server.Kind, err = options.GitServerHostURLKind(server.URL)
 			if err != nil {
@@ -362,7 +382,13 @@ func (options *ImportOptions) Run() error {
 	}
 	options.AppName = kube.ToValidName(strings.ToLower(options.AppName))
 
-	if !options.DisableDraft {
+	missingFiles := options.missingImportFiles(options.Dir)
+	if len(options.Paths) > 0 {
+		missingFiles, err = options.importMonorepoApps()
+		if err != nil {
+			return err
+		}
+	} else if !options.DisableDraft {
 		err = options.DraftCreate()
 		if err != nil {
 			return err
@@ -396,7 +422,7 @@ func (options *ImportOptions) Run() error {
 	}
 
 	if options.DryRun {
-		log.Infoln("dry-run so skipping import to Jenkins X")
+		options.logImportPreview(missingFiles)
 		return nil
 	}
 
@@ -407,7 +433,57 @@ func (options *ImportOptions) Run() error {
 		}
 	}
 
-	return options.doImport()
+	err = options.doImport()
+	if err != nil {
+		return err
+	}
+	options.runPostLifecycleExtensions(v1.ExtensionWhenPostImport, map[string]string{
+		"app":    options.AppName,
+		"gitUrl": options.RepoURL,
+	})
+	return nil
+}
+
+// missingImportFiles returns the files needed to build and deploy the project which are not in its directory
+func (options *ImportOptions) missingImportFiles(dir string) []string {
+	jenkinsfile := options.Jenkinsfile
+	if jenkinsfile == "" {
+		jenkinsfile = jenkins.DefaultJenkinsfile
+	}
+	answer := []string{}
+	for _, name := range []string{"Dockerfile", jenkinsfile, "charts"} {
+		exists, err := util.FileExists(filepath.Join(dir, name))
+		if err == nil && !exists {
+			answer = append(answer, name)
+		}
+	}
+	return answer
+}
+
+// logImportPreview logs the files which were generated and the remaining steps of the import which are skipped
+// in a dry run. Running the import again without --dry-run reuses the generated files
+func (options *ImportOptions) logImportPreview(missingFiles []string) {
+	log.Infof("Preview of the import of %s:\n", util.ColorInfo(options.AppName))
+	if options.DraftPack != "" {
+		log.Infof("  build pack:   %s\n", util.ColorInfo(options.DraftPack))
+	}
+	for _, app := range options.monorepoApps {
+		log.Infof("  application:  %s in %s using build pack %s\n", util.ColorInfo(app.Name), util.ColorInfo(app.Path), util.ColorInfo(app.DraftPack))
+	}
+	for _, name := range missingFiles {
+		exists, err := util.FileExists(filepath.Join(options.Dir, name))
+		if err == nil && exists {
+			log.Infof("  generated:    %s\n", util.ColorInfo(name))
+		}
+	}
+	repository := options.RepoURL
+	if repository == "" {
+		repository = options.getOrganisationOrCurrentUser() + "/" + options.AppName
+		log.Infof("  would create: the Git repository %s\n", util.ColorInfo(repository))
+	}
+	log.Infof("  would add:    a webhook on %s\n", util.ColorInfo(repository))
+	log.Infof("  would start:  the first pipeline of %s\n", util.ColorInfo(repository))
+	log.Infof("Run the command again without %s to import the project\n", util.ColorInfo("--dry-run"))
 }
 
 // ImportProjectsFromGitHub import projects from github
@@ -866,6 +942,10 @@ func (options *ImportOptions) doImport() error {
 		jenkinsfile = jenkins.DefaultJenkinsfile
 	}
 
+	if len(options.monorepoApps) > 0 {
+		return options.doImportMonorepoApps(gitURL, gitProvider, authConfigSvc)
+	}
+
 	err = options.ensureDockerRepositoryExists()
 	if err != nil {
 		return err
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/jenkins-x/jx/pkg/jenkinsfile"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// monorepoApp an application in a sub directory of a monorepo
+type monorepoApp struct {
+	// Path the slash separated path of the application relative to the root of the repository
+	Path      string
+	Name      string
+	DraftPack string
+}
+
+// findMonorepoApps returns the applications in the directories matching the paths or glob patterns
+func findMonorepoApps(dir string, patterns []string) ([]*monorepoApp, error) {
+	paths := map[string]bool{}
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(dir, filepath.FromSlash(pattern)))
+		if err != nil {
+			return nil, util.InvalidOptionf("path", pattern, "invalid glob pattern: %s", err)
+		}
+		for _, match := range matches {
+			fi, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+			if !fi.IsDir() || strings.HasPrefix(fi.Name(), ".") {
+				continue
+			}
+			rel, err := filepath.Rel(dir, match)
+			if err != nil {
+				return nil, err
+			}
+			paths[filepath.ToSlash(rel)] = true
+		}
+	}
+	answer := []*monorepoApp{}
+	for path := range paths {
+		answer = append(answer, &monorepoApp{
+			Path: path,
+			Name: kube.ToValidName(strings.ToLower(filepath.Base(path))),
+		})
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].Path < answer[j].Path
+	})
+	return answer, nil
+}
+
+// importMonorepoApps generates the missing Dockerfile, Jenkinsfile and chart of each application of the monorepo.
+// It returns the files which were missing relative to the root of the repository
+func (options *ImportOptions) importMonorepoApps() ([]string, error) {
+	apps, err := findMonorepoApps(options.Dir, options.Paths)
+	if err != nil {
+		return nil, err
+	}
+	if len(apps) == 0 {
+		return nil, fmt.Errorf("no application folders found in %s matching %s", options.Dir, strings.Join(options.Paths, ", "))
+	}
+	jenkinsfileName := options.Jenkinsfile
+	if jenkinsfileName == "" {
+		jenkinsfileName = jenkins.DefaultJenkinsfile
+	}
+
+	missingFiles := []string{}
+	for _, app := range apps {
+		appDir := filepath.Join(options.Dir, filepath.FromSlash(app.Path))
+		for _, name := range options.missingImportFiles(appDir) {
+			missingFiles = append(missingFiles, filepath.Join(filepath.FromSlash(app.Path), name))
+		}
+		if options.DisableDraft {
+			continue
+		}
+		log.Infof("Importing application %s from %s\n", util.ColorInfo(app.Name), util.ColorInfo(app.Path))
+
+		appOptions := *options
+		appOptions.Dir = appDir
+		appOptions.AppName = app.Name
+		appOptions.Paths = nil
+		appOptions.InitialisedGit = false
+		appJenkinsfile := filepath.Join(appDir, jenkinsfileName)
+		jenkinsfileExists, err := util.FileExists(appJenkinsfile)
+		if err != nil {
+			return nil, err
+		}
+		callback := options.PostDraftPackCallback
+		appOptions.PostDraftPackCallback = func() error {
+			if callback != nil {
+				err := callback()
+				if err != nil {
+					return err
+				}
+			}
+			if jenkinsfileExists {
+				// lets not modify an existing pipeline
+				return nil
+			}
+			return scopeJenkinsfileToPath(appJenkinsfile, app.Path, app.TagPrefix())
+		}
+		err = appOptions.DraftCreate()
+		if err != nil {
+			return nil, errors.Wrapf(err, "importing the application in %s", app.Path)
+		}
+		app.DraftPack = appOptions.DraftPack
+		// lets reuse the organisation picked for the first application
+		options.Organisation = appOptions.Organisation
+	}
+	options.monorepoApps = apps
+	return missingFiles, nil
+}
+
+// TagPrefix returns the prefix of the release tags of the application so that each application of the monorepo is
+// versioned separately
+func (app *monorepoApp) TagPrefix() string {
+	return app.Name + "-"
+}
+
+// scopeJenkinsfileToPath makes the generated Jenkinsfile of an application run in its folder and only when it changes.
+// The release tags of the application are prefixed with the given prefix
+func scopeJenkinsfileToPath(fileName string, path string, tagPrefix string) error {
+	exists, err := util.FileExists(fileName)
+	if err != nil || !exists {
+		return err
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", fileName)
+	}
+	text, err := jenkinsfile.ScopeToPath(string(data), path)
+	if err != nil {
+		return errors.Wrapf(err, "scoping %s to the path %s", fileName, path)
+	}
+	text = jenkinsfile.PrefixReleaseTags(text, tagPrefix)
+	return ioutil.WriteFile(fileName, []byte(text), util.DefaultWritePermissions)
+}
+
+// doImportMonorepoApps creates a Jenkins job for each application of the monorepo using the Jenkinsfile in its folder.
+// The jobs share the webhook of the repository which is registered once
+func (options *ImportOptions) doImportMonorepoApps(gitURL string, gitProvider gits.GitProvider, authConfigSvc auth.ConfigService) error {
+	gitInfo, err := gits.ParseGitURL(gitURL)
+	if err != nil {
+		return err
+	}
+	jenkinsfileName := options.Jenkinsfile
+	if jenkinsfileName == "" {
+		jenkinsfileName = jenkins.DefaultJenkinsfile
+	}
+	for _, app := range options.monorepoApps {
+		appOptions := *options
+		appOptions.AppName = app.Name
+		err = appOptions.ensureDockerRepositoryExists()
+		if err != nil {
+			return err
+		}
+		jobName := gitInfo.Name + "-" + app.Name
+		appJenkinsfile := app.Path + "/" + jenkinsfileName
+		err = options.ImportProjectJob(gitURL, options.Dir, appJenkinsfile, jobName, options.BranchPattern, options.Credentials, false, gitProvider, authConfigSvc, false, options.BatchMode)
+		if err != nil {
+			return errors.Wrapf(err, "importing the application %s", app.Name)
+		}
+	}
+	return options.CreateJenkinsWebHook(gitURL, gitProvider)
+}
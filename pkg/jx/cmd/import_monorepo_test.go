@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindMonorepoApps(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "test-monorepo-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, path := range []string{"services/orders", "services/Payment_API", "services/.hidden", "tools/cli"} {
+		err = os.MkdirAll(filepath.Join(dir, path), util.DefaultWritePermissions)
+		require.NoError(t, err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "services", "README.md"), []byte("services"), 0644)
+	require.NoError(t, err)
+
+	apps, err := findMonorepoApps(dir, []string{"services/*", "tools/cli", "services/orders"})
+	require.NoError(t, err)
+	require.Len(t, apps, 3)
+	assert.Equal(t, "services/Payment_API", apps[0].Path)
+	assert.Equal(t, "payment-api", apps[0].Name)
+	assert.Equal(t, "services/orders", apps[1].Path)
+	assert.Equal(t, "orders", apps[1].Name)
+	assert.Equal(t, "tools/cli", apps[2].Path)
+	assert.Equal(t, "cli", apps[2].Name)
+}
+
+func TestScopeJenkinsfileToPathPrefixesReleaseTags(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "test-monorepo-jenkinsfile-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "Jenkinsfile")
+	source := `pipeline {
+  stages {
+    stage('Release') {
+      steps {
+        sh "echo \$(jx-release-version) > VERSION"
+        sh "jx step tag --version \$(cat VERSION)"
+      }
+    }
+  }
+}
+`
+	err = ioutil.WriteFile(fileName, []byte(source), 0644)
+	require.NoError(t, err)
+
+	app := &monorepoApp{Path: "services/orders", Name: "orders"}
+	err = scopeJenkinsfileToPath(fileName, app.Path, app.TagPrefix())
+	require.NoError(t, err)
+
+	data, err := ioutil.ReadFile(fileName)
+	require.NoError(t, err)
+	text := string(data)
+	assert.Contains(t, text, `dir('services/orders') {`)
+	assert.Contains(t, text, `sh "jx step next-version --use-git-tag-only --tag-prefix orders-"`)
+	assert.Contains(t, text, `sh "jx step tag --tag-prefix orders- --version \$(cat VERSION)"`)
+	assert.NotContains(t, text, "jx-release-version")
+}
@@ -38,6 +38,8 @@ type InitOptions struct {
 // InitFlags the flags for running init
 type InitFlags struct {
 	Domain                     string
+	DNSProvider                string
+	DNSServiceAccount          string
 	Provider                   string
 	Namespace                  string
 	UserClusterRole            string
@@ -122,6 +124,8 @@ func NewCmdInit(f Factory, in terminal.FileReader, out terminal.FileWriter, errO
 
 func (o *InitOptions) addInitFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&o.Flags.Domain, "domain", "", "", "Domain to expose ingress endpoints.  Example: jenkinsx.io")
+	cmd.Flags().StringVarP(&o.Flags.DNSProvider, "dns-provider", "", "", fmt.Sprintf("The DNS provider used to create the zone and wildcard record of the domain. Supported providers: %s", strings.Join(DNSProviders, ", ")))
+	cmd.Flags().StringVarP(&o.Flags.DNSServiceAccount, "dns-service-account", "", "", "The Google Cloud service account key file used by external-dns and cert-manager to manage the records of the domain when using clouddns")
 	cmd.Flags().StringVarP(&o.Username, optionUsername, "", "", "The Kubernetes username used to initialise helm. Usually your email address for your Kubernetes account")
 	cmd.Flags().StringVarP(&o.Flags.UserClusterRole, "user-cluster-role", "", "cluster-admin", "The cluster role for the current user to be able to administer helm")
 	cmd.Flags().StringVarP(&o.Flags.TillerClusterRole, "tiller-cluster-role", "", "cluster-admin", "The cluster role for Helm's tiller")
@@ -577,7 +581,12 @@ func (o *InitOptions) initIngress() error {
 		return nil
 	}
 
-	podCount, err := kube.DeploymentPodCount(client, o.Flags.IngressDeployment, ingressNamespace)
+	// the API server of a new cluster can briefly be unavailable so retry the transient errors
+	podCount := 0
+	err = util.RetryWithOptions(util.CloudRetryOptions("checking for an ingress controller"), func() error {
+		podCount, err = kube.DeploymentPodCount(client, o.Flags.IngressDeployment, ingressNamespace)
+		return err
+	})
 	if podCount == 0 {
 		installIngressController := false
 		if o.BatchMode {
@@ -634,21 +643,17 @@ controller:
 			valuesFiles = append(valuesFiles, fileName)
 		}
 
-		i := 0
-		for {
-			log.Infof("Installing using helm binary: %s\n", util.ColorInfo(o.Helm().HelmBinary()))
-			err = o.Helm().InstallChart("stable/nginx-ingress", "jxing", ingressNamespace, nil, nil, values,
+		log.Infof("Installing using helm binary: %s\n", util.ColorInfo(o.Helm().HelmBinary()))
+		err = util.RetryWithOptions(util.RetryOptions{
+			Description:     "installing the ingress chart",
+			Attempts:        4,
+			InitialInterval: time.Second,
+		}, func() error {
+			return o.Helm().InstallChart("stable/nginx-ingress", "jxing", ingressNamespace, nil, nil, values,
 				valuesFiles, "", "", "")
-			if err != nil {
-				if i >= 3 {
-					log.Errorf("Failed to install ingress chart: %s", err)
-					break
-				}
-				i++
-				time.Sleep(time.Second)
-			} else {
-				break
-			}
+		})
+		if err != nil {
+			log.Errorf("Failed to install ingress chart: %s", err)
 		}
 		err = kube.WaitForDeploymentToBeReady(client, o.Flags.IngressDeployment, ingressNamespace, 10*time.Minute)
 		if err != nil {
@@ -699,10 +704,32 @@ controller:
 			log.Infof("Using external IP: %s\n", util.ColorInfo(externalIP))
 		}
 
+		customDomain := o.Flags.Domain
 		o.Flags.Domain, err = o.GetDomain(client, o.Flags.Domain, o.Flags.Provider, ingressNamespace, o.Flags.IngressService, externalIP)
 		if err != nil {
 			return err
 		}
+
+		if o.Flags.DNSProvider == "" {
+			o.Flags.DNSProvider = defaultDNSProvider(o.Flags.Provider, customDomain)
+			if o.Flags.DNSProvider != "" {
+				log.Infof("Using the DNS provider %s for the domain %s\n", util.ColorInfo(o.Flags.DNSProvider), util.ColorInfo(customDomain))
+			}
+		}
+
+		if o.Flags.DNSProvider != "" && o.Flags.DNSProvider != DNSProviderNipIO {
+			address := externalIP
+			if address == "" {
+				address, err = o.getIngressAddress(client, ingressNamespace, o.Flags.IngressService)
+				if err != nil {
+					return err
+				}
+			}
+			err = o.configureDNS(o.Flags.DNSProvider, o.Flags.Domain, address, o.Flags.DNSServiceAccount)
+			if err != nil {
+				return errors.Wrapf(err, "configuring DNS for domain %s", o.Flags.Domain)
+			}
+		}
 	}
 
 	log.Success("nginx ingress controller installed and configured")
@@ -25,6 +25,7 @@ import (
 	"github.com/jenkins-x/jx/pkg/auth"
 	"github.com/jenkins-x/jx/pkg/cloud/aks"
 	"github.com/jenkins-x/jx/pkg/cloud/amazon"
+	"github.com/jenkins-x/jx/pkg/cloud/buckets"
 	"github.com/jenkins-x/jx/pkg/cloud/iks"
 	"github.com/jenkins-x/jx/pkg/config"
 	"github.com/jenkins-x/jx/pkg/gits"
@@ -33,6 +34,7 @@ import (
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/prow"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -87,6 +89,7 @@ type InstallFlags struct {
 	EnvironmentGitOwner      string
 	Version                  string
 	Prow                     bool
+	Tekton                   bool
 	DisableSetKubeContext    bool
 	GitOpsMode               bool
 	Dir                      string
@@ -94,7 +97,23 @@ type InstallFlags struct {
 	NoGitOpsEnvRepo          bool
 	NoGitOpsVault            bool
 	Vault                    bool
+	VaultURL                 string
+	VaultToken               string
 	BuildPackName            string
+	TLSEmail                 string
+	Exclude                  []string
+	DockerRegistryKind       string
+	DockerRegistryOrg        string
+	DockerRegistryUsername   string
+	DockerRegistryPassword   string
+	SkipDockerRegistryCheck  bool
+	StorageBucketURL         string
+	Backup                   BackupFlags
+	OAuth2Proxy              OAuth2ProxyFlags
+	NetworkPolicies          bool
+	RestoreFrom              string
+	Cluster                  string
+	ClusterProjectID         string
 }
 
 // Secrets struct for secrets
@@ -145,6 +164,11 @@ mysecrets.yaml
 ## Jenkins X Development Environment
 
 This repository contains the source code for the Jenkins X Development Environment so that it can be managed via GitOps.
+
+Any change to the platform configuration should be made via a Pull Request on this repository. The pipeline validates
+the changes in the Pull Request then applies them to the cluster via 'jx step env apply' once merged to master.
+
+To upgrade the platform to a newer version create a Pull Request via: 'jx upgrade platform'
 `
 
 	devGitOpsJenkinsfile = `pipeline {
@@ -260,6 +284,8 @@ func NewCmdInstall(f Factory, in terminal.FileReader, out terminal.FileWriter, e
 	options.addInstallFlags(cmd, false)
 
 	cmd.Flags().StringVarP(&options.Flags.Provider, "provider", "", "", "Cloud service providing the Kubernetes cluster.  Supported providers: "+KubernetesProviderOptions())
+	cmd.Flags().StringVarP(&options.Flags.Cluster, "cluster", "", "", "The name of the cloud cluster to install on such as one listed by 'jx get gke'. Its credentials are fetched if there is no kube context for it yet")
+	cmd.Flags().StringVarP(&options.Flags.ClusterProjectID, "cluster-project-id", "", "", "The Google Cloud project of the GKE cluster given by --cluster. Defaults to the current project")
 
 	cmd.AddCommand(NewCmdInstallDependencies(f, in, out, errOut))
 
@@ -330,6 +356,8 @@ func CreateInstallOptions(f Factory, in terminal.FileReader, out terminal.FileWr
 func (options *InstallOptions) addInstallFlags(cmd *cobra.Command, includesInit bool) {
 	flags := &options.Flags
 	flags.addCloudEnvOptions(cmd)
+	options.addVersionStreamFlags(cmd)
+	options.addSkipVerifyFlags(cmd)
 	cmd.Flags().StringVarP(&flags.LocalHelmRepoName, "local-helm-repo-name", "", kube.LocalHelmRepoName, "The name of the helm repository for the installed Chart Museum")
 	cmd.Flags().BoolVarP(&flags.NoDefaultEnvironments, "no-default-environments", "", false, "Disables the creation of the default Staging and Production environments")
 	cmd.Flags().StringVarP(&flags.DefaultEnvironmentPrefix, "default-environment-prefix", "", "", "Default environment repo prefix, your Git repos will be of the form 'environment-$prefix-$envName'")
@@ -341,15 +369,33 @@ func (options *InstallOptions) addInstallFlags(cmd *cobra.Command, includesInit
 	cmd.Flags().BoolVarP(&flags.HelmTLS, "helm-tls", "", false, "Whether to use TLS with helm")
 	cmd.Flags().BoolVarP(&flags.InstallOnly, "install-only", "", false, "Force the install command to fail if there is already an installation. Otherwise lets update the installation")
 	cmd.Flags().StringVarP(&flags.DockerRegistry, "docker-registry", "", "", "The Docker Registry host or host:port which is used when tagging and pushing images. If not specified it defaults to the internal registry unless there is a better provider default (e.g. ECR on AWS/EKS)")
+	cmd.Flags().StringVarP(&flags.DockerRegistryKind, "docker-registry-kind", "", "", fmt.Sprintf("The kind of Docker Registry to use instead of the in-cluster registry. Supported kinds: %s", strings.Join(DockerRegistryKinds, ", ")))
+	cmd.Flags().StringVarP(&flags.DockerRegistryOrg, "docker-registry-org", "", "", "The organisation of the external Docker Registry the images are pushed to. Defaults to the project for GCR or the user name for Docker Hub and Harbor")
+	cmd.Flags().StringVarP(&flags.DockerRegistryUsername, "docker-registry-username", "", "", "The user name used to push to Docker Hub or Harbor")
+	cmd.Flags().StringVarP(&flags.DockerRegistryPassword, "docker-registry-password", "", "", "The password or token used to push to Docker Hub or Harbor")
+	cmd.Flags().BoolVarP(&flags.SkipDockerRegistryCheck, "skip-docker-registry-check", "", false, "Skips pushing a test image to the external Docker Registry at the end of the install")
+	cmd.Flags().StringVarP(&flags.StorageBucketURL, "storage-bucket-url", "", "", "The bucket URL used to archive the build logs, test reports and artifacts of the pipelines. Supports gs://bucket, s3://bucket and s3://bucket?endpoint=http://minio:9000 for MinIO")
 	cmd.Flags().StringVarP(&flags.ExposeControllerPathMode, "exposecontroller-pathmode", "", "", "The ExposeController path mode for how services should be exposed as URLs. Defaults to using subnets. Use a value of `path` to use relative paths within the domain host such as when using AWS ELB host names")
 	cmd.Flags().StringVarP(&flags.Version, "version", "", "", "The specific platform version to install")
-	cmd.Flags().BoolVarP(&flags.Prow, "prow", "", false, "Enable Prow")
+	cmd.Flags().BoolVarP(&flags.Prow, "prow", "", false, "Enable Prow to handle the webhooks and ChatOps commands of the team. In GitOps mode the Prow configuration is added to the source of the development environment")
+	cmd.Flags().BoolVarP(&flags.Tekton, "tekton", "", false, "Runs the serverless pipelines with Tekton rather than Knative Build. Requires --prow")
 	cmd.Flags().BoolVarP(&flags.GitOpsMode, "gitops", "", false, "Sets up the local file system for GitOps so that the current installation can be configured or upgraded at any time via GitOps")
 	cmd.Flags().BoolVarP(&flags.NoGitOpsEnvApply, "no-gitops-env-apply", "", false, "When using GitOps to create the source code for the development environment and installation, don't run 'jx step env apply' to perform the install")
 	cmd.Flags().BoolVarP(&flags.NoGitOpsEnvRepo, "no-gitops-env-repo", "", false, "When using GitOps to create the source code for the development environment this flag disables the creation of a git repository for the source code")
 	cmd.Flags().BoolVarP(&flags.NoGitOpsVault, "no-gitops-vault", "", false, "When using GitOps to create the source code for the development environment this flag disables the creation of a vault")
 	cmd.Flags().BoolVarP(&flags.Vault, "vault", "", false, "Sets up a Hashicorp Vault for storing secrets during installation")
+	cmd.Flags().StringVarP(&flags.VaultURL, "vault-url", "", "", "The URL of an existing Hashicorp Vault to store the secrets in instead of deploying one")
+	cmd.Flags().StringVarP(&flags.VaultToken, "vault-token", "", "", "The token used to access the existing Hashicorp Vault. Defaults to $VAULT_TOKEN or the token saved by 'vault login'")
 	cmd.Flags().StringVarP(&flags.BuildPackName, "buildpack", "", "", "The name of the build pack to use for the Team")
+	cmd.Flags().StringSliceVarP(&flags.Exclude, "exclude", "", []string{}, fmt.Sprintf("The platform components to exclude from the install such as when you already operate them externally. Supported components: %s", strings.Join(config.PlatformComponents, ", ")))
+	cmd.Flags().StringVarP(&flags.TLSEmail, "tls-email", "", "", "The email address registered with Let's Encrypt when using --tls-acme. Defaults to the git user.email")
+	cmd.Flags().StringVarP(&flags.Backup.Provider, "backup-provider", "", "", "The cloud provider of the bucket Velero stores the backups in. Defaults to the provider of the cluster. One of: "+strings.Join(BackupProviders, ", "))
+	cmd.Flags().StringVarP(&flags.Backup.Bucket, "backup-bucket", "", "", "Installs Velero to back up Jenkins X to the given bucket")
+	cmd.Flags().StringVarP(&flags.Backup.CredentialsFile, "backup-credentials-file", "", "", "The file with the cloud credentials Velero uses to access the backup bucket. Defaults to the credentials of the nodes")
+	cmd.Flags().StringVarP(&flags.Backup.Schedule, "backup-schedule", "", "", "The cron expression of the scheduled backups such as '0 2 * * *'. Requires Velero")
+	cmd.Flags().StringVarP(&flags.RestoreFrom, "restore-from", "", "", "The name of the backup to restore before installing or 'latest' for the latest completed backup. Requires Velero")
+	flags.OAuth2Proxy.addFlags(cmd, "sso-")
+	cmd.Flags().BoolVarP(&flags.NetworkPolicies, "network-policies", "", false, "Isolates the namespaces of the environments and previews with NetworkPolicies which deny the traffic from other teams")
 
 	addGitRepoOptionsArguments(cmd, &options.GitRepositoryOptions)
 	options.HelmValuesConfig.AddExposeControllerValues(cmd, true)
@@ -369,6 +415,20 @@ func (options *InstallOptions) Run() error {
 	// Default to verbose mode to get more information during the install
 	options.Verbose = true
 
+	if options.Flags.OAuth2Proxy.Provider != "" {
+		err := validateOAuth2ProxyRestriction(options.Flags.OAuth2Proxy)
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.Flags.Cluster != "" {
+		err := options.useCloudCluster(options.Flags.Provider, options.Flags.Cluster, options.Flags.ClusterProjectID)
+		if err != nil {
+			return errors.Wrapf(err, "switching to the cluster %s", options.Flags.Cluster)
+		}
+	}
+
 	client, originalNs, err := options.KubeClient()
 	if err != nil {
 		return errors.Wrap(err, "creating the kube client")
@@ -498,6 +558,11 @@ func (options *InstallOptions) Run() error {
 		return errors.Wrap(err, "verifying Tiller is running")
 	}
 
+	err = options.restoreFromBackup()
+	if err != nil {
+		return errors.Wrap(err, "restoring from the backup")
+	}
+
 	err = options.configureBuildPackMode()
 	if err != nil {
 		return errors.Wrap(err, "configuring the build pack mode")
@@ -524,6 +589,31 @@ func (options *InstallOptions) Run() error {
 		}
 	}
 
+	var ingressConfig kube.IngressConfig
+	wildcardCertificate := false
+	if !options.Flags.GitOpsMode {
+		ic, err := kube.GetIngressConfig(options.KubeClientCached, ns)
+		if err != nil {
+			return errors.Wrap(err, "reading the ingress config")
+		}
+		ingressConfig = ic
+		err = options.configureTLS(ns, ic)
+		if err != nil {
+			return errors.Wrap(err, "configuring TLS")
+		}
+
+		initFlags := options.InitOptions.Flags
+		wildcardCertificate, err = options.configureWildcardCertificate(ns, ic, initFlags.DNSProvider, initFlags.DNSServiceAccount)
+		if err != nil {
+			log.Warnf("Failed to request a wildcard certificate for %s: %s\n", ic.Domain, err)
+		}
+
+		err = options.validateDockerRegistryPush()
+		if err != nil {
+			return errors.Wrap(err, "validating the Docker registry")
+		}
+	}
+
 	if options.Flags.CleanupTempFiles {
 		err := options.cleanupTempFiles(temporaryFiles)
 		if err != nil {
@@ -541,6 +631,11 @@ func (options *InstallOptions) Run() error {
 		return errors.Wrap(err, "configuring Tiller in the dev environment")
 	}
 
+	err = options.configureStorageInTeamSettings()
+	if err != nil {
+		return errors.Wrap(err, "configuring the storage bucket in team settings")
+	}
+
 	err = options.configureHelm3(ns)
 	if err != nil {
 		return errors.Wrap(err, "configuring helm3")
@@ -568,13 +663,18 @@ func (options *InstallOptions) Run() error {
 		return errors.Wrap(err, "saving the Chartmuseum auth configuration")
 	}
 
-	if options.Flags.RegisterLocalHelmRepo {
+	if options.Flags.RegisterLocalHelmRepo && options.CreateEnvOptions.HelmValuesConfig.IsComponentEnabled(config.ComponentChartmuseum) {
 		err = options.registerLocalHelmRepo(options.Flags.LocalHelmRepoName, ns)
 		if err != nil {
 			return errors.Wrapf(err, "registering the local helm repo '%s'", options.Flags.LocalHelmRepoName)
 		}
 	}
 
+	err = options.writeProwConfigFiles(gitOpsDir, ns)
+	if err != nil {
+		return errors.Wrap(err, "writing the Prow configuration into the GitOps development environment")
+	}
+
 	err = options.generateGitOpsDevEnvironmentConfig(gitOpsDir)
 	if err != nil {
 		return errors.Wrap(err, "generating the GitOps development environment config")
@@ -585,6 +685,22 @@ func (options *InstallOptions) Run() error {
 		return errors.Wrap(err, "applying the GitOps development environment config")
 	}
 
+	err = options.scheduleBackups()
+	if err != nil {
+		return errors.Wrap(err, "scheduling the backups")
+	}
+
+	err = options.configureOAuth2Proxy(ns)
+	if err != nil {
+		return errors.Wrap(err, "configuring the oauth2-proxy")
+	}
+
+	options.runPostLifecycleExtensions(v1.ExtensionWhenPostInstall, map[string]string{
+		"provider": options.Flags.Provider,
+	})
+
+	options.reportDomainReadiness(ns, ingressConfig, wildcardCertificate)
+
 	log.Successf("\nJenkins X installation completed successfully")
 
 	options.logAdminPassword()
@@ -657,6 +773,11 @@ func (options *InstallOptions) init() error {
 			env.Spec.TeamSettings.KubeProvider = options.Flags.Provider
 			log.Infof("Storing the kubernetes provider %s in the TeamSettings\n", env.Spec.TeamSettings.KubeProvider)
 		}
+		if options.Flags.NetworkPolicies {
+			env.Spec.TeamSettings.NetworkPolicy.Enabled = true
+			env.Spec.TeamSettings.NetworkPolicy.IngressNamespace = initOpts.Flags.IngressNamespace
+			log.Infof("Enabling the NetworkPolicies of the environment namespaces in the TeamSettings\n")
+		}
 		return nil
 	}
 	err := options.ModifyDevEnvironment(callback)
@@ -700,6 +821,9 @@ func (options *InstallOptions) getPlatformVersion(cloudEnvDir string,
 	configStore configio.ConfigStore) (string, error) {
 	version := options.Flags.Version
 	var err error
+	if version == "" {
+		version = options.resolveChartVersion(JenkinsXPlatformChart)
+	}
 	if version == "" {
 		version, err = LoadVersionFromCloudEnvironmentsDir(cloudEnvDir, configStore)
 		if err != nil {
@@ -730,13 +854,26 @@ func (options *InstallOptions) installPlatform(providerEnvDir string, jxChart st
 		options.Debugf("Adding values file %s\n", util.ColorInfo(f))
 	}
 
-	if !options.Flags.InstallOnly {
-		err = options.Helm().UpgradeChart(jxChart, jxRelName, namespace, &version, true,
-			&timeoutInt, false, false, nil, allValuesFiles, "", "", "")
-	} else {
-		err = options.Helm().InstallChart(jxChart, jxRelName, namespace, &version, &timeoutInt,
-			nil, allValuesFiles, "", "", "")
+	checkpoint := &Checkpoint{
+		Name: "install-" + namespace,
+		Step: fmt.Sprintf("installing the %s chart in namespace %s", jxChart, namespace),
+		Values: map[string]string{
+			"namespace": namespace,
+			"release":   jxRelName,
+			"version":   version,
+		},
+		Hint: fmt.Sprintf("Check the state of the release with 'helm status %s'. Resuming the command upgrades the release", jxRelName),
 	}
+	err = options.runCheckpointStep(checkpoint, func(values map[string]string) error {
+		// lets resume with the version which was being installed when interrupted
+		version := values["version"]
+		if !options.Flags.InstallOnly {
+			return options.Helm().UpgradeChart(jxChart, jxRelName, namespace, &version, true,
+				&timeoutInt, false, false, nil, allValuesFiles, "", "", "")
+		}
+		return options.Helm().InstallChart(jxChart, jxRelName, namespace, &version, &timeoutInt,
+			nil, allValuesFiles, "", "", "")
+	})
 	if err != nil {
 		return errors.Wrap(err, "failed to install/upgrade the jenkins-x platform chart")
 	}
@@ -830,6 +967,9 @@ func (options *InstallOptions) installPlatformGitOpsMode(gitOpsEnvDir string, gi
 
 func (options *InstallOptions) configureAndInstallProw(namespace string) error {
 	options.currentNamespace = namespace
+	if options.Flags.Tekton && !options.Flags.Prow {
+		return util.InvalidOptionf("tekton", "true", "Tekton can only be used with --prow")
+	}
 	if options.Flags.Prow {
 		_, pipelineUser, err := options.getPipelineGitAuth()
 		if err != nil {
@@ -838,7 +978,18 @@ func (options *InstallOptions) configureAndInstallProw(namespace string) error {
 		options.OAUTHToken = pipelineUser.ApiToken
 		err = options.installProw()
 		if err != nil {
-			errors.Wrap(err, "installing Prow")
+			return errors.Wrap(err, "installing Prow")
+		}
+		if options.Flags.Tekton {
+			tillerNamespace := ""
+			initFlags := options.InitOptions.Flags
+			if !initFlags.NoTiller && !initFlags.Helm3 {
+				tillerNamespace = initFlags.TillerNamespace
+			}
+			err = options.installTekton(namespace, tillerNamespace)
+			if err != nil {
+				return errors.Wrap(err, "installing Tekton")
+			}
 		}
 	}
 	return nil
@@ -954,6 +1105,20 @@ func (options *InstallOptions) configureHelmValues(namespace string) error {
 		enableControllerBuild := true
 		helmConfig.ControllerBuild.Enabled = &enableControllerBuild
 	}
+
+	for _, component := range options.Flags.Exclude {
+		if component == config.ComponentJenkins && !isProw {
+			return util.InvalidOptionf("exclude", component, "Jenkins can only be excluded when using --prow")
+		}
+		err = helmConfig.DisableComponent(component)
+		if err != nil {
+			return util.InvalidOptionError("exclude", component, err)
+		}
+		log.Infof("Excluding the %s component from the install\n", util.ColorInfo(component))
+	}
+	if !helmConfig.IsComponentEnabled(config.ComponentDockerRegistry) && options.Flags.DockerRegistry == "" {
+		log.Warnf("The %s component is excluded so you should specify your own registry via --docker-registry\n", config.ComponentDockerRegistry)
+	}
 	return nil
 }
 
@@ -1281,12 +1446,31 @@ func (options *InstallOptions) configureTillerInDevEnvironment() error {
 	return nil
 }
 
+func (options *InstallOptions) configureStorageInTeamSettings() error {
+	bucketURL := options.Flags.StorageBucketURL
+	if bucketURL == "" {
+		return nil
+	}
+	if _, err := buckets.ParseBucketURL(bucketURL); err != nil {
+		return err
+	}
+	callback := func(env *v1.Environment) error {
+		env.Spec.TeamSettings.StorageLocation("default").BucketURL = bucketURL
+		log.Infof("Archiving the pipeline logs and artifacts to the bucket %s\n", util.ColorInfo(bucketURL))
+		return nil
+	}
+	return options.ModifyDevEnvironment(callback)
+}
+
 func (options *InstallOptions) configureProwInTeamSettings() error {
 	if options.Flags.Prow {
 		callback := func(env *v1.Environment) error {
 			env.Spec.WebHookEngine = v1.WebHookEngineProw
 			settings := &env.Spec.TeamSettings
 			settings.PromotionEngine = v1.PromotionEngineProw
+			if options.Flags.Tekton {
+				settings.PipelineEngine = v1.PipelineEngineTekton
+			}
 			log.Info("Configuring the TeamSettings for Prow\n")
 			return nil
 		}
@@ -1344,6 +1528,30 @@ func (options *InstallOptions) configureGitOpsMode(configStore configio.ConfigSt
 	return gitOpsDir, gitOpsEnvDir, nil
 }
 
+// writeProwConfigFiles adds the Prow configuration and plugins to the source of the GitOps development environment
+// so that they can be changed via pull requests
+func (options *InstallOptions) writeProwConfigFiles(gitOpsDir string, namespace string) error {
+	if !options.Flags.GitOpsMode || !options.Flags.Prow {
+		return nil
+	}
+	kubeClient, _, err := options.KubeClient()
+	if err != nil {
+		return errors.Wrap(err, "creating the kube client")
+	}
+	prowOptions := prow.Options{
+		KubeClient: kubeClient,
+		NS:         namespace,
+	}
+	files, err := prowOptions.WriteConfigFiles(gitOpsDir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		log.Infof("Generated the Prow configuration %s\n", util.ColorInfo(file))
+	}
+	return nil
+}
+
 func (options *InstallOptions) generateGitOpsDevEnvironmentConfig(gitOpsDir string) error {
 	if options.Flags.GitOpsMode {
 		log.Infof("\n\nGenerated the source code for the GitOps development environment at %s\n", util.ColorInfo(gitOpsDir))
@@ -1592,10 +1800,30 @@ func (options *InstallOptions) configureDockerRegistry(client kubernetes.Interfa
 		}
 		helmConfig.Jenkins.Servers.Global.EnvVars["DOCKER_REGISTRY"] = dockerRegistry
 	}
+	if options.isExternalDockerRegistry() {
+		err = options.createDockerRegistryPullSecret(client, namespace, dockerRegistryConfig)
+		if err != nil {
+			return err
+		}
+		dockerRegistryOrg := options.Flags.DockerRegistryOrg
+		if dockerRegistryOrg != "" {
+			helmConfig.Jenkins.Servers.Global.EnvVars["DOCKER_REGISTRY_ORG"] = dockerRegistryOrg
+			err = options.ModifyDevEnvironment(func(env *v1.Environment) error {
+				env.Spec.TeamSettings.DockerRegistryOrg = dockerRegistryOrg
+				return nil
+			})
+			if err != nil {
+				return errors.Wrap(err, "storing the docker registry organisation in the team settings")
+			}
+		}
+	}
 	return nil
 }
 
 func (options *InstallOptions) configureCloudProviderRegistry(client kubernetes.Interface, namespace string) (string, string, error) {
+	if options.isExternalDockerRegistry() {
+		return options.configureExternalDockerRegistry()
+	}
 	dockerRegistry, err := options.dockerRegistryValue()
 	if err != nil {
 		return "", "", err
@@ -1751,6 +1979,9 @@ func (options *InstallOptions) getAdminSecrets(configStore configio.ConfigStore,
 }
 
 func (options *InstallOptions) createSystemVault(client kubernetes.Interface, namespace string) error {
+	if options.Flags.VaultURL != "" {
+		return options.connectExternalVault(client, namespace)
+	}
 	if options.Flags.GitOpsMode && !options.Flags.NoGitOpsVault || options.Flags.Vault {
 		err := InstallVaultOperator(&options.CommonOptions, "")
 		if err != nil {
@@ -1797,6 +2028,35 @@ func (options *InstallOptions) createSystemVault(client kubernetes.Interface, na
 	return nil
 }
 
+// connectExternalVault uses an existing vault which is not managed by the vault operator to store the secrets
+func (options *InstallOptions) connectExternalVault(client kubernetes.Interface, namespace string) error {
+	options.Flags.Vault = true
+	vaultClient, err := vault.NewExternalVaultClient(options.Flags.VaultURL, options.Flags.VaultToken)
+	if err != nil {
+		return errors.Wrapf(err, "connecting to the vault %s", options.Flags.VaultURL)
+	}
+	_, err = vaultClient.List("")
+	if err != nil {
+		return errors.Wrapf(err, "listing the secrets of the vault %s", options.Flags.VaultURL)
+	}
+	callback := func(env *v1.Environment) error {
+		env.Spec.TeamSettings.VaultURL = options.Flags.VaultURL
+		return nil
+	}
+	err = options.ModifyDevEnvironment(callback)
+	if err != nil {
+		return errors.Wrap(err, "saving the URL of the vault in the team settings")
+	}
+	err = secrets.NewSecretLocation(client, namespace).SetInVault(true)
+	if err != nil {
+		return errors.Wrap(err, "configuring secrets location")
+	}
+	log.Infof("Using the vault %s to store the secrets\n", util.ColorInfo(options.Flags.VaultURL))
+	log.Infof("Other commands access the vault when you have run 'vault login', passed --vault-token or run:\n%s\n",
+		util.ColorInfo(util.EnvVarInstructions(vault.TokenEnvVar, "<token>")))
+	return nil
+}
+
 func (options *InstallOptions) storeSecretYamlFilesInVault(path string, files ...string) error {
 	vaultClient, err := options.Factory.GetSystemVaultClient()
 	if err != nil {
@@ -1860,6 +2120,23 @@ func (options *InstallOptions) saveIngressConfig() error {
 		TLS:     tls,
 		Exposer: exposeController.Config.Exposer,
 	}
+	if tls {
+		// use a ClusterIssuer so that every namespace, including previews, gets HTTPS ingress rules
+		ic.ClusterIssuer = true
+		if domain == "" || isMagicDNSDomain(domain) {
+			log.Warnf("Let's Encrypt cannot issue certificates for %s so falling back to self signed certificates\n", domain)
+			ic.Issuer = kube.CertmanagerIssuerSelfSigned
+		} else {
+			ic.Issuer = kube.CertmanagerIssuerProd
+			ic.Email = options.Flags.TLSEmail
+			if ic.Email == "" {
+				ic.Email, err = options.Git().Email("")
+				if err != nil || ic.Email == "" {
+					return util.MissingOption("tls-email")
+				}
+			}
+		}
+	}
 	// save ingress config details to a configmap
 	_, err = options.saveAsConfigMap(kube.IngressConfigConfigmap, ic)
 	if err != nil {
@@ -2538,3 +2815,63 @@ func (options *InstallOptions) saveAsConfigMap(name string, config interface{})
 		return nil
 	})
 }
+
+// configureOAuth2Proxy installs the oauth2-proxy in front of the web UIs when an identity provider is given
+func (options *InstallOptions) configureOAuth2Proxy(ns string) error {
+	flags := options.Flags.OAuth2Proxy
+	if flags.Provider == "" {
+		return nil
+	}
+	err := options.installOAuth2Proxy(ns, kube.DefaultOAuth2ProxyReleaseName, "", flags, nil)
+	if err != nil {
+		return err
+	}
+	return options.recordAddon(v1.AddonSettings{
+		Name:      kube.DefaultOAuth2ProxyReleaseName,
+		Chart:     kube.ChartOAuth2Proxy,
+		Namespace: ns,
+	})
+}
+
+// restoreFromBackup installs Velero when a backup bucket is given and restores a backup when installing onto a new
+// cluster. The backup is restored before the platform is installed as Velero skips the resources which already exist
+func (options *InstallOptions) restoreFromBackup() error {
+	flags := options.Flags.Backup
+	if flags.Bucket != "" {
+		if flags.Provider == "" {
+			provider, err := defaultBackupProvider(options.Flags.Provider)
+			if err != nil {
+				return err
+			}
+			flags.Provider = provider
+		}
+		// the backups are scheduled once the environments of the team exist
+		flags.Schedule = ""
+		err := options.installVelero(kube.DefaultVeleroNamespace, kube.DefaultVeleroReleaseName, "", flags, nil)
+		if err != nil {
+			return err
+		}
+	}
+	if options.Flags.RestoreFrom == "" {
+		return nil
+	}
+	backup := options.Flags.RestoreFrom
+	if backup == "latest" {
+		backup = ""
+	}
+	return options.restoreBackup(kube.DefaultVeleroNamespace, backup, true, 30*time.Minute)
+}
+
+// scheduleBackups schedules the backups of the namespaces of the team once its environments are created
+func (options *InstallOptions) scheduleBackups() error {
+	flags := options.Flags.Backup
+	if flags.Schedule == "" {
+		return nil
+	}
+	err := options.createBackupSchedule(kube.DefaultVeleroNamespace, flags.Schedule, defaultBackupTTL)
+	if err != nil && flags.Bucket == "" {
+		log.Warnf("Failed to schedule the backups, is Velero installed? Use 'jx create addon velero' to install it: %s\n", err)
+		return nil
+	}
+	return err
+}
@@ -1,16 +1,27 @@
 package cmd
 
 import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/binaries"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
-	"io"
 )
 
 // InstallDependenciesFlags flags for the install command
 type InstallDependenciesFlags struct {
 	Dependencies []string
+	Provider     string
+	Path         string
+	List         bool
 }
 
 // InstallDependenciesOptions options for install dependencies
@@ -22,6 +33,9 @@ type InstallDependenciesOptions struct {
 var (
 	installDependenciesLong = templates.LongDesc(`
 		Installs required dependencies
+
+		Lists the binaries required by a Kubernetes provider, which of them are installed locally with their versions
+		and which would be downloaded. This is useful to bake the binaries into images used by CI agents.
 `)
 
 	installDependenciesExample = templates.Examples(`
@@ -29,8 +43,32 @@ var (
 		jx install dependencies
 
 		jx install dependencies -d gcloud
+
+		# Report the dependencies required by GKE without installing them
+		jx install dependencies --provider gke --list
+
+		# Install the missing dependencies of GKE into a directory
+		jx install dependencies --provider gke --path /usr/local/bin
+
+		# Install only kubectl and helm
+		jx install dependencies -d kubectl,helm
 `)
 
+	// dependencyVersionArgs the arguments used to report the version of a dependency, defaults to --version
+	dependencyVersionArgs = map[string][]string{
+		"az":        {"--version"},
+		"gcloud":    {"version"},
+		"helm":      {"version", "--client", "--short"},
+		"kops":      {"version"},
+		"kubectl":   {"version", "--client", "--short"},
+		"kustomize": {"version"},
+		"terraform": {"version"},
+		"eksctl":    {"version"},
+		"ibmcloud":  {"version"},
+		"minikube":  {"version"},
+		"oc":        {"version"},
+	}
+
 	availableDependencies = []string{
 		"az",
 		"kubectl",
@@ -79,7 +117,12 @@ func NewCmdInstallDependencies(f Factory, in terminal.FileReader, out terminal.F
 	}
 
 	options.addCommonFlags(cmd)
-	cmd.Flags().StringArrayVarP(&options.Flags.Dependencies, "dependencies", "d", []string{}, "The dependencies to install")
+	options.addVersionStreamFlags(cmd)
+	options.addSkipVerifyFlags(cmd)
+	cmd.Flags().StringSliceVarP(&options.Flags.Dependencies, "dependencies", "d", []string{}, "The dependencies to install, such as kubectl,helm. Can be specified multiple times")
+	cmd.Flags().StringVarP(&options.Flags.Provider, "provider", "", "", "The Kubernetes provider whose required dependencies are installed, such as gke or eks")
+	cmd.Flags().StringVarP(&options.Flags.Path, "path", "", "", "The directory the dependencies are installed into. Defaults to ~/.jx/bin")
+	cmd.Flags().BoolVarP(&options.Flags.List, "list", "l", false, "Lists the required dependencies, their installed versions and which would be downloaded without installing them")
 
 	return cmd
 }
@@ -100,9 +143,52 @@ func CreateInstallDependenciesOptions(f Factory, in terminal.FileReader, out ter
 
 // Run implements this command
 func (options *InstallDependenciesOptions) Run() error {
-	install := []string{}
+	flags := &options.Flags
+	if flags.Provider != "" && util.StringArrayIndex(KUBERNETES_PROVIDERS, flags.Provider) < 0 {
+		return util.InvalidOption("provider", flags.Provider, KUBERNETES_PROVIDERS)
+	}
+	if flags.Path != "" {
+		path, err := filepath.Abs(flags.Path)
+		if err != nil {
+			return err
+		}
+		// the dependencies are installed into the directory even if they are on the PATH or brew is available
+		err = os.Setenv(util.JXBinEnvVar, path)
+		if err != nil {
+			return err
+		}
+		options.NoBrew = true
+	}
 
-	if len(options.Flags.Dependencies) == 0 {
+	for _, dep := range flags.Dependencies {
+		if util.StringArrayIndex(availableDependencies, dep) < 0 {
+			return util.InvalidOption("dependencies", dep, availableDependencies)
+		}
+	}
+	install := append([]string{}, flags.Dependencies...)
+
+	if flags.Provider != "" || flags.List {
+		deps := install
+		if len(deps) == 0 {
+			deps = append(clusterDependencies(), providerDependencies(flags.Provider)...)
+		}
+		missing, err := options.reportDependencies(deps)
+		if err != nil {
+			return err
+		}
+		if flags.List {
+			return nil
+		}
+		if len(install) == 0 {
+			if len(missing) == 0 {
+				log.Infof("All the dependencies are installed\n")
+				return nil
+			}
+			install = missing
+		}
+	}
+
+	if len(install) == 0 {
 
 		prompt := &survey.MultiSelect{
 			Message: "What dependencies would you like to install:",
@@ -112,8 +198,6 @@ func (options *InstallDependenciesOptions) Run() error {
 		surveyOpts := survey.WithStdio(options.In, options.Out, options.Err)
 
 		survey.AskOne(prompt, &install, nil, surveyOpts)
-	} else {
-		install = append(install, options.Flags.Dependencies...)
 	}
 
 	if len(install) > 0 {
@@ -123,3 +207,60 @@ func (options *InstallDependenciesOptions) Run() error {
 	options.Debugf("No dependencies selected to install\n")
 	return nil
 }
+
+// reportDependencies prints the installed location and version of the dependencies and returns the ones which would
+// be downloaded
+func (options *InstallDependenciesOptions) reportDependencies(deps []string) ([]string, error) {
+	binDir, err := util.JXBinLocation()
+	if err != nil {
+		return nil, err
+	}
+	missing := []string{}
+	table := options.CreateTable()
+	table.AddRow("NAME", "VERSION", "LOCATION", "ACTION")
+	for _, dep := range deps {
+		path := options.dependencyLocation(dep, binDir)
+		if path == "" {
+			missing = append(missing, dep)
+			table.AddRow(dep, "", "", util.ColorWarning("download to "+binDir))
+			continue
+		}
+		table.AddRow(dep, options.dependencyVersion(path, dep), path, util.ColorInfo("installed"))
+	}
+	table.Render()
+	return missing, nil
+}
+
+// dependencyLocation returns the path of the installed dependency or an empty string if it is not installed
+func (options *InstallDependenciesOptions) dependencyLocation(dep string, binDir string) string {
+	fileName := binaries.BinaryWithExtension(dep)
+	if !util.JXBinOverridden() {
+		path, err := exec.LookPath(fileName)
+		if err == nil {
+			return path
+		}
+	}
+	path := filepath.Join(binDir, fileName)
+	exists, err := util.FileExists(path)
+	if err != nil || !exists {
+		return ""
+	}
+	return path
+}
+
+// dependencyVersion returns the first line of the version output of the dependency
+func (options *InstallDependenciesOptions) dependencyVersion(path string, dep string) string {
+	args := dependencyVersionArgs[dep]
+	if len(args) == 0 {
+		args = []string{"--version"}
+	}
+	cmd := util.Command{
+		Name: path,
+		Args: args,
+	}
+	output, err := cmd.RunWithoutRetry()
+	if err != nil {
+		return "unknown"
+	}
+	return strings.TrimSpace(strings.SplitN(strings.TrimSpace(output), "\n", 2)[0])
+}
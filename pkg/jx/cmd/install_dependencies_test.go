@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderDependencies(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"gcloud"}, providerDependencies(GKE))
+	assert.Equal(t, []string{"eksctl", "heptio-authenticator-aws"}, providerDependencies(EKS))
+	assert.Empty(t, providerDependencies(KUBERNETES))
+	assert.Empty(t, providerDependencies(""))
+}
+
+func TestInstallDependenciesInvalidFlags(t *testing.T) {
+	t.Parallel()
+
+	o := &InstallDependenciesOptions{
+		Flags: InstallDependenciesFlags{
+			Provider: "unknown",
+		},
+	}
+	err := o.Run()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid option: --provider unknown")
+
+	o = &InstallDependenciesOptions{
+		Flags: InstallDependenciesFlags{
+			Dependencies: []string{"kubectl", "kubeclt"},
+		},
+	}
+	err = o.Run()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid option: --dependencies kubeclt")
+}
+
+func TestInstallDependenciesListIntoPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake dependency is a shell script")
+	}
+	defer os.Unsetenv(util.JXBinEnvVar)
+
+	dir, err := ioutil.TempDir("", "test-install-dependencies")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	script := "#!/bin/sh\necho 'Client Version: v1.13.4'\necho 'more details'\n"
+	err = ioutil.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0755)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	o := &InstallDependenciesOptions{
+		CommonOptions: CommonOptions{
+			Factory: NewFactory(),
+			Out:     out,
+		},
+		Flags: InstallDependenciesFlags{
+			Dependencies: []string{"kubectl", "helm"},
+			Path:         dir,
+			List:         true,
+		},
+	}
+	err = o.Run()
+	require.NoError(t, err)
+
+	assert.Equal(t, dir, os.Getenv(util.JXBinEnvVar))
+	assert.True(t, o.NoBrew, "the dependencies should not be installed via brew into the path")
+	output := out.String()
+	assert.Contains(t, output, "Client Version: v1.13.4")
+	assert.NotContains(t, output, "more details")
+	assert.Contains(t, output, filepath.Join(dir, "kubectl"))
+	assert.Contains(t, output, "download to "+dir)
+
+	missing, err := o.reportDependencies([]string{"kubectl", "helm"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"helm"}, missing)
+}
@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	b64 "encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/cloud/amazon"
+	"github.com/jenkins-x/jx/pkg/cloud/gke"
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/kube/serviceaccount"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/vault"
+	"github.com/pkg/errors"
+	"gopkg.in/AlecAivazis/survey.v1"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DockerRegistryKindInCluster uses the Docker registry installed by the platform chart
+	DockerRegistryKindInCluster = "in-cluster"
+	// DockerRegistryKindGCR uses Google Container Registry
+	DockerRegistryKindGCR = "gcr"
+	// DockerRegistryKindECR uses AWS Elastic Container Registry
+	DockerRegistryKindECR = "ecr"
+	// DockerRegistryKindDockerHub uses Docker Hub
+	DockerRegistryKindDockerHub = "dockerhub"
+	// DockerRegistryKindHarbor uses a Harbor registry
+	DockerRegistryKindHarbor = "harbor"
+
+	// DockerRegistryPullSecret the name of the Secret used to pull images from an external registry
+	DockerRegistryPullSecret = "jx-registry-pull"
+
+	gcrServiceAccount     = "jx-registry"
+	dockerHubAuthHost     = "https://index.docker.io/v1/"
+	registryCheckImage    = "busybox:latest"
+	registryCheckRepoName = "jx-registry-check"
+)
+
+// DockerRegistryKinds the supported kinds of Docker registry
+var DockerRegistryKinds = []string{DockerRegistryKindInCluster, DockerRegistryKindGCR, DockerRegistryKindECR, DockerRegistryKindDockerHub, DockerRegistryKindHarbor}
+
+func (options *InstallOptions) isExternalDockerRegistry() bool {
+	kind := options.Flags.DockerRegistryKind
+	return kind != "" && kind != DockerRegistryKindInCluster
+}
+
+// configureExternalDockerRegistry returns the Docker config JSON and host of the external registry
+// then disables the in-cluster registry
+func (options *InstallOptions) configureExternalDockerRegistry() (string, string, error) {
+	flags := &options.Flags
+	helmConfig := &options.CreateEnvOptions.HelmValuesConfig
+
+	var err error
+	dockerConfig := ""
+	dockerRegistry := flags.DockerRegistry
+	switch flags.DockerRegistryKind {
+	case DockerRegistryKindGCR:
+		if dockerRegistry == "" {
+			dockerRegistry = "gcr.io"
+		}
+		dockerConfig, err = options.gcrDockerConfig(dockerRegistry)
+	case DockerRegistryKindECR:
+		if dockerRegistry == "" {
+			dockerRegistry, err = amazon.GetContainerRegistryHost()
+			if err != nil {
+				return "", "", errors.Wrap(err, "finding the ECR registry host")
+			}
+		}
+		// the pipelines authenticate via the ECR credential helper using the IAM role of the nodes
+		dockerConfig = `{"credsStore": "ecr-login"}`
+	case DockerRegistryKindDockerHub:
+		if dockerRegistry == "" {
+			dockerRegistry = "docker.io"
+		}
+		dockerConfig, err = options.basicAuthDockerConfig(dockerHubAuthHost)
+	case DockerRegistryKindHarbor:
+		if dockerRegistry == "" {
+			return "", "", util.MissingOption("docker-registry")
+		}
+		dockerConfig, err = options.basicAuthDockerConfig(dockerRegistry)
+	default:
+		return "", "", util.InvalidOption("docker-registry-kind", flags.DockerRegistryKind, DockerRegistryKinds)
+	}
+	if err != nil {
+		return "", "", errors.Wrapf(err, "configuring the %s registry credentials", flags.DockerRegistryKind)
+	}
+	flags.DockerRegistry = dockerRegistry
+
+	err = helmConfig.DisableComponent(config.ComponentDockerRegistry)
+	if err != nil {
+		return "", "", err
+	}
+	log.Infof("Using the external %s registry %s\n", flags.DockerRegistryKind, util.ColorInfo(dockerRegistry))
+	return dockerConfig, dockerRegistry, nil
+}
+
+// gcrDockerConfig creates a service account with push access to the storage bucket of GCR
+func (options *InstallOptions) gcrDockerConfig(dockerRegistry string) (string, error) {
+	projectID, err := gke.GetCurrentProject()
+	if err != nil {
+		return "", errors.Wrap(err, "finding the current Google Cloud project")
+	}
+	if options.Flags.DockerRegistryOrg == "" {
+		options.Flags.DockerRegistryOrg = projectID
+	}
+	dir, err := util.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	keyPath, err := gke.GetOrCreateServiceAccount(gcrServiceAccount, projectID, filepath.Join(dir, "gcr"), []string{"roles/storage.admin"})
+	if err != nil {
+		return "", err
+	}
+	key, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return "", errors.Wrapf(err, "reading service account key %s", keyPath)
+	}
+	if options.Flags.Vault {
+		vaultClient, err := options.Factory.GetSystemVaultClient()
+		if err != nil {
+			return "", errors.Wrap(err, "retrieving the system vault client")
+		}
+		err = vault.WriteServiceAccountKey(vaultClient, gcrServiceAccount, keyPath)
+		if err != nil {
+			return "", err
+		}
+	}
+	return dockerConfigJSON(dockerRegistry, "_json_key", string(key), "")
+}
+
+// basicAuthDockerConfig returns the Docker config JSON for the registry using the user name and password flags,
+// prompting for any missing values
+func (options *InstallOptions) basicAuthDockerConfig(authHost string) (string, error) {
+	flags := &options.Flags
+	surveyOpts := survey.WithStdio(options.In, options.Out, options.Err)
+	if flags.DockerRegistryUsername == "" {
+		if options.BatchMode {
+			return "", util.MissingOption("docker-registry-username")
+		}
+		prompt := &survey.Input{
+			Message: fmt.Sprintf("User name for the Docker registry %s:", authHost),
+		}
+		err := survey.AskOne(prompt, &flags.DockerRegistryUsername, survey.Required, surveyOpts)
+		if err != nil {
+			return "", err
+		}
+	}
+	if flags.DockerRegistryPassword == "" {
+		if options.BatchMode {
+			return "", util.MissingOption("docker-registry-password")
+		}
+		prompt := &survey.Password{
+			Message: fmt.Sprintf("Password or token for the Docker registry %s:", authHost),
+		}
+		err := survey.AskOne(prompt, &flags.DockerRegistryPassword, survey.Required, surveyOpts)
+		if err != nil {
+			return "", err
+		}
+	}
+	if flags.DockerRegistryOrg == "" {
+		flags.DockerRegistryOrg = flags.DockerRegistryUsername
+	}
+	return dockerConfigJSON(authHost, flags.DockerRegistryUsername, flags.DockerRegistryPassword, "")
+}
+
+func dockerConfigJSON(host string, user string, password string, email string) (string, error) {
+	dockerConfig := &Config{
+		Auths: map[string]*Auth{
+			host: {
+				Auth:  b64.StdEncoding.EncodeToString([]byte(user + ":" + password)),
+				Email: email,
+			},
+		},
+	}
+	data, err := json.Marshal(dockerConfig)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// createDockerRegistryPullSecret creates the image pull secret of the external registry and adds it to the
+// default service account of the namespace so that pods can pull the images built by the pipelines
+func (options *InstallOptions) createDockerRegistryPullSecret(client kubernetes.Interface, namespace string, dockerConfig string) error {
+	if strings.Contains(dockerConfig, "credsStore") {
+		// credential helpers only work inside the pipelines, the nodes pull using their own credentials
+		return nil
+	}
+	_, err := kube.DefaultModifySecret(client, namespace, DockerRegistryPullSecret, func(secret *core_v1.Secret) error {
+		secret.Type = core_v1.SecretTypeDockerConfigJson
+		secret.Data = map[string][]byte{
+			core_v1.DockerConfigJsonKey: []byte(dockerConfig),
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		return errors.Wrapf(err, "creating the image pull secret %s", DockerRegistryPullSecret)
+	}
+	return serviceaccount.PatchImagePullSecrets(client, namespace, "default", []string{DockerRegistryPullSecret})
+}
+
+// validateDockerRegistryPush checks the pipelines can push to the external registry by pushing a test image
+// using the generated Docker config
+func (options *InstallOptions) validateDockerRegistryPush() error {
+	if !options.isExternalDockerRegistry() || options.Flags.SkipDockerRegistryCheck {
+		return nil
+	}
+	dockerConfig := options.CreateEnvOptions.HelmValuesConfig.PipelineSecrets.DockerConfig
+	if strings.Contains(dockerConfig, "credsStore") {
+		log.Infof("Skipping the push check of %s as it uses a credential helper\n", options.Flags.DockerRegistry)
+		return nil
+	}
+	if _, err := exec.LookPath("docker"); err != nil {
+		log.Warnf("Skipping the push check of %s as docker is not on the PATH\n", options.Flags.DockerRegistry)
+		return nil
+	}
+
+	dir, err := ioutil.TempDir("", "jx-docker-config-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	err = ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte(dockerConfig), util.DefaultWritePermissions)
+	if err != nil {
+		return err
+	}
+
+	image := options.Flags.DockerRegistry + "/"
+	if options.Flags.DockerRegistryOrg != "" {
+		image += strings.ToLower(options.Flags.DockerRegistryOrg) + "/"
+	}
+	image += registryCheckRepoName + ":latest"
+
+	log.Infof("Checking the pipelines can push to %s\n", util.ColorInfo(image))
+	env := map[string]string{"DOCKER_CONFIG": dir}
+	steps := [][]string{
+		{"pull", registryCheckImage},
+		{"tag", registryCheckImage, image},
+		{"push", image},
+	}
+	for _, step := range steps {
+		cmd := util.Command{
+			Name: "docker",
+			Args: step,
+			Env:  env,
+		}
+		_, err := cmd.RunWithoutRetry()
+		if err != nil {
+			return errors.Wrapf(err, "pushing a test image to %s, check the registry credentials", options.Flags.DockerRegistry)
+		}
+	}
+	log.Successf("Pushed %s", image)
+	return nil
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDockerConfigJSON(t *testing.T) {
+	t.Parallel()
+
+	text, err := dockerConfigJSON("https://index.docker.io/v1/", "myuser", "s3cret", "")
+	require.NoError(t, err)
+
+	dockerConfig := &Config{}
+	err = json.Unmarshal([]byte(text), dockerConfig)
+	require.NoError(t, err)
+	require.Contains(t, dockerConfig.Auths, "https://index.docker.io/v1/")
+	auth, err := base64.StdEncoding.DecodeString(dockerConfig.Auths["https://index.docker.io/v1/"].Auth)
+	require.NoError(t, err)
+	assert.Equal(t, "myuser:s3cret", string(auth))
+}
+
+func TestConfigureExternalDockerRegistry(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		flags            InstallFlags
+		expectedRegistry string
+		expectedAuthHost string
+		expectedOrg      string
+		expectedConfig   string
+		expectedError    string
+	}{
+		{
+			name:             "dockerhub",
+			flags:            InstallFlags{DockerRegistryKind: DockerRegistryKindDockerHub, DockerRegistryUsername: "myuser", DockerRegistryPassword: "s3cret"},
+			expectedRegistry: "docker.io",
+			expectedAuthHost: dockerHubAuthHost,
+			expectedOrg:      "myuser",
+		},
+		{
+			name:             "harbor",
+			flags:            InstallFlags{DockerRegistryKind: DockerRegistryKindHarbor, DockerRegistry: "harbor.example.com", DockerRegistryOrg: "myproject", DockerRegistryUsername: "myuser", DockerRegistryPassword: "s3cret"},
+			expectedRegistry: "harbor.example.com",
+			expectedAuthHost: "harbor.example.com",
+			expectedOrg:      "myproject",
+		},
+		{
+			name:             "ecr",
+			flags:            InstallFlags{DockerRegistryKind: DockerRegistryKindECR, DockerRegistry: "123456789012.dkr.ecr.us-west-2.amazonaws.com"},
+			expectedRegistry: "123456789012.dkr.ecr.us-west-2.amazonaws.com",
+			expectedConfig:   `{"credsStore": "ecr-login"}`,
+		},
+		{
+			name:          "harbor without registry",
+			flags:         InstallFlags{DockerRegistryKind: DockerRegistryKindHarbor, DockerRegistryUsername: "myuser", DockerRegistryPassword: "s3cret"},
+			expectedError: "Missing option: --docker-registry",
+		},
+		{
+			name:          "dockerhub without password in batch mode",
+			flags:         InstallFlags{DockerRegistryKind: DockerRegistryKindDockerHub, DockerRegistryUsername: "myuser"},
+			expectedError: "Missing option: --docker-registry-password",
+		},
+		{
+			name:          "unknown kind",
+			flags:         InstallFlags{DockerRegistryKind: "quay"},
+			expectedError: "Invalid option: --docker-registry-kind quay",
+		},
+	}
+	for _, tc := range testCases {
+		o := &InstallOptions{
+			CommonOptions: CommonOptions{
+				BatchMode: true,
+			},
+			Flags: tc.flags,
+		}
+		dockerConfig, registry, err := o.configureExternalDockerRegistry()
+		if tc.expectedError != "" {
+			require.Error(t, err, tc.name)
+			assert.Contains(t, err.Error(), tc.expectedError, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedRegistry, registry, tc.name)
+		assert.Equal(t, tc.expectedRegistry, o.Flags.DockerRegistry, tc.name)
+		assert.Equal(t, tc.expectedOrg, o.Flags.DockerRegistryOrg, tc.name)
+		assert.False(t, o.CreateEnvOptions.HelmValuesConfig.IsComponentEnabled(config.ComponentDockerRegistry), tc.name)
+		if tc.expectedConfig != "" {
+			assert.Equal(t, tc.expectedConfig, dockerConfig, tc.name)
+			continue
+		}
+		expectedConfig, err := dockerConfigJSON(tc.expectedAuthHost, "myuser", "s3cret", "")
+		require.NoError(t, err)
+		assert.Equal(t, expectedConfig, dockerConfig, tc.name)
+	}
+}
+
+func TestCreateDockerRegistryPullSecret(t *testing.T) {
+	t.Parallel()
+
+	kubeClient := fake.NewSimpleClientset(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default",
+			Namespace: "jx",
+		},
+	})
+	dockerConfig, err := dockerConfigJSON(dockerHubAuthHost, "myuser", "s3cret", "")
+	require.NoError(t, err)
+
+	o := &InstallOptions{}
+	err = o.createDockerRegistryPullSecret(kubeClient, "jx", dockerConfig)
+	require.NoError(t, err)
+
+	secret, err := kubeClient.CoreV1().Secrets("jx").Get(DockerRegistryPullSecret, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, corev1.SecretTypeDockerConfigJson, secret.Type)
+	assert.Equal(t, dockerConfig, string(secret.Data[corev1.DockerConfigJsonKey]))
+
+	sa, err := kubeClient.CoreV1().ServiceAccounts("jx").Get("default", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: DockerRegistryPullSecret}}, sa.ImagePullSecrets)
+
+	err = o.createDockerRegistryPullSecret(kubeClient, "other", `{"credsStore": "ecr-login"}`)
+	require.NoError(t, err)
+	_, err = kubeClient.CoreV1().Secrets("other").Get(DockerRegistryPullSecret, metav1.GetOptions{})
+	assert.Error(t, err, "no pull secret should be created for a credential helper")
+}
+
+func TestValidateDockerRegistryPushSkipped(t *testing.T) {
+	t.Parallel()
+
+	o := &InstallOptions{}
+	assert.NoError(t, o.validateDockerRegistryPush(), "the in-cluster registry is not checked")
+
+	o.Flags.DockerRegistryKind = DockerRegistryKindECR
+	o.Flags.DockerRegistry = "123456789012.dkr.ecr.us-west-2.amazonaws.com"
+	o.CreateEnvOptions.HelmValuesConfig.PipelineSecrets.DockerConfig = `{"credsStore": "ecr-login"}`
+	assert.NoError(t, o.validateDockerRegistryPush(), "a credential helper is not checked")
+
+	o.Flags.DockerRegistryKind = DockerRegistryKindDockerHub
+	o.Flags.SkipDockerRegistryCheck = true
+	assert.NoError(t, o.validateDockerRegistryPush())
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"io"
 
+	"github.com/jenkins-x/jx/pkg/io/secrets"
 	"github.com/jenkins-x/jx/pkg/vault"
 
 	"github.com/heptio/sonobuoy/pkg/dynamic"
@@ -11,6 +12,7 @@ import (
 	"github.com/heptio/sonobuoy/pkg/client"
 	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/table"
+	"github.com/jenkins-x/jx/pkg/util"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 
 	"github.com/jenkins-x/golang-jenkins"
@@ -89,9 +91,21 @@ type Factory interface {
 	// UseVault indicates if the platform is using a Vault to manage the secrets
 	UseVault() bool
 
+	// SecretsLocation returns where the secrets of the team are stored
+	SecretsLocation() secrets.SecretsLocationKind
+
+	// SetSecretsLocation saves where the secrets of the team are stored
+	SetSecretsLocation(location secrets.SecretsLocationKind) error
+
 	// GetSystemVaultClient gets the system vault client for managing the secreets
 	GetSystemVaultClient() (vault.Client, error)
 
+	// SetVaultToken sets the token used to access an existing vault which is not managed by the vault operator
+	SetVaultToken(token string)
+
 	// GetVaultClient returns the vault client for given vault
 	GetVaultClient(name string, namespace string) (vault.Client, error)
+
+	// CreateCommandRunner creates the runner of external commands such as gcloud, terraform or kubectl
+	CreateCommandRunner() util.CommandRunner
 }
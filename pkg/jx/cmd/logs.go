@@ -33,12 +33,18 @@ type LogsOptions struct {
 	Label           string
 	EditEnvironment bool
 	KNativeBuild    bool
+	All             bool
+	Grep            string
+	Since           time.Duration
 }
 
 var (
 	logs_long = templates.LongDesc(`
 		Tails the logs of the newest pod for a Deployment.
 
+		Use --all to tail the logs of all the pods of an application merged together with each line prefixed by the
+		pod name. The pods created by a new rollout of the application are tailed as they start.
+
 `)
 
 	logs_example = templates.Examples(`
@@ -50,6 +56,12 @@ var (
 
 		# Tails the log of the latest Knative build pod
 		jx logs -k
+
+		# Tails the logs of all the pods of the application myapp in the staging environment
+		jx logs myapp --all -e staging
+
+		# Tails the errors logged by all the pods of myapp over the last 10 minutes
+		jx logs myapp --all --since 10m --grep ERROR
 `)
 )
 
@@ -83,6 +95,9 @@ func NewCmdLogs(f Factory, in terminal.FileReader, out terminal.FileWriter, errO
 	cmd.Flags().StringVarP(&options.Label, "label", "l", "", "The label to filter the pods if no deployment argument is provided")
 	cmd.Flags().BoolVarP(&options.KNativeBuild, "knative-build", "k", false, "View the logs of the latest Knative build pod")
 	cmd.Flags().BoolVarP(&options.EditEnvironment, "edit", "d", false, "Use my Edit Environment to look for the Deployment pods")
+	cmd.Flags().BoolVarP(&options.All, "all", "a", false, "Tail the logs of all the pods of the Deployment merged together")
+	cmd.Flags().StringVarP(&options.Grep, "grep", "g", "", "Only show the log lines matching this regular expression when using --all")
+	cmd.Flags().DurationVarP(&options.Since, "since", "s", 0, "Only show the logs newer than this duration such as 5m when using --all")
 	return cmd
 }
 
@@ -140,8 +155,32 @@ func (o *LogsOptions) Run() error {
 	} else {
 		name = args[0]
 		if util.StringArrayIndex(names, name) < 0 {
-			return util.InvalidArg(name, names)
+			// lets try find the deployment of the application
+			d, err := kube.FindAppDeployment(client, ns, name, name)
+			if err != nil || d == nil {
+				return util.InvalidArg(name, names)
+			}
+			name = d.Name
+		}
+	}
+
+	if o.All {
+		if name == "" {
+			return util.MissingArgument("deployment")
+		}
+		deployment, err := client.AppsV1beta1().Deployments(ns).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if deployment.Spec.Selector == nil {
+			return fmt.Errorf("No selector defined on Deployment %s in namespace %s", name, ns)
+		}
+		selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+		if err != nil {
+			return err
 		}
+		log.Infof("Tailing the logs of all the pods of Deployment %s in namespace %s\n", util.ColorInfo(name), util.ColorInfo(ns))
+		return o.tailAppLogs(client, ns, selector.String())
 	}
 
 	for {
@@ -201,14 +240,14 @@ func (o *CommonOptions) tailLogs(ns string, pod string, containerName string) er
 	os.Setenv("PATH", util.PathWithBinary())
 	err := e.Start()
 	if err != nil {
-		log.Errorf("Error: Command failed  %s %s\n", name, strings.Join(args, " "))
+		log.Errorf("Error: Command failed  %s %s\n", name, strings.Join(util.RedactArgs(args), " "))
 	}
 
 	scanner := bufio.NewScanner(stdout)
 	scanner.Split(bufio.ScanLines)
 	for scanner.Scan() {
 		m := scanner.Text()
-		fmt.Fprintln(o.Out, m)
+		fmt.Fprintln(o.Out, util.RedactSecrets(m))
 		if m == "Finished: FAILURE" {
 			os.Exit(1)
 		}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// appLogsPollInterval how often new pods of an application are looked for while tailing its logs
+	appLogsPollInterval = 2 * time.Second
+)
+
+var appLogColors = []color.Attribute{
+	color.FgCyan,
+	color.FgGreen,
+	color.FgMagenta,
+	color.FgYellow,
+	color.FgBlue,
+	color.FgHiCyan,
+	color.FgHiGreen,
+	color.FgHiMagenta,
+	color.FgHiYellow,
+	color.FgHiBlue,
+}
+
+// appLogLine a line logged by a container of a pod of an application
+type appLogLine struct {
+	pod       string
+	container string
+	text      string
+}
+
+// tailAppLogs streams the logs of all the pods matching the selector merging them with a prefix of the pod name.
+// Pods created by a rollout are picked up as they start so that tailing survives new versions of the application
+func (o *LogsOptions) tailAppLogs(client kubernetes.Interface, ns string, selector string) error {
+	var grep *regexp.Regexp
+	if o.Grep != "" {
+		var err error
+		grep, err = regexp.Compile(o.Grep)
+		if err != nil {
+			return util.InvalidOptionError("grep", o.Grep, err)
+		}
+	}
+	var sinceSeconds *int64
+	if o.Since > 0 {
+		seconds := int64(o.Since.Seconds())
+		sinceSeconds = &seconds
+	}
+
+	lines := make(chan appLogLine)
+	streaming := map[string]bool{}
+	ended := map[string]time.Time{}
+	var lock sync.Mutex
+	started := time.Now()
+
+	go func() {
+		for {
+			pods, err := client.CoreV1().Pods(ns).List(metav1.ListOptions{
+				LabelSelector: selector,
+			})
+			if err != nil {
+				log.Warnf("Failed to find pods in namespace %s with selector %s: %s\n", ns, selector, err)
+			} else {
+				for i := range pods.Items {
+					pod := &pods.Items[i]
+					if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning {
+						continue
+					}
+					for _, container := range pod.Spec.Containers {
+						if o.Container != "" && container.Name != o.Container {
+							continue
+						}
+						key := pod.Name + "/" + container.Name
+						lock.Lock()
+						active := streaming[key]
+						streaming[key] = true
+						endTime, reconnect := ended[key]
+						lock.Unlock()
+						if active {
+							continue
+						}
+						since := containerLogsSince(sinceSeconds, pod, started, endTime, reconnect, time.Now())
+						go func(podName string, containerName string, since *int64) {
+							err := streamContainerLogs(client, ns, podName, containerName, since, lines)
+							if err != nil {
+								log.Warnf("Failed to tail the logs of container %s of pod %s: %s\n", containerName, podName, err)
+							}
+							lock.Lock()
+							key := podName + "/" + containerName
+							delete(streaming, key)
+							ended[key] = time.Now()
+							lock.Unlock()
+						}(pod.Name, container.Name, since)
+					}
+				}
+			}
+			time.Sleep(appLogsPollInterval)
+		}
+	}()
+
+	o.writeAppLogs(lines, grep)
+	return nil
+}
+
+// containerLogsSince returns the number of seconds of logs to show when starting to stream the logs of a container
+// of the pod. A nil value shows all of the logs
+func containerLogsSince(sinceSeconds *int64, pod *corev1.Pod, started time.Time, endTime time.Time, reconnect bool, now time.Time) *int64 {
+	if reconnect {
+		// lets only show what was logged since the previous stream of the container ended
+		seconds := int64(now.Sub(endTime).Seconds()) + 1
+		return &seconds
+	}
+	if pod.CreationTimestamp.After(started) {
+		// lets show all of the logs of pods created after we started tailing
+		return nil
+	}
+	return sinceSeconds
+}
+
+// writeAppLogs writes the lines matching the grep expression prefixed by their pod until the channel is closed
+func (o *LogsOptions) writeAppLogs(lines <-chan appLogLine, grep *regexp.Regexp) {
+	colors := map[string]func(a ...interface{}) string{}
+	for line := range lines {
+		if grep != nil && !grep.MatchString(line.text) {
+			continue
+		}
+		colorFn := colors[line.pod]
+		if colorFn == nil {
+			colorFn = color.New(appLogColors[len(colors)%len(appLogColors)]).SprintFunc()
+			colors[line.pod] = colorFn
+		}
+		prefix := line.pod
+		if o.Container == "" {
+			prefix += " " + line.container
+		}
+		fmt.Fprintf(o.Out, "%s %s\n", colorFn(prefix), line.text)
+	}
+}
+
+// streamContainerLogs follows the log of the container of the pod sending each line to the channel until the
+// container terminates
+func streamContainerLogs(client kubernetes.Interface, ns string, podName string, containerName string, sinceSeconds *int64, lines chan<- appLogLine) error {
+	req := client.CoreV1().Pods(ns).GetLogs(podName, &corev1.PodLogOptions{
+		Container:    containerName,
+		Follow:       true,
+		SinceSeconds: sinceSeconds,
+	})
+	readCloser, err := req.Stream()
+	if err != nil {
+		return err
+	}
+	defer readCloser.Close()
+
+	scanner := bufio.NewScanner(readCloser)
+	for scanner.Scan() {
+		lines <- appLogLine{
+			pod:       podName,
+			container: containerName,
+			text:      scanner.Text(),
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newLogsAllTestOptions(selector *metav1.LabelSelector, args ...string) *LogsOptions {
+	deployment := &appsv1beta1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myapp",
+			Namespace: "jx",
+		},
+		Spec: appsv1beta1.DeploymentSpec{
+			Selector: selector,
+		},
+	}
+	o := &LogsOptions{
+		All: true,
+	}
+	o.Args = args
+	ConfigureTestOptionsWithResources(&o.CommonOptions, []runtime.Object{deployment}, []runtime.Object{}, gits.NewGitCLI(), nil)
+	return o
+}
+
+func TestLogsAllInvalidGrep(t *testing.T) {
+	t.Parallel()
+
+	o := newLogsAllTestOptions(&metav1.LabelSelector{
+		MatchLabels: map[string]string{"app": "myapp"},
+	}, "myapp")
+	o.Grep = "[error"
+
+	err := o.Run()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid option: --grep [error")
+}
+
+func TestLogsAllWithoutSelector(t *testing.T) {
+	t.Parallel()
+
+	o := newLogsAllTestOptions(nil, "myapp")
+
+	err := o.Run()
+	require.Error(t, err)
+	assert.Equal(t, "No selector defined on Deployment myapp in namespace jx", err.Error())
+}
+
+func TestLogsAllRequiresDeployment(t *testing.T) {
+	t.Parallel()
+
+	o := newLogsAllTestOptions(&metav1.LabelSelector{
+		MatchLabels: map[string]string{"app": "myapp"},
+	})
+	o.Label = "app=myapp"
+
+	err := o.Run()
+	require.Error(t, err)
+	assert.Equal(t, "Missing argument: deployment", err.Error())
+}
+
+func TestContainerLogsSince(t *testing.T) {
+	t.Parallel()
+
+	started := time.Date(2018, time.November, 1, 12, 0, 0, 0, time.UTC)
+	since := int64(300)
+	pod := func(created time.Time) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "myapp-1",
+				CreationTimestamp: metav1.NewTime(created),
+			},
+		}
+	}
+
+	assert.Equal(t, &since, containerLogsSince(&since, pod(started.Add(-time.Hour)), started, time.Time{}, false, started),
+		"pods running when we started tailing should use --since")
+	assert.Nil(t, containerLogsSince(nil, pod(started.Add(-time.Hour)), started, time.Time{}, false, started))
+	assert.Nil(t, containerLogsSince(&since, pod(started.Add(time.Minute)), started, time.Time{}, false, started.Add(time.Minute)),
+		"pods created after we started tailing should show all their logs")
+
+	endTime := started.Add(10 * time.Minute)
+	reconnected := containerLogsSince(&since, pod(started.Add(-time.Hour)), started, endTime, true, endTime.Add(5*time.Second))
+	if assert.NotNil(t, reconnected) {
+		assert.Equal(t, int64(6), *reconnected, "reconnecting should only show the logs since the previous stream ended")
+	}
+}
+
+func TestWriteAppLogs(t *testing.T) {
+	t.Parallel()
+
+	lines := make(chan appLogLine, 4)
+	lines <- appLogLine{pod: "myapp-1", container: "myapp", text: "INFO started"}
+	lines <- appLogLine{pod: "myapp-2", container: "myapp", text: "ERROR failed to connect"}
+	lines <- appLogLine{pod: "myapp-1", container: "sidecar", text: "ERROR timeout"}
+	lines <- appLogLine{pod: "myapp-2", container: "myapp", text: "INFO retrying"}
+	close(lines)
+
+	out := &bytes.Buffer{}
+	o := &LogsOptions{CommonOptions: CommonOptions{Out: out}}
+	o.writeAppLogs(lines, regexp.MustCompile("ERROR"))
+
+	output := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, output, 2)
+	assert.Contains(t, output[0], "myapp-2 myapp")
+	assert.True(t, strings.HasSuffix(output[0], " ERROR failed to connect"))
+	assert.Contains(t, output[1], "myapp-1 sidecar")
+	assert.True(t, strings.HasSuffix(output[1], " ERROR timeout"))
+}
+
+func TestWriteAppLogsOfContainer(t *testing.T) {
+	t.Parallel()
+
+	lines := make(chan appLogLine, 2)
+	lines <- appLogLine{pod: "myapp-1", container: "myapp", text: "INFO started"}
+	lines <- appLogLine{pod: "myapp-2", container: "myapp", text: "INFO retrying"}
+	close(lines)
+
+	out := &bytes.Buffer{}
+	o := &LogsOptions{CommonOptions: CommonOptions{Out: out}, Container: "myapp"}
+	o.writeAppLogs(lines, nil)
+
+	output := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, output, 2)
+	assert.NotContains(t, output[0], "myapp-1 myapp", "the container is not needed in the prefix when using --container")
+	assert.True(t, strings.HasSuffix(output[0], " INFO started"))
+	assert.True(t, strings.HasSuffix(output[1], " INFO retrying"))
+}
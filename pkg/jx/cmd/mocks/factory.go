@@ -12,8 +12,10 @@ import (
 	versioned0 "github.com/jenkins-x/jx/pkg/client/clientset/versioned"
 	gits "github.com/jenkins-x/jx/pkg/gits"
 	helm "github.com/jenkins-x/jx/pkg/helm"
+	secrets "github.com/jenkins-x/jx/pkg/io/secrets"
 	cmd "github.com/jenkins-x/jx/pkg/jx/cmd"
 	table "github.com/jenkins-x/jx/pkg/table"
+	util "github.com/jenkins-x/jx/pkg/util"
 	vault "github.com/jenkins-x/jx/pkg/vault"
 	versioned "github.com/knative/build/pkg/client/clientset/versioned"
 	pegomock "github.com/petergtz/pegomock"
@@ -145,6 +147,21 @@ func (mock *MockFactory) CreateChatAuthConfigService(_param0 *v1.SecretList) (au
 	return ret0, ret1
 }
 
+func (mock *MockFactory) CreateCommandRunner() util.CommandRunner {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockFactory().")
+	}
+	params := []pegomock.Param{}
+	result := pegomock.GetGenericMockFrom(mock).Invoke("CreateCommandRunner", params, []reflect.Type{reflect.TypeOf((*util.CommandRunner)(nil)).Elem()})
+	var ret0 util.CommandRunner
+	if len(result) != 0 {
+		if result[0] != nil {
+			ret0 = result[0].(util.CommandRunner)
+		}
+	}
+	return ret0
+}
+
 func (mock *MockFactory) CreateComplianceClient() (*client.SonobuoyClient, error) {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockFactory().")
@@ -521,6 +538,21 @@ func (mock *MockFactory) IsInCluster() bool {
 	return ret0
 }
 
+func (mock *MockFactory) SecretsLocation() secrets.SecretsLocationKind {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockFactory().")
+	}
+	params := []pegomock.Param{}
+	result := pegomock.GetGenericMockFrom(mock).Invoke("SecretsLocation", params, []reflect.Type{reflect.TypeOf((*secrets.SecretsLocationKind)(nil)).Elem()})
+	var ret0 secrets.SecretsLocationKind
+	if len(result) != 0 {
+		if result[0] != nil {
+			ret0 = result[0].(secrets.SecretsLocationKind)
+		}
+	}
+	return ret0
+}
+
 func (mock *MockFactory) SetBatch(_param0 bool) {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockFactory().")
@@ -529,6 +561,29 @@ func (mock *MockFactory) SetBatch(_param0 bool) {
 	pegomock.GetGenericMockFrom(mock).Invoke("SetBatch", params, []reflect.Type{})
 }
 
+func (mock *MockFactory) SetVaultToken(_param0 string) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockFactory().")
+	}
+	params := []pegomock.Param{_param0}
+	pegomock.GetGenericMockFrom(mock).Invoke("SetVaultToken", params, []reflect.Type{})
+}
+
+func (mock *MockFactory) SetSecretsLocation(_param0 secrets.SecretsLocationKind) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockFactory().")
+	}
+	params := []pegomock.Param{_param0}
+	result := pegomock.GetGenericMockFrom(mock).Invoke("SetSecretsLocation", params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var ret0 error
+	if len(result) != 0 {
+		if result[0] != nil {
+			ret0 = result[0].(error)
+		}
+	}
+	return ret0
+}
+
 func (mock *MockFactory) UseVault() bool {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockFactory().")
@@ -731,6 +786,23 @@ func (c *Factory_CreateChatAuthConfigService_OngoingVerification) GetAllCaptured
 	return
 }
 
+func (verifier *VerifierFactory) CreateCommandRunner() *Factory_CreateCommandRunner_OngoingVerification {
+	params := []pegomock.Param{}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "CreateCommandRunner", params)
+	return &Factory_CreateCommandRunner_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type Factory_CreateCommandRunner_OngoingVerification struct {
+	mock              *MockFactory
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *Factory_CreateCommandRunner_OngoingVerification) GetCapturedArguments() {
+}
+
+func (c *Factory_CreateCommandRunner_OngoingVerification) GetAllCapturedArguments() {
+}
+
 func (verifier *VerifierFactory) CreateComplianceClient() *Factory_CreateComplianceClient_OngoingVerification {
 	params := []pegomock.Param{}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "CreateComplianceClient", params)
@@ -1233,6 +1305,23 @@ func (c *Factory_IsInCluster_OngoingVerification) GetCapturedArguments() {
 func (c *Factory_IsInCluster_OngoingVerification) GetAllCapturedArguments() {
 }
 
+func (verifier *VerifierFactory) SecretsLocation() *Factory_SecretsLocation_OngoingVerification {
+	params := []pegomock.Param{}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "SecretsLocation", params)
+	return &Factory_SecretsLocation_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type Factory_SecretsLocation_OngoingVerification struct {
+	mock              *MockFactory
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *Factory_SecretsLocation_OngoingVerification) GetCapturedArguments() {
+}
+
+func (c *Factory_SecretsLocation_OngoingVerification) GetAllCapturedArguments() {
+}
+
 func (verifier *VerifierFactory) SetBatch(_param0 bool) *Factory_SetBatch_OngoingVerification {
 	params := []pegomock.Param{_param0}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "SetBatch", params)
@@ -1260,6 +1349,60 @@ func (c *Factory_SetBatch_OngoingVerification) GetAllCapturedArguments() (_param
 	return
 }
 
+func (verifier *VerifierFactory) SetVaultToken(_param0 string) *Factory_SetVaultToken_OngoingVerification {
+	params := []pegomock.Param{_param0}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "SetVaultToken", params)
+	return &Factory_SetVaultToken_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type Factory_SetVaultToken_OngoingVerification struct {
+	mock              *MockFactory
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *Factory_SetVaultToken_OngoingVerification) GetCapturedArguments() string {
+	_param0 := c.GetAllCapturedArguments()
+	return _param0[len(_param0)-1]
+}
+
+func (c *Factory_SetVaultToken_OngoingVerification) GetAllCapturedArguments() (_param0 []string) {
+	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(params) > 0 {
+		_param0 = make([]string, len(params[0]))
+		for u, param := range params[0] {
+			_param0[u] = param.(string)
+		}
+	}
+	return
+}
+
+func (verifier *VerifierFactory) SetSecretsLocation(_param0 secrets.SecretsLocationKind) *Factory_SetSecretsLocation_OngoingVerification {
+	params := []pegomock.Param{_param0}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "SetSecretsLocation", params)
+	return &Factory_SetSecretsLocation_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type Factory_SetSecretsLocation_OngoingVerification struct {
+	mock              *MockFactory
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *Factory_SetSecretsLocation_OngoingVerification) GetCapturedArguments() secrets.SecretsLocationKind {
+	_param0 := c.GetAllCapturedArguments()
+	return _param0[len(_param0)-1]
+}
+
+func (c *Factory_SetSecretsLocation_OngoingVerification) GetAllCapturedArguments() (_param0 []secrets.SecretsLocationKind) {
+	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(params) > 0 {
+		_param0 = make([]secrets.SecretsLocationKind, len(params[0]))
+		for u, param := range params[0] {
+			_param0[u] = param.(secrets.SecretsLocationKind)
+		}
+	}
+	return
+}
+
 func (verifier *VerifierFactory) UseVault() *Factory_UseVault_OngoingVerification {
 	params := []pegomock.Param{}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "UseVault", params)
@@ -10,6 +10,7 @@ import (
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
 
 	"sort"
 
@@ -21,6 +22,8 @@ import (
 
 type NamespaceOptions struct {
 	CommonOptions
+
+	Context string
 }
 
 const (
@@ -38,7 +41,10 @@ var (
 		jx ns
 
 		# Change the current namespace to 'cheese'
-		jx ns cheese`)
+		jx ns cheese
+
+		# Switch to the jx managed context of the cluster 'mycluster' created by 'jx create cluster' and change its namespace to 'cheese'
+		jx ns cheese --context mycluster`)
 )
 
 func NewCmdNamespace(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
@@ -63,6 +69,7 @@ func NewCmdNamespace(f Factory, in terminal.FileReader, out terminal.FileWriter,
 			CheckErr(err)
 		},
 	}
+	cmd.Flags().StringVarP(&options.Context, "context", "", "", "Switches to the jx managed context of the given cluster, or the context of the given name, before changing the namespace")
 	options.addCommonFlags(cmd)
 	return cmd
 }
@@ -72,6 +79,12 @@ func (o *NamespaceOptions) Run() error {
 	if err != nil {
 		return err
 	}
+	if o.Context != "" {
+		err = o.switchContext(config, po)
+		if err != nil {
+			return err
+		}
+	}
 	ns := ""
 	args := o.Args
 	if len(args) > 0 {
@@ -127,6 +140,24 @@ func (o *NamespaceOptions) Run() error {
 	return nil
 }
 
+// switchContext makes the context given by --context the current context so that the namespace is changed in it
+func (o *NamespaceOptions) switchContext(config *api.Config, po *clientcmd.PathOptions) error {
+	ctxName := kube.FindContext(config, o.Context)
+	if ctxName == "" {
+		return util.InvalidOption("context", o.Context, kube.JXContextNames(config))
+	}
+	if ctxName == config.CurrentContext {
+		return nil
+	}
+	config.CurrentContext = ctxName
+	err := clientcmd.ModifyConfig(po, *config, false)
+	if err != nil {
+		return fmt.Errorf("Failed to update the kube config %s", err)
+	}
+	fmt.Fprintf(o.Out, "Now using context '%s'.\n", util.ColorInfo(ctxName))
+	return nil
+}
+
 // GetNamespaceNames returns the sorted list of environment names
 func GetNamespaceNames(client kubernetes.Interface) ([]string, error) {
 	names := []string{}
@@ -1,12 +1,15 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
 
+	"github.com/pkg/browser"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/util"
 )
 
 type OpenOptions struct {
@@ -17,6 +20,9 @@ var (
 	open_long = templates.LongDesc(`
 		Opens a named service in the browser.
 
+		If no service is named the URLs of the services in the team namespace and the namespaces of all of its
+		environments are listed and the chosen one is opened.
+
 		You can use the '--url' argument to just display the URL without opening it`)
 
 	open_example = templates.Examples(`
@@ -26,8 +32,14 @@ var (
 		# Print the Nexus console URL but do not open a browser
 		jx open jenkins-x-sonatype-nexus -u
 
-		# List all the service URLs
-		jx open`)
+		# Open the Grafana dashboards of the monitoring addon
+		jx open grafana
+
+		# Pick one of the service URLs of the team and its environments to open
+		jx open
+
+		# List all the service URLs of the team and its environments
+		jx open -u`)
 )
 
 func NewCmdOpen(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
@@ -59,13 +71,47 @@ func NewCmdOpen(f Factory, in terminal.FileReader, out terminal.FileWriter, errO
 		},
 	}
 	options.addConsoleFlags(cmd)
+	cmd.AddCommand(NewCmdOpenGrafana(f, in, out, errOut))
+	cmd.AddCommand(NewCmdOpenSonar(f, in, out, errOut))
 	return cmd
 }
 
 func (o *OpenOptions) Run() error {
 	if len(o.Args) == 0 {
-		return o.GetURLOptions.Run()
+		return o.pickAndOpen()
 	}
 	name := o.Args[0]
 	return o.ConsoleOptions.Open(name, name)
 }
+
+// pickAndOpen lets the user pick one of the service URLs of the team and its environments and opens it
+func (o *OpenOptions) pickAndOpen() error {
+	o.All = o.Namespace == "" && o.Environment == ""
+	if o.OnlyViewURL || o.BatchMode {
+		return o.GetURLOptions.Run()
+	}
+	urls, err := o.findURLs()
+	if err != nil {
+		return err
+	}
+	if len(urls) == 0 {
+		return fmt.Errorf("No service URLs found. Services are exposed via: %s", util.ColorInfo("jx upgrade ingress"))
+	}
+	labels := []string{}
+	urlMap := map[string]string{}
+	for _, u := range urls {
+		label := u.Name
+		if u.Namespace != "" {
+			label = u.Namespace + "/" + u.Name
+		}
+		labels = append(labels, label)
+		urlMap[label] = u.URL
+	}
+	label, err := util.PickName(labels, "Pick the service to open:", "", o.In, o.Out, o.Err)
+	if err != nil {
+		return err
+	}
+	url := urlMap[label]
+	fmt.Fprintf(o.Out, "%s: %s\n", label, util.ColorInfo(url))
+	return browser.OpenURL(url)
+}
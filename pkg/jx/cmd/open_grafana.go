@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+)
+
+// OpenGrafanaOptions the options for the open grafana command
+type OpenGrafanaOptions struct {
+	ConsoleOptions
+
+	ReleaseName string
+}
+
+var (
+	openGrafanaLong = templates.LongDesc(`
+		Opens the Grafana dashboards of the monitoring addon in a browser.
+
+		You can install the monitoring addon via 'jx create addon prometheus'`)
+
+	openGrafanaExample = templates.Examples(`
+		# Open the Grafana dashboards in a browser
+		jx open grafana
+
+		# Print the Grafana URL but do not open a browser
+		jx open grafana -u`)
+)
+
+// NewCmdOpenGrafana creates the command
+func NewCmdOpenGrafana(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &OpenGrafanaOptions{
+		ConsoleOptions: ConsoleOptions{
+			GetURLOptions: GetURLOptions{
+				GetOptions: GetOptions{
+					CommonOptions: CommonOptions{
+						Factory: f,
+						In:      in,
+
+						Out: out,
+						Err: errOut,
+					},
+				},
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "grafana",
+		Short:   "Open the Grafana dashboards in a browser",
+		Long:    openGrafanaLong,
+		Example: openGrafanaExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addConsoleFlags(cmd)
+	cmd.Flags().StringVarP(&options.ReleaseName, optionRelease, "r", kube.DefaultKubePrometheusStackReleaseName, "The release name of the monitoring addon")
+	return cmd
+}
+
+// Run implements this command
+func (o *OpenGrafanaOptions) Run() error {
+	return o.ConsoleOptions.Open(o.ReleaseName+"-grafana", "Grafana")
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+)
+
+// OpenSonarOptions the options for the open sonar command
+type OpenSonarOptions struct {
+	ConsoleOptions
+
+	ReleaseName string
+}
+
+var (
+	openSonarLong = templates.LongDesc(`
+		Opens the SonarQube dashboard of the static analysis addon in a browser.
+
+		You can install the static analysis addon via 'jx create addon sonarqube'`)
+
+	openSonarExample = templates.Examples(`
+		# Open the SonarQube dashboard in a browser
+		jx open sonar
+
+		# Print the SonarQube URL but do not open a browser
+		jx open sonar -u`)
+)
+
+// NewCmdOpenSonar creates the command
+func NewCmdOpenSonar(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &OpenSonarOptions{
+		ConsoleOptions: ConsoleOptions{
+			GetURLOptions: GetURLOptions{
+				GetOptions: GetOptions{
+					CommonOptions: CommonOptions{
+						Factory: f,
+						In:      in,
+
+						Out: out,
+						Err: errOut,
+					},
+				},
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "sonar",
+		Short:   "Open the SonarQube dashboard in a browser",
+		Aliases: []string{"sonarqube"},
+		Long:    openSonarLong,
+		Example: openSonarExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addConsoleFlags(cmd)
+	cmd.Flags().StringVarP(&options.ReleaseName, optionRelease, "r", kube.DefaultSonarQubeReleaseName, "The release name of the SonarQube addon")
+	return cmd
+}
+
+// Run implements this command
+func (o *OpenSonarOptions) Run() error {
+	return o.ConsoleOptions.Open(o.ReleaseName+"-sonarqube", "SonarQube")
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestOpenListsTheURLsOfAllEnvironments(t *testing.T) {
+	t.Parallel()
+
+	out := &bytes.Buffer{}
+	o := &OpenOptions{}
+	o.Out = out
+	o.OnlyViewURL = true
+	configureURLTestOptions(&o.CommonOptions)
+
+	err := o.Run()
+	require.NoError(t, err)
+	assert.True(t, o.All, "the URLs of the team and all of its environments should be listed")
+
+	output := out.String()
+	assert.Contains(t, output, "Namespace")
+	assert.Contains(t, output, "http://jenkins.jx.1.2.3.4.nip.io")
+	assert.Contains(t, output, "http://myapp.jx-staging.1.2.3.4.nip.io")
+	assert.Contains(t, output, "http://myapp.jx-production.1.2.3.4.nip.io")
+	assert.NotContains(t, output, "grafana")
+}
+
+func TestOpenListsTheURLsOfTheEnvironment(t *testing.T) {
+	t.Parallel()
+
+	out := &bytes.Buffer{}
+	o := &OpenOptions{}
+	o.Out = out
+	o.OnlyViewURL = true
+	o.Namespace = "jx-staging"
+	configureURLTestOptions(&o.CommonOptions)
+
+	err := o.Run()
+	require.NoError(t, err)
+	assert.False(t, o.All)
+
+	output := out.String()
+	assert.Contains(t, output, "http://myapp.jx-staging.1.2.3.4.nip.io")
+	assert.NotContains(t, output, "http://jenkins.jx.1.2.3.4.nip.io")
+}
+
+func TestOpenWithoutServiceURLs(t *testing.T) {
+	t.Parallel()
+
+	o := &OpenOptions{}
+	ConfigureTestOptionsWithResources(&o.CommonOptions, []runtime.Object{}, []runtime.Object{}, gits.NewGitCLI(), nil)
+	o.BatchMode = false
+
+	err := o.Run()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "No service URLs found")
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/jenkins-x/jx/pkg/extensions"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+var (
+	pluginLong = templates.LongDesc(`
+		Lists and installs the plugins of a plugin index.
+
+		Any executable on the PATH whose name starts with "jx-" is a plugin: the executable jx-foo is invoked by
+		running 'jx foo'. Plugins are passed the current environment along with the variables JX_BIN, JX_HOME,
+		JX_NAMESPACE, JX_TEAM, JX_ENVIRONMENT and JX_KUBE_CONTEXT. JX_TEAM and JX_ENVIRONMENT are only passed when
+		the plugins are managed by the cluster so that local plugins start without contacting the cluster.
+
+		Plugins installed from a plugin index do not need to be on the PATH. Their downloads are verified against the
+		SHA256 checksums of the index.
+`)
+)
+
+// PluginOptions the options for the plugin commands
+type PluginOptions struct {
+	CommonOptions
+
+	IndexURL string
+}
+
+// NewCmdPlugin creates the command to manage plugins
+func NewCmdPlugin(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &PluginOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			In:      in,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "plugin ACTION [flags]",
+		Short:   "Lists and installs plugins which add sub commands to jx",
+		Long:    pluginLong,
+		Aliases: []string{"plugins"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.AddCommand(NewCmdPluginList(f, in, out, errOut))
+	cmd.AddCommand(NewCmdPluginInstall(f, in, out, errOut))
+	return cmd
+}
+
+// Run implements the plugin root command
+func (o *PluginOptions) Run() error {
+	return o.Cmd.Help()
+}
+
+// addPluginIndexFlags adds the flag to choose the plugin index
+func (o *PluginOptions) addPluginIndexFlags(cmd *cobra.Command) {
+	indexURL := os.Getenv(extensions.PluginIndexURLEnvVar)
+	if indexURL == "" {
+		indexURL = extensions.DefaultPluginIndexURL
+	}
+	cmd.Flags().StringVarP(&o.IndexURL, "index", "", indexURL, "The URL or file of the plugin index. Defaults to $"+extensions.PluginIndexURLEnvVar+" if set")
+}
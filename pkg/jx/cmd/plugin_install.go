@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/extensions"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+var (
+	pluginInstallLong = templates.LongDesc(`
+		Installs plugins from the plugin index so that they can be invoked as sub commands of jx.
+
+		Installing a plugin which is already installed upgrades it to the version of the index.
+`)
+
+	pluginInstallExample = templates.Examples(`
+		# install a plugin
+		jx plugin install foo
+
+		# the plugin is then invoked via
+		jx foo
+	`)
+)
+
+// PluginInstallOptions the options for installing plugins from a plugin index
+type PluginInstallOptions struct {
+	PluginOptions
+}
+
+// NewCmdPluginInstall creates the command to install plugins from a plugin index
+func NewCmdPluginInstall(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &PluginInstallOptions{
+		PluginOptions: PluginOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "install NAME...",
+		Short:   "Installs plugins from the plugin index",
+		Long:    pluginInstallLong,
+		Example: pluginInstallExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addPluginIndexFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *PluginInstallOptions) Run() error {
+	if len(o.Args) == 0 {
+		return util.MissingArgument("name")
+	}
+	index, err := extensions.LoadPluginIndex(o.IndexURL)
+	if err != nil {
+		return err
+	}
+	names := []string{}
+	for _, plugin := range index.Plugins {
+		names = append(names, plugin.Name)
+	}
+	for _, name := range o.Args {
+		plugin := index.Find(name)
+		if plugin == nil {
+			return util.InvalidArg(name, names)
+		}
+		path, err := extensions.InstallIndexPlugin(*plugin)
+		if err != nil {
+			return err
+		}
+		log.Infof("Installed plugin %s version %s into %s. Invoke it via %s\n", util.ColorInfo(plugin.Name),
+			util.ColorInfo(plugin.Version), path, util.ColorInfo("jx "+plugin.SubCommand))
+	}
+	return nil
+}
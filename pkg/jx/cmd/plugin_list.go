@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/extensions"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+var (
+	pluginListLong = templates.LongDesc(`
+		Lists the plugins of the plugin index and whether they are installed.
+
+		To list the plugins on your PATH use 'jx get plugins'.
+`)
+
+	pluginListExample = templates.Examples(`
+		# list the plugins of the default plugin index
+		jx plugin list
+
+		# list the plugins of a custom index
+		jx plugin list --index https://example.com/my-plugins/index.yaml
+	`)
+)
+
+// PluginListOptions the options for listing the plugins of a plugin index
+type PluginListOptions struct {
+	PluginOptions
+}
+
+// NewCmdPluginList creates the command to list the plugins of a plugin index
+func NewCmdPluginList(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &PluginListOptions{
+		PluginOptions: PluginOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "Lists the plugins of the plugin index",
+		Long:    pluginListLong,
+		Example: pluginListExample,
+		Aliases: []string{"ls"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addPluginIndexFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *PluginListOptions) Run() error {
+	index, err := extensions.LoadPluginIndex(o.IndexURL)
+	if err != nil {
+		return err
+	}
+	table := o.CreateTable()
+	table.AddRow("NAME", "COMMAND", "VERSION", "INSTALLED", "DESCRIPTION")
+	for _, plugin := range index.Plugins {
+		installed := ""
+		path, err := extensions.InstalledPluginPath(plugin)
+		if err != nil {
+			return err
+		}
+		if path != "" {
+			installed = util.ColorInfo("yes")
+		}
+		table.AddRow(plugin.Name, "jx "+plugin.SubCommand, plugin.Version, installed, plugin.Description)
+	}
+	table.Render()
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/notify"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
@@ -150,9 +151,9 @@ func (o *PreviewOptions) Run() error {
 		}
 	}
 	if o.PostPreviewJobTimeout != "" {
-		o.PostPreviewJobTimeoutDuration, err = time.ParseDuration(o.Timeout)
+		o.PostPreviewJobTimeoutDuration, err = time.ParseDuration(o.PostPreviewJobTimeout)
 		if err != nil {
-			return fmt.Errorf("Invalid duration format %s for option --%s: %s", o.Timeout, optionPostPreviewJobTimeout, err)
+			return fmt.Errorf("Invalid duration format %s for option --%s: %s", o.PostPreviewJobTimeout, optionPostPreviewJobTimeout, err)
 		}
 	}
 
@@ -298,11 +299,14 @@ func (o *PreviewOptions) Run() error {
 		}
 	}
 
+	created := false
+	previousURL := ""
 	environmentsResource := jxClient.JenkinsV1().Environments(ns)
 	env, err := environmentsResource.Get(o.Name, metav1.GetOptions{})
 	if err == nil {
 		// lets check for updates...
 		update := false
+		previousURL = env.Spec.PreviewGitSpec.ApplicationURL
 
 		spec := &env.Spec
 		source := &spec.Source
@@ -314,8 +318,8 @@ func (o *PreviewOptions) Run() error {
 			spec.Namespace = o.Namespace
 			update = true
 		}
-		if spec.Namespace != o.Namespace {
-			spec.Namespace = o.Namespace
+		if spec.PullRequestURL != o.PullRequestURL {
+			spec.PullRequestURL = o.PullRequestURL
 			update = true
 		}
 		if spec.Kind != v1.EnvironmentKindTypePreview {
@@ -335,7 +339,7 @@ func (o *PreviewOptions) Run() error {
 			update = true
 		}
 
-		gitSpec := spec.PreviewGitSpec
+		gitSpec := &spec.PreviewGitSpec
 		if gitSpec.BuildStatus != buildStatus {
 			gitSpec.BuildStatus = buildStatus
 			update = true
@@ -417,6 +421,7 @@ func (o *PreviewOptions) Run() error {
 		if err != nil {
 			return fmt.Errorf("Failed to create environment in namespace %s due to: %s", ns, err)
 		}
+		created = true
 		log.Infof("Created environment %s\n", util.ColorInfo(env.Name))
 	}
 
@@ -480,6 +485,13 @@ func (o *PreviewOptions) Run() error {
 		}
 	}
 
+	if url == "" {
+		// applications deployed as Knative Services scale to zero so use the URL of the service rather than waiting for pods
+		url = o.findKnativeServiceURL(o.Namespace, appNames)
+		if url != "" {
+			writePreviewURL(o, url)
+		}
+	}
 	if url == "" {
 		log.Warnf("Could not find the service URL in namespace %s for names %s\n", o.Namespace, strings.Join(appNames, ", "))
 	}
@@ -533,7 +545,7 @@ func (o *PreviewOptions) Run() error {
 		if err != nil {
 			return err
 		}
-		if env != nil && env.Spec.PreviewGitSpec.ApplicationURL == "" {
+		if env != nil && env.Spec.PreviewGitSpec.ApplicationURL != url {
 			env.Spec.PreviewGitSpec.ApplicationURL = url
 			_, err = environmentsResource.Update(env)
 			if err != nil {
@@ -543,23 +555,41 @@ func (o *PreviewOptions) Run() error {
 		log.Infof("Preview application is now available at: %s\n\n", util.ColorInfo(url))
 	}
 
-	stepPRCommentOptions := StepPRCommentOptions{
-		Flags: StepPRCommentFlags{
-			Owner:      o.GitInfo.Organisation,
-			Repository: o.GitInfo.Name,
-			Comment:    comment,
-			PR:         o.PullRequestName,
-		},
-		StepPROptions: StepPROptions{
-			StepOptions: StepOptions{
-				CommonOptions: o.CommonOptions,
+	// lets only comment when the preview is first created or its URL changes rather than on every new commit
+	if created || url != previousURL {
+		stepPRCommentOptions := StepPRCommentOptions{
+			Flags: StepPRCommentFlags{
+				Owner:      o.GitInfo.Organisation,
+				Repository: o.GitInfo.Name,
+				Comment:    comment,
+				PR:         o.PullRequestName,
 			},
-		},
-	}
-	stepPRCommentOptions.BatchMode = true
-	err = stepPRCommentOptions.Run()
-	if err != nil {
-		log.Warnf("Failed to comment on the Pull Request with owner %s repo %s: %s\n", o.GitInfo.Organisation, o.GitInfo.Name, err)
+			StepPROptions: StepPROptions{
+				StepOptions: StepOptions{
+					CommonOptions: o.CommonOptions,
+				},
+			},
+		}
+		stepPRCommentOptions.BatchMode = true
+		err = stepPRCommentOptions.Run()
+		if err != nil {
+			log.Warnf("Failed to comment on the Pull Request with owner %s repo %s: %s\n", o.GitInfo.Organisation, o.GitInfo.Name, err)
+		}
+
+		o.sendNotification(&notify.Event{
+			Kind:           notify.EventPreview,
+			Owner:          o.GitInfo.Organisation,
+			Repository:     o.GitInfo.Name,
+			Pipeline:       pipeline,
+			Build:          build,
+			Application:    o.Application,
+			Environment:    o.Name,
+			URL:            url,
+			BuildURL:       os.Getenv("BUILD_URL"),
+			PullRequestURL: o.PullRequestURL,
+		})
+	} else {
+		log.Infof("Preview environment %s is up to date with the latest commit\n", util.ColorInfo(o.Name))
 	}
 	return o.RunPostPreviewSteps(kubeClient, o.Namespace, url, pipeline, build)
 }
@@ -16,9 +16,11 @@ import (
 	typev1 "github.com/jenkins-x/jx/pkg/client/clientset/versioned/typed/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/issues"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/notify"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
@@ -59,11 +61,15 @@ type PromoteOptions struct {
 	NoWaitAfterMerge        bool
 	IgnoreLocalFiles        bool
 	NoWaitForUpdatePipeline bool
+	NoVerifyRollout         bool
+	IgnoreMissingScan       bool
 	Timeout                 string
 	PullRequestPollTime     string
 	Filter                  string
 	Alias                   string
 
+	CanaryOptions
+
 	// allow git to be configured externally before a PR is created
 	ConfigureGitCallback ConfigureGitFolderFn
 
@@ -78,7 +84,12 @@ type PromoteOptions struct {
 	GitInfo                 *gits.GitRepository
 	jenkinsURL              string
 	releaseResource         *v1.Release
+	applicationURL          string
+	issueTracker            issues.IssueProvider
 	ReleaseInfo             *ReleaseInfo
+
+	// rollback is enabled when 'jx rollback' reverts an environment to a previous version
+	rollback bool
 }
 
 type ReleaseInfo struct {
@@ -86,12 +97,17 @@ type ReleaseInfo struct {
 	FullAppName     string
 	Version         string
 	PullRequestInfo *gits.PullRequestInfo
+	// Canary the name of the canary which progressively rolls out the version
+	Canary string
 }
 
 var (
 	promote_long = templates.LongDesc(`
 		Promotes a version of an application to zero to many permanent environments.
 
+		For GitOps environments a Pull Request is created on the environment's Git repository which is merged once
+		its checks pass. Then the command waits for the new version to be rolled out and reports the running version.
+
 		For more documentation see: [https://jenkins-x.io/about/features/#promotion](https://jenkins-x.io/about/features/#promotion)
 
 `)
@@ -111,6 +127,10 @@ var (
 		# To promote a postgres chart using an alias
 		jx promote -f postgres --alias mydb
 
+		# Promote a version to production progressively shifting 10%, 25% then 50% of the traffic
+		# to the new version and rolling back if the success rate or latency regress
+		jx promote myapp --version 1.2.3 --env production --canary --steps 10,25,50
+
 		# To create or update a Preview Environment please see the 'jx preview' command
 		jx preview
 	`)
@@ -146,6 +166,7 @@ func NewCmdPromote(f Factory, in terminal.FileReader, out terminal.FileWriter, e
 	cmd.Flags().BoolVarP(&options.AllAutomatic, "all-auto", "", false, "Promote to all automatic environments in order")
 
 	options.addPromoteOptions(cmd)
+	options.addCanaryFlags(cmd)
 	return cmd
 }
 
@@ -165,7 +186,9 @@ func (options *PromoteOptions) addPromoteOptions(cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(&options.NoMergePullRequest, "no-merge", "", false, "Disables automatic merge of promote Pull Requests")
 	cmd.Flags().BoolVarP(&options.NoPoll, "no-poll", "", false, "Disables polling for Pull Request or Pipeline status")
 	cmd.Flags().BoolVarP(&options.NoWaitAfterMerge, "no-wait", "", false, "Disables waiting for completing promotion after the Pull request is merged")
+	cmd.Flags().BoolVarP(&options.NoVerifyRollout, "no-verify", "", false, "Disables waiting for the new version to be rolled out in the Environment")
 	cmd.Flags().BoolVarP(&options.IgnoreLocalFiles, "ignore-local-file", "", false, "Ignores the local file system when deducing the Git repository")
+	cmd.Flags().BoolVarP(&options.IgnoreMissingScan, optionIgnoreMissingScan, "", false, "Promotes even if there is no image scan to check against the security policy of the environment")
 }
 
 // Run implements this command
@@ -190,6 +213,11 @@ func (o *PromoteOptions) Run() error {
 	}
 	o.Application = app
 
+	err := o.CanaryOptions.validate()
+	if err != nil {
+		return err
+	}
+
 	jxClient, ns, err := o.JXClientAndDevNamespace()
 	if err != nil {
 		return err
@@ -258,7 +286,7 @@ func (o *PromoteOptions) Run() error {
 		if o.Environment == "" {
 			return util.MissingOption(optionEnvironment)
 		}
-		env, err := jxClient.JenkinsV1().Environments(ns).Get(o.Environment, metav1.GetOptions{})
+		env, err = jxClient.JenkinsV1().Environments(ns).Get(o.Environment, metav1.GetOptions{})
 		if err != nil {
 			return err
 		}
@@ -273,10 +301,7 @@ func (o *PromoteOptions) Run() error {
 
 	o.ReleaseInfo = releaseInfo
 	if !o.NoPoll {
-		err = o.WaitForPromotion(targetNS, env, releaseInfo)
-		if err != nil {
-			return err
-		}
+		err = o.waitForPromotionAndNotify(targetNS, env, releaseInfo)
 	}
 	return err
 }
@@ -319,7 +344,7 @@ func (o *PromoteOptions) PromoteAllAutomatic() error {
 			}
 			o.ReleaseInfo = releaseInfo
 			if !o.NoPoll {
-				err = o.WaitForPromotion(ns, &env, releaseInfo)
+				err = o.waitForPromotionAndNotify(ns, &env, releaseInfo)
 				if err != nil {
 					return err
 				}
@@ -375,6 +400,32 @@ func (o *PromoteOptions) Promote(targetNS string, env *v1.Environment, warnIfAut
 		}
 	}
 
+	err := o.checkSecurityPolicy(env, app, version)
+	if err != nil {
+		return releaseInfo, err
+	}
+
+	envName := ""
+	if env != nil {
+		envName = env.Name
+	}
+	err = o.runLifecycleExtensions(v1.ExtensionWhenPrePromote, map[string]string{
+		"app":                  app,
+		"version":              version,
+		"environment":          envName,
+		"environmentNamespace": targetNS,
+	})
+	if err != nil {
+		return releaseInfo, fmt.Errorf("failed to promote %s to %s: %s", app, targetNS, err)
+	}
+
+	// the canary is applied directly to the environments which are not promoted via GitOps
+	gitOps := env != nil && env.Spec.Source.URL != "" && env.Spec.Kind.IsPermanent()
+	err = o.createCanary(targetNS, releaseInfo, !gitOps)
+	if err != nil {
+		return releaseInfo, err
+	}
+
 	promoteKey := o.createPromoteKey(env)
 	if env != nil {
 		source := &env.Spec.Source
@@ -403,7 +454,6 @@ func (o *PromoteOptions) Promote(targetNS string, env *v1.Environment, warnIfAut
 		}
 	}
 
-	var err error
 	if !o.UseFakeHelm {
 		err := o.verifyHelmConfigured()
 		if err != nil {
@@ -455,6 +505,11 @@ func (o *PromoteOptions) PromoteViaPullRequest(env *v1.Environment, releaseInfo
 
 	title := app + " to " + versionName
 	message := fmt.Sprintf("Promote %s to version %s", app, versionName)
+	if o.rollback {
+		branchNameText = "rollback-" + app + "-" + versionName
+		title = "Rollback " + title
+		message = fmt.Sprintf("Rollback %s to version %s", app, versionName)
+	}
 
 	modifyRequirementsFn := func(requirements *helm.Requirements) error {
 		var err error
@@ -470,11 +525,21 @@ func (o *PromoteOptions) PromoteViaPullRequest(env *v1.Environment, releaseInfo
 	if o.FakePullRequests != nil {
 		info, err := o.FakePullRequests(env, modifyRequirementsFn, branchNameText, title, message, releaseInfo.PullRequestInfo)
 		releaseInfo.PullRequestInfo = info
+		releaseInfo.Version = version
 		return err
 	} else {
-		info, err := o.createEnvironmentPullRequest(env, modifyRequirementsFn, &branchNameText, &title, &message,
+		// the canary is added to the chart of the environment so that the environment pipeline keeps it
+		modifyDirFn := func(dir string) error {
+			err := modifyRequirementsInDir(dir, modifyRequirementsFn)
+			if err != nil {
+				return err
+			}
+			return o.addCanaryTemplate(dir)
+		}
+		info, err := o.createEnvironmentPullRequestForDir(env, modifyDirFn, &branchNameText, &title, &message,
 			releaseInfo.PullRequestInfo, o.ConfigureGitCallback)
 		releaseInfo.PullRequestInfo = info
+		releaseInfo.Version = version
 		return err
 	}
 }
@@ -526,6 +591,46 @@ func (o *PromoteOptions) GetTargetNamespace(ns string, env string) (string, *v1.
 	return targetNS, envResource, nil
 }
 
+// waitForPromotionAndNotify waits for the promotion then notifies the team whether it succeeded or failed. Nothing
+// is notified if the promotion is not waited for as its outcome is not known
+func (o *PromoteOptions) waitForPromotionAndNotify(ns string, env *v1.Environment, releaseInfo *ReleaseInfo) error {
+	if o.TimeoutDuration == nil || o.PullRequestPollDuration == nil {
+		return o.WaitForPromotion(ns, env, releaseInfo)
+	}
+	err := o.WaitForPromotion(ns, env, releaseInfo)
+	if err != nil {
+		o.notifyPromotion(notify.EventPromotionFailed, env, releaseInfo)
+		return err
+	}
+	o.notifyPromotion(notify.EventPromoted, env, releaseInfo)
+	return nil
+}
+
+// notifyPromotion notifies the team of the promotion of the release to the environment
+func (o *PromoteOptions) notifyPromotion(kind string, env *v1.Environment, releaseInfo *ReleaseInfo) {
+	if o.rollback || releaseInfo == nil {
+		return
+	}
+	event := &notify.Event{
+		Kind:        kind,
+		Pipeline:    o.Pipeline,
+		Build:       o.Build,
+		Application: o.Application,
+		Version:     releaseInfo.Version,
+		Environment: env.Spec.Label,
+		URL:         o.applicationURL,
+		BuildURL:    os.Getenv("BUILD_URL"),
+	}
+	if o.GitInfo != nil {
+		event.Owner = o.GitInfo.Organisation
+		event.Repository = o.GitInfo.Name
+	}
+	if event.Environment == "" {
+		event.Environment = env.Name
+	}
+	o.sendNotification(event)
+}
+
 func (o *PromoteOptions) WaitForPromotion(ns string, env *v1.Environment, releaseInfo *ReleaseInfo) error {
 	if o.TimeoutDuration == nil {
 		log.Infof("No --%s option specified on the 'jx promote' command so not waiting for the promotion to succeed\n", optionTimeout)
@@ -549,7 +654,79 @@ func (o *PromoteOptions) WaitForPromotion(ns string, env *v1.Environment, releas
 			return err
 		}
 	}
-	return nil
+	if o.NoVerifyRollout || o.NoWaitAfterMerge {
+		return nil
+	}
+	if releaseInfo.Canary != "" {
+		return o.waitForCanary(ns, releaseInfo, end)
+	}
+	return o.verifyRollout(ns, releaseInfo, end)
+}
+
+// verifyRollout waits for the promoted version of the application to be rolled out in the namespace of the
+// environment then reports the running version
+func (o *PromoteOptions) verifyRollout(ns string, releaseInfo *ReleaseInfo, end time.Time) error {
+	return o.waitForRollout(ns, o.Application, releaseInfo.ReleaseName, releaseInfo.Version, end, *o.PullRequestPollDuration)
+}
+
+// waitForRollout waits for the given version, or any version if empty, of the application to be rolled out in the
+// namespace then reports the running version
+func (o *CommonOptions) waitForRollout(ns string, app string, releaseName string, version string, end time.Time, pollDuration time.Duration) error {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	info := util.ColorInfo
+	logWaiting := false
+	for {
+		d, err := kube.FindAppDeployment(kubeClient, ns, releaseName, app)
+		if err != nil {
+			return fmt.Errorf("Failed to find the deployment of %s in namespace %s: %s", app, ns, err)
+		}
+		running := ""
+		if d != nil {
+			running = kube.GetVersion(&d.ObjectMeta)
+			if (version == "" || running == version) && kube.IsDeploymentRolledOut(d) {
+				log.Successf("Application %s version %s is running in namespace %s", info(app), info(running), info(ns))
+				return nil
+			}
+		} else {
+			s, err := kube.FindAppStatefulSet(kubeClient, ns, releaseName, app)
+			if err != nil {
+				return fmt.Errorf("Failed to find the statefulset of %s in namespace %s: %s", app, ns, err)
+			}
+			if s != nil {
+				running = kube.GetVersion(&s.ObjectMeta)
+				if (version == "" || running == version) && kube.IsStatefulSetRolledOut(s) {
+					log.Successf("Application %s version %s is running in namespace %s", info(app), info(running), info(ns))
+					return nil
+				}
+			} else {
+				// applications deployed as Knative Services have no deployment until a request scales them up
+				ksvc, err := o.findKnativeServiceStatus(ns, []string{app, releaseName})
+				if err != nil {
+					return err
+				}
+				if ksvc == nil {
+					log.Warnf("No deployment or statefulset found for %s in namespace %s so cannot verify the rollout\n", app, ns)
+					return nil
+				}
+				running = ksvc.Version
+				if (version == "" || running == version) && ksvc.Ready {
+					log.Successf("Application %s version %s is available in namespace %s at %s and scales to zero when idle", info(app), info(running), info(ns), info(ksvc.URL))
+					return nil
+				}
+			}
+		}
+		if !logWaiting {
+			logWaiting = true
+			log.Infof("Waiting for %s version %s to roll out in namespace %s\n", info(app), info(version), info(ns))
+		}
+		if time.Now().After(end) {
+			return fmt.Errorf("Timed out waiting for %s version %s to roll out in namespace %s, the running version is %s", app, version, ns, running)
+		}
+		time.Sleep(pollDuration)
+	}
 }
 
 // TODO This could do with a refactor and some tests...
@@ -721,7 +898,7 @@ func (o *PromoteOptions) waitForGitOpsPullRequest(ns string, env *v1.Environment
 	return nil
 }
 
-func (o *PromoteOptions) findLatestVersion(app string) (string, error) {
+func (o *CommonOptions) findLatestVersion(app string) (string, error) {
 	versions, err := o.Helm().SearchChartVersions(app)
 	if err != nil {
 		return "", err
@@ -737,7 +914,7 @@ func (o *PromoteOptions) findLatestVersion(app string) (string, error) {
 				maxString = version
 			}
 		} else {
-			if maxSemVer == nil || maxSemVer.Compare(sv) > 0 {
+			if maxSemVer == nil || maxSemVer.Compare(sv) < 0 {
 				maxSemVer = &sv
 			}
 		}
@@ -1045,6 +1222,9 @@ func (o *PromoteOptions) commentOnIssues(targetNS string, environment *v1.Enviro
 			break
 		}
 	}
+	if url == "" {
+		url = o.findKnativeServiceURL(ens, appNames)
+	}
 	if url == "" {
 		log.Warnf("Could not find the service URL in namespace %s for names %s\n", ens, strings.Join(appNames, ", "))
 	}
@@ -1069,6 +1249,8 @@ func (o *PromoteOptions) commentOnIssues(targetNS string, environment *v1.Enviro
 		}
 	}
 
+	o.applicationURL = url
+
 	// lets try update the PipelineActivity
 	if url != "" && promoteKey.ApplicationURL == "" {
 		promoteKey.ApplicationURL = url
@@ -1095,7 +1277,16 @@ func (o *PromoteOptions) commentOnIssues(targetNS string, environment *v1.Enviro
 				if id != "" {
 					number, err := strconv.Atoi(id)
 					if err != nil {
-						log.Warnf("Could not parse issue id %s for URL %s\n", id, issue.URL)
+						// issues of trackers such as Jira have keys like ABC-123 rather than numbers
+						tracker := o.projectIssueTracker()
+						if tracker == nil {
+							log.Warnf("Could not parse issue id %s for URL %s\n", id, issue.URL)
+						} else {
+							err = tracker.CreateIssueComment(id, comment)
+							if err != nil {
+								log.Warnf("Failed to add comment to issue %s: %s", issue.URL, err)
+							}
+						}
 					} else {
 						if number > 0 {
 							err = provider.CreateIssueComment(gitInfo.Organisation, gitInfo.Name, number, comment)
@@ -1107,6 +1298,7 @@ func (o *PromoteOptions) commentOnIssues(targetNS string, environment *v1.Enviro
 				}
 			}
 		}
+		o.transitionIssues(environment, release)
 	}
 	return nil
 }
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/flagger"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	optionCanary      = "canary"
+	optionCanarySteps = "steps"
+
+	defaultCanaryPort int32 = 80
+)
+
+// CanaryOptions the options for promoting via a canary which progressively shifts the traffic to the new version
+type CanaryOptions struct {
+	Canary             bool
+	Steps              string
+	Interval           string
+	Threshold          int
+	MinSuccessRate     float64
+	MaxRequestDuration float64
+
+	stepWeights []int
+	// canary the canary which is added to the chart of the GitOps environment
+	canary *flagger.Canary
+}
+
+func (o *CanaryOptions) addCanaryFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&o.Canary, optionCanary, "", false, "Promotes via a canary which progressively shifts traffic to the new version using Flagger, rolling back if the metrics regress. Requires the Istio and Flagger addons")
+	cmd.Flags().StringVarP(&o.Steps, optionCanarySteps, "", "10,25,50", "The comma separated percentages of traffic to shift to the new version at each step of the canary")
+	cmd.Flags().StringVarP(&o.Interval, "canary-interval", "", "1m", "The time to wait between each step of the canary")
+	cmd.Flags().IntVarP(&o.Threshold, "canary-threshold", "", 5, "The number of failed metric checks before the canary is rolled back")
+	cmd.Flags().Float64VarP(&o.MinSuccessRate, "canary-success-rate", "", 99, "The minimum percentage of requests which must succeed at each step of the canary")
+	cmd.Flags().Float64VarP(&o.MaxRequestDuration, "canary-max-duration", "", 500, "The maximum 99th percentile request duration in milliseconds at each step of the canary")
+}
+
+// validate parses the step weights of the canary
+func (o *CanaryOptions) validate() error {
+	if !o.Canary {
+		return nil
+	}
+	weights, err := flagger.ParseStepWeights(o.Steps)
+	if err != nil {
+		return util.InvalidOptionf(optionCanarySteps, o.Steps, "%s", err)
+	}
+	o.stepWeights = weights
+	return nil
+}
+
+// createCanary creates the Flagger canary for the deployment of the application in the namespace so that the
+// promoted version is rolled out progressively. The canary is applied to the namespace if apply is true otherwise it
+// is added to the chart of the environment by the promotion Pull Request. If the application is not yet deployed it
+// is promoted normally
+func (o *PromoteOptions) createCanary(ns string, releaseInfo *ReleaseInfo, apply bool) error {
+	if !o.Canary {
+		return nil
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	d, err := kube.FindAppDeployment(kubeClient, ns, releaseInfo.ReleaseName, o.Application)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find the deployment of %s in namespace %s", o.Application, ns)
+	}
+	if d == nil {
+		log.Warnf("No deployment of %s found in namespace %s so promoting without a canary\n", o.Application, ns)
+		return nil
+	}
+
+	port := defaultCanaryPort
+	svc, err := kubeClient.CoreV1().Services(ns).Get(o.Application, metav1.GetOptions{})
+	if err == nil && len(svc.Spec.Ports) > 0 {
+		port = svc.Spec.Ports[0].Port
+	}
+
+	canary := flagger.CreateCanary(&flagger.CreateCanaryArguments{
+		Name:               d.Name,
+		Deployment:         d.Name,
+		Port:               port,
+		Interval:           o.Interval,
+		Threshold:          o.Threshold,
+		StepWeights:        o.stepWeights,
+		MinSuccessRate:     o.MinSuccessRate,
+		MaxRequestDuration: o.MaxRequestDuration,
+	})
+	if apply {
+		dynamicClient, err := o.dynamicClient()
+		if err != nil {
+			return err
+		}
+		err = flagger.ApplyCanary(dynamicClient, ns, canary)
+		if err != nil {
+			return err
+		}
+	} else {
+		o.canary = canary
+	}
+	releaseInfo.Canary = canary.Name
+	log.Infof("Promoting %s via canary %s with steps %s\n", util.ColorInfo(o.Application), util.ColorInfo(canary.Name), util.ColorInfo(o.Steps))
+	return nil
+}
+
+// addCanaryTemplate adds the canary to the templates of the chart of the environment source in the given directory
+func (o *PromoteOptions) addCanaryTemplate(dir string) error {
+	if o.canary == nil {
+		return nil
+	}
+	requirementsFile, err := helm.FindRequirementsFileName(dir)
+	if err != nil {
+		return err
+	}
+	templatesDir := filepath.Join(filepath.Dir(requirementsFile), "templates")
+	err = os.MkdirAll(templatesDir, util.DefaultWritePermissions)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create directory %s", templatesDir)
+	}
+	data, err := flagger.CanaryYAML(o.canary)
+	if err != nil {
+		return err
+	}
+	fileName := filepath.Join(templatesDir, o.canary.Name+"-canary.yaml")
+	err = ioutil.WriteFile(fileName, data, util.DefaultWritePermissions)
+	if err != nil {
+		return errors.Wrapf(err, "failed to write %s", fileName)
+	}
+	return nil
+}
+
+// waitForCanary waits for the canary to progress through its steps failing if the canary is rolled back
+func (o *PromoteOptions) waitForCanary(ns string, releaseInfo *ReleaseInfo, end time.Time) error {
+	dynamicClient, err := o.dynamicClient()
+	if err != nil {
+		return err
+	}
+	info := util.ColorInfo
+	name := releaseInfo.Canary
+	progressing := false
+	lastWeight := -1
+	for {
+		status, err := flagger.GetCanaryStatus(dynamicClient, ns, name)
+		if err != nil {
+			return err
+		}
+		switch status.Phase {
+		case flagger.PhaseFailed:
+			return fmt.Errorf("Canary %s of %s version %s failed its analysis after %d failed checks and has been rolled back", name, o.Application, releaseInfo.Version, status.FailedChecks)
+		case flagger.PhaseSucceeded:
+			if progressing {
+				log.Successf("Canary %s of %s version %s succeeded and is now serving all the traffic in namespace %s", info(name), info(o.Application), info(releaseInfo.Version), info(ns))
+				return nil
+			}
+		case flagger.PhaseProgressing, flagger.PhasePromoting, flagger.PhaseFinalising:
+			progressing = true
+			if status.CanaryWeight != lastWeight {
+				lastWeight = status.CanaryWeight
+				log.Infof("Canary %s is %s with %s of the traffic\n", info(name), info(status.Phase), info(fmt.Sprintf("%d%%", status.CanaryWeight)))
+			}
+		}
+		if time.Now().After(end) {
+			return fmt.Errorf("Timed out waiting for the canary %s of %s version %s in namespace %s, the current phase is %s", name, o.Application, releaseInfo.Version, ns, status.Phase)
+		}
+		time.Sleep(*o.PullRequestPollDuration)
+	}
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/issues"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// projectIssueTracker lazily creates the issue tracker of the project being promoted returning nil if the local
+// files are ignored or the tracker cannot be created
+func (o *PromoteOptions) projectIssueTracker() issues.IssueProvider {
+	if o.issueTracker == nil && !o.IgnoreLocalFiles {
+		tracker, err := o.createIssueProvider("")
+		if err != nil {
+			log.Warnf("Could not create the issue tracker of the project: %s\n", err)
+			return nil
+		}
+		o.issueTracker = tracker
+	}
+	return o.issueTracker
+}
+
+// transitionIssues moves the issues of the release to the status configured in the issue tracker of the project
+// when the release is promoted to one of the transition environments of the tracker
+func (o *PromoteOptions) transitionIssues(env *v1.Environment, release *v1.Release) {
+	if o.IgnoreLocalFiles || release == nil || len(release.Spec.Issues) == 0 {
+		return
+	}
+	it, err := o.issueTrackerConfig("")
+	if err != nil {
+		log.Warnf("Could not load the issue tracker configuration of the project: %s\n", err)
+		return
+	}
+	if it == nil || !it.TransitionsOn(env.Name) {
+		return
+	}
+	// without a project any text which looks like a Jira key, such as a CVE identifier, would be transitioned
+	if it.Kind == issues.Jira && it.Project == "" {
+		log.Warnf("Cannot transition the issues of %s as the Jira issue tracker of the project has no project key. Please add one via: jx edit issuetracker --project\n", release.Spec.Version)
+		return
+	}
+	tracker := o.projectIssueTracker()
+	if tracker == nil {
+		return
+	}
+	transitioner, ok := tracker.(issues.IssueTransitioner)
+	if !ok {
+		log.Warnf("Cannot transition the issues of %s as the %s issue tracker does not support transitions\n", release.Spec.Version, it.Kind)
+		return
+	}
+	for _, issue := range release.Spec.Issues {
+		if it.Kind == issues.Jira && !strings.HasPrefix(issue.ID, strings.ToUpper(it.Project)+"-") {
+			continue
+		}
+		err = transitioner.TransitionIssue(issue.ID, it.Transition)
+		if err != nil {
+			log.Warnf("%s\n", err)
+			continue
+		}
+		log.Infof("Moved issue %s to %s\n", util.ColorInfo(issue.ID), util.ColorInfo(it.Transition))
+	}
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/security"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const optionIgnoreMissingScan = "ignore-missing-scan"
+
+// checkSecurityPolicy fails the promotion if the security policy of the team applies to the environment and the scan
+// of the image of the version found vulnerabilities more severe than the policy allows. The promotion also fails if
+// there is no scan to check, unless IgnoreMissingScan is set, so that the policy cannot be bypassed
+func (o *PromoteOptions) checkSecurityPolicy(env *v1.Environment, app string, version string) error {
+	if env == nil {
+		return nil
+	}
+	settings, err := o.TeamSettings()
+	if err != nil {
+		return err
+	}
+	policy := &settings.SecurityPolicy
+	if !policy.AppliesTo(env.Name) {
+		return nil
+	}
+	maxSeverity, err := security.ParseSeverity(policy.MaxSeverity)
+	if err != nil {
+		return errors.Wrap(err, "invalid security policy")
+	}
+	if version == "" {
+		return o.missingScan(fmt.Sprintf("no version of %s specified so the security policy of environment %s cannot be checked", app, env.Name))
+	}
+
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	selector := labels.SelectorFromSet(labels.Set{kube.LabelApp: app, kube.LabelVersion: version})
+	scans, err := jxClient.JenkinsV1().ImageScans(ns).List(metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return errors.Wrapf(err, "failed to find the image scans of %s version %s", app, version)
+	}
+	if len(scans.Items) == 0 {
+		return o.missingScan(fmt.Sprintf("no image scan found for %s version %s so the security policy of environment %s cannot be checked", app, version, env.Name))
+	}
+	for _, scan := range scans.Items {
+		exceeding := security.Exceeding(scan.Spec.Vulnerabilities, maxSeverity)
+		if len(exceeding) > 0 {
+			return fmt.Errorf("cannot promote %s version %s to %s as image %s has vulnerabilities above %s: %s",
+				app, version, env.Name, scan.Spec.Image, maxSeverity, security.Summary(exceeding))
+		}
+	}
+	log.Infof("The image of %s version %s complies with the security policy of environment %s\n", util.ColorInfo(app), util.ColorInfo(version), util.ColorInfo(env.Name))
+	return nil
+}
+
+// missingScan fails the promotion with the given reason unless missing scans are explicitly ignored
+func (o *PromoteOptions) missingScan(reason string) error {
+	if o.IgnoreMissingScan {
+		log.Warnf("Ignoring the security policy as %s\n", reason)
+		return nil
+	}
+	return fmt.Errorf("cannot promote as %s. Use --%s to promote anyway", reason, optionIgnoreMissingScan)
+}
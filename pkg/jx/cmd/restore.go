@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+var (
+	restoreLong = templates.LongDesc(`
+		Restores the state of Jenkins X from a backup created by Velero.
+
+		On a new cluster install Velero pointing at the bucket of the backups first, for example via
+		'jx create addon velero' or the --backup-bucket flag of 'jx install'. To create a new cluster and restore
+		into it in one go use the --restore-from flag of 'jx create cluster'.
+`)
+
+	restoreExample = templates.Examples(`
+		# Restore the latest completed backup
+		jx restore
+
+		# Restore a specific backup
+		jx restore --backup before-upgrade
+
+		# Create a new cluster restoring the latest backup of the bucket
+		jx create cluster gke --backup-bucket my-jx-backups --backup-credentials-file velero-sa.json --restore-from latest
+	`)
+)
+
+// RestoreOptions the options for the restore command
+type RestoreOptions struct {
+	CommonOptions
+
+	Backup          string
+	VeleroNamespace string
+	Wait            bool
+	Timeout         string
+}
+
+// NewCmdRestore creates a command object for the "restore" command
+func NewCmdRestore(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &RestoreOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			In:      in,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "restore",
+		Short:   "Restores the state of Jenkins X from a backup",
+		Long:    restoreLong,
+		Example: restoreExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	cmd.Flags().StringVarP(&options.Backup, "backup", "", "", "The name of the backup to restore. Defaults to the latest completed backup")
+	cmd.Flags().StringVarP(&options.VeleroNamespace, "velero-namespace", "", kube.DefaultVeleroNamespace, "The namespace Velero is installed in")
+	cmd.Flags().BoolVarP(&options.Wait, "wait", "w", true, "Waits for the restore to complete")
+	cmd.Flags().StringVarP(&options.Timeout, "timeout", "t", "30m", "The maximum duration to wait for the backup to be found and restored")
+	return cmd
+}
+
+// Run implements the command
+func (o *RestoreOptions) Run() error {
+	timeout, err := time.ParseDuration(o.Timeout)
+	if err != nil {
+		return util.InvalidOptionError("timeout", o.Timeout, err)
+	}
+	backup := o.Backup
+	if backup == "latest" {
+		backup = ""
+	}
+	return o.restoreBackup(o.VeleroNamespace, backup, o.Wait, timeout)
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+var (
+	resumeLong = templates.LongDesc(`
+		Resumes a long running command such as 'jx install' or 'jx create cluster gke terraform' which was interrupted.
+
+		When interrupted the commands record a checkpoint of what they were doing along with their arguments. Resuming
+		runs the command again with the same arguments from the directory it was run in.
+`)
+
+	resumeExample = templates.Examples(`
+		# Pick the interrupted command to resume
+		jx resume
+
+		# Resume an interrupted install
+		jx resume install-jx
+	`)
+)
+
+// ResumeOptions the options for the resume command
+type ResumeOptions struct {
+	CommonOptions
+}
+
+// NewCmdResume creates a command object for the "resume" command
+func NewCmdResume(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &ResumeOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			In:      in,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "resume [checkpoint]",
+		Short:   "Resumes an interrupted command",
+		Long:    resumeLong,
+		Example: resumeExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *ResumeOptions) Run() error {
+	names, err := listCheckpoints()
+	if err != nil {
+		return errors.Wrap(err, "listing the checkpoints of the interrupted commands")
+	}
+	name := ""
+	if len(o.Args) > 0 {
+		name = o.Args[0]
+		if util.StringArrayIndex(names, name) < 0 {
+			return util.InvalidArg(name, names)
+		}
+	} else {
+		if len(names) == 0 {
+			log.Infof("There are no interrupted commands to resume\n")
+			return nil
+		}
+		if o.BatchMode && len(names) > 1 {
+			return util.MissingArgument("checkpoint")
+		}
+		name, err = util.PickName(names, "Pick the interrupted command to resume:", "", o.In, o.Out, o.Err)
+		if err != nil {
+			return err
+		}
+	}
+	checkpoint, err := loadCheckpoint(name)
+	if err != nil {
+		return err
+	}
+	if checkpoint == nil || len(checkpoint.Args) == 0 {
+		return fmt.Errorf("the checkpoint %s does not record the command to resume", name)
+	}
+	binary, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "finding the jx binary")
+	}
+
+	command := "jx " + strings.Join(util.RedactArgs(checkpoint.Args), " ")
+	log.Infof("Resuming %s which was interrupted while %s\n", util.ColorInfo(command), checkpoint.Step)
+	e := exec.Command(binary, checkpoint.Args...)
+	e.Dir = checkpoint.Dir
+	e.Stdin = os.Stdin
+	e.Stdout = o.Out
+	e.Stderr = o.Err
+
+	// the resumed command handles the interrupts of the terminal and records the checkpoint again
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	return e.Run()
+}
@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RollbackOptions the options for the rollback command
+type RollbackOptions struct {
+	PromoteOptions
+
+	Direct bool
+}
+
+var (
+	rollback_long = templates.LongDesc(`
+		Rolls back an application in a permanent environment to its previous version.
+
+		The previous version is the newest version successfully promoted to the environment before the running version
+		which has not been rolled back already. Use --version to roll back to a specific version instead.
+
+		For GitOps environments a Pull Request reverting the version in the environment's Git repository is created and
+		merged once its checks pass. Environments using automatic promotion, or when --direct is specified, get the change
+		committed directly to the environment's Git repository instead. The command then waits for the previous version
+		to be rolled out and records the rollback in the PipelineActivity of the rolled back version.
+`)
+
+	rollback_example = templates.Examples(`
+		# Rolls back the myapp application in production to the previous version
+		jx rollback myapp --env production
+
+		# Rolls back to a specific version committing directly to the environment's Git repository
+		jx rollback myapp --env production --version 1.2.3 --direct
+	`)
+)
+
+// NewCmdRollback creates the new command for: jx rollback
+func NewCmdRollback(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &RollbackOptions{
+		PromoteOptions: PromoteOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "rollback [application]",
+		Short:   "Rolls back an application in an Environment to its previous version",
+		Long:    rollback_long,
+		Example: rollback_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+
+	cmd.Flags().StringVarP(&options.Environment, optionEnvironment, "e", "", "The Environment to roll back")
+	cmd.Flags().StringVarP(&options.Application, optionApplication, "a", "", "The Application to roll back")
+	cmd.Flags().StringVarP(&options.Version, "version", "v", "", "The Version to roll back to. Defaults to the version promoted to the Environment before the running version")
+	cmd.Flags().StringVarP(&options.Alias, "alias", "", "", "The optional alias used in the 'requirements.yaml' file")
+	cmd.Flags().StringVarP(&options.LocalHelmRepoName, "helm-repo-name", "r", kube.LocalHelmRepoName, "The name of the helm repository that contains the app")
+	cmd.Flags().StringVarP(&options.HelmRepositoryURL, "helm-repo-url", "u", helm.DefaultHelmRepositoryURL, "The Helm Repository URL to use for the App")
+	cmd.Flags().StringVarP(&options.ReleaseName, "release", "", "", "The name of the helm release")
+	cmd.Flags().StringVarP(&options.Timeout, optionTimeout, "t", "1h", "The timeout to wait for the rollback to succeed in the underlying Environment. The command fails if the timeout is exceeded or the rollback does not complete")
+	cmd.Flags().StringVarP(&options.PullRequestPollTime, optionPullRequestPollTime, "", "20s", "Poll time when waiting for a Pull Request to merge")
+	cmd.Flags().BoolVarP(&options.Direct, "direct", "", false, "Commits the rollback directly to the Environment's Git repository rather than creating a Pull Request")
+	cmd.Flags().BoolVarP(&options.NoHelmUpdate, "no-helm-update", "", false, "Allows the 'helm repo update' command if you are sure your local helm cache is up to date with the version you wish to roll back to")
+	cmd.Flags().BoolVarP(&options.NoMergePullRequest, "no-merge", "", false, "Disables automatic merge of rollback Pull Requests")
+	cmd.Flags().BoolVarP(&options.NoPoll, "no-poll", "", false, "Disables polling for Pull Request or Pipeline status")
+	cmd.Flags().BoolVarP(&options.NoWaitAfterMerge, "no-wait", "", false, "Disables waiting for completing the rollback after the Pull request is merged")
+	cmd.Flags().BoolVarP(&options.NoVerifyRollout, "no-verify", "", false, "Disables waiting for the previous version to be rolled out in the Environment")
+	return cmd
+}
+
+// Run implements this command
+func (o *RollbackOptions) Run() error {
+	app := o.Application
+	if app == "" {
+		var err error
+		if len(o.Args) > 0 {
+			app = o.Args[0]
+		} else {
+			app, err = o.DiscoverAppName()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if app == "" {
+		return util.MissingOption(optionApplication)
+	}
+	o.Application = app
+	o.IgnoreLocalFiles = true
+	o.rollback = true
+
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+
+	if o.Environment == "" && !o.BatchMode {
+		names := []string{}
+		m, allEnvNames, err := kube.GetOrderedEnvironments(jxClient, ns)
+		if err != nil {
+			return err
+		}
+		for _, n := range allEnvNames {
+			env := m[n]
+			if env.Spec.Kind == v1.EnvironmentKindTypePermanent {
+				names = append(names, n)
+			}
+		}
+		o.Environment, err = kube.PickEnvironment(names, "", o.In, o.Out, o.Err)
+		if err != nil {
+			return err
+		}
+	}
+	if o.Environment == "" {
+		return util.MissingOption(optionEnvironment)
+	}
+
+	if o.PullRequestPollTime != "" {
+		duration, err := time.ParseDuration(o.PullRequestPollTime)
+		if err != nil {
+			return fmt.Errorf("Invalid duration format %s for option --%s: %s", o.PullRequestPollTime, optionPullRequestPollTime, err)
+		}
+		o.PullRequestPollDuration = &duration
+	}
+	if o.Timeout != "" {
+		duration, err := time.ParseDuration(o.Timeout)
+		if err != nil {
+			return fmt.Errorf("Invalid duration format %s for option --%s: %s", o.Timeout, optionTimeout, err)
+		}
+		o.TimeoutDuration = &duration
+	}
+
+	targetNS, env, err := o.GetTargetNamespace("", o.Environment)
+	if err != nil {
+		return err
+	}
+	if o.ReleaseName == "" {
+		o.ReleaseName = targetNS + "-" + app
+	}
+
+	o.Activities = jxClient.JenkinsV1().PipelineActivities(ns)
+	activityList, err := o.Activities.List(metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to load the PipelineActivities in namespace %s: %s", ns, err)
+	}
+	activities := activityList.Items
+
+	current, err := o.runningVersion(targetNS, app)
+	if err != nil {
+		return err
+	}
+	if current == "" {
+		promoted := kube.PromotedVersions(activities, app, env.Name)
+		if len(promoted) > 0 {
+			current = promoted[0]
+		}
+	}
+	if o.Version == "" {
+		o.Version = kube.PreviousPromotedVersion(activities, app, env.Name, current)
+		if o.Version == "" {
+			return fmt.Errorf("Could not find a version of %s promoted to %s before version %s. Please specify the version to roll back to via --version", app, env.Name, current)
+		}
+	}
+	if o.Version == current {
+		return fmt.Errorf("Version %s of %s is already running in %s", current, app, env.Name)
+	}
+
+	// lets record the promotion steps in the activity of the version we are rolling back to
+	target := kube.FindActivityForVersion(activities, app, o.Version)
+	if target != nil {
+		o.Pipeline = target.Spec.Pipeline
+		o.Build = target.Spec.Build
+	}
+
+	info := util.ColorInfo
+	log.Infof("Rolling back %s in environment %s from version %s to %s\n", info(app), info(env.Name), info(current), info(o.Version))
+
+	started := time.Now()
+	err = o.rollbackEnvironment(targetNS, env)
+
+	status := v1.ActivityStatusTypeSucceeded
+	if err != nil {
+		status = v1.ActivityStatusTypeFailed
+	}
+	recordErr := o.recordRollback(activities, env, current, status, started)
+	if recordErr != nil {
+		log.Warnf("Failed to record the rollback in the PipelineActivity: %s\n", recordErr)
+	}
+	if err != nil {
+		return err
+	}
+	log.Infof("Rolled back %s in environment %s to version %s\n", info(app), info(env.Name), info(o.Version))
+	return nil
+}
+
+// rollbackEnvironment reverts the version of the application in the environment either by promoting the previous
+// version or, for automatic or direct rollbacks, by committing the change directly to the environment's Git repository
+func (o *RollbackOptions) rollbackEnvironment(targetNS string, env *v1.Environment) error {
+	app := o.Application
+	version := o.Version
+	source := &env.Spec.Source
+	direct := o.Direct || env.Spec.PromotionStrategy == v1.PromotionStrategyTypeAutomatic
+	if direct && source.URL != "" && env.Spec.Kind.IsPermanent() {
+		modifyRequirementsFn := func(requirements *helm.Requirements) error {
+			requirements.SetAppVersion(app, version, o.HelmRepositoryURL, o.Alias)
+			return nil
+		}
+		message := fmt.Sprintf("Rollback %s to version %s", app, version)
+		_, err := o.commitEnvironmentChanges(env, modifyRequirementsFn, message, o.ConfigureGitCallback)
+		if err != nil {
+			return err
+		}
+		if o.NoPoll || o.NoVerifyRollout || o.TimeoutDuration == nil || o.PullRequestPollDuration == nil {
+			return nil
+		}
+		return o.waitForRollout(targetNS, app, o.ReleaseName, version, time.Now().Add(*o.TimeoutDuration), *o.PullRequestPollDuration)
+	}
+
+	releaseInfo, err := o.Promote(targetNS, env, false)
+	if err != nil {
+		return err
+	}
+	o.ReleaseInfo = releaseInfo
+	if o.NoPoll {
+		return nil
+	}
+	return o.WaitForPromotion(targetNS, env, releaseInfo)
+}
+
+// runningVersion returns the version of the application running in the namespace or an empty string if it could
+// not be found
+func (o *RollbackOptions) runningVersion(ns string, app string) (string, error) {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return "", err
+	}
+	d, err := kube.FindAppDeployment(kubeClient, ns, o.ReleaseName, app)
+	if err != nil {
+		return "", fmt.Errorf("Failed to find the deployment of %s in namespace %s: %s", app, ns, err)
+	}
+	if d != nil {
+		return kube.GetVersion(&d.ObjectMeta), nil
+	}
+	s, err := kube.FindAppStatefulSet(kubeClient, ns, o.ReleaseName, app)
+	if err != nil {
+		return "", fmt.Errorf("Failed to find the statefulset of %s in namespace %s: %s", app, ns, err)
+	}
+	if s != nil {
+		return kube.GetVersion(&s.ObjectMeta), nil
+	}
+	return "", nil
+}
+
+// recordRollback adds the rollback to the timeline of the PipelineActivity of the rolled back version
+func (o *RollbackOptions) recordRollback(activities []v1.PipelineActivity, env *v1.Environment, current string, status v1.ActivityStatusType, started time.Time) error {
+	if current == "" {
+		return nil
+	}
+	activity := kube.FindActivityForVersion(activities, o.Application, current)
+	if activity == nil {
+		log.Warnf("No PipelineActivity found for version %s of %s so cannot record the rollback\n", current, o.Application)
+		return nil
+	}
+	// lets reload the activity as it may have been modified while rolling back
+	latest, err := o.Activities.Get(activity.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	kube.AddRollbackStage(latest, env.Name, o.Version, status, started)
+	_, err = o.Activities.Update(latest)
+	return err
+}
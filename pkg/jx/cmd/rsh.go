@@ -43,6 +43,10 @@ var (
 	rsh_long = templates.LongDesc(`
 		Opens a terminal or runs a command in a pods container
 
+		The pod can be specified by the name of an application in which case the ready pod of the application
+		created most recently is used. Otherwise the pods whose name or application name contain the argument are
+		offered to pick from.
+
 `)
 
 	rsh_example = templates.Examples(`
@@ -52,6 +56,9 @@ var (
 		# Opens a terminal in the cheese container in the latest pod in the foo deployment
 		jx rsh -c cheese foo
 
+		# Opens a terminal in the latest pod of the foo application in the staging environment
+		jx rsh --env staging foo
+
 		# To connect to one of your DevPods use:
 		jx rsh -d
 
@@ -85,12 +92,13 @@ func NewCmdRsh(f Factory, in terminal.FileReader, out terminal.FileWriter, errOu
 	}
 	cmd.Flags().StringVarP(&options.Container, "container", "c", "", "The name of the container to log")
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "the namespace to look for the Deployment. Defaults to the current namespace")
-	cmd.Flags().StringVarP(&options.Namespace, "pod", "p", "", "the pod name to use")
+	cmd.Flags().StringVarP(&options.Pod, "pod", "p", "", "the pod name to use")
 	cmd.Flags().StringVarP(&options.Executable, "shell", "s", "", "Path to the shell command")
 	cmd.Flags().BoolVarP(&options.DevPod, "devpod", "d", false, "Connect to a DevPod")
 	cmd.Flags().StringVarP(&options.ExecCmd, "execute", "e", defaultRshCommand, "Execute this command on the remote container")
 	cmd.Flags().StringVarP(&options.Username, "username", "", "", "The username to create the DevPod. If not specified defaults to the current operating system user or $USER'")
 	cmd.Flags().StringVarP(&options.Environment, "environment", "", "", "The environment in which to look for the Deployment. Defaults to the current environment")
+	cmd.Flags().StringVarP(&options.Environment, "env", "", "", "The environment in which to look for the application. Alias of --environment")
 
 	return cmd
 }
@@ -133,7 +141,7 @@ func (o *RshOptions) Run() error {
 			return err
 		}
 	} else {
-		names, err = kube.GetPodNames(client, ns, "")
+		names, pods, err = kube.GetPods(client, ns, "")
 		if err != nil {
 			return err
 		}
@@ -157,24 +165,38 @@ func (o *RshOptions) Run() error {
 	} else {
 		name = args[0]
 		if util.StringArrayIndex(names, name) < 0 {
-			// lets try use the name as a filter
-			filteredNames := []string{}
-			for _, n := range names {
-				if strings.Contains(n, name) {
-					filteredNames = append(filteredNames, n)
+			appPodNames := kube.FindAppPodNames(pods, name)
+			if len(appPodNames) > 0 {
+				log.Infof("Using pod %s of application %s\n", util.ColorInfo(appPodNames[0]), util.ColorInfo(name))
+				name = appPodNames[0]
+			} else {
+				// lets try use the name as a filter
+				filteredNames := kube.FilterPodNames(pods, name)
+				if len(filteredNames) == 0 {
+					return fmt.Errorf("No pod or application matching %s found in namespace %s", name, ns)
 				}
+				n, err := util.PickName(filteredNames, "Pick Pod:", "", o.In, o.Out, o.Err)
+				if err != nil {
+					return err
+				}
+				name = n
 			}
-			n, err := util.PickName(filteredNames, "Pick Pod:", "", o.In, o.Out, o.Err)
-			if err != nil {
-				return err
-			}
-			name = n
 		}
 	}
 
 	if name == "" {
 		return fmt.Errorf("No pod found for namespace %s with name %s", ns, name)
 	}
+	if o.DevPod {
+		err = kube.UpdateDevPodActivity(client, ns, name)
+		if err != nil {
+			log.Warnf("%s\n", err)
+		}
+		// the DevPod is in use for as long as the shell is open
+		stop := make(chan struct{})
+		defer close(stop)
+		go kube.KeepDevPodActive(client, ns, name, kube.DevPodActivityInterval, stop)
+	}
 
 	commandArguments := []string{}
 	if o.Executable == "" {
@@ -31,12 +31,16 @@ const (
 	jmbrSourceURL  = "SOURCE_URL"
 )
 
+// reservedPipelineParams the environment variables populated by jx which cannot be overridden by a parameter
+var reservedPipelineParams = []string{repoOwnerEnv, repoNameEnv, jmbrBranchName, jmbrSourceURL}
+
 // StartPipelineOptions contains the command line options
 type StartPipelineOptions struct {
 	GetOptions
 
 	Tail   bool
 	Filter string
+	Params []string
 
 	Jobs map[string]gojenkins.Job
 
@@ -45,7 +49,11 @@ type StartPipelineOptions struct {
 
 var (
 	start_pipeline_long = templates.LongDesc(`
-		Starts the pipeline build.
+		Starts the pipeline build of a repository branch.
+
+		Parameters can be passed to the pipeline using --param which are exposed as environment variables
+		to the steps of knative builds or as build parameters of Jenkins jobs. The environment variables
+		REPO_OWNER, REPO_NAME, BRANCH_NAME and SOURCE_URL are populated by jx and cannot be passed as parameters.
 
 `)
 
@@ -58,6 +66,9 @@ var (
 
 		# Select the pipeline to start and tail the log
 		jx start pipeline -t
+
+		# Start the pipeline of the master branch of a repository with some parameter overrides
+		jx start pipeline myorg/myrepo/master --param SKIP_TESTS=true --param DEPLOY=false
 	`)
 )
 
@@ -90,6 +101,7 @@ func NewCmdStartPipeline(f Factory, in terminal.FileReader, out terminal.FileWri
 	}
 	cmd.Flags().BoolVarP(&options.Tail, "tail", "t", false, "Tails the build log to the current terminal")
 	cmd.Flags().StringVarP(&options.Filter, "filter", "f", "", "Filters all the available jobs by those that contain the given text")
+	cmd.Flags().StringArrayVarP(&options.Params, "param", "p", []string{}, "A parameter of the pipeline of the form NAME=VALUE. Can be specified multiple times")
 
 	return cmd
 }
@@ -109,12 +121,22 @@ func (o *StartPipelineOptions) Run() error {
 	if err != nil {
 		return err
 	}
+	params, err := parsePipelineParams(o.Params)
+	if err != nil {
+		return err
+	}
 	args := o.Args
 	names := []string{}
 	o.ProwOptions = prow.Options{
 		KubeClient: o.KubeClientCached,
 		NS:         o.currentNamespace,
 	}
+	if !isProw {
+		o.Jobs, err = o.getJobMap(o.Filter)
+		if err != nil {
+			return err
+		}
+	}
 	if len(args) == 0 {
 		if isProw {
 			names, err = o.ProwOptions.GetReleaseJobs()
@@ -122,12 +144,6 @@ func (o *StartPipelineOptions) Run() error {
 				return err
 			}
 		} else {
-			jobMap, err := o.getJobMap(o.Filter)
-			if err != nil {
-				return err
-			}
-			o.Jobs = jobMap
-
 			for k, _ := range o.Jobs {
 				names = append(names, k)
 			}
@@ -152,12 +168,12 @@ func (o *StartPipelineOptions) Run() error {
 	}
 	for _, a := range args {
 		if isProw {
-			err = o.createProwJob(a)
+			err = o.createProwJob(a, params)
 			if err != nil {
 				return err
 			}
 		} else {
-			err = o.startJenkinsJob(a)
+			err = o.startJenkinsJob(a, params)
 			if err != nil {
 				return err
 			}
@@ -166,7 +182,23 @@ func (o *StartPipelineOptions) Run() error {
 	return nil
 }
 
-func (o *StartPipelineOptions) createProwJob(jobname string) error {
+// parsePipelineParams parses the parameters of the form NAME=VALUE
+func parsePipelineParams(params []string) (map[string]string, error) {
+	answer := map[string]string{}
+	for _, param := range params {
+		values := strings.SplitN(param, "=", 2)
+		if len(values) != 2 || values[0] == "" {
+			return nil, fmt.Errorf("invalid parameter %s, parameters should be of the form NAME=VALUE", param)
+		}
+		if util.StringArrayIndex(reservedPipelineParams, values[0]) >= 0 {
+			return nil, fmt.Errorf("invalid parameter %s, %s is populated by jx and cannot be overridden", param, values[0])
+		}
+		answer[values[0]] = values[1]
+	}
+	return answer, nil
+}
+
+func (o *StartPipelineOptions) createProwJob(jobname string, params map[string]string) error {
 	parts := strings.Split(jobname, "/")
 	if len(parts) != 3 {
 		return fmt.Errorf("job name [%s] does not match org/repo/branch format", jobname)
@@ -200,6 +232,9 @@ func (o *StartPipelineOptions) createProwJob(jobname string) error {
 	env[jmbrSourceURL] = jobSpec.BuildSpec.Source.Git.Url
 	env[repoOwnerEnv] = org
 	env[repoNameEnv] = repo
+	for k, v := range params {
+		env[k] = v
+	}
 
 	for i, step := range jobSpec.BuildSpec.Steps {
 		if len(step.Env) == 0 {
@@ -239,11 +274,19 @@ func (o *StartPipelineOptions) createProwJob(jobname string) error {
 	}
 
 	_, err = prow.CreateProwJob(o.KubeClientCached, o.currentNamespace, p)
-	return err
+	if err != nil {
+		return err
+	}
+	log.Infof("Started pipeline %s\n", util.ColorInfo(jobname))
+	log.Infof("%s %s\n", util.ColorStatus("view the log via:"), util.ColorInfo(fmt.Sprintf("jx get build logs --repo %s --branch %s -p", repo, branch)))
+	return nil
 }
 
-func (o *StartPipelineOptions) startJenkinsJob(name string) error {
-	job := o.Jobs[name]
+func (o *StartPipelineOptions) startJenkinsJob(name string, params map[string]string) error {
+	job, ok := o.Jobs[name]
+	if !ok {
+		return fmt.Errorf("no pipeline found called %s", name)
+	}
 	jenkins, err := o.JenkinsClient()
 	if err != nil {
 		return err
@@ -252,8 +295,11 @@ func (o *StartPipelineOptions) startJenkinsJob(name string) error {
 	// ignore errors as it could be there's no last build yet
 	previous, _ := jenkins.GetLastBuild(job)
 
-	params := url.Values{}
-	err = jenkins.Build(job, params)
+	values := url.Values{}
+	for k, v := range params {
+		values.Set(k, v)
+	}
+	err = jenkins.Build(job, values)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePipelineParams(t *testing.T) {
+	t.Parallel()
+
+	params, err := parsePipelineParams([]string{"SKIP_TESTS=true", "JAVA_OPTS=-Xmx1g -Dfoo=bar", "EMPTY="})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"SKIP_TESTS": "true",
+		"JAVA_OPTS":  "-Xmx1g -Dfoo=bar",
+		"EMPTY":      "",
+	}, params)
+
+	params, err = parsePipelineParams(nil)
+	require.NoError(t, err)
+	assert.Empty(t, params)
+
+	for _, param := range []string{"SKIP_TESTS", "=true"} {
+		_, err = parsePipelineParams([]string{param})
+		if assert.Error(t, err, param) {
+			assert.Contains(t, err.Error(), "parameters should be of the form NAME=VALUE")
+		}
+	}
+
+	for _, name := range []string{"REPO_OWNER", "REPO_NAME", "BRANCH_NAME", "SOURCE_URL"} {
+		_, err = parsePipelineParams([]string{"SKIP_TESTS=true", name + "=other"})
+		if assert.Error(t, err, name) {
+			assert.Contains(t, err.Error(), name+" is populated by jx and cannot be overridden")
+		}
+	}
+}
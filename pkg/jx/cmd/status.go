@@ -16,18 +16,30 @@ import (
 
 type StatusOptions struct {
 	CommonOptions
-	node string
+	node          string
+	Health        bool
+	FailOnWarning bool
 }
 
 var (
 	StatusLong = templates.LongDesc(`
 		Gets the current status of the Kubernetes cluster
 
+		Use --health for a summary of the health of the nodes, pending pods, platform components, webhook endpoint,
+		certificates and recent pipelines. The command exits with a non zero code if any of the checks fail so that
+		it can be used in monitoring scripts.
+
 `)
 
 	StatusExample = templates.Examples(`
 		# displays the current status of the Kubernetes cluster
 		jx status
+
+		# summarises the health of the cluster and the Jenkins X platform
+		jx status --health
+
+		# also exit with a non zero code if any certificates expire soon or pipelines failed recently
+		jx status --health --fail-on-warning
 `)
 )
 
@@ -56,10 +68,15 @@ func NewCmdStatus(f Factory, in terminal.FileReader, out terminal.FileWriter, er
 	}
 
 	cmd.Flags().StringVarP(&options.node, "node", "n", "", "the named node to get ")
+	cmd.Flags().BoolVarP(&options.Health, "health", "", false, "Reports the health of the nodes, pending pods, platform components, webhook endpoint, certificates and recent pipelines")
+	cmd.Flags().BoolVarP(&options.FailOnWarning, "fail-on-warning", "", false, "Exits with a non zero code if any of the health checks have warnings as well as failures")
 	return cmd
 }
 
 func (o *StatusOptions) Run() error {
+	if o.Health {
+		return o.runHealthChecks()
+	}
 
 	client, namespace, err := o.KubeClient()
 	if err != nil {
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	healthOK      = "OK"
+	healthWarning = "WARN"
+	healthFailed  = "FAIL"
+
+	// healthPendingPodGracePeriod how long a pod can be pending before it is reported
+	healthPendingPodGracePeriod = 5 * time.Minute
+	// healthCertificateExpiryWarning how long before a certificate expires it is reported
+	healthCertificateExpiryWarning = 14 * 24 * time.Hour
+	// healthFailedPipelinesPeriod how far back failed pipelines are reported
+	healthFailedPipelinesPeriod = 24 * time.Hour
+	// healthMaxListed the maximum number of names listed in the message of a check
+	healthMaxListed = 5
+)
+
+// healthExitError returns the error which makes jx status exit with a non zero code or nil if the checks are healthy.
+// Warnings only count when failOnWarning is enabled
+func healthExitError(checks []*diagnoseCheck, failOnWarning bool) error {
+	failed := 0
+	warnings := 0
+	for _, check := range checks {
+		if !check.Passed {
+			failed++
+		} else if check.Warning {
+			warnings++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d health checks failed", failed, len(checks))
+	}
+	if failOnWarning && warnings > 0 {
+		return fmt.Errorf("%d of %d health checks have warnings", warnings, len(checks))
+	}
+	return nil
+}
+
+// listNames joins the names truncating the list if there are too many
+func listNames(names []string) string {
+	sort.Strings(names)
+	if len(names) > healthMaxListed {
+		return fmt.Sprintf("%s and %d more", strings.Join(names[:healthMaxListed], ", "), len(names)-healthMaxListed)
+	}
+	return strings.Join(names, ", ")
+}
+
+// runHealthChecks reports the health of the cluster and the Jenkins X platform
+func (o *StatusOptions) runHealthChecks() error {
+	client, currentNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	_, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		ns = currentNs
+	}
+	checks := []*diagnoseCheck{}
+	add := func(name string, status string, message string) {
+		checks = append(checks, &diagnoseCheck{
+			Name:    name,
+			Passed:  status != healthFailed,
+			Warning: status == healthWarning,
+			Message: message,
+		})
+	}
+	now := time.Now()
+
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		add("nodes", healthFailed, err.Error())
+	} else {
+		problems := []string{}
+		for i := range nodes.Items {
+			node := &nodes.Items[i]
+			if p := kube.NodeProblems(node); len(p) > 0 {
+				problems = append(problems, fmt.Sprintf("%s (%s)", node.Name, strings.Join(p, ", ")))
+			}
+		}
+		if len(problems) > 0 {
+			add("nodes", healthFailed, listNames(problems))
+		} else {
+			add("nodes", healthOK, fmt.Sprintf("%d nodes ready", len(nodes.Items)))
+		}
+	}
+
+	pods, err := client.CoreV1().Pods("").List(metav1.ListOptions{})
+	if err != nil {
+		add("pending pods", healthFailed, err.Error())
+	} else {
+		pending := []string{}
+		for _, pod := range pods.Items {
+			if pod.Status.Phase == corev1.PodPending && now.Sub(pod.CreationTimestamp.Time) > healthPendingPodGracePeriod {
+				pending = append(pending, pod.Namespace+"/"+pod.Name)
+			}
+		}
+		if len(pending) > 0 {
+			add("pending pods", healthWarning, listNames(pending))
+		} else {
+			add("pending pods", healthOK, "no pods pending for more than "+healthPendingPodGracePeriod.String())
+		}
+	}
+
+	deployments, err := client.AppsV1().Deployments(ns).List(metav1.ListOptions{})
+	if err != nil {
+		add("platform", healthFailed, err.Error())
+	} else {
+		failing := []string{}
+		for _, d := range deployments.Items {
+			replicas := int32(1)
+			if d.Spec.Replicas != nil {
+				replicas = *d.Spec.Replicas
+			}
+			if d.Status.ReadyReplicas < replicas {
+				failing = append(failing, fmt.Sprintf("%s (%d/%d ready)", d.Name, d.Status.ReadyReplicas, replicas))
+			}
+		}
+		if len(deployments.Items) == 0 {
+			add("platform", healthFailed, "no deployments found in namespace "+ns)
+		} else if len(failing) > 0 {
+			add("platform", healthFailed, listNames(failing))
+		} else {
+			add("platform", healthOK, fmt.Sprintf("%d deployments ready in %s", len(deployments.Items), ns))
+		}
+	}
+
+	webhookURL, err := o.GetWebHookEndpoint()
+	if err == nil {
+		err = checkURLReachable(util.GetClientWithTimeout(10*time.Second), webhookURL)
+	}
+	if err != nil {
+		add("webhook", healthFailed, err.Error())
+	} else {
+		add("webhook", healthOK, webhookURL+" reachable")
+	}
+
+	o.addCertificateChecks(ns, now, add)
+	o.addFailedPipelinesCheck(ns, now, add)
+
+	o.renderHealthChecks(checks)
+	return healthExitError(checks, o.FailOnWarning)
+}
+
+// addCertificateChecks checks the expiry of the TLS certificates of the ingresses in the namespace
+func (o *StatusOptions) addCertificateChecks(ns string, now time.Time, add func(name string, status string, message string)) {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return
+	}
+	ings, err := client.ExtensionsV1beta1().Ingresses(ns).List(metav1.ListOptions{})
+	if err != nil {
+		add("certificates", healthFailed, err.Error())
+		return
+	}
+	secretNames := map[string]bool{}
+	for _, ing := range ings.Items {
+		for _, tls := range ing.Spec.TLS {
+			if tls.SecretName != "" {
+				secretNames[tls.SecretName] = true
+			}
+		}
+	}
+	if len(secretNames) == 0 {
+		add("certificates", healthOK, "no TLS enabled ingresses in "+ns)
+		return
+	}
+	expired := []string{}
+	expiring := []string{}
+	earliest := time.Time{}
+	for name := range secretNames {
+		secret, err := client.CoreV1().Secrets(ns).Get(name, metav1.GetOptions{})
+		if err != nil {
+			expired = append(expired, fmt.Sprintf("%s (missing)", name))
+			continue
+		}
+		expiry, err := kube.CertificateExpiry(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			expired = append(expired, fmt.Sprintf("%s (%s)", name, err))
+			continue
+		}
+		if earliest.IsZero() || expiry.Before(earliest) {
+			earliest = expiry
+		}
+		if expiry.Before(now) {
+			expired = append(expired, fmt.Sprintf("%s (expired %s)", name, expiry.Format("2006-01-02")))
+		} else if expiry.Sub(now) < healthCertificateExpiryWarning {
+			expiring = append(expiring, fmt.Sprintf("%s (expires %s)", name, expiry.Format("2006-01-02")))
+		}
+	}
+	if len(expired) > 0 {
+		add("certificates", healthFailed, listNames(expired))
+	} else if len(expiring) > 0 {
+		add("certificates", healthWarning, listNames(expiring))
+	} else {
+		add("certificates", healthOK, fmt.Sprintf("%d certificates valid until at least %s", len(secretNames), earliest.Format("2006-01-02")))
+	}
+}
+
+// addFailedPipelinesCheck reports the pipelines which failed recently
+func (o *StatusOptions) addFailedPipelinesCheck(ns string, now time.Time, add func(name string, status string, message string)) {
+	jxClient, _, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		add("pipelines", healthFailed, err.Error())
+		return
+	}
+	activities, err := jxClient.JenkinsV1().PipelineActivities(ns).List(metav1.ListOptions{})
+	if err != nil {
+		add("pipelines", healthFailed, err.Error())
+		return
+	}
+	failed := []string{}
+	for _, a := range activities.Items {
+		status := a.Spec.Status
+		if status != v1.ActivityStatusTypeFailed && status != v1.ActivityStatusTypeError {
+			continue
+		}
+		completed := a.Spec.CompletedTimestamp
+		if completed == nil || now.Sub(completed.Time) > healthFailedPipelinesPeriod {
+			continue
+		}
+		failed = append(failed, fmt.Sprintf("%s #%s", a.Spec.Pipeline, a.Spec.Build))
+	}
+	if len(failed) > 0 {
+		add("pipelines", healthWarning, fmt.Sprintf("%d failed in the last %s: %s", len(failed), healthFailedPipelinesPeriod, listNames(failed)))
+	} else {
+		add("pipelines", healthOK, "no failed pipelines in the last "+healthFailedPipelinesPeriod.String())
+	}
+}
+
+func (o *StatusOptions) renderHealthChecks(checks []*diagnoseCheck) {
+	renderDiagnoseChecks(o.Out, checks)
+	if healthExitError(checks, true) == nil {
+		log.Successf("Jenkins X is healthy")
+	}
+}
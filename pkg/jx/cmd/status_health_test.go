@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthExitError(t *testing.T) {
+	t.Parallel()
+	checks := []*diagnoseCheck{
+		{Name: "nodes", Passed: true},
+		{Name: "certificates", Passed: true, Warning: true},
+	}
+	assert.NoError(t, healthExitError(checks, false))
+	assert.EqualError(t, healthExitError(checks, true), "1 of 2 health checks have warnings")
+
+	checks = append(checks, &diagnoseCheck{Name: "webhook", Passed: false})
+	assert.EqualError(t, healthExitError(checks, false), "1 of 3 health checks failed")
+}
+
+func TestListNames(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "a, b", listNames([]string{"b", "a"}))
+	assert.Equal(t, "a, b, c, d, e and 2 more", listNames([]string{"g", "f", "e", "d", "c", "b", "a"}))
+}
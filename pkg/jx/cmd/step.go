@@ -50,7 +50,9 @@ func NewCmdStep(f Factory, in terminal.FileReader, out terminal.FileWriter, errO
 	cmd.AddCommand(NewCmdStepGit(f, in, out, errOut))
 	cmd.AddCommand(NewCmdStepGpgCredentials(f, in, out, errOut))
 	cmd.AddCommand(NewCmdStepHelm(f, in, out, errOut))
+	cmd.AddCommand(NewCmdStepImage(f, in, out, errOut))
 	cmd.AddCommand(NewCmdStepLinkServices(f, in, out, errOut))
+	cmd.AddCommand(NewCmdStepMigrate(f, in, out, errOut))
 	cmd.AddCommand(NewCmdStepNexus(f, in, out, errOut))
 	cmd.AddCommand(NewCmdStepNextVersion(f, in, out, errOut))
 	cmd.AddCommand(NewCmdStepNextBuildNumber(f, in, out, errOut))
@@ -59,11 +61,15 @@ func NewCmdStep(f Factory, in terminal.FileReader, out terminal.FileWriter, errO
 	cmd.AddCommand(NewCmdStepPost(f, in, out, errOut))
 	cmd.AddCommand(NewCmdStepReport(f, in, out, errOut))
 	cmd.AddCommand(NewCmdStepRelease(f, in, out, errOut))
+	cmd.AddCommand(NewCmdStepScan(f, in, out, errOut))
 	cmd.AddCommand(NewCmdStepSplitMonorepo(f, in, out, errOut))
+	cmd.AddCommand(NewCmdStepSyntax(f, in, out, errOut))
 	cmd.AddCommand(NewCmdStepTag(f, in, out, errOut))
+	cmd.AddCommand(NewCmdStepTerraform(f, in, out, errOut))
 	cmd.AddCommand(NewCmdStepValidate(f, in, out, errOut))
 	cmd.AddCommand(NewCmdStepVerify(f, in, out, errOut))
 	cmd.AddCommand(NewCmdStepWaitForArtifact(f, in, out, errOut))
+	cmd.AddCommand(NewCmdStepWaitForRollout(f, in, out, errOut))
 	cmd.AddCommand(NewCmdStepCollect(f, in, out, errOut))
 
 	return cmd
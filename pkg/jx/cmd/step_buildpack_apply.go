@@ -14,6 +14,13 @@ import (
 var (
 	createJenkinsfileLong = templates.LongDesc(`
 		Applies the build pack for a project to add any missing files like a Jenkinsfile
+
+		Use --overwrite to re-apply an updated build pack to an existing project, replacing the files which came from
+		the build pack. The replaced files and directories are kept with a .backup suffix. Use git to review the
+		changes before committing them.
+
+		To try out changes to a build pack while developing it, point --pack-dir or the $JX_BUILD_PACK_DIR environment
+		variable at your local clone of the build pack repository.
 `)
 
 	createJenkinsfileExample = templates.Examples(`
@@ -23,7 +30,12 @@ var (
 		# applies the 'maven' build pack to the current project
 		jx step buildpack apply --pack maven
 
-			`)
+		# re-applies the latest version of the build pack to the current project
+		jx step buildpack apply --overwrite
+
+		# applies the build pack from a local clone of the build pack repository
+		jx step buildpack apply --pack-dir ~/go/src/github.com/myorg/my-build-packs
+	`)
 )
 
 // StepBuildPackApplyOptions contains the command line flags
@@ -34,6 +46,8 @@ type StepBuildPackApplyOptions struct {
 	Jenkinsfile             string
 	DraftPack               string
 	DisableJenkinsfileCheck bool
+	PacksDir                string
+	Overwrite               bool
 }
 
 // NewCmdStepBuildPackApply Creates a new Command object
@@ -67,6 +81,8 @@ func NewCmdStepBuildPackApply(f Factory, in terminal.FileReader, out terminal.Fi
 	cmd.Flags().StringVarP(&options.Jenkinsfile, "jenkinsfile", "", "", "The name of the Jenkinsfile to use. If not specified then 'Jenkinsfile' will be used")
 	cmd.Flags().StringVarP(&options.DraftPack, "pack", "", "", "The name of the pack to use")
 	cmd.Flags().BoolVarP(&options.DisableJenkinsfileCheck, "no-jenkinsfile", "", false, "Disable defaulting a Jenkinsfile if its missing")
+	cmd.Flags().StringVarP(&options.PacksDir, "pack-dir", "", "", "A local clone of a build pack repository to use instead of the team build pack")
+	cmd.Flags().BoolVarP(&options.Overwrite, "overwrite", "", false, "Replaces any files in the project which came from the build pack with the current version of the pack. The old files are kept with a .backup suffix")
 	return cmd
 }
 
@@ -77,15 +93,17 @@ func (o *StepBuildPackApplyOptions) Run() error {
 	if dir == "" {
 		dir, err = os.Getwd()
 		if err != nil {
-		  return err
+			return err
 		}
 	}
 
-	settings, err := o.CommonOptions.TeamSettings()
-	if err != nil {
-	  return err
+	if o.PacksDir == "" {
+		settings, err := o.CommonOptions.TeamSettings()
+		if err != nil {
+			return err
+		}
+		log.Infof("build pack is %s ref: %s\n", settings.BuildPackURL, settings.BuildPackRef)
 	}
-	log.Infof("build pack is %s\n", settings.BuildPackURL)
 
 	defaultJenkinsfile := filepath.Join(dir, jenkins.DefaultJenkinsfile)
 	jenkinsfile := jenkins.DefaultJenkinsfile
@@ -106,6 +124,8 @@ func (o *StepBuildPackApplyOptions) Run() error {
 		WithRename:              withRename,
 		InitialisedGit:          true,
 		DisableJenkinsfileCheck: o.DisableJenkinsfileCheck,
+		PacksDir:                o.PacksDir,
+		Overwrite:               o.Overwrite,
 	}
 	_, err = o.invokeDraftPack(args)
 	if err != nil {
@@ -8,7 +8,6 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 	"text/template"
 	"time"
@@ -22,8 +21,11 @@ import (
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
 
 	chgit "github.com/jenkins-x/chyle/chyle/git"
@@ -54,6 +56,7 @@ type StepChangelogOptions struct {
 	UpdateRelease       bool
 	NoReleaseInDev      bool
 	IncludeMergeCommits bool
+	FirstRelease        bool
 	State               StepChangelogState
 }
 
@@ -110,7 +113,7 @@ e.g. define environment variables GIT_USERNAME and GIT_API_TOKEN
 
 		If you have just created a git tag this command will try default to the changes between the last tag and the previous one. You can always specify the exact Git references (tag/sha) directly via '--previous-rev' and '--rev'
 
-		The changelog is generated by parsing the git commits. It will also detect any text like 'fixes #123' to link to issue fixes. You can also use Conventional Commits notation: https://conventionalcommits.org/ to get a nicer formatted changelog. e.g. using commits like 'fix:(my feature) this my fix' or 'feat:(cheese) something'
+		The changelog is generated by parsing the git commits. It will also detect any text like 'fixes #123' to link to issue fixes. You can also use Conventional Commits notation: https://conventionalcommits.org/ to get a nicer formatted changelog. e.g. using commits like 'fix(my feature): this my fix' or 'feat(cheese): something'. Breaking changes can be marked with 'feat!: something' or a 'BREAKING CHANGE:' footer
 
 		This command also generates a Release Custom Resource Definition you can include in your helm chart to give metadata about the changelog of the application along with metadata about the release (git tag, url, commits, issues fixed etc). Including this metadata in a helm charts means we can do things like automatically comment on issues when they hit Staging or Production; or give detailed descriptions of what things have changed when using GitOps to update versions in an environment by referencing the fixed issues in the Pull Request.
 
@@ -131,9 +134,6 @@ e.g. define environment variables GIT_USERNAME and GIT_API_TOKEN
 		jx step changelog --header-file docs/dev/changelog-header.md --version 1.2.3
 
 `)
-
-	GitHubIssueRegex = regexp.MustCompile(`(\#\d+)`)
-	JIRAIssueRegex   = regexp.MustCompile(`[A-Z][A-Z]+-(\d+)`)
 )
 
 func NewCmdStepChangelog(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
@@ -179,6 +179,7 @@ func NewCmdStepChangelog(f Factory, in terminal.FileReader, out terminal.FileWri
 	cmd.Flags().BoolVarP(&options.NoReleaseInDev, "no-dev-release", "", false, "Disables the generation of Release CRDs in the development namespace to track releases being performed")
 	cmd.Flags().BoolVarP(&options.IncludeMergeCommits, "include-merge-commits", "", false,
 		"Include merge commits when generating the changelog")
+	cmd.Flags().BoolVarP(&options.FirstRelease, "first-release", "", false, "Generates the changelog from all the commits up to the current revision when there is no previous revision")
 
 	cmd.Flags().StringVarP(&options.Header, "header", "", "", "The changelog header in markdown for the changelog. Can use go template expressions on the ReleaseSpec object: https://golang.org/pkg/text/template/")
 	cmd.Flags().StringVarP(&options.HeaderFile, "header-file", "", "", "The file name of the changelog header in markdown for the changelog. Can use go template expressions on the ReleaseSpec object: https://golang.org/pkg/text/template/")
@@ -233,7 +234,7 @@ func (o *StepChangelogOptions) Run() error {
 			}
 		}
 	}
-	if previousRev == "" {
+	if previousRev == "" && !o.FirstRelease {
 		previousRev, err = o.Git().GetPreviousGitTagSHA(dir)
 		if err != nil {
 			return err
@@ -252,20 +253,26 @@ func (o *StepChangelogOptions) Run() error {
 	}
 
 	templatesDir := o.TemplatesDir
-	if templatesDir == "" {
-		chartFile, err := o.FindHelmChart()
+	if o.GenerateReleaseYaml || o.GenerateCRD {
+		if templatesDir == "" {
+			chartFile, err := o.FindHelmChart()
+			if err != nil {
+				return fmt.Errorf("Could not find helm chart %s", err)
+			}
+			path, _ := filepath.Split(chartFile)
+			templatesDir = filepath.Join(path, "templates")
+		}
+		err = os.MkdirAll(templatesDir, DefaultWritePermissions)
 		if err != nil {
-			return fmt.Errorf("Could not find helm chart %s", err)
+			return fmt.Errorf("Failed to create the templates directory %s due to %s", templatesDir, err)
 		}
-		path, _ := filepath.Split(chartFile)
-		templatesDir = filepath.Join(path, "templates")
-	}
-	err = os.MkdirAll(templatesDir, DefaultWritePermissions)
-	if err != nil {
-		return fmt.Errorf("Failed to create the templates directory %s due to %s", templatesDir, err)
 	}
 
-	log.Infof("Generating change log from git ref %s => %s\n", util.ColorInfo(previousRev), util.ColorInfo(currentRev))
+	if previousRev == "" {
+		log.Infof("Generating change log from the first commit => %s\n", util.ColorInfo(currentRev))
+	} else {
+		log.Infof("Generating change log from git ref %s => %s\n", util.ColorInfo(previousRev), util.ColorInfo(currentRev))
+	}
 
 	gitDir, gitConfDir, err := o.Git().FindGitConfigDir(dir)
 	if err != nil {
@@ -311,7 +318,12 @@ func (o *StepChangelogOptions) Run() error {
 	o.State.GitProvider = gitProvider
 	o.State.FoundIssueNames = map[string]bool{}
 
-	commits, err := chgit.FetchCommits(gitDir, previousRev, currentRev)
+	var commits *[]object.Commit
+	if previousRev == "" {
+		commits, err = fetchAllCommits(gitDir, currentRev)
+	} else {
+		commits, err = chgit.FetchCommits(gitDir, previousRev, currentRev)
+	}
 	if err != nil {
 		return err
 	}
@@ -527,80 +539,80 @@ func (o *StepChangelogOptions) addCommit(spec *v1.ReleaseSpec, commit *object.Co
 
 func (o *StepChangelogOptions) addIssuesAndPullRequests(spec *v1.ReleaseSpec, commit *v1.CommitSummary, rawCommit *object.Commit) error {
 	tracker := o.State.Tracker
-
-	gitProvider := o.State.GitProvider
-	if gitProvider == nil || !gitProvider.HasIssues() {
+	if tracker == nil {
 		return nil
 	}
-	regex := GitHubIssueRegex
 	issueKind := issues.GetIssueProvider(tracker)
+	if issueKind == issues.Git {
+		gitProvider := o.State.GitProvider
+		if gitProvider == nil || !gitProvider.HasIssues() {
+			return nil
+		}
+	}
 	if !o.State.LoggedIssueKind {
 		o.State.LoggedIssueKind = true
 		log.Infof("Finding issues in commit messages using %s format\n", issueKind)
 	}
-	if issueKind == issues.Jira {
-		regex = JIRAIssueRegex
+	project := ""
+	if jira, ok := tracker.(*issues.JiraService); ok {
+		project = jira.Project
 	}
 	message := fullCommitMessageText(rawCommit)
-	matches := regex.FindAllStringSubmatch(message, -1)
-	for _, match := range matches {
-		for _, result := range match {
-			result = strings.TrimPrefix(result, "#")
-			if _, ok := o.State.FoundIssueNames[result]; !ok {
-				o.State.FoundIssueNames[result] = true
-				issue, err := tracker.GetIssue(result)
-				if err != nil {
-					log.Warnf("Failed to lookup issue %s in issue tracker %s due to %s\n", result, tracker.HomeURL(), err)
-					continue
-				}
-				if issue == nil {
-					log.Warnf("Failed to find issue %s for repository %s\n", result, tracker.HomeURL())
-					continue
-				}
-
-				var user v1.UserDetails
-				if issue.User == nil {
-					log.Warnf("Failed to find user for issue %s repository %s\n", result, tracker.HomeURL())
-				} else {
-					user = *o.gitUserToUserDetails(issue.User)
-				}
-
-				var closedBy v1.UserDetails
-				if issue.ClosedBy == nil {
-					log.Warnf("Failed to find closedBy user for issue %s repository %s\n", result, tracker.HomeURL())
-				} else {
-					closedBy = *o.gitUserToUserDetails(issue.User)
-				}
-
-				var assignees []v1.UserDetails
-				if issue.Assignees == nil {
-					log.Warnf("Failed to find assignees for issue %s repository %s\n", result, tracker.HomeURL())
-				} else {
-					assignees = o.gitUserToUserDetailSlice(issue.Assignees)
-				}
-
-				labels := toV1Labels(issue.Labels)
-				commit.IssueIDs = append(commit.IssueIDs, result)
-				issueSummary := v1.IssueSummary{
-					ID:                result,
-					URL:               issue.URL,
-					Title:             issue.Title,
-					Body:              issue.Body,
-					User:              &user,
-					CreationTimestamp: kube.ToMetaTime(issue.CreatedAt),
-					ClosedBy:          &closedBy,
-					Assignees:         assignees,
-					Labels:            labels,
-				}
-				state := issue.State
-				if state != nil {
-					issueSummary.State = *state
-				}
-				if issue.IsPullRequest {
-					spec.PullRequests = append(spec.PullRequests, issueSummary)
-				} else {
-					spec.Issues = append(spec.Issues, issueSummary)
-				}
+	for _, result := range issues.ParseIssueKeys(issueKind, project, message) {
+		if _, ok := o.State.FoundIssueNames[result]; !ok {
+			o.State.FoundIssueNames[result] = true
+			issue, err := tracker.GetIssue(result)
+			if err != nil {
+				log.Warnf("Failed to lookup issue %s in issue tracker %s due to %s\n", result, tracker.HomeURL(), err)
+				continue
+			}
+			if issue == nil {
+				log.Warnf("Failed to find issue %s for repository %s\n", result, tracker.HomeURL())
+				continue
+			}
+
+			var user v1.UserDetails
+			if issue.User == nil {
+				log.Warnf("Failed to find user for issue %s repository %s\n", result, tracker.HomeURL())
+			} else {
+				user = *o.gitUserToUserDetails(issue.User)
+			}
+
+			var closedBy v1.UserDetails
+			if issue.ClosedBy == nil {
+				log.Warnf("Failed to find closedBy user for issue %s repository %s\n", result, tracker.HomeURL())
+			} else {
+				closedBy = *o.gitUserToUserDetails(issue.User)
+			}
+
+			var assignees []v1.UserDetails
+			if issue.Assignees == nil {
+				log.Warnf("Failed to find assignees for issue %s repository %s\n", result, tracker.HomeURL())
+			} else {
+				assignees = o.gitUserToUserDetailSlice(issue.Assignees)
+			}
+
+			labels := toV1Labels(issue.Labels)
+			commit.IssueIDs = append(commit.IssueIDs, result)
+			issueSummary := v1.IssueSummary{
+				ID:                result,
+				URL:               issue.URL,
+				Title:             issue.Title,
+				Body:              issue.Body,
+				User:              &user,
+				CreationTimestamp: kube.ToMetaTime(issue.CreatedAt),
+				ClosedBy:          &closedBy,
+				Assignees:         assignees,
+				Labels:            labels,
+			}
+			state := issue.State
+			if state != nil {
+				issueSummary.State = *state
+			}
+			if issue.IsPullRequest {
+				spec.PullRequests = append(spec.PullRequests, issueSummary)
+			} else {
+				spec.Issues = append(spec.Issues, issueSummary)
 			}
 		}
 	}
@@ -707,3 +719,38 @@ func (o *StepChangelogOptions) getTemplateResult(releaseSpec *v1.ReleaseSpec, te
 	writer.Flush()
 	return buffer.String(), err
 }
+
+// fetchAllCommits returns all the commits reachable from the revision, which is a commit SHA or a tag, so that the
+// changelog of the first release includes the root commit
+func fetchAllCommits(gitDir string, rev string) (*[]object.Commit, error) {
+	repo, err := git.PlainOpen(gitDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening the git repository %s", gitDir)
+	}
+	commit, err := repo.CommitObject(plumbing.NewHash(rev))
+	if err != nil {
+		ref, err := repo.Reference(plumbing.ReferenceName("refs/tags/"+rev), true)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving the revision %s", rev)
+		}
+		tag, err := repo.TagObject(ref.Hash())
+		if err == nil {
+			commit, err = tag.Commit()
+		} else {
+			commit, err = repo.CommitObject(ref.Hash())
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "finding the commit of the tag %s", rev)
+		}
+	}
+	iter, err := repo.Log(&git.LogOptions{From: commit.Hash})
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing the commits of %s", rev)
+	}
+	commits := []object.Commit{}
+	err = iter.ForEach(func(c *object.Commit) error {
+		commits = append(commits, *c)
+		return nil
+	})
+	return &commits, err
+}
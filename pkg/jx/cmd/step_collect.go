@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	jenkinsv1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/cloud/buckets"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
@@ -52,6 +53,11 @@ var (
 		# lets collect some files to a specific HTTP URL
 		jx step collect -c coverage -p "build/coverage/*" --http-url https://myserver.cheese/
 
+		# lets collect some files to a Google Cloud Storage, S3 or MinIO bucket
+		jx step collect -c tests -p "target/test-reports/*" --bucket-url gs://my-bucket
+		jx step collect -c tests -p "target/test-reports/*" --bucket-url s3://my-bucket
+		jx step collect -c tests -p "target/test-reports/*" --bucket-url "s3://my-bucket?endpoint=http://minio.jx.svc:9000"
+
 `)
 )
 
@@ -82,6 +88,7 @@ func NewCmdStepCollect(f Factory, in terminal.FileReader, out terminal.FileWrite
 	cmd.Flags().StringVarP(&options.Dir, "dir", "", "", "The source directory to try detect the current git repository or branch. Defaults to using the current directory")
 	cmd.Flags().StringVarP(&options.StorageLocation.HttpURL, "http-url", "", "", "Specify the HTTP endpoint to send each file to")
 	cmd.Flags().StringVarP(&options.StorageLocation.GitURL, "git-url", "", "", "Specify the Git URL to populate files in a gh-pages branch")
+	cmd.Flags().StringVarP(&options.StorageLocation.BucketURL, "bucket-url", "", "", "Specify the bucket URL to store files in. Supports gs://bucket, s3://bucket and s3://bucket?endpoint=http://minio:9000 for MinIO")
 	cmd.Flags().StringVarP(&options.StorageLocation.Classifier, "classifier", "c", "", "A name which classifies this type of file. Example values: "+kube.ClassificationValues)
 	return cmd
 }
@@ -108,15 +115,7 @@ func (o *StepCollectOptions) Run() error {
 
 		if o.StorageLocation.IsEmpty() {
 			// we have no team settings so lets try detect the git repository using an env var or local file system
-			sourceURL := os.Getenv(envVarSourceUrl)
-			if sourceURL == "" {
-				_, gitConf, err := o.Git().FindGitConfigDir(o.Dir)
-				if err != nil {
-					log.Warnf("Could not find a .git directory: %s\n", err)
-				} else {
-					sourceURL, err = o.discoverGitURL(gitConf)
-				}
-			}
+			sourceURL := o.sourceGitURL()
 			if sourceURL == "" {
 				return fmt.Errorf("Missing option --git-url and we could not detect the current git repository URL")
 			}
@@ -124,6 +123,10 @@ func (o *StepCollectOptions) Run() error {
 		}
 	}
 
+	bucketURL := o.StorageLocation.BucketURL
+	if bucketURL != "" {
+		return o.collectBucketURL(bucketURL)
+	}
 	gitURL := o.StorageLocation.GitURL
 	if gitURL != "" {
 		return o.collectGitURL(gitURL)
@@ -135,6 +138,97 @@ func (o *StepCollectOptions) Run() error {
 	return fmt.Errorf("Missing option --git-url and we could not detect the current git repository URL")
 }
 
+// sourceGitURL detects the git repository being built using an env var or the local file system
+func (o *StepCollectOptions) sourceGitURL() string {
+	sourceURL := os.Getenv(envVarSourceUrl)
+	if sourceURL == "" {
+		_, gitConf, err := o.Git().FindGitConfigDir(o.Dir)
+		if err != nil {
+			log.Warnf("Could not find a .git directory: %s\n", err)
+		} else {
+			sourceURL, err = o.discoverGitURL(gitConf)
+		}
+	}
+	return sourceURL
+}
+
+// branchName returns the branch being built from the environment or the local git repository
+func (o *StepCollectOptions) branchName() (string, error) {
+	branchName := os.Getenv(envVarBranchName)
+	if branchName == "" {
+		// lets try find the branch name via git
+		var err error
+		branchName, err = o.Git().Branch(o.Dir)
+		if err != nil {
+			return "", err
+		}
+	}
+	if branchName == "" {
+		return "", fmt.Errorf("Environment variable %s is empty", envVarBranchName)
+	}
+	return branchName, nil
+}
+
+// collectBucketURL stores the files matching the patterns in the bucket under
+// jenkins-x/<classifier>/<owner>/<repository>/<branch>/<build>
+func (o *StepCollectOptions) collectBucketURL(bucketURL string) error {
+	sourceURL := o.sourceGitURL()
+	if sourceURL == "" {
+		return fmt.Errorf("Could not detect the current git repository URL")
+	}
+	gitInfo, err := gits.ParseGitURL(sourceURL)
+	if err != nil {
+		return err
+	}
+	branchName, err := o.branchName()
+	if err != nil {
+		return err
+	}
+	org := gitInfo.Organisation
+	repoName := gitInfo.Name
+	buildNo := o.getBuildNumber()
+	classifier := o.StorageLocation.Classifier
+	pathDir := filepath.Join("jenkins-x", classifier, org, repoName, branchName, buildNo)
+
+	urls := make([]string, 0)
+	for _, p := range o.Pattern {
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(o.Dir, p)
+		}
+		matches, err := filepath.Glob(p)
+		if err != nil {
+			return errors.Wrapf(err, "invalid pattern %s", p)
+		}
+		for _, match := range matches {
+			baseDir := filepath.Dir(match)
+			err = filepath.Walk(match, func(path string, info os.FileInfo, err error) error {
+				if err != nil || info.IsDir() {
+					return err
+				}
+				rPath, err := filepath.Rel(baseDir, path)
+				if err != nil {
+					return err
+				}
+				data, err := ioutil.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				url, err := buckets.WriteFile(bucketURL, filepath.Join(pathDir, rPath), data)
+				if err != nil {
+					return err
+				}
+				log.Infof("Publishing %s\n", util.ColorInfo(url))
+				urls = append(urls, url)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return o.addAttachment(org, repoName, branchName, urls)
+}
+
 func (o *StepCollectOptions) collectGitURL(sourceURL string) (err error) {
 	gitInfo, err := gits.ParseGitURL(sourceURL)
 	if err != nil {
@@ -151,16 +245,9 @@ func (o *StepCollectOptions) collectGitURL(sourceURL string) (err error) {
 	}
 
 	buildNo := o.getBuildNumber()
-	branchName := os.Getenv(envVarBranchName)
-	if branchName == "" {
-		// lets try find the branch name via git
-		branchName, err = o.Git().Branch(o.Dir)
-		if err != nil {
-			return err
-		}
-	}
-	if branchName == "" {
-		return fmt.Errorf("Environment variable %s is empty", envVarBranchName)
+	branchName, err := o.branchName()
+	if err != nil {
+		return err
 	}
 
 	classifier := o.StorageLocation.Classifier
@@ -212,7 +299,12 @@ func (o *StepCollectOptions) collectGitURL(sourceURL string) (err error) {
 	if err != nil {
 		return err
 	}
+	return o.addAttachment(org, repoName, branchName, urls)
+}
 
+// addAttachment adds the URLs of the collected files to the PipelineActivity of the build
+func (o *StepCollectOptions) addAttachment(org string, repoName string, branchName string, urls []string) error {
+	classifier := o.StorageLocation.Classifier
 	f := o.Factory
 	client, ns, err := f.CreateJXClient()
 	if err != nil {
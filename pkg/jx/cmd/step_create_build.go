@@ -1,14 +1,18 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jenkinsfile/syntax"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/pkg/errors"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
@@ -16,6 +20,7 @@ import (
 	"github.com/jenkins-x/jx/pkg/config"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/tekton"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,6 +30,9 @@ import (
 var (
 	createBuildLong = templates.LongDesc(`
 		Creates a Knative build resource for a project
+
+		If the pipeline engine of the team is Tekton the Tekton resources which run the pipelines of the project are
+		created instead. See 'jx step create pipelinerun'
 `)
 
 	createBuildExample = templates.Examples(`
@@ -85,6 +93,13 @@ func NewCmdStepCreateBuild(f Factory, in terminal.FileReader, out terminal.FileW
 
 // Run implements this command
 func (o *StepCreateBuildOptions) Run() error {
+	settings, err := o.TeamSettings()
+	if err != nil {
+		log.Warnf("Failed to load the team settings so creating Knative builds: %s\n", err)
+	} else if settings.PipelineEngine == v1.PipelineEngineTekton {
+		return o.createPipelineRuns()
+	}
+
 	pc, _, err := config.LoadProjectConfig(o.Dir)
 	if err != nil {
 		return err
@@ -126,6 +141,53 @@ func (o *StepCreateBuildOptions) Run() error {
 	return err
 }
 
+// createPipelineRuns renders the Tekton resources of the pipelines of the project for teams using the Tekton engine
+func (o *StepCreateBuildOptions) createPipelineRuns() error {
+	kinds := []string{syntax.PipelineKindRelease, syntax.PipelineKindPullRequest}
+	if o.BranchKind != "" {
+		kinds = []string{strings.ToLower(o.BranchKind)}
+	}
+	dir := o.Dir
+	if dir == "" {
+		dir = "."
+	}
+	for _, kind := range kinds {
+		options := &StepCreatePipelineRunOptions{
+			StepSyntaxPipelineOptions: StepSyntaxPipelineOptions{
+				StepOptions: o.StepOptions,
+				Dir:         dir,
+				Kind:        kind,
+			},
+			ServiceAccount: defaultTektonServiceAccount,
+			WorkspaceSize:  tekton.DefaultWorkspaceSize,
+			View:           true,
+		}
+		if o.BuildNumber > 0 {
+			options.Build = strconv.Itoa(o.BuildNumber)
+		}
+		var buffer bytes.Buffer
+		options.Out = &buffer
+		err := options.Run()
+		if err != nil {
+			return errors.Wrapf(err, "failed to create the Tekton resources of the %s pipeline", kind)
+		}
+		if o.OutputDir == "" {
+			log.Info(buffer.String())
+			continue
+		}
+		err = os.MkdirAll(o.OutputDir, DefaultWritePermissions)
+		if err != nil {
+			return err
+		}
+		output := filepath.Join(o.OutputDir, o.OutputFilePrefix+kind+".yml")
+		err = ioutil.WriteFile(output, buffer.Bytes(), DefaultWritePermissions)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (o *StepCreateBuildOptions) generateBuild(projectConfig *config.ProjectConfig, build *config.BranchBuild) (*buildapi.Build, error) {
 	dir := o.Dir
 	var err error
@@ -192,9 +254,33 @@ func (o *StepCreateBuildOptions) generateBuild(projectConfig *config.ProjectConf
 		steps = append(steps, step2)
 	}
 	answer.Spec.Steps = steps
+
+	err = o.addPipelineSecrets(answer, dir)
+	if err != nil {
+		return answer, err
+	}
 	return answer, nil
 }
 
+// addPipelineSecrets injects the pipeline secrets which the pipelines of the repository can use into the build
+func (o *StepCreateBuildOptions) addPipelineSecrets(build *buildapi.Build, dir string) error {
+	gitInfo, err := o.FindGitInfo(dir)
+	if err != nil {
+		log.Warnf("Failed to find the repository of %s so not adding the pipeline secrets: %s\n", dir, err)
+		return nil
+	}
+	kubeClient, ns, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	secrets, err := kube.GetPipelineSecrets(kubeClient, ns, gitInfo.Organisation, gitInfo.Name)
+	if err != nil {
+		return err
+	}
+	build.Spec.Volumes = kube.AddPipelineSecrets(build.Spec.Steps, build.Spec.Volumes, secrets)
+	return nil
+}
+
 func (o *StepCreateBuildOptions) loadPodTemplate(buildPack string) (*corev1.Pod, error) {
 	if buildPack == "" {
 		return nil, nil
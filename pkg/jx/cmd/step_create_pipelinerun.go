@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/tekton"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	defaultTektonServiceAccount = "tekton-bot"
+)
+
+var (
+	createPipelineRunLong = templates.LongDesc(`
+		Translates the pipeline of the project into Tekton Tasks, one per stage, and a Pipeline which runs them in order
+		then triggers a PipelineRun of it.
+
+		The source code is cloned into a PersistentVolumeClaim which is mounted as the workspace of every step so that
+		the stages share their files. The workspaces and the Tekton resources of old builds of the pipeline are deleted.
+
+		The PipelineRun runs as the tekton-bot ServiceAccount created by 'jx install --tekton'.
+
+		A PipelineActivity is created for the build so that it can be viewed via 'jx get builds' and its log
+		via 'jx get build logs'
+`)
+
+	createPipelineRunExample = templates.Examples(`
+		# run the release pipeline of the current project on Tekton
+		jx step create pipelinerun
+
+		# render the Tekton resources of the Pull Request pipeline without applying them
+		jx step create pipelinerun --kind pullrequest --view
+			`)
+)
+
+// StepCreatePipelineRunOptions contains the command line flags
+type StepCreatePipelineRunOptions struct {
+	StepSyntaxPipelineOptions
+
+	Build          string
+	ServiceAccount string
+	WorkspaceSize  string
+	KeepWorkspaces int
+	KeepRuns       int
+	View           bool
+}
+
+// NewCmdStepCreatePipelineRun Creates a new Command object
+func NewCmdStepCreatePipelineRun(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &StepCreatePipelineRunOptions{
+		StepSyntaxPipelineOptions: StepSyntaxPipelineOptions{
+			StepOptions: StepOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					In:      in,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "pipelinerun",
+		Short:   "Runs the pipeline of the project as a Tekton PipelineRun",
+		Long:    createPipelineRunLong,
+		Example: createPipelineRunExample,
+		Aliases: []string{"tekton"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addCommonFlags(cmd)
+	options.addPipelineFlags(cmd)
+
+	cmd.Flags().StringVarP(&options.Build, "build", "", "", "The build number. Defaults to the next build number of the pipeline")
+	cmd.Flags().StringVarP(&options.ServiceAccount, "service-account", "", defaultTektonServiceAccount, "The Kubernetes ServiceAccount the PipelineRun runs as")
+	cmd.Flags().StringVarP(&options.WorkspaceSize, "workspace-size", "", tekton.DefaultWorkspaceSize, "The size of the PersistentVolumeClaim of the workspace")
+	cmd.Flags().IntVarP(&options.KeepWorkspaces, "keep-workspaces", "", 3, "The number of workspaces of the most recent builds of the pipeline to keep")
+	cmd.Flags().IntVarP(&options.KeepRuns, "keep-runs", "", 10, "The number of the most recent builds of the pipeline whose Tasks, Pipeline and PipelineRun are kept")
+	cmd.Flags().BoolVarP(&options.View, "view", "", false, "Renders the Tekton resources rather than applying them")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepCreatePipelineRunOptions) Run() error {
+	pipeline, err := o.effectivePipeline()
+	if err != nil {
+		return err
+	}
+	err = pipeline.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "the %s pipeline is not valid", o.Kind)
+	}
+
+	gitInfo, err := o.Git().Info(o.Dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find the git repository in %s", o.Dir)
+	}
+	branch, err := o.Git().Branch(o.Dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find the git branch in %s", o.Dir)
+	}
+	pipelineID := kube.NewPipelineID(gitInfo.Organisation, gitInfo.Name, branch)
+
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	if o.Build == "" {
+		if o.View {
+			o.Build = "1"
+		} else {
+			o.Build, _, err = kube.GenerateBuildNumber(jxClient.JenkinsV1().PipelineActivities(ns), pipelineID)
+			if err != nil {
+				return errors.Wrapf(err, "failed to generate the next build number of %s", pipelineID.ID)
+			}
+		}
+	}
+	podTemplates, err := kube.LoadPodTemplates(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	teamSettings, err := o.TeamSettings()
+	if err != nil {
+		return err
+	}
+	secrets, err := kube.GetPipelineSecrets(kubeClient, ns, gitInfo.Organisation, gitInfo.Name)
+	if err != nil {
+		return err
+	}
+	gitSecrets, err := o.LoadPipelineSecrets(kube.ValueKindGit, "")
+	if err != nil {
+		return errors.Wrap(err, "failed to load the pipeline git credentials")
+	}
+
+	args := &tekton.CreatePipelineArguments{
+		Name:           pipelineID.Name,
+		Build:          o.Build,
+		GitURL:         gitInfo.URL,
+		Revision:       branch,
+		ServiceAccount: o.ServiceAccount,
+		WorkspaceSize:  o.WorkspaceSize,
+		PodTemplates:   podTemplates,
+		Secrets:        secrets,
+		GitSecret:      kube.FindGitCredentialsSecret(gitSecrets.Items, gitInfo.URL),
+		Env: []corev1.EnvVar{
+			{Name: "REPO_OWNER", Value: gitInfo.Organisation},
+			{Name: "REPO_NAME", Value: gitInfo.Name},
+			{Name: "BRANCH_NAME", Value: branch},
+			{Name: "JX_BUILD_NUMBER", Value: o.Build},
+			{Name: "BUILD_NUMBER", Value: o.Build},
+			{Name: "SOURCE_URL", Value: gitInfo.URL},
+		},
+	}
+	if teamSettings.BuildsOnSpot {
+		args.Tolerations = kube.SpotTolerations()
+		args.Affinity = kube.SpotAffinity()
+	}
+	resources, err := tekton.CreatePipelineResources(pipeline, args)
+	if err != nil {
+		return err
+	}
+	if o.View {
+		return writePipelineResources(o.Out, resources)
+	}
+
+	dynamicClient, err := o.dynamicClient()
+	if err != nil {
+		return err
+	}
+	err = tekton.ApplyPipelineResources(kubeClient, dynamicClient, ns, resources)
+	if err != nil {
+		return err
+	}
+	err = o.updatePipelineActivity(gitInfo, pipelineID)
+	if err != nil {
+		return err
+	}
+	log.Infof("Created PipelineRun %s for build %s of %s\n", util.ColorInfo(resources.PipelineRun.Name), util.ColorInfo("#"+o.Build), util.ColorInfo(pipelineID.ID))
+
+	pipelineName := resources.Workspace.Labels[tekton.LabelPipeline]
+	err = tekton.GarbageCollectPipelines(dynamicClient, ns, pipelineName, o.KeepRuns)
+	if err != nil {
+		return err
+	}
+	return tekton.GarbageCollectWorkspaces(kubeClient, ns, pipelineName, o.KeepWorkspaces)
+}
+
+func (o *StepCreatePipelineRunOptions) updatePipelineActivity(gitInfo *gits.GitRepository, pipelineID kube.PipelineID) error {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	key := &kube.PipelineActivityKey{
+		Name:     pipelineID.GetActivityName(o.Build),
+		Pipeline: pipelineID.ID,
+		Build:    o.Build,
+		GitInfo:  gitInfo,
+	}
+	_, _, err = key.GetOrCreate(jxClient.JenkinsV1().PipelineActivities(ns))
+	return err
+}
+
+// writePipelineResources writes the Tekton resources as a multi document YAML
+func writePipelineResources(out io.Writer, resources *tekton.PipelineResources) error {
+	objects := []interface{}{resources.Workspace}
+	for _, task := range resources.Tasks {
+		objects = append(objects, task)
+	}
+	objects = append(objects, resources.Pipeline, resources.PipelineRun)
+	for _, obj := range objects {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal to YAML")
+		}
+		_, err = fmt.Fprintf(out, "---\n%s", string(data))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
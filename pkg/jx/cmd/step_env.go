@@ -35,6 +35,7 @@ func NewCmdStepEnv(f Factory, in terminal.FileReader, out terminal.FileWriter, e
 		},
 	}
 	cmd.AddCommand(NewCmdStepEnvApply(f, in, out, errOut))
+	cmd.AddCommand(NewCmdStepEnvUsers(f, in, out, errOut))
 	return cmd
 }
 
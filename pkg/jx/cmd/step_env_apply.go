@@ -30,6 +30,8 @@ type StepEnvApplyOptions struct {
 	Wait               bool
 	Force              bool
 	DisableHelmVersion bool
+	ValueFiles         []string
+	PruneUsers         bool
 }
 
 var (
@@ -82,6 +84,8 @@ func NewCmdStepEnvApply(f Factory, in terminal.FileReader, out terminal.FileWrit
 	cmd.Flags().BoolVarP(&options.Wait, "wait", "", true, "Wait for Kubernetes readiness probe to confirm deployment")
 	cmd.Flags().BoolVarP(&options.Force, "force", "f", true, "Whether to to pass '--force' to helm to help deal with upgrading if a previous promote failed")
 	cmd.Flags().BoolVar(&options.DisableHelmVersion, "no-helm-version", false, "Don't set Chart version before applying")
+	cmd.Flags().StringArrayVarP(&options.ValueFiles, "values", "", []string{}, "Additional values files which override the values of the environment")
+	cmd.Flags().BoolVarP(&options.PruneUsers, "prune-users", "", true, "Removes the roles of the users which are not in the USERS file of the environment")
 
 	return cmd
 }
@@ -98,6 +102,8 @@ func (o *StepEnvApplyOptions) Run() error {
 		}
 	}
 
+	rootDir := dir
+
 	ns := o.Namespace
 	if ns == "" {
 		ns = os.Getenv("DEPLOY_NAMESPACE")
@@ -200,6 +206,7 @@ func (o *StepEnvApplyOptions) Run() error {
 		Wait:               o.Wait,
 		DisableHelmVersion: o.DisableHelmVersion,
 		Force:              o.Force,
+		ValueFiles:         o.ValueFiles,
 	}
 	err = stepApply.Run()
 	if err != nil {
@@ -215,5 +222,9 @@ func (o *StepEnvApplyOptions) Run() error {
 	if err != nil {
 		return err
 	}
+	_, err = o.syncUsersConfig(rootDir, o.PruneUsers)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to synchronise the user roles with the %s file in dir %s", kube.UsersConfigFileName, rootDir)
+	}
 	return nil
 }
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+// StepEnvUsersOptions contains the command line flags
+type StepEnvUsersOptions struct {
+	StepEnvOptions
+
+	Dir   string
+	Prune bool
+}
+
+var (
+	stepEnvUsersLong = templates.LongDesc(`
+		Synchronises the roles of the users of the team with the USERS file in the dev environment git repository.
+
+		The file maps the team roles such as viewer, developer or admin to the git provider logins of the users who
+		have the role in all environments or only in some environments. Users which do not exist yet are created.
+
+		For example:
+
+		    roles:
+		      admin:
+		      - alice
+		      developer:
+		      - bob
+		    environments:
+		      production:
+		        viewer:
+		        - bob
+
+		The USERS file is also synchronised when the dev environment is applied via 'jx step env apply'.
+`)
+
+	stepEnvUsersExample = templates.Examples(`
+		# synchronises the user roles with the USERS file in the current directory
+		jx step env users
+
+		# also removes the roles of users which are not in the USERS file
+		jx step env users --prune
+`)
+)
+
+// NewCmdStepEnvUsers registers the command
+func NewCmdStepEnvUsers(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := StepEnvUsersOptions{
+		StepEnvOptions: StepEnvOptions{
+			StepOptions: StepOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					In:      in,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "users",
+		Short:   "Synchronises the roles of the users of the team with the USERS file in the dev environment repository",
+		Aliases: []string{"user"},
+		Long:    stepEnvUsersLong,
+		Example: stepEnvUsersExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The directory containing the USERS file")
+	cmd.Flags().BoolVarP(&options.Prune, "prune", "p", false, "Removes the roles of the users which are not in the USERS file")
+	return cmd
+}
+
+// Run performs the command
+func (o *StepEnvUsersOptions) Run() error {
+	var err error
+	dir := o.Dir
+	if dir == "" {
+		dir, err = os.Getwd()
+		if err != nil {
+			return err
+		}
+	}
+	found, err := o.syncUsersConfig(dir, o.Prune)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("There is no %s file in directory %s", kube.UsersConfigFileName, dir)
+	}
+	return nil
+}
+
+// syncUsersConfig updates the roles of the users of the team to match the USERS file in the directory creating any
+// missing users. Returns false if there is no USERS file
+func (o *CommonOptions) syncUsersConfig(dir string, prune bool) (bool, error) {
+	config, err := kube.LoadUsersConfig(dir)
+	if err != nil || config == nil {
+		return false, err
+	}
+	err = o.registerUserCRD()
+	if err != nil {
+		return true, err
+	}
+	err = o.registerEnvironmentRoleBindingCRD()
+	if err != nil {
+		return true, err
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return true, err
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return true, err
+	}
+	roles, _, err := kube.GetTeamRoles(kubeClient, ns)
+	if err != nil {
+		return true, err
+	}
+	err = config.Validate(roles)
+	if err != nil {
+		return true, err
+	}
+	err = config.EnsureEnvironmentRoleBindings(jxClient, ns)
+	if err != nil {
+		return true, err
+	}
+	users, _, err := kube.GetUsers(jxClient, ns)
+	if err != nil {
+		return true, err
+	}
+
+	synced := map[string]bool{}
+	for login, bindings := range config.UserBindings() {
+		user := kube.FindUserByGitLogin(users, login)
+		if user == nil {
+			user = kube.CreateUser(ns, login, strings.Title(login), "")
+			user.Spec.GitProviderUser = login
+			user, err = jxClient.JenkinsV1().Users(ns).Create(user)
+			if err != nil {
+				return true, errors.Wrapf(err, "failed to create User %s", login)
+			}
+			log.Infof("Created User: %s\n", util.ColorInfo(login))
+		}
+		synced[user.Name] = true
+		err = kube.UpdateUserRoles(kubeClient, jxClient, ns, user.SubjectKind(), user.Name, bindings, roles)
+		if err != nil {
+			return true, errors.Wrapf(err, "failed to update the roles of User %s", login)
+		}
+		log.Infof("Updated roles for user: %s roles: %s\n", util.ColorInfo(user.Name), util.ColorInfo(strings.Join(bindings, ", ")))
+	}
+
+	if prune {
+		for name, user := range users {
+			if synced[name] {
+				continue
+			}
+			err = kube.UpdateUserRoles(kubeClient, jxClient, ns, user.SubjectKind(), name, []string{}, roles)
+			if err != nil {
+				return true, errors.Wrapf(err, "failed to remove the roles of User %s", name)
+			}
+			log.Infof("Removed the roles of user %s as it is not in the %s file\n", util.ColorInfo(name), kube.UsersConfigFileName)
+		}
+	}
+	return true, nil
+}
@@ -62,6 +62,7 @@ func NewCmdStepHelm(f Factory, in terminal.FileReader, out terminal.FileWriter,
 	cmd.AddCommand(NewCmdStepHelmInstall(f, in, out, errOut))
 	cmd.AddCommand(NewCmdStepHelmList(f, in, out, errOut))
 	cmd.AddCommand(NewCmdStepHelmRelease(f, in, out, errOut))
+	cmd.AddCommand(NewCmdStepHelmValidate(f, in, out, errOut))
 	cmd.AddCommand(NewCmdStepHelmVersion(f, in, out, errOut))
 	return cmd
 }
@@ -75,6 +76,7 @@ func (o *StepHelmOptions) addStepHelmFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&o.Dir, "dir", "d", ".", "The directory containing the helm chart to apply")
 	cmd.Flags().BoolVarP(&o.https, "clone-https", "", true, "Clone the environment Git repo over https rather than ssh which uses `git@foo/bar.git`")
 	cmd.Flags().StringVarP(&o.GitProvider, "git-provider", "", "github.com", "The Git provider for the environment Git repository")
+	cmd.Flags().BoolVarP(&o.Helm3, "helm3", "", false, "Uses the helm 3 command line syntax. Defaults to detecting the version of the helm binary")
 }
 
 func (o *StepHelmOptions) findStagingRepoIds() ([]string, error) {
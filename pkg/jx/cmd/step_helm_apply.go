@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -16,6 +18,9 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
 // StepHelmApplyOptions contains the command line flags
@@ -27,6 +32,10 @@ type StepHelmApplyOptions struct {
 	Wait               bool
 	Force              bool
 	DisableHelmVersion bool
+	ValueFiles         []string
+	NoDiff             bool
+	DiffOnly           bool
+	NoRollback         bool
 }
 
 var (
@@ -34,12 +43,21 @@ var (
 		Applies the helm chart in a given directory.
 
 		This step is usually used to apply any GitOps promotion changes into a Staging or Production cluster.
+
+		The values are merged from the team values in the ConfigMap jx-team-helm-values, then the values.yaml and
+		myvalues.yaml files of the environment repository, then the secrets from the secrets store and finally
+		any --values files so that later values override earlier ones.
+
+		A diff of the changes is shown before they are applied if the helm diff plugin is installed. The values of
+		Secrets are not shown in the diff. If the changes cannot be applied the release is rolled back to its previous revision.
 `)
 
 	StepHelmApplyExample = templates.Examples(`
 		# apply the chart in the env folder to namespace jx-staging 
 		jx step helm apply --dir env --namespace jx-staging
 
+		# show the changes a Pull Request on the environment repository would make without applying them
+		jx step helm apply --dir env --namespace jx-staging --diff-only
 `)
 
 	defaultValueFileNames = []string{"values.yaml", "myvalues.yaml", helm.SecretsFileName}
@@ -78,6 +96,10 @@ func NewCmdStepHelmApply(f Factory, in terminal.FileReader, out terminal.FileWri
 	cmd.Flags().BoolVarP(&options.Wait, "wait", "", true, "Wait for Kubernetes readiness probe to confirm deployment")
 	cmd.Flags().BoolVarP(&options.Force, "force", "f", true, "Whether to to pass '--force' to helm to help deal with upgrading if a previous promote failed")
 	cmd.Flags().BoolVar(&options.DisableHelmVersion, "no-helm-version", false, "Don't set Chart version before applying")
+	cmd.Flags().StringArrayVarP(&options.ValueFiles, "values", "", []string{}, "Additional values files which override all the other values")
+	cmd.Flags().BoolVarP(&options.NoDiff, "no-diff", "", false, "Don't show the changes before applying the chart")
+	cmd.Flags().BoolVarP(&options.DiffOnly, "diff-only", "", false, "Only show the changes without applying the chart")
+	cmd.Flags().BoolVarP(&options.NoRollback, "no-rollback", "", false, "Don't roll back the release if the chart fails to apply")
 
 	return cmd
 }
@@ -157,6 +179,14 @@ func (o *StepHelmApplyOptions) Run() error {
 	}
 
 	valueFiles := []string{}
+	teamValuesFile, err := o.teamValuesFile(kubeClient)
+	if err != nil {
+		return errors.Wrap(err, "loading the team helm values")
+	}
+	if teamValuesFile != "" {
+		defer util.DestroyFile(teamValuesFile)
+		valueFiles = append(valueFiles, teamValuesFile)
+	}
 	for _, name := range defaultValueFileNames {
 		file := filepath.Join(dir, name)
 		exists, err := util.FileExists(file)
@@ -165,8 +195,30 @@ func (o *StepHelmApplyOptions) Run() error {
 		}
 	}
 
+	valueFiles = append(valueFiles, o.ValueFiles...)
+
 	log.Infof("Using values files: %s\n", strings.Join(valueFiles, ", "))
 
+	if !o.NoDiff || o.DiffOnly {
+		diff, err := o.Helm().DiffChart(chartName, releaseName, ns, nil, valueFiles)
+		if err != nil {
+			if o.DiffOnly {
+				return errors.Wrapf(err, "diffing helm chart '%s'", chartName)
+			}
+			log.Warnf("Could not show the changes to release %s: %s\n", releaseName, err)
+		} else if strings.TrimSpace(diff) == "" {
+			log.Infof("No changes to release %s\n", info(releaseName))
+		} else {
+			log.Infof("Changes to release %s:\n%s\n", info(releaseName), diff)
+		}
+	}
+	if o.DiffOnly {
+		return nil
+	}
+
+	// lets remember if the release exists so that it can be rolled back if the upgrade fails
+	releaseExists := o.Helm().StatusRelease(ns, releaseName) == nil
+
 	if o.Wait {
 		timeout := 600
 		err = o.Helm().UpgradeChart(chartName, releaseName, ns, nil, true, &timeout, o.Force, true, nil, valueFiles,
@@ -176,11 +228,62 @@ func (o *StepHelmApplyOptions) Run() error {
 			"", "")
 	}
 	if err != nil {
-		return errors.Wrapf(err, "upgrading helm chart '%s'", chartName)
+		err = errors.Wrapf(err, "upgrading helm chart '%s'", chartName)
+		if !o.NoRollback && !helmTemplate {
+			return o.rollbackRelease(ns, releaseName, releaseExists, err)
+		}
+		return err
 	}
 	return nil
 }
 
+// rollbackRelease rolls back the release to its previous revision or deletes it if it did not exist before so that
+// a failed upgrade does not leave the environment half applied
+func (o *StepHelmApplyOptions) rollbackRelease(ns string, releaseName string, releaseExists bool, upgradeErr error) error {
+	var err error
+	if releaseExists {
+		log.Warnf("Rolling back release %s to its previous revision\n", releaseName)
+		err = o.Helm().RollbackRelease(ns, releaseName, 0)
+	} else {
+		log.Warnf("Deleting release %s as it failed to install\n", releaseName)
+		err = o.Helm().DeleteRelease(ns, releaseName, true)
+	}
+	if err != nil {
+		return fmt.Errorf("%s and failed to roll back release %s: %s", upgradeErr, releaseName, err)
+	}
+	return upgradeErr
+}
+
+// teamValuesFile writes the team helm values from the ConfigMap in the development namespace to a temporary file.
+// An empty file name is returned if the team has no values
+func (o *StepHelmApplyOptions) teamValuesFile(kubeClient kubernetes.Interface) (string, error) {
+	_, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return "", err
+	}
+	cm, err := kubeClient.CoreV1().ConfigMaps(devNs).Get(kube.ConfigMapTeamHelmValues, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	values := cm.Data[helm.ValuesFileName]
+	if strings.TrimSpace(values) == "" {
+		return "", nil
+	}
+	file, err := ioutil.TempFile("", "team-values-")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	_, err = file.WriteString(values)
+	if err != nil {
+		return "", errors.Wrapf(err, "writing the team values to %s", file.Name())
+	}
+	return file.Name(), nil
+}
+
 // ensureHelmSecrets ensures that the provided filename exists. If it does not, it will automatically create it and
 // populate it with secrets from the system vault. If the file exists, it naively assumes it is populated and won't
 // do any checks.
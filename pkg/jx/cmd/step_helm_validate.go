@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+// StepHelmValidateOptions contains the command line flags
+type StepHelmValidateOptions struct {
+	StepHelmOptions
+
+	Version string
+	NoLint  bool
+}
+
+var (
+	stepHelmValidateLong = templates.LongDesc(`
+		Validates the helm chart in a given directory before it is released.
+
+		The name and version of the chart, the versions and repositories of its requirements and its values are checked
+		and then the chart is linted.
+`)
+
+	stepHelmValidateExample = templates.Examples(`
+		# validates the helm chart in the current directory
+		jx step helm validate
+
+		# validates the chart has the version being released
+		jx step helm validate --dir charts/myapp --version 1.2.3
+`)
+)
+
+// NewCmdStepHelmValidate creates the command
+func NewCmdStepHelmValidate(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := StepHelmValidateOptions{
+		StepHelmOptions: StepHelmOptions{
+			StepOptions: StepOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					In:      in,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "validate",
+		Short:   "Validates the helm chart in a given directory",
+		Aliases: []string{"lint"},
+		Long:    stepHelmValidateLong,
+		Example: stepHelmValidateExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addStepHelmFlags(cmd)
+	options.addCommonFlags(cmd)
+
+	cmd.Flags().StringVarP(&options.Version, optionVersion, "v", "", "The version the chart must have")
+	cmd.Flags().BoolVarP(&options.NoLint, "no-lint", "", false, "Only validates the files of the chart without running helm lint")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepHelmValidateOptions) Run() error {
+	dir := o.Dir
+	if dir == "" {
+		var err error
+		dir, err = os.Getwd()
+		if err != nil {
+			return err
+		}
+	}
+	err := helm.ValidateChart(dir, o.Version)
+	if err != nil {
+		return errors.Wrapf(err, "validating the chart in %s", dir)
+	}
+	if !o.NoLint {
+		o.Helm().SetCWD(dir)
+		output, err := o.Helm().Lint()
+		if err != nil {
+			return errors.Wrapf(err, "linting the chart in %s: %s", dir, output)
+		}
+	}
+	log.Infof("The chart in %s is valid\n", util.ColorInfo(dir))
+	return nil
+}
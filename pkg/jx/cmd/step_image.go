@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+// StepImageOptions contains the command line flags
+type StepImageOptions struct {
+	StepOptions
+}
+
+// NewCmdStepImage Steps a command object for the "step" command
+func NewCmdStepImage(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &StepImageOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "image",
+		Short: "image [command]",
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.AddCommand(NewCmdStepImageBuild(f, in, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *StepImageOptions) Run() error {
+	return o.Cmd.Help()
+}
@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+const (
+	// DefaultBuildpacksBuilder the Cloud Native Buildpacks builder image used if the team does not specify one
+	DefaultBuildpacksBuilder = "cloudfoundry/cnb:bionic"
+
+	// kanikoDir the directory the kaniko addon installs the executor into in the build pods
+	kanikoDir        = "/kaniko"
+	kanikoExecutor   = kanikoDir + "/executor"
+	buildKitBuildctl = "buildctl-daemonless.sh"
+	// buildKitBinDir the directory the kaniko addon installs the rootless BuildKit binaries into in the build pods
+	buildKitBinDir = "/buildkit/bin"
+	// packBinDir the directory the pack CLI of Cloud Native Buildpacks is installed into in the build pods
+	packBinDir = "/buildpacks/bin"
+)
+
+var (
+	stepImageBuildLong = templates.LongDesc(`
+		Builds the container image of the current project and pushes it to the Docker registry using the image
+		builder of the team.
+
+		The image builders are:
+
+		* docker uses skaffold to build the Dockerfile with the Docker daemon
+		* kaniko builds the Dockerfile without a Docker daemon
+		* buildkit builds the Dockerfile without a Docker daemon using rootless BuildKit
+		* buildpacks builds the project without a Dockerfile using a Cloud Native Buildpacks builder
+
+		The credentials of the registry are taken from the Docker config.json in $DOCKER_CONFIG or ~/.docker
+
+		When the image cache of the team is enabled kaniko and buildkit push the image layers to a cache
+		repository in the registry so that subsequent builds can reuse them.
+`)
+
+	stepImageBuildExample = templates.Examples(`
+		# builds the image using the image builder of the team, the image defaults to
+		# $DOCKER_REGISTRY/$ORG/$APP_NAME:$VERSION
+		jx step image build
+
+		# builds the image without a Docker daemon
+		jx step image build --builder kaniko
+
+		# builds the image with rootless BuildKit caching the layers in the registry
+		jx step image build --builder buildkit --cache
+	`)
+)
+
+// StepImageBuildOptions contains the command line flags
+type StepImageBuildOptions struct {
+	StepOptions
+
+	Dir               string
+	Image             string
+	Builder           string
+	BuildpacksBuilder string
+	Dockerfile        string
+	Cache             bool
+	NoCache           bool
+	CacheRepo         string
+}
+
+// NewCmdStepImageBuild Creates a new Command object
+func NewCmdStepImageBuild(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &StepImageBuildOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "build",
+		Short:   "Builds the container image of the project using the image builder of the team",
+		Long:    stepImageBuildLong,
+		Example: stepImageBuildExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addCommonFlags(cmd)
+
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The directory of the project to build. Defaults to the current directory")
+	cmd.Flags().StringVarP(&options.Image, "image", "i", "", "The image to build and push. Defaults to $DOCKER_REGISTRY/$ORG/$APP_NAME:$VERSION")
+	cmd.Flags().StringVarP(&options.Builder, "builder", "", "", "The image builder to use. Defaults to the image builder of the team. Possible values: "+strings.Join(v1.ImageBuilderTypes, ", "))
+	cmd.Flags().StringVarP(&options.BuildpacksBuilder, "buildpacks-builder", "", "", "The Cloud Native Buildpacks builder image. Defaults to the builder of the team or "+DefaultBuildpacksBuilder)
+	cmd.Flags().StringVarP(&options.Dockerfile, "dockerfile", "f", "Dockerfile", "The Dockerfile used by kaniko and buildkit")
+	cmd.Flags().BoolVarP(&options.Cache, "cache", "", false, "Caches the image layers in the registry. Defaults to the image cache setting of the team")
+	cmd.Flags().BoolVarP(&options.NoCache, "no-cache", "", false, "Disables caching the image layers in the registry")
+	cmd.Flags().StringVarP(&options.CacheRepo, "cache-repo", "", "", "The repository the image layers are cached in. Defaults to the cache repository of the team or the image repository with a /cache suffix")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepImageBuildOptions) Run() error {
+	var err error
+	dir := o.Dir
+	if dir == "" {
+		dir, err = os.Getwd()
+		if err != nil {
+			return err
+		}
+	}
+
+	builder := v1.ImageBuilderType(o.Builder)
+	buildpacksBuilder := o.BuildpacksBuilder
+	cache := o.Cache
+	cacheRepo := o.CacheRepo
+	settings, err := o.TeamSettings()
+	if err != nil {
+		if builder == "" {
+			return errors.Wrap(err, "loading the team settings")
+		}
+		log.Warnf("Failed to load the team settings: %s\n", err)
+		settings = &v1.TeamSettings{}
+	}
+	if builder == "" {
+		builder = settings.GetImageBuilder()
+	}
+	if buildpacksBuilder == "" {
+		buildpacksBuilder = settings.BuildpacksBuilder
+	}
+	if !cache {
+		cache = settings.ImageCache || cacheRepo != ""
+	}
+	if cacheRepo == "" {
+		cacheRepo = settings.ImageCacheRepo
+	}
+	if o.NoCache {
+		cache = false
+	}
+	if util.StringArrayIndex(v1.ImageBuilderTypes, string(builder)) < 0 {
+		return util.InvalidOption("builder", string(builder), v1.ImageBuilderTypes)
+	}
+
+	if builder == v1.ImageBuilderDocker {
+		return o.runCommandVerboseAt(dir, "skaffold", "build", "-f", "skaffold.yaml")
+	}
+
+	image := o.Image
+	if image == "" {
+		image = defaultImageName()
+		if image == "" {
+			return util.MissingOption("image")
+		}
+	}
+	env := map[string]string{
+		"DOCKER_CONFIG": dockerConfigDir(),
+	}
+
+	cmd := util.Command{
+		Dir: dir,
+		Env: env,
+		Out: o.Out,
+		Err: o.Err,
+	}
+	switch builder {
+	case v1.ImageBuilderKaniko:
+		cmd.Name = kanikoExecutor
+		if exists, _ := util.FileExists(kanikoExecutor); !exists {
+			cmd.Name = "executor"
+		}
+		cmd.Args = []string{"--context", dir, "--dockerfile", filepath.Join(dir, o.Dockerfile), "--destination", image}
+		if cache {
+			cmd.Args = append(cmd.Args, "--cache=true", "--cache-repo", imageCacheRepo(image, cacheRepo))
+		}
+	case v1.ImageBuilderBuildKit:
+		cmd.Name = buildKitBuildctl
+		if exists, _ := util.FileExists(buildKitBinDir); exists {
+			cmd.Name = filepath.Join(buildKitBinDir, buildKitBuildctl)
+			cmd.Env["PATH"] = buildKitBinDir + string(os.PathListSeparator) + os.Getenv("PATH")
+		}
+		cmd.Env["BUILDKITD_FLAGS"] = "--oci-worker-no-process-sandbox"
+		cmd.Args = []string{"build", "--frontend", "dockerfile.v0", "--local", "context=" + dir,
+			"--local", "dockerfile=" + filepath.Dir(filepath.Join(dir, o.Dockerfile)),
+			"--opt", "filename=" + filepath.Base(o.Dockerfile),
+			"--output", "type=image,name=" + image + ",push=true"}
+		if cache {
+			ref := imageCacheRepo(image, cacheRepo)
+			cmd.Args = append(cmd.Args, "--export-cache", "type=registry,ref="+ref, "--import-cache", "type=registry,ref="+ref)
+		}
+	case v1.ImageBuilderBuildpacks:
+		if buildpacksBuilder == "" {
+			buildpacksBuilder = DefaultBuildpacksBuilder
+		}
+		cmd.Name = "pack"
+		if exists, _ := util.FileExists(filepath.Join(packBinDir, "pack")); exists {
+			cmd.Name = filepath.Join(packBinDir, "pack")
+		}
+		cmd.Args = []string{"build", image, "--builder", buildpacksBuilder, "--path", dir, "--publish"}
+	}
+
+	log.Infof("Building image %s using %s\n", util.ColorInfo(image), util.ColorInfo(builder))
+	_, err = cmd.RunWithoutRetry()
+	if err != nil {
+		return errors.Wrapf(err, "building image %s using %s", image, builder)
+	}
+	log.Successf("Pushed %s", image)
+	return nil
+}
+
+// imageBuildCommand returns the command used by the pipelines to build images with the given image builder or an
+// empty string if the default skaffold command of the build packs should be used
+func imageBuildCommand(builder v1.ImageBuilderType) string {
+	if builder == "" || builder == v1.ImageBuilderDocker {
+		return ""
+	}
+	return "jx step image build --builder " + string(builder)
+}
+
+// imageCacheRepo returns the repository the layers of the image are cached in defaulting to the repository of the
+// image with a /cache suffix
+func imageCacheRepo(image string, cacheRepo string) string {
+	if cacheRepo != "" {
+		return cacheRepo
+	}
+	repo := image
+	idx := strings.LastIndex(repo, ":")
+	if idx > strings.LastIndex(repo, "/") {
+		repo = repo[:idx]
+	}
+	return repo + "/cache"
+}
+
+// defaultImageName returns the image name from the environment variables of the pipeline
+func defaultImageName() string {
+	dockerRegistry := os.Getenv("DOCKER_REGISTRY")
+	dockerRegistryOrg := os.Getenv("DOCKER_REGISTRY_ORG")
+	if dockerRegistryOrg == "" {
+		dockerRegistryOrg = os.Getenv("ORG")
+	}
+	appName := os.Getenv("APP_NAME")
+	version := os.Getenv("VERSION")
+	if dockerRegistry == "" || dockerRegistryOrg == "" || appName == "" || version == "" {
+		return ""
+	}
+	return dockerRegistry + "/" + dockerRegistryOrg + "/" + appName + ":" + version
+}
+
+// dockerConfigDir returns the directory of the Docker config.json which contains the registry credentials
+func dockerConfigDir() string {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		dir = filepath.Join(util.HomeDir(), ".docker")
+	}
+	return dir
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"io"
+	"sort"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+const (
+	migrationFileName        = "migration.yaml"
+	migrationSecretsFileName = "secrets.yaml"
+)
+
+// StepMigrateOptions contains the command line flags
+type StepMigrateOptions struct {
+	StepOptions
+
+	Dir string
+}
+
+// Migration the state of Jenkins X exported from the old cluster which is imported into the new cluster
+type Migration struct {
+	Domain          string                 `json:"domain,omitempty"`
+	WebHookEndpoint string                 `json:"webHookEndpoint,omitempty"`
+	Environments    []v1.Environment       `json:"environments,omitempty"`
+	Applications    []MigrationApplication `json:"applications,omitempty"`
+	Repositories    []MigrationRepository  `json:"repositories,omitempty"`
+}
+
+// MigrationApplication an application deployed to an environment of the old cluster
+type MigrationApplication struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Version   string `json:"version,omitempty"`
+}
+
+// MigrationRepository a Git repository with a webhook which points at the old cluster
+type MigrationRepository struct {
+	Owner string `json:"owner"`
+	Name  string `json:"name"`
+}
+
+// NewCmdStepMigrate creates the command
+func NewCmdStepMigrate(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &StepMigrateOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrates Jenkins X from one cluster to another",
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.AddCommand(NewCmdStepMigrateExport(f, in, out, errOut))
+	cmd.AddCommand(NewCmdStepMigrateImport(f, in, out, errOut))
+
+	return cmd
+}
+
+// Run implements this command
+func (o *StepMigrateOptions) Run() error {
+	return o.Cmd.Help()
+}
+
+// migrationRepositories returns the repositories of the environments and of the applications built by the pipelines
+// sorted by owner and name without duplicates
+func migrationRepositories(envs []v1.Environment, activities []v1.PipelineActivity) []MigrationRepository {
+	answer := []MigrationRepository{}
+	found := map[MigrationRepository]bool{}
+	add := func(repo MigrationRepository) {
+		if repo.Owner == "" || repo.Name == "" || found[repo] {
+			return
+		}
+		found[repo] = true
+		answer = append(answer, repo)
+	}
+	for _, env := range envs {
+		if env.Spec.Source.URL == "" {
+			continue
+		}
+		gitInfo, err := gits.ParseGitURL(env.Spec.Source.URL)
+		if err != nil {
+			continue
+		}
+		add(MigrationRepository{Owner: gitInfo.Organisation, Name: gitInfo.Name})
+	}
+	for _, activity := range activities {
+		add(MigrationRepository{Owner: activity.Spec.GitOwner, Name: activity.Spec.GitRepository})
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		if answer[i].Owner != answer[j].Owner {
+			return answer[i].Owner < answer[j].Owner
+		}
+		return answer[i].Name < answer[j].Name
+	})
+	return answer
+}
+
+// missingApplications returns the applications of the old cluster which are not deployed to the same namespace
+// of the new cluster
+func missingApplications(apps []MigrationApplication, releases map[string]map[string]helm.Release) []MigrationApplication {
+	answer := []MigrationApplication{}
+	for _, app := range apps {
+		if _, ok := releases[app.Namespace][app.Name]; !ok {
+			answer = append(answer, app)
+		}
+	}
+	return answer
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StepMigrateExportOptions contains the command line flags
+type StepMigrateExportOptions struct {
+	StepMigrateOptions
+}
+
+var (
+	stepMigrateExportLong = templates.LongDesc(`
+		Exports the environments, applications, webhooks and secrets of Jenkins X from the current cluster into a
+		directory so that they can be imported into a new cluster with 'jx step migrate import'.
+
+		The secrets are written to a separate file which is only readable by the current user. Keep it safe and
+		delete it once the migration is complete.
+`)
+
+	stepMigrateExportExample = templates.Examples(`
+		# export Jenkins X from the old cluster
+		jx step migrate export --dir migration
+
+		# then switch to the new cluster and import it
+		jx step migrate import --dir migration --domain new.example.com
+`)
+)
+
+// NewCmdStepMigrateExport creates the command
+func NewCmdStepMigrateExport(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := StepMigrateExportOptions{
+		StepMigrateOptions: StepMigrateOptions{
+			StepOptions: StepOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					In:      in,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   "Exports the state of Jenkins X from the current cluster",
+		Long:    stepMigrateExportLong,
+		Example: stepMigrateExportExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "migration", "The directory the state of Jenkins X is exported to")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *StepMigrateExportOptions) Run() error {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(o.Dir, DefaultWritePermissions)
+	if err != nil {
+		return errors.Wrapf(err, "creating the directory %s", o.Dir)
+	}
+
+	migration := Migration{}
+	ic, err := kube.GetIngressConfig(kubeClient, devNs)
+	if err != nil {
+		return errors.Wrap(err, "reading the ingress config")
+	}
+	migration.Domain = ic.Domain
+	migration.WebHookEndpoint, err = o.GetWebHookEndpoint()
+	if err != nil {
+		return errors.Wrap(err, "getting the webhook endpoint")
+	}
+
+	envs, _, err := kube.GetEnvironments(jxClient, devNs)
+	if err != nil {
+		return errors.Wrap(err, "listing the environments")
+	}
+	for _, env := range envs {
+		if env.Spec.Kind == v1.EnvironmentKindTypePreview {
+			continue
+		}
+		exported := v1.Environment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        env.Name,
+				Labels:      env.Labels,
+				Annotations: env.Annotations,
+			},
+			Spec: env.Spec,
+		}
+		migration.Environments = append(migration.Environments, exported)
+
+		if env.Spec.Kind != v1.EnvironmentKindTypePermanent || env.Spec.Namespace == "" {
+			continue
+		}
+		releases, err := o.Helm().StatusReleases(env.Spec.Namespace)
+		if err != nil {
+			return errors.Wrapf(err, "listing the helm releases in namespace %s", env.Spec.Namespace)
+		}
+		// helm 2 lists the releases of all the namespaces
+		for name, release := range helm.ReleasesInNamespace(releases, env.Spec.Namespace) {
+			migration.Applications = append(migration.Applications, MigrationApplication{
+				Name:      name,
+				Namespace: env.Spec.Namespace,
+				Version:   release.Version,
+			})
+		}
+	}
+
+	activities, err := jxClient.JenkinsV1().PipelineActivities(devNs).List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "listing the pipeline activities")
+	}
+	migration.Repositories = migrationRepositories(migration.Environments, activities.Items)
+
+	data, err := yaml.Marshal(&migration)
+	if err != nil {
+		return errors.Wrap(err, "converting the migration to YAML")
+	}
+	fileName := filepath.Join(o.Dir, migrationFileName)
+	err = ioutil.WriteFile(fileName, data, DefaultWritePermissions)
+	if err != nil {
+		return errors.Wrapf(err, "saving %s", fileName)
+	}
+
+	secrets, err := o.exportSecrets(devNs)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Exported %d environments, %d applications, %d repositories and %d secrets to %s\n",
+		len(migration.Environments), len(migration.Applications), len(migration.Repositories), secrets, util.ColorInfo(o.Dir))
+	return nil
+}
+
+// exportSecrets saves the Secrets of the development namespace which are not generated by Kubernetes or cert-manager
+// and returns how many were saved
+func (o *StepMigrateExportOptions) exportSecrets(ns string) (int, error) {
+	list, err := o.KubeClientCached.CoreV1().Secrets(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return 0, errors.Wrapf(err, "listing the Secrets in namespace %s", ns)
+	}
+	exported := corev1.SecretList{}
+	for _, secret := range list.Items {
+		if secret.Type == corev1.SecretTypeServiceAccountToken || secret.Type == corev1.SecretTypeTLS {
+			continue
+		}
+		exported.Items = append(exported.Items, corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        secret.Name,
+				Labels:      secret.Labels,
+				Annotations: secret.Annotations,
+			},
+			Type: secret.Type,
+			Data: secret.Data,
+		})
+	}
+	data, err := yaml.Marshal(&exported)
+	if err != nil {
+		return 0, errors.Wrap(err, "converting the Secrets to YAML")
+	}
+	fileName := filepath.Join(o.Dir, migrationSecretsFileName)
+	err = ioutil.WriteFile(fileName, data, 0600)
+	if err != nil {
+		return 0, errors.Wrapf(err, "saving %s", fileName)
+	}
+	return len(exported.Items), nil
+}
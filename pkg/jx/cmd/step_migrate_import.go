@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StepMigrateImportOptions contains the command line flags
+type StepMigrateImportOptions struct {
+	StepMigrateOptions
+
+	Domain           string
+	OverwriteSecrets bool
+	NoRedeploy       bool
+	NoWebHooks       bool
+}
+
+var (
+	stepMigrateImportLong = templates.LongDesc(`
+		Imports the state of Jenkins X exported by 'jx step migrate export' into the current cluster.
+
+		Install Jenkins X on the new cluster first. The secrets and environments of the old cluster are then
+		created, the domain is changed, the permanent environments are redeployed from their GitOps repositories and
+		the webhooks of the repositories are moved from the old cluster to the new one. Finally any applications of
+		the old cluster which are not running on the new one are reported.
+
+		The development environment of the new cluster is kept as it is.
+`)
+
+	stepMigrateImportExample = templates.Examples(`
+		# import Jenkins X into the new cluster using a new domain
+		jx step migrate import --dir migration --domain new.example.com
+
+		# import Jenkins X without moving the webhooks yet so that both clusters can be compared
+		jx step migrate import --dir migration --no-webhooks
+`)
+)
+
+// NewCmdStepMigrateImport creates the command
+func NewCmdStepMigrateImport(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := StepMigrateImportOptions{
+		StepMigrateOptions: StepMigrateOptions{
+			StepOptions: StepOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					In:      in,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "import",
+		Short:   "Imports the state of Jenkins X into the current cluster",
+		Long:    stepMigrateImportLong,
+		Example: stepMigrateImportExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "migration", "The directory the state of Jenkins X was exported to")
+	cmd.Flags().StringVarP(&options.Domain, "domain", "", "", "The domain of the new cluster. Defaults to the domain of the current installation")
+	cmd.Flags().BoolVarP(&options.OverwriteSecrets, "overwrite-secrets", "", false, "Overwrites the Secrets which already exist on the new cluster")
+	cmd.Flags().BoolVarP(&options.NoRedeploy, "no-redeploy", "", false, "Doesn't redeploy the permanent environments from their GitOps repositories")
+	cmd.Flags().BoolVarP(&options.NoWebHooks, "no-webhooks", "", false, "Doesn't move the webhooks to the new cluster")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *StepMigrateImportOptions) Run() error {
+	migration := Migration{}
+	fileName := filepath.Join(o.Dir, migrationFileName)
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s, did you run 'jx step migrate export'?", fileName)
+	}
+	err = yaml.Unmarshal(data, &migration)
+	if err != nil {
+		return errors.Wrapf(err, "parsing %s", fileName)
+	}
+
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	_, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+
+	err = o.importSecrets(devNs)
+	if err != nil {
+		return err
+	}
+	err = o.importEnvironments(devNs, migration.Environments)
+	if err != nil {
+		return err
+	}
+
+	ic, err := kube.GetIngressConfig(kubeClient, devNs)
+	if err != nil {
+		return errors.Wrap(err, "reading the ingress config")
+	}
+	if o.Domain != "" && o.Domain != ic.Domain {
+		ic.Domain = o.Domain
+		_, err = kube.SaveAsConfigMap(kubeClient, kube.ConfigMapIngressConfig, devNs, ic)
+		if err != nil {
+			return errors.Wrap(err, "saving the domain into the ingress config")
+		}
+		log.Infof("Changed the domain from %s to %s\n", util.ColorInfo(migration.Domain), util.ColorInfo(o.Domain))
+	}
+
+	if !o.NoRedeploy {
+		err = o.redeployEnvironments(migration.Environments, ic.Domain)
+		if err != nil {
+			return err
+		}
+		err = o.verifyApplications(migration.Applications)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !o.NoWebHooks {
+		err = o.moveWebHooks(migration)
+		if err != nil {
+			return err
+		}
+	}
+	log.Successf("Imported Jenkins X from %s", o.Dir)
+	return nil
+}
+
+// importSecrets creates the exported Secrets in the development namespace
+func (o *StepMigrateImportOptions) importSecrets(ns string) error {
+	fileName := filepath.Join(o.Dir, migrationSecretsFileName)
+	exists, err := util.FileExists(fileName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Warnf("No Secrets found at %s\n", fileName)
+		return nil
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", fileName)
+	}
+	list := corev1.SecretList{}
+	err = yaml.Unmarshal(data, &list)
+	if err != nil {
+		return errors.Wrapf(err, "parsing %s", fileName)
+	}
+	secrets := o.KubeClientCached.CoreV1().Secrets(ns)
+	for i := range list.Items {
+		secret := &list.Items[i]
+		_, err = secrets.Create(secret)
+		if apierrors.IsAlreadyExists(err) {
+			if !o.OverwriteSecrets {
+				log.Infof("Keeping the existing Secret %s\n", util.ColorInfo(secret.Name))
+				continue
+			}
+			_, err = secrets.Update(secret)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "saving the Secret %s in namespace %s", secret.Name, ns)
+		}
+		log.Infof("Imported the Secret %s\n", util.ColorInfo(secret.Name))
+	}
+	return nil
+}
+
+// importEnvironments creates, or updates, the exported environments apart from the development environment
+func (o *StepMigrateImportOptions) importEnvironments(ns string, envs []v1.Environment) error {
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	environments := jxClient.JenkinsV1().Environments(ns)
+	for i := range envs {
+		env := &envs[i]
+		if env.Spec.Kind == v1.EnvironmentKindTypeDevelopment {
+			continue
+		}
+		existing, err := environments.Get(env.Name, metav1.GetOptions{})
+		if err == nil {
+			existing.Spec = env.Spec
+			_, err = environments.Update(existing)
+		} else {
+			_, err = environments.Create(env)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "saving the Environment %s in namespace %s", env.Name, ns)
+		}
+		log.Infof("Imported the Environment %s\n", util.ColorInfo(env.Name))
+	}
+	return nil
+}
+
+// redeployEnvironments applies the GitOps repositories of the permanent environments using the given domain
+func (o *StepMigrateImportOptions) redeployEnvironments(envs []v1.Environment, domain string) error {
+	tmpDir, err := ioutil.TempDir("", "jx-migrate-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	valuesFile := filepath.Join(tmpDir, "domain.yaml")
+	data, err := yaml.Marshal(map[string]interface{}{
+		"expose": map[string]interface{}{
+			"config": map[string]interface{}{
+				"domain": domain,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(valuesFile, data, DefaultWritePermissions)
+	if err != nil {
+		return errors.Wrapf(err, "saving %s", valuesFile)
+	}
+
+	for _, env := range envs {
+		if env.Spec.Kind != v1.EnvironmentKindTypePermanent || env.Spec.Source.URL == "" {
+			continue
+		}
+		dir := filepath.Join(tmpDir, env.Name)
+		err = o.Git().Clone(env.Spec.Source.URL, dir)
+		if err != nil {
+			return errors.Wrapf(err, "cloning the repository %s of the Environment %s", env.Spec.Source.URL, env.Name)
+		}
+		log.Infof("Redeploying the Environment %s from %s\n", util.ColorInfo(env.Name), util.ColorInfo(env.Spec.Source.URL))
+		stepEnvApply := &StepEnvApplyOptions{
+			StepEnvOptions: StepEnvOptions{
+				StepOptions: o.StepOptions,
+			},
+			Namespace:  env.Spec.Namespace,
+			Dir:        dir,
+			Wait:       true,
+			Force:      true,
+			ValueFiles: []string{valuesFile},
+		}
+		err = stepEnvApply.Run()
+		if err != nil {
+			return errors.Wrapf(err, "redeploying the Environment %s", env.Name)
+		}
+	}
+	return nil
+}
+
+// verifyApplications warns about the applications of the old cluster which are not running on the new cluster
+func (o *StepMigrateImportOptions) verifyApplications(apps []MigrationApplication) error {
+	releases := map[string]map[string]helm.Release{}
+	for _, app := range apps {
+		if releases[app.Namespace] != nil {
+			continue
+		}
+		nsReleases, err := o.Helm().StatusReleases(app.Namespace)
+		if err != nil {
+			return errors.Wrapf(err, "listing the helm releases in namespace %s", app.Namespace)
+		}
+		releases[app.Namespace] = helm.ReleasesInNamespace(nsReleases, app.Namespace)
+	}
+	missing := missingApplications(apps, releases)
+	for _, app := range missing {
+		log.Warnf("The application %s %s is not deployed to namespace %s\n", app.Name, app.Version, app.Namespace)
+	}
+	if len(missing) == 0 {
+		log.Infof("All %d applications are deployed\n", len(apps))
+	}
+	return nil
+}
+
+// moveWebHooks updates the webhooks of the repositories which point at the old cluster to the new cluster
+func (o *StepMigrateImportOptions) moveWebHooks(migration Migration) error {
+	newEndpoint, err := o.GetWebHookEndpoint()
+	if err != nil {
+		return errors.Wrap(err, "getting the webhook endpoint")
+	}
+	if newEndpoint == migration.WebHookEndpoint {
+		log.Infof("The webhook endpoint %s has not changed\n", util.ColorInfo(newEndpoint))
+		return nil
+	}
+	log.Infof("Moving the webhooks from %s to %s\n", util.ColorInfo(migration.WebHookEndpoint), util.ColorInfo(newEndpoint))
+	errs := []error{}
+	for _, repo := range migration.Repositories {
+		updateWebHooks := &UpdateWebhooksOptions{
+			CommonOptions:   o.CommonOptions,
+			Org:             repo.Owner,
+			Repo:            repo.Name,
+			ExactHookMatch:  true,
+			PreviousHookUrl: migration.WebHookEndpoint,
+			CreateMissing:   true,
+		}
+		err = updateWebHooks.Run()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("moving the webhooks of %s/%s: %s", repo.Owner, repo.Name, err))
+		}
+	}
+	return util.CombineErrors(errs...)
+}
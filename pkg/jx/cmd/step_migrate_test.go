@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrationRepositories(t *testing.T) {
+	t.Parallel()
+	envs := []v1.Environment{
+		{Spec: v1.EnvironmentSpec{Source: v1.EnvironmentRepository{URL: "https://github.com/myorg/environment-mycluster-staging.git"}}},
+		{Spec: v1.EnvironmentSpec{}},
+	}
+	activities := []v1.PipelineActivity{
+		{Spec: v1.PipelineActivitySpec{GitOwner: "myorg", GitRepository: "myapp"}},
+		{Spec: v1.PipelineActivitySpec{GitOwner: "myorg", GitRepository: "myapp"}},
+		{Spec: v1.PipelineActivitySpec{GitOwner: "another", GitRepository: "lib"}},
+		{Spec: v1.PipelineActivitySpec{GitOwner: "myorg"}},
+	}
+
+	assert.Equal(t, []MigrationRepository{
+		{Owner: "another", Name: "lib"},
+		{Owner: "myorg", Name: "environment-mycluster-staging"},
+		{Owner: "myorg", Name: "myapp"},
+	}, migrationRepositories(envs, activities))
+}
+
+func TestMissingApplications(t *testing.T) {
+	t.Parallel()
+	apps := []MigrationApplication{
+		{Name: "jx-staging-myapp", Namespace: "jx-staging", Version: "1.0.1"},
+		{Name: "jx-staging-other", Namespace: "jx-staging", Version: "0.0.3"},
+		{Name: "jx-production-myapp", Namespace: "jx-production", Version: "1.0.0"},
+	}
+	releases := map[string]map[string]helm.Release{
+		"jx-staging": {
+			"jx-staging-myapp": {Release: "jx-staging-myapp", Status: "DEPLOYED", Version: "1.0.1"},
+		},
+	}
+
+	assert.Equal(t, apps[1:], missingApplications(apps, releases))
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -15,8 +16,11 @@ import (
 
 	"github.com/blang/semver"
 	version "github.com/hashicorp/go-version"
+	chgit "github.com/jenkins-x/chyle/chyle/git"
+	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 )
@@ -34,6 +38,8 @@ type StepNextVersionOptions struct {
 	Dir           string
 	Tag           bool
 	UseGitTagOnly bool
+	Semantic      bool
+	TagPrefix     string
 	NewVersion    string
 	StepOptions
 }
@@ -49,6 +55,15 @@ type PackageJSON struct {
 var (
 	StepNextVersionLong = templates.LongDesc(`
 		This pipeline step command works out a semantic version, writes a file ./VERSION and optionally updates a file
+
+		By default the patch version of the latest git tag is incremented. The major and minor versions can be bumped
+		by changing the version in the build file (pom.xml, package.json, Makefile or Chart.yaml) or by using '--semantic'
+		which uses the Conventional Commits since the latest git tag: breaking changes bump the major version, features
+		the minor version and anything else the patch version. See: https://conventionalcommits.org/
+
+		The new version is written to the ./VERSION file so that later steps of the pipeline can use it.
+
+		The applications of a monorepo use '--tag-prefix' to only consider the tags of their own releases.
 `)
 
 	StepNextVersionExample = templates.Examples(`
@@ -56,6 +71,12 @@ var (
 		jx step next-version --filename package.json
 		jx step next-version --filename package.json --tag
 		jx step next-version --filename package.json --tag --version 1.2.3
+
+		# use the Conventional Commits since the last release to work out the next version and update the pom.xml
+		jx step next-version --semantic --filename pom.xml
+
+		# work out the next version of the orders application of a monorepo from its orders-v* tags
+		jx step next-version --use-git-tag-only --tag-prefix orders-
 `)
 )
 
@@ -78,6 +99,8 @@ func NewCmdStepNextVersion(f Factory, in terminal.FileReader, out terminal.FileW
 	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "the directory to look for files that contain a pom.xml or Makefile with the project version to bump")
 	cmd.Flags().BoolVarP(&options.Tag, "tag", "t", false, "tag and push new version")
 	cmd.Flags().BoolVarP(&options.UseGitTagOnly, "use-git-tag-only", "", false, "only use a git tag so work out new semantic version, else specify filename [pom.xml,package.json,Makefile,Chart.yaml]")
+	cmd.Flags().BoolVarP(&options.Semantic, "semantic", "", false, "use the Conventional Commits since the latest git tag to work out whether to bump the major, minor or patch version")
+	cmd.Flags().StringVarP(&options.TagPrefix, "tag-prefix", "", "", "only use the git tags with this prefix such as the name of the application of a monorepo")
 
 	options.addCommonFlags(cmd)
 	return cmd
@@ -98,6 +121,7 @@ func (o *StepNextVersionOptions) Run() error {
 	if err != nil {
 		return err
 	}
+	log.Infof("Next version is %s\n", util.ColorInfo(o.NewVersion))
 
 	// if filename flag set and recognised then update version, commit
 	if o.Filename != "" {
@@ -111,7 +135,8 @@ func (o *StepNextVersionOptions) Run() error {
 	if o.Tag {
 		tagOptions := StepTagOptions{
 			Flags: StepTagFlags{
-				Version: o.NewVersion,
+				Version:   o.NewVersion,
+				TagPrefix: o.TagPrefix,
 			},
 			StepOptions: o.StepOptions,
 		}
@@ -251,7 +276,10 @@ func (o *StepNextVersionOptions) getLatestTag() (string, error) {
 		if o.Verbose {
 			log.Infof("found tag %s\n", tag)
 		}
-		tag = strings.TrimPrefix(tag, "v")
+		if !strings.HasPrefix(tag, o.TagPrefix) {
+			continue
+		}
+		tag = strings.TrimPrefix(strings.TrimPrefix(tag, o.TagPrefix), "v")
 		if tag != "" {
 			versionsRaw[i] = tag
 		}
@@ -301,6 +329,16 @@ func (o *StepNextVersionOptions) getNewVersionFromTag() (string, error) {
 	majorVersion := sv.Major
 	minorVersion := sv.Minor
 	patchVersion := sv.Patch + 1
+	if o.Semantic {
+		messages, err := o.getCommitMessagesSinceLatestTag()
+		if err != nil {
+			return "", err
+		}
+		next := gits.NextVersion(sv, messages)
+		majorVersion = next.Major
+		minorVersion = next.Minor
+		patchVersion = next.Patch
+	}
 
 	// check if major or minor version has been changed
 	baseVersion, err := o.GetVersion()
@@ -338,6 +376,41 @@ func (o *StepNextVersionOptions) getNewVersionFromTag() (string, error) {
 	return fmt.Sprintf("%d.%d.%d", majorVersion, minorVersion, patchVersion), nil
 }
 
+// getCommitMessagesSinceLatestTag returns the messages of the commits since the latest version tag
+func (o *StepNextVersionOptions) getCommitMessagesSinceLatestTag() ([]string, error) {
+	dir := o.Dir
+	if dir == "" {
+		var err error
+		dir, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+	}
+	tags, err := o.Git().Tags(dir)
+	if err != nil {
+		return nil, err
+	}
+	tag, _ := gits.LatestPrefixedVersionTag(tags, o.TagPrefix)
+	if tag == "" {
+		return nil, nil
+	}
+	gitDir, _, err := o.Git().FindGitConfigDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := chgit.FetchCommits(gitDir, tag, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the commits since tag %s: %v", tag, err)
+	}
+	messages := []string{}
+	if commits != nil {
+		for _, commit := range *commits {
+			messages = append(messages, commit.Message)
+		}
+	}
+	return messages, nil
+}
+
 // SetVersion Sets the version...
 func (o *StepNextVersionOptions) SetVersion() error {
 	var err error
@@ -357,20 +430,33 @@ func (o *StepNextVersionOptions) SetVersion() error {
 		regex = regexp.MustCompile(`[0-9][0-9]{0,2}.[0-9][0-9]{0,2}(.[0-9][0-9]{0,2})?(.[0-9][0-9]{0,2})?(-.*)?`)
 		matchField = "version: "
 
+	case makefile, pomxml:
+
 	default:
-		return fmt.Errorf("unrecognised filename %s, supported files are %s %s", o.Filename, packagejson, chartyaml)
+		return fmt.Errorf("unrecognised filename %s, supported files are %s %s %s %s", o.Filename, packagejson, chartyaml, makefile, pomxml)
 	}
 
-	lines := strings.Split(string(b), "\n")
+	var output string
+	switch o.Filename {
+	case makefile:
+		output = setMakefileVersion(string(b), o.NewVersion)
+	case pomxml:
+		output, err = setPomVersion(string(b), o.NewVersion)
+		if err != nil {
+			return err
+		}
+	default:
+		lines := strings.Split(string(b), "\n")
 
-	for i, line := range lines {
-		if strings.Contains(line, matchField) {
-			lines[i] = regex.ReplaceAllString(line, o.NewVersion)
-		} else {
-			lines[i] = line
+		for i, line := range lines {
+			if strings.Contains(line, matchField) {
+				lines[i] = regex.ReplaceAllString(line, o.NewVersion)
+			} else {
+				lines[i] = line
+			}
 		}
+		output = strings.Join(lines, "\n")
 	}
-	output := strings.Join(lines, "\n")
 	err = ioutil.WriteFile(filename, []byte(output), 0644)
 	if err != nil {
 		return err
@@ -403,8 +489,50 @@ func (o *StepNextVersionOptions) setChartVersion(b []byte) error {
 	return nil
 }
 
-func (o *StepNextVersionOptions) setPomVersion(b []byte) error {
-	return nil
+// setMakefileVersion replaces the value of the VERSION variable of the Makefile
+func setMakefileVersion(text string, newVersion string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "VERSION") {
+			idx := strings.Index(line, "=")
+			if idx > 0 {
+				lines[i] = line[0:idx+1] + " " + newVersion
+				break
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// setPomVersion replaces the version of the project in the pom.xml ignoring the versions of any parent, dependency or
+// plugin which are nested in other elements
+func setPomVersion(text string, newVersion string) (string, error) {
+	decoder := xml.NewDecoder(strings.NewReader(text))
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse %s: %s", pomxml, err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 2 && t.Name.Local == "version" {
+				begin := int(decoder.InputOffset())
+				end := strings.Index(text[begin:], "</version>")
+				if end < 0 {
+					return "", fmt.Errorf("no end of the project version found in %s", pomxml)
+				}
+				return text[0:begin] + newVersion + text[begin+end:], nil
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+	return "", fmt.Errorf("no project version found in %s", pomxml)
 }
 
 // returns a string array containing the git owner and repo name for a given URL
@@ -74,3 +74,63 @@ func TestSetVersionChart(t *testing.T) {
 
 	assert.Equal(t, string(testFile), string(updatedFile), "replaced version")
 }
+
+func TestSetVersionMakefile(t *testing.T) {
+	f, err := ioutil.TempDir("", "test-set-version")
+	assert.NoError(t, err)
+
+	testData := path.Join("test_data", "next_version", "make")
+	_, err = os.Stat(testData)
+	assert.NoError(t, err)
+
+	err = util.CopyDir(testData, f, true)
+	assert.NoError(t, err)
+
+	git := gits.NewGitCLI()
+	err = git.Init(f)
+	assert.NoError(t, err)
+
+	o := cmd.StepNextVersionOptions{}
+	o.Out = tests.Output()
+	o.Dir = f
+	o.Filename = "Makefile"
+	o.NewVersion = "1.2.3"
+	err = o.SetVersion()
+	assert.NoError(t, err)
+
+	// root file
+	updatedFile, err := util.LoadBytes(o.Dir, o.Filename)
+	testFile, err := util.LoadBytes(testData, "expected_Makefile")
+
+	assert.Equal(t, string(testFile), string(updatedFile), "replaced version")
+}
+
+func TestSetVersionPomXML(t *testing.T) {
+	f, err := ioutil.TempDir("", "test-set-version")
+	assert.NoError(t, err)
+
+	testData := path.Join("test_data", "next_version", "java")
+	_, err = os.Stat(testData)
+	assert.NoError(t, err)
+
+	err = util.CopyDir(testData, f, true)
+	assert.NoError(t, err)
+
+	git := gits.NewGitCLI()
+	err = git.Init(f)
+	assert.NoError(t, err)
+
+	o := cmd.StepNextVersionOptions{}
+	o.Out = tests.Output()
+	o.Dir = f
+	o.Filename = "pom.xml"
+	o.NewVersion = "1.2.3"
+	err = o.SetVersion()
+	assert.NoError(t, err)
+
+	// root file
+	updatedFile, err := util.LoadBytes(o.Dir, o.Filename)
+	testFile, err := util.LoadBytes(testData, "expected_pom.xml")
+
+	assert.Equal(t, string(testFile), string(updatedFile), "replaced version")
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetPomVersion(t *testing.T) {
+	t.Parallel()
+	pom := `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <parent>
+    <groupId>org.springframework.boot</groupId>
+    <version>2.1.0.RELEASE</version>
+  </parent>
+  <!-- <version>0.0.0</version> -->
+  <artifactId>demo</artifactId>
+  <version>0.0.1-SNAPSHOT</version>
+</project>`
+	actual, err := setPomVersion(pom, "0.0.2")
+	require.NoError(t, err)
+	assert.Contains(t, actual, "<version>2.1.0.RELEASE</version>")
+	assert.Contains(t, actual, "<!-- <version>0.0.0</version> -->")
+	assert.Contains(t, actual, "<version>0.0.2</version>\n</project>")
+
+	pom = `<project>
+  <dependencies>
+    <dependency>
+      <artifactId>lib</artifactId>
+      <version>1.2.3</version>
+    </dependency>
+  </dependencies>
+  <version>1.0.0</version>
+</project>`
+	actual, err = setPomVersion(pom, "1.0.1")
+	require.NoError(t, err)
+	assert.Contains(t, actual, "<version>1.2.3</version>")
+	assert.Contains(t, actual, "<version>1.0.1</version>")
+
+	_, err = setPomVersion("<project><dependencies><dependency><version>1.0</version></dependency></dependencies></project>", "2.0")
+	assert.Error(t, err)
+}
@@ -0,0 +1,64 @@
+// +build integration
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepNextVersionSemanticCommitMessages(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-next-version-semantic")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for name, value := range map[string]string{
+		"GIT_AUTHOR_NAME":     "jx-test",
+		"GIT_AUTHOR_EMAIL":    "jx-test@jenkins-x.io",
+		"GIT_COMMITTER_NAME":  "jx-test",
+		"GIT_COMMITTER_EMAIL": "jx-test@jenkins-x.io",
+	} {
+		os.Setenv(name, value)
+	}
+
+	git := gits.NewGitCLI()
+	require.NoError(t, git.Init(dir))
+	require.NoError(t, git.AddCommit(dir, "chore: initial import"))
+
+	o := &StepNextVersionOptions{
+		Dir:      dir,
+		Semantic: true,
+	}
+	o.GitClient = git
+
+	messages, err := o.getCommitMessagesSinceLatestTag()
+	require.NoError(t, err)
+	assert.Empty(t, messages, "no messages without a version tag")
+
+	require.NoError(t, git.CreateTag(dir, "v1.2.3", "release 1.2.3"))
+	current := semver.MustParse("1.2.3")
+
+	require.NoError(t, git.AddCommit(dir, "fix: handle an empty chart"))
+	messages, err = o.getCommitMessagesSinceLatestTag()
+	require.NoError(t, err)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "1.2.4", gits.NextVersion(current, messages).String())
+
+	require.NoError(t, git.AddCommit(dir, "feat(helm): support the Chart.yaml"))
+	messages, err = o.getCommitMessagesSinceLatestTag()
+	require.NoError(t, err)
+	assert.Len(t, messages, 2)
+	assert.Equal(t, "1.3.0", gits.NextVersion(current, messages).String())
+
+	require.NoError(t, git.AddCommit(dir, "feat!: drop the Makefile support"))
+	messages, err = o.getCommitMessagesSinceLatestTag()
+	require.NoError(t, err)
+	assert.Len(t, messages, 3)
+	assert.Equal(t, "2.0.0", gits.NextVersion(current, messages).String())
+}
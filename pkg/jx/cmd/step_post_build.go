@@ -7,7 +7,6 @@ import (
 
 	"os"
 
-	"os/exec"
 	"strings"
 
 	"bufio"
@@ -38,6 +37,9 @@ type anchoreDetails struct {
 var (
 	StepPostBuildLong = templates.LongDesc(`
 		This pipeline step performs post build actions such as CVE analysis
+
+		If the team has installed an image scanning addon such as 'jx create addon trivy' the image is scanned
+		for vulnerabilities which are recorded as an ImageScan and commented on the Pull Request
 `)
 
 	StepPostBuildExample = templates.Examples(`
@@ -91,6 +93,11 @@ func (o *StepPostBuildOptions) Run() error {
 		return fmt.Errorf("error adding image to CVE provider: %v", err)
 	}
 
+	err = o.scanImage()
+	if err != nil {
+		return fmt.Errorf("error scanning image %s: %v", o.FullImageName, err)
+	}
+
 	return nil
 }
 func (o *StepPostBuildOptions) addImageCVEProvider() error {
@@ -139,11 +146,7 @@ func (o *StepPostBuildOptions) addImageToAnchore() (string, error) {
 		return "", err
 	}
 
-	cmd := exec.Command("anchore-cli", "image", "add", o.FullImageName)
-	cmd.Env = os.Environ()
-	cmd.Env = append(cmd.Env, "ANCHORE_CLI_USER="+a.Username)
-	cmd.Env = append(cmd.Env, "ANCHORE_CLI_PASS="+a.Password)
-	cmd.Env = append(cmd.Env, "ANCHORE_CLI_URL="+a.URL)
+	cmd := anchoreCommand(a, "image", "add", o.FullImageName)
 	data, err := cmd.CombinedOutput()
 	text := string(data)
 
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/security"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// maxImageScanCommentRows the maximum number of vulnerabilities listed in a Pull Request comment
+	maxImageScanCommentRows = 20
+)
+
+// teamImageScanner returns the image scanner addon installed by the team or nil if there is none
+func (o *CommonOptions) teamImageScanner() *v1.AddonSettings {
+	settings, err := o.TeamSettings()
+	if err != nil {
+		log.Warnf("Failed to load the team settings so not scanning images: %s\n", err)
+		return nil
+	}
+	for _, scanner := range security.Scanners {
+		addon := settings.Addon(scanner)
+		if addon != nil {
+			return addon
+		}
+	}
+	return nil
+}
+
+// scanImage scans the image for vulnerabilities with the scanner addon of the team, records the vulnerabilities
+// as an ImageScan and comments them on the Pull Request being built
+func (o *StepPostBuildOptions) scanImage() error {
+	addon := o.teamImageScanner()
+	if addon == nil {
+		return nil
+	}
+	log.Infof("Scanning image %s with %s\n", util.ColorInfo(o.FullImageName), util.ColorInfo(addon.Name))
+	var vulnerabilities []v1.ImageVulnerability
+	var err error
+	switch addon.Name {
+	case security.ScannerTrivy:
+		vulnerabilities, err = o.scanImageWithTrivy(addon)
+	case security.ScannerAnchore:
+		vulnerabilities, err = o.scanImageWithAnchore()
+	}
+	if err != nil {
+		return err
+	}
+	log.Infof("Image %s has %s\n", util.ColorInfo(o.FullImageName), security.Summary(vulnerabilities))
+
+	scan, err := o.saveImageScan(addon.Name, vulnerabilities)
+	if err != nil {
+		return err
+	}
+	if scan.Spec.PullRequest != "" {
+		err = o.commentImageScan(scan)
+		if err != nil {
+			log.Warnf("Failed to comment the vulnerabilities of image %s on the Pull Request: %s\n", o.FullImageName, err)
+		}
+	}
+	return nil
+}
+
+func (o *StepPostBuildOptions) scanImageWithTrivy(addon *v1.AddonSettings) ([]v1.ImageVulnerability, error) {
+	server := fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", kube.DefaultTrivyReleaseName, addon.Namespace, trivyServerPort)
+	cmd := exec.Command("trivy", "--quiet", "image", "--server", server, "--format", "json", o.FullImageName)
+	cmd.Stderr = o.Err
+	data, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to scan image %s with trivy", o.FullImageName)
+	}
+	return security.ParseTrivyReport(data)
+}
+
+func (o *StepPostBuildOptions) scanImageWithAnchore() ([]v1.ImageVulnerability, error) {
+	a, err := o.getAnchoreDetails()
+	if err != nil {
+		return nil, err
+	}
+	cmd := anchoreCommand(a, "image", "wait", o.FullImageName)
+	cmd.Stdout = o.Out
+	cmd.Stderr = o.Err
+	err = cmd.Run()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to wait for anchore to analyse image %s", o.FullImageName)
+	}
+	cmd = anchoreCommand(a, "--json", "image", "vuln", o.FullImageName, "all")
+	cmd.Stderr = o.Err
+	data, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the vulnerabilities of image %s from anchore", o.FullImageName)
+	}
+	return security.ParseAnchoreReport(data)
+}
+
+// saveImageScan creates or updates the ImageScan of the image
+func (o *StepPostBuildOptions) saveImageScan(scanner string, vulnerabilities []v1.ImageVulnerability) (*v1.ImageScan, error) {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return nil, err
+	}
+	err = o.registerImageScanCRD()
+	if err != nil {
+		return nil, err
+	}
+	app, version := imageApplicationAndVersion(o.FullImageName)
+	spec := v1.ImageScanSpec{
+		Image:           o.FullImageName,
+		Scanner:         scanner,
+		Application:     app,
+		Version:         version,
+		PullRequest:     os.Getenv("PULL_NUMBER"),
+		Vulnerabilities: vulnerabilities,
+	}
+	gitInfo, err := o.FindGitInfo("")
+	if err == nil && gitInfo != nil {
+		spec.GitURL = gitInfo.URL
+	}
+
+	name := kube.ToValidName(app + "-" + version)
+	scans := jxClient.JenkinsV1().ImageScans(ns)
+	scan, err := scans.Get(name, metav1.GetOptions{})
+	if err == nil {
+		scan.Spec = spec
+		scan, err = scans.Update(scan)
+	} else {
+		scan = &v1.ImageScan{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					kube.LabelApp:     app,
+					kube.LabelVersion: version,
+				},
+			},
+			Spec: spec,
+		}
+		scan, err = scans.Create(scan)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to save the ImageScan %s in namespace %s", name, ns)
+	}
+	return scan, nil
+}
+
+// commentImageScan comments the vulnerabilities found in the image on the Pull Request
+func (o *StepPostBuildOptions) commentImageScan(scan *v1.ImageScan) error {
+	prNumber, err := strconv.Atoi(scan.Spec.PullRequest)
+	if err != nil {
+		return err
+	}
+	gitInfo, provider, _, err := o.createGitProvider("")
+	if err != nil {
+		return err
+	}
+	if provider == nil {
+		return fmt.Errorf("no git provider found for the current directory")
+	}
+	pr := &gits.GitPullRequest{
+		Owner:  gitInfo.Organisation,
+		Repo:   gitInfo.Name,
+		Number: &prNumber,
+	}
+	return provider.AddPRComment(pr, security.MarkdownReport(scan, maxImageScanCommentRows))
+}
+
+// imageApplicationAndVersion returns the application and version of an image name of the form registry/org/app:version
+func imageApplicationAndVersion(image string) (string, string) {
+	name := image
+	version := ""
+	idx := strings.LastIndex(image, ":")
+	if idx > strings.LastIndex(image, "/") {
+		name = image[0:idx]
+		version = image[idx+1:]
+	}
+	paths := strings.Split(name, "/")
+	return paths[len(paths)-1], version
+}
+
+// anchoreCommand returns the anchore-cli command with the given arguments authenticated with the anchore addon
+func anchoreCommand(a anchoreDetails, args ...string) *exec.Cmd {
+	cmd := exec.Command("anchore-cli", args...)
+	cmd.Env = os.Environ()
+	cmd.Env = append(cmd.Env, "ANCHORE_CLI_USER="+a.Username)
+	cmd.Env = append(cmd.Env, "ANCHORE_CLI_PASS="+a.Password)
+	cmd.Env = append(cmd.Env, "ANCHORE_CLI_URL="+a.URL)
+	return cmd
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+// StepPostStatusOptions contains the command line flags
+type StepPostStatusOptions struct {
+	StepOptions
+
+	Dir         string
+	Owner       string
+	Repository  string
+	SHA         string
+	Context     string
+	State       string
+	Description string
+	TargetURL   string
+}
+
+var (
+	commitStatusStates = []string{"pending", "success", "error", "failure"}
+
+	stepPostStatusLong = templates.LongDesc(`
+		Posts the status of a pipeline stage to a commit of the Git repository so that it is shown on the commit or
+		Pull Request.
+
+		The commit defaults to the $PULL_PULL_SHA of the Pull Request being built or the HEAD of the current directory.
+`)
+
+	stepPostStatusExample = templates.Examples(`
+		# mark the integration tests of the current commit as running
+		jx step post status --context integration-tests --state pending --description "Running the integration tests"
+
+		# report that they passed linking to the results
+		jx step post status --context integration-tests --state success --target-url https://ci.example.com/results/123
+`)
+)
+
+// NewCmdStepPostStatus creates the command
+func NewCmdStepPostStatus(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := StepPostStatusOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "status",
+		Short:   "Posts the status of a pipeline stage to a commit",
+		Long:    stepPostStatusLong,
+		Example: stepPostStatusExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The directory of the Git repository")
+	cmd.Flags().StringVarP(&options.Owner, "owner", "o", "", "The Git organisation or owner. Defaults to the one of the Git repository")
+	cmd.Flags().StringVarP(&options.Repository, "repository", "r", "", "The Git repository. Defaults to the one of the current directory")
+	cmd.Flags().StringVarP(&options.SHA, "sha", "", "", "The SHA of the commit. Defaults to $PULL_PULL_SHA or the HEAD of the Git repository")
+	cmd.Flags().StringVarP(&options.Context, "context", "c", "jx", "The name of the status which is replaced by later statuses with the same context")
+	cmd.Flags().StringVarP(&options.State, "state", "s", "", "The state of the status. One of: "+strings.Join(commitStatusStates, ", "))
+	cmd.Flags().StringVarP(&options.Description, "description", "", "", "A short description of the status")
+	cmd.Flags().StringVarP(&options.TargetURL, "target-url", "u", "", "The URL of the page with the details of the status")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *StepPostStatusOptions) Run() error {
+	if o.State == "" {
+		return util.MissingOption("state")
+	}
+	if util.StringArrayIndex(commitStatusStates, o.State) < 0 {
+		return util.InvalidOption("state", o.State, commitStatusStates)
+	}
+	gitInfo, err := o.Git().Info(o.Dir)
+	if err != nil {
+		return errors.Wrap(err, "finding the Git repository")
+	}
+	owner := o.Owner
+	if owner == "" {
+		owner = gitInfo.Organisation
+	}
+	repo := o.Repository
+	if repo == "" {
+		repo = gitInfo.Name
+	}
+	sha := o.SHA
+	if sha == "" {
+		sha = os.Getenv(PULL_PULL_SHA)
+	}
+	if sha == "" {
+		sha, err = o.getCommandOutput(o.Dir, "git", "rev-parse", "HEAD")
+		if err != nil {
+			return errors.Wrap(err, "finding the HEAD commit")
+		}
+	}
+
+	authConfigSvc, err := o.CreateGitAuthConfigService()
+	if err != nil {
+		return err
+	}
+	gitKind, err := o.GitServerKind(gitInfo)
+	if err != nil {
+		return err
+	}
+	provider, err := o.Factory.CreateGitProvider(gitInfo.URL, "user name to post the status as", authConfigSvc, gitKind, o.BatchMode, o.Git(), o.In, o.Out, o.Err)
+	if err != nil {
+		return err
+	}
+	_, err = provider.UpdateCommitStatus(owner, repo, sha, &gits.GitRepoStatus{
+		Context:     o.Context,
+		State:       o.State,
+		Description: o.Description,
+		TargetURL:   o.TargetURL,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to post the status %s of %s to commit %s of %s/%s: %s", o.State, o.Context, sha, owner, repo, err)
+	}
+	log.Infof("Posted the status %s of %s to commit %s of %s\n", util.ColorInfo(o.State), util.ColorInfo(o.Context), util.ColorInfo(sha), util.ColorInfo(owner+"/"+repo))
+	return nil
+}
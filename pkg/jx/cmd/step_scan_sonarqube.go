@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/sonarqube"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	sonarQubeStatusContext = "sonarqube"
+)
+
+var (
+	stepScanSonarQubeLong = templates.LongDesc(`
+		Analyses the source code of the project with SonarQube and waits for the quality gate of the analysis.
+
+		The Maven or Gradle plugin is used if the project uses them, otherwise the standalone sonar-scanner.
+		When building a Pull Request the status of the quality gate is reported as a commit status on the Pull Request.
+`)
+
+	stepScanSonarQubeExample = templates.Examples(`
+		# Analyse the project in the current directory
+		jx step scan sonarqube
+
+		# Analyse the project but do not fail the pipeline if the quality gate fails
+		jx step scan sonarqube --ignore-quality-gate
+	`)
+)
+
+// StepScanSonarQubeOptions contains the command line flags
+type StepScanSonarQubeOptions struct {
+	StepOptions
+
+	Dir               string
+	ProjectKey        string
+	Timeout           string
+	IgnoreQualityGate bool
+	NoStatus          bool
+}
+
+// NewCmdStepScanSonarQube Creates a new Command object
+func NewCmdStepScanSonarQube(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &StepScanSonarQubeOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "sonarqube",
+		Short:   "Analyses the source code with SonarQube and waits for the quality gate",
+		Aliases: []string{"sonar"},
+		Long:    stepScanSonarQubeLong,
+		Example: stepScanSonarQubeExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addCommonFlags(cmd)
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", ".", "The directory of the project to analyse")
+	cmd.Flags().StringVarP(&options.ProjectKey, "project-key", "k", "", "The key of the SonarQube project. Defaults to the owner and name of the git repository")
+	cmd.Flags().StringVarP(&options.Timeout, "timeout", "t", "10m", "The maximum time to wait for the quality gate of the analysis")
+	cmd.Flags().BoolVarP(&options.IgnoreQualityGate, "ignore-quality-gate", "", false, "Does not fail if the quality gate of the analysis fails")
+	cmd.Flags().BoolVarP(&options.NoStatus, "no-status", "", false, "Does not report the status of the quality gate on the Pull Request")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepScanSonarQubeOptions) Run() error {
+	timeout, err := time.ParseDuration(o.Timeout)
+	if err != nil {
+		return util.InvalidOptionf("timeout", o.Timeout, "%s", err)
+	}
+	kubeClient, ns, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	secret, err := kubeClient.CoreV1().Secrets(ns).Get(kube.SecretSonarQube, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to find the SonarQube Secret %s in namespace %s: %s. You can create it via 'jx create addon sonarqube'", kube.SecretSonarQube, ns, err)
+	}
+	serverURL := string(secret.Data[sonarQubeSecretURL])
+	externalURL := string(secret.Data[sonarQubeSecretExternalURL])
+	token := string(secret.Data[sonarQubeSecretToken])
+	if serverURL == "" || token == "" {
+		return fmt.Errorf("the SonarQube Secret %s in namespace %s has no %s or %s", kube.SecretSonarQube, ns, sonarQubeSecretURL, sonarQubeSecretToken)
+	}
+	if externalURL == "" {
+		externalURL = serverURL
+	}
+
+	gitInfo, err := o.FindGitInfo(o.Dir)
+	if err != nil {
+		return err
+	}
+	projectKey := o.ProjectKey
+	if projectKey == "" {
+		projectKey = gitInfo.Organisation + ":" + gitInfo.Name
+	}
+
+	util.RegisterSecret(token)
+	cmd, err := sonarqube.ScannerCommand(&sonarqube.ScannerArguments{
+		Dir:        o.Dir,
+		URL:        serverURL,
+		Token:      token,
+		ProjectKey: projectKey,
+		Version:    os.Getenv("VERSION"),
+	})
+	if err != nil {
+		return err
+	}
+	cmd.Out = o.Out
+	cmd.Err = o.Err
+	log.Infof("Analysing project %s with %s\n", util.ColorInfo(projectKey), util.ColorInfo(cmd.Name))
+	err = o.runCommandSpec(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to analyse the project")
+	}
+
+	report, err := sonarqube.LoadReportTask(o.Dir)
+	if err != nil {
+		return err
+	}
+	client := sonarqube.NewClient(serverURL, token)
+	task, err := client.WaitForTask(report["ceTaskId"], 5*time.Second, timeout)
+	if err != nil {
+		return err
+	}
+	gate, err := client.GetQualityGate(task.AnalysisID)
+	if err != nil {
+		return err
+	}
+	dashboardURL := sonarqube.NewClient(externalURL, "").DashboardURL(projectKey)
+
+	if !o.NoStatus && os.Getenv("PULL_NUMBER") != "" {
+		err = o.reportQualityGate(gitInfo, gate, dashboardURL)
+		if err != nil {
+			log.Warnf("Failed to report the status of the quality gate on the Pull Request: %s\n", err)
+		}
+	}
+
+	if gate.Status == sonarqube.QualityGateError {
+		failed := []string{}
+		for _, c := range gate.Conditions {
+			if c.Status == sonarqube.QualityGateError {
+				failed = append(failed, fmt.Sprintf("%s is %s", c.MetricKey, c.ActualValue))
+			}
+		}
+		message := fmt.Sprintf("the project %s failed the SonarQube quality gate: %s. See %s", projectKey, strings.Join(failed, ", "), dashboardURL)
+		if o.IgnoreQualityGate {
+			log.Warnf("%s\n", message)
+			return nil
+		}
+		return errors.New(message)
+	}
+	log.Infof("The project %s passed the SonarQube quality gate. See %s\n", util.ColorInfo(projectKey), util.ColorInfo(dashboardURL))
+	return nil
+}
+
+// reportQualityGate reports the status of the quality gate as a commit status of the Pull Request
+func (o *StepScanSonarQubeOptions) reportQualityGate(gitInfo *gits.GitRepository, gate *sonarqube.QualityGate, dashboardURL string) error {
+	sha := os.Getenv(PULL_PULL_SHA)
+	if sha == "" {
+		var err error
+		sha, err = o.getCommandOutput(o.Dir, "git", "rev-parse", "HEAD")
+		if err != nil {
+			return err
+		}
+	}
+	provider, err := o.gitProviderForURL(gitInfo.URL, "user name to report the commit status as")
+	if err != nil {
+		return err
+	}
+	status := &gits.GitRepoStatus{
+		Context:     sonarQubeStatusContext,
+		TargetURL:   dashboardURL,
+		State:       "success",
+		Description: "SonarQube quality gate passed",
+	}
+	if gate.Status == sonarqube.QualityGateError {
+		status.State = "failure"
+		status.Description = "SonarQube quality gate failed"
+	}
+	_, err = provider.UpdateCommitStatus(gitInfo.Organisation, gitInfo.Name, sha, status)
+	return err
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"io"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/jenkinsfile"
+	"github.com/jenkins-x/jx/pkg/jenkinsfile/syntax"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+// StepSyntaxOptions contains the command line flags
+type StepSyntaxOptions struct {
+	StepOptions
+}
+
+// StepSyntaxPipelineOptions the flags used to find the effective build pack pipeline of a project
+type StepSyntaxPipelineOptions struct {
+	StepOptions
+
+	Dir          string
+	Pack         string
+	BuildPackURL string
+	BuildPackRef string
+	Kind         string
+	PipelineFile string
+}
+
+// NewCmdStepSyntax Steps a command object for the "step syntax" command
+func NewCmdStepSyntax(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &StepSyntaxOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "syntax",
+		Short: "syntax [command]",
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.AddCommand(NewCmdStepSyntaxConvert(f, in, out, errOut))
+	cmd.AddCommand(NewCmdStepSyntaxValidate(f, in, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *StepSyntaxOptions) Run() error {
+	return o.Cmd.Help()
+}
+
+func (o *StepSyntaxPipelineOptions) addPipelineFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.Dir, "dir", "d", ".", "The directory of the project")
+	cmd.Flags().StringVarP(&o.Pack, "pack", "", "", "The build pack to use. Defaults to the build pack in the jenkins-x.yml file of the project")
+	cmd.Flags().StringVarP(&o.BuildPackURL, "url", "u", "", "The URL for the build pack Git repository. Defaults to the project or team build pack")
+	cmd.Flags().StringVarP(&o.BuildPackRef, "ref", "r", "", "The Git reference (branch,tag,sha) in the Git repository to use")
+	cmd.Flags().StringVarP(&o.Kind, "kind", "k", syntax.PipelineKindRelease, "The kind of pipeline to convert. Possible values: "+util.ColorInfo(syntax.PipelineKinds))
+	cmd.Flags().StringVarP(&o.PipelineFile, "pipeline", "p", "", "The build pack pipeline.yaml file to convert instead of the pipeline of the build pack")
+}
+
+// effectivePipeline converts the build pack pipeline which the project will run to the YAML syntax
+func (o *StepSyntaxPipelineOptions) effectivePipeline() (*syntax.ParsedPipeline, error) {
+	projectConfig, _, err := config.LoadProjectConfig(o.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if o.Pack == "" {
+		o.Pack = projectConfig.BuildPack
+	}
+	if o.BuildPackURL == "" {
+		o.BuildPackURL = projectConfig.BuildPackGitURL
+	}
+	if o.BuildPackRef == "" {
+		o.BuildPackRef = projectConfig.BuildPackGitURef
+	}
+	if o.BuildPackURL == "" || o.BuildPackRef == "" {
+		settings, err := o.TeamSettings()
+		if err != nil {
+			return nil, err
+		}
+		if o.BuildPackURL == "" {
+			o.BuildPackURL = settings.BuildPackURL
+		}
+		if o.BuildPackRef == "" {
+			o.BuildPackRef = settings.BuildPackRef
+		}
+	}
+	if o.BuildPackURL == "" {
+		return nil, util.MissingOption("url")
+	}
+	if o.BuildPackRef == "" {
+		return nil, util.MissingOption("ref")
+	}
+
+	packDir, err := jenkinsfile.InitBuildPack(o.Git(), o.BuildPackURL, o.BuildPackRef)
+	if err != nil {
+		return nil, err
+	}
+	resolver, err := jenkinsfile.CreateResolver(packDir, o.Git())
+	if err != nil {
+		return nil, err
+	}
+	pipelineFile := o.PipelineFile
+	if pipelineFile == "" {
+		if o.Pack == "" {
+			return nil, util.MissingOption("pack")
+		}
+		pipelineFile = filepath.Join(packDir, o.Pack, jenkinsfile.PipelineConfigFileName)
+	}
+	_, _, err = o.JXClientAndDevNamespace()
+	prow := false
+	if err == nil {
+		prow, err = o.isProw()
+	}
+	if err != nil {
+		log.Warnf("Could not detect if the team uses Prow so assuming Jenkins: %s\n", err)
+		prow = false
+	}
+	pipelineConfig, err := jenkinsfile.LoadPipelineConfig(pipelineFile, resolver, prow)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, warnings, err := syntax.FromPipelineConfig(pipelineConfig, o.Kind)
+	if err != nil {
+		return nil, err
+	}
+	for _, warning := range warnings {
+		log.Warnf("%s\n", warning)
+	}
+	return pipeline, nil
+}
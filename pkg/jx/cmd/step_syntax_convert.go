@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+var (
+	stepSyntaxConvertLong = templates.LongDesc(`
+		Converts the build pack pipeline which the project runs from the Jenkinsfile based pipeline.yaml to the pipeline YAML syntax of stages and steps
+`)
+
+	stepSyntaxConvertExample = templates.Examples(`
+		# display the release pipeline of the current project as YAML
+		jx step syntax convert
+
+		# convert the Pull Request pipeline of the project to a file
+		jx step syntax convert --kind pullrequest -o pipeline.yaml
+
+		# convert the pipeline of the maven build pack
+		jx step syntax convert --pack maven
+			`)
+)
+
+// StepSyntaxConvertOptions contains the command line flags
+type StepSyntaxConvertOptions struct {
+	StepSyntaxPipelineOptions
+
+	OutputFile string
+}
+
+// NewCmdStepSyntaxConvert Creates a new Command object
+func NewCmdStepSyntaxConvert(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &StepSyntaxConvertOptions{
+		StepSyntaxPipelineOptions: StepSyntaxPipelineOptions{
+			StepOptions: StepOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					In:      in,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "convert",
+		Short:   "Converts the build pack pipeline of the project to the pipeline YAML syntax",
+		Long:    stepSyntaxConvertLong,
+		Example: stepSyntaxConvertExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addCommonFlags(cmd)
+	options.addPipelineFlags(cmd)
+
+	cmd.Flags().StringVarP(&options.OutputFile, "output", "o", "", "The file to write the pipeline YAML to. Defaults to the console")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepSyntaxConvertOptions) Run() error {
+	pipeline, err := o.effectivePipeline()
+	if err != nil {
+		return err
+	}
+	err = pipeline.Validate()
+	if err != nil {
+		log.Warnf("The converted pipeline is not valid: %s\n", err)
+	}
+	text, err := pipeline.ToYaml()
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the pipeline to YAML")
+	}
+	if o.OutputFile == "" {
+		_, err = fmt.Fprint(o.Out, text)
+		return err
+	}
+	err = ioutil.WriteFile(o.OutputFile, []byte(text), util.DefaultWritePermissions)
+	if err != nil {
+		return errors.Wrapf(err, "failed to save file %s", o.OutputFile)
+	}
+	log.Infof("Saved the %s pipeline to %s\n", o.Kind, util.ColorInfo(o.OutputFile))
+	return nil
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jenkinsfile/syntax"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+var (
+	stepSyntaxValidateLong = templates.LongDesc(`
+		Validates a pipeline YAML file or, if no file is specified, the build pack pipeline which the project runs
+`)
+
+	stepSyntaxValidateExample = templates.Examples(`
+		# validate the release pipeline of the current project
+		jx step syntax validate
+
+		# validate a pipeline YAML file
+		jx step syntax validate -f pipeline.yaml
+			`)
+)
+
+// StepSyntaxValidateOptions contains the command line flags
+type StepSyntaxValidateOptions struct {
+	StepSyntaxPipelineOptions
+
+	File string
+}
+
+// NewCmdStepSyntaxValidate Creates a new Command object
+func NewCmdStepSyntaxValidate(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &StepSyntaxValidateOptions{
+		StepSyntaxPipelineOptions: StepSyntaxPipelineOptions{
+			StepOptions: StepOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					In:      in,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "validate",
+		Short:   "Validates a pipeline YAML file or the build pack pipeline of the project",
+		Long:    stepSyntaxValidateLong,
+		Example: stepSyntaxValidateExample,
+		Aliases: []string{"lint"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addCommonFlags(cmd)
+	options.addPipelineFlags(cmd)
+
+	cmd.Flags().StringVarP(&options.File, "file", "f", "", "The pipeline YAML file to validate")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepSyntaxValidateOptions) Run() error {
+	var pipeline *syntax.ParsedPipeline
+	var err error
+	name := o.File
+	if name != "" {
+		pipeline, err = syntax.LoadParsedPipeline(name)
+	} else {
+		name = "the " + o.Kind + " pipeline"
+		pipeline, err = o.effectivePipeline()
+	}
+	if err != nil {
+		return err
+	}
+	err = pipeline.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "validation failed for %s", name)
+	}
+	log.Infof("Validated %s\n", util.ColorInfo(name))
+	return nil
+}
@@ -38,6 +38,7 @@ type StepTagFlags struct {
 	VersionFile          string
 	ChartsDir            string
 	ChartValueRepository string
+	TagPrefix            string
 }
 
 var (
@@ -51,12 +52,17 @@ var (
 		git tag -fa v$(VERSION) -m "Release version $(VERSION)"
 		git push origin v$(VERSION)
 
+		The applications of a monorepo use '--tag-prefix' so that each application has its own release tags.
+
 `)
 
 	stepTagExample = templates.Examples(`
 
 		jx step tag --version 1.0.0
 
+		# tag the release of the orders application of a monorepo as orders-v1.0.0
+		jx step tag --version 1.0.0 --tag-prefix orders-
+
 `)
 )
 
@@ -89,6 +95,7 @@ func NewCmdStepTag(f Factory, in terminal.FileReader, out terminal.FileWriter, e
 
 	cmd.Flags().StringVarP(&options.Flags.ChartsDir, "charts-dir", "d", "", "the directory of the chart to update the version")
 	cmd.Flags().StringVarP(&options.Flags.ChartValueRepository, "charts-value-repository", "r", "", "the fully qualified image name without the version tag. e.g. 'dockerregistry/myorg/myapp'")
+	cmd.Flags().StringVarP(&options.Flags.TagPrefix, "tag-prefix", "", "", "The prefix of the tag such as the name of the application of a monorepo")
 
 	return cmd
 }
@@ -132,7 +139,7 @@ func (o *StepTagOptions) Run() error {
 		return err
 	}
 
-	tag := "v" + o.Flags.Version
+	tag := o.Flags.TagPrefix + "v" + o.Flags.Version
 
 	err = o.Git().AddCommit("", fmt.Sprintf("release %s", o.Flags.Version))
 	if err != nil {
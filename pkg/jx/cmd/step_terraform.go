@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+// StepTerraformOptions contains the command line flags
+type StepTerraformOptions struct {
+	StepOptions
+}
+
+// NewCmdStepTerraform Steps a command object for the "step" command
+func NewCmdStepTerraform(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &StepTerraformOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "terraform",
+		Short: "terraform [command]",
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.AddCommand(NewCmdStepTerraformDrift(f, in, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *StepTerraformOptions) Run() error {
+	return o.Cmd.Help()
+}
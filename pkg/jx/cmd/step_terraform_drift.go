@@ -0,0 +1,414 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/notify"
+	"github.com/jenkins-x/jx/pkg/terraform"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	terraformDriftWebhookURLEnvVar      = "JX_TERRAFORM_DRIFT_WEBHOOK_URL"
+	terraformDriftSlackWebhookURLEnvVar = "JX_TERRAFORM_DRIFT_SLACK_WEBHOOK_URL"
+
+	terraformDriftSecretsMountPath = "/secrets/terraform"
+	defaultTerraformDriftImage     = "jenkinsxio/jx:latest"
+)
+
+// StepTerraformDriftOptions contains the command line flags
+type StepTerraformDriftOptions struct {
+	StepOptions
+
+	Cluster              string
+	Dir                  string
+	VarFile              string
+	ServiceAccount       string
+	GitURL               string
+	WebhookURL           string
+	SlackWebhookURL      string
+	FailOnDrift          bool
+	GitCredentials       bool
+	Schedule             string
+	Image                string
+	ServiceAccountSecret string
+}
+
+var (
+	stepTerraformDriftLong = templates.LongDesc(`
+		Detects drift between the Terraform state of a cluster and the live cloud resources.
+
+		Runs 'terraform plan -detailed-exitcode' against the stored cluster state and alerts the given webhooks when
+		someone has changed resources such as node pools or firewall rules outside of jx.
+
+		Use --schedule to run the drift detection periodically as a CronJob inside the cluster. The CronJob runs as the
+		service account of the pipelines so that it can clone the organisation Git repository using the pipeline Git
+		credentials.
+`)
+
+	stepTerraformDriftExample = templates.Examples(`
+		# Detects drift of a cluster created via 'jx create terraform' in ~/.jx/clusters
+		jx step terraform drift --cluster dev
+
+		# Detects drift of a cluster in an organisation Git repository and alerts a Slack channel
+		jx step terraform drift --cluster dev --git-url https://github.com/myorg/organisation-myorg.git --slack-webhook-url https://hooks.slack.com/services/...
+
+		# Checks for drift every hour in the cluster
+		jx step terraform drift --cluster dev --git-url https://github.com/myorg/organisation-myorg.git --service-account-secret terraform-sa --slack-webhook-url https://hooks.slack.com/services/... --schedule "0 * * * *"
+`)
+)
+
+// NewCmdStepTerraformDrift creates the command
+func NewCmdStepTerraformDrift(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &StepTerraformDriftOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "drift",
+		Short:   "Detects drift between the Terraform state of a cluster and the live cloud resources",
+		Long:    stepTerraformDriftLong,
+		Example: stepTerraformDriftExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addCommonFlags(cmd)
+
+	cmd.Flags().StringVarP(&options.Cluster, "cluster", "c", "", "The name of the cluster")
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The directory containing the Terraform configuration. Defaults to the cluster folder of the organisation Git repository or ~/.jx/clusters")
+	cmd.Flags().StringVarP(&options.VarFile, "var-file", "", "", "The Terraform variables file. Defaults to terraform.tfvars in the Terraform directory")
+	cmd.Flags().StringVarP(&options.ServiceAccount, "service-account", "", "", "The cloud service account key file used by Terraform")
+	cmd.Flags().StringVarP(&options.GitURL, "git-url", "", "", "The organisation Git repository containing the cluster Terraform configuration")
+	cmd.Flags().StringVarP(&options.WebhookURL, "webhook-url", "", os.Getenv(terraformDriftWebhookURLEnvVar), "The URL of a webhook to post the drift to as JSON")
+	cmd.Flags().StringVarP(&options.SlackWebhookURL, "slack-webhook-url", "", os.Getenv(terraformDriftSlackWebhookURLEnvVar), "The URL of a Slack incoming webhook to alert when drift is detected")
+	cmd.Flags().BoolVarP(&options.FailOnDrift, "fail", "", false, "Fails the command if drift is detected")
+	cmd.Flags().BoolVarP(&options.GitCredentials, "git-credentials", "", false, "Sets up the pipeline Git credentials before cloning the --git-url")
+	cmd.Flags().StringVarP(&options.Schedule, "schedule", "", "", "The cron schedule of a CronJob which detects drift periodically inside the cluster")
+	cmd.Flags().StringVarP(&options.Image, "image", "", defaultTerraformDriftImage, "The image of the CronJob which detects drift. It must contain jx and terraform")
+	cmd.Flags().StringVarP(&options.ServiceAccountSecret, "service-account-secret", "", "", "The Secret containing the cloud service account key file which is mounted into the CronJob")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepTerraformDriftOptions) Run() error {
+	if o.Cluster == "" && o.Dir == "" {
+		return util.MissingOption("cluster")
+	}
+	if o.Schedule != "" {
+		return o.createCronJob()
+	}
+
+	dir, cloneDir, err := o.terraformDir()
+	if cloneDir != "" {
+		defer os.RemoveAll(cloneDir)
+	}
+	if err != nil {
+		return err
+	}
+	varFile := o.VarFile
+	if varFile == "" {
+		varFile = filepath.Join(dir, "terraform.tfvars")
+		exists, err := util.FileExists(varFile)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			varFile = ""
+		}
+	}
+
+	err = terraform.Init(dir, o.ServiceAccount)
+	if err != nil {
+		return errors.Wrapf(err, "initialising terraform in %s", dir)
+	}
+	drift, err := terraform.PlanDrift(dir, varFile, o.ServiceAccount)
+	if err != nil {
+		return errors.Wrapf(err, "planning terraform in %s", dir)
+	}
+	name := o.clusterName(dir)
+	if !drift.HasChanges() {
+		log.Successf("No drift detected between the Terraform state and the resources of cluster %s", util.ColorInfo(name))
+		return nil
+	}
+
+	log.Warnf("Cluster %s has drifted from its Terraform state: %s\n", name, drift.Summary())
+	for _, change := range drift.Changes {
+		log.Warnf("  %s\n", change)
+	}
+	event := &notify.Event{
+		Kind:    notify.EventTerraformDrift,
+		Cluster: name,
+		Changes: drift.Changes,
+	}
+	err = o.sendDriftAlert(event, fmt.Sprintf("Cluster %s has been changed outside of jx: %s", name, drift.Summary()))
+	if err != nil {
+		return err
+	}
+	if o.FailOnDrift {
+		return fmt.Errorf("cluster %s has drifted from its Terraform state: %s", name, drift.Summary())
+	}
+	return nil
+}
+
+// terraformDir returns the directory of the Terraform configuration of the cluster cloning the organisation
+// Git repository if required along with the directory of the clone which should be removed afterwards
+func (o *StepTerraformDriftOptions) terraformDir() (string, string, error) {
+	if o.Dir != "" {
+		return o.Dir, "", nil
+	}
+	if o.GitURL != "" {
+		if o.GitCredentials {
+			err := o.setupGitCredentials()
+			if err != nil {
+				return "", "", err
+			}
+		}
+		cloneDir, err := ioutil.TempDir("", "jx-terraform-drift-")
+		if err != nil {
+			return "", "", err
+		}
+		err = o.Git().Clone(o.GitURL, cloneDir)
+		if err != nil {
+			return "", cloneDir, errors.Wrapf(err, "cloning %s", o.GitURL)
+		}
+		return filepath.Join(cloneDir, Clusters, o.Cluster, Terraform), cloneDir, nil
+	}
+	jxHome, err := util.ConfigDir()
+	if err != nil {
+		return "", "", err
+	}
+	dir := filepath.Join(jxHome, Clusters, o.Cluster, Terraform)
+	exists, err := util.FileExists(dir)
+	if err != nil {
+		return "", "", err
+	}
+	if !exists {
+		return "", "", fmt.Errorf("could not find the Terraform configuration of cluster %s at %s. Please specify it via --dir or --git-url", o.Cluster, dir)
+	}
+	return dir, "", nil
+}
+
+// setupGitCredentials stores the pipeline Git credentials where the git credential store helper finds them
+func (o *StepTerraformDriftOptions) setupGitCredentials() error {
+	err := o.RunCommand("git", "config", "--global", "credential.helper", "store")
+	if err != nil {
+		return err
+	}
+	sgc := &StepGitCredentialsOptions{}
+	sgc.CommonOptions = o.CommonOptions
+	if os.Getenv("XDG_CONFIG_HOME") == "" {
+		sgc.OutputFile = filepath.Join(util.HomeDir(), ".git-credentials")
+	}
+	err = sgc.Run()
+	if err != nil {
+		return errors.Wrap(err, "setting up the git credentials")
+	}
+	return nil
+}
+
+func (o *StepTerraformDriftOptions) clusterName(dir string) string {
+	if o.Cluster != "" {
+		return o.Cluster
+	}
+	return filepath.Base(filepath.Dir(dir))
+}
+
+// sendDriftAlert sends the drift event to the webhook and the Slack webhook
+func (o *StepTerraformDriftOptions) sendDriftAlert(event *notify.Event, message string) error {
+	if o.WebhookURL == "" && o.SlackWebhookURL == "" {
+		log.Infof("No --webhook-url or --slack-webhook-url specified so not sending an alert\n")
+		return nil
+	}
+	if o.WebhookURL != "" {
+		sender := &notify.WebhookSender{URL: o.WebhookURL}
+		err := sender.Send(event, message)
+		if err != nil {
+			return errors.Wrap(err, "sending the drift alert to the webhook")
+		}
+	}
+	if o.SlackWebhookURL != "" {
+		text := ":warning: " + message
+		if len(event.Changes) > 0 {
+			text += "\n```\n" + strings.Join(event.Changes, "\n") + "\n```"
+		}
+		sender := &notify.SlackWebhookSender{WebhookURL: o.SlackWebhookURL}
+		err := sender.Send(event, text)
+		if err != nil {
+			return errors.Wrap(err, "sending the drift alert to Slack")
+		}
+	}
+	log.Infof("Sent the drift alert for cluster %s\n", util.ColorInfo(event.Cluster))
+	return nil
+}
+
+// createCronJob creates or updates a CronJob which runs this command periodically in the dev namespace
+func (o *StepTerraformDriftOptions) createCronJob() error {
+	if o.GitURL == "" && o.Dir == "" {
+		return util.MissingOption("git-url")
+	}
+	kubeClient, ns, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	name := kube.ToValidName("jx-terraform-drift-" + o.Cluster)
+	labels := map[string]string{
+		"app":     "jx-terraform-drift",
+		"cluster": o.Cluster,
+	}
+
+	// lets keep the webhook URLs out of the CronJob as they are secrets
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		StringData: map[string]string{
+			"webhook-url":       o.WebhookURL,
+			"slack-webhook-url": o.SlackWebhookURL,
+		},
+	}
+	secrets := kubeClient.CoreV1().Secrets(ns)
+	_, err = secrets.Get(name, metav1.GetOptions{})
+	if err == nil {
+		_, err = secrets.Update(secret)
+	} else {
+		_, err = secrets.Create(secret)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "saving secret %s in namespace %s", name, ns)
+	}
+
+	args := []string{"step", "terraform", "drift", "--batch-mode", "--cluster", o.Cluster}
+	if o.GitURL != "" {
+		args = append(args, "--git-url", o.GitURL, "--git-credentials")
+	} else {
+		args = append(args, "--dir", o.Dir)
+	}
+	if o.VarFile != "" {
+		args = append(args, "--var-file", o.VarFile)
+	}
+	container := corev1.Container{
+		Name:    "terraform-drift",
+		Image:   o.Image,
+		Command: []string{"jx"},
+		Args:    args,
+		Env: []corev1.EnvVar{
+			secretEnvVar(terraformDriftWebhookURLEnvVar, name, "webhook-url"),
+			secretEnvVar(terraformDriftSlackWebhookURLEnvVar, name, "slack-webhook-url"),
+		},
+	}
+	// the service account of the pipelines can read the pipeline Git credentials to clone the organisation repository
+	serviceAccount, err := o.pipelineServiceAccount()
+	if err != nil {
+		return err
+	}
+	podSpec := corev1.PodSpec{
+		RestartPolicy:      corev1.RestartPolicyNever,
+		ServiceAccountName: serviceAccount,
+	}
+	if o.ServiceAccountSecret != "" {
+		keyFile := filepath.Join(terraformDriftSecretsMountPath, "credentials.json")
+		container.Args = append(container.Args, "--service-account", keyFile)
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  "GOOGLE_APPLICATION_CREDENTIALS",
+			Value: keyFile,
+		})
+		container.VolumeMounts = []corev1.VolumeMount{
+			{
+				Name:      "terraform-credentials",
+				MountPath: terraformDriftSecretsMountPath,
+				ReadOnly:  true,
+			},
+		}
+		podSpec.Volumes = []corev1.Volume{
+			{
+				Name: "terraform-credentials",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: o.ServiceAccountSecret,
+					},
+				},
+			},
+		}
+	}
+	podSpec.Containers = []corev1.Container{container}
+
+	cronJob := &batchv1beta1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule:          o.Schedule,
+			ConcurrencyPolicy: batchv1beta1.ForbidConcurrent,
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: labels,
+						},
+						Spec: podSpec,
+					},
+				},
+			},
+		},
+	}
+	cronJobs := kubeClient.BatchV1beta1().CronJobs(ns)
+	existing, err := cronJobs.Get(name, metav1.GetOptions{})
+	if err == nil {
+		existing.Spec = cronJob.Spec
+		existing.Labels = cronJob.Labels
+		_, err = cronJobs.Update(existing)
+	} else {
+		_, err = cronJobs.Create(cronJob)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "saving CronJob %s in namespace %s", name, ns)
+	}
+	log.Infof("CronJob %s in namespace %s detects drift of cluster %s on schedule %s\n", util.ColorInfo(name), util.ColorInfo(ns), util.ColorInfo(o.Cluster), util.ColorInfo(o.Schedule))
+	return nil
+}
+
+func secretEnvVar(name string, secretName string, key string) corev1.EnvVar {
+	optional := true
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretName,
+				},
+				Key:      key,
+				Optional: &optional,
+			},
+		},
+	}
+}
@@ -71,6 +71,8 @@ func NewCmdStepValidate(f Factory, in terminal.FileReader, out terminal.FileWrit
 	}
 	cmd.Flags().StringVarP(&options.MinimumJxVersion, optionMinJxVersion, "v", "", "The minimum version of the 'jx' command line tool required")
 	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The project directory to look inside for the Project configuration for things like required addons")
+
+	cmd.AddCommand(NewCmdStepValidatePolicies(f, in, out, errOut))
 	return cmd
 }
 
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/policy"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+var (
+	stepValidatePoliciesLong = templates.LongDesc(`
+		Evaluates the policies enforced by the Gatekeeper addon on the rendered chart of a project.
+
+		The chart is rendered via 'helm template' and the step fails if any of the resources violates a policy so
+		that the violations fail the pipeline before promotion rather than when the pods are admitted.
+
+		The Rego of the constraint templates is evaluated with the Open Policy Agent like Gatekeeper does so the
+		starter policies are evaluated along with the custom constraints of the cluster.
+
+		Starter policies: ` + strings.Join(policy.PolicyNames(policy.StarterPolicies), ", ") + `
+`)
+
+	stepValidatePoliciesExample = templates.Examples(`
+		# Evaluate all the policies on the chart in the current directory
+		jx step validate policies
+
+		# Evaluate all the policies on the chart of an application with its values for staging
+		jx step validate policies --dir charts/myapp --values env/staging-values.yaml
+
+		# Evaluate a single policy on manifests which are already rendered
+		jx step validate policies --templates-dir output --policy no-privileged-containers
+
+		# Evaluate only the starter policies without connecting to the cluster
+		jx step validate policies --no-cluster-constraints
+	`)
+)
+
+// StepValidatePoliciesOptions contains the command line flags
+type StepValidatePoliciesOptions struct {
+	StepOptions
+
+	Dir          string
+	TemplatesDir string
+	ReleaseName  string
+	Namespace    string
+	SetValues    []string
+	ValueFiles   []string
+	Policies     []string
+
+	NoClusterConstraints bool
+}
+
+// NewCmdStepValidatePolicies Creates a new Command object
+func NewCmdStepValidatePolicies(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &StepValidatePoliciesOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "policies",
+		Short:   "Evaluates the policies enforced by the Gatekeeper addon on the rendered chart of a project",
+		Aliases: []string{"policy"},
+		Long:    stepValidatePoliciesLong,
+		Example: stepValidatePoliciesExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The directory of the chart. Defaults to the chart of the current directory")
+	cmd.Flags().StringVarP(&options.TemplatesDir, "templates-dir", "t", "", "The directory of the already rendered manifests to evaluate rather than rendering the chart")
+	cmd.Flags().StringVarP(&options.ReleaseName, "name", "n", "policy-check", "The release name used to render the chart")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "", "", "The namespace used to render the chart. Defaults to the current namespace")
+	cmd.Flags().StringArrayVarP(&options.SetValues, "set", "s", []string{}, "The values to set when rendering the chart")
+	cmd.Flags().StringArrayVarP(&options.ValueFiles, "values", "f", []string{}, "The values files used when rendering the chart")
+	cmd.Flags().StringArrayVarP(&options.Policies, "policy", "p", []string{}, "The policy to evaluate. Can be specified multiple times. Defaults to all the policies")
+	cmd.Flags().BoolVarP(&options.NoClusterConstraints, "no-cluster-constraints", "", false, "Only evaluates the starter policies rather than also the Gatekeeper constraints of the cluster")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepValidatePoliciesOptions) Run() error {
+	allRules, err := o.policyRules()
+	if err != nil {
+		return err
+	}
+	rules, err := policy.FindRules(allRules, o.Policies)
+	if err != nil {
+		return util.InvalidOption("policy", strings.Join(o.Policies, ", "), policy.RuleNames(allRules))
+	}
+	err = o.installOpa()
+	if err != nil {
+		return errors.Wrap(err, "failed to install opa")
+	}
+
+	dir := o.TemplatesDir
+	if dir == "" {
+		dir, err = o.renderChart()
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+	}
+
+	violations, err := policy.ValidateDir(dir, rules)
+	if err != nil {
+		return errors.Wrapf(err, "failed to evaluate the policies on the manifests in %s", dir)
+	}
+	if len(violations) > 0 {
+		for _, v := range violations {
+			log.Warnf("%s\n", v.String())
+		}
+		return fmt.Errorf("found %d violations of the policies %s", len(violations), strings.Join(policy.RuleNames(rules), ", "))
+	}
+	log.Infof("The manifests satisfy the policies %s\n", util.ColorInfo(strings.Join(policy.RuleNames(rules), ", ")))
+	return nil
+}
+
+// policyRules returns the rules of the starter policies and of the Gatekeeper constraints of the cluster. The
+// constraints of the cluster replace the starter policies of the same name as they are the ones enforced
+func (o *StepValidatePoliciesOptions) policyRules() ([]*policy.Rule, error) {
+	starterRules, err := policy.StarterRules(policy.StarterPolicies)
+	if err != nil {
+		return nil, err
+	}
+	if o.NoClusterConstraints {
+		return starterRules, nil
+	}
+	dynamicClient, err := o.dynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	clusterRules, err := policy.ClusterRules(dynamicClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the Gatekeeper constraints of the cluster, use --no-cluster-constraints to only evaluate the starter policies")
+	}
+	answer := clusterRules
+	for _, r := range starterRules {
+		found := false
+		for _, cr := range clusterRules {
+			if cr.Name == r.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			answer = append(answer, r)
+		}
+	}
+	return answer, nil
+}
+
+// renderChart renders the chart into a temporary directory which is returned
+func (o *StepValidatePoliciesOptions) renderChart() (string, error) {
+	chartDir := o.Dir
+	if chartDir == "" {
+		chartFile, err := o.FindHelmChart()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to find the chart of the current directory")
+		}
+		if chartFile == "" {
+			return "", errors.New("no chart found in the current directory, try the --dir flag")
+		}
+		chartDir = filepath.Dir(chartFile)
+	}
+	exists, err := util.FileExists(filepath.Join(chartDir, "requirements.yaml"))
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		_, err = o.helmInitDependencyBuild(chartDir, o.defaultReleaseCharts())
+		if err != nil {
+			return "", err
+		}
+	}
+
+	ns := o.Namespace
+	if ns == "" {
+		_, ns, err = o.KubeClient()
+		if err != nil {
+			return "", err
+		}
+	}
+	outDir, err := ioutil.TempDir("", "jx-policies-")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create a temporary directory")
+	}
+	err = o.Helm().Template(chartDir, o.ReleaseName, ns, outDir, false, o.SetValues, o.ValueFiles)
+	if err != nil {
+		os.RemoveAll(outDir)
+		return "", errors.Wrapf(err, "failed to render the chart %s", chartDir)
+	}
+	return outDir, nil
+}
@@ -0,0 +1,342 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/addon"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/kube/services"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+// prometheusURLEnvVar the environment variable of the URL of Prometheus used when there is no --prometheus-url
+const prometheusURLEnvVar = "PROMETHEUS_URL"
+
+// StepWaitForRolloutOptions contains the command line flags
+type StepWaitForRolloutOptions struct {
+	StepOptions
+
+	Application string
+	ReleaseName string
+	Version     string
+	Environment string
+	Namespace   string
+	Timeout     string
+	PollTime    string
+
+	HealthCheck   bool
+	HealthURL     string
+	HealthPath    string
+	PrometheusURL string
+	Queries       []string
+	Rollback      bool
+}
+
+var (
+	stepWaitForRolloutLong = templates.LongDesc(`
+		Waits for a version of an application to be rolled out in an environment or namespace.
+
+		The application is rolled out when its deployment or statefulset is available with all its replicas updated
+		or, for applications deployed as Knative Services, when the service is ready.
+
+		Health gates can then be checked before the promotion is declared successful: the exposed URL of the
+		application must respond successfully and the Prometheus queries must satisfy their thresholds, such as an
+		error rate below 5%. The queries are evaluated by the Prometheus given by --prometheus-url, the
+		$PROMETHEUS_URL environment variable or the Prometheus of the monitoring addon of the team.
+
+		If the rollout or the health gates do not succeed before the timeout the application can be rolled back to
+		its previous version. Applications in environments with a git repository are rolled back by committing the
+		previous version to the repository as 'jx rollback --direct' does, otherwise the helm release is rolled back
+		to its previous revision.
+`)
+
+	stepWaitForRolloutExample = templates.Examples(`
+		# wait for the version of the current application to roll out in staging
+		jx step wait-for-rollout --env staging --version 1.2.3
+
+		# wait for any version of an application to roll out in a namespace
+		jx step wait-for-rollout --app myapp --namespace jx-production --timeout 5m
+
+		# wait for the rollout then for the health endpoint of the exposed URL of the application to respond
+		jx step wait-for-rollout --env production --version 1.2.3 --health-check --health-path /health
+
+		# also require an error rate below 5% and roll back the release if the gates do not pass
+		jx step wait-for-rollout --env production --version 1.2.3 --health-check --rollback \
+			--query 'sum(rate(http_requests_total{code=~"5.."}[1m])) / sum(rate(http_requests_total[1m])) < 0.05'
+`)
+)
+
+// NewCmdStepWaitForRollout creates the command
+func NewCmdStepWaitForRollout(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := StepWaitForRolloutOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "wait-for-rollout",
+		Short:   "Waits for a version of an application to be rolled out in an environment",
+		Aliases: []string{"rollout"},
+		Long:    stepWaitForRolloutLong,
+		Example: stepWaitForRolloutExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Application, optionApplication, "a", "", "The application to wait for. Defaults to the application in the current directory")
+	cmd.Flags().StringVarP(&options.ReleaseName, "release", "r", "", "The helm release of the application. Defaults to the namespace and application name")
+	cmd.Flags().StringVarP(&options.Version, optionVersion, "v", "", "The version to wait for. Any version is accepted if not specified")
+	cmd.Flags().StringVarP(&options.Environment, optionEnvironment, "e", "", "The environment the application is rolled out to")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace the application is rolled out to if no environment is specified")
+	cmd.Flags().StringVarP(&options.Timeout, optionTimeout, "t", "10m", "The duration before we consider this operation failed")
+	cmd.Flags().StringVarP(&options.PollTime, optionPollTime, "", "5s", "The amount of time between polls for the rollout")
+	cmd.Flags().BoolVarP(&options.HealthCheck, "health-check", "", false, "Waits for the exposed URL of the application to respond successfully once rolled out")
+	cmd.Flags().StringVarP(&options.HealthURL, "health-url", "", "", "The URL which must respond successfully once rolled out. Defaults to the exposed URL of the application if --health-check is enabled")
+	cmd.Flags().StringVarP(&options.HealthPath, "health-path", "", "", "The path appended to the exposed URL of the application for the health check such as /health")
+	cmd.Flags().StringVarP(&options.PrometheusURL, "prometheus-url", "", "", "The URL of Prometheus evaluating the queries. Defaults to $"+prometheusURLEnvVar+" or the Prometheus of the monitoring addon")
+	cmd.Flags().StringArrayVarP(&options.Queries, "query", "", []string{}, "A Prometheus query with a threshold which must be satisfied once rolled out such as 'error_rate < 0.05'. Can be specified multiple times")
+	cmd.Flags().BoolVarP(&options.Rollback, "rollback", "", false, "Rolls back the application to its previous version if the rollout or the health gates do not succeed")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *StepWaitForRolloutOptions) Run() error {
+	timeout, err := time.ParseDuration(o.Timeout)
+	if err != nil {
+		return util.InvalidOptionError(optionTimeout, o.Timeout, err)
+	}
+	pollDuration, err := time.ParseDuration(o.PollTime)
+	if err != nil {
+		return util.InvalidOptionError(optionPollTime, o.PollTime, err)
+	}
+	app := o.Application
+	if app == "" {
+		app, err = o.DiscoverAppName()
+		if err != nil {
+			return err
+		}
+	}
+	ns, err := o.rolloutNamespace()
+	if err != nil {
+		return err
+	}
+	releaseName := o.ReleaseName
+	if releaseName == "" {
+		releaseName = ns + "-" + app
+	}
+	conditions := []*addon.PrometheusCondition{}
+	for _, query := range o.Queries {
+		condition, err := addon.ParsePrometheusCondition(query)
+		if err != nil {
+			return util.InvalidOptionError("query", query, err)
+		}
+		conditions = append(conditions, condition)
+	}
+
+	end := time.Now().Add(timeout)
+	err = o.waitForRollout(ns, app, releaseName, o.Version, end, pollDuration)
+	if err == nil {
+		err = o.waitForHealthGates(ns, app, releaseName, conditions, end, pollDuration)
+	}
+	if err != nil && o.Rollback {
+		rollbackErr := o.rollback(ns, app, releaseName)
+		if rollbackErr != nil {
+			return fmt.Errorf("%s and failed to roll back %s: %s", err, app, rollbackErr)
+		}
+		return errors.Wrapf(err, "rolled back %s", app)
+	}
+	return err
+}
+
+// rollback reverts the application to its previous version. In environments with a git repository the application
+// is usually part of the release of the environment and the next run of the environment pipeline would apply the
+// failed version again, so the previous version is committed to the repository instead of rolling back the release
+func (o *StepWaitForRolloutOptions) rollback(ns string, app string, releaseName string) error {
+	if o.Environment != "" {
+		jxClient, devNs, err := o.JXClientAndDevNamespace()
+		if err != nil {
+			return err
+		}
+		env, err := kube.GetEnvironment(jxClient, devNs, o.Environment)
+		if err != nil {
+			return err
+		}
+		if env.Spec.Source.URL != "" {
+			log.Warnf("Rolling back %s in environment %s via its git repository\n", app, env.Name)
+			options := &RollbackOptions{
+				PromoteOptions: PromoteOptions{
+					CommonOptions:       o.CommonOptions,
+					Application:         app,
+					Environment:         env.Name,
+					ReleaseName:         o.ReleaseName,
+					LocalHelmRepoName:   kube.LocalHelmRepoName,
+					HelmRepositoryURL:   helm.DefaultHelmRepositoryURL,
+					Timeout:             o.Timeout,
+					PullRequestPollTime: o.PollTime,
+				},
+				Direct: true,
+			}
+			options.BatchMode = true
+			return options.Run()
+		}
+	}
+	log.Warnf("Rolling back release %s to its previous revision\n", releaseName)
+	return o.Helm().RollbackRelease(ns, releaseName, 0)
+}
+
+// waitForHealthGates waits for the health URL of the application to respond successfully and for the Prometheus
+// queries to satisfy their thresholds
+func (o *StepWaitForRolloutOptions) waitForHealthGates(ns string, app string, releaseName string, conditions []*addon.PrometheusCondition, end time.Time, pollDuration time.Duration) error {
+	healthURL, err := o.healthURL(ns, app, releaseName)
+	if err != nil {
+		return err
+	}
+	prometheusURL := ""
+	if len(conditions) > 0 {
+		prometheusURL, err = o.prometheusURL()
+		if err != nil {
+			return err
+		}
+	}
+	if healthURL == "" && len(conditions) == 0 {
+		return nil
+	}
+
+	client := util.GetClientWithTimeout(10 * time.Second)
+	lastFailures := ""
+	for {
+		failures := healthGateFailures(client, healthURL, prometheusURL, conditions)
+		if len(failures) == 0 {
+			log.Successf("Application %s passed the health gates in namespace %s", util.ColorInfo(app), util.ColorInfo(ns))
+			return nil
+		}
+		text := strings.Join(failures, "; ")
+		if time.Now().After(end) {
+			return fmt.Errorf("Timed out waiting for %s to pass the health gates in namespace %s: %s", app, ns, text)
+		}
+		if text != lastFailures {
+			lastFailures = text
+			log.Infof("Waiting for %s to pass the health gates: %s\n", util.ColorInfo(app), text)
+		}
+		time.Sleep(pollDuration)
+	}
+}
+
+// healthURL returns the URL of the health check or an empty string if it is not enabled
+func (o *StepWaitForRolloutOptions) healthURL(ns string, app string, releaseName string) (string, error) {
+	if o.HealthURL != "" {
+		return o.HealthURL, nil
+	}
+	if !o.HealthCheck {
+		return "", nil
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return "", err
+	}
+	u := ""
+	for _, name := range []string{app, releaseName} {
+		u, err = services.FindServiceURL(kubeClient, ns, name)
+		if err == nil && u != "" {
+			break
+		}
+	}
+	if u == "" {
+		return "", fmt.Errorf("could not find the exposed URL of %s in namespace %s, try the --health-url flag", app, ns)
+	}
+	if o.HealthPath != "" {
+		u = util.UrlJoin(u, o.HealthPath)
+	}
+	return u, nil
+}
+
+// prometheusURL returns the URL of Prometheus from the option or the environment variable defaulting to the
+// Prometheus of the monitoring addon in the namespace it was installed in
+func (o *StepWaitForRolloutOptions) prometheusURL() (string, error) {
+	if o.PrometheusURL != "" {
+		return o.PrometheusURL, nil
+	}
+	if u := os.Getenv(prometheusURLEnvVar); u != "" {
+		return u, nil
+	}
+	_, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return "", err
+	}
+	ns := devNs
+	settings, err := o.TeamSettings()
+	if err != nil {
+		return "", err
+	}
+	if a := settings.Addon(kube.DefaultKubePrometheusStackReleaseName); a != nil && a.Namespace != "" {
+		ns = a.Namespace
+	}
+	return fmt.Sprintf("http://prometheus-operated.%s:9090", ns), nil
+}
+
+// healthGateFailures returns the descriptions of the health gates which are not satisfied
+func healthGateFailures(client *http.Client, healthURL string, prometheusURL string, conditions []*addon.PrometheusCondition) []string {
+	failures := []string{}
+	if healthURL != "" {
+		resp, err := client.Get(healthURL)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s is not reachable: %s", healthURL, err))
+		} else {
+			resp.Body.Close()
+			if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+				failures = append(failures, fmt.Sprintf("%s returned status %d", healthURL, resp.StatusCode))
+			}
+		}
+	}
+	for _, condition := range conditions {
+		value, err := addon.QueryPrometheus(client, prometheusURL, condition.Query)
+		if err != nil {
+			failures = append(failures, err.Error())
+		} else if !condition.Satisfied(value) {
+			failures = append(failures, fmt.Sprintf("%s is %v", condition.String(), value))
+		}
+	}
+	return failures
+}
+
+// rolloutNamespace returns the namespace of the environment or the namespace option
+func (o *StepWaitForRolloutOptions) rolloutNamespace() (string, error) {
+	if o.Environment == "" {
+		if o.Namespace == "" {
+			return "", fmt.Errorf("either the --%s or --namespace option is required", optionEnvironment)
+		}
+		return o.Namespace, nil
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return "", err
+	}
+	env, err := kube.GetEnvironment(jxClient, ns, o.Environment)
+	if err != nil {
+		return "", err
+	}
+	if env.Spec.Namespace == "" {
+		return "", fmt.Errorf("the environment %s has no namespace", o.Environment)
+	}
+	return env.Spec.Namespace, nil
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/addon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthGateFailures(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			w.WriteHeader(http.StatusOK)
+		case "/api/v1/query":
+			value := "0.01"
+			if r.URL.Query().Get("query") == "canary_error_rate" {
+				value = "0.2"
+			}
+			fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1556000000.1,"%s"]}]}}`, value)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	condition, err := addon.ParsePrometheusCondition("error_rate < 0.05")
+	assert.NoError(t, err)
+	conditions := []*addon.PrometheusCondition{condition}
+
+	failures := healthGateFailures(server.Client(), server.URL+"/health", server.URL, conditions)
+	assert.Empty(t, failures)
+
+	condition, err = addon.ParsePrometheusCondition("canary_error_rate < 0.05")
+	assert.NoError(t, err)
+	conditions = []*addon.PrometheusCondition{condition}
+
+	failures = healthGateFailures(server.Client(), server.URL+"/ready", server.URL, conditions)
+	assert.Equal(t, []string{
+		server.URL + "/ready returned status 503",
+		"canary_error_rate < 0.05 is 0.2",
+	}, failures)
+}
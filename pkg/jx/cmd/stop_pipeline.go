@@ -5,13 +5,23 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 
 	"github.com/jenkins-x/golang-jenkins"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/builds"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
 // StopPipelineOptions contains the command line options
@@ -28,6 +38,9 @@ var (
 	stopPipelineLong = templates.LongDesc(`
 		Stops the pipeline build.
 
+		If no build number is specified the latest build of the pipeline is stopped. When using knative builds
+		the build is deleted and its PipelineActivity is marked as Aborted.
+
 `)
 
 	stopPipelineExample = templates.Examples(`
@@ -73,6 +86,23 @@ func NewCmdStopPipeline(f Factory, in terminal.FileReader, out terminal.FileWrit
 
 // Run implements this command
 func (o *StopPipelineOptions) Run() error {
+	_, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	_, _, err = o.JXClient()
+	if err != nil {
+		return err
+	}
+
+	isProw, err := o.isProw()
+	if err != nil {
+		return err
+	}
+	if isProw {
+		return o.stopKnativeBuilds()
+	}
+
 	jobMap, err := o.getJobMap(o.Filter)
 	if err != nil {
 		return err
@@ -109,7 +139,10 @@ func (o *StopPipelineOptions) Run() error {
 }
 
 func (o *StopPipelineOptions) stopJob(name string, allNames []string) error {
-	job := o.Jobs[name]
+	job, ok := o.Jobs[name]
+	if !ok {
+		return fmt.Errorf("no pipeline found called %s", name)
+	}
 	jenkinsClient, err := o.JenkinsClient()
 	if err != nil {
 		return err
@@ -127,3 +160,107 @@ func (o *StopPipelineOptions) stopJob(name string, allNames []string) error {
 	}
 	return jenkinsClient.StopBuild(job, build)
 }
+
+// stopKnativeBuilds stops the running knative builds of the pipelines
+func (o *StopPipelineOptions) stopKnativeBuilds() error {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	pods, err := builds.GetBuildPods(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	running := map[string][]*builds.BuildPodInfo{}
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodPending && pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		info := builds.CreateBuildPodInfo(pod)
+		if info == nil || info.Pipeline == "" || !strings.Contains(info.Pipeline, o.Filter) {
+			continue
+		}
+		if o.Build > 0 && info.BuildNumber != o.Build {
+			continue
+		}
+		running[info.Pipeline] = append(running[info.Pipeline], info)
+	}
+	names := []string{}
+	for k := range running {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	args := o.Args
+	if len(args) == 0 {
+		if len(names) == 0 {
+			return fmt.Errorf("no running builds found to stop")
+		}
+		name, err := util.PickName(names, "Which pipeline do you want to stop: ", "", o.In, o.Out, o.Err)
+		if err != nil {
+			return err
+		}
+		args = []string{name}
+	}
+	for _, name := range args {
+		infos := running[name]
+		if len(infos) == 0 {
+			return fmt.Errorf("no running build found for pipeline %s", name)
+		}
+		// lets stop the latest build unless a build number was specified
+		builds.SortBuildPodInfos(infos)
+		err = o.stopKnativeBuild(kubeClient, jxClient, ns, infos[0])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *StopPipelineOptions) stopKnativeBuild(kubeClient kubernetes.Interface, jxClient versioned.Interface, ns string, info *builds.BuildPodInfo) error {
+	pod := info.Pod
+	buildName := pod.Labels[builds.LabelBuildName]
+	if buildName == "" {
+		buildName = pod.Labels[builds.LabelOldBuildName]
+	}
+	if buildName != "" {
+		buildClient, _, err := o.KnativeBuildClient()
+		if err != nil {
+			return err
+		}
+		err = buildClient.BuildV1alpha1().Builds(ns).Delete(buildName, &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "deleting build %s", buildName)
+		}
+	}
+	err := kubeClient.CoreV1().Pods(ns).Delete(pod.Name, &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "deleting pod %s", pod.Name)
+	}
+
+	activities := jxClient.JenkinsV1().PipelineActivities(ns)
+	list, err := activities.List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for i := range list.Items {
+		activity := &list.Items[i]
+		if !info.MatchesPipeline(activity) || activity.Spec.Status.IsTerminated() {
+			continue
+		}
+		activity.Spec.Status = v1.ActivityStatusTypeAborted
+		activity.Spec.CompletedTimestamp = &metav1.Time{
+			Time: time.Now(),
+		}
+		_, err = activities.Update(activity)
+		if err != nil {
+			return errors.Wrapf(err, "updating PipelineActivity %s", activity.Name)
+		}
+	}
+	log.Infof("Stopped build %s of pipeline %s\n", util.ColorInfo("#"+info.Build), util.ColorInfo(info.Pipeline))
+	return nil
+}
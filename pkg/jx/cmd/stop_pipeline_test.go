@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/builds"
+	"github.com/jenkins-x/jx/pkg/gits"
+	build "github.com/knative/build/pkg/apis/build/v1alpha1"
+	knbfake "github.com/knative/build/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func knativeBuildPod(repo string, buildNumber string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myorg-" + repo + "-master-" + buildNumber + "-pod",
+			Namespace: "jx",
+			Labels: map[string]string{
+				builds.LabelBuildName: "myorg-" + repo + "-master-" + buildNumber,
+			},
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{
+					Name: "build-step-git-source",
+					Env: []corev1.EnvVar{
+						{Name: "REPO_OWNER", Value: "myorg"},
+						{Name: "REPO_NAME", Value: repo},
+						{Name: "BRANCH_NAME", Value: "master"},
+						{Name: "JX_BUILD_NUMBER", Value: buildNumber},
+						{Name: "SOURCE_URL", Value: "https://github.com/myorg/" + repo + ".git"},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: phase,
+		},
+	}
+}
+
+func knativeBuildActivity(repo string, buildNumber string, status v1.ActivityStatusType) *v1.PipelineActivity {
+	return &v1.PipelineActivity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myorg-" + repo + "-master-" + buildNumber,
+			Namespace: "jx",
+		},
+		Spec: v1.PipelineActivitySpec{
+			Pipeline:      "myorg/" + repo + "/master",
+			GitOwner:      "myorg",
+			GitRepository: repo,
+			Build:         buildNumber,
+			Status:        status,
+		},
+	}
+}
+
+func knativeBuild(repo string, buildNumber string) *build.Build {
+	return &build.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myorg-" + repo + "-master-" + buildNumber,
+			Namespace: "jx",
+		},
+	}
+}
+
+func newStopPipelineOptions(args ...string) *StopPipelineOptions {
+	o := &StopPipelineOptions{}
+	o.Args = args
+	ConfigureTestOptionsWithResources(&o.CommonOptions,
+		[]runtime.Object{
+			knativeBuildPod("myapp", "1", corev1.PodRunning),
+			knativeBuildPod("myapp", "2", corev1.PodPending),
+			knativeBuildPod("myapp", "3", corev1.PodSucceeded),
+			knativeBuildPod("other", "1", corev1.PodRunning),
+		},
+		[]runtime.Object{
+			knativeBuildActivity("myapp", "1", v1.ActivityStatusTypeRunning),
+			knativeBuildActivity("myapp", "2", v1.ActivityStatusTypeRunning),
+			knativeBuildActivity("myapp", "3", v1.ActivityStatusTypeSucceeded),
+			knativeBuildActivity("other", "1", v1.ActivityStatusTypeRunning),
+		},
+		gits.NewGitCLI(),
+		nil)
+	o.knbClient = knbfake.NewSimpleClientset(
+		knativeBuild("myapp", "1"),
+		knativeBuild("myapp", "2"),
+		knativeBuild("myapp", "3"),
+		knativeBuild("other", "1"),
+	)
+	return o
+}
+
+func TestStopKnativeBuildsStopsTheLatestBuild(t *testing.T) {
+	t.Parallel()
+
+	o := newStopPipelineOptions("myorg/myapp/master")
+	err := o.stopKnativeBuilds()
+	require.NoError(t, err)
+
+	pods, err := o.KubeClientCached.CoreV1().Pods("jx").List(metav1.ListOptions{})
+	require.NoError(t, err)
+	podNames := []string{}
+	for _, pod := range pods.Items {
+		podNames = append(podNames, pod.Name)
+	}
+	assert.ElementsMatch(t, []string{"myorg-myapp-master-1-pod", "myorg-myapp-master-3-pod", "myorg-other-master-1-pod"}, podNames)
+
+	buildList, err := o.knbClient.BuildV1alpha1().Builds("jx").List(metav1.ListOptions{})
+	require.NoError(t, err)
+	buildNames := []string{}
+	for _, b := range buildList.Items {
+		buildNames = append(buildNames, b.Name)
+	}
+	assert.ElementsMatch(t, []string{"myorg-myapp-master-1", "myorg-myapp-master-3", "myorg-other-master-1"}, buildNames)
+
+	activities := o.jxClient.JenkinsV1().PipelineActivities("jx")
+	expected := map[string]v1.ActivityStatusType{
+		"myorg-myapp-master-1": v1.ActivityStatusTypeRunning,
+		"myorg-myapp-master-2": v1.ActivityStatusTypeAborted,
+		"myorg-myapp-master-3": v1.ActivityStatusTypeSucceeded,
+		"myorg-other-master-1": v1.ActivityStatusTypeRunning,
+	}
+	for name, status := range expected {
+		activity, err := activities.Get(name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, status, activity.Spec.Status, name)
+	}
+	aborted, err := activities.Get("myorg-myapp-master-2", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotNil(t, aborted.Spec.CompletedTimestamp)
+}
+
+func TestStopKnativeBuildsStopsTheGivenBuild(t *testing.T) {
+	t.Parallel()
+
+	o := newStopPipelineOptions("myorg/myapp/master")
+	o.Build = 1
+	err := o.stopKnativeBuilds()
+	require.NoError(t, err)
+
+	_, err = o.KubeClientCached.CoreV1().Pods("jx").Get("myorg-myapp-master-1-pod", metav1.GetOptions{})
+	assert.Error(t, err)
+	_, err = o.KubeClientCached.CoreV1().Pods("jx").Get("myorg-myapp-master-2-pod", metav1.GetOptions{})
+	assert.NoError(t, err)
+
+	activity, err := o.jxClient.JenkinsV1().PipelineActivities("jx").Get("myorg-myapp-master-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, v1.ActivityStatusTypeAborted, activity.Spec.Status)
+}
+
+func TestStopKnativeBuildsFailsWithoutRunningBuild(t *testing.T) {
+	t.Parallel()
+
+	o := newStopPipelineOptions("myorg/unknown/master")
+	err := o.stopKnativeBuilds()
+	require.Error(t, err)
+	assert.Equal(t, "no running build found for pipeline myorg/unknown/master", err.Error())
+
+	o = newStopPipelineOptions("myorg/myapp/master")
+	o.Filter = "other"
+	err = o.stopKnativeBuilds()
+	require.Error(t, err)
+	assert.Equal(t, "no running build found for pipeline myorg/myapp/master", err.Error())
+}
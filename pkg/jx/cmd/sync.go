@@ -35,6 +35,8 @@ type SyncOptions struct {
 	RemoteDir string
 	Reload    bool
 	WatchOnly bool
+	Mode      string
+	Interval  time.Duration
 
 	stopCh chan struct{}
 }
@@ -50,6 +52,9 @@ var (
 	sync_example = templates.Examples(`
 		# Starts synchronizing the current directory files to the users DevPod
 		jx sync 
+
+		# Synchronizes the current directory files to the users DevPod with rsync which must be installed in the DevPod
+		jx sync --mode rsync
 `)
 
 	defaultStignoreFile = `.git
@@ -87,11 +92,13 @@ func NewCmdSync(f Factory, in terminal.FileReader, out terminal.FileWriter, errO
 	}
 	/*	cmd.Flags().StringVarP(&options.Container, "container", "c", "", "The name of the container to log")
 		cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "the namespace to look for the Deployment. Defaults to the current namespace")
-		cmd.Flags().StringVarP(&options.Pod, "pod", "p", "", "the pod name to use")
-		cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The directory to watch. Defaults to the current directory")
-		cmd.Flags().StringVarP(&options.RemoteDir, "remote-dir", "r", "", "The remote directory in the DevPod to sync")
 		cmd.Flags().BoolVarP(&options.Reload, "reload", "", false, "Should we reload the remote container on file changes?")
 	*/
+	cmd.Flags().StringVarP(&options.Mode, "mode", "m", devPodSyncModeKsync, "The tool used to synchronise the files. Possible values: "+strings.Join(devPodSyncModes, ", "))
+	cmd.Flags().StringVarP(&options.Pod, "pod", "p", "", "The DevPod to synchronise with rsync. Defaults to the DevPod synchronising the directory")
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The directory to synchronise with rsync. Defaults to the current directory")
+	cmd.Flags().StringVarP(&options.RemoteDir, "remote-dir", "r", "", "The directory in the DevPod to synchronise with rsync. Defaults to the working directory of the DevPod")
+	cmd.Flags().DurationVarP(&options.Interval, "interval", "", defaultRsyncInterval, "The time between each synchronisation with rsync")
 	cmd.Flags().BoolVarP(&options.Daemon, "daemon", "", false, "Runs ksync in a background daemon")
 	cmd.Flags().BoolVarP(&options.NoKsyncInit, "no-init", "", false, "Disables the use of 'ksync init' to ensure we have initialised ksync")
 	cmd.Flags().BoolVarP(&options.SingleMode, "single-mode", "", false, "Terminates eagerly if `ksync watch` fails")
@@ -102,6 +109,12 @@ func NewCmdSync(f Factory, in terminal.FileReader, out terminal.FileWriter, errO
 }
 
 func (o *SyncOptions) Run() error {
+	if o.Mode == devPodSyncModeRsync {
+		return o.RsyncWatch()
+	}
+	if o.Mode != "" && o.Mode != devPodSyncModeKsync {
+		return util.InvalidOption("mode", o.Mode, devPodSyncModes)
+	}
 
 	// ksync is installed to the jx/bin dir, so we can add it for the user
 	os.Setenv("PATH", util.PathWithBinary())
@@ -128,6 +141,11 @@ func (o *SyncOptions) Run() error {
 		}
 	}
 
+	// the DevPods of the user are in use for as long as ksync synchronises them
+	stop := make(chan struct{})
+	defer close(stop)
+	go o.keepKsyncDevPodsActive(client, stop)
+
 	if o.SingleMode {
 		return o.KsyncWatch()
 	}
@@ -139,6 +157,46 @@ func (o *SyncOptions) Run() error {
 	}
 }
 
+// keepKsyncDevPodsActive records the activity of the DevPods of the current user at every interval until the stop
+// channel is closed
+func (o *SyncOptions) keepKsyncDevPodsActive(client kubernetes.Interface, stop <-chan struct{}) {
+	_, curNs, err := o.KubeClient()
+	if err != nil {
+		log.Warnf("%s\n", err)
+		return
+	}
+	ns, _, err := kube.GetDevNamespace(client, curNs)
+	if err != nil {
+		log.Warnf("%s\n", err)
+		return
+	}
+	userName, err := o.getUsername("")
+	if err != nil {
+		log.Warnf("%s\n", err)
+		return
+	}
+	ticker := time.NewTicker(kube.DevPodActivityInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			_, pods, err := kube.GetPodsWithLabels(client, ns, kube.LabelDevPodUsername+"="+userName)
+			if err != nil {
+				log.Warnf("%s\n", err)
+				continue
+			}
+			for name := range pods {
+				err = kube.UpdateDevPodActivity(client, ns, name)
+				if err != nil {
+					log.Warnf("%s\n", err)
+				}
+			}
+		}
+	}
+}
+
 func (o *SyncOptions) waitForKsyncWatchToFail() {
 	logged := false
 	for {
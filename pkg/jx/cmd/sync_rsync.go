@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// defaultRsyncInterval the default time between each synchronisation with rsync
+	defaultRsyncInterval = 2 * time.Second
+)
+
+// StartRsync synchronises the directory to the DevPod with rsync then keeps it synchronised in a background process
+// logging to the jx logs directory
+func (o *SyncOptions) StartRsync() error {
+	if o.Interval <= 0 {
+		o.Interval = defaultRsyncInterval
+	}
+	err := o.ensureRemoteDir()
+	if err != nil {
+		return err
+	}
+	err = o.Rsync()
+	if err != nil {
+		return err
+	}
+	logsDir, err := util.LogsDir()
+	if err != nil {
+		return err
+	}
+	logFileName := filepath.Join(logsDir, fmt.Sprintf("sync-%s.log", o.Pod))
+	logFile, err := os.Create(logFileName)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+	jx, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(jx, "sync", "--mode", devPodSyncModeRsync, "--pod", o.Pod, "--dir", o.Dir, "--remote-dir", o.RemoteDir,
+		"--interval", o.Interval.String())
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	err = cmd.Start()
+	if err != nil {
+		return fmt.Errorf("failed to start synchronising with rsync: %s", err)
+	}
+	log.Infof("Synchronising directory %s to DevPod %s with rsync in the background, logging to %s\n", util.ColorInfo(o.Dir), util.ColorInfo(o.Pod), util.ColorInfo(logFileName))
+	return nil
+}
+
+// RsyncWatch synchronises the directory to the DevPod with rsync at every interval until the DevPod is deleted
+func (o *SyncOptions) RsyncWatch() error {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	err = o.defaultRsyncOptions(client)
+	if err != nil {
+		return err
+	}
+	err = o.ensureRemoteDir()
+	if err != nil {
+		return err
+	}
+	lastActivity := time.Time{}
+	for {
+		err = o.Rsync()
+		if err != nil {
+			if _, err2 := client.CoreV1().Pods(o.Namespace).Get(o.Pod, metav1.GetOptions{}); err2 != nil {
+				log.Infof("Stopped synchronising as DevPod %s no longer exists\n", o.Pod)
+				return nil
+			}
+			log.Warnf("%s\n", err)
+		} else if time.Since(lastActivity) > kube.DevPodActivityInterval {
+			// a DevPod being synchronised is in use so should not be deleted as idle
+			lastActivity = time.Now()
+			err = kube.UpdateDevPodActivity(client, o.Namespace, o.Pod)
+			if err != nil {
+				log.Warnf("%s\n", err)
+			}
+		}
+		time.Sleep(o.Interval)
+	}
+}
+
+// Rsync synchronises the directory to the DevPod once
+func (o *SyncOptions) Rsync() error {
+	ignoreFile := filepath.Join(o.Dir, ".stignore")
+	exists, err := util.FileExists(ignoreFile)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		err = ioutil.WriteFile(ignoreFile, []byte(defaultStignoreFile), DefaultWritePermissions)
+		if err != nil {
+			return err
+		}
+	}
+	// rsync runs the remote rsync via kubectl exec passing the pod name as the first argument of the shell
+	rsh := fmt.Sprintf(`sh -c 'pod=$0; shift; exec kubectl exec -i -n %s $pod -- "$@"'`, o.Namespace)
+	args := []string{"-az", "--delete", "--blocking-io", "--exclude-from", ignoreFile, "--rsh", rsh,
+		o.Dir + string(os.PathSeparator), fmt.Sprintf("%s:%s/", o.Pod, o.RemoteDir)}
+	_, err = o.getCommandOutput(o.Dir, "rsync", args...)
+	if err != nil {
+		return fmt.Errorf("failed to synchronise %s to DevPod %s with rsync: %s", o.Dir, o.Pod, err)
+	}
+	return nil
+}
+
+// ensureRemoteDir creates the directory in the DevPod the files are synchronised to
+func (o *SyncOptions) ensureRemoteDir() error {
+	_, err := o.getCommandOutput("", "kubectl", "exec", "-n", o.Namespace, o.Pod, "--", "mkdir", "-p", o.RemoteDir)
+	if err != nil {
+		return fmt.Errorf("failed to create directory %s in DevPod %s: %s", o.RemoteDir, o.Pod, err)
+	}
+	return nil
+}
+
+// defaultRsyncOptions defaults the namespace, directories and DevPod to synchronise
+func (o *SyncOptions) defaultRsyncOptions(client kubernetes.Interface) error {
+	var err error
+	if o.Dir == "" {
+		o.Dir, err = os.Getwd()
+		if err != nil {
+			return err
+		}
+	}
+	if o.Namespace == "" {
+		_, curNs, err := o.KubeClient()
+		if err != nil {
+			return err
+		}
+		o.Namespace, _, err = kube.GetDevNamespace(client, curNs)
+		if err != nil {
+			return err
+		}
+	}
+	var pod *corev1.Pod
+	devPods, err := kube.GetDevPods(client, o.Namespace)
+	if err != nil {
+		return err
+	}
+	for i := range devPods {
+		p := &devPods[i]
+		if p.Name == o.Pod || (o.Pod == "" && p.Annotations[kube.AnnotationLocalDir] == o.Dir) {
+			pod = p
+			break
+		}
+	}
+	if pod == nil {
+		if o.Pod != "" {
+			return fmt.Errorf("no DevPod %s found in namespace %s", o.Pod, o.Namespace)
+		}
+		return fmt.Errorf("no DevPod found synchronising directory %s. Create one via 'jx create devpod --sync --sync-mode rsync'", o.Dir)
+	}
+	o.Pod = pod.Name
+	if o.RemoteDir == "" {
+		o.RemoteDir = pod.Annotations[kube.AnnotationWorkingDir]
+	}
+	if o.RemoteDir == "" {
+		return util.MissingOption("remote-dir")
+	}
+	return nil
+}
@@ -0,0 +1,594 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/builds"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/kube/services"
+	"github.com/jenkins-x/jx/pkg/table"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/browser"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// UIOptions the options for the jx ui command
+type UIOptions struct {
+	CommonOptions
+
+	Refresh  time.Duration
+	LogLines int64
+}
+
+const (
+	uiPaneEnvironments = iota
+	uiPaneApplications
+	uiPanePipelines
+	uiPanePreviews
+)
+
+const (
+	uiActionNone = iota
+	uiActionQuit
+	uiActionOpen
+	uiActionPromote
+	uiActionRestart
+)
+
+// uiMaxPaneRows the maximum number of rows displayed in a pane, the rows scroll with the selection
+const uiMaxPaneRows = 8
+
+var (
+	ui_long = templates.LongDesc(`
+		Displays an interactive dashboard in the terminal with panes for the environments, applications, running
+		pipelines and preview environments of the team. The dashboard is refreshed periodically.
+
+		When a running pipeline is selected the last lines of the log of its current step are displayed.
+
+		Key bindings:
+
+		* tab or left/right: move between the panes
+		* up/down: select a row of the current pane
+		* o or enter: open the URL of the selected row in a browser
+		* p: promote the selected application to the next environment
+		* r: restart the selected pipeline
+		* q: quit
+`)
+
+	ui_example = templates.Examples(`
+		# Display the dashboard
+		jx ui
+
+		# Display the dashboard refreshing it every 10 seconds
+		jx ui --refresh 10s
+	`)
+)
+
+// uiRow a row of a pane of the dashboard
+type uiRow struct {
+	Cells []string
+	URL   string
+
+	App         string
+	Version     string
+	Environment string
+	Pipeline    string
+	Build       string
+}
+
+// uiPane a pane of the dashboard
+type uiPane struct {
+	Title  string
+	Header []string
+	Rows   []uiRow
+}
+
+// uiDashboard the state of the dashboard
+type uiDashboard struct {
+	Panes    []*uiPane
+	Focus    int
+	Selected []int
+	LogTitle string
+	Logs     []string
+	Message  string
+	Updated  time.Time
+}
+
+// NewCmdUI creates the command
+func NewCmdUI(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &UIOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			In:      in,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "ui",
+		Short:   "Displays an interactive dashboard of the environments, applications, pipelines and previews",
+		Long:    ui_long,
+		Example: ui_example,
+		Aliases: []string{"dashboard"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().DurationVarP(&options.Refresh, "refresh", "r", 5*time.Second, "How often the dashboard is refreshed")
+	cmd.Flags().Int64VarP(&options.LogLines, "log-lines", "l", 10, "The number of lines of the log of the selected pipeline to display")
+	return cmd
+}
+
+// Run implements this command
+func (o *UIOptions) Run() error {
+	if o.BatchMode {
+		return fmt.Errorf("the dashboard cannot be displayed in batch mode")
+	}
+	if o.Refresh <= 0 {
+		return util.InvalidOptionf("refresh", o.Refresh.String(), "the refresh interval must be positive")
+	}
+	dashboard := &uiDashboard{}
+	err := o.refreshDashboard(dashboard)
+	if err != nil {
+		return err
+	}
+
+	reader := terminal.NewRuneReader(o.In)
+	err = reader.SetTermMode()
+	if err != nil {
+		return errors.Wrap(err, "failed to switch the terminal to raw mode")
+	}
+	defer reader.RestoreTermMode()
+
+	keys := make(chan rune)
+	go func() {
+		for {
+			key, _, err := reader.ReadRune()
+			if err != nil {
+				close(keys)
+				return
+			}
+			keys <- key
+		}
+	}()
+
+	ticker := time.NewTicker(o.Refresh)
+	defer ticker.Stop()
+	for {
+		o.renderDashboard(dashboard)
+		select {
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			focus := dashboard.Focus
+			selected := dashboard.selectedIndex()
+			dashboard.Message = ""
+			switch dashboard.handleKey(key) {
+			case uiActionQuit:
+				fmt.Fprint(o.Out, clearScreen)
+				return nil
+			case uiActionOpen:
+				dashboard.Message = o.openRow(dashboard.selectedRow())
+			case uiActionPromote:
+				row := dashboard.selectedRow()
+				o.runAction(reader, keys, func() error {
+					return o.promoteRow(row)
+				})
+			case uiActionRestart:
+				row := dashboard.selectedRow()
+				o.runAction(reader, keys, func() error {
+					return o.restartRow(row)
+				})
+			}
+			if focus != dashboard.Focus || selected != dashboard.selectedIndex() {
+				o.refreshLogs(dashboard)
+			}
+		case <-ticker.C:
+			err = o.refreshDashboard(dashboard)
+			if err != nil {
+				dashboard.Message = err.Error()
+			}
+		}
+	}
+}
+
+// handleKey updates the focus and selection of the dashboard returning the action to perform for the key
+func (d *uiDashboard) handleKey(key rune) int {
+	count := len(d.Panes)
+	if count == 0 {
+		if key == 'q' || key == terminal.KeyInterrupt || key == terminal.KeyEscape {
+			return uiActionQuit
+		}
+		return uiActionNone
+	}
+	switch key {
+	case 'q', terminal.KeyInterrupt, terminal.KeyEscape:
+		return uiActionQuit
+	case '\t', terminal.KeyArrowRight:
+		d.Focus = (d.Focus + 1) % count
+	case terminal.KeyArrowLeft:
+		d.Focus = (d.Focus + count - 1) % count
+	case terminal.KeyArrowDown, 'j':
+		if d.selectedIndex() < len(d.Panes[d.Focus].Rows)-1 {
+			d.Selected[d.Focus]++
+		}
+	case terminal.KeyArrowUp, 'k':
+		if d.selectedIndex() > 0 {
+			d.Selected[d.Focus]--
+		}
+	case 'o', terminal.KeyEnter:
+		if d.selectedRow() != nil {
+			return uiActionOpen
+		}
+	case 'p':
+		if d.Focus != uiPaneApplications || d.selectedRow() == nil {
+			d.Message = "select an application to promote"
+			return uiActionNone
+		}
+		return uiActionPromote
+	case 'r':
+		if d.Focus != uiPanePipelines || d.selectedRow() == nil {
+			d.Message = "select a pipeline to restart"
+			return uiActionNone
+		}
+		return uiActionRestart
+	}
+	return uiActionNone
+}
+
+// selectedIndex returns the index of the selected row of the focused pane
+func (d *uiDashboard) selectedIndex() int {
+	if d.Focus < len(d.Selected) {
+		return d.Selected[d.Focus]
+	}
+	return 0
+}
+
+// selectedRow returns the selected row of the focused pane or nil if the pane is empty
+func (d *uiDashboard) selectedRow() *uiRow {
+	if d.Focus >= len(d.Panes) {
+		return nil
+	}
+	rows := d.Panes[d.Focus].Rows
+	idx := d.selectedIndex()
+	if idx < len(rows) {
+		return &rows[idx]
+	}
+	return nil
+}
+
+// setPanes replaces the panes keeping the selections within the new rows
+func (d *uiDashboard) setPanes(panes []*uiPane) {
+	d.Panes = panes
+	for len(d.Selected) < len(panes) {
+		d.Selected = append(d.Selected, 0)
+	}
+	for i, pane := range panes {
+		if d.Selected[i] >= len(pane.Rows) {
+			d.Selected[i] = len(pane.Rows) - 1
+		}
+		if d.Selected[i] < 0 {
+			d.Selected[i] = 0
+		}
+	}
+	if d.Focus >= len(panes) {
+		d.Focus = 0
+	}
+}
+
+// render writes the dashboard to the output
+func (d *uiDashboard) render(out io.Writer) {
+	var buffer bytes.Buffer
+	buffer.WriteString(clearScreen)
+	fmt.Fprintf(&buffer, "%s  %s\n", util.ColorInfo("Jenkins X"), d.Updated.Format("15:04:05"))
+	for i, pane := range d.Panes {
+		title := pane.Title
+		if i == d.Focus {
+			title = util.ColorInfo("> " + title)
+		} else {
+			title = "  " + title
+		}
+		fmt.Fprintf(&buffer, "\n%s\n", title)
+		if len(pane.Rows) == 0 {
+			buffer.WriteString("  none\n")
+			continue
+		}
+		t := table.CreateTable(&buffer)
+		t.AddRow(append([]string{" "}, pane.Header...)...)
+		first, last := visibleRows(len(pane.Rows), d.Selected[i])
+		for j := first; j < last; j++ {
+			marker := " "
+			if i == d.Focus && j == d.Selected[i] {
+				marker = util.ColorInfo("*")
+			}
+			t.AddRow(append([]string{marker}, pane.Rows[j].Cells...)...)
+		}
+		t.Render()
+	}
+	if d.LogTitle != "" {
+		fmt.Fprintf(&buffer, "\n  %s\n", d.LogTitle)
+		for _, line := range d.Logs {
+			fmt.Fprintf(&buffer, "  %s\n", line)
+		}
+	}
+	buffer.WriteString("\ntab: next pane  up/down: select  o: open  p: promote  r: restart  q: quit\n")
+	if d.Message != "" {
+		buffer.WriteString(util.ColorWarning(d.Message) + "\n")
+	}
+	// the terminal is in raw mode so lines need a carriage return
+	fmt.Fprint(out, strings.Replace(buffer.String(), "\n", "\r\n", -1))
+}
+
+// visibleRows returns the range of rows to display so that the selected row is visible
+func visibleRows(count int, selected int) (int, int) {
+	if count <= uiMaxPaneRows {
+		return 0, count
+	}
+	first := selected - uiMaxPaneRows/2
+	if first < 0 {
+		first = 0
+	}
+	if first+uiMaxPaneRows > count {
+		first = count - uiMaxPaneRows
+	}
+	return first, first + uiMaxPaneRows
+}
+
+func (o *UIOptions) renderDashboard(dashboard *uiDashboard) {
+	dashboard.render(o.Out)
+}
+
+// refreshDashboard loads the panes of the dashboard from the cluster
+func (o *UIOptions) refreshDashboard(dashboard *uiDashboard) error {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	envMap, envNames, err := kube.GetOrderedEnvironments(jxClient, ns)
+	if err != nil {
+		return err
+	}
+
+	environments := &uiPane{Title: "Environments", Header: []string{"NAME", "KIND", "PROMOTE", "NAMESPACE", "SOURCE"}}
+	applications := &uiPane{Title: "Applications", Header: []string{"APP", "ENVIRONMENT", "VERSION", "PODS", "URL"}}
+	pipelines := &uiPane{Title: "Running pipelines", Header: []string{"PIPELINE", "BUILD", "STATUS", "STARTED"}}
+	previews := &uiPane{Title: "Previews", Header: []string{"NAME", "PULL REQUEST", "NAMESPACE", "APPLICATION"}}
+
+	for _, name := range envNames {
+		env := envMap[name]
+		spec := &env.Spec
+		if spec.Kind == v1.EnvironmentKindTypePreview {
+			previews.Rows = append(previews.Rows, uiRow{
+				Cells: []string{name, spec.PullRequestURL, spec.Namespace, spec.PreviewGitSpec.ApplicationURL},
+				URL:   spec.PreviewGitSpec.ApplicationURL,
+			})
+			continue
+		}
+		environments.Rows = append(environments.Rows, uiRow{
+			Cells:       []string{name, kindString(spec), string(spec.PromotionStrategy), spec.Namespace, spec.Source.URL},
+			URL:         spec.Source.URL,
+			Environment: name,
+		})
+		if spec.Kind != v1.EnvironmentKindTypePermanent || spec.Namespace == "" {
+			continue
+		}
+		rows, err := o.applicationRows(kubeClient, name, spec.Namespace)
+		if err != nil {
+			return err
+		}
+		applications.Rows = append(applications.Rows, rows...)
+	}
+
+	activities, err := jxClient.JenkinsV1().PipelineActivities(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the pipeline activities in namespace %s", ns)
+	}
+	items := activities.Items
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Name < items[j].Name
+	})
+	for _, a := range items {
+		spec := &a.Spec
+		if spec.Status != v1.ActivityStatusTypeRunning && spec.Status != v1.ActivityStatusTypePending {
+			continue
+		}
+		started := ""
+		if spec.StartedTimestamp != nil {
+			started = time.Since(spec.StartedTimestamp.Time).Round(time.Second).String()
+		}
+		pipelines.Rows = append(pipelines.Rows, uiRow{
+			Cells:    []string{spec.Pipeline, spec.Build, string(spec.Status), started},
+			URL:      spec.BuildURL,
+			Pipeline: spec.Pipeline,
+			Build:    spec.Build,
+		})
+	}
+
+	dashboard.setPanes([]*uiPane{environments, applications, pipelines, previews})
+	dashboard.Updated = time.Now()
+	o.refreshLogs(dashboard)
+	return nil
+}
+
+// applicationRows returns the rows of the applications deployed in the namespace of an environment
+func (o *UIOptions) applicationRows(kubeClient kubernetes.Interface, envName string, ns string) ([]uiRow, error) {
+	deps, err := kubeClient.AppsV1beta1().Deployments(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the deployments in namespace %s", ns)
+	}
+	rows := []uiRow{}
+	for _, d := range deps.Items {
+		replicas := int32(1)
+		if d.Spec.Replicas != nil {
+			replicas = *d.Spec.Replicas
+		}
+		app := kube.GetAppName(d.Name, ns)
+		version := kube.GetVersion(&d.ObjectMeta)
+		url, _ := services.FindServiceURL(kubeClient, ns, d.Name)
+		rows = append(rows, uiRow{
+			Cells:       []string{app, envName, version, fmt.Sprintf("%d/%d", d.Status.ReadyReplicas, replicas), url},
+			URL:         url,
+			App:         app,
+			Version:     version,
+			Environment: envName,
+		})
+	}
+	return rows, nil
+}
+
+// refreshLogs loads the last lines of the log of the current step of the selected pipeline
+func (o *UIOptions) refreshLogs(dashboard *uiDashboard) {
+	dashboard.LogTitle = ""
+	dashboard.Logs = nil
+	row := dashboard.selectedRow()
+	if dashboard.Focus != uiPanePipelines || row == nil {
+		return
+	}
+	dashboard.LogTitle = fmt.Sprintf("Log of %s #%s", row.Pipeline, row.Build)
+	kubeClient, ns, err := o.KubeClient()
+	if err != nil {
+		dashboard.Logs = []string{err.Error()}
+		return
+	}
+	pods, err := builds.GetBuildPods(kubeClient, ns)
+	if err != nil {
+		dashboard.Logs = []string{err.Error()}
+		return
+	}
+	for _, pod := range pods {
+		info := builds.CreateBuildPodInfo(pod)
+		if info.Pipeline != row.Pipeline || info.Build != row.Build {
+			continue
+		}
+		container := ""
+		for _, c := range info.StepContainers("") {
+			if builds.IsStepStarted(pod, c.Name) {
+				container = c.Name
+			}
+		}
+		if container == "" {
+			dashboard.Logs = []string{"waiting for the first step to start"}
+			return
+		}
+		lines := o.LogLines
+		data, err := kubeClient.CoreV1().Pods(ns).GetLogs(pod.Name, &corev1.PodLogOptions{
+			Container: container,
+			TailLines: &lines,
+		}).Do().Raw()
+		if err != nil {
+			dashboard.Logs = []string{err.Error()}
+			return
+		}
+		dashboard.Logs = strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+		return
+	}
+	dashboard.Logs = []string{"no build pod found, use 'jx get build logs' for the logs of Jenkins builds"}
+}
+
+// runAction restores the terminal while running the action then waits for a key before returning to the dashboard
+func (o *UIOptions) runAction(reader *terminal.RuneReader, keys chan rune, action func() error) {
+	reader.RestoreTermMode()
+	fmt.Fprint(o.Out, clearScreen)
+	err := action()
+	if err != nil {
+		fmt.Fprintf(o.Out, "%s\n", util.ColorError(err.Error()))
+	}
+	fmt.Fprintf(o.Out, "\nPress any key to return to the dashboard\n")
+	err = reader.SetTermMode()
+	if err != nil {
+		fmt.Fprintf(o.Err, "failed to switch the terminal to raw mode: %s\n", err)
+	}
+	<-keys
+}
+
+// openRow opens the URL of the row in a browser returning the message to display
+func (o *UIOptions) openRow(row *uiRow) string {
+	if row == nil || row.URL == "" {
+		return "no URL to open"
+	}
+	err := browser.OpenURL(row.URL)
+	if err != nil {
+		return err.Error()
+	}
+	return "opened " + row.URL
+}
+
+// promoteRow promotes the version of the application of the row to the environment after its current one
+func (o *UIOptions) promoteRow(row *uiRow) error {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	envMap, envNames, err := kube.GetOrderedEnvironments(jxClient, ns)
+	if err != nil {
+		return err
+	}
+	target := nextPermanentEnvironment(envMap, envNames, row.Environment)
+	if target == "" {
+		return fmt.Errorf("there is no environment to promote %s to after %s", row.App, row.Environment)
+	}
+	fmt.Fprintf(o.Out, "Promoting %s version %s to %s\n\n", util.ColorInfo(row.App), util.ColorInfo(row.Version), util.ColorInfo(target))
+	po := &PromoteOptions{
+		Application:         row.App,
+		Version:             row.Version,
+		Environment:         target,
+		Timeout:             "1h",
+		PullRequestPollTime: "20s",
+		HelmRepositoryURL:   helm.DefaultHelmRepositoryURL,
+		LocalHelmRepoName:   kube.LocalHelmRepoName,
+		// the dashboard shows the progress of the promotion on refresh so lets not block it until the promotion completes
+		NoPoll:           true,
+		NoWaitAfterMerge: true,
+	}
+	po.CommonOptions = o.CommonOptions
+	po.BatchMode = true
+	return po.Run()
+}
+
+// nextPermanentEnvironment returns the name of the permanent environment ordered after the given one
+func nextPermanentEnvironment(envMap map[string]*v1.Environment, envNames []string, current string) string {
+	found := false
+	for _, name := range envNames {
+		if found && envMap[name].Spec.Kind == v1.EnvironmentKindTypePermanent {
+			return name
+		}
+		if name == current {
+			found = true
+		}
+	}
+	return ""
+}
+
+// restartRow starts the pipeline of the row again
+func (o *UIOptions) restartRow(row *uiRow) error {
+	fmt.Fprintf(o.Out, "Restarting pipeline %s\n\n", util.ColorInfo(row.Pipeline))
+	so := &StartPipelineOptions{
+		GetOptions: GetOptions{
+			CommonOptions: o.CommonOptions,
+		},
+	}
+	so.Args = []string{row.Pipeline}
+	so.BatchMode = true
+	return so.Run()
+}
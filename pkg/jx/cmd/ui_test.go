@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+func createTestDashboard() *uiDashboard {
+	d := &uiDashboard{}
+	d.setPanes([]*uiPane{
+		{Title: "Environments", Rows: []uiRow{{Environment: "staging"}, {Environment: "production"}}},
+		{Title: "Applications", Rows: []uiRow{{App: "myapp", Environment: "staging"}}},
+		{Title: "Running pipelines"},
+		{Title: "Previews"},
+	})
+	return d
+}
+
+func TestUIDashboardHandleKey(t *testing.T) {
+	t.Parallel()
+	d := createTestDashboard()
+
+	assert.Equal(t, uiActionNone, d.handleKey(terminal.KeyArrowDown))
+	assert.Equal(t, "production", d.selectedRow().Environment)
+	assert.Equal(t, uiActionNone, d.handleKey(terminal.KeyArrowDown))
+	assert.Equal(t, 1, d.selectedIndex(), "the selection should stop at the last row")
+
+	assert.Equal(t, uiActionNone, d.handleKey('p'))
+	assert.Equal(t, "select an application to promote", d.Message)
+
+	assert.Equal(t, uiActionNone, d.handleKey('\t'))
+	assert.Equal(t, uiPaneApplications, d.Focus)
+	assert.Equal(t, uiActionPromote, d.handleKey('p'))
+
+	assert.Equal(t, uiActionNone, d.handleKey('\t'))
+	assert.Nil(t, d.selectedRow())
+	assert.Equal(t, uiActionNone, d.handleKey('r'), "there is no pipeline to restart")
+	assert.Equal(t, uiActionNone, d.handleKey('o'), "there is no URL to open")
+
+	assert.Equal(t, uiActionNone, d.handleKey(terminal.KeyArrowLeft))
+	assert.Equal(t, uiActionNone, d.handleKey(terminal.KeyArrowLeft))
+	assert.Equal(t, uiPaneEnvironments, d.Focus)
+	assert.Equal(t, uiActionNone, d.handleKey(terminal.KeyArrowLeft))
+	assert.Equal(t, uiPanePreviews, d.Focus, "the focus should wrap around")
+
+	assert.Equal(t, uiActionQuit, d.handleKey('q'))
+}
+
+func TestUIDashboardSetPanesKeepsSelection(t *testing.T) {
+	t.Parallel()
+	d := createTestDashboard()
+	d.Selected[uiPaneEnvironments] = 1
+
+	d.setPanes([]*uiPane{
+		{Rows: []uiRow{{Environment: "staging"}}},
+		{},
+		{},
+		{},
+	})
+	assert.Equal(t, 0, d.Selected[uiPaneEnvironments])
+	assert.Equal(t, 0, d.Selected[uiPaneApplications])
+}
+
+func TestVisibleRows(t *testing.T) {
+	t.Parallel()
+	first, last := visibleRows(3, 2)
+	assert.Equal(t, []int{0, 3}, []int{first, last})
+
+	first, last = visibleRows(20, 10)
+	assert.Equal(t, []int{6, 14}, []int{first, last})
+
+	first, last = visibleRows(20, 19)
+	assert.Equal(t, []int{12, 20}, []int{first, last})
+}
+
+func TestNextPermanentEnvironment(t *testing.T) {
+	t.Parallel()
+	envMap := map[string]*v1.Environment{
+		"dev":        {Spec: v1.EnvironmentSpec{Kind: v1.EnvironmentKindTypeDevelopment}},
+		"staging":    {Spec: v1.EnvironmentSpec{Kind: v1.EnvironmentKindTypePermanent}},
+		"pr-1":       {Spec: v1.EnvironmentSpec{Kind: v1.EnvironmentKindTypePreview}},
+		"production": {Spec: v1.EnvironmentSpec{Kind: v1.EnvironmentKindTypePermanent}},
+	}
+	envNames := []string{"dev", "staging", "pr-1", "production"}
+
+	assert.Equal(t, "production", nextPermanentEnvironment(envMap, envNames, "staging"))
+	assert.Equal(t, "", nextPermanentEnvironment(envMap, envNames, "production"))
+}
@@ -1,10 +1,16 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"path/filepath"
+	"strings"
 
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io"
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/terraform"
 
 	"github.com/pkg/errors"
 
@@ -25,14 +31,37 @@ type UninstallOptions struct {
 	Context          string
 	Force            bool // Force uninstallation - programmatic use only - do not expose to the user
 	KeepEnvironments bool
+	DeleteCRDs       bool
+	DeleteCluster    bool
+	TerraformDir     string
+	ServiceAccount   string
+
+	// remaining the resources which could not be removed and need to be cleaned up manually
+	remaining []string
 }
 
 var (
 	uninstall_long = templates.LongDesc(`
-		Uninstalls the Jenkins X platform from a Kubernetes cluster`)
+		Uninstalls the Jenkins X platform from a Kubernetes cluster
+
+		Removes the team and environment namespaces, the Jenkins X CRDs and the cluster wide resources created
+		during the install. Any workloads outside of the Jenkins X namespaces are left untouched.
+
+		The CRDs and cluster wide resources are shared by all the teams in the cluster so they are only removed
+		along with the last team, unless --delete-crds is specified. Only the cluster wide resources labelled
+		as created by Jenkins X are removed.
+
+		Anything which could not be removed, such as webhooks on your git repositories, is listed at the end
+		so that it can be cleaned up manually.`)
 	uninstall_example = templates.Examples(`
 		# Uninstall the Jenkins X platform
-		jx uninstall`)
+		jx uninstall
+
+		# Uninstall Jenkins X but keep the applications deployed to the environments
+		jx uninstall --keep-environments
+
+		# Uninstall Jenkins X then destroy the cluster created via terraform
+		jx uninstall --delete-cluster --terraform-dir ~/.jx/clusters/mycluster/terraform`)
 )
 
 func NewCmdUninstall(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
@@ -61,6 +90,10 @@ func NewCmdUninstall(f Factory, in terminal.FileReader, out terminal.FileWriter,
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The team namespace to uninstall. Defaults to the current namespace.")
 	cmd.Flags().StringVarP(&options.Context, "context", "", "", "The kube context to uninstall JX from. This will be compared with the current context to prevent accidental uninstallation from the wrong cluster")
 	cmd.Flags().BoolVarP(&options.KeepEnvironments, "keep-environments", "", false, "Don't delete environments. Uninstall Jenkins X only.")
+	cmd.Flags().BoolVarP(&options.DeleteCRDs, "delete-crds", "", false, "Deletes the Jenkins X CRDs and cluster wide resources even if other teams are still installed in the cluster")
+	cmd.Flags().BoolVarP(&options.DeleteCluster, "delete-cluster", "", false, "Destroys the cluster via terraform once Jenkins X is uninstalled")
+	cmd.Flags().StringVarP(&options.TerraformDir, "terraform-dir", "", "", "The terraform directory the cluster was created from. Required when using --delete-cluster")
+	cmd.Flags().StringVarP(&options.ServiceAccount, "service-account", "", "", "The service account key file used by terraform to destroy the cluster")
 	return cmd
 }
 
@@ -98,8 +131,18 @@ func (o *UninstallOptions) Run() error {
 		}
 	}
 
+	if o.DeleteCluster && o.TerraformDir == "" {
+		return util.MissingOption("terraform-dir")
+	}
+
 	log.Infof("Removing installation of Jenkins X in team namespace %s\n", util.ColorInfo(namespace))
 
+	// lets find the webhook endpoint while the services still exist
+	webhookURL, err := o.GetWebHookEndpoint()
+	if err != nil {
+		webhookURL = ""
+	}
+
 	err = o.cleanupConfig()
 	if err != nil {
 		return err
@@ -139,6 +182,13 @@ func (o *UninstallOptions) Run() error {
 		}
 	}
 
+	o.findRemainingWebhooks(webhookURL, envNames, envMap)
+
+	otherTeams, teamsErr := o.otherTeams(namespace)
+	if teamsErr != nil {
+		errs = append(errs, fmt.Errorf("failed to find the other teams in the cluster: %s", teamsErr))
+	}
+
 	err = jxClient.JenkinsV1().Environments(namespace).DeleteCollection(&meta_v1.DeleteOptions{}, meta_v1.ListOptions{})
 	if err != nil {
 		errs = append(errs, fmt.Errorf("failed to delete the environments in namespace %s: %s", namespace, err))
@@ -147,6 +197,29 @@ func (o *UninstallOptions) Run() error {
 	if err != nil {
 		errs = append(errs, fmt.Errorf("failed to cleanup namespaces in namespace %s: %s", namespace, err))
 	}
+	if (len(otherTeams) > 0 || teamsErr != nil) && !o.DeleteCRDs {
+		log.Warnf("Keeping the Jenkins X CRDs and cluster wide resources as they are shared with the teams: %s. Use --delete-crds to remove them anyway\n",
+			strings.Join(otherTeams, ", "))
+	} else {
+		err = o.cleanupClusterResources()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to cleanup the cluster wide resources: %s", err))
+		}
+		if !o.KeepEnvironments {
+			err = o.cleanupCRDs()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete the Jenkins X CRDs: %s", err))
+			}
+		}
+	}
+	if o.DeleteCluster {
+		log.Infof("Destroying the cluster via terraform in %s\n", util.ColorInfo(o.TerraformDir))
+		err = terraform.Destroy(o.TerraformDir, filepath.Join(o.TerraformDir, "terraform.tfvars"), o.ServiceAccount, o.Out, o.Err)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to destroy the cluster via terraform in %s: %s", o.TerraformDir, err))
+		}
+	}
+	o.reportRemaining()
 	if len(errs) > 0 {
 		return util.CombineErrors(errs...)
 	}
@@ -154,6 +227,159 @@ func (o *UninstallOptions) Run() error {
 	return nil
 }
 
+// findRemainingWebhooks records the webhooks on the environment git repositories which call the installation
+// as they need to be removed via the git provider
+func (o *UninstallOptions) findRemainingWebhooks(webhookURL string, envNames []string, envMap map[string]*v1.Environment) {
+	if webhookURL == "" {
+		return
+	}
+	for _, name := range envNames {
+		env := envMap[name]
+		if env == nil || env.Spec.Source.URL == "" {
+			continue
+		}
+		gitURL := env.Spec.Source.URL
+		gitInfo, err := gits.ParseGitURL(gitURL)
+		if err != nil {
+			continue
+		}
+		provider, err := o.gitProviderForURL(gitURL, fmt.Sprintf("Environment %s", name))
+		if err != nil {
+			o.remaining = append(o.remaining, fmt.Sprintf("webhooks of %s", gitURL))
+			continue
+		}
+		hooks, err := provider.ListWebHooks(gitInfo.Organisation, gitInfo.Name)
+		if err != nil {
+			o.remaining = append(o.remaining, fmt.Sprintf("webhooks of %s", gitURL))
+			continue
+		}
+		for _, hook := range hooks {
+			if strings.HasPrefix(hook.URL, webhookURL) {
+				o.remaining = append(o.remaining, fmt.Sprintf("webhook %s on %s", hook.URL, gitURL))
+			}
+		}
+	}
+}
+
+// otherTeams returns the team namespaces in the cluster other than the given one
+func (o *UninstallOptions) otherTeams(namespace string) ([]string, error) {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting the kube client")
+	}
+	_, teams, err := kube.GetTeams(client)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing the team namespaces")
+	}
+	answer := []string{}
+	for _, team := range teams {
+		if team != namespace {
+			answer = append(answer, team)
+		}
+	}
+	return answer, nil
+}
+
+// cleanupClusterResources removes the resources created by Jenkins X outside of the team namespaces during the install
+func (o *UninstallOptions) cleanupClusterResources() error {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return errors.Wrap(err, "getting the kube client")
+	}
+	errs := []error{}
+	issuers := []string{kube.CertmanagerIssuerProd, kube.CertmanagerIssuerSelfSigned}
+	resources, err := client.Discovery().ServerResourcesForGroupVersion("certmanager.k8s.io/v1alpha1")
+	if err != nil || resources == nil {
+		// cert-manager is not installed
+		issuers = []string{}
+	}
+	for _, issuer := range issuers {
+		uri := "/apis/certmanager.k8s.io/v1alpha1/clusterissuers"
+		data, err := client.CoreV1().RESTClient().Get().RequestURI(uri).Name(issuer).DoRaw()
+		if err != nil {
+			continue
+		}
+		resource := struct {
+			Metadata meta_v1.ObjectMeta `json:"metadata"`
+		}{}
+		err = json.Unmarshal(data, &resource)
+		if err != nil || resource.Metadata.Labels[kube.LabelCreatedBy] != kube.ValueCreatedByJX {
+			o.remaining = append(o.remaining, fmt.Sprintf("ClusterIssuer %s which was not created by Jenkins X", issuer))
+			continue
+		}
+		log.Infof("deleting ClusterIssuer %s\n", util.ColorInfo(issuer))
+		_, err = client.CoreV1().RESTClient().Delete().RequestURI(uri).Name(issuer).DoRaw()
+		if err != nil {
+			o.remaining = append(o.remaining, fmt.Sprintf("ClusterIssuer %s", issuer))
+			errs = append(errs, errors.Wrapf(err, "deleting ClusterIssuer %s", issuer))
+		}
+	}
+
+	err = o.Helm().StatusRelease(externalDNSNamespace, externalDNSReleaseName)
+	if err == nil && !o.createdByJX(externalDNSNamespace, externalDNSReleaseName) {
+		o.remaining = append(o.remaining, fmt.Sprintf("helm release %s which was not installed by Jenkins X", externalDNSReleaseName))
+	} else if err == nil {
+		log.Infof("deleting helm release %s\n", util.ColorInfo(externalDNSReleaseName))
+		err = o.Helm().DeleteRelease(externalDNSNamespace, externalDNSReleaseName, true)
+		if err != nil {
+			o.remaining = append(o.remaining, fmt.Sprintf("helm release %s", externalDNSReleaseName))
+			errs = append(errs, errors.Wrapf(err, "deleting helm release %s", externalDNSReleaseName))
+		} else {
+			// the DNS zone may be shared with other services so lets leave it to the user
+			o.remaining = append(o.remaining, "DNS zone and records managed by external-dns")
+		}
+	}
+	return util.CombineErrors(errs...)
+}
+
+// createdByJX returns true if the pods of the deployment are labelled as created by Jenkins X
+func (o *UninstallOptions) createdByJX(namespace string, name string) bool {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return false
+	}
+	deployment, err := client.AppsV1beta1().Deployments(namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		return false
+	}
+	return deployment.Spec.Template.Labels[kube.LabelCreatedBy] == kube.ValueCreatedByJX
+}
+
+// cleanupCRDs deletes the Jenkins X custom resource definitions along with all of their resources
+func (o *UninstallOptions) cleanupCRDs() error {
+	apisClient, err := o.CreateApiExtensionsClient()
+	if err != nil {
+		return errors.Wrap(err, "creating the API extensions client")
+	}
+	crds, err := apisClient.ApiextensionsV1beta1().CustomResourceDefinitions().List(meta_v1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "listing the CRDs")
+	}
+	errs := []error{}
+	for _, crd := range crds.Items {
+		if crd.Spec.Group != jenkinsio.GroupName {
+			continue
+		}
+		log.Infof("deleting CRD %s\n", util.ColorInfo(crd.Name))
+		err = apisClient.ApiextensionsV1beta1().CustomResourceDefinitions().Delete(crd.Name, &meta_v1.DeleteOptions{})
+		if err != nil {
+			o.remaining = append(o.remaining, fmt.Sprintf("CRD %s", crd.Name))
+			errs = append(errs, errors.Wrapf(err, "deleting CRD %s", crd.Name))
+		}
+	}
+	return util.CombineErrors(errs...)
+}
+
+func (o *UninstallOptions) reportRemaining() {
+	if len(o.remaining) == 0 {
+		return
+	}
+	log.Warnf("\nThe following resources were not removed and need to be cleaned up manually:\n")
+	for _, r := range o.remaining {
+		log.Warnf("  %s\n", r)
+	}
+}
+
 func (o *UninstallOptions) cleanupNamespaces(namespace string, envNames []string, envMap map[string]*v1.Environment) error {
 	client, _, err := o.KubeClient()
 	if err != nil {
@@ -11,7 +11,9 @@ import (
 	kuber_mocks "github.com/jenkins-x/jx/pkg/kube/mocks"
 	"github.com/jenkins-x/jx/pkg/tests"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/api/core/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd/api"
 
@@ -184,3 +186,100 @@ func createNamespace(o *cmd.UninstallOptions, ns string) error {
 	})
 	return err
 }
+
+func TestUninstallOptions_Run_DeletesCRDsWithTheLastTeam(t *testing.T) {
+	o := setupUninstallTeams(t, "ns")
+
+	err := o.Run()
+	assert.NoError(t, err)
+
+	assertCRDs(t, o, "apps.certmanager.k8s.io")
+}
+
+func TestUninstallOptions_Run_KeepsCRDsWhenOtherTeamsRemain(t *testing.T) {
+	o := setupUninstallTeams(t, "ns", "other-team")
+
+	err := o.Run()
+	assert.NoError(t, err)
+
+	assertCRDs(t, o, "apps.certmanager.k8s.io", "environments.jenkins.io", "pipelineactivities.jenkins.io")
+}
+
+func TestUninstallOptions_Run_DeletesCRDsWhenOtherTeamsRemainAndDeleteCRDs(t *testing.T) {
+	o := setupUninstallTeams(t, "ns", "other-team")
+	o.DeleteCRDs = true
+
+	err := o.Run()
+	assert.NoError(t, err)
+
+	assertCRDs(t, o, "apps.certmanager.k8s.io")
+}
+
+func TestUninstallOptions_Run_KeepsCRDsWhenKeepingEnvironments(t *testing.T) {
+	o := setupUninstallTeams(t, "ns")
+	o.KeepEnvironments = true
+
+	err := o.Run()
+	assert.NoError(t, err)
+
+	assertCRDs(t, o, "apps.certmanager.k8s.io", "environments.jenkins.io", "pipelineactivities.jenkins.io")
+}
+
+// setupUninstallTeams creates the options to uninstall the first team of the given team namespaces
+// in a cluster without cert-manager which has the Jenkins X CRDs installed
+func setupUninstallTeams(t *testing.T, teams ...string) *cmd.UninstallOptions {
+	kubeMock := setupUninstall("correct-context-to-delete")
+
+	o := &cmd.UninstallOptions{
+		CommonOptions: cmd.CommonOptions{
+			Kuber: kubeMock,
+		},
+		Namespace: teams[0],
+		Force:     true,
+	}
+	cmd.ConfigureTestOptions(&o.CommonOptions, gits_test.NewMockGitter(), helm_test.NewMockHelmer())
+
+	for _, team := range teams {
+		_, err := o.KubeClientCached.CoreV1().Namespaces().Create(&v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: team,
+				Labels: map[string]string{
+					"env": "dev",
+				},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	apisClient, err := o.CreateApiExtensionsClient()
+	require.NoError(t, err)
+	crds := map[string]string{
+		"environments.jenkins.io":       "jenkins.io",
+		"pipelineactivities.jenkins.io": "jenkins.io",
+		"apps.certmanager.k8s.io":       "certmanager.k8s.io",
+	}
+	for name, group := range crds {
+		_, err = apisClient.ApiextensionsV1beta1().CustomResourceDefinitions().Create(&v1beta1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: v1beta1.CustomResourceDefinitionSpec{
+				Group: group,
+			},
+		})
+		require.NoError(t, err)
+	}
+	return o
+}
+
+func assertCRDs(t *testing.T, o *cmd.UninstallOptions, expected ...string) {
+	apisClient, err := o.CreateApiExtensionsClient()
+	require.NoError(t, err)
+	crds, err := apisClient.ApiextensionsV1beta1().CustomResourceDefinitions().List(metav1.ListOptions{})
+	require.NoError(t, err)
+	names := []string{}
+	for _, crd := range crds.Items {
+		names = append(names, crd.Name)
+	}
+	assert.ElementsMatch(t, expected, names)
+}
@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/jenkins-x/jx/pkg/gits"
-	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/pkg/errors"
@@ -13,8 +12,6 @@ import (
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
-	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // UpdateWebhooks the flags for running create cluster
@@ -25,6 +22,7 @@ type UpdateWebhooksOptions struct {
 	ExactHookMatch  bool
 	PreviousHookUrl string
 	DryRun          bool
+	CreateMissing   bool
 }
 
 var (
@@ -32,12 +30,24 @@ var (
 		
 		Updates the webhook for one repository, or all repositories in an organization.
 
+		Webhooks which are sent to an old endpoint, such as after the domain of the cluster has changed, or which
+		are missing the secret Prow requires are updated. Only the webhooks sent to a Jenkins X endpoint are updated.
+		Missing webhooks are created if --create-missing is specified on the given repository or, when updating
+		the whole organization, on the repositories which have been imported into the team.
+		Use 'jx get webhooks' to see which webhooks need repairing.
+
 `)
 
 	updateWebhooksExample = templates.Examples(`
 
 		jx update webhooks --org=mycorp
 
+		# see which webhooks would be repaired without changing them
+		jx update webhooks --org=mycorp --dry-run
+
+		# also create the webhook on the imported repositories of the organisation which do not have one
+		jx update webhooks --org=mycorp --create-missing
+
 `)
 )
 
@@ -61,6 +71,8 @@ func NewCmdUpdateWebhooks(f Factory, in terminal.FileReader, out terminal.FileWr
 	cmd.Flags().StringVarP(&options.Repo, "repo", "r", "", "The name of the repository to query")
 	cmd.Flags().BoolVarP(&options.ExactHookMatch, "exact-hook-url-match", "", true, "Whether to exactly match the hook based on the URL")
 	cmd.Flags().StringVarP(&options.PreviousHookUrl, "previous-hook-url", "", "", "Whether to match based on an another URL")
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Only log the webhooks which would be updated or created")
+	cmd.Flags().BoolVarP(&options.CreateMissing, "create-missing", "", false, "Creates the webhook on repositories which do not have one")
 
 	return cmd
 }
@@ -79,107 +91,93 @@ func createUpdateWebhooksOptions(f Factory, in terminal.FileReader, out terminal
 }
 
 func (options *UpdateWebhooksOptions) Run() error {
-	authConfigService, err := options.CreateGitAuthConfigService()
-	if err != nil {
-		return errors.Wrap(err, "failed to create git auth service")
-	}
-
-	_, _, err = options.KubeClient()
-	if err != nil {
-		return errors.Wrap(err, "failed to get kube client")
+	if options.Org == "" {
+		return util.MissingOption("org")
 	}
-
-	ns, _, err := kube.GetDevNamespace(options.KubeClientCached, options.currentNamespace)
+	matcher, git, err := options.createWebHookMatcher(options.PreviousHookUrl, options.ExactHookMatch)
 	if err != nil {
 		return err
 	}
-
-	webhookUrl, err := options.GetWebHookEndpoint()
+	secret, err := options.webHookSecret()
 	if err != nil {
 		return err
 	}
-
-	isProwEnabled, err := options.isProw()
+	repos, err := webHookRepositories(git, options.Org, options.Repo)
 	if err != nil {
 		return err
 	}
+	log.Infof("Found %v repos\n", util.ColorInfo(len(repos)))
 
-	hmacToken, err := options.KubeClientCached.CoreV1().Secrets(ns).Get("hmac-token", metav1.GetOptions{})
-	if err != nil {
-		return err
-	}
-
-	gitServer := authConfigService.Config().CurrentServer
-
-	git, err := options.gitProviderForGitServerURL(gitServer, "github")
-	if err != nil {
-		return errors.Wrap(err, "unable to determine git provider")
-	}
-
-	if options.Repo != "" {
-		options.updateRepoHook(git, options.Repo, webhookUrl, isProwEnabled, hmacToken)
-	} else {
-		repositories, err := git.ListRepositories(options.Org)
+	// only create the missing webhooks of the whole organisation on the repositories imported into the team
+	var imported map[string]bool
+	if options.CreateMissing && options.Repo == "" {
+		jxClient, ns, err := options.JXClientAndDevNamespace()
 		if err != nil {
-			return errors.Wrap(err, "unable to list repositories")
+			return err
 		}
-
-		log.Infof("Found %v repos\n", util.ColorInfo(len(repositories)))
-
-		for _, repo := range repositories {
-			options.updateRepoHook(git, repo.Name, webhookUrl, isProwEnabled, hmacToken)
+		imported, err = teamRepositories(jxClient, ns, options.Org)
+		if err != nil {
+			return err
 		}
 	}
 
-	return nil
+	errs := []error{}
+	for _, repo := range repos {
+		err = options.updateRepoHook(git, matcher, repo, secret, imported)
+		if err != nil {
+			log.Warnf("%s\n", err)
+			errs = append(errs, err)
+		}
+	}
+	return util.CombineErrors(errs...)
 }
 
-func (options *UpdateWebhooksOptions) updateRepoHook(git gits.GitProvider, repoName string, webhookURL string, isProwEnabled bool, hmacToken *corev1.Secret) error {
+// updateRepoHook updates the stale webhooks of the repository and creates the missing one if requested. The missing
+// webhook is only created if the repository is one of the imported ones unless they are nil
+func (options *UpdateWebhooksOptions) updateRepoHook(git gits.GitProvider, matcher *WebHookMatcher, repoName string, secret string, imported map[string]bool) error {
 	webhooks, err := git.ListWebHooks(options.Org, repoName)
 	if err != nil {
-		return errors.Wrap(err, "unable to list webhooks")
+		return errors.Wrapf(err, "unable to list the webhooks of %s/%s", options.Org, repoName)
 	}
 
 	log.Infof("Checking hooks for repository %s\n", util.ColorInfo(repoName))
 
-	if len(webhooks) > 0 {
-		// find matching hook
-		for _, webHook := range webhooks {
-			if options.matches(webhookURL, webHook) {
-				log.Infof("Found matching hook for url %s\n", util.ColorInfo(webHook.URL))
-
-				// update
-				webHookArgs := &gits.GitWebHookArguments{
-					Owner: options.Org,
-					Repo: &gits.GitRepository{
-						Name: repoName,
-					},
-					URL: webhookURL,
-				}
-
-				if isProwEnabled {
-					webHookArgs.Secret = string(hmacToken.Data["hmac"])
+	for _, status := range matcher.CheckWebHooks(options.Org, repoName, webhooks) {
+		webHookArgs := &gits.GitWebHookArguments{
+			ID:    status.ID,
+			Owner: options.Org,
+			Repo: &gits.GitRepository{
+				Name:         repoName,
+				Organisation: options.Org,
+			},
+			URL:    matcher.Endpoint,
+			Secret: secret,
+		}
+		switch status.Status {
+		case WebHookStatusStale, WebHookStatusNoSecret:
+			log.Infof("Updating the %s hook %s to %s\n", strings.ToLower(status.Status), util.ColorInfo(status.URL), util.ColorInfo(matcher.Endpoint))
+			if !options.DryRun {
+				err = git.UpdateWebHook(webHookArgs)
+				if err != nil {
+					return errors.Wrapf(err, "failed to update the webhook of %s/%s", options.Org, repoName)
 				}
-
-				log.Infof("Updating WebHook with new args\n")
-
-				if !options.DryRun {
-					git.UpdateWebHook(webHookArgs)
+			}
+		case WebHookStatusMissing:
+			if !options.CreateMissing {
+				continue
+			}
+			if imported != nil && !imported[strings.ToLower(repoName)] {
+				log.Infof("Not creating the missing hook as repository %s has not been imported\n", util.ColorInfo(repoName))
+				continue
+			}
+			log.Infof("Creating the missing hook %s\n", util.ColorInfo(matcher.Endpoint))
+			if !options.DryRun {
+				err = git.CreateWebHook(webHookArgs)
+				if err != nil {
+					return errors.Wrapf(err, "failed to create the webhook of %s/%s", options.Org, repoName)
 				}
 			}
 		}
 	}
 	return nil
 }
-
-func (options *UpdateWebhooksOptions) matches(webhookURL string, webHookArgs *gits.GitWebHookArguments) bool {
-	if "" != options.PreviousHookUrl {
-		return options.PreviousHookUrl == webHookArgs.URL
-	}
-
-	if options.ExactHookMatch {
-		return webhookURL == webHookArgs.URL
-	} else {
-		return strings.Contains(webHookArgs.URL, "hook.jx")
-	}
-}
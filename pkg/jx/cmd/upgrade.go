@@ -63,6 +63,7 @@ func NewCmdUpgrade(f Factory, in terminal.FileReader, out terminal.FileWriter, e
 	cmd.AddCommand(NewCmdUpgradePlatform(f, in, out, errOut))
 	cmd.AddCommand(NewCmdUpgradeExtensions(f, in, out, errOut))
 	cmd.AddCommand(NewCmdUpgradeApps(f, in, out, errOut))
+	cmd.AddCommand(NewCmdUpgradeVersions(f, in, out, errOut))
 	return cmd
 }
 
@@ -36,6 +36,7 @@ type UpgradeAddonsOptions struct {
 
 	Namespace string
 	Set       string
+	Version   string
 
 	InstallFlags InstallFlags
 }
@@ -68,6 +69,7 @@ func NewCmdUpgradeAddons(f Factory, in terminal.FileReader, out terminal.FileWri
 	}
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "", "", "The Namespace to promote to")
 	cmd.Flags().StringVarP(&options.Set, "set", "s", "", "The Helm parameters to pass in while upgrading")
+	cmd.Flags().StringVarP(&options.Version, "version", "", "", "The version of the chart to upgrade the addon to. Defaults to the latest version")
 
 	options.addCommonFlags(cmd)
 	options.InstallFlags.addCloudEnvOptions(cmd)
@@ -77,6 +79,9 @@ func NewCmdUpgradeAddons(f Factory, in terminal.FileReader, out terminal.FileWri
 
 // Run implements the command
 func (o *UpgradeAddonsOptions) Run() error {
+	if o.Version != "" && len(o.Args) != 1 {
+		return fmt.Errorf("the --version option requires the name of the addon to upgrade")
+	}
 	err := o.Helm().UpdateRepo()
 	if err != nil {
 		return err
@@ -109,22 +114,28 @@ func (o *UpgradeAddonsOptions) Run() error {
 		log.Warnf("Failed to find Helm installs: %s\n", err)
 	}
 
-	charts := kube.AddonCharts
+	registry, err := o.addonRegistry()
+	if err != nil {
+		return err
+	}
+	teamSettings, err := o.TeamSettings()
+	if err != nil {
+		return err
+	}
 	keys := []string{}
 	if len(o.Args) > 0 {
 		for _, k := range o.Args {
-			chart := charts[k]
-			if chart == "" {
-				return errors.Wrapf(err, "failed to match addon %s", k)
+			if registry.Get(k) == nil {
+				return util.InvalidArg(k, registry.Names())
 			}
 			keys = append(keys, k)
 		}
 	} else {
-		keys = util.SortedMapKeys(charts)
+		keys = registry.Names()
 	}
 
 	for _, k := range keys {
-		chart := charts[k]
+		chart := registry.Get(k).Chart
 		status := statusMap[k].Status
 		name := k
 		if name == k {
@@ -139,7 +150,29 @@ func (o *UpgradeAddonsOptions) Run() error {
 				return errors.Wrap(err, "failed to append the myvalues.yaml file")
 			}
 
+			// lets preserve the values the addon was installed with and upgrade it to the latest version unless one is given
 			values := []string{}
+			settings := teamSettings.Addon(k)
+			if settings != nil {
+				values, err = o.addonSetValues(settings)
+				if err != nil {
+					return err
+				}
+			}
+			if chart == kube.ChartKubePrometheusStack {
+				if helm.DetectVersion(o.Helm().HelmBinary()) != helm.V3 {
+					log.Warnf("Skipping %s as the %s chart requires Helm 3\n", name, chart)
+					continue
+				}
+				err = o.addHelmRepoIfMissing(prometheusRepoUrl, prometheusRepoName)
+				if err != nil {
+					return errors.Wrapf(err, "failed to add the %s helm repository", prometheusRepoName)
+				}
+			}
+			var version *string
+			if o.Version != "" {
+				version = &o.Version
+			}
 			if o.Set != "" {
 				values = append(values, o.Set)
 			}
@@ -150,9 +183,17 @@ func (o *UpgradeAddonsOptions) Run() error {
 				// lets backup any Prow config as we should never replace this, eventually we'll move config to a git repo so this is temporary
 				config, plugins = o.backupConfigs()
 			}
-			err = o.Helm().UpgradeChart(chart, k, ns, nil, false, nil, false, false, values, valueFiles, "", "", "")
+			err = o.Helm().UpgradeChart(chart, k, ns, version, false, nil, false, false, values, valueFiles, "", "", "")
 			if err != nil {
 				log.Warnf("Failed to upgrade %s chart %s: %v\n", name, chart, err)
+			} else if settings != nil {
+				upgraded := *settings
+				upgraded.Version = o.Version
+				upgraded.SetValues = values
+				err = o.recordAddon(upgraded)
+				if err != nil {
+					log.Warnf("Failed to record the version of the addon %s in the team settings: %s\n", k, err)
+				}
 			}
 
 			if k == kube.DefaultProwReleaseName {
@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
 	"runtime"
+	"sort"
+	"strings"
 
+	"github.com/blang/semver"
+	"github.com/google/go-github/github"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
@@ -13,14 +18,32 @@ import (
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 )
 
+const (
+	// upgradeChannelStable upgrades to the jx version of the version stream or the latest full release
+	upgradeChannelStable = "stable"
+	// upgradeChannelLatest upgrades to the most recent release including pre-releases
+	upgradeChannelLatest = "latest"
+)
+
 var (
+	upgradeChannels = []string{upgradeChannelStable, upgradeChannelLatest}
+
 	upgradeCLILong = templates.LongDesc(`
-		Upgrades the Jenkins X command line tools if there is a newer release
+		Upgrades the Jenkins X command line tools to the release of the given channel or to a specific version.
+
+		The checksum of the release is verified and the current binary is replaced atomically. The changes since the
+		current version are printed once the upgrade completes.
 `)
 
 	upgradeCLIExample = templates.Examples(`
 		# Upgrades the Jenkins X CLI tools 
 		jx upgrade cli
+
+		# Upgrades to the most recent release including pre-releases
+		jx upgrade cli --channel latest
+
+		# Upgrades or downgrades the Jenkins X CLI to a specific version
+		jx upgrade cli --version 1.3.500
 	`)
 )
 
@@ -29,6 +52,7 @@ type UpgradeCLIOptions struct {
 	CreateOptions
 
 	Version string
+	Channel string
 }
 
 // NewCmdUpgradeCLI defines the command
@@ -58,7 +82,9 @@ func NewCmdUpgradeCLI(f Factory, in terminal.FileReader, out terminal.FileWriter
 		},
 	}
 	cmd.Flags().StringVarP(&options.Version, "version", "v", "", "The specific version to upgrade to")
+	cmd.Flags().StringVarP(&options.Channel, "channel", "", upgradeChannelStable, fmt.Sprintf("The release channel to upgrade from which is one of: %s", strings.Join(upgradeChannels, ", ")))
 	options.addCommonFlags(cmd)
+	options.addSkipVerifyFlags(cmd)
 	return cmd
 }
 
@@ -66,11 +92,14 @@ func NewCmdUpgradeCLI(f Factory, in terminal.FileReader, out terminal.FileWriter
 func (o *UpgradeCLIOptions) Run() error {
 	log.ConfigureLog(o.LogLevel)
 
-	newVersion, err := o.GetLatestJXVersion()
+	newVersion, err := o.resolveVersion()
 	if err != nil {
 		return err
 	}
-	logger.Debugf("Found the latest version of jx: %s", util.ColorInfo(newVersion))
+	if o.Version != "" {
+		// brew can only upgrade to its latest version
+		o.NoBrew = true
+	}
 
 	currentVersion, err := version.GetSemverVersion()
 	if err != nil {
@@ -81,14 +110,115 @@ func (o *UpgradeCLIOptions) Run() error {
 		logger.Infof("You are already on the latest version of jx %s", util.ColorInfo(currentVersion.String()))
 		return nil
 	}
-	if newVersion.LE(currentVersion) {
+	if newVersion.LE(currentVersion) && o.Version == "" {
 		logger.Infof("Your jx version %s is actually newer than the latest available version %s", util.ColorInfo(currentVersion.String()), util.ColorInfo(newVersion.String()))
 		return nil
 	}
 
 	if runtime.GOOS == "darwin" && !o.NoBrew {
-		return o.RunCommand("brew", "upgrade", "jx")
+		err = o.RunCommand("brew", "upgrade", "jx")
 	} else {
-		return o.installJx(true, newVersion.String())
+		err = o.installJx(true, newVersion.String())
+	}
+	if err != nil {
+		return err
+	}
+	o.printChangelog(currentVersion, newVersion)
+	return nil
+}
+
+// resolveVersion returns the version to upgrade to from the version option or the release channel
+func (o *UpgradeCLIOptions) resolveVersion() (semver.Version, error) {
+	if o.Version != "" {
+		newVersion, err := semver.ParseTolerant(o.Version)
+		if err != nil {
+			return newVersion, util.InvalidOptionError("version", o.Version, err)
+		}
+		return newVersion, nil
+	}
+	switch o.Channel {
+	case upgradeChannelStable, "":
+		pinned := o.versionStream().ToolVersion("jx")
+		if pinned != "" {
+			logger.Debugf("Using the jx version %s from the version stream", util.ColorInfo(pinned))
+			return semver.ParseTolerant(pinned)
+		}
+		newVersion, err := o.GetLatestJXVersion()
+		if err != nil {
+			return newVersion, err
+		}
+		logger.Debugf("Found the latest version of jx: %s", util.ColorInfo(newVersion))
+		return newVersion, nil
+	case upgradeChannelLatest:
+		releases, err := util.GetReleasesFromGitHub("jenkins-x", "jx")
+		if err != nil {
+			return semver.Version{}, err
+		}
+		versions := releaseVersions(releases)
+		if len(versions) == 0 {
+			return semver.Version{}, fmt.Errorf("no releases found for github.com/jenkins-x/jx")
+		}
+		return versions[0].version, nil
+	default:
+		return semver.Version{}, util.InvalidOption("channel", o.Channel, upgradeChannels)
+	}
+}
+
+// printChangelog prints the release notes of the releases after the previous version up to the new version
+func (o *UpgradeCLIOptions) printChangelog(previous semver.Version, current semver.Version) {
+	if current.LT(previous) {
+		return
+	}
+	releases, err := util.GetReleasesFromGitHub("jenkins-x", "jx")
+	if err != nil {
+		log.Warnf("Failed to load the changelog: %s\n", err)
+		return
+	}
+	changes := changelogReleases(releaseVersions(releases), previous, current)
+	if len(changes) == 0 {
+		return
+	}
+	log.Infof("\nChanges since %s:\n", util.ColorInfo(previous.String()))
+	for _, r := range changes {
+		log.Infof("\n%s\n", util.ColorInfo(r.version.String()))
+		if r.release.Body != nil && strings.TrimSpace(*r.release.Body) != "" {
+			log.Infof("%s\n", strings.TrimSpace(*r.release.Body))
+		}
+	}
+}
+
+// versionedRelease a github release and its parsed version
+type versionedRelease struct {
+	version semver.Version
+	release *github.RepositoryRelease
+}
+
+// releaseVersions returns the releases whose tag is a valid version, newest version first
+func releaseVersions(releases []*github.RepositoryRelease) []versionedRelease {
+	answer := []versionedRelease{}
+	for _, release := range releases {
+		if release.TagName == nil || (release.Draft != nil && *release.Draft) {
+			continue
+		}
+		v, err := semver.ParseTolerant(*release.TagName)
+		if err != nil {
+			continue
+		}
+		answer = append(answer, versionedRelease{version: v, release: release})
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].version.GT(answer[j].version)
+	})
+	return answer
+}
+
+// changelogReleases returns the releases newer than the previous version up to and including the current version
+func changelogReleases(releases []versionedRelease, previous semver.Version, current semver.Version) []versionedRelease {
+	answer := []versionedRelease{}
+	for _, r := range releases {
+		if r.version.GT(previous) && r.version.LE(current) {
+			answer = append(answer, r)
+		}
 	}
+	return answer
 }
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/google/go-github/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangelogReleases(t *testing.T) {
+	t.Parallel()
+	release := func(tag string, draft bool) *github.RepositoryRelease {
+		return &github.RepositoryRelease{
+			TagName: &tag,
+			Draft:   &draft,
+		}
+	}
+	releases := releaseVersions([]*github.RepositoryRelease{
+		release("v1.3.502", false),
+		release("v1.3.500", false),
+		release("v1.3.503", true),
+		release("not-a-version", false),
+		release("v1.3.501", false),
+		release("v1.3.499", false),
+	})
+	versions := []string{}
+	for _, r := range releases {
+		versions = append(versions, r.version.String())
+	}
+	assert.Equal(t, []string{"1.3.502", "1.3.501", "1.3.500", "1.3.499"}, versions)
+
+	changes := changelogReleases(releases, semver.MustParse("1.3.499"), semver.MustParse("1.3.501"))
+	versions = []string{}
+	for _, r := range changes {
+		versions = append(versions, r.version.String())
+	}
+	assert.Equal(t, []string{"1.3.501", "1.3.500"}, versions)
+}
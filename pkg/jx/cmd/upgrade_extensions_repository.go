@@ -267,8 +267,8 @@ func (o *UpgradeExtensionsRepositoryOptions) walkRemote(remote string, tag strin
 			if oldSemanticVersion.LT(newSemanticVersion) || tag == "latest" {
 				var script string
 				children := make([]string, 0)
-				// If the children is present, there is no script
-				if len(ed.Children) == 0 {
+				// If the children is present, there is no script. Extensions running in an image may not have a script
+				if len(ed.Children) == 0 && (ed.Image == "" || ed.ScriptFile != "") {
 					scriptFile := ed.ScriptFile
 					if scriptFile == "" {
 						scriptFile = fmt.Sprintf("%s.sh", strings.ToLower(strcase.SnakeCase(ed.Name)))
@@ -289,7 +289,7 @@ func (o *UpgradeExtensionsRepositoryOptions) walkRemote(remote string, tag strin
 					if err != nil {
 						return result, err
 					}
-				} else {
+				} else if len(ed.Children) > 0 {
 					for _, c := range ed.Children {
 						if c.UUID != "" {
 							children = append(children, c.UUID)
@@ -315,6 +315,7 @@ func (o *UpgradeExtensionsRepositoryOptions) walkRemote(remote string, tag strin
 					When:        ed.When,
 					Given:       ed.Given,
 					Script:      strings.TrimSuffix(script, "\n"),
+					Image:       ed.Image,
 					Children:    children,
 				}
 				if o.Verbose {
@@ -23,11 +23,21 @@ import (
 var (
 	upgradeIngressLong = templates.LongDesc(`
 		Upgrades the Jenkins X Ingress rules
+
+		The domain, HTTP or HTTPS and whether services are exposed on subdomains or paths of the domain can be
+		changed without reinstalling Jenkins X. All the exposed services are then exposed again and the webhooks
+		are updated to the new URLs.
 `)
 
 	upgradeIngressExample = templates.Examples(`
 		# Upgrades the Jenkins X Ingress rules
 		jx upgrade ingress
+
+		# Changes the domain of the whole cluster and switches to HTTPS
+		jx upgrade ingress --cluster --domain jx.example.com --tls --email admin@example.com
+
+		# Exposes the services of the current namespace on paths of the domain rather than on subdomains
+		jx upgrade ingress --path-mode
 	`)
 )
 
@@ -48,6 +58,10 @@ type UpgradeIngressOptions struct {
 	TargetNamespaces    []string
 	Services            []string
 	SkipResourcesUpdate bool
+	Domain              string
+	TLS                 bool
+	Email               string
+	PathMode            bool
 
 	IngressConfig kube.IngressConfig
 }
@@ -92,6 +106,10 @@ func (o *UpgradeIngressOptions) addFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(&o.SkipCertManager, "skip-certmanager", "", false, "Skips certmanager installation")
 	cmd.Flags().StringArrayVarP(&o.Services, "services", "", []string{}, "Services to upgrdde")
 	cmd.Flags().BoolVarP(&o.SkipResourcesUpdate, "skip-resources-update", "", false, "Skips the update of jx related resources such as webhook or Jenkins URL")
+	cmd.Flags().StringVarP(&o.Domain, "domain", "", "", "The domain to expose the services on. Defaults to the current domain")
+	cmd.Flags().BoolVarP(&o.TLS, "tls", "", false, "Exposes the services using HTTPS with certificates from LetsEncrypt. Use --tls=false to switch to HTTP")
+	cmd.Flags().StringVarP(&o.Email, "email", "", "", "The email address to register with LetsEncrypt")
+	cmd.Flags().BoolVarP(&o.PathMode, "path-mode", "", false, "Exposes the services on paths of the domain rather than on subdomains. Use --path-mode=false to switch to subdomains")
 }
 
 // Run implements the command
@@ -310,7 +328,7 @@ func (o *UpgradeIngressOptions) getExistingIngressRules() (map[string]string, er
 		o.TargetNamespaces = append(o.TargetNamespaces, o.currentNamespace)
 	}
 
-	if len(existingIngressNames) == 0 {
+	if len(existingIngressNames) == 0 || o.BatchMode {
 		return existingIngressNames, nil
 	}
 
@@ -342,6 +360,10 @@ func (o *UpgradeIngressOptions) confirmExposecontrollerConfig() error {
 	if err != nil {
 		// carry on as it just means we dont have any defaults
 	}
+	o.applyIngressFlags()
+	if o.BatchMode {
+		return o.defaultIngressConfig()
+	}
 
 	o.IngressConfig.Exposer, err = util.PickNameWithDefault([]string{"Ingress", "Route"}, "Expose type", o.IngressConfig.Exposer, "", o.In, o.Out, o.Err)
 	if err != nil {
@@ -353,41 +375,99 @@ func (o *UpgradeIngressOptions) confirmExposecontrollerConfig() error {
 		return err
 	}
 
-	if !strings.HasSuffix(o.IngressConfig.Domain, "nip.io") {
+	if o.IngressConfig.Exposer == "Ingress" && !o.flagChanged("path-mode") {
+		pathMode := util.Confirm("Would you like to expose services on paths of the domain rather than on subdomains?",
+			o.IngressConfig.PathMode == kube.PathModePath, "Exposes services as http://domain/namespace/service rather than http://service.namespace.domain", o.In, o.Out, o.Err)
+		o.setPathMode(pathMode)
+	}
+
+	if !strings.HasSuffix(o.IngressConfig.Domain, "nip.io") && !o.flagChanged("tls") {
 
 		o.IngressConfig.TLS = util.Confirm("If your network is publicly available would you like to enable cluster wide TLS?", true, "Enables cert-manager and configures TLS with signed certificates from LetsEncrypt", o.In, o.Out, o.Err)
 
-		if o.IngressConfig.TLS {
-			log.Infof("If testing LetsEncrypt you should use staging as you may be rate limited using production.")
-			clusterIssuer, err := util.PickNameWithDefault([]string{"staging", "production"}, "Use LetsEncrypt staging or production?", "production", "", o.In, o.Out, o.Err)
-			// if the cluster issuer is production the string needed by letsencrypt is prod
-			if clusterIssuer == "production" {
-				clusterIssuer = "prod"
-			}
+	}
+	if o.IngressConfig.TLS {
+		log.Infof("If testing LetsEncrypt you should use staging as you may be rate limited using production.")
+		clusterIssuer, err := util.PickNameWithDefault([]string{"staging", "production"}, "Use LetsEncrypt staging or production?", "production", "", o.In, o.Out, o.Err)
+		// if the cluster issuer is production the string needed by letsencrypt is prod
+		if clusterIssuer == "production" {
+			clusterIssuer = "prod"
+		}
+		if err != nil {
+			return err
+		}
+		o.IngressConfig.Issuer = "letsencrypt-" + clusterIssuer
+
+		if o.IngressConfig.Email == "" {
+			email1, err := o.getCommandOutput("", "git", "config", "user.email")
 			if err != nil {
 				return err
 			}
-			o.IngressConfig.Issuer = "letsencrypt-" + clusterIssuer
 
-			if o.IngressConfig.Email == "" {
-				email1, err := o.getCommandOutput("", "git", "config", "user.email")
-				if err != nil {
-					return err
-				}
+			o.IngressConfig.Email = strings.TrimSpace(email1)
+		}
 
-				o.IngressConfig.Email = strings.TrimSpace(email1)
-			}
+		o.IngressConfig.Email, err = util.PickValue("Email address to register with LetsEncrypt:", o.IngressConfig.Email, true, "", o.In, o.Out, o.Err)
+		if err != nil {
+			return err
+		}
+	}
 
-			o.IngressConfig.Email, err = util.PickValue("Email address to register with LetsEncrypt:", o.IngressConfig.Email, true, "", o.In, o.Out, o.Err)
-			if err != nil {
-				return err
+	return nil
+
+}
+
+// applyIngressFlags overrides the current ingress config with the values of the command line flags
+func (o *UpgradeIngressOptions) applyIngressFlags() {
+	if o.Domain != "" {
+		o.IngressConfig.Domain = o.Domain
+	}
+	if o.Email != "" {
+		o.IngressConfig.Email = o.Email
+	}
+	if o.flagChanged("tls") {
+		o.IngressConfig.TLS = o.TLS
+	}
+	if o.flagChanged("path-mode") {
+		o.setPathMode(o.PathMode)
+	}
+}
+
+// defaultIngressConfig fills in the ingress config values which have not been specified when in batch mode
+func (o *UpgradeIngressOptions) defaultIngressConfig() error {
+	if o.IngressConfig.Exposer == "" {
+		o.IngressConfig.Exposer = "Ingress"
+	}
+	if o.IngressConfig.Domain == "" {
+		return util.MissingOption("domain")
+	}
+	if o.IngressConfig.TLS {
+		if o.IngressConfig.Issuer == "" {
+			o.IngressConfig.Issuer = kube.CertmanagerIssuerProd
+		}
+		if o.IngressConfig.Email == "" {
+			email, err := o.getCommandOutput("", "git", "config", "user.email")
+			if err != nil || strings.TrimSpace(email) == "" {
+				return util.MissingOption("email")
 			}
+			o.IngressConfig.Email = strings.TrimSpace(email)
 		}
 	}
-
 	return nil
+}
 
+func (o *UpgradeIngressOptions) setPathMode(pathMode bool) {
+	if pathMode {
+		o.IngressConfig.PathMode = kube.PathModePath
+	} else {
+		o.IngressConfig.PathMode = ""
+	}
 }
+
+func (o *UpgradeIngressOptions) flagChanged(name string) bool {
+	return o.Cmd != nil && o.Cmd.Flags().Changed(name)
+}
+
 func (o *UpgradeIngressOptions) recreateIngressRules() error {
 	devNamespace, _, err := kube.GetDevNamespace(o.KubeClientCached, o.currentNamespace)
 	if err != nil {
@@ -16,9 +16,11 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/helm"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/table"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -29,11 +31,17 @@ import (
 var (
 	upgrade_platform_long = templates.LongDesc(`
 		Upgrades the Jenkins X platform if there is a newer release
+
+		If the platform was installed via 'jx install --gitops' a Pull Request is created on the development
+		environment git repository instead so that the upgrade is reviewed before the pipeline applies it.
 `)
 
 	upgrade_platform_example = templates.Examples(`
 		# Upgrades the Jenkins X platform 
 		jx upgrade platform
+
+		# Upgrades the Jenkins X platform rolling back if it is not healthy afterwards
+		jx upgrade platform --rollback
 	`)
 )
 
@@ -47,8 +55,12 @@ type UpgradePlatformOptions struct {
 	Namespace     string
 	Set           string
 	AlwaysUpgrade bool
+	Rollback      bool
 
 	InstallFlags InstallFlags
+
+	// for testing
+	FakePullRequests CreateEnvPullRequestFn
 }
 
 // NewCmdUpgradePlatform defines the command
@@ -83,6 +95,7 @@ func NewCmdUpgradePlatform(f Factory, in terminal.FileReader, out terminal.FileW
 	cmd.Flags().StringVarP(&options.Version, "version", "v", "", "The specific platform version to upgrade to")
 	cmd.Flags().StringVarP(&options.Set, "set", "s", "", "The helm parameters to pass in while upgrading")
 	cmd.Flags().BoolVarP(&options.AlwaysUpgrade, "always-upgrade", "", false, "If set to true, jx will upgrade platform Helm chart even if requested version is already installed.")
+	cmd.Flags().BoolVarP(&options.Rollback, "rollback", "", false, "Rolls back to the previous release if the platform is not healthy after the upgrade")
 	cmd.Flags().BoolVarP(&options.Flags.CleanupTempFiles, "cleanup-temp-files", "", true, "Cleans up any temporary values.yaml used by helm install [default true]")
 
 	options.addCommonFlags(cmd)
@@ -162,6 +175,10 @@ func (o *UpgradePlatformOptions) Run() error {
 		}
 	}
 
+	if settings.UseGitOps {
+		return o.upgradePlatformViaGitOps(ns, targetVersion)
+	}
+
 	// Current version
 	var currentVersion string
 	output, err := o.Helm().ListCharts()
@@ -234,6 +251,15 @@ func (o *UpgradePlatformOptions) Run() error {
 		return nil
 	}
 
+	err = o.showVersionDiff(currentVersion, targetVersion)
+	if err != nil {
+		log.Warnf("Failed to compare the platform versions: %s\n", err)
+	}
+	if !o.BatchMode && !util.Confirm(fmt.Sprintf("Upgrade the platform from %s to %s?", currentVersion, targetVersion), true,
+		"Upgrades the Jenkins X platform helm chart", o.In, o.Out, o.Err) {
+		return nil
+	}
+
 	cloudEnvironmentValuesLocation := filepath.Join(makefileDir, CloudEnvValuesFile)
 	cloudEnvironmentSecretsLocation := filepath.Join(makefileDir, CloudEnvSecretsFile)
 	cloudEnvironmentSopsLocation := filepath.Join(makefileDir, CloudEnvSopsConfigFile)
@@ -308,6 +334,26 @@ func (o *UpgradePlatformOptions) Run() error {
 		return errors.Wrap(err, "unable to upgrade helm chart")
 	}
 
+	err = o.waitForInstallToBeReady(ns)
+	if err != nil {
+		if !o.Rollback {
+			return errors.Wrapf(err, "the platform is not healthy after the upgrade, you can roll back via: helm rollback %s 0", o.ReleaseName)
+		}
+		log.Warnf("The platform is not healthy after the upgrade so rolling back to version %s\n", currentVersion)
+		rollbackErr := o.Helm().RollbackRelease(ns, o.ReleaseName, 0)
+		if rollbackErr != nil {
+			return errors.Wrapf(rollbackErr, "rolling back release %s after failed upgrade: %s", o.ReleaseName, err)
+		}
+		return errors.Wrapf(err, "rolled back to version %s as the upgrade to %s was not healthy", currentVersion, targetVersion)
+	}
+	log.Successf("Upgraded the platform from %s to %s", currentVersion, targetVersion)
+
+	// the upgrade replaces the pod templates so lets merge the customisations of the team back in
+	err = kube.ApplyPodTemplateCustomisations(o.KubeClientCached, ns)
+	if err != nil {
+		log.Warnf("Failed to apply the customisations of the pod templates: %s\n", err)
+	}
+
 	if o.Flags.CleanupTempFiles {
 		if !configFileNameExists {
 			err = os.Remove(configFileName)
@@ -319,3 +365,95 @@ func (o *UpgradePlatformOptions) Run() error {
 
 	return nil
 }
+
+// showVersionDiff prints the chart dependencies which change version between the current and target platform versions
+func (o *UpgradePlatformOptions) showVersionDiff(currentVersion string, targetVersion string) error {
+	dir, err := ioutil.TempDir("", "jx-upgrade-platform-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	requirements := []*helm.Requirements{}
+	for _, version := range []string{currentVersion, targetVersion} {
+		versionDir := filepath.Join(dir, version)
+		err = os.MkdirAll(versionDir, DefaultWritePermissions)
+		if err != nil {
+			return err
+		}
+		v := version
+		err = o.Helm().FetchChart(o.Chart, &v, true, versionDir, "", "", "")
+		if err != nil {
+			return errors.Wrapf(err, "fetching chart %s version %s", o.Chart, version)
+		}
+		chartName := o.Chart[strings.LastIndex(o.Chart, "/")+1:]
+		r, err := helm.LoadRequirementsFile(filepath.Join(versionDir, chartName, helm.RequirementsFileName))
+		if err != nil {
+			return err
+		}
+		requirements = append(requirements, r)
+	}
+
+	changes := helm.DiffRequirements(requirements[0], requirements[1])
+	if len(changes) == 0 {
+		log.Infof("No chart versions change between %s and %s\n", currentVersion, targetVersion)
+		return nil
+	}
+	t := table.CreateTable(o.Out)
+	t.AddRow("CHART", currentVersion, targetVersion)
+	for _, c := range changes {
+		t.AddRow(c.Name, c.FromVersion, util.ColorInfo(c.ToVersion))
+	}
+	t.Render()
+	return nil
+}
+
+// upgradePlatformViaGitOps creates a Pull Request on the git repository of the development environment which changes
+// the platform version. The environment pipeline applies the upgrade once the Pull Request is merged
+func (o *UpgradePlatformOptions) upgradePlatformViaGitOps(ns string, targetVersion string) error {
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return errors.Wrap(err, "failed to create the jx client")
+	}
+	devEnv, err := kube.GetDevEnvironment(jxClient, ns)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find the development environment in namespace %s", ns)
+	}
+	if devEnv == nil || devEnv.Spec.Source.URL == "" {
+		return fmt.Errorf("the development environment in namespace %s has no git repository. Was it installed via: jx install --gitops", ns)
+	}
+
+	currentVersion := ""
+	modifyRequirementsFn := func(requirements *helm.Requirements) error {
+		for _, dep := range requirements.Dependencies {
+			if dep != nil && dep.Name == JenkinsXPlatformChartName {
+				currentVersion = dep.Version
+				dep.Version = targetVersion
+				return nil
+			}
+		}
+		return fmt.Errorf("no %s dependency found in the development environment requirements", JenkinsXPlatformChartName)
+	}
+	branchName := fmt.Sprintf("upgrade-platform-%s", targetVersion)
+	title := fmt.Sprintf("Upgrade %s to %s", JenkinsXPlatformChartName, targetVersion)
+	message := fmt.Sprintf("Upgrades the Jenkins X platform to version %s", targetVersion)
+	var info *gits.PullRequestInfo
+	if o.FakePullRequests != nil {
+		info, err = o.FakePullRequests(devEnv, modifyRequirementsFn, branchName, title, message, nil)
+	} else {
+		info, err = o.createEnvironmentPullRequest(devEnv, modifyRequirementsFn, &branchName, &title, &message, nil, nil)
+	}
+	if err != nil {
+		return errors.Wrap(err, "creating the Pull Request on the development environment")
+	}
+	if currentVersion == targetVersion {
+		log.Infof("Already installed platform version %s\n", util.ColorInfo(targetVersion))
+		return nil
+	}
+	if info != nil && info.PullRequest != nil {
+		log.Infof("Created Pull Request %s to upgrade the platform from version %s to %s\n",
+			util.ColorInfo(info.PullRequest.URL), util.ColorInfo(currentVersion), util.ColorInfo(targetVersion))
+		log.Infof("The upgrade is applied by the environment pipeline once the Pull Request is merged\n")
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// platformPullRequests records the Pull Request created to upgrade the platform
+type platformPullRequests struct {
+	requirements *helm.Requirements
+	branchName   string
+	title        string
+}
+
+func (p *platformPullRequests) create(env *v1.Environment, modifyRequirementsFn ModifyRequirementsFn, branchNameText string, title string, message string, pullRequestInfo *gits.PullRequestInfo) (*gits.PullRequestInfo, error) {
+	p.branchName = branchNameText
+	p.title = title
+	err := modifyRequirementsFn(p.requirements)
+	if err != nil {
+		return nil, err
+	}
+	return &gits.PullRequestInfo{
+		PullRequest: &gits.GitPullRequest{
+			URL: "https://github.com/myorg/environment-dev/pull/1",
+		},
+	}, nil
+}
+
+func newUpgradePlatformTestOptions(platformVersion string, gitURL string) (*UpgradePlatformOptions, *platformPullRequests) {
+	devEnv := kube.NewPermanentEnvironmentWithGit("dev", gitURL)
+	devEnv.Spec.Namespace = "jx"
+	devEnv.Spec.Kind = v1.EnvironmentKindTypeDevelopment
+
+	requirements := &helm.Requirements{
+		Dependencies: []*helm.Dependency{
+			{Name: "exposecontroller", Version: "2.3.82"},
+		},
+	}
+	if platformVersion != "" {
+		requirements.Dependencies = append(requirements.Dependencies, &helm.Dependency{
+			Name:    JenkinsXPlatformChartName,
+			Version: platformVersion,
+		})
+	}
+	prs := &platformPullRequests{requirements: requirements}
+
+	o := &UpgradePlatformOptions{
+		FakePullRequests: prs.create,
+	}
+	ConfigureTestOptionsWithResources(&o.CommonOptions, []runtime.Object{}, []runtime.Object{devEnv}, gits.NewGitCLI(), nil)
+	return o, prs
+}
+
+func TestUpgradePlatformViaGitOps(t *testing.T) {
+	t.Parallel()
+
+	o, prs := newUpgradePlatformTestOptions("0.0.3000", "https://github.com/myorg/environment-dev.git")
+	err := o.upgradePlatformViaGitOps("jx", "0.0.3100")
+	require.NoError(t, err)
+
+	assert.Equal(t, "upgrade-platform-0.0.3100", prs.branchName)
+	assert.Equal(t, "Upgrade jenkins-x-platform to 0.0.3100", prs.title)
+	require.Len(t, prs.requirements.Dependencies, 2)
+	assert.Equal(t, "2.3.82", prs.requirements.Dependencies[0].Version)
+	assert.Equal(t, "0.0.3100", prs.requirements.Dependencies[1].Version)
+}
+
+func TestUpgradePlatformViaGitOpsAlreadyInstalled(t *testing.T) {
+	t.Parallel()
+
+	o, prs := newUpgradePlatformTestOptions("0.0.3100", "https://github.com/myorg/environment-dev.git")
+	err := o.upgradePlatformViaGitOps("jx", "0.0.3100")
+	require.NoError(t, err)
+	assert.Equal(t, "0.0.3100", prs.requirements.Dependencies[1].Version)
+}
+
+func TestUpgradePlatformViaGitOpsMissingPlatformDependency(t *testing.T) {
+	t.Parallel()
+
+	o, _ := newUpgradePlatformTestOptions("", "https://github.com/myorg/environment-dev.git")
+	err := o.upgradePlatformViaGitOps("jx", "0.0.3100")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no jenkins-x-platform dependency found")
+}
+
+func TestUpgradePlatformViaGitOpsWithoutDevEnvironmentRepository(t *testing.T) {
+	t.Parallel()
+
+	o, prs := newUpgradePlatformTestOptions("0.0.3000", "")
+	err := o.upgradePlatformViaGitOps("jx", "0.0.3100")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "jx install --gitops")
+	assert.Equal(t, "", prs.branchName, "no Pull Request should be created")
+}
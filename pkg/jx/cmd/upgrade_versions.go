@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/blang/semver"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/versionstream"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+var (
+	upgradeVersionsLong = templates.LongDesc(`
+		Upgrades the tools and charts of a version stream to their latest releases.
+
+		The version stream pins the versions of the tools (like helm or terraform) and charts which have been
+		tested together. The new versions are committed to a new branch of a fresh clone of the version stream
+		repository, or of the checkout given by --dir, and submitted as a Pull Request so that they can be verified
+		before they are used. The local clone of the version stream used by the other commands is never modified.
+`)
+
+	upgradeVersionsExample = templates.Examples(`
+		# Creates a Pull Request upgrading the versions of the version stream
+		jx upgrade versions
+
+		# Creates a Pull Request upgrading the versions of a fork of the version stream
+		jx upgrade versions --git-url https://github.com/myorg/jenkins-x-versions.git
+
+		# Creates a Pull Request from a checkout of the version stream repository
+		jx upgrade versions --dir ~/jenkins-x-versions
+	`)
+)
+
+// UpgradeVersionsOptions the options for the upgrade versions command
+type UpgradeVersionsOptions struct {
+	CreateOptions
+
+	Dir    string
+	GitURL string
+}
+
+// NewCmdUpgradeVersions creates the command
+func NewCmdUpgradeVersions(f Factory, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) *cobra.Command {
+	options := &UpgradeVersionsOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				In:      in,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "versions",
+		Short:   "Upgrades the tools and charts of the version stream to their latest releases",
+		Long:    upgradeVersionsLong,
+		Example: upgradeVersionsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The directory of a checkout of the version stream repository. Defaults to a new clone of --git-url")
+	cmd.Flags().StringVarP(&options.GitURL, "git-url", "", versionstream.DefaultVersionsURL, "The git URL of the version stream repository to create the Pull Request on")
+	options.addVersionStreamFlags(cmd)
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *UpgradeVersionsOptions) Run() error {
+	base := o.VersionStreamRef
+	if base == "" {
+		base = "master"
+	}
+	dir := o.Dir
+	if dir == "" {
+		var err error
+		dir, err = ioutil.TempDir("", "jx-upgrade-versions-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+		err = o.Git().Clone(o.GitURL, dir)
+		if err != nil {
+			return errors.Wrapf(err, "cloning the version stream %s", o.GitURL)
+		}
+	}
+	err := o.Git().Checkout(dir, base)
+	if err != nil {
+		return errors.Wrapf(err, "checking out %s of the version stream", base)
+	}
+	versions, err := versionstream.LoadVersions(dir)
+	if err != nil {
+		return err
+	}
+
+	latestTools := map[string]func() (semver.Version, error){
+		"helm": func() (semver.Version, error) {
+			return util.GetLatestVersionFromGitHub("kubernetes", "helm")
+		},
+		"kubectl": o.getLatestVersionFromKubernetesReleaseUrl,
+		"kustomize": func() (semver.Version, error) {
+			return util.GetLatestVersionFromGitHub("kubernetes-sigs", "kustomize")
+		},
+		"terraform": func() (semver.Version, error) {
+			return util.GetLatestVersionFromGitHub("hashicorp", "terraform")
+		},
+	}
+
+	changes := 0
+	for _, name := range versions.ToolNames() {
+		latestFn := latestTools[name]
+		if latestFn == nil {
+			log.Warnf("Skipping tool %s as the latest release cannot be found\n", name)
+			continue
+		}
+		latest, err := latestFn()
+		if err != nil {
+			return errors.Wrapf(err, "finding the latest release of %s", name)
+		}
+		if o.upgradeVersion(name, versions.Tools, latest.String()) {
+			changes++
+		}
+	}
+	for _, chart := range versions.ChartNames() {
+		latest, err := helm.GetLatestVersion(chart, "", "", "", o.Helm())
+		if err != nil {
+			return errors.Wrapf(err, "finding the latest version of chart %s", chart)
+		}
+		if o.upgradeVersion(chart, versions.Charts, latest) {
+			changes++
+		}
+	}
+
+	if changes == 0 {
+		log.Infof("The version stream %s is up to date\n", util.ColorInfo(base))
+		return nil
+	}
+
+	branchName := o.Git().ConvertToValidBranchName("upgrade-versions-" + string(uuid.NewUUID()))
+	err = o.Git().CreateBranch(dir, branchName)
+	if err != nil {
+		return err
+	}
+	err = o.Git().Checkout(dir, branchName)
+	if err != nil {
+		return err
+	}
+	err = versionstream.SaveVersions(dir, versions)
+	if err != nil {
+		return errors.Wrapf(err, "saving the versions in %s", dir)
+	}
+	err = o.Git().Add(dir, versionstream.VersionsFileName)
+	if err != nil {
+		return err
+	}
+	message := fmt.Sprintf("Upgrade %d versions to their latest releases", changes)
+	err = o.Git().CommitDir(dir, message)
+	if err != nil {
+		return err
+	}
+	err = o.Git().Push(dir)
+	if err != nil {
+		return errors.Wrapf(err, "pushing the branch %s", branchName)
+	}
+	return o.createVersionsPullRequest(dir, base, branchName, message)
+}
+
+// createVersionsPullRequest creates the Pull Request of the pushed branch on the version stream repository
+func (o *UpgradeVersionsOptions) createVersionsPullRequest(dir string, base string, branchName string, message string) error {
+	_, gitConf, err := o.Git().FindGitConfigDir(dir)
+	if err != nil {
+		return err
+	}
+	gitURL, err := o.Git().DiscoverRemoteGitURL(gitConf)
+	if err != nil {
+		return err
+	}
+	gitInfo, err := gits.ParseGitURL(gitURL)
+	if err != nil {
+		return err
+	}
+	authConfigSvc, err := o.CreateGitAuthConfigService()
+	if err != nil {
+		return err
+	}
+	gitKind, err := o.GitServerKind(gitInfo)
+	if err != nil {
+		return err
+	}
+	provider, err := gitInfo.PickOrCreateProvider(authConfigSvc, "user name to submit the Pull Request", o.BatchMode, gitKind, o.Git(), o.In, o.Out, o.Err)
+	if err != nil {
+		return err
+	}
+	pr, err := provider.CreatePullRequest(&gits.GitPullRequestArguments{
+		GitRepository: gitInfo,
+		Title:         message,
+		Body:          "Please verify the new versions of the tools and charts before merging.",
+		Base:          base,
+		Head:          branchName,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "creating the Pull Request of the branch %s", branchName)
+	}
+	log.Successf("Created Pull Request: %s", pr.URL)
+	return nil
+}
+
+func (o *UpgradeVersionsOptions) upgradeVersion(name string, versions map[string]string, latest string) bool {
+	current := versions[name]
+	if latest == "" || current == latest {
+		return false
+	}
+	log.Infof("Upgrading %s from %s to %s\n", util.ColorInfo(name), util.ColorInfo(current), util.ColorInfo(latest))
+	versions[name] = latest
+	return true
+}
@@ -6,10 +6,13 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/blang/semver"
+	"github.com/jenkins-x/jx/pkg/helm"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/jenkins-x/jx/pkg/util/system"
 	"github.com/jenkins-x/jx/pkg/version"
+	"github.com/jenkins-x/jx/pkg/versionstream"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 )
@@ -59,11 +62,77 @@ func NewCmdVersion(f Factory, in terminal.FileReader, out terminal.FileWriter, e
 	return cmd
 }
 
+// versionComponent the installed version of a component and the version tested by the version stream
+type versionComponent struct {
+	Name     string
+	Version  string
+	Expected string
+	// Upgrade the command which upgrades the component to the version of the version stream
+	Upgrade string
+}
+
+// Mismatch returns true if the version stream pins a different version of the component
+func (c *versionComponent) Mismatch() bool {
+	return c.Expected != "" && c.Version != "" && !versionsMatch(c.Version, c.Expected)
+}
+
 func (o *VersionOptions) Run() error {
-	info := util.ColorInfo
+	components := o.components()
+	upgrades := []string{}
+
 	table := o.CreateTable()
-	table.AddRow("NAME", "VERSION")
-	table.AddRow("jx", info(version.GetVersion()))
+	if o.NoVersionCheck {
+		table.AddRow("NAME", "VERSION")
+	} else {
+		table.AddRow("NAME", "VERSION", "VERSION STREAM")
+	}
+	for _, c := range components {
+		if o.NoVersionCheck {
+			table.AddRow(c.Name, util.ColorInfo(c.Version))
+			continue
+		}
+		text := util.ColorInfo(c.Version)
+		if c.Mismatch() {
+			text = util.ColorWarning(c.Version)
+			if c.Upgrade != "" && util.StringArrayIndex(upgrades, c.Upgrade) < 0 {
+				upgrades = append(upgrades, c.Upgrade)
+			}
+		}
+		table.AddRow(c.Name, text, c.Expected)
+	}
+	table.Render()
+
+	if !o.NoVersionCheck {
+		if len(upgrades) > 0 {
+			log.Warnf("\nSome versions do not match the version stream. To upgrade them use: %s\n", util.ColorInfo(strings.Join(upgrades, " and ")))
+		}
+		return o.VersionCheck()
+	}
+	return nil
+}
+
+// components returns the versions of jx, the tools it uses and the components installed in the cluster. Any
+// component whose version cannot be found is logged and skipped
+func (o *VersionOptions) components() []*versionComponent {
+	var versions *versionstream.Versions
+	if !o.NoVersionCheck {
+		versions = o.versionStream()
+	}
+	components := []*versionComponent{}
+	add := func(name string, version string, expected string) {
+		upgrade := "jx upgrade binaries"
+		if name == "jenkins x platform" {
+			upgrade = "jx upgrade platform"
+		}
+		components = append(components, &versionComponent{
+			Name:     name,
+			Version:  version,
+			Expected: expected,
+			Upgrade:  upgrade,
+		})
+	}
+
+	add("jx", version.GetVersion(), "")
 
 	// Jenkins X version
 	output, err := o.Helm().ListCharts()
@@ -77,7 +146,7 @@ func (o *VersionOptions) Run() error {
 					f = strings.TrimSpace(f)
 					if strings.HasPrefix(f, jxChartPrefix) {
 						chart := strings.TrimPrefix(f, jxChartPrefix)
-						table.AddRow("jenkins x platform", info(chart))
+						add("jenkins x platform", chart, versions.ChartVersion(JenkinsXPlatformChart))
 					}
 				}
 			}
@@ -93,27 +162,19 @@ func (o *VersionOptions) Run() error {
 		if err != nil {
 			log.Warnf("Failed to get Kubernetes server version: %s\n", err)
 		} else if serverVersion != nil {
-			table.AddRow("Kubernetes cluster", info(serverVersion.String()))
+			add("Kubernetes cluster", serverVersion.String(), "")
 		}
 	}
 
 	// kubectl version
-	output, err = o.getCommandOutput("", "kubectl", "version", "--short")
+	output, err = o.getCommandOutput("", "kubectl", "version", "--short", "--client")
 	if err != nil {
 		log.Warnf("Failed to get kubectl version: %s\n", err)
 	} else {
-		for i, line := range strings.Split(output, "\n") {
+		for _, line := range strings.Split(output, "\n") {
 			fields := strings.Fields(line)
-			if len(fields) > 1 {
-				v := fields[2]
-				if v != "" {
-					switch i {
-					case 0:
-						table.AddRow("kubectl", info(v))
-					case 1:
-						// Ignore K8S server details as we have these above
-					}
-				}
+			if len(fields) > 2 && fields[0] == "Client" {
+				add("kubectl", fields[2], versions.ToolVersion("kubectl"))
 			}
 		}
 	}
@@ -124,8 +185,8 @@ func (o *VersionOptions) Run() error {
 		log.Warnf("Failed to get helm version: %s\n", err)
 	} else {
 		helmBinary, noTiller, helmTemplate, _ := o.TeamHelmBin()
-		if helmBinary == "helm3" || noTiller || helmTemplate {
-			table.AddRow("helm client", info(output))
+		if helm.DetectVersion(helmBinary) == helm.V3 || noTiller || helmTemplate {
+			add("helm client", output, versions.ToolVersion("helm"))
 		} else {
 			for i, line := range strings.Split(output, "\n") {
 				fields := strings.Fields(line)
@@ -134,9 +195,9 @@ func (o *VersionOptions) Run() error {
 					if v != "" {
 						switch i {
 						case 0:
-							table.AddRow("helm client", info(v))
+							add("helm client", v, versions.ToolVersion("helm"))
 						case 1:
-							table.AddRow("helm server", info(v))
+							add("helm server", v, versions.ToolVersion("helm"))
 						}
 					}
 				}
@@ -144,28 +205,46 @@ func (o *VersionOptions) Run() error {
 		}
 	}
 
+	// terraform version
+	output, err = o.getCommandOutput("", "terraform", "version")
+	if err != nil {
+		o.Debugf("Failed to get terraform version: %s\n", err)
+	} else {
+		fields := strings.Fields(strings.Split(output, "\n")[0])
+		if len(fields) > 1 {
+			add("terraform", fields[1], versions.ToolVersion("terraform"))
+		}
+	}
+
 	// git version
-	version, err := o.Git().Version()
+	gitVersion, err := o.Git().Version()
 	if err != nil {
 		log.Warnf("Failed to get git version: %s\n", err)
 	} else {
-		table.AddRow("git", info(version))
+		add("git", gitVersion, "")
 	}
 
 	// os version
-	version, err = o.GetOsVersion()
+	osVersion, err := o.GetOsVersion()
 	if err != nil {
 		log.Warnf("Failed to get OS version: %s\n", err)
 	} else {
-		table.AddRow("Operating System", info(version))
+		add("Operating System", osVersion, "")
 	}
+	return components
+}
 
-	table.Render()
-
-	if !o.NoVersionCheck {
-		return o.VersionCheck()
+// versionsMatch returns true if the versions are the same ignoring any 'v' prefix and build metadata
+func versionsMatch(actual string, expected string) bool {
+	actualVersion, err := semver.ParseTolerant(actual)
+	if err != nil {
+		return strings.TrimPrefix(actual, "v") == strings.TrimPrefix(expected, "v")
 	}
-	return nil
+	expectedVersion, err := semver.ParseTolerant(expected)
+	if err != nil {
+		return false
+	}
+	return actualVersion.EQ(expectedVersion)
 }
 
 func (o *VersionOptions) VersionCheck() error {
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionsMatch(t *testing.T) {
+	t.Parallel()
+	assert.True(t, versionsMatch("v2.11.0+g2e55dbe", "2.11.0"))
+	assert.True(t, versionsMatch("v1.12.2", "1.12.2"))
+	assert.False(t, versionsMatch("v0.11.8", "0.11.10"))
+	assert.True(t, versionsMatch("1.0.10", "v1.0.10"))
+}
+
+func TestVersionComponentMismatch(t *testing.T) {
+	t.Parallel()
+	assert.True(t, (&versionComponent{Name: "terraform", Version: "v0.11.8", Expected: "0.11.10"}).Mismatch())
+	assert.False(t, (&versionComponent{Name: "terraform", Version: "v0.11.10", Expected: "0.11.10"}).Mismatch())
+	assert.False(t, (&versionComponent{Name: "git", Version: "2.17.1"}).Mismatch(), "components not in the version stream never mismatch")
+}
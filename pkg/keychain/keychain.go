@@ -0,0 +1,77 @@
+package keychain
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNotFound is returned when there is no secret in the keychain for the service and account
+var ErrNotFound = errors.New("secret not found in the keychain")
+
+// Keychain stores secrets in the credential store of the operating system
+type Keychain interface {
+	// Get returns the secret of the account for the service or ErrNotFound
+	Get(service string, account string) (string, error)
+	// Set creates or updates the secret of the account for the service
+	Set(service string, account string, secret string) error
+	// Delete removes the secret of the account for the service
+	Delete(service string, account string) error
+}
+
+// NewKeychain returns the Keychain of the current operating system
+func NewKeychain() Keychain {
+	return newOSKeychain()
+}
+
+// commandError is returned when a keychain command fails along with what it wrote to stderr
+type commandError struct {
+	name   string
+	err    error
+	stderr string
+}
+
+func (e *commandError) Error() string {
+	return fmt.Sprintf("running %s: %s: %s", e.name, e.err, e.stderr)
+}
+
+// run runs the command feeding the optional input on stdin and returns the trimmed stdout
+func run(stdin string, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	err := cmd.Run()
+	if err != nil {
+		return "", &commandError{name: name, err: err, stderr: strings.TrimSpace(stderr.String())}
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// encodedSecretPrefix marks the secrets stored base64 encoded
+const encodedSecretPrefix = "base64:"
+
+// encodeSecret encodes the secret so that it is stored on a single line
+func encodeSecret(secret string) string {
+	return encodedSecretPrefix + base64.StdEncoding.EncodeToString([]byte(secret))
+}
+
+// decodeSecret decodes a secret encoded with encodeSecret. The secrets stored before they were encoded are returned
+// as they are
+func decodeSecret(stored string) (string, error) {
+	if !strings.HasPrefix(stored, encodedSecretPrefix) {
+		return stored, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(stored, encodedSecretPrefix))
+	if err != nil {
+		return "", errors.Wrap(err, "decoding the secret stored in the keychain")
+	}
+	return string(data), nil
+}
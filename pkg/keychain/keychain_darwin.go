@@ -0,0 +1,62 @@
+package keychain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// darwinKeychain uses the macOS Keychain via the security command
+type darwinKeychain struct {
+}
+
+func newOSKeychain() Keychain {
+	return &darwinKeychain{}
+}
+
+// Get returns the secret of the account for the service
+func (k *darwinKeychain) Get(service string, account string) (string, error) {
+	out, err := run("", "security", "find-generic-password", "-s", service, "-a", account, "-w")
+	if err != nil {
+		if strings.Contains(err.Error(), "could not be found") {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	return decodeSecret(out)
+}
+
+// Set creates or updates the secret of the account for the service. The command is passed to the interactive mode
+// of the security command on stdin so that the secret is not visible in the arguments of the process. The secret is
+// stored base64 encoded as the interactive mode is line based and the security command prints the secrets containing
+// new lines hex encoded
+func (k *darwinKeychain) Set(service string, account string, secret string) error {
+	command := fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n", quote(service), quote(account), quote(encodeSecret(secret)))
+	_, err := run(command, "security", "-i")
+	if err != nil {
+		return err
+	}
+	// the interactive mode does not fail when one of its commands does so lets check the secret was stored
+	stored, err := k.Get(service, account)
+	if err != nil {
+		return err
+	}
+	if stored != secret {
+		return fmt.Errorf("failed to store the secret of account %s for service %s in the keychain", account, service)
+	}
+	return nil
+}
+
+// quote quotes the argument of a command of the interactive mode of the security command
+func quote(arg string) string {
+	arg = strings.Replace(arg, `\`, `\\`, -1)
+	return `"` + strings.Replace(arg, `"`, `\"`, -1) + `"`
+}
+
+// Delete removes the secret of the account for the service
+func (k *darwinKeychain) Delete(service string, account string) error {
+	_, err := run("", "security", "delete-generic-password", "-s", service, "-a", account)
+	if err != nil && strings.Contains(err.Error(), "could not be found") {
+		return nil
+	}
+	return err
+}
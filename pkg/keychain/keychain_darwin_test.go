@@ -0,0 +1,34 @@
+package keychain
+
+import (
+	"testing"
+)
+
+// fakeSecurity implements the commands of the security command used by the keychain
+const fakeSecurity = `if [ "$1" = "-i" ]; then
+  read -r line
+  eval "set -- $line"
+  printf '%s' "$8" > "$FAKE_KEYCHAIN_DIR/$4.$6"
+  exit 0
+fi
+file="$FAKE_KEYCHAIN_DIR/$3.$5"
+if [ ! -f "$file" ]; then
+  echo "security: SecKeychainSearchCopyNext: The specified item could not be found in the keychain." >&2
+  exit 44
+fi
+case "$1" in
+find-generic-password)
+  cat "$file"
+  echo
+  ;;
+delete-generic-password)
+  rm "$file"
+  ;;
+esac
+`
+
+func TestDarwinKeychainRoundTrip(t *testing.T) {
+	defer fakeCommand(t, "security", fakeSecurity)()
+
+	assertRoundTrip(t, NewKeychain(), testAuthConfig)
+}
@@ -0,0 +1,44 @@
+package keychain
+
+import (
+	"os/exec"
+)
+
+// secretServiceKeychain uses the Secret Service API (GNOME Keyring, KWallet) via the secret-tool command of libsecret
+type secretServiceKeychain struct {
+}
+
+func newOSKeychain() Keychain {
+	return &secretServiceKeychain{}
+}
+
+// Get returns the secret of the account for the service
+func (k *secretServiceKeychain) Get(service string, account string) (string, error) {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return "", err
+	}
+	out, err := run("", "secret-tool", "lookup", "service", service, "account", account)
+	if err != nil {
+		// secret-tool exits with an error and no output when nothing matches
+		if cmdErr, ok := err.(*commandError); ok && cmdErr.stderr == "" {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	if out == "" {
+		return "", ErrNotFound
+	}
+	return out, nil
+}
+
+// Set creates or updates the secret of the account for the service
+func (k *secretServiceKeychain) Set(service string, account string, secret string) error {
+	_, err := run(secret, "secret-tool", "store", "--label", service+" "+account, "service", service, "account", account)
+	return err
+}
+
+// Delete removes the secret of the account for the service
+func (k *secretServiceKeychain) Delete(service string, account string) error {
+	_, err := run("", "secret-tool", "clear", "service", service, "account", account)
+	return err
+}
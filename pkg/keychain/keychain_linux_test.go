@@ -0,0 +1,27 @@
+package keychain
+
+import (
+	"strings"
+	"testing"
+)
+
+// fakeSecretTool implements the commands of the secret-tool command used by the keychain
+const fakeSecretTool = `case "$1" in
+store)
+  cat > "$FAKE_KEYCHAIN_DIR/$5.$7"
+  ;;
+lookup)
+  cat "$FAKE_KEYCHAIN_DIR/$3.$5" 2>/dev/null || exit 1
+  ;;
+clear)
+  rm -f "$FAKE_KEYCHAIN_DIR/$3.$5"
+  ;;
+esac
+`
+
+func TestSecretServiceKeychainRoundTrip(t *testing.T) {
+	defer fakeCommand(t, "secret-tool", fakeSecretTool)()
+
+	// the output of secret-tool is trimmed
+	assertRoundTrip(t, NewKeychain(), strings.TrimSpace(testAuthConfig))
+}
@@ -0,0 +1,35 @@
+// +build !darwin,!linux,!windows
+
+package keychain
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// unsupportedKeychain is used on operating systems without a supported credential store
+type unsupportedKeychain struct {
+}
+
+func newOSKeychain() Keychain {
+	return &unsupportedKeychain{}
+}
+
+// Get fails as the keychain is not supported
+func (k *unsupportedKeychain) Get(service string, account string) (string, error) {
+	return "", k.unsupported()
+}
+
+// Set fails as the keychain is not supported
+func (k *unsupportedKeychain) Set(service string, account string, secret string) error {
+	return k.unsupported()
+}
+
+// Delete fails as the keychain is not supported
+func (k *unsupportedKeychain) Delete(service string, account string) error {
+	return k.unsupported()
+}
+
+func (k *unsupportedKeychain) unsupported() error {
+	return fmt.Errorf("the keychain is not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,83 @@
+package keychain
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAuthConfig = `servers:
+- url: https://github.com
+  users:
+  - username: test-user
+    apitoken: "some \"quoted\" token\\"
+  name: GitHub
+currentserver: https://github.com
+`
+
+func TestEncodeSecret(t *testing.T) {
+	t.Parallel()
+
+	encoded := encodeSecret(testAuthConfig)
+	assert.NotContains(t, encoded, "\n")
+
+	decoded, err := decodeSecret(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, testAuthConfig, decoded)
+}
+
+func TestDecodeSecretStoredBeforeEncoding(t *testing.T) {
+	t.Parallel()
+
+	decoded, err := decodeSecret("my-secret")
+	require.NoError(t, err)
+	assert.Equal(t, "my-secret", decoded)
+
+	_, err = decodeSecret(encodedSecretPrefix + "not base64!")
+	assert.Error(t, err)
+}
+
+// fakeCommand creates a script with the name of the command at the front of the PATH which stores the secrets as files
+// in the directory of the FAKE_KEYCHAIN_DIR environment variable. The returned function restores the environment
+func fakeCommand(t *testing.T, name string, script string) func() {
+	dir, err := ioutil.TempDir("", "test-keychain-")
+	require.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script), 0755)
+	require.NoError(t, err)
+
+	path := os.Getenv("PATH")
+	os.Setenv("PATH", dir+string(os.PathListSeparator)+path)
+	os.Setenv("FAKE_KEYCHAIN_DIR", dir)
+	return func() {
+		os.Setenv("PATH", path)
+		os.Unsetenv("FAKE_KEYCHAIN_DIR")
+		os.RemoveAll(dir)
+	}
+}
+
+// assertRoundTrip asserts the secrets set in the keychain are returned as they were set
+func assertRoundTrip(t *testing.T, k Keychain, secret string) {
+	_, err := k.Get("jx", "test-account")
+	assert.Equal(t, ErrNotFound, err)
+
+	err = k.Set("jx", "test-account", secret)
+	require.NoError(t, err)
+	actual, err := k.Get("jx", "test-account")
+	require.NoError(t, err)
+	assert.Equal(t, secret, actual)
+
+	err = k.Set("jx", "test-account", "updated")
+	require.NoError(t, err)
+	actual, err = k.Get("jx", "test-account")
+	require.NoError(t, err)
+	assert.Equal(t, "updated", actual)
+
+	err = k.Delete("jx", "test-account")
+	require.NoError(t, err)
+	_, err = k.Get("jx", "test-account")
+	assert.Equal(t, ErrNotFound, err)
+}
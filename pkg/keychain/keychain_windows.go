@@ -0,0 +1,61 @@
+package keychain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// windowsKeychain uses the Windows Credential Manager via the PasswordVault of PowerShell
+type windowsKeychain struct {
+}
+
+const passwordVaultScript = `[void][Windows.Security.Credentials.PasswordVault,Windows.Security.Credentials,ContentType=WindowsRuntime]
+$vault = New-Object Windows.Security.Credentials.PasswordVault
+`
+
+func newOSKeychain() Keychain {
+	return &windowsKeychain{}
+}
+
+// Get returns the secret of the account for the service
+func (k *windowsKeychain) Get(service string, account string) (string, error) {
+	script := passwordVaultScript + fmt.Sprintf(`$cred = $vault.Retrieve(%s, %s)
+$cred.RetrievePassword()
+$cred.Password`, quote(service), quote(account))
+	out, err := powershell(script)
+	if err != nil {
+		if strings.Contains(err.Error(), "Element not found") {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	return out, nil
+}
+
+// Set creates or updates the secret of the account for the service
+func (k *windowsKeychain) Set(service string, account string, secret string) error {
+	// the secret is read from stdin so that it does not appear in the process list
+	script := passwordVaultScript + fmt.Sprintf(`$secret = [Console]::In.ReadToEnd()
+$vault.Add((New-Object Windows.Security.Credentials.PasswordCredential(%s, %s, $secret)))`, quote(service), quote(account))
+	_, err := run(secret, "powershell", "-NoProfile", "-NonInteractive", "-Command", script)
+	return err
+}
+
+// Delete removes the secret of the account for the service
+func (k *windowsKeychain) Delete(service string, account string) error {
+	script := passwordVaultScript + fmt.Sprintf(`$vault.Remove($vault.Retrieve(%s, %s))`, quote(service), quote(account))
+	_, err := powershell(script)
+	if err != nil && strings.Contains(err.Error(), "Element not found") {
+		return nil
+	}
+	return err
+}
+
+func powershell(script string) (string, error) {
+	return run("", "powershell", "-NoProfile", "-NonInteractive", "-Command", script)
+}
+
+// quote returns the text as a single quoted PowerShell string
+func quote(text string) string {
+	return "'" + strings.Replace(text, "'", "''", -1) + "'"
+}
@@ -0,0 +1,149 @@
+package knative
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// DeployKindDefault deploys the application as a Deployment, Service and Ingress
+	DeployKindDefault = "default"
+	// DeployKindKnative deploys the application as a Knative Service which scales to zero when idle
+	DeployKindKnative = "knative"
+
+	// ValuesKnativeDeploy the key in the values.yaml of the chart of the application which enables the Knative Service
+	ValuesKnativeDeploy = "knativeDeploy"
+
+	// ServiceTemplateFile the name of the template of the Knative Service in the chart
+	ServiceTemplateFile = "ksvc.yaml"
+
+	guardStart = "{{- if not .Values." + ValuesKnativeDeploy + " }}"
+	guardEnd   = "{{- end }}"
+)
+
+// DeployKinds the kinds of deployment of an application
+var DeployKinds = []string{DeployKindDefault, DeployKindKnative}
+
+// guardedTemplates the templates of the chart which are replaced by the Knative Service
+var guardedTemplates = []string{"deployment.yaml", "service.yaml", "ingress.yaml"}
+
+const serviceTemplate = `{{- if .Values.knativeDeploy }}
+apiVersion: serving.knative.dev/v1alpha1
+kind: Service
+metadata:
+  name: {{ .Values.service.name }}
+  labels:
+    chart: "{{ .Chart.Name }}-{{ .Chart.Version | replace "+" "_" }}"
+    version: "{{ .Chart.Version }}"
+spec:
+  runLatest:
+    configuration:
+      revisionTemplate:
+        spec:
+          container:
+            image: "{{ .Values.image.repository }}:{{ .Values.image.tag }}"
+            imagePullPolicy: {{ .Values.image.pullPolicy }}
+            ports:
+            - containerPort: {{ .Values.service.internalPort }}
+            env:
+{{- range $pkey, $pval := .Values.env }}
+            - name: {{ $pkey }}
+              value: {{ quote $pval }}
+{{- end }}
+            resources:
+{{ toYaml .Values.resources | indent 14 }}
+{{- end }}
+`
+
+// GetDeployKind returns the kind of deployment of the chart in the given directory
+func GetDeployKind(chartDir string) (string, error) {
+	data, err := ioutil.ReadFile(filepath.Join(chartDir, "values.yaml"))
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, ValuesKnativeDeploy+":") {
+			if strings.TrimSpace(strings.TrimPrefix(line, ValuesKnativeDeploy+":")) == "true" {
+				return DeployKindKnative, nil
+			}
+			break
+		}
+	}
+	return DeployKindDefault, nil
+}
+
+// SetDeployKind modifies the chart in the given directory so that the application is deployed with the given kind.
+// The Knative Service template is added to the chart if missing and the Deployment, Service and Ingress templates are
+// only rendered if the Knative Service is disabled
+func SetDeployKind(chartDir string, kind string) error {
+	if util.StringArrayIndex(DeployKinds, kind) < 0 {
+		return fmt.Errorf("unknown deploy kind %s, expected one of %s", kind, strings.Join(DeployKinds, ", "))
+	}
+	valuesFile := filepath.Join(chartDir, "values.yaml")
+	data, err := ioutil.ReadFile(valuesFile)
+	if err != nil {
+		return err
+	}
+	value := fmt.Sprintf("%s: %t", ValuesKnativeDeploy, kind == DeployKindKnative)
+	lines := strings.Split(string(data), "\n")
+	found := false
+	for idx, line := range lines {
+		if strings.HasPrefix(line, ValuesKnativeDeploy+":") {
+			lines[idx] = value
+			found = true
+		}
+	}
+	if !found {
+		lines = append([]string{value}, lines...)
+	}
+	err = ioutil.WriteFile(valuesFile, []byte(strings.Join(lines, "\n")), util.DefaultWritePermissions)
+	if err != nil {
+		return fmt.Errorf("failed to save %s: %s", valuesFile, err)
+	}
+
+	templatesDir := filepath.Join(chartDir, "templates")
+	serviceFile := filepath.Join(templatesDir, ServiceTemplateFile)
+	exists, err := util.FileExists(serviceFile)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		err = ioutil.WriteFile(serviceFile, []byte(serviceTemplate), util.DefaultWritePermissions)
+		if err != nil {
+			return fmt.Errorf("failed to save %s: %s", serviceFile, err)
+		}
+	}
+	for _, name := range guardedTemplates {
+		err = guardTemplate(filepath.Join(templatesDir, name))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// guardTemplate wraps the template so that it is only rendered if the Knative Service is disabled
+func guardTemplate(fileName string) error {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	text := string(data)
+	if strings.Contains(text, ".Values."+ValuesKnativeDeploy) {
+		return nil
+	}
+	text = guardStart + "\n" + strings.TrimSuffix(text, "\n") + "\n" + guardEnd + "\n"
+	err = ioutil.WriteFile(fileName, []byte(text), util.DefaultWritePermissions)
+	if err != nil {
+		return fmt.Errorf("failed to save %s: %s", fileName, err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package knative_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/knative"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetDeployKind(t *testing.T) {
+	t.Parallel()
+
+	chartDir, err := ioutil.TempDir("", "test-knative-chart")
+	require.NoError(t, err)
+	defer os.RemoveAll(chartDir)
+
+	templatesDir := filepath.Join(chartDir, "templates")
+	require.NoError(t, os.MkdirAll(templatesDir, 0755))
+	valuesFile := filepath.Join(chartDir, "values.yaml")
+	require.NoError(t, ioutil.WriteFile(valuesFile, []byte("replicaCount: 1\nservice:\n  name: myapp\n"), 0644))
+	deploymentFile := filepath.Join(templatesDir, "deployment.yaml")
+	require.NoError(t, ioutil.WriteFile(deploymentFile, []byte("apiVersion: extensions/v1beta1\nkind: Deployment\n"), 0644))
+
+	kind, err := knative.GetDeployKind(chartDir)
+	require.NoError(t, err)
+	assert.Equal(t, knative.DeployKindDefault, kind)
+
+	err = knative.SetDeployKind(chartDir, knative.DeployKindKnative)
+	require.NoError(t, err)
+	kind, err = knative.GetDeployKind(chartDir)
+	require.NoError(t, err)
+	assert.Equal(t, knative.DeployKindKnative, kind)
+
+	assert.FileExists(t, filepath.Join(templatesDir, knative.ServiceTemplateFile))
+	data, err := ioutil.ReadFile(deploymentFile)
+	require.NoError(t, err)
+	assert.Equal(t, "{{- if not .Values.knativeDeploy }}\napiVersion: extensions/v1beta1\nkind: Deployment\n{{- end }}\n", string(data))
+
+	// switching back keeps the templates guarded only once
+	err = knative.SetDeployKind(chartDir, knative.DeployKindDefault)
+	require.NoError(t, err)
+	data, err = ioutil.ReadFile(deploymentFile)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(data), "knativeDeploy"))
+	values, err := ioutil.ReadFile(valuesFile)
+	require.NoError(t, err)
+	assert.Equal(t, "knativeDeploy: false\nreplicaCount: 1\nservice:\n  name: myapp\n", string(values))
+
+	err = knative.SetDeployKind(chartDir, "lambda")
+	assert.Error(t, err)
+}
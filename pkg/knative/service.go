@@ -0,0 +1,96 @@
+package knative
+
+import (
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+var (
+	serviceResource = schema.GroupVersionResource{Group: "serving.knative.dev", Version: "v1alpha1", Resource: "services"}
+)
+
+// ServiceStatus the status of a Knative Service
+type ServiceStatus struct {
+	// URL the URL the service is available at. Requests to it scale the service up from zero
+	URL string
+	// Version the version label of the service
+	Version string
+	// Ready true if the latest revision of the service is ready to serve requests
+	Ready bool
+	// LatestRevision the name of the latest revision of the service
+	LatestRevision string
+}
+
+// GetServiceStatus returns the status of the Knative Service of the given name or nil if it does not exist
+func GetServiceStatus(dynamicClient dynamic.Interface, ns string, name string) (*ServiceStatus, error) {
+	u, err := dynamicClient.Resource(serviceResource).Namespace(ns).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get the Knative Service %s in namespace %s", name, ns)
+	}
+	return toServiceStatus(u)
+}
+
+// FindServiceURL returns the URL of the first Knative Service found with one of the given names
+func FindServiceURL(dynamicClient dynamic.Interface, ns string, names []string) (string, error) {
+	for _, name := range names {
+		status, err := GetServiceStatus(dynamicClient, ns, name)
+		if err != nil {
+			return "", err
+		}
+		if status != nil && status.URL != "" {
+			return status.URL, nil
+		}
+	}
+	return "", nil
+}
+
+// service the parts of a Knative Service jx reads
+type service struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Status            serviceStatus `json:"status,omitempty"`
+}
+
+type serviceStatus struct {
+	URL                       string             `json:"url,omitempty"`
+	Domain                    string             `json:"domain,omitempty"`
+	LatestReadyRevisionName   string             `json:"latestReadyRevisionName,omitempty"`
+	LatestCreatedRevisionName string             `json:"latestCreatedRevisionName,omitempty"`
+	Conditions                []serviceCondition `json:"conditions,omitempty"`
+}
+
+type serviceCondition struct {
+	Type   string `json:"type"`
+	Status string `json:"status"`
+}
+
+func toServiceStatus(u *unstructured.Unstructured) (*ServiceStatus, error) {
+	svc := &service{}
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, svc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert the Knative Service %s", u.GetName())
+	}
+	status := &ServiceStatus{
+		URL:            svc.Status.URL,
+		Version:        svc.Labels["version"],
+		LatestRevision: svc.Status.LatestReadyRevisionName,
+	}
+	if status.URL == "" && svc.Status.Domain != "" {
+		// older releases only report the domain of the service
+		status.URL = "http://" + svc.Status.Domain
+	}
+	for _, condition := range svc.Status.Conditions {
+		if condition.Type == "Ready" {
+			status.Ready = condition.Status == "True" && svc.Status.LatestReadyRevisionName == svc.Status.LatestCreatedRevisionName
+		}
+	}
+	return status, nil
+}
@@ -3,6 +3,7 @@ package kube
 import (
 	"fmt"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -51,8 +52,20 @@ type PipelineDetails struct {
 	BranchName    string
 	Pipeline      string
 	Build         string
+	Trigger       string
 }
 
+const (
+	// PipelineTriggerPush a pipeline triggered by a push to a branch
+	PipelineTriggerPush = "push"
+	// PipelineTriggerPullRequest a pipeline triggered by a Pull Request
+	PipelineTriggerPullRequest = "pr"
+	// PipelineTriggerTag a pipeline triggered by a tag
+	PipelineTriggerTag = "tag"
+)
+
+var versionTagRegex = regexp.MustCompile(`^v?\d+\.\d+\.\d+`)
+
 // PipelineID is an identifier for a Pipeline.
 // A pipeline is typically identified by its owner, repository, and branch with the ID field taking the form
 // `<owner>/>repository>/<branch>`
@@ -114,7 +127,19 @@ func CreatePipelineDetails(activity *v1.PipelineActivity) *PipelineDetails {
 		BranchName:    branchName,
 		Pipeline:      pipeline,
 		Build:         buildNumber,
+		Trigger:       PipelineTrigger(branchName),
+	}
+}
+
+// PipelineTrigger returns what triggered the pipeline of the given branch: a push, Pull Request or tag
+func PipelineTrigger(branchName string) string {
+	if strings.HasPrefix(strings.ToUpper(branchName), "PR-") {
+		return PipelineTriggerPullRequest
+	}
+	if versionTagRegex.MatchString(branchName) {
+		return PipelineTriggerTag
 	}
+	return PipelineTriggerPush
 }
 
 // GenerateBuildNumber generates a new build number for the given pipeline
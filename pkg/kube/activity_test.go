@@ -229,10 +229,21 @@ func TestCreatePipelineDetails(t *testing.T) {
 			assert.Equal(t, expectedBranch, d1.BranchName, "%s BranchName", name)
 			assert.Equal(t, expectedPipeline, d1.Pipeline, "%s Pipeline", name)
 			assert.Equal(t, expectedBuild, d1.Build, "%s Build", name)
+			assert.Equal(t, kube.PipelineTriggerPush, d1.Trigger, "%s Trigger", name)
 		}
 	}
 }
 
+func TestPipelineTrigger(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, kube.PipelineTriggerPush, kube.PipelineTrigger("master"))
+	assert.Equal(t, kube.PipelineTriggerPush, kube.PipelineTrigger("feature-1.2"))
+	assert.Equal(t, kube.PipelineTriggerPullRequest, kube.PipelineTrigger("PR-123"))
+	assert.Equal(t, kube.PipelineTriggerPullRequest, kube.PipelineTrigger("pr-7"))
+	assert.Equal(t, kube.PipelineTriggerTag, kube.PipelineTrigger("v1.2.3"))
+	assert.Equal(t, kube.PipelineTriggerTag, kube.PipelineTrigger("0.0.1"))
+}
+
 func TestPipelineID(t *testing.T) {
 	t.Parallel()
 
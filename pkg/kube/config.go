@@ -3,6 +3,8 @@ package kube
 import (
 	"fmt"
 	"io/ioutil"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 	"k8s.io/client-go/tools/clientcmd"
@@ -15,6 +17,9 @@ const (
 
 	// PodNamespaceFile the file path and name for pod namespace
 	PodNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+	// JXContextPrefix the prefix of the Kubernetes contexts created and managed by jx
+	JXContextPrefix = "jx-"
 )
 
 // KubeConfig implements kube interactions
@@ -145,6 +150,113 @@ func AddUserToConfig(user string, token string, config *api.Config) (*api.Config
 	return config, nil
 }
 
+// JXContextName returns the name of the jx managed context for the given cluster name
+func JXContextName(clusterName string) string {
+	if strings.HasPrefix(clusterName, JXContextPrefix) {
+		return clusterName
+	}
+	return JXContextPrefix + clusterName
+}
+
+// IsJXContext returns true if the given context name is managed by jx
+func IsJXContext(name string) bool {
+	return strings.HasPrefix(name, JXContextPrefix)
+}
+
+// JXContextNames returns the sorted names of all the jx managed contexts in the given config
+func JXContextNames(config *api.Config) []string {
+	answer := []string{}
+	if config != nil {
+		for name, ctx := range config.Contexts {
+			if ctx != nil && IsJXContext(name) {
+				answer = append(answer, name)
+			}
+		}
+	}
+	sort.Strings(answer)
+	return answer
+}
+
+// FindContext returns the name of the jx managed context of the given cluster, or of the context with the given name,
+// or an empty string if there is neither
+func FindContext(config *api.Config, name string) string {
+	if config == nil || name == "" {
+		return ""
+	}
+	for _, ctxName := range []string{JXContextName(name), name} {
+		if config.Contexts[ctxName] != nil {
+			return ctxName
+		}
+	}
+	return ""
+}
+
+// ContextForCluster returns the name of a context of the given config for the cloud cluster with the given name or
+// an empty string if there is none. The kube configs written by the cloud CLIs name the clusters differently such as
+// gke_<project>_<zone>_<name> for GKE and <name>.<region>.eksctl.io for EKS so we match on any of these forms
+func ContextForCluster(config *api.Config, clusterName string) string {
+	if config == nil || clusterName == "" {
+		return ""
+	}
+	answer := ""
+	for name, ctx := range config.Contexts {
+		if ctx == nil {
+			continue
+		}
+		cluster := ctx.Cluster
+		if cluster == clusterName || strings.HasSuffix(cluster, "_"+clusterName) || strings.HasPrefix(cluster, clusterName+".") ||
+			strings.HasSuffix(cluster, ":cluster/"+clusterName) {
+			// prefer the current context then the first context by name so that the result is stable
+			if name == config.CurrentContext {
+				return name
+			}
+			if answer == "" || name < answer {
+				answer = name
+			}
+		}
+	}
+	return answer
+}
+
+// AddJXContext copies the current context into a jx managed context for the given cluster using the
+// given namespace and makes it the current context. The name of the new context is returned
+func AddJXContext(config *api.Config, clusterName string, namespace string) (string, error) {
+	current := CurrentContext(config)
+	if current == nil {
+		return "", errors.New("no current context found in config")
+	}
+	ctxName := JXContextName(clusterName)
+	ctx := current.DeepCopy()
+	if namespace != "" {
+		ctx.Namespace = namespace
+	}
+	config.Contexts[ctxName] = ctx
+	config.CurrentContext = ctxName
+	return ctxName, nil
+}
+
+// WriteContextToFile writes the given context along with its cluster and user into a standalone kube config file
+func WriteContextToFile(config *api.Config, ctxName string, fileName string) error {
+	ctx := config.Contexts[ctxName]
+	if ctx == nil {
+		return fmt.Errorf("could not find Kubernetes context %s", ctxName)
+	}
+	out := api.NewConfig()
+	out.Contexts[ctxName] = ctx.DeepCopy()
+	if cluster := config.Clusters[ctx.Cluster]; cluster != nil {
+		out.Clusters[ctx.Cluster] = cluster.DeepCopy()
+	}
+	if authInfo := config.AuthInfos[ctx.AuthInfo]; authInfo != nil {
+		out.AuthInfos[ctx.AuthInfo] = authInfo.DeepCopy()
+	}
+	out.CurrentContext = ctxName
+	err := clientcmd.WriteToFile(*out, fileName)
+	if err != nil {
+		return errors.Wrapf(err, "writing kube config file %s", fileName)
+	}
+	return nil
+}
+
 // LoadConfig loads the Kubernetes configuration
 func (k *KubeConfig) LoadConfig() (*api.Config, *clientcmd.PathOptions, error) {
 	po := clientcmd.NewDefaultPathOptions()
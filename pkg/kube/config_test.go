@@ -0,0 +1,99 @@
+package kube_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+func createTestKubeConfig() *api.Config {
+	config := api.NewConfig()
+	config.Clusters["gke_myproject_europe-west1-b_cheese"] = &api.Cluster{Server: "https://1.2.3.4"}
+	config.AuthInfos["gke_myproject_europe-west1-b_cheese"] = &api.AuthInfo{Token: "abc"}
+	config.Contexts["gke_myproject_europe-west1-b_cheese"] = &api.Context{
+		Cluster:   "gke_myproject_europe-west1-b_cheese",
+		AuthInfo:  "gke_myproject_europe-west1-b_cheese",
+		Namespace: "default",
+	}
+	config.Contexts["minikube"] = &api.Context{Cluster: "minikube", AuthInfo: "minikube"}
+	config.CurrentContext = "gke_myproject_europe-west1-b_cheese"
+	return config
+}
+
+func TestJXContextName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "jx-cheese", kube.JXContextName("cheese"))
+	assert.Equal(t, "jx-cheese", kube.JXContextName("jx-cheese"))
+	assert.True(t, kube.IsJXContext("jx-cheese"))
+	assert.False(t, kube.IsJXContext("minikube"))
+}
+
+func TestAddJXContext(t *testing.T) {
+	t.Parallel()
+	config := createTestKubeConfig()
+
+	ctxName, err := kube.AddJXContext(config, "cheese", "jx")
+	require.NoError(t, err)
+
+	assert.Equal(t, "jx-cheese", ctxName)
+	assert.Equal(t, ctxName, config.CurrentContext)
+	assert.Equal(t, "jx", kube.CurrentNamespace(config))
+	assert.Equal(t, "https://1.2.3.4", kube.CurrentServer(config))
+	assert.Equal(t, "default", config.Contexts["gke_myproject_europe-west1-b_cheese"].Namespace, "original context should be unchanged")
+	assert.Equal(t, []string{"jx-cheese"}, kube.JXContextNames(config))
+}
+
+func TestWriteContextToFile(t *testing.T) {
+	t.Parallel()
+	config := createTestKubeConfig()
+	ctxName, err := kube.AddJXContext(config, "cheese", "jx")
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "test_kube_config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "config")
+	err = kube.WriteContextToFile(config, ctxName, fileName)
+	require.NoError(t, err)
+
+	loaded, err := clientcmd.LoadFromFile(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, ctxName, loaded.CurrentContext)
+	assert.Len(t, loaded.Contexts, 1)
+	assert.Len(t, loaded.Clusters, 1)
+	assert.Len(t, loaded.AuthInfos, 1)
+	assert.Equal(t, "jx", kube.CurrentNamespace(loaded))
+}
+
+func TestContextForCluster(t *testing.T) {
+	t.Parallel()
+	config := createTestKubeConfig()
+	config.Contexts["eks"] = &api.Context{Cluster: "wine.eu-west-1.eksctl.io"}
+	config.Contexts["aks"] = &api.Context{Cluster: "bread"}
+
+	assert.Equal(t, "gke_myproject_europe-west1-b_cheese", kube.ContextForCluster(config, "cheese"))
+	assert.Equal(t, "eks", kube.ContextForCluster(config, "wine"))
+	assert.Equal(t, "aks", kube.ContextForCluster(config, "bread"))
+	assert.Equal(t, "", kube.ContextForCluster(config, "chee"))
+	assert.Equal(t, "", kube.ContextForCluster(config, ""))
+}
+
+func TestFindContext(t *testing.T) {
+	t.Parallel()
+	config := createTestKubeConfig()
+	config.Contexts["jx-cheese"] = &api.Context{Cluster: "gke_myproject_europe-west1-b_cheese"}
+
+	assert.Equal(t, "jx-cheese", kube.FindContext(config, "cheese"))
+	assert.Equal(t, "jx-cheese", kube.FindContext(config, "jx-cheese"))
+	assert.Equal(t, "minikube", kube.FindContext(config, "minikube"))
+	assert.Equal(t, "", kube.FindContext(config, "wine"))
+	assert.Equal(t, "", kube.FindContext(config, ""))
+}
@@ -7,6 +7,9 @@ const (
 	// ChartAnchore the default chart for the Anchore plugin
 	ChartAnchore = "stable/anchore-engine"
 
+	// ChartArtifactory the default chart for the Artifactory artifact repository addon
+	ChartArtifactory = "jfrog/artifactory-oss"
+
 	// ChartCloudBees the default name of the CloudBees addon chart
 	ChartCloudBees = "cb/core"
 
@@ -16,15 +19,27 @@ const (
 	// ChartAnchore the default chart for the Anchore plugin
 	ChartPipelineEvent = "jenkins-x/pipeline-events-addon"
 
+	// ChartFlagger the default chart for the Flagger progressive delivery operator
+	ChartFlagger = "flagger/flagger"
+
 	// ChartGitea the default name of the gitea chart
 	ChartGitea = "jenkins-x/gitea"
 
 	// ChartIstio the default chart for the Istio chart
 	ChartIstio = "install/kubernetes/helm/istio"
 
+	// ChartKubePrometheusStack the default chart for the Prometheus and Grafana monitoring addon
+	ChartKubePrometheusStack = "prometheus-community/kube-prometheus-stack"
+
+	// DefaultKubePrometheusStackReleaseName the addon and release name of the monitoring addon which needs Helm 3
+	DefaultKubePrometheusStackReleaseName = "kube-prometheus-stack"
+
 	// ChartKubeless the default chart for kubeless
 	ChartKubeless = "incubator/kubeless"
 
+	// ChartOAuth2Proxy the default chart for the oauth2-proxy which authenticates the users of the web UIs
+	ChartOAuth2Proxy = "stable/oauth2-proxy"
+
 	// ChartProw the default chart for Prow
 	ChartProw = "jenkins-x/prow"
 
@@ -32,9 +47,34 @@ const (
 	ChartKnativeBuild   = "jenkins-x/knative-build"
 	ChartBuildTemplates = "jenkins-x/jx-build-templates"
 
+	// ChartGatekeeper the default chart for the Gatekeeper policy controller
+	ChartGatekeeper = "gatekeeper/gatekeeper"
+
+	// ChartSonarQube the default chart for the SonarQube static analysis addon
+	ChartSonarQube = "stable/sonarqube"
+
+	// ChartTrivy the default chart for the Trivy image scanning server
+	ChartTrivy = "aquasecurity/trivy"
+
+	// ChartTekton the default chart for the Tekton pipeline controller
+	ChartTekton = "jenkins-x/tekton"
+
+	// ChartVelero the default chart for the Velero backup addon
+	ChartVelero = "stable/velero"
+
+	DefaultArtifactoryReleaseName    = "artifactory"
 	DefaultProwReleaseName           = "jx-prow"
 	DefaultKnativeBuildReleaseName   = "knative-build"
 	DefaultBuildTemplatesReleaseName = "jx-build-templates"
+	DefaultTektonReleaseName         = "tekton"
+	DefaultFlaggerReleaseName        = "flagger"
+	DefaultSonarQubeReleaseName      = "sonarqube"
+	DefaultTrivyReleaseName          = "trivy"
+	DefaultVeleroReleaseName         = "velero"
+	DefaultVeleroNamespace           = "velero"
+	DefaultOAuth2ProxyReleaseName    = "oauth2-proxy"
+	DefaultGatekeeperReleaseName     = "gatekeeper"
+	DefaultGatekeeperNamespace       = "gatekeeper-system"
 
 	// Charts Single Sign-On addon
 	ChartSsoOperator              = "jenkinsxio/sso-operator"
@@ -64,6 +104,15 @@ const (
 	// SecretJenkinsChartMuseum the chart museum secret
 	SecretJenkinsChartMuseum = "jenkins-x-chartmuseum"
 
+	// SecretSonarQube the secret containing the URLs and the token the pipelines use to access SonarQube
+	SecretSonarQube = "jx-sonarqube"
+
+	// SecretJenkinsMavenSettings the secret containing the Maven settings.xml mounted into the build pods
+	SecretJenkinsMavenSettings = "jenkins-maven-settings"
+
+	// SecretArtifactRepository the secret containing the credentials and the Gradle and npm settings of the artifact repository
+	SecretArtifactRepository = "jx-artifact-repository"
+
 	// SecretJenkinsReleaseGPG the GPG secrets for doing releases
 	SecretJenkinsReleaseGPG = "jenkins-release-gpg"
 
@@ -94,6 +143,23 @@ const (
 	// ConfigMapJenkinsPodTemplates is the ConfigMap containing all the Pod Templates available
 	ConfigMapJenkinsPodTemplates = "jenkins-x-pod-templates"
 
+	// ConfigMapJenkinsPodTemplateCustomisations is the ConfigMap containing the customisations of the team which are
+	// merged into the Pod Templates
+	ConfigMapJenkinsPodTemplateCustomisations = "jenkins-x-pod-template-customisations"
+
+	// SecretJenkinsDockerConfig is the Secret containing the Docker config.json with the registry credentials of the pipelines
+	SecretJenkinsDockerConfig = "jenkins-docker-cfg"
+
+	// DockerConfigMountPath the path the Docker config Secret is mounted at in the build pods
+	DockerConfigMountPath = "/home/jenkins/.docker"
+
+	// DockerSocketPath the path of the Docker socket on the nodes
+	DockerSocketPath = "/var/run/docker.sock"
+
+	// ImageBuilderInstallPath the path the init containers which install an image builder into the build pods copy
+	// its files to
+	ImageBuilderInstallPath = "/install"
+
 	// ConfigMapJenkinsTeamController is the ConfigMap containing the TeamController config files
 	ConfigMapJenkinsTeamController = "jenkins-x-team-controller"
 
@@ -103,6 +169,9 @@ const (
 	// ConfigMapNameJXInstallConfig is the ConfigMap containing the jx installation's CA and server url. Used by jx login
 	ConfigMapNameJXInstallConfig = "jx-install-config"
 
+	// ConfigMapTeamHelmValues is the ConfigMap containing the helm values shared by all the environments of a team
+	ConfigMapTeamHelmValues = "jx-team-helm-values"
+
 	// LocalHelmRepoName is the default name of the local chart repository where CI/CD releases go to
 	LocalHelmRepoName = "releases"
 
@@ -170,6 +239,9 @@ const (
 	// LabelUsername the user name owner of a namespace or resource
 	LabelUsername = "jenkins.io/user"
 
+	// LabelNamespaceName the name of a namespace so that NetworkPolicies can select it
+	LabelNamespaceName = "jenkins.io/namespace"
+
 	// ValueCreatedByJX for resources created by the Jenkins X CLI
 	ValueCreatedByJX = "jx"
 
@@ -179,6 +251,18 @@ const (
 	// ValueCredentialTypeUsernamePassword for user password credential secrets
 	ValueCredentialTypeUsernamePassword = "usernamePassword"
 
+	// ValueCredentialTypeSecretText for secret text credential secrets
+	ValueCredentialTypeSecretText = "secretText"
+
+	// LabelPipelineSecret indicates a Secret is made available to the pipelines of the team
+	LabelPipelineSecret = "jenkins.io/pipeline-secret"
+
+	// ValuePipelineSecretEnv the values of the pipeline Secret are injected as environment variables
+	ValuePipelineSecretEnv = "env"
+
+	// ValuePipelineSecretFile the values of the pipeline Secret are mounted as files
+	ValuePipelineSecretFile = "file"
+
 	// LabelTeam indicates the team name an environment belongs to
 	LabelTeam = "team"
 
@@ -188,6 +272,12 @@ const (
 	// LabelValueDevEnvironment is the value of the LabelTeam label for Development environments (system namespace)
 	LabelValueDevEnvironment = "dev"
 
+	// LabelApp the name of the application a resource belongs to
+	LabelApp = "app"
+
+	// LabelVersion the version of the application a resource belongs to
+	LabelVersion = "version"
+
 	// LabelJobKind the kind of job
 	LabelJobKind = "jenkins.io/job-kind"
 
@@ -213,10 +303,26 @@ const (
 	// AnnotationCredentialsDescription the description text for a Credential on a Secret
 	AnnotationCredentialsDescription = "jenkins.io/credentials-description"
 
+	// AnnotationPipelineSecretRepositories the comma separated repositories of the form owner/name or owner/* whose
+	// pipelines can use a pipeline Secret. All the pipelines can use the Secret if there are no repositories
+	AnnotationPipelineSecretRepositories = "jenkins.io/pipeline-secret-repositories"
+
+	// AnnotationPodTemplateCustomised marks the Jenkins pod templates which the customisations of the team have been
+	// applied to so that they are told apart from the pod templates replaced by an upgrade
+	AnnotationPodTemplateCustomised = "jenkins.io/pod-template-customised"
+
 	// AnnotationWorkingDir the working directory, such as for a DevPod
 	AnnotationWorkingDir = "jenkins.io/working-dir"
 	// AnnotationLocalDir the local directory that is sync'd to the DevPod
 	AnnotationLocalDir = "jenkins.io/local-dir"
+	// AnnotationDevPodIdleTimeout the duration after which an idle DevPod is deleted
+	AnnotationDevPodIdleTimeout = "jenkins.io/devpod-idle-timeout"
+	// AnnotationDevPodLastActivity the time a user last connected to or synchronised with the DevPod
+	AnnotationDevPodLastActivity = "jenkins.io/devpod-last-activity"
+	// AnnotationDevPodIDE the IDE attached to the DevPod
+	AnnotationDevPodIDE = "jenkins.io/devpod-ide"
+	// AnnotationDevPodSyncMode the tool synchronising the local directory to the DevPod
+	AnnotationDevPodSyncMode = "jenkins.io/devpod-sync-mode"
 
 	// AnnotationIsDefaultStorageClass used to indicate a storageclass is default
 	AnnotationIsDefaultStorageClass = "storageclass.kubernetes.io/is-default-class"
@@ -227,6 +333,9 @@ const (
 	// SecretDataPassword the password in a Secret/Credentials
 	SecretDataPassword = "password"
 
+	// SecretDataText the text of a secret text Secret/Credentials
+	SecretDataText = "text"
+
 	// SecretBasicAuth the name for the Jenkins X basic auth secret
 	SecretBasicAuth = "jx-basic-auth"
 
@@ -243,15 +352,23 @@ var (
 	AddonCharts = map[string]string{
 		"ambassador":                    ChartAmbassador,
 		"anchore":                       ChartAnchore,
+		DefaultArtifactoryReleaseName:   ChartArtifactory,
 		"cb":                            ChartCloudBees,
 		"gitea":                         ChartGitea,
+		DefaultFlaggerReleaseName:       ChartFlagger,
+		DefaultGatekeeperReleaseName:    ChartGatekeeper,
 		"istio":                         ChartIstio,
 		"kubeless":                      ChartKubeless,
 		"prometheus":                    "stable/prometheus",
+		"kube-prometheus-stack":         ChartKubePrometheusStack,
+		DefaultSonarQubeReleaseName:     ChartSonarQube,
+		DefaultTrivyReleaseName:         ChartTrivy,
+		DefaultVeleroReleaseName:        ChartVelero,
 		"grafana":                       "stable/grafana",
 		"jx-build-templates":            "jenkins-x/jx-build-templates",
 		DefaultProwReleaseName:          ChartProw,
 		DefaultKnativeBuildReleaseName:  ChartKnativeBuild,
+		DefaultTektonReleaseName:        ChartTekton,
 		DefaultSsoDexReleaseName:        ChartSsoDex,
 		DefaultSsoOperatorReleaseName:   ChartSsoOperator,
 		DefaultVaultOperatorReleaseName: ChartVaultOperator,
@@ -261,5 +378,6 @@ var (
 		"anchore":         "anchore-anchore-engine",
 		"pipeline-events": "jx-pipeline-events-elasticsearch-client",
 		"grafana":         "grafana",
+		"sonarqube":       "sonarqube-sonarqube",
 	}
 )
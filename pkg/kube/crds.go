@@ -22,8 +22,26 @@ const (
 	CertmanagerCertificateStaging = "letsencrypt-staging"
 	CertmanagerIssuerProd         = "letsencrypt-prod"
 	CertmanagerIssuerStaging      = "letsencrypt-staging"
+	CertmanagerIssuerSelfSigned   = "selfsigned"
+
+	// CertmanagerCertificateWildcard the name of the wildcard Certificate of the domain
+	CertmanagerCertificateWildcard = "wildcard"
+	// CertmanagerWildcardSecret the name of the Secret the wildcard certificate is stored in
+	CertmanagerWildcardSecret = "tls-wildcard"
 )
 
+// DNS01Provider configures the DNS-01 challenge of the LetsEncrypt ClusterIssuer used for wildcard certificates
+type DNS01Provider struct {
+	// Name is the name of the provider which is either clouddns or route53
+	Name string
+	// Project is the Google Cloud project of the clouddns provider
+	Project string
+	// ServiceAccountSecret is the name of the Secret with the Google Cloud credentials of the clouddns provider
+	ServiceAccountSecret string
+	// Region is the AWS region of the route53 provider
+	Region string
+}
+
 // RegisterAllCRDs ensures that all Jenkins-X CRDs are registered
 func RegisterAllCRDs(apiClient apiextensionsclientset.Interface) error {
 	err := RegisterBuildPackCRD(apiClient)
@@ -58,6 +76,10 @@ func RegisterAllCRDs(apiClient apiextensionsclientset.Interface) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to register the Git Service CRD")
 	}
+	err = RegisterImageScanCRD(apiClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to register the Image Scan CRD")
+	}
 	err = RegisterPipelineActivityCRD(apiClient)
 	if err != nil {
 		return errors.Wrap(err, "failed to register the Pipeline Activity CRD")
@@ -176,6 +198,34 @@ func RegisterGitServiceCRD(apiClient apiextensionsclientset.Interface) error {
 	return RegisterCRD(apiClient, name, names, columns, &validation, jenkinsio.GroupName)
 }
 
+// RegisterImageScanCRD ensures that the CRD is registered for ImageScan
+func RegisterImageScanCRD(apiClient apiextensionsclientset.Interface) error {
+	name := "imagescans." + jenkinsio.GroupName
+	names := &v1beta1.CustomResourceDefinitionNames{
+		Kind:       "ImageScan",
+		ListKind:   "ImageScanList",
+		Plural:     "imagescans",
+		Singular:   "imagescan",
+		ShortNames: []string{"scan", "scans"},
+	}
+	columns := []v1beta1.CustomResourceColumnDefinition{
+		{
+			Name:        "Image",
+			Type:        "string",
+			Description: "The image which was scanned",
+			JSONPath:    ".spec.image",
+		},
+		{
+			Name:        "Scanner",
+			Type:        "string",
+			Description: "The scanner which found the vulnerabilities",
+			JSONPath:    ".spec.scanner",
+		},
+	}
+	validation := v1beta1.CustomResourceValidation{}
+	return RegisterCRD(apiClient, name, names, columns, &validation, jenkinsio.GroupName)
+}
+
 // RegisterPipelineActivityCRD ensures that the CRD is registered for PipelineActivity
 func RegisterPipelineActivityCRD(apiClient apiextensionsclientset.Interface) error {
 	name := "pipelineactivities." + jenkinsio.GroupName
@@ -538,3 +588,93 @@ func CleanCertmanagerResources(c kubernetes.Interface, ns string, config Ingress
 
 	return nil
 }
+
+// CreateCertmanagerClusterIssuer creates, or recreates, the cert-manager ClusterIssuer of the given ingress config
+func CreateCertmanagerClusterIssuer(c kubernetes.Interface, config IngressConfig) error {
+	var issuer string
+	switch config.Issuer {
+	case CertmanagerIssuerProd:
+		issuer = fmt.Sprintf(certmanager.Cert_manager_cluster_issuer_prod, config.Email)
+	case CertmanagerIssuerSelfSigned:
+		issuer = certmanager.Cert_manager_cluster_issuer_self_signed
+	default:
+		return fmt.Errorf("unsupported ClusterIssuer %s", config.Issuer)
+	}
+	return recreateCertmanagerResource(c, "/apis/certmanager.k8s.io/v1alpha1/clusterissuers", "ClusterIssuer", config.Issuer, issuer)
+}
+
+// CreateCertmanagerClusterIssuerWithDNS01 recreates the LetsEncrypt production ClusterIssuer of the given ingress
+// config so that it can also solve DNS-01 challenges with the given DNS provider
+func CreateCertmanagerClusterIssuerWithDNS01(c kubernetes.Interface, config IngressConfig, provider DNS01Provider) error {
+	if config.Issuer != CertmanagerIssuerProd {
+		return fmt.Errorf("DNS-01 challenges are only supported with the ClusterIssuer %s not %s", CertmanagerIssuerProd, config.Issuer)
+	}
+	var dns01 string
+	switch provider.Name {
+	case "clouddns":
+		dns01 = fmt.Sprintf(certmanager.Cert_manager_dns01_clouddns, provider.Project, provider.ServiceAccountSecret)
+	case "route53":
+		dns01 = fmt.Sprintf(certmanager.Cert_manager_dns01_route53, provider.Region)
+	default:
+		return fmt.Errorf("unsupported DNS-01 provider %s", provider.Name)
+	}
+	issuer := fmt.Sprintf(certmanager.Cert_manager_cluster_issuer_prod_dns01, config.Email, dns01)
+	return recreateCertmanagerResource(c, "/apis/certmanager.k8s.io/v1alpha1/clusterissuers", "ClusterIssuer", config.Issuer, issuer)
+}
+
+// CreateCertmanagerWildcardCertificate creates, or recreates, the Certificate for all the subdomains of the domain
+// of the given ingress config in the given namespace. The certificate is stored in the CertmanagerWildcardSecret
+func CreateCertmanagerWildcardCertificate(c kubernetes.Interface, ns string, config IngressConfig, provider DNS01Provider) error {
+	cert := fmt.Sprintf(certmanager.Cert_manager_wildcard_certificate, CertmanagerCertificateWildcard, CertmanagerWildcardSecret,
+		config.Issuer, config.Domain, provider.Name, config.Domain)
+	uri := fmt.Sprintf("/apis/certmanager.k8s.io/v1alpha1/namespaces/%s/certificates", ns)
+	return recreateCertmanagerResource(c, uri, "Certificate", CertmanagerCertificateWildcard, cert)
+}
+
+// IsCertmanagerCertificateReady returns true if the given cert-manager Certificate has been issued
+func IsCertmanagerCertificateReady(c kubernetes.Interface, ns string, name string) (bool, error) {
+	uri := fmt.Sprintf("/apis/certmanager.k8s.io/v1alpha1/namespaces/%s/certificates", ns)
+	data, err := c.CoreV1().RESTClient().Get().RequestURI(uri).Name(name).DoRaw()
+	if err != nil {
+		return false, errors.Wrapf(err, "getting Certificate %s in namespace %s", name, ns)
+	}
+	cert := struct {
+		Status struct {
+			Conditions []struct {
+				Type   string `json:"type"`
+				Status string `json:"status"`
+			} `json:"conditions"`
+		} `json:"status"`
+	}{}
+	err = yaml.Unmarshal(data, &cert)
+	if err != nil {
+		return false, errors.Wrapf(err, "parsing Certificate %s in namespace %s", name, ns)
+	}
+	for _, condition := range cert.Status.Conditions {
+		if condition.Type == "Ready" {
+			return condition.Status == "True", nil
+		}
+	}
+	return false, nil
+}
+
+// recreateCertmanagerResource deletes the cert-manager resource with the given name if it exists then creates it
+// from the given YAML
+func recreateCertmanagerResource(c kubernetes.Interface, uri string, kind string, name string, resource string) error {
+	_, err := c.CoreV1().RESTClient().Get().RequestURI(uri).Name(name).DoRaw()
+	if err == nil {
+		resp, err := c.CoreV1().RESTClient().Delete().RequestURI(uri).Name(name).DoRaw()
+		if err != nil {
+			return fmt.Errorf("failed to delete %s %s %v: %s", kind, name, err, string(resp))
+		}
+	}
+	json, err := yaml.YAMLToJSON([]byte(resource))
+	if err != nil {
+		return errors.Wrapf(err, "converting %s %s to JSON", kind, name)
+	}
+	resp, err := c.CoreV1().RESTClient().Post().RequestURI(uri).Body(json).DoRaw()
+	if err != nil {
+		return fmt.Errorf("failed to create %s %s %v: %s", kind, name, err, string(resp))
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/jenkins-x/jx/pkg/log"
+	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/api/apps/v1beta1"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -170,3 +171,76 @@ func GetDeploymentPods(client kubernetes.Interface, name, namespace string) ([]v
 
 	return pods.Items, err
 }
+
+// FindAppDeployment returns the deployment of the app in the namespace looking it up by the helm release name first
+// then falling back to the app name in the labels of the deployments. Returns nil if there is no deployment
+func FindAppDeployment(client kubernetes.Interface, namespace string, releaseName string, app string) (*appsv1.Deployment, error) {
+	if releaseName != "" {
+		d, err := client.AppsV1().Deployments(namespace).Get(releaseName, metav1.GetOptions{})
+		if err == nil {
+			return d, nil
+		}
+	}
+	deployments, err := client.AppsV1().Deployments(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		if GetName(&d.ObjectMeta) == app {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+// IsDeploymentRolledOut returns true if all of the desired replicas of the deployment are updated and ready
+func IsDeploymentRolledOut(d *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	status := d.Status
+	return status.ObservedGeneration >= d.Generation && status.UpdatedReplicas >= replicas &&
+		status.ReadyReplicas >= replicas && status.Replicas == status.UpdatedReplicas
+}
+
+// FindAppStatefulSet returns the statefulset of the app in the namespace looking it up by the helm release name first
+// then falling back to the app name in the labels of the statefulsets. Returns nil if there is no statefulset
+func FindAppStatefulSet(client kubernetes.Interface, namespace string, releaseName string, app string) (*appsv1.StatefulSet, error) {
+	if releaseName != "" {
+		s, err := client.AppsV1().StatefulSets(namespace).Get(releaseName, metav1.GetOptions{})
+		if err == nil {
+			return s, nil
+		}
+	}
+	statefulSets, err := client.AppsV1().StatefulSets(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range statefulSets.Items {
+		s := &statefulSets.Items[i]
+		if GetName(&s.ObjectMeta) == app {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+// IsStatefulSetRolledOut returns true if all of the desired replicas of the statefulset are ready and running
+// its latest revision
+func IsStatefulSetRolledOut(s *appsv1.StatefulSet) bool {
+	replicas := int32(1)
+	if s.Spec.Replicas != nil {
+		replicas = *s.Spec.Replicas
+	}
+	status := s.Status
+	if status.ObservedGeneration < s.Generation || status.ReadyReplicas < replicas {
+		return false
+	}
+	// statefulsets using the OnDelete strategy are only updated when their pods are deleted
+	if s.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return true
+	}
+	return status.UpdatedReplicas >= replicas && (status.UpdateRevision == "" || status.CurrentRevision == status.UpdateRevision)
+}
@@ -86,3 +86,87 @@ func TestWaitForDeploymentToBeReady(t *testing.T) {
 	assert.NoError(t, err, "Should not error")
 
 }
+
+func TestFindAppDeployment(t *testing.T) {
+	t.Parallel()
+
+	ns := "jx-staging"
+	replicas := int32(2)
+	byRelease := &appsv1.Deployment{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "jx-staging-myapp",
+			Namespace: ns,
+		},
+	}
+	byLabel := &appsv1.Deployment{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "other",
+			Namespace: ns,
+			Labels: map[string]string{
+				"app": "jx-staging-otherapp",
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas:        2,
+			UpdatedReplicas: 2,
+			ReadyReplicas:   1,
+		},
+	}
+	client := kube_mocks.NewSimpleClientset(byRelease, byLabel)
+
+	d, err := kube.FindAppDeployment(client, ns, "jx-staging-myapp", "myapp")
+	assert.NoError(t, err)
+	assert.Equal(t, byRelease.Name, d.Name)
+
+	d, err = kube.FindAppDeployment(client, ns, "jx-staging-otherapp", "otherapp")
+	assert.NoError(t, err)
+	assert.Equal(t, byLabel.Name, d.Name)
+	assert.False(t, kube.IsDeploymentRolledOut(d))
+
+	d.Status.ReadyReplicas = 2
+	assert.True(t, kube.IsDeploymentRolledOut(d))
+
+	d, err = kube.FindAppDeployment(client, ns, "jx-staging-missing", "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, d)
+}
+
+func TestFindAppStatefulSet(t *testing.T) {
+	t.Parallel()
+
+	ns := "jx-staging"
+	replicas := int32(2)
+	s := &appsv1.StatefulSet{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "jx-staging-mydb",
+			Namespace: ns,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+		},
+		Status: appsv1.StatefulSetStatus{
+			Replicas:        2,
+			ReadyReplicas:   2,
+			UpdatedReplicas: 1,
+			CurrentRevision: "mydb-1",
+			UpdateRevision:  "mydb-2",
+		},
+	}
+	client := kube_mocks.NewSimpleClientset(s)
+
+	found, err := kube.FindAppStatefulSet(client, ns, "jx-staging-mydb", "mydb")
+	assert.NoError(t, err)
+	assert.Equal(t, s.Name, found.Name)
+	assert.False(t, kube.IsStatefulSetRolledOut(found))
+
+	found.Status.UpdatedReplicas = 2
+	found.Status.CurrentRevision = "mydb-2"
+	assert.True(t, kube.IsStatefulSetRolledOut(found))
+
+	found, err = kube.FindAppStatefulSet(client, ns, "jx-staging-missing", "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+}
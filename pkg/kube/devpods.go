@@ -0,0 +1,97 @@
+package kube
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/log"
+	"k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DevPodActivityInterval how often the activity of a DevPod which is in use is recorded
+const DevPodActivityInterval = 5 * time.Minute
+
+// GetDevPods returns the DevPods of all the users in the namespace
+func GetDevPods(client kubernetes.Interface, ns string) ([]v1.Pod, error) {
+	list, err := client.CoreV1().Pods(ns).List(meta_v1.ListOptions{
+		LabelSelector: LabelDevPodName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load DevPods %s", err)
+	}
+	return list.Items, nil
+}
+
+// DevPodIdleTimeout returns the duration after which the DevPod is deleted if idle or zero if it is never deleted
+func DevPodIdleTimeout(pod *v1.Pod) time.Duration {
+	if pod.Annotations == nil {
+		return 0
+	}
+	text := pod.Annotations[AnnotationDevPodIdleTimeout]
+	if text == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(text)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// DevPodLastActivity returns the time a user last used the DevPod defaulting to its creation time
+func DevPodLastActivity(pod *v1.Pod) time.Time {
+	if pod.Annotations != nil {
+		t, err := time.Parse(time.RFC3339, pod.Annotations[AnnotationDevPodLastActivity])
+		if err == nil {
+			return t
+		}
+	}
+	return pod.CreationTimestamp.Time
+}
+
+// IsDevPodIdle returns true if the DevPod has an idle timeout and has not been used for longer than it
+func IsDevPodIdle(pod *v1.Pod, now time.Time) bool {
+	timeout := DevPodIdleTimeout(pod)
+	if timeout <= 0 {
+		return false
+	}
+	return now.Sub(DevPodLastActivity(pod)) > timeout
+}
+
+// UpdateDevPodActivity records that the DevPod of the given name is being used so that it is not deleted as idle
+func UpdateDevPodActivity(client kubernetes.Interface, ns string, name string) error {
+	pods := client.CoreV1().Pods(ns)
+	pod, err := pods.Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[AnnotationDevPodLastActivity] = time.Now().UTC().Format(time.RFC3339)
+	_, err = pods.Update(pod)
+	if err != nil {
+		return fmt.Errorf("Failed to update the activity of DevPod %s: %s", name, err)
+	}
+	return nil
+}
+
+// KeepDevPodActive records the activity of the DevPod at every interval until the stop channel is closed so that a
+// DevPod which is in use for longer than its idle timeout is not deleted
+func KeepDevPodActive(client kubernetes.Interface, ns string, name string, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			err := UpdateDevPodActivity(client, ns, name)
+			if err != nil {
+				log.Warnf("%s\n", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package kube_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_mocks "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestIsDevPodIdle(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	created := meta_v1.NewTime(now.Add(-3 * time.Hour))
+	pod := &v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:              "james-maven",
+			CreationTimestamp: created,
+		},
+	}
+	assert.False(t, kube.IsDevPodIdle(pod, now), "DevPod without an idle timeout should never be idle")
+
+	pod.Annotations = map[string]string{kube.AnnotationDevPodIdleTimeout: "2h"}
+	assert.True(t, kube.IsDevPodIdle(pod, now), "DevPod unused since its creation should be idle")
+
+	pod.Annotations[kube.AnnotationDevPodLastActivity] = now.Add(-time.Hour).Format(time.RFC3339)
+	assert.False(t, kube.IsDevPodIdle(pod, now), "recently used DevPod should not be idle")
+
+	pod.Annotations[kube.AnnotationDevPodIdleTimeout] = "30m"
+	assert.True(t, kube.IsDevPodIdle(pod, now), "DevPod unused for longer than its timeout should be idle")
+}
+
+func TestUpdateDevPodActivity(t *testing.T) {
+	t.Parallel()
+
+	ns := "jx"
+	pod := &v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "james-maven",
+			Namespace: ns,
+			Labels: map[string]string{
+				kube.LabelDevPodName:     "james-maven",
+				kube.LabelDevPodUsername: "james",
+			},
+		},
+	}
+	client := kube_mocks.NewSimpleClientset(pod)
+
+	err := kube.UpdateDevPodActivity(client, ns, pod.Name)
+	assert.NoError(t, err)
+
+	updated, err := client.CoreV1().Pods(ns).Get(pod.Name, meta_v1.GetOptions{})
+	assert.NoError(t, err)
+	lastActivity := kube.DevPodLastActivity(updated)
+	assert.WithinDuration(t, time.Now(), lastActivity, time.Minute)
+}
@@ -182,8 +182,12 @@ func CreateEnvironmentSurvey(batchMode bool, authConfigSvc auth.ConfigService, d
 		}
 	}
 	if string(config.Spec.PromotionStrategy) != "" {
-		data.Spec.PromotionStrategy = config.Spec.PromotionStrategy
-	} else {
+		promotionStrategy, err := ParsePromotionStrategy(string(config.Spec.PromotionStrategy))
+		if err != nil {
+			return nil, err
+		}
+		data.Spec.PromotionStrategy = promotionStrategy
+	} else if !batchMode {
 		promoteValues := []string{
 			string(v1.PromotionStrategyTypeAutomatic),
 			string(v1.PromotionStrategyTypeManual),
@@ -216,32 +220,66 @@ func CreateEnvironmentSurvey(batchMode bool, authConfigSvc auth.ConfigService, d
 	} else {
 		order := data.Spec.Order
 		if order == 0 {
-			// TODO should we generate an order to default to last one?
-			order = 100
-		}
-		defaultValue := util.Int32ToA(order)
-		q := &survey.Input{
-			Message: "Order:",
-			Default: defaultValue,
-			Help:    "This number is used to sort Environments in sequential order, lowest first",
-		}
-		textValue := ""
-		err := survey.AskOne(q, &textValue, survey.Required, surveyOpts)
-		if err != nil {
-			return nil, err
+			// lets default to promoting to the new environment after the existing ones
+			var err error
+			order, err = NextEnvironmentOrder(jxClient, ns)
+			if err != nil {
+				return nil, err
+			}
 		}
-		if textValue != "" {
-			i, err := util.AtoInt32(textValue)
+		if batchMode {
+			data.Spec.Order = order
+		} else {
+			defaultValue := util.Int32ToA(order)
+			q := &survey.Input{
+				Message: "Order:",
+				Default: defaultValue,
+				Help:    "This number is used to sort Environments in sequential order, lowest first",
+			}
+			textValue := ""
+			err := survey.AskOne(q, &textValue, survey.Required, surveyOpts)
 			if err != nil {
-				return nil, fmt.Errorf("Failed to convert input '%s' to number: %s", textValue, err)
+				return nil, err
+			}
+			if textValue != "" {
+				i, err := util.AtoInt32(textValue)
+				if err != nil {
+					return nil, fmt.Errorf("Failed to convert input '%s' to number: %s", textValue, err)
+				}
+				data.Spec.Order = i
 			}
-			data.Spec.Order = i
 		}
 	}
 	_, gitProvider, err := CreateEnvGitRepository(batchMode, authConfigSvc, devEnv, data, config, forkEnvGitURL, envDir, gitRepoOptions, helmValues, prefix, git, in, out, errOut)
 	return gitProvider, err
 }
 
+// ParsePromotionStrategy returns the promotion strategy for the given value ignoring case or an error if it is not valid
+func ParsePromotionStrategy(value string) (v1.PromotionStrategyType, error) {
+	for _, s := range v1.PromotionStrategyTypeValues {
+		if strings.EqualFold(s, value) {
+			return v1.PromotionStrategyType(s), nil
+		}
+	}
+	return "", util.InvalidOption("promotion", value, v1.PromotionStrategyTypeValues)
+}
+
+// NextEnvironmentOrder returns the order for a new environment so that it is sorted after the existing permanent
+// environments
+func NextEnvironmentOrder(jxClient versioned.Interface, ns string) (int32, error) {
+	envs, err := jxClient.JenkinsV1().Environments(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("Failed to list the environments in namespace %s: %s", ns, err)
+	}
+	order := int32(0)
+	for _, env := range envs.Items {
+		if env.Spec.Kind.IsPermanent() && env.Spec.Order > order {
+			order = env.Spec.Order
+		}
+	}
+	return order + 100, nil
+}
+
 // CreateEnvGitRepository creates the git repository for the given Environment
 func CreateEnvGitRepository(batchMode bool, authConfigSvc auth.ConfigService, devEnv *v1.Environment, data *v1.Environment, config *v1.Environment, forkEnvGitURL string, envDir string, gitRepoOptions *gits.GitRepositoryOptions, helmValues config.HelmValuesConfig, prefix string, git gits.Gitter, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) (*gits.GitRepository, gits.GitProvider, error) {
 	var gitProvider gits.GitProvider
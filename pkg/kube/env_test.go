@@ -243,3 +243,40 @@ func TestCreateEnvironmentSurvey(t *testing.T) {
 	// Dump the terminal's screen.
 	t.Log(expect.StripTrailingEmptyLines(console.CurrentState()))
 }
+
+func TestParsePromotionStrategy(t *testing.T) {
+	t.Parallel()
+
+	strategy, err := kube.ParsePromotionStrategy("manual")
+	assert.NoError(t, err)
+	assert.Equal(t, jenkinsio_v1.PromotionStrategyTypeManual, strategy)
+
+	strategy, err = kube.ParsePromotionStrategy("Auto")
+	assert.NoError(t, err)
+	assert.Equal(t, jenkinsio_v1.PromotionStrategyTypeAutomatic, strategy)
+
+	_, err = kube.ParsePromotionStrategy("sometimes")
+	assert.Error(t, err)
+}
+
+func TestNextEnvironmentOrder(t *testing.T) {
+	t.Parallel()
+
+	ns := "jx"
+	staging := kube.NewPermanentEnvironment("staging")
+	staging.Spec.Order = 100
+	production := kube.NewPermanentEnvironment("production")
+	production.Spec.Order = 200
+	preview := kube.NewPreviewEnvironment("preview")
+	preview.Spec.Order = 999
+
+	jxClient := versiond_mocks.NewSimpleClientset()
+	order, err := kube.NextEnvironmentOrder(jxClient, ns)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(100), order)
+
+	jxClient = versiond_mocks.NewSimpleClientset(staging, production, preview)
+	order, err = kube.NextEnvironmentOrder(jxClient, ns)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(300), order)
+}
@@ -0,0 +1,62 @@
+package kube
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"k8s.io/api/core/v1"
+)
+
+// nodePressureConditions the node conditions which are a problem when true
+var nodePressureConditions = []v1.NodeConditionType{
+	v1.NodeMemoryPressure,
+	v1.NodeDiskPressure,
+	v1.NodeNetworkUnavailable,
+}
+
+// NodeProblems returns the problems of the node such as not being ready or running low on memory
+func NodeProblems(node *v1.Node) []string {
+	answer := []string{}
+	ready := false
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			ready = condition.Status == v1.ConditionTrue
+			continue
+		}
+		for _, pressure := range nodePressureConditions {
+			if condition.Type == pressure && condition.Status == v1.ConditionTrue {
+				answer = append(answer, string(condition.Type))
+			}
+		}
+	}
+	if !ready {
+		answer = append([]string{"NotReady"}, answer...)
+	}
+	if node.Spec.Unschedulable {
+		answer = append(answer, "Unschedulable")
+	}
+	return answer
+}
+
+// CertificateExpiry returns when the first certificate in the PEM encoded data, such as the tls.crt of a TLS
+// secret, expires
+func CertificateExpiry(data []byte) (time.Time, error) {
+	for len(data) > 0 {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return cert.NotAfter, nil
+	}
+	return time.Time{}, fmt.Errorf("no PEM encoded certificate found")
+}
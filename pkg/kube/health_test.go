@@ -0,0 +1,59 @@
+package kube_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/core/v1"
+)
+
+func TestNodeProblems(t *testing.T) {
+	t.Parallel()
+	node := &v1.Node{
+		Status: v1.NodeStatus{
+			Conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionTrue},
+				{Type: v1.NodeMemoryPressure, Status: v1.ConditionFalse},
+				{Type: v1.NodeDiskPressure, Status: v1.ConditionFalse},
+			},
+		},
+	}
+	assert.Empty(t, kube.NodeProblems(node))
+
+	node.Status.Conditions[0].Status = v1.ConditionFalse
+	node.Status.Conditions[2].Status = v1.ConditionTrue
+	node.Spec.Unschedulable = true
+	assert.Equal(t, []string{"NotReady", "DiskPressure", "Unschedulable"}, kube.NodeProblems(node))
+}
+
+func TestCertificateExpiry(t *testing.T) {
+	t.Parallel()
+	notAfter := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "jenkins.jx.example.com"},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	expiry, err := kube.CertificateExpiry(data)
+	require.NoError(t, err)
+	assert.True(t, notAfter.Equal(expiry), "expected %s but was %s", notAfter, expiry)
+
+	_, err = kube.CertificateExpiry([]byte("not a certificate"))
+	assert.Error(t, err)
+}
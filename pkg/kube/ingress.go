@@ -17,6 +17,12 @@ const (
 	TLS                    = "tls"
 	Issuer                 = "issuer"
 	Exposer                = "exposer"
+	ClusterIssuer          = "clusterissuer"
+	PathMode               = "pathmode"
+	TLSSecretName          = "tlssecretname"
+
+	// PathModePath exposes services using paths on the domain rather than a subdomain per service
+	PathModePath = "path"
 )
 
 type IngressConfig struct {
@@ -25,6 +31,13 @@ type IngressConfig struct {
 	Issuer  string `structs:"issuer" yaml:"issuer" json:"issuer"`
 	Exposer string `structs:"exposer" yaml:"exposer" json:"exposer"`
 	TLS     bool   `structs:"tls" yaml:"tls" json:"tls"`
+	// ClusterIssuer indicates the Issuer is a cert-manager ClusterIssuer rather than an Issuer in each namespace
+	ClusterIssuer bool `structs:"clusterissuer" yaml:"clusterissuer" json:"clusterissuer"`
+	// PathMode is PathModePath if services are exposed on paths of the domain rather than on subdomains
+	PathMode string `structs:"pathmode" yaml:"pathmode" json:"pathmode"`
+	// TLSSecretName the Secret of a wildcard certificate which all the ingresses use rather than requesting a
+	// certificate each
+	TLSSecretName string `structs:"tlssecretname" yaml:"tlssecretname" json:"tlssecretname"`
 }
 
 func GetIngress(client kubernetes.Interface, ns, name string) (string, error) {
@@ -69,6 +82,8 @@ func GetIngressConfig(c kubernetes.Interface, ns string) (IngressConfig, error)
 	ic.Email = data[Email]
 	ic.Exposer = data[Exposer]
 	ic.Issuer = data[Issuer]
+	ic.PathMode = data[PathMode]
+	ic.TLSSecretName = data[TLSSecretName]
 	tls, exists := data[TLS]
 
 	if exists {
@@ -79,6 +94,13 @@ func GetIngressConfig(c kubernetes.Interface, ns string) (IngressConfig, error)
 	} else {
 		ic.TLS = false
 	}
+	clusterIssuer, exists := data[ClusterIssuer]
+	if exists {
+		ic.ClusterIssuer, err = strconv.ParseBool(clusterIssuer)
+		if err != nil {
+			return ic, fmt.Errorf("failed to parse ClusterIssuer string %s to bool from %s: %v", clusterIssuer, IngressConfigConfigmap, err)
+		}
+	}
 	return ic, nil
 }
 
@@ -0,0 +1,87 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_mocks "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetIngressConfigWithClusterIssuer(t *testing.T) {
+	t.Parallel()
+
+	cm := &v1.ConfigMap{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      kube.IngressConfigConfigmap,
+			Namespace: "jx",
+		},
+		Data: map[string]string{
+			kube.Domain:        "example.com",
+			kube.Email:         "admin@example.com",
+			kube.Issuer:        kube.CertmanagerIssuerProd,
+			kube.TLS:           "true",
+			kube.ClusterIssuer: "true",
+			kube.Exposer:       "Ingress",
+		},
+	}
+	client := kube_mocks.NewSimpleClientset(cm)
+
+	ic, err := kube.GetIngressConfig(client, "jx")
+	require.NoError(t, err)
+	assert.True(t, ic.TLS)
+	assert.True(t, ic.ClusterIssuer)
+	assert.Equal(t, kube.CertmanagerIssuerProd, ic.Issuer)
+	assert.Equal(t, "admin@example.com", ic.Email)
+	assert.Equal(t, "", ic.PathMode)
+}
+
+func TestGetIngressConfigWithPathMode(t *testing.T) {
+	t.Parallel()
+
+	cm := &v1.ConfigMap{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      kube.IngressConfigConfigmap,
+			Namespace: "jx",
+		},
+		Data: map[string]string{
+			kube.Domain:   "example.com",
+			kube.TLS:      "false",
+			kube.Exposer:  "Ingress",
+			kube.PathMode: kube.PathModePath,
+		},
+	}
+	client := kube_mocks.NewSimpleClientset(cm)
+
+	ic, err := kube.GetIngressConfig(client, "jx")
+	require.NoError(t, err)
+	assert.False(t, ic.TLS)
+	assert.Equal(t, "example.com", ic.Domain)
+	assert.Equal(t, kube.PathModePath, ic.PathMode)
+}
+
+func TestGetIngressConfigWithTLSSecretName(t *testing.T) {
+	t.Parallel()
+
+	cm := &v1.ConfigMap{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      kube.IngressConfigConfigmap,
+			Namespace: "jx",
+		},
+		Data: map[string]string{
+			kube.Domain:        "example.com",
+			kube.TLS:           "true",
+			kube.Exposer:       "Ingress",
+			kube.TLSSecretName: kube.CertmanagerWildcardSecret,
+		},
+	}
+	client := kube_mocks.NewSimpleClientset(cm)
+
+	ic, err := kube.GetIngressConfig(client, "jx")
+	require.NoError(t, err)
+	assert.True(t, ic.TLS)
+	assert.Equal(t, kube.CertmanagerWildcardSecret, ic.TLSSecretName)
+}
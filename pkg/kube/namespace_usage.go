@@ -0,0 +1,160 @@
+package kube
+
+import (
+	"time"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NamespaceUsage the total resources requested and limited by the running pods of a namespace
+type NamespaceUsage struct {
+	Namespace      string
+	Pods           int
+	CPURequests    resource.Quantity
+	CPULimits      resource.Quantity
+	MemoryRequests resource.Quantity
+	MemoryLimits   resource.Quantity
+	// LastPodCreated the time the most recent pod of the namespace was created
+	LastPodCreated time.Time
+	// Quota the hard limits of the resource quotas of the namespace if it has any
+	Quota v1.ResourceList
+}
+
+// ClusterCapacity the total allocatable resources of the nodes of the cluster and the resources requested from them
+type ClusterCapacity struct {
+	Nodes  int
+	CPU    resource.Quantity
+	Memory resource.Quantity
+	// CPURequests the CPU requested by the pods of all the namespaces which have not terminated
+	CPURequests resource.Quantity
+	// MemoryRequests the memory requested by the pods of all the namespaces which have not terminated
+	MemoryRequests resource.Quantity
+}
+
+// GetNamespaceUsage returns the resources used by the pods of the namespace which have not terminated along with
+// the resource quota of the namespace
+func GetNamespaceUsage(client kubernetes.Interface, ns string) (*NamespaceUsage, error) {
+	usage := &NamespaceUsage{
+		Namespace: ns,
+	}
+	quotas, err := client.CoreV1().ResourceQuotas(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return usage, err
+	}
+	for _, quota := range quotas.Items {
+		// when there are several quotas the most restrictive one applies
+		for name, hard := range quota.Spec.Hard {
+			if usage.Quota == nil {
+				usage.Quota = v1.ResourceList{}
+			}
+			current, ok := usage.Quota[name]
+			if !ok || hard.Cmp(current) < 0 {
+				usage.Quota[name] = hard
+			}
+		}
+	}
+	running, err := runningPods(client, ns)
+	if err != nil {
+		return usage, err
+	}
+	for _, pod := range running.Items {
+		if pod.CreationTimestamp.Time.After(usage.LastPodCreated) {
+			usage.LastPodCreated = pod.CreationTimestamp.Time
+		}
+	}
+	usage.Pods = len(running.Items)
+	reqs, limits := getPodsTotalRequestsAndLimits(running)
+	usage.CPURequests = reqs[v1.ResourceCPU]
+	usage.CPULimits = limits[v1.ResourceCPU]
+	usage.MemoryRequests = reqs[v1.ResourceMemory]
+	usage.MemoryLimits = limits[v1.ResourceMemory]
+	return usage, nil
+}
+
+// GetClusterCapacity returns the allocatable resources of all the nodes of the cluster and the resources requested by
+// the pods of all the namespaces
+func GetClusterCapacity(client kubernetes.Interface) (*ClusterCapacity, error) {
+	capacity := &ClusterCapacity{}
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return capacity, err
+	}
+	for _, node := range nodes.Items {
+		allocatable := node.Status.Capacity
+		if len(node.Status.Allocatable) > 0 {
+			allocatable = node.Status.Allocatable
+		}
+		capacity.Nodes++
+		capacity.CPU.Add(*allocatable.Cpu())
+		capacity.Memory.Add(*allocatable.Memory())
+	}
+	running, err := runningPods(client, metav1.NamespaceAll)
+	if err != nil {
+		return capacity, err
+	}
+	reqs, _ := getPodsTotalRequestsAndLimits(running)
+	capacity.CPURequests = reqs[v1.ResourceCPU]
+	capacity.MemoryRequests = reqs[v1.ResourceMemory]
+	return capacity, nil
+}
+
+// runningPods returns the pods of the namespace which have not terminated
+func runningPods(client kubernetes.Interface, ns string) (*v1.PodList, error) {
+	pods, err := client.CoreV1().Pods(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	running := &v1.PodList{}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		running.Items = append(running.Items, pod)
+	}
+	return running, nil
+}
+
+// IsOverCommitted returns true if the pods of the whole cluster request more resources than its nodes can allocate so
+// that some of them cannot be scheduled
+func (c *ClusterCapacity) IsOverCommitted() bool {
+	return c.CPURequests.Cmp(c.CPU) > 0 || c.MemoryRequests.Cmp(c.Memory) > 0
+}
+
+// IsOverCommitted returns true if the requests or limits of the namespace exceed its resource quota or, when the
+// namespace has no quota, if the cluster is over committed
+func (u *NamespaceUsage) IsOverCommitted(capacity *ClusterCapacity) bool {
+	if len(u.Quota) == 0 {
+		return capacity.IsOverCommitted()
+	}
+	return exceedsQuota(u.CPURequests, u.Quota, v1.ResourceRequestsCPU, v1.ResourceCPU) ||
+		exceedsQuota(u.MemoryRequests, u.Quota, v1.ResourceRequestsMemory, v1.ResourceMemory) ||
+		exceedsQuota(u.CPULimits, u.Quota, v1.ResourceLimitsCPU) ||
+		exceedsQuota(u.MemoryLimits, u.Quota, v1.ResourceLimitsMemory)
+}
+
+// exceedsQuota returns true if the quantity exceeds any of the given resources of the quota
+func exceedsQuota(quantity resource.Quantity, quota v1.ResourceList, names ...v1.ResourceName) bool {
+	for _, name := range names {
+		hard, ok := quota[name]
+		if ok && quantity.Cmp(hard) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// IsIdle returns true if no pod has been created in the namespace for longer than the idle duration
+func (u *NamespaceUsage) IsIdle(idle time.Duration, now time.Time) bool {
+	return u.LastPodCreated.Add(idle).Before(now)
+}
+
+// PercentOf returns the given quantity as a percentage of the total
+func PercentOf(quantity resource.Quantity, total resource.Quantity) int {
+	if total.MilliValue() == 0 {
+		return 0
+	}
+	return int(float64(quantity.MilliValue()) / float64(total.MilliValue()) * 100)
+}
@@ -0,0 +1,112 @@
+package kube_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_mocks "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetNamespaceUsage(t *testing.T) {
+	t.Parallel()
+
+	ns := "jx-staging"
+	now := time.Now()
+	newPod := func(name string, phase v1.PodPhase, created time.Time, cpu string, memory string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:              name,
+				Namespace:         ns,
+				CreationTimestamp: meta_v1.NewTime(created),
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Name: "app",
+						Resources: v1.ResourceRequirements{
+							Requests: v1.ResourceList{
+								v1.ResourceCPU:    resource.MustParse(cpu),
+								v1.ResourceMemory: resource.MustParse(memory),
+							},
+							Limits: v1.ResourceList{
+								v1.ResourceCPU:    resource.MustParse("1"),
+								v1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						},
+					},
+				},
+			},
+			Status: v1.PodStatus{
+				Phase: phase,
+			},
+		}
+	}
+	node := &v1.Node{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: "node1",
+		},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("1500m"),
+				v1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
+	}
+	client := kube_mocks.NewSimpleClientset(
+		newPod("app1", v1.PodRunning, now.Add(-2*time.Hour), "100m", "256Mi"),
+		newPod("app2", v1.PodRunning, now.Add(-time.Hour), "200m", "256Mi"),
+		newPod("job", v1.PodSucceeded, now, "500m", "1Gi"),
+		node,
+	)
+
+	usage, err := kube.GetNamespaceUsage(client, ns)
+	require.NoError(t, err)
+	assert.Equal(t, 2, usage.Pods)
+	assert.Equal(t, int64(300), usage.CPURequests.MilliValue())
+	assert.Equal(t, "512Mi", usage.MemoryRequests.String())
+	assert.Equal(t, int64(2000), usage.CPULimits.MilliValue())
+
+	capacity, err := kube.GetClusterCapacity(client)
+	require.NoError(t, err)
+	assert.Equal(t, 1, capacity.Nodes)
+	assert.Equal(t, 20, kube.PercentOf(usage.CPURequests, capacity.CPU))
+	assert.Equal(t, int64(300), capacity.CPURequests.MilliValue())
+	assert.False(t, usage.IsOverCommitted(capacity), "the requests of the cluster do not exceed its capacity")
+
+	capacity.CPURequests = resource.MustParse("2")
+	assert.True(t, usage.IsOverCommitted(capacity), "the requests of the cluster exceed its capacity")
+
+	assert.False(t, usage.IsIdle(2*time.Hour, now))
+	assert.True(t, usage.IsIdle(30*time.Minute, now))
+
+	quota := &v1.ResourceQuota{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "compute",
+			Namespace: ns,
+		},
+		Spec: v1.ResourceQuotaSpec{
+			Hard: v1.ResourceList{
+				v1.ResourceRequestsCPU: resource.MustParse("1"),
+				v1.ResourceLimitsCPU:   resource.MustParse("4"),
+			},
+		},
+	}
+	_, err = client.CoreV1().ResourceQuotas(ns).Create(quota)
+	require.NoError(t, err)
+	usage, err = kube.GetNamespaceUsage(client, ns)
+	require.NoError(t, err)
+	assert.False(t, usage.IsOverCommitted(capacity), "the namespace is within its quota")
+
+	quota.Spec.Hard[v1.ResourceLimitsCPU] = resource.MustParse("1500m")
+	_, err = client.CoreV1().ResourceQuotas(ns).Update(quota)
+	require.NoError(t, err)
+	usage, err = kube.GetNamespaceUsage(client, ns)
+	require.NoError(t, err)
+	assert.True(t, usage.IsOverCommitted(capacity), "the CPU limits exceed the quota of the namespace")
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -33,8 +34,17 @@ func EnsureEnvironmentNamespaceSetup(kubeClient kubernetes.Interface, jxClient v
 	if err != nil {
 		return err
 	}
-	_, err = EnsureDevEnvironmentSetup(jxClient, ns)
-	return err
+	devEnv, err := EnsureDevEnvironmentSetup(jxClient, ns)
+	if err != nil {
+		return err
+	}
+	if spec.Cluster == "" && spec.Namespace != "" && spec.Namespace != ns {
+		err = EnsureNetworkPolicies(kubeClient, spec.Namespace, ns, &devEnv.Spec.TeamSettings.NetworkPolicy)
+		if err != nil {
+			return errors.Wrapf(err, "failed to setup the NetworkPolicies of namespace %s", spec.Namespace)
+		}
+	}
+	return nil
 }
 
 // EnsureDevNamespaceCreatedWithoutEnvironment ensures that there is a development namespace created
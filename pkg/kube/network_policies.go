@@ -0,0 +1,170 @@
+package kube
+
+import (
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// NetworkPolicyDefaultDeny the name of the NetworkPolicy which denies all the ingress traffic into a namespace
+	NetworkPolicyDefaultDeny = "jx-default-deny"
+	// NetworkPolicyAllowSameNamespace the name of the NetworkPolicy which allows the traffic between the pods of a namespace
+	NetworkPolicyAllowSameNamespace = "jx-allow-same-namespace"
+	// NetworkPolicyAllowPlatform the name of the NetworkPolicy which allows the traffic from the ingress controller
+	// and the platform services of the team
+	NetworkPolicyAllowPlatform = "jx-allow-platform"
+
+	// DefaultIngressNamespace the default namespace of the ingress controller
+	DefaultIngressNamespace = "kube-system"
+)
+
+// NetworkPolicyNames the names of the NetworkPolicies which isolate the namespaces of the environments
+var NetworkPolicyNames = []string{NetworkPolicyDefaultDeny, NetworkPolicyAllowSameNamespace, NetworkPolicyAllowPlatform}
+
+// NetworkPolicyAllowedNamespaces returns the namespaces allowed to access the namespaces of the environments
+// of the team in the given development namespace
+func NetworkPolicyAllowedNamespaces(devNs string, settings *v1.NetworkPolicySettings) []string {
+	ingressNs := settings.IngressNamespace
+	if ingressNs == "" {
+		ingressNs = DefaultIngressNamespace
+	}
+	answer := []string{devNs}
+	for _, ns := range append([]string{ingressNs}, settings.AllowedNamespaces...) {
+		if ns != "" && util.StringArrayIndex(answer, ns) < 0 {
+			answer = append(answer, ns)
+		}
+	}
+	return answer
+}
+
+// CreateNetworkPolicies creates the NetworkPolicies which deny all the ingress traffic into the given namespace
+// other than from its own pods and from the given namespaces
+func CreateNetworkPolicies(ns string, allowedNamespaces []string) []*networkingv1.NetworkPolicy {
+	labels := map[string]string{
+		LabelCreatedBy: ValueCreatedByJX,
+	}
+	allPods := metav1.LabelSelector{}
+	return []*networkingv1.NetworkPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      NetworkPolicyDefaultDeny,
+				Namespace: ns,
+				Labels:    labels,
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: allPods,
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      NetworkPolicyAllowSameNamespace,
+				Namespace: ns,
+				Labels:    labels,
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: allPods,
+				Ingress: []networkingv1.NetworkPolicyIngressRule{
+					{
+						From: []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}},
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      NetworkPolicyAllowPlatform,
+				Namespace: ns,
+				Labels:    labels,
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: allPods,
+				Ingress: []networkingv1.NetworkPolicyIngressRule{
+					{
+						From: []networkingv1.NetworkPolicyPeer{
+							{
+								NamespaceSelector: &metav1.LabelSelector{
+									MatchExpressions: []metav1.LabelSelectorRequirement{
+										{
+											Key:      LabelNamespaceName,
+											Operator: metav1.LabelSelectorOpIn,
+											Values:   allowedNamespaces,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// EnsureNetworkPolicies creates or updates the NetworkPolicies which isolate the given namespace of an environment
+// or preview if they are enabled in the settings of the team. Nothing is done if they are disabled.
+//
+// The allowed namespaces are labelled with their name which needs the permission to update namespaces. If it is
+// missing a warning explains how to label them instead
+func EnsureNetworkPolicies(kubeClient kubernetes.Interface, ns string, devNs string, settings *v1.NetworkPolicySettings) error {
+	if !settings.Enabled {
+		return nil
+	}
+	allowedNamespaces := NetworkPolicyAllowedNamespaces(devNs, settings)
+	// the namespaces are selected by a label as NetworkPolicies cannot select them by name
+	for _, name := range allowedNamespaces {
+		namespace, err := kubeClient.CoreV1().Namespaces().Get(name, metav1.GetOptions{})
+		if err != nil {
+			log.Warnf("Could not find the namespace %s allowed by the NetworkPolicies of namespace %s: %s\n", name, ns, err)
+			continue
+		}
+		if namespace.Labels[LabelNamespaceName] != name {
+			if namespace.Labels == nil {
+				namespace.Labels = map[string]string{}
+			}
+			namespace.Labels[LabelNamespaceName] = name
+			_, err = kubeClient.CoreV1().Namespaces().Update(namespace)
+			if apierrors.IsForbidden(err) {
+				log.Warnf("Not allowed to label the namespace %s so its traffic is denied until a cluster administrator runs: kubectl label namespace %s %s=%s\n", name, name, LabelNamespaceName, name)
+				continue
+			}
+			if err != nil {
+				return errors.Wrapf(err, "failed to label the namespace %s", name)
+			}
+		}
+	}
+
+	policies := kubeClient.NetworkingV1().NetworkPolicies(ns)
+	for _, policy := range CreateNetworkPolicies(ns, allowedNamespaces) {
+		existing, err := policies.Get(policy.Name, metav1.GetOptions{})
+		if err == nil {
+			existing.Labels = policy.Labels
+			existing.Spec = policy.Spec
+			_, err = policies.Update(existing)
+		} else if apierrors.IsNotFound(err) {
+			_, err = policies.Create(policy)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to save the NetworkPolicy %s in namespace %s", policy.Name, ns)
+		}
+	}
+	return nil
+}
+
+// DeleteNetworkPolicies removes the NetworkPolicies which isolate the given namespace
+func DeleteNetworkPolicies(kubeClient kubernetes.Interface, ns string) error {
+	policies := kubeClient.NetworkingV1().NetworkPolicies(ns)
+	for _, name := range NetworkPolicyNames {
+		err := policies.Delete(name, nil)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete the NetworkPolicy %s in namespace %s", name, ns)
+		}
+	}
+	return nil
+}
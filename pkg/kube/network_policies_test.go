@@ -0,0 +1,63 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNetworkPolicyAllowedNamespaces(t *testing.T) {
+	t.Parallel()
+	settings := &v1.NetworkPolicySettings{AllowedNamespaces: []string{"monitoring", "jx"}}
+	assert.Equal(t, []string{"jx", "kube-system", "monitoring"}, kube.NetworkPolicyAllowedNamespaces("jx", settings))
+
+	settings.IngressNamespace = "ingress-nginx"
+	assert.Equal(t, []string{"jx", "ingress-nginx", "monitoring"}, kube.NetworkPolicyAllowedNamespaces("jx", settings))
+}
+
+func TestEnsureNetworkPolicies(t *testing.T) {
+	t.Parallel()
+	kubeClient := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "jx"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "jx-staging"}},
+	)
+	settings := &v1.NetworkPolicySettings{Enabled: true}
+
+	err := kube.EnsureNetworkPolicies(kubeClient, "jx-staging", "jx", settings)
+	require.NoError(t, err)
+
+	policies, err := kubeClient.NetworkingV1().NetworkPolicies("jx-staging").List(metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, policies.Items, len(kube.NetworkPolicyNames))
+
+	platform, err := kubeClient.NetworkingV1().NetworkPolicies("jx-staging").Get(kube.NetworkPolicyAllowPlatform, metav1.GetOptions{})
+	require.NoError(t, err)
+	selector := platform.Spec.Ingress[0].From[0].NamespaceSelector
+	assert.Equal(t, []string{"jx", "kube-system"}, selector.MatchExpressions[0].Values)
+
+	ns, err := kubeClient.CoreV1().Namespaces().Get("kube-system", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "kube-system", ns.Labels[kube.LabelNamespaceName])
+
+	settings.Enabled = false
+	err = kube.EnsureNetworkPolicies(kubeClient, "jx-staging", "jx", settings)
+	require.NoError(t, err)
+
+	policies, err = kubeClient.NetworkingV1().NetworkPolicies("jx-staging").List(metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, policies.Items, len(kube.NetworkPolicyNames), "disabled NetworkPolicies should be left alone")
+
+	err = kube.DeleteNetworkPolicies(kubeClient, "jx-staging")
+	require.NoError(t, err)
+
+	policies, err = kubeClient.NetworkingV1().NetworkPolicies("jx-staging").List(metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, policies.Items)
+}
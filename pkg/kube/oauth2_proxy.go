@@ -0,0 +1,88 @@
+package kube
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/api/extensions/v1beta1"
+)
+
+const (
+	// AnnotationIngressAuthURL the URL the nginx ingress controller authenticates the requests of an ingress with
+	AnnotationIngressAuthURL = "nginx.ingress.kubernetes.io/auth-url"
+	// AnnotationIngressAuthSignin the URL the nginx ingress controller redirects unauthenticated users to
+	AnnotationIngressAuthSignin = "nginx.ingress.kubernetes.io/auth-signin"
+)
+
+// basicAuthIngressAnnotations the annotations of an ingress protected with basic authentication
+var basicAuthIngressAnnotations = []string{
+	"nginx.ingress.kubernetes.io/auth-type",
+	"nginx.ingress.kubernetes.io/auth-secret",
+	"nginx.ingress.kubernetes.io/auth-realm",
+}
+
+// OAuth2ProxyIngressAnnotations returns the annotations of an ingress which make the nginx ingress controller
+// authenticate its requests with the oauth2-proxy at the given URL
+func OAuth2ProxyIngressAnnotations(proxyURL string) map[string]string {
+	proxyURL = strings.TrimSuffix(proxyURL, "/")
+	return map[string]string{
+		AnnotationIngressAuthURL:    proxyURL + "/oauth2/auth",
+		AnnotationIngressAuthSignin: proxyURL + "/oauth2/start?rd=$scheme://$host$request_uri",
+	}
+}
+
+// ProtectIngressWithOAuth2Proxy replaces any basic authentication of the ingress with the oauth2-proxy at the given
+// URL returning true if the ingress was modified
+func ProtectIngressWithOAuth2Proxy(ingress *v1beta1.Ingress, proxyURL string) bool {
+	if ingress.Annotations == nil {
+		ingress.Annotations = map[string]string{}
+	}
+	return protectAnnotations(ingress.Annotations, proxyURL)
+}
+
+// ProtectServiceWithOAuth2Proxy replaces any basic authentication of the ingress which exposecontroller generates
+// for the service with the oauth2-proxy at the given URL returning true if the service was modified
+func ProtectServiceWithOAuth2Proxy(service *v1.Service, proxyURL string) bool {
+	if service.Annotations == nil {
+		service.Annotations = map[string]string{}
+	}
+	annotations := map[string]string{}
+	for _, line := range strings.Split(service.Annotations[AnnotationIngress], "\n") {
+		values := strings.SplitN(line, ":", 2)
+		if len(values) == 2 && strings.TrimSpace(values[0]) != "" {
+			annotations[strings.TrimSpace(values[0])] = strings.TrimSpace(values[1])
+		}
+	}
+	if !protectAnnotations(annotations, proxyURL) {
+		return false
+	}
+	keys := []string{}
+	for k := range annotations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	lines := []string{}
+	for _, k := range keys {
+		lines = append(lines, k+": "+annotations[k])
+	}
+	service.Annotations[AnnotationIngress] = strings.Join(lines, "\n")
+	return true
+}
+
+func protectAnnotations(annotations map[string]string, proxyURL string) bool {
+	modified := false
+	for _, k := range basicAuthIngressAnnotations {
+		if _, ok := annotations[k]; ok {
+			delete(annotations, k)
+			modified = true
+		}
+	}
+	for k, v := range OAuth2ProxyIngressAnnotations(proxyURL) {
+		if annotations[k] != v {
+			annotations[k] = v
+			modified = true
+		}
+	}
+	return modified
+}
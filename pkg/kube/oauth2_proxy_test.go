@@ -0,0 +1,49 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/api/core/v1"
+	"k8s.io/api/extensions/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProtectIngressWithOAuth2Proxy(t *testing.T) {
+	t.Parallel()
+	ingress := &v1beta1.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Annotations: map[string]string{
+				"kubernetes.io/ingress.class":             "nginx",
+				"nginx.ingress.kubernetes.io/auth-type":   "basic",
+				"nginx.ingress.kubernetes.io/auth-secret": "jx-basic-auth",
+			},
+		},
+	}
+
+	assert.True(t, kube.ProtectIngressWithOAuth2Proxy(ingress, "https://oauth2-proxy.jx.example.com/"))
+	assert.Equal(t, map[string]string{
+		"kubernetes.io/ingress.class":    "nginx",
+		kube.AnnotationIngressAuthURL:    "https://oauth2-proxy.jx.example.com/oauth2/auth",
+		kube.AnnotationIngressAuthSignin: "https://oauth2-proxy.jx.example.com/oauth2/start?rd=$scheme://$host$request_uri",
+	}, ingress.Annotations)
+
+	assert.False(t, kube.ProtectIngressWithOAuth2Proxy(ingress, "https://oauth2-proxy.jx.example.com"), "the ingress is already protected")
+}
+
+func TestProtectServiceWithOAuth2Proxy(t *testing.T) {
+	t.Parallel()
+	service := &v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Annotations: map[string]string{
+				kube.AnnotationIngress: "nginx.ingress.kubernetes.io/auth-type: basic\nnginx.ingress.kubernetes.io/auth-secret: jx-basic-auth\nnginx.ingress.kubernetes.io/proxy-body-size: 500m",
+			},
+		},
+	}
+
+	assert.True(t, kube.ProtectServiceWithOAuth2Proxy(service, "http://oauth2-proxy.jx.example.com"))
+	assert.Equal(t, "nginx.ingress.kubernetes.io/auth-signin: http://oauth2-proxy.jx.example.com/oauth2/start?rd=$scheme://$host$request_uri\n"+
+		"nginx.ingress.kubernetes.io/auth-url: http://oauth2-proxy.jx.example.com/oauth2/auth\n"+
+		"nginx.ingress.kubernetes.io/proxy-body-size: 500m", service.Annotations[kube.AnnotationIngress])
+}
@@ -0,0 +1,121 @@
+package kube
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// OIDCProviderGoogle the Google OIDC identity provider
+	OIDCProviderGoogle = "google"
+	// OIDCProviderAzure the Azure Active Directory OIDC identity provider
+	OIDCProviderAzure = "azure"
+	// OIDCProviderDex the Dex OIDC identity provider
+	OIDCProviderDex = "dex"
+
+	// GoogleOIDCIssuerURL the issuer URL of the Google identity provider
+	GoogleOIDCIssuerURL = "https://accounts.google.com"
+
+	// OIDCLoginPlugin the kubectl plugin which logs the user in to the OIDC identity provider and returns their
+	// token to kubectl via the exec credential protocol. See https://github.com/int128/kubelogin
+	OIDCLoginPlugin = "oidc-login"
+
+	execCredentialAPIVersion = "client.authentication.k8s.io/v1beta1"
+)
+
+// OIDCProviders the supported OIDC identity providers
+var OIDCProviders = []string{OIDCProviderGoogle, OIDCProviderAzure, OIDCProviderDex}
+
+// OIDCConfig the configuration of the OIDC identity provider the API server trusts
+type OIDCConfig struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	ExtraScopes  []string
+}
+
+// OIDCIssuerURL returns the default issuer URL of the given provider. Azure needs the tenant ID while
+// Dex has no default as it is hosted by the team
+func OIDCIssuerURL(provider string, tenant string) (string, error) {
+	switch provider {
+	case OIDCProviderGoogle:
+		return GoogleOIDCIssuerURL, nil
+	case OIDCProviderAzure:
+		if tenant == "" {
+			return "", errors.New("the tenant ID is required for the Azure identity provider")
+		}
+		return fmt.Sprintf("https://sts.windows.net/%s/", tenant), nil
+	case OIDCProviderDex:
+		return "", errors.New("the issuer URL is required for the Dex identity provider")
+	default:
+		return "", fmt.Errorf("unknown OIDC provider %s, supported providers are %s", provider, strings.Join(OIDCProviders, ", "))
+	}
+}
+
+// CreateOIDCKubeConfig creates a standalone kube config for the given user of the current cluster of the given
+// config. The user authenticates via the OIDC identity provider so that no admin credentials are shared. kubectl gets
+// the token of the user from the kubelogin exec credential plugin which logs them in via their browser
+func CreateOIDCKubeConfig(config *api.Config, user string, oidc *OIDCConfig, namespace string) (*api.Config, error) {
+	clusterName, cluster := CurrentCluster(config)
+	if cluster == nil || clusterName == "" {
+		return nil, errors.New("no cluster found in config")
+	}
+	if user == "" {
+		return nil, errors.New("no user specified")
+	}
+	if oidc.IssuerURL == "" {
+		return nil, errors.New("no OIDC issuer URL specified")
+	}
+	if oidc.ClientID == "" {
+		return nil, errors.New("no OIDC client ID specified")
+	}
+
+	args := []string{
+		OIDCLoginPlugin,
+		"get-token",
+		"--oidc-issuer-url=" + oidc.IssuerURL,
+		"--oidc-client-id=" + oidc.ClientID,
+	}
+	if oidc.ClientSecret != "" {
+		args = append(args, "--oidc-client-secret="+oidc.ClientSecret)
+	}
+	for _, scope := range oidc.ExtraScopes {
+		args = append(args, "--oidc-extra-scope="+scope)
+	}
+
+	// inline the certificate authority as the file of the admin is not available on the machine of the user
+	caData := cluster.CertificateAuthorityData
+	if len(caData) == 0 && cluster.CertificateAuthority != "" {
+		data, err := ioutil.ReadFile(cluster.CertificateAuthority)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read the certificate authority %s of cluster %s", cluster.CertificateAuthority, clusterName)
+		}
+		caData = data
+	}
+
+	out := api.NewConfig()
+	out.Clusters[clusterName] = &api.Cluster{
+		Server:                   cluster.Server,
+		CertificateAuthorityData: caData,
+		InsecureSkipTLSVerify:    cluster.InsecureSkipTLSVerify,
+	}
+	out.AuthInfos[user] = &api.AuthInfo{
+		Exec: &api.ExecConfig{
+			APIVersion: execCredentialAPIVersion,
+			Command:    "kubectl",
+			Args:       args,
+		},
+	}
+	ctxName := fmt.Sprintf("%s-%s", clusterName, ToValidName(user))
+	out.Contexts[ctxName] = &api.Context{
+		Cluster:   clusterName,
+		AuthInfo:  user,
+		Namespace: namespace,
+	}
+	out.CurrentContext = ctxName
+	return out, nil
+}
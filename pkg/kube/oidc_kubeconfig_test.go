@@ -0,0 +1,90 @@
+package kube_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCIssuerURL(t *testing.T) {
+	t.Parallel()
+	url, err := kube.OIDCIssuerURL(kube.OIDCProviderGoogle, "")
+	require.NoError(t, err)
+	assert.Equal(t, kube.GoogleOIDCIssuerURL, url)
+
+	url, err = kube.OIDCIssuerURL(kube.OIDCProviderAzure, "mytenant")
+	require.NoError(t, err)
+	assert.Equal(t, "https://sts.windows.net/mytenant/", url)
+
+	_, err = kube.OIDCIssuerURL(kube.OIDCProviderAzure, "")
+	assert.Error(t, err)
+	_, err = kube.OIDCIssuerURL(kube.OIDCProviderDex, "")
+	assert.Error(t, err)
+	_, err = kube.OIDCIssuerURL("cheese", "")
+	assert.Error(t, err)
+}
+
+func TestCreateOIDCKubeConfig(t *testing.T) {
+	t.Parallel()
+	config := createTestKubeConfig()
+	oidc := &kube.OIDCConfig{
+		IssuerURL:   kube.GoogleOIDCIssuerURL,
+		ClientID:    "myclient",
+		ExtraScopes: []string{"email", "profile"},
+	}
+
+	out, err := kube.CreateOIDCKubeConfig(config, "someone@corp.com", oidc, "jx")
+	require.NoError(t, err)
+
+	assert.Len(t, out.Clusters, 1)
+	assert.Len(t, out.AuthInfos, 1)
+	assert.Equal(t, "https://1.2.3.4", kube.CurrentServer(out))
+	assert.Equal(t, "jx", kube.CurrentNamespace(out))
+
+	authInfo := out.AuthInfos["someone@corp.com"]
+	require.NotNil(t, authInfo)
+	assert.Empty(t, authInfo.Token, "the admin credentials should not be copied")
+	assert.Nil(t, authInfo.AuthProvider)
+	require.NotNil(t, authInfo.Exec)
+	assert.Equal(t, "kubectl", authInfo.Exec.Command)
+	assert.Equal(t, []string{
+		kube.OIDCLoginPlugin,
+		"get-token",
+		"--oidc-issuer-url=" + kube.GoogleOIDCIssuerURL,
+		"--oidc-client-id=myclient",
+		"--oidc-extra-scope=email",
+		"--oidc-extra-scope=profile",
+	}, authInfo.Exec.Args)
+
+	_, err = kube.CreateOIDCKubeConfig(config, "someone@corp.com", &kube.OIDCConfig{IssuerURL: kube.GoogleOIDCIssuerURL}, "jx")
+	assert.Error(t, err)
+}
+
+func TestCreateOIDCKubeConfigInlinesCertificateAuthority(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-oidc-kubeconfig-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, ioutil.WriteFile(caFile, []byte("my-ca"), 0600))
+
+	config := createTestKubeConfig()
+	config.Clusters["gke_myproject_europe-west1-b_cheese"].CertificateAuthority = caFile
+	oidc := &kube.OIDCConfig{
+		IssuerURL: kube.GoogleOIDCIssuerURL,
+		ClientID:  "myclient",
+	}
+
+	out, err := kube.CreateOIDCKubeConfig(config, "someone@corp.com", oidc, "jx")
+	require.NoError(t, err)
+
+	cluster := out.Clusters["gke_myproject_europe-west1-b_cheese"]
+	require.NotNil(t, cluster)
+	assert.Equal(t, []byte("my-ca"), cluster.CertificateAuthorityData)
+	assert.Empty(t, cluster.CertificateAuthority, "the local file of the admin should not be referenced")
+}
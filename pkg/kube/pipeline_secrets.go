@@ -0,0 +1,135 @@
+package kube
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PipelineSecretsMountPath the directory which the pipeline secrets mounted as files are mounted in. Each secret
+// is mounted in a directory with the name of the secret containing a file for each of its keys
+const PipelineSecretsMountPath = "/home/jenkins/pipeline-secrets"
+
+var invalidEnvNameCharacters = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// GetPipelineSecrets returns the pipeline secrets which the pipelines of the given repository can use
+func GetPipelineSecrets(client kubernetes.Interface, ns string, owner string, repository string) ([]v1.Secret, error) {
+	list, err := client.CoreV1().Secrets(ns).List(meta_v1.ListOptions{
+		LabelSelector: LabelPipelineSecret,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the pipeline secrets in namespace %s", ns)
+	}
+	answer := []v1.Secret{}
+	for _, secret := range list.Items {
+		if IsPipelineSecretForRepository(&secret, owner, repository) {
+			answer = append(answer, secret)
+		}
+	}
+	return answer, nil
+}
+
+// FindGitCredentialsSecret returns the pipeline git credentials secret of the git server of the given URL or nil if
+// there is none
+func FindGitCredentialsSecret(secrets []v1.Secret, gitURL string) *v1.Secret {
+	u, err := url.Parse(gitURL)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+	for i := range secrets {
+		secret := &secrets[i]
+		if secret.Labels[LabelKind] != ValueKindGit {
+			continue
+		}
+		serverURL, err := url.Parse(secret.Annotations[AnnotationURL])
+		if err == nil && strings.EqualFold(serverURL.Host, u.Host) {
+			return secret
+		}
+	}
+	return nil
+}
+
+// IsPipelineSecretForRepository returns true if the pipelines of the given repository can use the pipeline secret
+func IsPipelineSecretForRepository(secret *v1.Secret, owner string, repository string) bool {
+	text := ""
+	if secret.Annotations != nil {
+		text = strings.TrimSpace(secret.Annotations[AnnotationPipelineSecretRepositories])
+	}
+	if text == "" {
+		return true
+	}
+	for _, repo := range strings.Split(text, ",") {
+		repo = strings.TrimSpace(repo)
+		if strings.EqualFold(repo, owner+"/"+repository) || strings.EqualFold(repo, owner+"/*") {
+			return true
+		}
+	}
+	return false
+}
+
+// PipelineSecretEnvName returns the name of the environment variable the key of a pipeline secret is injected as
+func PipelineSecretEnvName(key string) string {
+	return invalidEnvNameCharacters.ReplaceAllString(strings.ToUpper(key), "_")
+}
+
+// AddPipelineSecrets injects the pipeline secrets into the given containers either as environment variables or as
+// files depending on the label of each secret, returning the volumes with the volumes of the secret files added
+func AddPipelineSecrets(containers []v1.Container, volumes []v1.Volume, secrets []v1.Secret) []v1.Volume {
+	for _, secret := range secrets {
+		if secret.Labels[LabelPipelineSecret] == ValuePipelineSecretFile {
+			volumeName := "pipeline-secret-" + secret.Name
+			if GetVolume(&volumes, volumeName) == nil {
+				volumes = append(volumes, v1.Volume{
+					Name: volumeName,
+					VolumeSource: v1.VolumeSource{
+						Secret: &v1.SecretVolumeSource{
+							SecretName: secret.Name,
+						},
+					},
+				})
+			}
+			for i := range containers {
+				container := &containers[i]
+				if GetVolumeMount(&container.VolumeMounts, volumeName) == nil {
+					container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+						Name:      volumeName,
+						MountPath: PipelineSecretsMountPath + "/" + secret.Name,
+						ReadOnly:  true,
+					})
+				}
+			}
+			continue
+		}
+		keys := []string{}
+		for key := range secret.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			name := PipelineSecretEnvName(key)
+			for i := range containers {
+				container := &containers[i]
+				if GetEnvVar(container, name) == nil {
+					container.Env = append(container.Env, v1.EnvVar{
+						Name: name,
+						ValueFrom: &v1.EnvVarSource{
+							SecretKeyRef: &v1.SecretKeySelector{
+								LocalObjectReference: v1.LocalObjectReference{
+									Name: secret.Name,
+								},
+								Key: key,
+							},
+						},
+					})
+				}
+			}
+		}
+	}
+	return volumes
+}
@@ -0,0 +1,111 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetPipelineSecrets(t *testing.T) {
+	t.Parallel()
+	ns := "jx"
+	client := fake.NewSimpleClientset(
+		&v1.Secret{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "all",
+				Namespace: ns,
+				Labels:    map[string]string{kube.LabelPipelineSecret: kube.ValuePipelineSecretEnv},
+			},
+		},
+		&v1.Secret{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:        "myorg",
+				Namespace:   ns,
+				Labels:      map[string]string{kube.LabelPipelineSecret: kube.ValuePipelineSecretEnv},
+				Annotations: map[string]string{kube.AnnotationPipelineSecretRepositories: "other/app, myorg/*"},
+			},
+		},
+		&v1.Secret{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:        "other",
+				Namespace:   ns,
+				Labels:      map[string]string{kube.LabelPipelineSecret: kube.ValuePipelineSecretEnv},
+				Annotations: map[string]string{kube.AnnotationPipelineSecretRepositories: "other/app"},
+			},
+		},
+		&v1.Secret{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "not-for-pipelines", Namespace: ns},
+		},
+	)
+
+	secrets, err := kube.GetPipelineSecrets(client, ns, "myorg", "myapp")
+	require.NoError(t, err)
+	names := []string{}
+	for _, secret := range secrets {
+		names = append(names, secret.Name)
+	}
+	assert.ElementsMatch(t, []string{"all", "myorg"}, names)
+}
+
+func TestFindGitCredentialsSecret(t *testing.T) {
+	t.Parallel()
+	gitSecret := func(name string, serverURL string) v1.Secret {
+		return v1.Secret{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:        name,
+				Labels:      map[string]string{kube.LabelKind: kube.ValueKindGit},
+				Annotations: map[string]string{kube.AnnotationURL: serverURL},
+			},
+		}
+	}
+	secrets := []v1.Secret{
+		gitSecret("jx-pipeline-git-bitbucketserver-bbs", "https://bitbucket.example.com"),
+		gitSecret("jx-pipeline-git-github-github", "https://github.com"),
+	}
+
+	secret := kube.FindGitCredentialsSecret(secrets, "https://github.com/myorg/myapp.git")
+	require.NotNil(t, secret)
+	assert.Equal(t, "jx-pipeline-git-github-github", secret.Name)
+
+	assert.Nil(t, kube.FindGitCredentialsSecret(secrets, "https://gitlab.com/myorg/myapp.git"))
+	assert.Nil(t, kube.FindGitCredentialsSecret(secrets, "not a URL"))
+}
+
+func TestAddPipelineSecrets(t *testing.T) {
+	t.Parallel()
+	containers := []v1.Container{{Name: "build"}, {Name: "test"}}
+	secrets := []v1.Secret{
+		{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:   "npm",
+				Labels: map[string]string{kube.LabelPipelineSecret: kube.ValuePipelineSecretEnv},
+			},
+			Data: map[string][]byte{"npm-token": []byte("abcd1234")},
+		},
+		{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:   "signing",
+				Labels: map[string]string{kube.LabelPipelineSecret: kube.ValuePipelineSecretFile},
+			},
+			Data: map[string][]byte{"key.pem": []byte("-----BEGIN-----")},
+		},
+	}
+
+	volumes := kube.AddPipelineSecrets(containers, nil, secrets)
+
+	require.Len(t, volumes, 1)
+	assert.Equal(t, "signing", volumes[0].Secret.SecretName)
+	for _, container := range containers {
+		env := kube.GetEnvVar(&container, "NPM_TOKEN")
+		require.NotNil(t, env, "container %s", container.Name)
+		assert.Equal(t, "npm", env.ValueFrom.SecretKeyRef.Name)
+		assert.Equal(t, "npm-token", env.ValueFrom.SecretKeyRef.Key)
+		require.Len(t, container.VolumeMounts, 1)
+		assert.Equal(t, kube.PipelineSecretsMountPath+"/signing", container.VolumeMounts[0].MountPath)
+	}
+}
@@ -2,10 +2,13 @@ package kube
 
 import (
 	"fmt"
+	"reflect"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
 	"k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -153,6 +156,46 @@ func GetPods(client kubernetes.Interface, ns string, filter string) ([]string, m
 	return names, m, nil
 }
 
+// FindAppPodNames returns the names of the pods of the application of the given name with the ready and most recently
+// created pods first
+func FindAppPodNames(pods map[string]*v1.Pod, app string) []string {
+	matches := []*v1.Pod{}
+	for _, pod := range pods {
+		if pod.DeletionTimestamp == nil && GetName(&pod.ObjectMeta) == app {
+			matches = append(matches, pod)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		ri := IsPodReady(matches[i])
+		rj := IsPodReady(matches[j])
+		if ri != rj {
+			return ri
+		}
+		return matches[j].CreationTimestamp.Before(&matches[i].CreationTimestamp)
+	})
+	names := []string{}
+	for _, pod := range matches {
+		names = append(names, pod.Name)
+	}
+	return names
+}
+
+// FilterPodNames returns the sorted names of the pods whose name or application name contains the filter ignoring case
+func FilterPodNames(pods map[string]*v1.Pod, filter string) []string {
+	filter = strings.ToLower(filter)
+	names := []string{}
+	for name, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if strings.Contains(strings.ToLower(name), filter) || strings.Contains(strings.ToLower(GetName(&pod.ObjectMeta)), filter) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 func GetPodsWithLabels(client kubernetes.Interface, ns string, selector string) ([]string, map[string]*v1.Pod, error) {
 	names := []string{}
 	m := map[string]*v1.Pod{}
@@ -206,3 +249,300 @@ func GetPodRestarts(pod *v1.Pod) int32 {
 	}
 	return restarts
 }
+
+// LoadPodTemplates returns the Jenkins pod templates of the team indexed by name
+func LoadPodTemplates(client kubernetes.Interface, ns string) (map[string]*v1.Pod, error) {
+	cm, err := client.CoreV1().ConfigMaps(ns).Get(ConfigMapJenkinsPodTemplates, meta_v1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find ConfigMap %s in namespace %s", ConfigMapJenkinsPodTemplates, ns)
+	}
+	answer := map[string]*v1.Pod{}
+	for k, v := range cm.Data {
+		if v == "" {
+			continue
+		}
+		pod := &v1.Pod{}
+		err := yaml.Unmarshal([]byte(v), pod)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the pod template %s", k)
+		}
+		answer[k] = pod
+	}
+	return answer, nil
+}
+
+// UpdatePodTemplates applies the given function to each of the Jenkins pod templates of the team saving the
+// templates which the function returns true for
+func UpdatePodTemplates(client kubernetes.Interface, ns string, fn func(name string, pod *v1.Pod) bool) error {
+	configMaps := client.CoreV1().ConfigMaps(ns)
+	cm, err := configMaps.Get(ConfigMapJenkinsPodTemplates, meta_v1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to find ConfigMap %s in namespace %s", ConfigMapJenkinsPodTemplates, ns)
+	}
+	modified := false
+	for k, v := range cm.Data {
+		if v == "" {
+			continue
+		}
+		pod := &v1.Pod{}
+		err := yaml.Unmarshal([]byte(v), pod)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse the pod template %s", k)
+		}
+		if !fn(k, pod) {
+			continue
+		}
+		data, err := yaml.Marshal(pod)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal the pod template %s", k)
+		}
+		cm.Data[k] = string(data)
+		modified = true
+	}
+	if modified {
+		_, err = configMaps.Update(cm)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update ConfigMap %s in namespace %s", ConfigMapJenkinsPodTemplates, ns)
+		}
+	}
+	// the customised pod templates are restored from their originals so lets keep the originals up to date too
+	return updatePodTemplateOriginals(client, ns, fn)
+}
+
+// RemoveDockerSocket removes the host path volume of the Docker socket and its mounts from the pod returning
+// true if the pod was modified
+func RemoveDockerSocket(pod *v1.Pod) bool {
+	removed := map[string]bool{}
+	volumes := []v1.Volume{}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.HostPath != nil && volume.HostPath.Path == DockerSocketPath {
+			removed[volume.Name] = true
+			continue
+		}
+		volumes = append(volumes, volume)
+	}
+	if len(removed) == 0 {
+		return false
+	}
+	pod.Spec.Volumes = volumes
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		mounts := []v1.VolumeMount{}
+		for _, mount := range container.VolumeMounts {
+			if !removed[mount.Name] {
+				mounts = append(mounts, mount)
+			}
+		}
+		container.VolumeMounts = mounts
+	}
+	return true
+}
+
+// HasDockerSocket returns true if the pod mounts the host path volume of the Docker socket
+func HasDockerSocket(pod *v1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.HostPath != nil && volume.HostPath.Path == DockerSocketPath {
+			return true
+		}
+	}
+	return false
+}
+
+// EnsureInitContainerInstall adds an init container running the command in the given image which installs files into
+// an emptyDir volume mounted at ImageBuilderInstallPath. The volume is mounted into the containers of the pod at the
+// given path so that they can use the installed files. Returns true if the pod was modified
+func EnsureInitContainerInstall(pod *v1.Pod, name string, image string, command []string, mountPath string) bool {
+	modified := false
+	found := false
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+			Name: name,
+			VolumeSource: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{},
+			},
+		})
+		modified = true
+	}
+	initContainer := v1.Container{
+		Name:    name,
+		Image:   image,
+		Command: command,
+		VolumeMounts: []v1.VolumeMount{
+			{
+				Name:      name,
+				MountPath: ImageBuilderInstallPath,
+			},
+		},
+	}
+	found = false
+	for i := range pod.Spec.InitContainers {
+		container := &pod.Spec.InitContainers[i]
+		if container.Name == name {
+			found = true
+			if !reflect.DeepEqual(*container, initContainer) {
+				*container = initContainer
+				modified = true
+			}
+			break
+		}
+	}
+	if !found {
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, initContainer)
+		modified = true
+	}
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		mounted := false
+		for _, mount := range container.VolumeMounts {
+			if mount.Name == name {
+				mounted = true
+				break
+			}
+		}
+		if !mounted {
+			container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+				Name:      name,
+				MountPath: mountPath,
+			})
+			modified = true
+		}
+	}
+	return modified
+}
+
+// EnsureDockerConfigSecret mounts the Secret containing the Docker config.json with the registry credentials into
+// the containers of the pod at the given path and points $DOCKER_CONFIG at it returning true if the pod was modified
+func EnsureDockerConfigSecret(pod *v1.Pod, secretName string, mountPath string) bool {
+	modified := false
+	volumeName := ""
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Secret != nil && volume.Secret.SecretName == secretName {
+			volumeName = volume.Name
+			break
+		}
+	}
+	if volumeName == "" {
+		volumeName = secretName
+		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+			Name: volumeName,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: secretName,
+				},
+			},
+		})
+		modified = true
+	}
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		mounted := false
+		for _, mount := range container.VolumeMounts {
+			if mount.Name == volumeName {
+				mounted = true
+				mountPath = mount.MountPath
+				break
+			}
+		}
+		if !mounted {
+			container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+				Name:      volumeName,
+				MountPath: mountPath,
+			})
+			modified = true
+		}
+		if SetEnvVar(container, "DOCKER_CONFIG", mountPath) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// EnsureSecretFile mounts the key of the Secret as a file at the given path into the containers of the pod
+// returning true if the pod was modified
+func EnsureSecretFile(pod *v1.Pod, secretName string, key string, path string) bool {
+	modified := false
+	volumeName := ""
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Secret != nil && volume.Secret.SecretName == secretName {
+			volumeName = volume.Name
+			break
+		}
+	}
+	if volumeName == "" {
+		volumeName = secretName
+		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+			Name: volumeName,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: secretName,
+				},
+			},
+		})
+		modified = true
+	}
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		mounted := false
+		for _, mount := range container.VolumeMounts {
+			if mount.Name == volumeName && mount.SubPath == key {
+				mounted = true
+				break
+			}
+		}
+		if !mounted {
+			container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+				Name:      volumeName,
+				MountPath: path,
+				SubPath:   key,
+			})
+			modified = true
+		}
+	}
+	return modified
+}
+
+// EnableRootlessBuildKit configures the containers of the pod so that rootless BuildKit can run without a privileged
+// security context returning true if the pod was modified
+func EnableRootlessBuildKit(pod *v1.Pod) bool {
+	modified := false
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		for _, prefix := range []string{"container.apparmor.security.beta.kubernetes.io/", "container.seccomp.security.alpha.kubernetes.io/"} {
+			key := prefix + container.Name
+			if pod.Annotations[key] != "unconfined" {
+				pod.Annotations[key] = "unconfined"
+				modified = true
+			}
+		}
+		if SetEnvVar(container, "BUILDKITD_FLAGS", "--oci-worker-no-process-sandbox") {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// SetEnvVar sets the environment variable on the container returning true if it was modified
+func SetEnvVar(container *v1.Container, name string, value string) bool {
+	for i := range container.Env {
+		env := &container.Env[i]
+		if env.Name == name {
+			if env.Value == value && env.ValueFrom == nil {
+				return false
+			}
+			env.Value = value
+			env.ValueFrom = nil
+			return true
+		}
+	}
+	container.Env = append(container.Env, v1.EnvVar{Name: name, Value: value})
+	return true
+}
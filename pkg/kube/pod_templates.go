@@ -0,0 +1,382 @@
+package kube
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PodTemplateCustomisation the changes a team makes to a Jenkins pod template. The customisations are stored
+// separately from the pod templates so that they can be merged into the pod templates again when the platform is
+// upgraded rather than being overwritten
+type PodTemplateCustomisation struct {
+	// From the name of the pod template which a new pod template is created from
+	From         string                  `json:"from,omitempty"`
+	Image        string                  `json:"image,omitempty"`
+	Resources    v1.ResourceRequirements `json:"resources,omitempty"`
+	Env          []v1.EnvVar             `json:"env,omitempty"`
+	Volumes      []v1.Volume             `json:"volumes,omitempty"`
+	VolumeMounts []v1.VolumeMount        `json:"volumeMounts,omitempty"`
+	// Original the pod template before it was customised which is restored when the customisation is removed
+	Original string `json:"original,omitempty"`
+}
+
+// Merge merges the given customisation into this one
+func (c *PodTemplateCustomisation) Merge(other *PodTemplateCustomisation) {
+	if other.From != "" {
+		c.From = other.From
+	}
+	if other.Image != "" {
+		c.Image = other.Image
+	}
+	c.Resources.Requests = mergeResourceList(c.Resources.Requests, other.Resources.Requests)
+	c.Resources.Limits = mergeResourceList(c.Resources.Limits, other.Resources.Limits)
+	for _, env := range other.Env {
+		c.Env = setEnvVar(c.Env, env)
+	}
+	for _, volume := range other.Volumes {
+		c.Volumes = setVolume(c.Volumes, volume)
+	}
+	for _, mount := range other.VolumeMounts {
+		c.VolumeMounts = setVolumeMount(c.VolumeMounts, mount)
+	}
+}
+
+// Apply applies the customisation to the build container of the pod template
+func (c *PodTemplateCustomisation) Apply(name string, pod *v1.Pod) error {
+	container := PodTemplateBuildContainer(name, pod)
+	if container == nil {
+		return fmt.Errorf("the pod template %s has no containers", name)
+	}
+	if c.Image != "" {
+		container.Image = c.Image
+	}
+	container.Resources.Requests = mergeResourceList(container.Resources.Requests, c.Resources.Requests)
+	container.Resources.Limits = mergeResourceList(container.Resources.Limits, c.Resources.Limits)
+	for _, env := range c.Env {
+		container.Env = setEnvVar(container.Env, env)
+	}
+	for _, volume := range c.Volumes {
+		pod.Spec.Volumes = setVolume(pod.Spec.Volumes, volume)
+	}
+	for _, mount := range c.VolumeMounts {
+		container.VolumeMounts = setVolumeMount(container.VolumeMounts, mount)
+	}
+	return nil
+}
+
+// PodTemplateBuildContainer returns the container of the pod template which runs the build steps. This is the
+// container with the same name as the pod template or else the first container
+func PodTemplateBuildContainer(name string, pod *v1.Pod) *v1.Container {
+	containers := pod.Spec.Containers
+	for i := range containers {
+		if containers[i].Name == name {
+			return &containers[i]
+		}
+	}
+	if len(containers) > 0 {
+		return &containers[0]
+	}
+	return nil
+}
+
+// LoadPodTemplateCustomisations returns the customisations of the Jenkins pod templates of the team indexed by
+// the name of the pod template
+func LoadPodTemplateCustomisations(client kubernetes.Interface, ns string) (map[string]*PodTemplateCustomisation, error) {
+	answer := map[string]*PodTemplateCustomisation{}
+	cm, err := client.CoreV1().ConfigMaps(ns).Get(ConfigMapJenkinsPodTemplateCustomisations, meta_v1.GetOptions{})
+	if err != nil {
+		// there are no customisations yet
+		return answer, nil
+	}
+	for k, v := range cm.Data {
+		customisation := &PodTemplateCustomisation{}
+		err := yaml.Unmarshal([]byte(v), customisation)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the customisation of the pod template %s", k)
+		}
+		answer[k] = customisation
+	}
+	return answer, nil
+}
+
+// SavePodTemplateCustomisation merges the given customisation into the stored customisation of the named pod
+// template then applies the customisations to the pod templates
+func SavePodTemplateCustomisation(client kubernetes.Interface, ns string, name string, customisation *PodTemplateCustomisation) error {
+	customisations, err := LoadPodTemplateCustomisations(client, ns)
+	if err != nil {
+		return err
+	}
+	existing := customisations[name]
+	if existing == nil {
+		existing = &PodTemplateCustomisation{}
+		customisations[name] = existing
+	}
+	existing.Merge(customisation)
+	err = savePodTemplateCustomisations(client, ns, customisations)
+	if err != nil {
+		return err
+	}
+	return ApplyPodTemplateCustomisations(client, ns)
+}
+
+// RemovePodTemplateCustomisation removes the customisation of the named pod template. The pod template is restored
+// as it was before being customised or deleted if it was created from another pod template
+func RemovePodTemplateCustomisation(client kubernetes.Interface, ns string, name string) error {
+	customisations, err := LoadPodTemplateCustomisations(client, ns)
+	if err != nil {
+		return err
+	}
+	customisation := customisations[name]
+	if customisation == nil {
+		return fmt.Errorf("the pod template %s has not been customised", name)
+	}
+	for k, c := range customisations {
+		if c.From == name {
+			return fmt.Errorf("the pod template %s is created from the pod template %s. Please remove it first", k, name)
+		}
+	}
+
+	configMaps := client.CoreV1().ConfigMaps(ns)
+	cm, err := configMaps.Get(ConfigMapJenkinsPodTemplates, meta_v1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to find ConfigMap %s in namespace %s", ConfigMapJenkinsPodTemplates, ns)
+	}
+	if customisation.From != "" {
+		delete(cm.Data, name)
+	} else if customisation.Original != "" {
+		cm.Data[name] = customisation.Original
+	} else {
+		return fmt.Errorf("the pod template %s before it was customised is not known so it can only be restored by upgrading the platform", name)
+	}
+	_, err = configMaps.Update(cm)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update ConfigMap %s in namespace %s", ConfigMapJenkinsPodTemplates, ns)
+	}
+
+	delete(customisations, name)
+	return savePodTemplateCustomisations(client, ns, customisations)
+}
+
+// savePodTemplateCustomisations stores the given customisations of the pod templates of the team
+func savePodTemplateCustomisations(client kubernetes.Interface, ns string, customisations map[string]*PodTemplateCustomisation) error {
+	data := map[string]string{}
+	for name, customisation := range customisations {
+		text, err := yaml.Marshal(customisation)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal the customisation of the pod template %s", name)
+		}
+		data[name] = string(text)
+	}
+
+	configMaps := client.CoreV1().ConfigMaps(ns)
+	cm, err := configMaps.Get(ConfigMapJenkinsPodTemplateCustomisations, meta_v1.GetOptions{})
+	if err != nil {
+		cm = &v1.ConfigMap{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name: ConfigMapJenkinsPodTemplateCustomisations,
+				Labels: map[string]string{
+					LabelCreatedBy: ValueCreatedByJX,
+				},
+			},
+			Data: data,
+		}
+		_, err = configMaps.Create(cm)
+	} else {
+		cm.Data = data
+		_, err = configMaps.Update(cm)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to save ConfigMap %s in namespace %s", ConfigMapJenkinsPodTemplateCustomisations, ns)
+	}
+	return nil
+}
+
+// ApplyPodTemplateCustomisations merges the customisations of the team into the Jenkins pod templates creating the
+// pod templates which are customised from another pod template
+func ApplyPodTemplateCustomisations(client kubernetes.Interface, ns string) error {
+	customisations, err := LoadPodTemplateCustomisations(client, ns)
+	if err != nil {
+		return err
+	}
+	if len(customisations) == 0 {
+		return nil
+	}
+	configMaps := client.CoreV1().ConfigMaps(ns)
+	cm, err := configMaps.Get(ConfigMapJenkinsPodTemplates, meta_v1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to find ConfigMap %s in namespace %s", ConfigMapJenkinsPodTemplates, ns)
+	}
+	// lets customise the pod templates as they were before any customisations so that removed customisations do not
+	// linger and the pod templates created from another pod template include its customisations whatever order they
+	// are applied in
+	original := map[string]string{}
+	for k, v := range cm.Data {
+		original[k] = v
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	originalsChanged := false
+	for name, customisation := range customisations {
+		if customisation.From != "" {
+			continue
+		}
+		text := original[name]
+		if text == "" {
+			continue
+		}
+		customised, err := isCustomisedPodTemplate(text)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse the pod template %s", name)
+		}
+		if !customised || customisation.Original == "" {
+			// the pod template is new or has been replaced by an upgrade
+			customisation.Original = text
+			originalsChanged = true
+		}
+		original[name] = customisation.Original
+	}
+	if originalsChanged {
+		err = savePodTemplateCustomisations(client, ns, customisations)
+		if err != nil {
+			return err
+		}
+	}
+	for name, customisation := range customisations {
+		from := name
+		if customisation.From != "" {
+			from = customisation.From
+		}
+		text := original[from]
+		if text == "" {
+			return fmt.Errorf("no pod template %s found in ConfigMap %s", from, ConfigMapJenkinsPodTemplates)
+		}
+		pod := &v1.Pod{}
+		err := yaml.Unmarshal([]byte(text), pod)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse the pod template %s", from)
+		}
+		if from != name {
+			base := customisations[from]
+			if base != nil && base.From == "" {
+				err = base.Apply(from, pod)
+				if err != nil {
+					return err
+				}
+			}
+			pod.Name = "jenkins-" + name
+			container := PodTemplateBuildContainer(from, pod)
+			if container != nil && container.Name == from {
+				container.Name = name
+			}
+		}
+		err = customisation.Apply(name, pod)
+		if err != nil {
+			return err
+		}
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[AnnotationPodTemplateCustomised] = "true"
+		data, err := yaml.Marshal(pod)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal the pod template %s", name)
+		}
+		cm.Data[name] = string(data)
+	}
+	_, err = configMaps.Update(cm)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update ConfigMap %s in namespace %s", ConfigMapJenkinsPodTemplates, ns)
+	}
+	return nil
+}
+
+// updatePodTemplateOriginals applies the given function to the pod templates as they were before being customised
+func updatePodTemplateOriginals(client kubernetes.Interface, ns string, fn func(name string, pod *v1.Pod) bool) error {
+	customisations, err := LoadPodTemplateCustomisations(client, ns)
+	if err != nil {
+		return err
+	}
+	modified := false
+	for name, customisation := range customisations {
+		if customisation.Original == "" {
+			continue
+		}
+		pod := &v1.Pod{}
+		err := yaml.Unmarshal([]byte(customisation.Original), pod)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse the original pod template %s", name)
+		}
+		if !fn(name, pod) {
+			continue
+		}
+		data, err := yaml.Marshal(pod)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal the original pod template %s", name)
+		}
+		customisation.Original = string(data)
+		modified = true
+	}
+	if !modified {
+		return nil
+	}
+	return savePodTemplateCustomisations(client, ns, customisations)
+}
+
+// isCustomisedPodTemplate returns true if the customisations have been applied to the given pod template
+func isCustomisedPodTemplate(text string) (bool, error) {
+	pod := &v1.Pod{}
+	err := yaml.Unmarshal([]byte(text), pod)
+	if err != nil {
+		return false, err
+	}
+	return pod.Annotations[AnnotationPodTemplateCustomised] == "true", nil
+}
+
+func mergeResourceList(list v1.ResourceList, other v1.ResourceList) v1.ResourceList {
+	if len(other) == 0 {
+		return list
+	}
+	if list == nil {
+		list = v1.ResourceList{}
+	}
+	for k, v := range other {
+		list[k] = v
+	}
+	return list
+}
+
+func setEnvVar(envVars []v1.EnvVar, env v1.EnvVar) []v1.EnvVar {
+	for i := range envVars {
+		if envVars[i].Name == env.Name {
+			envVars[i] = env
+			return envVars
+		}
+	}
+	return append(envVars, env)
+}
+
+func setVolume(volumes []v1.Volume, volume v1.Volume) []v1.Volume {
+	for i := range volumes {
+		if volumes[i].Name == volume.Name {
+			volumes[i] = volume
+			return volumes
+		}
+	}
+	return append(volumes, volume)
+}
+
+func setVolumeMount(mounts []v1.VolumeMount, mount v1.VolumeMount) []v1.VolumeMount {
+	for i := range mounts {
+		if mounts[i].MountPath == mount.MountPath {
+			mounts[i] = mount
+			return mounts
+		}
+	}
+	return append(mounts, mount)
+}
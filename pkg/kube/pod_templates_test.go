@@ -0,0 +1,89 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSavePodTemplateCustomisation(t *testing.T) {
+	t.Parallel()
+	ns := "jx"
+	maven := &v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "jenkins-maven"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name:  "maven",
+					Image: "jenkinsxio/builder-maven:0.1.1",
+					Env:   []v1.EnvVar{{Name: "MAVEN_OPTS", Value: "-Xmx1g"}},
+				},
+			},
+		},
+	}
+	data, err := yaml.Marshal(maven)
+	require.NoError(t, err)
+	client := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: meta_v1.ObjectMeta{Name: kube.ConfigMapJenkinsPodTemplates, Namespace: ns},
+		Data:       map[string]string{"maven": string(data)},
+	})
+
+	err = kube.SavePodTemplateCustomisation(client, ns, "maven", &kube.PodTemplateCustomisation{
+		Env: []v1.EnvVar{{Name: "MAVEN_OPTS", Value: "-Xmx2g"}},
+		Resources: v1.ResourceRequirements{
+			Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("2Gi")},
+		},
+	})
+	require.NoError(t, err)
+	err = kube.SavePodTemplateCustomisation(client, ns, "maven-big", &kube.PodTemplateCustomisation{
+		From:  "maven",
+		Image: "myorg/builder-maven:1.0.0",
+	})
+	require.NoError(t, err)
+
+	templates, err := kube.LoadPodTemplates(client, ns)
+	require.NoError(t, err)
+	container := templates["maven"].Spec.Containers[0]
+	assert.Equal(t, "-Xmx2g", container.Env[0].Value)
+	assert.Equal(t, "2Gi", container.Resources.Limits.Memory().String())
+
+	big := templates["maven-big"]
+	require.NotNil(t, big)
+	assert.Equal(t, "jenkins-maven-big", big.Name)
+	assert.Equal(t, "maven-big", big.Spec.Containers[0].Name)
+	assert.Equal(t, "myorg/builder-maven:1.0.0", big.Spec.Containers[0].Image)
+
+	// lets simulate an upgrade overwriting the pod templates
+	cm, err := client.CoreV1().ConfigMaps(ns).Get(kube.ConfigMapJenkinsPodTemplates, meta_v1.GetOptions{})
+	require.NoError(t, err)
+	cm.Data = map[string]string{"maven": string(data)}
+	_, err = client.CoreV1().ConfigMaps(ns).Update(cm)
+	require.NoError(t, err)
+
+	require.NoError(t, kube.ApplyPodTemplateCustomisations(client, ns))
+	templates, err = kube.LoadPodTemplates(client, ns)
+	require.NoError(t, err)
+	assert.Equal(t, "-Xmx2g", templates["maven"].Spec.Containers[0].Env[0].Value)
+	assert.NotNil(t, templates["maven-big"])
+
+	err = kube.RemovePodTemplateCustomisation(client, ns, "maven")
+	assert.Error(t, err, "maven-big is created from maven")
+	require.NoError(t, kube.RemovePodTemplateCustomisation(client, ns, "maven-big"))
+	require.NoError(t, kube.RemovePodTemplateCustomisation(client, ns, "maven"))
+
+	templates, err = kube.LoadPodTemplates(client, ns)
+	require.NoError(t, err)
+	assert.Nil(t, templates["maven-big"])
+	assert.Equal(t, maven.Spec.Containers[0], templates["maven"].Spec.Containers[0])
+	assert.Empty(t, templates["maven"].Annotations)
+	customisations, err := kube.LoadPodTemplateCustomisations(client, ns)
+	require.NoError(t, err)
+	assert.Empty(t, customisations)
+}
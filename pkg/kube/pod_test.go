@@ -2,6 +2,7 @@ package kube_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/stretchr/testify/assert"
@@ -252,3 +253,104 @@ func TestIsPodReadyFailures(t *testing.T) {
 	res = kube.IsPodReady(pod)
 	assert.Equal(t, false, res)
 }
+
+func TestRemoveDockerSocketAndEnsureDockerConfigSecret(t *testing.T) {
+	t.Parallel()
+
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{
+				{
+					Name: "volume-0",
+					VolumeSource: v1.VolumeSource{
+						HostPath: &v1.HostPathVolumeSource{Path: kube.DockerSocketPath},
+					},
+				},
+				{
+					Name: "workspace",
+					VolumeSource: v1.VolumeSource{
+						EmptyDir: &v1.EmptyDirVolumeSource{},
+					},
+				},
+			},
+			Containers: []v1.Container{
+				{
+					Name: "maven",
+					VolumeMounts: []v1.VolumeMount{
+						{Name: "volume-0", MountPath: kube.DockerSocketPath},
+						{Name: "workspace", MountPath: "/home/jenkins"},
+					},
+				},
+			},
+		},
+	}
+
+	assert.True(t, kube.RemoveDockerSocket(pod))
+	assert.False(t, kube.RemoveDockerSocket(pod), "should not modify the pod twice")
+	assert.Len(t, pod.Spec.Volumes, 1)
+	assert.Equal(t, []v1.VolumeMount{{Name: "workspace", MountPath: "/home/jenkins"}}, pod.Spec.Containers[0].VolumeMounts)
+
+	assert.True(t, kube.EnsureDockerConfigSecret(pod, kube.SecretJenkinsDockerConfig, kube.DockerConfigMountPath))
+	assert.False(t, kube.EnsureDockerConfigSecret(pod, kube.SecretJenkinsDockerConfig, kube.DockerConfigMountPath), "should not modify the pod twice")
+	assert.Equal(t, kube.SecretJenkinsDockerConfig, pod.Spec.Volumes[1].Secret.SecretName)
+	container := pod.Spec.Containers[0]
+	assert.Contains(t, container.VolumeMounts, v1.VolumeMount{Name: kube.SecretJenkinsDockerConfig, MountPath: kube.DockerConfigMountPath})
+	assert.Contains(t, container.Env, v1.EnvVar{Name: "DOCKER_CONFIG", Value: kube.DockerConfigMountPath})
+
+	assert.True(t, kube.EnableRootlessBuildKit(pod))
+	assert.False(t, kube.EnableRootlessBuildKit(pod), "should not modify the pod twice")
+	assert.Equal(t, "unconfined", pod.Annotations["container.apparmor.security.beta.kubernetes.io/maven"])
+}
+
+func TestFindAppPodNames(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	readyCondition := v1.PodStatus{
+		Phase: v1.PodRunning,
+		Conditions: []v1.PodCondition{
+			{
+				Type:   v1.PodReady,
+				Status: v1.ConditionTrue,
+			},
+		},
+	}
+	pods := map[string]*v1.Pod{
+		"myapp-old": {
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:              "myapp-old",
+				Labels:            map[string]string{"app": "myapp"},
+				CreationTimestamp: meta_v1.NewTime(now.Add(-time.Hour)),
+			},
+			Status: readyCondition,
+		},
+		"myapp-new": {
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:              "myapp-new",
+				Labels:            map[string]string{"app": "myapp"},
+				CreationTimestamp: meta_v1.NewTime(now),
+			},
+			Status: readyCondition,
+		},
+		"myapp-starting": {
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:              "myapp-starting",
+				Labels:            map[string]string{"app": "myapp"},
+				CreationTimestamp: meta_v1.NewTime(now.Add(time.Minute)),
+			},
+		},
+		"other-app": {
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:   "other-app",
+				Labels: map[string]string{"app": "other"},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"myapp-new", "myapp-old", "myapp-starting"}, kube.FindAppPodNames(pods, "myapp"))
+	assert.Empty(t, kube.FindAppPodNames(pods, "cheese"))
+
+	assert.Equal(t, []string{"myapp-new", "myapp-old", "myapp-starting"}, kube.FilterPodNames(pods, "MyApp"))
+	assert.Equal(t, []string{"other-app"}, kube.FilterPodNames(pods, "other"))
+	assert.Empty(t, kube.FilterPodNames(pods, "cheese"))
+}
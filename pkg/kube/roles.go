@@ -281,3 +281,134 @@ func DeleteClusterRole(kubeClient kubernetes.Interface, name string) error {
 	}
 	return nil
 }
+
+// PipelineRules the permissions the serverless pipelines need in the namespace of the team they run in
+var PipelineRules = []rbacv1.PolicyRule{
+	{
+		APIGroups: []string{"", "apps", "extensions", "batch"},
+		Resources: []string{"*"},
+		Verbs:     []string{"*"},
+	},
+	{
+		APIGroups: []string{"jenkins.io", "tekton.dev"},
+		Resources: []string{"*"},
+		Verbs:     []string{"*"},
+	},
+}
+
+// TillerClientRules the permissions helm needs in the namespace of tiller to connect to it
+var TillerClientRules = []rbacv1.PolicyRule{
+	{
+		APIGroups: []string{""},
+		Resources: []string{"pods"},
+		Verbs:     []string{"get", "list"},
+	},
+	{
+		APIGroups: []string{""},
+		Resources: []string{"pods/portforward"},
+		Verbs:     []string{"create"},
+	},
+}
+
+// PreviewNamespaceRules the cluster wide permissions the pipelines need to create the namespaces of the previews
+var PreviewNamespaceRules = []rbacv1.PolicyRule{
+	{
+		APIGroups: []string{""},
+		Resources: []string{"namespaces"},
+		Verbs:     []string{"get", "list", "watch", "create", "update", "patch"},
+	},
+}
+
+// EnsureRoleBinding creates or updates the Role with the given rules in the namespace and binds it to the service account
+func EnsureRoleBinding(kubeClient kubernetes.Interface, ns string, name string, rules []rbacv1.PolicyRule, serviceAccountNamespace string, serviceAccountName string) error {
+	roles := kubeClient.RbacV1().Roles(ns)
+	role, err := roles.Get(name, metav1.GetOptions{})
+	if err == nil {
+		role.Rules = rules
+		_, err = roles.Update(role)
+	} else if apierrors.IsNotFound(err) {
+		_, err = roles.Create(&rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+			},
+			Rules: rules,
+		})
+	}
+	if err != nil {
+		return errors.Wrapf(err, "saving the Role %s in namespace %s", name, ns)
+	}
+
+	bindings := kubeClient.RbacV1().RoleBindings(ns)
+	_, err = bindings.Get(name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = bindings.Create(&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:      subjectKind,
+					Name:      serviceAccountName,
+					Namespace: serviceAccountNamespace,
+				},
+			},
+			RoleRef: rbacv1.RoleRef{
+				Kind:     "Role",
+				Name:     name,
+				APIGroup: apiGroup,
+			},
+		})
+	}
+	if err != nil {
+		return errors.Wrapf(err, "creating the RoleBinding %s in namespace %s", name, ns)
+	}
+	return nil
+}
+
+// EnsureClusterRoleBinding creates or updates the ClusterRole with the given rules and binds it to the service account
+func EnsureClusterRoleBinding(kubeClient kubernetes.Interface, name string, rules []rbacv1.PolicyRule, serviceAccountNamespace string, serviceAccountName string) error {
+	roles := kubeClient.RbacV1().ClusterRoles()
+	role, err := roles.Get(name, metav1.GetOptions{})
+	if err == nil {
+		role.Rules = rules
+		_, err = roles.Update(role)
+	} else if apierrors.IsNotFound(err) {
+		_, err = roles.Create(&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Rules: rules,
+		})
+	}
+	if err != nil {
+		return errors.Wrapf(err, "saving the ClusterRole %s", name)
+	}
+
+	bindings := kubeClient.RbacV1().ClusterRoleBindings()
+	_, err = bindings.Get(name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = bindings.Create(&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:      subjectKind,
+					Name:      serviceAccountName,
+					Namespace: serviceAccountNamespace,
+				},
+			},
+			RoleRef: rbacv1.RoleRef{
+				Kind:     clusterRoleKind,
+				Name:     name,
+				APIGroup: apiGroup,
+			},
+		})
+	}
+	if err != nil {
+		return errors.Wrapf(err, "creating the ClusterRoleBinding %s", name)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestEnsureRoleBinding(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset(&rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "tekton-bot", Namespace: "jx"},
+	})
+
+	err := kube.EnsureRoleBinding(client, "jx", "tekton-bot", kube.PipelineRules, "jx", "tekton-bot")
+	require.NoError(t, err)
+	err = kube.EnsureRoleBinding(client, "jx", "tekton-bot", kube.PipelineRules, "jx", "tekton-bot")
+	require.NoError(t, err, "the role binding should be idempotent")
+
+	role, err := client.RbacV1().Roles("jx").Get("tekton-bot", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, kube.PipelineRules, role.Rules)
+
+	binding, err := client.RbacV1().RoleBindings("jx").Get("tekton-bot", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "Role", binding.RoleRef.Kind)
+	assert.Equal(t, "tekton-bot", binding.RoleRef.Name)
+	assert.Equal(t, []rbacv1.Subject{{Kind: "ServiceAccount", Name: "tekton-bot", Namespace: "jx"}}, binding.Subjects)
+
+	bindings, err := client.RbacV1().ClusterRoleBindings().List(metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, bindings.Items, "no cluster wide permissions should be granted")
+}
+
+func TestEnsureClusterRoleBinding(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset()
+
+	err := kube.EnsureClusterRoleBinding(client, "tekton-bot-jx", kube.PreviewNamespaceRules, "jx", "tekton-bot")
+	require.NoError(t, err)
+
+	role, err := client.RbacV1().ClusterRoles().Get("tekton-bot-jx", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, kube.PreviewNamespaceRules, role.Rules)
+
+	binding, err := client.RbacV1().ClusterRoleBindings().Get("tekton-bot-jx", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "ClusterRole", binding.RoleRef.Kind)
+	assert.Equal(t, "tekton-bot-jx", binding.RoleRef.Name)
+}
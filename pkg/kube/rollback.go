@@ -0,0 +1,131 @@
+package kube
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RollbackStageName the name of the stage recorded in a PipelineActivity when a version is rolled back
+const RollbackStageName = "Rollback"
+
+// ActivityMatchesApplication returns true if the activity is a pipeline of the given application
+func ActivityMatchesApplication(activity *v1.PipelineActivity, app string) bool {
+	spec := &activity.Spec
+	if spec.GitRepository != "" {
+		return spec.GitRepository == app
+	}
+	paths := strings.Split(spec.Pipeline, "/")
+	return len(paths) > 1 && paths[1] == app
+}
+
+// FindActivityForVersion returns the most recent activity of the application which released the given version
+func FindActivityForVersion(activities []v1.PipelineActivity, app string, version string) *v1.PipelineActivity {
+	var answer *v1.PipelineActivity
+	for i := range activities {
+		activity := &activities[i]
+		if activity.Spec.Version != version || !ActivityMatchesApplication(activity, app) {
+			continue
+		}
+		if answer == nil || answer.CreationTimestamp.Before(&activity.CreationTimestamp) {
+			answer = activity
+		}
+	}
+	return answer
+}
+
+// PromotedVersions returns the versions of the application which were successfully promoted to the environment
+// and have not been rolled back since, newest version first
+func PromotedVersions(activities []v1.PipelineActivity, app string, envName string) []string {
+	answer := []string{}
+	for i := range activities {
+		activity := &activities[i]
+		version := activity.Spec.Version
+		if version == "" || !ActivityMatchesApplication(activity, app) || IsRolledBack(activity, envName) {
+			continue
+		}
+		for _, step := range activity.Spec.Steps {
+			promote := step.Promote
+			if promote != nil && promote.Environment == envName && promote.Status == v1.ActivityStatusTypeSucceeded {
+				if !containsString(answer, version) {
+					answer = append(answer, version)
+				}
+				break
+			}
+		}
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return versionLess(answer[j], answer[i])
+	})
+	return answer
+}
+
+// PreviousPromotedVersion returns the newest version of the application successfully promoted to the environment
+// which is older than the given current version or an empty string if there is no such version
+func PreviousPromotedVersion(activities []v1.PipelineActivity, app string, envName string, current string) string {
+	for _, version := range PromotedVersions(activities, app, envName) {
+		if version != current && (current == "" || versionLess(version, current)) {
+			return version
+		}
+	}
+	return ""
+}
+
+// IsRolledBack returns true if the version released by the activity has been rolled back in the environment
+func IsRolledBack(activity *v1.PipelineActivity, envName string) bool {
+	prefix := rollbackStageDescriptionPrefix(envName)
+	for _, step := range activity.Spec.Steps {
+		stage := step.Stage
+		if stage != nil && stage.Name == RollbackStageName && stage.Status == v1.ActivityStatusTypeSucceeded &&
+			strings.HasPrefix(stage.Description, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddRollbackStage records in the activity of the rolled back version that the environment was reverted to the
+// given version with the status of the rollback
+func AddRollbackStage(activity *v1.PipelineActivity, envName string, toVersion string, status v1.ActivityStatusType, started time.Time) *v1.StageActivityStep {
+	stage := &v1.StageActivityStep{
+		CoreActivityStep: v1.CoreActivityStep{
+			Name:               RollbackStageName,
+			Description:        rollbackStageDescriptionPrefix(envName) + toVersion,
+			Status:             status,
+			StartedTimestamp:   &metav1.Time{Time: started},
+			CompletedTimestamp: &metav1.Time{Time: time.Now()},
+		},
+	}
+	activity.Spec.Steps = append(activity.Spec.Steps, v1.PipelineActivityStep{
+		Kind:  v1.ActivityStepKindTypeStage,
+		Stage: stage,
+	})
+	return stage
+}
+
+func rollbackStageDescriptionPrefix(envName string) string {
+	return fmt.Sprintf("Rolled back %s to version ", envName)
+}
+
+func versionLess(a string, b string) bool {
+	va, errA := semver.ParseTolerant(a)
+	vb, errB := semver.ParseTolerant(b)
+	if errA == nil && errB == nil {
+		return va.LT(vb)
+	}
+	return a < b
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package kube_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func promotedActivity(name string, version string, envName string, status v1.ActivityStatusType) v1.PipelineActivity {
+	return v1.PipelineActivity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: v1.PipelineActivitySpec{
+			Pipeline: "myorg/myapp/master",
+			Version:  version,
+			Steps: []v1.PipelineActivityStep{
+				{
+					Kind: v1.ActivityStepKindTypePromote,
+					Promote: &v1.PromoteActivityStep{
+						CoreActivityStep: v1.CoreActivityStep{
+							Status: status,
+						},
+						Environment: envName,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestPreviousPromotedVersion(t *testing.T) {
+	t.Parallel()
+
+	activities := []v1.PipelineActivity{
+		promotedActivity("myorg-myapp-master-1", "1.0.1", "production", v1.ActivityStatusTypeSucceeded),
+		promotedActivity("myorg-myapp-master-3", "1.0.10", "production", v1.ActivityStatusTypeSucceeded),
+		promotedActivity("myorg-myapp-master-2", "1.0.2", "production", v1.ActivityStatusTypeSucceeded),
+		promotedActivity("myorg-myapp-master-4", "1.0.11", "production", v1.ActivityStatusTypeFailed),
+		promotedActivity("myorg-myapp-master-5", "1.0.12", "staging", v1.ActivityStatusTypeSucceeded),
+		promotedActivity("myorg-other-master-1", "1.0.9", "production", v1.ActivityStatusTypeSucceeded),
+	}
+	activities[5].Spec.Pipeline = "myorg/other/master"
+
+	assert.Equal(t, []string{"1.0.10", "1.0.2", "1.0.1"}, kube.PromotedVersions(activities, "myapp", "production"))
+	assert.Equal(t, "1.0.2", kube.PreviousPromotedVersion(activities, "myapp", "production", "1.0.10"))
+	assert.Equal(t, "1.0.10", kube.PreviousPromotedVersion(activities, "myapp", "production", "1.0.12"))
+	assert.Equal(t, "", kube.PreviousPromotedVersion(activities, "myapp", "production", "1.0.1"))
+	assert.Equal(t, "", kube.PreviousPromotedVersion(activities, "myapp", "staging", "1.0.12"))
+
+	failed := kube.AddRollbackStage(&activities[1], "production", "1.0.2", v1.ActivityStatusTypeFailed, time.Now())
+	assert.Equal(t, kube.RollbackStageName, failed.Name)
+	assert.Equal(t, "Rolled back production to version 1.0.2", failed.Description)
+	assert.False(t, kube.IsRolledBack(&activities[1], "production"))
+
+	kube.AddRollbackStage(&activities[1], "production", "1.0.2", v1.ActivityStatusTypeSucceeded, time.Now())
+	assert.Len(t, activities[1].Spec.Steps, 3)
+	assert.True(t, kube.IsRolledBack(&activities[1], "production"))
+	assert.False(t, kube.IsRolledBack(&activities[1], "staging"))
+	assert.Equal(t, "1.0.1", kube.PreviousPromotedVersion(activities, "myapp", "production", "1.0.2"))
+}
+
+func TestFindActivityForVersion(t *testing.T) {
+	t.Parallel()
+
+	activities := []v1.PipelineActivity{
+		promotedActivity("myorg-myapp-master-1", "1.0.1", "production", v1.ActivityStatusTypeSucceeded),
+		promotedActivity("myorg-myapp-master-2", "1.0.2", "production", v1.ActivityStatusTypeSucceeded),
+	}
+	activity := kube.FindActivityForVersion(activities, "myapp", "1.0.2")
+	if assert.NotNil(t, activity) {
+		assert.Equal(t, "myorg-myapp-master-2", activity.Name)
+	}
+	assert.Nil(t, kube.FindActivityForVersion(activities, "other", "1.0.2"))
+	assert.Nil(t, kube.FindActivityForVersion(activities, "myapp", "1.0.3"))
+}
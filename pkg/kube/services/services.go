@@ -17,17 +17,19 @@ import (
 )
 
 const (
-	ExposeAnnotation            = "fabric8.io/expose"
-	ExposeURLAnnotation         = "fabric8.io/exposeUrl"
-	ExposeGeneratedByAnnotation = "fabric8.io/generated-by"
-	JenkinsXSkipTLSAnnotation   = "jenkins-x.io/skip.tls"
-	ExposeIngressAnnotation     = "fabric8.io/ingress.annotations"
-	CertManagerAnnotation       = "certmanager.k8s.io/issuer"
+	ExposeAnnotation             = "fabric8.io/expose"
+	ExposeURLAnnotation          = "fabric8.io/exposeUrl"
+	ExposeGeneratedByAnnotation  = "fabric8.io/generated-by"
+	JenkinsXSkipTLSAnnotation    = "jenkins-x.io/skip.tls"
+	ExposeIngressAnnotation      = "fabric8.io/ingress.annotations"
+	CertManagerAnnotation        = "certmanager.k8s.io/issuer"
+	CertManagerClusterAnnotation = "certmanager.k8s.io/cluster-issuer"
 )
 
 type ServiceURL struct {
-	Name string
-	URL  string
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	URL       string `json:"url"`
 }
 
 func GetServices(client kubernetes.Interface, ns string) (map[string]*v1.Service, error) {
@@ -159,8 +161,9 @@ func FindServiceURLs(client kubernetes.Interface, namespace string) ([]ServiceUR
 		url := GetServiceURL(&svc)
 		if len(url) > 0 {
 			urls = append(urls, ServiceURL{
-				Name: svc.Name,
-				URL:  url,
+				Name:      svc.Name,
+				Namespace: namespace,
+				URL:       url,
 			})
 		}
 	}
@@ -280,6 +283,16 @@ func IsServicePresent(c kubernetes.Interface, name, ns string) (bool, error) {
 }
 
 func AnnotateNamespaceServicesWithCertManager(c kubernetes.Interface, ns, issuer string, services ...string) error {
+	return annotateNamespaceServices(c, ns, CertManagerAnnotation, issuer, services...)
+}
+
+// AnnotateNamespaceServicesWithCertManagerClusterIssuer annotates the exposed services so that their ingress rules
+// use the given cert-manager ClusterIssuer
+func AnnotateNamespaceServicesWithCertManagerClusterIssuer(c kubernetes.Interface, ns, issuer string, services ...string) error {
+	return annotateNamespaceServices(c, ns, CertManagerClusterAnnotation, issuer, services...)
+}
+
+func annotateNamespaceServices(c kubernetes.Interface, ns, annotation, issuer string, services ...string) error {
 	svcList, err := GetServices(c, ns)
 	if err != nil {
 		return err
@@ -297,9 +310,9 @@ func AnnotateNamespaceServicesWithCertManager(c kubernetes.Interface, ns, issuer
 			existingAnnotations, _ := s.Annotations[ExposeIngressAnnotation]
 			// if no existing `fabric8.io/ingress.annotations` initialise and add else update with ClusterIssuer
 			if len(existingAnnotations) > 0 {
-				s.Annotations[ExposeIngressAnnotation] = existingAnnotations + "\n" + CertManagerAnnotation + ": " + issuer
+				s.Annotations[ExposeIngressAnnotation] = existingAnnotations + "\n" + annotation + ": " + issuer
 			} else {
-				s.Annotations[ExposeIngressAnnotation] = CertManagerAnnotation + ": " + issuer
+				s.Annotations[ExposeIngressAnnotation] = annotation + ": " + issuer
 			}
 			_, err = c.CoreV1().Services(ns).Update(s)
 			if err != nil {
@@ -334,7 +347,7 @@ func CleanServiceAnnotations(c kubernetes.Interface, ns string, services ...stri
 				for _, element := range annotations {
 					annotation := strings.SplitN(element, ":", 2)
 					key, _ := annotation[0], strings.TrimSpace(annotation[1])
-					if key != CertManagerAnnotation {
+					if key != CertManagerAnnotation && key != CertManagerClusterAnnotation {
 						newAnnotations = append(newAnnotations, element)
 					}
 				}
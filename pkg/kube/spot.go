@@ -0,0 +1,240 @@
+package kube
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DefaultSpotRetries the number of times a build is run again when its pod is evicted from a preemptible or
+	// spot node
+	DefaultSpotRetries = 2
+
+	// AnnotationEvictionRetries the number of times a build was run again because its pod was evicted
+	AnnotationEvictionRetries = "jenkins.io/eviction-retries"
+	// AnnotationRetryOf the name of the original build which a build runs again
+	AnnotationRetryOf = "jenkins.io/retry-of"
+
+	spotAffinityWeight = 100
+)
+
+// podEvictionReasons the reasons of the failed pods which were evicted or preempted rather than failing themselves
+var podEvictionReasons = map[string]bool{
+	"Evicted":      true,
+	"Preempting":   true,
+	"NodeLost":     true,
+	"Shutdown":     true,
+	"NodeShutdown": true,
+	"Terminated":   true,
+}
+
+// SpotNodeLabels the labels which the cloud providers put on their preemptible or spot nodes indexed by label key
+var SpotNodeLabels = map[string]string{
+	// GKE preemptible VMs
+	"cloud.google.com/gke-preemptible": "true",
+	// EKS spot instances added with eksctl
+	"lifecycle": "Ec2Spot",
+	// AKS spot node pools
+	"kubernetes.azure.com/scalesetpriority": "spot",
+}
+
+// IsSpotNode returns true if the node is a preemptible or spot node
+func IsSpotNode(node *v1.Node) bool {
+	for key, value := range SpotNodeLabels {
+		if node.Labels[key] == value {
+			return true
+		}
+	}
+	return false
+}
+
+// FindSpotNodes returns the names of the preemptible or spot nodes of the cluster
+func FindSpotNodes(client kubernetes.Interface) ([]string, error) {
+	nodes, err := client.CoreV1().Nodes().List(meta_v1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the nodes")
+	}
+	answer := []string{}
+	for i := range nodes.Items {
+		if IsSpotNode(&nodes.Items[i]) {
+			answer = append(answer, nodes.Items[i].Name)
+		}
+	}
+	return answer, nil
+}
+
+// SpotTolerations returns the tolerations of the taints which the preemptible or spot nodes may have
+func SpotTolerations() []v1.Toleration {
+	answer := []v1.Toleration{}
+	for _, key := range spotNodeLabelKeys() {
+		answer = append(answer, v1.Toleration{
+			Key:      key,
+			Operator: v1.TolerationOpEqual,
+			Value:    SpotNodeLabels[key],
+			Effect:   v1.TaintEffectNoSchedule,
+		})
+	}
+	return answer
+}
+
+// SpotAffinity returns the affinity which prefers scheduling pods on the preemptible or spot nodes
+func SpotAffinity() *v1.Affinity {
+	terms := []v1.PreferredSchedulingTerm{}
+	for _, key := range spotNodeLabelKeys() {
+		terms = append(terms, v1.PreferredSchedulingTerm{
+			Weight: spotAffinityWeight,
+			Preference: v1.NodeSelectorTerm{
+				MatchExpressions: []v1.NodeSelectorRequirement{
+					{
+						Key:      key,
+						Operator: v1.NodeSelectorOpIn,
+						Values:   []string{SpotNodeLabels[key]},
+					},
+				},
+			},
+		})
+	}
+	return &v1.Affinity{
+		NodeAffinity: &v1.NodeAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: terms,
+		},
+	}
+}
+
+// ApplySpotScheduling adds the spot tolerations and affinity to the pod when enabled or removes them otherwise
+// returning true if the pod was modified
+func ApplySpotScheduling(pod *v1.Pod, enabled bool) bool {
+	original := pod.Spec.DeepCopy()
+
+	tolerations := []v1.Toleration{}
+	for _, toleration := range pod.Spec.Tolerations {
+		if _, ok := SpotNodeLabels[toleration.Key]; !ok {
+			tolerations = append(tolerations, toleration)
+		}
+	}
+	if enabled {
+		tolerations = append(tolerations, SpotTolerations()...)
+	}
+	if len(tolerations) == 0 {
+		tolerations = nil
+	}
+	pod.Spec.Tolerations = tolerations
+
+	affinity := pod.Spec.Affinity
+	if affinity == nil {
+		affinity = &v1.Affinity{}
+	}
+	nodeAffinity := affinity.NodeAffinity
+	if nodeAffinity == nil {
+		nodeAffinity = &v1.NodeAffinity{}
+	}
+	terms := []v1.PreferredSchedulingTerm{}
+	for _, term := range nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		if !isSpotSchedulingTerm(term) {
+			terms = append(terms, term)
+		}
+	}
+	if enabled {
+		terms = append(terms, SpotAffinity().NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution...)
+	}
+	if len(terms) == 0 {
+		terms = nil
+	}
+	nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = terms
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil && terms == nil {
+		nodeAffinity = nil
+	}
+	affinity.NodeAffinity = nodeAffinity
+	if affinity.NodeAffinity == nil && affinity.PodAffinity == nil && affinity.PodAntiAffinity == nil {
+		affinity = nil
+	}
+	pod.Spec.Affinity = affinity
+	return !reflect.DeepEqual(original, &pod.Spec)
+}
+
+func isSpotSchedulingTerm(term v1.PreferredSchedulingTerm) bool {
+	expressions := term.Preference.MatchExpressions
+	if len(expressions) != 1 {
+		return false
+	}
+	_, ok := SpotNodeLabels[expressions[0].Key]
+	return ok
+}
+
+// spotNodeLabelKeys returns the keys of the spot node labels in a stable order
+func spotNodeLabelKeys() []string {
+	answer := []string{}
+	for key := range SpotNodeLabels {
+		answer = append(answer, key)
+	}
+	sort.Strings(answer)
+	return answer
+}
+
+// IsPodEvicted returns true if the pod failed because it was evicted or preempted from its node rather than because
+// one of its containers failed, so that running it again does not repeat any side effects of a failed step
+func IsPodEvicted(pod *v1.Pod) bool {
+	if pod.Status.Phase != v1.PodFailed {
+		return false
+	}
+	if podEvictionReasons[pod.Status.Reason] {
+		return true
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == "DisruptionTarget" && condition.Status == v1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryEvictedResource creates a copy of the build resource of the given name so that the build runs again after its
+// pod was evicted. The name of the copy is returned or an empty string if the build was already retried maxRetries times
+func RetryEvictedResource(client dynamic.ResourceInterface, name string, maxRetries int) (string, error) {
+	u, err := client.Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get %s", name)
+	}
+	annotations := u.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	retries, _ := strconv.Atoi(annotations[AnnotationEvictionRetries])
+	if retries >= maxRetries {
+		return "", nil
+	}
+	original := annotations[AnnotationRetryOf]
+	if original == "" {
+		original = name
+	}
+	retries++
+	annotations[AnnotationEvictionRetries] = strconv.Itoa(retries)
+	annotations[AnnotationRetryOf] = original
+
+	retry := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": u.GetAPIVersion(),
+			"kind":       u.GetKind(),
+			"spec":       u.Object["spec"],
+		},
+	}
+	retry.SetName(fmt.Sprintf("%s-retry-%d", original, retries))
+	retry.SetNamespace(u.GetNamespace())
+	retry.SetLabels(u.GetLabels())
+	retry.SetAnnotations(annotations)
+	_, err = client.Create(retry)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return "", errors.Wrapf(err, "failed to create %s", retry.GetName())
+	}
+	return retry.GetName(), nil
+}
@@ -0,0 +1,70 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFindSpotNodes(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset(
+		&v1.Node{ObjectMeta: meta_v1.ObjectMeta{Name: "regular"}},
+		&v1.Node{ObjectMeta: meta_v1.ObjectMeta{
+			Name:   "preemptible",
+			Labels: map[string]string{"cloud.google.com/gke-preemptible": "true"},
+		}},
+		&v1.Node{ObjectMeta: meta_v1.ObjectMeta{
+			Name:   "on-demand",
+			Labels: map[string]string{"lifecycle": "OnDemand"},
+		}},
+	)
+
+	nodes, err := kube.FindSpotNodes(client)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"preemptible"}, nodes)
+}
+
+func TestApplySpotScheduling(t *testing.T) {
+	t.Parallel()
+	other := v1.Toleration{Key: "dedicated", Operator: v1.TolerationOpExists}
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Tolerations: []v1.Toleration{other},
+		},
+	}
+
+	assert.True(t, kube.ApplySpotScheduling(pod, true), "enabling should modify the pod")
+	assert.Equal(t, append([]v1.Toleration{other}, kube.SpotTolerations()...), pod.Spec.Tolerations)
+	require.NotNil(t, pod.Spec.Affinity)
+	assert.Equal(t, kube.SpotAffinity(), pod.Spec.Affinity)
+
+	assert.False(t, kube.ApplySpotScheduling(pod, true), "enabling again should not modify the pod")
+
+	assert.True(t, kube.ApplySpotScheduling(pod, false), "disabling should modify the pod")
+	assert.Equal(t, []v1.Toleration{other}, pod.Spec.Tolerations)
+	assert.Nil(t, pod.Spec.Affinity)
+}
+
+func TestIsPodEvicted(t *testing.T) {
+	t.Parallel()
+	evicted := &v1.Pod{Status: v1.PodStatus{Phase: v1.PodFailed, Reason: "Evicted"}}
+	assert.True(t, kube.IsPodEvicted(evicted))
+
+	disrupted := &v1.Pod{Status: v1.PodStatus{
+		Phase:      v1.PodFailed,
+		Conditions: []v1.PodCondition{{Type: "DisruptionTarget", Status: v1.ConditionTrue}},
+	}}
+	assert.True(t, kube.IsPodEvicted(disrupted))
+
+	failed := &v1.Pod{Status: v1.PodStatus{Phase: v1.PodFailed}}
+	assert.False(t, kube.IsPodEvicted(failed), "a pod whose step failed should not be retried")
+
+	running := &v1.Pod{Status: v1.PodStatus{Phase: v1.PodRunning, Reason: "Evicted"}}
+	assert.False(t, kube.IsPodEvicted(running))
+}
@@ -0,0 +1,166 @@
+package kube
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// UsersConfigFileName the name of the OWNERS style file in the dev environment git repository which lists the
+	// git provider logins of the users of each team role
+	UsersConfigFileName = "USERS"
+)
+
+// UsersConfig the roles of the users of a team mapped to their git provider logins
+type UsersConfig struct {
+	// Roles maps the names of the team roles to the logins of the users with the role in all environments
+	Roles map[string][]string `json:"roles,omitempty"`
+	// Environments maps the names of environments to the logins of the users of each role in only that environment
+	Environments map[string]map[string][]string `json:"environments,omitempty"`
+}
+
+// LoadUsersConfig loads the users configuration file in the given directory returning nil if there is no file
+func LoadUsersConfig(dir string) (*UsersConfig, error) {
+	fileName := filepath.Join(dir, UsersConfigFileName)
+	exists, err := util.FileExists(fileName)
+	if err != nil || !exists {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load file %s", fileName)
+	}
+	config := &UsersConfig{}
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal YAML file %s", fileName)
+	}
+	return config, nil
+}
+
+// EnvironmentRoleBindingName returns the name of the EnvironmentRoleBinding of the role in the environment or of the
+// role in all environments if no environment is given
+func EnvironmentRoleBindingName(role string, env string) string {
+	if env == "" {
+		return role
+	}
+	return role + "-" + env
+}
+
+// UserBindings returns the sorted names of the EnvironmentRoleBindings of each user login
+func (c *UsersConfig) UserBindings() map[string][]string {
+	answer := map[string][]string{}
+	for role, logins := range c.Roles {
+		for _, login := range logins {
+			answer[login] = append(answer[login], EnvironmentRoleBindingName(role, ""))
+		}
+	}
+	for env, roles := range c.Environments {
+		for role, logins := range roles {
+			for _, login := range logins {
+				answer[login] = append(answer[login], EnvironmentRoleBindingName(role, env))
+			}
+		}
+	}
+	for _, bindings := range answer {
+		sort.Strings(bindings)
+	}
+	return answer
+}
+
+// Validate returns an error if the configuration refers to a role which is not one of the team roles
+func (c *UsersConfig) Validate(roles map[string]*rbacv1.Role) error {
+	roleNames := []string{}
+	for name := range roles {
+		roleNames = append(roleNames, name)
+	}
+	sort.Strings(roleNames)
+	check := func(role string) error {
+		if roles[role] == nil {
+			return fmt.Errorf("unknown role %s in %s. Available roles: %v", role, UsersConfigFileName, roleNames)
+		}
+		return nil
+	}
+	for role := range c.Roles {
+		if err := check(role); err != nil {
+			return err
+		}
+	}
+	for _, envRoles := range c.Environments {
+		for role := range envRoles {
+			if err := check(role); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// EnsureEnvironmentRoleBindings lazily creates the EnvironmentRoleBindings which bind a role to only one environment
+func (c *UsersConfig) EnsureEnvironmentRoleBindings(jxClient versioned.Interface, ns string) error {
+	envRoles, _, err := GetEnvironmentRoles(jxClient, ns)
+	if err != nil {
+		return err
+	}
+	envRoleInterface := jxClient.JenkinsV1().EnvironmentRoleBindings(ns)
+	for env, roles := range c.Environments {
+		for role := range roles {
+			name := EnvironmentRoleBindingName(role, env)
+			if envRoles[name] != nil {
+				continue
+			}
+			envRole := &v1.EnvironmentRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: ns,
+					Labels: map[string]string{
+						LabelKind: ValueKindEnvironmentRole,
+					},
+				},
+				Spec: v1.EnvironmentRoleBindingSpec{
+					RoleRef: rbacv1.RoleRef{
+						Kind:     "Role",
+						Name:     role,
+						APIGroup: "rbac.authorization.k8s.io",
+					},
+					Subjects: []rbacv1.Subject{},
+					Environments: []v1.EnvironmentFilter{
+						{
+							Includes: []string{env},
+						},
+					},
+				},
+			}
+			_, err = envRoleInterface.Create(envRole)
+			if err != nil {
+				return errors.Wrapf(err, "Failed to create EnvironmentRoleBinding %s", name)
+			}
+		}
+	}
+	return nil
+}
+
+// FindUserByGitLogin returns the user with the given git provider login or login or nil if there is no such user
+func FindUserByGitLogin(users map[string]*v1.User, login string) *v1.User {
+	for _, user := range users {
+		if user.Spec.GitProviderUser == login {
+			return user
+		}
+	}
+	for _, user := range users {
+		if user.Spec.Login == login {
+			return user
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package kube_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestLoadUsersConfig(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "test-users-config-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	config, err := kube.LoadUsersConfig(dir)
+	assert.NoError(t, err)
+	assert.Nil(t, config, "there should be no config without a file")
+
+	data := `roles:
+  admin:
+  - alice
+  viewer:
+  - bob
+environments:
+  staging:
+    developer:
+    - bob
+`
+	err = ioutil.WriteFile(filepath.Join(dir, kube.UsersConfigFileName), []byte(data), 0644)
+	require.NoError(t, err)
+
+	config, err = kube.LoadUsersConfig(dir)
+	require.NoError(t, err)
+	require.NotNil(t, config)
+
+	bindings := config.UserBindings()
+	assert.Equal(t, []string{"admin"}, bindings["alice"])
+	assert.Equal(t, []string{"developer-staging", "viewer"}, bindings["bob"])
+
+	roles := map[string]*rbacv1.Role{
+		"admin":     {},
+		"developer": {},
+		"viewer":    {},
+	}
+	assert.NoError(t, config.Validate(roles))
+	delete(roles, "developer")
+	assert.Error(t, config.Validate(roles), "developer should be an unknown role")
+}
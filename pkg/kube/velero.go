@@ -0,0 +1,237 @@
+package kube
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// VeleroAPIVersion the API version of the Velero resources
+	VeleroAPIVersion = "velero.io/v1"
+
+	// VeleroPhaseCompleted the phase of a Velero backup or restore which completed successfully
+	VeleroPhaseCompleted = "Completed"
+	// VeleroPhaseFailed the phase of a Velero backup or restore which failed
+	VeleroPhaseFailed = "Failed"
+	// VeleroPhasePartiallyFailed the phase of a Velero backup or restore which completed with errors
+	VeleroPhasePartiallyFailed = "PartiallyFailed"
+
+	// LabelVeleroSchedule the label Velero adds to the backups created by a schedule
+	LabelVeleroSchedule = "velero.io/schedule-name"
+)
+
+// VeleroBackupSpec defines what a Velero backup contains
+type VeleroBackupSpec struct {
+	IncludedNamespaces      []string `json:"includedNamespaces,omitempty"`
+	IncludeClusterResources *bool    `json:"includeClusterResources,omitempty"`
+	TTL                     string   `json:"ttl,omitempty"`
+}
+
+// VeleroStatus the status of a Velero backup or restore
+type VeleroStatus struct {
+	Phase string `json:"phase,omitempty"`
+}
+
+// VeleroBackup a Velero backup of the resources of some namespaces
+type VeleroBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              VeleroBackupSpec `json:"spec"`
+	Status            VeleroStatus     `json:"status,omitempty"`
+}
+
+// VeleroBackupList a list of Velero backups
+type VeleroBackupList struct {
+	Items []VeleroBackup `json:"items"`
+}
+
+// VeleroScheduleSpec defines when a Velero backup is created and what it contains
+type VeleroScheduleSpec struct {
+	Schedule string           `json:"schedule"`
+	Template VeleroBackupSpec `json:"template"`
+}
+
+// VeleroSchedule a Velero schedule of backups
+type VeleroSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              VeleroScheduleSpec `json:"spec"`
+}
+
+// VeleroRestoreSpec defines which backup is restored
+type VeleroRestoreSpec struct {
+	BackupName string `json:"backupName"`
+	RestorePVs *bool  `json:"restorePVs,omitempty"`
+}
+
+// VeleroRestore a Velero restore of a backup
+type VeleroRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              VeleroRestoreSpec `json:"spec"`
+	Status            VeleroStatus      `json:"status,omitempty"`
+}
+
+// NewVeleroBackupSpec returns the spec of a backup of the given namespaces including the cluster wide resources, such
+// as CRDs, they use. An empty TTL uses the default of Velero
+func NewVeleroBackupSpec(namespaces []string, ttl string) VeleroBackupSpec {
+	includeClusterResources := true
+	return VeleroBackupSpec{
+		IncludedNamespaces:      namespaces,
+		IncludeClusterResources: &includeClusterResources,
+		TTL:                     ttl,
+	}
+}
+
+// CreateVeleroBackup creates a Velero backup with the given name and spec in the namespace Velero is installed in
+func CreateVeleroBackup(c kubernetes.Interface, ns string, name string, spec VeleroBackupSpec) error {
+	backup := &VeleroBackup{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: VeleroAPIVersion,
+			Kind:       "Backup",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+		Spec: spec,
+	}
+	return createVeleroResource(c, ns, "backups", name, backup)
+}
+
+// CreateVeleroSchedule creates, or recreates, the Velero schedule with the given name which backs up on the given cron
+// schedule
+func CreateVeleroSchedule(c kubernetes.Interface, ns string, name string, schedule string, spec VeleroBackupSpec) error {
+	uri := veleroURI(ns, "schedules")
+	_, err := c.CoreV1().RESTClient().Get().RequestURI(uri).Name(name).DoRaw()
+	if err == nil {
+		resp, err := c.CoreV1().RESTClient().Delete().RequestURI(uri).Name(name).DoRaw()
+		if err != nil {
+			return fmt.Errorf("failed to delete Velero schedule %s %v: %s", name, err, string(resp))
+		}
+	}
+	s := &VeleroSchedule{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: VeleroAPIVersion,
+			Kind:       "Schedule",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+		Spec: VeleroScheduleSpec{
+			Schedule: schedule,
+			Template: spec,
+		},
+	}
+	return createVeleroResource(c, ns, "schedules", name, s)
+}
+
+// GetVeleroSchedule returns the Velero schedule with the given name or nil if there is none
+func GetVeleroSchedule(c kubernetes.Interface, ns string, name string) (*VeleroSchedule, error) {
+	data, err := c.CoreV1().RESTClient().Get().RequestURI(veleroURI(ns, "schedules")).Name(name).DoRaw()
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "getting the Velero schedule %s in namespace %s", name, ns)
+	}
+	schedule := &VeleroSchedule{}
+	err = json.Unmarshal(data, schedule)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing the Velero schedule %s in namespace %s", name, ns)
+	}
+	return schedule, nil
+}
+
+// CreateVeleroRestore creates a Velero restore with the given name of the given backup
+func CreateVeleroRestore(c kubernetes.Interface, ns string, name string, backupName string) error {
+	restorePVs := true
+	restore := &VeleroRestore{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: VeleroAPIVersion,
+			Kind:       "Restore",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+		Spec: VeleroRestoreSpec{
+			BackupName: backupName,
+			RestorePVs: &restorePVs,
+		},
+	}
+	return createVeleroResource(c, ns, "restores", name, restore)
+}
+
+// GetVeleroBackups returns the Velero backups in the given namespace with the newest first
+func GetVeleroBackups(c kubernetes.Interface, ns string) ([]VeleroBackup, error) {
+	data, err := c.CoreV1().RESTClient().Get().RequestURI(veleroURI(ns, "backups")).DoRaw()
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing the Velero backups in namespace %s", ns)
+	}
+	list := VeleroBackupList{}
+	err = json.Unmarshal(data, &list)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing the Velero backups in namespace %s", ns)
+	}
+	backups := list.Items
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[j].CreationTimestamp.Before(&backups[i].CreationTimestamp)
+	})
+	return backups, nil
+}
+
+// LatestCompletedVeleroBackup returns the newest of the given backups which completed successfully or nil if there is none
+func LatestCompletedVeleroBackup(backups []VeleroBackup) *VeleroBackup {
+	var answer *VeleroBackup
+	for i := range backups {
+		backup := &backups[i]
+		if backup.Status.Phase != VeleroPhaseCompleted {
+			continue
+		}
+		if answer == nil || answer.CreationTimestamp.Before(&backup.CreationTimestamp) {
+			answer = backup
+		}
+	}
+	return answer
+}
+
+// GetVeleroPhase returns the phase of the Velero backup or restore with the given name. The resource is either
+// backups or restores
+func GetVeleroPhase(c kubernetes.Interface, ns string, resource string, name string) (string, error) {
+	data, err := c.CoreV1().RESTClient().Get().RequestURI(veleroURI(ns, resource)).Name(name).DoRaw()
+	if err != nil {
+		return "", errors.Wrapf(err, "getting the Velero %s %s in namespace %s", resource, name, ns)
+	}
+	status := struct {
+		Status VeleroStatus `json:"status"`
+	}{}
+	err = json.Unmarshal(data, &status)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing the Velero %s %s in namespace %s", resource, name, ns)
+	}
+	return status.Status.Phase, nil
+}
+
+func createVeleroResource(c kubernetes.Interface, ns string, resource string, name string, obj interface{}) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return errors.Wrapf(err, "converting the Velero %s %s to JSON", resource, name)
+	}
+	resp, err := c.CoreV1().RESTClient().Post().RequestURI(veleroURI(ns, resource)).Body(data).DoRaw()
+	if err != nil {
+		return fmt.Errorf("failed to create the Velero %s %s %v: %s", resource, name, err, string(resp))
+	}
+	return nil
+}
+
+func veleroURI(ns string, resource string) string {
+	return fmt.Sprintf("/apis/%s/namespaces/%s/%s", VeleroAPIVersion, ns, resource)
+}
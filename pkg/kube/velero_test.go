@@ -0,0 +1,50 @@
+package kube_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func veleroBackup(name string, phase string, created time.Time) kube.VeleroBackup {
+	return kube.VeleroBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Status: kube.VeleroStatus{
+			Phase: phase,
+		},
+	}
+}
+
+func TestLatestCompletedVeleroBackup(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	backups := []kube.VeleroBackup{
+		veleroBackup("old", kube.VeleroPhaseCompleted, now.Add(-48*time.Hour)),
+		veleroBackup("failed", kube.VeleroPhaseFailed, now.Add(-1*time.Hour)),
+		veleroBackup("yesterday", kube.VeleroPhaseCompleted, now.Add(-24*time.Hour)),
+		veleroBackup("running", "InProgress", now),
+	}
+
+	latest := kube.LatestCompletedVeleroBackup(backups)
+	require.NotNil(t, latest)
+	assert.Equal(t, "yesterday", latest.Name)
+
+	assert.Nil(t, kube.LatestCompletedVeleroBackup(backups[1:2]))
+	assert.Nil(t, kube.LatestCompletedVeleroBackup(nil))
+}
+
+func TestNewVeleroBackupSpec(t *testing.T) {
+	t.Parallel()
+	spec := kube.NewVeleroBackupSpec([]string{"jx", "jx-staging"}, "720h")
+	assert.Equal(t, []string{"jx", "jx-staging"}, spec.IncludedNamespaces)
+	require.NotNil(t, spec.IncludeClusterResources)
+	assert.True(t, *spec.IncludeClusterResources)
+	assert.Equal(t, "720h", spec.TTL)
+}
@@ -2,7 +2,9 @@ package log
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 
@@ -10,20 +12,132 @@ import (
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 )
 
+var (
+	// level the level of the messages which are logged
+	level = logrus.InfoLevel
+	// jsonFormat whether messages are logged as JSON objects
+	jsonFormat = false
+	// logger logs the structured messages and all messages when using the JSON format
+	logger = logrus.New()
+	// output the writer the messages are written to instead of the standard output, if any
+	output io.Writer
+)
+
+// SetOutput writes the messages to the given writer instead of the standard output until the returned function is
+// called, such as to capture the output of a command
+func SetOutput(w io.Writer) func() {
+	previousOutput := output
+	previousColorOutput := color.Output
+	output = w
+	color.Output = w
+	return func() {
+		output = previousOutput
+		color.Output = previousColorOutput
+	}
+}
+
+// stdout returns the writer the messages are written to
+func stdout() io.Writer {
+	if output != nil {
+		return output
+	}
+	return terminal.NewAnsiStdout(os.Stdout)
+}
+
+// SetLevel sets the level of the messages which are logged. One of: panic, fatal, error, warning, info, debug
+func SetLevel(s string) error {
+	lvl, err := logrus.ParseLevel(s)
+	if err != nil {
+		return err
+	}
+	level = lvl
+	logger.SetLevel(lvl)
+	return nil
+}
+
+// GetLevel returns the level of the messages which are logged
+func GetLevel() string {
+	return level.String()
+}
+
+// IsDebug returns true if debug messages are logged
+func IsDebug() bool {
+	return level >= logrus.DebugLevel
+}
+
+// SetJSONFormat enables or disables logging the messages as JSON objects including their level and time
+func SetJSONFormat(enabled bool) {
+	jsonFormat = enabled
+	if enabled {
+		logger.Formatter = &logrus.JSONFormatter{}
+	} else {
+		logger.Formatter = &logrus.TextFormatter{}
+	}
+}
+
+// WithFields returns an entry which logs a message along with the given structured fields
+func WithFields(fields map[string]interface{}) *logrus.Entry {
+	return logger.WithFields(logrus.Fields(fields))
+}
+
+// logJSON logs the message with the given level as JSON returning false if the JSON format is not enabled
+func logJSON(lvl logrus.Level, msg string) bool {
+	if !jsonFormat {
+		return false
+	}
+	msg = strings.TrimSpace(msg)
+	if msg == "" {
+		return true
+	}
+	switch lvl {
+	case logrus.DebugLevel:
+		logger.Debug(msg)
+	case logrus.InfoLevel:
+		logger.Info(msg)
+	case logrus.WarnLevel:
+		logger.Warn(msg)
+	default:
+		logger.Error(msg)
+	}
+	return true
+}
+
+// Debugf logs the formatted message if debug messages are enabled such as via the --verbose flag
+func Debugf(msg string, args ...interface{}) {
+	Debug(fmt.Sprintf(msg, args...))
+}
+
+// Debug logs the message if debug messages are enabled such as via the --verbose flag
+func Debug(msg string) {
+	if level < logrus.DebugLevel || logJSON(logrus.DebugLevel, msg) {
+		return
+	}
+	fmt.Fprint(stdout(), msg)
+}
+
 func Infof(msg string, args ...interface{}) {
 	Info(fmt.Sprintf(msg, args...))
 }
 
 func Info(msg string) {
-	fmt.Fprint(terminal.NewAnsiStdout(os.Stdout), msg)
+	if level < logrus.InfoLevel || logJSON(logrus.InfoLevel, msg) {
+		return
+	}
+	fmt.Fprint(stdout(), msg)
 }
 
 func Infoln(msg string) {
-	fmt.Fprintln(terminal.NewAnsiStdout(os.Stdout), msg)
+	if level < logrus.InfoLevel || logJSON(logrus.InfoLevel, msg) {
+		return
+	}
+	fmt.Fprintln(stdout(), msg)
 }
 
 func Blank() {
-	fmt.Println()
+	if level < logrus.InfoLevel || jsonFormat {
+		return
+	}
+	fmt.Fprintln(stdout())
 }
 
 func Warnf(msg string, args ...interface{}) {
@@ -31,6 +145,9 @@ func Warnf(msg string, args ...interface{}) {
 }
 
 func Warn(msg string) {
+	if level < logrus.WarnLevel || logJSON(logrus.WarnLevel, msg) {
+		return
+	}
 	color.Yellow(msg)
 }
 
@@ -39,6 +156,9 @@ func Errorf(msg string, args ...interface{}) {
 }
 
 func Error(msg string) {
+	if level < logrus.ErrorLevel || logJSON(logrus.ErrorLevel, msg) {
+		return
+	}
 	color.Red(msg)
 }
 
@@ -52,10 +172,16 @@ func Fatalf(msg string, args ...interface{}) {
 }
 
 func Fatal(msg string) {
+	if logJSON(logrus.FatalLevel, msg) {
+		return
+	}
 	color.Red(msg)
 }
 
 func Success(msg string) {
+	if level < logrus.InfoLevel || logJSON(logrus.InfoLevel, msg) {
+		return
+	}
 	color.Green(msg)
 }
 
@@ -64,6 +190,9 @@ func Successf(msg string, args ...interface{}) {
 }
 
 func Failure(msg string) {
+	if level < logrus.ErrorLevel || logJSON(logrus.ErrorLevel, msg) {
+		return
+	}
 	color.Red(msg)
 }
 
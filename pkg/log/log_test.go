@@ -0,0 +1,38 @@
+package log_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetLevel(t *testing.T) {
+	defer log.SetLevel("info")
+
+	err := log.SetLevel("debug")
+	assert.NoError(t, err)
+	assert.Equal(t, "debug", log.GetLevel())
+	assert.True(t, log.IsDebug())
+
+	err = log.SetLevel("warn")
+	assert.NoError(t, err)
+	assert.Equal(t, "warning", log.GetLevel())
+	assert.False(t, log.IsDebug())
+
+	err = log.SetLevel("cheese")
+	assert.Error(t, err)
+	assert.Equal(t, "warning", log.GetLevel(), "an invalid level should not change the level")
+}
+
+func TestSetOutput(t *testing.T) {
+	var buffer bytes.Buffer
+	restore := log.SetOutput(&buffer)
+	log.Infof("hello %s\n", "world")
+	log.Blank()
+	restore()
+	log.Infof("not captured\n")
+
+	assert.Equal(t, "hello world\n\n", buffer.String())
+}
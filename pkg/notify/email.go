@@ -0,0 +1,59 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// sendMail sends the emails; it is a variable so that it can be replaced in tests
+var sendMail = smtp.SendMail
+
+// EmailSender emails messages to the recipients using an SMTP server
+type EmailSender struct {
+	Server     string
+	From       string
+	Recipients []string
+	Username   string
+	Password   string
+}
+
+// Send emails the message of the event to the recipients
+func (s *EmailSender) Send(event *Event, message string) error {
+	var auth smtp.Auth
+	if s.Username != "" {
+		host, _, err := net.SplitHostPort(s.Server)
+		if err != nil {
+			return fmt.Errorf("invalid SMTP server %s: %s", s.Server, err)
+		}
+		auth = smtp.PlainAuth("", s.Username, s.Password, host)
+	}
+	err := sendMail(s.Server, auth, s.From, s.Recipients, s.createMessage(event, message))
+	if err != nil {
+		return fmt.Errorf("failed to email %s using SMTP server %s: %s", strings.Join(s.Recipients, ", "), s.Server, err)
+	}
+	return nil
+}
+
+func (s *EmailSender) createMessage(event *Event, message string) []byte {
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "From: %s\r\n", s.From)
+	fmt.Fprintf(&buffer, "To: %s\r\n", strings.Join(s.Recipients, ", "))
+	fmt.Fprintf(&buffer, "Subject: [jx] %s\r\n", firstLine(message))
+	buffer.WriteString("MIME-Version: 1.0\r\n")
+	buffer.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	buffer.WriteString("\r\n")
+	buffer.WriteString(message)
+	buffer.WriteString("\r\n")
+	if link := event.Link(); link != "" && !strings.Contains(message, link) {
+		fmt.Fprintf(&buffer, "\r\n%s\r\n", link)
+	}
+	return buffer.Bytes()
+}
+
+// firstLine returns the first line of the text so it can be used in an email header
+func firstLine(text string) string {
+	return strings.TrimSpace(strings.SplitN(text, "\n", 2)[0])
+}
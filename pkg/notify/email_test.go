@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailSender(t *testing.T) {
+	var server, from string
+	var to []string
+	var body string
+	sendMail = func(addr string, a smtp.Auth, f string, recipients []string, msg []byte) error {
+		server, from, to, body = addr, f, recipients, string(msg)
+		return nil
+	}
+	defer func() {
+		sendMail = smtp.SendMail
+	}()
+
+	sender := &EmailSender{
+		Server:     "smtp.example.com:587",
+		From:       "jx@example.com",
+		Recipients: []string{"oncall@example.com", "team@example.com"},
+		Username:   "jx",
+		Password:   "secret",
+	}
+	event := &Event{Kind: EventPromoted, URL: "http://myapp.jx-production.example.com"}
+	err := sender.Send(event, "Promoted myapp version 1.0.2 to production")
+	require.NoError(t, err)
+
+	assert.Equal(t, "smtp.example.com:587", server)
+	assert.Equal(t, "jx@example.com", from)
+	assert.Equal(t, []string{"oncall@example.com", "team@example.com"}, to)
+	assert.True(t, strings.Contains(body, "To: oncall@example.com, team@example.com\r\n"))
+	assert.True(t, strings.Contains(body, "Subject: [jx] Promoted myapp version 1.0.2 to production\r\n"))
+	assert.True(t, strings.HasSuffix(body, "\r\nhttp://myapp.jx-production.example.com\r\n"))
+
+	sender.Server = "smtp.example.com"
+	err = sender.Send(event, "Promoted myapp version 1.0.2 to production")
+	assert.Error(t, err)
+}
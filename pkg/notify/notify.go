@@ -0,0 +1,258 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// EventPipelineStarted a pipeline started
+	EventPipelineStarted = "pipeline-started"
+	// EventPipelineSucceeded a pipeline completed successfully
+	EventPipelineSucceeded = "pipeline-succeeded"
+	// EventPipelineFailed a pipeline failed
+	EventPipelineFailed = "pipeline-failed"
+	// EventPromoted a version of an application was promoted to an environment
+	EventPromoted = "promoted"
+	// EventPromotionFailed the promotion of a version of an application to an environment failed
+	EventPromotionFailed = "promotion-failed"
+	// EventPreview a preview environment was created or updated for a pull request
+	EventPreview = "preview"
+	// EventTerraformDrift the resources of a cluster were changed outside of its Terraform state. It is sent by
+	// 'jx step terraform drift' to its own webhooks rather than to the notification channels
+	EventTerraformDrift = "terraform-drift"
+
+	// SecretKeyToken the key of the token in the Secret of a notification channel
+	SecretKeyToken = "token"
+	// SecretKeyURL the key of the URL in the Secret of a notification channel
+	SecretKeyURL = "url"
+	// SecretKeyUsername the key of the SMTP user name in the Secret of an email notification channel
+	SecretKeyUsername = "username"
+	// SecretKeyPassword the key of the SMTP password in the Secret of an email notification channel
+	SecretKeyPassword = "password"
+	// SecretKeyHeaderPrefix the prefix of the keys of the HTTP headers posted to a webhook in the Secret of a webhook
+	// notification channel so that API keys in headers are not stored in the team settings
+	SecretKeyHeaderPrefix = "header."
+
+	colorSuccess = "2EB886"
+	colorFailure = "A30200"
+	colorInfo    = "439FE0"
+)
+
+// Events the events which can be notified
+var Events = []string{EventPipelineStarted, EventPipelineSucceeded, EventPipelineFailed, EventPromoted, EventPromotionFailed, EventPreview}
+
+// DefaultTemplates the default Go templates of the messages of the events
+var DefaultTemplates = map[string]string{
+	EventPipelineStarted:   `Pipeline {{.Pipeline}} #{{.Build}} started{{if .BuildURL}}: {{.BuildURL}}{{end}}`,
+	EventPipelineSucceeded: `Pipeline {{.Pipeline}} #{{.Build}} succeeded{{if .Version}} releasing version {{.Version}}{{end}}{{if .BuildURL}}: {{.BuildURL}}{{end}}`,
+	EventPipelineFailed:    `Pipeline {{.Pipeline}} #{{.Build}} failed{{if .BuildURL}}: {{.BuildURL}}{{end}}`,
+	EventPromoted:          `Promoted {{.Application}} version {{.Version}} to {{.Environment}}{{if .URL}}: {{.URL}}{{end}}`,
+	EventPromotionFailed:   `Promotion of {{.Application}} version {{.Version}} to {{.Environment}} failed{{if .BuildURL}}: {{.BuildURL}}{{end}}`,
+	EventPreview:           `Preview of {{.Application}} for {{.PullRequestURL}} is available{{if .URL}} at {{.URL}}{{end}}`,
+}
+
+// Event a pipeline, promotion, preview or drift event which is notified
+type Event struct {
+	Kind           string   `json:"kind"`
+	Owner          string   `json:"owner,omitempty"`
+	Repository     string   `json:"repository,omitempty"`
+	Branch         string   `json:"branch,omitempty"`
+	Pipeline       string   `json:"pipeline,omitempty"`
+	Build          string   `json:"build,omitempty"`
+	Application    string   `json:"application,omitempty"`
+	Version        string   `json:"version,omitempty"`
+	Environment    string   `json:"environment,omitempty"`
+	URL            string   `json:"url,omitempty"`
+	BuildURL       string   `json:"buildUrl,omitempty"`
+	PullRequestURL string   `json:"pullRequestUrl,omitempty"`
+	Cluster        string   `json:"cluster,omitempty"`
+	Changes        []string `json:"changes,omitempty"`
+}
+
+// Title returns a short title of the event
+func (e *Event) Title() string {
+	switch e.Kind {
+	case EventPipelineStarted:
+		return "Pipeline started"
+	case EventPipelineSucceeded:
+		return "Pipeline succeeded"
+	case EventPipelineFailed:
+		return "Pipeline failed"
+	case EventPromoted:
+		return "Promoted"
+	case EventPromotionFailed:
+		return "Promotion failed"
+	case EventPreview:
+		return "Preview"
+	case EventTerraformDrift:
+		return "Terraform drift"
+	default:
+		return e.Kind
+	}
+}
+
+// Link returns the most relevant URL of the event
+func (e *Event) Link() string {
+	if e.URL != "" {
+		return e.URL
+	}
+	if e.BuildURL != "" {
+		return e.BuildURL
+	}
+	return e.PullRequestURL
+}
+
+// Color returns the hex color of the event
+func (e *Event) Color() string {
+	switch e.Kind {
+	case EventPipelineSucceeded, EventPromoted:
+		return colorSuccess
+	case EventPipelineFailed, EventPromotionFailed, EventTerraformDrift:
+		return colorFailure
+	default:
+		return colorInfo
+	}
+}
+
+// Sender sends the messages of events to a notification channel
+type Sender interface {
+	Send(event *Event, message string) error
+}
+
+// NewSender creates the sender of the notification channel using the data of its Secret
+func NewSender(channel *v1.NotificationChannel, secret map[string][]byte) (Sender, error) {
+	switch channel.Kind {
+	case v1.NotificationKindTypeSlack:
+		token := string(secret[SecretKeyToken])
+		if token == "" {
+			return nil, fmt.Errorf("no %s found in Secret %s of Slack notification channel %s", SecretKeyToken, channel.SecretName, channel.Name)
+		}
+		if channel.Channel == "" {
+			return nil, fmt.Errorf("no channel specified for Slack notification channel %s", channel.Name)
+		}
+		return &SlackSender{
+			Token:   token,
+			Channel: channel.Channel,
+		}, nil
+	case v1.NotificationKindTypeTeams:
+		url := string(secret[SecretKeyURL])
+		if url == "" {
+			return nil, fmt.Errorf("no %s found in Secret %s of Teams notification channel %s", SecretKeyURL, channel.SecretName, channel.Name)
+		}
+		return &TeamsSender{
+			WebhookURL: url,
+		}, nil
+	case v1.NotificationKindTypeEmail:
+		if channel.SMTPServer == "" {
+			return nil, fmt.Errorf("no SMTP server specified for email notification channel %s", channel.Name)
+		}
+		if len(channel.Recipients) == 0 {
+			return nil, fmt.Errorf("no recipients specified for email notification channel %s", channel.Name)
+		}
+		sender := &EmailSender{
+			Server:     channel.SMTPServer,
+			From:       channel.From,
+			Recipients: channel.Recipients,
+			Username:   string(secret[SecretKeyUsername]),
+			Password:   string(secret[SecretKeyPassword]),
+		}
+		if sender.From == "" {
+			sender.From = sender.Username
+		}
+		if sender.From == "" {
+			return nil, fmt.Errorf("no from address specified for email notification channel %s", channel.Name)
+		}
+		return sender, nil
+	case v1.NotificationKindTypeWebhook:
+		url := string(secret[SecretKeyURL])
+		if url == "" {
+			return nil, fmt.Errorf("no %s found in Secret %s of webhook notification channel %s", SecretKeyURL, channel.SecretName, channel.Name)
+		}
+		headers := map[string]string{}
+		for k, v := range secret {
+			if strings.HasPrefix(k, SecretKeyHeaderPrefix) {
+				headers[strings.TrimPrefix(k, SecretKeyHeaderPrefix)] = string(v)
+			}
+		}
+		return &WebhookSender{
+			URL:     url,
+			Token:   string(secret[SecretKeyToken]),
+			Headers: headers,
+		}, nil
+	case v1.NotificationKindTypePagerDuty:
+		routingKey := string(secret[SecretKeyToken])
+		if routingKey == "" {
+			return nil, fmt.Errorf("no %s found in Secret %s of PagerDuty notification channel %s", SecretKeyToken, channel.SecretName, channel.Name)
+		}
+		return &PagerDutySender{
+			RoutingKey: routingKey,
+			APIURL:     string(secret[SecretKeyURL]),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported kind %s of notification channel %s", channel.Kind, channel.Name)
+	}
+}
+
+// SendWithTimeout sends the message of the event failing if the sender has not finished within the timeout so that
+// a slow SMTP server or webhook cannot hold up the caller
+func SendWithTimeout(sender Sender, event *Event, message string, timeout time.Duration) error {
+	result := make(chan error, 1)
+	go func() {
+		result <- sender.Send(event, message)
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+}
+
+// Matches returns true if the event should be notified to the channel
+func Matches(channel *v1.NotificationChannel, event *Event) bool {
+	if len(channel.Events) > 0 && util.StringArrayIndex(channel.Events, event.Kind) < 0 {
+		return false
+	}
+	if len(channel.Repositories) == 0 {
+		return true
+	}
+	for _, repo := range channel.Repositories {
+		owner, name := "", repo
+		paths := strings.SplitN(repo, "/", 2)
+		if len(paths) == 2 {
+			owner, name = paths[0], paths[1]
+		}
+		if (owner == "" || owner == event.Owner) && name == event.Repository {
+			return true
+		}
+	}
+	return false
+}
+
+// RenderMessage renders the message of the event using the template of the channel or the default template
+func RenderMessage(channel *v1.NotificationChannel, event *Event) (string, error) {
+	text := channel.Templates[event.Kind]
+	if text == "" {
+		text = DefaultTemplates[event.Kind]
+	}
+	if text == "" {
+		return "", fmt.Errorf("no template for event %s", event.Kind)
+	}
+	tmpl, err := template.New(event.Kind).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse the template of event %s of notification channel %s: %s", event.Kind, channel.Name, err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, event)
+	if err != nil {
+		return "", fmt.Errorf("failed to render the template of event %s of notification channel %s: %s", event.Kind, channel.Name, err)
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,295 @@
+package notify_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatches(t *testing.T) {
+	t.Parallel()
+
+	event := &notify.Event{
+		Kind:       notify.EventPipelineFailed,
+		Owner:      "myorg",
+		Repository: "myapp",
+	}
+	assert.True(t, notify.Matches(&v1.NotificationChannel{}, event))
+	assert.True(t, notify.Matches(&v1.NotificationChannel{Events: []string{notify.EventPipelineFailed}}, event))
+	assert.False(t, notify.Matches(&v1.NotificationChannel{Events: []string{notify.EventPromoted}}, event))
+	assert.True(t, notify.Matches(&v1.NotificationChannel{Repositories: []string{"myapp"}}, event))
+	assert.True(t, notify.Matches(&v1.NotificationChannel{Repositories: []string{"other", "myorg/myapp"}}, event))
+	assert.False(t, notify.Matches(&v1.NotificationChannel{Repositories: []string{"otherorg/myapp"}}, event))
+}
+
+func TestRenderMessage(t *testing.T) {
+	t.Parallel()
+
+	event := &notify.Event{
+		Kind:        notify.EventPromoted,
+		Application: "myapp",
+		Version:     "1.0.2",
+		Environment: "production",
+		URL:         "http://myapp.jx-production.example.com",
+	}
+	message, err := notify.RenderMessage(&v1.NotificationChannel{}, event)
+	require.NoError(t, err)
+	assert.Equal(t, "Promoted myapp version 1.0.2 to production: http://myapp.jx-production.example.com", message)
+
+	channel := &v1.NotificationChannel{
+		Name: "releases",
+		Templates: map[string]string{
+			notify.EventPromoted: ":rocket: {{.Application}} {{.Version}} is live in {{.Environment}}",
+		},
+	}
+	message, err = notify.RenderMessage(channel, event)
+	require.NoError(t, err)
+	assert.Equal(t, ":rocket: myapp 1.0.2 is live in production", message)
+
+	channel.Templates[notify.EventPromoted] = "{{.Unknown}}"
+	_, err = notify.RenderMessage(channel, event)
+	assert.Error(t, err)
+}
+
+func TestSendWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	event := &notify.Event{Kind: notify.EventPromotionFailed}
+	err := notify.SendWithTimeout(senderFunc(func(*notify.Event, string) error {
+		return nil
+	}), event, "Promotion failed", time.Second)
+	assert.NoError(t, err)
+
+	blocked := make(chan struct{})
+	defer close(blocked)
+	err = notify.SendWithTimeout(senderFunc(func(*notify.Event, string) error {
+		<-blocked
+		return nil
+	}), event, "Promotion failed", 10*time.Millisecond)
+	assert.EqualError(t, err, "timed out after 10ms")
+}
+
+type senderFunc func(event *notify.Event, message string) error
+
+func (f senderFunc) Send(event *notify.Event, message string) error {
+	return f(event, message)
+}
+
+func TestSlackSender(t *testing.T) {
+	t.Parallel()
+
+	payloads := make(chan map[string]interface{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer xoxb-token" {
+			w.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+			return
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		payload := map[string]interface{}{}
+		json.Unmarshal(data, &payload)
+		payloads <- payload
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	sender, err := notify.NewSender(&v1.NotificationChannel{
+		Name:    "slack",
+		Kind:    v1.NotificationKindTypeSlack,
+		Channel: "#builds",
+	}, map[string][]byte{notify.SecretKeyToken: []byte("xoxb-token")})
+	require.NoError(t, err)
+	slack := sender.(*notify.SlackSender)
+	slack.APIURL = server.URL
+
+	event := &notify.Event{Kind: notify.EventPipelineFailed, BuildURL: "http://jenkins/job/1"}
+	err = slack.Send(event, "Pipeline myorg/myapp/master #1 failed")
+	require.NoError(t, err)
+
+	payload := <-payloads
+	assert.Equal(t, "#builds", payload["channel"])
+	attachments := payload["attachments"].([]interface{})
+	attachment := attachments[0].(map[string]interface{})
+	assert.Equal(t, "Pipeline myorg/myapp/master #1 failed", attachment["text"])
+	assert.Equal(t, "http://jenkins/job/1", attachment["title_link"])
+
+	slack.Token = "wrong"
+	err = slack.Send(event, "Pipeline myorg/myapp/master #1 failed")
+	assert.EqualError(t, err, "failed to post to Slack channel #builds: invalid_auth")
+}
+
+func TestTeamsSender(t *testing.T) {
+	t.Parallel()
+
+	payloads := make(chan map[string]interface{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		payload := map[string]interface{}{}
+		json.Unmarshal(data, &payload)
+		payloads <- payload
+	}))
+	defer server.Close()
+
+	sender, err := notify.NewSender(&v1.NotificationChannel{
+		Name: "teams",
+		Kind: v1.NotificationKindTypeTeams,
+	}, map[string][]byte{notify.SecretKeyURL: []byte(server.URL)})
+	require.NoError(t, err)
+
+	err = sender.Send(&notify.Event{Kind: notify.EventPromoted}, "Promoted myapp version 1.0.2 to production")
+	require.NoError(t, err)
+
+	payload := <-payloads
+	assert.Equal(t, "MessageCard", payload["@type"])
+	assert.Equal(t, "Promoted myapp version 1.0.2 to production", payload["text"])
+	assert.Equal(t, "2EB886", payload["themeColor"])
+
+	_, err = notify.NewSender(&v1.NotificationChannel{Name: "teams", Kind: v1.NotificationKindTypeTeams}, nil)
+	assert.Error(t, err)
+}
+
+func TestSlackWebhookSender(t *testing.T) {
+	t.Parallel()
+
+	payloads := make(chan map[string]interface{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		payload := map[string]interface{}{}
+		json.Unmarshal(data, &payload)
+		payloads <- payload
+	}))
+	defer server.Close()
+
+	sender := &notify.SlackWebhookSender{WebhookURL: server.URL}
+	err := sender.Send(&notify.Event{Kind: notify.EventTerraformDrift, Cluster: "dev"}, "Cluster dev has been changed outside of jx")
+	require.NoError(t, err)
+
+	payload := <-payloads
+	assert.Nil(t, payload["channel"])
+	attachments := payload["attachments"].([]interface{})
+	require.Len(t, attachments, 1)
+	attachment := attachments[0].(map[string]interface{})
+	assert.Equal(t, "Terraform drift", attachment["title"])
+	assert.Equal(t, "Cluster dev has been changed outside of jx", attachment["text"])
+	assert.Equal(t, "#A30200", attachment["color"])
+}
+
+func TestWebhookSender(t *testing.T) {
+	t.Parallel()
+
+	requests := make(chan *http.Request, 1)
+	payloads := make(chan notify.WebhookPayload, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		payload := notify.WebhookPayload{}
+		json.Unmarshal(data, &payload)
+		requests <- r
+		payloads <- payload
+	}))
+	defer server.Close()
+
+	sender, err := notify.NewSender(&v1.NotificationChannel{
+		Name: "opsgenie",
+		Kind: v1.NotificationKindTypeWebhook,
+	}, map[string][]byte{
+		notify.SecretKeyURL:                            []byte(server.URL),
+		notify.SecretKeyToken:                          []byte("secret"),
+		notify.SecretKeyHeaderPrefix + "X-Routing-Key": []byte("abc"),
+	})
+	require.NoError(t, err)
+
+	event := &notify.Event{
+		Kind:       notify.EventPipelineFailed,
+		Owner:      "myorg",
+		Repository: "myapp",
+		Pipeline:   "myorg/myapp/master",
+		Build:      "3",
+		BuildURL:   "http://jenkins/job/3",
+	}
+	err = sender.Send(event, "Pipeline myorg/myapp/master #3 failed")
+	require.NoError(t, err)
+
+	r := <-requests
+	assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+	assert.Equal(t, "abc", r.Header.Get("X-Routing-Key"))
+	payload := <-payloads
+	assert.Equal(t, "Pipeline failed", payload.Title)
+	assert.Equal(t, "Pipeline myorg/myapp/master #3 failed", payload.Message)
+	assert.Equal(t, "http://jenkins/job/3", payload.Link)
+	assert.Equal(t, event, payload.Event)
+}
+
+func TestNewEmailSender(t *testing.T) {
+	t.Parallel()
+
+	channel := &v1.NotificationChannel{
+		Name:       "email",
+		Kind:       v1.NotificationKindTypeEmail,
+		SMTPServer: "smtp.example.com:587",
+		Recipients: []string{"oncall@example.com"},
+	}
+	sender, err := notify.NewSender(channel, map[string][]byte{
+		notify.SecretKeyUsername: []byte("jx@example.com"),
+		notify.SecretKeyPassword: []byte("secret"),
+	})
+	require.NoError(t, err)
+	email := sender.(*notify.EmailSender)
+	assert.Equal(t, "jx@example.com", email.From)
+	assert.Equal(t, []string{"oncall@example.com"}, email.Recipients)
+
+	_, err = notify.NewSender(channel, nil)
+	assert.EqualError(t, err, "no from address specified for email notification channel email")
+
+	channel.Recipients = nil
+	_, err = notify.NewSender(channel, nil)
+	assert.EqualError(t, err, "no recipients specified for email notification channel email")
+}
+
+func TestPagerDutySender(t *testing.T) {
+	t.Parallel()
+
+	events := make(chan notify.PagerDutyEvent, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		event := notify.PagerDutyEvent{}
+		json.Unmarshal(data, &event)
+		events <- event
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	sender, err := notify.NewSender(&v1.NotificationChannel{
+		Name: "pagerduty",
+		Kind: v1.NotificationKindTypePagerDuty,
+	}, map[string][]byte{notify.SecretKeyURL: []byte(server.URL), notify.SecretKeyToken: []byte("routing-key")})
+	require.NoError(t, err)
+
+	event := &notify.Event{
+		Kind:        notify.EventPipelineFailed,
+		Application: "myapp",
+		Pipeline:    "myorg/myapp/master",
+		Build:       "3",
+		BuildURL:    "http://jenkins/job/3",
+	}
+	err = sender.Send(event, "Pipeline myorg/myapp/master #3 failed")
+	require.NoError(t, err)
+
+	pdEvent := <-events
+	assert.Equal(t, "routing-key", pdEvent.RoutingKey)
+	assert.Equal(t, "trigger", pdEvent.EventAction)
+	assert.Equal(t, "Pipeline myorg/myapp/master #3 failed", pdEvent.Payload.Summary)
+	assert.Equal(t, "myorg/myapp/master", pdEvent.Payload.Source)
+	assert.Equal(t, "error", pdEvent.Payload.Severity)
+	assert.Equal(t, "myapp", pdEvent.Payload.Component)
+	assert.Equal(t, []notify.PagerDutyLink{{Href: "http://jenkins/job/3", Text: "Pipeline failed"}}, pdEvent.Links)
+
+	_, err = notify.NewSender(&v1.NotificationChannel{Name: "pagerduty", Kind: v1.NotificationKindTypePagerDuty}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,90 @@
+package notify
+
+import "unicode/utf8"
+
+// PagerDutyEventsURL the URL of the PagerDuty Events API v2
+const PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDutySender triggers PagerDuty incidents using the routing key of an Events API v2 integration
+type PagerDutySender struct {
+	RoutingKey string
+	APIURL     string
+}
+
+// PagerDutyEvent the body of an Events API v2 request
+type PagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	Payload     *PagerDutyPayload `json:"payload"`
+	Links       []PagerDutyLink   `json:"links,omitempty"`
+}
+
+// PagerDutyPayload the details of the incident of a PagerDuty event
+type PagerDutyPayload struct {
+	Summary       string `json:"summary"`
+	Source        string `json:"source"`
+	Severity      string `json:"severity"`
+	Component     string `json:"component,omitempty"`
+	Class         string `json:"class,omitempty"`
+	CustomDetails *Event `json:"custom_details,omitempty"`
+}
+
+// PagerDutyLink a link attached to the incident of a PagerDuty event
+type PagerDutyLink struct {
+	Href string `json:"href"`
+	Text string `json:"text,omitempty"`
+}
+
+// Send triggers an incident with the message of the event as its summary
+func (s *PagerDutySender) Send(event *Event, message string) error {
+	apiURL := s.APIURL
+	if apiURL == "" {
+		apiURL = PagerDutyEventsURL
+	}
+	body := &PagerDutyEvent{
+		RoutingKey:  s.RoutingKey,
+		EventAction: "trigger",
+		Payload: &PagerDutyPayload{
+			Summary:       truncate(message, 1024),
+			Source:        pagerDutySource(event),
+			Severity:      pagerDutySeverity(event),
+			Component:     event.Application,
+			Class:         event.Kind,
+			CustomDetails: event,
+		},
+	}
+	if link := event.Link(); link != "" {
+		body.Links = []PagerDutyLink{{Href: link, Text: event.Title()}}
+	}
+	return postJSON(apiURL, nil, body, nil)
+}
+
+// pagerDutySource returns the most specific thing the event happened in
+func pagerDutySource(event *Event) string {
+	for _, source := range []string{event.Pipeline, event.Cluster, event.Environment, event.Repository} {
+		if source != "" {
+			return source
+		}
+	}
+	return "jenkins-x"
+}
+
+func pagerDutySeverity(event *Event) string {
+	switch event.Color() {
+	case colorFailure:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// truncate returns the text truncated to the maximum number of bytes without splitting a character
+func truncate(text string, max int) string {
+	if len(text) <= max {
+		return text
+	}
+	for max > 0 && !utf8.RuneStart(text[max]) {
+		max--
+	}
+	return text[:max]
+}
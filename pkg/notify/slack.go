@@ -0,0 +1,67 @@
+package notify
+
+import (
+	"fmt"
+)
+
+// SlackPostMessageURL the URL of the Slack API to post messages
+const SlackPostMessageURL = "https://slack.com/api/chat.postMessage"
+
+// SlackSender posts messages to a Slack channel using a bot token
+type SlackSender struct {
+	Token   string
+	Channel string
+	APIURL  string
+}
+
+// SlackWebhookSender posts messages to the Slack channel of an incoming webhook
+type SlackWebhookSender struct {
+	WebhookURL string
+}
+
+type slackResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// Send posts the message of the event to the Slack channel
+func (s *SlackSender) Send(event *Event, message string) error {
+	apiURL := s.APIURL
+	if apiURL == "" {
+		apiURL = SlackPostMessageURL
+	}
+	payload := map[string]interface{}{
+		"channel":     s.Channel,
+		"attachments": []interface{}{slackAttachment(event, message)},
+	}
+	response := &slackResponse{}
+	err := postJSON(apiURL, map[string]string{"Authorization": "Bearer " + s.Token}, payload, response)
+	if err != nil {
+		return err
+	}
+	if !response.OK {
+		return fmt.Errorf("failed to post to Slack channel %s: %s", s.Channel, response.Error)
+	}
+	return nil
+}
+
+// Send posts the message of the event to the Slack incoming webhook
+func (s *SlackWebhookSender) Send(event *Event, message string) error {
+	payload := map[string]interface{}{
+		"attachments": []interface{}{slackAttachment(event, message)},
+	}
+	return postJSON(s.WebhookURL, nil, payload, nil)
+}
+
+func slackAttachment(event *Event, message string) map[string]interface{} {
+	attachment := map[string]interface{}{
+		"fallback": message,
+		"color":    "#" + event.Color(),
+		"title":    event.Title(),
+		"text":     message,
+	}
+	if link := event.Link(); link != "" {
+		attachment["title_link"] = link
+	}
+	return attachment
+}
@@ -0,0 +1,30 @@
+package notify
+
+// TeamsSender posts messages to a Microsoft Teams channel using an incoming webhook
+type TeamsSender struct {
+	WebhookURL string
+}
+
+// Send posts the message of the event as a card to the Teams channel
+func (s *TeamsSender) Send(event *Event, message string) error {
+	card := map[string]interface{}{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"summary":    event.Title(),
+		"title":      event.Title(),
+		"themeColor": event.Color(),
+		"text":       message,
+	}
+	if link := event.Link(); link != "" {
+		card["potentialAction"] = []interface{}{
+			map[string]interface{}{
+				"@type": "OpenUri",
+				"name":  "Open",
+				"targets": []interface{}{
+					map[string]string{"os": "default", "uri": link},
+				},
+			},
+		}
+	}
+	return postJSON(s.WebhookURL, nil, card, nil)
+}
@@ -0,0 +1,37 @@
+package notify
+
+// WebhookSender posts the events as JSON to a URL so that they can be consumed by tools such as Opsgenie or
+// internal systems
+type WebhookSender struct {
+	URL     string
+	Token   string
+	Headers map[string]string
+}
+
+// WebhookPayload the JSON body posted to a webhook
+type WebhookPayload struct {
+	Title   string `json:"title"`
+	Message string `json:"message"`
+	Color   string `json:"color"`
+	Link    string `json:"link,omitempty"`
+	Event   *Event `json:"event"`
+}
+
+// Send posts the event and its message to the webhook
+func (s *WebhookSender) Send(event *Event, message string) error {
+	headers := map[string]string{}
+	for k, v := range s.Headers {
+		headers[k] = v
+	}
+	if s.Token != "" {
+		headers["Authorization"] = "Bearer " + s.Token
+	}
+	payload := &WebhookPayload{
+		Title:   event.Title(),
+		Message: message,
+		Color:   event.Color(),
+		Link:    event.Link(),
+		Event:   event,
+	}
+	return postJSON(s.URL, headers, payload, nil)
+}
@@ -0,0 +1,50 @@
+package policy
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+var (
+	constraintTemplateResource = schema.GroupVersionResource{Group: "templates.gatekeeper.sh", Version: "v1beta1", Resource: "constrainttemplates"}
+)
+
+// ClusterRules returns the rules of the Gatekeeper constraints of the cluster which includes the custom constraints
+// as well as the ones of the starter policies. No rules are returned if Gatekeeper is not installed
+func ClusterRules(dynamicClient dynamic.Interface) ([]*Rule, error) {
+	list, err := dynamicClient.Resource(constraintTemplateResource).List(metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return []*Rule{}, nil
+		}
+		return nil, errors.Wrap(err, "failed to list the Gatekeeper constraint templates")
+	}
+	constraintTemplates := []map[string]interface{}{}
+	constraints := []map[string]interface{}{}
+	for _, t := range list.Items {
+		constraintTemplates = append(constraintTemplates, t.Object)
+		kind, _, _ := unstructured.NestedString(t.Object, "spec", "crd", "spec", "names", "kind")
+		if kind == "" {
+			continue
+		}
+		// Gatekeeper names the resources of the constraints after the lower case kind of their template
+		constraintResource := schema.GroupVersionResource{Group: "constraints.gatekeeper.sh", Version: "v1beta1", Resource: strings.ToLower(kind)}
+		cl, err := dynamicClient.Resource(constraintResource).List(metav1.ListOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to list the Gatekeeper constraints of kind %s", kind)
+		}
+		for _, c := range cl.Items {
+			constraints = append(constraints, c.Object)
+		}
+	}
+	return Rules(constraintTemplates, constraints)
+}
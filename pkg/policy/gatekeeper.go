@@ -0,0 +1,93 @@
+package policy
+
+import (
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+// ConstraintTemplate returns the Gatekeeper constraint template of the given policy
+func ConstraintTemplate(p *Policy) map[string]interface{} {
+	name := strings.ToLower(p.Kind)
+	return map[string]interface{}{
+		"apiVersion": ConstraintTemplateAPIVersion,
+		"kind":       "ConstraintTemplate",
+		"metadata": map[string]interface{}{
+			"name": name,
+			"annotations": map[string]interface{}{
+				"description": p.Description,
+			},
+		},
+		"spec": map[string]interface{}{
+			"crd": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"names": map[string]interface{}{
+						"kind": p.Kind,
+					},
+				},
+			},
+			"targets": []interface{}{
+				map[string]interface{}{
+					"target": GatekeeperTarget,
+					"rego":   "package " + name + "\n" + p.Rego,
+				},
+			},
+		},
+	}
+}
+
+// Constraint returns the Gatekeeper constraint which enforces the given policy on the pods of the namespaces
+// with the given label other than those with one of the excluded values such as the development namespace
+func Constraint(p *Policy, namespaceLabel string, excludedValues []string) map[string]interface{} {
+	matchExpressions := []interface{}{
+		map[string]interface{}{
+			"key":      namespaceLabel,
+			"operator": "Exists",
+		},
+	}
+	if len(excludedValues) > 0 {
+		values := []interface{}{}
+		for _, v := range excludedValues {
+			values = append(values, v)
+		}
+		matchExpressions = append(matchExpressions, map[string]interface{}{
+			"key":      namespaceLabel,
+			"operator": "NotIn",
+			"values":   values,
+		})
+	}
+	return map[string]interface{}{
+		"apiVersion": ConstraintAPIVersion,
+		"kind":       p.Kind,
+		"metadata": map[string]interface{}{
+			"name": p.Name,
+		},
+		"spec": map[string]interface{}{
+			"match": map[string]interface{}{
+				"kinds": []interface{}{
+					map[string]interface{}{
+						"apiGroups": []interface{}{""},
+						"kinds":     []interface{}{"Pod"},
+					},
+				},
+				"namespaceSelector": map[string]interface{}{
+					"matchExpressions": matchExpressions,
+				},
+			},
+		},
+	}
+}
+
+// RenderYAML renders the given resources as a multi document YAML file
+func RenderYAML(resources []map[string]interface{}) ([]byte, error) {
+	docs := []string{}
+	for _, r := range resources {
+		data, err := yaml.Marshal(r)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal the policy resource")
+		}
+		docs = append(docs, string(data))
+	}
+	return []byte(strings.Join(docs, "---\n")), nil
+}
@@ -0,0 +1,92 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// OpaBinary the binary of the Open Policy Agent which evaluates the Rego of the constraint templates like Gatekeeper
+const OpaBinary = "opa"
+
+type opaOutput struct {
+	Result []struct {
+		Expressions []struct {
+			Value []struct {
+				Index int         `json:"index"`
+				Msg   interface{} `json:"msg"`
+			} `json:"value"`
+		} `json:"expressions"`
+	} `json:"result"`
+}
+
+// evaluate evaluates the Rego of the given rule with opa on each of the given inputs and returns the messages of the
+// violations by the key of the input
+func evaluate(rule *Rule, inputs map[int]map[string]interface{}) (map[int][]string, error) {
+	dir, err := ioutil.TempDir("", "jx-opa-")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create a temporary directory")
+	}
+	defer os.RemoveAll(dir)
+
+	regoDir := filepath.Join(dir, "rego")
+	err = os.MkdirAll(regoDir, util.DefaultWritePermissions)
+	if err != nil {
+		return nil, err
+	}
+	modules := append([]string{rule.Rego}, rule.Libs...)
+	for i, module := range modules {
+		fileName := filepath.Join(regoDir, fmt.Sprintf("module-%d.rego", i))
+		err = ioutil.WriteFile(fileName, []byte(module), util.DefaultWritePermissions)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to write %s", fileName)
+		}
+	}
+
+	reviews := []interface{}{}
+	for key, input := range inputs {
+		reviews = append(reviews, map[string]interface{}{
+			"index": key,
+			"input": input,
+		})
+	}
+	data, err := json.Marshal(map[string]interface{}{"reviews": reviews})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the input")
+	}
+	inputFile := filepath.Join(dir, "input.json")
+	err = ioutil.WriteFile(inputFile, data, util.DefaultWritePermissions)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to write %s", inputFile)
+	}
+
+	// each review is evaluated as the input of the template like Gatekeeper does at admission
+	query := fmt.Sprintf(`[{"index": r.index, "msg": v.msg} | r := input.reviews[_]; data.%s.violation[v] with input as r.input]`, rule.Package)
+	cmd := util.Command{
+		Name: OpaBinary,
+		Args: []string{"eval", "--format", "json", "--data", regoDir, "--input", inputFile, query},
+	}
+	text, err := cmd.RunWithoutRetry()
+	if err != nil {
+		return nil, err
+	}
+	output := opaOutput{}
+	err = json.Unmarshal([]byte(text), &output)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the output of opa: %s", text)
+	}
+	answer := map[int][]string{}
+	for _, result := range output.Result {
+		for _, expression := range result.Expressions {
+			for _, v := range expression.Value {
+				answer[v.Index] = append(answer[v.Index], fmt.Sprintf("%v", v.Msg))
+			}
+		}
+	}
+	return answer, nil
+}
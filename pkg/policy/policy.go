@@ -0,0 +1,141 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// GatekeeperTarget the admission target of the Gatekeeper constraint templates
+	GatekeeperTarget = "admission.k8s.gatekeeper.sh"
+	// ConstraintTemplateAPIVersion the API version of the Gatekeeper constraint templates
+	ConstraintTemplateAPIVersion = "templates.gatekeeper.sh/v1beta1"
+	// ConstraintAPIVersion the API version of the Gatekeeper constraints
+	ConstraintAPIVersion = "constraints.gatekeeper.sh/v1beta1"
+)
+
+// Policy a starter policy which is enforced at admission time by Gatekeeper via its Rego and evaluated with the same
+// Rego by the pipelines on the rendered charts so that the violations fail in CI first
+type Policy struct {
+	// Name the name of the policy and of its constraint
+	Name string
+	// Kind the kind of the constraint created by the template of the policy
+	Kind string
+	// Description a description of the policy
+	Description string
+	// Rego the rules of the policy evaluated by Gatekeeper and by the pipelines
+	Rego string
+}
+
+// regoContainers the rules which return the containers and init containers of the pod under review
+const regoContainers = `
+input_containers[c] {
+  c := input.review.object.spec.containers[_]
+}
+input_containers[c] {
+  c := input.review.object.spec.initContainers[_]
+}
+`
+
+var (
+	// NoPrivilegedContainers denies privileged containers
+	NoPrivilegedContainers = &Policy{
+		Name:        "no-privileged-containers",
+		Kind:        "JxNoPrivilegedContainers",
+		Description: "Containers must not run privileged",
+		Rego: `
+violation[{"msg": msg}] {
+  c := input_containers[_]
+  c.securityContext.privileged
+  msg := sprintf("container %v must not run privileged", [c.name])
+}
+` + regoContainers,
+	}
+
+	// NoHostNamespaces denies pods sharing the network, PID or IPC namespaces of the node
+	NoHostNamespaces = &Policy{
+		Name:        "no-host-namespaces",
+		Kind:        "JxNoHostNamespaces",
+		Description: "Pods must not share the network, PID or IPC namespaces of the node",
+		Rego: `
+violation[{"msg": msg}] {
+  spec := input.review.object.spec
+  host := {"hostNetwork", "hostPID", "hostIPC"}
+  spec[field]
+  host[field]
+  msg := sprintf("the pod must not use %v", [field])
+}
+`,
+	}
+
+	// RequireResourceLimits requires containers to have CPU and memory limits
+	RequireResourceLimits = &Policy{
+		Name:        "require-resource-limits",
+		Kind:        "JxRequireResourceLimits",
+		Description: "Containers must have CPU and memory limits",
+		Rego: `
+violation[{"msg": msg}] {
+  c := input_containers[_]
+  resource := {"cpu", "memory"}[_]
+  not c.resources.limits[resource]
+  msg := sprintf("container %v must have a %v limit", [c.name, resource])
+}
+` + regoContainers,
+	}
+
+	// NoLatestImageTag denies images without a tag or with the latest tag
+	NoLatestImageTag = &Policy{
+		Name:        "no-latest-image-tag",
+		Kind:        "JxNoLatestImageTag",
+		Description: "Images must be pinned to a version rather than the latest tag",
+		Rego: `
+violation[{"msg": msg}] {
+  c := input_containers[_]
+  latest_image(c.image)
+  msg := sprintf("container %v must not use the latest tag of image %v", [c.name, c.image])
+}
+latest_image(image) {
+  endswith(image, ":latest")
+}
+latest_image(image) {
+  not contains(image, "@")
+  parts := split(image, "/")
+  not contains(parts[count(parts) - 1], ":")
+}
+` + regoContainers,
+	}
+
+	// StarterPolicies the starter bundle of policies installed by the Gatekeeper addon
+	StarterPolicies = []*Policy{NoPrivilegedContainers, NoHostNamespaces, RequireResourceLimits, NoLatestImageTag}
+)
+
+// PolicyNames returns the names of the given policies
+func PolicyNames(policies []*Policy) []string {
+	answer := []string{}
+	for _, p := range policies {
+		answer = append(answer, p.Name)
+	}
+	return answer
+}
+
+// FindPolicies returns the starter policies of the given names or all of them if no names are given
+func FindPolicies(names []string) ([]*Policy, error) {
+	if len(names) == 0 {
+		return StarterPolicies, nil
+	}
+	answer := []*Policy{}
+	for _, name := range names {
+		found := false
+		for _, p := range StarterPolicies {
+			if p.Name == name {
+				answer = append(answer, p)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown policy %s, the available policies are %s", name, strings.Join(PolicyNames(StarterPolicies), ", "))
+		}
+	}
+	return answer, nil
+}
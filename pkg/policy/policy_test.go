@@ -0,0 +1,221 @@
+package policy_test
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifests = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: myapp
+spec:
+  ports:
+  - port: 80
+---
+apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: myapp
+spec:
+  template:
+    spec:
+      hostNetwork: true
+      containers:
+      - name: myapp
+        image: myorg/myapp
+        securityContext:
+          privileged: true
+      - name: sidecar
+        image: registry.example.com:5000/sidecar:1.0.0
+        resources:
+          limits:
+            cpu: 100m
+            memory: 128Mi
+---
+apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: cleanup
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+          - name: cleanup
+            image: myorg/cleanup:latest
+            resources:
+              limits:
+                cpu: 100m
+                memory: 128Mi
+`
+
+// requireOpa skips the test if the opa binary which evaluates the Rego is not installed
+func requireOpa(t *testing.T) {
+	_, err := exec.LookPath(policy.OpaBinary)
+	if err != nil {
+		t.Skipf("%s is not installed", policy.OpaBinary)
+	}
+}
+
+func TestValidateData(t *testing.T) {
+	t.Parallel()
+	requireOpa(t)
+	rules, err := policy.StarterRules(policy.StarterPolicies)
+	require.NoError(t, err)
+	violations, err := policy.ValidateData([]byte(testManifests), "templates/deployment.yaml", rules)
+	require.NoError(t, err)
+
+	messages := []string{}
+	for _, v := range violations {
+		messages = append(messages, v.Kind+"/"+v.Name+": "+v.Message)
+	}
+	assert.Equal(t, []string{
+		"Deployment/myapp: container myapp must not run privileged",
+		"Deployment/myapp: the pod must not use hostNetwork",
+		"Deployment/myapp: container myapp must have a cpu limit",
+		"Deployment/myapp: container myapp must have a memory limit",
+		"Deployment/myapp: container myapp must not use the latest tag of image myorg/myapp",
+		"CronJob/cleanup: container cleanup must not use the latest tag of image myorg/cleanup:latest",
+	}, messages)
+}
+
+func TestNoLatestImageTag(t *testing.T) {
+	t.Parallel()
+	requireOpa(t)
+	rules, err := policy.StarterRules([]*policy.Policy{policy.NoLatestImageTag})
+	require.NoError(t, err)
+	violations, err := policy.ValidateData([]byte(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: images
+spec:
+  containers:
+  - name: untagged
+    image: myorg/myapp
+  - name: latest
+    image: myorg/myapp:latest
+  - name: port
+    image: registry.example.com:5000/myapp
+  - name: tagged
+    image: registry.example.com:5000/myapp:1.0.0
+  - name: digest
+    image: myorg/myapp@sha256:abc
+`), "pod.yaml", rules)
+	require.NoError(t, err)
+
+	messages := []string{}
+	for _, v := range violations {
+		messages = append(messages, v.Message)
+	}
+	assert.Equal(t, []string{
+		"container latest must not use the latest tag of image myorg/myapp:latest",
+		"container port must not use the latest tag of image registry.example.com:5000/myapp",
+		"container untagged must not use the latest tag of image myorg/myapp",
+	}, messages)
+}
+
+func TestRules(t *testing.T) {
+	t.Parallel()
+	resources, err := policy.ParseResources([]byte(`
+apiVersion: templates.gatekeeper.sh/v1beta1
+kind: ConstraintTemplate
+metadata:
+  name: k8srequiredlabels
+spec:
+  crd:
+    spec:
+      names:
+        kind: K8sRequiredLabels
+  targets:
+  - target: admission.k8s.gatekeeper.sh
+    rego: |
+      package k8srequiredlabels
+
+      violation[{"msg": msg}] {
+        label := input.parameters.labels[_]
+        not input.review.object.metadata.labels[label]
+        msg := sprintf("missing label %v", [label])
+      }
+---
+apiVersion: constraints.gatekeeper.sh/v1beta1
+kind: K8sRequiredLabels
+metadata:
+  name: must-have-team
+spec:
+  match:
+    kinds:
+    - apiGroups: ["apps"]
+      kinds: ["Deployment"]
+  parameters:
+    labels: ["team"]
+`), "gatekeeper.yaml")
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+
+	rules, err := policy.Rules([]map[string]interface{}{resources[0].Object}, []map[string]interface{}{resources[1].Object})
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	rule := rules[0]
+	assert.Equal(t, "must-have-team", rule.Name)
+	assert.Equal(t, "K8sRequiredLabels", rule.Kind)
+	assert.Equal(t, "k8srequiredlabels", rule.Package)
+	assert.Equal(t, []string{"Deployment"}, rule.Kinds)
+	assert.Equal(t, map[string]interface{}{"labels": []interface{}{"team"}}, rule.Parameters)
+	assert.True(t, rule.Matches("Deployment"))
+	assert.False(t, rule.Matches("Pod"))
+
+	_, err = policy.Rules(nil, []map[string]interface{}{resources[1].Object})
+	assert.Error(t, err, "a constraint without its template should fail")
+
+	starterRules, err := policy.StarterRules(policy.StarterPolicies)
+	require.NoError(t, err)
+	assert.Equal(t, policy.PolicyNames(policy.StarterPolicies), policy.RuleNames(starterRules))
+	assert.Equal(t, "jxnoprivilegedcontainers", starterRules[0].Package)
+	assert.Equal(t, []string{"Pod"}, starterRules[0].Kinds)
+
+	found, err := policy.FindRules(starterRules, []string{"no-latest-image-tag"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"no-latest-image-tag"}, policy.RuleNames(found))
+	_, err = policy.FindRules(starterRules, []string{"cheese"})
+	assert.Error(t, err)
+}
+
+func TestFindPolicies(t *testing.T) {
+	t.Parallel()
+	policies, err := policy.FindPolicies(nil)
+	require.NoError(t, err)
+	assert.Equal(t, policy.StarterPolicies, policies)
+
+	policies, err = policy.FindPolicies([]string{"no-latest-image-tag"})
+	require.NoError(t, err)
+	assert.Equal(t, []*policy.Policy{policy.NoLatestImageTag}, policies)
+
+	_, err = policy.FindPolicies([]string{"cheese"})
+	assert.Error(t, err)
+}
+
+func TestConstraintTemplate(t *testing.T) {
+	t.Parallel()
+	template := policy.ConstraintTemplate(policy.NoPrivilegedContainers)
+	data, err := policy.RenderYAML([]map[string]interface{}{
+		template,
+		policy.Constraint(policy.NoPrivilegedContainers, "env", []string{"dev"}),
+	})
+	require.NoError(t, err)
+
+	text := string(data)
+	assert.Equal(t, 1, strings.Count(text, "---\n"))
+	assert.Contains(t, text, "name: jxnoprivilegedcontainers")
+	assert.Contains(t, text, "package jxnoprivilegedcontainers")
+	assert.Contains(t, text, "kind: JxNoPrivilegedContainers")
+	assert.Contains(t, text, "operator: NotIn")
+}
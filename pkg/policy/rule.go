@@ -0,0 +1,156 @@
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Rule a Gatekeeper constraint together with the Rego of its constraint template which is evaluated on the resources
+// of the rendered charts
+type Rule struct {
+	// Name the name of the constraint
+	Name string
+	// Kind the kind of the constraint
+	Kind string
+	// Package the package of the Rego of the constraint template
+	Package string
+	// Rego the Rego of the constraint template
+	Rego string
+	// Libs the Rego libraries of the constraint template
+	Libs []string
+	// Parameters the parameters of the constraint passed to the Rego as input.parameters
+	Parameters interface{}
+	// Kinds the kinds of the resources matched by the constraint or empty if it matches all the resources
+	Kinds []string
+}
+
+var regoPackage = regexp.MustCompile(`(?m)^\s*package\s+([\w.]+)`)
+
+// Matches returns true if the constraint of the rule applies to the resources of the given kind
+func (r *Rule) Matches(kind string) bool {
+	if len(r.Kinds) == 0 {
+		return true
+	}
+	for _, k := range r.Kinds {
+		if k == kind || k == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// StarterRules returns the rules of the constraints created for the given starter policies by the Gatekeeper addon
+func StarterRules(policies []*Policy) ([]*Rule, error) {
+	constraintTemplates := []map[string]interface{}{}
+	constraints := []map[string]interface{}{}
+	for _, p := range policies {
+		constraintTemplates = append(constraintTemplates, ConstraintTemplate(p))
+		constraints = append(constraints, Constraint(p, "", nil))
+	}
+	return Rules(constraintTemplates, constraints)
+}
+
+// Rules returns the rules of the given Gatekeeper constraints with the Rego of their constraint templates
+func Rules(constraintTemplates []map[string]interface{}, constraints []map[string]interface{}) ([]*Rule, error) {
+	templates := map[string]map[string]interface{}{}
+	for _, t := range constraintTemplates {
+		kind, _, err := unstructured.NestedString(t, "spec", "crd", "spec", "names", "kind")
+		if err != nil || kind == "" {
+			return nil, fmt.Errorf("constraint template %s has no kind", resourceName(t))
+		}
+		templates[kind] = t
+	}
+
+	answer := []*Rule{}
+	for _, c := range constraints {
+		kind, _, _ := unstructured.NestedString(c, "kind")
+		name := resourceName(c)
+		template := templates[kind]
+		if template == nil {
+			return nil, fmt.Errorf("no constraint template found for the constraint %s of kind %s", name, kind)
+		}
+		rule := &Rule{
+			Name: name,
+			Kind: kind,
+		}
+		err := rule.setRego(template)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read the Rego of the constraint template of the constraint %s", name)
+		}
+		rule.Parameters, _, _ = unstructured.NestedFieldCopy(c, "spec", "parameters")
+		matchKinds, _, _ := unstructured.NestedSlice(c, "spec", "match", "kinds")
+		for _, mk := range matchKinds {
+			m, ok := mk.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			kinds, _, _ := unstructured.NestedStringSlice(m, "kinds")
+			rule.Kinds = append(rule.Kinds, kinds...)
+		}
+		answer = append(answer, rule)
+	}
+	return answer, nil
+}
+
+// setRego sets the Rego of the rule from the admission target of the given constraint template
+func (r *Rule) setRego(template map[string]interface{}) error {
+	targets, _, err := unstructured.NestedSlice(template, "spec", "targets")
+	if err != nil {
+		return err
+	}
+	for _, t := range targets {
+		target, ok := t.(map[string]interface{})
+		if !ok || target["target"] != GatekeeperTarget {
+			continue
+		}
+		r.Rego, _, _ = unstructured.NestedString(target, "rego")
+		r.Libs, _, _ = unstructured.NestedStringSlice(target, "libs")
+		match := regoPackage.FindStringSubmatch(r.Rego)
+		if match == nil {
+			return errors.New("the Rego has no package")
+		}
+		r.Package = match[1]
+		return nil
+	}
+	return fmt.Errorf("no target %s found", GatekeeperTarget)
+}
+
+// RuleNames returns the names of the given rules
+func RuleNames(rules []*Rule) []string {
+	answer := []string{}
+	for _, r := range rules {
+		answer = append(answer, r.Name)
+	}
+	return answer
+}
+
+// FindRules returns the rules of the given names or all of them if no names are given
+func FindRules(rules []*Rule, names []string) ([]*Rule, error) {
+	if len(names) == 0 {
+		return rules, nil
+	}
+	answer := []*Rule{}
+	for _, name := range names {
+		found := false
+		for _, r := range rules {
+			if r.Name == name {
+				answer = append(answer, r)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown policy %s, the available policies are %s", name, strings.Join(RuleNames(rules), ", "))
+		}
+	}
+	return answer, nil
+}
+
+func resourceName(r map[string]interface{}) string {
+	name, _, _ := unstructured.NestedString(r, "metadata", "name")
+	return name
+}
@@ -0,0 +1,207 @@
+package policy
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Violation a violation of a policy by a resource of a rendered chart
+type Violation struct {
+	Policy  string
+	File    string
+	Kind    string
+	Name    string
+	Message string
+}
+
+// String returns a description of the violation
+func (v *Violation) String() string {
+	return fmt.Sprintf("%s %s in %s violates %s: %s", v.Kind, v.Name, v.File, v.Policy, v.Message)
+}
+
+// Resource a resource of a rendered chart
+type Resource struct {
+	File   string
+	Kind   string
+	Name   string
+	Object map[string]interface{}
+}
+
+// podTemplatePaths the paths of the pod templates of the resources which create pods
+var podTemplatePaths = map[string][]string{
+	"Deployment":            {"spec", "template"},
+	"StatefulSet":           {"spec", "template"},
+	"DaemonSet":             {"spec", "template"},
+	"ReplicaSet":            {"spec", "template"},
+	"ReplicationController": {"spec", "template"},
+	"Job":                   {"spec", "template"},
+	"CronJob":               {"spec", "jobTemplate", "spec", "template"},
+}
+
+var documentSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// reviewObject returns the object reviewed by the given rule for the resource. As Gatekeeper reviews the pods
+// created by the workloads at admission, the rules which match pods review the pod template of the workloads
+func (r *Resource) reviewObject(rule *Rule) map[string]interface{} {
+	if rule.Matches(r.Kind) {
+		return r.Object
+	}
+	path := podTemplatePaths[r.Kind]
+	if path == nil || !rule.Matches("Pod") {
+		return nil
+	}
+	template, found, err := unstructured.NestedMap(r.Object, path...)
+	if err != nil || !found {
+		return nil
+	}
+	pod := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   template["metadata"],
+		"spec":       template["spec"],
+	}
+	if pod["metadata"] == nil {
+		pod["metadata"] = map[string]interface{}{}
+	}
+	return pod
+}
+
+// ParseResources returns the resources of the given YAML file which may contain many documents
+func ParseResources(data []byte, fileName string) ([]*Resource, error) {
+	answer := []*Resource{}
+	for _, doc := range documentSeparator.Split(string(data), -1) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		object := map[string]interface{}{}
+		err := yaml.Unmarshal([]byte(doc), &object)
+		if err != nil {
+			return answer, errors.Wrapf(err, "failed to parse the YAML of %s", fileName)
+		}
+		if len(object) == 0 {
+			continue
+		}
+		kind, _, _ := unstructured.NestedString(object, "kind")
+		name, _, _ := unstructured.NestedString(object, "metadata", "name")
+		answer = append(answer, &Resource{
+			File:   fileName,
+			Kind:   kind,
+			Name:   name,
+			Object: object,
+		})
+	}
+	return answer, nil
+}
+
+// LoadResources returns the resources of all the YAML files in the given directory such as the output of
+// helm template
+func LoadResources(dir string) ([]*Resource, error) {
+	answer := []*Resource{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		ext := filepath.Ext(path)
+		if info.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			return nil
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", path)
+		}
+		fileName, err := filepath.Rel(dir, path)
+		if err != nil {
+			fileName = path
+		}
+		resources, err := ParseResources(data, fileName)
+		if err != nil {
+			return err
+		}
+		answer = append(answer, resources...)
+		return nil
+	})
+	return answer, err
+}
+
+// Validate evaluates the Rego of the given rules on the given resources with the same engine as Gatekeeper and
+// returns the violations ordered by resource
+func Validate(resources []*Resource, rules []*Rule) ([]Violation, error) {
+	messages := make([]map[int][]string, len(resources))
+	for i := range messages {
+		messages[i] = map[int][]string{}
+	}
+	for ruleIndex, rule := range rules {
+		inputs := map[int]map[string]interface{}{}
+		for i, r := range resources {
+			object := r.reviewObject(rule)
+			if object == nil {
+				continue
+			}
+			inputs[i] = map[string]interface{}{
+				"review": map[string]interface{}{
+					"kind": map[string]interface{}{
+						"kind": object["kind"],
+					},
+					"name":   r.Name,
+					"object": object,
+				},
+				"parameters": rule.Parameters,
+			}
+		}
+		if len(inputs) == 0 {
+			continue
+		}
+		results, err := evaluate(rule, inputs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to evaluate the policy %s", rule.Name)
+		}
+		for i, m := range results {
+			sort.Strings(m)
+			messages[i][ruleIndex] = m
+		}
+	}
+
+	answer := []Violation{}
+	for i, r := range resources {
+		for ruleIndex, rule := range rules {
+			for _, message := range messages[i][ruleIndex] {
+				answer = append(answer, Violation{
+					Policy:  rule.Name,
+					File:    r.File,
+					Kind:    r.Kind,
+					Name:    r.Name,
+					Message: message,
+				})
+			}
+		}
+	}
+	return answer, nil
+}
+
+// ValidateData evaluates the given rules on the resources of the given YAML file which may contain many documents
+func ValidateData(data []byte, fileName string, rules []*Rule) ([]Violation, error) {
+	resources, err := ParseResources(data, fileName)
+	if err != nil {
+		return nil, err
+	}
+	return Validate(resources, rules)
+}
+
+// ValidateDir evaluates the given rules on all the YAML files in the given directory such as the output
+// of helm template
+func ValidateDir(dir string, rules []*Rule) ([]Violation, error) {
+	resources, err := LoadResources(dir)
+	if err != nil {
+		return nil, err
+	}
+	return Validate(resources, rules)
+}
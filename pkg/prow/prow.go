@@ -3,12 +3,17 @@ package prow
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 
 	"github.com/pkg/errors"
 
 	"github.com/ghodss/yaml"
 	prowconfig "github.com/jenkins-x/jx/pkg/prow/config"
+	"github.com/jenkins-x/jx/pkg/util"
 	build "github.com/knative/build/pkg/apis/build/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,6 +42,9 @@ const (
 	ProwExternalPluginsFilename = "external-plugins.yaml"
 	ProwConfigFilename          = "config.yaml"
 	ProwPluginsFilename         = "plugins.yaml"
+
+	// ConfigDir the directory of the source of the development environment which contains the Prow configuration
+	ConfigDir = "prow"
 )
 
 // Options for Prow
@@ -253,7 +261,68 @@ func (o *Options) AddProwConfig() error {
 		}
 	}
 
-	for _, r := range o.Repos {
+	addJobs(prowConfig, o.Repos, preSubmit, postSubmit)
+
+	return o.saveProwConfig(prowConfig, create)
+}
+
+// AddJobs adds the presubmit and postsubmit jobs to the repositories replacing any existing jobs of the same names.
+// Jobs without a name are ignored so that only a presubmit or a postsubmit job can be added
+func (o *Options) AddJobs(preSubmit config.Presubmit, postSubmit config.Postsubmit) error {
+	prowConfig, create, err := o.GetProwConfig()
+	if err != nil {
+		return err
+	}
+	addJobs(prowConfig, o.Repos, preSubmit, postSubmit)
+	return o.saveProwConfig(prowConfig, create)
+}
+
+// AddJobsToConfigFile adds the presubmit and postsubmit jobs to the repositories in the Prow configuration in the
+// prow directory of the source of the development environment. The configuration is created from the ConfigMap if
+// the source has no Prow configuration yet
+func (o *Options) AddJobsToConfigFile(dir string, preSubmit config.Presubmit, postSubmit config.Postsubmit) error {
+	path := filepath.Join(dir, ConfigDir, ProwConfigFilename)
+	exists, err := util.FileExists(path)
+	if err != nil {
+		return err
+	}
+	var prowConfig *config.Config
+	if exists {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load %s", path)
+		}
+		prowConfig = &config.Config{}
+		err = yaml.Unmarshal(data, prowConfig)
+		if err != nil {
+			return errors.Wrapf(err, "failed to unmarshal %s", path)
+		}
+		if prowConfig.Presubmits == nil {
+			prowConfig.Presubmits = make(map[string][]config.Presubmit)
+		}
+		if prowConfig.Postsubmits == nil {
+			prowConfig.Postsubmits = make(map[string][]config.Postsubmit)
+		}
+	} else {
+		prowConfig, _, err = o.GetProwConfig()
+		if err != nil {
+			return err
+		}
+	}
+	addJobs(prowConfig, o.Repos, preSubmit, postSubmit)
+	data, err := yaml.Marshal(prowConfig)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), util.DefaultWritePermissions)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create directory %s", filepath.Dir(path))
+	}
+	return ioutil.WriteFile(path, data, util.DefaultWritePermissions)
+}
+
+func addJobs(prowConfig *config.Config, repos []string, preSubmit config.Presubmit, postSubmit config.Postsubmit) {
+	for _, r := range repos {
 		if prowConfig.Presubmits[r] == nil {
 			prowConfig.Presubmits[r] = make([]config.Presubmit, 0)
 		}
@@ -287,8 +356,6 @@ func (o *Options) AddProwConfig() error {
 			}
 		}
 	}
-
-	return o.saveProwConfig(prowConfig, create)
 }
 
 // RemoveProwConfig deletes a config (normally a repository integration) from Prow
@@ -447,9 +514,13 @@ func (o *Options) upsertPluginConfig(closure func(pluginConfig *plugins.Configur
 }
 
 // AddProwPlugins adds plugins and external plugins to prow for any repos defined in o.Repos,
-// or for all repos which have plugins if o.Repos is nil
+// or for all repos which have plugins if o.Repos is nil.
+//
+// The plugins provide the ChatOps commands on pull requests such as /approve and /lgtm which are checked against the
+// OWNERS files, /retest to trigger the pipelines again and /label to add labels. Pull requests which are approved
+// and pass all their checks are then merged by tide. Adding the plugins again for a repo replaces its configuration
 func (o *Options) AddProwPlugins() error {
-	pluginsList := []string{"config-updater", "approve", "assign", "blunderbuss", "help", "hold", "lgtm", "lifecycle", "size", "trigger", "wip", "heart", "cat", "override"}
+	pluginsList := []string{"config-updater", "approve", "assign", "blunderbuss", "help", "hold", "label", "lgtm", "lifecycle", "size", "trigger", "wip", "heart", "cat", "override"}
 	closure := func(pluginConfig *plugins.Configuration, externalPlugins *ExternalPlugins) error {
 		if o.Repos == nil {
 			// Then we need react for all repos defined in the plugins list
@@ -466,14 +537,14 @@ func (o *Options) AddProwPlugins() error {
 				ReviewActsAsApprove: true,
 				LgtmActsAsApprove:   true,
 			}
-			pluginConfig.Approve = append(pluginConfig.Approve, a)
+			pluginConfig.Approve = upsertApprove(pluginConfig.Approve, a)
 
 			parts := strings.Split(r, "/")
 			t := plugins.Trigger{
 				Repos:      []string{r},
 				TrustedOrg: parts[0],
 			}
-			pluginConfig.Triggers = append(pluginConfig.Triggers, t)
+			pluginConfig.Triggers = upsertTrigger(pluginConfig.Triggers, t)
 
 			// External Plugins
 			pluginConfig.ExternalPlugins[r] = externalPlugins.Items
@@ -483,6 +554,28 @@ func (o *Options) AddProwPlugins() error {
 	return o.upsertPluginConfig(closure)
 }
 
+// upsertApprove replaces the approve configuration of the same repos or appends it
+func upsertApprove(approves []plugins.Approve, approve plugins.Approve) []plugins.Approve {
+	for i, a := range approves {
+		if reflect.DeepEqual(a.Repos, approve.Repos) {
+			approves[i] = approve
+			return approves
+		}
+	}
+	return append(approves, approve)
+}
+
+// upsertTrigger replaces the trigger configuration of the same repos or appends it
+func upsertTrigger(triggers []plugins.Trigger, trigger plugins.Trigger) []plugins.Trigger {
+	for i, t := range triggers {
+		if reflect.DeepEqual(t.Repos, trigger.Repos) {
+			triggers[i] = trigger
+			return triggers
+		}
+	}
+	return append(triggers, trigger)
+}
+
 func (o *Options) AddExternalProwPlugins(adds []plugins.ExternalPlugin) error {
 	closure := func(pluginConfig *plugins.Configuration, externalPlugins *ExternalPlugins) error {
 		for _, add := range adds {
@@ -504,6 +597,36 @@ func (o *Options) AddExternalProwPlugins(adds []plugins.ExternalPlugin) error {
 	return o.upsertPluginConfig(closure)
 }
 
+// WriteConfigFiles writes the Prow configuration and plugins from their ConfigMaps into the prow directory of the
+// source of the development environment so that they can be changed via pull requests. The config-updater plugin
+// updates the ConfigMaps from these files when the pull requests are merged
+func (o *Options) WriteConfigFiles(dir string) ([]string, error) {
+	files := []string{}
+	prowDir := filepath.Join(dir, ConfigDir)
+	err := os.MkdirAll(prowDir, util.DefaultWritePermissions)
+	if err != nil {
+		return files, errors.Wrapf(err, "failed to create directory %s", prowDir)
+	}
+	configMaps := map[string]string{
+		ProwConfigFilename:  ProwConfigMapName,
+		ProwPluginsFilename: ProwPluginsConfigMapName,
+	}
+	for _, fileName := range []string{ProwConfigFilename, ProwPluginsFilename} {
+		name := configMaps[fileName]
+		cm, err := o.KubeClient.CoreV1().ConfigMaps(o.NS).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return files, errors.Wrapf(err, "failed to load the Prow ConfigMap %s in namespace %s", name, o.NS)
+		}
+		path := filepath.Join(prowDir, fileName)
+		err = ioutil.WriteFile(path, []byte(cm.Data[fileName]), util.DefaultWritePermissions)
+		if err != nil {
+			return files, errors.Wrapf(err, "failed to write %s", path)
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
+
 func (o *Options) GetReleaseJobs() ([]string, error) {
 	cm, err := o.KubeClient.CoreV1().ConfigMaps(o.NS).Get(ProwConfigMapName, metav1.GetOptions{})
 	if err != nil {
@@ -1,6 +1,10 @@
 package prow_test
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
 	"github.com/jenkins-x/jx/pkg/prow"
 	prowconfig "github.com/jenkins-x/jx/pkg/prow/config"
 	"github.com/jenkins-x/jx/pkg/util"
@@ -152,6 +156,31 @@ func TestAddProwPlugin(t *testing.T) {
 	assert.NotEmpty(t, pluginConfig.Plugins["test/repo2"])
 }
 
+func TestAddProwPluginTwice(t *testing.T) {
+	t.Parallel()
+	o := TestOptions{}
+	o.Setup()
+	o.Kind = prowconfig.Application
+
+	err := o.AddProwPlugins()
+	assert.NoError(t, err)
+	err = o.AddProwPlugins()
+	assert.NoError(t, err)
+
+	cm, err := o.KubeClient.CoreV1().ConfigMaps(o.NS).Get(prow.ProwPluginsConfigMapName, metav1.GetOptions{})
+	assert.NoError(t, err)
+
+	pluginConfig := &plugins.Configuration{}
+	assert.NoError(t, yaml.Unmarshal([]byte(cm.Data[prow.ProwPluginsFilename]), &pluginConfig))
+
+	assert.Len(t, pluginConfig.Approve, 1)
+	assert.Len(t, pluginConfig.Triggers, 1)
+	assert.Equal(t, "test", pluginConfig.Triggers[0].TrustedOrg)
+	assert.Contains(t, pluginConfig.Plugins["test/repo"], "approve")
+	assert.Contains(t, pluginConfig.Plugins["test/repo"], "label")
+	assert.Contains(t, pluginConfig.Plugins["test/repo"], "trigger")
+}
+
 func TestAddProwExternalPlugin(t *testing.T) {
 	t.Parallel()
 	o := TestOptions{}
@@ -362,6 +391,101 @@ func TestGetPostSubmitJob(t *testing.T) {
 	assert.Equal(t, "release", job.Name)
 }
 
+func TestAddJobs(t *testing.T) {
+	t.Parallel()
+	o := TestOptions{}
+	o.Setup()
+	o.Kind = prowconfig.Application
+
+	err := o.AddProwConfig()
+	assert.NoError(t, err)
+
+	lint := config.Presubmit{}
+	lint.Name = "lint"
+	lint.Context = "lint"
+	lint.AlwaysRun = true
+	err = o.AddJobs(lint, config.Postsubmit{})
+	assert.NoError(t, err)
+
+	lint.AlwaysRun = false
+	err = o.AddJobs(lint, config.Postsubmit{})
+	assert.NoError(t, err)
+
+	prowConfig, err := getProwConfig(t, o)
+	assert.NoError(t, err)
+	presubmits := prowConfig.Presubmits["test/repo"]
+	assert.Equal(t, 2, len(presubmits))
+	assert.Equal(t, "lint", presubmits[1].Name)
+	assert.False(t, presubmits[1].AlwaysRun, "the job should have been replaced")
+	assert.Equal(t, 1, len(prowConfig.Postsubmits["test/repo"]))
+}
+
+func TestWriteConfigFiles(t *testing.T) {
+	t.Parallel()
+	o := TestOptions{}
+	o.Setup()
+	o.Kind = prowconfig.Application
+
+	err := o.AddProwConfig()
+	assert.NoError(t, err)
+	err = o.AddProwPlugins()
+	assert.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "test-prow-config-files")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files, err := o.WriteConfigFiles(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "prow", "config.yaml"), filepath.Join(dir, "prow", "plugins.yaml")}, files)
+
+	data, err := ioutil.ReadFile(files[0])
+	assert.NoError(t, err)
+	prowConfig := &config.Config{}
+	assert.NoError(t, yaml.Unmarshal(data, prowConfig))
+	assert.NotEmpty(t, prowConfig.Presubmits["test/repo"])
+}
+
+func TestAddJobsToConfigFile(t *testing.T) {
+	t.Parallel()
+	o := TestOptions{}
+	o.Setup()
+	o.Kind = prowconfig.Application
+
+	err := o.AddProwConfig()
+	assert.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "test-prow-config-jobs")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	preSubmit := config.Presubmit{}
+	preSubmit.Name = "lint"
+	err = o.AddJobsToConfigFile(dir, preSubmit, config.Postsubmit{})
+	assert.NoError(t, err)
+
+	preSubmit.Name = "integration"
+	err = o.AddJobsToConfigFile(dir, preSubmit, config.Postsubmit{})
+	assert.NoError(t, err)
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "prow", "config.yaml"))
+	assert.NoError(t, err)
+	prowConfig := &config.Config{}
+	assert.NoError(t, yaml.Unmarshal(data, prowConfig))
+	names := []string{}
+	for _, job := range prowConfig.Presubmits["test/repo"] {
+		names = append(names, job.Name)
+	}
+	assert.Contains(t, names, "lint")
+	assert.Contains(t, names, "integration")
+
+	cmConfig, err := getProwConfig(t, o)
+	assert.NoError(t, err)
+	for _, job := range cmConfig.Presubmits["test/repo"] {
+		assert.NotEqual(t, "lint", job.Name, "the ConfigMap should not be modified")
+	}
+}
+
 func getProwConfig(t *testing.T, o TestOptions) (*config.Config, error) {
 	cm, err := o.KubeClient.CoreV1().ConfigMaps(o.NS).Get(prow.ProwConfigMapName, metav1.GetOptions{})
 	assert.NoError(t, err)
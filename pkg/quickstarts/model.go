@@ -32,14 +32,51 @@ func GitQuickstart(provider gits.GitProvider, owner string, repo string, languag
 	}
 }
 
+// quickstartFrameworks maps the words of the names of quickstart repositories to their framework
+var quickstartFrameworks = map[string]string{
+	"spring":     "spring",
+	"vertx":      "vertx",
+	"dropwizard": "dropwizard",
+	"rails":      "rails",
+	"django":     "django",
+	"flask":      "flask",
+	"aspnet":     "aspnet",
+	"angular":    "angular",
+	"react":      "react",
+	"express":    "express",
+}
+
 func toGitHubQuickstart(provider gits.GitProvider, owner string, repo *gits.GitRepository) *Quickstart {
 	language := repo.Language
-	// TODO find this from GitHub???
-	framework := ""
-	tags := []string{}
+	framework := frameworkFromName(repo.Name)
+	tags := tagsFromName(repo.Name)
 	return GitQuickstart(provider, owner, repo.Name, language, framework, tags...)
 }
 
+// frameworkFromName returns the framework of a quickstart from the first word of the name of its repository which is
+// the name of a framework so that words containing the name of a framework, such as reactive, are ignored
+func frameworkFromName(name string) string {
+	for _, word := range tagsFromName(name) {
+		if framework, ok := quickstartFrameworks[word]; ok {
+			return framework
+		}
+	}
+	return ""
+}
+
+// tagsFromName returns the words of the name of a quickstart repository as its tags
+func tagsFromName(name string) []string {
+	tags := []string{}
+	for _, word := range strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == '-' || r == '_' || r == '.'
+	}) {
+		if util.StringArrayIndex(tags, word) < 0 {
+			tags = append(tags, word)
+		}
+	}
+	return tags
+}
+
 // LoadGithubQuickstarts Loads quickstarts from github
 func (model *QuickstartModel) LoadGithubQuickstarts(provider gits.GitProvider, owner string, includes []string, excludes []string) error {
 	repos, err := provider.ListRepositories(owner)
@@ -77,19 +114,9 @@ func (model *QuickstartModel) Add(q *Quickstart) bool {
 // CreateSurvey creates a survey to query pick a quickstart
 func (model *QuickstartModel) CreateSurvey(filter *QuickstartFilter, batchMode bool, in terminal.FileReader, out terminal.FileWriter, errOut io.Writer) (*QuickstartForm, error) {
 	surveyOpts := survey.WithStdio(in, out, errOut)
-	language := filter.Language
-	if language != "" {
-		languages := model.Languages()
-		if len(languages) == 0 {
-			// lets ignore this filter as there are none available
-			filter.Language = ""
-		} else {
-			lower := strings.ToLower(language)
-			lowerLanguages := util.StringArrayToLower(languages)
-			if util.StringArrayIndex(lowerLanguages, lower) < 0 {
-				return nil, util.InvalidOption("language", language, languages)
-			}
-		}
+	err := model.ValidateFilter(filter)
+	if err != nil {
+		return nil, err
 	}
 	quickstarts := model.Filter(filter)
 	names := []string{}
@@ -166,6 +193,43 @@ func (model *QuickstartModel) Filter(filter *QuickstartFilter) []*Quickstart {
 	return answer
 }
 
+// ValidateFilter returns an error if the language or framework of the filter is not used by any of the quickstarts.
+// An option is ignored if none of the quickstarts have a value for it
+func (model *QuickstartModel) ValidateFilter(filter *QuickstartFilter) error {
+	err := validateFilterOption("language", &filter.Language, model.Languages())
+	if err != nil {
+		return err
+	}
+	return validateFilterOption("framework", &filter.Framework, model.Frameworks())
+}
+
+func validateFilterOption(option string, value *string, values []string) error {
+	if *value == "" {
+		return nil
+	}
+	if len(values) == 0 {
+		// lets ignore this filter as there are none available
+		*value = ""
+		return nil
+	}
+	if util.StringArrayIndex(util.StringArrayToLower(values), strings.ToLower(*value)) < 0 {
+		return util.InvalidOption(option, *value, values)
+	}
+	return nil
+}
+
+// Frameworks returns all the frameworks in the quickstarts sorted
+func (model *QuickstartModel) Frameworks() []string {
+	m := map[string]string{}
+	for _, q := range model.Quickstarts {
+		f := q.Framework
+		if f != "" {
+			m[f] = f
+		}
+	}
+	return util.SortedMapKeys(m)
+}
+
 // Languages returns all the languages in the quickstarts sorted
 func (model *QuickstartModel) Languages() []string {
 	m := map[string]string{}
@@ -3,8 +3,10 @@ package quickstarts_test
 import (
 	"testing"
 
+	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/quickstarts"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestQuickstartModelFilterText(t *testing.T) {
@@ -112,3 +114,76 @@ func TestQuickstartModelFilterTextMatchesOneExactly(t *testing.T) {
 	assert.Equal(t, 1, len(results))
 	assert.Contains(t, results, quickstart1)
 }
+
+func TestQuickstartModelFilterTags(t *testing.T) {
+	t.Parallel()
+
+	springBoot := &quickstarts.Quickstart{
+		ID:        "jenkins-x-quickstarts/spring-boot-rest-prometheus",
+		Name:      "spring-boot-rest-prometheus",
+		Framework: "spring",
+		Tags:      []string{"spring", "boot", "rest", "prometheus"},
+	}
+	nodeHTTP := &quickstarts.Quickstart{
+		ID:   "jenkins-x-quickstarts/node-http",
+		Name: "node-http",
+		Tags: []string{"node", "http"},
+	}
+	quickstartModel := &quickstarts.QuickstartModel{
+		Quickstarts: map[string]*quickstarts.Quickstart{
+			springBoot.Name: springBoot,
+			nodeHTTP.Name:   nodeHTTP,
+		},
+	}
+
+	results := quickstartModel.Filter(&quickstarts.QuickstartFilter{Tags: []string{"REST", "prometheus"}})
+	assert.Equal(t, []*quickstarts.Quickstart{springBoot}, results)
+
+	results = quickstartModel.Filter(&quickstarts.QuickstartFilter{Tags: []string{"http", "rest"}})
+	assert.Empty(t, results)
+
+	results = quickstartModel.Filter(&quickstarts.QuickstartFilter{Framework: "Spring"})
+	assert.Equal(t, []*quickstarts.Quickstart{springBoot}, results)
+
+	assert.Equal(t, []string{"spring"}, quickstartModel.Frameworks())
+	assert.NoError(t, quickstartModel.ValidateFilter(&quickstarts.QuickstartFilter{Framework: "Spring"}))
+	assert.Error(t, quickstartModel.ValidateFilter(&quickstarts.QuickstartFilter{Framework: "rails"}))
+
+	// the language is ignored as none of the quickstarts have one
+	filter := &quickstarts.QuickstartFilter{Language: "go"}
+	assert.NoError(t, quickstartModel.ValidateFilter(filter))
+	assert.Equal(t, "", filter.Language)
+}
+
+func TestLoadGithubQuickstartsFramework(t *testing.T) {
+	t.Parallel()
+
+	owner := quickstarts.JenkinsXQuickstartsOwner
+	expected := map[string]string{
+		"spring-boot-rest-prometheus": "spring",
+		"vertx-reactive-rest":         "vertx",
+		"spring-boot-reactive":        "spring",
+		"react-quickstart":            "react",
+		"golang-http-expressions":     "",
+		"ruby-on-trails":              "",
+		"python-flask-http":           "flask",
+		"node-http":                   "",
+	}
+	repos := []*gits.FakeRepository{}
+	for name := range expected {
+		repos = append(repos, gits.NewFakeRepository(owner, name))
+	}
+	provider := gits.NewFakeProvider(repos...)
+
+	quickstartModel := quickstarts.NewQuickstartModel()
+	err := quickstartModel.LoadGithubQuickstarts(provider, owner, []string{"*"}, []string{})
+	require.NoError(t, err)
+	require.Len(t, quickstartModel.Quickstarts, len(expected))
+
+	for name, framework := range expected {
+		quickstart := quickstartModel.Quickstarts[owner+"/"+name]
+		if assert.NotNil(t, quickstart, name) {
+			assert.Equal(t, framework, quickstart.Framework, name)
+		}
+	}
+}
@@ -32,5 +32,20 @@ func (f *QuickstartFilter) Matches(q *Quickstart) bool {
 	if framework != "" && strings.ToLower(q.Framework) != framework {
 		return false
 	}
+	for _, tag := range f.Tags {
+		if !q.hasTag(tag) {
+			return false
+		}
+	}
 	return true
 }
+
+// hasTag returns true if the quickstart has the tag ignoring case
+func (q *Quickstart) hasTag(tag string) bool {
+	for _, t := range q.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
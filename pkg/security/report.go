@@ -0,0 +1,150 @@
+package security
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+)
+
+const (
+	// ScannerTrivy scans images using Trivy
+	ScannerTrivy = "trivy"
+	// ScannerAnchore scans images using the Anchore engine
+	ScannerAnchore = "anchore"
+)
+
+// Scanners the supported image scanners
+var Scanners = []string{ScannerTrivy, ScannerAnchore}
+
+type trivyResult struct {
+	Target          string `json:"Target"`
+	Vulnerabilities []struct {
+		VulnerabilityID  string   `json:"VulnerabilityID"`
+		PkgName          string   `json:"PkgName"`
+		InstalledVersion string   `json:"InstalledVersion"`
+		FixedVersion     string   `json:"FixedVersion"`
+		Severity         string   `json:"Severity"`
+		PrimaryURL       string   `json:"PrimaryURL"`
+		References       []string `json:"References"`
+	} `json:"Vulnerabilities"`
+}
+
+// ParseTrivyReport parses the JSON report of Trivy
+func ParseTrivyReport(data []byte) ([]v1.ImageVulnerability, error) {
+	results := []trivyResult{}
+	// older versions of Trivy report an array of results
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		err := json.Unmarshal(data, &results)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the Trivy report: %s", err)
+		}
+	} else {
+		report := struct {
+			Results []trivyResult `json:"Results"`
+		}{}
+		err := json.Unmarshal(data, &report)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the Trivy report: %s", err)
+		}
+		results = report.Results
+	}
+	answer := []v1.ImageVulnerability{}
+	for _, r := range results {
+		for _, v := range r.Vulnerabilities {
+			url := v.PrimaryURL
+			if url == "" && len(v.References) > 0 {
+				url = v.References[0]
+			}
+			answer = append(answer, v1.ImageVulnerability{
+				ID:               v.VulnerabilityID,
+				Package:          v.PkgName,
+				InstalledVersion: v.InstalledVersion,
+				FixedVersion:     v.FixedVersion,
+				Severity:         strings.ToLower(v.Severity),
+				URL:              url,
+			})
+		}
+	}
+	return answer, nil
+}
+
+// ParseAnchoreReport parses the JSON report of 'anchore-cli --json image vuln <image> all'
+func ParseAnchoreReport(data []byte) ([]v1.ImageVulnerability, error) {
+	report := struct {
+		Vulnerabilities []struct {
+			Vuln           string `json:"vuln"`
+			PackageName    string `json:"package_name"`
+			PackageVersion string `json:"package_version"`
+			Fix            string `json:"fix"`
+			Severity       string `json:"severity"`
+			URL            string `json:"url"`
+		} `json:"vulnerabilities"`
+	}{}
+	err := json.Unmarshal(data, &report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the Anchore report: %s", err)
+	}
+	answer := []v1.ImageVulnerability{}
+	for _, v := range report.Vulnerabilities {
+		fix := v.Fix
+		if fix == "None" {
+			fix = ""
+		}
+		answer = append(answer, v1.ImageVulnerability{
+			ID:               v.Vuln,
+			Package:          v.PackageName,
+			InstalledVersion: v.PackageVersion,
+			FixedVersion:     fix,
+			Severity:         strings.ToLower(v.Severity),
+			URL:              v.URL,
+		})
+	}
+	return answer, nil
+}
+
+// Summary returns a one line summary of the number of vulnerabilities of each severity
+func Summary(vulnerabilities []v1.ImageVulnerability) string {
+	if len(vulnerabilities) == 0 {
+		return "no vulnerabilities"
+	}
+	counts := CountBySeverity(vulnerabilities)
+	parts := []string{}
+	for s := SeverityCritical; s >= SeverityUnknown; s-- {
+		if counts[s] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[s], s))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// MarkdownReport returns a markdown report of the most severe vulnerabilities of the scan suitable for a Pull Request comment
+func MarkdownReport(scan *v1.ImageScan, maxRows int) string {
+	spec := &scan.Spec
+	vulnerabilities := append([]v1.ImageVulnerability{}, spec.Vulnerabilities...)
+	sort.SliceStable(vulnerabilities, func(i, j int) bool {
+		return SeverityOf(&vulnerabilities[i]) > SeverityOf(&vulnerabilities[j])
+	})
+
+	var buffer strings.Builder
+	buffer.WriteString(fmt.Sprintf("**%s** scanned image `%s`: %s\n", spec.Scanner, spec.Image, Summary(vulnerabilities)))
+	if len(vulnerabilities) == 0 {
+		return buffer.String()
+	}
+	buffer.WriteString("\n| Severity | Vulnerability | Package | Installed | Fixed |\n")
+	buffer.WriteString("| --- | --- | --- | --- | --- |\n")
+	for i, v := range vulnerabilities {
+		if i >= maxRows {
+			buffer.WriteString(fmt.Sprintf("\n... and %d more. Use `kubectl get imagescan %s -o yaml` to see them all\n", len(vulnerabilities)-maxRows, scan.Name))
+			break
+		}
+		id := v.ID
+		if v.URL != "" {
+			id = fmt.Sprintf("[%s](%s)", v.ID, v.URL)
+		}
+		buffer.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n", v.Severity, id, v.Package, v.InstalledVersion, v.FixedVersion))
+	}
+	return buffer.String()
+}
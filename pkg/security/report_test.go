@@ -0,0 +1,60 @@
+package security_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeverity(t *testing.T) {
+	t.Parallel()
+
+	s, err := security.ParseSeverity("HIGH")
+	require.NoError(t, err)
+	assert.Equal(t, security.SeverityHigh, s)
+	assert.Equal(t, "high", s.String())
+
+	_, err = security.ParseSeverity("severe")
+	assert.Error(t, err)
+}
+
+func TestParseTrivyReport(t *testing.T) {
+	t.Parallel()
+
+	data := `{"Results": [{"Target": "myapp:1.0.0 (alpine 3.9)", "Vulnerabilities": [
+		{"VulnerabilityID": "CVE-2019-1", "PkgName": "openssl", "InstalledVersion": "1.1.1a", "FixedVersion": "1.1.1b", "Severity": "CRITICAL", "PrimaryURL": "https://avd.aquasec.com/nvd/cve-2019-1"},
+		{"VulnerabilityID": "CVE-2019-2", "PkgName": "musl", "InstalledVersion": "1.1.20", "Severity": "LOW"}
+	]}]}`
+	vulnerabilities, err := security.ParseTrivyReport([]byte(data))
+	require.NoError(t, err)
+	require.Len(t, vulnerabilities, 2)
+	assert.Equal(t, "critical", vulnerabilities[0].Severity)
+	assert.Equal(t, "openssl", vulnerabilities[0].Package)
+	assert.Equal(t, "1 critical, 1 low", security.Summary(vulnerabilities))
+
+	exceeding := security.Exceeding(vulnerabilities, security.SeverityHigh)
+	require.Len(t, exceeding, 1)
+	assert.Equal(t, "CVE-2019-1", exceeding[0].ID)
+	assert.Empty(t, security.Exceeding(vulnerabilities, security.SeverityCritical))
+}
+
+func TestParseAnchoreReport(t *testing.T) {
+	t.Parallel()
+
+	data := `{"imageDigest": "sha256:abc", "vulnerabilities": [
+		{"vuln": "CVE-2018-1", "package_name": "bash", "package_version": "4.4", "fix": "None", "severity": "Medium", "url": "https://security-tracker.debian.org/tracker/CVE-2018-1"}
+	]}`
+	vulnerabilities, err := security.ParseAnchoreReport([]byte(data))
+	require.NoError(t, err)
+	require.Len(t, vulnerabilities, 1)
+	assert.Equal(t, v1.ImageVulnerability{
+		ID:               "CVE-2018-1",
+		Package:          "bash",
+		InstalledVersion: "4.4",
+		Severity:         "medium",
+		URL:              "https://security-tracker.debian.org/tracker/CVE-2018-1",
+	}, vulnerabilities[0])
+}
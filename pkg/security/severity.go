@@ -0,0 +1,77 @@
+package security
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+)
+
+// Severity the severity of a vulnerability
+type Severity int
+
+const (
+	// SeverityUnknown the scanner does not know the severity of the vulnerability
+	SeverityUnknown Severity = iota
+	// SeverityNegligible a vulnerability which is not a security problem
+	SeverityNegligible
+	// SeverityLow a vulnerability of low severity
+	SeverityLow
+	// SeverityMedium a vulnerability of medium severity
+	SeverityMedium
+	// SeverityHigh a vulnerability of high severity
+	SeverityHigh
+	// SeverityCritical a vulnerability of critical severity
+	SeverityCritical
+)
+
+// SeverityNames the names of the severities in increasing order
+var SeverityNames = []string{"unknown", "negligible", "low", "medium", "high", "critical"}
+
+// String returns the name of the severity
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(SeverityNames) {
+		return SeverityNames[SeverityUnknown]
+	}
+	return SeverityNames[s]
+}
+
+// ParseSeverity parses the name of a severity ignoring case
+func ParseSeverity(text string) (Severity, error) {
+	name := strings.ToLower(strings.TrimSpace(text))
+	for i, n := range SeverityNames {
+		if n == name {
+			return Severity(i), nil
+		}
+	}
+	return SeverityUnknown, fmt.Errorf("invalid severity %s, possible values are: %s", text, strings.Join(SeverityNames, ", "))
+}
+
+// SeverityOf returns the severity of the vulnerability or SeverityUnknown if the scanner reported an unknown severity
+func SeverityOf(v *v1.ImageVulnerability) Severity {
+	s, err := ParseSeverity(v.Severity)
+	if err != nil {
+		return SeverityUnknown
+	}
+	return s
+}
+
+// Exceeding returns the vulnerabilities which are more severe than the given severity
+func Exceeding(vulnerabilities []v1.ImageVulnerability, max Severity) []v1.ImageVulnerability {
+	answer := []v1.ImageVulnerability{}
+	for i := range vulnerabilities {
+		if SeverityOf(&vulnerabilities[i]) > max {
+			answer = append(answer, vulnerabilities[i])
+		}
+	}
+	return answer
+}
+
+// CountBySeverity returns the number of vulnerabilities of each severity
+func CountBySeverity(vulnerabilities []v1.ImageVulnerability) map[Severity]int {
+	answer := map[Severity]int{}
+	for i := range vulnerabilities {
+		answer[SeverityOf(&vulnerabilities[i])]++
+	}
+	return answer
+}
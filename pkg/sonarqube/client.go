@@ -0,0 +1,208 @@
+package sonarqube
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// QualityGateOK the project passed the quality gate
+	QualityGateOK = "OK"
+	// QualityGateWarn the project passed the quality gate with warnings
+	QualityGateWarn = "WARN"
+	// QualityGateError the project failed the quality gate
+	QualityGateError = "ERROR"
+	// QualityGateNone the project has no quality gate
+	QualityGateNone = "NONE"
+
+	// TaskSuccess the background task which processes an analysis completed
+	TaskSuccess = "SUCCESS"
+	// TaskFailed the background task which processes an analysis failed
+	TaskFailed = "FAILED"
+	// TaskCanceled the background task which processes an analysis was canceled
+	TaskCanceled = "CANCELED"
+)
+
+// Client a client of the SonarQube web API
+type Client struct {
+	URL        string
+	Token      string
+	Username   string
+	Password   string
+	HTTPClient *http.Client
+}
+
+// QualityGate the status of the quality gate of an analysis
+type QualityGate struct {
+	Status     string                 `json:"status"`
+	Conditions []QualityGateCondition `json:"conditions,omitempty"`
+}
+
+// QualityGateCondition a condition of a quality gate
+type QualityGateCondition struct {
+	Status         string `json:"status"`
+	MetricKey      string `json:"metricKey"`
+	Comparator     string `json:"comparator,omitempty"`
+	ErrorThreshold string `json:"errorThreshold,omitempty"`
+	ActualValue    string `json:"actualValue,omitempty"`
+}
+
+// Task the background task which processes an analysis after the scanner has uploaded it
+type Task struct {
+	ID           string `json:"id"`
+	Status       string `json:"status"`
+	AnalysisID   string `json:"analysisId,omitempty"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// NewClient creates a client of the SonarQube server at the given URL authenticating with the token
+func NewClient(serverURL string, token string) *Client {
+	return &Client{
+		URL:   strings.TrimSuffix(serverURL, "/"),
+		Token: token,
+		HTTPClient: &http.Client{
+			Timeout: time.Minute,
+		},
+	}
+}
+
+// GenerateToken generates a new user token of the given name
+func (c *Client) GenerateToken(name string) (string, error) {
+	result := struct {
+		Token string `json:"token"`
+	}{}
+	err := c.do("POST", "/api/user_tokens/generate", url.Values{"name": {name}}, &result)
+	if err != nil {
+		return "", err
+	}
+	return result.Token, nil
+}
+
+// GetTask returns the background task of the given ID
+func (c *Client) GetTask(id string) (*Task, error) {
+	result := struct {
+		Task Task `json:"task"`
+	}{}
+	err := c.do("GET", "/api/ce/task", url.Values{"id": {id}}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result.Task, nil
+}
+
+// GetQualityGate returns the status of the quality gate of the given analysis
+func (c *Client) GetQualityGate(analysisID string) (*QualityGate, error) {
+	result := struct {
+		ProjectStatus QualityGate `json:"projectStatus"`
+	}{}
+	err := c.do("GET", "/api/qualitygates/project_status", url.Values{"analysisId": {analysisID}}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result.ProjectStatus, nil
+}
+
+// WaitForTask waits for the background task to complete returning an error if it fails or the timeout is exceeded
+func (c *Client) WaitForTask(id string, pollTime time.Duration, timeout time.Duration) (*Task, error) {
+	end := time.Now().Add(timeout)
+	for {
+		task, err := c.GetTask(id)
+		if err != nil {
+			return nil, err
+		}
+		switch task.Status {
+		case TaskSuccess:
+			return task, nil
+		case TaskFailed, TaskCanceled:
+			return task, fmt.Errorf("the SonarQube analysis task %s is %s: %s", id, task.Status, task.ErrorMessage)
+		}
+		if time.Now().After(end) {
+			return task, fmt.Errorf("timed out waiting for the SonarQube analysis task %s which is %s", id, task.Status)
+		}
+		time.Sleep(pollTime)
+	}
+}
+
+// DashboardURL returns the URL of the dashboard of the project
+func (c *Client) DashboardURL(projectKey string) string {
+	return c.URL + "/dashboard?id=" + url.QueryEscape(projectKey)
+}
+
+func (c *Client) do(method string, path string, params url.Values, result interface{}) error {
+	u := c.URL + path
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	req, err := http.NewRequest(method, u, nil)
+	if err != nil {
+		return err
+	}
+	if c.Token != "" {
+		req.SetBasicAuth(c.Token, "")
+	} else if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to invoke %s %s: %s", method, u, err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read the response of %s %s: %s", method, u, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned status %s: %s", method, u, resp.Status, string(data))
+	}
+	err = json.Unmarshal(data, result)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal the response of %s %s: %s", method, u, err)
+	}
+	return nil
+}
+
+// ReportTaskFiles the files the different scanners write the details of the uploaded analysis to
+var ReportTaskFiles = []string{
+	filepath.Join("target", "sonar", "report-task.txt"),
+	filepath.Join("build", "sonar", "report-task.txt"),
+	filepath.Join(".scannerwork", "report-task.txt"),
+}
+
+// LoadReportTask loads the properties of the report-task.txt file written by the scanner in the given directory
+func LoadReportTask(dir string) (map[string]string, error) {
+	fileNames := []string{}
+	for _, f := range ReportTaskFiles {
+		fileNames = append(fileNames, filepath.Join(dir, f))
+	}
+	fileName, err := util.FirstFileExists(fileNames...)
+	if err != nil {
+		return nil, err
+	}
+	if fileName == "" {
+		return nil, fmt.Errorf("no report-task.txt file found in %s so cannot find the analysis of the scanner", dir)
+	}
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	answer := map[string]string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), "=", 2)
+		if len(parts) == 2 {
+			answer[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		}
+	}
+	return answer, scanner.Err()
+}
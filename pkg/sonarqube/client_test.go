@@ -0,0 +1,68 @@
+package sonarqube_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/sonarqube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQualityGate(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/ce/task", func(w http.ResponseWriter, r *http.Request) {
+		user, _, _ := r.BasicAuth()
+		assert.Equal(t, "mytoken", user)
+		assert.Equal(t, "AWb1", r.URL.Query().Get("id"))
+		fmt.Fprint(w, `{"task": {"id": "AWb1", "status": "SUCCESS", "analysisId": "AWb2"}}`)
+	})
+	mux.HandleFunc("/api/qualitygates/project_status", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "AWb2", r.URL.Query().Get("analysisId"))
+		fmt.Fprint(w, `{"projectStatus": {"status": "ERROR", "conditions": [{"status": "ERROR", "metricKey": "new_coverage", "actualValue": "42.0"}]}}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := sonarqube.NewClient(server.URL+"/", "mytoken")
+	task, err := client.WaitForTask("AWb1", time.Millisecond, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "AWb2", task.AnalysisID)
+
+	gate, err := client.GetQualityGate(task.AnalysisID)
+	require.NoError(t, err)
+	assert.Equal(t, sonarqube.QualityGateError, gate.Status)
+	require.Len(t, gate.Conditions, 1)
+	assert.Equal(t, "new_coverage", gate.Conditions[0].MetricKey)
+
+	assert.Equal(t, server.URL+"/dashboard?id=myorg%3Amyapp", client.DashboardURL("myorg:myapp"))
+}
+
+func TestLoadReportTask(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "test-sonarqube-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = sonarqube.LoadReportTask(dir)
+	assert.Error(t, err)
+
+	reportDir := filepath.Join(dir, "target", "sonar")
+	require.NoError(t, os.MkdirAll(reportDir, 0755))
+	data := "projectKey=myorg:myapp\nceTaskId=AWb1\nceTaskUrl=http://sonar/api/ce/task?id=AWb1\n"
+	require.NoError(t, ioutil.WriteFile(filepath.Join(reportDir, "report-task.txt"), []byte(data), 0644))
+
+	report, err := sonarqube.LoadReportTask(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "AWb1", report["ceTaskId"])
+	assert.Equal(t, "http://sonar/api/ce/task?id=AWb1", report["ceTaskUrl"])
+}
@@ -0,0 +1,93 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// AnalysisCommand the pipeline step command which runs the analysis of a project
+	AnalysisCommand = "jx step scan sonarqube"
+
+	// ScannerParamsEnvVar the environment variable of the JSON analysis properties read by all the scanners which
+	// keeps the token out of the command line
+	ScannerParamsEnvVar = "SONARQUBE_SCANNER_PARAMS"
+)
+
+// SupportedBuildPacks the build packs whose projects can be analysed
+var SupportedBuildPacks = []string{
+	"appserver",
+	"csharp",
+	"go",
+	"gradle",
+	"javascript",
+	"liberty",
+	"maven",
+	"maven-java11",
+	"python",
+	"scala",
+	"typescript",
+}
+
+// IsSupportedBuildPack returns true if projects of the given build pack can be analysed
+func IsSupportedBuildPack(pack string) bool {
+	return util.StringArrayIndex(SupportedBuildPacks, pack) >= 0
+}
+
+// ScannerArguments the arguments to analyse a project
+type ScannerArguments struct {
+	Dir        string
+	URL        string
+	Token      string
+	ProjectKey string
+	Version    string
+}
+
+// ScannerCommand returns the command which analyses the project in the directory using the Maven or Gradle plugin if
+// the project uses them or the standalone scanner otherwise. The token is passed to the scanner via its environment
+// rather than its arguments so that it does not show up in the process list
+func ScannerCommand(args *ScannerArguments) (*util.CommandSpec, error) {
+	params, err := json.Marshal(map[string]string{
+		"sonar.login": args.Token,
+	})
+	if err != nil {
+		return nil, err
+	}
+	cmd := &util.CommandSpec{
+		Dir: args.Dir,
+		Env: map[string]string{
+			ScannerParamsEnvVar: string(params),
+		},
+	}
+	properties := []string{
+		"-Dsonar.host.url=" + args.URL,
+		"-Dsonar.projectKey=" + args.ProjectKey,
+	}
+	if args.Version != "" {
+		properties = append(properties, "-Dsonar.projectVersion="+args.Version)
+	}
+	exists, err := util.FileExists(filepath.Join(args.Dir, "pom.xml"))
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		cmd.Name = "mvn"
+		cmd.Args = append([]string{"--batch-mode", "sonar:sonar"}, properties...)
+		return cmd, nil
+	}
+	gradleFile, err := util.FirstFileExists(filepath.Join(args.Dir, "build.gradle"), filepath.Join(args.Dir, "build.gradle.kts"))
+	if err != nil {
+		return nil, err
+	}
+	if gradleFile != "" {
+		cmd.Name = "gradle"
+		cmd.Args = append([]string{"sonarqube"}, properties...)
+		return cmd, nil
+	}
+	cmd.Name = "sonar-scanner"
+	cmd.Args = append(properties, fmt.Sprintf("-Dsonar.projectBaseDir=%s", args.Dir))
+	return cmd, nil
+}
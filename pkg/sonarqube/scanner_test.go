@@ -0,0 +1,39 @@
+package sonarqube_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/sonarqube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScannerCommand(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "sonar-scanner-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	args := &sonarqube.ScannerArguments{
+		Dir:        dir,
+		URL:        "http://sonarqube",
+		Token:      "s3cr3t-token",
+		ProjectKey: "myorg:myapp",
+	}
+
+	cmd, err := sonarqube.ScannerCommand(args)
+	require.NoError(t, err)
+	assert.Equal(t, "sonar-scanner", cmd.Name)
+	assert.NotContains(t, strings.Join(cmd.Args, " "), args.Token)
+	assert.JSONEq(t, `{"sonar.login": "s3cr3t-token"}`, cmd.Env[sonarqube.ScannerParamsEnvVar])
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "pom.xml"), []byte("<project/>"), 0644))
+	cmd, err = sonarqube.ScannerCommand(args)
+	require.NoError(t, err)
+	assert.Equal(t, "mvn", cmd.Name)
+	assert.Equal(t, []string{"--batch-mode", "sonar:sonar", "-Dsonar.host.url=http://sonarqube", "-Dsonar.projectKey=myorg:myapp"}, cmd.Args)
+	assert.NotContains(t, strings.Join(cmd.Args, " "), args.Token)
+}
@@ -28,8 +28,12 @@ const (
 	OptionDependency     = "dep"
 	OptionDependencyKind = "kind"
 	OptionType           = "type"
+	OptionPackageName    = "package-name"
+	OptionDescription    = "description"
+	OptionServerURL      = "server-url"
 
-	startSpringURL = "http://start.spring.io"
+	// DefaultSpringURL the URL of the public Spring Initializr
+	DefaultSpringURL = "https://start.spring.io"
 )
 
 var (
@@ -92,6 +96,10 @@ type SpringBootForm struct {
 	Dependencies    []string
 	DependencyKinds []string
 	Type            string
+	// Description the description of the generated project
+	Description string
+	// ServerURL the URL of the Spring Initializr to use such as a private one. Defaults to DefaultSpringURL
+	ServerURL string
 }
 
 type errorResponse struct {
@@ -102,10 +110,19 @@ type errorResponse struct {
 	Path      string `json:"path,omitempty"`
 }
 
+// LoadSpringBoot loads the model of the public Spring Initializr
 func LoadSpringBoot(cacheDir string) (*SpringBootModel, error) {
+	return LoadSpringBootFromURL(DefaultSpringURL, cacheDir)
+}
+
+// LoadSpringBootFromURL loads the model of the Spring Initializr at the given URL caching it in the cache directory
+func LoadSpringBootFromURL(serverURL string, cacheDir string) (*SpringBootModel, error) {
+	if serverURL == "" {
+		serverURL = DefaultSpringURL
+	}
 	loader := func() ([]byte, error) {
 		client := http.Client{}
-		req, err := http.NewRequest(http.MethodGet, startSpringURL, nil)
+		req, err := http.NewRequest(http.MethodGet, serverURL, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -116,12 +133,16 @@ func LoadSpringBoot(cacheDir string) (*SpringBootModel, error) {
 		if err != nil {
 			return nil, err
 		}
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to load the Spring Initializr model from %s: %s", serverURL, res.Status)
+		}
 		return ioutil.ReadAll(res.Body)
 	}
 
 	cacheFileName := ""
 	if cacheDir != "" {
-		cacheFileName = filepath.Join(cacheDir, "start_spring_io.json")
+		cacheFileName = filepath.Join(cacheDir, initializrCacheFileName(serverURL))
 	}
 	body, err := util.LoadCacheData(cacheFileName, loader)
 	if err != nil {
@@ -322,6 +343,27 @@ func CreateSpringTreeSelect(message string, name string, tree *SpringTreeSelect,
 	}
 }
 
+// initializrCacheFileName returns the name of the file the model of the Spring Initializr at the URL is cached in
+func initializrCacheFileName(serverURL string) string {
+	if serverURL == DefaultSpringURL {
+		return "start_spring_io.json"
+	}
+	u, err := url.Parse(serverURL)
+	name := serverURL
+	if err == nil && u.Host != "" {
+		name = u.Host + u.Path
+	}
+	name = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+	return "spring_initializr_" + strings.Trim(name, "_") + ".json"
+}
+
+// CreateProject generates the project with the Spring Initializr and unzips it into a directory named after the
+// artifact in the work directory
 func (data *SpringBootForm) CreateProject(workDir string) (string, error) {
 	dirName := data.ArtifactId
 	if dirName == "" {
@@ -338,7 +380,11 @@ func (data *SpringBootForm) CreateProject(workDir string) (string, error) {
 	if parameters != "" {
 		parameters = "?" + parameters
 	}
-	u := "http://start.spring.io/starter.zip" + parameters
+	serverURL := data.ServerURL
+	if serverURL == "" {
+		serverURL = DefaultSpringURL
+	}
+	u := util.UrlJoin(serverURL, "starter.zip") + parameters
 	req, err := http.NewRequest(http.MethodGet, u, strings.NewReader(""))
 	if err != nil {
 		return answer, err
@@ -348,6 +394,7 @@ func (data *SpringBootForm) CreateProject(workDir string) (string, error) {
 	if err != nil {
 		return answer, err
 	}
+	defer res.Body.Close()
 
 	if res.StatusCode == 400 {
 		errorBody, err := ioutil.ReadAll(res.Body)
@@ -361,6 +408,9 @@ func (data *SpringBootForm) CreateProject(workDir string) (string, error) {
 		log.Infof("%s\n", util.ColorError(errorResponse.Message))
 		return answer, errors.New("unable to create spring quickstart")
 	}
+	if res.StatusCode != http.StatusOK {
+		return answer, fmt.Errorf("failed to generate the project with the Spring Initializr %s: %s", serverURL, res.Status)
+	}
 
 	body, err := ioutil.ReadAll(res.Body)
 	if err != nil {
@@ -393,6 +443,8 @@ func (data *SpringBootForm) AddFormValues(form *url.Values) {
 	AddFormValue(form, "artifactId", data.ArtifactId)
 	AddFormValue(form, "version", data.Version)
 	AddFormValue(form, "name", data.Name)
+	AddFormValue(form, "packageName", data.PackageName)
+	AddFormValue(form, "description", data.Description)
 	AddFormValue(form, "type", data.Type)
 	AddFormValues(form, "dependencies", data.Dependencies)
 }
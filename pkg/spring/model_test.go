@@ -0,0 +1,75 @@
+package spring_test
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/spring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateProjectFromServerURL(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/starter.zip" {
+			http.NotFound(w, r)
+			return
+		}
+		assert.Equal(t, "com.example", r.URL.Query().Get("groupId"))
+		assert.Equal(t, "com.example.demo", r.URL.Query().Get("packageName"))
+		assert.Equal(t, "My demo", r.URL.Query().Get("description"))
+		assert.Equal(t, []string{"actuator", "web"}, r.URL.Query()["dependencies"])
+
+		zw := zip.NewWriter(w)
+		f, err := zw.Create("pom.xml")
+		require.NoError(t, err)
+		_, err = f.Write([]byte("<project/>"))
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "test-spring-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	form := &spring.SpringBootForm{
+		GroupId:      "com.example",
+		ArtifactId:   "demo",
+		PackageName:  "com.example.demo",
+		Description:  "My demo",
+		Dependencies: []string{"actuator", "web"},
+		ServerURL:    server.URL,
+	}
+	outDir, err := form.CreateProject(dir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "demo"), outDir)
+	assert.FileExists(t, filepath.Join(outDir, "pom.xml"))
+}
+
+func TestCreateProjectServerError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "test-spring-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	form := &spring.SpringBootForm{
+		ArtifactId: "demo",
+		ServerURL:  server.URL,
+	}
+	_, err = form.CreateProject(dir)
+	assert.Error(t, err)
+}
@@ -1,19 +1,62 @@
 package table
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"unicode/utf8"
 
+	"github.com/ghodss/yaml"
 	"github.com/jenkins-x/jx/pkg/util"
 )
 
+const (
+	// FormatTable renders the rows as text columns
+	FormatTable = "table"
+	// FormatWide renders the rows as text columns including the wide columns
+	FormatWide = "wide"
+	// FormatJSON renders the rows as a JSON list of objects keyed by the column headers
+	FormatJSON = "json"
+	// FormatYAML renders the rows as a YAML list of objects keyed by the column headers
+	FormatYAML = "yaml"
+	// FormatCustomColumnsPrefix the prefix of the format which renders only the given comma separated columns
+	FormatCustomColumnsPrefix = "custom-columns="
+)
+
+// Formats the output formats a table supports
+var Formats = []string{FormatTable, FormatWide, FormatJSON, FormatYAML, FormatCustomColumnsPrefix + "NAME,..."}
+
+var ansiCodes = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// Table renders rows of columns where the first row is the header of the columns
 type Table struct {
 	Out          io.Writer
 	Rows         [][]string
 	ColumnWidths []int
 	ColumnAlign  []int
 	Separator    string
+
+	// Format the output format which is one of Formats. Defaults to FormatTable
+	Format string
+	// NoHeaders omits the header row from the text formats
+	NoHeaders bool
+	// SortBy the header of the column the rows are sorted by
+	SortBy string
+	// WideColumns the headers of the columns which are only rendered in the wide format
+	WideColumns []string
+}
+
+// IsValidFormat returns true if the format is supported by tables
+func IsValidFormat(format string) bool {
+	switch format {
+	case "", FormatTable, FormatWide, FormatJSON, FormatYAML:
+		return true
+	}
+	return strings.HasPrefix(format, FormatCustomColumnsPrefix) && len(format) > len(FormatCustomColumnsPrefix)
 }
 
 func CreateTable(out io.Writer) Table {
@@ -34,8 +77,17 @@ func (t *Table) AddRow(col ...string) {
 }
 
 func (t *Table) Render() {
+	rows, columns := t.outputRows()
+	if t.Format == FormatJSON || t.Format == FormatYAML {
+		t.renderRecords(rows)
+		return
+	}
+	if t.NoHeaders && len(rows) > 0 {
+		rows = rows[1:]
+	}
+
 	// lets figure out the max widths of each column
-	for _, row := range t.Rows {
+	for _, row := range rows {
 		for ci, col := range row {
 			l := utf8.RuneCountInString(col)
 			t.ColumnWidths = ensureArrayCanContain(t.ColumnWidths, ci)
@@ -46,7 +98,7 @@ func (t *Table) Render() {
 	}
 
 	out := t.Out
-	for _, row := range t.Rows {
+	for _, row := range rows {
 		lastColumn := len(row) - 1
 		for ci, col := range row {
 			if ci > 0 {
@@ -54,6 +106,12 @@ func (t *Table) Render() {
 			}
 			l := t.ColumnWidths[ci]
 			align := t.GetColumnAlign(ci)
+			if columns != nil {
+				align = util.ALIGN_LEFT
+				if columns[ci] >= 0 {
+					align = t.GetColumnAlign(columns[ci])
+				}
+			}
 			if ci >= lastColumn && align != util.ALIGN_CENTER && align != util.ALIGN_RIGHT {
 				fmt.Fprint(out, col)
 			} else {
@@ -88,3 +146,110 @@ func ensureArrayCanContain(array []int, idx int) []int {
 	}
 	return array
 }
+
+// outputRows returns the header and rows to render after sorting and selecting the columns of the format along
+// with the index of each selected column in the original rows. The indexes are nil if all the columns are rendered
+func (t *Table) outputRows() ([][]string, []int) {
+	if len(t.Rows) == 0 {
+		return t.Rows, nil
+	}
+	header := t.Rows[0]
+	body := t.Rows[1:]
+	if t.SortBy != "" {
+		column := columnIndex(header, t.SortBy)
+		if column >= 0 {
+			body = append([][]string{}, body...)
+			sort.SliceStable(body, func(i, j int) bool {
+				return lessValue(cell(body[i], column), cell(body[j], column))
+			})
+		}
+	}
+
+	names := []string{}
+	if strings.HasPrefix(t.Format, FormatCustomColumnsPrefix) {
+		for _, name := range strings.Split(strings.TrimPrefix(t.Format, FormatCustomColumnsPrefix), ",") {
+			name = strings.TrimSpace(name)
+			if name != "" {
+				names = append(names, strings.ToUpper(name))
+			}
+		}
+	} else if len(t.WideColumns) > 0 && t.Format != FormatWide {
+		for _, name := range header {
+			if columnIndex(t.WideColumns, name) < 0 {
+				names = append(names, name)
+			}
+		}
+	} else {
+		return append([][]string{header}, body...), nil
+	}
+
+	indexes := []int{}
+	for _, name := range names {
+		indexes = append(indexes, columnIndex(header, name))
+	}
+	answer := [][]string{names}
+	for _, row := range body {
+		selected := []string{}
+		for _, i := range indexes {
+			selected = append(selected, cell(row, i))
+		}
+		answer = append(answer, selected)
+	}
+	return answer, indexes
+}
+
+// renderRecords renders the rows as a list of objects keyed by the headers without any colours
+func (t *Table) renderRecords(rows [][]string) {
+	records := []map[string]string{}
+	if len(rows) > 0 {
+		header := rows[0]
+		for _, row := range rows[1:] {
+			record := map[string]string{}
+			for i, name := range header {
+				record[name] = ansiCodes.ReplaceAllString(cell(row, i), "")
+			}
+			records = append(records, record)
+		}
+	}
+	var data []byte
+	var err error
+	if t.Format == FormatJSON {
+		data, err = json.MarshalIndent(records, "", "  ")
+		data = append(data, '\n')
+	} else {
+		data, err = yaml.Marshal(records)
+	}
+	if err != nil {
+		fmt.Fprintf(t.Out, "failed to render the table as %s: %s\n", t.Format, err)
+		return
+	}
+	t.Out.Write(data)
+}
+
+func columnIndex(header []string, name string) int {
+	for i, h := range header {
+		if strings.EqualFold(h, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func cell(row []string, i int) string {
+	if i < 0 || i >= len(row) {
+		return ""
+	}
+	return row[i]
+}
+
+// lessValue compares the values numerically if they are both numbers otherwise as text
+func lessValue(a string, b string) bool {
+	a = ansiCodes.ReplaceAllString(a, "")
+	b = ansiCodes.ReplaceAllString(b, "")
+	na, errA := strconv.ParseFloat(a, 64)
+	nb, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		return na < nb
+	}
+	return a < b
+}
@@ -0,0 +1,94 @@
+package table_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/table"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func createEnvTable(out *bytes.Buffer) table.Table {
+	t := table.CreateTable(out)
+	t.AddRow("NAME", "ORDER", "SOURCE")
+	t.AddRow("staging", "100", "https://github.com/myorg/staging.git")
+	t.AddRow("production", "200", "https://github.com/myorg/production.git")
+	t.AddRow("dev", "0", "https://github.com/myorg/dev.git")
+	return t
+}
+
+func TestRenderTable(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	tbl := createEnvTable(&out)
+	tbl.Render()
+	assert.Equal(t, `NAME       ORDER SOURCE
+staging    100   https://github.com/myorg/staging.git
+production 200   https://github.com/myorg/production.git
+dev        0     https://github.com/myorg/dev.git
+`, out.String())
+}
+
+func TestRenderTableSortedWithoutHeaders(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	tbl := createEnvTable(&out)
+	tbl.SortBy = "order"
+	tbl.NoHeaders = true
+	tbl.WideColumns = []string{"SOURCE"}
+	tbl.Render()
+	assert.Equal(t, `dev        0
+staging    100
+production 200
+`, out.String())
+}
+
+func TestRenderTableWide(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	tbl := createEnvTable(&out)
+	tbl.Format = table.FormatWide
+	tbl.WideColumns = []string{"SOURCE"}
+	tbl.SortBy = "NAME"
+	tbl.Render()
+	assert.Equal(t, `NAME       ORDER SOURCE
+dev        0     https://github.com/myorg/dev.git
+production 200   https://github.com/myorg/production.git
+staging    100   https://github.com/myorg/staging.git
+`, out.String())
+}
+
+func TestRenderTableCustomColumns(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	tbl := createEnvTable(&out)
+	tbl.Format = "custom-columns=source,name"
+	tbl.Render()
+	assert.Equal(t, `SOURCE                                  NAME
+https://github.com/myorg/staging.git    staging
+https://github.com/myorg/production.git production
+https://github.com/myorg/dev.git        dev
+`, out.String())
+}
+
+func TestRenderTableJSON(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	tbl := table.CreateTable(&out)
+	tbl.Format = table.FormatJSON
+	tbl.AddRow("NAME", "STATUS")
+	tbl.AddRow("myapp", util.ColorInfo("Running"))
+	tbl.Render()
+	assert.JSONEq(t, `[{"NAME": "myapp", "STATUS": "Running"}]`, out.String())
+}
+
+func TestIsValidFormat(t *testing.T) {
+	t.Parallel()
+	for _, format := range []string{"", "table", "wide", "json", "yaml", "custom-columns=NAME"} {
+		assert.True(t, table.IsValidFormat(format), format)
+	}
+	for _, format := range []string{"xml", "custom-columns="} {
+		assert.False(t, table.IsValidFormat(format), format)
+	}
+}
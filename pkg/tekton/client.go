@@ -0,0 +1,153 @@
+package tekton
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	taskResource        = schema.GroupVersionResource{Group: "tekton.dev", Version: "v1alpha1", Resource: "tasks"}
+	pipelineResource    = schema.GroupVersionResource{Group: "tekton.dev", Version: "v1alpha1", Resource: "pipelines"}
+	pipelineRunResource = schema.GroupVersionResource{Group: "tekton.dev", Version: "v1alpha1", Resource: "pipelineruns"}
+)
+
+// ApplyPipelineResources creates the workspace volume claim, tasks and pipeline then triggers the pipeline run
+func ApplyPipelineResources(kubeClient kubernetes.Interface, dynamicClient dynamic.Interface, ns string, resources *PipelineResources) error {
+	_, err := kubeClient.CoreV1().PersistentVolumeClaims(ns).Create(resources.Workspace)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "failed to create the workspace PersistentVolumeClaim %s", resources.Workspace.Name)
+	}
+	for _, task := range resources.Tasks {
+		err = createOrUpdate(dynamicClient.Resource(taskResource).Namespace(ns), task)
+		if err != nil {
+			return errors.Wrapf(err, "failed to apply the Task %s", task.Name)
+		}
+	}
+	err = createOrUpdate(dynamicClient.Resource(pipelineResource).Namespace(ns), resources.Pipeline)
+	if err != nil {
+		return errors.Wrapf(err, "failed to apply the Pipeline %s", resources.Pipeline.Name)
+	}
+	u, err := toUnstructured(resources.PipelineRun)
+	if err != nil {
+		return err
+	}
+	_, err = dynamicClient.Resource(pipelineRunResource).Namespace(ns).Create(u)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create the PipelineRun %s", resources.PipelineRun.Name)
+	}
+	return nil
+}
+
+func createOrUpdate(client dynamic.ResourceInterface, obj interface{}) error {
+	u, err := toUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	existing, err := client.Get(u.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = client.Create(u)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	u.SetResourceVersion(existing.GetResourceVersion())
+	_, err = client.Update(u)
+	return err
+}
+
+func toUnstructured(obj interface{}) (*unstructured.Unstructured, error) {
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert to unstructured")
+	}
+	return &unstructured.Unstructured{Object: data}, nil
+}
+
+// GarbageCollectWorkspaces deletes the workspace volume claims of the pipeline except for the given number of the most
+// recent builds
+func GarbageCollectWorkspaces(kubeClient kubernetes.Interface, ns string, pipelineName string, keep int) error {
+	list, err := kubeClient.CoreV1().PersistentVolumeClaims(ns).List(metav1.ListOptions{
+		LabelSelector: LabelPipeline + "=" + pipelineName,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the workspaces of pipeline %s", pipelineName)
+	}
+	claims := list.Items
+	if len(claims) <= keep {
+		return nil
+	}
+	sort.Slice(claims, func(i, j int) bool {
+		return claims[j].CreationTimestamp.Before(&claims[i].CreationTimestamp)
+	})
+	for _, claim := range claims[keep:] {
+		err = kubeClient.CoreV1().PersistentVolumeClaims(ns).Delete(claim.Name, &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete the workspace %s", claim.Name)
+		}
+		log.Infof("Deleted the workspace %s of an old build\n", claim.Name)
+	}
+	return nil
+}
+
+// GarbageCollectPipelines deletes the PipelineRuns, Pipelines and Tasks of the pipeline except for those of the given
+// number of the most recent builds
+func GarbageCollectPipelines(dynamicClient dynamic.Interface, ns string, pipelineName string, keep int) error {
+	selector := metav1.ListOptions{
+		LabelSelector: LabelPipeline + "=" + pipelineName,
+	}
+	runs, err := dynamicClient.Resource(pipelineRunResource).Namespace(ns).List(selector)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the PipelineRuns of pipeline %s", pipelineName)
+	}
+	builds := []int{}
+	for _, run := range runs.Items {
+		build, err := strconv.Atoi(run.GetLabels()[LabelBuild])
+		if err == nil {
+			builds = append(builds, build)
+		}
+	}
+	if len(builds) <= keep {
+		return nil
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(builds)))
+	kept := map[string]bool{}
+	for _, build := range builds[:keep] {
+		kept[strconv.Itoa(build)] = true
+	}
+	for _, resource := range []schema.GroupVersionResource{pipelineRunResource, pipelineResource, taskResource} {
+		client := dynamicClient.Resource(resource).Namespace(ns)
+		list, err := client.List(selector)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list the %s of pipeline %s", resource.Resource, pipelineName)
+		}
+		for _, item := range list.Items {
+			if kept[item.GetLabels()[LabelBuild]] {
+				continue
+			}
+			err = client.Delete(item.GetName(), &metav1.DeleteOptions{})
+			if err != nil && !apierrors.IsNotFound(err) {
+				return errors.Wrapf(err, "failed to delete %s %s", resource.Resource, item.GetName())
+			}
+		}
+	}
+	log.Infof("Deleted the Tekton resources of %d old builds of pipeline %s\n", len(builds)-keep, pipelineName)
+	return nil
+}
+
+// RetryPipelineRun runs the pipeline of the PipelineRun again after one of its pods was evicted, returning the name of
+// the new PipelineRun or an empty string if it was already retried maxRetries times
+func RetryPipelineRun(dynamicClient dynamic.Interface, ns string, name string, maxRetries int) (string, error) {
+	return kube.RetryEvictedResource(dynamicClient.Resource(pipelineRunResource).Namespace(ns), name, maxRetries)
+}
@@ -0,0 +1,350 @@
+package tekton
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jenkinsfile/syntax"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LabelPipeline the label on the generated resources for the name of the pipeline
+	LabelPipeline = "jenkins.io/pipeline"
+	// LabelBuild the label on the generated resources for the build number
+	LabelBuild = "jenkins.io/build"
+	// LabelLastTask the label on the pipeline run for the name of the last task of the pipeline which Tekton copies
+	// onto the pods of the tasks so that we know when the pipeline completes
+	LabelLastTask = "jenkins.io/last-task"
+
+	// WorkspaceVolumeName the name of the volume of the workspace shared by the tasks of a pipeline run
+	WorkspaceVolumeName = "workspace"
+	// WorkspaceMountPath the directory the workspace volume is mounted in each step
+	WorkspaceMountPath = "/workspace"
+	// SourceDir the directory within the workspace the source code is cloned into
+	SourceDir = "source"
+
+	// DefaultWorkspaceSize the default size of the workspace volume claim
+	DefaultWorkspaceSize = "5Gi"
+
+	// DefaultGitCloneImage the default image of the step which clones the source into the workspace
+	DefaultGitCloneImage = "alpine/git:1.0.4"
+)
+
+// PipelineResources the Tekton resources and workspace volume claim which run a pipeline
+type PipelineResources struct {
+	Workspace   *corev1.PersistentVolumeClaim
+	Tasks       []*Task
+	Pipeline    *Pipeline
+	PipelineRun *PipelineRun
+}
+
+// CreatePipelineArguments the details of the build used to translate a pipeline into Tekton resources
+type CreatePipelineArguments struct {
+	// Name the name of the pipeline such as myorg-myrepo-master
+	Name           string
+	Build          string
+	GitURL         string
+	Revision       string
+	Env            []corev1.EnvVar
+	ServiceAccount string
+	WorkspaceSize  string
+	// PodTemplates the Jenkins pod templates indexed by container name which define the images of the steps
+	PodTemplates map[string]*corev1.Pod
+	// Tolerations and Affinity control which nodes the pods of the tasks are scheduled on
+	Tolerations []corev1.Toleration
+	Affinity    *corev1.Affinity
+	// Secrets the pipeline secrets which are injected into the steps
+	Secrets []corev1.Secret
+	// GitSecret the pipeline git credentials secret used to clone the source or nil for public repositories
+	GitSecret *corev1.Secret
+	// GitCloneImage the image of the step which clones the source. Defaults to DefaultGitCloneImage
+	GitCloneImage string
+}
+
+type stageTask struct {
+	name  string
+	agent syntax.Agent
+	env   []corev1.EnvVar
+	steps []syntax.Step
+}
+
+// CreatePipelineResources translates the pipeline into a Task per stage run in order by a Pipeline and a PipelineRun.
+// The source is cloned into a workspace volume claim which is mounted into every step so that the tasks share it
+func CreatePipelineResources(pipeline *syntax.ParsedPipeline, args *CreatePipelineArguments) (*PipelineResources, error) {
+	if args.Name == "" {
+		return nil, fmt.Errorf("no pipeline name specified")
+	}
+	if args.Build == "" {
+		return nil, fmt.Errorf("no build number specified")
+	}
+	runName := kube.ToValidName(args.Name + "-" + args.Build)
+	labels := map[string]string{
+		LabelPipeline: kube.ToValidName(args.Name),
+		LabelBuild:    args.Build,
+	}
+
+	workspace, err := createWorkspace(runName, labels, args.WorkspaceSize)
+	if err != nil {
+		return nil, err
+	}
+
+	env := append([]corev1.EnvVar{}, args.Env...)
+	env = append(env, toEnvVars(pipeline.Environment)...)
+	stages := flattenStages(pipeline.Stages, pipeline.Agent, env)
+	if len(pipeline.Post) > 0 {
+		stages = append(stages, stageTask{name: "post", agent: pipeline.Agent, env: env, steps: pipeline.Post})
+	}
+	if len(stages) == 0 {
+		return nil, fmt.Errorf("the pipeline has no stages")
+	}
+
+	answer := &PipelineResources{
+		Workspace: workspace,
+		Pipeline: &Pipeline{
+			TypeMeta:   metav1.TypeMeta{APIVersion: APIVersion, Kind: "Pipeline"},
+			ObjectMeta: metav1.ObjectMeta{Name: runName, Labels: labels},
+		},
+		PipelineRun: &PipelineRun{
+			TypeMeta:   metav1.TypeMeta{APIVersion: APIVersion, Kind: "PipelineRun"},
+			ObjectMeta: metav1.ObjectMeta{Name: runName, Labels: labels},
+			Spec: PipelineRunSpec{
+				PipelineRef:    PipelineRef{Name: runName},
+				Trigger:        Trigger{Type: "manual"},
+				ServiceAccount: args.ServiceAccount,
+				Tolerations:    args.Tolerations,
+				Affinity:       args.Affinity,
+			},
+		},
+	}
+	previous := ""
+	for i, stage := range stages {
+		task, err := createTask(runName, labels, &stage, args, i == 0)
+		if err != nil {
+			return nil, err
+		}
+		answer.Tasks = append(answer.Tasks, task)
+		pipelineTask := PipelineTask{
+			Name:    kube.ToValidName(stage.name),
+			TaskRef: TaskRef{Name: task.Name},
+		}
+		if previous != "" {
+			pipelineTask.RunAfter = []string{previous}
+		}
+		previous = pipelineTask.Name
+		answer.Pipeline.Spec.Tasks = append(answer.Pipeline.Spec.Tasks, pipelineTask)
+	}
+	runLabels := map[string]string{
+		LabelLastTask: previous,
+	}
+	for k, v := range labels {
+		runLabels[k] = v
+	}
+	answer.PipelineRun.Labels = runLabels
+	return answer, nil
+}
+
+// IsLastTaskPod returns true if the pod runs the last task of its pipeline run so that the pipeline completes with it
+func IsLastTaskPod(pod *corev1.Pod) bool {
+	lastTask := pod.Labels[LabelLastTask]
+	return lastTask == "" || pod.Labels[LabelPipelineTaskName] == lastTask
+}
+
+func createWorkspace(name string, labels map[string]string, size string) (*corev1.PersistentVolumeClaim, error) {
+	if size == "" {
+		size = DefaultWorkspaceSize
+	}
+	quantity, err := resource.ParseQuantity(size)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid workspace size %s", size)
+	}
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: quantity,
+				},
+			},
+		},
+	}, nil
+}
+
+// flattenStages returns the stages which have steps in the order they run inheriting the agent and environment
+// of their parent stages
+func flattenStages(stages []syntax.Stage, agent syntax.Agent, env []corev1.EnvVar) []stageTask {
+	answer := []stageTask{}
+	for _, stage := range stages {
+		stageAgent := agent
+		if stage.Agent != nil {
+			if stage.Agent.Label != "" {
+				stageAgent.Label = stage.Agent.Label
+			}
+			if stage.Agent.Container != "" {
+				stageAgent.Container = stage.Agent.Container
+			}
+		}
+		stageEnv := append(append([]corev1.EnvVar{}, env...), toEnvVars(stage.Environment)...)
+		if len(stage.Steps) > 0 {
+			answer = append(answer, stageTask{name: stage.Name, agent: stageAgent, env: stageEnv, steps: stage.Steps})
+		}
+		answer = append(answer, flattenStages(stage.Stages, stageAgent, stageEnv)...)
+	}
+	return answer
+}
+
+func createTask(runName string, labels map[string]string, stage *stageTask, args *CreatePipelineArguments, cloneSource bool) (*Task, error) {
+	task := &Task{
+		TypeMeta:   metav1.TypeMeta{APIVersion: APIVersion, Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{Name: kube.ToValidName(runName + "-" + stage.name), Labels: labels},
+		Spec: TaskSpec{
+			Volumes: []corev1.Volume{
+				{
+					Name: WorkspaceVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: runName},
+					},
+				},
+			},
+		},
+	}
+	sourceDir := filepath.Join(WorkspaceMountPath, SourceDir)
+	if cloneSource {
+		clone, err := gitCloneStep(args)
+		if err != nil {
+			return nil, err
+		}
+		task.Spec.Steps = append(task.Spec.Steps, *clone)
+	}
+	for i, step := range stage.steps {
+		containerName := step.Container
+		if containerName == "" {
+			containerName = stage.agent.Container
+		}
+		if containerName == "" {
+			containerName = stage.agent.Label
+		}
+		container, volumes, err := stepContainer(containerName, args.PodTemplates)
+		if err != nil {
+			return nil, errors.Wrapf(err, "step %d of stage %s", i+1, stage.name)
+		}
+		task.Spec.Volumes = addVolumes(task.Spec.Volumes, volumes)
+		container.Env = append(container.Env, stage.env...)
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: WorkspaceVolumeName, MountPath: WorkspaceMountPath})
+
+		container.Name = fmt.Sprintf("step%d", i+1)
+		container.Command = []string{"/bin/sh", "-c"}
+		container.Args = []string{step.Command}
+		container.WorkingDir = sourceDir
+		if step.Dir != "" {
+			container.WorkingDir = filepath.Join(sourceDir, step.Dir)
+		}
+		task.Spec.Steps = append(task.Spec.Steps, *container)
+	}
+	task.Spec.Volumes = kube.AddPipelineSecrets(task.Spec.Steps, task.Spec.Volumes, args.Secrets)
+	return task, nil
+}
+
+// gitCloneStep returns the step which clones the source into the workspace. The credentials of the git secret are
+// passed to git via a credential helper reading them from the environment so that they are not written to the workspace
+func gitCloneStep(args *CreatePipelineArguments) (*corev1.Container, error) {
+	if args.GitURL == "" {
+		return nil, fmt.Errorf("no git URL specified to clone the source")
+	}
+	image := args.GitCloneImage
+	if image == "" {
+		image = DefaultGitCloneImage
+	}
+	sourceDir := filepath.Join(WorkspaceMountPath, SourceDir)
+	git := "git"
+	step := &corev1.Container{
+		Name:       "git-clone",
+		Image:      image,
+		Command:    []string{"/bin/sh", "-c"},
+		WorkingDir: WorkspaceMountPath,
+		Env:        append([]corev1.EnvVar{}, args.Env...),
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: WorkspaceVolumeName, MountPath: WorkspaceMountPath},
+		},
+	}
+	if args.GitSecret != nil {
+		git = `git -c credential.helper='!f() { echo "username=$GIT_USERNAME"; echo "password=$GIT_PASSWORD"; }; f'`
+		step.Env = append(step.Env, secretEnvVar("GIT_USERNAME", args.GitSecret.Name, kube.SecretDataUsername),
+			secretEnvVar("GIT_PASSWORD", args.GitSecret.Name, kube.SecretDataPassword))
+	}
+	step.Args = []string{fmt.Sprintf("rm -rf %s && %s clone %s %s && cd %s && git checkout %s", sourceDir, git, args.GitURL, sourceDir, sourceDir, revision(args.Revision))}
+	return step, nil
+}
+
+func secretEnvVar(name string, secretName string, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+// stepContainer returns a copy of the container of the pod template for the given container name
+// along with the volumes of the pod template which the container mounts
+func stepContainer(name string, podTemplates map[string]*corev1.Pod) (*corev1.Container, []corev1.Volume, error) {
+	if name == "" {
+		return nil, nil, fmt.Errorf("no container or agent to run in")
+	}
+	pod := podTemplates[name]
+	if pod == nil {
+		// agent labels are of the form jenkins-maven whereas the pod templates are indexed by the container name
+		pod = podTemplates[strings.TrimPrefix(name, "jenkins-")]
+	}
+	if pod == nil || len(pod.Spec.Containers) == 0 {
+		return nil, nil, fmt.Errorf("no pod template for container %s", name)
+	}
+	container := &pod.Spec.Containers[0]
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == name {
+			container = &pod.Spec.Containers[i]
+		}
+	}
+	return container.DeepCopy(), pod.Spec.Volumes, nil
+}
+
+func addVolumes(volumes []corev1.Volume, newVolumes []corev1.Volume) []corev1.Volume {
+	for _, v := range newVolumes {
+		found := false
+		for _, existing := range volumes {
+			if existing.Name == v.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			volumes = append(volumes, v)
+		}
+	}
+	return volumes
+}
+
+func toEnvVars(env []syntax.EnvVar) []corev1.EnvVar {
+	answer := []corev1.EnvVar{}
+	for _, e := range env {
+		answer = append(answer, corev1.EnvVar{Name: e.Name, Value: e.Value})
+	}
+	return answer
+}
+
+func revision(text string) string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "master"
+	}
+	return text
+}
@@ -0,0 +1,225 @@
+package tekton_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/jenkinsfile/syntax"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/tekton"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCreatePipelineResources(t *testing.T) {
+	t.Parallel()
+
+	pipeline := &syntax.ParsedPipeline{
+		Agent:       syntax.Agent{Label: "jenkins-maven"},
+		Environment: []syntax.EnvVar{{Name: "DEPLOY_NAMESPACE", Value: "jx-staging"}},
+		Stages: []syntax.Stage{
+			{
+				Name:  "build",
+				Steps: []syntax.Step{{Command: "mvn clean deploy"}},
+			},
+			{
+				Name:  "release",
+				Agent: &syntax.Agent{Container: "go"},
+				Steps: []syntax.Step{
+					{Command: "make release"},
+					{Command: "jx step helm release", Dir: "charts/myapp", Container: "maven"},
+				},
+			},
+		},
+	}
+	args := &tekton.CreatePipelineArguments{
+		Name:     "myorg-myapp-master",
+		Build:    "3",
+		GitURL:   "https://github.com/myorg/myapp.git",
+		Revision: "master",
+		PodTemplates: map[string]*corev1.Pod{
+			"maven": podTemplate("maven", "jenkinsxio/builder-maven"),
+			"go":    podTemplate("go", "jenkinsxio/builder-go"),
+		},
+	}
+	resources, err := tekton.CreatePipelineResources(pipeline, args)
+	require.NoError(t, err)
+
+	assert.Equal(t, "myorg-myapp-master-3", resources.Workspace.Name)
+	assert.Equal(t, "myorg-myapp-master", resources.Workspace.Labels[tekton.LabelPipeline])
+	assert.Equal(t, "myorg-myapp-master-3", resources.PipelineRun.Spec.PipelineRef.Name)
+	assert.Equal(t, "release", resources.PipelineRun.Labels[tekton.LabelLastTask])
+	assert.Empty(t, resources.Pipeline.Labels[tekton.LabelLastTask])
+
+	require.Len(t, resources.Pipeline.Spec.Tasks, 2)
+	assert.Empty(t, resources.Pipeline.Spec.Tasks[0].RunAfter)
+	assert.Equal(t, []string{"build"}, resources.Pipeline.Spec.Tasks[1].RunAfter)
+
+	require.Len(t, resources.Tasks, 2)
+	buildSteps := resources.Tasks[0].Spec.Steps
+	require.Len(t, buildSteps, 2)
+	assert.Equal(t, "git-clone", buildSteps[0].Name)
+	assert.Equal(t, tekton.DefaultGitCloneImage, buildSteps[0].Image)
+	assert.Equal(t, []string{"rm -rf /workspace/source && git clone https://github.com/myorg/myapp.git /workspace/source && cd /workspace/source && git checkout master"}, buildSteps[0].Args)
+	assert.Equal(t, "jenkinsxio/builder-maven", buildSteps[1].Image)
+	assert.Equal(t, []string{"mvn clean deploy"}, buildSteps[1].Args)
+	assert.Equal(t, "/workspace/source", buildSteps[1].WorkingDir)
+	assert.Contains(t, buildSteps[1].Env, corev1.EnvVar{Name: "DEPLOY_NAMESPACE", Value: "jx-staging"})
+	assert.Equal(t, "myorg-myapp-master-3", resources.Tasks[0].Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+
+	releaseSteps := resources.Tasks[1].Spec.Steps
+	require.Len(t, releaseSteps, 2)
+	assert.Equal(t, "jenkinsxio/builder-go", releaseSteps[0].Image)
+	assert.Equal(t, "jenkinsxio/builder-maven", releaseSteps[1].Image)
+	assert.Equal(t, "/workspace/source/charts/myapp", releaseSteps[1].WorkingDir)
+
+	assert.Empty(t, resources.PipelineRun.Spec.Tolerations)
+
+	toleration := corev1.Toleration{Key: "cloud.google.com/gke-preemptible", Operator: corev1.TolerationOpEqual, Value: "true"}
+	args.Tolerations = []corev1.Toleration{toleration}
+	resources, err = tekton.CreatePipelineResources(pipeline, args)
+	require.NoError(t, err)
+	assert.Equal(t, []corev1.Toleration{toleration}, resources.PipelineRun.Spec.Tolerations)
+
+	delete(args.PodTemplates, "go")
+	_, err = tekton.CreatePipelineResources(pipeline, args)
+	assert.Error(t, err)
+}
+
+func TestCreatePipelineResourcesWithSecrets(t *testing.T) {
+	t.Parallel()
+
+	pipeline := &syntax.ParsedPipeline{
+		Agent: syntax.Agent{Label: "jenkins-maven"},
+		Stages: []syntax.Stage{
+			{
+				Name:  "build",
+				Steps: []syntax.Step{{Command: "npm publish"}},
+			},
+		},
+	}
+	args := &tekton.CreatePipelineArguments{
+		Name:     "myorg-myapp-master",
+		Build:    "1",
+		GitURL:   "https://github.com/myorg/myapp.git",
+		Revision: "master",
+		PodTemplates: map[string]*corev1.Pod{
+			"maven": podTemplate("maven", "jenkinsxio/builder-maven"),
+		},
+		Secrets: []corev1.Secret{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "npm",
+					Labels: map[string]string{kube.LabelPipelineSecret: kube.ValuePipelineSecretEnv},
+				},
+				Data: map[string][]byte{"npm-token": []byte("abc123")},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "signing",
+					Labels: map[string]string{kube.LabelPipelineSecret: kube.ValuePipelineSecretFile},
+				},
+				Data: map[string][]byte{"key.pem": []byte("secret")},
+			},
+		},
+	}
+	resources, err := tekton.CreatePipelineResources(pipeline, args)
+	require.NoError(t, err)
+
+	require.Len(t, resources.Tasks, 1)
+	task := resources.Tasks[0]
+	for _, step := range task.Spec.Steps {
+		assert.Contains(t, step.Env, corev1.EnvVar{
+			Name: "NPM_TOKEN",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "npm"},
+					Key:                  "npm-token",
+				},
+			},
+		}, "step %s", step.Name)
+		assert.Contains(t, step.VolumeMounts, corev1.VolumeMount{
+			Name:      "pipeline-secret-signing",
+			MountPath: kube.PipelineSecretsMountPath + "/signing",
+			ReadOnly:  true,
+		}, "step %s", step.Name)
+	}
+	volume := kube.GetVolume(&task.Spec.Volumes, "pipeline-secret-signing")
+	require.NotNil(t, volume)
+	assert.Equal(t, "signing", volume.Secret.SecretName)
+}
+
+func TestCreatePipelineResourcesWithGitSecret(t *testing.T) {
+	t.Parallel()
+
+	pipeline := &syntax.ParsedPipeline{
+		Agent: syntax.Agent{Label: "jenkins-maven"},
+		Stages: []syntax.Stage{
+			{
+				Name:  "build",
+				Steps: []syntax.Step{{Command: "mvn clean deploy"}},
+			},
+		},
+	}
+	args := &tekton.CreatePipelineArguments{
+		Name:     "myorg-myapp-master",
+		Build:    "1",
+		GitURL:   "https://github.com/myorg/myapp.git",
+		Revision: "master",
+		PodTemplates: map[string]*corev1.Pod{
+			"maven": podTemplate("maven", "jenkinsxio/builder-maven"),
+		},
+		GitSecret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "jx-pipeline-git-github-github"},
+		},
+	}
+	resources, err := tekton.CreatePipelineResources(pipeline, args)
+	require.NoError(t, err)
+
+	require.Len(t, resources.Tasks, 1)
+	clone := resources.Tasks[0].Spec.Steps[0]
+	assert.Equal(t, "git-clone", clone.Name)
+	require.Len(t, clone.Args, 1)
+	assert.Contains(t, clone.Args[0], "credential.helper")
+	assert.Contains(t, clone.Env, corev1.EnvVar{
+		Name: "GIT_PASSWORD",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "jx-pipeline-git-github-github"},
+				Key:                  kube.SecretDataPassword,
+			},
+		},
+	})
+	assert.Contains(t, clone.VolumeMounts, corev1.VolumeMount{Name: tekton.WorkspaceVolumeName, MountPath: tekton.WorkspaceMountPath})
+}
+
+func TestIsLastTaskPod(t *testing.T) {
+	t.Parallel()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				tekton.LabelLastTask:         "release",
+				tekton.LabelPipelineTaskName: "build",
+			},
+		},
+	}
+	assert.False(t, tekton.IsLastTaskPod(pod))
+
+	pod.Labels[tekton.LabelPipelineTaskName] = "release"
+	assert.True(t, tekton.IsLastTaskPod(pod))
+
+	delete(pod.Labels, tekton.LabelLastTask)
+	assert.True(t, tekton.IsLastTaskPod(pod), "pods of pipeline runs without the label should complete the pipeline")
+}
+
+func podTemplate(name string, image string) *corev1.Pod {
+	return &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: name, Image: image},
+			},
+		},
+	}
+}
@@ -0,0 +1,87 @@
+package tekton
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// the subset of the Tekton pipeline v1alpha1 API which jx generates. The resources are applied via the dynamic
+// client so that we do not depend on the Tekton client libraries
+
+const (
+	// APIVersion the API version of the Tekton pipeline resources
+	APIVersion = "tekton.dev/v1alpha1"
+
+	// LabelPipelineRunName the label Tekton adds to the pods of the tasks of a pipeline run
+	LabelPipelineRunName = "tekton.dev/pipelineRun"
+	// LabelTaskName the label Tekton adds to the pod of a task run for the task name
+	LabelTaskName = "tekton.dev/task"
+	// LabelPipelineTaskName the label Tekton adds to the pod of a task run for the name of the task in the pipeline
+	LabelPipelineTaskName = "tekton.dev/pipelineTask"
+)
+
+// Task a sequence of steps run in a single pod
+type Task struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TaskSpec `json:"spec"`
+}
+
+// TaskSpec the steps of a task and the volumes they share
+type TaskSpec struct {
+	Steps   []corev1.Container `json:"steps,omitempty"`
+	Volumes []corev1.Volume    `json:"volumes,omitempty"`
+}
+
+// Pipeline the tasks which make up a pipeline
+type Pipeline struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PipelineSpec `json:"spec"`
+}
+
+// PipelineSpec the ordered tasks of a pipeline
+type PipelineSpec struct {
+	Tasks []PipelineTask `json:"tasks,omitempty"`
+}
+
+// PipelineTask a reference to a task in a pipeline and the tasks it must run after
+type PipelineTask struct {
+	Name     string   `json:"name"`
+	TaskRef  TaskRef  `json:"taskRef"`
+	RunAfter []string `json:"runAfter,omitempty"`
+}
+
+// TaskRef refers to a Task by name
+type TaskRef struct {
+	Name string `json:"name"`
+}
+
+// PipelineRun a single execution of a pipeline
+type PipelineRun struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PipelineRunSpec `json:"spec"`
+}
+
+// PipelineRunSpec the pipeline to run and the service account to run it as
+type PipelineRunSpec struct {
+	PipelineRef    PipelineRef         `json:"pipelineRef"`
+	Trigger        Trigger             `json:"trigger"`
+	ServiceAccount string              `json:"serviceAccount,omitempty"`
+	Tolerations    []corev1.Toleration `json:"tolerations,omitempty"`
+	Affinity       *corev1.Affinity    `json:"affinity,omitempty"`
+}
+
+// PipelineRef refers to a Pipeline by name
+type PipelineRef struct {
+	Name string `json:"name"`
+}
+
+// Trigger describes what triggered a pipeline run
+type Trigger struct {
+	Type string `json:"type"`
+}
@@ -0,0 +1,191 @@
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/version"
+	"github.com/pkg/errors"
+)
+
+const (
+	// URLEnvVar the environment variable which overrides the endpoint the usage metrics are sent to
+	URLEnvVar = "JX_TELEMETRY_URL"
+
+	// DisabledEnvVar the environment variable which disables telemetry when set to true, even if it was enabled via
+	// jx edit config, such as in CI
+	DisabledEnvVar = "JX_NO_TELEMETRY"
+
+	// SendTimeout the maximum time the sending of the usage metrics may delay the exit of a command
+	SendTimeout = 2 * time.Second
+
+	configFileName = "telemetry.yml"
+)
+
+// Config the telemetry configuration of the user which is stored in the `~/.jx/telemetry.yml` file
+type Config struct {
+	// Enabled is true if the user opted in to send anonymous usage metrics
+	Enabled bool `json:"enabled"`
+	// URL the endpoint the metrics are sent to. There is no default endpoint so nothing is sent without one
+	URL string `json:"url,omitempty"`
+}
+
+// Event the anonymous usage metrics of a single command. It must never contain anything identifying the user, the
+// cluster or the projects such as names, arguments or URLs
+type Event struct {
+	// Command the path of the command such as "jx create cluster gke"
+	Command string `json:"command"`
+	// DurationSeconds how long the command ran
+	DurationSeconds float64 `json:"durationSeconds"`
+	// Success is true if the command did not fail
+	Success bool `json:"success"`
+	// Provider the kubernetes provider the command was run for if known such as gke or eks
+	Provider string `json:"provider,omitempty"`
+	// Version the version of jx
+	Version string `json:"version,omitempty"`
+	// OS the operating system jx runs on
+	OS string `json:"os"`
+	// Arch the architecture jx runs on
+	Arch string `json:"arch"`
+}
+
+type commandRun struct {
+	command  string
+	provider string
+	start    time.Time
+}
+
+var (
+	lock    sync.Mutex
+	current *commandRun
+)
+
+// LoadConfig loads the telemetry configuration. Telemetry is disabled if the configuration file does not exist
+func LoadConfig() (*Config, error) {
+	config := &Config{}
+	fileName, err := configFile()
+	if err != nil {
+		return config, err
+	}
+	exists, err := util.FileExists(fileName)
+	if err != nil || !exists {
+		return config, err
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return config, errors.Wrapf(err, "reading %s", fileName)
+	}
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		return config, errors.Wrapf(err, "unmarshalling %s", fileName)
+	}
+	return config, nil
+}
+
+// Save stores the telemetry configuration
+func (c *Config) Save() error {
+	fileName, err := configFile()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, data, util.DefaultWritePermissions)
+}
+
+// EndpointURL returns the URL the metrics are sent to or an empty string if no endpoint is configured
+func (c *Config) EndpointURL() string {
+	if u := os.Getenv(URLEnvVar); u != "" {
+		return u
+	}
+	return c.URL
+}
+
+// IsEnabled returns true if the user opted in to telemetry, an endpoint is configured and telemetry is not disabled
+// via the DisabledEnvVar
+func (c *Config) IsEnabled() bool {
+	return c.Enabled && c.EndpointURL() != "" && strings.ToLower(os.Getenv(DisabledEnvVar)) != "true"
+}
+
+// Start records that the given command started. The provider is the kubernetes provider if it is known
+func Start(command string, provider string) {
+	lock.Lock()
+	defer lock.Unlock()
+	current = &commandRun{
+		command:  command,
+		provider: provider,
+		start:    time.Now(),
+	}
+}
+
+// Finish sends the usage metrics of the started command if the user opted in to telemetry. Failing to send the
+// metrics is only logged at debug level so that telemetry never gets in the way of a command
+func Finish(success bool) {
+	lock.Lock()
+	run := current
+	current = nil
+	lock.Unlock()
+	if run == nil {
+		return
+	}
+	config, err := LoadConfig()
+	if err != nil || !config.IsEnabled() {
+		return
+	}
+	event := NewEvent(run.command, time.Since(run.start), success, run.provider)
+	err = Send(config.EndpointURL(), event)
+	if err != nil && log.IsDebug() {
+		log.Warnf("Failed to send the usage metrics: %s\n", err)
+	}
+}
+
+// NewEvent creates the usage metrics of a command
+func NewEvent(command string, duration time.Duration, success bool, provider string) Event {
+	return Event{
+		Command:         command,
+		DurationSeconds: duration.Round(time.Millisecond).Seconds(),
+		Success:         success,
+		Provider:        provider,
+		Version:         version.GetVersion(),
+		OS:              runtime.GOOS,
+		Arch:            runtime.GOARCH,
+	}
+}
+
+// Send posts the usage metrics to the given URL
+func Send(url string, event Event) error {
+	data, err := json.Marshal(&event)
+	if err != nil {
+		return err
+	}
+	resp, err := util.GetClientWithTimeout(SendTimeout).Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s posting to %s", resp.Status, url)
+	}
+	return nil
+}
+
+func configFile() (string, error) {
+	dir, err := util.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, configFileName), nil
+}
@@ -0,0 +1,92 @@
+package telemetry_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupJXHome points JX_HOME at a new directory returning a function which restores it
+func setupJXHome(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "test-telemetry-")
+	require.NoError(t, err)
+	oldHome := os.Getenv("JX_HOME")
+	os.Setenv("JX_HOME", dir)
+	return func() {
+		os.Setenv("JX_HOME", oldHome)
+		os.RemoveAll(dir)
+	}
+}
+
+func TestTelemetryIsDisabledByDefault(t *testing.T) {
+	defer setupJXHome(t)()
+
+	config, err := telemetry.LoadConfig()
+	require.NoError(t, err)
+	assert.False(t, config.IsEnabled())
+
+	config.Enabled = true
+	require.NoError(t, config.Save())
+	config, err = telemetry.LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "", config.EndpointURL())
+	assert.False(t, config.IsEnabled(), "telemetry should be disabled without an endpoint")
+
+	config.URL = "https://telemetry.acme.com/events"
+	require.NoError(t, config.Save())
+	config, err = telemetry.LoadConfig()
+	require.NoError(t, err)
+	assert.True(t, config.IsEnabled())
+	assert.Equal(t, "https://telemetry.acme.com/events", config.EndpointURL())
+
+	os.Setenv(telemetry.DisabledEnvVar, "true")
+	defer os.Unsetenv(telemetry.DisabledEnvVar)
+	assert.False(t, config.IsEnabled())
+}
+
+func TestFinishSendsEventOnlyWhenEnabled(t *testing.T) {
+	defer setupJXHome(t)()
+
+	events := []telemetry.Event{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event := telemetry.Event{}
+		err := json.NewDecoder(r.Body).Decode(&event)
+		assert.NoError(t, err)
+		events = append(events, event)
+	}))
+	defer server.Close()
+	os.Setenv(telemetry.URLEnvVar, server.URL)
+	defer os.Unsetenv(telemetry.URLEnvVar)
+
+	telemetry.Start("jx get env", "")
+	telemetry.Finish(true)
+	assert.Empty(t, events)
+
+	require.NoError(t, (&telemetry.Config{Enabled: true}).Save())
+	telemetry.Start("jx create cluster gke", "gke")
+	telemetry.Finish(false)
+	// a command is only reported once
+	telemetry.Finish(true)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, "jx create cluster gke", events[0].Command)
+	assert.Equal(t, "gke", events[0].Provider)
+	assert.False(t, events[0].Success)
+}
+
+func TestNewEvent(t *testing.T) {
+	event := telemetry.NewEvent("jx import", 1500*time.Millisecond, true, "eks")
+	assert.Equal(t, "jx import", event.Command)
+	assert.Equal(t, 1.5, event.DurationSeconds)
+	assert.True(t, event.Success)
+	assert.Equal(t, "eks", event.Provider)
+	assert.NotEmpty(t, event.OS)
+}
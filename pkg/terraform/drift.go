@@ -0,0 +1,127 @@
+package terraform
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// planChangesExitCode the exit code of 'terraform plan -detailed-exitcode' when the plan contains changes
+const planChangesExitCode = 2
+
+var (
+	planSummaryRegex = regexp.MustCompile(`Plan: (\d+) to add, (\d+) to change, (\d+) to destroy`)
+
+	// resource lines of terraform 0.11 plans such as '  ~ google_container_node_pool.jx_node_pool'
+	planResourceRegex = regexp.MustCompile(`^\s*(\+|~|-|-/\+|\+/-|<=)\s+([\w\-.\[\]"]+)(\s+\(.+\))?\s*$`)
+
+	// resource lines of terraform 0.12 plans such as '  # google_compute_firewall.ssh will be updated in-place'
+	planResourceCommentRegex = regexp.MustCompile(`^\s*#\s+(\S+)\s+(will be|must be)\s+(.+)$`)
+)
+
+// Drift the changes terraform would make to bring the live cloud resources back in line with the configuration
+type Drift struct {
+	Add     int      `json:"add"`
+	Change  int      `json:"change"`
+	Destroy int      `json:"destroy"`
+	Changes []string `json:"changes,omitempty"`
+	Output  string   `json:"-"`
+}
+
+// HasChanges returns true if the live resources have drifted from the terraform configuration
+func (d *Drift) HasChanges() bool {
+	return d.Add+d.Change+d.Destroy > 0 || len(d.Changes) > 0
+}
+
+// Summary returns a one line summary of the drift
+func (d *Drift) Summary() string {
+	if !d.HasChanges() {
+		return "No drift detected"
+	}
+	return fmt.Sprintf("%d to add, %d to change, %d to destroy", d.Add, d.Change, d.Destroy)
+}
+
+// PlanDrift runs 'terraform plan -detailed-exitcode' against the state of the configuration in the given directory
+// and returns the drift between the state and the live cloud resources
+func PlanDrift(terraformDir string, terraformVars string, serviceAccountPath string) (*Drift, error) {
+	args := []string{"plan", "-detailed-exitcode", "-input=false", "-no-color"}
+	if terraformVars != "" {
+		args = append(args, fmt.Sprintf("-var-file=%s", terraformVars))
+	}
+	if serviceAccountPath != "" {
+		args = append(args, "-var", fmt.Sprintf("credentials=%s", serviceAccountPath))
+	}
+	cmd := util.Command{
+		Name: "terraform",
+		Args: append(args, terraformDir),
+	}
+	out, err := cmd.RunWithoutRetry()
+	if err != nil {
+		if exitCode(err) != planChangesExitCode {
+			return nil, err
+		}
+		drift := ParseDrift(out)
+		if !drift.HasChanges() {
+			// lets make sure we report drift even if the plan output could not be parsed
+			drift.Change = 1
+		}
+		return drift, nil
+	}
+	return &Drift{Output: out}, nil
+}
+
+// ParseDrift parses the resource changes and the summary of the output of 'terraform plan'
+func ParseDrift(output string) *Drift {
+	drift := &Drift{
+		Output: output,
+	}
+	for _, line := range strings.Split(output, "\n") {
+		matches := planSummaryRegex.FindStringSubmatch(line)
+		if len(matches) == 4 {
+			drift.Add, _ = strconv.Atoi(matches[1])
+			drift.Change, _ = strconv.Atoi(matches[2])
+			drift.Destroy, _ = strconv.Atoi(matches[3])
+			continue
+		}
+		matches = planResourceCommentRegex.FindStringSubmatch(line)
+		if len(matches) == 4 {
+			drift.Changes = append(drift.Changes, fmt.Sprintf("%s %s %s", matches[1], matches[2], matches[3]))
+			continue
+		}
+		matches = planResourceRegex.FindStringSubmatch(line)
+		if len(matches) == 4 {
+			drift.Changes = append(drift.Changes, fmt.Sprintf("%s %s", matches[2], planActionDescription(matches[1])))
+		}
+	}
+	return drift
+}
+
+func planActionDescription(symbol string) string {
+	switch symbol {
+	case "+":
+		return "will be created"
+	case "-":
+		return "will be destroyed"
+	case "~":
+		return "will be updated in-place"
+	case "<=":
+		return "will be read"
+	default:
+		return "must be replaced"
+	}
+}
+
+func exitCode(err error) int {
+	if exitErr, ok := errors.Cause(err).(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			return status.ExitStatus()
+		}
+	}
+	return -1
+}
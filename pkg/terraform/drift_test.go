@@ -0,0 +1,58 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDrift(t *testing.T) {
+	t.Parallel()
+
+	v0_11 := `Refreshing Terraform state in-memory prior to plan...
+
+An execution plan has been generated and is shown below.
+Resource actions are indicated with the following symbols:
+  ~ update in-place
+-/+ destroy and then create replacement
+
+Terraform will perform the following actions:
+
+  ~ google_container_node_pool.jx_node_pool
+      node_count:         "5" => "3"
+
+-/+ google_compute_firewall.ssh (new resource required)
+      id:                 "ssh" => <computed> (forces new resource)
+
+Plan: 1 to add, 1 to change, 1 to destroy.`
+
+	drift := ParseDrift(v0_11)
+	assert.True(t, drift.HasChanges())
+	assert.Equal(t, 1, drift.Add)
+	assert.Equal(t, 1, drift.Change)
+	assert.Equal(t, 1, drift.Destroy)
+	assert.Equal(t, []string{
+		"google_container_node_pool.jx_node_pool will be updated in-place",
+		"google_compute_firewall.ssh must be replaced",
+	}, drift.Changes)
+	assert.Equal(t, "1 to add, 1 to change, 1 to destroy", drift.Summary())
+
+	v0_12 := `Terraform will perform the following actions:
+
+  # google_compute_firewall.ssh will be updated in-place
+  ~ resource "google_compute_firewall" "ssh" {
+      ~ source_ranges = [
+          - "0.0.0.0/0",
+        ]
+    }
+
+Plan: 0 to add, 1 to change, 0 to destroy.`
+
+	drift = ParseDrift(v0_12)
+	assert.Equal(t, 1, drift.Change)
+	assert.Equal(t, []string{"google_compute_firewall.ssh will be updated in-place"}, drift.Changes)
+
+	drift = ParseDrift("No changes. Infrastructure is up-to-date.")
+	assert.False(t, drift.HasChanges())
+	assert.Equal(t, "No drift detected", drift.Summary())
+}
@@ -71,6 +71,29 @@ func Apply(terraformDir string, terraformVars string, serviceAccountPath string,
 	return nil
 }
 
+// Destroy destroys all the resources of the terraform configuration in the given directory
+func Destroy(terraformDir string, terraformVars string, serviceAccountPath string, stdout io.Writer, stderr io.Writer) error {
+	fmt.Println("Destroying Terraform resources")
+	args := []string{"destroy", "-auto-approve"}
+	if terraformVars != "" {
+		args = append(args, fmt.Sprintf("-var-file=%s", terraformVars))
+	}
+	if serviceAccountPath != "" {
+		args = append(args, "-var", fmt.Sprintf("credentials=%s", serviceAccountPath))
+	}
+	cmd := util.Command{
+		Name: "terraform",
+		Args: append(args, terraformDir),
+		Out:  stdout,
+		Err:  stderr,
+	}
+	_, err := cmd.RunWithoutRetry()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func WriteKeyValueToFileIfNotExists(path string, key string, value string) error {
 	// file exists
 	if _, err := os.Stat(path); err == nil {
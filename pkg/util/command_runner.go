@@ -0,0 +1,188 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CommandSpec the details of an external command to be run by a CommandRunner
+type CommandSpec struct {
+	Name string
+	Args []string
+	Dir  string
+	Env  map[string]string
+	In   io.Reader
+	// Out the writer of the standard output of the command. If nil the combined output is returned instead
+	Out io.Writer
+	Err io.Writer
+	// Timeout the maximum duration of each attempt to run the command. No timeout if zero
+	Timeout time.Duration
+	// Attempts the number of times the command is run until it succeeds. Defaults to 1
+	Attempts int
+	// RetryDelay the duration to wait between attempts
+	RetryDelay time.Duration
+}
+
+// String returns the command line of the command with any secrets redacted
+func (c *CommandSpec) String() string {
+	return strings.TrimSpace(c.Name + " " + strings.Join(RedactArgs(c.Args), " "))
+}
+
+// CommandRunner runs external commands so that they can be recorded and faked in tests
+type CommandRunner interface {
+	// Run runs the command returning its trimmed output if the command has no output writer
+	Run(ctx context.Context, cmd *CommandSpec) (string, error)
+}
+
+// DefaultCommandRunner runs the commands as processes
+type DefaultCommandRunner struct {
+}
+
+// NewCommandRunner creates the CommandRunner which runs commands as processes
+func NewCommandRunner() CommandRunner {
+	return &DefaultCommandRunner{}
+}
+
+// Run runs the command retrying on failure if more than one attempt is configured. The command is interrupted if the
+// context is cancelled or the timeout of an attempt is exceeded and killed if it does not stop in time
+func (r *DefaultCommandRunner) Run(ctx context.Context, cmd *CommandSpec) (string, error) {
+	attempts := cmd.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	var text string
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return text, errors.Wrapf(ctx.Err(), "cancelled '%s'", cmd.String())
+			case <-time.After(cmd.RetryDelay):
+			}
+		}
+		text, err = r.run(ctx, cmd)
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+	}
+	return text, err
+}
+
+func (r *DefaultCommandRunner) run(ctx context.Context, cmd *CommandSpec) (string, error) {
+	if cmd.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cmd.Timeout)
+		defer cancel()
+	}
+	os.Setenv("PATH", PathWithBinary(cmd.Dir))
+	e := exec.Command(cmd.Name, cmd.Args...)
+	e.Dir = cmd.Dir
+	e.Stdin = cmd.In
+	if len(cmd.Env) > 0 {
+		e.Env = os.Environ()
+		for k, v := range cmd.Env {
+			e.Env = append(e.Env, k+"="+v)
+			if IsSecretName(k) {
+				RegisterSecret(v)
+			}
+		}
+	}
+
+	start := time.Now()
+	var text string
+	var err error
+	if cmd.Out != nil {
+		e.Stdout = cmd.Out
+		e.Stderr = cmd.Err
+		err = runProcess(ctx, e)
+	} else {
+		var output bytes.Buffer
+		e.Stdout = &output
+		e.Stderr = &output
+		err = runProcess(ctx, e)
+		text = strings.TrimSpace(output.String())
+	}
+	LogCommand(cmd.Name, cmd.Args, cmd.Dir, start, err)
+	if err != nil {
+		if IsInterrupted(err) {
+			return text, errors.Wrapf(err, "interrupted '%s' command in directory '%s'", cmd.String(), cmd.Dir)
+		}
+		if ctx.Err() == context.DeadlineExceeded {
+			return text, errors.Errorf("timed out after %s running '%s' command in directory '%s'", cmd.Timeout.String(), cmd.String(), cmd.Dir)
+		}
+		return text, errors.Wrapf(err, "failed to run '%s' command in directory '%s', output: '%s'", cmd.String(), cmd.Dir, RedactSecrets(text))
+	}
+	return text, nil
+}
+
+// FakeCommandRunner a CommandRunner for tests which records the commands rather than running them
+type FakeCommandRunner struct {
+	// Commands the commands which have been run
+	Commands []*CommandSpec
+	// Outputs the output of the commands whose command line starts with the key
+	Outputs map[string]string
+	// Errors the errors of the commands whose command line starts with the key
+	Errors map[string]error
+
+	lock sync.Mutex
+}
+
+// NewFakeCommandRunner creates a new FakeCommandRunner
+func NewFakeCommandRunner() *FakeCommandRunner {
+	return &FakeCommandRunner{
+		Outputs: map[string]string{},
+		Errors:  map[string]error{},
+	}
+}
+
+// Run records the command returning the output and error registered for its command line
+func (r *FakeCommandRunner) Run(ctx context.Context, cmd *CommandSpec) (string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.Commands = append(r.Commands, cmd)
+
+	line := strings.TrimSpace(cmd.Name + " " + strings.Join(cmd.Args, " "))
+	output := ""
+	match := -1
+	for prefix, value := range r.Outputs {
+		if strings.HasPrefix(line, prefix) && len(prefix) > match {
+			output = value
+			match = len(prefix)
+		}
+	}
+	var err error
+	match = -1
+	for prefix, value := range r.Errors {
+		if strings.HasPrefix(line, prefix) && len(prefix) > match {
+			err = value
+			match = len(prefix)
+		}
+	}
+	if cmd.Out != nil {
+		_, writeErr := io.Copy(cmd.Out, bytes.NewBufferString(output))
+		if writeErr != nil {
+			return "", writeErr
+		}
+		return "", err
+	}
+	return output, err
+}
+
+// CommandLines returns the command lines of the commands which have been run
+func (r *FakeCommandRunner) CommandLines() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	answer := []string{}
+	for _, cmd := range r.Commands {
+		answer = append(answer, strings.TrimSpace(cmd.Name+" "+strings.Join(cmd.Args, " ")))
+	}
+	return answer
+}
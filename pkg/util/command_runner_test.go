@@ -0,0 +1,100 @@
+package util_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandRunnerOutput(t *testing.T) {
+	t.Parallel()
+	runner := util.NewCommandRunner()
+	output, err := runner.Run(context.Background(), &util.CommandSpec{
+		Name: "echo",
+		Args: []string{"hello", "world"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", output)
+
+	out := &bytes.Buffer{}
+	output, err = runner.Run(context.Background(), &util.CommandSpec{
+		Name: "echo",
+		Args: []string{"cheese"},
+		Out:  out,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "", output)
+	assert.Equal(t, "cheese\n", out.String())
+}
+
+func TestCommandRunnerTimeout(t *testing.T) {
+	t.Parallel()
+	runner := util.NewCommandRunner()
+	start := time.Now()
+	_, err := runner.Run(context.Background(), &util.CommandSpec{
+		Name:       "sleep",
+		Args:       []string{"10"},
+		Timeout:    100 * time.Millisecond,
+		Attempts:   2,
+		RetryDelay: 10 * time.Millisecond,
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out")
+	assert.True(t, time.Since(start) < 5*time.Second, "the command should have been killed")
+}
+
+func TestCommandRunnerCancelled(t *testing.T) {
+	t.Parallel()
+	runner := util.NewCommandRunner()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := runner.Run(ctx, &util.CommandSpec{
+		Name: "sleep",
+		Args: []string{"10"},
+	})
+	assert.Error(t, err)
+	assert.True(t, util.IsInterrupted(err), "the error should be an interruption: %s", err)
+}
+
+func TestCommandRunnerKillsCommandIgnoringInterrupt(t *testing.T) {
+	oldGracePeriod := util.InterruptGracePeriod
+	util.InterruptGracePeriod = 200 * time.Millisecond
+	defer func() {
+		util.InterruptGracePeriod = oldGracePeriod
+	}()
+
+	runner := util.NewCommandRunner()
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		cancel()
+	}()
+	start := time.Now()
+	_, err := runner.Run(ctx, &util.CommandSpec{
+		Name: "sh",
+		Args: []string{"-c", "trap '' INT; sleep 10"},
+	})
+	assert.True(t, util.IsInterrupted(err), "the error should be an interruption: %s", err)
+	assert.True(t, time.Since(start) < 5*time.Second, "the command should have been killed")
+}
+
+func TestFakeCommandRunner(t *testing.T) {
+	t.Parallel()
+	runner := util.NewFakeCommandRunner()
+	runner.Outputs["gcloud config get-value"] = "my-project"
+	runner.Errors["gcloud container clusters create"] = errors.New("quota exceeded")
+
+	output, err := runner.Run(context.Background(), &util.CommandSpec{Name: "gcloud", Args: []string{"config", "get-value", "project"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "my-project", output)
+
+	_, err = runner.Run(context.Background(), &util.CommandSpec{Name: "gcloud", Args: []string{"container", "clusters", "create", "cheese"}})
+	assert.EqualError(t, err, "quota exceeded")
+
+	assert.Equal(t, []string{"gcloud config get-value project", "gcloud container clusters create cheese"}, runner.CommandLines())
+}
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"bytes"
 	"io"
 	"os"
 	"os/exec"
@@ -8,6 +9,7 @@ import (
 	"time"
 
 	"github.com/cenkalti/backoff"
+	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/pkg/errors"
 )
 
@@ -123,6 +125,10 @@ func (c *Command) Run() (string, error) {
 		c.attempts++
 		if e != nil {
 			c.Errors = append(c.Errors, e)
+			if IsInterrupted(e) {
+				// stop retrying as jx is being interrupted
+				return nil
+			}
 			return e
 		}
 		return nil
@@ -138,6 +144,9 @@ func (c *Command) Run() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if IsInterrupted(e) {
+		return "", e
+	}
 	return r, nil
 }
 
@@ -171,6 +180,9 @@ func (c *Command) run() (string, error) {
 		}
 		for k, v := range c.Env {
 			m[k] = v
+			if IsSecretName(k) {
+				RegisterSecret(v)
+			}
 		}
 		envVars := []string{}
 		for k, v := range m {
@@ -190,25 +202,50 @@ func (c *Command) run() (string, error) {
 	var text string
 	var err error
 
+	start := time.Now()
 	if c.Out != nil {
-		err := e.Run()
+		err := runProcess(InterruptContext(), e)
+		LogCommand(c.Name, c.Args, c.Dir, start, err)
 		if err != nil {
 			return text, errors.Wrapf(err, "failed to run '%s %s' command in directory '%s', output: '%s'",
-				c.Name, strings.Join(c.Args, " "), c.Dir, text)
+				c.Name, strings.Join(RedactArgs(c.Args), " "), c.Dir, RedactSecrets(text))
 		}
 	} else {
-		data, err := e.CombinedOutput()
-		output := string(data)
-		text = strings.TrimSpace(output)
+		var output bytes.Buffer
+		e.Stdout = &output
+		e.Stderr = &output
+		err := runProcess(InterruptContext(), e)
+		LogCommand(c.Name, c.Args, c.Dir, start, err)
+		text = strings.TrimSpace(output.String())
 		if err != nil {
 			return text, errors.Wrapf(err, "failed to run '%s %s' command in directory '%s', output: '%s'",
-				c.Name, strings.Join(c.Args, " "), c.Dir, text)
+				c.Name, strings.Join(RedactArgs(c.Args), " "), c.Dir, RedactSecrets(text))
 		}
 	}
 
 	return text, err
 }
 
+// LogCommand logs the external command with its arguments, directory and duration if debug logging is enabled.
+// Any secrets in the arguments are masked
+func LogCommand(name string, args []string, dir string, start time.Time, err error) {
+	if !log.IsDebug() {
+		return
+	}
+	fields := map[string]interface{}{
+		"command":  name,
+		"args":     strings.Join(RedactArgs(args), " "),
+		"duration": time.Since(start).String(),
+	}
+	if dir != "" {
+		fields["dir"] = dir
+	}
+	if err != nil {
+		fields["error"] = RedactSecrets(err.Error())
+	}
+	log.WithFields(fields).Debug("ran command")
+}
+
 // PathWithBinary Sets the $PATH variable. Accepts an optional slice of strings containing paths to add to $PATH
 func PathWithBinary(paths ...string) string {
 	path := os.Getenv("PATH")
@@ -3,19 +3,30 @@ package util
 import (
 	"os"
 	"path/filepath"
+	"runtime"
 
 	"github.com/sirupsen/logrus"
 )
 
+// JXBinEnvVar the environment variable used to override the directory binaries are installed into
+const JXBinEnvVar = "JX_BIN"
+
 func HomeDir() string {
-	if h := os.Getenv("HOME"); h != "" {
-		return h
+	return homeDir(runtime.GOOS, os.Getenv)
+}
+
+func homeDir(goos string, getenv func(string) string) string {
+	// on windows HOME may be set to a unix style path by git bash or cygwin so prefer %USERPROFILE%
+	keys := []string{"HOME", "USERPROFILE"}
+	if goos == "windows" {
+		keys = []string{"USERPROFILE", "HOME"}
 	}
-	h := os.Getenv("USERPROFILE") // windows
-	if h == "" {
-		h = "."
+	for _, key := range keys {
+		if h := getenv(key); h != "" {
+			return h
+		}
 	}
-	return h
+	return "."
 }
 
 func DraftDir() (string, error) {
@@ -124,14 +135,24 @@ func LogsDir() (string, error) {
 	return path, nil
 }
 
-// JXBinLocation finds the JX config directory and creates a bin directory inside it if it does not already exist. Returns the JX bin path
+// JXBinOverridden returns true if the directory binaries are installed into was chosen via the $JX_BIN environment
+// variable in which case the binaries are installed into it even if they are already on the PATH
+func JXBinOverridden() bool {
+	return os.Getenv(JXBinEnvVar) != ""
+}
+
+// JXBinLocation finds the JX config directory and creates a bin directory inside it if it does not already exist. Returns the JX bin path.
+// The location can be overridden via the $JX_BIN environment variable
 func JXBinLocation() (string, error) {
-	h, err := ConfigDir()
-	if err != nil {
-		return "", err
+	path := os.Getenv(JXBinEnvVar)
+	if path == "" {
+		h, err := ConfigDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(h, "bin")
 	}
-	path := filepath.Join(h, "bin")
-	err = os.MkdirAll(path, DefaultWritePermissions)
+	err := os.MkdirAll(path, DefaultWritePermissions)
 	if err != nil {
 		return "", err
 	}
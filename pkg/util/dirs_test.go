@@ -43,3 +43,22 @@ func stubFunction(str string, err error) func() (string, error) {
 		return str, err
 	}
 }
+
+func TestHomeDir(t *testing.T) {
+	t.Parallel()
+	env := map[string]string{
+		"HOME":        "/c/Users/jx",
+		"USERPROFILE": `C:\Users\jx`,
+	}
+	getenv := func(key string) string {
+		return env[key]
+	}
+	assert.Equal(t, "/c/Users/jx", homeDir("linux", getenv))
+	assert.Equal(t, `C:\Users\jx`, homeDir("windows", getenv))
+
+	delete(env, "USERPROFILE")
+	assert.Equal(t, "/c/Users/jx", homeDir("windows", getenv))
+
+	delete(env, "HOME")
+	assert.Equal(t, ".", homeDir("windows", getenv))
+}
@@ -93,6 +93,17 @@ func GetLatestReleaseFromGitHub(githubOwner, githubRepo string) (string, error)
 	return "", fmt.Errorf("Unable to find the latest version for github.com/%s/%s", githubOwner, githubRepo)
 }
 
+// GetReleasesFromGitHub gets the most recent releases, including pre-releases, of a specific github repo newest first
+func GetReleasesFromGitHub(githubOwner, githubRepo string) ([]*github.RepositoryRelease, error) {
+	client, _, _, _ := preamble()
+	releases, resp, err := client.Repositories.ListReleases(context.Background(), githubOwner, githubRepo, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get releases for github.com/%s/%s %v", githubOwner, githubRepo, err)
+	}
+	defer resp.Body.Close()
+	return releases, nil
+}
+
 // GetLatestFullTagFromGithub gets the latest 'full' tag from a specific github repo. This (at present) ignores releases
 // with a hyphen in it, usually used with -SNAPSHOT, or -RC1 or -beta
 func GetLatestFullTagFromGithub(githubOwner, githubRepo string) (string, error) {
@@ -1,6 +1,10 @@
 package util
 
-import "os"
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
 
 // GetAndCleanEnviron cleans the provided env variables and returns their current value
 func GetAndCleanEnviron(keys []string) (map[string]string, error) {
@@ -28,3 +32,27 @@ func RestoreEnviron(environ map[string]string) error {
 	}
 	return nil
 }
+
+// EnvVarInstructions returns the shell command to set the environment variable. PowerShell syntax is used on windows
+func EnvVarInstructions(name string, value string) string {
+	return envVarInstructions(runtime.GOOS, name, value)
+}
+
+func envVarInstructions(goos string, name string, value string) string {
+	if goos == "windows" {
+		return fmt.Sprintf("$env:%s = \"%s\"", name, value)
+	}
+	return fmt.Sprintf("export %s=\"%s\"", name, value)
+}
+
+// PathInstructions returns the shell command to add the directory to the PATH. PowerShell syntax is used on windows
+func PathInstructions(dir string) string {
+	return pathInstructions(runtime.GOOS, dir)
+}
+
+func pathInstructions(goos string, dir string) string {
+	if goos == "windows" {
+		return fmt.Sprintf("$env:Path += \";%s\"", dir)
+	}
+	return fmt.Sprintf("export PATH=$PATH:%s", dir)
+}
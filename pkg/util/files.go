@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strconv"
 
 	"github.com/pkg/errors"
@@ -117,6 +118,37 @@ func RenameFile(src string, dst string) (err error) {
 	return nil
 }
 
+// ReplaceExecutable atomically replaces the target executable with the source file which must be on the same file
+// system. A running executable cannot be overwritten on windows so it is renamed to target.deleteme first
+func ReplaceExecutable(source string, target string) error {
+	err := os.Chmod(source, 0755)
+	if err != nil {
+		return err
+	}
+	if runtime.GOOS != "windows" {
+		return os.Rename(source, target)
+	}
+	old := target + ".deleteme"
+	// remove the executable replaced by an earlier upgrade which is no longer running
+	os.Remove(old)
+	exists, err := FileExists(target)
+	if err != nil {
+		return err
+	}
+	if exists {
+		err = os.Rename(target, old)
+		if err != nil {
+			return errors.Wrapf(err, "renaming the running executable %s", target)
+		}
+	}
+	err = os.Rename(source, target)
+	if err != nil && exists {
+		// restore the original executable so that it keeps working
+		os.Rename(old, target)
+	}
+	return err
+}
+
 // credit https://gist.github.com/r0l1/92462b38df26839a3ca324697c8cba04
 func CopyDir(src string, dst string, force bool) (err error) {
 	src = filepath.Clean(src)
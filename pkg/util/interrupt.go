@@ -0,0 +1,121 @@
+package util
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/pkg/errors"
+)
+
+// InterruptExitCode the exit code used when jx is stopped by an interrupt or terminate signal
+const InterruptExitCode = 130
+
+// InterruptGracePeriod the time a running command is given to stop after it has been interrupted before it is killed
+var InterruptGracePeriod = 30 * time.Second
+
+var (
+	interruptOnce    sync.Once
+	interruptContext context.Context
+
+	// runningProcesses the external commands currently running
+	runningProcesses     = map[*exec.Cmd]bool{}
+	runningProcessesLock sync.Mutex
+)
+
+// InterruptContext returns the context which is cancelled when jx receives an interrupt (Ctrl-C) or terminate signal.
+//
+// If no external command is running jx exits straight away. Otherwise the signal is forwarded to the running command
+// so that tools such as terraform and helm can stop cleanly and the caller can record how to resume. A second signal
+// kills the running commands, along with any processes they started, and exits immediately
+func InterruptContext() context.Context {
+	interruptOnce.Do(func() {
+		var cancel context.CancelFunc
+		interruptContext, cancel = context.WithCancel(context.Background())
+		signals := make(chan os.Signal, 2)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-signals
+			cancel()
+			if runningProcessCount() == 0 {
+				os.Exit(InterruptExitCode)
+			}
+			log.Warn("\nInterrupted, waiting for the running command to stop. Press Ctrl-C again to exit immediately\n")
+			<-signals
+			killRunningProcesses()
+			os.Exit(InterruptExitCode)
+		}()
+	})
+	return interruptContext
+}
+
+// IsInterrupted returns true if the error was caused by the cancellation of a context such as the InterruptContext
+func IsInterrupted(err error) bool {
+	return err != nil && errors.Cause(err) == context.Canceled
+}
+
+// runProcess runs the command waiting for it to complete. If the context is done before the command completes the
+// command is interrupted and then killed if it has not stopped within the InterruptGracePeriod.
+//
+// Commands which do not read from the terminal run in their own process group so that they are signalled once by
+// jx rather than by both the terminal and jx
+func runProcess(ctx context.Context, e *exec.Cmd) error {
+	if e.Stdin == nil {
+		setProcessGroup(e)
+	}
+	// the command is registered as it starts so that a second interrupt always finds it to kill
+	runningProcessesLock.Lock()
+	err := e.Start()
+	if err == nil {
+		runningProcesses[e] = true
+	}
+	runningProcessesLock.Unlock()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		runningProcessesLock.Lock()
+		delete(runningProcesses, e)
+		runningProcessesLock.Unlock()
+	}()
+	done := make(chan error, 1)
+	go func() {
+		done <- e.Wait()
+	}()
+	select {
+	case err = <-done:
+		return err
+	case <-ctx.Done():
+	}
+
+	interruptProcess(e)
+	select {
+	case <-done:
+	case <-time.After(InterruptGracePeriod):
+		killProcess(e)
+		<-done
+	}
+	return ctx.Err()
+}
+
+// runningProcessCount returns the number of external commands currently running
+func runningProcessCount() int {
+	runningProcessesLock.Lock()
+	defer runningProcessesLock.Unlock()
+	return len(runningProcesses)
+}
+
+// killRunningProcesses kills the running external commands so that the processes, which may be in their own process
+// group, are not left running after jx exits
+func killRunningProcesses() {
+	runningProcessesLock.Lock()
+	defer runningProcessesLock.Unlock()
+	for e := range runningProcesses {
+		killProcess(e)
+	}
+}
@@ -0,0 +1,39 @@
+// +build !windows
+
+package util
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup runs the command in its own process group
+func setProcessGroup(e *exec.Cmd) {
+	if e.SysProcAttr == nil {
+		e.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	e.SysProcAttr.Setpgid = true
+}
+
+// interruptProcess sends an interrupt signal to the command and any processes it has started
+func interruptProcess(e *exec.Cmd) {
+	signalProcess(e, syscall.SIGINT)
+}
+
+// killProcess kills the command and any processes it has started
+func killProcess(e *exec.Cmd) {
+	signalProcess(e, syscall.SIGKILL)
+}
+
+func signalProcess(e *exec.Cmd, sig syscall.Signal) {
+	if e.Process == nil {
+		return
+	}
+	if e.SysProcAttr != nil && e.SysProcAttr.Setpgid {
+		// a negative pid signals the whole process group
+		syscall.Kill(-e.Process.Pid, sig)
+		return
+	}
+	e.Process.Signal(os.Signal(sig))
+}
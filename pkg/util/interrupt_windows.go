@@ -0,0 +1,23 @@
+// +build windows
+
+package util
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup does nothing as process groups cannot be signalled on windows
+func setProcessGroup(e *exec.Cmd) {
+}
+
+// interruptProcess kills the command as windows does not support sending an interrupt to another process
+func interruptProcess(e *exec.Cmd) {
+	killProcess(e)
+}
+
+// killProcess kills the command
+func killProcess(e *exec.Cmd) {
+	if e.Process != nil {
+		e.Process.Kill()
+	}
+}
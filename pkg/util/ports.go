@@ -0,0 +1,29 @@
+package util
+
+import (
+	"fmt"
+	"net"
+)
+
+// IsLocalPortFree returns true if nothing is listening on the local port
+func IsLocalPortFree(port int) bool {
+	listener, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", port))
+	if err != nil {
+		return false
+	}
+	listener.Close()
+	return true
+}
+
+// FreeLocalPort returns the preferred local port if it is free otherwise a free port chosen by the operating system
+func FreeLocalPort(preferred int) (int, error) {
+	if preferred > 0 && IsLocalPortFree(preferred) {
+		return preferred, nil
+	}
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free local port: %s", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,30 @@
+package util_test
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeLocalPort(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+	usedPort := listener.Addr().(*net.TCPAddr).Port
+
+	assert.False(t, util.IsLocalPortFree(usedPort), fmt.Sprintf("port %d should be in use", usedPort))
+
+	port, err := util.FreeLocalPort(usedPort)
+	assert.NoError(t, err)
+	assert.NotEqual(t, usedPort, port)
+	assert.True(t, util.IsLocalPortFree(port))
+
+	same, err := util.FreeLocalPort(port)
+	assert.NoError(t, err)
+	assert.Equal(t, port, same)
+}
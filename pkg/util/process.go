@@ -19,7 +19,7 @@ func RunCommandBackground(name string, output io.Writer, verbose bool, args ...s
 	os.Setenv("PATH", PathWithBinary())
 	err := e.Start()
 	if err != nil && verbose {
-		log.Errorf("Error: Command failed to start  %s %s\n", name, strings.Join(args, " "))
+		log.Errorf("Error: Command failed to start  %s %s\n", name, strings.Join(RedactArgs(args), " "))
 	}
 	return err
 }
@@ -1,10 +1,51 @@
 package util
 
 import (
-	"github.com/cenkalti/backoff"
+	"strings"
 	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/pkg/errors"
 )
 
+// TransientCloudErrors the text of errors returned by cloud APIs and CLIs when they are rate limited, briefly
+// unavailable or the connection fails which usually succeed when retried. Errors such as a missing resource or a
+// denied permission are permanent so they are not retried
+var TransientCloudErrors = []string{
+	"error 429",
+	"too many requests",
+	"rate limit",
+	"error 500",
+	"error 502",
+	"error 503",
+	"error 504",
+	"internal error",
+	"service unavailable",
+	"deadline exceeded",
+	"timed out",
+	"i/o timeout",
+	"connection reset",
+	"connection refused",
+	"tls handshake timeout",
+}
+
+// RetryOptions configures how often and for which errors a function is retried
+type RetryOptions struct {
+	// Description describes what is retried in the log
+	Description string
+	// Attempts the maximum number of attempts. Only the MaxElapsedTime limits the attempts if zero
+	Attempts int
+	// InitialInterval the delay before the first retry which then grows exponentially
+	InitialInterval time.Duration
+	// MaxInterval the maximum delay between attempts
+	MaxInterval time.Duration
+	// MaxElapsedTime the time after which no more attempts are made. Never stops on time if zero
+	MaxElapsedTime time.Duration
+	// Retryable returns true if the error is transient. All errors are retried if nil
+	Retryable func(err error) bool
+}
+
 // Retry retries with exponential backoff the given function
 func Retry(maxElapsedTime time.Duration, f func() error) error {
 	bo := backoff.NewExponentialBackOff()
@@ -13,3 +54,71 @@ func Retry(maxElapsedTime time.Duration, f func() error) error {
 	return backoff.Retry(f, bo)
 
 }
+
+// RetryWithOptions calls the function until it succeeds, it returns an error which is not retryable or the attempts
+// or time of the options run out. The delay between attempts grows exponentially. Interrupted functions are not
+// retried
+func RetryWithOptions(options RetryOptions, f func() error) error {
+	bo := backoff.NewExponentialBackOff()
+	if options.InitialInterval > 0 {
+		bo.InitialInterval = options.InitialInterval
+	}
+	if options.MaxInterval > 0 {
+		bo.MaxInterval = options.MaxInterval
+	}
+	bo.MaxElapsedTime = options.MaxElapsedTime
+	bo.Reset()
+
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil {
+			return nil
+		}
+		if IsInterrupted(err) || (options.Retryable != nil && !options.Retryable(err)) {
+			return err
+		}
+		if options.Attempts > 0 && attempt >= options.Attempts {
+			return errors.Wrapf(err, "failed after %d attempts", attempt)
+		}
+		delay := bo.NextBackOff()
+		if delay == backoff.Stop {
+			return errors.Wrapf(err, "failed after %d attempts", attempt)
+		}
+		if options.Description != "" {
+			log.Warnf("Failed %s, retrying in %s: %s\n", options.Description, delay.Round(time.Second), RedactSecrets(err.Error()))
+		}
+		time.Sleep(delay)
+	}
+}
+
+// RetryOnErrorsContaining returns a matcher of retryable errors whose message contains any of the given texts
+// ignoring case
+func RetryOnErrorsContaining(texts ...string) func(err error) bool {
+	return func(err error) bool {
+		message := strings.ToLower(err.Error())
+		for _, text := range texts {
+			if strings.Contains(message, strings.ToLower(text)) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// IsTransientCloudError returns true if the error is one of the TransientCloudErrors
+func IsTransientCloudError(err error) bool {
+	return RetryOnErrorsContaining(TransientCloudErrors...)(err)
+}
+
+// CloudRetryOptions returns the options to retry calls to cloud APIs which fail with transient errors such as
+// rate limiting or the API being briefly unavailable
+func CloudRetryOptions(description string) RetryOptions {
+	return RetryOptions{
+		Description:     description,
+		Attempts:        8,
+		InitialInterval: 2 * time.Second,
+		MaxInterval:     30 * time.Second,
+		MaxElapsedTime:  3 * time.Minute,
+		Retryable:       IsTransientCloudError,
+	}
+}
@@ -0,0 +1,75 @@
+package util_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryWithOptionsSucceedsAfterTransientErrors(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	err := util.RetryWithOptions(util.RetryOptions{
+		Attempts:        5,
+		InitialInterval: time.Millisecond,
+		Retryable:       util.IsTransientCloudError,
+	}, func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("ERROR: (gcloud.container.clusters.get-credentials) ResponseError: code=503, message=Error 503: the service is currently unavailable")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryWithOptionsStopsOnPermanentError(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	err := util.RetryWithOptions(util.RetryOptions{
+		Attempts:        5,
+		InitialInterval: time.Millisecond,
+		Retryable:       util.IsTransientCloudError,
+	}, func() error {
+		calls++
+		return errors.New("invalid zone: moon-central1-a")
+	})
+	assert.EqualError(t, err, "invalid zone: moon-central1-a")
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryWithOptionsStopsAfterAttempts(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	err := util.RetryWithOptions(util.RetryOptions{
+		Attempts:        3,
+		InitialInterval: time.Millisecond,
+	}, func() error {
+		calls++
+		return errors.New("Error 503: the service is currently unavailable")
+	})
+	assert.EqualError(t, err, "failed after 3 attempts: Error 503: the service is currently unavailable")
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryOnErrorsContaining(t *testing.T) {
+	t.Parallel()
+	retryable := util.RetryOnErrorsContaining("quota exceeded", "Connection Reset")
+	assert.True(t, retryable(errors.New("Quota Exceeded for quota metric")))
+	assert.True(t, retryable(errors.New("read tcp: connection reset by peer")))
+	assert.False(t, retryable(errors.New("cluster already exists")))
+}
+
+func TestIsTransientCloudError(t *testing.T) {
+	t.Parallel()
+	assert.True(t, util.IsTransientCloudError(errors.New("googleapi: Error 429: Quota exceeded, rateLimitExceeded")))
+	assert.True(t, util.IsTransientCloudError(errors.New("googleapi: Error 502: Bad Gateway")))
+	assert.True(t, util.IsTransientCloudError(errors.New("dial tcp 10.0.0.1:443: i/o timeout")))
+	assert.False(t, util.IsTransientCloudError(errors.New("googleapi: Error 403: Required 'container.clusters.get' permission, forbidden")))
+	assert.False(t, util.IsTransientCloudError(errors.New("ERROR: (gcloud.container.clusters.describe) ResponseError: code=404, message=Not found: cluster cheese")))
+	assert.False(t, util.IsTransientCloudError(errors.New("ERROR: PERMISSION_DENIED: The caller does not have permission")))
+}
@@ -7,6 +7,12 @@ const (
 	InstallSecretsPath = "install/"
 	// AdminSecretsPath the path of admin secrets
 	AdminSecretsPath = "admin/"
+	// TokenEnvVar the environment variable of the token used to access an external vault
+	TokenEnvVar = "VAULT_TOKEN"
+	// TokenFileName the name of the file in the home directory which 'vault login' stores the token in
+	TokenFileName = ".vault-token"
+	// ServiceAccountsPath the path of the cloud service account keys
+	ServiceAccountsPath = "service-accounts/"
 )
 
 // AdminSecret type for a vault admin secret
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/jenkins-x/jx/pkg/config"
 	"github.com/jenkins-x/jx/pkg/util"
@@ -13,6 +15,9 @@ import (
 const (
 	usernameKey = "Username"
 	passwordKey = "Password"
+
+	// ServiceAccountKey the key of the JSON key file in a service account secret
+	ServiceAccountKey = "key.json"
 )
 
 // WriteYAMLFiles stores the given YAML files in vault. The final secret path is
@@ -90,3 +95,44 @@ func ReadBasicAuth(client Client, path string) (*config.BasicAuth, error) {
 		Password: passwordStr,
 	}, nil
 }
+
+// ListAll returns the sorted paths of all the secrets stored under the given path, descending into the sub paths
+func ListAll(client Client, path string) ([]string, error) {
+	if path != "" && !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+	keys, err := client.List(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing the secrets in vault at the path '%s'", path)
+	}
+	answer := []string{}
+	for _, key := range keys {
+		if strings.HasSuffix(key, "/") {
+			children, err := ListAll(client, path+key)
+			if err != nil {
+				return nil, err
+			}
+			answer = append(answer, children...)
+		} else {
+			answer = append(answer, path+key)
+		}
+	}
+	sort.Strings(answer)
+	return answer, nil
+}
+
+// WriteServiceAccountKey stores the JSON key file of a cloud service account in vault
+func WriteServiceAccountKey(client Client, name string, keyPath string) error {
+	data, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return errors.Wrapf(err, "reading the service account key '%s'", keyPath)
+	}
+	path := ServiceAccountPath(name)
+	_, err = client.Write(path, map[string]interface{}{
+		ServiceAccountKey: string(data),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "storing the service account key into vault at path '%s'", path)
+	}
+	return nil
+}
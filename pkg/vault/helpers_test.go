@@ -0,0 +1,75 @@
+package vault_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/vault"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient stores the secrets in memory
+type fakeClient struct {
+	secrets map[string]map[string]interface{}
+}
+
+func (f *fakeClient) Write(secretName string, data map[string]interface{}) (map[string]interface{}, error) {
+	f.secrets[secretName] = data
+	return data, nil
+}
+
+func (f *fakeClient) WriteObject(secretName string, secret interface{}) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+func (f *fakeClient) WriteYaml(secretName string, yamlstring string) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+func (f *fakeClient) List(path string) ([]string, error) {
+	keys := map[string]bool{}
+	for name := range f.secrets {
+		if strings.HasPrefix(name, path) {
+			key := strings.TrimPrefix(name, path)
+			if i := strings.Index(key, "/"); i >= 0 {
+				key = key[:i+1]
+			}
+			keys[key] = true
+		}
+	}
+	answer := []string{}
+	for key := range keys {
+		answer = append(answer, key)
+	}
+	return answer, nil
+}
+
+func (f *fakeClient) Read(secretName string) (map[string]interface{}, error) {
+	return f.secrets[secretName], nil
+}
+
+func (f *fakeClient) Config() (url.URL, string, error) {
+	return url.URL{}, "", nil
+}
+
+func TestListAll(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{secrets: map[string]map[string]interface{}{
+		"admin/jenkins":           {},
+		"admin/nexus":             {},
+		"install/secrets.yaml":    {},
+		"service-accounts/jx/gcr": {},
+		"gitAuth.yaml":            {},
+	}}
+
+	all, err := vault.ListAll(client, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin/jenkins", "admin/nexus", "gitAuth.yaml", "install/secrets.yaml", "service-accounts/jx/gcr"}, all)
+
+	admin, err := vault.ListAll(client, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin/jenkins", "admin/nexus"}, admin)
+}
@@ -1,7 +1,11 @@
 package vault
 
 import (
+	"fmt"
+	"io/ioutil"
 	"net/url"
+	"path/filepath"
+	"strings"
 
 	"github.com/hashicorp/vault/api"
 	"github.com/jenkins-x/jx/pkg/util"
@@ -40,6 +44,50 @@ func NewVaultClient(apiclient *api.Client) Client {
 	return &client{client: apiclient}
 }
 
+// NewExternalVaultClient creates a new Vault Client for an existing vault at the given address which is not managed
+// by the vault operator. The given token is used, otherwise it is read from the VAULT_TOKEN environment variable or
+// from the token file written by 'vault login'
+func NewExternalVaultClient(address string, token string) (Client, error) {
+	config := api.DefaultConfig()
+	if config.Error != nil {
+		return nil, errors.Wrap(config.Error, "reading the vault configuration from the environment")
+	}
+	config.Address = address
+	apiclient, err := api.NewClient(config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating the client for the vault %s", config.Address)
+	}
+	token, err = ExternalToken(token, apiclient.Token(), filepath.Join(util.HomeDir(), TokenFileName))
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, fmt.Errorf("no token found to access the vault %s, please use --vault-token, set $%s or run 'vault login'", config.Address, TokenEnvVar)
+	}
+	apiclient.SetToken(token)
+	return NewVaultClient(apiclient), nil
+}
+
+// ExternalToken returns the token used to access an existing vault. The given token takes precedence over the token
+// of the environment, which takes precedence over the token in the token file
+func ExternalToken(token string, envToken string, tokenFile string) (string, error) {
+	if token != "" {
+		return token, nil
+	}
+	if envToken != "" {
+		return envToken, nil
+	}
+	exists, err := util.FileExists(tokenFile)
+	if err != nil || !exists {
+		return "", err
+	}
+	data, err := ioutil.ReadFile(tokenFile)
+	if err != nil {
+		return "", errors.Wrapf(err, "reading the vault token file %s", tokenFile)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
 // Write writes a named secret to the vault with the data provided. Data can be a generic map of stuff, but at all points
 // in the map, keys _must_ be strings (not bool, int or even interface{}) otherwise you'll get an error
 func (v *client) Write(secretName string, data map[string]interface{}) (map[string]interface{}, error) {
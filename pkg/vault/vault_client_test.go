@@ -0,0 +1,43 @@
+package vault_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/vault"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalToken(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "test-vault-token")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	tokenFile := filepath.Join(dir, vault.TokenFileName)
+	err = ioutil.WriteFile(tokenFile, []byte("file-token\n"), 0600)
+	require.NoError(t, err)
+	missingFile := filepath.Join(dir, "missing")
+
+	testCases := []struct {
+		name      string
+		token     string
+		envToken  string
+		tokenFile string
+		expected  string
+	}{
+		{"flag", "flag-token", "env-token", tokenFile, "flag-token"},
+		{"env", "", "env-token", tokenFile, "env-token"},
+		{"file", "", "", tokenFile, "file-token"},
+		{"none", "", "", missingFile, ""},
+	}
+	for _, tc := range testCases {
+		token, err := vault.ExternalToken(tc.token, tc.envToken, tc.tokenFile)
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expected, token, tc.name)
+	}
+}
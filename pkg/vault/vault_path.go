@@ -15,3 +15,8 @@ func AdminSecretPath(secret AdminSecret) string {
 func InstallSecretPath(secret string) string {
 	return InstallSecretsPath + secret
 }
+
+// ServiceAccountPath returns the path of a cloud service account key
+func ServiceAccountPath(name string) string {
+	return ServiceAccountsPath + name
+}
@@ -0,0 +1,126 @@
+package versionstream
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultVersionsURL the default git repository of the version stream
+	DefaultVersionsURL = "https://github.com/jenkins-x/jenkins-x-versions.git"
+
+	// VersionsFileName the name of the file in the version stream repository which contains the versions
+	VersionsFileName = "versions.yaml"
+)
+
+// Versions maps tools and charts to the versions which have been tested together
+type Versions struct {
+	// Tools maps the name of a binary such as helm or terraform to its version
+	Tools map[string]string `json:"tools,omitempty"`
+	// Charts maps the full name of a chart such as jenkins-x/jenkins-x-platform to its version
+	Charts map[string]string `json:"charts,omitempty"`
+	// Checksums maps the file names of downloads whose publishers do not publish checksum files, such as the
+	// archives of the Google Cloud SDK, to their SHA256 checksums
+	Checksums map[string]string `json:"checksums,omitempty"`
+}
+
+// DefaultVersions returns the versions used when the version stream repository cannot be loaded. Nothing is
+// pinned so that the latest releases are used rather than versions which go stale in the binary
+func DefaultVersions() *Versions {
+	return &Versions{
+		Tools:     map[string]string{},
+		Charts:    map[string]string{},
+		Checksums: map[string]string{},
+	}
+}
+
+// LoadVersions loads the versions file from the given directory of a version stream repository.
+// The latest releases are used for any tools or charts which are not in the file
+func LoadVersions(dir string) (*Versions, error) {
+	answer := DefaultVersions()
+	fileName := filepath.Join(dir, VersionsFileName)
+	exists, err := util.FileExists(fileName)
+	if err != nil {
+		return answer, err
+	}
+	if !exists {
+		return answer, nil
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return answer, errors.Wrapf(err, "reading %s", fileName)
+	}
+	loaded := &Versions{}
+	err = yaml.Unmarshal(data, loaded)
+	if err != nil {
+		return answer, errors.Wrapf(err, "parsing %s", fileName)
+	}
+	for k, v := range loaded.Tools {
+		answer.Tools[k] = v
+	}
+	for k, v := range loaded.Charts {
+		answer.Charts[k] = v
+	}
+	for k, v := range loaded.Checksums {
+		answer.Checksums[k] = v
+	}
+	return answer, nil
+}
+
+// SaveVersions saves the versions file into the given directory
+func SaveVersions(dir string, versions *Versions) error {
+	data, err := yaml.Marshal(versions)
+	if err != nil {
+		return err
+	}
+	fileName := filepath.Join(dir, VersionsFileName)
+	return ioutil.WriteFile(fileName, data, util.DefaultWritePermissions)
+}
+
+// ToolVersion returns the pinned version of the given tool or an empty string if the latest release should be used
+func (v *Versions) ToolVersion(name string) string {
+	if v == nil || v.Tools == nil {
+		return ""
+	}
+	return v.Tools[name]
+}
+
+// ChartVersion returns the pinned version of the given chart or an empty string if the latest release should be used
+func (v *Versions) ChartVersion(name string) string {
+	if v == nil || v.Charts == nil {
+		return ""
+	}
+	return v.Charts[name]
+}
+
+// Checksum returns the pinned SHA256 checksum of the downloaded file or an empty string if it is not pinned
+func (v *Versions) Checksum(fileName string) string {
+	if v == nil || v.Checksums == nil {
+		return ""
+	}
+	return v.Checksums[fileName]
+}
+
+// ToolNames returns the sorted names of the pinned tools
+func (v *Versions) ToolNames() []string {
+	return sortedKeys(v.Tools)
+}
+
+// ChartNames returns the sorted names of the pinned charts
+func (v *Versions) ChartNames() []string {
+	return sortedKeys(v.Charts)
+}
+
+func sortedKeys(m map[string]string) []string {
+	answer := []string{}
+	for k := range m {
+		answer = append(answer, k)
+	}
+	sort.Strings(answer)
+	return answer
+}
@@ -0,0 +1,53 @@
+package versionstream_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/versionstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadVersions(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "test-versions-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	data := `tools:
+  helm: 2.10.0
+charts:
+  jenkins-x/jenkins-x-platform: 0.0.3000
+checksums:
+  google-cloud-sdk-228.0.0-linux-x86_64.tar.gz: abc123
+`
+	err = ioutil.WriteFile(filepath.Join(dir, versionstream.VersionsFileName), []byte(data), 0644)
+	require.NoError(t, err)
+
+	versions, err := versionstream.LoadVersions(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "2.10.0", versions.ToolVersion("helm"))
+	assert.Equal(t, "", versions.ToolVersion("terraform"))
+	assert.Equal(t, "0.0.3000", versions.ChartVersion("jenkins-x/jenkins-x-platform"))
+	assert.Equal(t, "", versions.ChartVersion("stable/cert-manager"))
+	assert.Equal(t, "abc123", versions.Checksum("google-cloud-sdk-228.0.0-linux-x86_64.tar.gz"))
+	assert.Equal(t, "", versions.Checksum("google-cloud-sdk-228.0.0-darwin-x86_64.tar.gz"))
+}
+
+func TestLoadVersionsMissingFile(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "test-versions-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	versions, err := versionstream.LoadVersions(dir)
+	require.NoError(t, err)
+	assert.Equal(t, versionstream.DefaultVersions(), versions)
+	assert.Equal(t, "", versions.ToolVersion("helm"))
+	assert.Empty(t, versions.ToolNames())
+}